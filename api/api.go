@@ -0,0 +1,9 @@
+// Package api holds the OpenAPI description of the HTTP API.
+package api
+
+import _ "embed"
+
+// Spec is the OpenAPI 3 document of the HTTP API in YAML, served at GET /openapi.yaml.
+//
+//go:embed openapi.yaml
+var Spec []byte
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/infrastructure/persistence/postgres"
+	"github.com/shirr9/pr-reviewer-service/internal/seed"
+)
+
+// usage lists the subcommands of the binary.
+const usage = `usage: app [command]
+
+Without a command the service is started. Commands:
+  migrate up          apply the pending migrations
+  migrate down [N]    revert the last N applied migrations, 1 by default
+  migrate status      print the schema version and the pending migrations
+  seed [flags]        write sample teams, users and pull requests, skipping those that exist:
+    --teams N           number of teams, 3 by default
+    --users-per-team N  members of each team, 5 by default
+    --prs N             number of pull requests, 50 by default
+    --seed N            seed of the generated data, 1 by default`
+
+// command is a subcommand of the binary, run with the arguments after its name. It reports to out
+// and exits once done.
+type command func(ctx context.Context, cfg *config.Config, log *slog.Logger, out io.Writer, args []string) error
+
+// commands are the subcommands of the binary by name.
+var commands = map[string]command{
+	"migrate": runMigrate,
+	"seed":    runSeed,
+}
+
+// runCommand runs the subcommand named by the first argument.
+func runCommand(ctx context.Context, cfg *config.Config, log *slog.Logger, out io.Writer, args []string) error {
+	run, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown command\n%s", usage)
+	}
+	return run(ctx, cfg, log, out, args[1:])
+}
+
+// connectPrimary connects to the primary database of the configuration, as the server does, leaving
+// out the replica that commands have no use for.
+func connectPrimary(ctx context.Context, cfg *config.Config, log *slog.Logger) (*postgres.Storage, error) {
+	primary := *cfg
+	primary.PostgresDb.Replica = config.PostgresReplica{}
+	storage, err := postgres.NewStorage(ctx, primary, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return storage, nil
+}
+
+// runMigrate applies, reverts or reports the embedded migrations.
+func runMigrate(ctx context.Context, cfg *config.Config, log *slog.Logger, out io.Writer, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing action\n%s", usage)
+	}
+	action, args := args[0], args[1:]
+	n := 1
+	switch {
+	case action == "down" && len(args) == 1:
+		var err error
+		if n, err = strconv.Atoi(args[0]); err != nil || n < 1 {
+			return fmt.Errorf("N must be a positive number\n%s", usage)
+		}
+	case action != "up" && action != "down" && action != "status":
+		return fmt.Errorf("unknown action %q\n%s", action, usage)
+	case len(args) > 0:
+		return fmt.Errorf("unexpected arguments %v\n%s", args, usage)
+	}
+
+	storage, err := connectPrimary(ctx, cfg, log)
+	if err != nil {
+		return err
+	}
+	defer storage.Close()
+	migrator := storage.NewMigrator()
+
+	switch action {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			_, _ = fmt.Fprintf(out, "applied %s\n", migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			_, _ = fmt.Fprintln(out, "no pending migrations")
+		}
+	case "down":
+		reverted, err := migrator.Down(ctx, n)
+		for _, migration := range reverted {
+			_, _ = fmt.Fprintf(out, "reverted %s\n", migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(reverted) == 0 {
+			_, _ = fmt.Fprintln(out, "no applied migrations")
+		}
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(out, "version %d of %d", status.Version, status.Latest)
+		if status.Dirty {
+			_, _ = fmt.Fprint(out, ", dirty: the last migration failed halfway")
+		}
+		_, _ = fmt.Fprintln(out)
+		for _, migration := range status.Pending {
+			_, _ = fmt.Fprintf(out, "pending %s\n", migration.Name)
+		}
+	}
+	return nil
+}
+
+// runSeed writes the sample data for local development through the repositories.
+func runSeed(ctx context.Context, cfg *config.Config, log *slog.Logger, out io.Writer, args []string) error {
+	var seedCfg seed.Config
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.IntVar(&seedCfg.Teams, "teams", 3, "")
+	flags.IntVar(&seedCfg.UsersPerTeam, "users-per-team", 5, "")
+	flags.IntVar(&seedCfg.PRs, "prs", 50, "")
+	flags.Int64Var(&seedCfg.Seed, "seed", 1, "")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w\n%s", err, usage)
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments %v\n%s", flags.Args(), usage)
+	}
+	if err := seedCfg.Validate(); err != nil {
+		return err
+	}
+
+	storage, err := connectPrimary(ctx, cfg, log)
+	if err != nil {
+		return err
+	}
+	defer storage.Close()
+
+	seeder := seed.NewSeeder(storage.NewTeamRepository(), storage.NewPullRequestRepository(),
+		storage.NewReviewerRepository(), storage.NewUnitOfWork())
+	summary, err := seeder.Seed(ctx, seedCfg)
+	if summary != nil {
+		_, _ = fmt.Fprintf(out, "teams: %d created, %d existed\n", summary.TeamsCreated, summary.TeamsExisted)
+		_, _ = fmt.Fprintf(out, "users: %d created\n", summary.UsersCreated)
+		_, _ = fmt.Fprintf(out, "pull requests: %d created, %d existed\n", summary.PRsCreated, summary.PRsExisted)
+		_, _ = fmt.Fprintf(out, "reviewers: %d assigned\n", summary.Reviewers)
+	}
+	return err
+}
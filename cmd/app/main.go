@@ -5,19 +5,31 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 
-	"time"
+	// embedded so working hours resolve in images without system zoneinfo
+	_ "time/tzdata"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/events"
+	"github.com/shirr9/pr-reviewer-service/internal/app/graphql"
+	"github.com/shirr9/pr-reviewer-service/internal/app/grpchandler"
 	"github.com/shirr9/pr-reviewer-service/internal/app/handler"
+	"github.com/shirr9/pr-reviewer-service/internal/app/job"
+	"github.com/shirr9/pr-reviewer-service/internal/app/metrics"
 	"github.com/shirr9/pr-reviewer-service/internal/app/service"
+	"github.com/shirr9/pr-reviewer-service/internal/app/shutdown"
+	"github.com/shirr9/pr-reviewer-service/internal/infrastructure/jobs"
+	"github.com/shirr9/pr-reviewer-service/internal/infrastructure/leader"
 	"github.com/shirr9/pr-reviewer-service/internal/infrastructure/logger"
 	"github.com/shirr9/pr-reviewer-service/internal/infrastructure/persistence/postgres"
+	"github.com/shirr9/pr-reviewer-service/internal/infrastructure/webhook"
 )
 
 func main() {
@@ -25,12 +37,23 @@ func main() {
 	if err != nil {
 		log.Fatalf("failed to load config: %v", err)
 	}
+	appLogger := logger.NewLogger(cfg.Server.Env, cfg.Log, os.Stdout)
 
-	appLogger := logger.NewLogger(cfg.Server.Env, os.Stdout)
+	if len(os.Args) > 1 {
+		if err = runCommand(context.Background(), cfg, appLogger, os.Stdout, os.Args[1:]); err != nil {
+			log.Fatalf("%s: %v", os.Args[1], err)
+		}
+		return
+	}
+	serve(cfg, appLogger)
+}
+
+// serve runs the HTTP and gRPC servers and the background jobs until the process is signalled to stop.
+func serve(cfg *config.Config, appLogger *slog.Logger) {
 	appLogger.Info("starting pr-reviewer-service", "env", cfg.Server.Env)
 
 	ctx := context.Background()
-	storage, err := postgres.NewStorage(ctx, *cfg)
+	storage, err := postgres.NewStorage(ctx, *cfg, appLogger)
 	if err != nil {
 		appLogger.Error("failed to connect to database", "error", err)
 		log.Fatalf("failed to connect to database: %v", err)
@@ -42,31 +65,152 @@ func main() {
 	reviewerRepo := storage.NewReviewerRepository()
 	userRepo := storage.NewUserRepository()
 	teamRepo := storage.NewTeamRepository()
+	exclusionRepo := storage.NewExclusionRepository()
+	archiveRepo := storage.NewArchiveRepository()
+	webhookRepo := storage.NewWebhookRepository()
 	uow := storage.NewUnitOfWork()
 
-	prService := service.NewPullRequestService(prRepo, reviewerRepo, userRepo, uow, appLogger)
-	userService := service.NewUserService(userRepo, prRepo, appLogger)
-	teamService := service.NewTeamService(teamRepo, userRepo, prRepo, reviewerRepo, uow, appLogger)
-	statisticsService := service.NewStatisticsService(userRepo, prRepo, reviewerRepo, appLogger)
+	// queue changes are announced to the live queue sockets of this replica
+	queues := events.NewBus(events.DefaultBuffer)
+	prService := service.NewPullRequestService(prRepo, reviewerRepo, userRepo, uow, cfg.Review, appLogger)
+	prService.SetPublisher(queues)
+	userService := service.NewUserService(userRepo, prRepo, reviewerRepo, cfg.Review, appLogger)
+	teamService := service.NewTeamService(teamRepo, userRepo, prRepo, reviewerRepo, uow, cfg.Review, appLogger)
+	teamImportService := service.NewTeamImportService(teamRepo, cfg.Import, appLogger)
+	exclusionService := service.NewExclusionService(exclusionRepo, userRepo, appLogger)
+	archiveService := service.NewArchiveService(archiveRepo, prRepo, reviewerRepo, uow, cfg.Archive, appLogger)
+	statisticsService := service.NewStatisticsService(userRepo, prRepo, reviewerRepo, cfg.Statistics, cfg.Review, appLogger)
+	snapshotService := service.NewSnapshotService(storage.NewSnapshotRepository(), userRepo, statisticsService, appLogger)
+	dumpService := service.NewDumpService(storage.NewDumpRepository(), uow, cfg.Dump, appLogger)
+	metricsService := service.NewMetricsService(storage.NewMetricsRepository(), appLogger)
+	duplicateService := service.NewDuplicateService(storage.NewDuplicateRepository(), appLogger)
+	appMetrics := metrics.New(cfg.Metrics.MaxTeamLabels)
+	shedder := service.NewLoadShedder(storage, cfg.Overload)
+	appMetrics.RegisterOverload(storage, shedder.ShedCount)
 
-	validate := validator.New()
+	// events are queued in the database and posted by the webhook job, which retries failed posts
+	var webhookSender service.WebhookSender
+	if cfg.Webhook.URL != "" {
+		webhookSender = webhook.NewClient(cfg.Webhook.URL, cfg.Webhook.Timeout)
+	}
+	webhookService := service.NewWebhookService(webhookRepo, uow, webhookSender, cfg.Webhook, appLogger)
 
-	prHandler := handler.NewPullRequestHandler(prService, appLogger, validate)
-	userHandler := handler.NewUserHandler(userService, appLogger, validate)
-	teamHandler := handler.NewTeamHandler(teamService, appLogger, validate)
-	statisticsHandler := handler.NewStatisticsHandler(statisticsService, appLogger)
+	expectedMigration, err := postgres.LatestMigration()
+	if err != nil {
+		log.Fatalf("failed to read embedded migrations: %v", err)
+	}
+	readinessService := service.NewReadinessService(storage.NewHealthRepository(), webhookRepo, expectedMigration,
+		cfg.Readiness, appLogger)
 
-	mux := http.NewServeMux()
+	// the exclusive jobs run on the elected leader under their advisory locks, the metrics job on
+	// every replica: each serves the business metrics it scrapes. The escalation and the snapshot
+	// work on the data of an organization, so they run once per organization.
+	orgRepo := storage.NewOrgRepository()
+	elector, err := leader.NewElector(storage.NewLeaseRepository(), leader.NewID(), cfg.Leader, appMetrics.Leader,
+		appLogger)
+	if err != nil {
+		log.Fatalf("invalid leader config: %v", err)
+	}
+	readinessService.SetLeader(elector)
+	scheduler := jobs.NewScheduler(storage.NewAdvisoryLocker(), storage.NewJobRunRepository(), appMetrics.Jobs,
+		appLogger)
+	scheduler.SetLeader(elector)
+	var scheduled []jobs.Job
+	if cfg.Escalation.Enabled {
+		var notifier job.Notifier
+		if webhookSender != nil {
+			notifier = webhookService
+		}
+		scheduled = append(scheduled, jobs.Job{
+			Name: "escalation", Interval: cfg.Escalation.Interval, LockKey: job.EscalationLockKey,
+			Run: job.ForEachOrg(orgRepo, job.NewEscalationJob(prService, notifier, cfg.Escalation, appLogger).RunOnce),
+		})
+	}
+	if webhookSender != nil {
+		webhookJob := job.NewWebhookJob(webhookService, cfg.Webhook)
+		scheduled = append(scheduled, jobs.Job{
+			Name: "webhook", Interval: cfg.Webhook.Interval, LockKey: job.WebhookLockKey,
+			Run: job.ForEachOrg(orgRepo, webhookJob.RunOnce), Flush: job.ForEachOrg(orgRepo, webhookJob.Flush),
+		})
+	}
+	if cfg.Snapshot.Enabled {
+		scheduled = append(scheduled, jobs.Job{
+			Name: "snapshot", Interval: cfg.Snapshot.Interval, LockKey: job.SnapshotLockKey,
+			Run: job.ForEachOrg(orgRepo, job.NewSnapshotJob(snapshotService, appLogger).RunOnce),
+		})
+	}
+	scheduled = append(scheduled, jobs.Job{
+		Name: "metrics", Interval: cfg.Metrics.Interval, RunAtStart: true,
+		Run: job.NewMetricsJob(metricsService, appMetrics.Business).RunOnce,
+	})
+	for _, j := range scheduled {
+		if err = scheduler.Register(j); err != nil {
+			log.Fatalf("failed to schedule job: %v", err)
+		}
+	}
+	for _, entry := range scheduler.Entries() {
+		readinessService.AddWorker(entry.Name()+"_job", entry)
+	}
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	var background sync.WaitGroup
+	background.Go(func() { elector.Run(jobsCtx) })
+	background.Go(func() { scheduler.Run(jobsCtx) })
+	jobsDone := make(chan struct{})
+	go func() {
+		defer close(jobsDone)
+		background.Wait()
+	}()
 
-	mux.HandleFunc("POST /team/add", teamHandler.AddTeam)
-	mux.HandleFunc("GET /team/get", teamHandler.GetTeam)
-	mux.HandleFunc("POST /team/deactivate", teamHandler.DeactivateTeam)
-	mux.HandleFunc("POST /users/setIsActive", userHandler.SetIsActive)
-	mux.HandleFunc("GET /users/getReview", userHandler.GetReview)
-	mux.HandleFunc("POST /pullRequest/create", prHandler.CreatePR)
-	mux.HandleFunc("POST /pullRequest/merge", prHandler.MergePR)
-	mux.HandleFunc("POST /pullRequest/reassign", prHandler.ReassignReviewer)
-	mux.HandleFunc("GET /statistics", statisticsHandler.GetStatistics)
+	graphQLHandler, err := graphql.NewHandler(graphql.Services{
+		Teams:        teamService,
+		Users:        userService,
+		PullRequests: prService,
+		Statistics:   statisticsService,
+	}, cfg.GraphQL, appLogger)
+	if err != nil {
+		log.Fatalf("failed to build GraphQL schema: %v", err)
+	}
+
+	services := handler.Services{
+		PullRequests:  prService,
+		Users:         userService,
+		Teams:         teamService,
+		Statistics:    statisticsService,
+		Exclusions:    exclusionService,
+		Archive:       archiveService,
+		Webhooks:      webhookService,
+		Snapshots:     snapshotService,
+		Queues:        queues,
+		GraphQL:       graphQLHandler,
+		Metrics:       appMetrics.Handler(),
+		Dump:          dumpService,
+		DumpToken:     cfg.Dump.Token,
+		AdminToken:    cfg.Admin.Token,
+		Readiness:     readinessService,
+		TeamImports:   teamImportService,
+		Duplicates:    duplicateService,
+		Jobs:          service.NewJobService(scheduler, appLogger),
+		MaxImportSize: cfg.Import.MaxCSVSize,
+
+		RequestTimeout:      cfg.Server.RequestTimeout,
+		LongRequestTimeout:  cfg.Server.LongRequestTimeout,
+		Shedder:             shedder,
+		LowercaseTeamNames:  cfg.Server.LowercaseTeamNames,
+		DisableLegacyRoutes: cfg.Server.DisableLegacyRoutes,
+	}
+	if cfg.Server.LogBodies {
+		if cfg.Server.Env == logger.EnvProd {
+			appLogger.Warn("server.log_bodies is ignored in prod")
+		} else {
+			redact, err := handler.RedactKeys(cfg.Server.BodyLog.RedactKeys)
+			if err != nil {
+				log.Fatalf("invalid server.body_log.redact_keys: %v", err)
+			}
+			services.BodyLog = &handler.BodyLog{MaxSize: cfg.Server.BodyLog.MaxSize, Redact: redact}
+		}
+	}
+	validate := handler.NewValidator()
+	mux := handler.NewRouter(services, appLogger, validate)
 
 	addr := fmt.Sprintf(":%d", cfg.Server.Port)
 	srv := &http.Server{
@@ -84,6 +228,21 @@ func main() {
 		}
 	}()
 
+	grpcAddr := fmt.Sprintf(":%d", cfg.GRPC.Port)
+	grpcListener, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		appLogger.Error("failed to listen for gRPC", "error", err)
+		log.Fatalf("failed to listen for gRPC: %v", err)
+	}
+	grpcSrv := grpchandler.NewServer(services, appLogger, validate)
+	go func() {
+		appLogger.Info("starting gRPC server", "addr", grpcAddr)
+		if err := grpcSrv.Serve(grpcListener); err != nil {
+			appLogger.Error("gRPC server failed", "error", err)
+			log.Fatalf("gRPC server failed: %v", err)
+		}
+	}()
+
 	quit := make(chan os.Signal, 1)
 	// kill (no param) default send syscall.SIGTERM
 	// kill -2 is syscall.SIGINT
@@ -92,18 +251,47 @@ func main() {
 	<-quit
 	appLogger.Info("shutdown server ...")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	if err = srv.Shutdown(ctx); err != nil {
-		appLogger.Error("server shutdown failed", "error", err)
-		log.Fatal("server shutdown:", err)
-	}
-
-	select {
-	case <-ctx.Done():
-		appLogger.Info("timeout of 5 seconds.")
-	default:
+	// the servers stop taking work first, then the jobs, then what the jobs left queued is flushed
+	coordinator := shutdown.NewCoordinator(cfg.Server.ShutdownTimeout, appLogger)
+	coordinator.Add("http", func(ctx context.Context) error {
+		// closing the queues ends the parked polls Shutdown waits for, and the sockets it doesn't
+		// track get their queued events and a closing message
+		queuesClosed := make(chan error, 1)
+		go func() { queuesClosed <- queues.Close(ctx) }()
+		err := srv.Shutdown(ctx)
+		if queuesErr := <-queuesClosed; queuesErr != nil {
+			err = errors.Join(err, fmt.Errorf("live queue: %w", queuesErr))
+		}
+		return err
+	})
+	coordinator.Add("grpc", func(ctx context.Context) error {
+		// GracefulStop waits for running calls without a deadline, so they are cut off when it runs out
+		grpcStopped := make(chan struct{})
+		go func() {
+			defer close(grpcStopped)
+			grpcSrv.GracefulStop()
+		}()
+		select {
+		case <-grpcStopped:
+			return nil
+		case <-ctx.Done():
+			grpcSrv.Stop()
+			return fmt.Errorf("calls cut off: %w", ctx.Err())
+		}
+	})
+	coordinator.Add("jobs", func(ctx context.Context) error {
+		stopJobs()
+		select {
+		case <-jobsDone:
+			return nil
+		case <-ctx.Done():
+			return fmt.Errorf("runs still in progress: %w", ctx.Err())
+		}
+	})
+	coordinator.Add("flush", scheduler.Flush)
+	if err := coordinator.Shutdown(context.Background()); err != nil {
+		appLogger.Warn("server exited with abandoned work", "error", err)
+	} else {
 		appLogger.Info("server exited gracefully")
 	}
 
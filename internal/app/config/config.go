@@ -1,12 +1,32 @@
 package config
 
-import "time"
+import (
+	"fmt"
+	"log/slog"
+	"time"
+)
 
 // Config represents the application configuration.
 type Config struct {
 	Env        string     `yaml:"env" env-default:"local"`
 	Server     Server     `yaml:"server"`
+	Log        Log        `yaml:"log"`
+	GRPC       GRPC       `yaml:"grpc"`
 	PostgresDb PostgresDb `yaml:"postgres"`
+	Statistics Statistics `yaml:"statistics"`
+	Review     Review     `yaml:"review"`
+	Escalation Escalation `yaml:"escalation"`
+	Webhook    Webhook    `yaml:"webhook"`
+	Snapshot   Snapshot   `yaml:"snapshot"`
+	Metrics    Metrics    `yaml:"metrics"`
+	Archive    Archive    `yaml:"archive"`
+	GraphQL    GraphQL    `yaml:"graphql"`
+	Import     Import     `yaml:"import"`
+	Dump       Dump       `yaml:"dump"`
+	Admin      Admin      `yaml:"admin"`
+	Readiness  Readiness  `yaml:"readiness"`
+	Overload   Overload   `yaml:"overload"`
+	Leader     Leader     `yaml:"leader"`
 }
 
 // Server contains HTTP server configuration.
@@ -15,6 +35,72 @@ type Server struct {
 	Env          string        `yaml:"env" env-default:"local"`
 	ReadTimeout  time.Duration `yaml:"read_timeout" env-default:"10s"`
 	WriteTimeout time.Duration `yaml:"write_timeout" env-default:"10s"`
+	// RequestTimeout bounds the handling of a request; a request running out of it, such as one
+	// waiting on a slow query, is answered 504. LongRequestTimeout replaces it for statistics and
+	// bulk endpoints. Zero leaves requests unbounded.
+	RequestTimeout     time.Duration `yaml:"request_timeout" env-default:"5s"`
+	LongRequestTimeout time.Duration `yaml:"long_request_timeout" env-default:"30s"`
+	// ShutdownTimeout bounds the whole shutdown: draining the servers, stopping the jobs and flushing
+	// the events they left queued. What is left when it runs out is logged as abandoned.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" env-default:"15s"`
+	// LowercaseTeamNames folds the team names of requests to lower case, so "Backend" and "backend"
+	// are one team. Existing teams keep their names; see /admin/duplicates before enabling it.
+	LowercaseTeamNames bool `yaml:"lowercase_team_names" env:"LOWERCASE_TEAM_NAMES" env-default:"false"`
+	// DisableLegacyRoutes serves the API only under /api/v1, dropping the deprecated unprefixed
+	// paths once no client uses them.
+	DisableLegacyRoutes bool `yaml:"disable_legacy_routes" env:"DISABLE_LEGACY_ROUTES" env-default:"false"`
+	// LogBodies logs request and response bodies at Debug level for diagnosing integrations. It is
+	// ignored in prod, where bodies are never logged.
+	LogBodies bool    `yaml:"log_bodies" env:"LOG_BODIES" env-default:"false"`
+	BodyLog   BodyLog `yaml:"body_log"`
+}
+
+// Log formats.
+const (
+	LogFormatJSON = "json"
+	LogFormatText = "text"
+)
+
+// Log contains configuration of the service log. Empty fields take the defaults of the environment
+// of server.env: JSON in every environment, Debug level in local and dev and Info otherwise.
+type Log struct {
+	// Format is json or text.
+	Format string `yaml:"format" env:"LOG_FORMAT"`
+	// Level is the lowest level logged: debug, info, warn or error.
+	Level string `yaml:"level" env:"LOG_LEVEL"`
+	// AddSource adds the file and line of the logging call to every record.
+	AddSource bool `yaml:"add_source" env:"LOG_ADD_SOURCE" env-default:"false"`
+}
+
+// Validate checks the format and the level of the log.
+func (l Log) Validate() error {
+	switch l.Format {
+	case "", LogFormatJSON, LogFormatText:
+	default:
+		return fmt.Errorf("log.format must be %s or %s, got %q", LogFormatJSON, LogFormatText, l.Format)
+	}
+	if l.Level != "" {
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(l.Level)); err != nil {
+			return fmt.Errorf("log.level must be debug, info, warn or error, got %q", l.Level)
+		}
+	}
+	return nil
+}
+
+// BodyLog contains configuration of request and response body logging.
+type BodyLog struct {
+	// MaxSize is the largest body logged in bytes; larger bodies are logged as their hash and length.
+	MaxSize int `yaml:"max_size" env-default:"4096"`
+	// RedactKeys are the path.Match patterns of JSON field names whose values are masked, matched
+	// case-insensitively at any depth. Bodies that are not JSON are logged as their hash and length.
+	RedactKeys []string `yaml:"redact_keys" env-default:"*token*,*password*,*secret*,*email*,authorization"`
+}
+
+// GRPC contains gRPC server configuration.
+type GRPC struct {
+	// Port is where the gRPC API is served next to the HTTP one.
+	Port int `yaml:"port" env:"GRPC_PORT" env-default:"9090"`
 }
 
 // PostgresDb contains PostgreSQL database connection parameters.
@@ -25,4 +111,196 @@ type PostgresDb struct {
 	Port     string `yaml:"port"`
 	DbName   string `yaml:"db_name"`
 	SSlMode  string `yaml:"sslmode" env-default:"disable"`
+	// Replica is an optional read replica serving read-only requests.
+	Replica PostgresReplica `yaml:"replica"`
+	// QueryLog configures logging of SQL statements.
+	QueryLog QueryLog `yaml:"query_log"`
+	// Connect configures the retries of the first connection, for a database starting with the service.
+	Connect PostgresConnect `yaml:"connect"`
+}
+
+// PostgresConnect contains configuration of connecting to the database at startup. A failed ping is
+// retried after a backoff doubling from InitialBackoff up to MaxBackoff, until MaxAttempts pings
+// failed or Timeout passed.
+type PostgresConnect struct {
+	MaxAttempts    int           `yaml:"max_attempts" env:"POSTGRES_CONNECT_MAX_ATTEMPTS" env-default:"10"`
+	InitialBackoff time.Duration `yaml:"initial_backoff" env-default:"500ms"`
+	MaxBackoff     time.Duration `yaml:"max_backoff" env-default:"5s"`
+	// Timeout bounds connecting, the retries included; zero leaves it to MaxAttempts.
+	Timeout time.Duration `yaml:"timeout" env:"POSTGRES_CONNECT_TIMEOUT" env-default:"30s"`
+}
+
+// QueryLog contains configuration of SQL statement logging at Debug level.
+type QueryLog struct {
+	// Enabled logs every statement in any environment; local and dev log them regardless.
+	Enabled bool `yaml:"enabled" env:"POSTGRES_LOG_QUERIES" env-default:"false"`
+	// LogArgs logs argument values, which may hold personal data, instead of only their count.
+	LogArgs bool `yaml:"log_args" env-default:"false"`
+}
+
+// PostgresReplica contains connection parameters of a read replica. It is used when Host is set;
+// other empty fields take the values of the primary.
+type PostgresReplica struct {
+	Username string `yaml:"user"`
+	Password string `yaml:"password" env:"POSTGRES_REPLICA_PASSWORD"`
+	Host     string `yaml:"host" env:"POSTGRES_REPLICA_HOST"`
+	Port     string `yaml:"port"`
+	DbName   string `yaml:"db_name"`
+}
+
+// Statistics contains statistics service configuration.
+type Statistics struct {
+	// DisableSingleflight turns off sharing of one computation between concurrent identical requests.
+	DisableSingleflight bool `yaml:"disable_singleflight"`
+	// MaxTimeseriesBuckets caps the number of buckets a time series request may span; zero uses
+	// the default of 366.
+	MaxTimeseriesBuckets int `yaml:"max_timeseries_buckets" env-default:"366"`
+}
+
+// Review contains review policy configuration.
+type Review struct {
+	// Deadline is the business time a reviewer has to review an assigned PR.
+	Deadline time.Duration `yaml:"deadline" env-default:"24h"`
+	// MaxActiveReviews is the number of open reviews after which a user gets no more non-urgent PRs
+	// unless the whole team is at capacity. Users may override it; zero disables the limit.
+	MaxActiveReviews int `yaml:"max_active_reviews" env-default:"0"`
+	// BlockMergeOnChangesRequested refuses to merge PRs while a reviewer has requested changes.
+	BlockMergeOnChangesRequested bool `yaml:"block_merge_on_changes_requested" env-default:"false"`
+	// RequireReviewerForMerge refuses to merge PRs that have no assigned reviewers.
+	RequireReviewerForMerge bool `yaml:"require_reviewer_for_merge" env-default:"false"`
+	// Strategy decides how reviewer candidates are ranked, see StrategyLeastLoaded and StrategyWeighted.
+	Strategy string `yaml:"strategy" env-default:"least_loaded"`
+	// WeightHalfLife is the age at which an assignment counts half towards the weighted strategy.
+	WeightHalfLife time.Duration `yaml:"weight_half_life" env-default:"168h"`
+}
+
+// Escalation contains configuration of the job reassigning stale reviews.
+type Escalation struct {
+	// Enabled starts the job; it is off by default.
+	Enabled bool `yaml:"enabled" env-default:"false"`
+	// Interval is how often the job looks for stale reviews.
+	Interval time.Duration `yaml:"interval" env-default:"1h"`
+	// Threshold is how long a review may stay pending before it is reassigned.
+	Threshold time.Duration `yaml:"threshold" env-default:"72h"`
+	// BatchSize limits the number of reviews reassigned in one run.
+	BatchSize int `yaml:"batch_size" env-default:"100"`
 }
+
+// Webhook contains configuration of the delivery of events to the webhook.
+// Events are stored and posted by a background job, which retries failed posts with exponential backoff.
+type Webhook struct {
+	// URL receives a POST for every event when set; no events are stored without it.
+	URL string `yaml:"url" env:"WEBHOOK_URL"`
+	// Timeout bounds a single post.
+	Timeout time.Duration `yaml:"timeout" env-default:"10s"`
+	// Interval is how often the job posts the deliveries that are due.
+	Interval time.Duration `yaml:"interval" env-default:"10s"`
+	// BatchSize limits the number of deliveries posted in one run.
+	BatchSize int `yaml:"batch_size" env-default:"100"`
+	// MaxAttempts is the number of failed posts after which a delivery is dead-lettered.
+	MaxAttempts int `yaml:"max_attempts" env-default:"8"`
+	// InitialBackoff is the delay after the first failure; it doubles with every further one.
+	InitialBackoff time.Duration `yaml:"initial_backoff" env-default:"30s"`
+	// MaxBackoff caps the delay between two attempts.
+	MaxBackoff time.Duration `yaml:"max_backoff" env-default:"1h"`
+}
+
+// Snapshot contains configuration of the job keeping a daily snapshot of the statistics.
+type Snapshot struct {
+	// Enabled starts the job; it is off by default.
+	Enabled bool `yaml:"enabled" env-default:"false"`
+	// Interval is how often the job checks whether the snapshot of the day was taken.
+	Interval time.Duration `yaml:"interval" env-default:"1h"`
+}
+
+// Metrics contains configuration of the business metrics served at /metrics, which a job refreshes
+// from the database instead of every scrape.
+type Metrics struct {
+	// Interval is how often the job refreshes the business metrics.
+	Interval time.Duration `yaml:"interval" env:"METRICS_INTERVAL" env-default:"30s"`
+	// MaxTeamLabels is the number of teams the PR gauges are labelled by; the PRs of the other teams
+	// are summed under "other". Zero labels every team.
+	MaxTeamLabels int `yaml:"max_team_labels" env:"METRICS_MAX_TEAM_LABELS" env-default:"50"`
+}
+
+// GraphQL contains the limits of queries to the GraphQL endpoint, which are rejected before running
+// when they exceed either.
+type GraphQL struct {
+	// MaxDepth limits the nesting of selections.
+	MaxDepth int `yaml:"max_depth" env:"GRAPHQL_MAX_DEPTH" env-default:"8"`
+	// MaxComplexity limits the estimated number of resolved fields, with lists counted at their limit.
+	MaxComplexity int `yaml:"max_complexity" env:"GRAPHQL_MAX_COMPLEXITY" env-default:"5000"`
+}
+
+// Import contains limits of the data imports.
+type Import struct {
+	// MaxCSVSize is the largest CSV team import accepted, in bytes.
+	MaxCSVSize int64 `yaml:"max_csv_size" env-default:"1048576"`
+	// MaxTeamMembers is the largest team accepted by the streamed JSON import.
+	MaxTeamMembers int `yaml:"max_team_members" env-default:"50000"`
+	// BatchSize is how many members of a streamed JSON import are stored at a time.
+	BatchSize int `yaml:"batch_size" env-default:"500"`
+}
+
+// Dump contains configuration of the export of all data through the admin API.
+type Dump struct {
+	// Token is the bearer token the export requires; the export is not served without one.
+	Token string `yaml:"token" env:"DUMP_TOKEN"`
+	// Timeout bounds an export, which reads everything in one transaction.
+	Timeout time.Duration `yaml:"timeout" env-default:"5m"`
+}
+
+// Admin contains configuration of the admin requests within the other routes.
+type Admin struct {
+	// Token is the bearer token admin requests require; they are refused without one.
+	Token string `yaml:"token" env:"ADMIN_TOKEN"`
+}
+
+// Readiness contains configuration of the checks behind /readyz.
+type Readiness struct {
+	// Critical lists the checks whose failure makes the service unready; the failure of another
+	// check is only reported as a warning. Checks are database, migrations, outbox, leader,
+	// escalation_job, webhook_job, snapshot_job and metrics_job.
+	Critical []string `yaml:"critical" env-default:"database,migrations"`
+	// Timeout bounds every check.
+	Timeout time.Duration `yaml:"timeout" env-default:"2s"`
+	// MaxOutboxBacklog is the number of pending webhook deliveries above which the outbox check fails.
+	MaxOutboxBacklog int `yaml:"max_outbox_backlog" env-default:"1000"`
+}
+
+// Overload contains configuration of the refusal of requests while the database connections are
+// exhausted; refused requests are answered 503 SERVICE_OVERLOADED with a Retry-After header.
+type Overload struct {
+	// AcquireTimeout bounds the wait for a free database connection; zero waits as long as the request.
+	AcquireTimeout time.Duration `yaml:"acquire_timeout" env:"POSTGRES_ACQUIRE_TIMEOUT" env-default:"1s"`
+	// MaxWaiting is the number of requests waiting for a connection above which new requests are
+	// refused before reaching the database. Zero disables the check.
+	MaxWaiting int `yaml:"max_waiting" env:"OVERLOAD_MAX_WAITING" env-default:"0"`
+}
+
+// Leader contains configuration of the election of the replica running the exclusive background
+// jobs, such as the escalation and the webhook delivery.
+type Leader struct {
+	// LeaseTTL is how long the leader holds its lease without renewing it, so a replica takes over
+	// at most this long, plus RenewInterval, after the leader died.
+	LeaseTTL time.Duration `yaml:"lease_ttl" env:"LEADER_LEASE_TTL" env-default:"15s"`
+	// RenewInterval is how often the leader renews its lease and the other replicas try to take it;
+	// it must be shorter than LeaseTTL.
+	RenewInterval time.Duration `yaml:"renew_interval" env:"LEADER_RENEW_INTERVAL" env-default:"5s"`
+}
+
+// Archive contains configuration of the archival of merged PRs.
+type Archive struct {
+	// Retention is how long a merged PR stays in the main tables when the archival request gives no cutoff.
+	Retention time.Duration `yaml:"retention" env-default:"8760h"`
+	// BatchSize limits the number of PRs moved in one transaction.
+	BatchSize int `yaml:"batch_size" env-default:"500"`
+}
+
+// Reviewer selection strategies.
+const (
+	// StrategyLeastLoaded ranks candidates by their current number of open reviews.
+	StrategyLeastLoaded = "least_loaded"
+	// StrategyWeighted ranks candidates by their assignments of the last 30 days, older ones counting less.
+	StrategyWeighted = "weighted"
+)
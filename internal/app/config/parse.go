@@ -27,5 +27,13 @@ func MustLoad(path string) (*Config, error) {
 	if err := cleanenv.ReadConfig(path, &cfg); err != nil {
 		return nil, err
 	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
 	return &cfg, nil
 }
+
+// Validate checks the settings that can't be checked while reading the config.
+func (c *Config) Validate() error {
+	return c.Log.Validate()
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLog_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		log     Log
+		wantErr string
+	}{
+		{name: "Success - Empty section takes the env defaults", log: Log{}},
+		{name: "Success - JSON", log: Log{Format: LogFormatJSON, Level: "info"}},
+		{name: "Success - Text", log: Log{Format: LogFormatText, Level: "debug", AddSource: true}},
+		{name: "Success - Level in upper case", log: Log{Level: "WARN"}},
+		{name: "Error - Unknown format", log: Log{Format: "logfmt"}, wantErr: `log.format must be json or text, got "logfmt"`},
+		{name: "Error - Unknown level", log: Log{Level: "verbose"}, wantErr: `log.level must be debug, info, warn or error, got "verbose"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.log.Validate()
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestMustLoad(t *testing.T) {
+	write := func(t *testing.T, content string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "config.yml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+	t.Setenv("POSTGRES_PASSWORD", "secret")
+
+	t.Run("Success - Log section is read", func(t *testing.T) {
+		cfg, err := MustLoad(write(t, "log:\n  format: text\n  level: warn\n  add_source: true\n"))
+
+		require.NoError(t, err)
+		assert.Equal(t, Log{Format: LogFormatText, Level: "warn", AddSource: true}, cfg.Log)
+	})
+
+	t.Run("Success - Absent log section is left empty", func(t *testing.T) {
+		cfg, err := MustLoad(write(t, "server:\n  env: prod\n"))
+
+		require.NoError(t, err)
+		assert.Equal(t, Log{}, cfg.Log)
+	})
+
+	t.Run("Success - Webhook URL is read from the environment", func(t *testing.T) {
+		t.Setenv("WEBHOOK_URL", "http://hooks.local/events")
+
+		cfg, err := MustLoad(write(t, "webhook:\n  url: http://other.local\n  timeout: 5s\n"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "http://hooks.local/events", cfg.Webhook.URL)
+		assert.Equal(t, 5*time.Second, cfg.Webhook.Timeout)
+	})
+
+	t.Run("Error - Invalid format fails validation", func(t *testing.T) {
+		cfg, err := MustLoad(write(t, "log:\n  format: xml\n"))
+
+		assert.Nil(t, cfg)
+		assert.ErrorContains(t, err, `invalid config: log.format must be json or text, got "xml"`)
+	})
+}
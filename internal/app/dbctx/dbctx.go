@@ -0,0 +1,28 @@
+// Package dbctx marks contexts with where the storage may serve their reads from.
+package dbctx
+
+import "context"
+
+// replicaKey is a key for storing whether reads may be served by a replica in context.
+type replicaKey struct{}
+
+// ReadOnly marks ctx as only reading, so the storage may serve it from a read replica, which can
+// lag behind the primary. A context already marked by Primary keeps reading from the primary.
+func ReadOnly(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(replicaKey{}).(bool); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, replicaKey{}, true)
+}
+
+// Primary marks ctx to read from the primary, including the read-only calls made with it.
+// Transactors mark their transactions so, as a write must see its own reads.
+func Primary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replicaKey{}, false)
+}
+
+// IsReadOnly reports whether reads of ctx may be served by a read replica.
+func IsReadOnly(ctx context.Context) bool {
+	allowed, _ := ctx.Value(replicaKey{}).(bool)
+	return allowed
+}
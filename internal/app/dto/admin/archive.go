@@ -0,0 +1,29 @@
+package admin
+
+import (
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/pullrequest"
+)
+
+// ArchiveRequest represents a request to archive PRs merged before a moment.
+// A nil Before archives PRs merged longer ago than the configured retention.
+type ArchiveRequest struct {
+	Before *time.Time
+}
+
+// ArchiveResponse represents the result of archiving merged PRs.
+type ArchiveResponse struct {
+	Archived int    `json:"archived"`
+	Before   string `json:"before"`
+}
+
+// RestoreArchivedRequest represents a request to move an archived PR back.
+type RestoreArchivedRequest struct {
+	PullRequestID string `json:"pull_request_id" validate:"required"`
+}
+
+// RestoreArchivedResponse represents the restored PR.
+type RestoreArchivedResponse struct {
+	Pr pullrequest.PR `json:"pr"`
+}
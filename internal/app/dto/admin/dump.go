@@ -0,0 +1,98 @@
+package admin
+
+import "time"
+
+// DumpSchemaVersion is the version of the Dump document, raised whenever its format changes.
+const DumpSchemaVersion = 1
+
+// Dump represents an export of all teams, users, PRs and reviewer assignments. Every section is
+// ordered by its key. Checksums are written last, as the sections are streamed.
+type Dump struct {
+	SchemaVersion int               `json:"schema_version" validate:"required"`
+	ExportedAt    time.Time         `json:"exported_at"`
+	Teams         []DumpTeam        `json:"teams" validate:"dive"`
+	Users         []DumpUser        `json:"users" validate:"dive"`
+	PullRequests  []DumpPullRequest `json:"pull_requests" validate:"dive"`
+	Reviewers     []DumpReviewer    `json:"reviewers" validate:"dive"`
+	Checksums     DumpChecksums     `json:"checksums"`
+}
+
+// DumpTeam represents the metadata of a team; its members are the users with its name.
+type DumpTeam struct {
+	TeamName    string     `json:"team_name" validate:"required"`
+	Description string     `json:"description"`
+	LeadID      string     `json:"lead_id,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+}
+
+// DumpUser represents a user with all their settings. IsReviewer is missing from dumps made before
+// it, which restore the user as a reviewer.
+type DumpUser struct {
+	UserID           string   `json:"user_id" validate:"required"`
+	Username         string   `json:"username" validate:"required"`
+	TeamName         string   `json:"team_name" validate:"required"`
+	IsActive         bool     `json:"is_active"`
+	IsReviewer       *bool    `json:"is_reviewer,omitempty"`
+	MaxActiveReviews *int     `json:"max_active_reviews,omitempty" validate:"omitempty,min=1"`
+	Tags             []string `json:"tags" validate:"dive,required"`
+	Timezone         string   `json:"timezone,omitempty"`
+	WorkHoursStart   string   `json:"work_hours_start,omitempty"`
+	WorkHoursEnd     string   `json:"work_hours_end,omitempty"`
+}
+
+// DumpPullRequest represents a PR without its reviewers, which are in the reviewers section.
+// AssignmentSkipped is left out unless set, so dumps made before it still restore.
+type DumpPullRequest struct {
+	PullRequestID     string     `json:"pull_request_id" validate:"required"`
+	PullRequestName   string     `json:"pull_request_name" validate:"required"`
+	AuthorID          string     `json:"author_id" validate:"required"`
+	Status            string     `json:"status" validate:"oneof=OPEN MERGED CLOSED"`
+	Priority          string     `json:"priority" validate:"oneof=LOW NORMAL HIGH URGENT"`
+	Labels            []string   `json:"labels" validate:"dive,required"`
+	CreatedAt         time.Time  `json:"created_at" validate:"required"`
+	UpdatedAt         time.Time  `json:"updated_at" validate:"required"`
+	MergedAt          *time.Time `json:"merged_at,omitempty"`
+	AssignmentSkipped bool       `json:"assignment_skipped,omitempty"`
+}
+
+// DumpReviewer represents the assignment of a reviewer to a PR.
+type DumpReviewer struct {
+	PullRequestID  string     `json:"pull_request_id" validate:"required"`
+	ReviewerID     string     `json:"reviewer_id" validate:"required"`
+	AssignedAt     time.Time  `json:"assigned_at" validate:"required"`
+	Source         string     `json:"source" validate:"oneof=auto manual reassign deactivation escalation"`
+	State          string     `json:"state" validate:"oneof=PENDING APPROVED CHANGES_REQUESTED"`
+	StateChangedAt *time.Time `json:"state_changed_at,omitempty"`
+}
+
+// DumpChecksums holds the checksum of every section of a Dump.
+type DumpChecksums struct {
+	Teams        DumpChecksum `json:"teams"`
+	Users        DumpChecksum `json:"users"`
+	PullRequests DumpChecksum `json:"pull_requests"`
+	Reviewers    DumpChecksum `json:"reviewers"`
+}
+
+// DumpChecksum is the number of records of a section and the hex SHA-256 of their compact JSON,
+// each followed by a newline, in the order they appear.
+type DumpChecksum struct {
+	Count  int    `json:"count"`
+	SHA256 string `json:"sha256"`
+}
+
+// ImportDumpRequest represents a Dump to load. Without Force the storage must be empty; with it,
+// everything stored is deleted first.
+type ImportDumpRequest struct {
+	Dump  Dump
+	Force bool
+}
+
+// ImportDumpResponse represents the number of records imported into each table, and whether the
+// data stored before was deleted.
+type ImportDumpResponse struct {
+	Teams        int  `json:"teams"`
+	Users        int  `json:"users"`
+	PullRequests int  `json:"pull_requests"`
+	Reviewers    int  `json:"reviewers"`
+	Cleared      bool `json:"cleared"`
+}
@@ -0,0 +1,15 @@
+package admin
+
+// NearDuplicate represents identifiers of one kind (user, team or pull_request) that differ only in
+// surrounding whitespace or case, with the key they share.
+type NearDuplicate struct {
+	Kind string   `json:"kind"`
+	Key  string   `json:"key"`
+	IDs  []string `json:"ids"`
+}
+
+// NearDuplicatesResponse represents the near-duplicate identifiers stored, which operators merge
+// or rename by hand.
+type NearDuplicatesResponse struct {
+	Duplicates []NearDuplicate `json:"duplicates"`
+}
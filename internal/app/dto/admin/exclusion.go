@@ -0,0 +1,42 @@
+package admin
+
+import "github.com/shirr9/pr-reviewer-service/internal/app/dto"
+
+// AddExclusionRequest represents a request to forbid a reviewer to review PRs of an author.
+// A mutual exclusion also forbids the author to review PRs of the reviewer.
+type AddExclusionRequest struct {
+	ReviewerID string `json:"reviewer_id" validate:"required,nefield=AuthorID"`
+	AuthorID   string `json:"author_id" validate:"required"`
+	Mutual     bool   `json:"mutual"`
+}
+
+// RemoveExclusionRequest represents a request to lift the exclusion of a reviewer for an author.
+type RemoveExclusionRequest struct {
+	ReviewerID string `validate:"required"`
+	AuthorID   string `validate:"required"`
+}
+
+// ExclusionResponse represents the response after adding an exclusion.
+type ExclusionResponse struct {
+	Exclusion Exclusion `json:"exclusion"`
+}
+
+// RemoveExclusionResponse represents the response after removing exclusions.
+type RemoveExclusionResponse struct {
+	Removed int `json:"removed"`
+}
+
+// ListExclusionsRequest represents a request for a page of exclusions ordered by reviewer and author.
+// UserID keeps only exclusions where the user is the reviewer or the author.
+type ListExclusionsRequest struct {
+	UserID string
+	Page   dto.PageRequest
+}
+
+// Exclusion represents a reviewer who must not review PRs of the author.
+type Exclusion struct {
+	ReviewerID string `json:"reviewer_id"`
+	AuthorID   string `json:"author_id"`
+	Mutual     bool   `json:"mutual"`
+	CreatedAt  string `json:"created_at,omitempty"`
+}
@@ -0,0 +1,33 @@
+package admin
+
+// JobsResponse represents the periodic background jobs in the order they were registered.
+type JobsResponse struct {
+	Jobs []Job `json:"jobs"`
+}
+
+// Job represents a periodic background job with its last run, which is omitted before the first one.
+type Job struct {
+	Name            string  `json:"name"`
+	IntervalSeconds float64 `json:"interval_seconds"`
+	Exclusive       bool    `json:"exclusive"`
+	LastRun         *JobRun `json:"last_run,omitempty"`
+}
+
+// JobRun represents a run of a job; Error is empty for a successful one. LastSuccessAt is the end of
+// the last successful run, this one or an earlier one, and empty if the job never succeeded.
+type JobRun struct {
+	StartedAt     string `json:"started_at"`
+	FinishedAt    string `json:"finished_at"`
+	Error         string `json:"error,omitempty"`
+	LastSuccessAt string `json:"last_success_at,omitempty"`
+}
+
+// RunJobRequest represents a request to run a job now, outside its schedule.
+type RunJobRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// RunJobResponse represents the run of the job; a failed run is reported in it.
+type RunJobResponse struct {
+	Run JobRun `json:"run"`
+}
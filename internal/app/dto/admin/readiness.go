@@ -0,0 +1,67 @@
+package admin
+
+// Readiness statuses of the service.
+const (
+	// ReadinessReady means every check passed.
+	ReadinessReady = "ready"
+	// ReadinessDegraded means only checks that are not critical failed, so requests are still served.
+	ReadinessDegraded = "degraded"
+	// ReadinessUnavailable means a critical check failed.
+	ReadinessUnavailable = "unavailable"
+)
+
+// Statuses of a readiness check.
+const (
+	CheckOK   = "ok"
+	CheckFail = "fail"
+)
+
+// ReadinessResponse represents the result of every readiness check, in a fixed order, and the
+// failures of the checks that are not critical as warnings.
+type ReadinessResponse struct {
+	Status   string           `json:"status"`
+	Checks   []ReadinessCheck `json:"checks"`
+	Warnings []string         `json:"warnings,omitempty"`
+}
+
+// ReadinessCheck represents the result of a check, with the details of its kind: Migration for
+// migrations, Outbox for outbox, Leader for leader and Worker for the background jobs.
+type ReadinessCheck struct {
+	Name      string           `json:"name"`
+	Status    string           `json:"status"`
+	Critical  bool             `json:"critical"`
+	LatencyMS float64          `json:"latency_ms"`
+	Error     string           `json:"error,omitempty"`
+	Migration *MigrationStatus `json:"migration,omitempty"`
+	Outbox    *OutboxStatus    `json:"outbox,omitempty"`
+	Leader    *LeaderStatus    `json:"leader,omitempty"`
+	Worker    *WorkerStatus    `json:"worker,omitempty"`
+}
+
+// MigrationStatus represents the schema version of the database and the one the service was built for.
+type MigrationStatus struct {
+	Current  uint `json:"current"`
+	Expected uint `json:"expected"`
+	Dirty    bool `json:"dirty"`
+}
+
+// OutboxStatus represents the webhook deliveries waiting to be posted.
+type OutboxStatus struct {
+	Pending int `json:"pending"`
+	Max     int `json:"max"`
+}
+
+// LeaderStatus represents the leader election as this replica sees it: its own id, whether it leads
+// and the id of the leader, empty when unknown.
+type LeaderStatus struct {
+	ID     string `json:"id"`
+	Leader bool   `json:"leader"`
+	Holder string `json:"holder,omitempty"`
+}
+
+// WorkerStatus represents when a background job last went round its loop; it is empty when the
+// job never did.
+type WorkerStatus struct {
+	LastBeatAt string `json:"last_beat_at,omitempty"`
+	Interval   string `json:"interval"`
+}
@@ -0,0 +1,53 @@
+package admin
+
+import (
+	"encoding/json"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+)
+
+// ListDeadLettersRequest represents a request for a page of dead-lettered deliveries, oldest first.
+type ListDeadLettersRequest struct {
+	Page dto.PageRequest
+}
+
+// RedeliverRequest represents a request to retry a dead-lettered delivery.
+type RedeliverRequest struct {
+	DeliveryID int64 `json:"delivery_id" validate:"required,min=1"`
+}
+
+// RedeliverResponse represents the delivery queued again.
+type RedeliverResponse struct {
+	Delivery WebhookDelivery `json:"delivery"`
+}
+
+// WebhookDelivery represents an event delivered, or to be delivered, to the webhook with its attempts.
+// NextAttemptAt is set for pending deliveries, DeliveredAt for delivered ones.
+type WebhookDelivery struct {
+	DeliveryID    int64            `json:"delivery_id"`
+	EventType     string           `json:"event_type"`
+	Payload       json.RawMessage  `json:"payload"`
+	Status        string           `json:"status"`
+	Attempts      int              `json:"attempts"`
+	LastError     string           `json:"last_error,omitempty"`
+	CreatedAt     string           `json:"created_at"`
+	NextAttemptAt string           `json:"next_attempt_at,omitempty"`
+	DeliveredAt   string           `json:"delivered_at,omitempty"`
+	History       []WebhookAttempt `json:"history"`
+}
+
+// WebhookAttempt represents an attempt to deliver an event; Error is empty for a successful one.
+type WebhookAttempt struct {
+	Attempt     int    `json:"attempt"`
+	AttemptedAt string `json:"attempted_at"`
+	Error       string `json:"error,omitempty"`
+}
+
+// WebhookStatsResponse counts the deliveries by status and the attempts made, failed ones included.
+type WebhookStatsResponse struct {
+	Pending        int `json:"pending"`
+	Delivered      int `json:"delivered"`
+	Dead           int `json:"dead"`
+	Attempts       int `json:"attempts"`
+	FailedAttempts int `json:"failed_attempts"`
+}
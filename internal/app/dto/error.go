@@ -5,10 +5,11 @@ type ErrorResponse struct {
 	Error ErrorDetail `json:"error"`
 }
 
-// ErrorDetail contains error code and message.
+// ErrorDetail contains error code, message and optional details.
 type ErrorDetail struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
+	Details any    `json:"details,omitempty"`
 }
 
 // NewErrorResponse creates a new ErrorResponse.
@@ -20,3 +21,16 @@ func NewErrorResponse(code, message string) ErrorResponse {
 		},
 	}
 }
+
+// ValidationDetails lists the fields of a request that failed validation.
+type ValidationDetails struct {
+	Fields []FieldError `json:"fields"`
+}
+
+// FieldError describes a field that failed a validation rule, e.g. "max" with Param "100".
+// Field is the JSON path of the field, such as "members[0].user_id".
+type FieldError struct {
+	Field string `json:"field"`
+	Rule  string `json:"rule"`
+	Param string `json:"param,omitempty"`
+}
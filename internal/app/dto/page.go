@@ -0,0 +1,31 @@
+package dto
+
+// PageRequest selects a page of a list: at most Limit items after skipping Offset of them.
+type PageRequest struct {
+	Limit  int
+	Offset int
+}
+
+// Page is the envelope of a page of a list endpoint. Total counts all items matching the request,
+// so another page exists while Offset+len(Items) < Total.
+type Page[T any] struct {
+	Items  []T `json:"items"`
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// NewPage wraps the items of the page selected by req out of total matching items.
+func NewPage[T any](items []T, total int, req PageRequest) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return Page[T]{Items: items, Total: total, Limit: req.Limit, Offset: req.Offset}
+}
+
+// PageOf cuts the page selected by req out of all items. The items are not copied.
+func PageOf[T any](all []T, req PageRequest) Page[T] {
+	start := min(req.Offset, len(all))
+	end := start + min(req.Limit, len(all)-start)
+	return NewPage(all[start:end:end], len(all), req)
+}
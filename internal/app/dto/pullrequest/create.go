@@ -1,23 +1,111 @@
 package pullrequest
 
 // PR represents info about a pull request.
+// Reviewers holds details of AssignedReviewers when they are expanded.
+// AssignmentSkipped is set while the reviewer assignment skipped at creation is not requested.
 type PR struct {
-	PullRequestID     string   `json:"pull_request_id"`
-	PullRequestName   string   `json:"pull_request_name"`
-	AuthorID          string   `json:"author_id"`
-	Status            string   `json:"status"`
-	AssignedReviewers []string `json:"assigned_reviewers"`
-	MergedAt          string   `json:"mergedAt,omitempty"`
+	PullRequestID     string     `json:"pull_request_id"`
+	PullRequestName   string     `json:"pull_request_name"`
+	AuthorID          string     `json:"author_id"`
+	Status            string     `json:"status"`
+	Priority          string     `json:"priority,omitempty"`
+	Labels            []string   `json:"labels"`
+	AssignedReviewers []string   `json:"assigned_reviewers"`
+	Reviewers         []Reviewer `json:"reviewers,omitempty"`
+	CreatedAt         string     `json:"created_at,omitempty"`
+	UpdatedAt         string     `json:"updated_at,omitempty"`
+	MergedAt          string     `json:"mergedAt,omitempty"`
+	AssignmentSkipped bool       `json:"assignment_skipped,omitempty"`
+}
+
+// Reviewer represents details of an assigned reviewer, how they were assigned and their review state.
+// Overdue is set only for open PRs whose review is past Deadline.
+type Reviewer struct {
+	UserID         string `json:"user_id"`
+	Username       string `json:"username"`
+	TeamName       string `json:"team_name"`
+	IsActive       bool   `json:"is_active"`
+	AssignedAt     string `json:"assigned_at,omitempty"`
+	Source         string `json:"source,omitempty"`
+	Deadline       string `json:"deadline,omitempty"`
+	Overdue        bool   `json:"overdue"`
+	State          string `json:"state,omitempty"`
+	StateChangedAt string `json:"state_changed_at,omitempty"`
 }
 
 // CreatePrRequest represents a request to create a new pull request.
+// Labels are stored lowercase without duplicates.
+// Reviewers having any of RequiredTags are preferred; they are not stored with the PR.
+// ReviewerIDs pins the reviewers instead of selecting them; with FillRemaining the service selects
+// the rest when fewer than the maximum are pinned.
+// SkipAssignment creates the PR without reviewers, to be assigned later with an AssignPrRequest.
 type CreatePrRequest struct {
-	PullRequestID   string `json:"pull_request_id" validate:"required"`
-	PullRequestName string `json:"pull_request_name" validate:"required"`
-	AuthorID        string `json:"author_id" validate:"required"`
+	PullRequestID   string   `json:"pull_request_id" validate:"required"`
+	PullRequestName string   `json:"pull_request_name" validate:"required"`
+	AuthorID        string   `json:"author_id" validate:"required"`
+	Priority        string   `json:"priority,omitempty" validate:"omitempty,oneof=LOW NORMAL HIGH URGENT"`
+	Labels          []string `json:"labels,omitempty" validate:"max=10,dive,required,max=50"`
+	RequiredTags    []string `json:"required_tags,omitempty" validate:"max=10,dive,required,max=50"`
+	ReviewerIDs     []string `json:"reviewer_ids,omitempty" validate:"max=2,unique,dive,required"`
+	FillRemaining   bool     `json:"fill_remaining,omitempty"`
+	SkipAssignment  bool     `json:"skip_assignment,omitempty" validate:"excluded_with=ReviewerIDs"`
 }
 
 // CreatePrResponse represents the response of creating a pull request.
+// OverloadedReviewers lists reviewers assigned although they were at capacity.
+// TagMatch is present when the request had required tags.
 type CreatePrResponse struct {
-	Pr PR `json:"pr"`
+	Pr                  PR        `json:"pr"`
+	OverloadedReviewers []string  `json:"overloaded_reviewers,omitempty"`
+	TagMatch            *TagMatch `json:"tag_match,omitempty"`
+}
+
+// TagMatch reports how the assigned reviewers matched the required tags.
+// Fallback is set when some reviewers have none of the tags and were taken from the rest of the team.
+type TagMatch struct {
+	RequiredTags     []string `json:"required_tags"`
+	MatchedReviewers []string `json:"matched_reviewers"`
+	Fallback         bool     `json:"fallback"`
+}
+
+// Results of creating a PR in a bulk create.
+const (
+	CreateResultCreated       = "created"
+	CreateResultAlreadyExists = "already_exists"
+	CreateResultNotFound      = "not_found"
+	CreateResultInvalid       = "invalid"
+	CreateResultFailed        = "failed"
+)
+
+// CreateBulkRequest represents a request to create a batch of pull requests.
+// Items are validated one by one, so an invalid item is reported in its result instead of failing the batch.
+// Reviewers are assigned only when AssignReviewers is set.
+type CreateBulkRequest struct {
+	PullRequests    []CreatePrRequest `json:"pull_requests" validate:"required,min=1,max=100"`
+	AssignReviewers bool              `json:"assign_reviewers"`
+}
+
+// CreateBulkResult represents the outcome of creating one pull request of the batch.
+// Index is the position of the item in the request; Pr is set for created PRs.
+type CreateBulkResult struct {
+	Index         int    `json:"index"`
+	PullRequestID string `json:"pull_request_id"`
+	Result        string `json:"result"`
+	Error         string `json:"error,omitempty"`
+	Pr            *PR    `json:"pr,omitempty"`
+}
+
+// CreateBulkSummary counts the bulk create results.
+type CreateBulkSummary struct {
+	Created       int `json:"created"`
+	AlreadyExists int `json:"already_exists"`
+	NotFound      int `json:"not_found"`
+	Invalid       int `json:"invalid"`
+	Failed        int `json:"failed"`
+}
+
+// CreateBulkResponse represents the response of a bulk create in the order of the request.
+type CreateBulkResponse struct {
+	Results []CreateBulkResult `json:"results"`
+	Summary CreateBulkSummary  `json:"summary"`
 }
@@ -0,0 +1,16 @@
+package pullrequest
+
+// HistoryResponse represents the reviewer changes of a pull request in chronological order.
+type HistoryResponse struct {
+	PullRequestID string           `json:"pull_request_id"`
+	History       []ReviewerChange `json:"history"`
+}
+
+// ReviewerChange represents a reviewer replaced on a pull request.
+// NewReviewerID is empty when the reviewer was removed without replacement.
+type ReviewerChange struct {
+	OldReviewerID string `json:"old_reviewer_id"`
+	NewReviewerID string `json:"new_reviewer_id,omitempty"`
+	Trigger       string `json:"trigger"`
+	ChangedAt     string `json:"changed_at"`
+}
@@ -0,0 +1,13 @@
+package pullrequest
+
+// SetLabelsRequest represents a request to replace the labels of an open pull request.
+// An empty list removes all labels.
+type SetLabelsRequest struct {
+	PullRequestID string   `json:"pull_request_id" validate:"required"`
+	Labels        []string `json:"labels" validate:"max=10,dive,required,max=50"`
+}
+
+// SetLabelsResponse represents the response of replacing the labels of a pull request.
+type SetLabelsResponse struct {
+	Pr PR `json:"pr"`
+}
@@ -1,11 +1,47 @@
 package pullrequest
 
-// MergePrRequest represents a request to merge a pull request.
+// MergePrRequest represents a request to merge a pull request. Override merges it although
+// reviewers requested changes; it is only accepted from admins.
 type MergePrRequest struct {
 	PullRequestID string `json:"pull_request_id" validate:"required"`
+	Override      bool   `json:"override,omitempty"`
 }
 
 // MergePrResponse represents the response of merging a pull request.
 type MergePrResponse struct {
 	Pr PR `json:"pr"`
 }
+
+// Results of merging a PR in a bulk merge.
+const (
+	MergeResultMerged        = "merged"
+	MergeResultAlreadyMerged = "already_merged"
+	MergeResultNotFound      = "not_found"
+	MergeResultFailed        = "failed"
+)
+
+// MergeBulkRequest represents a request to merge a batch of pull requests.
+type MergeBulkRequest struct {
+	PullRequestIDs []string `json:"pull_request_ids" validate:"required,min=1,max=100,dive,required"`
+}
+
+// MergeBulkResult represents the outcome of merging one pull request of the batch.
+type MergeBulkResult struct {
+	PullRequestID string `json:"pull_request_id"`
+	Result        string `json:"result"`
+	Error         string `json:"error,omitempty"`
+}
+
+// MergeBulkSummary counts the bulk merge results.
+type MergeBulkSummary struct {
+	Merged        int `json:"merged"`
+	AlreadyMerged int `json:"already_merged"`
+	NotFound      int `json:"not_found"`
+	Failed        int `json:"failed"`
+}
+
+// MergeBulkResponse represents the response of a bulk merge in the order of the request.
+type MergeBulkResponse struct {
+	Results []MergeBulkResult `json:"results"`
+	Summary MergeBulkSummary  `json:"summary"`
+}
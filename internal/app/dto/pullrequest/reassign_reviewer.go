@@ -1,13 +1,17 @@
 package pullrequest
 
 // ReassignReviewerRequest represents a request to reassign a reviewer from a pull request.
+// NewReviewerID optionally names the replacement instead of letting the service choose it.
 type ReassignReviewerRequest struct {
 	PullRequestID string `json:"pull_request_id" validate:"required"`
 	OldReviewerID string `json:"old_reviewer_id" validate:"required"`
+	NewReviewerID string `json:"new_reviewer_id,omitempty"`
 }
 
 // ReassignReviewerResponse represents the response of reassigning a reviewer.
+// ReplacedByOpenReviews is the number of open PRs the new reviewer reviewed before this one.
 type ReassignReviewerResponse struct {
-	Pr         PR     `json:"pr"`
-	ReplacedBy string `json:"replaced_by"`
+	Pr                    PR     `json:"pr"`
+	ReplacedBy            string `json:"replaced_by"`
+	ReplacedByOpenReviews int    `json:"replaced_by_open_reviews"`
 }
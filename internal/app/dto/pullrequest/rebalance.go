@@ -0,0 +1,34 @@
+package pullrequest
+
+// RebalanceSuggestionsRequest represents a request for reviews of the user's teammates that could
+// be handed over to the user, such as after the user is reactivated.
+type RebalanceSuggestionsRequest struct {
+	UserID string `validate:"required"`
+	Limit  int    `validate:"min=1,max=20"`
+}
+
+// RebalanceSuggestionsResponse represents the suggested reassignments, which are not applied.
+// OpenReviews is the number of open PRs the user reviews now.
+type RebalanceSuggestionsResponse struct {
+	UserID      string                `json:"user_id"`
+	TeamName    string                `json:"team_name"`
+	OpenReviews int                   `json:"open_reviews"`
+	Suggestions []RebalanceSuggestion `json:"suggestions"`
+}
+
+// RebalanceSuggestion represents a review to move from the current reviewer to the proposed one.
+// CurrentReviewerOpenReviews is the number of open PRs the current reviewer reviews now.
+type RebalanceSuggestion struct {
+	PullRequestID              string `json:"pull_request_id"`
+	PullRequestName            string `json:"pull_request_name"`
+	CurrentReviewerID          string `json:"current_reviewer_id"`
+	CurrentReviewerOpenReviews int    `json:"current_reviewer_open_reviews"`
+	ProposedReviewerID         string `json:"proposed_reviewer_id"`
+}
+
+// ApplyRebalanceRequest represents a request to apply a rebalance suggestion.
+type ApplyRebalanceRequest struct {
+	PullRequestID      string `json:"pull_request_id" validate:"required"`
+	CurrentReviewerID  string `json:"current_reviewer_id" validate:"required"`
+	ProposedReviewerID string `json:"proposed_reviewer_id" validate:"required"`
+}
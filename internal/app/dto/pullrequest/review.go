@@ -0,0 +1,13 @@
+package pullrequest
+
+// ReviewRequest represents a request to record the review state of an assigned reviewer.
+type ReviewRequest struct {
+	PullRequestID string `json:"pull_request_id" validate:"required"`
+	ReviewerID    string `json:"reviewer_id" validate:"required"`
+	State         string `json:"state" validate:"required,oneof=PENDING APPROVED CHANGES_REQUESTED"`
+}
+
+// ReviewResponse represents the response of recording a review state.
+type ReviewResponse struct {
+	Pr PR `json:"pr"`
+}
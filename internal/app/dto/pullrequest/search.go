@@ -0,0 +1,16 @@
+package pullrequest
+
+import "github.com/shirr9/pr-reviewer-service/internal/app/dto"
+
+// SearchPrRequest represents a request for a page of pull requests found by title, newest first
+// unless Sort is set.
+type SearchPrRequest struct {
+	Query  string `validate:"required,max=100"`
+	Status string `validate:"omitempty,oneof=OPEN MERGED CLOSED"`
+	Page   dto.PageRequest
+	Sort   dto.Sort
+	// Labels keeps only PRs that carry all of them.
+	Labels []string `validate:"max=10,dive,required,max=50"`
+	// ExpandReviewers requests reviewer details in each PR.
+	ExpandReviewers bool
+}
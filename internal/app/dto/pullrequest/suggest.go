@@ -0,0 +1,31 @@
+package pullrequest
+
+// SuggestReviewersRequest represents a request to preview reviewers for a new PR by the author.
+type SuggestReviewersRequest struct {
+	AuthorID     string   `validate:"required"`
+	Count        int      `validate:"min=1,max=10"`
+	Priority     string   `validate:"omitempty,oneof=LOW NORMAL HIGH URGENT"`
+	RequiredTags []string `validate:"max=10,dive,required,max=50"`
+}
+
+// SuggestReviewersResponse represents the ranked reviewer candidates, best first.
+type SuggestReviewersResponse struct {
+	AuthorID   string              `json:"author_id"`
+	TeamName   string              `json:"team_name"`
+	Candidates []SuggestedReviewer `json:"candidates"`
+}
+
+// SuggestedReviewer represents a reviewer candidate with the number of open PRs they review.
+// Capacity is omitted when the user has no limit; Weight is present with the weighted strategy.
+// MatchesTags is set when the user has one of the required tags; InHours when it's now
+// working time for them (always true without a timezone).
+type SuggestedReviewer struct {
+	UserID      string  `json:"user_id"`
+	Username    string  `json:"username"`
+	OpenReviews int     `json:"open_reviews"`
+	Weight      float64 `json:"weight,omitempty"`
+	Capacity    int     `json:"capacity,omitempty"`
+	AtCapacity  bool    `json:"at_capacity"`
+	MatchesTags bool    `json:"matches_tags,omitempty"`
+	InHours     bool    `json:"in_hours"`
+}
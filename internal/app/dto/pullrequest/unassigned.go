@@ -0,0 +1,44 @@
+package pullrequest
+
+import "github.com/shirr9/pr-reviewer-service/internal/app/dto"
+
+// UnassignedRequest represents a request for a page of open PRs without reviewers, oldest first
+// unless Sort is set. Labels keeps only PRs that carry all of them. Unless AssignmentSkipped is nil,
+// it keeps only the PRs created with skipped assignment, or only the others.
+type UnassignedRequest struct {
+	Page              dto.PageRequest
+	Sort              dto.Sort
+	Labels            []string `validate:"max=10,dive,required,max=50"`
+	AssignmentSkipped *bool
+}
+
+// AssignPrRequest represents a request to assign reviewers to an open PR without them, usually one
+// created with skipped assignment.
+type AssignPrRequest struct {
+	PullRequestID string `json:"pull_request_id" validate:"required"`
+}
+
+// AssignPrResponse represents the PR with its assigned reviewers, none when there are no candidates.
+// OverloadedReviewers lists reviewers assigned although they were at capacity.
+type AssignPrResponse struct {
+	Pr                  PR       `json:"pr"`
+	OverloadedReviewers []string `json:"overloaded_reviewers,omitempty"`
+}
+
+// AssignPendingRequest represents a request to retry reviewer assignment for a page of unassigned PRs;
+// PRs created with skipped assignment are left for an AssignPrRequest.
+type AssignPendingRequest struct {
+	Limit  int `json:"limit" validate:"min=1,max=100"`
+	Offset int `json:"offset" validate:"min=0"`
+}
+
+// AssignPendingResponse represents the result of retrying reviewer assignment.
+// PRs that are still unassigned stay in the set, so NextOffset skips them on the next call.
+type AssignPendingResponse struct {
+	Processed       int  `json:"processed"`
+	Assigned        int  `json:"assigned"`
+	StillUnassigned int  `json:"still_unassigned"`
+	PullRequests    []PR `json:"pull_requests"`
+	NextOffset      int  `json:"next_offset"`
+	HasMore         bool `json:"has_more"`
+}
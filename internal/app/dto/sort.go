@@ -0,0 +1,8 @@
+package dto
+
+// Sort orders a list by one field, descending when Desc is set. Handlers accept only the fields
+// allowed for the endpoint; the zero value keeps the default order of the list.
+type Sort struct {
+	Field string
+	Desc  bool
+}
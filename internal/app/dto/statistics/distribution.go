@@ -0,0 +1,35 @@
+package statistics
+
+// DistributionRequest selects the team of the review load distribution, all teams when TeamName is
+// empty; Detail adds the load of every user.
+type DistributionRequest struct {
+	TeamName string
+	Detail   bool
+}
+
+// DistributionBucket counts the users whose count falls into the bucket; the last bucket, such as
+// "5+", holds every larger count.
+type DistributionBucket struct {
+	Bucket string `json:"bucket"`
+	Users  int    `json:"users"`
+}
+
+// DistributionUser is the review load of a user.
+type DistributionUser struct {
+	UserID           string `json:"user_id"`
+	Username         string `json:"username"`
+	TeamName         string `json:"team_name"`
+	ActiveReviews    int    `json:"active_reviews"`
+	TotalAssignments int    `json:"total_assignments"`
+}
+
+// DistributionResponse holds histograms of the active users by their reviews on open PRs and by
+// all their assignments, archived PRs included.
+type DistributionResponse struct {
+	TeamName         string               `json:"team_name,omitempty"`
+	ActiveUsers      int                  `json:"active_users"`
+	ActiveReviews    []DistributionBucket `json:"active_reviews"`
+	TotalAssignments []DistributionBucket `json:"total_assignments"`
+	// Users is nil unless requested, with the most loaded users first.
+	Users []DistributionUser `json:"users,omitzero"`
+}
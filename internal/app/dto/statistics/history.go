@@ -0,0 +1,41 @@
+package statistics
+
+import "time"
+
+// HistoryRequest selects the range [From, To) of the statistics history.
+type HistoryRequest struct {
+	From time.Time
+	To   time.Time
+}
+
+// SnapshotAggregates are the key aggregates kept by a daily snapshot, as the statistics reported them.
+type SnapshotAggregates struct {
+	TotalPRs                int `json:"total_prs"`
+	OpenPRs                 int `json:"open_prs"`
+	MergedPRs               int `json:"merged_prs"`
+	TotalAssignments        int `json:"total_assignments"`
+	ReassignmentEvents      int `json:"reassignment_events"`
+	OpenPRsWithoutReviewers int `json:"open_prs_without_reviewers"`
+}
+
+// TeamSnapshot holds the aggregates of the PRs authored by the members of a team.
+type TeamSnapshot struct {
+	TeamName string `json:"team_name"`
+	SnapshotAggregates
+}
+
+// DailySnapshot holds the aggregates of all PRs on a day, the start of the day in UTC, and those of
+// every team that had members when the snapshot was taken, in alphabetical order.
+type DailySnapshot struct {
+	Day     string `json:"day"`
+	TakenAt string `json:"taken_at"`
+	SnapshotAggregates
+	Teams []TeamSnapshot `json:"teams"`
+}
+
+// HistoryResponse lists the days of the range that have a snapshot, in order.
+type HistoryResponse struct {
+	From      string          `json:"from"`
+	To        string          `json:"to"`
+	Snapshots []DailySnapshot `json:"snapshots"`
+}
@@ -0,0 +1,18 @@
+package statistics
+
+// OverdueResponse lists open PR reviews past their deadline, grouped by reviewer.
+type OverdueResponse struct {
+	Total     int               `json:"total"`
+	Reviewers []OverdueReviewer `json:"reviewers"`
+}
+
+type OverdueReviewer struct {
+	UserID      string              `json:"user_id"`
+	Assignments []OverdueAssignment `json:"assignments"`
+}
+
+type OverdueAssignment struct {
+	PullRequestID string `json:"pull_request_id"`
+	AssignedAt    string `json:"assigned_at"`
+	Deadline      string `json:"deadline"`
+}
@@ -1,25 +1,129 @@
 package statistics
 
+import "github.com/shirr9/pr-reviewer-service/internal/app/dto"
+
 type UserStats struct {
 	UserID           string `json:"user_id"`
 	Username         string `json:"username"`
 	AssignmentsCount int    `json:"assignments_count"`
 	ActiveReviews    int    `json:"active_reviews"`
+	// Weight is the decayed count of recent assignments used by the weighted reviewer strategy.
+	Weight float64 `json:"weight"`
+	// AvgTurnaroundSeconds and MedianTurnaroundSeconds measure, in whole seconds, how long the
+	// user's reviews of merged PRs took from assignment to merge; they are null without any.
+	AvgTurnaroundSeconds    *int64 `json:"avg_turnaround_seconds"`
+	MedianTurnaroundSeconds *int64 `json:"median_turnaround_seconds"`
 }
 
 type PRStats struct {
-	PullRequestID      string `json:"pull_request_id"`
-	PullRequestName    string `json:"pull_request_name"`
-	ReviewersCount     int    `json:"reviewers_count"`
-	Status             string `json:"status"`
-	ReassignmentsCount int    `json:"reassignments_count"`
+	PullRequestID      string   `json:"pull_request_id"`
+	PullRequestName    string   `json:"pull_request_name"`
+	ReviewersCount     int      `json:"reviewers_count"`
+	Status             string   `json:"status"`
+	ReassignmentsCount int      `json:"reassignments_count"`
+	Priority           string   `json:"priority"`
+	Labels             []string `json:"labels"`
+	// AssignmentSkipped is set for a PR created without reviewers on purpose, so a zero
+	// ReviewersCount is not an assignment failure.
+	AssignmentSkipped bool `json:"assignment_skipped"`
+}
+
+type PriorityStats struct {
+	Priority string `json:"priority"`
+	TotalPRs int    `json:"total_prs"`
+	OpenPRs  int    `json:"open_prs"`
+}
+
+// SourceStats counts the reviewer assignments made from a source.
+type SourceStats struct {
+	Source      string `json:"source"`
+	Assignments int    `json:"assignments"`
+}
+
+type LabelStats struct {
+	Label    string `json:"label"`
+	TotalPRs int    `json:"total_prs"`
+	OpenPRs  int    `json:"open_prs"`
+}
+
+// TeamStats aggregates the members of a team and their open PRs and reviews.
+type TeamStats struct {
+	TeamName      string `json:"team_name"`
+	Members       int    `json:"members"`
+	ActiveMembers int    `json:"active_members"`
+	// OpenPRs counts open PRs authored by the members.
+	OpenPRs int `json:"open_prs"`
+	// ActiveReviews counts the members' reviewer assignments on open PRs.
+	ActiveReviews int `json:"active_reviews"`
+	// BalanceScore is 1 when the open reviews are spread evenly over the active members and 0 when
+	// one member has them all; it is null for teams with fewer than two active members.
+	BalanceScore *float64 `json:"balance_score"`
 }
 
+// AuthorStats counts the PRs a user authored. Username is null for an author who is not a known user.
+type AuthorStats struct {
+	AuthorID  string  `json:"author_id"`
+	Username  *string `json:"username"`
+	TotalPRs  int     `json:"total_prs"`
+	OpenPRs   int     `json:"open_prs"`
+	MergedPRs int     `json:"merged_prs"`
+	// AvgReviewers is the average number of reviewers assigned to the authored PRs.
+	AvgReviewers float64 `json:"avg_reviewers"`
+}
+
+// StatisticsResponse holds the aggregates, which are always computed, and the requested sections;
+// a section that was not requested is left out.
 type StatisticsResponse struct {
-	TotalPRs         int         `json:"total_prs"`
-	OpenPRs          int         `json:"open_prs"`
-	MergedPRs        int         `json:"merged_prs"`
-	TotalAssignments int         `json:"total_assignments"`
-	UserStats        []UserStats `json:"user_stats,omitempty"`
-	PRStats          []PRStats   `json:"pr_stats,omitempty"`
+	TotalPRs         int `json:"total_prs"`
+	OpenPRs          int `json:"open_prs"`
+	MergedPRs        int `json:"merged_prs"`
+	TotalAssignments int `json:"total_assignments"`
+	// ReassignmentEvents counts every recorded reviewer change, replacements and removals alike.
+	ReassignmentEvents int `json:"reassignment_events"`
+	// AssignmentSkippedPRs counts open PRs created without reviewers on purpose that wait to be
+	// assigned; they are not assignment failures.
+	AssignmentSkippedPRs int `json:"assignment_skipped_prs"`
+	// OpenPRsWithoutReviewers counts open PRs nobody is assigned to, skipped ones included;
+	// PRsWithoutReviewers lists the ids of the oldest of them.
+	OpenPRsWithoutReviewers int                  `json:"open_prs_without_reviewers"`
+	PRsWithoutReviewers     []string             `json:"prs_without_reviewers"`
+	ByPriority              []PriorityStats      `json:"by_priority"`
+	ByLabel                 []LabelStats         `json:"by_label"`
+	BySource                []SourceStats        `json:"by_source"`
+	UserStats               *dto.Page[UserStats] `json:"user_stats,omitempty"`
+	PRStats                 *dto.Page[PRStats]   `json:"pr_stats,omitempty"`
+	// TeamStats is nil when not requested and empty when there are no teams.
+	TeamStats []TeamStats `json:"team_stats,omitzero"`
+	// AuthorStats is nil when not requested and empty when no PRs were authored.
+	AuthorStats []AuthorStats `json:"author_stats,omitzero"`
+}
+
+// Names of the statistics sections accepted by the "include" query parameter.
+const (
+	SectionUserStats   = "user_stats"
+	SectionPRStats     = "pr_stats"
+	SectionTeamStats   = "team_stats"
+	SectionAuthorStats = "author_stats"
+)
+
+// Include selects the sections of the statistics to compute; the aggregates are always computed.
+type Include struct {
+	UserStats   bool
+	PRStats     bool
+	TeamStats   bool
+	AuthorStats bool
+}
+
+// DefaultInclude is used when the request doesn't select sections.
+var DefaultInclude = Include{UserStats: true, PRStats: true}
+
+// StatisticsRequest selects the sections of the statistics and the pages of the user and PR lists.
+// IncludeArchived adds archived PRs and their reviewers to everything computed from PRs. Unless
+// TeamName is empty, the statistics cover only the members of the team and the PRs they authored.
+type StatisticsRequest struct {
+	Include         Include
+	IncludeArchived bool
+	TeamName        string
+	Users           dto.PageRequest
+	PRs             dto.PageRequest
 }
@@ -0,0 +1,29 @@
+package statistics
+
+import "time"
+
+// TimeseriesRequest selects the bucket size and the range [From, To) of the activity time series.
+// Unless TeamName is empty, only the PRs authored by members of the team and their reviewer changes
+// are counted.
+type TimeseriesRequest struct {
+	Bucket   string
+	TeamName string
+	From     time.Time
+	To       time.Time
+}
+
+// TimeseriesBucket counts the PRs created and merged and the reviewer changes made within a bucket.
+type TimeseriesBucket struct {
+	Start         string `json:"start"`
+	Created       int    `json:"created"`
+	Merged        int    `json:"merged"`
+	Reassignments int    `json:"reassignments"`
+}
+
+// TimeseriesResponse lists every bucket of the range in order, empty buckets included.
+type TimeseriesResponse struct {
+	Bucket  string             `json:"bucket"`
+	From    string             `json:"from"`
+	To      string             `json:"to"`
+	Buckets []TimeseriesBucket `json:"buckets"`
+}
@@ -0,0 +1,24 @@
+package statistics
+
+// UserStatisticsRequest selects the user of the single-user statistics; IncludeArchived adds the
+// archived PRs to everything computed from PRs, as it does for the statistics.
+type UserStatisticsRequest struct {
+	UserID          string
+	IncludeArchived bool
+}
+
+// RecentAssignment is one of the latest reviewer assignments of a user.
+type RecentAssignment struct {
+	PullRequestID string `json:"pull_request_id"`
+	AssignedAt    string `json:"assigned_at"`
+	Source        string `json:"source"`
+	State         string `json:"state"`
+}
+
+// UserStatisticsResponse holds the statistics of one user, their entry of user_stats and author_stats
+// of the statistics, and their latest reviewer assignments, most recent first.
+type UserStatisticsResponse struct {
+	UserStats
+	Authored          AuthorStats        `json:"authored"`
+	RecentAssignments []RecentAssignment `json:"recent_assignments"`
+}
@@ -1,25 +1,49 @@
 package team
 
 // AddTeamRequest represents the request to create a team with members.
+// LeadID is optional and must be the user id of one of the members.
 type AddTeamRequest struct {
-	TeamName string       `json:"team_name" validate:"required"`
-	Members  []TeamMember `json:"members" validate:"required,min=1,dive"`
+	TeamName    string       `json:"team_name" validate:"required"`
+	Description string       `json:"description,omitempty" validate:"max=500"`
+	LeadID      string       `json:"lead_id,omitempty"`
+	Members     []TeamMember `json:"members" validate:"required,min=1,dive"`
 }
 
 // TeamMember represents a member of the team.
+// MaxActiveReviews overrides the configured review capacity of the member.
+// Tags are stored lowercase without duplicates.
+// Working hours are local "HH:MM" times in Timezone, 09:00-18:00 when only the timezone is set.
+// IsReviewer puts the member in the reviewer pool of the team, which is the default; it is always
+// set in responses.
 type TeamMember struct {
-	UserID   string `json:"user_id" validate:"required"`
-	Username string `json:"username" validate:"required"`
-	IsActive bool   `json:"is_active"`
+	UserID           string   `json:"user_id" validate:"required"`
+	Username         string   `json:"username" validate:"required"`
+	IsActive         bool     `json:"is_active"`
+	IsReviewer       *bool    `json:"is_reviewer,omitempty"`
+	MaxActiveReviews *int     `json:"max_active_reviews,omitempty" validate:"omitempty,min=1"`
+	Tags             []string `json:"tags,omitempty" validate:"max=20,dive,required,max=50"`
+	Timezone         string   `json:"timezone,omitempty" validate:"required_with=WorkHoursStart WorkHoursEnd,omitempty,timezone"`
+	WorkHoursStart   string   `json:"work_hours_start,omitempty" validate:"required_with=WorkHoursEnd,omitempty,datetime=15:04"`
+	WorkHoursEnd     string   `json:"work_hours_end,omitempty" validate:"required_with=WorkHoursStart,omitempty,datetime=15:04"`
 }
 
-// AddTeamResponse represents the response after creating a team.
+// InReviewerPool reports whether the member reviews PRs.
+func (m TeamMember) InReviewerPool() bool {
+	return m.IsReviewer == nil || *m.IsReviewer
+}
+
+// AddTeamResponse represents the response after creating a team. Warnings describe problems that
+// did not keep the team from being created, such as an empty reviewer pool.
 type AddTeamResponse struct {
-	Team Team `json:"team"`
+	Team     Team     `json:"team"`
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 // Team represents team data with members.
 type Team struct {
-	TeamName string       `json:"team_name"`
-	Members  []TeamMember `json:"members"`
+	TeamName    string       `json:"team_name"`
+	Description string       `json:"description,omitempty"`
+	LeadID      string       `json:"lead_id,omitempty"`
+	CreatedAt   string       `json:"created_at,omitempty"`
+	Members     []TeamMember `json:"members"`
 }
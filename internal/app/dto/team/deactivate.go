@@ -1,11 +1,41 @@
 package team
 
+// DeactivateTeamRequest represents a request to deactivate a team.
+// CloseAuthoredPRs also closes the open PRs authored by the team members.
 type DeactivateTeamRequest struct {
-	TeamName string `json:"team_name" validate:"required"`
+	TeamName         string `json:"team_name" validate:"required"`
+	CloseAuthoredPRs bool   `json:"close_authored_prs,omitempty"`
 }
 
+// MaxAffectedPRs limits the affected PRs listed in a deactivation response.
+const MaxAffectedPRs = 100
+
+// DeactivateTeamResponse represents the result of a team deactivation.
+// ReassignedPRs counts the reviewer assignments of the team taken off open PRs, Reassigned and
+// Removed those replaced and dropped without replacement, so it is their sum.
+// AffectedPRs lists the open PRs that had reviewers from the team up to MaxAffectedPRs, with
+// AffectedPRsTruncated set when there were more.
+// ClosedPRs lists the PRs of the team members closed on request; they are not counted as affected.
 type DeactivateTeamResponse struct {
-	DeactivatedUsers int      `json:"deactivated_users"`
-	ReassignedPRs    int      `json:"reassigned_prs"`
-	UserIDs          []string `json:"user_ids"`
+	DeactivatedUsers     int          `json:"deactivated_users"`
+	ReassignedPRs        int          `json:"reassigned_prs"`
+	Reassigned           int          `json:"reassigned"`
+	Removed              int          `json:"removed"`
+	UserIDs              []string     `json:"user_ids"`
+	AffectedPRs          []AffectedPR `json:"affected_prs"`
+	AffectedPRsTruncated bool         `json:"affected_prs_truncated"`
+	ClosedPRs            []string     `json:"closed_prs,omitempty"`
+}
+
+// AffectedPR represents an open PR that lost reviewers of the deactivated team.
+type AffectedPR struct {
+	PullRequestID string             `json:"pull_request_id"`
+	Reviewers     []ReplacedReviewer `json:"reviewers"`
+}
+
+// ReplacedReviewer represents a removed reviewer and the one assigned instead, which is nil
+// when nobody was left to take the review.
+type ReplacedReviewer struct {
+	OldReviewerID string  `json:"old_reviewer_id"`
+	NewReviewerID *string `json:"new_reviewer_id"`
 }
@@ -1,7 +1,13 @@
 package team
 
 // GetTeamResponse represents the response when getting a team.
+// CreatedAt is empty for teams created before it was recorded.
+// ETag identifies the team and changes whenever its metadata, a member or any of their fields does.
 type GetTeamResponse struct {
-	TeamName string       `json:"team_name"`
-	Members  []TeamMember `json:"members"`
+	TeamName    string       `json:"team_name"`
+	Description string       `json:"description,omitempty"`
+	LeadID      string       `json:"lead_id,omitempty"`
+	CreatedAt   string       `json:"created_at,omitempty"`
+	Members     []TeamMember `json:"members"`
+	ETag        string       `json:"-"`
 }
@@ -0,0 +1,67 @@
+package team
+
+// Results of importing one team.
+const (
+	ImportResultCreated       = "created"
+	ImportResultAlreadyExists = "already_exists"
+	ImportResultInvalid       = "invalid"
+	ImportResultFailed        = "failed"
+)
+
+// ImportTeamsRequest represents a batch of teams to create. Each team is created as AddTeam
+// would, independently of the others, so a rejected team doesn't fail the batch.
+type ImportTeamsRequest struct {
+	Teams []AddTeamRequest
+}
+
+// ImportTeamResult represents the outcome of importing one team. Lines are the lines of the CSV
+// rows of the team.
+type ImportTeamResult struct {
+	TeamName string `json:"team_name"`
+	Result   string `json:"result"`
+	Members  int    `json:"members"`
+	Lines    []int  `json:"lines,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ImportRowError reports a CSV row that was not imported.
+type ImportRowError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// ImportTeamsSummary counts the imported rows and the team results.
+type ImportTeamsSummary struct {
+	Rows          int `json:"rows"`
+	InvalidRows   int `json:"invalid_rows"`
+	Created       int `json:"created"`
+	AlreadyExists int `json:"already_exists"`
+	Invalid       int `json:"invalid"`
+	Failed        int `json:"failed"`
+}
+
+// ImportTeamsResponse represents the results of an import in the order the teams first appear,
+// with the rows that were not imported.
+type ImportTeamsResponse struct {
+	Results   []ImportTeamResult `json:"results"`
+	RowErrors []ImportRowError   `json:"row_errors"`
+	Summary   ImportTeamsSummary `json:"summary"`
+}
+
+// ImportTeamRequest represents the team fields of a streamed JSON import, which come before its
+// members in the body. The members are read and validated one at a time like those of /team/add.
+type ImportTeamRequest struct {
+	TeamName    string `json:"team_name" validate:"required"`
+	Description string `json:"description,omitempty" validate:"max=500"`
+	LeadID      string `json:"lead_id,omitempty"`
+}
+
+// ImportTeamResponse represents a team created by a streamed JSON import. Its members are counted
+// rather than listed, as the team may be too large to echo back.
+type ImportTeamResponse struct {
+	TeamName  string   `json:"team_name"`
+	CreatedAt string   `json:"created_at"`
+	Members   int      `json:"members"`
+	Reviewers int      `json:"reviewers"`
+	Warnings  []string `json:"warnings,omitempty"`
+}
@@ -0,0 +1,18 @@
+package team
+
+import "github.com/shirr9/pr-reviewer-service/internal/app/dto"
+
+// ListTeamsRequest represents a request for a page of teams ordered by name.
+type ListTeamsRequest struct {
+	Page dto.PageRequest
+}
+
+// TeamSummary represents a team in the team list, with the number of its members and active members.
+type TeamSummary struct {
+	TeamName      string `json:"team_name"`
+	Description   string `json:"description,omitempty"`
+	LeadID        string `json:"lead_id,omitempty"`
+	CreatedAt     string `json:"created_at,omitempty"`
+	Members       int    `json:"members"`
+	ActiveMembers int    `json:"active_members"`
+}
@@ -0,0 +1,41 @@
+package team
+
+import (
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/pullrequest"
+)
+
+// ReviewQueueRequest represents a request for the team review queue.
+// UnreviewedOnly keeps only PRs nobody has approved yet; Sort replaces the URGENT-first order.
+type ReviewQueueRequest struct {
+	TeamName        string
+	UnreviewedOnly  bool
+	ExpandReviewers bool
+	Sort            dto.Sort
+}
+
+// ReviewQueueResponse represents open PRs the team is responsible for reviewing, oldest first,
+// along with the current review load of the team members.
+type ReviewQueueResponse struct {
+	TeamName     string            `json:"team_name"`
+	PullRequests []ReviewQueueItem `json:"pull_requests"`
+	Reviewers    []ReviewerLoad    `json:"reviewers"`
+}
+
+// ReviewerLoad represents the open reviews of a team member against their capacity.
+// Capacity is omitted when the member has no limit.
+type ReviewerLoad struct {
+	UserID      string `json:"user_id"`
+	Username    string `json:"username"`
+	IsActive    bool   `json:"is_active"`
+	OpenReviews int    `json:"open_reviews"`
+	Capacity    int    `json:"capacity,omitempty"`
+	AtCapacity  bool   `json:"at_capacity"`
+}
+
+// ReviewQueueItem represents a PR in the team review queue.
+// AssignedReviewers contains only the reviewers from the team.
+type ReviewQueueItem struct {
+	pullrequest.PR
+	AgeSeconds int64 `json:"age_seconds"`
+}
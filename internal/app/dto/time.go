@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+// FormatTime formats a timestamp for responses as an RFC3339 string in UTC.
+// The zero time is formatted as an empty string.
+func FormatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// FormatTimePtr formats an optional timestamp, returning an empty string for nil.
+func FormatTimePtr(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return FormatTime(*t)
+}
@@ -1,15 +1,28 @@
 package user
 
+import "time"
+
 // GetReviewResponse represents the response with user's assigned PRs for review.
+// LastModified is when the response last changed, zero when the user never had an assignment.
 type GetReviewResponse struct {
-	UserID       string `json:"user_id"`
-	PullRequests []PR   `json:"pull_requests"`
+	UserID       string    `json:"user_id"`
+	PullRequests []PR      `json:"pull_requests"`
+	LastModified time.Time `json:"-"`
 }
 
-// PR represents short PR information.
+// PR represents short PR information with the user's assignment on it.
+// Overdue is set only for open PRs whose review is past Deadline.
 type PR struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
-	Status          string `json:"status"`
+	PullRequestID   string   `json:"pull_request_id"`
+	PullRequestName string   `json:"pull_request_name"`
+	AuthorID        string   `json:"author_id"`
+	Status          string   `json:"status"`
+	Priority        string   `json:"priority,omitempty"`
+	Labels          []string `json:"labels"`
+	AssignedAt      string   `json:"assigned_at,omitempty"`
+	Source          string   `json:"assignment_source,omitempty"`
+	Deadline        string   `json:"deadline,omitempty"`
+	Overdue         bool     `json:"overdue"`
+	ReviewState     string   `json:"review_state,omitempty"`
+	StateChangedAt  string   `json:"review_state_changed_at,omitempty"`
 }
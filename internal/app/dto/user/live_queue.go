@@ -0,0 +1,37 @@
+package user
+
+// Live queue message types.
+const (
+	// QueueSnapshot carries the user's open queue when the connection starts.
+	QueueSnapshot = "snapshot"
+	// QueueResync replaces the client's queue after it fell behind and missed events.
+	QueueResync = "resync"
+	// QueueAdded carries a PR that entered the queue.
+	QueueAdded = "added"
+	// QueueRemoved names a PR that left the queue.
+	QueueRemoved = "removed"
+	// QueueClosing is the last message, sent before the server closes the socket on shutdown.
+	QueueClosing = "closing"
+)
+
+// QueueSnapshotMessage carries the user's whole open queue, on connect and on resync.
+type QueueSnapshotMessage struct {
+	Type         string `json:"type"`
+	UserID       string `json:"user_id"`
+	PullRequests []PR   `json:"pull_requests"`
+}
+
+// QueueEventMessage carries a change of the user's open queue: the PR for added, its id for both.
+type QueueEventMessage struct {
+	Type          string `json:"type"`
+	UserID        string `json:"user_id"`
+	PullRequestID string `json:"pull_request_id"`
+	PullRequest   *PR    `json:"pull_request,omitempty"`
+}
+
+// QueueClosingMessage tells the client that the server closes the socket, so it should reconnect
+// and resync rather than treat the queue as final.
+type QueueClosingMessage struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
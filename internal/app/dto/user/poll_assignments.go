@@ -0,0 +1,59 @@
+package user
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// PollAssignmentsRequest represents the request for the user's assignments on open PRs made after
+// Since; the zero cursor asks for all of them.
+type PollAssignmentsRequest struct {
+	UserID string
+	Since  models.AssignmentCursor
+}
+
+// PollAssignmentsResponse represents the assignments found, in the order they were made. Cursor is
+// the position after the last of them to poll from next, or the requested one when none were
+// found. HasMore is set when more assignments are waiting than fit in the response.
+type PollAssignmentsResponse struct {
+	UserID       string `json:"user_id"`
+	Cursor       string `json:"cursor"`
+	PullRequests []PR   `json:"pull_requests"`
+	HasMore      bool   `json:"has_more"`
+}
+
+// errInvalidCursor is returned for a cursor that was not made by FormatAssignmentCursor.
+var errInvalidCursor = errors.New("since must be a cursor returned by an earlier poll")
+
+// FormatAssignmentCursor encodes the cursor for responses; the zero cursor is an empty string.
+func FormatAssignmentCursor(c models.AssignmentCursor) string {
+	if c == (models.AssignmentCursor{}) {
+		return ""
+	}
+	raw := c.AssignedAt.UTC().Format(time.RFC3339Nano) + "|" + c.PRId
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseAssignmentCursor decodes a cursor of FormatAssignmentCursor; an empty string is the zero cursor.
+func ParseAssignmentCursor(s string) (models.AssignmentCursor, error) {
+	if s == "" {
+		return models.AssignmentCursor{}, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return models.AssignmentCursor{}, errInvalidCursor
+	}
+	at, prID, ok := strings.Cut(string(raw), "|")
+	if !ok || prID == "" {
+		return models.AssignmentCursor{}, errInvalidCursor
+	}
+	assignedAt, err := time.Parse(time.RFC3339Nano, at)
+	if err != nil {
+		return models.AssignmentCursor{}, errInvalidCursor
+	}
+	return models.AssignmentCursor{AssignedAt: assignedAt, PRId: prID}, nil
+}
@@ -11,10 +11,12 @@ type SetIsActiveResponse struct {
 	User User `json:"user"`
 }
 
-// User represents user data
+// User represents user data. IsReviewer is set while the user is in the reviewer pool of the team.
 type User struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID     string   `json:"user_id"`
+	Username   string   `json:"username"`
+	TeamName   string   `json:"team_name"`
+	IsActive   bool     `json:"is_active"`
+	IsReviewer bool     `json:"is_reviewer"`
+	Tags       []string `json:"tags,omitempty"`
 }
@@ -0,0 +1,13 @@
+package user
+
+// SetIsReviewerRequest represents the request to add the user to the reviewer pool of their team
+// or take them out of it.
+type SetIsReviewerRequest struct {
+	UserID     string `json:"user_id" validate:"required"`
+	IsReviewer bool   `json:"is_reviewer"`
+}
+
+// SetIsReviewerResponse represents the response after setting whether the user reviews PRs.
+type SetIsReviewerResponse struct {
+	User User `json:"user"`
+}
@@ -0,0 +1,13 @@
+package user
+
+// SetTagsRequest represents the request to replace user's tags.
+// Tags are stored lowercase without duplicates; an empty list clears them.
+type SetTagsRequest struct {
+	UserID string   `json:"user_id" validate:"required"`
+	Tags   []string `json:"tags" validate:"max=20,dive,required,max=50"`
+}
+
+// SetTagsResponse represents the response after replacing user's tags.
+type SetTagsResponse struct {
+	User User `json:"user"`
+}
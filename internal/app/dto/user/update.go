@@ -0,0 +1,12 @@
+package user
+
+// UpdateUserRequest represents the request to change mutable fields of a user.
+type UpdateUserRequest struct {
+	UserID   string `json:"user_id" validate:"required"`
+	Username string `json:"username" validate:"required,max=255"`
+}
+
+// UpdateUserResponse represents the response with the updated user.
+type UpdateUserResponse struct {
+	User User `json:"user"`
+}
@@ -0,0 +1,188 @@
+// Package events carries changes of the reviewers' queues from the services to the live connections
+// watching them, within a single process.
+package events
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	userDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/user"
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+)
+
+// Event types.
+const (
+	// AssignmentAdded reports a PR that entered the reviewer's open queue.
+	AssignmentAdded = "added"
+	// AssignmentRemoved reports a PR that left the reviewer's open queue, by a reassignment or a merge.
+	AssignmentRemoved = "removed"
+)
+
+// DefaultBuffer is the number of events a subscriber may fall behind before it has to resync.
+const DefaultBuffer = 64
+
+// Event is a change of one reviewer's open queue.
+type Event struct {
+	Type string
+	// OrgID is the organization of the reviewer, the default one when empty
+	OrgID         string
+	UserID        string
+	PullRequestID string
+	// PullRequest is the queued PR, set for AssignmentAdded
+	PullRequest *userDto.PR
+}
+
+// Bus fans the published events out to the subscribers of their reviewers. Publishing never
+// blocks: a subscriber whose buffer is full misses the event and is told to resync instead.
+type Bus struct {
+	buffer int
+
+	mu     sync.Mutex
+	subs   map[subscriber]map[*Subscription]struct{}
+	closed bool
+	active sync.WaitGroup
+}
+
+// NewBus creates a bus buffering up to buffer events per subscriber, DefaultBuffer when not positive.
+func NewBus(buffer int) *Bus {
+	if buffer <= 0 {
+		buffer = DefaultBuffer
+	}
+	return &Bus{
+		buffer: buffer,
+		subs:   make(map[subscriber]map[*Subscription]struct{}),
+	}
+}
+
+// subscriber identifies a reviewer across organizations, whose user ids may collide.
+type subscriber struct {
+	orgID  string
+	userID string
+}
+
+// Subscription receives the events of one reviewer until it is closed.
+type Subscription struct {
+	bus    *Bus
+	key    subscriber
+	events chan Event
+	lagged chan struct{}
+	done   chan struct{}
+	once   sync.Once
+}
+
+// Subscribe starts receiving the events of the user of the organization of ctx. The subscription
+// of a closed bus is done right away.
+func (b *Bus) Subscribe(ctx context.Context, userID string) *Subscription {
+	sub := &Subscription{
+		bus:    b,
+		key:    subscriber{orgID: orgctx.ID(ctx), userID: userID},
+		events: make(chan Event, b.buffer),
+		lagged: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(sub.done)
+		// nothing to release
+		sub.once.Do(func() {})
+		return sub
+	}
+	b.active.Add(1)
+	if b.subs[sub.key] == nil {
+		b.subs[sub.key] = make(map[*Subscription]struct{})
+	}
+	b.subs[sub.key][sub] = struct{}{}
+	return sub
+}
+
+// Publish hands the events to the subscribers of their reviewers.
+func (b *Bus) Publish(events ...Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	for _, event := range events {
+		orgID := event.OrgID
+		if orgID == "" {
+			orgID = orgctx.DefaultID
+		}
+		for sub := range b.subs[subscriber{orgID: orgID, userID: event.UserID}] {
+			select {
+			case sub.events <- event:
+			default:
+				// the subscriber can't keep up; it rereads the queue instead of the missed events
+				select {
+				case sub.lagged <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}
+}
+
+// Close ends all subscriptions and waits until their holders have released them or ctx is done,
+// then reports how many were left open.
+func (b *Bus) Close(ctx context.Context) error {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		for _, subs := range b.subs {
+			for sub := range subs {
+				close(sub.done)
+			}
+		}
+	}
+	b.mu.Unlock()
+
+	released := make(chan struct{})
+	go func() {
+		defer close(released)
+		b.active.Wait()
+	}()
+	select {
+	case <-released:
+		return nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		open := 0
+		for _, subs := range b.subs {
+			open += len(subs)
+		}
+		b.mu.Unlock()
+		return fmt.Errorf("%d subscriptions not released: %w", open, ctx.Err())
+	}
+}
+
+// Events returns the channel of the reviewer's events.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Lagged returns a channel signalled when events were dropped because the subscriber fell behind.
+func (s *Subscription) Lagged() <-chan struct{} {
+	return s.lagged
+}
+
+// Done returns a channel closed when the bus is closing down.
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Unsubscribe stops receiving events and releases the subscription. It is safe to call repeatedly.
+func (s *Subscription) Unsubscribe() {
+	s.once.Do(func() {
+		s.bus.mu.Lock()
+		if subs := s.bus.subs[s.key]; subs != nil {
+			delete(subs, s)
+			if len(subs) == 0 {
+				delete(s.bus.subs, s.key)
+			}
+		}
+		s.bus.mu.Unlock()
+		s.bus.active.Done()
+	})
+}
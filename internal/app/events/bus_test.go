@@ -0,0 +1,107 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBus_Publish(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - Events reach the subscribers of their user", func(t *testing.T) {
+		bus := NewBus(4)
+		alice := bus.Subscribe(ctx, "u1")
+		again := bus.Subscribe(ctx, "u1")
+		bob := bus.Subscribe(ctx, "u2")
+
+		bus.Publish(Event{Type: AssignmentAdded, UserID: "u1", PullRequestID: "pr-1"})
+
+		assert.Equal(t, "pr-1", (<-alice.Events()).PullRequestID)
+		assert.Equal(t, "pr-1", (<-again.Events()).PullRequestID)
+		assert.Empty(t, bob.Events())
+	})
+
+	t.Run("Success - Events don't reach the same user id of another organization", func(t *testing.T) {
+		bus := NewBus(4)
+		payments := bus.Subscribe(orgctx.WithID(ctx, "payments"), "u1")
+		defaultOrg := bus.Subscribe(ctx, "u1")
+
+		bus.Publish(Event{Type: AssignmentAdded, OrgID: "payments", UserID: "u1", PullRequestID: "pr-1"})
+		bus.Publish(Event{Type: AssignmentAdded, UserID: "u1", PullRequestID: "pr-2"})
+
+		assert.Equal(t, "pr-1", (<-payments.Events()).PullRequestID)
+		assert.Equal(t, "pr-2", (<-defaultOrg.Events()).PullRequestID)
+		assert.Empty(t, payments.Events())
+		assert.Empty(t, defaultOrg.Events())
+	})
+
+	t.Run("Success - Full subscriber lags instead of blocking", func(t *testing.T) {
+		bus := NewBus(2)
+		sub := bus.Subscribe(ctx, "u1")
+
+		for i := 0; i < 5; i++ {
+			bus.Publish(Event{Type: AssignmentAdded, UserID: "u1"})
+		}
+
+		assert.Len(t, sub.Events(), 2)
+		select {
+		case <-sub.Lagged():
+		default:
+			t.Fatal("subscriber was not told it lagged")
+		}
+	})
+
+	t.Run("Success - Unsubscribed subscriber gets nothing", func(t *testing.T) {
+		bus := NewBus(2)
+		sub := bus.Subscribe(ctx, "u1")
+		sub.Unsubscribe()
+		sub.Unsubscribe()
+
+		bus.Publish(Event{Type: AssignmentRemoved, UserID: "u1"})
+
+		assert.Empty(t, sub.Events())
+	})
+}
+
+func TestBus_Close(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - Waits for the subscriptions to be released", func(t *testing.T) {
+		bus := NewBus(0)
+		sub := bus.Subscribe(ctx, "u1")
+		go func() {
+			<-sub.Done()
+			sub.Unsubscribe()
+		}()
+
+		assert.NoError(t, bus.Close(context.Background()))
+		assert.NoError(t, bus.Close(context.Background()))
+	})
+
+	t.Run("Error - Gives up on subscriptions that are held", func(t *testing.T) {
+		bus := NewBus(0)
+		bus.Subscribe(ctx, "u1")
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := bus.Close(ctx)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorContains(t, err, "1 subscriptions not released")
+	})
+
+	t.Run("Success - Subscription of a closed bus is done", func(t *testing.T) {
+		bus := NewBus(0)
+		assert.NoError(t, bus.Close(context.Background()))
+
+		sub := bus.Subscribe(ctx, "u1")
+
+		assert.NotNil(t, sub)
+		<-sub.Done()
+		sub.Unsubscribe()
+	})
+}
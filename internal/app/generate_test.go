@@ -0,0 +1,129 @@
+package app_test
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const mockgenDirective = "//go:generate go tool mockgen "
+
+// mockgenCall is a single go:generate mockgen directive found in a source file.
+type mockgenCall struct {
+	dir         string
+	args        []string
+	destination string
+}
+
+// TestMocksUpToDate runs every mockgen directive under internal/app into a temp dir
+// and fails if the output differs from the committed mocks, or if a committed mock
+// is not produced by any directive. Run `make generate` to fix it.
+func TestMocksUpToDate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping mock generation in short mode")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain is not available")
+	}
+
+	calls := findMockgenCalls(t, ".")
+	if len(calls) == 0 {
+		t.Fatal("no mockgen directives found")
+	}
+
+	generated := make(map[string]bool, len(calls))
+	for _, call := range calls {
+		committedPath := filepath.Join(call.dir, call.destination)
+		generated[filepath.Clean(committedPath)] = true
+
+		t.Run(committedPath, func(t *testing.T) {
+			tmpPath := filepath.Join(t.TempDir(), filepath.Base(call.destination))
+			args := append([]string{"tool", "mockgen"}, call.args...)
+			for i, arg := range args {
+				if arg == "-destination="+call.destination {
+					args[i] = "-destination=" + tmpPath
+				}
+			}
+			cmd := exec.Command("go", args...)
+			cmd.Dir = call.dir
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("mockgen failed: %v\n%s", err, out)
+			}
+
+			got, err := os.ReadFile(tmpPath)
+			if err != nil {
+				t.Fatalf("read generated mock: %v", err)
+			}
+			// the header records the command line, so put the real destination back
+			got = bytes.ReplaceAll(got, []byte(tmpPath), []byte(call.destination))
+
+			want, err := os.ReadFile(committedPath)
+			if err != nil {
+				t.Fatalf("read committed mock (run `make generate`): %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s is out of date, run `make generate`", committedPath)
+			}
+		})
+	}
+
+	mockFiles, err := filepath.Glob(filepath.Join("*", "mocks", "*.go"))
+	if err != nil {
+		t.Fatalf("list mocks: %v", err)
+	}
+	for _, path := range mockFiles {
+		if !generated[filepath.Clean(path)] {
+			t.Errorf("%s is not produced by any go:generate directive", path)
+		}
+	}
+}
+
+// findMockgenCalls collects the mockgen directives from non-test Go files below root.
+func findMockgenCalls(t *testing.T, root string) []mockgenCall {
+	t.Helper()
+
+	var calls []mockgenCall
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".go" || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if !strings.HasPrefix(line, mockgenDirective) {
+				continue
+			}
+			call := mockgenCall{dir: filepath.Dir(path)}
+			for _, arg := range strings.Fields(strings.TrimPrefix(line, mockgenDirective)) {
+				arg = strings.ReplaceAll(arg, "$GOFILE", filepath.Base(path))
+				if dest, ok := strings.CutPrefix(arg, "-destination="); ok {
+					call.destination = dest
+				}
+				call.args = append(call.args, arg)
+			}
+			if call.destination == "" {
+				t.Fatalf("%s: mockgen directive has no -destination", path)
+			}
+			calls = append(calls, call)
+		}
+		return scanner.Err()
+	})
+	if err != nil {
+		t.Fatalf("scan go:generate directives: %v", err)
+	}
+	return calls
+}
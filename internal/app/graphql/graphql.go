@@ -0,0 +1,184 @@
+// Package graphql serves a read-only GraphQL view of teams, users, pull requests and statistics,
+// resolved through the application services. Mutations stay in the REST API.
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+)
+
+// Request is the body of a GraphQL request.
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Response is the body of a GraphQL response. Each error carries the code of the REST API's
+// errors in its "code" extension.
+type Response struct {
+	Data   any                        `json:"data,omitempty"`
+	Errors []gqlerrors.FormattedError `json:"errors,omitempty"`
+}
+
+// Handler serves GraphQL queries.
+type Handler struct {
+	schema   graphql.Schema
+	resolver *resolver
+	limits   limits
+	logger   *slog.Logger
+}
+
+// NewHandler creates a new Handler; cfg bounds the depth and complexity of the queries it runs.
+func NewHandler(services Services, cfg config.GraphQL, logger *slog.Logger) (*Handler, error) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	r := &resolver{services: services, validate: handler.NewValidator()}
+	schema, err := newSchema(r)
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{
+		schema:   schema,
+		resolver: r,
+		limits:   limits{maxDepth: cfg.MaxDepth, maxComplexity: cfg.MaxComplexity},
+		logger:   logger,
+	}, nil
+}
+
+// ServeHTTP runs the query of the request. A query that doesn't parse, isn't a query, doesn't
+// validate against the schema or exceeds the limits is answered with 400 and only errors; otherwise the answer is
+// 200 with the data and the errors of the fields that failed, which resolve to null.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	op := "GraphQLHandler.ServeHTTP"
+	logger := h.logger.With(slog.String("op", op))
+
+	var req Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, domainErrors.NewValidation(err.Error()), logger)
+		return
+	}
+	if req.Query == "" {
+		h.respondError(w, domainErrors.NewValidation("query is required"), logger)
+		return
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+		Body: []byte(req.Query),
+		Name: "GraphQL request",
+	})})
+	if err != nil {
+		h.respond(w, http.StatusBadRequest, Response{Errors: queryErrors(gqlerrors.FormatError(err))}, logger)
+		return
+	}
+	if err := onlyQueries(doc); err != nil {
+		h.respond(w, http.StatusBadRequest, Response{Errors: queryErrors(gqlerrors.FormatError(err))}, logger)
+		return
+	}
+	if result := graphql.ValidateDocument(&h.schema, doc, nil); !result.IsValid {
+		h.respond(w, http.StatusBadRequest, Response{Errors: queryErrors(result.Errors...)}, logger)
+		return
+	}
+	if err := h.limits.check(&h.schema, doc, req.OperationName, req.Variables); err != nil {
+		h.respond(w, http.StatusBadRequest, Response{Errors: queryErrors(gqlerrors.FormatError(err))}, logger)
+		return
+	}
+
+	ctx := r.Context()
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       h.resolver.withLoaders(ctx),
+	})
+	status := http.StatusOK
+	for i := range result.Errors {
+		result.Errors[i] = h.fieldError(ctx, result.Errors[i], logger)
+		if result.Data == nil && originalError(result.Errors[i]) == nil {
+			// the variables didn't match the operation, so nothing was resolved
+			status = http.StatusBadRequest
+		}
+	}
+	h.respond(w, status, Response{Data: result.Data, Errors: result.Errors}, logger)
+}
+
+// fieldError sets the code of an execution error. Errors of the services keep their message and
+// code; other errors of the resolvers are logged and masked like in the REST API, while errors
+// of the executor itself describe the query and are validation errors.
+func (h *Handler) fieldError(ctx context.Context, gqlErr gqlerrors.FormattedError,
+	logger *slog.Logger) gqlerrors.FormattedError {
+	cause := originalError(gqlErr)
+	var appErr *domainErrors.AppError
+	switch {
+	case cause == nil:
+		gqlErr.Extensions = code(domainErrors.CodeValidation)
+	case errors.As(cause, &appErr):
+		gqlErr.Message = appErr.Message
+		gqlErr.Extensions = code(appErr.Code)
+	default:
+		level := slog.LevelError
+		if errors.Is(cause, context.Canceled) {
+			level = slog.LevelInfo
+		}
+		logger.LogAttrs(ctx, level, "failed to resolve field",
+			slog.Any("path", gqlErr.Path), slog.String("error", cause.Error()))
+		gqlErr.Message = "internal server error"
+		gqlErr.Extensions = code(handler.CodeInternalError)
+	}
+	return gqlErr
+}
+
+// originalError unwraps the error a resolver returned; nil means the executor raised the error.
+func originalError(err error) error {
+	for {
+		switch e := err.(type) {
+		case gqlerrors.FormattedError:
+			err = e.OriginalError()
+		case *gqlerrors.Error:
+			err = e.OriginalError
+		default:
+			return err
+		}
+		if err == nil {
+			return nil
+		}
+	}
+}
+
+// queryErrors marks errors found in the query before it ran as validation errors.
+func queryErrors(errs ...gqlerrors.FormattedError) []gqlerrors.FormattedError {
+	for i := range errs {
+		errs[i].Extensions = code(domainErrors.CodeValidation)
+	}
+	return errs
+}
+
+func code(c string) map[string]any {
+	return map[string]any{"code": c}
+}
+
+// respondError answers a request that isn't GraphQL with the error body of the REST API.
+func (h *Handler) respondError(w http.ResponseWriter, err error, logger *slog.Logger) {
+	if respErr := handler.RespondWithError(w, err); respErr != nil {
+		logger.Error("failed to send error response", slog.String("error", respErr.Error()))
+	}
+}
+
+func (h *Handler) respond(w http.ResponseWriter, status int, resp Response, logger *slog.Logger) {
+	if err := handler.RespondJSON(w, status, resp); err != nil {
+		logger.Error("failed to send response", slog.String("error", err.Error()))
+	}
+}
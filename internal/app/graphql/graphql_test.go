@@ -0,0 +1,269 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	prDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/pullrequest"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/statistics"
+	teamDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/team"
+	userDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/user"
+	"github.com/shirr9/pr-reviewer-service/internal/app/graphql/mocks"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testEnv struct {
+	teams   *mocks.MockTeamService
+	users   *mocks.MockUserService
+	prs     *mocks.MockPullRequestService
+	stats   *mocks.MockStatisticsService
+	handler *Handler
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	ctrl := gomock.NewController(t)
+	env := &testEnv{
+		teams: mocks.NewMockTeamService(ctrl),
+		users: mocks.NewMockUserService(ctrl),
+		prs:   mocks.NewMockPullRequestService(ctrl),
+		stats: mocks.NewMockStatisticsService(ctrl),
+	}
+	h, err := NewHandler(Services{Teams: env.teams, Users: env.users, PullRequests: env.prs, Statistics: env.stats},
+		config.GraphQL{MaxDepth: 8, MaxComplexity: 5000}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	env.handler = h
+	return env
+}
+
+// testResponse is Response with the data left to decode.
+type testResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func (e *testEnv) query(t *testing.T, req Request) (int, testResponse) {
+	t.Helper()
+	body, err := json.Marshal(req)
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	e.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body)))
+	var resp testResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return rec.Code, resp
+}
+
+func TestHandler_Queries(t *testing.T) {
+	t.Run("Success - Team with members, lead and open reviews", func(t *testing.T) {
+		env := newTestEnv(t)
+		env.teams.EXPECT().GetTeam(gomock.Any(), "backend").Return(&teamDto.GetTeamResponse{
+			TeamName: "backend",
+			LeadID:   "u1",
+			Members: []teamDto.TeamMember{
+				{UserID: "u1", Username: "Alice", IsActive: true},
+				{UserID: "u2", Username: "Bob", IsActive: false, Tags: []string{"go"}},
+			},
+		}, nil)
+		env.users.EXPECT().GetUsers(gomock.Any(), []string{"u1"}).
+			Return([]userDto.User{{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}}, nil)
+		env.users.EXPECT().GetOpenReviews(gomock.Any(), []string{"u1", "u2"}).Return(map[string][]userDto.PR{
+			"u2": {{PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u1", Status: models.PRStatusOpen}},
+		}, nil)
+
+		status, resp := env.query(t, Request{Query: `{
+			team(name: "backend") {
+				name description lead { username }
+				members { id isActive tags openReviews { id status } }
+			}
+		}`})
+
+		assert.Equal(t, http.StatusOK, status)
+		assert.Empty(t, resp.Errors)
+		assert.JSONEq(t, `{"team": {
+			"name": "backend", "description": null, "lead": {"username": "Alice"},
+			"members": [
+				{"id": "u1", "isActive": true, "tags": [], "openReviews": []},
+				{"id": "u2", "isActive": false, "tags": ["go"], "openReviews": [{"id": "pr-1", "status": "OPEN"}]}
+			]
+		}}`, string(resp.Data))
+	})
+
+	t.Run("Success - Reviewers of a page of PRs are looked up at once", func(t *testing.T) {
+		env := newTestEnv(t)
+		prs := make([]prDto.PR, 0, 10)
+		for _, id := range []string{"pr-1", "pr-2", "pr-3", "pr-4", "pr-5"} {
+			prs = append(prs, prDto.PR{PullRequestID: id, AuthorID: "u1", Status: models.PRStatusOpen,
+				Reviewers: []prDto.Reviewer{{UserID: "u2"}, {UserID: "u3"}}})
+		}
+		env.prs.EXPECT().SearchPRs(gomock.Any(), prDto.SearchPrRequest{
+			Query: "search", Status: models.PRStatusOpen, Labels: []string{"backend"},
+			Page: dto.PageRequest{Limit: 5}, ExpandReviewers: true,
+		}).Return(&dto.Page[prDto.PR]{Items: prs, Total: 7, Limit: 5}, nil)
+		env.users.EXPECT().GetUsers(gomock.Any(), []string{"u1", "u2", "u3"}).Return([]userDto.User{
+			{UserID: "u1", Username: "Alice"}, {UserID: "u2", Username: "Bob"}, {UserID: "u3", Username: "Carol"},
+		}, nil).Times(1)
+
+		status, resp := env.query(t, Request{
+			Query: `query Search($limit: Int) {
+				pullRequests(query: "search", status: OPEN, labels: ["backend"], limit: $limit) {
+					total
+					items { id author { username } reviewers { user { username } } }
+				}
+			}`,
+			Variables: map[string]any{"limit": 5},
+		})
+
+		assert.Equal(t, http.StatusOK, status)
+		assert.Empty(t, resp.Errors)
+		var data struct {
+			PullRequests struct {
+				Total int
+				Items []struct {
+					Author    struct{ Username string }
+					Reviewers []struct{ User struct{ Username string } }
+				}
+			}
+		}
+		require.NoError(t, json.Unmarshal(resp.Data, &data))
+		assert.Equal(t, 7, data.PullRequests.Total)
+		assert.Len(t, data.PullRequests.Items, 5)
+		assert.Equal(t, "Alice", data.PullRequests.Items[4].Author.Username)
+		assert.Equal(t, "Carol", data.PullRequests.Items[4].Reviewers[1].User.Username)
+	})
+
+	t.Run("Success - Statistics compute the team section only when selected", func(t *testing.T) {
+		env := newTestEnv(t)
+		env.stats.EXPECT().GetStatistics(gomock.Any(), statistics.StatisticsRequest{
+			Include: statistics.Include{TeamStats: true},
+		}).Return(&statistics.StatisticsResponse{
+			TotalPRs:  3,
+			TeamStats: []statistics.TeamStats{{TeamName: "backend", Members: 2}},
+		}, nil)
+
+		status, resp := env.query(t, Request{Query: `{ statistics { totalPRs ...teams } }
+			fragment teams on Statistics { teams { teamName members } }`})
+
+		assert.Equal(t, http.StatusOK, status)
+		assert.JSONEq(t, `{"statistics": {"totalPRs": 3, "teams": [{"teamName": "backend", "members": 2}]}}`,
+			string(resp.Data))
+	})
+
+	t.Run("Success - Unknown team and user are null", func(t *testing.T) {
+		env := newTestEnv(t)
+		env.teams.EXPECT().GetTeam(gomock.Any(), "missing").Return(nil, domainErrors.NewNotFound("team not found"))
+		env.users.EXPECT().GetUsers(gomock.Any(), []string{"u9"}).Return([]userDto.User{}, nil)
+
+		status, resp := env.query(t, Request{Query: `{ team(name: "missing") { name } user(id: "u9") { id } }`})
+
+		assert.Equal(t, http.StatusOK, status)
+		assert.Empty(t, resp.Errors)
+		assert.JSONEq(t, `{"team": null, "user": null}`, string(resp.Data))
+	})
+
+	t.Run("Error - Failed field is null with the error code", func(t *testing.T) {
+		env := newTestEnv(t)
+		env.teams.EXPECT().ListTeams(gomock.Any(), teamDto.ListTeamsRequest{Page: dto.PageRequest{Limit: 20}}).
+			Return(&dto.Page[teamDto.TeamSummary]{Items: []teamDto.TeamSummary{{TeamName: "backend", LeadID: "u1"}}}, nil)
+		env.users.EXPECT().GetUsers(gomock.Any(), []string{"u1"}).Return(nil, assert.AnError)
+
+		status, resp := env.query(t, Request{Query: `{ teams { items { name lead { id } } } }`})
+
+		assert.Equal(t, http.StatusOK, status)
+		assert.JSONEq(t, `{"teams": {"items": [{"name": "backend", "lead": null}]}}`, string(resp.Data))
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "internal server error", resp.Errors[0].Message)
+		assert.Equal(t, "INTERNAL_ERROR", resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("Error - Service errors keep their code", func(t *testing.T) {
+		env := newTestEnv(t)
+
+		status, resp := env.query(t, Request{Query: `{ teams(offset: -1) { total } }`})
+
+		assert.Equal(t, http.StatusOK, status)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "offset must be a non-negative integer", resp.Errors[0].Message)
+		assert.Equal(t, domainErrors.CodeValidation, resp.Errors[0].Extensions["code"])
+	})
+}
+
+func TestHandler_RejectedQueries(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     Request
+		message string
+	}{
+		{
+			name:    "Error - Mutations stay in the REST API",
+			req:     Request{Query: `mutation { createTeam(name: "x") }`},
+			message: "only queries are supported, use the REST API for mutations",
+		},
+		{
+			name:    "Error - Syntax error",
+			req:     Request{Query: `{ team(name: "backend") {`},
+			message: "Syntax Error GraphQL request (1:26) Expected Name, found EOF\n\n1: { team(name: \"backend\") {\n                            ^\n",
+		},
+		{
+			name:    "Error - Unknown field",
+			req:     Request{Query: `{ team(name: "backend") { secret } }`},
+			message: `Cannot query field "secret" on type "Team".`,
+		},
+		{
+			name:    "Error - Too deep",
+			req:     Request{Query: `{ user(id: "u1") { openReviews { author { openReviews { author { openReviews { author { openReviews { id } } } } } } } } }`},
+			message: "query depth 9 exceeds the limit of 8",
+		},
+		{
+			name:    "Error - Too complex",
+			req:     Request{Query: `{ pullRequests(query: "a", limit: 100) { items { reviewers { user { openReviews { id } } } } } }`},
+			message: "query complexity 12201 exceeds the limit of 5000",
+		},
+		{
+			name:    "Error - Page too large",
+			req:     Request{Query: `query($limit: Int) { teams(limit: $limit) { total } }`, Variables: map[string]any{"limit": 500}},
+			message: "limit must be an integer between 1 and 100",
+		},
+		{
+			name:    "Error - Variable of the wrong type",
+			req:     Request{Query: `query($limit: Int) { teams(limit: $limit) { total } }`, Variables: map[string]any{"limit": "many"}},
+			message: `Variable "$limit" got invalid value "many".` + "\nExpected type \"Int\", found \"many\".",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+
+			status, resp := env.query(t, tt.req)
+
+			assert.Equal(t, http.StatusBadRequest, status)
+			assert.Empty(t, resp.Data)
+			require.Len(t, resp.Errors, 1)
+			assert.Equal(t, tt.message, resp.Errors[0].Message)
+			assert.Equal(t, domainErrors.CodeValidation, resp.Errors[0].Extensions["code"])
+		})
+	}
+
+	t.Run("Error - Body that isn't JSON", func(t *testing.T) {
+		env := newTestEnv(t)
+		rec := httptest.NewRecorder()
+
+		env.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewBufferString("{")))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), domainErrors.CodeValidation)
+	})
+}
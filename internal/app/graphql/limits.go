@@ -0,0 +1,206 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+const (
+	// defaultPageLimit is the page size of a paged field without a limit argument.
+	defaultPageLimit = 20
+	// maxPageLimit is the largest limit a paged field accepts.
+	maxPageLimit = 100
+	// listEstimate is the number of items assumed for a list that is not paged, such as the members of a team.
+	listEstimate = 10
+)
+
+// limits bounds the size of a query before it is executed.
+type limits struct {
+	maxDepth      int
+	maxComplexity int
+}
+
+// onlyQueries rejects documents with mutations or subscriptions, which the graph doesn't serve.
+func onlyQueries(doc *ast.Document) error {
+	for _, def := range doc.Definitions {
+		if op, ok := def.(*ast.OperationDefinition); ok && op.Operation != ast.OperationTypeQuery {
+			return fmt.Errorf("only queries are supported, use the REST API for %ss", op.Operation)
+		}
+	}
+	return nil
+}
+
+// check rejects queries of a validated document nested deeper than maxDepth or costing more than
+// maxComplexity. A field costs 1 plus the cost of its selection, times the limit
+// of a paged field or listEstimate for a list that is not paged. Introspection fields are bounded
+// by the schema and not counted.
+func (l limits) check(schema *graphql.Schema, doc *ast.Document, operationName string, variables map[string]any) error {
+	fragments := make(map[string]*ast.FragmentDefinition)
+	var operations []*ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.FragmentDefinition:
+			fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			if operationName == "" || (def.Name != nil && def.Name.Value == operationName) {
+				operations = append(operations, def)
+			}
+		}
+	}
+
+	a := analysis{fragments: fragments, variables: variables}
+	for _, op := range operations {
+		cost, depth, err := a.selectionSet(schema.QueryType(), op.SelectionSet, false)
+		if err != nil {
+			return err
+		}
+		if depth > l.maxDepth {
+			return fmt.Errorf("query depth %d exceeds the limit of %d", depth, l.maxDepth)
+		}
+		if cost > l.maxComplexity {
+			return fmt.Errorf("query complexity %d exceeds the limit of %d", cost, l.maxComplexity)
+		}
+	}
+	return nil
+}
+
+// analysis computes the cost and depth of selections, resolving fragment spreads.
+type analysis struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]any
+}
+
+// selectionSet returns the cost and depth of the selections on the parent type. paged reports that
+// the parent already multiplied the cost by its limit, so the list of its items is not counted again.
+func (a analysis) selectionSet(parent *graphql.Object, set *ast.SelectionSet, paged bool) (int, int, error) {
+	if set == nil || parent == nil {
+		return 0, 0, nil
+	}
+	cost, depth := 0, 0
+	for _, selection := range set.Selections {
+		var c, d int
+		var err error
+		switch selection := selection.(type) {
+		case *ast.Field:
+			c, d, err = a.field(parent, selection, paged)
+		case *ast.InlineFragment:
+			c, d, err = a.selectionSet(parent, selection.SelectionSet, paged)
+		case *ast.FragmentSpread:
+			// validation has checked that the fragment exists and doesn't spread itself
+			if fragment, ok := a.fragments[selection.Name.Value]; ok {
+				c, d, err = a.selectionSet(parent, fragment.SelectionSet, paged)
+			}
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+		cost += c
+		depth = max(depth, d)
+	}
+	return cost, depth, nil
+}
+
+func (a analysis) field(parent *graphql.Object, field *ast.Field, paged bool) (int, int, error) {
+	name := field.Name.Value
+	if strings.HasPrefix(name, "__") {
+		return 0, 0, nil
+	}
+	def, ok := parent.Fields()[name]
+	if !ok {
+		return 0, 0, nil
+	}
+
+	multiplier := 1
+	if limit, ok, err := a.limit(field); err != nil {
+		return 0, 0, err
+	} else if ok {
+		multiplier = limit
+	} else if isPaged(def) {
+		multiplier = defaultPageLimit
+	} else if isList(def.Type) && !paged {
+		multiplier = listEstimate
+	}
+
+	cost, depth, err := a.selectionSet(objectOf(def.Type), field.SelectionSet, isPaged(def))
+	if err != nil {
+		return 0, 0, err
+	}
+	return 1 + multiplier*cost, depth + 1, nil
+}
+
+// limit returns the value of the "limit" argument of the field, reading variables.
+func (a analysis) limit(field *ast.Field) (int, bool, error) {
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "limit" {
+			continue
+		}
+		var value any
+		switch v := arg.Value.(type) {
+		case *ast.IntValue:
+			value = v.Value
+		case *ast.Variable:
+			value = a.variables[v.Name.Value]
+		}
+		limit, ok := toInt(value)
+		if !ok {
+			return 0, false, nil
+		}
+		if limit < 1 || limit > maxPageLimit {
+			return 0, false, fmt.Errorf("limit must be an integer between 1 and %d", maxPageLimit)
+		}
+		return limit, true, nil
+	}
+	return 0, false, nil
+}
+
+func toInt(value any) (int, bool) {
+	switch v := value.(type) {
+	case string:
+		n, err := strconv.Atoi(v)
+		return n, err == nil
+	case int:
+		return v, true
+	case float64:
+		return int(v), v == float64(int(v))
+	default:
+		return 0, false
+	}
+}
+
+// isPaged reports whether the field returns a page, which it sizes with its "limit" argument.
+func isPaged(def *graphql.FieldDefinition) bool {
+	for _, arg := range def.Args {
+		if arg.Name() == "limit" {
+			return true
+		}
+	}
+	return false
+}
+
+func isList(t graphql.Type) bool {
+	if nonNull, ok := t.(*graphql.NonNull); ok {
+		t = nonNull.OfType
+	}
+	_, ok := t.(*graphql.List)
+	return ok
+}
+
+// objectOf returns the object type a field resolves to, or nil for scalars and enums.
+func objectOf(t graphql.Type) *graphql.Object {
+	for {
+		switch wrapper := t.(type) {
+		case *graphql.NonNull:
+			t = wrapper.OfType
+		case *graphql.List:
+			t = wrapper.OfType
+		case *graphql.Object:
+			return wrapper
+		default:
+			return nil
+		}
+	}
+}
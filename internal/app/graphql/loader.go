@@ -0,0 +1,75 @@
+package graphql
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// loader batches the lookups of one request. Keys asked for while a level of the query is resolved
+// are fetched together when the first of their values is needed, and every key is fetched once, so
+// a list of PRs costs one lookup of all its reviewers instead of one per PR.
+type loader[V any] struct {
+	fetch func(ctx context.Context, keys []string) (map[string]V, error)
+
+	mu      sync.Mutex
+	pending []string
+	queued  map[string]bool
+	fetched map[string]bool
+	values  map[string]V
+	errs    map[string]error
+}
+
+func newLoader[V any](fetch func(ctx context.Context, keys []string) (map[string]V, error)) *loader[V] {
+	return &loader[V]{
+		fetch:   fetch,
+		queued:  make(map[string]bool),
+		fetched: make(map[string]bool),
+		values:  make(map[string]V),
+		errs:    make(map[string]error),
+	}
+}
+
+// load queues the key and returns a thunk yielding its value, which the executor calls after the
+// other fields of the level have queued their keys. A key the fetch didn't return yields ok false.
+func (l *loader[V]) load(ctx context.Context, key string) func() (V, bool, error) {
+	l.mu.Lock()
+	if !l.queued[key] {
+		l.queued[key] = true
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, bool, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if !l.fetched[key] {
+			l.flush(ctx)
+		}
+		if err := l.errs[key]; err != nil {
+			var zero V
+			return zero, false, err
+		}
+		value, ok := l.values[key]
+		return value, ok, nil
+	}
+}
+
+// flush fetches all pending keys at once, in order, as fields of a level are resolved in no fixed
+// order; a failed fetch fails each of them.
+func (l *loader[V]) flush(ctx context.Context) {
+	keys := l.pending
+	l.pending = nil
+	slices.Sort(keys)
+	values, err := l.fetch(ctx, keys)
+	for _, key := range keys {
+		l.fetched[key] = true
+		if err != nil {
+			l.errs[key] = err
+			continue
+		}
+		if value, ok := values[key]; ok {
+			l.values[key] = value
+		}
+	}
+}
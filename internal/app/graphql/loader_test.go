@@ -0,0 +1,49 @@
+package graphql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoader_Load(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - Keys queued together are fetched once", func(t *testing.T) {
+		var batches [][]string
+		l := newLoader(func(_ context.Context, keys []string) (map[string]int, error) {
+			batches = append(batches, keys)
+			return map[string]int{"a": 1, "b": 2}, nil
+		})
+
+		a, b, again, missing := l.load(ctx, "a"), l.load(ctx, "b"), l.load(ctx, "a"), l.load(ctx, "c")
+
+		value, ok, err := a()
+		assert.Equal(t, 1, value)
+		assert.True(t, ok)
+		assert.NoError(t, err)
+		value, _, _ = b()
+		assert.Equal(t, 2, value)
+		value, _, _ = again()
+		assert.Equal(t, 1, value)
+		_, ok, err = missing()
+		assert.False(t, ok)
+		assert.NoError(t, err)
+		_, _, _ = l.load(ctx, "b")()
+		assert.Equal(t, [][]string{{"a", "b", "c"}}, batches)
+	})
+
+	t.Run("Error - Failed fetch fails each key of the batch", func(t *testing.T) {
+		l := newLoader(func(context.Context, []string) (map[string]int, error) {
+			return nil, assert.AnError
+		})
+
+		a, b := l.load(ctx, "a"), l.load(ctx, "b")
+
+		_, _, err := a()
+		assert.ErrorIs(t, err, assert.AnError)
+		_, _, err = b()
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
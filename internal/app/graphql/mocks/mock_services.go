@@ -0,0 +1,223 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services.go
+//
+// Generated by this command:
+//
+//	mockgen -source=services.go -destination=mocks/mock_services.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	pullrequest "github.com/shirr9/pr-reviewer-service/internal/app/dto/pullrequest"
+	statistics "github.com/shirr9/pr-reviewer-service/internal/app/dto/statistics"
+	team "github.com/shirr9/pr-reviewer-service/internal/app/dto/team"
+	user "github.com/shirr9/pr-reviewer-service/internal/app/dto/user"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTeamService is a mock of TeamService interface.
+type MockTeamService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTeamServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockTeamServiceMockRecorder is the mock recorder for MockTeamService.
+type MockTeamServiceMockRecorder struct {
+	mock *MockTeamService
+}
+
+// NewMockTeamService creates a new mock instance.
+func NewMockTeamService(ctrl *gomock.Controller) *MockTeamService {
+	mock := &MockTeamService{ctrl: ctrl}
+	mock.recorder = &MockTeamServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTeamService) EXPECT() *MockTeamServiceMockRecorder {
+	return m.recorder
+}
+
+// GetReviewQueue mocks base method.
+func (m *MockTeamService) GetReviewQueue(ctx context.Context, req team.ReviewQueueRequest) (*team.ReviewQueueResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewQueue", ctx, req)
+	ret0, _ := ret[0].(*team.ReviewQueueResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewQueue indicates an expected call of GetReviewQueue.
+func (mr *MockTeamServiceMockRecorder) GetReviewQueue(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewQueue", reflect.TypeOf((*MockTeamService)(nil).GetReviewQueue), ctx, req)
+}
+
+// GetTeam mocks base method.
+func (m *MockTeamService) GetTeam(ctx context.Context, teamName string) (*team.GetTeamResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeam", ctx, teamName)
+	ret0, _ := ret[0].(*team.GetTeamResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeam indicates an expected call of GetTeam.
+func (mr *MockTeamServiceMockRecorder) GetTeam(ctx, teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*MockTeamService)(nil).GetTeam), ctx, teamName)
+}
+
+// ListTeams mocks base method.
+func (m *MockTeamService) ListTeams(ctx context.Context, req team.ListTeamsRequest) (*dto.Page[team.TeamSummary], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTeams", ctx, req)
+	ret0, _ := ret[0].(*dto.Page[team.TeamSummary])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTeams indicates an expected call of ListTeams.
+func (mr *MockTeamServiceMockRecorder) ListTeams(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTeams", reflect.TypeOf((*MockTeamService)(nil).ListTeams), ctx, req)
+}
+
+// MockUserService is a mock of UserService interface.
+type MockUserService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockUserServiceMockRecorder is the mock recorder for MockUserService.
+type MockUserServiceMockRecorder struct {
+	mock *MockUserService
+}
+
+// NewMockUserService creates a new mock instance.
+func NewMockUserService(ctrl *gomock.Controller) *MockUserService {
+	mock := &MockUserService{ctrl: ctrl}
+	mock.recorder = &MockUserServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
+	return m.recorder
+}
+
+// GetOpenReviews mocks base method.
+func (m *MockUserService) GetOpenReviews(ctx context.Context, userIDs []string) (map[string][]user.PR, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenReviews", ctx, userIDs)
+	ret0, _ := ret[0].(map[string][]user.PR)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenReviews indicates an expected call of GetOpenReviews.
+func (mr *MockUserServiceMockRecorder) GetOpenReviews(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenReviews", reflect.TypeOf((*MockUserService)(nil).GetOpenReviews), ctx, userIDs)
+}
+
+// GetUsers mocks base method.
+func (m *MockUserService) GetUsers(ctx context.Context, userIDs []string) ([]user.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", ctx, userIDs)
+	ret0, _ := ret[0].([]user.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockUserServiceMockRecorder) GetUsers(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockUserService)(nil).GetUsers), ctx, userIDs)
+}
+
+// MockPullRequestService is a mock of PullRequestService interface.
+type MockPullRequestService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPullRequestServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPullRequestServiceMockRecorder is the mock recorder for MockPullRequestService.
+type MockPullRequestServiceMockRecorder struct {
+	mock *MockPullRequestService
+}
+
+// NewMockPullRequestService creates a new mock instance.
+func NewMockPullRequestService(ctrl *gomock.Controller) *MockPullRequestService {
+	mock := &MockPullRequestService{ctrl: ctrl}
+	mock.recorder = &MockPullRequestServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPullRequestService) EXPECT() *MockPullRequestServiceMockRecorder {
+	return m.recorder
+}
+
+// SearchPRs mocks base method.
+func (m *MockPullRequestService) SearchPRs(ctx context.Context, req pullrequest.SearchPrRequest) (*dto.Page[pullrequest.PR], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchPRs", ctx, req)
+	ret0, _ := ret[0].(*dto.Page[pullrequest.PR])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchPRs indicates an expected call of SearchPRs.
+func (mr *MockPullRequestServiceMockRecorder) SearchPRs(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchPRs", reflect.TypeOf((*MockPullRequestService)(nil).SearchPRs), ctx, req)
+}
+
+// MockStatisticsService is a mock of StatisticsService interface.
+type MockStatisticsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatisticsServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockStatisticsServiceMockRecorder is the mock recorder for MockStatisticsService.
+type MockStatisticsServiceMockRecorder struct {
+	mock *MockStatisticsService
+}
+
+// NewMockStatisticsService creates a new mock instance.
+func NewMockStatisticsService(ctrl *gomock.Controller) *MockStatisticsService {
+	mock := &MockStatisticsService{ctrl: ctrl}
+	mock.recorder = &MockStatisticsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatisticsService) EXPECT() *MockStatisticsServiceMockRecorder {
+	return m.recorder
+}
+
+// GetStatistics mocks base method.
+func (m *MockStatisticsService) GetStatistics(ctx context.Context, req statistics.StatisticsRequest) (*statistics.StatisticsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatistics", ctx, req)
+	ret0, _ := ret[0].(*statistics.StatisticsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatistics indicates an expected call of GetStatistics.
+func (mr *MockStatisticsServiceMockRecorder) GetStatistics(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatistics", reflect.TypeOf((*MockStatisticsService)(nil).GetStatistics), ctx, req)
+}
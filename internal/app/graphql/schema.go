@@ -0,0 +1,503 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	prDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/pullrequest"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/statistics"
+	teamDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/team"
+	userDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/user"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// resolver resolves the fields of the graph through the services. Users and open reviews are
+// looked up through the loaders of the request, so a list of PRs asks for its authors and
+// reviewers at once.
+type resolver struct {
+	services Services
+	validate *validator.Validate
+}
+
+type loadersKey struct{}
+
+// loaders are the batching loaders of one request.
+type loaders struct {
+	users   *loader[userDto.User]
+	reviews *loader[[]userDto.PR]
+}
+
+// withLoaders returns the context of a request with fresh loaders.
+func (r *resolver) withLoaders(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loadersKey{}, &loaders{
+		users: newLoader(func(ctx context.Context, ids []string) (map[string]userDto.User, error) {
+			users, err := r.services.Users.GetUsers(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			byID := make(map[string]userDto.User, len(users))
+			for _, u := range users {
+				byID[u.UserID] = u
+			}
+			return byID, nil
+		}),
+		reviews: newLoader(r.services.Users.GetOpenReviews),
+	})
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// user resolves to the user with the id, or null for an empty or unknown id.
+func (r *resolver) user(ctx context.Context, userID string) (any, error) {
+	if userID == "" {
+		return nil, nil
+	}
+	thunk := loadersFrom(ctx).users.load(ctx, userID)
+	return func() (any, error) {
+		u, ok, err := thunk()
+		if err != nil || !ok {
+			return nil, err
+		}
+		return u, nil
+	}, nil
+}
+
+func (r *resolver) openReviews(ctx context.Context, userID string) (any, error) {
+	thunk := loadersFrom(ctx).reviews.load(ctx, userID)
+	return func() (any, error) {
+		prs, _, err := thunk()
+		if err != nil {
+			return nil, err
+		}
+		if prs == nil {
+			prs = []userDto.PR{}
+		}
+		return prs, nil
+	}, nil
+}
+
+func (r *resolver) team(p graphql.ResolveParams) (any, error) {
+	t, err := r.services.Teams.GetTeam(p.Context, p.Args["name"].(string))
+	if domainErrors.HasCode(err, domainErrors.CodeNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return *t, nil
+}
+
+func (r *resolver) teams(p graphql.ResolveParams) (any, error) {
+	page, err := pageArgs(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	return r.services.Teams.ListTeams(p.Context, teamDto.ListTeamsRequest{Page: page})
+}
+
+func (r *resolver) reviewQueue(p graphql.ResolveParams) (any, error) {
+	queue, err := r.services.Teams.GetReviewQueue(p.Context, teamDto.ReviewQueueRequest{
+		TeamName:        p.Source.(teamDto.GetTeamResponse).TeamName,
+		UnreviewedOnly:  p.Args["unreviewedOnly"].(bool),
+		ExpandReviewers: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	prs := make([]prDto.PR, 0, len(queue.PullRequests))
+	for _, item := range queue.PullRequests {
+		prs = append(prs, item.PR)
+	}
+	return prs, nil
+}
+
+func (r *resolver) pullRequests(p graphql.ResolveParams) (any, error) {
+	page, err := pageArgs(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	req := prDto.SearchPrRequest{
+		Query:           p.Args["query"].(string),
+		Page:            page,
+		ExpandReviewers: true,
+	}
+	if status, ok := p.Args["status"].(string); ok {
+		req.Status = status
+	}
+	if labels, ok := p.Args["labels"].([]any); ok {
+		for _, label := range labels {
+			req.Labels = append(req.Labels, label.(string))
+		}
+	}
+	if err := r.validate.Struct(req); err != nil {
+		return nil, validationError(err)
+	}
+	return r.services.PullRequests.SearchPRs(p.Context, req)
+}
+
+// statistics computes the team section only when its field is selected.
+func (r *resolver) statistics(p graphql.ResolveParams) (any, error) {
+	return r.services.Statistics.GetStatistics(p.Context, statistics.StatisticsRequest{
+		Include: statistics.Include{TeamStats: selects(p.Info, "teams")},
+	})
+}
+
+// pageArgs reads the "limit" and "offset" arguments of a paged field.
+func pageArgs(args map[string]any) (dto.PageRequest, error) {
+	page := dto.PageRequest{Limit: args["limit"].(int), Offset: args["offset"].(int)}
+	if page.Limit < 1 || page.Limit > maxPageLimit {
+		return dto.PageRequest{}, domainErrors.NewValidation(
+			fmt.Sprintf("limit must be an integer between 1 and %d", maxPageLimit))
+	}
+	if page.Offset < 0 {
+		return dto.PageRequest{}, domainErrors.NewValidation("offset must be a non-negative integer")
+	}
+	return page, nil
+}
+
+// validationError names the arguments that failed validation.
+func validationError(err error) error {
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return domainErrors.NewValidation(err.Error())
+	}
+	msgs := make([]string, 0, len(fieldErrs))
+	for _, fe := range fieldErrs {
+		msgs = append(msgs, fmt.Sprintf("%s failed the %q rule", strings.ToLower(fe.Field()), fe.Tag()))
+	}
+	return domainErrors.NewValidation(strings.Join(msgs, "; "))
+}
+
+// selects reports whether the field's selection, with its fragments, includes the named field.
+func selects(info graphql.ResolveInfo, name string) bool {
+	var walk func(set *ast.SelectionSet) bool
+	walk = func(set *ast.SelectionSet) bool {
+		if set == nil {
+			return false
+		}
+		for _, selection := range set.Selections {
+			switch selection := selection.(type) {
+			case *ast.Field:
+				if selection.Name.Value == name {
+					return true
+				}
+			case *ast.InlineFragment:
+				if walk(selection.SelectionSet) {
+					return true
+				}
+			case *ast.FragmentSpread:
+				if fragment, ok := info.Fragments[selection.Name.Value].(*ast.FragmentDefinition); ok &&
+					walk(fragment.SelectionSet) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	for _, field := range info.FieldASTs {
+		if walk(field.SelectionSet) {
+			return true
+		}
+	}
+	return false
+}
+
+// prop is a field read from the source of type S.
+func prop[S any](t graphql.Output, get func(S) any) *graphql.Field {
+	return &graphql.Field{
+		Type: t,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return get(p.Source.(S)), nil
+		},
+	}
+}
+
+// optional maps an empty string to null.
+func optional(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// orEmpty keeps a missing list from resolving to null.
+func orEmpty(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+var (
+	nonNullString  = graphql.NewNonNull(graphql.String)
+	nonNullID      = graphql.NewNonNull(graphql.ID)
+	nonNullInt     = graphql.NewNonNull(graphql.Int)
+	nonNullBoolean = graphql.NewNonNull(graphql.Boolean)
+	stringList     = graphql.NewNonNull(graphql.NewList(nonNullString))
+)
+
+func listOf(t graphql.Type) graphql.Output {
+	return graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(t)))
+}
+
+// pageArgsConfig are the arguments of a paged field.
+func pageArgsConfig() graphql.FieldConfigArgument {
+	return graphql.FieldConfigArgument{
+		"limit":  {Type: graphql.Int, DefaultValue: defaultPageLimit},
+		"offset": {Type: graphql.Int, DefaultValue: 0},
+	}
+}
+
+// pageType is the envelope of a page of items, like the one of the list endpoints.
+func pageType[T any](name string, item graphql.Type) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: name,
+		Fields: graphql.Fields{
+			"items":  prop(listOf(item), func(p *dto.Page[T]) any { return p.Items }),
+			"total":  prop(nonNullInt, func(p *dto.Page[T]) any { return p.Total }),
+			"limit":  prop(nonNullInt, func(p *dto.Page[T]) any { return p.Limit }),
+			"offset": prop(nonNullInt, func(p *dto.Page[T]) any { return p.Offset }),
+		},
+	})
+}
+
+// newSchema builds the read-only schema of the graph.
+func newSchema(r *resolver) (graphql.Schema, error) {
+	statusEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name: "PullRequestStatus",
+		Values: graphql.EnumValueConfigMap{
+			models.PRStatusOpen:   {Value: models.PRStatusOpen},
+			models.PRStatusMerged: {Value: models.PRStatusMerged},
+			models.PRStatusClosed: {Value: models.PRStatusClosed},
+		},
+	})
+
+	var userType *graphql.Object
+	assignedPRType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "AssignedPullRequest",
+		Description: "A pull request in a reviewer's queue, with the reviewer's assignment.",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":       prop(nonNullID, func(pr userDto.PR) any { return pr.PullRequestID }),
+				"name":     prop(nonNullString, func(pr userDto.PR) any { return pr.PullRequestName }),
+				"authorId": prop(nonNullID, func(pr userDto.PR) any { return pr.AuthorID }),
+				"author": {Type: userType, Resolve: func(p graphql.ResolveParams) (any, error) {
+					return r.user(p.Context, p.Source.(userDto.PR).AuthorID)
+				}},
+				"status":         prop(graphql.NewNonNull(statusEnum), func(pr userDto.PR) any { return pr.Status }),
+				"priority":       prop(graphql.String, func(pr userDto.PR) any { return optional(pr.Priority) }),
+				"labels":         prop(stringList, func(pr userDto.PR) any { return orEmpty(pr.Labels) }),
+				"assignedAt":     prop(graphql.String, func(pr userDto.PR) any { return optional(pr.AssignedAt) }),
+				"source":         prop(graphql.String, func(pr userDto.PR) any { return optional(pr.Source) }),
+				"deadline":       prop(graphql.String, func(pr userDto.PR) any { return optional(pr.Deadline) }),
+				"overdue":        prop(nonNullBoolean, func(pr userDto.PR) any { return pr.Overdue }),
+				"reviewState":    prop(graphql.String, func(pr userDto.PR) any { return optional(pr.ReviewState) }),
+				"stateChangedAt": prop(graphql.String, func(pr userDto.PR) any { return optional(pr.StateChangedAt) }),
+			}
+		}),
+	})
+
+	userType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id":         prop(nonNullID, func(u userDto.User) any { return u.UserID }),
+			"username":   prop(nonNullString, func(u userDto.User) any { return u.Username }),
+			"teamName":   prop(nonNullString, func(u userDto.User) any { return u.TeamName }),
+			"isActive":   prop(nonNullBoolean, func(u userDto.User) any { return u.IsActive }),
+			"isReviewer": prop(nonNullBoolean, func(u userDto.User) any { return u.IsReviewer }),
+			"tags":       prop(stringList, func(u userDto.User) any { return orEmpty(u.Tags) }),
+			"openReviews": {
+				Type:        listOf(assignedPRType),
+				Description: "Open pull requests the user is assigned to review, URGENT first.",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return r.openReviews(p.Context, p.Source.(userDto.User).UserID)
+				},
+			},
+		},
+	})
+
+	reviewerType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Reviewer",
+		Fields: graphql.Fields{
+			"userId": prop(nonNullID, func(rv prDto.Reviewer) any { return rv.UserID }),
+			"user": {Type: userType, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return r.user(p.Context, p.Source.(prDto.Reviewer).UserID)
+			}},
+			"assignedAt":     prop(graphql.String, func(rv prDto.Reviewer) any { return optional(rv.AssignedAt) }),
+			"source":         prop(graphql.String, func(rv prDto.Reviewer) any { return optional(rv.Source) }),
+			"deadline":       prop(graphql.String, func(rv prDto.Reviewer) any { return optional(rv.Deadline) }),
+			"overdue":        prop(nonNullBoolean, func(rv prDto.Reviewer) any { return rv.Overdue }),
+			"state":          prop(graphql.String, func(rv prDto.Reviewer) any { return optional(rv.State) }),
+			"stateChangedAt": prop(graphql.String, func(rv prDto.Reviewer) any { return optional(rv.StateChangedAt) }),
+		},
+	})
+
+	pullRequestType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PullRequest",
+		Fields: graphql.Fields{
+			"id":       prop(nonNullID, func(pr prDto.PR) any { return pr.PullRequestID }),
+			"name":     prop(nonNullString, func(pr prDto.PR) any { return pr.PullRequestName }),
+			"authorId": prop(nonNullID, func(pr prDto.PR) any { return pr.AuthorID }),
+			"author": {Type: userType, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return r.user(p.Context, p.Source.(prDto.PR).AuthorID)
+			}},
+			"status":      prop(graphql.NewNonNull(statusEnum), func(pr prDto.PR) any { return pr.Status }),
+			"priority":    prop(graphql.String, func(pr prDto.PR) any { return optional(pr.Priority) }),
+			"labels":      prop(stringList, func(pr prDto.PR) any { return orEmpty(pr.Labels) }),
+			"reviewerIds": prop(graphql.NewNonNull(graphql.NewList(nonNullID)), func(pr prDto.PR) any { return orEmpty(pr.AssignedReviewers) }),
+			"reviewers": prop(listOf(reviewerType), func(pr prDto.PR) any {
+				if pr.Reviewers == nil {
+					return []prDto.Reviewer{}
+				}
+				return pr.Reviewers
+			}),
+			"createdAt": prop(graphql.String, func(pr prDto.PR) any { return optional(pr.CreatedAt) }),
+			"updatedAt": prop(graphql.String, func(pr prDto.PR) any { return optional(pr.UpdatedAt) }),
+			"mergedAt":  prop(graphql.String, func(pr prDto.PR) any { return optional(pr.MergedAt) }),
+		},
+	})
+
+	teamType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Team",
+		Fields: graphql.Fields{
+			"name":        prop(nonNullString, func(t teamDto.GetTeamResponse) any { return t.TeamName }),
+			"description": prop(graphql.String, func(t teamDto.GetTeamResponse) any { return optional(t.Description) }),
+			"leadId":      prop(graphql.ID, func(t teamDto.GetTeamResponse) any { return optional(t.LeadID) }),
+			"lead": {Type: userType, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return r.user(p.Context, p.Source.(teamDto.GetTeamResponse).LeadID)
+			}},
+			"createdAt": prop(graphql.String, func(t teamDto.GetTeamResponse) any { return optional(t.CreatedAt) }),
+			"members": prop(listOf(userType), func(t teamDto.GetTeamResponse) any {
+				members := make([]userDto.User, 0, len(t.Members))
+				for _, m := range t.Members {
+					members = append(members, userDto.User{UserID: m.UserID, Username: m.Username,
+						TeamName: t.TeamName, IsActive: m.IsActive, IsReviewer: m.InReviewerPool(), Tags: m.Tags})
+				}
+				return members
+			}),
+			"reviewQueue": {
+				Type:        listOf(pullRequestType),
+				Description: "Open pull requests with reviewers from the team, URGENT first, then oldest first.",
+				Args: graphql.FieldConfigArgument{
+					"unreviewedOnly": {Type: graphql.Boolean, DefaultValue: false},
+				},
+				Resolve: r.reviewQueue,
+			},
+		},
+	})
+
+	teamSummaryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TeamSummary",
+		Fields: graphql.Fields{
+			"name":        prop(nonNullString, func(t teamDto.TeamSummary) any { return t.TeamName }),
+			"description": prop(graphql.String, func(t teamDto.TeamSummary) any { return optional(t.Description) }),
+			"leadId":      prop(graphql.ID, func(t teamDto.TeamSummary) any { return optional(t.LeadID) }),
+			"lead": {Type: userType, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return r.user(p.Context, p.Source.(teamDto.TeamSummary).LeadID)
+			}},
+			"createdAt":         prop(graphql.String, func(t teamDto.TeamSummary) any { return optional(t.CreatedAt) }),
+			"memberCount":       prop(nonNullInt, func(t teamDto.TeamSummary) any { return t.Members }),
+			"activeMemberCount": prop(nonNullInt, func(t teamDto.TeamSummary) any { return t.ActiveMembers }),
+		},
+	})
+
+	priorityStatsType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PriorityStats",
+		Fields: graphql.Fields{
+			"priority": prop(nonNullString, func(s statistics.PriorityStats) any { return s.Priority }),
+			"totalPRs": prop(nonNullInt, func(s statistics.PriorityStats) any { return s.TotalPRs }),
+			"openPRs":  prop(nonNullInt, func(s statistics.PriorityStats) any { return s.OpenPRs }),
+		},
+	})
+	labelStatsType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "LabelStats",
+		Fields: graphql.Fields{
+			"label":    prop(nonNullString, func(s statistics.LabelStats) any { return s.Label }),
+			"totalPRs": prop(nonNullInt, func(s statistics.LabelStats) any { return s.TotalPRs }),
+			"openPRs":  prop(nonNullInt, func(s statistics.LabelStats) any { return s.OpenPRs }),
+		},
+	})
+	sourceStatsType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "SourceStats",
+		Fields: graphql.Fields{
+			"source":      prop(nonNullString, func(s statistics.SourceStats) any { return s.Source }),
+			"assignments": prop(nonNullInt, func(s statistics.SourceStats) any { return s.Assignments }),
+		},
+	})
+	teamStatsType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TeamStats",
+		Fields: graphql.Fields{
+			"teamName":      prop(nonNullString, func(s statistics.TeamStats) any { return s.TeamName }),
+			"members":       prop(nonNullInt, func(s statistics.TeamStats) any { return s.Members }),
+			"activeMembers": prop(nonNullInt, func(s statistics.TeamStats) any { return s.ActiveMembers }),
+			"openPRs":       prop(nonNullInt, func(s statistics.TeamStats) any { return s.OpenPRs }),
+			"activeReviews": prop(nonNullInt, func(s statistics.TeamStats) any { return s.ActiveReviews }),
+		},
+	})
+
+	type stats = *statistics.StatisticsResponse
+	statisticsType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Statistics",
+		Fields: graphql.Fields{
+			"totalPRs":           prop(nonNullInt, func(s stats) any { return s.TotalPRs }),
+			"openPRs":            prop(nonNullInt, func(s stats) any { return s.OpenPRs }),
+			"mergedPRs":          prop(nonNullInt, func(s stats) any { return s.MergedPRs }),
+			"totalAssignments":   prop(nonNullInt, func(s stats) any { return s.TotalAssignments }),
+			"reassignmentEvents": prop(nonNullInt, func(s stats) any { return s.ReassignmentEvents }),
+			"byPriority":         prop(listOf(priorityStatsType), func(s stats) any { return s.ByPriority }),
+			"byLabel":            prop(listOf(labelStatsType), func(s stats) any { return s.ByLabel }),
+			"bySource":           prop(listOf(sourceStatsType), func(s stats) any { return s.BySource }),
+			"teams":              prop(listOf(teamStatsType), func(s stats) any { return s.TeamStats }),
+		},
+	})
+
+	searchArgs := pageArgsConfig()
+	searchArgs["query"] = &graphql.ArgumentConfig{Type: nonNullString, Description: "Text searched in PR titles."}
+	searchArgs["status"] = &graphql.ArgumentConfig{Type: statusEnum}
+	searchArgs["labels"] = &graphql.ArgumentConfig{Type: graphql.NewList(nonNullString),
+		Description: "Keeps only PRs that carry all of the labels."}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"team": {
+				Type:    teamType,
+				Args:    graphql.FieldConfigArgument{"name": {Type: nonNullString}},
+				Resolve: r.team,
+			},
+			"teams": {
+				Type:    graphql.NewNonNull(pageType[teamDto.TeamSummary]("TeamSummaryPage", teamSummaryType)),
+				Args:    pageArgsConfig(),
+				Resolve: r.teams,
+			},
+			"user": {
+				Type: userType,
+				Args: graphql.FieldConfigArgument{"id": {Type: nonNullID}},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return r.user(p.Context, p.Args["id"].(string))
+				},
+			},
+			"pullRequests": {
+				Type:        graphql.NewNonNull(pageType[prDto.PR]("PullRequestPage", pullRequestType)),
+				Description: "Pull requests found by title, newest first.",
+				Args:        searchArgs,
+				Resolve:     r.pullRequests,
+			},
+			"statistics": {
+				Type:    graphql.NewNonNull(statisticsType),
+				Resolve: r.statistics,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
@@ -0,0 +1,44 @@
+package graphql
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mocks/mock_services.go -package=mocks
+
+import (
+	"context"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	prDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/pullrequest"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/statistics"
+	teamDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/team"
+	userDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/user"
+)
+
+// TeamService defines the team reads the graph resolves.
+type TeamService interface {
+	GetTeam(ctx context.Context, teamName string) (*teamDto.GetTeamResponse, error)
+	ListTeams(ctx context.Context, req teamDto.ListTeamsRequest) (*dto.Page[teamDto.TeamSummary], error)
+	GetReviewQueue(ctx context.Context, req teamDto.ReviewQueueRequest) (*teamDto.ReviewQueueResponse, error)
+}
+
+// UserService defines the batched user reads the graph resolves.
+type UserService interface {
+	GetUsers(ctx context.Context, userIDs []string) ([]userDto.User, error)
+	GetOpenReviews(ctx context.Context, userIDs []string) (map[string][]userDto.PR, error)
+}
+
+// PullRequestService defines the PR reads the graph resolves.
+type PullRequestService interface {
+	SearchPRs(ctx context.Context, req prDto.SearchPrRequest) (*dto.Page[prDto.PR], error)
+}
+
+// StatisticsService defines the statistics reads the graph resolves.
+type StatisticsService interface {
+	GetStatistics(ctx context.Context, req statistics.StatisticsRequest) (*statistics.StatisticsResponse, error)
+}
+
+// Services are the application services behind the graph.
+type Services struct {
+	Teams        TeamService
+	Users        UserService
+	PullRequests PullRequestService
+	Statistics   StatisticsService
+}
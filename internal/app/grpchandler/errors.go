@@ -0,0 +1,84 @@
+package grpchandler
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain is the domain of the ErrorInfo details, whose reason is the domain error code.
+const errorDomain = "pr-reviewer-service"
+
+// toStatus converts a service error to a gRPC status error. Domain errors keep their message and
+// carry their code as the ErrorInfo reason, like the code of the HTTP error body; other errors
+// become Internal without exposing their message.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	var appErr *domainErrors.AppError
+	if !errors.As(err, &appErr) {
+		return status.Error(codes.Internal, "internal server error")
+	}
+	return withDetails(status.New(mapErrorCodeToGRPCCode(appErr.Code), appErr.Message),
+		&errdetails.ErrorInfo{Reason: appErr.Code, Domain: errorDomain})
+}
+
+// validationStatus converts a request validation error to InvalidArgument, listing the fields that
+// failed in BadRequest details.
+func validationStatus(err error) error {
+	info := &errdetails.ErrorInfo{Reason: domainErrors.CodeValidation, Domain: errorDomain}
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return withDetails(status.New(codes.InvalidArgument, err.Error()), info)
+	}
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		// the namespace starts with the request struct name, which means nothing to clients
+		_, field, _ := strings.Cut(fe.Namespace(), ".")
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: field, Description: fe.Error()})
+	}
+	return withDetails(status.New(codes.InvalidArgument, err.Error()), info,
+		&errdetails.BadRequest{FieldViolations: violations})
+}
+
+// withDetails attaches details to st, keeping st as is when they can't be encoded.
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) error {
+	if detailed, err := st.WithDetails(details...); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// mapErrorCodeToGRPCCode maps domain error codes to gRPC codes the way the HTTP API maps them to
+// statuses: 400 is InvalidArgument, 404 NotFound and 409 AlreadyExists for duplicates and
+// FailedPrecondition otherwise, and 503 is Unavailable; unknown codes are Internal.
+func mapErrorCodeToGRPCCode(code string) codes.Code {
+	switch code {
+	case domainErrors.CodeNotFound:
+		return codes.NotFound
+	case domainErrors.CodeValidation, domainErrors.CodeBadRequest, domainErrors.CodeNotAssigned, domainErrors.CodeWrongTeam,
+		domainErrors.CodeReviewerIsAuthor, domainErrors.CodeReviewerExcluded, domainErrors.CodeNotReviewer:
+		return codes.InvalidArgument
+	case domainErrors.CodeTeamExists, domainErrors.CodePRExists:
+		return codes.AlreadyExists
+	case domainErrors.CodePRMerged, domainErrors.CodePRClosed, domainErrors.CodeNoCandidate, domainErrors.CodeAlreadyAssigned,
+		domainErrors.CodeTooManyReviewers, domainErrors.CodeInvalidTransition, domainErrors.CodeChangesRequested,
+		domainErrors.CodeNoReviewers, domainErrors.CodeNotEmpty:
+		return codes.FailedPrecondition
+	case domainErrors.CodeServiceOverloaded:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
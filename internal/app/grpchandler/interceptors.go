@@ -0,0 +1,88 @@
+package grpchandler
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// orgMetadataKey names the organization a call works on, as the X-Org-Id header does over HTTP.
+const orgMetadataKey = "x-org-id"
+
+// maxOrgIDLength is the length of the org_id columns.
+const maxOrgIDLength = 255
+
+// loggingInterceptor logs every call with its method, code and duration. Successful calls are
+// logged at Debug, calls the client got wrong or gave up on at Info and server failures at Error.
+func loggingInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := next(ctx, req)
+		st := status.Convert(err)
+
+		attrs := []slog.Attr{
+			slog.String("method", info.FullMethod),
+			slog.String("code", st.Code().String()),
+			slog.Duration("duration", time.Since(start)),
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String("error", st.Message()))
+		}
+		logger.LogAttrs(ctx, codeLevel(st.Code()), "grpc call", attrs...)
+		return resp, err
+	}
+}
+
+// codeLevel is the level a call finished with code is logged at.
+func codeLevel(code codes.Code) slog.Level {
+	switch code {
+	case codes.OK:
+		return slog.LevelDebug
+	case codes.Canceled, codes.DeadlineExceeded, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists,
+		codes.FailedPrecondition:
+		return slog.LevelInfo
+	default:
+		return slog.LevelError
+	}
+}
+
+// recoveryInterceptor answers a call that panicked with Internal and logs the panic with its stack.
+func recoveryInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.LogAttrs(ctx, slog.LevelError, "panic in grpc call",
+					slog.String("method", info.FullMethod),
+					slog.Any("panic", r),
+					slog.String("stack", string(debug.Stack())),
+				)
+				err = status.Error(codes.Internal, "internal server error")
+			}
+		}()
+		return next(ctx, req)
+	}
+}
+
+// orgInterceptor puts the organization of the x-org-id metadata into the call context; calls
+// without it work on the default organization. An ID longer than the columns hold is refused.
+func orgInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, next grpc.UnaryHandler) (any, error) {
+		var id string
+		if values := metadata.ValueFromIncomingContext(ctx, orgMetadataKey); len(values) > 0 {
+			id = strings.TrimSpace(values[0])
+		}
+		if len(id) > maxOrgIDLength {
+			return nil, toStatus(domainErrors.NewValidation("x-org-id must be at most 255 characters"))
+		}
+		return next(orgctx.WithID(ctx, id), req)
+	}
+}
@@ -0,0 +1,85 @@
+package grpchandler
+
+import (
+	"context"
+
+	"github.com/go-playground/validator/v10"
+	pb "github.com/shirr9/pr-reviewer-service/api/proto/prreviewer/v1"
+	prDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/pullrequest"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler"
+)
+
+// pullRequestServer serves PullRequestService.
+type pullRequestServer struct {
+	pb.UnimplementedPullRequestServiceServer
+	service  handler.PullRequestService
+	validate *validator.Validate
+}
+
+// CreatePullRequest creates a pull request.
+func (s *pullRequestServer) CreatePullRequest(ctx context.Context,
+	in *pb.CreatePullRequestRequest) (*pb.CreatePullRequestResponse, error) {
+	req := prDto.CreatePrRequest{
+		PullRequestID:   in.GetPullRequestId(),
+		PullRequestName: in.GetPullRequestName(),
+		AuthorID:        in.GetAuthorId(),
+		Priority:        in.GetPriority(),
+		Labels:          in.GetLabels(),
+		RequiredTags:    in.GetRequiredTags(),
+	}
+	if err := s.validate.Struct(req); err != nil {
+		return nil, validationStatus(err)
+	}
+	resp, err := s.service.CreatePR(ctx, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.CreatePullRequestResponse{Pr: toPullRequest(resp.Pr), OverloadedReviewers: resp.OverloadedReviewers}, nil
+}
+
+// MergePullRequest merges a pull request.
+func (s *pullRequestServer) MergePullRequest(ctx context.Context,
+	in *pb.MergePullRequestRequest) (*pb.MergePullRequestResponse, error) {
+	req := prDto.MergePrRequest{PullRequestID: in.GetPullRequestId()}
+	if err := s.validate.Struct(req); err != nil {
+		return nil, validationStatus(err)
+	}
+	resp, err := s.service.MergePR(ctx, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.MergePullRequestResponse{Pr: toPullRequest(resp.Pr)}, nil
+}
+
+// ReassignReviewer replaces a reviewer of a pull request.
+func (s *pullRequestServer) ReassignReviewer(ctx context.Context,
+	in *pb.ReassignReviewerRequest) (*pb.ReassignReviewerResponse, error) {
+	req := prDto.ReassignReviewerRequest{
+		PullRequestID: in.GetPullRequestId(),
+		OldReviewerID: in.GetOldReviewerId(),
+		NewReviewerID: in.GetNewReviewerId(),
+	}
+	if err := s.validate.Struct(req); err != nil {
+		return nil, validationStatus(err)
+	}
+	resp, err := s.service.ReassignReviewer(ctx, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.ReassignReviewerResponse{Pr: toPullRequest(resp.Pr), ReplacedBy: resp.ReplacedBy}, nil
+}
+
+func toPullRequest(pr prDto.PR) *pb.PullRequest {
+	return &pb.PullRequest{
+		PullRequestId:     pr.PullRequestID,
+		PullRequestName:   pr.PullRequestName,
+		AuthorId:          pr.AuthorID,
+		Status:            pr.Status,
+		Priority:          pr.Priority,
+		Labels:            pr.Labels,
+		AssignedReviewers: pr.AssignedReviewers,
+		CreatedAt:         pr.CreatedAt,
+		UpdatedAt:         pr.UpdatedAt,
+		MergedAt:          pr.MergedAt,
+	}
+}
@@ -0,0 +1,35 @@
+// Package grpchandler serves the gRPC API of api/proto/prreviewer/v1 from the services behind the
+// HTTP API, so both transports share validation, business rules and error codes.
+package grpchandler
+
+import (
+	"log/slog"
+
+	"github.com/go-playground/validator/v10"
+	pb "github.com/shirr9/pr-reviewer-service/api/proto/prreviewer/v1"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler"
+	"google.golang.org/grpc"
+)
+
+// NewServer creates a gRPC server with the API services registered. Calls are logged, panics in
+// them are answered with Internal instead of crashing the process, and the x-org-id metadata
+// selects the organization they work on.
+func NewServer(services handler.Services, logger *slog.Logger, validate *validator.Validate,
+	opts ...grpc.ServerOption) *grpc.Server {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if validate == nil {
+		validate = handler.NewValidator()
+	}
+
+	opts = append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(loggingInterceptor(logger), recoveryInterceptor(logger), orgInterceptor()),
+	}, opts...)
+	srv := grpc.NewServer(opts...)
+	pb.RegisterPullRequestServiceServer(srv, &pullRequestServer{service: services.PullRequests, validate: validate})
+	pb.RegisterTeamServiceServer(srv, &teamServer{service: services.Teams, validate: validate})
+	pb.RegisterUserServiceServer(srv, &userServer{service: services.Users, validate: validate})
+	pb.RegisterStatisticsServiceServer(srv, &statisticsServer{service: services.Statistics})
+	return srv
+}
@@ -0,0 +1,465 @@
+package grpchandler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+
+	pb "github.com/shirr9/pr-reviewer-service/api/proto/prreviewer/v1"
+	prDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/pullrequest"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/statistics"
+	teamDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/team"
+	userDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/user"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler/mocks"
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// testServices are mocked services behind a server started by dial.
+type testServices struct {
+	prs   *mocks.MockPullRequestService
+	teams *mocks.MockTeamService
+	users *mocks.MockUserService
+	stats *mocks.MockStatisticsService
+}
+
+// dial serves the API from fresh mocks over an in-memory listener and connects to it.
+func dial(t *testing.T) (*testServices, *grpc.ClientConn) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	m := &testServices{
+		prs:   mocks.NewMockPullRequestService(ctrl),
+		teams: mocks.NewMockTeamService(ctrl),
+		users: mocks.NewMockUserService(ctrl),
+		stats: mocks.NewMockStatisticsService(ctrl),
+	}
+	srv := NewServer(handler.Services{
+		PullRequests: m.prs,
+		Teams:        m.teams,
+		Users:        m.users,
+		Statistics:   m.stats,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)), nil)
+
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = srv.Serve(listener) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return m, conn
+}
+
+// assertStatus checks the code of a call error and the domain code in its ErrorInfo.
+func assertStatus(t *testing.T, err error, code codes.Code, reason string) *status.Status {
+	t.Helper()
+	st, ok := status.FromError(err)
+	if !assert.True(t, ok, "not a status error: %v", err) {
+		return nil
+	}
+	assert.Equal(t, code, st.Code(), st.Message())
+	var gotReason string
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			gotReason = info.GetReason()
+			assert.Equal(t, errorDomain, info.GetDomain())
+		}
+	}
+	assert.Equal(t, reason, gotReason)
+	return st
+}
+
+func TestPullRequestServer_CreatePullRequest(t *testing.T) {
+	ctx := context.Background()
+	req := prDto.CreatePrRequest{PullRequestID: "pr-1", PullRequestName: "Add feature", AuthorID: "u1",
+		Labels: []string{"backend"}}
+	in := &pb.CreatePullRequestRequest{PullRequestId: "pr-1", PullRequestName: "Add feature", AuthorId: "u1",
+		Labels: []string{"backend"}}
+
+	t.Run("Success - PR created", func(t *testing.T) {
+		m, conn := dial(t)
+		m.prs.EXPECT().CreatePR(gomock.Any(), req).Return(&prDto.CreatePrResponse{
+			Pr: prDto.PR{PullRequestID: "pr-1", PullRequestName: "Add feature", AuthorID: "u1", Status: "OPEN",
+				Labels: []string{"backend"}, AssignedReviewers: []string{"u2", "u3"}, CreatedAt: "2025-01-01T10:00:00Z"},
+			OverloadedReviewers: []string{"u3"},
+		}, nil)
+
+		resp, err := pb.NewPullRequestServiceClient(conn).CreatePullRequest(ctx, in)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, "pr-1", resp.GetPr().GetPullRequestId())
+			assert.Equal(t, "OPEN", resp.GetPr().GetStatus())
+			assert.Equal(t, []string{"u2", "u3"}, resp.GetPr().GetAssignedReviewers())
+			assert.Equal(t, "2025-01-01T10:00:00Z", resp.GetPr().GetCreatedAt())
+			assert.Equal(t, []string{"u3"}, resp.GetOverloadedReviewers())
+		}
+	})
+
+	t.Run("Error - Missing fields fail validation", func(t *testing.T) {
+		_, conn := dial(t)
+
+		_, err := pb.NewPullRequestServiceClient(conn).CreatePullRequest(ctx,
+			&pb.CreatePullRequestRequest{PullRequestId: "pr-1"})
+
+		st := assertStatus(t, err, codes.InvalidArgument, domainErrors.CodeValidation)
+		var fields []string
+		for _, detail := range st.Details() {
+			if bad, ok := detail.(*errdetails.BadRequest); ok {
+				for _, v := range bad.GetFieldViolations() {
+					fields = append(fields, v.GetField())
+				}
+			}
+		}
+		assert.Equal(t, []string{"pull_request_name", "author_id"}, fields)
+	})
+
+	t.Run("Error - Existing PR", func(t *testing.T) {
+		m, conn := dial(t)
+		m.prs.EXPECT().CreatePR(gomock.Any(), req).Return(nil, domainErrors.NewPRExists("PR id already exists"))
+
+		_, err := pb.NewPullRequestServiceClient(conn).CreatePullRequest(ctx, in)
+
+		st := assertStatus(t, err, codes.AlreadyExists, domainErrors.CodePRExists)
+		assert.Equal(t, "PR id already exists", st.Message())
+	})
+}
+
+func TestPullRequestServer_MergePullRequest(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - PR merged", func(t *testing.T) {
+		m, conn := dial(t)
+		m.prs.EXPECT().MergePR(gomock.Any(), prDto.MergePrRequest{PullRequestID: "pr-1"}).Return(&prDto.MergePrResponse{
+			Pr: prDto.PR{PullRequestID: "pr-1", Status: "MERGED", MergedAt: "2025-01-02T10:00:00Z"},
+		}, nil)
+
+		resp, err := pb.NewPullRequestServiceClient(conn).MergePullRequest(ctx,
+			&pb.MergePullRequestRequest{PullRequestId: "pr-1"})
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, "MERGED", resp.GetPr().GetStatus())
+			assert.Equal(t, "2025-01-02T10:00:00Z", resp.GetPr().GetMergedAt())
+		}
+	})
+
+	t.Run("Error - PR not found", func(t *testing.T) {
+		m, conn := dial(t)
+		m.prs.EXPECT().MergePR(gomock.Any(), gomock.Any()).Return(nil, domainErrors.NewNotFound("PR not found"))
+
+		_, err := pb.NewPullRequestServiceClient(conn).MergePullRequest(ctx,
+			&pb.MergePullRequestRequest{PullRequestId: "pr-1"})
+
+		assertStatus(t, err, codes.NotFound, domainErrors.CodeNotFound)
+	})
+
+	t.Run("Error - Concurrent status change", func(t *testing.T) {
+		m, conn := dial(t)
+		m.prs.EXPECT().MergePR(gomock.Any(), gomock.Any()).
+			Return(nil, domainErrors.NewInvalidTransition("PR status changed concurrently"))
+
+		_, err := pb.NewPullRequestServiceClient(conn).MergePullRequest(ctx,
+			&pb.MergePullRequestRequest{PullRequestId: "pr-1"})
+
+		assertStatus(t, err, codes.FailedPrecondition, domainErrors.CodeInvalidTransition)
+	})
+}
+
+func TestPullRequestServer_ReassignReviewer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - Explicit replacement passed on", func(t *testing.T) {
+		m, conn := dial(t)
+		m.prs.EXPECT().ReassignReviewer(gomock.Any(), prDto.ReassignReviewerRequest{
+			PullRequestID: "pr-1", OldReviewerID: "u2", NewReviewerID: "u4",
+		}).Return(&prDto.ReassignReviewerResponse{
+			Pr:         prDto.PR{PullRequestID: "pr-1", AssignedReviewers: []string{"u4", "u3"}},
+			ReplacedBy: "u4",
+		}, nil)
+
+		resp, err := pb.NewPullRequestServiceClient(conn).ReassignReviewer(ctx,
+			&pb.ReassignReviewerRequest{PullRequestId: "pr-1", OldReviewerId: "u2", NewReviewerId: "u4"})
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, "u4", resp.GetReplacedBy())
+			assert.Equal(t, []string{"u4", "u3"}, resp.GetPr().GetAssignedReviewers())
+		}
+	})
+
+	t.Run("Error - Reviewer not assigned", func(t *testing.T) {
+		m, conn := dial(t)
+		m.prs.EXPECT().ReassignReviewer(gomock.Any(), gomock.Any()).
+			Return(nil, domainErrors.NewNotAssigned("reviewer is not assigned to this PR"))
+
+		_, err := pb.NewPullRequestServiceClient(conn).ReassignReviewer(ctx,
+			&pb.ReassignReviewerRequest{PullRequestId: "pr-1", OldReviewerId: "u9"})
+
+		assertStatus(t, err, codes.InvalidArgument, domainErrors.CodeNotAssigned)
+	})
+}
+
+func TestTeamServer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - Team added with member capacity", func(t *testing.T) {
+		m, conn := dial(t)
+		limit := 3
+		members := []teamDto.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true, MaxActiveReviews: &limit}}
+		m.teams.EXPECT().AddTeam(gomock.Any(), teamDto.AddTeamRequest{TeamName: "backend", Members: members}).
+			Return(&teamDto.AddTeamResponse{Team: teamDto.Team{TeamName: "backend", Members: members}}, nil)
+
+		capacity := int32(3)
+		resp, err := pb.NewTeamServiceClient(conn).AddTeam(ctx, &pb.AddTeamRequest{
+			TeamName: "backend",
+			Members:  []*pb.TeamMember{{UserId: "u1", Username: "Alice", IsActive: true, MaxActiveReviews: &capacity}},
+		})
+
+		if assert.NoError(t, err) && assert.Len(t, resp.GetTeam().GetMembers(), 1) {
+			member := resp.GetTeam().GetMembers()[0]
+			assert.Equal(t, "u1", member.GetUserId())
+			if assert.NotNil(t, member.MaxActiveReviews) {
+				assert.EqualValues(t, 3, member.GetMaxActiveReviews())
+			}
+		}
+	})
+
+	t.Run("Success - Member without capacity keeps it unset", func(t *testing.T) {
+		m, conn := dial(t)
+		m.teams.EXPECT().GetTeam(gomock.Any(), "backend").Return(&teamDto.GetTeamResponse{
+			TeamName: "backend",
+			Members:  []teamDto.TeamMember{{UserID: "u1", Username: "Alice"}},
+		}, nil)
+
+		resp, err := pb.NewTeamServiceClient(conn).GetTeam(ctx, &pb.GetTeamRequest{TeamName: "backend"})
+
+		if assert.NoError(t, err) && assert.Len(t, resp.GetTeam().GetMembers(), 1) {
+			assert.Nil(t, resp.GetTeam().GetMembers()[0].MaxActiveReviews)
+		}
+	})
+
+	t.Run("Error - Team name required", func(t *testing.T) {
+		_, conn := dial(t)
+
+		_, err := pb.NewTeamServiceClient(conn).GetTeam(ctx, &pb.GetTeamRequest{})
+
+		assertStatus(t, err, codes.InvalidArgument, domainErrors.CodeValidation)
+	})
+
+	t.Run("Error - Existing team", func(t *testing.T) {
+		m, conn := dial(t)
+		m.teams.EXPECT().AddTeam(gomock.Any(), gomock.Any()).Return(nil, domainErrors.NewTeamExists("team_name already exists"))
+
+		_, err := pb.NewTeamServiceClient(conn).AddTeam(ctx, &pb.AddTeamRequest{
+			TeamName: "backend",
+			Members:  []*pb.TeamMember{{UserId: "u1", Username: "Alice"}},
+		})
+
+		assertStatus(t, err, codes.AlreadyExists, domainErrors.CodeTeamExists)
+	})
+
+	t.Run("Success - Team deactivated", func(t *testing.T) {
+		m, conn := dial(t)
+		m.teams.EXPECT().DeactivateTeam(gomock.Any(), teamDto.DeactivateTeamRequest{TeamName: "backend"}).Return(&teamDto.DeactivateTeamResponse{
+			DeactivatedUsers: 2, ReassignedPRs: 1, Reassigned: 1, UserIDs: []string{"u1", "u2"},
+		}, nil)
+
+		resp, err := pb.NewTeamServiceClient(conn).DeactivateTeam(ctx, &pb.DeactivateTeamRequest{TeamName: "backend"})
+
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, 2, resp.GetDeactivatedUsers())
+			assert.EqualValues(t, 1, resp.GetReassignedPrs())
+			assert.Equal(t, []string{"u1", "u2"}, resp.GetUserIds())
+		}
+	})
+}
+
+func TestUserServer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - User deactivated", func(t *testing.T) {
+		m, conn := dial(t)
+		m.users.EXPECT().SetIsActive(gomock.Any(), userDto.SetIsActiveRequest{UserID: "u1", IsActive: false}).
+			Return(&userDto.SetIsActiveResponse{User: userDto.User{UserID: "u1", TeamName: "backend"}}, nil)
+
+		resp, err := pb.NewUserServiceClient(conn).SetIsActive(ctx, &pb.SetIsActiveRequest{UserId: "u1"})
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, "backend", resp.GetUser().GetTeamName())
+			assert.False(t, resp.GetUser().GetIsActive())
+		}
+	})
+
+	t.Run("Success - Reviews listed with their assignment", func(t *testing.T) {
+		m, conn := dial(t)
+		m.users.EXPECT().GetReview(gomock.Any(), "u2").Return(&userDto.GetReviewResponse{
+			UserID: "u2",
+			PullRequests: []userDto.PR{{PullRequestID: "pr-1", Status: "OPEN", Source: "manual", Overdue: true,
+				ReviewState: "PENDING"}},
+		}, nil)
+
+		resp, err := pb.NewUserServiceClient(conn).GetReview(ctx, &pb.GetReviewRequest{UserId: "u2"})
+
+		if assert.NoError(t, err) && assert.Len(t, resp.GetPullRequests(), 1) {
+			review := resp.GetPullRequests()[0]
+			assert.Equal(t, "manual", review.GetAssignmentSource())
+			assert.True(t, review.GetOverdue())
+			assert.Equal(t, "PENDING", review.GetReviewState())
+		}
+	})
+
+	t.Run("Error - User not found", func(t *testing.T) {
+		m, conn := dial(t)
+		m.users.EXPECT().GetReview(gomock.Any(), "u9").Return(nil, domainErrors.NewNotFound("user not found"))
+
+		_, err := pb.NewUserServiceClient(conn).GetReview(ctx, &pb.GetReviewRequest{UserId: "u9"})
+
+		assertStatus(t, err, codes.NotFound, domainErrors.CodeNotFound)
+	})
+}
+
+func TestStatisticsServer_GetStatistics(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - Team statistics on request", func(t *testing.T) {
+		m, conn := dial(t)
+		m.stats.EXPECT().GetStatistics(gomock.Any(), statistics.StatisticsRequest{
+			Include:         statistics.Include{TeamStats: true},
+			IncludeArchived: true,
+		}).Return(&statistics.StatisticsResponse{
+			TotalPRs:   3,
+			OpenPRs:    2,
+			ByPriority: []statistics.PriorityStats{{Priority: "NORMAL", TotalPRs: 3, OpenPRs: 2}},
+			BySource:   []statistics.SourceStats{{Source: "auto", Assignments: 4}},
+			TeamStats:  []statistics.TeamStats{{TeamName: "backend", Members: 2, ActiveReviews: 4}},
+		}, nil)
+
+		resp, err := pb.NewStatisticsServiceClient(conn).GetStatistics(ctx,
+			&pb.GetStatisticsRequest{IncludeArchived: true, IncludeTeamStats: true})
+
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, 3, resp.GetTotalPrs())
+			assert.EqualValues(t, 2, resp.GetByPriority()[0].GetOpenPrs())
+			assert.EqualValues(t, 4, resp.GetBySource()[0].GetAssignments())
+			assert.Equal(t, "backend", resp.GetTeamStats()[0].GetTeamName())
+		}
+	})
+}
+
+func TestServer_Errors(t *testing.T) {
+	ctx := context.Background()
+	merge := func(conn *grpc.ClientConn) error {
+		_, err := pb.NewPullRequestServiceClient(conn).MergePullRequest(ctx,
+			&pb.MergePullRequestRequest{PullRequestId: "pr-1"})
+		return err
+	}
+
+	t.Run("Error - Unexpected error hidden as Internal", func(t *testing.T) {
+		m, conn := dial(t)
+		m.prs.EXPECT().MergePR(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+
+		st := assertStatus(t, merge(conn), codes.Internal, "")
+		assert.Equal(t, "internal server error", st.Message())
+	})
+
+	t.Run("Error - Panic answered with Internal", func(t *testing.T) {
+		m, conn := dial(t)
+		m.prs.EXPECT().MergePR(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(context.Context, prDto.MergePrRequest) (*prDto.MergePrResponse, error) {
+				panic("boom")
+			})
+
+		assertStatus(t, merge(conn), codes.Internal, "")
+	})
+
+	t.Run("Error - Canceled request", func(t *testing.T) {
+		m, conn := dial(t)
+		m.prs.EXPECT().MergePR(gomock.Any(), gomock.Any()).Return(nil, context.Canceled)
+
+		assertStatus(t, merge(conn), codes.Canceled, "")
+	})
+}
+
+func TestServer_Org(t *testing.T) {
+	getTeam := func(conn *grpc.ClientConn, orgID string) error {
+		ctx := context.Background()
+		if orgID != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, orgMetadataKey, orgID)
+		}
+		_, err := pb.NewTeamServiceClient(conn).GetTeam(ctx, &pb.GetTeamRequest{TeamName: "backend"})
+		return err
+	}
+	expectOrg := func(m *testServices, want string) {
+		m.teams.EXPECT().GetTeam(gomock.Any(), "backend").DoAndReturn(
+			func(ctx context.Context, name string) (*teamDto.GetTeamResponse, error) {
+				assert.Equal(t, want, orgctx.ID(ctx))
+				return &teamDto.GetTeamResponse{TeamName: name}, nil
+			})
+	}
+
+	t.Run("Success - The metadata selects the organization", func(t *testing.T) {
+		m, conn := dial(t)
+		expectOrg(m, "payments")
+
+		assert.NoError(t, getTeam(conn, "payments"))
+	})
+
+	t.Run("Success - Calls without the metadata use the default organization", func(t *testing.T) {
+		m, conn := dial(t)
+		expectOrg(m, orgctx.DefaultID)
+
+		assert.NoError(t, getTeam(conn, ""))
+	})
+
+	t.Run("Error - Too long organization is refused", func(t *testing.T) {
+		_, conn := dial(t)
+
+		assertStatus(t, getTeam(conn, strings.Repeat("a", maxOrgIDLength+1)), codes.InvalidArgument,
+			domainErrors.CodeValidation)
+	})
+}
+
+func TestMapErrorCodeToGRPCCode(t *testing.T) {
+	tests := []struct {
+		code string
+		want codes.Code
+	}{
+		{domainErrors.CodeNotFound, codes.NotFound},
+		{domainErrors.CodeValidation, codes.InvalidArgument},
+		{domainErrors.CodeWrongTeam, codes.InvalidArgument},
+		{domainErrors.CodeNotReviewer, codes.InvalidArgument},
+		{domainErrors.CodeTeamExists, codes.AlreadyExists},
+		{domainErrors.CodePRExists, codes.AlreadyExists},
+		{domainErrors.CodePRMerged, codes.FailedPrecondition},
+		{domainErrors.CodeNoCandidate, codes.FailedPrecondition},
+		{domainErrors.CodeChangesRequested, codes.FailedPrecondition},
+		{domainErrors.CodeNoReviewers, codes.FailedPrecondition},
+		{domainErrors.CodePRClosed, codes.FailedPrecondition},
+		{domainErrors.CodeNotEmpty, codes.FailedPrecondition},
+		{domainErrors.CodeServiceOverloaded, codes.Unavailable},
+		{"SOMETHING_ELSE", codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			assert.Equal(t, tt.want, mapErrorCodeToGRPCCode(tt.code))
+		})
+	}
+}
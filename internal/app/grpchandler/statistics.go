@@ -0,0 +1,57 @@
+package grpchandler
+
+import (
+	"context"
+
+	pb "github.com/shirr9/pr-reviewer-service/api/proto/prreviewer/v1"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/statistics"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler"
+)
+
+// statisticsServer serves StatisticsService.
+type statisticsServer struct {
+	pb.UnimplementedStatisticsServiceServer
+	service handler.StatisticsService
+}
+
+// GetStatistics returns the aggregates and, on request, the statistics of teams. The paged user and
+// PR lists of the HTTP API are left out.
+func (s *statisticsServer) GetStatistics(ctx context.Context,
+	in *pb.GetStatisticsRequest) (*pb.GetStatisticsResponse, error) {
+	stats, err := s.service.GetStatistics(ctx, statistics.StatisticsRequest{
+		Include:         statistics.Include{TeamStats: in.GetIncludeTeamStats()},
+		IncludeArchived: in.GetIncludeArchived(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	out := &pb.GetStatisticsResponse{
+		TotalPrs:           int32(stats.TotalPRs),
+		OpenPrs:            int32(stats.OpenPRs),
+		MergedPrs:          int32(stats.MergedPRs),
+		TotalAssignments:   int32(stats.TotalAssignments),
+		ReassignmentEvents: int32(stats.ReassignmentEvents),
+	}
+	for _, p := range stats.ByPriority {
+		out.ByPriority = append(out.ByPriority,
+			&pb.PriorityStats{Priority: p.Priority, TotalPrs: int32(p.TotalPRs), OpenPrs: int32(p.OpenPRs)})
+	}
+	for _, l := range stats.ByLabel {
+		out.ByLabel = append(out.ByLabel,
+			&pb.LabelStats{Label: l.Label, TotalPrs: int32(l.TotalPRs), OpenPrs: int32(l.OpenPRs)})
+	}
+	for _, src := range stats.BySource {
+		out.BySource = append(out.BySource, &pb.SourceStats{Source: src.Source, Assignments: int32(src.Assignments)})
+	}
+	for _, t := range stats.TeamStats {
+		out.TeamStats = append(out.TeamStats, &pb.TeamStats{
+			TeamName:      t.TeamName,
+			Members:       int32(t.Members),
+			ActiveMembers: int32(t.ActiveMembers),
+			OpenPrs:       int32(t.OpenPRs),
+			ActiveReviews: int32(t.ActiveReviews),
+		})
+	}
+	return out, nil
+}
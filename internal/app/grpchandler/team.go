@@ -0,0 +1,116 @@
+package grpchandler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+	pb "github.com/shirr9/pr-reviewer-service/api/proto/prreviewer/v1"
+	teamDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/team"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler"
+)
+
+// teamServer serves TeamService.
+type teamServer struct {
+	pb.UnimplementedTeamServiceServer
+	service  handler.TeamService
+	validate *validator.Validate
+}
+
+// AddTeam creates a team with its members.
+func (s *teamServer) AddTeam(ctx context.Context, in *pb.AddTeamRequest) (*pb.AddTeamResponse, error) {
+	req := teamDto.AddTeamRequest{
+		TeamName:    in.GetTeamName(),
+		Description: in.GetDescription(),
+		LeadID:      in.GetLeadId(),
+		Members:     make([]teamDto.TeamMember, 0, len(in.GetMembers())),
+	}
+	for _, m := range in.GetMembers() {
+		req.Members = append(req.Members, fromTeamMember(m))
+	}
+	if err := s.validate.Struct(req); err != nil {
+		return nil, validationStatus(err)
+	}
+	resp, err := s.service.AddTeam(ctx, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	t := resp.Team
+	return &pb.AddTeamResponse{Team: toTeam(t.TeamName, t.Description, t.LeadID, t.CreatedAt, t.Members)}, nil
+}
+
+// GetTeam returns a team with its members.
+func (s *teamServer) GetTeam(ctx context.Context, in *pb.GetTeamRequest) (*pb.GetTeamResponse, error) {
+	if in.GetTeamName() == "" {
+		return nil, validationStatus(errors.New("team_name is required"))
+	}
+	t, err := s.service.GetTeam(ctx, in.GetTeamName())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.GetTeamResponse{Team: toTeam(t.TeamName, t.Description, t.LeadID, t.CreatedAt, t.Members)}, nil
+}
+
+// DeactivateTeam deactivates the members of a team.
+func (s *teamServer) DeactivateTeam(ctx context.Context,
+	in *pb.DeactivateTeamRequest) (*pb.DeactivateTeamResponse, error) {
+	req := teamDto.DeactivateTeamRequest{TeamName: in.GetTeamName()}
+	if err := s.validate.Struct(req); err != nil {
+		return nil, validationStatus(err)
+	}
+	resp, err := s.service.DeactivateTeam(ctx, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.DeactivateTeamResponse{
+		DeactivatedUsers: int32(resp.DeactivatedUsers),
+		ReassignedPrs:    int32(resp.ReassignedPRs),
+		Reassigned:       int32(resp.Reassigned),
+		Removed:          int32(resp.Removed),
+		UserIds:          resp.UserIDs,
+	}, nil
+}
+
+func fromTeamMember(m *pb.TeamMember) teamDto.TeamMember {
+	member := teamDto.TeamMember{
+		UserID:         m.GetUserId(),
+		Username:       m.GetUsername(),
+		IsActive:       m.GetIsActive(),
+		Tags:           m.GetTags(),
+		Timezone:       m.GetTimezone(),
+		WorkHoursStart: m.GetWorkHoursStart(),
+		WorkHoursEnd:   m.GetWorkHoursEnd(),
+	}
+	if m.MaxActiveReviews != nil {
+		limit := int(m.GetMaxActiveReviews())
+		member.MaxActiveReviews = &limit
+	}
+	return member
+}
+
+func toTeam(name, description, leadID, createdAt string, members []teamDto.TeamMember) *pb.Team {
+	team := &pb.Team{
+		TeamName:    name,
+		Description: description,
+		LeadId:      leadID,
+		CreatedAt:   createdAt,
+		Members:     make([]*pb.TeamMember, 0, len(members)),
+	}
+	for _, m := range members {
+		member := &pb.TeamMember{
+			UserId:         m.UserID,
+			Username:       m.Username,
+			IsActive:       m.IsActive,
+			Tags:           m.Tags,
+			Timezone:       m.Timezone,
+			WorkHoursStart: m.WorkHoursStart,
+			WorkHoursEnd:   m.WorkHoursEnd,
+		}
+		if m.MaxActiveReviews != nil {
+			limit := int32(*m.MaxActiveReviews)
+			member.MaxActiveReviews = &limit
+		}
+		team.Members = append(team.Members, member)
+	}
+	return team
+}
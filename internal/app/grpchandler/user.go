@@ -0,0 +1,67 @@
+package grpchandler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+	pb "github.com/shirr9/pr-reviewer-service/api/proto/prreviewer/v1"
+	userDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/user"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler"
+)
+
+// userServer serves UserService.
+type userServer struct {
+	pb.UnimplementedUserServiceServer
+	service  handler.UserService
+	validate *validator.Validate
+}
+
+// SetIsActive activates or deactivates a user.
+func (s *userServer) SetIsActive(ctx context.Context, in *pb.SetIsActiveRequest) (*pb.SetIsActiveResponse, error) {
+	req := userDto.SetIsActiveRequest{UserID: in.GetUserId(), IsActive: in.GetIsActive()}
+	if err := s.validate.Struct(req); err != nil {
+		return nil, validationStatus(err)
+	}
+	resp, err := s.service.SetIsActive(ctx, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	u := resp.User
+	return &pb.SetIsActiveResponse{User: &pb.User{
+		UserId:   u.UserID,
+		Username: u.Username,
+		TeamName: u.TeamName,
+		IsActive: u.IsActive,
+		Tags:     u.Tags,
+	}}, nil
+}
+
+// GetReview lists the pull requests a user is assigned to review.
+func (s *userServer) GetReview(ctx context.Context, in *pb.GetReviewRequest) (*pb.GetReviewResponse, error) {
+	if in.GetUserId() == "" {
+		return nil, validationStatus(errors.New("user_id is required"))
+	}
+	resp, err := s.service.GetReview(ctx, in.GetUserId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	out := &pb.GetReviewResponse{UserId: resp.UserID, PullRequests: make([]*pb.Review, 0, len(resp.PullRequests))}
+	for _, pr := range resp.PullRequests {
+		out.PullRequests = append(out.PullRequests, &pb.Review{
+			PullRequestId:        pr.PullRequestID,
+			PullRequestName:      pr.PullRequestName,
+			AuthorId:             pr.AuthorID,
+			Status:               pr.Status,
+			Priority:             pr.Priority,
+			Labels:               pr.Labels,
+			AssignedAt:           pr.AssignedAt,
+			AssignmentSource:     pr.Source,
+			Deadline:             pr.Deadline,
+			Overdue:              pr.Overdue,
+			ReviewState:          pr.ReviewState,
+			ReviewStateChangedAt: pr.StateChangedAt,
+		})
+	}
+	return out, nil
+}
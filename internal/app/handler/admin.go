@@ -0,0 +1,211 @@
+package handler
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mocks/mock_admin_service.go -package=mocks
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+)
+
+// ExclusionService defines the interface for reviewer exclusion operations.
+type ExclusionService interface {
+	AddExclusion(ctx context.Context, req admin.AddExclusionRequest) (*admin.ExclusionResponse, error)
+	RemoveExclusion(ctx context.Context, req admin.RemoveExclusionRequest) (*admin.RemoveExclusionResponse, error)
+	ListExclusions(ctx context.Context, req admin.ListExclusionsRequest) (*dto.Page[admin.Exclusion], error)
+}
+
+// ArchiveService defines the interface for archival of merged PRs.
+type ArchiveService interface {
+	Archive(ctx context.Context, req admin.ArchiveRequest) (*admin.ArchiveResponse, error)
+	Restore(ctx context.Context, req admin.RestoreArchivedRequest) (*admin.RestoreArchivedResponse, error)
+}
+
+// WebhookService defines the interface for inspecting and retrying webhook deliveries.
+type WebhookService interface {
+	ListDeadLetters(ctx context.Context, req admin.ListDeadLettersRequest) (*dto.Page[admin.WebhookDelivery], error)
+	Redeliver(ctx context.Context, req admin.RedeliverRequest) (*admin.RedeliverResponse, error)
+	Stats(ctx context.Context) (*admin.WebhookStatsResponse, error)
+}
+
+// AdminHandler handles administrative HTTP requests.
+type AdminHandler struct {
+	exclusions ExclusionService
+	archive    ArchiveService
+	webhooks   WebhookService
+	logger     *slog.Logger
+	validate   *validator.Validate
+}
+
+// NewAdminHandler creates a new AdminHandler.
+func NewAdminHandler(
+	exclusions ExclusionService,
+	archive ArchiveService,
+	webhooks WebhookService,
+	logger *slog.Logger,
+	validate *validator.Validate) *AdminHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if validate == nil {
+		validate = NewValidator()
+	}
+	return &AdminHandler{
+		exclusions: exclusions,
+		archive:    archive,
+		webhooks:   webhooks,
+		logger:     logger,
+		validate:   validate,
+	}
+}
+
+// AddExclusion forbids a reviewer to review PRs of an author.
+func (h *AdminHandler) AddExclusion(w http.ResponseWriter, r *http.Request) {
+	op := "AdminHandler.AddExclusion"
+	logger := h.logger.With(slog.String("op", op))
+	var req admin.AddExclusionRequest
+	if err := decodeAndValidate(r, h.validate, &req); err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	response, err := h.exclusions.AddExclusion(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusCreated, response, logger)
+}
+
+// RemoveExclusion lifts the exclusion given by "reviewer_id" and "author_id" query parameters.
+func (h *AdminHandler) RemoveExclusion(w http.ResponseWriter, r *http.Request) {
+	op := "AdminHandler.RemoveExclusion"
+	logger := h.logger.With(slog.String("op", op))
+	query := readQuery(r)
+	req := admin.RemoveExclusionRequest{
+		ReviewerID: query.get("reviewer_id"),
+		AuthorID:   query.get("author_id"),
+	}
+	if query.err != nil {
+		handleValidationError(w, query.err, logger)
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	response, err := h.exclusions.RemoveExclusion(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
+
+// ListExclusions returns a page of exclusions, optionally only those involving the "user_id" query parameter.
+func (h *AdminHandler) ListExclusions(w http.ResponseWriter, r *http.Request) {
+	op := "AdminHandler.ListExclusions"
+	logger := h.logger.With(slog.String("op", op))
+	page, err := parsePage(r, "", defaultPageLimit)
+	if err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	query := readQuery(r)
+	req := admin.ListExclusionsRequest{UserID: query.get("user_id"), Page: page}
+	if query.err != nil {
+		handleValidationError(w, query.err, logger)
+		return
+	}
+	response, err := h.exclusions.ListExclusions(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
+
+// Archive moves PRs merged before the "before" query parameter (RFC 3339), or longer ago than the
+// configured retention without it, into the archive.
+func (h *AdminHandler) Archive(w http.ResponseWriter, r *http.Request) {
+	op := "AdminHandler.Archive"
+	logger := h.logger.With(slog.String("op", op))
+	query := readQuery(r)
+	req := admin.ArchiveRequest{Before: query.time("before")}
+	if query.err != nil {
+		handleValidationError(w, query.err, logger)
+		return
+	}
+	response, err := h.archive.Archive(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
+
+// RestoreArchived moves an archived PR back.
+func (h *AdminHandler) RestoreArchived(w http.ResponseWriter, r *http.Request) {
+	op := "AdminHandler.RestoreArchived"
+	logger := h.logger.With(slog.String("op", op))
+	var req admin.RestoreArchivedRequest
+	if err := decodeAndValidate(r, h.validate, &req); err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	response, err := h.archive.Restore(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
+
+// ListDeadLetters returns a page of webhook deliveries that failed every attempt, with their attempts.
+func (h *AdminHandler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	op := "AdminHandler.ListDeadLetters"
+	logger := h.logger.With(slog.String("op", op))
+	page, err := parsePage(r, "", defaultPageLimit)
+	if err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	response, err := h.webhooks.ListDeadLetters(r.Context(), admin.ListDeadLettersRequest{Page: page})
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
+
+// Redeliver queues a dead-lettered webhook delivery again.
+func (h *AdminHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	op := "AdminHandler.Redeliver"
+	logger := h.logger.With(slog.String("op", op))
+	var req admin.RedeliverRequest
+	if err := decodeAndValidate(r, h.validate, &req); err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	response, err := h.webhooks.Redeliver(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
+
+// WebhookStats returns the counts of webhook deliveries by status and of the attempts made.
+func (h *AdminHandler) WebhookStats(w http.ResponseWriter, r *http.Request) {
+	op := "AdminHandler.WebhookStats"
+	logger := h.logger.With(slog.String("op", op))
+	response, err := h.webhooks.Stats(r.Context())
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler/mocks"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type adminCase = handlerCase[*mocks.MockExclusionService]
+
+func runAdminCases(t *testing.T, handle func(h *AdminHandler) http.HandlerFunc, cases []adminCase) {
+	t.Helper()
+	runCases(t, mocks.NewMockExclusionService, func(m *mocks.MockExclusionService) http.HandlerFunc {
+		return handle(NewAdminHandler(m, nil, nil, testLogger(), nil))
+	}, cases)
+}
+
+type archiveCase = handlerCase[*mocks.MockArchiveService]
+
+func runArchiveCases(t *testing.T, handle func(h *AdminHandler) http.HandlerFunc, cases []archiveCase) {
+	t.Helper()
+	runCases(t, mocks.NewMockArchiveService, func(m *mocks.MockArchiveService) http.HandlerFunc {
+		return handle(NewAdminHandler(nil, m, nil, testLogger(), nil))
+	}, cases)
+}
+
+type webhookCase = handlerCase[*mocks.MockWebhookService]
+
+func runWebhookCases(t *testing.T, handle func(h *AdminHandler) http.HandlerFunc, cases []webhookCase) {
+	t.Helper()
+	runCases(t, mocks.NewMockWebhookService, func(m *mocks.MockWebhookService) http.HandlerFunc {
+		return handle(NewAdminHandler(nil, nil, m, testLogger(), nil))
+	}, cases)
+}
+
+func TestAdminHandler_AddExclusion(t *testing.T) {
+	const body = `{"reviewer_id":"u2","author_id":"u1","mutual":true}`
+	req := admin.AddExclusionRequest{ReviewerID: "u2", AuthorID: "u1", Mutual: true}
+
+	runAdminCases(t, func(h *AdminHandler) http.HandlerFunc { return h.AddExclusion }, []adminCase{
+		{
+			name: "Success - Exclusion added", method: http.MethodPost, target: "/admin/exclusions", body: body,
+			setup: func(m *mocks.MockExclusionService) {
+				m.EXPECT().AddExclusion(gomock.Any(), req).Return(&admin.ExclusionResponse{
+					Exclusion: admin.Exclusion{ReviewerID: "u2", AuthorID: "u1", Mutual: true},
+				}, nil)
+			},
+			status: http.StatusCreated,
+			check: func(t *testing.T, body []byte) {
+				assert.True(t, decodeBody[admin.ExclusionResponse](t, body).Exclusion.Mutual)
+			},
+		},
+		{
+			name: "Error - Malformed JSON", method: http.MethodPost, target: "/admin/exclusions",
+			body: `{"reviewer_id":`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Reviewer is author", method: http.MethodPost, target: "/admin/exclusions",
+			body: `{"reviewer_id":"u1","author_id":"u1"}`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - User not found", method: http.MethodPost, target: "/admin/exclusions", body: body,
+			setup: func(m *mocks.MockExclusionService) {
+				m.EXPECT().AddExclusion(gomock.Any(), req).Return(nil, domainErrors.NewNotFound("user not found"))
+			},
+			status: http.StatusNotFound, code: domainErrors.CodeNotFound,
+		},
+	})
+}
+
+func TestAdminHandler_RemoveExclusion(t *testing.T) {
+	req := admin.RemoveExclusionRequest{ReviewerID: "u2", AuthorID: "u1"}
+
+	runAdminCases(t, func(h *AdminHandler) http.HandlerFunc { return h.RemoveExclusion }, []adminCase{
+		{
+			name: "Success - Exclusion removed", method: http.MethodDelete,
+			target: "/admin/exclusions?reviewer_id=u2&author_id=u1",
+			setup: func(m *mocks.MockExclusionService) {
+				m.EXPECT().RemoveExclusion(gomock.Any(), req).Return(&admin.RemoveExclusionResponse{Removed: 1}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Error - Missing author", method: http.MethodDelete, target: "/admin/exclusions?reviewer_id=u2",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Exclusion not found", method: http.MethodDelete,
+			target: "/admin/exclusions?reviewer_id=u2&author_id=u1",
+			setup: func(m *mocks.MockExclusionService) {
+				m.EXPECT().RemoveExclusion(gomock.Any(), req).Return(nil, domainErrors.NewNotFound("exclusion not found"))
+			},
+			status: http.StatusNotFound, code: domainErrors.CodeNotFound,
+		},
+	})
+}
+
+func TestAdminHandler_ListExclusions(t *testing.T) {
+	runAdminCases(t, func(h *AdminHandler) http.HandlerFunc { return h.ListExclusions }, []adminCase{
+		{
+			name: "Success - All exclusions", method: http.MethodGet, target: "/admin/exclusions",
+			setup: func(m *mocks.MockExclusionService) {
+				m.EXPECT().ListExclusions(gomock.Any(), admin.ListExclusionsRequest{
+					Page: dto.PageRequest{Limit: defaultPageLimit},
+				}).Return(&dto.Page[admin.Exclusion]{Items: []admin.Exclusion{}, Limit: defaultPageLimit}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Success - Exclusions of a user", method: http.MethodGet,
+			target: "/admin/exclusions?user_id=u1&limit=5&offset=5",
+			setup: func(m *mocks.MockExclusionService) {
+				m.EXPECT().ListExclusions(gomock.Any(), admin.ListExclusionsRequest{
+					UserID: "u1", Page: dto.PageRequest{Limit: 5, Offset: 5},
+				}).Return(&dto.Page[admin.Exclusion]{
+					Items: []admin.Exclusion{{ReviewerID: "u2", AuthorID: "u1"}}, Total: 6, Limit: 5, Offset: 5,
+				}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Error - Limit out of range", method: http.MethodGet, target: "/admin/exclusions?limit=0",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+	})
+}
+
+func TestAdminHandler_Archive(t *testing.T) {
+	before := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	runArchiveCases(t, func(h *AdminHandler) http.HandlerFunc { return h.Archive }, []archiveCase{
+		{
+			name: "Success - Retention cutoff", method: http.MethodPost, target: "/admin/archive",
+			setup: func(m *mocks.MockArchiveService) {
+				m.EXPECT().Archive(gomock.Any(), admin.ArchiveRequest{}).
+					Return(&admin.ArchiveResponse{Archived: 3, Before: "2024-10-14T00:00:00Z"}, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				assert.Equal(t, 3, decodeBody[admin.ArchiveResponse](t, body).Archived)
+			},
+		},
+		{
+			name: "Success - Explicit cutoff", method: http.MethodPost, target: "/admin/archive?before=2025-01-01T00:00:00Z",
+			setup: func(m *mocks.MockArchiveService) {
+				m.EXPECT().Archive(gomock.Any(), admin.ArchiveRequest{Before: &before}).
+					Return(&admin.ArchiveResponse{Before: "2025-01-01T00:00:00Z"}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Error - Invalid cutoff", method: http.MethodPost, target: "/admin/archive?before=yesterday",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+	})
+}
+
+func TestAdminHandler_RestoreArchived(t *testing.T) {
+	req := admin.RestoreArchivedRequest{PullRequestID: "pr-1"}
+
+	runArchiveCases(t, func(h *AdminHandler) http.HandlerFunc { return h.RestoreArchived }, []archiveCase{
+		{
+			name: "Success - PR restored", method: http.MethodPost, target: "/admin/archive/restore",
+			body: `{"pull_request_id":"pr-1"}`,
+			setup: func(m *mocks.MockArchiveService) {
+				m.EXPECT().Restore(gomock.Any(), req).Return(&admin.RestoreArchivedResponse{}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Error - Missing PR id", method: http.MethodPost, target: "/admin/archive/restore",
+			body: `{}`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - PR not archived", method: http.MethodPost, target: "/admin/archive/restore",
+			body: `{"pull_request_id":"pr-1"}`,
+			setup: func(m *mocks.MockArchiveService) {
+				m.EXPECT().Restore(gomock.Any(), req).Return(nil, domainErrors.NewNotFound("archived PR not found"))
+			},
+			status: http.StatusNotFound, code: domainErrors.CodeNotFound,
+		},
+		{
+			name: "Error - PR id taken", method: http.MethodPost, target: "/admin/archive/restore",
+			body: `{"pull_request_id":"pr-1"}`,
+			setup: func(m *mocks.MockArchiveService) {
+				m.EXPECT().Restore(gomock.Any(), req).Return(nil, domainErrors.NewPRExists("PR id already exists"))
+			},
+			status: http.StatusConflict, code: domainErrors.CodePRExists,
+		},
+	})
+}
+
+func TestAdminHandler_ListDeadLetters(t *testing.T) {
+	runWebhookCases(t, func(h *AdminHandler) http.HandlerFunc { return h.ListDeadLetters }, []webhookCase{
+		{
+			name: "Success - Page of dead deliveries", method: http.MethodGet,
+			target: "/admin/webhooks/deadletter?limit=1&offset=2",
+			setup: func(m *mocks.MockWebhookService) {
+				m.EXPECT().ListDeadLetters(gomock.Any(), admin.ListDeadLettersRequest{
+					Page: dto.PageRequest{Limit: 1, Offset: 2},
+				}).Return(&dto.Page[admin.WebhookDelivery]{
+					Items: []admin.WebhookDelivery{{DeliveryID: 7, Status: "dead", Attempts: 8}},
+					Total: 3, Limit: 1, Offset: 2,
+				}, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				page := decodeBody[dto.Page[admin.WebhookDelivery]](t, body)
+				assert.Equal(t, int64(7), page.Items[0].DeliveryID)
+				assert.Equal(t, 3, page.Total)
+			},
+		},
+		{
+			name: "Error - Offset is not a number", method: http.MethodGet, target: "/admin/webhooks/deadletter?offset=x",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+	})
+}
+
+func TestAdminHandler_Redeliver(t *testing.T) {
+	req := admin.RedeliverRequest{DeliveryID: 7}
+
+	runWebhookCases(t, func(h *AdminHandler) http.HandlerFunc { return h.Redeliver }, []webhookCase{
+		{
+			name: "Success - Delivery requeued", method: http.MethodPost, target: "/admin/webhooks/redeliver",
+			body: `{"delivery_id":7}`,
+			setup: func(m *mocks.MockWebhookService) {
+				m.EXPECT().Redeliver(gomock.Any(), req).Return(&admin.RedeliverResponse{
+					Delivery: admin.WebhookDelivery{DeliveryID: 7, Status: "pending"},
+				}, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				assert.Equal(t, "pending", decodeBody[admin.RedeliverResponse](t, body).Delivery.Status)
+			},
+		},
+		{
+			name: "Error - Missing delivery id", method: http.MethodPost, target: "/admin/webhooks/redeliver",
+			body: `{}`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Delivery not found", method: http.MethodPost, target: "/admin/webhooks/redeliver",
+			body: `{"delivery_id":7}`,
+			setup: func(m *mocks.MockWebhookService) {
+				m.EXPECT().Redeliver(gomock.Any(), req).Return(nil, domainErrors.NewNotFound("delivery not found"))
+			},
+			status: http.StatusNotFound, code: domainErrors.CodeNotFound,
+		},
+		{
+			name: "Error - Delivery is not dead", method: http.MethodPost, target: "/admin/webhooks/redeliver",
+			body: `{"delivery_id":7}`,
+			setup: func(m *mocks.MockWebhookService) {
+				m.EXPECT().Redeliver(gomock.Any(), req).
+					Return(nil, domainErrors.NewInvalidTransition("only dead deliveries can be redelivered"))
+			},
+			status: http.StatusConflict, code: domainErrors.CodeInvalidTransition,
+		},
+	})
+}
+
+func TestAdminHandler_WebhookStats(t *testing.T) {
+	runWebhookCases(t, func(h *AdminHandler) http.HandlerFunc { return h.WebhookStats }, []webhookCase{
+		{
+			name: "Success - Delivery counts", method: http.MethodGet, target: "/admin/webhooks/stats",
+			setup: func(m *mocks.MockWebhookService) {
+				m.EXPECT().Stats(gomock.Any()).Return(&admin.WebhookStatsResponse{
+					Delivered: 5, Dead: 1, Attempts: 14, FailedAttempts: 9,
+				}, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				assert.Equal(t, 9, decodeBody[admin.WebhookStatsResponse](t, body).FailedAttempts)
+			},
+		},
+	})
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// redactedValue replaces the values of redacted fields in logged bodies.
+const redactedValue = "[REDACTED]"
+
+// BodyLog configures the Debug logging of request and response bodies for diagnosing integrations.
+type BodyLog struct {
+	// MaxSize is the largest body logged as is; larger ones are logged as their SHA-256 and length
+	MaxSize int
+	// Redact masks what must not be logged in a body, reporting false for a body it can't judge,
+	// which is then logged like a large one; bodies are logged as they are without it
+	Redact func(body []byte) ([]byte, bool)
+}
+
+// RedactKeys returns a BodyLog.Redact masking the values of the JSON fields, at any depth, whose
+// names match one of the patterns case-insensitively. Patterns are those of path.Match, such as
+// "*token*". Bodies that are not JSON can't be judged.
+func RedactKeys(patterns []string) (func(body []byte) ([]byte, bool), error) {
+	lowered := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("redaction pattern %q: %w", pattern, err)
+		}
+		lowered = append(lowered, pattern)
+	}
+	redacted := func(key string) bool {
+		key = strings.ToLower(key)
+		for _, pattern := range lowered {
+			if ok, _ := path.Match(pattern, key); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(body []byte) ([]byte, bool) {
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.UseNumber()
+		var value any
+		if err := dec.Decode(&value); err != nil || dec.More() {
+			return nil, false
+		}
+		masked, err := json.Marshal(redactValue(value, redacted))
+		if err != nil {
+			return nil, false
+		}
+		return masked, true
+	}, nil
+}
+
+// redactValue replaces the values of the fields of value named as redacted reports.
+func redactValue(value any, redacted func(key string) bool) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if redacted(key) {
+				v[key] = redactedValue
+			} else {
+				v[key] = redactValue(field, redacted)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item, redacted)
+		}
+	}
+	return value
+}
+
+// withBodyLog logs the body of every request as the handler read it and the body of its response
+// at Debug level, both after the response is written. The bodies are copied as they pass, so the
+// handler reads and writes them as without the log, and only the first MaxSize bytes are kept
+// next to the hash of the whole. Upgraded connections are left alone. A nil cfg or a logger not
+// enabled for Debug leaves the handler as is.
+func withBodyLog(next http.Handler, cfg *BodyLog, logger *slog.Logger) http.Handler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if cfg == nil || !logger.Enabled(context.Background(), slog.LevelDebug) {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Upgrade") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		request := newBodyCapture(cfg.MaxSize)
+		if r.Body != nil && r.Body != http.NoBody {
+			r.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.TeeReader(r.Body, request), r.Body}
+		}
+		bw := &bodyLogWriter{ResponseWriter: w, status: http.StatusOK, body: newBodyCapture(cfg.MaxSize)}
+		next.ServeHTTP(bw, r)
+
+		logger.LogAttrs(r.Context(), slog.LevelDebug, "http bodies",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", bw.status),
+			request.attr("request", cfg),
+			bw.body.attr("response", cfg))
+	})
+}
+
+// bodyCapture keeps the start of a body and hashes all of it.
+type bodyCapture struct {
+	maxSize int
+	head    []byte
+	size    int
+	hash    hash.Hash
+}
+
+func newBodyCapture(maxSize int) *bodyCapture {
+	return &bodyCapture{maxSize: maxSize, hash: sha256.New()}
+}
+
+func (c *bodyCapture) Write(b []byte) (int, error) {
+	if keep := c.maxSize - len(c.head); keep > 0 {
+		c.head = append(c.head, b[:min(keep, len(b))]...)
+	}
+	c.size += len(b)
+	c.hash.Write(b)
+	return len(b), nil
+}
+
+// attr logs the body under name: as is, redacted, when it is within the size cap and can be
+// redacted, and otherwise as its length and hash.
+func (c *bodyCapture) attr(name string, cfg *BodyLog) slog.Attr {
+	if c.size <= cfg.MaxSize {
+		body, ok := c.head, true
+		if cfg.Redact != nil && c.size > 0 {
+			body, ok = cfg.Redact(c.head)
+		}
+		if ok {
+			return slog.String(name, string(body))
+		}
+	}
+	return slog.Group(name,
+		slog.Int("bytes", c.size),
+		slog.String("sha256", hex.EncodeToString(c.hash.Sum(nil))))
+}
+
+// bodyLogWriter copies the response body into the capture as it is written.
+type bodyLogWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        *bodyCapture
+}
+
+func (w *bodyLogWriter) WriteHeader(statusCode int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		w.status = statusCode
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *bodyLogWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	n, err := w.ResponseWriter.Write(b)
+	_, _ = w.body.Write(b[:n])
+	return n, err
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *bodyLogWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactKeys(t *testing.T) {
+	redact, err := RedactKeys([]string{"*token*", "email"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		body string
+		want string
+		ok   bool
+	}{
+		{"Success - Fields at any depth", `{"user_id":"u1","Email":"a@b.c","items":[{"access_token":"x","n":1.50}]}`,
+			`{"Email":"[REDACTED]","items":[{"access_token":"[REDACTED]","n":1.50}],"user_id":"u1"}`, true},
+		{"Success - Nothing to redact", `[1,"two"]`, `[1,"two"]`, true},
+		{"Error - Not JSON", "user_id,username\nu1,Alice\n", "", false},
+		{"Error - Several values", `{} {}`, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, ok := redact([]byte(tt.body))
+
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.JSONEq(t, tt.want, string(body))
+			}
+		})
+	}
+
+	t.Run("Error - Malformed pattern", func(t *testing.T) {
+		_, err := RedactKeys([]string{"[token"})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestWithBodyLog(t *testing.T) {
+	redact, err := RedactKeys([]string{"*token*", "email"})
+	require.NoError(t, err)
+	cfg := &BodyLog{MaxSize: 64, Redact: redact}
+
+	// serve runs an echo handler behind the body log, returning the body the handler read, the
+	// response and the logged entry
+	serve := func(t *testing.T, level slog.Level, body string) (string, *httptest.ResponseRecorder, map[string]any) {
+		t.Helper()
+		var logs bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: level}))
+		var read []byte
+		echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			read, _ = io.ReadAll(r.Body)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write(read)
+		})
+
+		rec := httptest.NewRecorder()
+		withBodyLog(echo, cfg, logger).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/team/add",
+			strings.NewReader(body)))
+
+		var entry map[string]any
+		if logs.Len() > 0 {
+			require.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+		}
+		return string(read), rec, entry
+	}
+
+	t.Run("Success - Bodies are logged redacted and passed on whole", func(t *testing.T) {
+		body := `{"team_name":"backend","email":"a@b.c"}`
+
+		read, rec, entry := serve(t, slog.LevelDebug, body)
+
+		assert.Equal(t, body, read)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, body, rec.Body.String())
+		require.NotNil(t, entry)
+		assert.Equal(t, "http bodies", entry["msg"])
+		assert.Equal(t, float64(http.StatusCreated), entry["status"])
+		assert.JSONEq(t, `{"team_name":"backend","email":"[REDACTED]"}`, entry["request"].(string))
+		assert.JSONEq(t, `{"team_name":"backend","email":"[REDACTED]"}`, entry["response"].(string))
+		assert.NotContains(t, entry["request"], "a@b.c")
+	})
+
+	t.Run("Success - Large bodies are logged as their hash", func(t *testing.T) {
+		body := `{"team_name":"` + strings.Repeat("x", 100) + `"}`
+		sum := sha256.Sum256([]byte(body))
+
+		read, rec, entry := serve(t, slog.LevelDebug, body)
+
+		assert.Equal(t, body, read)
+		assert.Equal(t, body, rec.Body.String())
+		require.NotNil(t, entry)
+		want := map[string]any{"bytes": float64(len(body)), "sha256": hex.EncodeToString(sum[:])}
+		assert.Equal(t, want, entry["request"])
+		assert.Equal(t, want, entry["response"])
+	})
+
+	t.Run("Success - Bodies that can't be redacted are logged as their hash", func(t *testing.T) {
+		_, _, entry := serve(t, slog.LevelDebug, "user_id,email\nu1,a@b.c\n")
+
+		require.NotNil(t, entry)
+		assert.NotContains(t, entry["request"], "a@b.c")
+		assert.Contains(t, entry["request"], "sha256")
+	})
+
+	t.Run("Success - Nothing is logged above Debug", func(t *testing.T) {
+		body := `{"team_name":"backend"}`
+
+		read, rec, entry := serve(t, slog.LevelInfo, body)
+
+		assert.Equal(t, body, read)
+		assert.Equal(t, body, rec.Body.String())
+		assert.Nil(t, entry)
+	})
+}
@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/statistics"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// serveCompressed serves the request through the compression wrapper with a 100-byte threshold.
+func serveCompressed(t *testing.T, handler http.HandlerFunc, acceptEncoding string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	rec := httptest.NewRecorder()
+	withCompression(handler, 100).ServeHTTP(rec, req)
+	return rec
+}
+
+// gunzip decompresses the body, failing the test if it is not gzip.
+func gunzip(t *testing.T, body []byte) string {
+	t.Helper()
+	zr, err := gzip.NewReader(strings.NewReader(string(body)))
+	if err != nil {
+		t.Fatalf("body is not gzip: %v", err)
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("failed to decompress body: %v", err)
+	}
+	return string(data)
+}
+
+func TestWithCompression(t *testing.T) {
+	large := strings.Repeat(`{"user_id":"u1"},`, 50)
+	respondJSON := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			// written in parts, as the JSON encoder may do
+			_, _ = io.WriteString(w, body[:len(body)/2])
+			_, _ = io.WriteString(w, body[len(body)/2:])
+		}
+	}
+
+	t.Run("Success - Large body is compressed", func(t *testing.T) {
+		rec := serveCompressed(t, respondJSON(large), "deflate, gzip;q=0.8")
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		assert.Less(t, rec.Body.Len(), len(large))
+		assert.Equal(t, large, gunzip(t, rec.Body.Bytes()))
+	})
+
+	t.Run("Success - Small body is sent as is", func(t *testing.T) {
+		rec := serveCompressed(t, respondJSON(`{"ok":true}`), "gzip")
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+		assert.Equal(t, `{"ok":true}`, rec.Body.String())
+	})
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+	}{
+		{"Success - gzip not accepted", ""},
+		{"Success - Other encodings only", "br, deflate"},
+		{"Success - gzip refused with q=0", "gzip;q=0, br"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveCompressed(t, respondJSON(large), tt.acceptEncoding)
+
+			assert.Empty(t, rec.Header().Get("Content-Encoding"))
+			assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+			assert.Equal(t, large, rec.Body.String())
+		})
+	}
+
+	t.Run("Success - Event stream is not compressed and is flushed", func(t *testing.T) {
+		rec := serveCompressed(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			for i := range 20 {
+				_, _ = fmt.Fprintf(w, "data: event %d padded to be long enough\n\n", i)
+				assert.NoError(t, http.NewResponseController(w).Flush())
+			}
+		}, "gzip")
+
+		assert.True(t, rec.Flushed)
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.True(t, strings.HasPrefix(rec.Body.String(), "data: event 0"))
+	})
+
+	t.Run("Success - Already encoded body is left alone", func(t *testing.T) {
+		rec := serveCompressed(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Encoding", "br")
+			_, _ = io.WriteString(w, large)
+		}, "gzip, br")
+
+		assert.Equal(t, "br", rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, large, rec.Body.String())
+	})
+
+	t.Run("Success - Not modified has no body", func(t *testing.T) {
+		rec := serveCompressed(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", `"v1"`)
+			w.WriteHeader(http.StatusNotModified)
+		}, "gzip")
+
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, `"v1"`, rec.Header().Get("ETag"))
+		assert.Empty(t, rec.Body.String())
+	})
+}
+
+// BenchmarkStatisticsCompression serves a statistics response of 500 users and 500 PRs through the
+// router's compression with and without gzip accepted; "wire-bytes" is the size sent to the client.
+func BenchmarkStatisticsCompression(b *testing.B) {
+	stats := &statistics.StatisticsResponse{}
+	users := make([]statistics.UserStats, 0, 500)
+	prs := make([]statistics.PRStats, 0, 500)
+	for i := range 500 {
+		users = append(users, statistics.UserStats{UserID: fmt.Sprintf("user-%d", i),
+			Username: fmt.Sprintf("User %d", i), AssignmentsCount: i % 7, ActiveReviews: i % 3, Weight: 1.5})
+		prs = append(prs, statistics.PRStats{PullRequestID: fmt.Sprintf("pr-%d", i),
+			PullRequestName: fmt.Sprintf("Feature %d", i), ReviewersCount: 2, Status: "OPEN",
+			Priority: "NORMAL", Labels: []string{"backend"}})
+	}
+	userPage := dto.NewPage(users, len(users), dto.PageRequest{Limit: len(users)})
+	prPage := dto.NewPage(prs, len(prs), dto.PageRequest{Limit: len(prs)})
+	stats.UserStats, stats.PRStats = &userPage, &prPage
+
+	ctrl := gomock.NewController(b)
+	m := mocks.NewMockStatisticsService(ctrl)
+	m.EXPECT().GetStatistics(gomock.Any(), gomock.Any()).Return(stats, nil).AnyTimes()
+	handler := withCompression(http.HandlerFunc(NewStatisticsHandler(m, testLogger()).GetStatistics), compressMinSize)
+
+	for _, acceptEncoding := range []string{"identity", "gzip"} {
+		b.Run(acceptEncoding, func(b *testing.B) {
+			b.ReportAllocs()
+			var size int
+			for b.Loop() {
+				req := httptest.NewRequest(http.MethodGet, "/statistics", nil)
+				req.Header.Set("Accept-Encoding", acceptEncoding)
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, req)
+				size = rec.Body.Len()
+			}
+			b.ReportMetric(float64(size), "wire-bytes")
+		})
+	}
+}
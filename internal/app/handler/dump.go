@@ -0,0 +1,137 @@
+package handler
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mocks/mock_dump_service.go -package=mocks
+
+import (
+	"context"
+	"crypto/subtle"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+)
+
+// DumpService defines the interface for exporting and importing all data.
+type DumpService interface {
+	Export(ctx context.Context, w io.Writer) error
+	Import(ctx context.Context, req admin.ImportDumpRequest) (*admin.ImportDumpResponse, error)
+}
+
+// DumpHandler handles the export and import of all data, which require a bearer token.
+type DumpHandler struct {
+	service  DumpService
+	token    string
+	logger   *slog.Logger
+	validate *validator.Validate
+}
+
+// NewDumpHandler creates a new DumpHandler accepting requests with the token.
+func NewDumpHandler(service DumpService, token string, logger *slog.Logger, validate *validator.Validate) *DumpHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if validate == nil {
+		validate = NewValidator()
+	}
+	return &DumpHandler{
+		service:  service,
+		token:    token,
+		logger:   logger,
+		validate: validate,
+	}
+}
+
+// Export streams all teams, users, PRs and reviewer assignments as an admin.Dump document. An
+// error before anything was sent is answered as usual; after that the connection is aborted, so
+// the client doesn't take the document it got for a complete one.
+func (h *DumpHandler) Export(w http.ResponseWriter, r *http.Request) {
+	op := "DumpHandler.Export"
+	logger := h.logger.With(slog.String("op", op))
+	if !authorizeBearer(w, r, h.token) {
+		return
+	}
+
+	// the export runs as long as the service allows, past the write timeout meant for single requests
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+	out := &dumpResponseWriter{w: w}
+	if err := h.service.Export(r.Context(), out); err != nil {
+		if !out.started {
+			handleServiceError(w, err, logger)
+			return
+		}
+		logger.LogAttrs(r.Context(), slog.LevelWarn, "export aborted", slog.String("error", err.Error()))
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// Import loads an admin.Dump document, as written by Export, into an empty storage, or replaces
+// everything stored when the "force" query parameter is true. Answers 201 with the number of
+// imported records, or 200 when the stored data was replaced.
+func (h *DumpHandler) Import(w http.ResponseWriter, r *http.Request) {
+	op := "DumpHandler.Import"
+	logger := h.logger.With(slog.String("op", op))
+	if !authorizeBearer(w, r, h.token) {
+		return
+	}
+
+	query := readQuery(r)
+	req := admin.ImportDumpRequest{Force: query.boolean("force")}
+	if query.err != nil {
+		handleValidationError(w, query.err, logger)
+		return
+	}
+	// a whole dump takes longer to upload and load than the timeouts meant for single requests
+	rc := http.NewResponseController(w)
+	_ = rc.SetReadDeadline(time.Time{})
+	_ = rc.SetWriteDeadline(time.Time{})
+	if err := decodeAndValidate(r, h.validate, &req.Dump); err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+
+	response, err := h.service.Import(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	status := http.StatusCreated
+	if response.Cleared {
+		status = http.StatusOK
+	}
+	sendSuccessResponse(w, status, response, logger)
+}
+
+// authorizeBearer checks the bearer token of the request against want, answering 401 when it
+// doesn't match. No token is valid when want is empty.
+func authorizeBearer(w http.ResponseWriter, r *http.Request, want string) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if ok && want != "" && subtle.ConstantTimeCompare([]byte(token), []byte(want)) == 1 {
+		return true
+	}
+	w.Header().Set("WWW-Authenticate", "Bearer")
+	_ = RespondWithCustomError(w, http.StatusUnauthorized,
+		dto.NewErrorResponse(CodeUnauthorized, "a valid bearer token is required"))
+	return false
+}
+
+// dumpResponseWriter sends the headers of the document with its first bytes, so an error before
+// them can still be answered with an error response.
+type dumpResponseWriter struct {
+	w       http.ResponseWriter
+	started bool
+}
+
+func (d *dumpResponseWriter) Write(b []byte) (int, error) {
+	if !d.started {
+		d.started = true
+		d.w.Header().Set("Content-Type", "application/json")
+		d.w.Header().Set("Content-Disposition", `attachment; filename="pr-reviewer-export.json"`)
+		d.w.WriteHeader(http.StatusOK)
+	}
+	return d.w.Write(b)
+}
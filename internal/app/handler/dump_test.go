@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler/mocks"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+const testToken = "secret"
+
+func exportRequest(authorization string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/admin/export", nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	return req
+}
+
+func TestDumpHandler_Export(t *testing.T) {
+	t.Run("Success - Document is streamed", func(t *testing.T) {
+		m := mocks.NewMockDumpService(gomock.NewController(t))
+		m.EXPECT().Export(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, w io.Writer) error {
+			_, err := io.WriteString(w, `{"schema_version":1}`)
+			return err
+		})
+
+		rec := httptest.NewRecorder()
+		NewDumpHandler(m, testToken, testLogger(), nil).Export(rec, exportRequest("Bearer "+testToken))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Header().Get("Content-Disposition"), "attachment")
+		assert.Equal(t, `{"schema_version":1}`, rec.Body.String())
+	})
+
+	for _, authorization := range []string{"", "Bearer wrong", "Basic " + testToken, testToken} {
+		t.Run("Error - Unauthorized with "+authorization, func(t *testing.T) {
+			m := mocks.NewMockDumpService(gomock.NewController(t))
+
+			rec := httptest.NewRecorder()
+			NewDumpHandler(m, testToken, testLogger(), nil).Export(rec, exportRequest(authorization))
+
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			assert.Equal(t, "Bearer", rec.Header().Get("WWW-Authenticate"))
+			assert.Equal(t, CodeUnauthorized, decodeBody[dto.ErrorResponse](t, rec.Body.Bytes()).Error.Code)
+		})
+	}
+
+	t.Run("Error - Failure before the document is answered", func(t *testing.T) {
+		m := mocks.NewMockDumpService(gomock.NewController(t))
+		m.EXPECT().Export(gomock.Any(), gomock.Any()).Return(domainErrors.NewBadRequest("not now"))
+
+		rec := httptest.NewRecorder()
+		NewDumpHandler(m, testToken, testLogger(), nil).Export(rec, exportRequest("Bearer "+testToken))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Empty(t, rec.Header().Get("Content-Disposition"))
+		assert.Equal(t, domainErrors.CodeBadRequest, decodeBody[dto.ErrorResponse](t, rec.Body.Bytes()).Error.Code)
+	})
+
+	t.Run("Error - Failure while streaming aborts the response", func(t *testing.T) {
+		m := mocks.NewMockDumpService(gomock.NewController(t))
+		m.EXPECT().Export(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, w io.Writer) error {
+			_, _ = io.WriteString(w, `{"schema_version":1,`)
+			return assert.AnError
+		})
+
+		rec := httptest.NewRecorder()
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			NewDumpHandler(m, testToken, testLogger(), nil).Export(rec, exportRequest("Bearer "+testToken))
+		})
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func importRequest(target, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	return req
+}
+
+func TestDumpHandler_Import(t *testing.T) {
+	const body = `{"schema_version":1,"teams":[{"team_name":"backend","description":""}],"users":[],` +
+		`"pull_requests":[],"reviewers":[],"checksums":{}}`
+
+	tests := []struct {
+		name   string
+		target string
+		body   string
+		setup  func(m *mocks.MockDumpService)
+		status int
+		code   string
+		check  func(t *testing.T, body []byte)
+	}{
+		{
+			name: "Success - Imported into empty storage", target: "/admin/import", body: body,
+			setup: func(m *mocks.MockDumpService) {
+				m.EXPECT().Import(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, req admin.ImportDumpRequest) (*admin.ImportDumpResponse, error) {
+						assert.False(t, req.Force)
+						assert.Equal(t, []admin.DumpTeam{{TeamName: "backend"}}, req.Dump.Teams)
+						return &admin.ImportDumpResponse{Teams: 1}, nil
+					})
+			},
+			status: http.StatusCreated,
+			check: func(t *testing.T, body []byte) {
+				assert.Equal(t, admin.ImportDumpResponse{Teams: 1}, decodeBody[admin.ImportDumpResponse](t, body))
+			},
+		},
+		{
+			name: "Success - Stored data replaced with force", target: "/admin/import?force=true", body: body,
+			setup: func(m *mocks.MockDumpService) {
+				m.EXPECT().Import(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, req admin.ImportDumpRequest) (*admin.ImportDumpResponse, error) {
+						assert.True(t, req.Force)
+						return &admin.ImportDumpResponse{Teams: 1, Cleared: true}, nil
+					})
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				assert.True(t, decodeBody[admin.ImportDumpResponse](t, body).Cleared)
+			},
+		},
+		{
+			name: "Error - Invalid force", target: "/admin/import?force=maybe", body: body,
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Invalid record", target: "/admin/import",
+			body:   `{"schema_version":1,"users":[{"user_id":"u1","username":"Alice","team_name":""}]}`,
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+			check: func(t *testing.T, body []byte) {
+				details := decodeBody[struct {
+					Error struct {
+						Details dto.ValidationDetails `json:"details"`
+					} `json:"error"`
+				}](t, body).Error.Details
+				assert.Equal(t, []dto.FieldError{{Field: "users[0].team_name", Rule: "required"}}, details.Fields)
+			},
+		},
+		{
+			name: "Error - Storage not empty", target: "/admin/import", body: body,
+			setup: func(m *mocks.MockDumpService) {
+				m.EXPECT().Import(gomock.Any(), gomock.Any()).Return(nil, domainErrors.NewNotEmpty("not empty"))
+			},
+			status: http.StatusConflict, code: domainErrors.CodeNotEmpty,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mocks.NewMockDumpService(gomock.NewController(t))
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+
+			rec := httptest.NewRecorder()
+			NewDumpHandler(m, testToken, testLogger(), nil).Import(rec, importRequest(tt.target, tt.body))
+
+			assert.Equal(t, tt.status, rec.Code, rec.Body.String())
+			if tt.code != "" {
+				assert.Equal(t, tt.code, decodeBody[dto.ErrorResponse](t, rec.Body.Bytes()).Error.Code)
+			}
+			if tt.check != nil {
+				tt.check(t, rec.Body.Bytes())
+			}
+		})
+	}
+
+	t.Run("Error - Unauthorized", func(t *testing.T) {
+		m := mocks.NewMockDumpService(gomock.NewController(t))
+		req := importRequest("/admin/import", body)
+		req.Header.Set("Authorization", "Bearer wrong")
+
+		rec := httptest.NewRecorder()
+		NewDumpHandler(m, testToken, testLogger(), nil).Import(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
@@ -0,0 +1,46 @@
+package handler
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mocks/mock_duplicate_service.go -package=mocks
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+)
+
+// DuplicateService defines the interface for finding near-duplicate identifiers.
+type DuplicateService interface {
+	FindNearDuplicates(ctx context.Context) (*admin.NearDuplicatesResponse, error)
+}
+
+// DuplicateHandler handles the report of near-duplicate identifiers.
+type DuplicateHandler struct {
+	service DuplicateService
+	logger  *slog.Logger
+}
+
+// NewDuplicateHandler creates a new DuplicateHandler.
+func NewDuplicateHandler(service DuplicateService, logger *slog.Logger) *DuplicateHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &DuplicateHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// FindNearDuplicates lists the stored user ids, team names and PR ids that differ only in
+// surrounding whitespace or case, which requests no longer can since identifiers are normalized.
+func (h *DuplicateHandler) FindNearDuplicates(w http.ResponseWriter, r *http.Request) {
+	op := "DuplicateHandler.FindNearDuplicates"
+	logger := h.logger.With(slog.String("op", op))
+	response, err := h.service.FindNearDuplicates(r.Context())
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type duplicateCase = handlerCase[*mocks.MockDuplicateService]
+
+func TestDuplicateHandler_FindNearDuplicates(t *testing.T) {
+	duplicates := &admin.NearDuplicatesResponse{Duplicates: []admin.NearDuplicate{
+		{Kind: "user", Key: "user-1", IDs: []string{"user-1", "user-1 "}},
+	}}
+
+	runCases(t, mocks.NewMockDuplicateService, func(m *mocks.MockDuplicateService) http.HandlerFunc {
+		return NewDuplicateHandler(m, testLogger()).FindNearDuplicates
+	}, []duplicateCase{
+		{
+			name: "Success - Duplicates listed", method: http.MethodGet, target: "/admin/duplicates",
+			setup: func(m *mocks.MockDuplicateService) {
+				m.EXPECT().FindNearDuplicates(gomock.Any()).Return(duplicates, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				assert.Equal(t, *duplicates, decodeBody[admin.NearDuplicatesResponse](t, body))
+			},
+		},
+		{
+			name: "Error - Repository failure", method: http.MethodGet, target: "/admin/duplicates",
+			setup: func(m *mocks.MockDuplicateService) {
+				m.EXPECT().FindNearDuplicates(gomock.Any()).Return(nil, errors.New("connection refused"))
+			},
+			status: http.StatusInternalServerError, code: CodeInternalError,
+		},
+	})
+}
@@ -1,37 +1,136 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
+	"maps"
 	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
 )
 
-// decodeAndValidate decode and validate request body.
+// decodeAndValidate decode, normalize and validate request body.
 func decodeAndValidate(r *http.Request, v *validator.Validate, target interface{}) error {
 	if err := json.NewDecoder(r.Body).Decode(target); err != nil {
 		return err
 	}
+	if err := requestNormalizer(r).normalize(target); err != nil {
+		return err
+	}
 	if err := v.Struct(target); err != nil {
 		return err
 	}
 	return nil
 }
 
-// handleValidationError handles validation error and logs it.
+// NewValidator creates a validator naming fields in its errors by their JSON names.
+func NewValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	return v
+}
+
+// handleValidationError responds with VALIDATION_ERROR, listing the fields that failed in the details.
 func handleValidationError(w http.ResponseWriter, err error, logger *slog.Logger) {
-	respErr := RespondWithCustomError(w, http.StatusBadRequest,
-		dto.NewErrorResponse(CodeBadRequest, err.Error()))
-	if respErr != nil {
+	appErr := domainErrors.NewValidation(err.Error())
+	if fields := fieldErrors(err); len(fields) > 0 {
+		appErr.WithDetails(dto.ValidationDetails{Fields: fields})
+	}
+	if respErr := RespondWithError(w, appErr); respErr != nil {
 		logger.Error("failed to send validation error response",
 			slog.String("error", respErr.Error()))
 	}
 }
 
-// handleServiceError handles service error and logs it.
+// fieldErrors describes the fields of a validation, blank identifier or JSON type error, prefixed
+// with the member of a memberError; other errors have none.
+func fieldErrors(err error) []dto.FieldError {
+	var memberErr *memberError
+	if errors.As(err, &memberErr) {
+		fields := fieldErrors(memberErr.err)
+		for i := range fields {
+			fields[i].Field = fmt.Sprintf("members[%d].%s", memberErr.index, fields[i].Field)
+		}
+		return fields
+	}
+
+	var blankErr *blankFieldError
+	if errors.As(err, &blankErr) {
+		return []dto.FieldError{{Field: blankErr.field, Rule: "notblank"}}
+	}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]dto.FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			// the namespace starts with the request struct name, which means nothing to clients
+			_, field, _ := strings.Cut(fe.Namespace(), ".")
+			fields = append(fields, dto.FieldError{Field: field, Rule: fe.Tag(), Param: fe.Param()})
+		}
+		return fields
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return []dto.FieldError{{Field: typeErr.Field, Rule: "type", Param: typeErr.Type.String()}}
+	}
+	return nil
+}
+
+// statusClientClosedRequest is nginx's status for a request the client gave up on before the response.
+const statusClientClosedRequest = 499
+
+// errorLevel is the level a failed request is logged at: Info when its client canceled it, Warn when
+// it ran out of its timeout and Error otherwise.
+func errorLevel(err error) slog.Level {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return slog.LevelInfo
+	case errors.Is(err, context.DeadlineExceeded):
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
+
+// handleServiceError handles service error and logs it. A request canceled by its client gets no
+// body, only the 499 status for the access log, and is logged at Info; one that ran out of its
+// timeout is answered 504 and logged at Warn, as is one that got no database connection in time.
 func handleServiceError(w http.ResponseWriter, err error, logger *slog.Logger) {
+	switch {
+	case domainErrors.HasCode(err, domainErrors.CodeServiceOverloaded):
+		logger.Warn("no database connection available",
+			slog.Int("status", http.StatusServiceUnavailable),
+			slog.String("error", err.Error()),
+		)
+	case errors.Is(err, context.Canceled):
+		logger.Info("request canceled by client",
+			slog.Int("status", statusClientClosedRequest),
+			slog.String("error", err.Error()),
+		)
+	case errors.Is(err, context.DeadlineExceeded):
+		logger.Warn("request timed out",
+			slog.Int("status", http.StatusGatewayTimeout),
+			slog.String("error", err.Error()),
+		)
+	}
 	if respErr := RespondWithError(w, err); respErr != nil {
 		logger.Error("unexpected error in handler",
 			slog.String("error", err.Error()),
@@ -48,3 +147,202 @@ func sendSuccessResponse(w http.ResponseWriter, statusCode int, data interface{}
 		)
 	}
 }
+
+// notModified sets the ETag header and, when If-None-Match lists the ETag or is "*", answers 304
+// without a body and reports true. Weak validators match their strong counterpart.
+func notModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	if etag == "" {
+		return false
+	}
+	w.Header().Set("ETag", etag)
+	for _, candidate := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+// notModifiedSince sets the Last-Modified header and, when If-Modified-Since is not before it,
+// answers 304 without a body and reports true. HTTP dates have whole seconds, so the header is set
+// only once the second of lastModified is over; a later change in that second would otherwise
+// carry the same date and be answered 304.
+func notModifiedSince(w http.ResponseWriter, r *http.Request, lastModified time.Time) bool {
+	lastModified = lastModified.Truncate(time.Second)
+	if lastModified.IsZero() || time.Since(lastModified) < time.Second {
+		return false
+	}
+	w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil || lastModified.After(since) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// queryParams reads the single-valued query parameters of a request. Values are trimmed of
+// surrounding whitespace and identifiers normalized like those of bodies; a parameter given more than
+// once or with an empty value is rejected with an error naming it, rather than one of the values
+// being used silently. The first error is kept in err, so a handler reads all its parameters and
+// checks err once. List parameters, which may be repeated, are read by parseList.
+type queryParams struct {
+	values     url.Values
+	normalizer normalizer
+	err        error
+}
+
+// readQuery parses the query of the request.
+func readQuery(r *http.Request) *queryParams {
+	return &queryParams{values: r.URL.Query(), normalizer: requestNormalizer(r)}
+}
+
+// fail keeps err unless an earlier read failed.
+func (q *queryParams) fail(err error) {
+	if q.err == nil {
+		q.err = err
+	}
+}
+
+// has reports whether the named parameter is present.
+func (q *queryParams) has(name string) bool {
+	return q.values.Has(name)
+}
+
+// get returns the named parameter, "" when it is absent or rejected.
+func (q *queryParams) get(name string) string {
+	values := q.values[name]
+	switch {
+	case len(values) == 0:
+		return ""
+	case len(values) > 1:
+		q.fail(fmt.Errorf("%s must be given once", name))
+		return ""
+	}
+	value := q.normalizer.identifier(name, values[0])
+	if value == "" {
+		q.fail(fmt.Errorf("%s must not be empty", name))
+	}
+	return value
+}
+
+// required returns the named parameter, failing when it is absent.
+func (q *queryParams) required(name string) string {
+	if !q.has(name) {
+		q.fail(fmt.Errorf("%s is required", name))
+		return ""
+	}
+	return q.get(name)
+}
+
+// boolean parses the named parameter as a boolean, false when it is absent.
+func (q *queryParams) boolean(name string) bool {
+	value := q.get(name)
+	if value == "" {
+		return false
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		q.fail(fmt.Errorf("%s must be a boolean", name))
+	}
+	return parsed
+}
+
+// integer parses the named parameter as an integer, fallback when it is absent.
+func (q *queryParams) integer(name string, fallback int) int {
+	value := q.get(name)
+	if value == "" {
+		return fallback
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		q.fail(fmt.Errorf("%s must be an integer", name))
+		return fallback
+	}
+	return parsed
+}
+
+// time parses the named parameter as an RFC 3339 time, nil when it is absent.
+func (q *queryParams) time(name string) *time.Time {
+	value := q.get(name)
+	if value == "" {
+		return nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		q.fail(fmt.Errorf("%s must be an RFC 3339 time", name))
+		return nil
+	}
+	return &parsed
+}
+
+// expandsReviewers reports whether the "expand" query parameter requests reviewer details.
+func expandsReviewers(r *http.Request) bool {
+	return slices.Contains(parseList(r, "expand"), "reviewers")
+}
+
+// parseList collects values of the named query parameter, each holding a comma-separated list; the
+// parameter may be repeated.
+func parseList(r *http.Request, name string) []string {
+	var values []string
+	for _, param := range r.URL.Query()[name] {
+		for _, value := range strings.Split(param, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// sortFields lists the fields a list endpoint can be sorted by.
+type sortFields map[string]bool
+
+// parseSort parses the "sort" query parameter: a field, descending when prefixed with "-". Only the
+// fields allowed for the endpoint are accepted, so repositories never see an unchecked value;
+// without the parameter the zero Sort keeps the default order of the list.
+func parseSort(r *http.Request, allowed sortFields) (dto.Sort, error) {
+	query := readQuery(r)
+	value := query.get("sort")
+	if query.err != nil || value == "" {
+		return dto.Sort{}, query.err
+	}
+	field, desc := strings.CutPrefix(value, "-")
+	if !allowed[field] {
+		return dto.Sort{}, fmt.Errorf("sort must be one of %s, prefixed with - for descending order",
+			strings.Join(slices.Sorted(maps.Keys(allowed)), ", "))
+	}
+	return dto.Sort{Field: field, Desc: desc}, nil
+}
+
+// maxPageLimit is the largest page a list endpoint returns.
+const maxPageLimit = 100
+
+// parsePage parses the "<prefix>limit" and "<prefix>offset" query parameters, applying defaultLimit
+// when the limit is absent. A value that is not a number or is out of range fails with the same message,
+// so all list endpoints reject bad pagination alike.
+func parsePage(r *http.Request, prefix string, defaultLimit int) (dto.PageRequest, error) {
+	query := readQuery(r)
+	limit, offset := query.get(prefix+"limit"), query.get(prefix+"offset")
+	if query.err != nil {
+		return dto.PageRequest{}, query.err
+	}
+	page := dto.PageRequest{Limit: defaultLimit}
+	if limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed < 1 || parsed > maxPageLimit {
+			return dto.PageRequest{}, fmt.Errorf("%slimit must be an integer between 1 and %d", prefix, maxPageLimit)
+		}
+		page.Limit = parsed
+	}
+	if offset != "" {
+		parsed, err := strconv.Atoi(offset)
+		if err != nil || parsed < 0 {
+			return dto.PageRequest{}, fmt.Errorf("%soffset must be a non-negative integer", prefix)
+		}
+		page.Offset = parsed
+	}
+	return page, nil
+}
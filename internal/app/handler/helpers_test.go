@@ -0,0 +1,350 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// handlerCase is a request to a handler backed by a mocked service of type M.
+// setup registers the expected service calls, a non-empty code is the expected error code.
+type handlerCase[M any] struct {
+	name   string
+	method string
+	target string
+	body   string
+	// token is sent as the bearer token of the request
+	token  string
+	setup  func(m M)
+	status int
+	code   string
+	check  func(t *testing.T, body []byte)
+}
+
+// runCases serves every case with a fresh mock, so unexpected service calls fail the case.
+func runCases[M any](t *testing.T, newMock func(*gomock.Controller) M, handle func(M) http.HandlerFunc,
+	cases []handlerCase[M]) {
+	t.Helper()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := newMock(ctrl)
+			if tc.setup != nil {
+				tc.setup(m)
+			}
+
+			var body io.Reader
+			if tc.body != "" {
+				body = strings.NewReader(tc.body)
+			}
+			req := httptest.NewRequest(tc.method, tc.target, body)
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			rec := httptest.NewRecorder()
+			handle(m)(rec, req)
+
+			assert.Equal(t, tc.status, rec.Code, rec.Body.String())
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+			if tc.code != "" {
+				var errResp dto.ErrorResponse
+				if assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errResp)) {
+					assert.Equal(t, tc.code, errResp.Error.Code)
+					assert.NotEmpty(t, errResp.Error.Message)
+				}
+			}
+			if tc.check != nil {
+				tc.check(t, rec.Body.Bytes())
+			}
+		})
+	}
+}
+
+// decodeBody decodes a response body in a check, failing the test on malformed JSON.
+func decodeBody[T any](t *testing.T, body []byte) T {
+	t.Helper()
+	var target T
+	if err := json.Unmarshal(body, &target); err != nil {
+		t.Fatalf("failed to decode response %s: %v", body, err)
+	}
+	return target
+}
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// newTestServer serves the router of the services over a real listener until the test ends.
+func newTestServer(t *testing.T, services Services) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(NewRouter(services, testLogger(), nil))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHandleValidationError(t *testing.T) {
+	type member struct {
+		UserID string `json:"user_id" validate:"required"`
+	}
+	type request struct {
+		TeamName string   `json:"team_name" validate:"required,max=5"`
+		Members  []member `json:"members" validate:"dive"`
+		Internal string   `json:"-" validate:"required"`
+	}
+	respond := func(t *testing.T, body string) dto.ErrorResponse {
+		t.Helper()
+		var req request
+		err := decodeAndValidate(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)), NewValidator(), &req)
+		if err == nil {
+			t.Fatal("expected the request to be rejected")
+		}
+		rec := httptest.NewRecorder()
+		handleValidationError(rec, err, testLogger())
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		return decodeBody[dto.ErrorResponse](t, rec.Body.Bytes())
+	}
+	fields := func(t *testing.T, resp dto.ErrorResponse) []dto.FieldError {
+		t.Helper()
+		data, err := json.Marshal(resp.Error.Details)
+		if err != nil {
+			t.Fatalf("failed to encode details: %v", err)
+		}
+		return decodeBody[dto.ValidationDetails](t, data).Fields
+	}
+
+	t.Run("Error - Failed rules are listed by JSON path", func(t *testing.T) {
+		resp := respond(t, `{"team_name":"backend","members":[{"user_id":"u1"},{}]}`)
+
+		assert.Equal(t, domainErrors.CodeValidation, resp.Error.Code)
+		assert.Equal(t, []dto.FieldError{
+			{Field: "team_name", Rule: "max", Param: "5"},
+			{Field: "members[1].user_id", Rule: "required"},
+			{Field: "Internal", Rule: "required"},
+		}, fields(t, resp))
+	})
+
+	t.Run("Error - Wrong JSON type names the field", func(t *testing.T) {
+		resp := respond(t, `{"team_name":1}`)
+
+		assert.Equal(t, domainErrors.CodeValidation, resp.Error.Code)
+		assert.Equal(t, []dto.FieldError{{Field: "team_name", Rule: "type", Param: "string"}}, fields(t, resp))
+	})
+
+	t.Run("Error - Blank identifier names the field", func(t *testing.T) {
+		resp := respond(t, `{"team_name":"backend","members":[{"user_id":" \t"}]}`)
+
+		assert.Equal(t, domainErrors.CodeValidation, resp.Error.Code)
+		assert.Equal(t, []dto.FieldError{{Field: "members[0].user_id", Rule: "notblank"}}, fields(t, resp))
+	})
+
+	t.Run("Error - Malformed JSON has no details", func(t *testing.T) {
+		resp := respond(t, `{"team_name":`)
+
+		assert.Equal(t, domainErrors.CodeValidation, resp.Error.Code)
+		assert.Nil(t, resp.Error.Details)
+	})
+}
+
+func TestHandleServiceError(t *testing.T) {
+	t.Run("Error - Domain error mapped to its status", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handleServiceError(rec, domainErrors.NewNotFound("team not found"), testLogger())
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, domainErrors.CodeNotFound, decodeBody[dto.ErrorResponse](t, rec.Body.Bytes()).Error.Code)
+	})
+
+	t.Run("Error - Client cancellation is 499 without a body", func(t *testing.T) {
+		var logs strings.Builder
+		rec := httptest.NewRecorder()
+		handleServiceError(rec, fmt.Errorf("failed to get team: %w", context.Canceled),
+			slog.New(slog.NewTextHandler(&logs, nil)))
+
+		assert.Equal(t, statusClientClosedRequest, rec.Code)
+		assert.Empty(t, rec.Body.String())
+		assert.Contains(t, logs.String(), "level=INFO")
+		assert.Contains(t, logs.String(), "status=499")
+	})
+
+	t.Run("Error - Timeout is 504 logged at Warn", func(t *testing.T) {
+		var logs strings.Builder
+		rec := httptest.NewRecorder()
+		handleServiceError(rec, fmt.Errorf("failed to get team: %w", context.DeadlineExceeded),
+			slog.New(slog.NewTextHandler(&logs, nil)))
+
+		assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+		assert.Equal(t, CodeTimeout, decodeBody[dto.ErrorResponse](t, rec.Body.Bytes()).Error.Code)
+		assert.Contains(t, logs.String(), "level=WARN")
+	})
+
+	t.Run("Error - Other errors are internal", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handleServiceError(rec, errors.New("connection refused"), testLogger())
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestParsePage(t *testing.T) {
+	t.Run("Success - Defaults applied", func(t *testing.T) {
+		page, err := parsePage(httptest.NewRequest(http.MethodGet, "/list", nil), "", 20)
+
+		assert.NoError(t, err)
+		assert.Equal(t, dto.PageRequest{Limit: 20}, page)
+	})
+
+	t.Run("Success - Prefixed parameters", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/list?limit=1&users_limit=100&users_offset=7", nil)
+
+		page, err := parsePage(r, "users_", 20)
+
+		assert.NoError(t, err)
+		assert.Equal(t, dto.PageRequest{Limit: 100, Offset: 7}, page)
+	})
+
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"Error - Limit is not a number", "limit=ten", "limit must be an integer between 1 and 100"},
+		{"Error - Zero limit", "limit=0", "limit must be an integer between 1 and 100"},
+		{"Error - Limit above maximum", "limit=101", "limit must be an integer between 1 and 100"},
+		{"Error - Offset is not a number", "offset=1.5", "offset must be a non-negative integer"},
+		{"Error - Negative offset", "offset=-1", "offset must be a non-negative integer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parsePage(httptest.NewRequest(http.MethodGet, "/list?"+tt.query, nil), "", 20)
+
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	allowed := sortFields{"created_at": true, "title": true}
+	parse := func(query string) (dto.Sort, error) {
+		return parseSort(httptest.NewRequest(http.MethodGet, "/list?"+query, nil), allowed)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  dto.Sort
+	}{
+		{"Success - No sort keeps the default order", "", dto.Sort{}},
+		{"Success - Ascending", "sort=title", dto.Sort{Field: "title"}},
+		{"Success - Descending", "sort=-created_at", dto.Sort{Field: "created_at", Desc: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sort, err := parse(tt.query)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, sort)
+		})
+	}
+
+	// anything but an allowed field is rejected before it can reach a repository
+	invalid := []string{
+		"author_id",
+		"Title",
+		"--title",
+		"title desc",
+		"title,created_at",
+		"created_at; DROP TABLE pull_request; --",
+		"(SELECT 1)",
+		"-",
+	}
+	for _, value := range invalid {
+		t.Run("Error - Rejects "+value, func(t *testing.T) {
+			sort, err := parse("sort=" + url.QueryEscape(value))
+
+			assert.EqualError(t, err, "sort must be one of created_at, title, prefixed with - for descending order")
+			assert.Equal(t, dto.Sort{}, sort)
+		})
+	}
+}
+
+func TestQueryParams(t *testing.T) {
+	read := func(query string) *queryParams {
+		return readQuery(httptest.NewRequest(http.MethodGet, "/list?"+query, nil))
+	}
+
+	t.Run("Success - Values are trimmed", func(t *testing.T) {
+		query := read("team_name=%20backend%20&detail=true&count=3&from=2024-01-01T00:00:00Z")
+
+		assert.Equal(t, "backend", query.get("team_name"))
+		assert.True(t, query.boolean("detail"))
+		assert.Equal(t, 3, query.integer("count", 5))
+		assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), *query.time("from"))
+		assert.NoError(t, query.err)
+	})
+
+	t.Run("Success - Absent parameters read as defaults", func(t *testing.T) {
+		query := read("")
+
+		assert.Empty(t, query.get("team_name"))
+		assert.False(t, query.boolean("detail"))
+		assert.Equal(t, 5, query.integer("count", 5))
+		assert.Nil(t, query.time("from"))
+		assert.NoError(t, query.err)
+	})
+
+	t.Run("Success - List parameters may be repeated", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/list?labels=a,b&labels=c&expand=reviewers&expand=reviewers", nil)
+
+		assert.Equal(t, []string{"a", "b", "c"}, parseList(r, "labels"))
+		assert.True(t, expandsReviewers(r))
+	})
+
+	tests := []struct {
+		name  string
+		query string
+		read  func(q *queryParams)
+		want  string
+	}{
+		{"Error - Repeated parameter", "user_id=a&user_id=b",
+			func(q *queryParams) { q.get("user_id") }, "user_id must be given once"},
+		{"Error - Repeated equal values", "user_id=a&user_id=a",
+			func(q *queryParams) { q.get("user_id") }, "user_id must be given once"},
+		{"Error - Empty value", "user_id=",
+			func(q *queryParams) { q.get("user_id") }, "user_id must not be empty"},
+		{"Error - Blank value", "user_id=%20%20",
+			func(q *queryParams) { q.get("user_id") }, "user_id must not be empty"},
+		{"Error - Required parameter absent", "",
+			func(q *queryParams) { q.required("user_id") }, "user_id is required"},
+		{"Error - Not a boolean", "detail=yes",
+			func(q *queryParams) { q.boolean("detail") }, "detail must be a boolean"},
+		{"Error - Not an integer", "count=many",
+			func(q *queryParams) { q.integer("count", 5) }, "count must be an integer"},
+		{"Error - Not a time", "from=yesterday",
+			func(q *queryParams) { q.time("from") }, "from must be an RFC 3339 time"},
+		{"Error - First failure is kept", "user_id=a&user_id=b&detail=yes",
+			func(q *queryParams) { q.get("user_id"); q.boolean("detail") }, "user_id must be given once"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := read(tt.query)
+
+			tt.read(query)
+
+			assert.EqualError(t, query.err, tt.want)
+		})
+	}
+}
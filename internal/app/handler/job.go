@@ -0,0 +1,70 @@
+package handler
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mocks/mock_job_service.go -package=mocks
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+)
+
+// JobService defines the interface for listing the periodic background jobs and running them on demand.
+type JobService interface {
+	ListJobs(ctx context.Context) (*admin.JobsResponse, error)
+	RunJob(ctx context.Context, req admin.RunJobRequest) (*admin.RunJobResponse, error)
+}
+
+// JobHandler handles the admin requests of the periodic background jobs.
+type JobHandler struct {
+	service  JobService
+	logger   *slog.Logger
+	validate *validator.Validate
+}
+
+// NewJobHandler creates a new JobHandler.
+func NewJobHandler(service JobService, logger *slog.Logger, validate *validator.Validate) *JobHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if validate == nil {
+		validate = NewValidator()
+	}
+	return &JobHandler{
+		service:  service,
+		logger:   logger,
+		validate: validate,
+	}
+}
+
+// ListJobs lists the jobs with their intervals and last runs.
+func (h *JobHandler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	op := "JobHandler.ListJobs"
+	logger := h.logger.With(slog.String("op", op))
+	response, err := h.service.ListJobs(r.Context())
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
+
+// RunJob runs a job now and answers once the run finished; a failed run is answered 200 with its
+// error, a job running already 409.
+func (h *JobHandler) RunJob(w http.ResponseWriter, r *http.Request) {
+	op := "JobHandler.RunJob"
+	logger := h.logger.With(slog.String("op", op))
+	var req admin.RunJobRequest
+	if err := decodeAndValidate(r, h.validate, &req); err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	response, err := h.service.RunJob(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
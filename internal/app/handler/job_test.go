@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler/mocks"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type jobCase = handlerCase[*mocks.MockJobService]
+
+func runJobCases(t *testing.T, handle func(h *JobHandler) http.HandlerFunc, cases []jobCase) {
+	t.Helper()
+	runCases(t, mocks.NewMockJobService, func(m *mocks.MockJobService) http.HandlerFunc {
+		return handle(NewJobHandler(m, testLogger(), nil))
+	}, cases)
+}
+
+func TestJobHandler_ListJobs(t *testing.T) {
+	jobs := &admin.JobsResponse{Jobs: []admin.Job{
+		{Name: "escalation", IntervalSeconds: 300, Exclusive: true, LastRun: &admin.JobRun{
+			StartedAt: "2026-03-01T12:00:00Z", FinishedAt: "2026-03-01T12:00:02Z", LastSuccessAt: "2026-03-01T12:00:02Z",
+		}},
+		{Name: "metrics", IntervalSeconds: 30},
+	}}
+
+	runJobCases(t, func(h *JobHandler) http.HandlerFunc { return h.ListJobs }, []jobCase{
+		{
+			name: "Success - Jobs listed", method: http.MethodGet, target: "/admin/jobs",
+			setup: func(m *mocks.MockJobService) {
+				m.EXPECT().ListJobs(gomock.Any()).Return(jobs, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				assert.Equal(t, *jobs, decodeBody[admin.JobsResponse](t, body))
+			},
+		},
+		{
+			name: "Error - Repository failure", method: http.MethodGet, target: "/admin/jobs",
+			setup: func(m *mocks.MockJobService) {
+				m.EXPECT().ListJobs(gomock.Any()).Return(nil, errors.New("connection refused"))
+			},
+			status: http.StatusInternalServerError, code: CodeInternalError,
+		},
+	})
+}
+
+func TestJobHandler_RunJob(t *testing.T) {
+	req := admin.RunJobRequest{Name: "webhook"}
+
+	runJobCases(t, func(h *JobHandler) http.HandlerFunc { return h.RunJob }, []jobCase{
+		{
+			name: "Success - Failed run is reported", method: http.MethodPost, target: "/admin/jobs/run",
+			body: `{"name":"webhook"}`,
+			setup: func(m *mocks.MockJobService) {
+				m.EXPECT().RunJob(gomock.Any(), req).Return(&admin.RunJobResponse{Run: admin.JobRun{
+					StartedAt: "2026-03-01T12:00:00Z", FinishedAt: "2026-03-01T12:00:02Z", Error: "receiver is down",
+				}}, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				assert.Equal(t, "receiver is down", decodeBody[admin.RunJobResponse](t, body).Run.Error)
+			},
+		},
+		{
+			name: "Error - Missing name", method: http.MethodPost, target: "/admin/jobs/run",
+			body: `{}`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Unknown job", method: http.MethodPost, target: "/admin/jobs/run",
+			body: `{"name":"webhook"}`,
+			setup: func(m *mocks.MockJobService) {
+				m.EXPECT().RunJob(gomock.Any(), req).Return(nil, domainErrors.NewNotFound("job webhook not found"))
+			},
+			status: http.StatusNotFound, code: domainErrors.CodeNotFound,
+		},
+		{
+			name: "Error - Job is running", method: http.MethodPost, target: "/admin/jobs/run",
+			body: `{"name":"webhook"}`,
+			setup: func(m *mocks.MockJobService) {
+				m.EXPECT().RunJob(gomock.Any(), req).Return(nil, domainErrors.NewJobRunning("job webhook is running"))
+			},
+			status: http.StatusConflict, code: domainErrors.CodeJobRunning,
+		},
+	})
+}
@@ -0,0 +1,317 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dbctx"
+	userDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/user"
+	"github.com/shirr9/pr-reviewer-service/internal/app/events"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+const (
+	// liveQueueWriteTimeout bounds a write to the socket, so a stuck client is dropped.
+	liveQueueWriteTimeout = 10 * time.Second
+	// liveQueuePingInterval is how often an idle socket is pinged to detect dead clients.
+	liveQueuePingInterval = 30 * time.Second
+	// defaultPollTimeout is how long a poll waits for an assignment without a timeout parameter,
+	// maxPollTimeout the longest it may ask for.
+	defaultPollTimeout = 30 * time.Second
+	maxPollTimeout     = 2 * time.Minute
+	// shutdownReason tells the clients of the live queue why their stream ends.
+	shutdownReason = "server is shutting down"
+)
+
+// QueueSubscriber defines the interface for following the changes of a reviewer's queue.
+type QueueSubscriber interface {
+	Subscribe(ctx context.Context, userID string) *events.Subscription
+}
+
+// LiveQueueHandler streams reviewers' open queues over WebSocket and serves long polls for new
+// assignments.
+type LiveQueueHandler struct {
+	users      UserService
+	subscriber QueueSubscriber
+	logger     *slog.Logger
+}
+
+// NewLiveQueueHandler creates a new LiveQueueHandler.
+func NewLiveQueueHandler(users UserService, subscriber QueueSubscriber, logger *slog.Logger) *LiveQueueHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &LiveQueueHandler{
+		users:      users,
+		subscriber: subscriber,
+		logger:     logger,
+	}
+}
+
+// ServeReviews upgrades to a WebSocket sending the open queue of "user_id", then a message for each
+// PR entering or leaving it. A client too slow to take the events gets the whole queue again as a
+// resync instead of the events it missed. On shutdown the socket gets a closing message and is
+// closed with 1001.
+func (h *LiveQueueHandler) ServeReviews(w http.ResponseWriter, r *http.Request) {
+	op := "LiveQueueHandler.ServeReviews"
+	logger := h.logger.With(slog.String("op", op))
+	query := readQuery(r)
+	userID := query.required("user_id")
+	if query.err != nil {
+		handleValidationError(w, query.err, logger)
+		return
+	}
+
+	// subscribing before reading the queue keeps the changes made in between
+	sub := h.subscriber.Subscribe(r.Context(), userID)
+	defer sub.Unsubscribe()
+
+	queue, err := h.openQueue(r.Context(), userID)
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+
+	// the socket outlives the server's timeouts, which are meant for single requests
+	rc := http.NewResponseController(w)
+	_ = rc.SetReadDeadline(time.Time{})
+	_ = rc.SetWriteDeadline(time.Time{})
+	conn, err := websocket.Accept(w, r, nil)
+	if err != nil {
+		// Accept has already responded
+		logger.LogAttrs(r.Context(), slog.LevelInfo, "failed to accept websocket",
+			slog.String("error", err.Error()))
+		return
+	}
+	defer conn.CloseNow()
+
+	// nothing is expected from the client; reading handles its pings and close. The socket keeps
+	// the values of the request, such as its organization, for the resyncs
+	ctx := conn.CloseRead(context.WithoutCancel(r.Context()))
+	logger = logger.With(slog.String("user_id", userID))
+	logger.LogAttrs(ctx, slog.LevelDebug, "live queue connected")
+
+	if err := h.write(ctx, conn, queueSnapshot(userDto.QueueSnapshot, userID, queue)); err != nil {
+		logClosed(ctx, logger, err)
+		return
+	}
+
+	ping := time.NewTicker(liveQueuePingInterval)
+	defer ping.Stop()
+	for {
+		var msg any
+		select {
+		case <-ctx.Done():
+			logClosed(ctx, logger, ctx.Err())
+			return
+		case <-sub.Done():
+			h.closeStream(ctx, conn, sub, logger)
+			return
+		case <-ping.C:
+			pingCtx, cancel := context.WithTimeout(ctx, liveQueueWriteTimeout)
+			err := conn.Ping(pingCtx)
+			cancel()
+			if err != nil {
+				logClosed(ctx, logger, err)
+				return
+			}
+			continue
+		case <-sub.Lagged():
+			drain(sub)
+			queue, err := h.openQueue(ctx, userID)
+			if err != nil {
+				logger.LogAttrs(ctx, slog.LevelError, "failed to read queue for resync",
+					slog.String("error", err.Error()))
+				_ = conn.Close(websocket.StatusInternalError, "failed to read the queue")
+				return
+			}
+			msg = queueSnapshot(userDto.QueueResync, userID, queue)
+		case event := <-sub.Events():
+			msg = queueEvent(event)
+		}
+
+		if err := h.write(ctx, conn, msg); err != nil {
+			logClosed(ctx, logger, err)
+			return
+		}
+	}
+}
+
+// closeStream writes the events still queued for the socket and a closing message, so the client
+// learns the stream ended on purpose before the close frame. Events that can't be written are
+// logged as abandoned.
+func (h *LiveQueueHandler) closeStream(ctx context.Context, conn *websocket.Conn, sub *events.Subscription,
+	logger *slog.Logger) {
+	for {
+		select {
+		case event := <-sub.Events():
+			if err := h.write(ctx, conn, queueEvent(event)); err != nil {
+				logger.LogAttrs(ctx, slog.LevelWarn, "live queue events abandoned on shutdown",
+					slog.Int("events", len(sub.Events())+1), slog.String("error", err.Error()))
+				_ = conn.Close(websocket.StatusGoingAway, shutdownReason)
+				return
+			}
+		default:
+			_ = h.write(ctx, conn, userDto.QueueClosingMessage{Type: userDto.QueueClosing, Reason: shutdownReason})
+			_ = conn.Close(websocket.StatusGoingAway, shutdownReason)
+			return
+		}
+	}
+}
+
+// PollAssignments returns the assignments of "user_id" on open PRs made after the "since" cursor
+// as soon as there are any. Without them the request waits up to "timeout" for one, or until the
+// server shuts down, then answers 200 without assignments and with the same cursor. Without "since"
+// the user's whole open queue is new.
+func (h *LiveQueueHandler) PollAssignments(w http.ResponseWriter, r *http.Request) {
+	op := "LiveQueueHandler.PollAssignments"
+	logger := h.logger.With(slog.String("op", op))
+	query := readQuery(r)
+	req := userDto.PollAssignmentsRequest{UserID: query.required("user_id")}
+	since, timeoutValue := query.get("since"), query.get("timeout")
+	if query.err != nil {
+		handleValidationError(w, query.err, logger)
+		return
+	}
+	var err error
+	if req.Since, err = userDto.ParseAssignmentCursor(since); err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	timeout, err := parsePollTimeout(timeoutValue)
+	if err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+
+	// subscribing before reading keeps the assignments made in between
+	sub := h.subscriber.Subscribe(r.Context(), req.UserID)
+	defer sub.Unsubscribe()
+	// the poll waits past the write timeout meant for single requests
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(timeout + liveQueueWriteTimeout))
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	// reads go to the primary, as a replica may not have the assignments the subscription saw yet
+	ctx := dbctx.Primary(r.Context())
+	for {
+		response, err := h.users.PollAssignments(ctx, req)
+		if err != nil {
+			handleServiceError(w, err, logger)
+			return
+		}
+		if len(response.PullRequests) > 0 || !awaitAssignment(ctx, sub, deadline.C) {
+			if ctx.Err() == nil {
+				sendSuccessResponse(w, http.StatusOK, response, logger)
+			}
+			return
+		}
+		// assignments of PRs merged meanwhile are skipped, moving the cursor without returning them
+		if req.Since, err = userDto.ParseAssignmentCursor(response.Cursor); err != nil {
+			handleServiceError(w, err, logger)
+			return
+		}
+	}
+}
+
+// parsePollTimeout parses the duration a poll waits, defaultPollTimeout when empty.
+func parsePollTimeout(value string) (time.Duration, error) {
+	if value == "" {
+		return defaultPollTimeout, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout < 0 || timeout > maxPollTimeout {
+		return 0, fmt.Errorf("timeout must be a duration such as 30s, at most %s", maxPollTimeout)
+	}
+	return timeout, nil
+}
+
+// awaitAssignment waits for the user to be assigned a PR. It reports false when the wait ended
+// without one: on the deadline, on shutdown or when the client went away.
+func awaitAssignment(ctx context.Context, sub *events.Subscription, deadline <-chan time.Time) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-deadline:
+			return false
+		case <-sub.Done():
+			return false
+		case <-sub.Lagged():
+			// events were missed, so one of them may have been an assignment
+			drain(sub)
+			return true
+		case event := <-sub.Events():
+			if event.Type == events.AssignmentAdded {
+				return true
+			}
+		}
+	}
+}
+
+// openQueue returns the user's open PRs. It reads from the primary, as a replica may not have the
+// changes the subscription already saw yet.
+func (h *LiveQueueHandler) openQueue(ctx context.Context, userID string) ([]userDto.PR, error) {
+	review, err := h.users.GetReview(dbctx.Primary(ctx), userID)
+	if err != nil {
+		return nil, err
+	}
+	queue := make([]userDto.PR, 0, len(review.PullRequests))
+	for _, pr := range review.PullRequests {
+		if pr.Status == models.PRStatusOpen {
+			queue = append(queue, pr)
+		}
+	}
+	return queue, nil
+}
+
+// write sends the message, giving up after liveQueueWriteTimeout.
+func (h *LiveQueueHandler) write(ctx context.Context, conn *websocket.Conn, msg any) error {
+	ctx, cancel := context.WithTimeout(ctx, liveQueueWriteTimeout)
+	defer cancel()
+	return wsjson.Write(ctx, conn, msg)
+}
+
+// drain drops the buffered events, which a resync covers.
+func drain(sub *events.Subscription) {
+	for {
+		select {
+		case <-sub.Events():
+		default:
+			return
+		}
+	}
+}
+
+func queueSnapshot(msgType, userID string, queue []userDto.PR) userDto.QueueSnapshotMessage {
+	return userDto.QueueSnapshotMessage{Type: msgType, UserID: userID, PullRequests: queue}
+}
+
+func queueEvent(event events.Event) userDto.QueueEventMessage {
+	msg := userDto.QueueEventMessage{
+		Type:          userDto.QueueRemoved,
+		UserID:        event.UserID,
+		PullRequestID: event.PullRequestID,
+	}
+	if event.Type == events.AssignmentAdded {
+		msg.Type = userDto.QueueAdded
+		msg.PullRequest = event.PullRequest
+	}
+	return msg
+}
+
+// logClosed logs why the socket ended, quietly when the client closed it.
+func logClosed(ctx context.Context, logger *slog.Logger, err error) {
+	status := websocket.CloseStatus(err)
+	if status == websocket.StatusNormalClosure || status == websocket.StatusGoingAway || errors.Is(err, context.Canceled) {
+		logger.LogAttrs(ctx, slog.LevelDebug, "live queue disconnected")
+		return
+	}
+	logger.LogAttrs(ctx, slog.LevelInfo, "live queue connection lost", slog.String("error", err.Error()))
+}
@@ -0,0 +1,276 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	userDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/user"
+	"github.com/shirr9/pr-reviewer-service/internal/app/events"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler/mocks"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// liveQueueEnv serves the router with the live queue socket over a real listener.
+type liveQueueEnv struct {
+	bus    *events.Bus
+	users  *mocks.MockUserService
+	server *httptest.Server
+}
+
+func newLiveQueueEnv(t *testing.T, buffer int) *liveQueueEnv {
+	env := &liveQueueEnv{
+		bus:   events.NewBus(buffer),
+		users: mocks.NewMockUserService(gomock.NewController(t)),
+	}
+	env.server = newTestServer(t, Services{Users: env.users, Queues: env.bus})
+	return env
+}
+
+// dial connects to the queue of the user, asking for gzip like browsers do.
+func (e *liveQueueEnv) dial(t *testing.T, userID string) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(e.server.URL, "http") + "/ws/reviews?user_id=" + userID
+	conn, _, err := websocket.Dial(context.Background(), url, &websocket.DialOptions{
+		HTTPHeader: http.Header{"Accept-Encoding": {"gzip"}},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.CloseNow() })
+	return conn
+}
+
+func read[T any](t *testing.T, conn *websocket.Conn) T {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	var msg T
+	require.NoError(t, wsjson.Read(ctx, conn, &msg))
+	return msg
+}
+
+func TestLiveQueueHandler_ServeReviews(t *testing.T) {
+	review := &userDto.GetReviewResponse{UserID: "u2", PullRequests: []userDto.PR{
+		{PullRequestID: "pr-1", Status: models.PRStatusOpen},
+		{PullRequestID: "pr-0", Status: models.PRStatusMerged},
+	}}
+
+	t.Run("Success - Snapshot of the open queue, then its changes", func(t *testing.T) {
+		env := newLiveQueueEnv(t, 4)
+		env.users.EXPECT().GetReview(gomock.Any(), "u2").Return(review, nil)
+		conn := env.dial(t, "u2")
+
+		snapshot := read[userDto.QueueSnapshotMessage](t, conn)
+		assert.Equal(t, userDto.QueueSnapshot, snapshot.Type)
+		assert.Equal(t, []userDto.PR{review.PullRequests[0]}, snapshot.PullRequests)
+
+		env.bus.Publish(
+			events.Event{Type: events.AssignmentAdded, UserID: "u2", PullRequestID: "pr-2",
+				PullRequest: &userDto.PR{PullRequestID: "pr-2", Status: models.PRStatusOpen}},
+			events.Event{Type: events.AssignmentRemoved, UserID: "u3", PullRequestID: "pr-2"},
+			events.Event{Type: events.AssignmentRemoved, UserID: "u2", PullRequestID: "pr-1"},
+		)
+
+		added := read[userDto.QueueEventMessage](t, conn)
+		assert.Equal(t, userDto.QueueAdded, added.Type)
+		assert.Equal(t, "pr-2", added.PullRequest.PullRequestID)
+		removed := read[userDto.QueueEventMessage](t, conn)
+		assert.Equal(t, userDto.QueueEventMessage{Type: userDto.QueueRemoved, UserID: "u2", PullRequestID: "pr-1"},
+			removed)
+	})
+
+	t.Run("Success - Lagging client gets a resync", func(t *testing.T) {
+		env := newLiveQueueEnv(t, 1)
+		env.users.EXPECT().GetReview(gomock.Any(), "u2").Return(review, nil).MinTimes(2)
+		conn := env.dial(t, "u2")
+		read[userDto.QueueSnapshotMessage](t, conn)
+
+		// the handler sends the resync instead of whatever it still buffered
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		for {
+			env.bus.Publish(events.Event{Type: events.AssignmentRemoved, UserID: "u2", PullRequestID: "pr-1"})
+			env.bus.Publish(events.Event{Type: events.AssignmentRemoved, UserID: "u2", PullRequestID: "pr-1"})
+			var msg userDto.QueueSnapshotMessage
+			require.NoError(t, wsjson.Read(ctx, conn, &msg))
+			if msg.Type == userDto.QueueResync {
+				assert.Len(t, msg.PullRequests, 1)
+				return
+			}
+		}
+	})
+
+	t.Run("Success - Closing the bus ends the stream with a closing message", func(t *testing.T) {
+		env := newLiveQueueEnv(t, 4)
+		env.users.EXPECT().GetReview(gomock.Any(), "u2").Return(review, nil)
+		conn := env.dial(t, "u2")
+		read[userDto.QueueSnapshotMessage](t, conn)
+
+		// the event queued as the bus closes is written before the closing message
+		env.bus.Publish(events.Event{Type: events.AssignmentRemoved, UserID: "u2", PullRequestID: "pr-1"})
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		closeErr := make(chan error, 1)
+		go func() { closeErr <- env.bus.Close(ctx) }()
+
+		event := read[userDto.QueueEventMessage](t, conn)
+		assert.Equal(t, userDto.QueueRemoved, event.Type)
+		assert.Equal(t, "pr-1", event.PullRequestID)
+		closing := read[userDto.QueueClosingMessage](t, conn)
+		assert.Equal(t, userDto.QueueClosingMessage{Type: userDto.QueueClosing, Reason: "server is shutting down"}, closing)
+		_, _, err := conn.Read(ctx)
+		assert.Equal(t, websocket.StatusGoingAway, websocket.CloseStatus(err))
+		assert.NoError(t, <-closeErr)
+	})
+
+	t.Run("Error - Missing user id", func(t *testing.T) {
+		env := newLiveQueueEnv(t, 4)
+
+		resp, err := http.Get(env.server.URL + "/ws/reviews")
+
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("Error - Queue read failure is answered before the upgrade", func(t *testing.T) {
+		env := newLiveQueueEnv(t, 4)
+		env.users.EXPECT().GetReview(gomock.Any(), "u2").Return(nil, domainErrors.NewNotFound("user not found"))
+
+		resp, err := http.Get(env.server.URL + "/ws/reviews?user_id=u2")
+
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestRouter_LiveQueueNeedsSubscriber(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	NewRouter(Services{}, testLogger(), nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/reviews", nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+// poll sends a long-poll request and returns the status and the body of the response.
+func (e *liveQueueEnv) poll(t *testing.T, query string) (int, []byte) {
+	t.Helper()
+	resp, err := http.Get(e.server.URL + "/users/pollAssignments?" + query)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, body
+}
+
+func TestLiveQueueHandler_PollAssignments(t *testing.T) {
+	since := models.AssignmentCursor{AssignedAt: time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC), PRId: "pr-1"}
+	next := models.AssignmentCursor{AssignedAt: since.AssignedAt.Add(time.Minute), PRId: "pr-2"}
+	empty := &userDto.PollAssignmentsResponse{
+		UserID: "u2", Cursor: userDto.FormatAssignmentCursor(since), PullRequests: []userDto.PR{},
+	}
+	found := &userDto.PollAssignmentsResponse{
+		UserID: "u2", Cursor: userDto.FormatAssignmentCursor(next),
+		PullRequests: []userDto.PR{{PullRequestID: "pr-2", Status: models.PRStatusOpen}},
+	}
+	query := "user_id=u2&since=" + userDto.FormatAssignmentCursor(since)
+
+	t.Run("Success - Existing assignments are returned at once", func(t *testing.T) {
+		env := newLiveQueueEnv(t, 4)
+		env.users.EXPECT().
+			PollAssignments(gomock.Any(), userDto.PollAssignmentsRequest{UserID: "u2", Since: since}).
+			Return(found, nil)
+
+		status, body := env.poll(t, query)
+
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, *found, decodeBody[userDto.PollAssignmentsResponse](t, body))
+	})
+
+	t.Run("Success - Parked request is answered on an assignment", func(t *testing.T) {
+		env := newLiveQueueEnv(t, 4)
+		polled := make(chan struct{})
+		gomock.InOrder(
+			env.users.EXPECT().PollAssignments(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(context.Context, userDto.PollAssignmentsRequest) (*userDto.PollAssignmentsResponse, error) {
+					close(polled)
+					return empty, nil
+				}),
+			env.users.EXPECT().
+				PollAssignments(gomock.Any(), userDto.PollAssignmentsRequest{UserID: "u2", Since: since}).
+				Return(found, nil),
+		)
+		go func() {
+			<-polled
+			env.bus.Publish(
+				events.Event{Type: events.AssignmentRemoved, UserID: "u2", PullRequestID: "pr-1"},
+				events.Event{Type: events.AssignmentAdded, UserID: "u2", PullRequestID: "pr-2"},
+			)
+		}()
+
+		status, body := env.poll(t, query+"&timeout=10s")
+
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, *found, decodeBody[userDto.PollAssignmentsResponse](t, body))
+	})
+
+	t.Run("Success - Timeout answers the same cursor", func(t *testing.T) {
+		env := newLiveQueueEnv(t, 4)
+		env.users.EXPECT().PollAssignments(gomock.Any(), gomock.Any()).Return(empty, nil)
+
+		status, body := env.poll(t, query+"&timeout=50ms")
+
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, *empty, decodeBody[userDto.PollAssignmentsResponse](t, body))
+	})
+
+	t.Run("Success - Shutdown answers the parked request", func(t *testing.T) {
+		env := newLiveQueueEnv(t, 4)
+		env.users.EXPECT().PollAssignments(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(context.Context, userDto.PollAssignmentsRequest) (*userDto.PollAssignmentsResponse, error) {
+				go env.bus.Close(context.Background())
+				return empty, nil
+			})
+
+		status, body := env.poll(t, query+"&timeout=10s")
+
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, *empty, decodeBody[userDto.PollAssignmentsResponse](t, body))
+	})
+
+	for name, query := range map[string]string{
+		"missing user_id":  "since=" + userDto.FormatAssignmentCursor(since),
+		"invalid since":    "user_id=u2&since=not-a-cursor",
+		"invalid timeout":  "user_id=u2&timeout=forever",
+		"timeout too long": "user_id=u2&timeout=1h",
+	} {
+		t.Run("Error - "+name, func(t *testing.T) {
+			env := newLiveQueueEnv(t, 4)
+
+			status, body := env.poll(t, query)
+
+			assert.Equal(t, http.StatusBadRequest, status)
+			assert.Equal(t, domainErrors.CodeValidation, decodeBody[dto.ErrorResponse](t, body).Error.Code)
+		})
+	}
+
+	t.Run("Error - Unknown user", func(t *testing.T) {
+		env := newLiveQueueEnv(t, 4)
+		env.users.EXPECT().PollAssignments(gomock.Any(), gomock.Any()).Return(nil, domainErrors.NewNotFound("user not found"))
+
+		status, _ := env.poll(t, "user_id=ghost")
+
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+}
@@ -0,0 +1,211 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: admin.go
+//
+// Generated by this command:
+//
+//	mockgen -source=admin.go -destination=mocks/mock_admin_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	admin "github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockExclusionService is a mock of ExclusionService interface.
+type MockExclusionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockExclusionServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockExclusionServiceMockRecorder is the mock recorder for MockExclusionService.
+type MockExclusionServiceMockRecorder struct {
+	mock *MockExclusionService
+}
+
+// NewMockExclusionService creates a new mock instance.
+func NewMockExclusionService(ctrl *gomock.Controller) *MockExclusionService {
+	mock := &MockExclusionService{ctrl: ctrl}
+	mock.recorder = &MockExclusionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExclusionService) EXPECT() *MockExclusionServiceMockRecorder {
+	return m.recorder
+}
+
+// AddExclusion mocks base method.
+func (m *MockExclusionService) AddExclusion(ctx context.Context, req admin.AddExclusionRequest) (*admin.ExclusionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddExclusion", ctx, req)
+	ret0, _ := ret[0].(*admin.ExclusionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddExclusion indicates an expected call of AddExclusion.
+func (mr *MockExclusionServiceMockRecorder) AddExclusion(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddExclusion", reflect.TypeOf((*MockExclusionService)(nil).AddExclusion), ctx, req)
+}
+
+// ListExclusions mocks base method.
+func (m *MockExclusionService) ListExclusions(ctx context.Context, req admin.ListExclusionsRequest) (*dto.Page[admin.Exclusion], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExclusions", ctx, req)
+	ret0, _ := ret[0].(*dto.Page[admin.Exclusion])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExclusions indicates an expected call of ListExclusions.
+func (mr *MockExclusionServiceMockRecorder) ListExclusions(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExclusions", reflect.TypeOf((*MockExclusionService)(nil).ListExclusions), ctx, req)
+}
+
+// RemoveExclusion mocks base method.
+func (m *MockExclusionService) RemoveExclusion(ctx context.Context, req admin.RemoveExclusionRequest) (*admin.RemoveExclusionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveExclusion", ctx, req)
+	ret0, _ := ret[0].(*admin.RemoveExclusionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveExclusion indicates an expected call of RemoveExclusion.
+func (mr *MockExclusionServiceMockRecorder) RemoveExclusion(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveExclusion", reflect.TypeOf((*MockExclusionService)(nil).RemoveExclusion), ctx, req)
+}
+
+// MockArchiveService is a mock of ArchiveService interface.
+type MockArchiveService struct {
+	ctrl     *gomock.Controller
+	recorder *MockArchiveServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockArchiveServiceMockRecorder is the mock recorder for MockArchiveService.
+type MockArchiveServiceMockRecorder struct {
+	mock *MockArchiveService
+}
+
+// NewMockArchiveService creates a new mock instance.
+func NewMockArchiveService(ctrl *gomock.Controller) *MockArchiveService {
+	mock := &MockArchiveService{ctrl: ctrl}
+	mock.recorder = &MockArchiveServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArchiveService) EXPECT() *MockArchiveServiceMockRecorder {
+	return m.recorder
+}
+
+// Archive mocks base method.
+func (m *MockArchiveService) Archive(ctx context.Context, req admin.ArchiveRequest) (*admin.ArchiveResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Archive", ctx, req)
+	ret0, _ := ret[0].(*admin.ArchiveResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Archive indicates an expected call of Archive.
+func (mr *MockArchiveServiceMockRecorder) Archive(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Archive", reflect.TypeOf((*MockArchiveService)(nil).Archive), ctx, req)
+}
+
+// Restore mocks base method.
+func (m *MockArchiveService) Restore(ctx context.Context, req admin.RestoreArchivedRequest) (*admin.RestoreArchivedResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, req)
+	ret0, _ := ret[0].(*admin.RestoreArchivedResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockArchiveServiceMockRecorder) Restore(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockArchiveService)(nil).Restore), ctx, req)
+}
+
+// MockWebhookService is a mock of WebhookService interface.
+type MockWebhookService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookServiceMockRecorder is the mock recorder for MockWebhookService.
+type MockWebhookServiceMockRecorder struct {
+	mock *MockWebhookService
+}
+
+// NewMockWebhookService creates a new mock instance.
+func NewMockWebhookService(ctrl *gomock.Controller) *MockWebhookService {
+	mock := &MockWebhookService{ctrl: ctrl}
+	mock.recorder = &MockWebhookServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookService) EXPECT() *MockWebhookServiceMockRecorder {
+	return m.recorder
+}
+
+// ListDeadLetters mocks base method.
+func (m *MockWebhookService) ListDeadLetters(ctx context.Context, req admin.ListDeadLettersRequest) (*dto.Page[admin.WebhookDelivery], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeadLetters", ctx, req)
+	ret0, _ := ret[0].(*dto.Page[admin.WebhookDelivery])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeadLetters indicates an expected call of ListDeadLetters.
+func (mr *MockWebhookServiceMockRecorder) ListDeadLetters(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeadLetters", reflect.TypeOf((*MockWebhookService)(nil).ListDeadLetters), ctx, req)
+}
+
+// Redeliver mocks base method.
+func (m *MockWebhookService) Redeliver(ctx context.Context, req admin.RedeliverRequest) (*admin.RedeliverResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeliver", ctx, req)
+	ret0, _ := ret[0].(*admin.RedeliverResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockWebhookServiceMockRecorder) Redeliver(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*MockWebhookService)(nil).Redeliver), ctx, req)
+}
+
+// Stats mocks base method.
+func (m *MockWebhookService) Stats(ctx context.Context) (*admin.WebhookStatsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", ctx)
+	ret0, _ := ret[0].(*admin.WebhookStatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockWebhookServiceMockRecorder) Stats(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockWebhookService)(nil).Stats), ctx)
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: dump.go
+//
+// Generated by this command:
+//
+//	mockgen -source=dump.go -destination=mocks/mock_dump_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	admin "github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDumpService is a mock of DumpService interface.
+type MockDumpService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDumpServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockDumpServiceMockRecorder is the mock recorder for MockDumpService.
+type MockDumpServiceMockRecorder struct {
+	mock *MockDumpService
+}
+
+// NewMockDumpService creates a new mock instance.
+func NewMockDumpService(ctrl *gomock.Controller) *MockDumpService {
+	mock := &MockDumpService{ctrl: ctrl}
+	mock.recorder = &MockDumpServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDumpService) EXPECT() *MockDumpServiceMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockDumpService) Export(ctx context.Context, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockDumpServiceMockRecorder) Export(ctx, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockDumpService)(nil).Export), ctx, w)
+}
+
+// Import mocks base method.
+func (m *MockDumpService) Import(ctx context.Context, req admin.ImportDumpRequest) (*admin.ImportDumpResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, req)
+	ret0, _ := ret[0].(*admin.ImportDumpResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockDumpServiceMockRecorder) Import(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockDumpService)(nil).Import), ctx, req)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: duplicate.go
+//
+// Generated by this command:
+//
+//	mockgen -source=duplicate.go -destination=mocks/mock_duplicate_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	admin "github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDuplicateService is a mock of DuplicateService interface.
+type MockDuplicateService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDuplicateServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockDuplicateServiceMockRecorder is the mock recorder for MockDuplicateService.
+type MockDuplicateServiceMockRecorder struct {
+	mock *MockDuplicateService
+}
+
+// NewMockDuplicateService creates a new mock instance.
+func NewMockDuplicateService(ctrl *gomock.Controller) *MockDuplicateService {
+	mock := &MockDuplicateService{ctrl: ctrl}
+	mock.recorder = &MockDuplicateServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDuplicateService) EXPECT() *MockDuplicateServiceMockRecorder {
+	return m.recorder
+}
+
+// FindNearDuplicates mocks base method.
+func (m *MockDuplicateService) FindNearDuplicates(ctx context.Context) (*admin.NearDuplicatesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindNearDuplicates", ctx)
+	ret0, _ := ret[0].(*admin.NearDuplicatesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindNearDuplicates indicates an expected call of FindNearDuplicates.
+func (mr *MockDuplicateServiceMockRecorder) FindNearDuplicates(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindNearDuplicates", reflect.TypeOf((*MockDuplicateService)(nil).FindNearDuplicates), ctx)
+}
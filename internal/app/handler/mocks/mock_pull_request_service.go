@@ -0,0 +1,268 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pull_request.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pull_request.go -destination=mocks/mock_pull_request_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	pullrequest "github.com/shirr9/pr-reviewer-service/internal/app/dto/pullrequest"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPullRequestService is a mock of PullRequestService interface.
+type MockPullRequestService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPullRequestServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPullRequestServiceMockRecorder is the mock recorder for MockPullRequestService.
+type MockPullRequestServiceMockRecorder struct {
+	mock *MockPullRequestService
+}
+
+// NewMockPullRequestService creates a new mock instance.
+func NewMockPullRequestService(ctrl *gomock.Controller) *MockPullRequestService {
+	mock := &MockPullRequestService{ctrl: ctrl}
+	mock.recorder = &MockPullRequestServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPullRequestService) EXPECT() *MockPullRequestServiceMockRecorder {
+	return m.recorder
+}
+
+// ApplyRebalance mocks base method.
+func (m *MockPullRequestService) ApplyRebalance(ctx context.Context, req pullrequest.ApplyRebalanceRequest) (*pullrequest.ReassignReviewerResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyRebalance", ctx, req)
+	ret0, _ := ret[0].(*pullrequest.ReassignReviewerResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyRebalance indicates an expected call of ApplyRebalance.
+func (mr *MockPullRequestServiceMockRecorder) ApplyRebalance(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyRebalance", reflect.TypeOf((*MockPullRequestService)(nil).ApplyRebalance), ctx, req)
+}
+
+// AssignPR mocks base method.
+func (m *MockPullRequestService) AssignPR(ctx context.Context, req pullrequest.AssignPrRequest) (*pullrequest.AssignPrResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignPR", ctx, req)
+	ret0, _ := ret[0].(*pullrequest.AssignPrResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignPR indicates an expected call of AssignPR.
+func (mr *MockPullRequestServiceMockRecorder) AssignPR(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignPR", reflect.TypeOf((*MockPullRequestService)(nil).AssignPR), ctx, req)
+}
+
+// AssignPending mocks base method.
+func (m *MockPullRequestService) AssignPending(ctx context.Context, req pullrequest.AssignPendingRequest) (*pullrequest.AssignPendingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignPending", ctx, req)
+	ret0, _ := ret[0].(*pullrequest.AssignPendingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignPending indicates an expected call of AssignPending.
+func (mr *MockPullRequestServiceMockRecorder) AssignPending(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignPending", reflect.TypeOf((*MockPullRequestService)(nil).AssignPending), ctx, req)
+}
+
+// CreateBulk mocks base method.
+func (m *MockPullRequestService) CreateBulk(ctx context.Context, req pullrequest.CreateBulkRequest) (*pullrequest.CreateBulkResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBulk", ctx, req)
+	ret0, _ := ret[0].(*pullrequest.CreateBulkResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBulk indicates an expected call of CreateBulk.
+func (mr *MockPullRequestServiceMockRecorder) CreateBulk(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBulk", reflect.TypeOf((*MockPullRequestService)(nil).CreateBulk), ctx, req)
+}
+
+// CreatePR mocks base method.
+func (m *MockPullRequestService) CreatePR(ctx context.Context, req pullrequest.CreatePrRequest) (*pullrequest.CreatePrResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePR", ctx, req)
+	ret0, _ := ret[0].(*pullrequest.CreatePrResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePR indicates an expected call of CreatePR.
+func (mr *MockPullRequestServiceMockRecorder) CreatePR(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePR", reflect.TypeOf((*MockPullRequestService)(nil).CreatePR), ctx, req)
+}
+
+// GetHistory mocks base method.
+func (m *MockPullRequestService) GetHistory(ctx context.Context, prID string) (*pullrequest.HistoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, prID)
+	ret0, _ := ret[0].(*pullrequest.HistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockPullRequestServiceMockRecorder) GetHistory(ctx, prID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockPullRequestService)(nil).GetHistory), ctx, prID)
+}
+
+// GetUnassignedPRs mocks base method.
+func (m *MockPullRequestService) GetUnassignedPRs(ctx context.Context, req pullrequest.UnassignedRequest) (*dto.Page[pullrequest.PR], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnassignedPRs", ctx, req)
+	ret0, _ := ret[0].(*dto.Page[pullrequest.PR])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnassignedPRs indicates an expected call of GetUnassignedPRs.
+func (mr *MockPullRequestServiceMockRecorder) GetUnassignedPRs(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnassignedPRs", reflect.TypeOf((*MockPullRequestService)(nil).GetUnassignedPRs), ctx, req)
+}
+
+// MergeBulk mocks base method.
+func (m *MockPullRequestService) MergeBulk(ctx context.Context, req pullrequest.MergeBulkRequest) (*pullrequest.MergeBulkResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeBulk", ctx, req)
+	ret0, _ := ret[0].(*pullrequest.MergeBulkResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeBulk indicates an expected call of MergeBulk.
+func (mr *MockPullRequestServiceMockRecorder) MergeBulk(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeBulk", reflect.TypeOf((*MockPullRequestService)(nil).MergeBulk), ctx, req)
+}
+
+// MergePR mocks base method.
+func (m *MockPullRequestService) MergePR(ctx context.Context, req pullrequest.MergePrRequest) (*pullrequest.MergePrResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergePR", ctx, req)
+	ret0, _ := ret[0].(*pullrequest.MergePrResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergePR indicates an expected call of MergePR.
+func (mr *MockPullRequestServiceMockRecorder) MergePR(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergePR", reflect.TypeOf((*MockPullRequestService)(nil).MergePR), ctx, req)
+}
+
+// ReassignReviewer mocks base method.
+func (m *MockPullRequestService) ReassignReviewer(ctx context.Context, req pullrequest.ReassignReviewerRequest) (*pullrequest.ReassignReviewerResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignReviewer", ctx, req)
+	ret0, _ := ret[0].(*pullrequest.ReassignReviewerResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReassignReviewer indicates an expected call of ReassignReviewer.
+func (mr *MockPullRequestServiceMockRecorder) ReassignReviewer(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignReviewer", reflect.TypeOf((*MockPullRequestService)(nil).ReassignReviewer), ctx, req)
+}
+
+// SearchPRs mocks base method.
+func (m *MockPullRequestService) SearchPRs(ctx context.Context, req pullrequest.SearchPrRequest) (*dto.Page[pullrequest.PR], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchPRs", ctx, req)
+	ret0, _ := ret[0].(*dto.Page[pullrequest.PR])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchPRs indicates an expected call of SearchPRs.
+func (mr *MockPullRequestServiceMockRecorder) SearchPRs(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchPRs", reflect.TypeOf((*MockPullRequestService)(nil).SearchPRs), ctx, req)
+}
+
+// SetLabels mocks base method.
+func (m *MockPullRequestService) SetLabels(ctx context.Context, req pullrequest.SetLabelsRequest) (*pullrequest.SetLabelsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLabels", ctx, req)
+	ret0, _ := ret[0].(*pullrequest.SetLabelsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetLabels indicates an expected call of SetLabels.
+func (mr *MockPullRequestServiceMockRecorder) SetLabels(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLabels", reflect.TypeOf((*MockPullRequestService)(nil).SetLabels), ctx, req)
+}
+
+// SubmitReview mocks base method.
+func (m *MockPullRequestService) SubmitReview(ctx context.Context, req pullrequest.ReviewRequest) (*pullrequest.ReviewResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitReview", ctx, req)
+	ret0, _ := ret[0].(*pullrequest.ReviewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitReview indicates an expected call of SubmitReview.
+func (mr *MockPullRequestServiceMockRecorder) SubmitReview(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitReview", reflect.TypeOf((*MockPullRequestService)(nil).SubmitReview), ctx, req)
+}
+
+// SuggestRebalance mocks base method.
+func (m *MockPullRequestService) SuggestRebalance(ctx context.Context, req pullrequest.RebalanceSuggestionsRequest) (*pullrequest.RebalanceSuggestionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestRebalance", ctx, req)
+	ret0, _ := ret[0].(*pullrequest.RebalanceSuggestionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestRebalance indicates an expected call of SuggestRebalance.
+func (mr *MockPullRequestServiceMockRecorder) SuggestRebalance(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestRebalance", reflect.TypeOf((*MockPullRequestService)(nil).SuggestRebalance), ctx, req)
+}
+
+// SuggestReviewers mocks base method.
+func (m *MockPullRequestService) SuggestReviewers(ctx context.Context, req pullrequest.SuggestReviewersRequest) (*pullrequest.SuggestReviewersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestReviewers", ctx, req)
+	ret0, _ := ret[0].(*pullrequest.SuggestReviewersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestReviewers indicates an expected call of SuggestReviewers.
+func (mr *MockPullRequestServiceMockRecorder) SuggestReviewers(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestReviewers", reflect.TypeOf((*MockPullRequestService)(nil).SuggestReviewers), ctx, req)
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: readiness.go
+//
+// Generated by this command:
+//
+//	mockgen -source=readiness.go -destination=mocks/mock_readiness_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	admin "github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReadinessService is a mock of ReadinessService interface.
+type MockReadinessService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReadinessServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockReadinessServiceMockRecorder is the mock recorder for MockReadinessService.
+type MockReadinessServiceMockRecorder struct {
+	mock *MockReadinessService
+}
+
+// NewMockReadinessService creates a new mock instance.
+func NewMockReadinessService(ctrl *gomock.Controller) *MockReadinessService {
+	mock := &MockReadinessService{ctrl: ctrl}
+	mock.recorder = &MockReadinessServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReadinessService) EXPECT() *MockReadinessServiceMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockReadinessService) Check(ctx context.Context) *admin.ReadinessResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx)
+	ret0, _ := ret[0].(*admin.ReadinessResponse)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockReadinessServiceMockRecorder) Check(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockReadinessService)(nil).Check), ctx)
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: snapshot.go
+//
+// Generated by this command:
+//
+//	mockgen -source=snapshot.go -destination=mocks/mock_snapshot_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	statistics "github.com/shirr9/pr-reviewer-service/internal/app/dto/statistics"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSnapshotService is a mock of SnapshotService interface.
+type MockSnapshotService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSnapshotServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockSnapshotServiceMockRecorder is the mock recorder for MockSnapshotService.
+type MockSnapshotServiceMockRecorder struct {
+	mock *MockSnapshotService
+}
+
+// NewMockSnapshotService creates a new mock instance.
+func NewMockSnapshotService(ctrl *gomock.Controller) *MockSnapshotService {
+	mock := &MockSnapshotService{ctrl: ctrl}
+	mock.recorder = &MockSnapshotServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSnapshotService) EXPECT() *MockSnapshotServiceMockRecorder {
+	return m.recorder
+}
+
+// GetHistory mocks base method.
+func (m *MockSnapshotService) GetHistory(ctx context.Context, req statistics.HistoryRequest) (*statistics.HistoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, req)
+	ret0, _ := ret[0].(*statistics.HistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockSnapshotServiceMockRecorder) GetHistory(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockSnapshotService)(nil).GetHistory), ctx, req)
+}
+
+// TakeSnapshot mocks base method.
+func (m *MockSnapshotService) TakeSnapshot(ctx context.Context) (*statistics.DailySnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeSnapshot", ctx)
+	ret0, _ := ret[0].(*statistics.DailySnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeSnapshot indicates an expected call of TakeSnapshot.
+func (mr *MockSnapshotServiceMockRecorder) TakeSnapshot(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeSnapshot", reflect.TypeOf((*MockSnapshotService)(nil).TakeSnapshot), ctx)
+}
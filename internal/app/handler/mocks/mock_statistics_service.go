@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: statistics.go
+//
+// Generated by this command:
+//
+//	mockgen -source=statistics.go -destination=mocks/mock_statistics_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	statistics "github.com/shirr9/pr-reviewer-service/internal/app/dto/statistics"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStatisticsService is a mock of StatisticsService interface.
+type MockStatisticsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatisticsServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockStatisticsServiceMockRecorder is the mock recorder for MockStatisticsService.
+type MockStatisticsServiceMockRecorder struct {
+	mock *MockStatisticsService
+}
+
+// NewMockStatisticsService creates a new mock instance.
+func NewMockStatisticsService(ctrl *gomock.Controller) *MockStatisticsService {
+	mock := &MockStatisticsService{ctrl: ctrl}
+	mock.recorder = &MockStatisticsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatisticsService) EXPECT() *MockStatisticsServiceMockRecorder {
+	return m.recorder
+}
+
+// GetDistribution mocks base method.
+func (m *MockStatisticsService) GetDistribution(ctx context.Context, req statistics.DistributionRequest) (*statistics.DistributionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDistribution", ctx, req)
+	ret0, _ := ret[0].(*statistics.DistributionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDistribution indicates an expected call of GetDistribution.
+func (mr *MockStatisticsServiceMockRecorder) GetDistribution(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDistribution", reflect.TypeOf((*MockStatisticsService)(nil).GetDistribution), ctx, req)
+}
+
+// GetOverdue mocks base method.
+func (m *MockStatisticsService) GetOverdue(ctx context.Context) (*statistics.OverdueResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverdue", ctx)
+	ret0, _ := ret[0].(*statistics.OverdueResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverdue indicates an expected call of GetOverdue.
+func (mr *MockStatisticsServiceMockRecorder) GetOverdue(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdue", reflect.TypeOf((*MockStatisticsService)(nil).GetOverdue), ctx)
+}
+
+// GetStatistics mocks base method.
+func (m *MockStatisticsService) GetStatistics(ctx context.Context, req statistics.StatisticsRequest) (*statistics.StatisticsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatistics", ctx, req)
+	ret0, _ := ret[0].(*statistics.StatisticsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatistics indicates an expected call of GetStatistics.
+func (mr *MockStatisticsServiceMockRecorder) GetStatistics(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatistics", reflect.TypeOf((*MockStatisticsService)(nil).GetStatistics), ctx, req)
+}
+
+// GetTimeseries mocks base method.
+func (m *MockStatisticsService) GetTimeseries(ctx context.Context, req statistics.TimeseriesRequest) (*statistics.TimeseriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeseries", ctx, req)
+	ret0, _ := ret[0].(*statistics.TimeseriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimeseries indicates an expected call of GetTimeseries.
+func (mr *MockStatisticsServiceMockRecorder) GetTimeseries(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeseries", reflect.TypeOf((*MockStatisticsService)(nil).GetTimeseries), ctx, req)
+}
+
+// GetUserStatistics mocks base method.
+func (m *MockStatisticsService) GetUserStatistics(ctx context.Context, req statistics.UserStatisticsRequest) (*statistics.UserStatisticsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserStatistics", ctx, req)
+	ret0, _ := ret[0].(*statistics.UserStatisticsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserStatistics indicates an expected call of GetUserStatistics.
+func (mr *MockStatisticsServiceMockRecorder) GetUserStatistics(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserStatistics", reflect.TypeOf((*MockStatisticsService)(nil).GetUserStatistics), ctx, req)
+}
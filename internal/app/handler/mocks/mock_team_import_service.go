@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: team_import_json.go
+//
+// Generated by this command:
+//
+//	mockgen -source=team_import_json.go -destination=mocks/mock_team_import_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	iter "iter"
+	reflect "reflect"
+
+	team "github.com/shirr9/pr-reviewer-service/internal/app/dto/team"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTeamImportService is a mock of TeamImportService interface.
+type MockTeamImportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTeamImportServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockTeamImportServiceMockRecorder is the mock recorder for MockTeamImportService.
+type MockTeamImportServiceMockRecorder struct {
+	mock *MockTeamImportService
+}
+
+// NewMockTeamImportService creates a new mock instance.
+func NewMockTeamImportService(ctrl *gomock.Controller) *MockTeamImportService {
+	mock := &MockTeamImportService{ctrl: ctrl}
+	mock.recorder = &MockTeamImportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTeamImportService) EXPECT() *MockTeamImportServiceMockRecorder {
+	return m.recorder
+}
+
+// ImportTeam mocks base method.
+func (m *MockTeamImportService) ImportTeam(ctx context.Context, req team.ImportTeamRequest, members iter.Seq2[team.TeamMember, error]) (*team.ImportTeamResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTeam", ctx, req, members)
+	ret0, _ := ret[0].(*team.ImportTeamResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportTeam indicates an expected call of ImportTeam.
+func (mr *MockTeamImportServiceMockRecorder) ImportTeam(ctx, req, members any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTeam", reflect.TypeOf((*MockTeamImportService)(nil).ImportTeam), ctx, req, members)
+}
@@ -0,0 +1,133 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: team.go
+//
+// Generated by this command:
+//
+//	mockgen -source=team.go -destination=mocks/mock_team_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	team "github.com/shirr9/pr-reviewer-service/internal/app/dto/team"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTeamService is a mock of TeamService interface.
+type MockTeamService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTeamServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockTeamServiceMockRecorder is the mock recorder for MockTeamService.
+type MockTeamServiceMockRecorder struct {
+	mock *MockTeamService
+}
+
+// NewMockTeamService creates a new mock instance.
+func NewMockTeamService(ctrl *gomock.Controller) *MockTeamService {
+	mock := &MockTeamService{ctrl: ctrl}
+	mock.recorder = &MockTeamServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTeamService) EXPECT() *MockTeamServiceMockRecorder {
+	return m.recorder
+}
+
+// AddTeam mocks base method.
+func (m *MockTeamService) AddTeam(ctx context.Context, req team.AddTeamRequest) (*team.AddTeamResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTeam", ctx, req)
+	ret0, _ := ret[0].(*team.AddTeamResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTeam indicates an expected call of AddTeam.
+func (mr *MockTeamServiceMockRecorder) AddTeam(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTeam", reflect.TypeOf((*MockTeamService)(nil).AddTeam), ctx, req)
+}
+
+// DeactivateTeam mocks base method.
+func (m *MockTeamService) DeactivateTeam(ctx context.Context, req team.DeactivateTeamRequest) (*team.DeactivateTeamResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateTeam", ctx, req)
+	ret0, _ := ret[0].(*team.DeactivateTeamResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateTeam indicates an expected call of DeactivateTeam.
+func (mr *MockTeamServiceMockRecorder) DeactivateTeam(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateTeam", reflect.TypeOf((*MockTeamService)(nil).DeactivateTeam), ctx, req)
+}
+
+// GetReviewQueue mocks base method.
+func (m *MockTeamService) GetReviewQueue(ctx context.Context, req team.ReviewQueueRequest) (*team.ReviewQueueResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewQueue", ctx, req)
+	ret0, _ := ret[0].(*team.ReviewQueueResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewQueue indicates an expected call of GetReviewQueue.
+func (mr *MockTeamServiceMockRecorder) GetReviewQueue(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewQueue", reflect.TypeOf((*MockTeamService)(nil).GetReviewQueue), ctx, req)
+}
+
+// GetTeam mocks base method.
+func (m *MockTeamService) GetTeam(ctx context.Context, teamName string) (*team.GetTeamResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeam", ctx, teamName)
+	ret0, _ := ret[0].(*team.GetTeamResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeam indicates an expected call of GetTeam.
+func (mr *MockTeamServiceMockRecorder) GetTeam(ctx, teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*MockTeamService)(nil).GetTeam), ctx, teamName)
+}
+
+// ImportTeams mocks base method.
+func (m *MockTeamService) ImportTeams(ctx context.Context, req team.ImportTeamsRequest) (*team.ImportTeamsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTeams", ctx, req)
+	ret0, _ := ret[0].(*team.ImportTeamsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportTeams indicates an expected call of ImportTeams.
+func (mr *MockTeamServiceMockRecorder) ImportTeams(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTeams", reflect.TypeOf((*MockTeamService)(nil).ImportTeams), ctx, req)
+}
+
+// ListTeams mocks base method.
+func (m *MockTeamService) ListTeams(ctx context.Context, req team.ListTeamsRequest) (*dto.Page[team.TeamSummary], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTeams", ctx, req)
+	ret0, _ := ret[0].(*dto.Page[team.TeamSummary])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTeams indicates an expected call of ListTeams.
+func (mr *MockTeamServiceMockRecorder) ListTeams(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTeams", reflect.TypeOf((*MockTeamService)(nil).ListTeams), ctx, req)
+}
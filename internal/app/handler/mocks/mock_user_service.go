@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user.go
+//
+// Generated by this command:
+//
+//	mockgen -source=user.go -destination=mocks/mock_user_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	user "github.com/shirr9/pr-reviewer-service/internal/app/dto/user"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserService is a mock of UserService interface.
+type MockUserService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockUserServiceMockRecorder is the mock recorder for MockUserService.
+type MockUserServiceMockRecorder struct {
+	mock *MockUserService
+}
+
+// NewMockUserService creates a new mock instance.
+func NewMockUserService(ctrl *gomock.Controller) *MockUserService {
+	mock := &MockUserService{ctrl: ctrl}
+	mock.recorder = &MockUserServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
+	return m.recorder
+}
+
+// GetReview mocks base method.
+func (m *MockUserService) GetReview(ctx context.Context, userID string) (*user.GetReviewResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReview", ctx, userID)
+	ret0, _ := ret[0].(*user.GetReviewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReview indicates an expected call of GetReview.
+func (mr *MockUserServiceMockRecorder) GetReview(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReview", reflect.TypeOf((*MockUserService)(nil).GetReview), ctx, userID)
+}
+
+// PollAssignments mocks base method.
+func (m *MockUserService) PollAssignments(ctx context.Context, req user.PollAssignmentsRequest) (*user.PollAssignmentsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PollAssignments", ctx, req)
+	ret0, _ := ret[0].(*user.PollAssignmentsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PollAssignments indicates an expected call of PollAssignments.
+func (mr *MockUserServiceMockRecorder) PollAssignments(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PollAssignments", reflect.TypeOf((*MockUserService)(nil).PollAssignments), ctx, req)
+}
+
+// SetIsActive mocks base method.
+func (m *MockUserService) SetIsActive(ctx context.Context, req user.SetIsActiveRequest) (*user.SetIsActiveResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetIsActive", ctx, req)
+	ret0, _ := ret[0].(*user.SetIsActiveResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetIsActive indicates an expected call of SetIsActive.
+func (mr *MockUserServiceMockRecorder) SetIsActive(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIsActive", reflect.TypeOf((*MockUserService)(nil).SetIsActive), ctx, req)
+}
+
+// SetIsReviewer mocks base method.
+func (m *MockUserService) SetIsReviewer(ctx context.Context, req user.SetIsReviewerRequest) (*user.SetIsReviewerResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetIsReviewer", ctx, req)
+	ret0, _ := ret[0].(*user.SetIsReviewerResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetIsReviewer indicates an expected call of SetIsReviewer.
+func (mr *MockUserServiceMockRecorder) SetIsReviewer(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIsReviewer", reflect.TypeOf((*MockUserService)(nil).SetIsReviewer), ctx, req)
+}
+
+// SetTags mocks base method.
+func (m *MockUserService) SetTags(ctx context.Context, req user.SetTagsRequest) (*user.SetTagsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTags", ctx, req)
+	ret0, _ := ret[0].(*user.SetTagsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTags indicates an expected call of SetTags.
+func (mr *MockUserServiceMockRecorder) SetTags(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTags", reflect.TypeOf((*MockUserService)(nil).SetTags), ctx, req)
+}
+
+// UpdateUser mocks base method.
+func (m *MockUserService) UpdateUser(ctx context.Context, req user.UpdateUserRequest) (*user.UpdateUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", ctx, req)
+	ret0, _ := ret[0].(*user.UpdateUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockUserServiceMockRecorder) UpdateUser(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserService)(nil).UpdateUser), ctx, req)
+}
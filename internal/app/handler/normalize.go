@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// normalizer normalizes the identifiers of requests: user, PR and other ids and team names are
+// trimmed of surrounding whitespace, so "user-1 " can't become a user apart from "user-1", and
+// with lowerTeamNames team names are folded to lower case. Identifiers are the fields whose JSON
+// name ends in _id or _ids and team_name.
+type normalizer struct {
+	lowerTeamNames bool
+}
+
+// normalizerKey is a key for storing the normalizer of the router in context.
+type normalizerKey struct{}
+
+// withNormalizer gives the handler the normalizer of the router through the request context.
+func withNormalizer(next http.HandlerFunc, n normalizer) http.HandlerFunc {
+	if n == (normalizer{}) {
+		return next
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		next(w, r.WithContext(context.WithValue(r.Context(), normalizerKey{}, n)))
+	}
+}
+
+// requestNormalizer returns the normalizer of the request; without one identifiers are only trimmed.
+func requestNormalizer(r *http.Request) normalizer {
+	n, _ := r.Context().Value(normalizerKey{}).(normalizer)
+	return n
+}
+
+// isIdentifier reports whether the JSON field or query parameter name holds an identifier.
+func isIdentifier(name string) bool {
+	return strings.HasSuffix(name, "_id") || strings.HasSuffix(name, "_ids") || name == "team_name"
+}
+
+// identifier normalizes the value of the identifier named name.
+func (n normalizer) identifier(name, value string) string {
+	value = strings.TrimSpace(value)
+	if n.lowerTeamNames && name == "team_name" {
+		value = strings.ToLower(value)
+	}
+	return value
+}
+
+// blankFieldError reports an identifier given as nothing but whitespace.
+type blankFieldError struct {
+	field string
+}
+
+func (e *blankFieldError) Error() string {
+	return fmt.Sprintf("%s must not be blank", e.field)
+}
+
+// normalize normalizes the identifiers of target, a pointer to a decoded request, in nested structs
+// and slices included. An identifier left empty after trimming fails with a blankFieldError; one
+// that was not given at all stays empty for the validation to judge.
+func (n normalizer) normalize(target any) error {
+	return n.walk(reflect.ValueOf(target), "")
+}
+
+func (n normalizer) walk(v reflect.Value, path string) error {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return n.walk(v.Elem(), path)
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			if err := n.walk(v.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := range t.NumField() {
+			field := t.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if !field.IsExported() || name == "-" {
+				continue
+			}
+			fieldPath := path
+			if name != "" {
+				fieldPath = strings.TrimPrefix(path+"."+name, ".")
+			}
+			var err error
+			if isIdentifier(name) {
+				err = n.identifiers(v.Field(i), name, fieldPath)
+			} else {
+				err = n.walk(v.Field(i), fieldPath)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// identifiers normalizes an identifier field: a string, a pointer to one or a slice of them.
+func (n normalizer) identifiers(v reflect.Value, name, path string) error {
+	switch v.Kind() {
+	case reflect.String:
+		if v.Len() == 0 {
+			return nil
+		}
+		normalized := n.identifier(name, v.String())
+		if normalized == "" {
+			return &blankFieldError{field: path}
+		}
+		v.SetString(normalized)
+	case reflect.Pointer:
+		if !v.IsNil() {
+			return n.identifiers(v.Elem(), name, path)
+		}
+	case reflect.Slice:
+		for i := range v.Len() {
+			if err := n.identifiers(v.Index(i), name, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	prDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/pullrequest"
+	teamDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/team"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNormalizer_Normalize(t *testing.T) {
+	t.Run("Success - Identifiers are trimmed in nested structs and lists", func(t *testing.T) {
+		req := teamDto.AddTeamRequest{
+			TeamName:    " Backend ",
+			Description: " Platform ",
+			LeadID:      "u1\t",
+			Members:     []teamDto.TeamMember{{UserID: " u1", Username: " Alice "}},
+		}
+
+		require.NoError(t, normalizer{}.normalize(&req))
+
+		assert.Equal(t, "Backend", req.TeamName)
+		assert.Equal(t, "u1", req.LeadID)
+		assert.Equal(t, "u1", req.Members[0].UserID)
+		assert.Equal(t, " Platform ", req.Description, "only identifiers are normalized")
+		assert.Equal(t, " Alice ", req.Members[0].Username, "only identifiers are normalized")
+	})
+
+	t.Run("Success - Team names are folded to lower case when enabled", func(t *testing.T) {
+		req := teamDto.AddTeamRequest{TeamName: " Backend ", Members: []teamDto.TeamMember{{UserID: "User-1"}}}
+
+		require.NoError(t, normalizer{lowerTeamNames: true}.normalize(&req))
+
+		assert.Equal(t, "backend", req.TeamName)
+		assert.Equal(t, "User-1", req.Members[0].UserID)
+	})
+
+	t.Run("Success - Identifier lists are trimmed", func(t *testing.T) {
+		req := prDto.CreatePrRequest{PullRequestID: "pr-1 ", ReviewerIDs: []string{" u2", "u3 "}}
+
+		require.NoError(t, normalizer{}.normalize(&req))
+
+		assert.Equal(t, "pr-1", req.PullRequestID)
+		assert.Equal(t, []string{"u2", "u3"}, req.ReviewerIDs)
+	})
+
+	t.Run("Error - Blank identifier is rejected", func(t *testing.T) {
+		req := prDto.CreatePrRequest{PullRequestID: "pr-1", ReviewerIDs: []string{"u2", "  "}}
+
+		err := normalizer{}.normalize(&req)
+
+		assert.EqualError(t, err, "reviewer_ids[1] must not be blank")
+	})
+
+	t.Run("Success - Identifiers not given stay empty", func(t *testing.T) {
+		req := prDto.CreatePrRequest{PullRequestID: "pr-1"}
+
+		require.NoError(t, normalizer{}.normalize(&req))
+
+		assert.Empty(t, req.AuthorID)
+	})
+}
+
+func TestNewRouter_LowercaseTeamNames(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	teams := mocks.NewMockTeamService(ctrl)
+	router := NewRouter(Services{Teams: teams, LowercaseTeamNames: true}, testLogger(), nil)
+
+	t.Run("Success - Team name of the body is folded", func(t *testing.T) {
+		teams.EXPECT().AddTeam(gomock.Any(), teamDto.AddTeamRequest{
+			TeamName: "backend",
+			Members:  []teamDto.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}},
+		}).Return(&teamDto.AddTeamResponse{}, nil)
+		body := `{"team_name":" Backend","members":[{"user_id":"u1 ","username":"Alice","is_active":true}]}`
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/team/add", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("Success - Team name of the query is folded", func(t *testing.T) {
+		teams.EXPECT().GetTeam(gomock.Any(), "backend").Return(&teamDto.GetTeamResponse{TeamName: "backend"}, nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/team/get?team_name=BackEnd", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
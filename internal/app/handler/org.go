@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+)
+
+// OrgHeader names the organization a request works on; requests without it work on the default one.
+const OrgHeader = "X-Org-Id"
+
+// maxOrgIDLength is the length of the org_id columns.
+const maxOrgIDLength = 255
+
+// withOrg puts the organization of the X-Org-Id header into the request context, where the
+// repositories scope their queries by it. An ID longer than the columns hold is refused with 400.
+func withOrg(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimSpace(r.Header.Get(OrgHeader))
+		if len(id) > maxOrgIDLength {
+			_ = RespondWithError(w, domainErrors.NewValidation("X-Org-Id must be at most 255 characters"))
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(orgctx.WithID(r.Context(), id)))
+	})
+}
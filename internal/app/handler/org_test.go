@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/team"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler/mocks"
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewRouter_Org(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	teams := mocks.NewMockTeamService(ctrl)
+	router := NewRouter(Services{Teams: teams}, testLogger(), nil)
+
+	getTeam := func(orgID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/team/get?team_name=backend", nil)
+		if orgID != "" {
+			req.Header.Set(OrgHeader, orgID)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+	expectOrg := func(want string) {
+		teams.EXPECT().GetTeam(gomock.Any(), "backend").DoAndReturn(
+			func(ctx context.Context, name string) (*team.GetTeamResponse, error) {
+				assert.Equal(t, want, orgctx.ID(ctx))
+				return &team.GetTeamResponse{TeamName: name}, nil
+			})
+	}
+
+	t.Run("Success - The header selects the organization", func(t *testing.T) {
+		expectOrg("payments")
+
+		rec := getTeam(" payments ")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("Success - Requests without the header use the default organization", func(t *testing.T) {
+		expectOrg(orgctx.DefaultID)
+
+		rec := getTeam("")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("Error - Too long organization is refused", func(t *testing.T) {
+		rec := getTeam(strings.Repeat("a", maxOrgIDLength+1))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, domainErrors.CodeValidation, decodeBody[dto.ErrorResponse](t, rec.Body.Bytes()).Error.Code)
+	})
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"net/http"
+
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+)
+
+// retryAfterSeconds is the Retry-After of requests refused as SERVICE_OVERLOADED: waiting for a
+// connection is bounded by about a second, so the pool is likely to have drained by then.
+const retryAfterSeconds = "1"
+
+// LoadShedder defines the interface for refusing requests while the database is saturated.
+type LoadShedder interface {
+	Shed() bool
+}
+
+// unshedRoutes are served however saturated the database is: probes and scrapes must report the
+// overload rather than fall victim to it, and the spec needs no database.
+var unshedRoutes = map[string]bool{
+	"/readyz":       true,
+	"/metrics":      true,
+	"/openapi.yaml": true,
+}
+
+// withLoadShedding refuses requests with 503 SERVICE_OVERLOADED before they reach the database
+// while the shedder reports it saturated. A nil shedder leaves the handler as is.
+func withLoadShedding(next http.HandlerFunc, shedder LoadShedder) http.HandlerFunc {
+	if shedder == nil {
+		return next
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if shedder.Shed() {
+			_ = RespondWithError(w, domainErrors.NewServiceOverloaded("the service is overloaded, retry later"))
+			return
+		}
+		next(w, r)
+	}
+}
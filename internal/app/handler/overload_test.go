@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/team"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler/mocks"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeShedder sheds every request while saturated.
+type fakeShedder struct {
+	saturated bool
+}
+
+func (f *fakeShedder) Shed() bool { return f.saturated }
+
+func TestNewRouter_LoadShedding(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	teams := mocks.NewMockTeamService(ctrl)
+	shedder := &fakeShedder{}
+	router := NewRouter(Services{
+		Teams:   teams,
+		Metrics: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }),
+		Shedder: shedder,
+	}, testLogger(), nil)
+
+	t.Run("Success - Requests pass while the database keeps up", func(t *testing.T) {
+		shedder.saturated = false
+		teams.EXPECT().GetTeam(gomock.Any(), "backend").Return(&team.GetTeamResponse{TeamName: "backend"}, nil)
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/team/get?team_name=backend", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("Error - Requests are shed while the database is saturated", func(t *testing.T) {
+		shedder.saturated = true
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/team/get?team_name=backend", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+		assert.Equal(t, domainErrors.CodeServiceOverloaded, decodeBody[dto.ErrorResponse](t, rec.Body.Bytes()).Error.Code)
+	})
+
+	t.Run("Success - Metrics are served while the database is saturated", func(t *testing.T) {
+		shedder.saturated = true
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -10,16 +11,38 @@ import (
 )
 
 const (
+	// CodeInternalError marks errors that aren't domain errors; their message is not exposed.
 	CodeInternalError = "INTERNAL_ERROR"
-	CodeBadRequest    = "BAD_REQUEST"
+	// CodeMethodNotAllowed marks requests to a known path with a method it doesn't serve.
+	CodeMethodNotAllowed = "METHOD_NOT_ALLOWED"
+	// CodePayloadTooLarge marks request bodies over the limit of the endpoint.
+	CodePayloadTooLarge = "PAYLOAD_TOO_LARGE"
+	// CodeUnauthorized marks requests to a protected endpoint without its token.
+	CodeUnauthorized = "UNAUTHORIZED"
+	// CodeTimeout marks requests that ran out of their timeout before the service answered.
+	CodeTimeout = "TIMEOUT"
 )
 
-// RespondWithError handles error responses and returns encoding error if any.
+// RespondWithError handles error responses and returns encoding error if any. A request that ran
+// out of its timeout is answered 504; one canceled by its client gets only the 499 status for the
+// access log, as nobody reads a body. SERVICE_OVERLOADED tells the client when to retry.
 func RespondWithError(w http.ResponseWriter, err error) error {
 	var appErr *domainErrors.AppError
 	if errors.As(err, &appErr) {
+		if appErr.Code == domainErrors.CodeServiceOverloaded {
+			w.Header().Set("Retry-After", retryAfterSeconds)
+		}
 		statusCode := mapErrorCodeToHTTPStatus(appErr.Code)
-		return RespondJSON(w, statusCode, dto.NewErrorResponse(appErr.Code, appErr.Message))
+		errResp := dto.NewErrorResponse(appErr.Code, appErr.Message)
+		errResp.Error.Details = appErr.Details
+		return RespondJSON(w, statusCode, errResp)
+	}
+	if errors.Is(err, context.Canceled) {
+		w.WriteHeader(statusClientClosedRequest)
+		return nil
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return RespondJSON(w, http.StatusGatewayTimeout, dto.NewErrorResponse(CodeTimeout, "request timed out"))
 	}
 
 	if encodeErr := RespondJSON(w, http.StatusInternalServerError,
@@ -44,16 +67,21 @@ func RespondJSON(w http.ResponseWriter, statusCode int, data interface{}) error
 	return nil
 }
 
-// mapErrorCodeToHTTPStatus maps domain error codes to HTTP status codes.
+// mapErrorCodeToHTTPStatus maps domain error codes to HTTP status codes; unknown codes are 500.
 func mapErrorCodeToHTTPStatus(code string) int {
 	switch code {
 	case domainErrors.CodeNotFound:
 		return http.StatusNotFound
-	case domainErrors.CodeNotAssigned:
+	case domainErrors.CodeValidation, domainErrors.CodeBadRequest, domainErrors.CodeNotAssigned, domainErrors.CodeWrongTeam, domainErrors.CodeReviewerIsAuthor,
+		domainErrors.CodeReviewerExcluded, domainErrors.CodeNotReviewer:
 		return http.StatusBadRequest
 	case domainErrors.CodeTeamExists, domainErrors.CodePRExists,
-		domainErrors.CodePRMerged, domainErrors.CodeNoCandidate:
+		domainErrors.CodePRMerged, domainErrors.CodePRClosed, domainErrors.CodeNoCandidate, domainErrors.CodeAlreadyAssigned,
+		domainErrors.CodeTooManyReviewers, domainErrors.CodeInvalidTransition, domainErrors.CodeChangesRequested,
+		domainErrors.CodeNoReviewers, domainErrors.CodeNotEmpty, domainErrors.CodeJobRunning:
 		return http.StatusConflict
+	case domainErrors.CodeServiceOverloaded:
+		return http.StatusServiceUnavailable
 	default:
 		return http.StatusInternalServerError
 	}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRespondWithError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"NOT_FOUND", domainErrors.NewNotFound("resource not found"), http.StatusNotFound, domainErrors.CodeNotFound},
+		{"VALIDATION_ERROR", domainErrors.NewValidation("invalid"), http.StatusBadRequest, domainErrors.CodeValidation},
+		{"BAD_REQUEST", domainErrors.NewBadRequest("rejected"), http.StatusBadRequest, domainErrors.CodeBadRequest},
+		{"NOT_ASSIGNED", domainErrors.NewNotAssigned("not assigned"), http.StatusBadRequest, domainErrors.CodeNotAssigned},
+		{"WRONG_TEAM", domainErrors.NewWrongTeam("wrong team"), http.StatusBadRequest, domainErrors.CodeWrongTeam},
+		{"NOT_REVIEWER", domainErrors.NewNotReviewer("not a reviewer"), http.StatusBadRequest, domainErrors.CodeNotReviewer},
+		{"REVIEWER_IS_AUTHOR", domainErrors.NewReviewerIsAuthor("author"), http.StatusBadRequest,
+			domainErrors.CodeReviewerIsAuthor},
+		{"REVIEWER_EXCLUDED", domainErrors.NewReviewerExcluded("excluded"), http.StatusBadRequest,
+			domainErrors.CodeReviewerExcluded},
+		{"TEAM_EXISTS", domainErrors.NewTeamExists("team exists"), http.StatusConflict, domainErrors.CodeTeamExists},
+		{"PR_EXISTS", domainErrors.NewPRExists("pr exists"), http.StatusConflict, domainErrors.CodePRExists},
+		{"PR_MERGED", domainErrors.NewPRMerged("pr merged"), http.StatusConflict, domainErrors.CodePRMerged},
+		{"NO_CANDIDATE", domainErrors.NewNoCandidate("no candidate"), http.StatusConflict, domainErrors.CodeNoCandidate},
+		{"ALREADY_ASSIGNED", domainErrors.NewAlreadyAssigned("assigned"), http.StatusConflict,
+			domainErrors.CodeAlreadyAssigned},
+		{"TOO_MANY_REVIEWERS", domainErrors.NewTooManyReviewers("too many"), http.StatusConflict,
+			domainErrors.CodeTooManyReviewers},
+		{"INVALID_TRANSITION", domainErrors.NewInvalidTransition("transition"), http.StatusConflict,
+			domainErrors.CodeInvalidTransition},
+		{"CHANGES_REQUESTED", domainErrors.NewChangesRequested("changes"), http.StatusConflict,
+			domainErrors.CodeChangesRequested},
+		{"NO_REVIEWERS", domainErrors.NewNoReviewers("no reviewers"), http.StatusConflict, domainErrors.CodeNoReviewers},
+		{"PR_CLOSED", domainErrors.NewPRClosed("cannot merge closed PR"), http.StatusConflict, domainErrors.CodePRClosed},
+		{"NOT_EMPTY", domainErrors.NewNotEmpty("not empty"), http.StatusConflict, domainErrors.CodeNotEmpty},
+		{"JOB_RUNNING", domainErrors.NewJobRunning("job escalation is running"), http.StatusConflict,
+			domainErrors.CodeJobRunning},
+		{"SERVICE_OVERLOADED", domainErrors.NewServiceOverloaded("overloaded"), http.StatusServiceUnavailable,
+			domainErrors.CodeServiceOverloaded},
+		{"Unknown code", domainErrors.New("SOMETHING_ELSE", "unknown"), http.StatusInternalServerError, "SOMETHING_ELSE"},
+		{"Wrapped AppError", errors.Join(errors.New("context"), domainErrors.NewPRMerged("pr merged")),
+			http.StatusConflict, domainErrors.CodePRMerged},
+		{"Plain error", errors.New("connection refused"), http.StatusInternalServerError, CodeInternalError},
+		{"Deadline exceeded", fmt.Errorf("failed to get team: %w", context.DeadlineExceeded),
+			http.StatusGatewayTimeout, CodeTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			_ = RespondWithError(rec, tt.err)
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+			var errResp dto.ErrorResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errResp))
+			assert.Equal(t, tt.code, errResp.Error.Code)
+		})
+	}
+
+	t.Run("Plain error is returned and not exposed", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		err := errors.New("connection refused")
+
+		assert.Equal(t, err, RespondWithError(rec, err))
+		assert.NotContains(t, rec.Body.String(), "connection refused")
+	})
+
+	t.Run("Details are included", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		err := domainErrors.NewNoCandidate("no candidate").WithDetails(map[string]any{"team_name": "backend"})
+
+		assert.NoError(t, RespondWithError(rec, err))
+		assert.JSONEq(t,
+			`{"error":{"code":"NO_CANDIDATE","message":"no candidate","details":{"team_name":"backend"}}}`,
+			rec.Body.String())
+	})
+}
+
+func TestRespondWithError_ServiceOverloaded(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	_ = RespondWithError(rec, fmt.Errorf("failed to get team: %w", domainErrors.NewServiceOverloaded("overloaded")))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+}
+
+func TestRespondWithError_Canceled(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	assert.NoError(t, RespondWithError(rec, fmt.Errorf("failed to get team: %w", context.Canceled)))
+	assert.Equal(t, statusClientClosedRequest, rec.Code)
+	assert.Empty(t, rec.Body.String())
+}
@@ -1,43 +1,82 @@
 package handler
 
+//go:generate go tool mockgen -source=$GOFILE -destination=mocks/mock_pull_request_service.go -package=mocks
+
 import (
 	"context"
+	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
 	prDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/pullrequest"
 )
 
 // PullRequestService defines the interface for pull request operations.
 type PullRequestService interface {
 	CreatePR(ctx context.Context, req prDto.CreatePrRequest) (*prDto.CreatePrResponse, error)
+	CreateBulk(ctx context.Context, req prDto.CreateBulkRequest) (*prDto.CreateBulkResponse, error)
+	SuggestReviewers(ctx context.Context, req prDto.SuggestReviewersRequest) (*prDto.SuggestReviewersResponse, error)
 	MergePR(ctx context.Context, req prDto.MergePrRequest) (*prDto.MergePrResponse, error)
+	MergeBulk(ctx context.Context, req prDto.MergeBulkRequest) (*prDto.MergeBulkResponse, error)
 	ReassignReviewer(ctx context.Context, req prDto.ReassignReviewerRequest) (*prDto.ReassignReviewerResponse, error)
+	SuggestRebalance(ctx context.Context, req prDto.RebalanceSuggestionsRequest) (*prDto.RebalanceSuggestionsResponse, error)
+	ApplyRebalance(ctx context.Context, req prDto.ApplyRebalanceRequest) (*prDto.ReassignReviewerResponse, error)
+	SubmitReview(ctx context.Context, req prDto.ReviewRequest) (*prDto.ReviewResponse, error)
+	SetLabels(ctx context.Context, req prDto.SetLabelsRequest) (*prDto.SetLabelsResponse, error)
+	SearchPRs(ctx context.Context, req prDto.SearchPrRequest) (*dto.Page[prDto.PR], error)
+	GetHistory(ctx context.Context, prID string) (*prDto.HistoryResponse, error)
+	GetUnassignedPRs(ctx context.Context, req prDto.UnassignedRequest) (*dto.Page[prDto.PR], error)
+	AssignPending(ctx context.Context, req prDto.AssignPendingRequest) (*prDto.AssignPendingResponse, error)
+	AssignPR(ctx context.Context, req prDto.AssignPrRequest) (*prDto.AssignPrResponse, error)
 }
 
+// defaultSearchLimit is used when the search request has no limit.
+const defaultSearchLimit = 20
+
+// defaultPageLimit is used when a paginated request has no limit.
+const defaultPageLimit = 20
+
+// Fields the PR search and the unassigned PR list can be sorted by.
+var (
+	searchSortFields     = sortFields{"created_at": true, "updated_at": true, "title": true, "priority": true}
+	unassignedSortFields = sortFields{"created_at": true, "title": true, "priority": true}
+)
+
+// defaultSuggestCount matches the number of reviewers assigned to a new PR.
+const defaultSuggestCount = 2
+
+// defaultRebalanceLimit is used when the rebalance suggestions request has no limit.
+const defaultRebalanceLimit = 5
+
 // PullRequestHandler handles pull request related HTTP requests.
 type PullRequestHandler struct {
 	service  PullRequestService
 	logger   *slog.Logger
 	validate *validator.Validate
+	// adminToken is the bearer token required to override merge checks; none is possible without it
+	adminToken string
 }
 
 // NewPullRequestHandler create new PullRequestHandler.
 func NewPullRequestHandler(
 	service PullRequestService,
 	logger *slog.Logger,
-	validate *validator.Validate) *PullRequestHandler {
+	validate *validator.Validate,
+	adminToken string) *PullRequestHandler {
 	if logger == nil {
 		logger = slog.Default()
 	}
 	if validate == nil {
-		validate = validator.New()
+		validate = NewValidator()
 	}
 	return &PullRequestHandler{
-		service:  service,
-		logger:   logger,
-		validate: validate,
+		service:    service,
+		logger:     logger,
+		validate:   validate,
+		adminToken: adminToken,
 	}
 }
 
@@ -58,7 +97,73 @@ func (h *PullRequestHandler) CreatePR(w http.ResponseWriter, r *http.Request) {
 	sendSuccessResponse(w, http.StatusCreated, response, logger)
 }
 
+// CreateBulk creates a batch of pull requests.
+// Items failing validation are reported as invalid and the rest are passed to the service.
+// Answers 201 when all PRs were created and 207 otherwise.
+func (h *PullRequestHandler) CreateBulk(w http.ResponseWriter, r *http.Request) {
+	op := "PullRequestHandler.CreateBulk"
+	logger := h.logger.With(slog.String("op", op))
+	var req prDto.CreateBulkRequest
+	if err := decodeAndValidate(r, h.validate, &req); err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+
+	invalid := make(map[int]string)
+	valid := make([]prDto.CreatePrRequest, 0, len(req.PullRequests))
+	for i, item := range req.PullRequests {
+		if err := h.validate.Struct(item); err != nil {
+			invalid[i] = err.Error()
+			continue
+		}
+		valid = append(valid, item)
+	}
+
+	created := &prDto.CreateBulkResponse{}
+	if len(valid) > 0 {
+		var err error
+		created, err = h.service.CreateBulk(r.Context(), prDto.CreateBulkRequest{
+			PullRequests:    valid,
+			AssignReviewers: req.AssignReviewers,
+		})
+		if err != nil {
+			handleServiceError(w, err, logger)
+			return
+		}
+	}
+
+	// results of the service are in the order of the valid items, so they fill the gaps between invalid ones
+	response := prDto.CreateBulkResponse{
+		Results: make([]prDto.CreateBulkResult, 0, len(req.PullRequests)),
+		Summary: created.Summary,
+	}
+	response.Summary.Invalid += len(invalid)
+	next := 0
+	for i, item := range req.PullRequests {
+		if message, ok := invalid[i]; ok {
+			response.Results = append(response.Results, prDto.CreateBulkResult{
+				Index:         i,
+				PullRequestID: item.PullRequestID,
+				Result:        prDto.CreateResultInvalid,
+				Error:         message,
+			})
+			continue
+		}
+		result := created.Results[next]
+		result.Index = i
+		response.Results = append(response.Results, result)
+		next++
+	}
+
+	status := http.StatusCreated
+	if response.Summary.Created < len(req.PullRequests) {
+		status = http.StatusMultiStatus
+	}
+	sendSuccessResponse(w, status, response, logger)
+}
+
 // MergePR merges pull request.
+// An override of the merge checks requires the admin bearer token.
 func (h *PullRequestHandler) MergePR(w http.ResponseWriter, r *http.Request) {
 	op := "PullRequestHandler.MergePR"
 	logger := h.logger.With(slog.String("op", op))
@@ -67,6 +172,10 @@ func (h *PullRequestHandler) MergePR(w http.ResponseWriter, r *http.Request) {
 		handleValidationError(w, err, logger)
 		return
 	}
+	if req.Override && !authorizeBearer(w, r, h.adminToken) {
+		logger.Warn("merge override without the admin token", slog.String("pr_id", req.PullRequestID))
+		return
+	}
 	response, err := h.service.MergePR(r.Context(), req)
 	if err != nil {
 		handleServiceError(w, err, logger)
@@ -75,6 +184,28 @@ func (h *PullRequestHandler) MergePR(w http.ResponseWriter, r *http.Request) {
 	sendSuccessResponse(w, http.StatusOK, response, logger)
 }
 
+// MergeBulk merges a batch of pull requests.
+// Responds 207 Multi-Status when some of them were not merged.
+func (h *PullRequestHandler) MergeBulk(w http.ResponseWriter, r *http.Request) {
+	op := "PullRequestHandler.MergeBulk"
+	logger := h.logger.With(slog.String("op", op))
+	var req prDto.MergeBulkRequest
+	if err := decodeAndValidate(r, h.validate, &req); err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	response, err := h.service.MergeBulk(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	status := http.StatusOK
+	if response.Summary.NotFound > 0 || response.Summary.Failed > 0 {
+		status = http.StatusMultiStatus
+	}
+	sendSuccessResponse(w, status, response, logger)
+}
+
 // ReassignReviewer reassigning a reviewer of pull request.
 func (h *PullRequestHandler) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
 	op := "PullRequestHandler.ReassignReviewer"
@@ -91,3 +222,235 @@ func (h *PullRequestHandler) ReassignReviewer(w http.ResponseWriter, r *http.Req
 	}
 	sendSuccessResponse(w, http.StatusOK, response, logger)
 }
+
+// SuggestRebalance previews reviews of the user's teammates that could be handed over to the user.
+func (h *PullRequestHandler) SuggestRebalance(w http.ResponseWriter, r *http.Request) {
+	op := "PullRequestHandler.SuggestRebalance"
+	logger := h.logger.With(slog.String("op", op))
+	query := readQuery(r)
+	req := prDto.RebalanceSuggestionsRequest{
+		UserID: query.get("user_id"),
+		Limit:  query.integer("limit", defaultRebalanceLimit),
+	}
+	if query.err != nil {
+		handleValidationError(w, query.err, logger)
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	response, err := h.service.SuggestRebalance(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
+
+// ApplyRebalance hands a review over as suggested by SuggestRebalance.
+func (h *PullRequestHandler) ApplyRebalance(w http.ResponseWriter, r *http.Request) {
+	op := "PullRequestHandler.ApplyRebalance"
+	logger := h.logger.With(slog.String("op", op))
+	var req prDto.ApplyRebalanceRequest
+	if err := decodeAndValidate(r, h.validate, &req); err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	response, err := h.service.ApplyRebalance(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
+
+// SubmitReview records the review state of a reviewer of pull request.
+func (h *PullRequestHandler) SubmitReview(w http.ResponseWriter, r *http.Request) {
+	op := "PullRequestHandler.SubmitReview"
+	logger := h.logger.With(slog.String("op", op))
+	var req prDto.ReviewRequest
+	if err := decodeAndValidate(r, h.validate, &req); err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	response, err := h.service.SubmitReview(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
+
+// SetLabels replaces labels of an open pull request.
+func (h *PullRequestHandler) SetLabels(w http.ResponseWriter, r *http.Request) {
+	op := "PullRequestHandler.SetLabels"
+	logger := h.logger.With(slog.String("op", op))
+	var req prDto.SetLabelsRequest
+	if err := decodeAndValidate(r, h.validate, &req); err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	response, err := h.service.SetLabels(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
+
+// SearchPRs searches pull requests by title substring.
+func (h *PullRequestHandler) SearchPRs(w http.ResponseWriter, r *http.Request) {
+	op := "PullRequestHandler.SearchPRs"
+	logger := h.logger.With(slog.String("op", op))
+	page, err := parsePage(r, "", defaultSearchLimit)
+	if err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	sort, err := parseSort(r, searchSortFields)
+	if err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	query := readQuery(r)
+	req := prDto.SearchPrRequest{
+		Query:           query.get("q"),
+		Status:          query.get("status"),
+		Page:            page,
+		Sort:            sort,
+		Labels:          parseList(r, "labels"),
+		ExpandReviewers: expandsReviewers(r),
+	}
+	if query.err != nil {
+		handleValidationError(w, query.err, logger)
+		return
+	}
+	if err = h.validate.Struct(req); err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	response, err := h.service.SearchPRs(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
+
+// SuggestReviewers previews reviewers that would be assigned to a new pull request by the author.
+func (h *PullRequestHandler) SuggestReviewers(w http.ResponseWriter, r *http.Request) {
+	op := "PullRequestHandler.SuggestReviewers"
+	logger := h.logger.With(slog.String("op", op))
+	query := readQuery(r)
+	req := prDto.SuggestReviewersRequest{
+		AuthorID:     query.get("author_id"),
+		Count:        query.integer("count", defaultSuggestCount),
+		Priority:     query.get("priority"),
+		RequiredTags: parseList(r, "required_tags"),
+	}
+	if query.err != nil {
+		handleValidationError(w, query.err, logger)
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	response, err := h.service.SuggestReviewers(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
+
+// GetHistory returns reviewer changes of a pull request.
+func (h *PullRequestHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	op := "PullRequestHandler.GetHistory"
+	logger := h.logger.With(slog.String("op", op))
+	query := readQuery(r)
+	prID := query.required("pull_request_id")
+	if query.err != nil {
+		handleValidationError(w, query.err, logger)
+		return
+	}
+	response, err := h.service.GetHistory(r.Context(), prID)
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
+
+// GetUnassignedPRs returns open pull requests without reviewers.
+func (h *PullRequestHandler) GetUnassignedPRs(w http.ResponseWriter, r *http.Request) {
+	op := "PullRequestHandler.GetUnassignedPRs"
+	logger := h.logger.With(slog.String("op", op))
+	page, err := parsePage(r, "", defaultPageLimit)
+	if err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	sort, err := parseSort(r, unassignedSortFields)
+	if err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	req := prDto.UnassignedRequest{Page: page, Sort: sort, Labels: parseList(r, "labels")}
+	query := readQuery(r)
+	if query.has("assignment_skipped") {
+		skipped := query.boolean("assignment_skipped")
+		req.AssignmentSkipped = &skipped
+	}
+	if query.err != nil {
+		handleValidationError(w, query.err, logger)
+		return
+	}
+	if err = h.validate.Struct(req); err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	response, err := h.service.GetUnassignedPRs(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
+
+// AssignPR assigns reviewers to an open pull request without them, usually one created with
+// skipped assignment.
+func (h *PullRequestHandler) AssignPR(w http.ResponseWriter, r *http.Request) {
+	op := "PullRequestHandler.AssignPR"
+	logger := h.logger.With(slog.String("op", op))
+	var req prDto.AssignPrRequest
+	if err := decodeAndValidate(r, h.validate, &req); err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	response, err := h.service.AssignPR(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
+
+// AssignPending retries reviewer assignment for open pull requests without reviewers.
+func (h *PullRequestHandler) AssignPending(w http.ResponseWriter, r *http.Request) {
+	op := "PullRequestHandler.AssignPending"
+	logger := h.logger.With(slog.String("op", op))
+	req := prDto.AssignPendingRequest{Limit: defaultPageLimit}
+	// the body is optional, an empty one processes the first page
+	if err := decodeAndValidate(r, h.validate, &req); err != nil && !errors.Is(err, io.EOF) {
+		handleValidationError(w, err, logger)
+		return
+	}
+	response, err := h.service.AssignPending(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
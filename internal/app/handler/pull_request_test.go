@@ -0,0 +1,786 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	prDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/pullrequest"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler/mocks"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type prCase = handlerCase[*mocks.MockPullRequestService]
+
+func runPRCases(t *testing.T, handle func(h *PullRequestHandler) http.HandlerFunc, cases []prCase) {
+	t.Helper()
+	runCases(t, mocks.NewMockPullRequestService, func(m *mocks.MockPullRequestService) http.HandlerFunc {
+		return handle(NewPullRequestHandler(m, testLogger(), nil, testToken))
+	}, cases)
+}
+
+func TestPullRequestHandler_CreatePR(t *testing.T) {
+	const body = `{"pull_request_id":"pr-1","pull_request_name":"Add feature","author_id":"u1"}`
+	req := prDto.CreatePrRequest{PullRequestID: "pr-1", PullRequestName: "Add feature", AuthorID: "u1"}
+	expectError := func(err error) func(m *mocks.MockPullRequestService) {
+		return func(m *mocks.MockPullRequestService) {
+			m.EXPECT().CreatePR(gomock.Any(), req).Return(nil, err)
+		}
+	}
+
+	runPRCases(t, func(h *PullRequestHandler) http.HandlerFunc { return h.CreatePR }, []prCase{
+		{
+			name: "Success - PR created", method: http.MethodPost, target: "/pullRequest/create", body: body,
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().CreatePR(gomock.Any(), req).Return(&prDto.CreatePrResponse{
+					Pr: prDto.PR{PullRequestID: "pr-1", AuthorID: "u1", Status: "OPEN", AssignedReviewers: []string{"u2", "u3"}},
+				}, nil)
+			},
+			status: http.StatusCreated,
+			check: func(t *testing.T, body []byte) {
+				resp := decodeBody[prDto.CreatePrResponse](t, body)
+				assert.Equal(t, "pr-1", resp.Pr.PullRequestID)
+				assert.Equal(t, []string{"u2", "u3"}, resp.Pr.AssignedReviewers)
+			},
+		},
+		{
+			name: "Error - Malformed JSON", method: http.MethodPost, target: "/pullRequest/create",
+			body: `{"pull_request_id":`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Empty body", method: http.MethodPost, target: "/pullRequest/create",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Missing author", method: http.MethodPost, target: "/pullRequest/create",
+			body: `{"pull_request_id":"pr-1","pull_request_name":"Add feature"}`, status: http.StatusBadRequest,
+			code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Unknown priority", method: http.MethodPost, target: "/pullRequest/create",
+			body:   `{"pull_request_id":"pr-1","pull_request_name":"Add feature","author_id":"u1","priority":"ASAP"}`,
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Success - Pinned reviewers", method: http.MethodPost, target: "/pullRequest/create",
+			body: `{"pull_request_id":"pr-1","pull_request_name":"Add feature","author_id":"u1",` +
+				`"reviewer_ids":["u3"],"fill_remaining":true}`,
+			setup: func(m *mocks.MockPullRequestService) {
+				pinned := req
+				pinned.ReviewerIDs = []string{"u3"}
+				pinned.FillRemaining = true
+				m.EXPECT().CreatePR(gomock.Any(), pinned).Return(&prDto.CreatePrResponse{
+					Pr: prDto.PR{PullRequestID: "pr-1", AssignedReviewers: []string{"u3", "u2"}},
+				}, nil)
+			},
+			status: http.StatusCreated,
+		},
+		{
+			name: "Error - Too many pinned reviewers", method: http.MethodPost, target: "/pullRequest/create",
+			body:   `{"pull_request_id":"pr-1","pull_request_name":"Add feature","author_id":"u1","reviewer_ids":["u2","u3","u4"]}`,
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Repeated pinned reviewer", method: http.MethodPost, target: "/pullRequest/create",
+			body:   `{"pull_request_id":"pr-1","pull_request_name":"Add feature","author_id":"u1","reviewer_ids":["u2","u2"]}`,
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Skipped assignment with pinned reviewers", method: http.MethodPost, target: "/pullRequest/create",
+			body:   `{"pull_request_id":"pr-1","pull_request_name":"Add feature","author_id":"u1","reviewer_ids":["u2"],"skip_assignment":true}`,
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Author not found", method: http.MethodPost, target: "/pullRequest/create", body: body,
+			setup:  expectError(domainErrors.NewNotFound("author not found")),
+			status: http.StatusNotFound, code: domainErrors.CodeNotFound,
+		},
+		{
+			name: "Error - PR exists", method: http.MethodPost, target: "/pullRequest/create", body: body,
+			setup:  expectError(domainErrors.NewPRExists("PR id already exists")),
+			status: http.StatusConflict, code: domainErrors.CodePRExists,
+		},
+		{
+			name: "Error - Repository failure", method: http.MethodPost, target: "/pullRequest/create", body: body,
+			setup:  expectError(errors.New("connection refused")),
+			status: http.StatusInternalServerError, code: CodeInternalError,
+		},
+	})
+}
+
+func TestPullRequestHandler_MergePR(t *testing.T) {
+	const body = `{"pull_request_id":"pr-1"}`
+	req := prDto.MergePrRequest{PullRequestID: "pr-1"}
+	expectError := func(err error) func(m *mocks.MockPullRequestService) {
+		return func(m *mocks.MockPullRequestService) {
+			m.EXPECT().MergePR(gomock.Any(), req).Return(nil, err)
+		}
+	}
+
+	runPRCases(t, func(h *PullRequestHandler) http.HandlerFunc { return h.MergePR }, []prCase{
+		{
+			name: "Success - PR merged", method: http.MethodPost, target: "/pullRequest/merge", body: body,
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().MergePR(gomock.Any(), req).Return(&prDto.MergePrResponse{
+					Pr: prDto.PR{PullRequestID: "pr-1", Status: "MERGED"},
+				}, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				assert.Equal(t, "MERGED", decodeBody[prDto.MergePrResponse](t, body).Pr.Status)
+			},
+		},
+		{
+			name: "Error - Malformed JSON", method: http.MethodPost, target: "/pullRequest/merge",
+			body: `[]`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Missing PR id", method: http.MethodPost, target: "/pullRequest/merge",
+			body: `{}`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - PR not found", method: http.MethodPost, target: "/pullRequest/merge", body: body,
+			setup:  expectError(domainErrors.NewNotFound("PR not found")),
+			status: http.StatusNotFound, code: domainErrors.CodeNotFound,
+		},
+		{
+			name: "Error - Changes requested", method: http.MethodPost, target: "/pullRequest/merge", body: body,
+			setup:  expectError(domainErrors.NewChangesRequested("changes requested")),
+			status: http.StatusConflict, code: domainErrors.CodeChangesRequested,
+		},
+		{
+			name: "Error - No reviewers", method: http.MethodPost, target: "/pullRequest/merge", body: body,
+			setup:  expectError(domainErrors.NewNoReviewers("PR has 0 assigned reviewers, at least 1 is required to merge")),
+			status: http.StatusConflict, code: domainErrors.CodeNoReviewers,
+		},
+		{
+			name: "Success - Override with the admin token", method: http.MethodPost, target: "/pullRequest/merge",
+			body: `{"pull_request_id":"pr-1","override":true}`, token: testToken,
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().MergePR(gomock.Any(), prDto.MergePrRequest{PullRequestID: "pr-1", Override: true}).
+					Return(&prDto.MergePrResponse{Pr: prDto.PR{PullRequestID: "pr-1", Status: "MERGED"}}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Error - Override without the admin token", method: http.MethodPost, target: "/pullRequest/merge",
+			body:   `{"pull_request_id":"pr-1","override":true}`,
+			status: http.StatusUnauthorized, code: CodeUnauthorized,
+		},
+		{
+			name: "Error - Override with a wrong token", method: http.MethodPost, target: "/pullRequest/merge",
+			body: `{"pull_request_id":"pr-1","override":true}`, token: "wrong",
+			status: http.StatusUnauthorized, code: CodeUnauthorized,
+		},
+	})
+}
+
+func TestPullRequestHandler_CreateBulk(t *testing.T) {
+	const body = `{"pull_requests":[` +
+		`{"pull_request_id":"pr-1","pull_request_name":"Add feature","author_id":"u1"},` +
+		`{"pull_request_id":"pr-2","author_id":"u1"},` +
+		`{"pull_request_id":"pr-3","pull_request_name":"Fix bug","author_id":"u2"}],"assign_reviewers":true}`
+	req := prDto.CreateBulkRequest{
+		PullRequests: []prDto.CreatePrRequest{
+			{PullRequestID: "pr-1", PullRequestName: "Add feature", AuthorID: "u1"},
+			{PullRequestID: "pr-3", PullRequestName: "Fix bug", AuthorID: "u2"},
+		},
+		AssignReviewers: true,
+	}
+
+	runPRCases(t, func(h *PullRequestHandler) http.HandlerFunc { return h.CreateBulk }, []prCase{
+		{
+			name: "Success - All created", method: http.MethodPost, target: "/pullRequest/createBulk",
+			body: `{"pull_requests":[{"pull_request_id":"pr-1","pull_request_name":"Add feature","author_id":"u1"}]}`,
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().CreateBulk(gomock.Any(), prDto.CreateBulkRequest{
+					PullRequests: req.PullRequests[:1],
+				}).Return(&prDto.CreateBulkResponse{
+					Results: []prDto.CreateBulkResult{{PullRequestID: "pr-1", Result: prDto.CreateResultCreated}},
+					Summary: prDto.CreateBulkSummary{Created: 1},
+				}, nil)
+			},
+			status: http.StatusCreated,
+		},
+		{
+			name: "Success - Invalid items are reported in place", method: http.MethodPost,
+			target: "/pullRequest/createBulk", body: body,
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().CreateBulk(gomock.Any(), req).Return(&prDto.CreateBulkResponse{
+					Results: []prDto.CreateBulkResult{
+						{Index: 0, PullRequestID: "pr-1", Result: prDto.CreateResultCreated},
+						{Index: 1, PullRequestID: "pr-3", Result: prDto.CreateResultAlreadyExists},
+					},
+					Summary: prDto.CreateBulkSummary{Created: 1, AlreadyExists: 1},
+				}, nil)
+			},
+			status: http.StatusMultiStatus,
+			check: func(t *testing.T, body []byte) {
+				resp := decodeBody[prDto.CreateBulkResponse](t, body)
+				if !assert.Len(t, resp.Results, 3) {
+					return
+				}
+				assert.Equal(t, prDto.CreateBulkResult{Index: 0, PullRequestID: "pr-1",
+					Result: prDto.CreateResultCreated}, resp.Results[0])
+				assert.Equal(t, 1, resp.Results[1].Index)
+				assert.Equal(t, prDto.CreateResultInvalid, resp.Results[1].Result)
+				assert.Contains(t, resp.Results[1].Error, "pull_request_name")
+				assert.Equal(t, prDto.CreateBulkResult{Index: 2, PullRequestID: "pr-3",
+					Result: prDto.CreateResultAlreadyExists}, resp.Results[2])
+				assert.Equal(t, prDto.CreateBulkSummary{Created: 1, AlreadyExists: 1, Invalid: 1}, resp.Summary)
+			},
+		},
+		{
+			name: "Success - All items invalid", method: http.MethodPost, target: "/pullRequest/createBulk",
+			body:   `{"pull_requests":[{"pull_request_id":"pr-1"}]}`,
+			status: http.StatusMultiStatus,
+			check: func(t *testing.T, body []byte) {
+				resp := decodeBody[prDto.CreateBulkResponse](t, body)
+				assert.Equal(t, prDto.CreateBulkSummary{Invalid: 1}, resp.Summary)
+			},
+		},
+		{
+			name: "Error - Malformed JSON", method: http.MethodPost, target: "/pullRequest/createBulk",
+			body: `{"pull_requests":{}}`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Empty batch", method: http.MethodPost, target: "/pullRequest/createBulk",
+			body: `{"pull_requests":[]}`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Repository failure", method: http.MethodPost, target: "/pullRequest/createBulk", body: body,
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().CreateBulk(gomock.Any(), req).Return(nil, errors.New("connection refused"))
+			},
+			status: http.StatusInternalServerError, code: CodeInternalError,
+		},
+	})
+}
+
+func TestPullRequestHandler_MergeBulk(t *testing.T) {
+	const body = `{"pull_request_ids":["pr-1","pr-2"]}`
+	req := prDto.MergeBulkRequest{PullRequestIDs: []string{"pr-1", "pr-2"}}
+
+	runPRCases(t, func(h *PullRequestHandler) http.HandlerFunc { return h.MergeBulk }, []prCase{
+		{
+			name: "Success - All merged", method: http.MethodPost, target: "/pullRequest/mergeBulk", body: body,
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().MergeBulk(gomock.Any(), req).Return(&prDto.MergeBulkResponse{
+					Summary: prDto.MergeBulkSummary{Merged: 1, AlreadyMerged: 1},
+				}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Success - Partially merged", method: http.MethodPost, target: "/pullRequest/mergeBulk", body: body,
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().MergeBulk(gomock.Any(), req).Return(&prDto.MergeBulkResponse{
+					Summary: prDto.MergeBulkSummary{Merged: 1, NotFound: 1},
+				}, nil)
+			},
+			status: http.StatusMultiStatus,
+		},
+		{
+			name: "Error - Malformed JSON", method: http.MethodPost, target: "/pullRequest/mergeBulk",
+			body: `{"pull_request_ids":"pr-1"}`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Empty batch", method: http.MethodPost, target: "/pullRequest/mergeBulk",
+			body: `{"pull_request_ids":[]}`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Repository failure", method: http.MethodPost, target: "/pullRequest/mergeBulk", body: body,
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().MergeBulk(gomock.Any(), req).Return(nil, errors.New("connection refused"))
+			},
+			status: http.StatusInternalServerError, code: CodeInternalError,
+		},
+	})
+}
+
+func TestPullRequestHandler_ReassignReviewer(t *testing.T) {
+	const body = `{"pull_request_id":"pr-1","old_reviewer_id":"u2"}`
+	req := prDto.ReassignReviewerRequest{PullRequestID: "pr-1", OldReviewerID: "u2"}
+	expectError := func(err error) func(m *mocks.MockPullRequestService) {
+		return func(m *mocks.MockPullRequestService) {
+			m.EXPECT().ReassignReviewer(gomock.Any(), req).Return(nil, err)
+		}
+	}
+
+	runPRCases(t, func(h *PullRequestHandler) http.HandlerFunc { return h.ReassignReviewer }, []prCase{
+		{
+			name: "Success - Reviewer replaced", method: http.MethodPost, target: "/pullRequest/reassign", body: body,
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().ReassignReviewer(gomock.Any(), req).Return(&prDto.ReassignReviewerResponse{
+					Pr: prDto.PR{PullRequestID: "pr-1", AssignedReviewers: []string{"u3", "u4"}}, ReplacedBy: "u4",
+				}, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				assert.Equal(t, "u4", decodeBody[prDto.ReassignReviewerResponse](t, body).ReplacedBy)
+			},
+		},
+		{
+			name: "Success - Explicit new reviewer", method: http.MethodPost, target: "/pullRequest/reassign",
+			body: `{"pull_request_id":"pr-1","old_reviewer_id":"u2","new_reviewer_id":"u5"}`,
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().ReassignReviewer(gomock.Any(), prDto.ReassignReviewerRequest{
+					PullRequestID: "pr-1", OldReviewerID: "u2", NewReviewerID: "u5",
+				}).Return(&prDto.ReassignReviewerResponse{ReplacedBy: "u5"}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Error - Malformed JSON", method: http.MethodPost, target: "/pullRequest/reassign",
+			body: `not json`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Missing old reviewer", method: http.MethodPost, target: "/pullRequest/reassign",
+			body: `{"pull_request_id":"pr-1"}`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - PR not found", method: http.MethodPost, target: "/pullRequest/reassign", body: body,
+			setup:  expectError(domainErrors.NewNotFound("PR not found")),
+			status: http.StatusNotFound, code: domainErrors.CodeNotFound,
+		},
+		{
+			name: "Error - PR merged", method: http.MethodPost, target: "/pullRequest/reassign", body: body,
+			setup:  expectError(domainErrors.NewPRMerged("cannot reassign on merged PR")),
+			status: http.StatusConflict, code: domainErrors.CodePRMerged,
+		},
+		{
+			name: "Error - Not assigned", method: http.MethodPost, target: "/pullRequest/reassign", body: body,
+			setup:  expectError(domainErrors.NewNotAssigned("reviewer is not assigned to this PR")),
+			status: http.StatusBadRequest, code: domainErrors.CodeNotAssigned,
+		},
+		{
+			name: "Error - No candidate", method: http.MethodPost, target: "/pullRequest/reassign", body: body,
+			setup:  expectError(domainErrors.NewNoCandidate("no active replacement candidate in team")),
+			status: http.StatusConflict, code: domainErrors.CodeNoCandidate,
+		},
+		{
+			name: "Error - Already assigned", method: http.MethodPost, target: "/pullRequest/reassign", body: body,
+			setup:  expectError(domainErrors.NewAlreadyAssigned("reviewer is already assigned")),
+			status: http.StatusConflict, code: domainErrors.CodeAlreadyAssigned,
+		},
+		{
+			name: "Error - Wrong team", method: http.MethodPost, target: "/pullRequest/reassign", body: body,
+			setup:  expectError(domainErrors.NewWrongTeam("reviewer is from another team")),
+			status: http.StatusBadRequest, code: domainErrors.CodeWrongTeam,
+		},
+		{
+			name: "Error - Reviewer is author", method: http.MethodPost, target: "/pullRequest/reassign", body: body,
+			setup:  expectError(domainErrors.NewReviewerIsAuthor("author cannot review")),
+			status: http.StatusBadRequest, code: domainErrors.CodeReviewerIsAuthor,
+		},
+		{
+			name: "Error - Reviewer excluded", method: http.MethodPost, target: "/pullRequest/reassign", body: body,
+			setup:  expectError(domainErrors.NewReviewerExcluded("reviewer is excluded")),
+			status: http.StatusBadRequest, code: domainErrors.CodeReviewerExcluded,
+		},
+	})
+}
+
+func TestPullRequestHandler_SuggestRebalance(t *testing.T) {
+	runPRCases(t, func(h *PullRequestHandler) http.HandlerFunc { return h.SuggestRebalance }, []prCase{
+		{
+			name: "Success - Default limit", method: http.MethodGet, target: "/users/rebalanceSuggestions?user_id=u5",
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().SuggestRebalance(gomock.Any(), prDto.RebalanceSuggestionsRequest{
+					UserID: "u5", Limit: defaultRebalanceLimit,
+				}).Return(&prDto.RebalanceSuggestionsResponse{UserID: "u5", Suggestions: []prDto.RebalanceSuggestion{}}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Success - Explicit limit", method: http.MethodGet, target: "/users/rebalanceSuggestions?user_id=u5&limit=20",
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().SuggestRebalance(gomock.Any(), prDto.RebalanceSuggestionsRequest{UserID: "u5", Limit: 20}).
+					Return(&prDto.RebalanceSuggestionsResponse{UserID: "u5", Suggestions: []prDto.RebalanceSuggestion{}}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Error - Missing user", method: http.MethodGet, target: "/users/rebalanceSuggestions",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Limit out of range", method: http.MethodGet, target: "/users/rebalanceSuggestions?user_id=u5&limit=21",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Limit is not a number", method: http.MethodGet, target: "/users/rebalanceSuggestions?user_id=u5&limit=all",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - User not active", method: http.MethodGet, target: "/users/rebalanceSuggestions?user_id=u5",
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().SuggestRebalance(gomock.Any(), gomock.Any()).Return(nil, domainErrors.NewNotFound("user is not active"))
+			},
+			status: http.StatusNotFound, code: domainErrors.CodeNotFound,
+		},
+	})
+}
+
+func TestPullRequestHandler_ApplyRebalance(t *testing.T) {
+	const body = `{"pull_request_id":"pr-1","current_reviewer_id":"u2","proposed_reviewer_id":"u5"}`
+	req := prDto.ApplyRebalanceRequest{PullRequestID: "pr-1", CurrentReviewerID: "u2", ProposedReviewerID: "u5"}
+
+	runPRCases(t, func(h *PullRequestHandler) http.HandlerFunc { return h.ApplyRebalance }, []prCase{
+		{
+			name: "Success - Review handed over", method: http.MethodPost, target: "/pullRequest/applyRebalance", body: body,
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().ApplyRebalance(gomock.Any(), req).Return(&prDto.ReassignReviewerResponse{ReplacedBy: "u5"}, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				assert.Equal(t, "u5", decodeBody[prDto.ReassignReviewerResponse](t, body).ReplacedBy)
+			},
+		},
+		{
+			name: "Error - Missing proposed reviewer", method: http.MethodPost, target: "/pullRequest/applyRebalance",
+			body:   `{"pull_request_id":"pr-1","current_reviewer_id":"u2"}`,
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Suggestion already applied", method: http.MethodPost, target: "/pullRequest/applyRebalance", body: body,
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().ApplyRebalance(gomock.Any(), req).
+					Return(nil, domainErrors.NewNotAssigned("reviewer is not assigned to this PR"))
+			},
+			status: http.StatusBadRequest, code: domainErrors.CodeNotAssigned,
+		},
+	})
+}
+
+func TestPullRequestHandler_SubmitReview(t *testing.T) {
+	const body = `{"pull_request_id":"pr-1","reviewer_id":"u2","state":"APPROVED"}`
+	req := prDto.ReviewRequest{PullRequestID: "pr-1", ReviewerID: "u2", State: "APPROVED"}
+	expectError := func(err error) func(m *mocks.MockPullRequestService) {
+		return func(m *mocks.MockPullRequestService) {
+			m.EXPECT().SubmitReview(gomock.Any(), req).Return(nil, err)
+		}
+	}
+
+	runPRCases(t, func(h *PullRequestHandler) http.HandlerFunc { return h.SubmitReview }, []prCase{
+		{
+			name: "Success - Review submitted", method: http.MethodPost, target: "/pullRequest/review", body: body,
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().SubmitReview(gomock.Any(), req).Return(&prDto.ReviewResponse{
+					Pr: prDto.PR{PullRequestID: "pr-1"},
+				}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Error - Malformed JSON", method: http.MethodPost, target: "/pullRequest/review",
+			body: `{"state":1}`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Unknown state", method: http.MethodPost, target: "/pullRequest/review",
+			body:   `{"pull_request_id":"pr-1","reviewer_id":"u2","state":"LGTM"}`,
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Not assigned", method: http.MethodPost, target: "/pullRequest/review", body: body,
+			setup:  expectError(domainErrors.NewNotAssigned("reviewer is not assigned to this PR")),
+			status: http.StatusBadRequest, code: domainErrors.CodeNotAssigned,
+		},
+		{
+			name: "Error - Invalid transition", method: http.MethodPost, target: "/pullRequest/review", body: body,
+			setup:  expectError(domainErrors.NewInvalidTransition("review is already approved")),
+			status: http.StatusConflict, code: domainErrors.CodeInvalidTransition,
+		},
+		{
+			name: "Error - PR merged", method: http.MethodPost, target: "/pullRequest/review", body: body,
+			setup:  expectError(domainErrors.NewPRMerged("PR is merged")),
+			status: http.StatusConflict, code: domainErrors.CodePRMerged,
+		},
+	})
+}
+
+func TestPullRequestHandler_SetLabels(t *testing.T) {
+	const body = `{"pull_request_id":"pr-1","labels":["backend"]}`
+	req := prDto.SetLabelsRequest{PullRequestID: "pr-1", Labels: []string{"backend"}}
+
+	runPRCases(t, func(h *PullRequestHandler) http.HandlerFunc { return h.SetLabels }, []prCase{
+		{
+			name: "Success - Labels set", method: http.MethodPost, target: "/pullRequest/setLabels", body: body,
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().SetLabels(gomock.Any(), req).Return(&prDto.SetLabelsResponse{
+					Pr: prDto.PR{PullRequestID: "pr-1", Labels: []string{"backend"}},
+				}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Error - Malformed JSON", method: http.MethodPost, target: "/pullRequest/setLabels",
+			body: `{"labels":`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Empty label", method: http.MethodPost, target: "/pullRequest/setLabels",
+			body: `{"pull_request_id":"pr-1","labels":[""]}`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - PR merged", method: http.MethodPost, target: "/pullRequest/setLabels", body: body,
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().SetLabels(gomock.Any(), req).Return(nil, domainErrors.NewPRMerged("PR is merged"))
+			},
+			status: http.StatusConflict, code: domainErrors.CodePRMerged,
+		},
+	})
+}
+
+func TestPullRequestHandler_SearchPRs(t *testing.T) {
+	runPRCases(t, func(h *PullRequestHandler) http.HandlerFunc { return h.SearchPRs }, []prCase{
+		{
+			name: "Success - Defaults applied", method: http.MethodGet, target: "/pullRequest/search?q=feature",
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().SearchPRs(gomock.Any(), prDto.SearchPrRequest{
+					Query: "feature", Page: dto.PageRequest{Limit: defaultSearchLimit},
+				}).Return(&dto.Page[prDto.PR]{Items: []prDto.PR{}, Limit: defaultSearchLimit}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "Success - All parameters",
+			method: http.MethodGet,
+			target: "/pullRequest/search?q=feature&status=OPEN&limit=5&offset=10&labels=backend,urgent&expand=reviewers" +
+				"&sort=-priority",
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().SearchPRs(gomock.Any(), prDto.SearchPrRequest{
+					Query: "feature", Status: "OPEN", Page: dto.PageRequest{Limit: 5, Offset: 10},
+					Sort:   dto.Sort{Field: "priority", Desc: true},
+					Labels: []string{"backend", "urgent"}, ExpandReviewers: true,
+				}).Return(&dto.Page[prDto.PR]{Items: []prDto.PR{}, Limit: 5, Offset: 10}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Error - Missing query", method: http.MethodGet, target: "/pullRequest/search",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Limit is not a number", method: http.MethodGet, target: "/pullRequest/search?q=a&limit=ten",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Limit out of range", method: http.MethodGet, target: "/pullRequest/search?q=a&limit=101",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Negative offset", method: http.MethodGet, target: "/pullRequest/search?q=a&offset=-1",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Unknown status", method: http.MethodGet, target: "/pullRequest/search?q=a&status=DRAFT",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Unknown sort field", method: http.MethodGet, target: "/pullRequest/search?q=a&sort=author_id",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - SQL in sort", method: http.MethodGet,
+			target: "/pullRequest/search?q=a&sort=" + url.QueryEscape("created_at; DROP TABLE pull_request"),
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+	})
+}
+
+func TestPullRequestHandler_SuggestReviewers(t *testing.T) {
+	runPRCases(t, func(h *PullRequestHandler) http.HandlerFunc { return h.SuggestReviewers }, []prCase{
+		{
+			name: "Success - Default count", method: http.MethodGet, target: "/pullRequest/suggestReviewers?author_id=u1",
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().SuggestReviewers(gomock.Any(), prDto.SuggestReviewersRequest{
+					AuthorID: "u1", Count: defaultSuggestCount,
+				}).Return(&prDto.SuggestReviewersResponse{AuthorID: "u1", Candidates: []prDto.SuggestedReviewer{}}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "Success - All parameters",
+			method: http.MethodGet,
+			target: "/pullRequest/suggestReviewers?author_id=u1&count=3&priority=URGENT&required_tags=go",
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().SuggestReviewers(gomock.Any(), prDto.SuggestReviewersRequest{
+					AuthorID: "u1", Count: 3, Priority: "URGENT", RequiredTags: []string{"go"},
+				}).Return(&prDto.SuggestReviewersResponse{AuthorID: "u1", Candidates: []prDto.SuggestedReviewer{}}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Error - Missing author", method: http.MethodGet, target: "/pullRequest/suggestReviewers",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Count is not a number", method: http.MethodGet,
+			target: "/pullRequest/suggestReviewers?author_id=u1&count=many",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Count out of range", method: http.MethodGet,
+			target: "/pullRequest/suggestReviewers?author_id=u1&count=0",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Author not found", method: http.MethodGet, target: "/pullRequest/suggestReviewers?author_id=u1",
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().SuggestReviewers(gomock.Any(), gomock.Any()).Return(nil, domainErrors.NewNotFound("author not found"))
+			},
+			status: http.StatusNotFound, code: domainErrors.CodeNotFound,
+		},
+	})
+}
+
+func TestPullRequestHandler_GetHistory(t *testing.T) {
+	runPRCases(t, func(h *PullRequestHandler) http.HandlerFunc { return h.GetHistory }, []prCase{
+		{
+			name: "Success - History returned", method: http.MethodGet, target: "/pullRequest/history?pull_request_id=pr-1",
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().GetHistory(gomock.Any(), "pr-1").Return(&prDto.HistoryResponse{
+					PullRequestID: "pr-1", History: []prDto.ReviewerChange{},
+				}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Error - Missing PR id", method: http.MethodGet, target: "/pullRequest/history",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - PR not found", method: http.MethodGet, target: "/pullRequest/history?pull_request_id=pr-1",
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().GetHistory(gomock.Any(), "pr-1").Return(nil, domainErrors.NewNotFound("PR not found"))
+			},
+			status: http.StatusNotFound, code: domainErrors.CodeNotFound,
+		},
+	})
+}
+
+func TestPullRequestHandler_GetUnassignedPRs(t *testing.T) {
+	runPRCases(t, func(h *PullRequestHandler) http.HandlerFunc { return h.GetUnassignedPRs }, []prCase{
+		{
+			name: "Success - Default page", method: http.MethodGet, target: "/pullRequest/unassigned",
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().GetUnassignedPRs(gomock.Any(), prDto.UnassignedRequest{
+					Page: dto.PageRequest{Limit: defaultPageLimit},
+				}).Return(&dto.Page[prDto.PR]{Items: []prDto.PR{}, Limit: defaultPageLimit}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Success - Explicit page", method: http.MethodGet,
+			target: "/pullRequest/unassigned?limit=10&offset=30&labels=backend&sort=title",
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().GetUnassignedPRs(gomock.Any(), prDto.UnassignedRequest{
+					Page: dto.PageRequest{Limit: 10, Offset: 30}, Sort: dto.Sort{Field: "title"},
+					Labels: []string{"backend"},
+				}).Return(&dto.Page[prDto.PR]{Items: []prDto.PR{}, Limit: 10, Offset: 30}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Success - Only PRs with skipped assignment", method: http.MethodGet,
+			target: "/pullRequest/unassigned?assignment_skipped=true",
+			setup: func(m *mocks.MockPullRequestService) {
+				skipped := true
+				m.EXPECT().GetUnassignedPRs(gomock.Any(), prDto.UnassignedRequest{
+					Page: dto.PageRequest{Limit: defaultPageLimit}, AssignmentSkipped: &skipped,
+				}).Return(&dto.Page[prDto.PR]{Items: []prDto.PR{}, Limit: defaultPageLimit}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Error - Skipped assignment is not a boolean", method: http.MethodGet,
+			target: "/pullRequest/unassigned?assignment_skipped=maybe",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Offset is not a number", method: http.MethodGet, target: "/pullRequest/unassigned?offset=x",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Negative offset", method: http.MethodGet, target: "/pullRequest/unassigned?offset=-1",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Sort field of another endpoint", method: http.MethodGet,
+			target: "/pullRequest/unassigned?sort=-updated_at",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Repository failure", method: http.MethodGet, target: "/pullRequest/unassigned",
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().GetUnassignedPRs(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+			},
+			status: http.StatusInternalServerError, code: CodeInternalError,
+		},
+	})
+}
+
+func TestPullRequestHandler_AssignPR(t *testing.T) {
+	const body = `{"pull_request_id":"pr-1"}`
+	req := prDto.AssignPrRequest{PullRequestID: "pr-1"}
+
+	runPRCases(t, func(h *PullRequestHandler) http.HandlerFunc { return h.AssignPR }, []prCase{
+		{
+			name: "Success - Reviewers assigned", method: http.MethodPost, target: "/pullRequest/assign", body: body,
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().AssignPR(gomock.Any(), req).Return(&prDto.AssignPrResponse{
+					Pr: prDto.PR{PullRequestID: "pr-1", AssignedReviewers: []string{"u2", "u3"}},
+				}, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				assert.Equal(t, []string{"u2", "u3"}, decodeBody[prDto.AssignPrResponse](t, body).Pr.AssignedReviewers)
+			},
+		},
+		{
+			name: "Error - Missing PR id", method: http.MethodPost, target: "/pullRequest/assign",
+			body: `{}`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Merged PR", method: http.MethodPost, target: "/pullRequest/assign", body: body,
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().AssignPR(gomock.Any(), req).Return(nil, domainErrors.NewPRMerged("cannot assign reviewers to merged PR"))
+			},
+			status: http.StatusConflict, code: domainErrors.CodePRMerged,
+		},
+	})
+}
+
+func TestPullRequestHandler_AssignPending(t *testing.T) {
+	runPRCases(t, func(h *PullRequestHandler) http.HandlerFunc { return h.AssignPending }, []prCase{
+		{
+			name: "Success - Empty body", method: http.MethodPost, target: "/pullRequest/assignPending",
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().AssignPending(gomock.Any(), prDto.AssignPendingRequest{Limit: defaultPageLimit}).
+					Return(&prDto.AssignPendingResponse{PullRequests: []prDto.PR{}}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Success - Explicit page", method: http.MethodPost, target: "/pullRequest/assignPending",
+			body: `{"limit":50,"offset":100}`,
+			setup: func(m *mocks.MockPullRequestService) {
+				m.EXPECT().AssignPending(gomock.Any(), prDto.AssignPendingRequest{Limit: 50, Offset: 100}).
+					Return(&prDto.AssignPendingResponse{PullRequests: []prDto.PR{}}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Error - Malformed JSON", method: http.MethodPost, target: "/pullRequest/assignPending",
+			body: `{"limit":`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Limit out of range", method: http.MethodPost, target: "/pullRequest/assignPending",
+			body: `{"limit":1000}`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+	})
+}
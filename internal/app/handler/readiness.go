@@ -0,0 +1,49 @@
+package handler
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mocks/mock_readiness_service.go -package=mocks
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+)
+
+// ReadinessService defines the interface for checking the dependencies of the service.
+type ReadinessService interface {
+	Check(ctx context.Context) *admin.ReadinessResponse
+}
+
+// ReadinessHandler handles the readiness probe.
+type ReadinessHandler struct {
+	service ReadinessService
+	logger  *slog.Logger
+}
+
+// NewReadinessHandler creates a new ReadinessHandler.
+func NewReadinessHandler(service ReadinessService, logger *slog.Logger) *ReadinessHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &ReadinessHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Ready answers 200 while the service can serve requests, also when only checks that are not
+// critical failed, and 503 when a critical one failed. The body has the result of every check.
+func (h *ReadinessHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	op := "ReadinessHandler.Ready"
+	logger := h.logger.With(slog.String("op", op))
+
+	response := h.service.Check(r.Context())
+	status := http.StatusOK
+	if response.Status == admin.ReadinessUnavailable {
+		status = http.StatusServiceUnavailable
+	}
+	// probes must see the current state, never a cached one
+	w.Header().Set("Cache-Control", "no-store")
+	sendSuccessResponse(w, status, response, logger)
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReadinessHandler_Ready(t *testing.T) {
+	tests := []struct {
+		name     string
+		response *admin.ReadinessResponse
+		status   int
+	}{
+		{
+			name: "Success - Ready",
+			response: &admin.ReadinessResponse{Status: admin.ReadinessReady, Checks: []admin.ReadinessCheck{
+				{Name: "database", Status: admin.CheckOK, Critical: true, LatencyMS: 0.4},
+			}},
+			status: http.StatusOK,
+		},
+		{
+			name: "Success - Degraded is still ready",
+			response: &admin.ReadinessResponse{
+				Status: admin.ReadinessDegraded,
+				Checks: []admin.ReadinessCheck{
+					{Name: "outbox", Status: admin.CheckFail, Error: "11 deliveries pending, more than 10",
+						Outbox: &admin.OutboxStatus{Pending: 11, Max: 10}},
+				},
+				Warnings: []string{"outbox: 11 deliveries pending, more than 10"},
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Error - Critical check failed",
+			response: &admin.ReadinessResponse{Status: admin.ReadinessUnavailable, Checks: []admin.ReadinessCheck{
+				{Name: "database", Status: admin.CheckFail, Critical: true, Error: "failed to ping database"},
+			}},
+			status: http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mocks.NewMockReadinessService(gomock.NewController(t))
+			m.EXPECT().Check(gomock.Any()).Return(tt.response)
+			router := NewRouter(Services{Readiness: m}, testLogger(), nil)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+			assert.Equal(t, *tt.response, decodeBody[admin.ReadinessResponse](t, rec.Body.Bytes()))
+		})
+	}
+}
@@ -0,0 +1,307 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/shirr9/pr-reviewer-service/api"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+)
+
+// Services are the application services behind the HTTP API.
+type Services struct {
+	PullRequests PullRequestService
+	Users        UserService
+	Teams        TeamService
+	Statistics   StatisticsService
+	Exclusions   ExclusionService
+	Archive      ArchiveService
+	Webhooks     WebhookService
+	// Snapshots keeps the daily statistics snapshots for /statistics/history, which is not served without it
+	Snapshots SnapshotService
+	// Queues follows the reviewers' queues for the live queue socket, which is not served without it
+	Queues QueueSubscriber
+	// GraphQL serves read-only queries of the services as a graph, which is not served without it
+	GraphQL http.Handler
+	// Metrics serves the Prometheus metrics at /metrics, which is not served without it
+	Metrics http.Handler
+	// Dump exports and restores all data; it is served only with DumpToken as the bearer token
+	Dump      DumpService
+	DumpToken string
+	// AdminToken is the bearer token of admin requests within the other routes
+	AdminToken string
+	// Readiness checks the dependencies for /readyz, which is not served without it
+	Readiness ReadinessService
+	// TeamImports imports large teams at /team/importJson, which is not served without it
+	TeamImports TeamImportService
+	// Duplicates reports near-duplicate identifiers at /admin/duplicates, which is not served without it
+	Duplicates DuplicateService
+	// Jobs lists and runs the background jobs at /admin/jobs, which is not served without it
+	Jobs JobService
+	// MaxImportSize limits the body of the CSV team import in bytes; zero uses the default of 1 MiB
+	MaxImportSize int64
+	// RequestTimeout and LongRequestTimeout bound requests, the latter statistics and bulk ones; zero is unbounded
+	RequestTimeout     time.Duration
+	LongRequestTimeout time.Duration
+	// Shedder refuses requests with 503 while too many wait for a database connection
+	Shedder LoadShedder
+	// LowercaseTeamNames folds the team names of requests to lower case
+	LowercaseTeamNames bool
+	// BodyLog logs request and response bodies at Debug level, which are not logged without it
+	BodyLog *BodyLog
+	// DisableLegacyRoutes serves the API only under /api/v1, without the deprecated aliases
+	DisableLegacyRoutes bool
+}
+
+// apiPrefix is the path prefix of the current version of the API. Its routes are also served
+// without it as deprecated aliases unless Services.DisableLegacyRoutes is set.
+const apiPrefix = "/api/v1"
+
+// unversionedRoutes are served only at their own paths: probes and scrapes are configured in the
+// infrastructure and are no part of the API, so its versions don't apply to them.
+var unversionedRoutes = map[string]bool{
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+// NewRouter creates a handler serving all API routes under apiPrefix and, unless disabled, at their
+// deprecated unprefixed paths.
+func NewRouter(services Services, logger *slog.Logger, validate *validator.Validate) http.Handler {
+	if validate == nil {
+		validate = NewValidator()
+	}
+
+	routes := apiRoutes(services, logger, validate)
+	norm := normalizer{lowerTeamNames: services.LowercaseTeamNames}
+	for i := range routes {
+		routes[i].handler = withNormalizer(routes[i].handler, norm)
+		routes[i].handler = withTimeout(routes[i].handler, routeTimeout(routes[i].path, services))
+		if !unshedRoutes[routes[i].path] {
+			routes[i].handler = withLoadShedding(routes[i].handler, services.Shedder)
+		}
+	}
+
+	table := newRouteTable(versionRoutes(routes, !services.DisableLegacyRoutes))
+	return withCompression(withBodyLog(withOrg(table), services.BodyLog, logger), compressMinSize)
+}
+
+// apiRoutes lists the routes of the services at their unprefixed paths; routes of the optional
+// services are left out without them.
+func apiRoutes(services Services, logger *slog.Logger, validate *validator.Validate) []route {
+	prHandler := NewPullRequestHandler(services.PullRequests, logger, validate, services.AdminToken)
+	userHandler := NewUserHandler(services.Users, logger, validate)
+	teamHandler := NewTeamHandler(services.Teams, logger, validate, services.MaxImportSize)
+	statisticsHandler := NewStatisticsHandler(services.Statistics, logger)
+	adminHandler := NewAdminHandler(services.Exclusions, services.Archive, services.Webhooks, logger, validate)
+
+	routes := []route{
+		{http.MethodPost, "/team/add", teamHandler.AddTeam},
+		{http.MethodGet, "/team/get", teamHandler.GetTeam},
+		{http.MethodGet, "/team/list", teamHandler.ListTeams},
+		{http.MethodPost, "/team/importCsv", teamHandler.ImportCSV},
+		{http.MethodPost, "/team/deactivate", teamHandler.DeactivateTeam},
+		{http.MethodGet, "/team/reviewQueue", teamHandler.GetReviewQueue},
+		{http.MethodPost, "/users/setIsActive", userHandler.SetIsActive},
+		{http.MethodPost, "/users/setIsReviewer", userHandler.SetIsReviewer},
+		{http.MethodPost, "/users/setTags", userHandler.SetTags},
+		{http.MethodPost, "/users/update", userHandler.UpdateUser},
+		{http.MethodGet, "/users/getReview", userHandler.GetReview},
+		{http.MethodGet, "/users/rebalanceSuggestions", prHandler.SuggestRebalance},
+		{http.MethodPost, "/pullRequest/create", prHandler.CreatePR},
+		{http.MethodPost, "/pullRequest/createBulk", prHandler.CreateBulk},
+		{http.MethodPost, "/pullRequest/merge", prHandler.MergePR},
+		{http.MethodPost, "/pullRequest/mergeBulk", prHandler.MergeBulk},
+		{http.MethodPost, "/pullRequest/reassign", prHandler.ReassignReviewer},
+		{http.MethodPost, "/pullRequest/applyRebalance", prHandler.ApplyRebalance},
+		{http.MethodPost, "/pullRequest/review", prHandler.SubmitReview},
+		{http.MethodPost, "/pullRequest/setLabels", prHandler.SetLabels},
+		{http.MethodGet, "/pullRequest/search", prHandler.SearchPRs},
+		{http.MethodGet, "/pullRequest/suggestReviewers", prHandler.SuggestReviewers},
+		{http.MethodGet, "/pullRequest/history", prHandler.GetHistory},
+		{http.MethodGet, "/pullRequest/unassigned", prHandler.GetUnassignedPRs},
+		{http.MethodPost, "/pullRequest/assign", prHandler.AssignPR},
+		{http.MethodPost, "/pullRequest/assignPending", prHandler.AssignPending},
+		{http.MethodGet, "/statistics", statisticsHandler.GetStatistics},
+		{http.MethodGet, "/statistics/user", statisticsHandler.GetUserStatistics},
+		{http.MethodGet, "/statistics/overdue", statisticsHandler.GetOverdue},
+		{http.MethodGet, "/statistics/distribution", statisticsHandler.GetDistribution},
+		{http.MethodGet, "/statistics/timeseries", statisticsHandler.GetTimeseries},
+		{http.MethodPost, "/admin/exclusions", adminHandler.AddExclusion},
+		{http.MethodDelete, "/admin/exclusions", adminHandler.RemoveExclusion},
+		{http.MethodGet, "/admin/exclusions", adminHandler.ListExclusions},
+		{http.MethodPost, "/admin/archive", adminHandler.Archive},
+		{http.MethodPost, "/admin/archive/restore", adminHandler.RestoreArchived},
+		{http.MethodGet, "/admin/webhooks/deadletter", adminHandler.ListDeadLetters},
+		{http.MethodPost, "/admin/webhooks/redeliver", adminHandler.Redeliver},
+		{http.MethodGet, "/admin/webhooks/stats", adminHandler.WebhookStats},
+		{http.MethodGet, "/openapi.yaml", serveSpec},
+	}
+	if services.Queues != nil {
+		liveQueueHandler := NewLiveQueueHandler(services.Users, services.Queues, logger)
+		routes = append(routes,
+			route{http.MethodGet, "/ws/reviews", liveQueueHandler.ServeReviews},
+			route{http.MethodGet, "/users/pollAssignments", liveQueueHandler.PollAssignments},
+		)
+	}
+	if services.Dump != nil && services.DumpToken != "" {
+		dumpHandler := NewDumpHandler(services.Dump, services.DumpToken, logger, validate)
+		routes = append(routes,
+			route{http.MethodGet, "/admin/export", dumpHandler.Export},
+			route{http.MethodPost, "/admin/import", dumpHandler.Import},
+		)
+	}
+	if services.Snapshots != nil {
+		snapshotHandler := NewSnapshotHandler(services.Snapshots, logger)
+		routes = append(routes,
+			route{http.MethodGet, "/statistics/history", snapshotHandler.GetHistory},
+			route{http.MethodPost, "/admin/statistics/snapshot", snapshotHandler.TakeSnapshot},
+		)
+	}
+	if services.TeamImports != nil {
+		teamImportHandler := NewTeamImportHandler(services.TeamImports, logger, validate)
+		routes = append(routes, route{http.MethodPost, "/team/importJson", teamImportHandler.ImportJSON})
+	}
+	if services.Duplicates != nil {
+		duplicateHandler := NewDuplicateHandler(services.Duplicates, logger)
+		routes = append(routes, route{http.MethodGet, "/admin/duplicates", duplicateHandler.FindNearDuplicates})
+	}
+	if services.Jobs != nil {
+		jobHandler := NewJobHandler(services.Jobs, logger, validate)
+		routes = append(routes,
+			route{http.MethodGet, "/admin/jobs", jobHandler.ListJobs},
+			route{http.MethodPost, "/admin/jobs/run", jobHandler.RunJob},
+		)
+	}
+	if services.Readiness != nil {
+		readinessHandler := NewReadinessHandler(services.Readiness, logger)
+		routes = append(routes, route{http.MethodGet, "/readyz", readinessHandler.Ready})
+	}
+	if services.GraphQL != nil {
+		routes = append(routes, route{http.MethodPost, "/graphql", services.GraphQL.ServeHTTP})
+	}
+	if services.Metrics != nil {
+		routes = append(routes, route{http.MethodGet, "/metrics", services.Metrics.ServeHTTP})
+	}
+	return routes
+}
+
+// versionRoutes mounts the routes under apiPrefix and, with legacy, keeps them at their unprefixed
+// paths as deprecated aliases of the same handlers. Unversioned routes stay as they are.
+func versionRoutes(routes []route, legacy bool) []route {
+	versioned := make([]route, 0, 2*len(routes))
+	for _, rt := range routes {
+		if unversionedRoutes[rt.path] {
+			versioned = append(versioned, rt)
+			continue
+		}
+		versioned = append(versioned, route{rt.method, apiPrefix + rt.path, rt.handler})
+		if legacy {
+			versioned = append(versioned, route{rt.method, rt.path, withDeprecation(rt.handler, apiPrefix+rt.path)})
+		}
+	}
+	return versioned
+}
+
+// withDeprecation marks the responses of a legacy alias as deprecated, linking the route that
+// replaces it.
+func withDeprecation(next http.HandlerFunc, successor string) http.HandlerFunc {
+	link := "<" + successor + `>; rel="successor-version"`
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Link", link)
+		next(w, r)
+	}
+}
+
+// route is an API endpoint: a method and an exact path.
+type route struct {
+	method  string
+	path    string
+	handler http.HandlerFunc
+}
+
+// routeTable serves the routes and answers everything else from the same metadata: HEAD for GET
+// routes, OPTIONS with the Allow header, and JSON errors instead of the plain-text bodies of
+// ServeMux, NOT_FOUND for unknown paths and METHOD_NOT_ALLOWED for known paths requested with
+// another method.
+type routeTable struct {
+	mux *http.ServeMux
+	// allow holds the Allow header value of every path
+	allow map[string]string
+}
+
+func newRouteTable(routes []route) *routeTable {
+	mux := http.NewServeMux()
+	methods := make(map[string][]string)
+	for _, rt := range routes {
+		handler := rt.handler
+		if rt.method == http.MethodGet {
+			// a GET pattern also matches HEAD
+			handler = withHead(handler)
+			methods[rt.path] = append(methods[rt.path], http.MethodHead)
+		}
+		mux.Handle(rt.method+" "+rt.path, handler)
+		methods[rt.path] = append(methods[rt.path], rt.method)
+	}
+
+	allow := make(map[string]string, len(methods))
+	for path, list := range methods {
+		list = append(list, http.MethodOptions)
+		slices.Sort(list)
+		allow[path] = strings.Join(list, ", ")
+		mux.Handle(http.MethodOptions+" "+path, serveOptions(allow[path]))
+	}
+	return &routeTable{mux: mux, allow: allow}
+}
+
+func (t *routeTable) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if _, pattern := t.mux.Handler(r); pattern != "" {
+		t.mux.ServeHTTP(w, r)
+		return
+	}
+	allow, ok := t.allow[r.URL.Path]
+	if !ok {
+		_ = RespondJSON(w, http.StatusNotFound, dto.NewErrorResponse(domainErrors.CodeNotFound, "route not found"))
+		return
+	}
+	w.Header().Set("Allow", allow)
+	_ = RespondJSON(w, http.StatusMethodNotAllowed, dto.NewErrorResponse(CodeMethodNotAllowed, "method not allowed"))
+}
+
+// serveOptions answers OPTIONS with the methods of the path.
+func serveOptions(allow string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Allow", allow)
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// withHead serves HEAD with the status and headers of GET, dropping the body.
+func withHead(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w = headWriter{w}
+		}
+		next(w, r)
+	}
+}
+
+// headWriter discards the body of a HEAD response.
+type headWriter struct {
+	http.ResponseWriter
+}
+
+func (w headWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+// serveSpec serves the OpenAPI document of the API.
+func serveSpec(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	_, _ = w.Write(api.Spec)
+}
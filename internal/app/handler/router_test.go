@@ -0,0 +1,259 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	userDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/user"
+	"github.com/shirr9/pr-reviewer-service/internal/app/events"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler/mocks"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewRouter_Fallback(t *testing.T) {
+	router := NewRouter(Services{}, testLogger(), nil)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		status int
+		code   string
+		allow  string
+	}{
+		{"Error - Unknown path", http.MethodGet, "/pullRequest/crate", http.StatusNotFound,
+			domainErrors.CodeNotFound, ""},
+		{"Error - Unknown root path", http.MethodPost, "/", http.StatusNotFound, domainErrors.CodeNotFound, ""},
+		{"Error - Path under a route", http.MethodGet, "/statistics/overdue/extra", http.StatusNotFound,
+			domainErrors.CodeNotFound, ""},
+		{"Error - GET of a POST route", http.MethodGet, "/pullRequest/create", http.StatusMethodNotAllowed,
+			CodeMethodNotAllowed, "OPTIONS, POST"},
+		{"Error - POST of a GET route", http.MethodPost, "/team/get", http.StatusMethodNotAllowed,
+			CodeMethodNotAllowed, "GET, HEAD, OPTIONS"},
+		{"Error - PUT of a route with several methods", http.MethodPut, "/admin/exclusions",
+			http.StatusMethodNotAllowed, CodeMethodNotAllowed, "DELETE, GET, HEAD, OPTIONS, POST"},
+		{"Error - Export without a configured token", http.MethodGet, "/admin/export", http.StatusNotFound,
+			domainErrors.CodeNotFound, ""},
+		{"Error - Import without a configured token", http.MethodPost, "/admin/import", http.StatusNotFound,
+			domainErrors.CodeNotFound, ""},
+		{"Error - Readiness without checks", http.MethodGet, "/readyz", http.StatusNotFound,
+			domainErrors.CodeNotFound, ""},
+		{"Error - History without snapshots", http.MethodGet, "/statistics/history", http.StatusNotFound,
+			domainErrors.CodeNotFound, ""},
+		{"Error - Metrics without a registry", http.MethodGet, "/metrics", http.StatusNotFound,
+			domainErrors.CodeNotFound, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, nil))
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+			assert.Equal(t, tt.allow, rec.Header().Get("Allow"))
+			resp := decodeBody[dto.ErrorResponse](t, rec.Body.Bytes())
+			assert.Equal(t, tt.code, resp.Error.Code)
+			assert.NotEmpty(t, resp.Error.Message)
+		})
+	}
+
+	t.Run("Success - Matched routes are served as before", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.yaml", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/yaml", rec.Header().Get("Content-Type"))
+	})
+}
+
+func TestNewRouter_HeadAndOptions(t *testing.T) {
+	router := NewRouter(Services{}, testLogger(), nil)
+
+	t.Run("Success - HEAD of a GET route has no body", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodHead, "/openapi.yaml", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/yaml", rec.Header().Get("Content-Type"))
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Empty(t, rec.Body.Bytes())
+	})
+
+	t.Run("Success - HEAD keeps the status of an error", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodHead, "/team/get", nil))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		assert.Empty(t, rec.Body.Bytes())
+	})
+
+	tests := []struct {
+		name   string
+		target string
+		allow  string
+	}{
+		{"Success - OPTIONS of a GET route", "/statistics", "GET, HEAD, OPTIONS"},
+		{"Success - OPTIONS of a POST route", "/pullRequest/create", "OPTIONS, POST"},
+		{"Success - OPTIONS of a route with several methods", "/admin/exclusions",
+			"DELETE, GET, HEAD, OPTIONS, POST"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodOptions, tt.target, nil))
+
+			assert.Equal(t, http.StatusNoContent, rec.Code)
+			assert.Equal(t, tt.allow, rec.Header().Get("Allow"))
+			assert.Empty(t, rec.Body.Bytes())
+		})
+	}
+
+	t.Run("Error - OPTIONS of an unknown path", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodOptions, "/teams", nil))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		resp := decodeBody[dto.ErrorResponse](t, rec.Body.Bytes())
+		assert.Equal(t, domainErrors.CodeNotFound, resp.Error.Code)
+	})
+}
+
+func TestNewRouter_SingleValuedQueryParameters(t *testing.T) {
+	router := NewRouter(Services{
+		Snapshots: mocks.NewMockSnapshotService(gomock.NewController(t)),
+		Queues:    events.NewBus(events.DefaultBuffer),
+	}, testLogger(), nil)
+
+	// every endpoint rejects its parameter before calling a service, which the router has none of
+	endpoints := []struct {
+		method string
+		path   string
+		param  string
+	}{
+		{http.MethodGet, "/team/get", "team_name"},
+		{http.MethodGet, "/team/list", "limit"},
+		{http.MethodGet, "/team/reviewQueue", "team_name"},
+		{http.MethodGet, "/users/getReview", "user_id"},
+		{http.MethodGet, "/users/rebalanceSuggestions", "user_id"},
+		{http.MethodGet, "/users/pollAssignments", "user_id"},
+		{http.MethodGet, "/ws/reviews", "user_id"},
+		{http.MethodGet, "/pullRequest/search", "q"},
+		{http.MethodGet, "/pullRequest/search", "sort"},
+		{http.MethodGet, "/pullRequest/suggestReviewers", "author_id"},
+		{http.MethodGet, "/pullRequest/history", "pull_request_id"},
+		{http.MethodGet, "/pullRequest/unassigned", "assignment_skipped"},
+		{http.MethodGet, "/statistics", "team_name"},
+		{http.MethodGet, "/statistics", "users_limit"},
+		{http.MethodGet, "/statistics/user", "user_id"},
+		{http.MethodGet, "/statistics/distribution", "detail"},
+		{http.MethodGet, "/statistics/timeseries", "from"},
+		{http.MethodGet, "/statistics/history", "from"},
+		{http.MethodGet, "/admin/exclusions", "user_id"},
+		{http.MethodDelete, "/admin/exclusions", "reviewer_id"},
+		{http.MethodGet, "/admin/webhooks/deadletter", "offset"},
+	}
+	for _, e := range endpoints {
+		cases := []struct {
+			name  string
+			query string
+			want  string
+		}{
+			{"repeated", e.param + "=a&" + e.param + "=b", e.param + " must be given once"},
+			{"empty", e.param + "=", e.param + " must not be empty"},
+		}
+		for _, c := range cases {
+			t.Run("Error - "+e.method+" "+e.path+" with "+c.name+" "+e.param, func(t *testing.T) {
+				rec := httptest.NewRecorder()
+
+				router.ServeHTTP(rec, httptest.NewRequest(e.method, e.path+"?"+c.query, nil))
+
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+				resp := decodeBody[dto.ErrorResponse](t, rec.Body.Bytes())
+				assert.Equal(t, domainErrors.CodeValidation, resp.Error.Code)
+				assert.Equal(t, c.want, resp.Error.Message)
+			})
+		}
+	}
+}
+
+func TestNewRouter_Versions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	services := Services{
+		Snapshots:   mocks.NewMockSnapshotService(ctrl),
+		Queues:      events.NewBus(events.DefaultBuffer),
+		Dump:        mocks.NewMockDumpService(ctrl),
+		DumpToken:   "secret",
+		Readiness:   mocks.NewMockReadinessService(ctrl),
+		TeamImports: mocks.NewMockTeamImportService(ctrl),
+		Duplicates:  mocks.NewMockDuplicateService(ctrl),
+		Jobs:        mocks.NewMockJobService(ctrl),
+		GraphQL:     http.NotFoundHandler(),
+		Metrics:     http.NotFoundHandler(),
+	}
+	router := NewRouter(services, testLogger(), nil)
+
+	// options answers OPTIONS of path, returning the status and the Allow header
+	options := func(router http.Handler, path string) (int, string) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodOptions, path, nil))
+		return rec.Code, rec.Header().Get("Allow")
+	}
+
+	for _, rt := range apiRoutes(services, testLogger(), NewValidator()) {
+		t.Run("Success - "+rt.method+" "+rt.path+" under both prefixes", func(t *testing.T) {
+			status, allow := options(router, rt.path)
+			assert.Equal(t, http.StatusNoContent, status)
+			assert.Contains(t, allow, rt.method)
+
+			versionedStatus, versionedAllow := options(router, apiPrefix+rt.path)
+			if unversionedRoutes[rt.path] {
+				assert.Equal(t, http.StatusNotFound, versionedStatus)
+				return
+			}
+			assert.Equal(t, http.StatusNoContent, versionedStatus)
+			assert.Equal(t, allow, versionedAllow)
+		})
+	}
+
+	t.Run("Success - Legacy alias serves the same handler, deprecated", func(t *testing.T) {
+		users := mocks.NewMockUserService(gomock.NewController(t))
+		users.EXPECT().GetReview(gomock.Any(), "u1").Return(&userDto.GetReviewResponse{
+			UserID: "u1", PullRequests: []userDto.PR{{PullRequestID: "pr-1", Status: "OPEN"}},
+		}, nil).Times(2)
+		router := NewRouter(Services{Users: users}, testLogger(), nil)
+
+		versioned := httptest.NewRecorder()
+		router.ServeHTTP(versioned, httptest.NewRequest(http.MethodGet, "/api/v1/users/getReview?user_id=u1", nil))
+		legacy := httptest.NewRecorder()
+		router.ServeHTTP(legacy, httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=u1", nil))
+
+		assert.Equal(t, http.StatusOK, versioned.Code)
+		assert.Equal(t, versioned.Code, legacy.Code)
+		assert.Equal(t, versioned.Body.String(), legacy.Body.String())
+		assert.Empty(t, versioned.Header().Get("Deprecation"))
+		assert.Equal(t, "true", legacy.Header().Get("Deprecation"))
+		assert.Equal(t, `</api/v1/users/getReview>; rel="successor-version"`, legacy.Header().Get("Link"))
+	})
+
+	t.Run("Error - Legacy aliases disabled", func(t *testing.T) {
+		router := NewRouter(Services{Metrics: http.NotFoundHandler(), DisableLegacyRoutes: true}, testLogger(), nil)
+
+		status, _ := options(router, "/openapi.yaml")
+		assert.Equal(t, http.StatusNotFound, status)
+		status, _ = options(router, "/api/v1/openapi.yaml")
+		assert.Equal(t, http.StatusNoContent, status)
+		status, _ = options(router, "/metrics")
+		assert.Equal(t, http.StatusNoContent, status)
+	})
+}
@@ -0,0 +1,84 @@
+package handler
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mocks/mock_snapshot_service.go -package=mocks
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/statistics"
+)
+
+// SnapshotService defines the interface for the daily statistics snapshots.
+type SnapshotService interface {
+	TakeSnapshot(ctx context.Context) (*statistics.DailySnapshot, error)
+	GetHistory(ctx context.Context, req statistics.HistoryRequest) (*statistics.HistoryResponse, error)
+}
+
+// SnapshotHandler handles the statistics history and snapshots taken on demand.
+type SnapshotHandler struct {
+	service SnapshotService
+	logger  *slog.Logger
+}
+
+// NewSnapshotHandler creates a new SnapshotHandler.
+func NewSnapshotHandler(service SnapshotService, logger *slog.Logger) *SnapshotHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &SnapshotHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// GetHistory returns the daily snapshots from "from" until "to" (RFC 3339; now by default).
+func (h *SnapshotHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	op := "SnapshotHandler.GetHistory"
+	logger := h.logger.With(slog.String("op", op))
+	req, err := parseHistory(r)
+	if err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	response, err := h.service.GetHistory(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
+
+// TakeSnapshot takes the snapshot of today, replacing the one the job or an earlier request took.
+func (h *SnapshotHandler) TakeSnapshot(w http.ResponseWriter, r *http.Request) {
+	op := "SnapshotHandler.TakeSnapshot"
+	logger := h.logger.With(slog.String("op", op))
+	response, err := h.service.TakeSnapshot(r.Context())
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
+
+// parseHistory parses the range of a statistics history request.
+func parseHistory(r *http.Request) (statistics.HistoryRequest, error) {
+	query := readQuery(r)
+	req := statistics.HistoryRequest{To: time.Now().UTC()}
+
+	if !query.has("from") {
+		return req, fmt.Errorf("from is required")
+	}
+	from, to := query.time("from"), query.time("to")
+	if query.err != nil {
+		return req, query.err
+	}
+	req.From = *from
+	if to != nil {
+		req.To = *to
+	}
+	return req, nil
+}
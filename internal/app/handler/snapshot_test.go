@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/statistics"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler/mocks"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type snapshotCase = handlerCase[*mocks.MockSnapshotService]
+
+func runSnapshotCases(t *testing.T, handle func(h *SnapshotHandler) http.HandlerFunc, cases []snapshotCase) {
+	t.Helper()
+	runCases(t, mocks.NewMockSnapshotService, func(m *mocks.MockSnapshotService) http.HandlerFunc {
+		return handle(NewSnapshotHandler(m, testLogger()))
+	}, cases)
+}
+
+func TestSnapshotHandler_GetHistory(t *testing.T) {
+	from := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.June, 8, 0, 0, 0, 0, time.UTC)
+	history := &statistics.HistoryResponse{
+		From: "2024-06-01T00:00:00Z", To: "2024-06-08T00:00:00Z",
+		Snapshots: []statistics.DailySnapshot{{
+			Day: "2024-06-03T00:00:00Z", TakenAt: "2024-06-03T00:10:00Z",
+			SnapshotAggregates: statistics.SnapshotAggregates{TotalPRs: 4, OpenPRs: 3},
+			Teams:              []statistics.TeamSnapshot{},
+		}},
+	}
+
+	runSnapshotCases(t, func(h *SnapshotHandler) http.HandlerFunc { return h.GetHistory }, []snapshotCase{
+		{
+			name: "Success - Range", method: http.MethodGet,
+			target: "/statistics/history?from=2024-06-01T00:00:00Z&to=2024-06-08T00:00:00Z",
+			setup: func(m *mocks.MockSnapshotService) {
+				m.EXPECT().GetHistory(gomock.Any(), statistics.HistoryRequest{From: from, To: to}).Return(history, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				assert.Equal(t, *history, decodeBody[statistics.HistoryResponse](t, body))
+			},
+		},
+		{
+			name: "Success - Range until now", method: http.MethodGet,
+			target: "/statistics/history?from=2024-06-01T00:00:00Z",
+			setup: func(m *mocks.MockSnapshotService) {
+				m.EXPECT().GetHistory(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, req statistics.HistoryRequest) (*statistics.HistoryResponse, error) {
+						assert.Equal(t, from, req.From)
+						assert.WithinDuration(t, time.Now(), req.To, time.Minute)
+						return history, nil
+					})
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Error - Missing from", method: http.MethodGet, target: "/statistics/history",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Malformed to", method: http.MethodGet,
+			target: "/statistics/history?from=2024-06-01T00:00:00Z&to=tomorrow",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Range ends before it starts", method: http.MethodGet,
+			target: "/statistics/history?from=2024-06-08T00:00:00Z&to=2024-06-01T00:00:00Z",
+			setup: func(m *mocks.MockSnapshotService) {
+				m.EXPECT().GetHistory(gomock.Any(), statistics.HistoryRequest{From: to, To: from}).
+					Return(nil, domainErrors.NewValidation("to must be after from"))
+			},
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+	})
+}
+
+func TestSnapshotHandler_TakeSnapshot(t *testing.T) {
+	runSnapshotCases(t, func(h *SnapshotHandler) http.HandlerFunc { return h.TakeSnapshot }, []snapshotCase{
+		{
+			name: "Success - Snapshot taken", method: http.MethodPost, target: "/admin/statistics/snapshot",
+			setup: func(m *mocks.MockSnapshotService) {
+				m.EXPECT().TakeSnapshot(gomock.Any()).Return(&statistics.DailySnapshot{
+					Day: "2024-06-03T00:00:00Z", Teams: []statistics.TeamSnapshot{{TeamName: "backend"}},
+				}, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				assert.Equal(t, "backend", decodeBody[statistics.DailySnapshot](t, body).Teams[0].TeamName)
+			},
+		},
+		{
+			name: "Error - Service failure", method: http.MethodPost, target: "/admin/statistics/snapshot",
+			setup: func(m *mocks.MockSnapshotService) {
+				m.EXPECT().TakeSnapshot(gomock.Any()).Return(nil, errors.New("connection refused"))
+			},
+			status: http.StatusInternalServerError, code: CodeInternalError,
+		},
+	})
+}
@@ -1,16 +1,31 @@
 package handler
 
+//go:generate go tool mockgen -source=$GOFILE -destination=mocks/mock_statistics_service.go -package=mocks
+
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/shirr9/pr-reviewer-service/internal/app/dto/statistics"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
 )
 
+// defaultStatsPageLimit is used when a page of the user or PR statistics has no limit.
+const defaultStatsPageLimit = maxPageLimit
+
 type StatisticsService interface {
-	GetStatistics(ctx context.Context) (*statistics.StatisticsResponse, error)
+	GetStatistics(ctx context.Context, req statistics.StatisticsRequest) (*statistics.StatisticsResponse, error)
+	GetUserStatistics(ctx context.Context, req statistics.UserStatisticsRequest) (*statistics.UserStatisticsResponse, error)
+	GetOverdue(ctx context.Context) (*statistics.OverdueResponse, error)
+	GetDistribution(ctx context.Context, req statistics.DistributionRequest) (*statistics.DistributionResponse, error)
+	GetTimeseries(ctx context.Context, req statistics.TimeseriesRequest) (*statistics.TimeseriesResponse, error)
 }
 
 type StatisticsHandler struct {
@@ -25,21 +40,206 @@ func NewStatisticsHandler(service StatisticsService, log *slog.Logger) *Statisti
 	}
 }
 
+// GetStatistics returns the statistics; "include" selects the sections, "include_archived" adds
+// archived PRs, "team_name" limits them to a team, "users_limit"/"users_offset" and
+// "prs_limit"/"prs_offset" page the user and PR lists.
 func (h *StatisticsHandler) GetStatistics(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	stats, err := h.service.GetStatistics(ctx)
+	query := readQuery(r)
+	req := statistics.StatisticsRequest{
+		TeamName:        query.get("team_name"),
+		IncludeArchived: query.boolean("include_archived"),
+	}
+	err := query.err
+	if err == nil {
+		if req.Include, err = parseInclude(r); err == nil {
+			if req.Users, err = parsePage(r, "users_", defaultStatsPageLimit); err == nil {
+				req.PRs, err = parsePage(r, "prs_", defaultStatsPageLimit)
+			}
+		}
+	}
+	if err != nil {
+		if encodeErr := RespondWithError(w, domainErrors.NewValidation(err.Error())); encodeErr != nil {
+			h.log.LogAttrs(ctx, slog.LevelError, "failed to encode error response", slog.String("error", encodeErr.Error()))
+		}
+		return
+	}
+
+	stats, err := h.service.GetStatistics(ctx, req)
 	if err != nil {
-		h.log.LogAttrs(ctx, slog.LevelError, "failed to get statistics", slog.String("error", err.Error()))
+		h.log.LogAttrs(ctx, errorLevel(err), "failed to get statistics", slog.String("error", err.Error()))
 		if encodeErr := RespondWithError(w, err); encodeErr != nil {
 			h.log.LogAttrs(ctx, slog.LevelError, "failed to encode error response", slog.String("error", encodeErr.Error()))
 		}
 		return
 	}
 
+	h.respond(ctx, w, stats)
+}
+
+// respond encodes a response of the statistics unless the request is done meanwhile: a client that
+// disconnected while the statistics were computed gets nothing encoded, and the request is logged
+// and answered like one failed with the error of its context.
+func (h *StatisticsHandler) respond(ctx context.Context, w http.ResponseWriter, response any) {
+	if err := ctx.Err(); err != nil {
+		handleServiceError(w, err, h.log)
+		return
+	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(stats); err != nil {
+	if err := json.NewEncoder(w).Encode(response); err != nil {
 		h.log.LogAttrs(ctx, slog.LevelError, "failed to encode response", slog.String("error", err.Error()))
 	}
 }
+
+// parseInclude parses the comma-separated sections of the "include" query parameter, which may be
+// repeated. Without the parameter the default sections are included; an empty value includes only
+// the aggregates.
+func parseInclude(r *http.Request) (statistics.Include, error) {
+	query := readQuery(r)
+	if !query.has("include") {
+		return statistics.DefaultInclude, nil
+	}
+
+	var include statistics.Include
+	for _, section := range parseList(r, "include") {
+		switch section {
+		case statistics.SectionUserStats:
+			include.UserStats = true
+		case statistics.SectionPRStats:
+			include.PRStats = true
+		case statistics.SectionTeamStats:
+			include.TeamStats = true
+		case statistics.SectionAuthorStats:
+			include.AuthorStats = true
+		default:
+			return statistics.Include{}, fmt.Errorf("include must list sections of %s, %s, %s and %s",
+				statistics.SectionUserStats, statistics.SectionPRStats, statistics.SectionTeamStats,
+				statistics.SectionAuthorStats)
+		}
+	}
+	return include, nil
+}
+
+// GetUserStatistics returns the statistics of the user "user_id"; "include_archived" adds archived PRs.
+func (h *StatisticsHandler) GetUserStatistics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	query := readQuery(r)
+	req := statistics.UserStatisticsRequest{
+		UserID:          query.required("user_id"),
+		IncludeArchived: query.boolean("include_archived"),
+	}
+	if query.err != nil {
+		if encodeErr := RespondWithError(w, domainErrors.NewValidation(query.err.Error())); encodeErr != nil {
+			h.log.LogAttrs(ctx, slog.LevelError, "failed to encode error response", slog.String("error", encodeErr.Error()))
+		}
+		return
+	}
+
+	stats, err := h.service.GetUserStatistics(ctx, req)
+	if err != nil {
+		h.log.LogAttrs(ctx, errorLevel(err), "failed to get user statistics", slog.String("error", err.Error()))
+		if encodeErr := RespondWithError(w, err); encodeErr != nil {
+			h.log.LogAttrs(ctx, slog.LevelError, "failed to encode error response", slog.String("error", encodeErr.Error()))
+		}
+		return
+	}
+
+	h.respond(ctx, w, stats)
+}
+
+func (h *StatisticsHandler) GetOverdue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	overdue, err := h.service.GetOverdue(ctx)
+	if err != nil {
+		h.log.LogAttrs(ctx, errorLevel(err), "failed to get overdue reviews", slog.String("error", err.Error()))
+		if encodeErr := RespondWithError(w, err); encodeErr != nil {
+			h.log.LogAttrs(ctx, slog.LevelError, "failed to encode error response", slog.String("error", encodeErr.Error()))
+		}
+		return
+	}
+
+	h.respond(ctx, w, overdue)
+}
+
+// GetDistribution returns histograms of the review load; "team_name" limits them to a team and
+// "detail" adds the load of every user.
+func (h *StatisticsHandler) GetDistribution(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	query := readQuery(r)
+	req := statistics.DistributionRequest{TeamName: query.get("team_name"), Detail: query.boolean("detail")}
+	if query.err != nil {
+		if encodeErr := RespondWithError(w, domainErrors.NewValidation(query.err.Error())); encodeErr != nil {
+			h.log.LogAttrs(ctx, slog.LevelError, "failed to encode error response", slog.String("error", encodeErr.Error()))
+		}
+		return
+	}
+
+	distribution, err := h.service.GetDistribution(ctx, req)
+	if err != nil {
+		h.log.LogAttrs(ctx, errorLevel(err), "failed to get review load distribution", slog.String("error", err.Error()))
+		if encodeErr := RespondWithError(w, err); encodeErr != nil {
+			h.log.LogAttrs(ctx, slog.LevelError, "failed to encode error response", slog.String("error", encodeErr.Error()))
+		}
+		return
+	}
+
+	h.respond(ctx, w, distribution)
+}
+
+// GetTimeseries returns the activity in buckets of "bucket", day or week (the default), from "from"
+// until "to" (RFC 3339; now by default).
+func (h *StatisticsHandler) GetTimeseries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := parseTimeseries(r)
+	if err != nil {
+		if encodeErr := RespondWithError(w, domainErrors.NewValidation(err.Error())); encodeErr != nil {
+			h.log.LogAttrs(ctx, slog.LevelError, "failed to encode error response", slog.String("error", encodeErr.Error()))
+		}
+		return
+	}
+
+	timeseries, err := h.service.GetTimeseries(ctx, req)
+	if err != nil {
+		h.log.LogAttrs(ctx, errorLevel(err), "failed to get activity time series", slog.String("error", err.Error()))
+		if encodeErr := RespondWithError(w, err); encodeErr != nil {
+			h.log.LogAttrs(ctx, slog.LevelError, "failed to encode error response", slog.String("error", encodeErr.Error()))
+		}
+		return
+	}
+
+	h.respond(ctx, w, timeseries)
+}
+
+// parseTimeseries parses the bucket size, the team and the range of a time series request.
+func parseTimeseries(r *http.Request) (statistics.TimeseriesRequest, error) {
+	query := readQuery(r)
+	req := statistics.TimeseriesRequest{Bucket: query.get("bucket"), TeamName: query.get("team_name"),
+		To: time.Now().UTC()}
+	if query.err != nil {
+		return req, query.err
+	}
+	if req.Bucket == "" {
+		req.Bucket = models.ActivityBucketWeek
+	} else if !slices.Contains(models.ActivityBuckets, req.Bucket) {
+		return req, fmt.Errorf("bucket must be one of %s", strings.Join(models.ActivityBuckets, ", "))
+	}
+
+	if !query.has("from") {
+		return req, fmt.Errorf("from is required")
+	}
+	from, to := query.time("from"), query.time("to")
+	if query.err != nil {
+		return req, query.err
+	}
+	req.From = *from
+	if to != nil {
+		req.To = *to
+	}
+	return req, nil
+}
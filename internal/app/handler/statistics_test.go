@@ -0,0 +1,367 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/statistics"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler/mocks"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type statisticsCase = handlerCase[*mocks.MockStatisticsService]
+
+func runStatisticsCases(t *testing.T, handle func(h *StatisticsHandler) http.HandlerFunc, cases []statisticsCase) {
+	t.Helper()
+	runCases(t, mocks.NewMockStatisticsService, func(m *mocks.MockStatisticsService) http.HandlerFunc {
+		return handle(NewStatisticsHandler(m, testLogger()))
+	}, cases)
+}
+
+func TestStatisticsHandler_GetStatistics(t *testing.T) {
+	runStatisticsCases(t, func(h *StatisticsHandler) http.HandlerFunc { return h.GetStatistics }, []statisticsCase{
+		{
+			name: "Success - Statistics returned", method: http.MethodGet, target: "/statistics",
+			setup: func(m *mocks.MockStatisticsService) {
+				m.EXPECT().GetStatistics(gomock.Any(), statistics.StatisticsRequest{
+					Include: statistics.DefaultInclude,
+					Users:   dto.PageRequest{Limit: defaultStatsPageLimit},
+					PRs:     dto.PageRequest{Limit: defaultStatsPageLimit},
+				}).Return(&statistics.StatisticsResponse{
+					TotalPRs: 3, OpenPRs: 1, MergedPRs: 2,
+				}, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				resp := decodeBody[statistics.StatisticsResponse](t, body)
+				assert.Equal(t, 3, resp.TotalPRs)
+				assert.Equal(t, 2, resp.MergedPRs)
+			},
+		},
+		{
+			name: "Success - Pages of the lists", method: http.MethodGet,
+			target: "/statistics?users_limit=5&users_offset=10&prs_limit=2",
+			setup: func(m *mocks.MockStatisticsService) {
+				m.EXPECT().GetStatistics(gomock.Any(), statistics.StatisticsRequest{
+					Include: statistics.DefaultInclude,
+					Users:   dto.PageRequest{Limit: 5, Offset: 10},
+					PRs:     dto.PageRequest{Limit: 2},
+				}).Return(&statistics.StatisticsResponse{}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Success - Selected sections", method: http.MethodGet,
+			target: "/statistics?include=team_stats,%20user_stats",
+			setup: func(m *mocks.MockStatisticsService) {
+				m.EXPECT().GetStatistics(gomock.Any(), statistics.StatisticsRequest{
+					Include: statistics.Include{UserStats: true, TeamStats: true},
+					Users:   dto.PageRequest{Limit: defaultStatsPageLimit},
+					PRs:     dto.PageRequest{Limit: defaultStatsPageLimit},
+				}).Return(&statistics.StatisticsResponse{TotalPRs: 3}, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				assert.NotContains(t, string(body), `"pr_stats"`)
+				assert.NotContains(t, string(body), `"user_stats"`)
+			},
+		},
+		{
+			name: "Success - Author stats", method: http.MethodGet, target: "/statistics?include=author_stats",
+			setup: func(m *mocks.MockStatisticsService) {
+				m.EXPECT().GetStatistics(gomock.Any(), statistics.StatisticsRequest{
+					Include: statistics.Include{AuthorStats: true},
+					Users:   dto.PageRequest{Limit: defaultStatsPageLimit},
+					PRs:     dto.PageRequest{Limit: defaultStatsPageLimit},
+				}).Return(&statistics.StatisticsResponse{AuthorStats: []statistics.AuthorStats{
+					{AuthorID: "gone", TotalPRs: 1, AvgReviewers: 2},
+				}}, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				assert.Contains(t, string(body), `"author_stats":[{"author_id":"gone","username":null,`)
+			},
+		},
+		{
+			name: "Success - Empty include returns only the aggregates", method: http.MethodGet,
+			target: "/statistics?include=",
+			setup: func(m *mocks.MockStatisticsService) {
+				m.EXPECT().GetStatistics(gomock.Any(), statistics.StatisticsRequest{
+					Users: dto.PageRequest{Limit: defaultStatsPageLimit},
+					PRs:   dto.PageRequest{Limit: defaultStatsPageLimit},
+				}).Return(&statistics.StatisticsResponse{}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Success - Team with archived PRs", method: http.MethodGet,
+			target: "/statistics?team_name=backend&include_archived=true",
+			setup: func(m *mocks.MockStatisticsService) {
+				m.EXPECT().GetStatistics(gomock.Any(), statistics.StatisticsRequest{
+					Include:         statistics.DefaultInclude,
+					IncludeArchived: true,
+					TeamName:        "backend",
+					Users:           dto.PageRequest{Limit: defaultStatsPageLimit},
+					PRs:             dto.PageRequest{Limit: defaultStatsPageLimit},
+				}).Return(&statistics.StatisticsResponse{}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Error - Team not found", method: http.MethodGet, target: "/statistics?team_name=missing",
+			setup: func(m *mocks.MockStatisticsService) {
+				m.EXPECT().GetStatistics(gomock.Any(), gomock.Any()).Return(nil, domainErrors.NewNotFound("team not found"))
+			},
+			status: http.StatusNotFound, code: domainErrors.CodeNotFound,
+		},
+		{
+			name: "Error - Unknown section", method: http.MethodGet, target: "/statistics?include=user_stats,reviews",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Users limit is not a number", method: http.MethodGet, target: "/statistics?users_limit=all",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Negative PRs offset", method: http.MethodGet, target: "/statistics?prs_offset=-5",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Repository failure", method: http.MethodGet, target: "/statistics",
+			setup: func(m *mocks.MockStatisticsService) {
+				m.EXPECT().GetStatistics(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+			},
+			status: http.StatusInternalServerError, code: CodeInternalError,
+		},
+		{
+			name: "Error - AppError is mapped", method: http.MethodGet, target: "/statistics",
+			setup: func(m *mocks.MockStatisticsService) {
+				m.EXPECT().GetStatistics(gomock.Any(), gomock.Any()).Return(nil, domainErrors.NewNotFound("nothing to report"))
+			},
+			status: http.StatusNotFound, code: domainErrors.CodeNotFound,
+		},
+	})
+}
+
+func TestStatisticsHandler_GetStatistics_Canceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := mocks.NewMockStatisticsService(ctrl)
+	ctx, cancel := context.WithCancel(context.Background())
+	// the client disconnects once the statistics are computed
+	service.EXPECT().GetStatistics(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(context.Context, statistics.StatisticsRequest) (*statistics.StatisticsResponse, error) {
+			cancel()
+			return &statistics.StatisticsResponse{TotalPRs: 3}, nil
+		})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/statistics", nil).WithContext(ctx)
+	NewStatisticsHandler(service, testLogger()).GetStatistics(rec, req)
+
+	assert.Equal(t, statusClientClosedRequest, rec.Code)
+	assert.Empty(t, rec.Body.String())
+}
+
+func TestStatisticsHandler_GetUserStatistics(t *testing.T) {
+	runStatisticsCases(t, func(h *StatisticsHandler) http.HandlerFunc { return h.GetUserStatistics }, []statisticsCase{
+		{
+			name: "Success - Statistics of a user", method: http.MethodGet, target: "/statistics/user?user_id=u2",
+			setup: func(m *mocks.MockStatisticsService) {
+				m.EXPECT().GetUserStatistics(gomock.Any(), statistics.UserStatisticsRequest{UserID: "u2"}).Return(
+					&statistics.UserStatisticsResponse{
+						UserStats: statistics.UserStats{UserID: "u2", AssignmentsCount: 3},
+						Authored:  statistics.AuthorStats{AuthorID: "u2", TotalPRs: 1},
+						RecentAssignments: []statistics.RecentAssignment{
+							{PullRequestID: "pr-1", Source: models.AssignmentSourceAuto},
+						},
+					}, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				resp := decodeBody[statistics.UserStatisticsResponse](t, body)
+				assert.Equal(t, "u2", resp.UserID)
+				assert.Equal(t, 3, resp.AssignmentsCount)
+				assert.Equal(t, 1, resp.Authored.TotalPRs)
+				assert.Equal(t, "pr-1", resp.RecentAssignments[0].PullRequestID)
+			},
+		},
+		{
+			name: "Success - Archived PRs included", method: http.MethodGet,
+			target: "/statistics/user?user_id=u2&include_archived=true",
+			setup: func(m *mocks.MockStatisticsService) {
+				m.EXPECT().GetUserStatistics(gomock.Any(),
+					statistics.UserStatisticsRequest{UserID: "u2", IncludeArchived: true}).Return(
+					&statistics.UserStatisticsResponse{}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Error - User id is required", method: http.MethodGet, target: "/statistics/user",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Include archived is not a boolean", method: http.MethodGet,
+			target: "/statistics/user?user_id=u2&include_archived=maybe",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - User not found", method: http.MethodGet, target: "/statistics/user?user_id=missing",
+			setup: func(m *mocks.MockStatisticsService) {
+				m.EXPECT().GetUserStatistics(gomock.Any(), gomock.Any()).Return(nil, domainErrors.NewNotFound("user not found"))
+			},
+			status: http.StatusNotFound, code: domainErrors.CodeNotFound,
+		},
+	})
+}
+
+func TestStatisticsHandler_GetOverdue(t *testing.T) {
+	runStatisticsCases(t, func(h *StatisticsHandler) http.HandlerFunc { return h.GetOverdue }, []statisticsCase{
+		{
+			name: "Success - Overdue returned", method: http.MethodGet, target: "/statistics/overdue",
+			setup: func(m *mocks.MockStatisticsService) {
+				m.EXPECT().GetOverdue(gomock.Any()).Return(&statistics.OverdueResponse{
+					Total: 1, Reviewers: []statistics.OverdueReviewer{{UserID: "u2"}},
+				}, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				assert.Equal(t, 1, decodeBody[statistics.OverdueResponse](t, body).Total)
+			},
+		},
+		{
+			name: "Error - Repository failure", method: http.MethodGet, target: "/statistics/overdue",
+			setup: func(m *mocks.MockStatisticsService) {
+				m.EXPECT().GetOverdue(gomock.Any()).Return(nil, errors.New("connection refused"))
+			},
+			status: http.StatusInternalServerError, code: CodeInternalError,
+		},
+	})
+}
+
+func TestStatisticsHandler_GetDistribution(t *testing.T) {
+	runStatisticsCases(t, func(h *StatisticsHandler) http.HandlerFunc { return h.GetDistribution }, []statisticsCase{
+		{
+			name: "Success - Distribution of all teams", method: http.MethodGet, target: "/statistics/distribution",
+			setup: func(m *mocks.MockStatisticsService) {
+				m.EXPECT().GetDistribution(gomock.Any(), statistics.DistributionRequest{}).Return(
+					&statistics.DistributionResponse{ActiveUsers: 2}, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				assert.Equal(t, 2, decodeBody[statistics.DistributionResponse](t, body).ActiveUsers)
+				assert.NotContains(t, string(body), `"users"`)
+			},
+		},
+		{
+			name: "Success - Team with detail", method: http.MethodGet,
+			target: "/statistics/distribution?team_name=backend&detail=true",
+			setup: func(m *mocks.MockStatisticsService) {
+				m.EXPECT().GetDistribution(gomock.Any(), statistics.DistributionRequest{TeamName: "backend", Detail: true}).
+					Return(&statistics.DistributionResponse{
+						TeamName: "backend", ActiveUsers: 1,
+						Users: []statistics.DistributionUser{{UserID: "u2", ActiveReviews: 6}},
+					}, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				resp := decodeBody[statistics.DistributionResponse](t, body)
+				assert.Equal(t, "u2", resp.Users[0].UserID)
+			},
+		},
+		{
+			name: "Error - Detail is not a boolean", method: http.MethodGet, target: "/statistics/distribution?detail=all",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Team not found", method: http.MethodGet, target: "/statistics/distribution?team_name=missing",
+			setup: func(m *mocks.MockStatisticsService) {
+				m.EXPECT().GetDistribution(gomock.Any(), gomock.Any()).Return(nil, domainErrors.NewNotFound("team not found"))
+			},
+			status: http.StatusNotFound, code: domainErrors.CodeNotFound,
+		},
+	})
+}
+
+func TestStatisticsHandler_GetTimeseries(t *testing.T) {
+	from := time.Date(2024, time.June, 3, 0, 0, 0, 0, time.UTC)
+	runStatisticsCases(t, func(h *StatisticsHandler) http.HandlerFunc { return h.GetTimeseries }, []statisticsCase{
+		{
+			name: "Success - Days of a range", method: http.MethodGet,
+			target: "/statistics/timeseries?bucket=day&from=2024-06-03T00:00:00Z&to=2024-06-05T00:00:00Z",
+			setup: func(m *mocks.MockStatisticsService) {
+				m.EXPECT().GetTimeseries(gomock.Any(), statistics.TimeseriesRequest{
+					Bucket: models.ActivityBucketDay, From: from, To: from.AddDate(0, 0, 2),
+				}).Return(&statistics.TimeseriesResponse{Bucket: models.ActivityBucketDay, Buckets: []statistics.TimeseriesBucket{
+					{Start: "2024-06-03T00:00:00Z", Created: 2}, {Start: "2024-06-04T00:00:00Z"},
+				}}, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				resp := decodeBody[statistics.TimeseriesResponse](t, body)
+				assert.Len(t, resp.Buckets, 2)
+				assert.Equal(t, 2, resp.Buckets[0].Created)
+			},
+		},
+		{
+			name: "Success - Weeks until now by default", method: http.MethodGet,
+			target: "/statistics/timeseries?from=2024-06-03T00:00:00Z",
+			setup: func(m *mocks.MockStatisticsService) {
+				m.EXPECT().GetTimeseries(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, req statistics.TimeseriesRequest) (*statistics.TimeseriesResponse, error) {
+						assert.Equal(t, models.ActivityBucketWeek, req.Bucket)
+						assert.WithinDuration(t, time.Now(), req.To, time.Minute)
+						return &statistics.TimeseriesResponse{}, nil
+					})
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Success - Days of a team", method: http.MethodGet,
+			target: "/statistics/timeseries?bucket=day&team_name=backend&from=2024-06-03T00:00:00Z&to=2024-06-05T00:00:00Z",
+			setup: func(m *mocks.MockStatisticsService) {
+				m.EXPECT().GetTimeseries(gomock.Any(), statistics.TimeseriesRequest{
+					Bucket: models.ActivityBucketDay, TeamName: "backend", From: from, To: from.AddDate(0, 0, 2),
+				}).Return(&statistics.TimeseriesResponse{}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Error - Unknown team", method: http.MethodGet,
+			target: "/statistics/timeseries?team_name=ghost&from=2024-06-03T00:00:00Z",
+			setup: func(m *mocks.MockStatisticsService) {
+				m.EXPECT().GetTimeseries(gomock.Any(), gomock.Any()).Return(nil, domainErrors.NewNotFound("team not found"))
+			},
+			status: http.StatusNotFound, code: domainErrors.CodeNotFound,
+		},
+		{
+			name: "Error - Unknown bucket", method: http.MethodGet,
+			target: "/statistics/timeseries?bucket=month&from=2024-06-03T00:00:00Z",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Missing from", method: http.MethodGet, target: "/statistics/timeseries?bucket=day",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - To is not a time", method: http.MethodGet,
+			target: "/statistics/timeseries?from=2024-06-03T00:00:00Z&to=tomorrow",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Range too long", method: http.MethodGet, target: "/statistics/timeseries?from=2000-01-01T00:00:00Z",
+			setup: func(m *mocks.MockStatisticsService) {
+				m.EXPECT().GetTimeseries(gomock.Any(), gomock.Any()).Return(nil,
+					domainErrors.NewValidation("the range must span at most 366 buckets"))
+			},
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+	})
+}
@@ -1,12 +1,14 @@
 package handler
 
+//go:generate go tool mockgen -source=$GOFILE -destination=mocks/mock_team_service.go -package=mocks
+
 import (
 	"context"
-	"fmt"
 	"log/slog"
 	"net/http"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
 	teamDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/team"
 )
 
@@ -14,31 +16,44 @@ import (
 type TeamService interface {
 	AddTeam(ctx context.Context, req teamDto.AddTeamRequest) (*teamDto.AddTeamResponse, error)
 	GetTeam(ctx context.Context, teamName string) (*teamDto.GetTeamResponse, error)
-	DeactivateTeam(ctx context.Context, teamName string) (*teamDto.DeactivateTeamResponse, error)
+	DeactivateTeam(ctx context.Context, req teamDto.DeactivateTeamRequest) (*teamDto.DeactivateTeamResponse, error)
+	GetReviewQueue(ctx context.Context, req teamDto.ReviewQueueRequest) (*teamDto.ReviewQueueResponse, error)
+	ListTeams(ctx context.Context, req teamDto.ListTeamsRequest) (*dto.Page[teamDto.TeamSummary], error)
+	ImportTeams(ctx context.Context, req teamDto.ImportTeamsRequest) (*teamDto.ImportTeamsResponse, error)
 }
 
+// reviewQueueSortFields are the fields the team review queue can be sorted by.
+var reviewQueueSortFields = sortFields{"created_at": true, "updated_at": true, "priority": true}
+
 // TeamHandler handles team related HTTP requests.
 type TeamHandler struct {
-	service  TeamService
-	logger   *slog.Logger
-	validate *validator.Validate
+	service       TeamService
+	logger        *slog.Logger
+	validate      *validator.Validate
+	maxImportSize int64
 }
 
-// NewTeamHandler creates a new TeamHandler.
+// NewTeamHandler creates a new TeamHandler. maxImportSize limits the body of a CSV import in bytes;
+// zero uses defaultMaxImportSize.
 func NewTeamHandler(
 	service TeamService,
 	logger *slog.Logger,
-	validate *validator.Validate) *TeamHandler {
+	validate *validator.Validate,
+	maxImportSize int64) *TeamHandler {
 	if logger == nil {
 		logger = slog.Default()
 	}
 	if validate == nil {
-		validate = validator.New()
+		validate = NewValidator()
+	}
+	if maxImportSize <= 0 {
+		maxImportSize = defaultMaxImportSize
 	}
 	return &TeamHandler{
-		service:  service,
-		logger:   logger,
-		validate: validate,
+		service:       service,
+		logger:        logger,
+		validate:      validate,
+		maxImportSize: maxImportSize,
 	}
 }
 
@@ -63,9 +78,10 @@ func (h *TeamHandler) AddTeam(w http.ResponseWriter, r *http.Request) {
 func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
 	op := "TeamHandler.GetTeam"
 	logger := h.logger.With(slog.String("op", op))
-	teamName := r.URL.Query().Get("team_name")
-	if teamName == "" {
-		handleValidationError(w, fmt.Errorf("team_name is required"), logger)
+	query := readQuery(r)
+	teamName := query.required("team_name")
+	if query.err != nil {
+		handleValidationError(w, query.err, logger)
 		return
 	}
 	response, err := h.service.GetTeam(r.Context(), teamName)
@@ -73,6 +89,9 @@ func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
 		handleServiceError(w, err, logger)
 		return
 	}
+	if notModified(w, r, response.ETag) {
+		return
+	}
 	sendSuccessResponse(w, http.StatusOK, response, logger)
 }
 
@@ -85,7 +104,51 @@ func (h *TeamHandler) DeactivateTeam(w http.ResponseWriter, r *http.Request) {
 		handleValidationError(w, err, logger)
 		return
 	}
-	response, err := h.service.DeactivateTeam(r.Context(), req.TeamName)
+	response, err := h.service.DeactivateTeam(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
+
+// ListTeams returns a page of teams ordered by name.
+func (h *TeamHandler) ListTeams(w http.ResponseWriter, r *http.Request) {
+	op := "TeamHandler.ListTeams"
+	logger := h.logger.With(slog.String("op", op))
+	page, err := parsePage(r, "", defaultPageLimit)
+	if err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	response, err := h.service.ListTeams(r.Context(), teamDto.ListTeamsRequest{Page: page})
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
+
+// GetReviewQueue returns open PRs the team is responsible for reviewing.
+func (h *TeamHandler) GetReviewQueue(w http.ResponseWriter, r *http.Request) {
+	op := "TeamHandler.GetReviewQueue"
+	logger := h.logger.With(slog.String("op", op))
+	query := readQuery(r)
+	req := teamDto.ReviewQueueRequest{
+		TeamName:        query.required("team_name"),
+		UnreviewedOnly:  query.boolean("unreviewed_only"),
+		ExpandReviewers: expandsReviewers(r),
+	}
+	if query.err != nil {
+		handleValidationError(w, query.err, logger)
+		return
+	}
+	var err error
+	if req.Sort, err = parseSort(r, reviewQueueSortFields); err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	response, err := h.service.GetReviewQueue(r.Context(), req)
 	if err != nil {
 		handleServiceError(w, err, logger)
 		return
@@ -0,0 +1,249 @@
+package handler
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	teamDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/team"
+)
+
+// defaultMaxImportSize limits the body of a CSV import when no limit is configured.
+const defaultMaxImportSize = 1 << 20
+
+// Columns of a team import. The header names them in any order; is_active may be left out,
+// making every member active.
+const (
+	importColumnTeamName = "team_name"
+	importColumnUserID   = "user_id"
+	importColumnUsername = "username"
+	importColumnIsActive = "is_active"
+)
+
+var (
+	importColumns         = []string{importColumnTeamName, importColumnUserID, importColumnUsername, importColumnIsActive}
+	requiredImportColumns = []string{importColumnTeamName, importColumnUserID, importColumnUsername}
+)
+
+// importedTeam collects the rows of one team of the CSV.
+type importedTeam struct {
+	req     teamDto.AddTeamRequest
+	lines   []int
+	invalid bool
+}
+
+// ImportCSV creates teams from a text/csv body with a team_name,user_id,username,is_active header,
+// one member per row. Rows are read and validated one at a time and grouped by team_name; a team
+// with an invalid row is not imported at all, so it can be fixed and imported again. Answers 201
+// when every team was created and 207 with the result of each team and the invalid rows otherwise.
+func (h *TeamHandler) ImportCSV(w http.ResponseWriter, r *http.Request) {
+	op := "TeamHandler.ImportCSV"
+	logger := h.logger.With(slog.String("op", op))
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mediaType != "text/csv" {
+		handleValidationError(w, fmt.Errorf("content type must be text/csv"), logger)
+		return
+	}
+
+	teams, rowErrors, rows, err := h.readTeamsCSV(http.MaxBytesReader(w, r.Body, h.maxImportSize), requestNormalizer(r))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			_ = RespondWithCustomError(w, http.StatusRequestEntityTooLarge, dto.NewErrorResponse(CodePayloadTooLarge,
+				fmt.Sprintf("file must not be larger than %d bytes", tooLarge.Limit)))
+			return
+		}
+		handleValidationError(w, err, logger)
+		return
+	}
+
+	valid := make([]teamDto.AddTeamRequest, 0, len(teams))
+	for _, t := range teams {
+		if !t.invalid {
+			valid = append(valid, t.req)
+		}
+	}
+	imported := &teamDto.ImportTeamsResponse{}
+	if len(valid) > 0 {
+		imported, err = h.service.ImportTeams(r.Context(), teamDto.ImportTeamsRequest{Teams: valid})
+		if err != nil {
+			handleServiceError(w, err, logger)
+			return
+		}
+	}
+
+	// results of the service are in the order of the valid teams, so they fill the gaps between invalid ones
+	response := teamDto.ImportTeamsResponse{
+		Results:   make([]teamDto.ImportTeamResult, 0, len(teams)),
+		RowErrors: rowErrors,
+		Summary:   imported.Summary,
+	}
+	response.Summary.Rows = rows
+	response.Summary.InvalidRows = len(rowErrors)
+	next := 0
+	for _, t := range teams {
+		if t.invalid {
+			response.Results = append(response.Results, teamDto.ImportTeamResult{
+				TeamName: t.req.TeamName,
+				Result:   teamDto.ImportResultInvalid,
+				Members:  len(t.req.Members),
+				Lines:    t.lines,
+				Error:    "team has invalid rows",
+			})
+			response.Summary.Invalid++
+			continue
+		}
+		result := imported.Results[next]
+		result.Lines = t.lines
+		response.Results = append(response.Results, result)
+		next++
+	}
+
+	status := http.StatusCreated
+	if response.Summary.Created < len(teams) || len(rowErrors) > 0 {
+		status = http.StatusMultiStatus
+	}
+	sendSuccessResponse(w, status, response, logger)
+}
+
+// readTeamsCSV reads the teams of the CSV in the order they first appear, with the errors of the
+// invalid rows and the number of rows. A row without a team name can't be given to a team and is
+// only reported. Team names are normalized by n. The error is set when the header is invalid or
+// the body can't be read.
+func (h *TeamHandler) readTeamsCSV(body io.Reader, n normalizer) ([]*importedTeam, []teamDto.ImportRowError, int, error) {
+	reader := csv.NewReader(body)
+	reader.ReuseRecord = true
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, 0, fmt.Errorf("file is empty, expected a header with %s", strings.Join(importColumns, ","))
+	}
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	index, err := importColumnIndex(header)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	var teams []*importedTeam
+	byName := make(map[string]*importedTeam)
+	memberLines := make(map[string]int)
+	rowErrors := []teamDto.ImportRowError{}
+	rows := 0
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			// the reader goes on with the next row after a malformed one
+			rows++
+			rowErrors = append(rowErrors, teamDto.ImportRowError{Line: parseErr.StartLine, Error: parseErr.Err.Error()})
+			if t := byName[n.identifier(importColumnTeamName, field(record, index[importColumnTeamName]))]; t != nil {
+				t.invalid = true
+				t.lines = append(t.lines, parseErr.StartLine)
+			}
+			continue
+		}
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		rows++
+		line, _ := reader.FieldPos(0)
+
+		teamName := n.identifier(importColumnTeamName, field(record, index[importColumnTeamName]))
+		if teamName == "" {
+			rowErrors = append(rowErrors, teamDto.ImportRowError{Line: line, Error: "team_name is required"})
+			continue
+		}
+		t := byName[teamName]
+		if t == nil {
+			t = &importedTeam{req: teamDto.AddTeamRequest{TeamName: teamName}}
+			byName[teamName] = t
+			teams = append(teams, t)
+		}
+		t.lines = append(t.lines, line)
+
+		member, err := h.importMember(record, index)
+		if err == nil {
+			if first, ok := memberLines[member.UserID]; ok {
+				err = fmt.Errorf("user_id %s is already listed on line %d", member.UserID, first)
+			}
+		}
+		if err != nil {
+			rowErrors = append(rowErrors, teamDto.ImportRowError{Line: line, Error: err.Error()})
+			t.invalid = true
+			continue
+		}
+		memberLines[member.UserID] = line
+		t.req.Members = append(t.req.Members, member)
+	}
+	return teams, rowErrors, rows, nil
+}
+
+// importMember validates the member of a row like a member of /team/add.
+func (h *TeamHandler) importMember(record []string, index map[string]int) (teamDto.TeamMember, error) {
+	member := teamDto.TeamMember{
+		UserID:   field(record, index[importColumnUserID]),
+		Username: field(record, index[importColumnUsername]),
+		IsActive: true,
+	}
+	if i, ok := index[importColumnIsActive]; ok {
+		isActive, err := strconv.ParseBool(field(record, i))
+		if err != nil {
+			return teamDto.TeamMember{}, fmt.Errorf("is_active must be true or false")
+		}
+		member.IsActive = isActive
+	}
+	if err := h.validate.Struct(member); err != nil {
+		fields := fieldErrors(err)
+		if len(fields) == 0 {
+			return teamDto.TeamMember{}, err
+		}
+		messages := make([]string, 0, len(fields))
+		for _, f := range fields {
+			messages = append(messages, fmt.Sprintf("%s is %s", f.Field, f.Rule))
+		}
+		return teamDto.TeamMember{}, errors.New(strings.Join(messages, "; "))
+	}
+	return member, nil
+}
+
+// importColumnIndex maps the columns of the header to their positions, rejecting unknown,
+// repeated and missing columns.
+func importColumnIndex(header []string) (map[string]int, error) {
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		// spreadsheets often start the file with a byte order mark
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !slices.Contains(importColumns, name) {
+			return nil, fmt.Errorf("unknown column %q, expected %s", name, strings.Join(importColumns, ","))
+		}
+		if _, ok := index[name]; ok {
+			return nil, fmt.Errorf("column %q is repeated", name)
+		}
+		index[name] = i
+	}
+	for _, name := range requiredImportColumns {
+		if _, ok := index[name]; !ok {
+			return nil, fmt.Errorf("column %q is required", name)
+		}
+	}
+	return index, nil
+}
+
+// field returns the trimmed value of the column, empty when the row is too short.
+func field(record []string, i int) string {
+	if i < len(record) {
+		return strings.TrimSpace(record[i])
+	}
+	return ""
+}
@@ -0,0 +1,184 @@
+package handler
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mocks/mock_team_import_service.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	teamDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/team"
+)
+
+// TeamImportService defines the interface for the streamed import of a team.
+type TeamImportService interface {
+	ImportTeam(ctx context.Context, req teamDto.ImportTeamRequest,
+		members iter.Seq2[teamDto.TeamMember, error]) (*teamDto.ImportTeamResponse, error)
+}
+
+// TeamImportHandler handles the streamed JSON import of teams too large for /team/add.
+type TeamImportHandler struct {
+	service  TeamImportService
+	logger   *slog.Logger
+	validate *validator.Validate
+}
+
+// NewTeamImportHandler creates a new TeamImportHandler.
+func NewTeamImportHandler(service TeamImportService, logger *slog.Logger, validate *validator.Validate) *TeamImportHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if validate == nil {
+		validate = NewValidator()
+	}
+	return &TeamImportHandler{
+		service:  service,
+		logger:   logger,
+		validate: validate,
+	}
+}
+
+// memberError reports an invalid member of a streamed import; index is its position in members.
+type memberError struct {
+	index int
+	err   error
+}
+
+func (e *memberError) Error() string {
+	return fmt.Sprintf("members[%d]: %s", e.index, e.err)
+}
+
+func (e *memberError) Unwrap() error {
+	return e.err
+}
+
+// ImportJSON creates a team from a body shaped like that of /team/add, whose team_name, description
+// and lead_id must come before members. The members are decoded, normalized and validated one at a
+// time as the service stores them, so the team is never held in memory as a whole; the first
+// invalid member fails the import and nothing is stored.
+func (h *TeamImportHandler) ImportJSON(w http.ResponseWriter, r *http.Request) {
+	op := "TeamImportHandler.ImportJSON"
+	logger := h.logger.With(slog.String("op", op))
+	n := requestNormalizer(r)
+
+	dec := json.NewDecoder(r.Body)
+	req, err := readImportHeader(dec)
+	if err == nil {
+		err = n.normalize(&req)
+	}
+	if err == nil {
+		err = h.validate.Struct(req)
+	}
+	if err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+
+	// the error of the body, which is a validation error rather than one of the service
+	var readErr error
+	members := func(yield func(teamDto.TeamMember, error) bool) {
+		for index := 0; dec.More(); index++ {
+			var member teamDto.TeamMember
+			err := dec.Decode(&member)
+			if err == nil {
+				err = n.normalize(&member)
+			}
+			if err == nil {
+				err = h.validate.Struct(member)
+			}
+			if err != nil {
+				readErr = &memberError{index: index, err: err}
+				yield(member, readErr)
+				return
+			}
+			if !yield(member, nil) {
+				return
+			}
+		}
+		if readErr = readImportTrailer(dec); readErr != nil {
+			yield(teamDto.TeamMember{}, readErr)
+		}
+	}
+
+	response, err := h.service.ImportTeam(r.Context(), req, members)
+	if readErr != nil {
+		handleValidationError(w, readErr, logger)
+		return
+	}
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusCreated, response, logger)
+}
+
+// readImportHeader reads the body up to the start of the members array, decoding the team fields
+// before it. Unknown fields are skipped as they are by /team/add.
+func readImportHeader(dec *json.Decoder) (teamDto.ImportTeamRequest, error) {
+	var req teamDto.ImportTeamRequest
+	if err := readDelim(dec, '{', "body must be an object"); err != nil {
+		return req, err
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return req, err
+		}
+		var value any
+		switch key {
+		case "team_name":
+			value = &req.TeamName
+		case "description":
+			value = &req.Description
+		case "lead_id":
+			value = &req.LeadID
+		case "members":
+			return req, readDelim(dec, '[', "members must be an array")
+		default:
+			value = &json.RawMessage{}
+		}
+		if err = dec.Decode(value); err != nil {
+			return req, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	return req, errors.New("members is required")
+}
+
+// readImportTrailer reads the body after the members array, where the team fields are refused
+// since the members were already stored without them.
+func readImportTrailer(dec *json.Decoder) error {
+	if err := readDelim(dec, ']', "members must be an array"); err != nil {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "team_name", "description", "lead_id":
+			return fmt.Errorf("%s must come before members", key)
+		}
+		if err = dec.Decode(&json.RawMessage{}); err != nil {
+			return err
+		}
+	}
+	return readDelim(dec, '}', "body must be an object")
+}
+
+// readDelim reads the next token of dec, failing with message unless it is delim.
+func readDelim(dec *json.Decoder, delim json.Delim, message string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != delim {
+		return errors.New(message)
+	}
+	return nil
+}
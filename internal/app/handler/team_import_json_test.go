@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"testing"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	teamDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/team"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler/mocks"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type teamImportCase = handlerCase[*mocks.MockTeamImportService]
+
+// expectImport makes the mock read the members as the service does, storing them into got and
+// stopping at the first error.
+func expectImport(m *mocks.MockTeamImportService, req teamDto.ImportTeamRequest, got *[]teamDto.TeamMember) {
+	m.EXPECT().ImportTeam(gomock.Any(), req, gomock.Any()).DoAndReturn(
+		func(_ context.Context, req teamDto.ImportTeamRequest,
+			members iter.Seq2[teamDto.TeamMember, error]) (*teamDto.ImportTeamResponse, error) {
+			for member, err := range members {
+				if err != nil {
+					return nil, err
+				}
+				*got = append(*got, member)
+			}
+			return &teamDto.ImportTeamResponse{TeamName: req.TeamName, Members: len(*got)}, nil
+		})
+}
+
+func TestTeamImportHandler_ImportJSON(t *testing.T) {
+	var got []teamDto.TeamMember
+
+	runCases(t, mocks.NewMockTeamImportService, func(m *mocks.MockTeamImportService) http.HandlerFunc {
+		got = nil
+		return NewTeamImportHandler(m, testLogger(), nil).ImportJSON
+	}, []teamImportCase{
+		{
+			name: "Success - Members are read one at a time", method: http.MethodPost, target: "/team/importJson",
+			body: `{"team_name":" backend ","lead_id":"u1","extra":{"a":[1]},"members":[` +
+				`{"user_id":"u1","username":"Alice","is_active":true},` +
+				`{"user_id":" u2","username":"Bob","is_active":false}]}`,
+			setup: func(m *mocks.MockTeamImportService) {
+				expectImport(m, teamDto.ImportTeamRequest{TeamName: "backend", LeadID: "u1"}, &got)
+			},
+			status: http.StatusCreated,
+			check: func(t *testing.T, body []byte) {
+				assert.Equal(t, teamDto.ImportTeamResponse{TeamName: "backend", Members: 2},
+					decodeBody[teamDto.ImportTeamResponse](t, body))
+				assert.Equal(t, []teamDto.TeamMember{
+					{UserID: "u1", Username: "Alice", IsActive: true},
+					{UserID: "u2", Username: "Bob", IsActive: false},
+				}, got)
+			},
+		},
+		{
+			name: "Error - Invalid member is reported with its position", method: http.MethodPost, target: "/team/importJson",
+			body: `{"team_name":"backend","members":[{"user_id":"u1","username":"Alice"},{"user_id":"u2"}]}`,
+			setup: func(m *mocks.MockTeamImportService) {
+				expectImport(m, teamDto.ImportTeamRequest{TeamName: "backend"}, &got)
+			},
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+			check: func(t *testing.T, body []byte) {
+				errResp := decodeBody[dto.ErrorResponse](t, body)
+				assert.Contains(t, errResp.Error.Message, "members[1]")
+				assert.Equal(t, map[string]any{"fields": []any{
+					map[string]any{"field": "members[1].username", "rule": "required"},
+				}}, errResp.Error.Details)
+			},
+		},
+		{
+			name: "Error - Member of the wrong type", method: http.MethodPost, target: "/team/importJson",
+			body: `{"team_name":"backend","members":[{"user_id":"u1","username":"Alice","is_active":"yes"}]}`,
+			setup: func(m *mocks.MockTeamImportService) {
+				expectImport(m, teamDto.ImportTeamRequest{TeamName: "backend"}, &got)
+			},
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+			check: func(t *testing.T, body []byte) {
+				errResp := decodeBody[dto.ErrorResponse](t, body)
+				assert.Equal(t, map[string]any{"fields": []any{
+					map[string]any{"field": "members[0].is_active", "rule": "type", "param": "bool"},
+				}}, errResp.Error.Details)
+			},
+		},
+		{
+			name: "Error - Team field after members", method: http.MethodPost, target: "/team/importJson",
+			body: `{"team_name":"backend","members":[{"user_id":"u1","username":"Alice"}],"lead_id":"u1"}`,
+			setup: func(m *mocks.MockTeamImportService) {
+				expectImport(m, teamDto.ImportTeamRequest{TeamName: "backend"}, &got)
+			},
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Truncated body", method: http.MethodPost, target: "/team/importJson",
+			body: `{"team_name":"backend","members":[{"user_id":"u1","username":"Alice"}`,
+			setup: func(m *mocks.MockTeamImportService) {
+				expectImport(m, teamDto.ImportTeamRequest{TeamName: "backend"}, &got)
+			},
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Team name missing before members", method: http.MethodPost, target: "/team/importJson",
+			body:   `{"members":[{"user_id":"u1","username":"Alice"}],"team_name":"backend"}`,
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Members missing", method: http.MethodPost, target: "/team/importJson",
+			body:   `{"team_name":"backend"}`,
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Members not an array", method: http.MethodPost, target: "/team/importJson",
+			body:   `{"team_name":"backend","members":{}}`,
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Team exists", method: http.MethodPost, target: "/team/importJson",
+			body: `{"team_name":"backend","members":[{"user_id":"u1","username":"Alice"}]}`,
+			setup: func(m *mocks.MockTeamImportService) {
+				m.EXPECT().ImportTeam(gomock.Any(), teamDto.ImportTeamRequest{TeamName: "backend"}, gomock.Any()).
+					Return(nil, domainErrors.NewTeamExists("team_name already exists"))
+			},
+			status: http.StatusConflict, code: domainErrors.CodeTeamExists,
+		},
+	})
+}
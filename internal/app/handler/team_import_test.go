@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	teamDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/team"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler/mocks"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func serveImport(t *testing.T, m *mocks.MockTeamService, maxSize int64, contentType, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/team/importCsv", strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	NewTeamHandler(m, testLogger(), nil, maxSize).ImportCSV(rec, req)
+	return rec
+}
+
+func TestTeamHandler_ImportCSV(t *testing.T) {
+	t.Run("Success - Rows are grouped into teams", func(t *testing.T) {
+		m := mocks.NewMockTeamService(gomock.NewController(t))
+		m.EXPECT().ImportTeams(gomock.Any(), teamDto.ImportTeamsRequest{Teams: []teamDto.AddTeamRequest{
+			{TeamName: "backend", Members: []teamDto.TeamMember{
+				{UserID: "u1", Username: "Alice", IsActive: true},
+				{UserID: "u3", Username: "Carol", IsActive: false},
+			}},
+			{TeamName: "frontend", Members: []teamDto.TeamMember{{UserID: "u2", Username: "Bob", IsActive: true}}},
+		}}).Return(&teamDto.ImportTeamsResponse{
+			Results: []teamDto.ImportTeamResult{
+				{TeamName: "backend", Result: teamDto.ImportResultCreated, Members: 2},
+				{TeamName: "frontend", Result: teamDto.ImportResultCreated, Members: 1},
+			},
+			Summary: teamDto.ImportTeamsSummary{Created: 2},
+		}, nil)
+
+		rec := serveImport(t, m, 0, "text/csv; charset=utf-8", "\ufeffTeam_Name, user_id,username,is_active\n"+
+			"backend,u1,Alice,true\n"+
+			"frontend,u2,Bob,1\n"+
+			"backend, u3 ,\"Carol\",false\n")
+
+		assert.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		resp := decodeBody[teamDto.ImportTeamsResponse](t, rec.Body.Bytes())
+		assert.Equal(t, []int{2, 4}, resp.Results[0].Lines)
+		assert.Equal(t, []int{3}, resp.Results[1].Lines)
+		assert.Empty(t, resp.RowErrors)
+		assert.Equal(t, teamDto.ImportTeamsSummary{Rows: 3, Created: 2}, resp.Summary)
+	})
+
+	t.Run("Success - Team with an invalid row is not imported", func(t *testing.T) {
+		m := mocks.NewMockTeamService(gomock.NewController(t))
+		m.EXPECT().ImportTeams(gomock.Any(), teamDto.ImportTeamsRequest{Teams: []teamDto.AddTeamRequest{
+			{TeamName: "frontend", Members: []teamDto.TeamMember{{UserID: "u2", Username: "Bob", IsActive: true}}},
+		}}).Return(&teamDto.ImportTeamsResponse{
+			Results: []teamDto.ImportTeamResult{{TeamName: "frontend", Result: teamDto.ImportResultAlreadyExists,
+				Members: 1, Error: "team_name already exists"}},
+			Summary: teamDto.ImportTeamsSummary{AlreadyExists: 1},
+		}, nil)
+
+		rec := serveImport(t, m, 0, "text/csv", "team_name,user_id,username\n"+
+			"backend,u1,Alice\n"+
+			"frontend,u2,Bob\n"+
+			"backend,u3,\n"+
+			",u4,Dave\n"+
+			"backend,u1,Alice again\n"+
+			"backend,u5\n")
+
+		assert.Equal(t, http.StatusMultiStatus, rec.Code, rec.Body.String())
+		resp := decodeBody[teamDto.ImportTeamsResponse](t, rec.Body.Bytes())
+		assert.Equal(t, []teamDto.ImportTeamResult{
+			{TeamName: "backend", Result: teamDto.ImportResultInvalid, Members: 1, Lines: []int{2, 4, 6, 7},
+				Error: "team has invalid rows"},
+			{TeamName: "frontend", Result: teamDto.ImportResultAlreadyExists, Members: 1, Lines: []int{3},
+				Error: "team_name already exists"},
+		}, resp.Results)
+		assert.Equal(t, []teamDto.ImportRowError{
+			{Line: 4, Error: "username is required"},
+			{Line: 5, Error: "team_name is required"},
+			{Line: 6, Error: "user_id u1 is already listed on line 2"},
+			{Line: 7, Error: "wrong number of fields"},
+		}, resp.RowErrors)
+		assert.Equal(t, teamDto.ImportTeamsSummary{Rows: 6, InvalidRows: 4, AlreadyExists: 1, Invalid: 1}, resp.Summary)
+	})
+
+	t.Run("Error - Service fails", func(t *testing.T) {
+		m := mocks.NewMockTeamService(gomock.NewController(t))
+		m.EXPECT().ImportTeams(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+
+		rec := serveImport(t, m, 0, "text/csv", "team_name,user_id,username\nbackend,u1,Alice\n")
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	tests := []struct {
+		name        string
+		maxSize     int64
+		contentType string
+		body        string
+		status      int
+		code        string
+		message     string
+	}{
+		{
+			name: "Error - Not CSV", contentType: "application/json", body: `{}`,
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation, message: "content type must be text/csv",
+		},
+		{
+			name: "Error - Empty file", contentType: "text/csv",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+			message: "file is empty, expected a header with team_name,user_id,username,is_active",
+		},
+		{
+			name: "Error - Unknown column", contentType: "text/csv", body: "team_name,user_id,username,email\n",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+			message: `unknown column "email", expected team_name,user_id,username,is_active`,
+		},
+		{
+			name: "Error - Missing column", contentType: "text/csv", body: "team_name,username\n",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation, message: `column "user_id" is required`,
+		},
+		{
+			name: "Error - Repeated column", contentType: "text/csv", body: "team_name,user_id,username,user_id\n",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation, message: `column "user_id" is repeated`,
+		},
+		{
+			name: "Error - File too large", maxSize: 64, contentType: "text/csv",
+			body:   "team_name,user_id,username\n" + strings.Repeat("backend,u1,Alice\n", 10),
+			status: http.StatusRequestEntityTooLarge, code: CodePayloadTooLarge,
+			message: "file must not be larger than 64 bytes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mocks.NewMockTeamService(gomock.NewController(t))
+
+			rec := serveImport(t, m, tt.maxSize, tt.contentType, tt.body)
+
+			assert.Equal(t, tt.status, rec.Code)
+			resp := decodeBody[dto.ErrorResponse](t, rec.Body.Bytes())
+			require.Equal(t, tt.code, resp.Error.Code)
+			assert.Equal(t, tt.message, resp.Error.Message)
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	teamDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/team"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler/mocks"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type teamCase = handlerCase[*mocks.MockTeamService]
+
+func runTeamCases(t *testing.T, handle func(h *TeamHandler) http.HandlerFunc, cases []teamCase) {
+	t.Helper()
+	runCases(t, mocks.NewMockTeamService, func(m *mocks.MockTeamService) http.HandlerFunc {
+		return handle(NewTeamHandler(m, testLogger(), nil, 0))
+	}, cases)
+}
+
+func TestTeamHandler_AddTeam(t *testing.T) {
+	const body = `{"team_name":"backend","members":[{"user_id":"u1","username":"Alice","is_active":true}]}`
+	req := teamDto.AddTeamRequest{
+		TeamName: "backend",
+		Members:  []teamDto.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}},
+	}
+
+	runTeamCases(t, func(h *TeamHandler) http.HandlerFunc { return h.AddTeam }, []teamCase{
+		{
+			name: "Success - Team created", method: http.MethodPost, target: "/team/add", body: body,
+			setup: func(m *mocks.MockTeamService) {
+				m.EXPECT().AddTeam(gomock.Any(), req).Return(&teamDto.AddTeamResponse{
+					Team: teamDto.Team{TeamName: "backend", Members: req.Members},
+				}, nil)
+			},
+			status: http.StatusCreated,
+			check: func(t *testing.T, body []byte) {
+				resp := decodeBody[teamDto.AddTeamResponse](t, body)
+				assert.Equal(t, "backend", resp.Team.TeamName)
+				assert.Len(t, resp.Team.Members, 1)
+			},
+		},
+		{
+			name: "Error - Malformed JSON", method: http.MethodPost, target: "/team/add",
+			body: `{"team_name":"backend","members":{}}`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - No members", method: http.MethodPost, target: "/team/add",
+			body: `{"team_name":"backend","members":[]}`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Member without username", method: http.MethodPost, target: "/team/add",
+			body: `{"team_name":"backend","members":[{"user_id":"u1"}]}`, status: http.StatusBadRequest,
+			code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Working hours without timezone", method: http.MethodPost, target: "/team/add",
+			body: `{"team_name":"backend","members":[{"user_id":"u1","username":"Alice",` +
+				`"work_hours_start":"09:00","work_hours_end":"18:00"}]}`,
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Service rejects the team", method: http.MethodPost, target: "/team/add", body: body,
+			setup: func(m *mocks.MockTeamService) {
+				m.EXPECT().AddTeam(gomock.Any(), req).Return(nil, domainErrors.NewBadRequest("team must have at least one member"))
+			},
+			status: http.StatusBadRequest, code: domainErrors.CodeBadRequest,
+		},
+		{
+			name: "Error - Team exists", method: http.MethodPost, target: "/team/add", body: body,
+			setup: func(m *mocks.MockTeamService) {
+				m.EXPECT().AddTeam(gomock.Any(), req).Return(nil, domainErrors.NewTeamExists("team_name already exists").
+					WithDetails(teamDto.TeamSummary{TeamName: "backend", LeadID: "u2", Members: 3, ActiveMembers: 2}))
+			},
+			status: http.StatusConflict, code: domainErrors.CodeTeamExists,
+			check: func(t *testing.T, body []byte) {
+				existing := decodeBody[struct {
+					Error struct {
+						Details teamDto.TeamSummary `json:"details"`
+					} `json:"error"`
+				}](t, body).Error.Details
+				assert.Equal(t, teamDto.TeamSummary{TeamName: "backend", LeadID: "u2", Members: 3, ActiveMembers: 2}, existing)
+			},
+		},
+		{
+			name: "Error - Repository failure", method: http.MethodPost, target: "/team/add", body: body,
+			setup: func(m *mocks.MockTeamService) {
+				m.EXPECT().AddTeam(gomock.Any(), req).Return(nil, errors.New("connection refused"))
+			},
+			status: http.StatusInternalServerError, code: CodeInternalError,
+		},
+	})
+}
+
+func TestTeamHandler_GetTeam(t *testing.T) {
+	runTeamCases(t, func(h *TeamHandler) http.HandlerFunc { return h.GetTeam }, []teamCase{
+		{
+			name: "Success - Team returned", method: http.MethodGet, target: "/team/get?team_name=backend",
+			setup: func(m *mocks.MockTeamService) {
+				m.EXPECT().GetTeam(gomock.Any(), "backend").Return(&teamDto.GetTeamResponse{
+					TeamName: "backend", Members: []teamDto.TeamMember{{UserID: "u1", Username: "Alice"}},
+				}, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				assert.Equal(t, "backend", decodeBody[teamDto.GetTeamResponse](t, body).TeamName)
+			},
+		},
+		{
+			name: "Error - Missing team name", method: http.MethodGet, target: "/team/get",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Team not found", method: http.MethodGet, target: "/team/get?team_name=backend",
+			setup: func(m *mocks.MockTeamService) {
+				m.EXPECT().GetTeam(gomock.Any(), "backend").Return(nil, domainErrors.NewNotFound("team not found"))
+			},
+			status: http.StatusNotFound, code: domainErrors.CodeNotFound,
+		},
+	})
+}
+
+func TestTeamHandler_ListTeams(t *testing.T) {
+	runTeamCases(t, func(h *TeamHandler) http.HandlerFunc { return h.ListTeams }, []teamCase{
+		{
+			name: "Success - Page of teams", method: http.MethodGet, target: "/team/list?limit=1&offset=1",
+			setup: func(m *mocks.MockTeamService) {
+				m.EXPECT().ListTeams(gomock.Any(), teamDto.ListTeamsRequest{
+					Page: dto.PageRequest{Limit: 1, Offset: 1},
+				}).Return(&dto.Page[teamDto.TeamSummary]{
+					Items: []teamDto.TeamSummary{{TeamName: "backend", LeadID: "u1", Members: 2, ActiveMembers: 1}},
+					Total: 2, Limit: 1, Offset: 1,
+				}, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				page := decodeBody[dto.Page[teamDto.TeamSummary]](t, body)
+				assert.Equal(t, 2, page.Total)
+				assert.Equal(t, "u1", page.Items[0].LeadID)
+			},
+		},
+		{
+			name: "Error - Offset not a number", method: http.MethodGet, target: "/team/list?offset=next",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+	})
+}
+
+func TestTeamHandler_GetTeam_ETag(t *testing.T) {
+	const etag = `"abc123"`
+	response := &teamDto.GetTeamResponse{
+		TeamName: "backend", Members: []teamDto.TeamMember{{UserID: "u1", Username: "Alice"}}, ETag: etag,
+	}
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		status      int
+	}{
+		{"Success - No condition", "", http.StatusOK},
+		{"Success - Roster changed", `"old"`, http.StatusOK},
+		{"Success - Unchanged", etag, http.StatusNotModified},
+		{"Success - Unchanged among several", `"old", ` + etag, http.StatusNotModified},
+		{"Success - Weak validator matches", "W/" + etag, http.StatusNotModified},
+		{"Success - Any", "*", http.StatusNotModified},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := mocks.NewMockTeamService(ctrl)
+			m.EXPECT().GetTeam(gomock.Any(), "backend").Return(response, nil)
+			req := httptest.NewRequest(http.MethodGet, "/team/get?team_name=backend", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+
+			NewTeamHandler(m, testLogger(), nil, 0).GetTeam(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, etag, rec.Header().Get("ETag"))
+			if tt.status == http.StatusNotModified {
+				assert.Empty(t, rec.Body.String())
+			} else {
+				assert.Equal(t, "backend", decodeBody[teamDto.GetTeamResponse](t, rec.Body.Bytes()).TeamName)
+			}
+		})
+	}
+}
+
+func TestTeamHandler_DeactivateTeam(t *testing.T) {
+	const body = `{"team_name":"backend"}`
+
+	runTeamCases(t, func(h *TeamHandler) http.HandlerFunc { return h.DeactivateTeam }, []teamCase{
+		{
+			name: "Success - Team deactivated", method: http.MethodPost, target: "/team/deactivate", body: body,
+			setup: func(m *mocks.MockTeamService) {
+				m.EXPECT().DeactivateTeam(gomock.Any(), teamDto.DeactivateTeamRequest{TeamName: "backend"}).Return(&teamDto.DeactivateTeamResponse{
+					DeactivatedUsers: 2, UserIDs: []string{"u1", "u2"},
+				}, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				assert.Equal(t, 2, decodeBody[teamDto.DeactivateTeamResponse](t, body).DeactivatedUsers)
+			},
+		},
+		{
+			name: "Success - Authored PRs closed", method: http.MethodPost, target: "/team/deactivate",
+			body: `{"team_name":"backend","close_authored_prs":true}`,
+			setup: func(m *mocks.MockTeamService) {
+				m.EXPECT().DeactivateTeam(gomock.Any(), teamDto.DeactivateTeamRequest{TeamName: "backend", CloseAuthoredPRs: true}).
+					Return(&teamDto.DeactivateTeamResponse{DeactivatedUsers: 2, ClosedPRs: []string{"pr-1"}}, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				assert.Equal(t, []string{"pr-1"}, decodeBody[teamDto.DeactivateTeamResponse](t, body).ClosedPRs)
+			},
+		},
+		{
+			name: "Error - Malformed JSON", method: http.MethodPost, target: "/team/deactivate",
+			body: `"backend"`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Missing team name", method: http.MethodPost, target: "/team/deactivate",
+			body: `{}`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Team not found", method: http.MethodPost, target: "/team/deactivate", body: body,
+			setup: func(m *mocks.MockTeamService) {
+				m.EXPECT().DeactivateTeam(gomock.Any(), teamDto.DeactivateTeamRequest{TeamName: "backend"}).Return(nil, domainErrors.NewNotFound("team not found"))
+			},
+			status: http.StatusNotFound, code: domainErrors.CodeNotFound,
+		},
+	})
+}
+
+func TestTeamHandler_GetReviewQueue(t *testing.T) {
+	runTeamCases(t, func(h *TeamHandler) http.HandlerFunc { return h.GetReviewQueue }, []teamCase{
+		{
+			name: "Success - Queue returned", method: http.MethodGet, target: "/team/reviewQueue?team_name=backend",
+			setup: func(m *mocks.MockTeamService) {
+				m.EXPECT().GetReviewQueue(gomock.Any(), teamDto.ReviewQueueRequest{TeamName: "backend"}).
+					Return(&teamDto.ReviewQueueResponse{TeamName: "backend"}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Success - All parameters", method: http.MethodGet,
+			target: "/team/reviewQueue?team_name=backend&unreviewed_only=true&expand=reviewers&sort=-created_at",
+			setup: func(m *mocks.MockTeamService) {
+				m.EXPECT().GetReviewQueue(gomock.Any(), teamDto.ReviewQueueRequest{
+					TeamName: "backend", UnreviewedOnly: true, ExpandReviewers: true,
+					Sort: dto.Sort{Field: "created_at", Desc: true},
+				}).Return(&teamDto.ReviewQueueResponse{TeamName: "backend"}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Error - Missing team name", method: http.MethodGet, target: "/team/reviewQueue",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Flag is not a boolean", method: http.MethodGet,
+			target: "/team/reviewQueue?team_name=backend&unreviewed_only=maybe",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Unknown sort field", method: http.MethodGet,
+			target: "/team/reviewQueue?team_name=backend&sort=title",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Team not found", method: http.MethodGet, target: "/team/reviewQueue?team_name=backend",
+			setup: func(m *mocks.MockTeamService) {
+				m.EXPECT().GetReviewQueue(gomock.Any(), gomock.Any()).Return(nil, domainErrors.NewNotFound("team not found"))
+			},
+			status: http.StatusNotFound, code: domainErrors.CodeNotFound,
+		},
+	})
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// timeoutWriteMargin is how long after its timeout a request may still take to write its response.
+const timeoutWriteMargin = 5 * time.Second
+
+// longRoutes are given the long request timeout: statistics aggregate over all PRs, bulk
+// endpoints change many records in one request and a job run on demand is answered once it finished.
+var longRoutes = map[string]bool{
+	"/statistics":                true,
+	"/statistics/user":           true,
+	"/statistics/overdue":        true,
+	"/statistics/distribution":   true,
+	"/statistics/timeseries":     true,
+	"/statistics/history":        true,
+	"/admin/statistics/snapshot": true,
+	"/graphql":                   true,
+	"/pullRequest/createBulk":    true,
+	"/pullRequest/mergeBulk":     true,
+	"/pullRequest/assignPending": true,
+	"/team/importCsv":            true,
+	"/team/importJson":           true,
+	"/team/deactivate":           true,
+	"/admin/archive":             true,
+	"/admin/jobs/run":            true,
+}
+
+// untimedRoutes keep their connection open on purpose and are not bounded by a request timeout:
+// the live queue socket and long poll, and the dump transfers.
+var untimedRoutes = map[string]bool{
+	"/ws/reviews":            true,
+	"/users/pollAssignments": true,
+	"/admin/export":          true,
+	"/admin/import":          true,
+}
+
+// routeTimeout returns the timeout of the route at path, zero when it is not bounded.
+func routeTimeout(path string, services Services) time.Duration {
+	switch {
+	case untimedRoutes[path]:
+		return 0
+	case longRoutes[path]:
+		return services.LongRequestTimeout
+	default:
+		return services.RequestTimeout
+	}
+}
+
+// withTimeout bounds the context of every request by timeout, so a query that runs too long fails
+// with context.DeadlineExceeded and is answered 504 instead of holding the handler until the
+// server's write timeout drops the connection without a body. The write deadline follows the
+// timeout, which may outlast the write timeout meant for single requests. A zero timeout leaves
+// the handler as is.
+func withTimeout(next http.HandlerFunc, timeout time.Duration) http.HandlerFunc {
+	if timeout <= 0 {
+		return next
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(timeout + timeoutWriteMargin))
+		next(w, r.WithContext(ctx))
+	}
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/statistics"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/team"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRouteTimeout(t *testing.T) {
+	services := Services{RequestTimeout: time.Second, LongRequestTimeout: time.Minute}
+
+	assert.Equal(t, time.Second, routeTimeout("/team/get", services))
+	assert.Equal(t, time.Minute, routeTimeout("/statistics", services))
+	assert.Equal(t, time.Minute, routeTimeout("/pullRequest/createBulk", services))
+	assert.Zero(t, routeTimeout("/users/pollAssignments", services))
+	assert.Zero(t, routeTimeout("/admin/import", services))
+}
+
+func TestNewRouter_RequestTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	teams := mocks.NewMockTeamService(ctrl)
+	stats := mocks.NewMockStatisticsService(ctrl)
+	router := NewRouter(Services{
+		Teams:              teams,
+		Statistics:         stats,
+		RequestTimeout:     10 * time.Millisecond,
+		LongRequestTimeout: 50 * time.Millisecond,
+	}, testLogger(), nil)
+
+	t.Run("Error - Slow request is answered 504", func(t *testing.T) {
+		teams.EXPECT().GetTeam(gomock.Any(), "backend").DoAndReturn(
+			func(ctx context.Context, _ string) (*team.GetTeamResponse, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			})
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/team/get?team_name=backend", nil))
+
+		assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+		assert.Equal(t, CodeTimeout, decodeBody[dto.ErrorResponse](t, rec.Body.Bytes()).Error.Code)
+	})
+
+	t.Run("Success - Statistics get the long timeout", func(t *testing.T) {
+		stats.EXPECT().GetOverdue(gomock.Any()).DoAndReturn(
+			func(ctx context.Context) (*statistics.OverdueResponse, error) {
+				deadline, ok := ctx.Deadline()
+				assert.True(t, ok)
+				assert.Greater(t, time.Until(deadline), 10*time.Millisecond)
+				return &statistics.OverdueResponse{}, nil
+			})
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/statistics/overdue", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("Error - Client cancellation has no body", func(t *testing.T) {
+		teams.EXPECT().GetTeam(gomock.Any(), "backend").DoAndReturn(
+			func(ctx context.Context, _ string) (*team.GetTeamResponse, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		rec := httptest.NewRecorder()
+
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/team/get?team_name=backend", nil).WithContext(ctx))
+
+		assert.Equal(t, statusClientClosedRequest, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+}
@@ -1,8 +1,9 @@
 package handler
 
+//go:generate go tool mockgen -source=$GOFILE -destination=mocks/mock_user_service.go -package=mocks
+
 import (
 	"context"
-	"fmt"
 	"log/slog"
 	"net/http"
 
@@ -13,7 +14,11 @@ import (
 // UserService defines the interface for user operations.
 type UserService interface {
 	SetIsActive(ctx context.Context, req userDto.SetIsActiveRequest) (*userDto.SetIsActiveResponse, error)
+	SetIsReviewer(ctx context.Context, req userDto.SetIsReviewerRequest) (*userDto.SetIsReviewerResponse, error)
+	SetTags(ctx context.Context, req userDto.SetTagsRequest) (*userDto.SetTagsResponse, error)
+	UpdateUser(ctx context.Context, req userDto.UpdateUserRequest) (*userDto.UpdateUserResponse, error)
 	GetReview(ctx context.Context, userID string) (*userDto.GetReviewResponse, error)
+	PollAssignments(ctx context.Context, req userDto.PollAssignmentsRequest) (*userDto.PollAssignmentsResponse, error)
 }
 
 // UserHandler handles user related HTTP requests.
@@ -32,7 +37,7 @@ func NewUserHandler(
 		logger = slog.Default()
 	}
 	if validate == nil {
-		validate = validator.New()
+		validate = NewValidator()
 	}
 	return &UserHandler{
 		service:  service,
@@ -58,13 +63,66 @@ func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 	sendSuccessResponse(w, http.StatusOK, response, logger)
 }
 
-// GetReview handles getReview request.
+// SetIsReviewer handles setIsReviewer request.
+func (h *UserHandler) SetIsReviewer(w http.ResponseWriter, r *http.Request) {
+	op := "UserHandler.SetIsReviewer"
+	logger := h.logger.With(slog.String("op", op))
+	var req userDto.SetIsReviewerRequest
+	if err := decodeAndValidate(r, h.validate, &req); err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	response, err := h.service.SetIsReviewer(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
+
+// SetTags handles setTags request.
+func (h *UserHandler) SetTags(w http.ResponseWriter, r *http.Request) {
+	op := "UserHandler.SetTags"
+	logger := h.logger.With(slog.String("op", op))
+	var req userDto.SetTagsRequest
+	if err := decodeAndValidate(r, h.validate, &req); err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	response, err := h.service.SetTags(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
+
+// UpdateUser handles update request.
+func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	op := "UserHandler.UpdateUser"
+	logger := h.logger.With(slog.String("op", op))
+	var req userDto.UpdateUserRequest
+	if err := decodeAndValidate(r, h.validate, &req); err != nil {
+		handleValidationError(w, err, logger)
+		return
+	}
+	response, err := h.service.UpdateUser(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err, logger)
+		return
+	}
+	sendSuccessResponse(w, http.StatusOK, response, logger)
+}
+
+// GetReview handles getReview request. Dashboards polling the list send If-Modified-Since with the
+// Last-Modified of the previous response and get 304 while it is unchanged.
 func (h *UserHandler) GetReview(w http.ResponseWriter, r *http.Request) {
 	op := "UserHandler.GetReview"
 	logger := h.logger.With(slog.String("op", op))
-	userID := r.URL.Query().Get("user_id")
-	if userID == "" {
-		handleValidationError(w, fmt.Errorf("user_id is required"), logger)
+	query := readQuery(r)
+	userID := query.required("user_id")
+	if query.err != nil {
+		handleValidationError(w, query.err, logger)
 		return
 	}
 	response, err := h.service.GetReview(r.Context(), userID)
@@ -72,5 +130,8 @@ func (h *UserHandler) GetReview(w http.ResponseWriter, r *http.Request) {
 		handleServiceError(w, err, logger)
 		return
 	}
+	if notModifiedSince(w, r, response.LastModified) {
+		return
+	}
 	sendSuccessResponse(w, http.StatusOK, response, logger)
 }
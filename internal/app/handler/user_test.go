@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	userDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/user"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler/mocks"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type userCase = handlerCase[*mocks.MockUserService]
+
+func runUserCases(t *testing.T, handle func(h *UserHandler) http.HandlerFunc, cases []userCase) {
+	t.Helper()
+	runCases(t, mocks.NewMockUserService, func(m *mocks.MockUserService) http.HandlerFunc {
+		return handle(NewUserHandler(m, testLogger(), nil))
+	}, cases)
+}
+
+func TestUserHandler_SetIsActive(t *testing.T) {
+	const body = `{"user_id":"u1","is_active":false}`
+	req := userDto.SetIsActiveRequest{UserID: "u1", IsActive: false}
+
+	runUserCases(t, func(h *UserHandler) http.HandlerFunc { return h.SetIsActive }, []userCase{
+		{
+			name: "Success - User deactivated", method: http.MethodPost, target: "/users/setIsActive", body: body,
+			setup: func(m *mocks.MockUserService) {
+				m.EXPECT().SetIsActive(gomock.Any(), req).Return(&userDto.SetIsActiveResponse{
+					User: userDto.User{UserID: "u1", Username: "Alice"},
+				}, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				resp := decodeBody[userDto.SetIsActiveResponse](t, body)
+				assert.Equal(t, "u1", resp.User.UserID)
+				assert.False(t, resp.User.IsActive)
+			},
+		},
+		{
+			name: "Error - Malformed JSON", method: http.MethodPost, target: "/users/setIsActive",
+			body: `{"user_id":"u1","is_active":"no"}`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Missing user id", method: http.MethodPost, target: "/users/setIsActive",
+			body: `{"is_active":true}`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - User not found", method: http.MethodPost, target: "/users/setIsActive", body: body,
+			setup: func(m *mocks.MockUserService) {
+				m.EXPECT().SetIsActive(gomock.Any(), req).Return(nil, domainErrors.NewNotFound("user not found"))
+			},
+			status: http.StatusNotFound, code: domainErrors.CodeNotFound,
+		},
+		{
+			name: "Error - Repository failure", method: http.MethodPost, target: "/users/setIsActive", body: body,
+			setup: func(m *mocks.MockUserService) {
+				m.EXPECT().SetIsActive(gomock.Any(), req).Return(nil, errors.New("connection refused"))
+			},
+			status: http.StatusInternalServerError, code: CodeInternalError,
+		},
+	})
+}
+
+func TestUserHandler_SetIsReviewer(t *testing.T) {
+	const body = `{"user_id":"u1","is_reviewer":false}`
+	req := userDto.SetIsReviewerRequest{UserID: "u1", IsReviewer: false}
+
+	runUserCases(t, func(h *UserHandler) http.HandlerFunc { return h.SetIsReviewer }, []userCase{
+		{
+			name: "Success - User leaves the reviewer pool", method: http.MethodPost, target: "/users/setIsReviewer",
+			body: body,
+			setup: func(m *mocks.MockUserService) {
+				m.EXPECT().SetIsReviewer(gomock.Any(), req).Return(&userDto.SetIsReviewerResponse{
+					User: userDto.User{UserID: "u1", Username: "Alice", IsActive: true},
+				}, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				resp := decodeBody[userDto.SetIsReviewerResponse](t, body)
+				assert.Equal(t, "u1", resp.User.UserID)
+				assert.False(t, resp.User.IsReviewer)
+			},
+		},
+		{
+			name: "Error - Missing user id", method: http.MethodPost, target: "/users/setIsReviewer",
+			body: `{"is_reviewer":true}`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - User not found", method: http.MethodPost, target: "/users/setIsReviewer", body: body,
+			setup: func(m *mocks.MockUserService) {
+				m.EXPECT().SetIsReviewer(gomock.Any(), req).Return(nil, domainErrors.NewNotFound("user not found"))
+			},
+			status: http.StatusNotFound, code: domainErrors.CodeNotFound,
+		},
+	})
+}
+
+func TestUserHandler_SetTags(t *testing.T) {
+	const body = `{"user_id":"u1","tags":["go"]}`
+	req := userDto.SetTagsRequest{UserID: "u1", Tags: []string{"go"}}
+
+	runUserCases(t, func(h *UserHandler) http.HandlerFunc { return h.SetTags }, []userCase{
+		{
+			name: "Success - Tags set", method: http.MethodPost, target: "/users/setTags", body: body,
+			setup: func(m *mocks.MockUserService) {
+				m.EXPECT().SetTags(gomock.Any(), req).Return(&userDto.SetTagsResponse{
+					User: userDto.User{UserID: "u1"},
+				}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "Error - Malformed JSON", method: http.MethodPost, target: "/users/setTags",
+			body: `{"user_id":"u1","tags":"go"}`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Empty tag", method: http.MethodPost, target: "/users/setTags",
+			body: `{"user_id":"u1","tags":[""]}`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - User not found", method: http.MethodPost, target: "/users/setTags", body: body,
+			setup: func(m *mocks.MockUserService) {
+				m.EXPECT().SetTags(gomock.Any(), req).Return(nil, domainErrors.NewNotFound("user not found"))
+			},
+			status: http.StatusNotFound, code: domainErrors.CodeNotFound,
+		},
+	})
+}
+
+func TestUserHandler_UpdateUser(t *testing.T) {
+	const body = `{"user_id":"u1","username":"Alice Smith"}`
+	req := userDto.UpdateUserRequest{UserID: "u1", Username: "Alice Smith"}
+
+	runUserCases(t, func(h *UserHandler) http.HandlerFunc { return h.UpdateUser }, []userCase{
+		{
+			name: "Success - Username updated", method: http.MethodPost, target: "/users/update", body: body,
+			setup: func(m *mocks.MockUserService) {
+				m.EXPECT().UpdateUser(gomock.Any(), req).Return(&userDto.UpdateUserResponse{
+					User: userDto.User{UserID: "u1", Username: "Alice Smith"},
+				}, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				assert.Equal(t, "Alice Smith", decodeBody[userDto.UpdateUserResponse](t, body).User.Username)
+			},
+		},
+		{
+			name: "Error - Missing username", method: http.MethodPost, target: "/users/update",
+			body: `{"user_id":"u1"}`, status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Username too long", method: http.MethodPost, target: "/users/update",
+			body:   `{"user_id":"u1","username":"` + strings.Repeat("a", 256) + `"}`,
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - User not found", method: http.MethodPost, target: "/users/update", body: body,
+			setup: func(m *mocks.MockUserService) {
+				m.EXPECT().UpdateUser(gomock.Any(), req).Return(nil, domainErrors.NewNotFound("user not found"))
+			},
+			status: http.StatusNotFound, code: domainErrors.CodeNotFound,
+		},
+	})
+}
+
+func TestUserHandler_GetReview(t *testing.T) {
+	runUserCases(t, func(h *UserHandler) http.HandlerFunc { return h.GetReview }, []userCase{
+		{
+			name: "Success - Reviews returned", method: http.MethodGet, target: "/users/getReview?user_id=u1",
+			setup: func(m *mocks.MockUserService) {
+				m.EXPECT().GetReview(gomock.Any(), "u1").Return(&userDto.GetReviewResponse{
+					UserID: "u1", PullRequests: []userDto.PR{{PullRequestID: "pr-1", Status: "OPEN"}},
+				}, nil)
+			},
+			status: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				resp := decodeBody[userDto.GetReviewResponse](t, body)
+				assert.Equal(t, "u1", resp.UserID)
+				assert.Len(t, resp.PullRequests, 1)
+			},
+		},
+		{
+			name: "Error - Missing user id", method: http.MethodGet, target: "/users/getReview",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Empty user id", method: http.MethodGet, target: "/users/getReview?user_id=",
+			status: http.StatusBadRequest, code: domainErrors.CodeValidation,
+		},
+		{
+			name: "Error - Repository failure", method: http.MethodGet, target: "/users/getReview?user_id=u1",
+			setup: func(m *mocks.MockUserService) {
+				m.EXPECT().GetReview(gomock.Any(), "u1").Return(nil, errors.New("connection refused"))
+			},
+			status: http.StatusInternalServerError, code: CodeInternalError,
+		},
+	})
+}
+
+func TestUserHandler_GetReview_LastModified(t *testing.T) {
+	lastModified := time.Date(2026, 3, 2, 10, 30, 15, 500_000_000, time.UTC)
+	header := "Mon, 02 Mar 2026 10:30:15 GMT"
+
+	tests := []struct {
+		name            string
+		lastModified    time.Time
+		ifModifiedSince string
+		status          int
+		header          string
+	}{
+		{"Success - No condition", lastModified, "", http.StatusOK, header},
+		{"Success - Changed since", lastModified, "Mon, 02 Mar 2026 10:30:14 GMT", http.StatusOK, header},
+		{"Success - Unchanged", lastModified, header, http.StatusNotModified, header},
+		{"Success - Unchanged since later", lastModified, "Tue, 03 Mar 2026 00:00:00 GMT", http.StatusNotModified, header},
+		{"Success - Invalid date is ignored", lastModified, "yesterday", http.StatusOK, header},
+		{"Success - Never assigned", time.Time{}, header, http.StatusOK, ""},
+		{"Success - Changed within the current second", time.Now(), "Tue, 03 Mar 2099 00:00:00 GMT", http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mocks.NewMockUserService(gomock.NewController(t))
+			m.EXPECT().GetReview(gomock.Any(), "u1").Return(&userDto.GetReviewResponse{
+				UserID: "u1", PullRequests: []userDto.PR{}, LastModified: tt.lastModified,
+			}, nil)
+			req := httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=u1", nil)
+			if tt.ifModifiedSince != "" {
+				req.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+			}
+			rec := httptest.NewRecorder()
+
+			NewUserHandler(m, testLogger(), nil).GetReview(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, tt.header, rec.Header().Get("Last-Modified"))
+			if tt.status == http.StatusNotModified {
+				assert.Empty(t, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package job
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// Escalator defines the interface for reassigning stale reviews.
+type Escalator interface {
+	EscalateStale(ctx context.Context, assignedBefore time.Time, limit int) ([]*models.ReviewerChange, error)
+}
+
+// Notifier defines the interface for delivering events to an external receiver.
+type Notifier interface {
+	Send(ctx context.Context, event any) error
+}
+
+// EventReviewEscalated is the type of the event emitted for a reassigned stale review.
+const EventReviewEscalated = "review.escalated"
+
+// EscalationLockKey is the advisory lock key held by the replica running the escalation job.
+const EscalationLockKey int64 = 0x70727276_00000001
+
+// EscalationEvent describes a stale review reassigned by the job.
+type EscalationEvent struct {
+	Type          string `json:"type"`
+	OrgID         string `json:"org_id"`
+	PullRequestID string `json:"pull_request_id"`
+	OldReviewerID string `json:"old_reviewer_id"`
+	NewReviewerID string `json:"new_reviewer_id"`
+	EscalatedAt   string `json:"escalated_at"`
+}
+
+// EscalationJob reassigns reviews that stayed pending longer than the threshold. The scheduler runs
+// it every interval under EscalationLockKey, so one replica runs it at a time.
+type EscalationJob struct {
+	escalator Escalator
+	notifier  Notifier
+	cfg       config.Escalation
+	log       *slog.Logger
+}
+
+// NewEscalationJob creates a new escalation job. Events are only logged when notifier is nil.
+func NewEscalationJob(
+	escalator Escalator,
+	notifier Notifier,
+	cfg config.Escalation,
+	log *slog.Logger,
+) *EscalationJob {
+	if log == nil {
+		log = slog.Default()
+	}
+	return &EscalationJob{
+		escalator: escalator,
+		notifier:  notifier,
+		cfg:       cfg,
+		log:       log,
+	}
+}
+
+// RunOnce reassigns the stale reviews and emits an event for each.
+func (j *EscalationJob) RunOnce(ctx context.Context) error {
+	assignedBefore := time.Now().UTC().Add(-j.cfg.Threshold)
+	changes, err := j.escalator.EscalateStale(ctx, assignedBefore, j.cfg.BatchSize)
+	// changes made before a failure are still reported
+	for _, change := range changes {
+		j.emit(ctx, change)
+	}
+	return err
+}
+
+// emit logs the escalation and hands it to the notifier when one is configured, which queues it
+// for the webhook. A failure to do so doesn't undo the reassignment.
+func (j *EscalationJob) emit(ctx context.Context, change *models.ReviewerChange) {
+	event := EscalationEvent{
+		Type:          EventReviewEscalated,
+		OrgID:         orgctx.ID(ctx),
+		PullRequestID: change.PRId,
+		OldReviewerID: change.OldReviewerId,
+		NewReviewerID: change.NewReviewerId,
+		EscalatedAt:   dto.FormatTime(change.ChangedAt),
+	}
+	j.log.LogAttrs(ctx, slog.LevelInfo, "review escalated",
+		slog.String("pr_id", event.PullRequestID),
+		slog.String("old_reviewer", event.OldReviewerID),
+		slog.String("new_reviewer", event.NewReviewerID))
+
+	if j.notifier == nil {
+		return
+	}
+	if err := j.notifier.Send(ctx, event); err != nil {
+		j.log.LogAttrs(ctx, slog.LevelError, "failed to queue escalation event",
+			slog.String("pr_id", event.PullRequestID), slog.String("error", err.Error()))
+	}
+}
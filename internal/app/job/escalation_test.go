@@ -0,0 +1,91 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeEscalator struct {
+	calls          int
+	assignedBefore time.Time
+	changes        []*models.ReviewerChange
+	err            error
+}
+
+func (e *fakeEscalator) EscalateStale(ctx context.Context, assignedBefore time.Time, limit int) ([]*models.ReviewerChange, error) {
+	e.calls++
+	e.assignedBefore = assignedBefore
+	return e.changes, e.err
+}
+
+type fakeNotifier struct {
+	events []any
+	err    error
+}
+
+func (n *fakeNotifier) Send(ctx context.Context, event any) error {
+	n.events = append(n.events, event)
+	return n.err
+}
+
+func TestEscalationJob_RunOnce(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	cfg := config.Escalation{Enabled: true, Interval: time.Hour, Threshold: 72 * time.Hour, BatchSize: 10}
+	changedAt := time.Date(2024, time.June, 5, 12, 0, 0, 0, time.UTC)
+	change := &models.ReviewerChange{
+		PRId: "pr-1", OldReviewerId: "u2", NewReviewerId: "u3",
+		Trigger: models.ReviewerChangeEscalation, ChangedAt: changedAt,
+	}
+
+	t.Run("Success - Emits an event per reassigned review", func(t *testing.T) {
+		escalator := &fakeEscalator{changes: []*models.ReviewerChange{change}}
+		notifier := &fakeNotifier{}
+		job := NewEscalationJob(escalator, notifier, cfg, logger)
+
+		err := job.RunOnce(orgctx.WithID(context.Background(), "payments"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, escalator.calls)
+		assert.WithinDuration(t, time.Now().Add(-cfg.Threshold), escalator.assignedBefore, time.Minute)
+		assert.Equal(t, []any{EscalationEvent{
+			Type: EventReviewEscalated, OrgID: "payments", PullRequestID: "pr-1", OldReviewerID: "u2", NewReviewerID: "u3",
+			EscalatedAt: "2024-06-05T12:00:00Z",
+		}}, notifier.events)
+	})
+
+	t.Run("Success - Failed delivery doesn't fail the run", func(t *testing.T) {
+		escalator := &fakeEscalator{changes: []*models.ReviewerChange{change}}
+		notifier := &fakeNotifier{err: fmt.Errorf("receiver is down")}
+		job := NewEscalationJob(escalator, notifier, cfg, logger)
+
+		assert.NoError(t, job.RunOnce(context.Background()))
+		assert.Len(t, notifier.events, 1)
+	})
+
+	t.Run("Success - Works without a notifier", func(t *testing.T) {
+		escalator := &fakeEscalator{changes: []*models.ReviewerChange{change}}
+		job := NewEscalationJob(escalator, nil, cfg, logger)
+
+		assert.NoError(t, job.RunOnce(context.Background()))
+	})
+
+	t.Run("Error - Escalation failure still reports the changes made", func(t *testing.T) {
+		escalator := &fakeEscalator{changes: []*models.ReviewerChange{change}, err: context.Canceled}
+		notifier := &fakeNotifier{}
+		job := NewEscalationJob(escalator, notifier, cfg, logger)
+
+		err := job.RunOnce(context.Background())
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Len(t, notifier.events, 1)
+	})
+}
@@ -0,0 +1,47 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// MetricsReader defines the interface for reading the counts behind the business metrics.
+type MetricsReader interface {
+	GetBusinessMetrics(ctx context.Context) (*models.BusinessMetrics, error)
+}
+
+// MetricsRecorder defines the interface of the business gauges.
+type MetricsRecorder interface {
+	Set(metrics *models.BusinessMetrics, refreshedAt time.Time)
+	MarkStale()
+}
+
+// MetricsJob refreshes the business gauges, so scrapes never query the database. The scheduler runs
+// it at start and then every interval on every replica, which refreshes the gauges it serves, so
+// no lock is taken.
+type MetricsJob struct {
+	reader   MetricsReader
+	recorder MetricsRecorder
+}
+
+// NewMetricsJob creates a new business metrics job.
+func NewMetricsJob(reader MetricsReader, recorder MetricsRecorder) *MetricsJob {
+	return &MetricsJob{
+		reader:   reader,
+		recorder: recorder,
+	}
+}
+
+// RunOnce reads the counts and sets the gauges; when the read fails the gauges keep their values and
+// are marked stale.
+func (j *MetricsJob) RunOnce(ctx context.Context) error {
+	metrics, err := j.reader.GetBusinessMetrics(ctx)
+	if err != nil {
+		j.recorder.MarkStale()
+		return err
+	}
+	j.recorder.Set(metrics, time.Now().UTC())
+	return nil
+}
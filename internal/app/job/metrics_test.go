@@ -0,0 +1,53 @@
+package job
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeMetricsReader struct {
+	metrics *models.BusinessMetrics
+	err     error
+}
+
+func (r *fakeMetricsReader) GetBusinessMetrics(ctx context.Context) (*models.BusinessMetrics, error) {
+	return r.metrics, r.err
+}
+
+type fakeMetricsRecorder struct {
+	set   *models.BusinessMetrics
+	stale int
+}
+
+func (r *fakeMetricsRecorder) Set(metrics *models.BusinessMetrics, refreshedAt time.Time) {
+	r.set = metrics
+}
+
+func (r *fakeMetricsRecorder) MarkStale() {
+	r.stale++
+}
+
+func TestMetricsJob_RunOnce(t *testing.T) {
+	t.Run("Success - Sets the gauges", func(t *testing.T) {
+		metrics := &models.BusinessMetrics{ActiveUsers: 3, ActiveAssignments: 2}
+		recorder := &fakeMetricsRecorder{}
+		job := NewMetricsJob(&fakeMetricsReader{metrics: metrics}, recorder)
+
+		assert.NoError(t, job.RunOnce(context.Background()))
+		assert.Equal(t, metrics, recorder.set)
+		assert.Zero(t, recorder.stale)
+	})
+
+	t.Run("Error - Read failure marks the gauges stale", func(t *testing.T) {
+		recorder := &fakeMetricsRecorder{}
+		job := NewMetricsJob(&fakeMetricsReader{err: context.Canceled}, recorder)
+
+		assert.ErrorIs(t, job.RunOnce(context.Background()), context.Canceled)
+		assert.Nil(t, recorder.set)
+		assert.Equal(t, 1, recorder.stale)
+	})
+}
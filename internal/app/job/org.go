@@ -0,0 +1,37 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+)
+
+// OrgLister defines the interface for listing the organizations holding data.
+type OrgLister interface {
+	ListOrgs(ctx context.Context) ([]string, error)
+}
+
+// ForEachOrg makes run, which works on the organization of its context, run for every organization
+// in turn. An organization failing doesn't stop the others; the errors are returned joined, each
+// naming its organization.
+func ForEachOrg(orgs OrgLister, run func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		ids, err := orgs.ListOrgs(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list organizations: %w", err)
+		}
+		var errs []error
+		for _, id := range ids {
+			if ctx.Err() != nil {
+				errs = append(errs, ctx.Err())
+				break
+			}
+			if err := run(orgctx.WithID(ctx, id)); err != nil {
+				errs = append(errs, fmt.Errorf("org %s: %w", id, err))
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
@@ -0,0 +1,63 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeOrgLister struct {
+	orgs []string
+	err  error
+}
+
+func (l *fakeOrgLister) ListOrgs(ctx context.Context) ([]string, error) {
+	return l.orgs, l.err
+}
+
+func TestForEachOrg(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - Runs once in every organization", func(t *testing.T) {
+		var seen []string
+		run := ForEachOrg(&fakeOrgLister{orgs: []string{"default", "payments"}}, func(ctx context.Context) error {
+			seen = append(seen, orgctx.ID(ctx))
+			return nil
+		})
+
+		assert.NoError(t, run(ctx))
+		assert.Equal(t, []string{"default", "payments"}, seen)
+	})
+
+	t.Run("Error - Failing organization doesn't stop the others", func(t *testing.T) {
+		boom := errors.New("boom")
+		var seen []string
+		run := ForEachOrg(&fakeOrgLister{orgs: []string{"default", "payments"}}, func(ctx context.Context) error {
+			seen = append(seen, orgctx.ID(ctx))
+			if orgctx.ID(ctx) == "default" {
+				return boom
+			}
+			return nil
+		})
+
+		err := run(ctx)
+
+		assert.ErrorIs(t, err, boom)
+		assert.ErrorContains(t, err, "org default: boom")
+		assert.Equal(t, []string{"default", "payments"}, seen)
+	})
+
+	t.Run("Error - Organizations can't be listed", func(t *testing.T) {
+		called := false
+		run := ForEachOrg(&fakeOrgLister{err: context.Canceled}, func(ctx context.Context) error {
+			called = true
+			return nil
+		})
+
+		assert.ErrorIs(t, run(ctx), context.Canceled)
+		assert.False(t, called)
+	})
+}
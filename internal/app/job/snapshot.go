@@ -0,0 +1,45 @@
+package job
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Snapshotter defines the interface for taking the daily statistics snapshot.
+type Snapshotter interface {
+	TakeDailySnapshot(ctx context.Context) (bool, error)
+}
+
+// SnapshotLockKey is the advisory lock key held by the replica taking the statistics snapshot.
+const SnapshotLockKey int64 = 0x70727276_00000003
+
+// SnapshotJob takes the statistics snapshot of the day on its first run of the day; the later runs
+// find it taken and do nothing. The scheduler runs it every interval under SnapshotLockKey, so the
+// snapshot is taken once.
+type SnapshotJob struct {
+	snapshotter Snapshotter
+	log         *slog.Logger
+}
+
+// NewSnapshotJob creates a new statistics snapshot job.
+func NewSnapshotJob(snapshotter Snapshotter, log *slog.Logger) *SnapshotJob {
+	if log == nil {
+		log = slog.Default()
+	}
+	return &SnapshotJob{
+		snapshotter: snapshotter,
+		log:         log,
+	}
+}
+
+// RunOnce takes the snapshot of the day unless it was taken.
+func (j *SnapshotJob) RunOnce(ctx context.Context) error {
+	taken, err := j.snapshotter.TakeDailySnapshot(ctx)
+	if err != nil {
+		return err
+	}
+	if !taken {
+		j.log.LogAttrs(ctx, slog.LevelDebug, "statistics snapshot of the day already taken")
+	}
+	return nil
+}
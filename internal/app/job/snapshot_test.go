@@ -0,0 +1,48 @@
+package job
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSnapshotter struct {
+	calls int
+	taken bool
+	err   error
+}
+
+func (s *fakeSnapshotter) TakeDailySnapshot(ctx context.Context) (bool, error) {
+	s.calls++
+	return s.taken, s.err
+}
+
+func TestSnapshotJob_RunOnce(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	t.Run("Success - Takes the snapshot", func(t *testing.T) {
+		snapshotter := &fakeSnapshotter{taken: true}
+		job := NewSnapshotJob(snapshotter, logger)
+
+		assert.NoError(t, job.RunOnce(context.Background()))
+		assert.Equal(t, 1, snapshotter.calls)
+	})
+
+	t.Run("Success - Snapshot taken earlier in the day", func(t *testing.T) {
+		snapshotter := &fakeSnapshotter{taken: false}
+		job := NewSnapshotJob(snapshotter, logger)
+
+		assert.NoError(t, job.RunOnce(context.Background()))
+		assert.Equal(t, 1, snapshotter.calls)
+	})
+
+	t.Run("Error - Snapshot failure fails the run", func(t *testing.T) {
+		snapshotter := &fakeSnapshotter{err: context.Canceled}
+		job := NewSnapshotJob(snapshotter, logger)
+
+		assert.ErrorIs(t, job.RunOnce(context.Background()), context.Canceled)
+	})
+}
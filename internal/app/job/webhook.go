@@ -0,0 +1,72 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+)
+
+// Deliverer defines the interface for posting the webhook deliveries that are due.
+type Deliverer interface {
+	DeliverDue(ctx context.Context, limit int) (delivered, failed int, err error)
+	Stats(ctx context.Context) (*admin.WebhookStatsResponse, error)
+}
+
+// statsTimeout bounds the count of the deliveries a flush leaves pending, made once its context is done.
+const statsTimeout = time.Second
+
+// WebhookLockKey is the advisory lock key held by the replica delivering webhooks.
+const WebhookLockKey int64 = 0x70727276_00000002
+
+// WebhookJob posts the queued webhook deliveries that are due, including retries of failed ones.
+// The scheduler runs it every interval under WebhookLockKey, so a delivery is never posted twice at once.
+type WebhookJob struct {
+	deliverer Deliverer
+	cfg       config.Webhook
+}
+
+// NewWebhookJob creates a new webhook delivery job.
+func NewWebhookJob(deliverer Deliverer, cfg config.Webhook) *WebhookJob {
+	return &WebhookJob{
+		deliverer: deliverer,
+		cfg:       cfg,
+	}
+}
+
+// RunOnce posts a batch of the due deliveries.
+func (j *WebhookJob) RunOnce(ctx context.Context) error {
+	_, _, err := j.deliverer.DeliverDue(ctx, j.cfg.BatchSize)
+	return err
+}
+
+// Flush posts the due deliveries batch after batch until none is left, so the events queued before
+// shutdown go out before the service stops. Deliveries failing now are retried after the next
+// start. Returns an error counting the pending deliveries when ctx is done first; they stay queued.
+func (j *WebhookJob) Flush(ctx context.Context) error {
+	for {
+		delivered, failed, err := j.deliverer.DeliverDue(ctx, j.cfg.BatchSize)
+		if err != nil {
+			return j.abandoned(ctx, err)
+		}
+		if delivered+failed == 0 {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return j.abandoned(ctx, ctx.Err())
+		}
+	}
+}
+
+// abandoned wraps the error that stopped a flush with the number of deliveries left pending.
+func (j *WebhookJob) abandoned(ctx context.Context, err error) error {
+	statsCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), statsTimeout)
+	defer cancel()
+	stats, statsErr := j.deliverer.Stats(statsCtx)
+	if statsErr != nil {
+		return fmt.Errorf("deliveries left pending: %w", err)
+	}
+	return fmt.Errorf("%d deliveries left pending: %w", stats.Pending, err)
+}
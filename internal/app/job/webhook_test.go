@@ -0,0 +1,82 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeDeliverer struct {
+	calls int
+	limit int
+	err   error
+	// due is the number of deliveries due; each call delivers up to limit of them
+	due int
+}
+
+func (d *fakeDeliverer) DeliverDue(ctx context.Context, limit int) (int, int, error) {
+	d.calls++
+	d.limit = limit
+	if d.err != nil {
+		return 0, 0, d.err
+	}
+	delivered := min(d.due, limit)
+	d.due -= delivered
+	return delivered, 0, nil
+}
+
+func (d *fakeDeliverer) Stats(ctx context.Context) (*admin.WebhookStatsResponse, error) {
+	return &admin.WebhookStatsResponse{Pending: d.due}, nil
+}
+
+func TestWebhookJob_RunOnce(t *testing.T) {
+	cfg := config.Webhook{Interval: time.Second, BatchSize: 20, MaxAttempts: 3}
+
+	t.Run("Success - Delivers a batch", func(t *testing.T) {
+		deliverer := &fakeDeliverer{}
+
+		err := NewWebhookJob(deliverer, cfg).RunOnce(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, deliverer.calls)
+		assert.Equal(t, 20, deliverer.limit)
+	})
+
+	t.Run("Error - Delivery failure fails the run", func(t *testing.T) {
+		deliverer := &fakeDeliverer{err: fmt.Errorf("database is down")}
+
+		err := NewWebhookJob(deliverer, cfg).RunOnce(context.Background())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestWebhookJob_Flush(t *testing.T) {
+	cfg := config.Webhook{Interval: time.Second, BatchSize: 20, MaxAttempts: 3}
+
+	t.Run("Success - Delivers batches until none is due", func(t *testing.T) {
+		deliverer := &fakeDeliverer{due: 45}
+
+		err := NewWebhookJob(deliverer, cfg).Flush(context.Background())
+
+		assert.NoError(t, err)
+		assert.Zero(t, deliverer.due)
+		assert.Equal(t, 4, deliverer.calls)
+	})
+
+	t.Run("Error - Shutdown deadline leaves deliveries pending", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		deliverer := &fakeDeliverer{due: 5, err: context.Canceled}
+
+		err := NewWebhookJob(deliverer, cfg).Flush(ctx)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorContains(t, err, "5 deliveries left pending")
+	})
+}
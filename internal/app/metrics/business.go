@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// Label values of the team label for PRs whose author is not a known user and for the teams beyond
+// the label limit.
+const (
+	TeamUnknown = "unknown"
+	TeamOther   = "other"
+)
+
+// Business holds the gauges of the business metrics, refreshed from the database by the metrics job
+// rather than on every scrape. The open PR gauges are labelled by the team of the author; when there
+// are more teams than maxTeamLabels, the teams with the most open PRs keep their label and the
+// rest are summed under "other", so the number of series stays bounded.
+type Business struct {
+	openPRs           *prometheus.GaugeVec
+	withoutReviewers  *prometheus.GaugeVec
+	activeUsers       prometheus.Gauge
+	activeAssignments prometheus.Gauge
+	stale             prometheus.Gauge
+	lastRefresh       prometheus.Gauge
+	maxTeamLabels     int
+}
+
+func newBusiness(registerer prometheus.Registerer, maxTeamLabels int) *Business {
+	b := &Business{
+		openPRs: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "pr_open_total",
+			Help: "Open PRs by the team of their author.",
+		}, []string{"team"}),
+		withoutReviewers: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "pr_without_reviewers_total",
+			Help: "Open PRs nobody is assigned to, by the team of their author.",
+		}, []string{"team"}),
+		activeUsers: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "users_active_total",
+			Help: "Active users.",
+		}),
+		activeAssignments: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "assignments_active_total",
+			Help: "Reviewer assignments on open PRs.",
+		}),
+		stale: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "business_metrics_stale",
+			Help: "1 while the business metrics keep earlier values as the last refresh failed or none succeeded yet.",
+		}),
+		lastRefresh: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "business_metrics_last_refresh_timestamp_seconds",
+			Help: "Unix time of the last successful refresh of the business metrics.",
+		}),
+		maxTeamLabels: maxTeamLabels,
+	}
+	registerer.MustRegister(b.openPRs, b.withoutReviewers, b.activeUsers, b.activeAssignments, b.stale, b.lastRefresh)
+	// nothing has been read yet
+	b.stale.Set(1)
+	return b
+}
+
+// Set replaces the gauges with the counts read at refreshedAt and clears the staleness.
+func (b *Business) Set(metrics *models.BusinessMetrics, refreshedAt time.Time) {
+	b.openPRs.Reset()
+	b.withoutReviewers.Reset()
+	for _, counts := range b.labelTeams(metrics.Teams) {
+		b.openPRs.WithLabelValues(counts.TeamName).Set(float64(counts.OpenPRs))
+		b.withoutReviewers.WithLabelValues(counts.TeamName).Set(float64(counts.WithoutReviewers))
+	}
+	b.activeUsers.Set(float64(metrics.ActiveUsers))
+	b.activeAssignments.Set(float64(metrics.ActiveAssignments))
+	b.lastRefresh.Set(float64(refreshedAt.Unix()))
+	b.stale.Set(0)
+}
+
+// MarkStale flags the gauges as stale after a failed refresh; they keep their values.
+func (b *Business) MarkStale() {
+	b.stale.Set(1)
+}
+
+// labelTeams names the teams by their label values, folding the teams beyond the limit into "other".
+func (b *Business) labelTeams(teams []models.TeamPRCounts) []models.TeamPRCounts {
+	labelled := make([]models.TeamPRCounts, len(teams))
+	copy(labelled, teams)
+	for i := range labelled {
+		if labelled[i].TeamName == "" {
+			labelled[i].TeamName = TeamUnknown
+		}
+	}
+	if b.maxTeamLabels <= 0 || len(labelled) <= b.maxTeamLabels {
+		return labelled
+	}
+
+	sort.SliceStable(labelled, func(i, j int) bool { return labelled[i].OpenPRs > labelled[j].OpenPRs })
+	other := models.TeamPRCounts{TeamName: TeamOther}
+	for _, counts := range labelled[b.maxTeamLabels:] {
+		other.OpenPRs += counts.OpenPRs
+		other.WithoutReviewers += counts.WithoutReviewers
+	}
+	return append(labelled[:b.maxTeamLabels], other)
+}
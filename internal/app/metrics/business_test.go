@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBusiness_Set(t *testing.T) {
+	refreshedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Success - Gauges are stale until the first refresh", func(t *testing.T) {
+		m := New(10)
+
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.Business.stale))
+	})
+
+	t.Run("Success - Sets the gauges by team", func(t *testing.T) {
+		m := New(10)
+
+		m.Business.Set(&models.BusinessMetrics{
+			Teams: []models.TeamPRCounts{
+				{TeamName: "", OpenPRs: 1, WithoutReviewers: 1},
+				{TeamName: "backend", OpenPRs: 3, WithoutReviewers: 1},
+			},
+			ActiveUsers:       5,
+			ActiveAssignments: 4,
+		}, refreshedAt)
+
+		assert.Equal(t, 3.0, testutil.ToFloat64(m.Business.openPRs.WithLabelValues("backend")))
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.Business.withoutReviewers.WithLabelValues(TeamUnknown)))
+		assert.Equal(t, 5.0, testutil.ToFloat64(m.Business.activeUsers))
+		assert.Equal(t, 4.0, testutil.ToFloat64(m.Business.activeAssignments))
+		assert.Equal(t, float64(refreshedAt.Unix()), testutil.ToFloat64(m.Business.lastRefresh))
+		assert.Zero(t, testutil.ToFloat64(m.Business.stale))
+	})
+
+	t.Run("Success - Teams beyond the limit are summed under other", func(t *testing.T) {
+		m := New(2)
+
+		m.Business.Set(&models.BusinessMetrics{Teams: []models.TeamPRCounts{
+			{TeamName: "a", OpenPRs: 1},
+			{TeamName: "b", OpenPRs: 5, WithoutReviewers: 2},
+			{TeamName: "c", OpenPRs: 2, WithoutReviewers: 1},
+			{TeamName: "d", OpenPRs: 1, WithoutReviewers: 1},
+		}}, refreshedAt)
+
+		assert.Equal(t, 3, testutil.CollectAndCount(m.Business.openPRs))
+		assert.Equal(t, 5.0, testutil.ToFloat64(m.Business.openPRs.WithLabelValues("b")))
+		assert.Equal(t, 2.0, testutil.ToFloat64(m.Business.openPRs.WithLabelValues("c")))
+		assert.Equal(t, 2.0, testutil.ToFloat64(m.Business.openPRs.WithLabelValues(TeamOther)))
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.Business.withoutReviewers.WithLabelValues(TeamOther)))
+	})
+
+	t.Run("Success - Teams without open PRs are dropped on refresh", func(t *testing.T) {
+		m := New(10)
+		m.Business.Set(&models.BusinessMetrics{Teams: []models.TeamPRCounts{{TeamName: "a", OpenPRs: 1}}}, refreshedAt)
+
+		m.Business.Set(&models.BusinessMetrics{Teams: []models.TeamPRCounts{{TeamName: "b", OpenPRs: 1}}}, refreshedAt)
+
+		assert.Equal(t, 1, testutil.CollectAndCount(m.Business.openPRs))
+	})
+
+	t.Run("Success - Failed refresh keeps the values and marks them stale", func(t *testing.T) {
+		m := New(10)
+		m.Business.Set(&models.BusinessMetrics{ActiveUsers: 2}, refreshedAt)
+
+		m.Business.MarkStale()
+
+		assert.Equal(t, 2.0, testutil.ToFloat64(m.Business.activeUsers))
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.Business.stale))
+		assert.NoError(t, testutil.GatherAndCompare(m.registry, strings.NewReader(`
+# HELP users_active_total Active users.
+# TYPE users_active_total gauge
+users_active_total 2
+`), "users_active_total"))
+	})
+}
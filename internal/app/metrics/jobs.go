@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Jobs holds the metrics of the scheduled jobs, labelled by job name. Runs skipped because another
+// replica holds the lock of the job are not counted.
+type Jobs struct {
+	runs     *prometheus.CounterVec
+	failures *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+func newJobs(registerer prometheus.Registerer) *Jobs {
+	j := &Jobs{
+		runs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "job_runs_total",
+			Help: "Runs of the scheduled jobs, failed ones included.",
+		}, []string{"job"}),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "job_failures_total",
+			Help: "Runs of the scheduled jobs that failed or panicked.",
+		}, []string{"job"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "job_run_duration_seconds",
+			Help:    "Duration of the runs of the scheduled jobs.",
+			Buckets: prometheus.ExponentialBuckets(0.01, 4, 8),
+		}, []string{"job"}),
+	}
+	registerer.MustRegister(j.runs, j.failures, j.duration)
+	return j
+}
+
+// ObserveRun records a run of the job that took duration.
+func (j *Jobs) ObserveRun(job string, duration time.Duration, failed bool) {
+	j.runs.WithLabelValues(job).Inc()
+	if failed {
+		j.failures.WithLabelValues(job).Inc()
+	}
+	j.duration.WithLabelValues(job).Observe(duration.Seconds())
+}
@@ -0,0 +1,23 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobs_ObserveRun(t *testing.T) {
+	m := New(10)
+
+	m.Jobs.ObserveRun("escalation", 2*time.Second, false)
+	m.Jobs.ObserveRun("escalation", time.Second, true)
+	m.Jobs.ObserveRun("webhook", time.Millisecond, false)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.Jobs.runs.WithLabelValues("escalation")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.Jobs.failures.WithLabelValues("escalation")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.Jobs.runs.WithLabelValues("webhook")))
+	assert.Zero(t, testutil.ToFloat64(m.Jobs.failures.WithLabelValues("webhook")))
+	assert.Equal(t, 2, testutil.CollectAndCount(m.Jobs.duration))
+}
@@ -0,0 +1,28 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Leader holds the metric of the leader election.
+type Leader struct {
+	leader prometheus.Gauge
+}
+
+func newLeader(registerer prometheus.Registerer) *Leader {
+	l := &Leader{
+		leader: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "leader_election_is_leader",
+			Help: "1 while this replica holds the leader lease and runs the exclusive jobs, 0 otherwise.",
+		}),
+	}
+	registerer.MustRegister(l.leader)
+	return l
+}
+
+// SetLeader records whether this replica leads.
+func (l *Leader) SetLeader(leader bool) {
+	if leader {
+		l.leader.Set(1)
+		return
+	}
+	l.leader.Set(0)
+}
@@ -0,0 +1,19 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeader_SetLeader(t *testing.T) {
+	m := New(10)
+	assert.Zero(t, testutil.ToFloat64(m.Leader.leader))
+
+	m.Leader.SetLeader(true)
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.Leader.leader))
+
+	m.Leader.SetLeader(false)
+	assert.Zero(t, testutil.ToFloat64(m.Leader.leader))
+}
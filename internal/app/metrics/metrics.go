@@ -0,0 +1,46 @@
+// Package metrics holds the Prometheus metrics of the service, served at /metrics.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics is the registry of the service metrics: the Go runtime and process collectors, the
+// business gauges, the metrics of the scheduled jobs and that of the leader election.
+type Metrics struct {
+	registry *prometheus.Registry
+	Business *Business
+	Jobs     *Jobs
+	Leader   *Leader
+}
+
+// New creates the registry with the business gauges registered. PR gauges are labelled by at most
+// maxTeamLabels teams, see Business.
+func New(maxTeamLabels int) *Metrics {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return &Metrics{
+		registry: registry,
+		Business: newBusiness(registry, maxTeamLabels),
+		Jobs:     newJobs(registry),
+		Leader:   newLeader(registry),
+	}
+}
+
+// Registerer registers further collectors to be served with the others.
+func (m *Metrics) Registerer() prometheus.Registerer {
+	return m.registry
+}
+
+// Handler serves the metrics in the Prometheus text format. Responses are left uncompressed, as
+// the router compresses them.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{DisableCompression: true})
+}
@@ -0,0 +1,39 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Reasons of the requests_shed_total counter: refused by the load shedder before reaching the
+// database, or failed after waiting the acquire timeout for a connection.
+const (
+	ShedSaturated      = "saturated"
+	ShedAcquireTimeout = "acquire_timeout"
+)
+
+// OverloadSource reports how saturated the database connections are.
+type OverloadSource interface {
+	// WaitingAcquires returns the number of requests waiting for a connection.
+	WaitingAcquires() int
+	// AcquireTimeouts returns the number of requests that got no connection within the acquire timeout.
+	AcquireTimeouts() int64
+}
+
+// RegisterOverload registers the overload metrics, read from source and from shed, which returns
+// the number of requests the load shedder refused.
+func (m *Metrics) RegisterOverload(source OverloadSource, shed func() int64) {
+	m.registry.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "db_pool_waiting_acquires",
+			Help: "Requests waiting for a database connection.",
+		}, func() float64 { return float64(source.WaitingAcquires()) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name:        "requests_shed_total",
+			Help:        "Requests refused as SERVICE_OVERLOADED, by reason.",
+			ConstLabels: prometheus.Labels{"reason": ShedSaturated},
+		}, func() float64 { return float64(shed()) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name:        "requests_shed_total",
+			Help:        "Requests refused as SERVICE_OVERLOADED, by reason.",
+			ConstLabels: prometheus.Labels{"reason": ShedAcquireTimeout},
+		}, func() float64 { return float64(source.AcquireTimeouts()) }),
+	)
+}
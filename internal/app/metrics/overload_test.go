@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeOverloadSource struct {
+	waiting  int
+	timeouts int64
+}
+
+func (f fakeOverloadSource) WaitingAcquires() int   { return f.waiting }
+func (f fakeOverloadSource) AcquireTimeouts() int64 { return f.timeouts }
+
+func TestMetrics_RegisterOverload(t *testing.T) {
+	m := New(0)
+	m.RegisterOverload(fakeOverloadSource{waiting: 4, timeouts: 2}, func() int64 { return 7 })
+
+	err := testutil.GatherAndCompare(m.registry, strings.NewReader(`
+# HELP db_pool_waiting_acquires Requests waiting for a database connection.
+# TYPE db_pool_waiting_acquires gauge
+db_pool_waiting_acquires 4
+# HELP requests_shed_total Requests refused as SERVICE_OVERLOADED, by reason.
+# TYPE requests_shed_total counter
+requests_shed_total{reason="acquire_timeout"} 2
+requests_shed_total{reason="saturated"} 7
+`), "db_pool_waiting_acquires", "requests_shed_total")
+	assert.NoError(t, err)
+}
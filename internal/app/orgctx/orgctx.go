@@ -0,0 +1,28 @@
+// Package orgctx marks contexts with the organization they act in, which scopes everything the
+// storage reads and writes for them.
+package orgctx
+
+import "context"
+
+// DefaultID is the organization of contexts that name none, so that a single-tenant installation
+// keeps all its data in one organization without sending any.
+const DefaultID = "default"
+
+// orgKey is a key for storing the organization in context.
+type orgKey struct{}
+
+// WithID marks ctx as acting in the organization. An empty id marks it with the default one.
+func WithID(ctx context.Context, orgID string) context.Context {
+	if orgID == "" {
+		orgID = DefaultID
+	}
+	return context.WithValue(ctx, orgKey{}, orgID)
+}
+
+// ID returns the organization ctx acts in, DefaultID when it names none.
+func ID(ctx context.Context) string {
+	if orgID, ok := ctx.Value(orgKey{}).(string); ok {
+		return orgID
+	}
+	return DefaultID
+}
@@ -0,0 +1,24 @@
+package orgctx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestID(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - Context without an organization is in the default one", func(t *testing.T) {
+		assert.Equal(t, DefaultID, ID(ctx))
+	})
+
+	t.Run("Success - Marked organization is returned", func(t *testing.T) {
+		assert.Equal(t, "payments", ID(WithID(ctx, "payments")))
+	})
+
+	t.Run("Success - Empty organization is the default one", func(t *testing.T) {
+		assert.Equal(t, DefaultID, ID(WithID(WithID(ctx, "payments"), "")))
+	})
+}
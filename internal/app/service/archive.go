@@ -0,0 +1,149 @@
+package service
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mocks/mock_archive_deps.go -package=mocks
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// defaultArchiveBatchSize is used when the configured batch size is not positive.
+const defaultArchiveBatchSize = 500
+
+// ArchiveRepository defines the interface for moving merged PRs to and from the archive.
+type ArchiveRepository interface {
+	ArchiveMerged(ctx context.Context, mergedBefore time.Time, limit int) ([]string, error)
+	Restore(ctx context.Context, prID string) (bool, error)
+}
+
+// ArchivePRRepository defines the interface for PR lookups needed by ArchiveService.
+type ArchivePRRepository interface {
+	FindByID(ctx context.Context, prID string) (*models.PullRequest, error)
+}
+
+// ArchiveReviewerRepository defines the interface for reviewer lookups needed by ArchiveService.
+type ArchiveReviewerRepository interface {
+	GetReviewers(ctx context.Context, prID string) ([]string, error)
+}
+
+// ArchiveService implements archival of old merged PRs.
+type ArchiveService struct {
+	archiveRepo  ArchiveRepository
+	prRepo       ArchivePRRepository
+	reviewerRepo ArchiveReviewerRepository
+	uow          Transactor
+	cfg          config.Archive
+	log          *slog.Logger
+}
+
+// NewArchiveService creates a new archive service.
+func NewArchiveService(
+	archiveRepo ArchiveRepository,
+	prRepo ArchivePRRepository,
+	reviewerRepo ArchiveReviewerRepository,
+	uow Transactor,
+	cfg config.Archive,
+	log *slog.Logger,
+) *ArchiveService {
+	if log == nil {
+		log = slog.Default()
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultArchiveBatchSize
+	}
+	return &ArchiveService{
+		archiveRepo:  archiveRepo,
+		prRepo:       prRepo,
+		reviewerRepo: reviewerRepo,
+		uow:          uow,
+		cfg:          cfg,
+		log:          log,
+	}
+}
+
+// Archive moves PRs merged before the requested moment, or longer ago than the retention, into the
+// archive. Each batch is moved in its own transaction, so an error keeps the batches already moved.
+func (s *ArchiveService) Archive(ctx context.Context, req admin.ArchiveRequest) (*admin.ArchiveResponse, error) {
+	before := time.Now().UTC().Add(-s.cfg.Retention)
+	if req.Before != nil {
+		before = req.Before.UTC()
+	}
+
+	archived := 0
+	for {
+		var prIDs []string
+		err := s.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
+			var err error
+			prIDs, err = s.archiveRepo.ArchiveMerged(txCtx, before, s.cfg.BatchSize)
+			return err
+		})
+		if err != nil {
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to archive merged PRs",
+				slog.Time("before", before),
+				slog.Int("archived", archived),
+				slog.String("error", err.Error()))
+			return nil, err
+		}
+		archived += len(prIDs)
+		if len(prIDs) < s.cfg.BatchSize {
+			break
+		}
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "merged PRs archived",
+		slog.Time("before", before),
+		slog.Int("archived", archived))
+
+	return &admin.ArchiveResponse{Archived: archived, Before: dto.FormatTime(before)}, nil
+}
+
+// Restore moves an archived PR with its reviewers back, e.g. for an audit.
+func (s *ArchiveService) Restore(ctx context.Context, req admin.RestoreArchivedRequest) (*admin.RestoreArchivedResponse, error) {
+	var response admin.RestoreArchivedResponse
+	err := s.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
+		restored, err := s.archiveRepo.Restore(txCtx, req.PullRequestID)
+		if err != nil {
+			return err
+		}
+		if !restored {
+			return errors.NewNotFound("archived PR not found")
+		}
+
+		pr, err := s.prRepo.FindByID(txCtx, req.PullRequestID)
+		if err != nil {
+			return err
+		}
+		if pr == nil {
+			return errors.NewNotFound("archived PR not found")
+		}
+		reviewers, err := s.reviewerRepo.GetReviewers(txCtx, req.PullRequestID)
+		if err != nil {
+			return err
+		}
+		response.Pr = newPRDto(pr, reviewers)
+		return nil
+	})
+	if err != nil {
+		level := errorLevel(err)
+		if errors.HasCode(err, errors.CodeNotFound) || errors.HasCode(err, errors.CodePRExists) {
+			level = slog.LevelWarn
+		}
+		s.log.LogAttrs(ctx, level, "failed to restore archived PR",
+			slog.String("pr_id", req.PullRequestID), slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "archived PR restored", slog.String("pr_id", req.PullRequestID))
+
+	return &response, nil
+}
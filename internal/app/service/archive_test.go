@@ -0,0 +1,173 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/statistics"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// archiveEnv wires the archive and statistics services to in-memory storage with a team of u1-u3.
+type archiveEnv struct {
+	*memoryEnv
+	archive    *ArchiveService
+	statistics *StatisticsService
+}
+
+func newArchiveEnv(t *testing.T, batchSize int) *archiveEnv {
+	t.Helper()
+	env := &archiveEnv{memoryEnv: newMemoryEnv()}
+	env.archive = NewArchiveService(env.storage.NewArchiveRepository(), env.prRepo, env.reviewerRepo, env.uow,
+		config.Archive{Retention: 30 * 24 * time.Hour, BatchSize: batchSize}, env.logger)
+	env.statistics = env.statisticsService(config.Statistics{DisableSingleflight: true})
+	env.team(t, "backend", "u1", "u2", "u3")
+	return env
+}
+
+// pr creates a PR by u1 reviewed by u2, merged at the given time unless it is zero.
+func (e *archiveEnv) pr(t *testing.T, prID string, mergedAt time.Time) {
+	t.Helper()
+	e.memoryEnv.pr(t, prID, "u1", time.Now().UTC(), "u2")
+	if !mergedAt.IsZero() {
+		_, err := e.prRepo.UpdateStatus(e.ctx, prID, models.PRStatusOpen, models.PRStatusMerged, &mergedAt)
+		require.NoError(t, err)
+	}
+}
+
+func TestArchiveService_Archive(t *testing.T) {
+	old := time.Now().UTC().Add(-60 * 24 * time.Hour)
+	recent := time.Now().UTC().Add(-time.Hour)
+
+	t.Run("Success - Old merged PRs are archived in batches", func(t *testing.T) {
+		env := newArchiveEnv(t, 1)
+		env.pr(t, "pr-1", old)
+		env.pr(t, "pr-2", old.Add(time.Minute))
+		env.pr(t, "pr-3", recent)
+		env.pr(t, "pr-4", time.Time{})
+
+		resp, err := env.archive.Archive(env.ctx, admin.ArchiveRequest{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, resp.Archived)
+		for prID, archived := range map[string]bool{"pr-1": true, "pr-2": true, "pr-3": false, "pr-4": false} {
+			pr, err := env.prRepo.FindByID(env.ctx, prID)
+			assert.NoError(t, err)
+			assert.Equal(t, archived, pr == nil, prID)
+		}
+		archived, err := env.reviewerRepo.GetArchivedReviewers(env.ctx, "")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string][]string{"pr-1": {"u2"}, "pr-2": {"u2"}}, archived)
+	})
+
+	t.Run("Success - Explicit cutoff", func(t *testing.T) {
+		env := newArchiveEnv(t, 10)
+		env.pr(t, "pr-1", old)
+		env.pr(t, "pr-2", recent)
+		before := time.Now().UTC()
+
+		resp, err := env.archive.Archive(env.ctx, admin.ArchiveRequest{Before: &before})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, resp.Archived)
+	})
+
+	t.Run("Success - Statistics include archived PRs on request", func(t *testing.T) {
+		env := newArchiveEnv(t, 10)
+		env.pr(t, "pr-1", old)
+		env.pr(t, "pr-2", time.Time{})
+		_, err := env.archive.Archive(env.ctx, admin.ArchiveRequest{})
+		assert.NoError(t, err)
+
+		current, err := env.statistics.GetStatistics(env.ctx, statistics.StatisticsRequest{})
+		assert.NoError(t, err)
+		all, err := env.statistics.GetStatistics(env.ctx, statistics.StatisticsRequest{IncludeArchived: true})
+		assert.NoError(t, err)
+
+		assert.Equal(t, 1, current.TotalPRs)
+		assert.Equal(t, 0, current.MergedPRs)
+		assert.Equal(t, 1, current.TotalAssignments)
+		assert.Equal(t, 2, all.TotalPRs)
+		assert.Equal(t, 1, all.MergedPRs)
+		assert.Equal(t, 2, all.TotalAssignments)
+	})
+
+	t.Run("Success - Reassignments of archived PRs are counted on request", func(t *testing.T) {
+		env := newArchiveEnv(t, 10)
+		env.pr(t, "pr-1", old)
+		assert.NoError(t, env.reviewerRepo.RecordReviewerChange(env.ctx, &models.ReviewerChange{
+			PRId: "pr-1", OldReviewerId: "u2", NewReviewerId: "u3", Trigger: models.ReviewerChangeManual,
+			ChangedAt: old}))
+		_, err := env.archive.Archive(env.ctx, admin.ArchiveRequest{})
+		assert.NoError(t, err)
+
+		req := statistics.StatisticsRequest{
+			Include: statistics.Include{PRStats: true},
+			PRs:     dto.PageRequest{Limit: 10},
+		}
+		current, err := env.statistics.GetStatistics(env.ctx, req)
+		assert.NoError(t, err)
+		req.IncludeArchived = true
+		all, err := env.statistics.GetStatistics(env.ctx, req)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 0, current.ReassignmentEvents)
+		assert.Empty(t, current.PRStats.Items)
+		assert.Equal(t, 1, all.ReassignmentEvents)
+		if assert.Len(t, all.PRStats.Items, 1) {
+			assert.Equal(t, 1, all.PRStats.Items[0].ReassignmentsCount)
+		}
+	})
+}
+
+func TestArchiveService_Restore(t *testing.T) {
+	old := time.Now().UTC().Add(-60 * 24 * time.Hour)
+
+	t.Run("Success - PR restored with its reviewers", func(t *testing.T) {
+		env := newArchiveEnv(t, 10)
+		env.pr(t, "pr-1", old)
+		_, err := env.archive.Archive(env.ctx, admin.ArchiveRequest{})
+		assert.NoError(t, err)
+
+		resp, err := env.archive.Restore(env.ctx, admin.RestoreArchivedRequest{PullRequestID: "pr-1"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.PRStatusMerged, resp.Pr.Status)
+		assert.Equal(t, []string{"u2"}, resp.Pr.AssignedReviewers)
+		archived, err := env.prRepo.GetArchivedPRs(env.ctx, "")
+		assert.NoError(t, err)
+		assert.Empty(t, archived)
+	})
+
+	t.Run("Error - PR not archived", func(t *testing.T) {
+		env := newArchiveEnv(t, 10)
+		env.pr(t, "pr-1", time.Time{})
+
+		resp, err := env.archive.Restore(env.ctx, admin.RestoreArchivedRequest{PullRequestID: "pr-1"})
+
+		assert.Nil(t, resp)
+		assert.True(t, errors.HasCode(err, errors.CodeNotFound))
+	})
+
+	t.Run("Error - PR id taken since archival", func(t *testing.T) {
+		env := newArchiveEnv(t, 10)
+		env.pr(t, "pr-1", old)
+		_, err := env.archive.Archive(env.ctx, admin.ArchiveRequest{})
+		assert.NoError(t, err)
+		env.pr(t, "pr-1", time.Time{})
+
+		resp, err := env.archive.Restore(env.ctx, admin.RestoreArchivedRequest{PullRequestID: "pr-1"})
+
+		assert.Nil(t, resp)
+		assert.True(t, errors.HasCode(err, errors.CodePRExists))
+		archived, err := env.prRepo.GetArchivedPRs(env.ctx, "")
+		assert.NoError(t, err)
+		assert.Len(t, archived, 1)
+	})
+}
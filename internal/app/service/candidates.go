@@ -0,0 +1,73 @@
+package service
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mocks/mock_candidates_deps.go -package=mocks
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"sort"
+
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// CandidateRepository defines the interface for finding and locking replacement reviewers.
+type CandidateRepository interface {
+	FindActiveCandidatesForReassignment(ctx context.Context, teamName string, excludeUserIDs []string) ([]*models.User, error)
+	LockActiveCandidate(ctx context.Context, userID string) (bool, error)
+}
+
+// ExclusionLookup defines the interface for reading reviewer exclusions.
+type ExclusionLookup interface {
+	GetExcludedReviewers(ctx context.Context, authorID string) ([]string, error)
+}
+
+// withExclusions extends excludeUserIDs with the users who must not review PRs of the author.
+func withExclusions(ctx context.Context, repo ExclusionLookup, authorID string,
+	excludeUserIDs []string) ([]string, error) {
+	excluded, err := repo.GetExcludedReviewers(ctx, authorID)
+	if err != nil {
+		return nil, err
+	}
+	return append(excludeUserIDs, excluded...), nil
+}
+
+// lockFirstActiveCandidate returns the id of the first candidate that is still active once its row is locked.
+// Candidates deactivated after selection are skipped. Returns empty id if none is left.
+func lockFirstActiveCandidate(ctx context.Context, repo CandidateRepository,
+	candidates []*models.User, log *slog.Logger) (string, error) {
+	for _, candidate := range candidates {
+		active, err := repo.LockActiveCandidate(ctx, candidate.Id)
+		if err != nil {
+			return "", err
+		}
+		if active {
+			return candidate.Id, nil
+		}
+		log.LogAttrs(ctx, slog.LevelWarn, "candidate was deactivated concurrently",
+			slog.String("user_id", candidate.Id))
+	}
+	return "", nil
+}
+
+// rankByOpenReviews orders the candidates by their number of open reviews, least loaded first.
+// Ties are broken at random, so equally loaded teammates share the reassignments.
+func rankByOpenReviews(candidates []*models.User, counts map[string]int) {
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return counts[candidates[i].Id] < counts[candidates[j].Id]
+	})
+}
+
+// findReplacement selects the first active reviewer from the team in id order, skipping excluded
+// users. Returns empty id if there is no candidate.
+func findReplacement(ctx context.Context, repo CandidateRepository, teamName string,
+	excludeUserIDs []string, log *slog.Logger) (string, error) {
+	candidates, err := repo.FindActiveCandidatesForReassignment(ctx, teamName, excludeUserIDs)
+	if err != nil {
+		return "", err
+	}
+	return lockFirstActiveCandidate(ctx, repo, candidates, log)
+}
@@ -0,0 +1,502 @@
+package service
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mocks/mock_dump_deps.go -package=mocks
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// defaultDumpTimeout is used when the configured timeout is not positive.
+const defaultDumpTimeout = 5 * time.Minute
+
+const (
+	// importBatchSize is the most records inserted with one batch of statements.
+	importBatchSize = 500
+	// maxImportFieldErrors limits the broken references reported for an import.
+	maxImportFieldErrors = 20
+)
+
+// DumpRepository defines the interface for reading all stored data for an export and writing it
+// back. Each Each method calls fn for every record, ordered by key, and stops at the first error
+// of fn. The Insert methods expect the records they reference to be inserted already.
+type DumpRepository interface {
+	EachTeam(ctx context.Context, fn func(*models.TeamRecord) error) error
+	EachUser(ctx context.Context, fn func(*models.User) error) error
+	EachPullRequest(ctx context.Context, fn func(*models.PullRequest) error) error
+	EachAssignment(ctx context.Context, fn func(*models.ReviewAssignment) error) error
+	// IsEmpty reports whether no users, teams or PRs, archived or not, are stored.
+	IsEmpty(ctx context.Context) (bool, error)
+	// Clear deletes all users, teams, PRs and everything about them; webhook deliveries and statistics
+	// snapshots are kept.
+	Clear(ctx context.Context) error
+	InsertUsers(ctx context.Context, users []*models.User) error
+	InsertTeams(ctx context.Context, teams []*models.TeamRecord) error
+	InsertPullRequests(ctx context.Context, prs []*models.PullRequest) error
+	InsertAssignments(ctx context.Context, assignments []*models.ReviewAssignment) error
+}
+
+// DumpService implements the export and import of all data.
+type DumpService struct {
+	repo DumpRepository
+	uow  Transactor
+	cfg  config.Dump
+	log  *slog.Logger
+}
+
+// NewDumpService creates a new dump service.
+func NewDumpService(repo DumpRepository, uow Transactor, cfg config.Dump, log *slog.Logger) *DumpService {
+	if log == nil {
+		log = slog.Default()
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultDumpTimeout
+	}
+	return &DumpService{
+		repo: repo,
+		uow:  uow,
+		cfg:  cfg,
+		log:  log,
+	}
+}
+
+// Export writes all teams, users, PRs and reviewer assignments to w as an admin.Dump document.
+// Everything is read in one Repeatable Read transaction, so the document is a consistent
+// snapshot, and records are written as they are read instead of being collected first. Nothing
+// is written to w before the transaction has started; an error after that leaves the document
+// cut short, without checksums.
+func (s *DumpService) Export(ctx context.Context, w io.Writer) error {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	d := &dumpWriter{w: bufio.NewWriter(w)}
+	var checksums admin.DumpChecksums
+	err := s.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := d.begin(time.Now().UTC()); err != nil {
+			return err
+		}
+		var err error
+		if checksums.Teams, err = dumpSection(txCtx, d, "teams", s.repo.EachTeam, newDumpTeam); err != nil {
+			return err
+		}
+		if checksums.Users, err = dumpSection(txCtx, d, "users", s.repo.EachUser, newDumpUser); err != nil {
+			return err
+		}
+		checksums.PullRequests, err = dumpSection(txCtx, d, "pull_requests", s.repo.EachPullRequest, newDumpPullRequest)
+		if err != nil {
+			return err
+		}
+		checksums.Reviewers, err = dumpSection(txCtx, d, "reviewers", s.repo.EachAssignment, newDumpReviewer)
+		if err != nil {
+			return err
+		}
+		return d.end(checksums)
+	})
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to export data", slog.String("error", err.Error()))
+		return err
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "data exported",
+		slog.Int("teams", checksums.Teams.Count),
+		slog.Int("users", checksums.Users.Count),
+		slog.Int("pull_requests", checksums.PullRequests.Count),
+		slog.Int("reviewers", checksums.Reviewers.Count))
+
+	return nil
+}
+
+// Import loads a Dump written by Export. The dump is checked before anything is written: it must
+// have the current schema version, every section must match its checksum, keys must be unique and
+// every reference must point to a record of the dump. Returns VALIDATION_ERROR listing the broken
+// references, and NOT_EMPTY when data is stored and req.Force is not set. The records are inserted
+// in one transaction, in batches, with the records they reference first.
+func (s *DumpService) Import(ctx context.Context, req admin.ImportDumpRequest) (*admin.ImportDumpResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	if err := checkDump(&req.Dump); err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to import data", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	d := &req.Dump
+	response := &admin.ImportDumpResponse{
+		Teams:        len(d.Teams),
+		Users:        len(d.Users),
+		PullRequests: len(d.PullRequests),
+		Reviewers:    len(d.Reviewers),
+	}
+	err := s.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
+		empty, err := s.repo.IsEmpty(txCtx)
+		if err != nil {
+			return err
+		}
+		if !empty {
+			if !req.Force {
+				return domainErrors.NewNotEmpty("data is already stored, import with force to replace it")
+			}
+			if err = s.repo.Clear(txCtx); err != nil {
+				return err
+			}
+			response.Cleared = true
+		}
+
+		if err = insertInBatches(txCtx, d.Users, importedUser, s.repo.InsertUsers); err != nil {
+			return err
+		}
+		if err = insertInBatches(txCtx, d.Teams, importedTeam, s.repo.InsertTeams); err != nil {
+			return err
+		}
+		if err = insertInBatches(txCtx, d.PullRequests, importedPullRequest, s.repo.InsertPullRequests); err != nil {
+			return err
+		}
+		return insertInBatches(txCtx, d.Reviewers, importedAssignment, s.repo.InsertAssignments)
+	})
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to import data", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "data imported",
+		slog.Int("teams", response.Teams),
+		slog.Int("users", response.Users),
+		slog.Int("pull_requests", response.PullRequests),
+		slog.Int("reviewers", response.Reviewers),
+		slog.Bool("cleared", response.Cleared))
+
+	return response, nil
+}
+
+// checkDump checks the version, the checksums and the references of a dump.
+func checkDump(d *admin.Dump) error {
+	if d.SchemaVersion != admin.DumpSchemaVersion {
+		return domainErrors.NewBadRequest(fmt.Sprintf("schema_version %d is not supported, expected %d",
+			d.SchemaVersion, admin.DumpSchemaVersion))
+	}
+	for _, section := range []struct {
+		name string
+		got  admin.DumpChecksum
+		want admin.DumpChecksum
+	}{
+		{"teams", recordsChecksum(d.Teams), d.Checksums.Teams},
+		{"users", recordsChecksum(d.Users), d.Checksums.Users},
+		{"pull_requests", recordsChecksum(d.PullRequests), d.Checksums.PullRequests},
+		{"reviewers", recordsChecksum(d.Reviewers), d.Checksums.Reviewers},
+	} {
+		if section.got != section.want {
+			return domainErrors.NewBadRequest(fmt.Sprintf("%s do not match their checksum", section.name))
+		}
+	}
+
+	if fields := brokenReferences(d); len(fields) > 0 {
+		return domainErrors.NewValidation("dump has duplicate keys or broken references").
+			WithDetails(dto.ValidationDetails{Fields: fields})
+	}
+	return nil
+}
+
+// recordsChecksum computes the checksum of decoded records, which encode as they were exported.
+func recordsChecksum[R any](records []R) admin.DumpChecksum {
+	sum := newDumpHash()
+	for _, record := range records {
+		encoded, err := json.Marshal(record)
+		if err != nil {
+			// the records were decoded from JSON, so they encode again
+			return admin.DumpChecksum{}
+		}
+		sum.add(encoded)
+	}
+	return sum.checksum()
+}
+
+// brokenReferences lists the duplicate keys and the references to records missing from the dump,
+// at most maxImportFieldErrors of them.
+func brokenReferences(d *admin.Dump) []dto.FieldError {
+	var fields []dto.FieldError
+	report := func(field, rule, param string) {
+		if len(fields) < maxImportFieldErrors {
+			fields = append(fields, dto.FieldError{Field: field, Rule: rule, Param: param})
+		}
+	}
+
+	users := make(map[string]bool, len(d.Users))
+	for i, u := range d.Users {
+		if users[u.UserID] {
+			report(fmt.Sprintf("users[%d].user_id", i), "unique", "")
+		}
+		users[u.UserID] = true
+	}
+	teams := make(map[string]bool, len(d.Teams))
+	for i, t := range d.Teams {
+		if teams[t.TeamName] {
+			report(fmt.Sprintf("teams[%d].team_name", i), "unique", "")
+		}
+		teams[t.TeamName] = true
+		if t.LeadID != "" && !users[t.LeadID] {
+			report(fmt.Sprintf("teams[%d].lead_id", i), "exists", "users")
+		}
+	}
+	for i, u := range d.Users {
+		if !teams[u.TeamName] {
+			report(fmt.Sprintf("users[%d].team_name", i), "exists", "teams")
+		}
+	}
+	prs := make(map[string]bool, len(d.PullRequests))
+	for i, pr := range d.PullRequests {
+		if prs[pr.PullRequestID] {
+			report(fmt.Sprintf("pull_requests[%d].pull_request_id", i), "unique", "")
+		}
+		prs[pr.PullRequestID] = true
+		if !users[pr.AuthorID] {
+			report(fmt.Sprintf("pull_requests[%d].author_id", i), "exists", "users")
+		}
+	}
+	assigned := make(map[[2]string]bool, len(d.Reviewers))
+	reviewers := make(map[string]int)
+	for i, r := range d.Reviewers {
+		if !prs[r.PullRequestID] {
+			report(fmt.Sprintf("reviewers[%d].pull_request_id", i), "exists", "pull_requests")
+		}
+		if !users[r.ReviewerID] {
+			report(fmt.Sprintf("reviewers[%d].reviewer_id", i), "exists", "users")
+		}
+		key := [2]string{r.PullRequestID, r.ReviewerID}
+		if assigned[key] {
+			report(fmt.Sprintf("reviewers[%d].reviewer_id", i), "unique", "")
+			continue
+		}
+		assigned[key] = true
+		if reviewers[r.PullRequestID]++; reviewers[r.PullRequestID] > models.MaxReviewers {
+			report(fmt.Sprintf("reviewers[%d].pull_request_id", i), "max", strconv.Itoa(models.MaxReviewers))
+		}
+	}
+	return fields
+}
+
+// insertInBatches converts the records and inserts them importBatchSize at a time.
+func insertInBatches[R, M any](ctx context.Context, records []R, convert func(*R) *M,
+	insert func(context.Context, []*M) error) error {
+	for start := 0; start < len(records); start += importBatchSize {
+		batch := records[start:min(start+importBatchSize, len(records))]
+		converted := make([]*M, 0, len(batch))
+		for i := range batch {
+			converted = append(converted, convert(&batch[i]))
+		}
+		if err := insert(ctx, converted); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dumpSection writes the records each reads as the named section of the document and returns
+// the checksum of the section.
+func dumpSection[M, R any](ctx context.Context, d *dumpWriter, name string,
+	each func(context.Context, func(*M) error) error, convert func(*M) R) (admin.DumpChecksum, error) {
+	if err := d.open(name); err != nil {
+		return admin.DumpChecksum{}, err
+	}
+	sum := newDumpHash()
+	err := each(ctx, func(m *M) error {
+		record, err := json.Marshal(convert(m))
+		if err != nil {
+			return fmt.Errorf("failed to encode %s record: %w", name, err)
+		}
+		sum.add(record)
+		return d.record(record, sum.count == 1)
+	})
+	if err != nil {
+		return admin.DumpChecksum{}, err
+	}
+	return sum.checksum(), d.close()
+}
+
+// dumpWriter writes a Dump document one record at a time, a record per line.
+type dumpWriter struct {
+	w *bufio.Writer
+}
+
+func (d *dumpWriter) begin(exportedAt time.Time) error {
+	_, err := fmt.Fprintf(d.w, `{"schema_version":%d,"exported_at":"%s",`, admin.DumpSchemaVersion,
+		exportedAt.Format(time.RFC3339Nano))
+	return err
+}
+
+func (d *dumpWriter) open(section string) error {
+	_, err := fmt.Fprintf(d.w, "%q:[", section)
+	return err
+}
+
+func (d *dumpWriter) record(record []byte, first bool) error {
+	if !first {
+		if err := d.w.WriteByte(','); err != nil {
+			return err
+		}
+	}
+	if err := d.w.WriteByte('\n'); err != nil {
+		return err
+	}
+	_, err := d.w.Write(record)
+	return err
+}
+
+func (d *dumpWriter) close() error {
+	_, err := d.w.WriteString("\n],")
+	return err
+}
+
+// end writes the checksums, closing the document, and flushes it.
+func (d *dumpWriter) end(checksums admin.DumpChecksums) error {
+	encoded, err := json.Marshal(checksums)
+	if err != nil {
+		return err
+	}
+	if _, err = fmt.Fprintf(d.w, "\"checksums\":%s}\n", encoded); err != nil {
+		return err
+	}
+	return d.w.Flush()
+}
+
+// dumpHash computes the checksum of a section as described by admin.DumpChecksum.
+type dumpHash struct {
+	h     hash.Hash
+	count int
+}
+
+func newDumpHash() *dumpHash {
+	return &dumpHash{h: sha256.New()}
+}
+
+func (d *dumpHash) add(record []byte) {
+	d.h.Write(record)
+	d.h.Write([]byte{'\n'})
+	d.count++
+}
+
+func (d *dumpHash) checksum() admin.DumpChecksum {
+	return admin.DumpChecksum{Count: d.count, SHA256: hex.EncodeToString(d.h.Sum(nil))}
+}
+
+func newDumpTeam(t *models.TeamRecord) admin.DumpTeam {
+	team := admin.DumpTeam{TeamName: t.Name, Description: t.Description, LeadID: t.LeadId}
+	if !t.CreatedAt.IsZero() {
+		createdAt := t.CreatedAt.UTC()
+		team.CreatedAt = &createdAt
+	}
+	return team
+}
+
+func newDumpUser(u *models.User) admin.DumpUser {
+	isReviewer := !u.NonReviewer
+	return admin.DumpUser{
+		UserID:           u.Id,
+		Username:         u.Name,
+		TeamName:         u.TeamName,
+		IsActive:         u.IsActive,
+		IsReviewer:       &isReviewer,
+		MaxActiveReviews: u.MaxActiveReviews,
+		Tags:             append([]string{}, u.Tags...),
+		Timezone:         u.Timezone,
+		WorkHoursStart:   u.WorkStart,
+		WorkHoursEnd:     u.WorkEnd,
+	}
+}
+
+func newDumpPullRequest(pr *models.PullRequest) admin.DumpPullRequest {
+	return admin.DumpPullRequest{
+		PullRequestID:     pr.Id,
+		PullRequestName:   pr.Title,
+		AuthorID:          pr.AuthorId,
+		Status:            pr.Status,
+		Priority:          pr.Priority,
+		Labels:            append([]string{}, pr.Labels...),
+		CreatedAt:         pr.CreatedAt.UTC(),
+		UpdatedAt:         pr.UpdatedAt.UTC(),
+		MergedAt:          utcPtr(pr.MergedAt),
+		AssignmentSkipped: pr.AssignmentSkipped,
+	}
+}
+
+func newDumpReviewer(a *models.ReviewAssignment) admin.DumpReviewer {
+	return admin.DumpReviewer{
+		PullRequestID:  a.PRId,
+		ReviewerID:     a.ReviewerId,
+		AssignedAt:     a.AssignedAt.UTC(),
+		Source:         a.Source,
+		State:          a.State,
+		StateChangedAt: utcPtr(a.StateChangedAt),
+	}
+}
+
+func utcPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+func importedTeam(t *admin.DumpTeam) *models.TeamRecord {
+	team := &models.TeamRecord{Name: t.TeamName, Description: t.Description, LeadId: t.LeadID}
+	if t.CreatedAt != nil {
+		team.CreatedAt = *t.CreatedAt
+	}
+	return team
+}
+
+func importedUser(u *admin.DumpUser) *models.User {
+	return &models.User{
+		Id:               u.UserID,
+		Name:             u.Username,
+		TeamName:         u.TeamName,
+		IsActive:         u.IsActive,
+		MaxActiveReviews: u.MaxActiveReviews,
+		Tags:             append([]string{}, u.Tags...),
+		Timezone:         u.Timezone,
+		WorkStart:        u.WorkHoursStart,
+		WorkEnd:          u.WorkHoursEnd,
+		NonReviewer:      u.IsReviewer != nil && !*u.IsReviewer,
+	}
+}
+
+func importedPullRequest(pr *admin.DumpPullRequest) *models.PullRequest {
+	return &models.PullRequest{
+		Id:                pr.PullRequestID,
+		Title:             pr.PullRequestName,
+		AuthorId:          pr.AuthorID,
+		Status:            pr.Status,
+		CreatedAt:         pr.CreatedAt,
+		MergedAt:          pr.MergedAt,
+		UpdatedAt:         pr.UpdatedAt,
+		Priority:          pr.Priority,
+		Labels:            append([]string{}, pr.Labels...),
+		AssignmentSkipped: pr.AssignmentSkipped,
+	}
+}
+
+func importedAssignment(r *admin.DumpReviewer) *models.ReviewAssignment {
+	return &models.ReviewAssignment{
+		PRId:           r.PullRequestID,
+		ReviewerId:     r.ReviewerID,
+		AssignedAt:     r.AssignedAt,
+		Source:         r.Source,
+		State:          r.State,
+		StateChangedAt: r.StateChangedAt,
+	}
+}
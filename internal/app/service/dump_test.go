@@ -0,0 +1,311 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+	"github.com/shirr9/pr-reviewer-service/internal/app/service/mocks"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/shirr9/pr-reviewer-service/internal/infrastructure/persistence/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// sectionChecksum recomputes the checksum of a section from its decoded records.
+func sectionChecksum[T any](t *testing.T, records []T) admin.DumpChecksum {
+	t.Helper()
+	h := sha256.New()
+	for _, record := range records {
+		encoded, err := json.Marshal(record)
+		require.NoError(t, err)
+		h.Write(append(encoded, '\n'))
+	}
+	return admin.DumpChecksum{Count: len(records), SHA256: hex.EncodeToString(h.Sum(nil))}
+}
+
+func TestDumpService_Export(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	t.Run("Success - Everything is exported with checksums", func(t *testing.T) {
+		ctx := context.Background()
+		storage := memory.NewStorage()
+		createdAt := time.Date(2025, 3, 1, 9, 30, 0, 123456000, time.UTC)
+		maxReviews := 3
+		reviewer, nonReviewer := true, false
+		team := &models.Team{Description: "Core services", LeadId: "u1", CreatedAt: createdAt, Members: []*models.User{
+			{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: false, NonReviewer: true},
+			{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true, MaxActiveReviews: &maxReviews,
+				Tags: []string{"go"}, Timezone: "Europe/Berlin", WorkStart: "10:00", WorkEnd: "19:00"},
+		}}
+		require.NoError(t, storage.NewTeamRepository().CreateTeam(ctx, team))
+		require.NoError(t, storage.NewPullRequestRepository().Create(ctx, &models.PullRequest{
+			Id: "pr-1", Title: "Add search", AuthorId: "u2", Status: models.PRStatusOpen, CreatedAt: createdAt,
+			UpdatedAt: createdAt, Priority: models.PRPriorityHigh, Labels: []string{"api"},
+		}))
+		err := storage.NewReviewerRepository().AssignReviewer(ctx, "pr-1", "u1", models.AssignmentSourceManual)
+		require.NoError(t, err)
+
+		service := NewDumpService(storage.NewDumpRepository(), storage.NewUnitOfWork(), config.Dump{}, logger)
+		var out bytes.Buffer
+		require.NoError(t, service.Export(ctx, &out))
+
+		var dump admin.Dump
+		require.NoError(t, json.Unmarshal(out.Bytes(), &dump), out.String())
+		assert.Equal(t, admin.DumpSchemaVersion, dump.SchemaVersion)
+		assert.WithinDuration(t, time.Now(), dump.ExportedAt, time.Minute)
+		assert.Equal(t, []admin.DumpTeam{
+			{TeamName: "backend", Description: "Core services", LeadID: "u1", CreatedAt: &createdAt},
+		}, dump.Teams)
+		assert.Equal(t, []admin.DumpUser{
+			{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true, IsReviewer: &reviewer,
+				MaxActiveReviews: &maxReviews, Tags: []string{"go"}, Timezone: "Europe/Berlin", WorkHoursStart: "10:00",
+				WorkHoursEnd: "19:00"},
+			{UserID: "u2", Username: "Bob", TeamName: "backend", IsReviewer: &nonReviewer, Tags: []string{}},
+		}, dump.Users)
+		require.Len(t, dump.PullRequests, 1)
+		assert.Equal(t, admin.DumpPullRequest{
+			PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u2", Status: models.PRStatusOpen,
+			Priority: models.PRPriorityHigh, Labels: []string{"api"}, CreatedAt: createdAt, UpdatedAt: createdAt,
+		}, dump.PullRequests[0])
+		require.Len(t, dump.Reviewers, 1)
+		assert.Equal(t, "u1", dump.Reviewers[0].ReviewerID)
+		assert.Equal(t, models.ReviewStatePending, dump.Reviewers[0].State)
+		assert.Equal(t, models.AssignmentSourceManual, dump.Reviewers[0].Source)
+
+		assert.Equal(t, admin.DumpChecksums{
+			Teams:        sectionChecksum(t, dump.Teams),
+			Users:        sectionChecksum(t, dump.Users),
+			PullRequests: sectionChecksum(t, dump.PullRequests),
+			Reviewers:    sectionChecksum(t, dump.Reviewers),
+		}, dump.Checksums)
+	})
+
+	t.Run("Success - Empty storage", func(t *testing.T) {
+		storage := memory.NewStorage()
+		service := NewDumpService(storage.NewDumpRepository(), storage.NewUnitOfWork(), config.Dump{}, logger)
+		var out bytes.Buffer
+		require.NoError(t, service.Export(context.Background(), &out))
+
+		var dump admin.Dump
+		require.NoError(t, json.Unmarshal(out.Bytes(), &dump), out.String())
+		assert.Empty(t, dump.Teams)
+		assert.Equal(t, 0, dump.Checksums.Users.Count)
+		assert.Equal(t, sectionChecksum(t, []admin.DumpUser{}), dump.Checksums.Users)
+	})
+
+	t.Run("Error - Reading fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockDumpRepository(ctrl)
+		repo.EXPECT().EachTeam(gomock.Any(), gomock.Any()).Return(nil)
+		repo.EXPECT().EachUser(gomock.Any(), gomock.Any()).Return(assert.AnError)
+
+		service := NewDumpService(repo, memory.NewStorage().NewUnitOfWork(), config.Dump{}, logger)
+		var out bytes.Buffer
+		err := service.Export(context.Background(), &out)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.NotContains(t, out.String(), "checksums")
+	})
+}
+
+// exportDump exports the storage and decodes the document.
+func exportDump(t *testing.T, storage *memory.Storage) admin.Dump {
+	t.Helper()
+	service := NewDumpService(storage.NewDumpRepository(), storage.NewUnitOfWork(), config.Dump{}, slog.Default())
+	var out bytes.Buffer
+	require.NoError(t, service.Export(context.Background(), &out))
+	var dump admin.Dump
+	require.NoError(t, json.Unmarshal(out.Bytes(), &dump), out.String())
+	return dump
+}
+
+// seedDumpStorage stores a team with a lead, a merged and an open PR, and reviews in every state.
+func seedDumpStorage(t *testing.T) *memory.Storage {
+	t.Helper()
+	env := newMemoryEnv()
+	createdAt := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	maxReviews := 2
+	require.NoError(t, env.storage.NewTeamRepository().CreateTeam(env.ctx, &models.Team{
+		Description: "Core services", LeadId: "u1", CreatedAt: createdAt, Members: []*models.User{
+			{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true, Tags: []string{"go", "sql"}},
+			{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true, MaxActiveReviews: &maxReviews},
+			{Id: "u3", Name: "Carol", TeamName: "backend", Timezone: "Europe/Berlin", WorkStart: "09:00", WorkEnd: "17:00"},
+		}}))
+	require.NoError(t, env.prRepo.Create(env.ctx, &models.PullRequest{
+		Id: "pr-1", Title: "Add search", AuthorId: "u1", Status: models.PRStatusOpen, CreatedAt: createdAt,
+		UpdatedAt: createdAt, Priority: models.PRPriorityUrgent, Labels: []string{"api", "search"},
+	}))
+	mergedAt := createdAt.Add(time.Hour)
+	require.NoError(t, env.prRepo.Create(env.ctx, &models.PullRequest{
+		Id: "pr-2", Title: "Fix login", AuthorId: "u2", Status: models.PRStatusMerged, CreatedAt: createdAt,
+		UpdatedAt: mergedAt, MergedAt: &mergedAt, Priority: models.PRPriorityNormal,
+	}))
+	require.NoError(t, env.reviewerRepo.AssignReviewer(env.ctx, "pr-1", "u2", models.AssignmentSourceAuto))
+	require.NoError(t, env.reviewerRepo.AssignReviewer(env.ctx, "pr-1", "u3", models.AssignmentSourceManual))
+	require.NoError(t, env.reviewerRepo.AssignReviewer(env.ctx, "pr-2", "u1", models.AssignmentSourceAuto))
+	require.NoError(t, env.reviewerRepo.SetReviewState(env.ctx, "pr-1", "u3", models.ReviewStateChangesRequested, mergedAt))
+	require.NoError(t, env.reviewerRepo.SetReviewState(env.ctx, "pr-2", "u1", models.ReviewStateApproved, mergedAt))
+	return env.storage
+}
+
+func TestDumpService_Import(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	t.Run("Success - Export, import and export again match", func(t *testing.T) {
+		dump := exportDump(t, seedDumpStorage(t))
+
+		restored := memory.NewStorage()
+		service := NewDumpService(restored.NewDumpRepository(), restored.NewUnitOfWork(), config.Dump{}, logger)
+		response, err := service.Import(context.Background(), admin.ImportDumpRequest{Dump: dump})
+		require.NoError(t, err)
+		assert.Equal(t, &admin.ImportDumpResponse{Teams: 1, Users: 3, PullRequests: 2, Reviewers: 3}, response)
+
+		again := exportDump(t, restored)
+		again.ExportedAt = dump.ExportedAt
+		assert.Equal(t, dump, again)
+	})
+
+	t.Run("Error - Stored data without force", func(t *testing.T) {
+		storage := seedDumpStorage(t)
+		dump := exportDump(t, storage)
+
+		service := NewDumpService(storage.NewDumpRepository(), storage.NewUnitOfWork(), config.Dump{}, logger)
+		_, err := service.Import(context.Background(), admin.ImportDumpRequest{Dump: dump})
+
+		assert.True(t, domainErrors.HasCode(err, domainErrors.CodeNotEmpty))
+	})
+
+	t.Run("Success - Stored data is replaced with force", func(t *testing.T) {
+		dump := exportDump(t, seedDumpStorage(t))
+		storage := memory.NewStorage()
+		require.NoError(t, storage.NewTeamRepository().CreateTeam(context.Background(), &models.Team{
+			Members: []*models.User{{Id: "other", Name: "Other", TeamName: "frontend", IsActive: true}},
+		}))
+
+		service := NewDumpService(storage.NewDumpRepository(), storage.NewUnitOfWork(), config.Dump{}, logger)
+		response, err := service.Import(context.Background(), admin.ImportDumpRequest{Dump: dump, Force: true})
+		require.NoError(t, err)
+		assert.True(t, response.Cleared)
+
+		again := exportDump(t, storage)
+		again.ExportedAt = dump.ExportedAt
+		assert.Equal(t, dump, again)
+	})
+
+	t.Run("Error - Unsupported schema version", func(t *testing.T) {
+		dump := exportDump(t, seedDumpStorage(t))
+		dump.SchemaVersion = admin.DumpSchemaVersion + 1
+
+		service := NewDumpService(mocks.NewMockDumpRepository(gomock.NewController(t)), nil, config.Dump{}, logger)
+		_, err := service.Import(context.Background(), admin.ImportDumpRequest{Dump: dump})
+
+		assert.True(t, domainErrors.HasCode(err, domainErrors.CodeBadRequest))
+	})
+
+	t.Run("Error - Edited section doesn't match its checksum", func(t *testing.T) {
+		dump := exportDump(t, seedDumpStorage(t))
+		dump.Users[1].IsActive = false
+
+		service := NewDumpService(mocks.NewMockDumpRepository(gomock.NewController(t)), nil, config.Dump{}, logger)
+		_, err := service.Import(context.Background(), admin.ImportDumpRequest{Dump: dump})
+
+		assert.True(t, domainErrors.HasCode(err, domainErrors.CodeBadRequest))
+		assert.Contains(t, err.Error(), "users")
+	})
+
+	t.Run("Error - Broken references are listed", func(t *testing.T) {
+		now := time.Now().UTC()
+		dump := admin.Dump{
+			SchemaVersion: admin.DumpSchemaVersion,
+			Teams:         []admin.DumpTeam{{TeamName: "backend", LeadID: "ghost"}},
+			Users: []admin.DumpUser{
+				{UserID: "u1", Username: "Alice", TeamName: "backend", Tags: []string{}},
+				{UserID: "u1", Username: "Alice again", TeamName: "frontend", Tags: []string{}},
+			},
+			PullRequests: []admin.DumpPullRequest{{PullRequestID: "pr-1", PullRequestName: "Add search",
+				AuthorID: "u1", Status: models.PRStatusOpen, Priority: models.PRPriorityNormal, Labels: []string{},
+				CreatedAt: now, UpdatedAt: now}},
+			Reviewers: []admin.DumpReviewer{
+				{PullRequestID: "pr-1", ReviewerID: "u2", AssignedAt: now},
+				{PullRequestID: "pr-9", ReviewerID: "u1", AssignedAt: now},
+			},
+		}
+		dump.Checksums = admin.DumpChecksums{
+			Teams:        sectionChecksum(t, dump.Teams),
+			Users:        sectionChecksum(t, dump.Users),
+			PullRequests: sectionChecksum(t, dump.PullRequests),
+			Reviewers:    sectionChecksum(t, dump.Reviewers),
+		}
+
+		service := NewDumpService(mocks.NewMockDumpRepository(gomock.NewController(t)), nil, config.Dump{}, logger)
+		_, err := service.Import(context.Background(), admin.ImportDumpRequest{Dump: dump})
+
+		var appErr *domainErrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, domainErrors.CodeValidation, appErr.Code)
+		assert.Equal(t, dto.ValidationDetails{Fields: []dto.FieldError{
+			{Field: "users[1].user_id", Rule: "unique"},
+			{Field: "teams[0].lead_id", Rule: "exists", Param: "users"},
+			{Field: "users[1].team_name", Rule: "exists", Param: "teams"},
+			{Field: "reviewers[0].reviewer_id", Rule: "exists", Param: "users"},
+			{Field: "reviewers[1].pull_request_id", Rule: "exists", Param: "pull_requests"},
+		}}, appErr.Details)
+	})
+
+	t.Run("Success - Records are inserted in batches", func(t *testing.T) {
+		dump := admin.Dump{SchemaVersion: admin.DumpSchemaVersion, Teams: []admin.DumpTeam{{TeamName: "backend"}}}
+		for i := range importBatchSize + 1 {
+			dump.Users = append(dump.Users, admin.DumpUser{
+				UserID: fmt.Sprintf("u%04d", i), Username: "User", TeamName: "backend", Tags: []string{},
+			})
+		}
+		dump.Checksums = admin.DumpChecksums{
+			Teams:        sectionChecksum(t, dump.Teams),
+			Users:        sectionChecksum(t, dump.Users),
+			PullRequests: sectionChecksum(t, dump.PullRequests),
+			Reviewers:    sectionChecksum(t, dump.Reviewers),
+		}
+
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockDumpRepository(ctrl)
+		repo.EXPECT().IsEmpty(gomock.Any()).Return(true, nil)
+		gomock.InOrder(
+			repo.EXPECT().InsertUsers(gomock.Any(), gomock.Len(importBatchSize)).Return(nil),
+			repo.EXPECT().InsertUsers(gomock.Any(), gomock.Len(1)).Return(nil),
+			repo.EXPECT().InsertTeams(gomock.Any(), gomock.Len(1)).Return(nil),
+		)
+
+		service := NewDumpService(repo, memory.NewStorage().NewUnitOfWork(), config.Dump{}, logger)
+		response, err := service.Import(context.Background(), admin.ImportDumpRequest{Dump: dump})
+
+		require.NoError(t, err)
+		assert.Equal(t, importBatchSize+1, response.Users)
+	})
+
+	t.Run("Error - Inserting fails", func(t *testing.T) {
+		dump := exportDump(t, seedDumpStorage(t))
+
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockDumpRepository(ctrl)
+		repo.EXPECT().IsEmpty(gomock.Any()).Return(true, nil)
+		repo.EXPECT().InsertUsers(gomock.Any(), gomock.Any()).Return(assert.AnError)
+
+		service := NewDumpService(repo, memory.NewStorage().NewUnitOfWork(), config.Dump{}, logger)
+		_, err := service.Import(context.Background(), admin.ImportDumpRequest{Dump: dump})
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
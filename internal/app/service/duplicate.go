@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dbctx"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// DuplicateRepository defines the interface for finding near-duplicate identifiers.
+type DuplicateRepository interface {
+	FindNearDuplicates(ctx context.Context) ([]models.NearDuplicate, error)
+}
+
+// DuplicateService reports the identifiers stored before input was normalized that differ only in
+// surrounding whitespace or case.
+type DuplicateService struct {
+	repo DuplicateRepository
+	log  *slog.Logger
+}
+
+// NewDuplicateService creates a new duplicate service.
+func NewDuplicateService(repo DuplicateRepository, log *slog.Logger) *DuplicateService {
+	if log == nil {
+		log = slog.Default()
+	}
+	return &DuplicateService{repo: repo, log: log}
+}
+
+// FindNearDuplicates returns the groups of near-duplicate user ids, team names and PR ids. It only
+// reads and may be served by a replica.
+func (s *DuplicateService) FindNearDuplicates(ctx context.Context) (*admin.NearDuplicatesResponse, error) {
+	ctx = dbctx.ReadOnly(ctx)
+	duplicates, err := s.repo.FindNearDuplicates(ctx)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find near-duplicate identifiers",
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	response := &admin.NearDuplicatesResponse{Duplicates: make([]admin.NearDuplicate, 0, len(duplicates))}
+	for _, d := range duplicates {
+		response.Duplicates = append(response.Duplicates, admin.NearDuplicate{Kind: d.Kind, Key: d.Key, IDs: d.Ids})
+	}
+	if len(duplicates) > 0 {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "near-duplicate identifiers found",
+			slog.Int("groups", len(duplicates)))
+	}
+	return response, nil
+}
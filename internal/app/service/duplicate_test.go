@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/shirr9/pr-reviewer-service/internal/infrastructure/persistence/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuplicateService_FindNearDuplicates(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	ctx := context.Background()
+
+	t.Run("Success - Identifiers differing in whitespace or case are grouped", func(t *testing.T) {
+		storage := memory.NewStorage()
+		require.NoError(t, storage.NewTeamRepository().CreateTeam(ctx, &models.Team{Members: []*models.User{
+			{Id: "user-1", Name: "Alice", TeamName: "backend", IsActive: true},
+			{Id: "user-1 ", Name: "Alice", TeamName: "backend", IsActive: true},
+			{Id: "user-2", Name: "Bob", TeamName: "backend", IsActive: true},
+		}}))
+		require.NoError(t, storage.NewTeamRepository().CreateTeam(ctx, &models.Team{Members: []*models.User{
+			{Id: "user-3", Name: "Carol", TeamName: "Backend", IsActive: true},
+		}}))
+		now := time.Now().UTC()
+		require.NoError(t, storage.NewDumpRepository().InsertPullRequests(ctx, []*models.PullRequest{
+			{Id: "pr-1", Title: "pr-1", AuthorId: "user-2", Status: models.PRStatusOpen, Priority: models.PRPriorityNormal, CreatedAt: now},
+			{Id: "PR-1", Title: "PR-1", AuthorId: "user-2", Status: models.PRStatusOpen, Priority: models.PRPriorityNormal, CreatedAt: now},
+		}))
+		service := NewDuplicateService(storage.NewDuplicateRepository(), logger)
+
+		resp, err := service.FindNearDuplicates(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []admin.NearDuplicate{
+			{Kind: models.IdentifierKindPullRequest, Key: "pr-1", IDs: []string{"PR-1", "pr-1"}},
+			{Kind: models.IdentifierKindTeam, Key: "backend", IDs: []string{"Backend", "backend"}},
+			{Kind: models.IdentifierKindUser, Key: "user-1", IDs: []string{"user-1", "user-1 "}},
+		}, resp.Duplicates)
+	})
+
+	t.Run("Success - Distinct identifiers are not reported", func(t *testing.T) {
+		storage := memory.NewStorage()
+		require.NoError(t, storage.NewTeamRepository().CreateTeam(ctx, &models.Team{Members: []*models.User{
+			{Id: "user-1", Name: "Alice", TeamName: "backend", IsActive: true},
+		}}))
+		service := NewDuplicateService(storage.NewDuplicateRepository(), logger)
+
+		resp, err := service.FindNearDuplicates(ctx)
+
+		require.NoError(t, err)
+		assert.Empty(t, resp.Duplicates)
+		assert.NotNil(t, resp.Duplicates)
+	})
+}
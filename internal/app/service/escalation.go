@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/pullrequest"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// EscalateStale reassigns up to limit pending reviews assigned before the given moment on open PRs
+// nobody has approved, choosing replacements the same way as ReassignReviewer. Every review is
+// reassigned in its own transaction: reviews picked up or reassigned in the meantime are skipped,
+// and so are reviews without a replacement candidate. Returns the reviewer changes made.
+func (s *PullRequestService) EscalateStale(ctx context.Context, assignedBefore time.Time,
+	limit int) ([]*models.ReviewerChange, error) {
+	stale, err := s.reviewerRepo.FindStaleAssignments(ctx, assignedBefore, limit)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find stale assignments",
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	changes := make([]*models.ReviewerChange, 0, len(stale))
+	for _, assignment := range stale {
+		change, err := s.escalate(ctx, assignment)
+		if err != nil {
+			if ctx.Err() != nil {
+				return changes, ctx.Err()
+			}
+			if errors.HasCode(err, errors.CodeNoCandidate) {
+				s.log.LogAttrs(ctx, slog.LevelWarn, "no replacement for stale review",
+					slog.String("pr_id", assignment.PRId),
+					slog.String("reviewer_id", assignment.ReviewerId))
+				continue
+			}
+			// one broken PR must not hold back the others
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to escalate stale review",
+				slog.String("pr_id", assignment.PRId),
+				slog.String("reviewer_id", assignment.ReviewerId),
+				slog.String("error", err.Error()))
+			continue
+		}
+		if change != nil {
+			changes = append(changes, change)
+		}
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "stale reviews escalated",
+		slog.Int("stale", len(stale)),
+		slog.Int("reassigned", len(changes)))
+	return changes, nil
+}
+
+// escalate reassigns a stale review if it is still pending since the same assignment.
+// Returns nil when there was nothing to do.
+func (s *PullRequestService) escalate(ctx context.Context, stale *models.ReviewAssignment) (*models.ReviewerChange, error) {
+	var response *pullrequest.ReassignReviewerResponse
+	var change *models.ReviewerChange
+
+	err := s.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
+		current, err := s.reviewerRepo.LockAssignment(txCtx, stale.PRId, stale.ReviewerId)
+		if err != nil {
+			return err
+		}
+		if current == nil || current.State != models.ReviewStatePending || !current.AssignedAt.Equal(stale.AssignedAt) {
+			return nil
+		}
+
+		response, change, err = s.reassignReviewer(txCtx, pullrequest.ReassignReviewerRequest{
+			PullRequestID: stale.PRId,
+			OldReviewerID: stale.ReviewerId,
+		}, models.ReviewerChangeEscalation)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if change != nil {
+		s.log.LogAttrs(ctx, slog.LevelInfo, "stale review reassigned",
+			slog.String("pr_id", change.PRId),
+			slog.String("old_reviewer", change.OldReviewerId),
+			slog.String("new_reviewer", change.NewReviewerId))
+		s.publish(ctx,
+			removedEvent(change.PRId, change.OldReviewerId),
+			s.assignedEvent(response.Pr, change.NewReviewerId, models.AssignmentSourceEscalation, change.ChangedAt),
+		)
+	}
+	return change, nil
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPullRequestService_EscalateStale(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	now := time.Now().UTC()
+	stale := now.Add(-4 * 24 * time.Hour)
+
+	newStore := func() *fakeStore {
+		store := newFakeStore(
+			&models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u3", Name: "Carol", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u4", Name: "Dave", TeamName: "backend", IsActive: true},
+		)
+		add := func(prID, status string, reviewers map[string]time.Time) {
+			store.prs[prID] = &models.PullRequest{Id: prID, AuthorId: "u1", Status: status}
+			for reviewerID, at := range reviewers {
+				store.reviewers[prID] = append(store.reviewers[prID], reviewerID)
+				store.assignedAt[[2]string{prID, reviewerID}] = at
+			}
+		}
+		add("pr-stale", models.PRStatusOpen, map[string]time.Time{"u2": stale})
+		add("pr-approved", models.PRStatusOpen, map[string]time.Time{"u2": stale, "u3": stale})
+		store.states[[2]string{"pr-approved", "u3"}] = models.ReviewStateApproved
+		add("pr-fresh", models.PRStatusOpen, map[string]time.Time{"u3": now.Add(-time.Hour)})
+		add("pr-merged", models.PRStatusMerged, map[string]time.Time{"u4": stale})
+		return store
+	}
+
+	t.Run("Success - Reassigns only pending reviews past the threshold", func(t *testing.T) {
+		store := newStore()
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+		changes, err := service.EscalateStale(context.Background(), now.Add(-72*time.Hour), 100)
+
+		assert.NoError(t, err)
+		assert.Len(t, changes, 1)
+		assert.Equal(t, "pr-stale", changes[0].PRId)
+		assert.Equal(t, "u2", changes[0].OldReviewerId)
+		assert.Equal(t, "u4", changes[0].NewReviewerId, "u3 has open reviews, u4 only a merged one")
+		assert.Equal(t, models.ReviewerChangeEscalation, changes[0].Trigger)
+		assert.Equal(t, []string{"u4"}, store.reviewers["pr-stale"])
+		assert.Equal(t, models.AssignmentSourceEscalation, store.sources[[2]string{"pr-stale", "u4"}])
+
+		history, _ := store.GetReviewerHistory(context.Background(), "pr-stale")
+		assert.Len(t, history, 1)
+		assert.Equal(t, models.ReviewerChangeEscalation, history[0].Trigger)
+	})
+
+	t.Run("Success - Replacement is not stale on the next run", func(t *testing.T) {
+		store := newStore()
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+		_, err := service.EscalateStale(context.Background(), now.Add(-72*time.Hour), 100)
+		assert.NoError(t, err)
+		changes, err := service.EscalateStale(context.Background(), now.Add(-72*time.Hour), 100)
+
+		assert.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("Success - Skips reviews without a replacement", func(t *testing.T) {
+		store := newStore()
+		store.users["u3"].IsActive = false
+		store.users["u4"].IsActive = false
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+		changes, err := service.EscalateStale(context.Background(), now.Add(-72*time.Hour), 100)
+
+		assert.NoError(t, err)
+		assert.Empty(t, changes)
+		assert.Equal(t, []string{"u2"}, store.reviewers["pr-stale"])
+		assert.Empty(t, store.history)
+	})
+
+	t.Run("Success - Team lead is the default target", func(t *testing.T) {
+		store := newStore()
+		store.leads["backend"] = "u4"
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+		changes, err := service.EscalateStale(context.Background(), now.Add(-72*time.Hour), 100)
+
+		assert.NoError(t, err)
+		if assert.Len(t, changes, 1) {
+			assert.Equal(t, "u4", changes[0].NewReviewerId)
+		}
+		assert.Equal(t, []string{"u4"}, store.reviewers["pr-stale"])
+	})
+
+	t.Run("Success - Unavailable lead falls back to the team", func(t *testing.T) {
+		store := newStore()
+		store.leads["backend"] = "u4"
+		store.users["u4"].IsActive = false
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+		changes, err := service.EscalateStale(context.Background(), now.Add(-72*time.Hour), 100)
+
+		assert.NoError(t, err)
+		if assert.Len(t, changes, 1) {
+			assert.Equal(t, "u3", changes[0].NewReviewerId)
+		}
+	})
+
+	t.Run("Success - Batch size limits the run", func(t *testing.T) {
+		store := newStore()
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+		changes, err := service.EscalateStale(context.Background(), now.Add(-72*time.Hour), 0)
+
+		assert.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/team"
+)
+
+// teamETag returns a strong ETag of the team: a hash of the team name, its metadata and every field
+// of its members, taken in user id order so that it doesn't depend on the order members are listed.
+func teamETag(t *team.GetTeamResponse) string {
+	sorted := make([]team.TeamMember, len(t.Members))
+	copy(sorted, t.Members)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].UserID < sorted[j].UserID })
+
+	// marshalling plain strings, bools and ints can't fail
+	data, _ := json.Marshal(struct {
+		TeamName    string            `json:"team_name"`
+		Description string            `json:"description"`
+		LeadID      string            `json:"lead_id"`
+		CreatedAt   string            `json:"created_at"`
+		Members     []team.TeamMember `json:"members"`
+	}{t.TeamName, t.Description, t.LeadID, t.CreatedAt, sorted})
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
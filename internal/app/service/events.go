@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/pullrequest"
+	userDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/user"
+	"github.com/shirr9/pr-reviewer-service/internal/app/events"
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// EventPublisher defines the interface for announcing changes of the reviewers' queues.
+type EventPublisher interface {
+	Publish(events ...events.Event)
+}
+
+// SetPublisher makes the service announce the queue changes of its committed creates,
+// reassignments and merges to p.
+func (s *PullRequestService) SetPublisher(p EventPublisher) {
+	s.events = p
+}
+
+// publish announces the events of the organization of ctx when the service has a publisher.
+func (s *PullRequestService) publish(ctx context.Context, evts ...events.Event) {
+	if s.events == nil || len(evts) == 0 {
+		return
+	}
+	orgID := orgctx.ID(ctx)
+	for i := range evts {
+		evts[i].OrgID = orgID
+	}
+	s.events.Publish(evts...)
+}
+
+// assignedEvent reports pr entering the reviewer's queue at assignedAt.
+func (s *PullRequestService) assignedEvent(pr pullrequest.PR, reviewerID, source string,
+	assignedAt time.Time) events.Event {
+	return events.Event{
+		Type:          events.AssignmentAdded,
+		UserID:        reviewerID,
+		PullRequestID: pr.PullRequestID,
+		PullRequest: &userDto.PR{
+			PullRequestID:   pr.PullRequestID,
+			PullRequestName: pr.PullRequestName,
+			AuthorID:        pr.AuthorID,
+			Status:          pr.Status,
+			Priority:        pr.Priority,
+			Labels:          pr.Labels,
+			AssignedAt:      dto.FormatTime(assignedAt),
+			Source:          source,
+			Deadline:        dto.FormatTime(models.ReviewDeadline(assignedAt, s.review.Deadline)),
+			ReviewState:     models.ReviewStatePending,
+		},
+	}
+}
+
+// removedEvent reports the PR leaving the reviewer's queue.
+func removedEvent(prID, reviewerID string) events.Event {
+	return events.Event{
+		Type:          events.AssignmentRemoved,
+		UserID:        reviewerID,
+		PullRequestID: prID,
+	}
+}
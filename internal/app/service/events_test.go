@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/pullrequest"
+	"github.com/shirr9/pr-reviewer-service/internal/app/events"
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingPublisher keeps the published events.
+type recordingPublisher struct {
+	published []events.Event
+}
+
+func (p *recordingPublisher) Publish(evts ...events.Event) {
+	p.published = append(p.published, evts...)
+}
+
+// take returns the events published since the last call.
+func (p *recordingPublisher) take() []events.Event {
+	published := p.published
+	p.published = nil
+	return published
+}
+
+func newPublishingService(t *testing.T) (*PullRequestService, *recordingPublisher) {
+	env := newMemoryEnv()
+	env.team(t, "backend", "u1", "u2", "u3", "u4")
+
+	service := env.prService()
+	publisher := &recordingPublisher{}
+	service.SetPublisher(publisher)
+	return service, publisher
+}
+
+func TestPullRequestService_PublishesQueueChanges(t *testing.T) {
+	ctx := context.Background()
+	service, publisher := newPublishingService(t)
+
+	created, err := service.CreatePR(ctx, pullrequest.CreatePrRequest{
+		PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u1",
+	})
+	require.NoError(t, err)
+	reviewers := created.Pr.AssignedReviewers
+	require.Len(t, reviewers, 2)
+
+	t.Run("Success - Created PR is added to the queue of each reviewer", func(t *testing.T) {
+		published := publisher.take()
+
+		assert.Len(t, published, 2)
+		for i, event := range published {
+			assert.Equal(t, events.AssignmentAdded, event.Type)
+			assert.Equal(t, reviewers[i], event.UserID)
+			assert.Equal(t, "pr-1", event.PullRequest.PullRequestID)
+			assert.Equal(t, "Add search", event.PullRequest.PullRequestName)
+			assert.Equal(t, models.AssignmentSourceAuto, event.PullRequest.Source)
+			assert.NotEmpty(t, event.PullRequest.Deadline)
+		}
+	})
+
+	t.Run("Success - Reassignment moves the PR between queues", func(t *testing.T) {
+		resp, err := service.ReassignReviewer(ctx, pullrequest.ReassignReviewerRequest{
+			PullRequestID: "pr-1", OldReviewerID: reviewers[0],
+		})
+		require.NoError(t, err)
+
+		published := publisher.take()
+		assert.Len(t, published, 2)
+		assert.Equal(t, events.Event{Type: events.AssignmentRemoved, OrgID: orgctx.DefaultID, UserID: reviewers[0],
+			PullRequestID: "pr-1"}, published[0])
+		assert.Equal(t, events.AssignmentAdded, published[1].Type)
+		assert.Equal(t, resp.ReplacedBy, published[1].UserID)
+		assert.Equal(t, models.AssignmentSourceReassign, published[1].PullRequest.Source)
+	})
+
+	t.Run("Error - Failed reassignment publishes nothing", func(t *testing.T) {
+		_, err := service.ReassignReviewer(ctx, pullrequest.ReassignReviewerRequest{
+			PullRequestID: "pr-1", OldReviewerID: "u1",
+		})
+
+		assert.Error(t, err)
+		assert.Empty(t, publisher.take())
+	})
+
+	t.Run("Success - Merged PR leaves the queues once", func(t *testing.T) {
+		resp, err := service.MergePR(ctx, pullrequest.MergePrRequest{PullRequestID: "pr-1"})
+		require.NoError(t, err)
+
+		published := publisher.take()
+		assert.Len(t, published, 2)
+		for i, event := range published {
+			assert.Equal(t, events.AssignmentRemoved, event.Type)
+			assert.Equal(t, resp.Pr.AssignedReviewers[i], event.UserID)
+		}
+
+		_, err = service.MergePR(ctx, pullrequest.MergePrRequest{PullRequestID: "pr-1"})
+		require.NoError(t, err)
+		assert.Empty(t, publisher.take())
+	})
+
+	t.Run("Success - Bulk created PRs are added to the queues of their reviewers", func(t *testing.T) {
+		resp, err := service.CreateBulk(ctx, pullrequest.CreateBulkRequest{
+			AssignReviewers: true,
+			PullRequests: []pullrequest.CreatePrRequest{
+				{PullRequestID: "pr-2", PullRequestName: "Add filters", AuthorID: "u1"},
+				{PullRequestID: "pr-3", PullRequestName: "Add docs", AuthorID: "u2", SkipAssignment: true},
+			},
+		})
+		require.NoError(t, err)
+
+		published := publisher.take()
+		assert.Len(t, published, 2)
+		for i, event := range published {
+			assert.Equal(t, events.AssignmentAdded, event.Type)
+			assert.Equal(t, resp.Results[0].Pr.AssignedReviewers[i], event.UserID)
+			assert.Equal(t, "pr-2", event.PullRequest.PullRequestID)
+			assert.Equal(t, models.AssignmentSourceAuto, event.PullRequest.Source)
+		}
+	})
+}
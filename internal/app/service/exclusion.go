@@ -0,0 +1,145 @@
+package service
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mocks/mock_exclusion_deps.go -package=mocks
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// ExclusionRepository defines the interface for reviewer exclusion persistence.
+type ExclusionRepository interface {
+	Upsert(ctx context.Context, exclusion *models.ReviewerExclusion) error
+	Delete(ctx context.Context, reviewerID, authorID string) (int, error)
+	List(ctx context.Context, userID string) ([]*models.ReviewerExclusion, error)
+}
+
+// ExclusionUserRepository defines the interface for user lookups needed by ExclusionService.
+type ExclusionUserRepository interface {
+	FindByIDs(ctx context.Context, userIDs []string) ([]*models.User, error)
+}
+
+// ExclusionService implements business logic for managing reviewer exclusions.
+type ExclusionService struct {
+	exclusionRepo ExclusionRepository
+	userRepo      ExclusionUserRepository
+	log           *slog.Logger
+}
+
+// NewExclusionService creates a new exclusion service.
+func NewExclusionService(
+	exclusionRepo ExclusionRepository,
+	userRepo ExclusionUserRepository,
+	log *slog.Logger,
+) *ExclusionService {
+	if log == nil {
+		log = slog.Default()
+	}
+	return &ExclusionService{
+		exclusionRepo: exclusionRepo,
+		userRepo:      userRepo,
+		log:           log,
+	}
+}
+
+// AddExclusion forbids the reviewer to review PRs of the author. Adding an existing exclusion
+// updates whether it is mutual. Assignments made before are kept.
+func (s *ExclusionService) AddExclusion(ctx context.Context, req admin.AddExclusionRequest) (*admin.ExclusionResponse, error) {
+	if req.ReviewerID == req.AuthorID {
+		return nil, errors.NewBadRequest("reviewer and author must differ")
+	}
+
+	users, err := s.userRepo.FindByIDs(ctx, []string{req.ReviewerID, req.AuthorID})
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find users",
+			slog.String("reviewer_id", req.ReviewerID),
+			slog.String("author_id", req.AuthorID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+	if len(users) != 2 {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "user not found",
+			slog.String("reviewer_id", req.ReviewerID), slog.String("author_id", req.AuthorID))
+		return nil, errors.NewNotFound("user not found")
+	}
+
+	exclusion := &models.ReviewerExclusion{
+		ReviewerId: req.ReviewerID,
+		AuthorId:   req.AuthorID,
+		Mutual:     req.Mutual,
+	}
+	if err := s.exclusionRepo.Upsert(ctx, exclusion); err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to add exclusion",
+			slog.String("reviewer_id", req.ReviewerID),
+			slog.String("author_id", req.AuthorID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "exclusion added",
+		slog.String("reviewer_id", req.ReviewerID),
+		slog.String("author_id", req.AuthorID),
+		slog.Bool("mutual", req.Mutual))
+
+	return &admin.ExclusionResponse{Exclusion: newExclusionDto(exclusion)}, nil
+}
+
+// RemoveExclusion lifts the exclusion of the reviewer for the author, together with
+// a mutual exclusion added the other way round.
+func (s *ExclusionService) RemoveExclusion(ctx context.Context,
+	req admin.RemoveExclusionRequest) (*admin.RemoveExclusionResponse, error) {
+	removed, err := s.exclusionRepo.Delete(ctx, req.ReviewerID, req.AuthorID)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to remove exclusion",
+			slog.String("reviewer_id", req.ReviewerID),
+			slog.String("author_id", req.AuthorID),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+	if removed == 0 {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "exclusion not found",
+			slog.String("reviewer_id", req.ReviewerID), slog.String("author_id", req.AuthorID))
+		return nil, errors.NewNotFound("exclusion not found")
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "exclusion removed",
+		slog.String("reviewer_id", req.ReviewerID),
+		slog.String("author_id", req.AuthorID),
+		slog.Int("removed", removed))
+
+	return &admin.RemoveExclusionResponse{Removed: removed}, nil
+}
+
+// ListExclusions returns a page of exclusions involving the user as reviewer or author,
+// or of all exclusions when the user id is empty.
+func (s *ExclusionService) ListExclusions(ctx context.Context,
+	req admin.ListExclusionsRequest) (*dto.Page[admin.Exclusion], error) {
+	exclusions, err := s.exclusionRepo.List(ctx, req.UserID)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to list exclusions",
+			slog.String("user_id", req.UserID), slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	items := make([]admin.Exclusion, 0, len(exclusions))
+	for _, exclusion := range exclusions {
+		items = append(items, newExclusionDto(exclusion))
+	}
+	page := dto.PageOf(items, req.Page)
+	return &page, nil
+}
+
+// newExclusionDto converts an exclusion to the response DTO.
+func newExclusionDto(exclusion *models.ReviewerExclusion) admin.Exclusion {
+	return admin.Exclusion{
+		ReviewerID: exclusion.ReviewerId,
+		AuthorID:   exclusion.AuthorId,
+		Mutual:     exclusion.Mutual,
+		CreatedAt:  dto.FormatTime(exclusion.CreatedAt),
+	}
+}
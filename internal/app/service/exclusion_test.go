@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/pullrequest"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExclusionService(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	ctx := context.Background()
+	newStore := func() *fakeStore {
+		return newFakeStore(
+			&models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u3", Name: "Charlie", TeamName: "backend", IsActive: true},
+		)
+	}
+
+	t.Run("Success - Adds and lists exclusions", func(t *testing.T) {
+		store := newStore()
+		service := NewExclusionService(store, store, logger)
+
+		resp, err := service.AddExclusion(ctx, admin.AddExclusionRequest{ReviewerID: "u2", AuthorID: "u1", Mutual: true})
+		assert.NoError(t, err)
+		assert.Equal(t, "u2", resp.Exclusion.ReviewerID)
+		assert.True(t, resp.Exclusion.Mutual)
+		assert.NotEmpty(t, resp.Exclusion.CreatedAt)
+		_, err = service.AddExclusion(ctx, admin.AddExclusionRequest{ReviewerID: "u3", AuthorID: "u2"})
+		assert.NoError(t, err)
+
+		all, err := service.ListExclusions(ctx, admin.ListExclusionsRequest{Page: dto.PageRequest{Limit: 10}})
+		assert.NoError(t, err)
+		assert.Len(t, all.Items, 2)
+		assert.Equal(t, 2, all.Total)
+		forU1, err := service.ListExclusions(ctx, admin.ListExclusionsRequest{UserID: "u1", Page: dto.PageRequest{Limit: 10}})
+		assert.NoError(t, err)
+		assert.Len(t, forU1.Items, 1)
+		assert.Equal(t, "u1", forU1.Items[0].AuthorID)
+		second, err := service.ListExclusions(ctx, admin.ListExclusionsRequest{Page: dto.PageRequest{Limit: 1, Offset: 1}})
+		assert.NoError(t, err)
+		assert.Equal(t, []admin.Exclusion{all.Items[1]}, second.Items)
+		assert.Equal(t, 2, second.Total)
+	})
+
+	t.Run("Success - Adding again updates mutual", func(t *testing.T) {
+		store := newStore()
+		service := NewExclusionService(store, store, logger)
+
+		_, err := service.AddExclusion(ctx, admin.AddExclusionRequest{ReviewerID: "u2", AuthorID: "u1", Mutual: true})
+		assert.NoError(t, err)
+		_, err = service.AddExclusion(ctx, admin.AddExclusionRequest{ReviewerID: "u2", AuthorID: "u1"})
+		assert.NoError(t, err)
+
+		assert.Len(t, store.exclusions, 1)
+		assert.False(t, store.exclusions[0].Mutual)
+	})
+
+	t.Run("Success - Removes the pair", func(t *testing.T) {
+		store := newStore()
+		store.exclusions = []*models.ReviewerExclusion{{ReviewerId: "u1", AuthorId: "u2", Mutual: true}}
+		service := NewExclusionService(store, store, logger)
+
+		resp, err := service.RemoveExclusion(ctx, admin.RemoveExclusionRequest{ReviewerID: "u2", AuthorID: "u1"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, resp.Removed)
+		assert.Empty(t, store.exclusions)
+	})
+
+	t.Run("Error - Same reviewer and author", func(t *testing.T) {
+		service := NewExclusionService(newStore(), newStore(), logger)
+
+		_, err := service.AddExclusion(ctx, admin.AddExclusionRequest{ReviewerID: "u1", AuthorID: "u1"})
+
+		assert.Error(t, err)
+		assert.Equal(t, errors.CodeBadRequest, err.(*errors.AppError).Code)
+	})
+
+	t.Run("Error - Unknown user", func(t *testing.T) {
+		store := newStore()
+		service := NewExclusionService(store, store, logger)
+
+		_, err := service.AddExclusion(ctx, admin.AddExclusionRequest{ReviewerID: "ghost", AuthorID: "u1"})
+
+		assert.Error(t, err)
+		assert.Equal(t, "NOT_FOUND", err.(*errors.AppError).Code)
+		assert.Empty(t, store.exclusions)
+	})
+
+	t.Run("Error - Removing a missing exclusion", func(t *testing.T) {
+		store := newStore()
+		service := NewExclusionService(store, store, logger)
+
+		_, err := service.RemoveExclusion(ctx, admin.RemoveExclusionRequest{ReviewerID: "u2", AuthorID: "u1"})
+
+		assert.Error(t, err)
+		assert.Equal(t, "NOT_FOUND", err.(*errors.AppError).Code)
+	})
+}
+
+func TestPullRequestService_Exclusions(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	ctx := context.Background()
+	newStore := func(exclusions ...*models.ReviewerExclusion) *fakeStore {
+		store := newFakeStore(
+			&models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u3", Name: "Charlie", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u4", Name: "David", TeamName: "backend", IsActive: true},
+		)
+		store.exclusions = exclusions
+		return store
+	}
+
+	t.Run("Success - CreatePR skips excluded reviewers", func(t *testing.T) {
+		store := newStore(&models.ReviewerExclusion{ReviewerId: "u2", AuthorId: "u1"})
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+		resp, err := service.CreatePR(ctx, pullrequest.CreatePrRequest{
+			PullRequestID: "pr-1", PullRequestName: "Test PR", AuthorID: "u1"})
+
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"u3", "u4"}, resp.Pr.AssignedReviewers)
+	})
+
+	t.Run("Success - Ordered exclusion applies to one direction only", func(t *testing.T) {
+		store := newStore(&models.ReviewerExclusion{ReviewerId: "u1", AuthorId: "u2"})
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+		resp, err := service.CreatePR(ctx, pullrequest.CreatePrRequest{
+			PullRequestID: "pr-1", PullRequestName: "Test PR", AuthorID: "u1"})
+
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"u2", "u3"}, resp.Pr.AssignedReviewers)
+	})
+
+	t.Run("Success - Mutual exclusion applies to both directions", func(t *testing.T) {
+		store := newStore(&models.ReviewerExclusion{ReviewerId: "u1", AuthorId: "u2", Mutual: true})
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+		resp, err := service.CreatePR(ctx, pullrequest.CreatePrRequest{
+			PullRequestID: "pr-1", PullRequestName: "Test PR", AuthorID: "u1"})
+
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"u3", "u4"}, resp.Pr.AssignedReviewers)
+	})
+
+	t.Run("Success - SuggestReviewers skips excluded reviewers", func(t *testing.T) {
+		store := newStore(&models.ReviewerExclusion{ReviewerId: "u3", AuthorId: "u1"})
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+		resp, err := service.SuggestReviewers(ctx, pullrequest.SuggestReviewersRequest{AuthorID: "u1", Count: 5})
+
+		assert.NoError(t, err)
+		userIDs := make([]string, 0, len(resp.Candidates))
+		for _, candidate := range resp.Candidates {
+			userIDs = append(userIDs, candidate.UserID)
+		}
+		assert.Equal(t, []string{"u2", "u4"}, userIDs)
+	})
+
+	t.Run("Success - Reassign skips excluded and current reviewers", func(t *testing.T) {
+		store := newStore(&models.ReviewerExclusion{ReviewerId: "u4", AuthorId: "u1"})
+		store.prs["pr-1"] = &models.PullRequest{Id: "pr-1", Title: "Test PR", AuthorId: "u1", Status: models.PRStatusOpen}
+		store.reviewers["pr-1"] = []string{"u2"}
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+		resp, err := service.ReassignReviewer(ctx, pullrequest.ReassignReviewerRequest{PullRequestID: "pr-1", OldReviewerID: "u2"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "u3", resp.ReplacedBy)
+	})
+
+	t.Run("Error - Reassign without candidates left after exclusions", func(t *testing.T) {
+		store := newStore(&models.ReviewerExclusion{ReviewerId: "u4", AuthorId: "u1"})
+		store.prs["pr-1"] = &models.PullRequest{Id: "pr-1", Title: "Test PR", AuthorId: "u1", Status: models.PRStatusOpen}
+		store.reviewers["pr-1"] = []string{"u2", "u3"}
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+		_, err := service.ReassignReviewer(ctx, pullrequest.ReassignReviewerRequest{PullRequestID: "pr-1", OldReviewerID: "u2"})
+
+		assert.Error(t, err)
+		assert.Equal(t, "NO_CANDIDATE", err.(*errors.AppError).Code)
+		assert.Equal(t, []string{"u2", "u3"}, store.reviewers["pr-1"])
+	})
+
+	t.Run("Error - Explicit reviewer is excluded", func(t *testing.T) {
+		store := newStore(&models.ReviewerExclusion{ReviewerId: "u1", AuthorId: "u4", Mutual: true})
+		store.prs["pr-1"] = &models.PullRequest{Id: "pr-1", Title: "Test PR", AuthorId: "u1", Status: models.PRStatusOpen}
+		store.reviewers["pr-1"] = []string{"u2"}
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+		_, err := service.ReassignReviewer(ctx, pullrequest.ReassignReviewerRequest{
+			PullRequestID: "pr-1", OldReviewerID: "u2", NewReviewerID: "u4"})
+
+		assert.Error(t, err)
+		assert.Equal(t, errors.CodeReviewerExcluded, err.(*errors.AppError).Code)
+		assert.Equal(t, []string{"u2"}, store.reviewers["pr-1"])
+	})
+
+	t.Run("Success - Escalation skips excluded reviewers", func(t *testing.T) {
+		store := newStore(&models.ReviewerExclusion{ReviewerId: "u3", AuthorId: "u1"})
+		store.prs["pr-1"] = &models.PullRequest{Id: "pr-1", Title: "Test PR", AuthorId: "u1", Status: models.PRStatusOpen}
+		store.reviewers["pr-1"] = []string{"u2"}
+		store.assignedAt[[2]string{"pr-1", "u2"}] = time.Now().UTC().Add(-4 * 24 * time.Hour)
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+		changes, err := service.EscalateStale(ctx, time.Now().UTC().Add(-72*time.Hour), 100)
+
+		assert.NoError(t, err)
+		assert.Len(t, changes, 1)
+		assert.Equal(t, "u4", changes[0].NewReviewerId)
+	})
+}
@@ -0,0 +1,737 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/shirr9/pr-reviewer-service/internal/infrastructure/persistence/memory"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// testReview is the review policy used by service tests.
+var testReview = config.Review{Deadline: 24 * time.Hour}
+
+// fakeStore is a thread-safe in-memory implementation of the pull request service dependencies,
+// used by tests that exercise concurrent interleavings which gomock expectations can't express.
+type fakeStore struct {
+	mu        sync.Mutex
+	users     map[string]*models.User
+	prs       map[string]*models.PullRequest
+	reviewers map[string][]string
+	// assignedAt is keyed by PR id and reviewer id; reviewers seeded directly have no entry.
+	assignedAt map[[2]string]time.Time
+	// states and stateChangedAt are keyed like assignedAt; reviewers without an entry are PENDING.
+	states         map[[2]string]string
+	stateChangedAt map[[2]string]time.Time
+	// sources are keyed like assignedAt; reviewers seeded directly have no entry.
+	sources    map[[2]string]string
+	history    []*models.ReviewerChange
+	exclusions []*models.ReviewerExclusion
+	// leads are keyed by team name.
+	leads map[string]string
+
+	// beforeExists runs after the existence result is computed, emulating a snapshot taken earlier.
+	beforeExists func()
+	// afterFindPR runs after a PR is read, emulating a snapshot taken earlier.
+	afterFindPR func()
+	// afterCandidates runs after reviewer candidates are selected.
+	afterCandidates func()
+}
+
+func newFakeStore(users ...*models.User) *fakeStore {
+	s := &fakeStore{
+		users:          make(map[string]*models.User),
+		prs:            make(map[string]*models.PullRequest),
+		reviewers:      make(map[string][]string),
+		assignedAt:     make(map[[2]string]time.Time),
+		states:         make(map[[2]string]string),
+		stateChangedAt: make(map[[2]string]time.Time),
+		sources:        make(map[[2]string]string),
+		leads:          make(map[string]string),
+	}
+	for _, u := range users {
+		s.users[u.Id] = u
+	}
+	return s
+}
+
+func (s *fakeStore) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (s *fakeStore) Create(ctx context.Context, pr *models.PullRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.prs[pr.Id]; ok {
+		return errors.NewPRExists("PR id already exists")
+	}
+	cp := *pr
+	s.prs[pr.Id] = &cp
+	return nil
+}
+
+func (s *fakeStore) CreateBatch(ctx context.Context, prs []*models.PullRequest) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var created []string
+	for _, pr := range prs {
+		if _, ok := s.prs[pr.Id]; ok {
+			continue
+		}
+		cp := *pr
+		s.prs[pr.Id] = &cp
+		created = append(created, pr.Id)
+	}
+	return created, nil
+}
+
+func (s *fakeStore) FindByID(ctx context.Context, id string) (*models.PullRequest, error) {
+	s.mu.Lock()
+	pr, ok := s.prs[id]
+	var cp *models.PullRequest
+	if ok {
+		found := *pr
+		cp = &found
+	}
+	s.mu.Unlock()
+	if s.afterFindPR != nil {
+		s.afterFindPR()
+	}
+	return cp, nil
+}
+
+func (s *fakeStore) Exists(ctx context.Context, prID string) (bool, error) {
+	s.mu.Lock()
+	_, ok := s.prs[prID]
+	s.mu.Unlock()
+	if s.beforeExists != nil {
+		s.beforeExists()
+	}
+	return ok, nil
+}
+
+func (s *fakeStore) UpdateStatus(ctx context.Context, prID, fromStatus, status string, mergedAt *time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pr, ok := s.prs[prID]
+	if !ok || pr.Status != fromStatus {
+		return 0, nil
+	}
+	pr.Status = status
+	pr.MergedAt = mergedAt
+	pr.UpdatedAt = time.Now().UTC()
+	return 1, nil
+}
+
+func (s *fakeStore) SetLabels(ctx context.Context, prID string, labels []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if pr, ok := s.prs[prID]; ok {
+		pr.Labels = append([]string{}, labels...)
+		pr.UpdatedAt = time.Now().UTC()
+	}
+	return nil
+}
+
+func (s *fakeStore) ClearAssignmentSkipped(ctx context.Context, prID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if pr, ok := s.prs[prID]; ok {
+		pr.AssignmentSkipped = false
+	}
+	return nil
+}
+
+// hasLabels reports whether the PR carries all the labels.
+func hasLabels(pr *models.PullRequest, labels []string) bool {
+	for _, label := range labels {
+		found := false
+		for _, l := range pr.Labels {
+			found = found || l == label
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// SearchByTitle keeps the default order, newest first, whatever the sort.
+func (s *fakeStore) SearchByTitle(ctx context.Context, query, status string, labels []string,
+	_ models.PRSort, limit, offset int) ([]*models.PullRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var prs []*models.PullRequest
+	for _, pr := range s.prs {
+		if strings.Contains(strings.ToLower(pr.Title), strings.ToLower(query)) && (status == "" || pr.Status == status) &&
+			hasLabels(pr, labels) {
+			cp := *pr
+			prs = append(prs, &cp)
+		}
+	}
+	sort.Slice(prs, func(i, j int) bool { return prs[i].CreatedAt.After(prs[j].CreatedAt) })
+	if offset >= len(prs) {
+		return nil, nil
+	}
+	prs = prs[offset:]
+	if len(prs) > limit {
+		prs = prs[:limit]
+	}
+	return prs, nil
+}
+
+func (s *fakeStore) CountByTitle(ctx context.Context, query, status string, labels []string) (int, error) {
+	prs, err := s.SearchByTitle(ctx, query, status, labels, models.PRSort{}, math.MaxInt, 0)
+	return len(prs), err
+}
+
+func (s *fakeStore) CountOpenWithoutReviewers(ctx context.Context, teamName string, labels []string,
+	skipped *bool) (int, error) {
+	prs, err := s.FindOpenWithoutReviewers(ctx, teamName, labels, skipped, models.PRSort{}, math.MaxInt, 0)
+	return len(prs), err
+}
+
+// FindOpenWithoutReviewers keeps the default order, oldest first, whatever the sort.
+func (s *fakeStore) FindOpenWithoutReviewers(ctx context.Context, teamName string, labels []string, skipped *bool,
+	_ models.PRSort, limit, offset int) ([]*models.PullRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var prs []*models.PullRequest
+	for _, pr := range s.prs {
+		if pr.Status == models.PRStatusOpen && len(s.reviewers[pr.Id]) == 0 && hasLabels(pr, labels) &&
+			(skipped == nil || pr.AssignmentSkipped == *skipped) &&
+			(teamName == "" || s.users[pr.AuthorId] != nil && s.users[pr.AuthorId].TeamName == teamName) {
+			cp := *pr
+			prs = append(prs, &cp)
+		}
+	}
+	sort.Slice(prs, func(i, j int) bool {
+		if !prs[i].CreatedAt.Equal(prs[j].CreatedAt) {
+			return prs[i].CreatedAt.Before(prs[j].CreatedAt)
+		}
+		return prs[i].Id < prs[j].Id
+	})
+	if offset >= len(prs) {
+		return nil, nil
+	}
+	prs = prs[offset:]
+	if len(prs) > limit {
+		prs = prs[:limit]
+	}
+	return prs, nil
+}
+
+func (s *fakeStore) FindOpenPRsByReviewers(ctx context.Context, reviewerIDs []string) ([]*models.PullRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var prs []*models.PullRequest
+	for _, pr := range s.prs {
+		if pr.Status != models.PRStatusOpen {
+			continue
+		}
+		for _, r := range s.reviewers[pr.Id] {
+			if slices.Contains(reviewerIDs, r) {
+				cp := *pr
+				prs = append(prs, &cp)
+				break
+			}
+		}
+	}
+	sort.Slice(prs, func(i, j int) bool { return prs[i].CreatedAt.After(prs[j].CreatedAt) })
+	return prs, nil
+}
+
+func (s *fakeStore) AssignReviewer(ctx context.Context, prID, reviewerID, source string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reviewers[prID] = append(s.reviewers[prID], reviewerID)
+	sort.Strings(s.reviewers[prID])
+	s.assignedAt[[2]string{prID, reviewerID}] = time.Now().UTC()
+	s.sources[[2]string{prID, reviewerID}] = source
+	return nil
+}
+
+func (s *fakeStore) GetReviewers(ctx context.Context, prID string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.reviewers[prID]...), nil
+}
+
+func (s *fakeStore) GetReviewersByPRs(ctx context.Context, prIDs []string) (map[string][]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reviewers := make(map[string][]string)
+	for _, id := range prIDs {
+		if r := s.reviewers[id]; len(r) > 0 {
+			reviewers[id] = append([]string(nil), r...)
+		}
+	}
+	return reviewers, nil
+}
+
+func (s *fakeStore) GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	wanted := make(map[string]bool, len(userIDs))
+	for _, id := range userIDs {
+		wanted[id] = true
+	}
+	counts := make(map[string]int)
+	for prID, reviewers := range s.reviewers {
+		if pr, ok := s.prs[prID]; !ok || pr.Status != models.PRStatusOpen {
+			continue
+		}
+		for _, r := range reviewers {
+			if wanted[r] {
+				counts[r]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+func (s *fakeStore) GetAssignmentsByPRs(ctx context.Context, prIDs []string) ([]*models.ReviewAssignment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var assignments []*models.ReviewAssignment
+	for _, prID := range prIDs {
+		for _, r := range s.reviewers[prID] {
+			assignments = append(assignments, s.assignment(prID, r))
+		}
+	}
+	return assignments, nil
+}
+
+func (s *fakeStore) GetAssignmentTimes(ctx context.Context, userIDs []string, since time.Time) (map[string][]time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	wanted := make(map[string]bool, len(userIDs))
+	for _, id := range userIDs {
+		wanted[id] = true
+	}
+	times := make(map[string][]time.Time)
+	for key, at := range s.assignedAt {
+		if wanted[key[1]] && !at.Before(since) {
+			times[key[1]] = append(times[key[1]], at)
+		}
+	}
+	return times, nil
+}
+
+func (s *fakeStore) FindStaleAssignments(ctx context.Context, assignedBefore time.Time, limit int) ([]*models.ReviewAssignment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var stale []*models.ReviewAssignment
+	for prID, reviewers := range s.reviewers {
+		if pr, ok := s.prs[prID]; !ok || pr.Status != models.PRStatusOpen {
+			continue
+		}
+		var pending []*models.ReviewAssignment
+		approved := false
+		for _, r := range reviewers {
+			a := s.assignment(prID, r)
+			approved = approved || a.State == models.ReviewStateApproved
+			if a.State == models.ReviewStatePending && a.AssignedAt.Before(assignedBefore) {
+				pending = append(pending, a)
+			}
+		}
+		if !approved {
+			stale = append(stale, pending...)
+		}
+	}
+	sort.Slice(stale, func(i, j int) bool {
+		if !stale[i].AssignedAt.Equal(stale[j].AssignedAt) {
+			return stale[i].AssignedAt.Before(stale[j].AssignedAt)
+		}
+		if stale[i].PRId != stale[j].PRId {
+			return stale[i].PRId < stale[j].PRId
+		}
+		return stale[i].ReviewerId < stale[j].ReviewerId
+	})
+	if len(stale) > limit {
+		stale = stale[:limit]
+	}
+	return stale, nil
+}
+
+// assignment builds the assignment of a reviewer; the caller holds the lock.
+func (s *fakeStore) assignment(prID, reviewerID string) *models.ReviewAssignment {
+	key := [2]string{prID, reviewerID}
+	a := &models.ReviewAssignment{
+		PRId: prID, ReviewerId: reviewerID, AssignedAt: s.assignedAt[key], Source: s.sources[key],
+		State: models.ReviewStatePending,
+	}
+	if state, ok := s.states[key]; ok {
+		a.State = state
+	}
+	if at, ok := s.stateChangedAt[key]; ok {
+		a.StateChangedAt = &at
+	}
+	return a
+}
+
+func (s *fakeStore) LockAssignment(ctx context.Context, prID, reviewerID string) (*models.ReviewAssignment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range s.reviewers[prID] {
+		if r == reviewerID {
+			return s.assignment(prID, reviewerID), nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *fakeStore) SetReviewState(ctx context.Context, prID, reviewerID, state string, changedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := [2]string{prID, reviewerID}
+	s.states[key] = state
+	s.stateChangedAt[key] = changedAt
+	return nil
+}
+
+func (s *fakeStore) GetPRsByReviewer(ctx context.Context, reviewerID string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ids []string
+	for prID, reviewers := range s.reviewers {
+		for _, r := range reviewers {
+			if r == reviewerID {
+				ids = append(ids, prID)
+			}
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (s *fakeStore) IsAssigned(ctx context.Context, prID, reviewerID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range s.reviewers[prID] {
+		if r == reviewerID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *fakeStore) ReplaceReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID, source string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reviewers := s.reviewers[prID]
+	for i, r := range reviewers {
+		if r == oldReviewerID {
+			reviewers[i] = newReviewerID
+		}
+	}
+	sort.Strings(reviewers)
+	delete(s.assignedAt, [2]string{prID, oldReviewerID})
+	delete(s.states, [2]string{prID, oldReviewerID})
+	delete(s.stateChangedAt, [2]string{prID, oldReviewerID})
+	delete(s.sources, [2]string{prID, oldReviewerID})
+	s.assignedAt[[2]string{prID, newReviewerID}] = time.Now().UTC()
+	s.sources[[2]string{prID, newReviewerID}] = source
+	return nil
+}
+
+func (s *fakeStore) RecordReviewerChange(ctx context.Context, change *models.ReviewerChange) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cp := *change
+	s.history = append(s.history, &cp)
+	return nil
+}
+
+func (s *fakeStore) GetReviewerHistory(ctx context.Context, prID string) ([]*models.ReviewerChange, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var changes []*models.ReviewerChange
+	for _, change := range s.history {
+		if change.PRId == prID {
+			cp := *change
+			changes = append(changes, &cp)
+		}
+	}
+	return changes, nil
+}
+
+func (s *fakeStore) FindUser(id string) *models.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if u, ok := s.users[id]; ok {
+		cp := *u
+		return &cp
+	}
+	return nil
+}
+
+func (s *fakeStore) FindByIDs(ctx context.Context, userIDs []string) ([]*models.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var users []*models.User
+	for _, id := range userIDs {
+		if u, ok := s.users[id]; ok {
+			cp := *u
+			users = append(users, &cp)
+		}
+	}
+	return users, nil
+}
+
+func (s *fakeStore) FindActiveCandidatesForReassignment(ctx context.Context, teamName string, excludeUserIDs []string) ([]*models.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	excluded := make(map[string]bool, len(excludeUserIDs))
+	for _, id := range excludeUserIDs {
+		excluded[id] = true
+	}
+	var users []*models.User
+	for _, u := range s.users {
+		if u.TeamName == teamName && u.IsActive && !u.NonReviewer && !excluded[u.Id] {
+			cp := *u
+			users = append(users, &cp)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Id < users[j].Id })
+	if s.afterCandidates != nil {
+		s.mu.Unlock()
+		s.afterCandidates()
+		s.mu.Lock()
+	}
+	return users, nil
+}
+
+func (s *fakeStore) GetTeamLead(ctx context.Context, teamName string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	leadID := s.leads[teamName]
+	if lead, ok := s.users[leadID]; !ok || lead.TeamName != teamName {
+		return "", nil
+	}
+	return leadID, nil
+}
+
+func (s *fakeStore) LockActiveCandidate(ctx context.Context, userID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.users[userID]
+	return ok && u.IsActive, nil
+}
+
+func (s *fakeStore) SetIsActive(ctx context.Context, userID string, isActive bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if u, ok := s.users[userID]; ok {
+		u.IsActive = isActive
+	}
+	return nil
+}
+
+func (s *fakeStore) GetExcludedReviewers(ctx context.Context, authorID string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var userIDs []string
+	for _, e := range s.exclusions {
+		for _, userID := range []string{e.ReviewerId, e.AuthorId} {
+			if userID != authorID && e.Excludes(userID, authorID) {
+				userIDs = append(userIDs, userID)
+			}
+		}
+	}
+	sort.Strings(userIDs)
+	return userIDs, nil
+}
+
+func (s *fakeStore) Upsert(ctx context.Context, exclusion *models.ReviewerExclusion) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.exclusions {
+		if e.ReviewerId == exclusion.ReviewerId && e.AuthorId == exclusion.AuthorId {
+			e.Mutual = exclusion.Mutual
+			exclusion.CreatedAt = e.CreatedAt
+			return nil
+		}
+	}
+	exclusion.CreatedAt = time.Now().UTC()
+	cp := *exclusion
+	s.exclusions = append(s.exclusions, &cp)
+	return nil
+}
+
+func (s *fakeStore) Delete(ctx context.Context, reviewerID, authorID string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	kept := s.exclusions[:0]
+	removed := 0
+	for _, e := range s.exclusions {
+		if (e.ReviewerId == reviewerID && e.AuthorId == authorID) ||
+			(e.Mutual && e.ReviewerId == authorID && e.AuthorId == reviewerID) {
+			removed++
+			continue
+		}
+		kept = append(kept, e)
+	}
+	s.exclusions = kept
+	return removed, nil
+}
+
+func (s *fakeStore) List(ctx context.Context, userID string) ([]*models.ReviewerExclusion, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var exclusions []*models.ReviewerExclusion
+	for _, e := range s.exclusions {
+		if userID == "" || e.ReviewerId == userID || e.AuthorId == userID {
+			cp := *e
+			exclusions = append(exclusions, &cp)
+		}
+	}
+	sort.Slice(exclusions, func(i, j int) bool {
+		if exclusions[i].ReviewerId != exclusions[j].ReviewerId {
+			return exclusions[i].ReviewerId < exclusions[j].ReviewerId
+		}
+		return exclusions[i].AuthorId < exclusions[j].AuthorId
+	})
+	return exclusions, nil
+}
+
+// fakeUsers adapts fakeStore to UserRepository, whose FindByID clashes with the PR repository one.
+type fakeUsers struct {
+	*fakeStore
+}
+
+func (u fakeUsers) FindByID(ctx context.Context, userID string) (*models.User, error) {
+	return u.FindUser(userID), nil
+}
+
+// CreateTeam performs the existence check and the upsert under the store lock,
+// mirroring the key of the team table in the postgres repository.
+func (s *fakeStore) CreateTeam(ctx context.Context, team *models.Team) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	teamName := team.GetTeamName()
+	for _, u := range s.users {
+		if u.TeamName == teamName {
+			return errors.NewTeamExists("team_name already exists")
+		}
+	}
+	for _, m := range team.Members {
+		cp := *m
+		s.users[m.Id] = &cp
+	}
+	return nil
+}
+
+func (s *fakeStore) GetTeamByName(ctx context.Context, teamName string) (*models.Team, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := &models.Team{}
+	for _, u := range s.users {
+		if u.TeamName == teamName {
+			cp := *u
+			t.Members = append(t.Members, &cp)
+		}
+	}
+	if len(t.Members) == 0 {
+		return nil, nil
+	}
+	sort.Slice(t.Members, func(i, j int) bool { return t.Members[i].Id < t.Members[j].Id })
+	return t, nil
+}
+
+func (s *fakeStore) ListTeams(ctx context.Context) ([]*models.Team, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	byTeam := make(map[string]*models.Team)
+	for _, u := range s.users {
+		if byTeam[u.TeamName] == nil {
+			byTeam[u.TeamName] = &models.Team{}
+		}
+		cp := *u
+		byTeam[u.TeamName].Members = append(byTeam[u.TeamName].Members, &cp)
+	}
+	teams := make([]*models.Team, 0, len(byTeam))
+	for _, t := range byTeam {
+		teams = append(teams, t)
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].GetTeamName() < teams[j].GetTeamName() })
+	return teams, nil
+}
+
+// reviewerChangeMatcher matches a recorded reviewer change regardless of its timestamp.
+func reviewerChangeMatcher(prID, oldReviewerID, newReviewerID, trigger string) gomock.Matcher {
+	return gomock.Cond(func(change *models.ReviewerChange) bool {
+		return change.PRId == prID && change.OldReviewerId == oldReviewerID &&
+			change.NewReviewerId == newReviewerID && change.Trigger == trigger && !change.ChangedAt.IsZero()
+	})
+}
+
+// memoryEnv wires in-memory storage for tests that run the services end to end.
+type memoryEnv struct {
+	ctx          context.Context
+	storage      *memory.Storage
+	prRepo       *memory.PullRequestRepository
+	reviewerRepo *memory.ReviewerRepository
+	userRepo     *memory.UserRepository
+	uow          *memory.UnitOfWork
+	logger       *slog.Logger
+}
+
+func newMemoryEnv() *memoryEnv {
+	storage := memory.NewStorage()
+	return &memoryEnv{
+		ctx:          context.Background(),
+		storage:      storage,
+		prRepo:       storage.NewPullRequestRepository(),
+		reviewerRepo: storage.NewReviewerRepository(),
+		userRepo:     storage.NewUserRepository(),
+		uow:          storage.NewUnitOfWork(),
+		logger:       slog.New(slog.DiscardHandler),
+	}
+}
+
+// team stores a team of active members named after their ids.
+func (e *memoryEnv) team(t *testing.T, teamName string, userIDs ...string) {
+	t.Helper()
+	team := &models.Team{}
+	for _, userID := range userIDs {
+		team.Members = append(team.Members, &models.User{Id: userID, Name: userID, TeamName: teamName, IsActive: true})
+	}
+	require.NoError(t, e.storage.NewTeamRepository().CreateTeam(e.ctx, team))
+}
+
+// pr stores an open PR created at createdAt, with the reviewers assigned automatically.
+func (e *memoryEnv) pr(t *testing.T, prID, authorID string, createdAt time.Time, reviewerIDs ...string) {
+	t.Helper()
+	require.NoError(t, e.prRepo.Create(e.ctx, &models.PullRequest{
+		Id: prID, Title: "PR " + prID, AuthorId: authorID, Status: models.PRStatusOpen,
+		CreatedAt: createdAt, UpdatedAt: createdAt, Priority: models.PRPriorityNormal, Labels: []string{},
+	}))
+	for _, reviewerID := range reviewerIDs {
+		require.NoError(t, e.reviewerRepo.AssignReviewer(e.ctx, prID, reviewerID, models.AssignmentSourceAuto))
+	}
+}
+
+func (e *memoryEnv) prService() *PullRequestService {
+	return NewPullRequestService(e.prRepo, e.reviewerRepo, e.userRepo, e.uow, testReview, e.logger)
+}
+
+func (e *memoryEnv) teamService() *TeamService {
+	return NewTeamService(e.storage.NewTeamRepository(), e.userRepo, e.prRepo, e.reviewerRepo, e.uow, testReview,
+		e.logger)
+}
+
+func (e *memoryEnv) statisticsService(cfg config.Statistics) *StatisticsService {
+	return NewStatisticsService(e.userRepo, e.prRepo, e.reviewerRepo, cfg, testReview, e.logger)
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/pullrequest"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/team"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/user"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/shirr9/pr-reviewer-service/internal/infrastructure/persistence/memory"
+	"pgregory.net/rapid"
+)
+
+// assignmentMachine runs random operations against the services wired to in-memory storage.
+type assignmentMachine struct {
+	ctx          context.Context
+	prService    *PullRequestService
+	userService  *UserService
+	teamService  *TeamService
+	prRepo       *memory.PullRequestRepository
+	reviewerRepo *memory.ReviewerRepository
+	userRepo     *memory.UserRepository
+
+	teamNames []string
+	userIDs   []string
+	prIDs     []string
+}
+
+func newAssignmentMachine(t *rapid.T) *assignmentMachine {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	storage := memory.NewStorage()
+	m := &assignmentMachine{
+		ctx:          context.Background(),
+		prRepo:       storage.NewPullRequestRepository(),
+		reviewerRepo: storage.NewReviewerRepository(),
+		userRepo:     storage.NewUserRepository(),
+	}
+	uow := storage.NewUnitOfWork()
+	m.prService = NewPullRequestService(m.prRepo, m.reviewerRepo, m.userRepo, uow, testReview, logger)
+	m.userService = NewUserService(m.userRepo, m.prRepo, m.reviewerRepo, testReview, logger)
+	m.teamService = NewTeamService(storage.NewTeamRepository(), m.userRepo, m.prRepo, m.reviewerRepo, uow,
+		testReview, logger)
+
+	teams := rapid.IntRange(1, 3).Draw(t, "teams")
+	for i := 0; i < teams; i++ {
+		req := team.AddTeamRequest{TeamName: fmt.Sprintf("team-%d", i)}
+		members := rapid.IntRange(1, 6).Draw(t, req.TeamName+" members")
+		for j := 0; j < members; j++ {
+			userID := fmt.Sprintf("u%d-%d", i, j)
+			req.Members = append(req.Members, team.TeamMember{
+				UserID:   userID,
+				Username: userID,
+				IsActive: rapid.Bool().Draw(t, userID+" active"),
+			})
+			m.userIDs = append(m.userIDs, userID)
+		}
+		if _, err := m.teamService.AddTeam(m.ctx, req); err != nil {
+			t.Fatalf("failed to add team %s: %v", req.TeamName, err)
+		}
+		m.teamNames = append(m.teamNames, req.TeamName)
+	}
+	return m
+}
+
+// CreatePR checks that new reviewers are active members of the author's team.
+func (m *assignmentMachine) CreatePR(t *rapid.T) {
+	req := pullrequest.CreatePrRequest{
+		PullRequestID:   fmt.Sprintf("pr-%d", len(m.prIDs)),
+		PullRequestName: "PR",
+		AuthorID:        rapid.SampledFrom(m.userIDs).Draw(t, "author"),
+	}
+	resp, err := m.prService.CreatePR(m.ctx, req)
+	if err != nil {
+		m.requireDomainError(t, "CreatePR", err)
+		return
+	}
+	m.prIDs = append(m.prIDs, req.PullRequestID)
+
+	author := m.user(t, req.AuthorID)
+	for _, reviewerID := range resp.Pr.AssignedReviewers {
+		reviewer := m.user(t, reviewerID)
+		if !reviewer.IsActive || reviewer.TeamName != author.TeamName {
+			t.Fatalf("%s got reviewer %s (active %v, team %s) for author of team %s",
+				req.PullRequestID, reviewerID, reviewer.IsActive, reviewer.TeamName, author.TeamName)
+		}
+	}
+}
+
+// Reassign checks that only the replaced reviewer changes, and that nothing changes on failure.
+func (m *assignmentMachine) Reassign(t *rapid.T) {
+	if len(m.prIDs) == 0 {
+		t.Skip("no PRs yet")
+	}
+	prID := rapid.SampledFrom(m.prIDs).Draw(t, "pr")
+	before := m.reviewers(t, prID)
+	// mostly pick an assigned reviewer, sometimes anybody to cover NOT_ASSIGNED
+	candidates := m.userIDs
+	if len(before) > 0 && rapid.IntRange(0, 3).Draw(t, "pick assigned") > 0 {
+		candidates = before
+	}
+	oldReviewerID := rapid.SampledFrom(candidates).Draw(t, "old reviewer")
+
+	resp, err := m.prService.ReassignReviewer(m.ctx, pullrequest.ReassignReviewerRequest{
+		PullRequestID: prID,
+		OldReviewerID: oldReviewerID,
+	})
+	after := m.reviewers(t, prID)
+	if err != nil {
+		m.requireDomainError(t, "ReassignReviewer", err)
+		if !slices.Equal(before, after) {
+			t.Fatalf("failed reassign of %s on %s changed reviewers %v to %v", oldReviewerID, prID, before, after)
+		}
+		return
+	}
+
+	want := make([]string, 0, len(before))
+	for _, reviewerID := range before {
+		if reviewerID != oldReviewerID {
+			want = append(want, reviewerID)
+		}
+	}
+	want = append(want, resp.ReplacedBy)
+	slices.Sort(want)
+	if resp.ReplacedBy == oldReviewerID || !slices.Equal(want, after) {
+		t.Fatalf("reassign of %s on %s by %s: reviewers %v became %v", oldReviewerID, prID, resp.ReplacedBy, before, after)
+	}
+}
+
+func (m *assignmentMachine) SetIsActive(t *rapid.T) {
+	req := user.SetIsActiveRequest{
+		UserID:   rapid.SampledFrom(m.userIDs).Draw(t, "user"),
+		IsActive: rapid.Bool().Draw(t, "active"),
+	}
+	if _, err := m.userService.SetIsActive(m.ctx, req); err != nil {
+		t.Fatalf("SetIsActive(%s, %v): %v", req.UserID, req.IsActive, err)
+	}
+}
+
+// DeactivateTeam checks that none of the team members keeps a review.
+func (m *assignmentMachine) DeactivateTeam(t *rapid.T) {
+	teamName := rapid.SampledFrom(m.teamNames).Draw(t, "team")
+	if _, err := m.teamService.DeactivateTeam(m.ctx, team.DeactivateTeamRequest{TeamName: teamName}); err != nil {
+		t.Fatalf("DeactivateTeam(%s): %v", teamName, err)
+	}
+	for _, prID := range m.prIDs {
+		for _, reviewerID := range m.reviewers(t, prID) {
+			if m.user(t, reviewerID).TeamName == teamName {
+				t.Fatalf("%s still reviews %s after deactivation of %s", reviewerID, prID, teamName)
+			}
+		}
+	}
+}
+
+// Check verifies the invariants of every PR after each operation.
+func (m *assignmentMachine) Check(t *rapid.T) {
+	for _, prID := range m.prIDs {
+		pr, err := m.prRepo.FindByID(m.ctx, prID)
+		if err != nil || pr == nil {
+			t.Fatalf("failed to find %s: %v", prID, err)
+		}
+		reviewers := m.reviewers(t, prID)
+		if len(reviewers) > models.MaxReviewers {
+			t.Fatalf("%s has %d reviewers %v, max is %d", prID, len(reviewers), reviewers, models.MaxReviewers)
+		}
+		if slices.Contains(reviewers, pr.AuthorId) {
+			t.Fatalf("author %s reviews own %s", pr.AuthorId, prID)
+		}
+		if len(slices.Compact(slices.Clone(reviewers))) != len(reviewers) {
+			t.Fatalf("%s has duplicate reviewers %v", prID, reviewers)
+		}
+	}
+}
+
+// reviewers returns the sorted reviewers of the PR.
+func (m *assignmentMachine) reviewers(t *rapid.T, prID string) []string {
+	reviewers, err := m.reviewerRepo.GetReviewers(m.ctx, prID)
+	if err != nil {
+		t.Fatalf("failed to get reviewers of %s: %v", prID, err)
+	}
+	slices.Sort(reviewers)
+	return reviewers
+}
+
+func (m *assignmentMachine) user(t *rapid.T, userID string) *models.User {
+	found, err := m.userRepo.FindByID(m.ctx, userID)
+	if err != nil || found == nil {
+		t.Fatalf("failed to find user %s: %v", userID, err)
+	}
+	return found
+}
+
+// requireDomainError fails on errors that would be reported as internal ones.
+func (m *assignmentMachine) requireDomainError(t *rapid.T, op string, err error) {
+	var appErr *errors.AppError
+	if !stderrors.As(err, &appErr) {
+		t.Fatalf("%s failed with a non-domain error: %v", op, err)
+	}
+}
+
+func TestPullRequestService_AssignmentInvariants(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		m := newAssignmentMachine(t)
+		t.Repeat(rapid.StateMachineActions(m))
+	})
+}
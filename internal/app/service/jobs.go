@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// JobScheduler defines the interface of the scheduler running the periodic background jobs.
+// Trigger must return NOT_FOUND AppError for an unknown job and JOB_RUNNING AppError while the job runs.
+type JobScheduler interface {
+	Jobs(ctx context.Context) ([]*models.ScheduledJob, error)
+	Trigger(ctx context.Context, name string) (*models.JobRun, error)
+}
+
+// JobService lists the periodic background jobs and runs them on demand.
+type JobService struct {
+	scheduler JobScheduler
+	log       *slog.Logger
+}
+
+// NewJobService creates a new job service.
+func NewJobService(scheduler JobScheduler, log *slog.Logger) *JobService {
+	if log == nil {
+		log = slog.Default()
+	}
+	return &JobService{scheduler: scheduler, log: log}
+}
+
+// ListJobs returns the jobs with their last runs.
+func (s *JobService) ListJobs(ctx context.Context) (*admin.JobsResponse, error) {
+	jobs, err := s.scheduler.Jobs(ctx)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to list jobs", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	response := &admin.JobsResponse{Jobs: make([]admin.Job, 0, len(jobs))}
+	for _, job := range jobs {
+		item := admin.Job{
+			Name:            job.Name,
+			IntervalSeconds: job.Interval.Seconds(),
+			Exclusive:       job.Exclusive,
+		}
+		if job.LastRun != nil {
+			run := toJobRun(job.LastRun)
+			item.LastRun = &run
+		}
+		response.Jobs = append(response.Jobs, item)
+	}
+	return response, nil
+}
+
+// RunJob runs the job now and waits for the run to finish.
+// Returns NOT_FOUND AppError for an unknown job and JOB_RUNNING AppError while it runs already.
+func (s *JobService) RunJob(ctx context.Context, req admin.RunJobRequest) (*admin.RunJobResponse, error) {
+	run, err := s.scheduler.Trigger(ctx, req.Name)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to run job",
+			slog.String("job", req.Name), slog.String("error", err.Error()))
+		return nil, err
+	}
+	s.log.LogAttrs(ctx, slog.LevelInfo, "job run on demand",
+		slog.String("job", req.Name), slog.Bool("failed", run.Error != ""))
+	return &admin.RunJobResponse{Run: toJobRun(run)}, nil
+}
+
+func toJobRun(run *models.JobRun) admin.JobRun {
+	return admin.JobRun{
+		StartedAt:     dto.FormatTime(run.StartedAt),
+		FinishedAt:    dto.FormatTime(run.FinishedAt),
+		Error:         run.Error,
+		LastSuccessAt: dto.FormatTimePtr(run.LastSuccessAt),
+	}
+}
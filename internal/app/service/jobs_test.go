@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeScheduler struct {
+	jobs []*models.ScheduledJob
+	runs map[string]*models.JobRun
+}
+
+func (s *fakeScheduler) Jobs(ctx context.Context) ([]*models.ScheduledJob, error) {
+	return s.jobs, nil
+}
+
+func (s *fakeScheduler) Trigger(ctx context.Context, name string) (*models.JobRun, error) {
+	run, ok := s.runs[name]
+	if !ok {
+		return nil, errors.NewNotFound("job not found")
+	}
+	return run, nil
+}
+
+func TestJobService(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	ctx := context.Background()
+	startedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	finishedAt := startedAt.Add(2 * time.Second)
+	succeeded := &models.JobRun{Name: "escalation", StartedAt: startedAt, FinishedAt: finishedAt, LastSuccessAt: &finishedAt}
+	failed := &models.JobRun{Name: "webhook", StartedAt: startedAt, FinishedAt: finishedAt, Error: "receiver is down"}
+	service := NewJobService(&fakeScheduler{
+		jobs: []*models.ScheduledJob{
+			{Name: "escalation", Interval: 5 * time.Minute, Exclusive: true, LastRun: succeeded},
+			{Name: "metrics", Interval: 30 * time.Second},
+		},
+		runs: map[string]*models.JobRun{"webhook": failed},
+	}, logger)
+
+	t.Run("Success - Jobs are listed with their last runs", func(t *testing.T) {
+		resp, err := service.ListJobs(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []admin.Job{
+			{Name: "escalation", IntervalSeconds: 300, Exclusive: true, LastRun: &admin.JobRun{
+				StartedAt: "2026-03-01T12:00:00Z", FinishedAt: "2026-03-01T12:00:02Z",
+				LastSuccessAt: "2026-03-01T12:00:02Z",
+			}},
+			{Name: "metrics", IntervalSeconds: 30},
+		}, resp.Jobs)
+	})
+
+	t.Run("Success - Failed run is reported", func(t *testing.T) {
+		resp, err := service.RunJob(ctx, admin.RunJobRequest{Name: "webhook"})
+
+		require.NoError(t, err)
+		assert.Equal(t, admin.JobRun{
+			StartedAt: "2026-03-01T12:00:00Z", FinishedAt: "2026-03-01T12:00:02Z", Error: "receiver is down",
+		}, resp.Run)
+	})
+
+	t.Run("Error - Unknown job", func(t *testing.T) {
+		_, err := service.RunJob(ctx, admin.RunJobRequest{Name: "digest"})
+
+		assert.True(t, errors.HasCode(err, errors.CodeNotFound))
+	})
+}
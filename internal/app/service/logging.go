@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"log/slog"
+)
+
+// errorLevel is the level a failed operation is logged at. A request canceled by its client, like
+// nginx's 499, is no failure of the service and is logged at Info instead of Error.
+func errorLevel(err error) slog.Level {
+	if stderrors.Is(err, context.Canceled) {
+		return slog.LevelInfo
+	}
+	return slog.LevelError
+}
+
+// isContextError reports whether err comes from a canceled or timed out context.
+func isContextError(err error) bool {
+	return stderrors.Is(err, context.Canceled) || stderrors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dbctx"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// MetricsRepository defines the interface for reading the counts behind the business metrics.
+type MetricsRepository interface {
+	GetBusinessMetrics(ctx context.Context) (*models.BusinessMetrics, error)
+}
+
+// MetricsService reads the counts behind the business metrics.
+type MetricsService struct {
+	repo MetricsRepository
+	log  *slog.Logger
+}
+
+// NewMetricsService creates a new metrics service.
+func NewMetricsService(repo MetricsRepository, log *slog.Logger) *MetricsService {
+	if log == nil {
+		log = slog.Default()
+	}
+	return &MetricsService{repo: repo, log: log}
+}
+
+// GetBusinessMetrics counts the open PRs of every team, the active users and the assignments on open
+// PRs. The counts are read-only and may be served by a replica.
+func (s *MetricsService) GetBusinessMetrics(ctx context.Context) (*models.BusinessMetrics, error) {
+	ctx = dbctx.ReadOnly(ctx)
+	metrics, err := s.repo.GetBusinessMetrics(ctx)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get business metrics", slog.String("error", err.Error()))
+		return nil, err
+	}
+	return metrics, nil
+}
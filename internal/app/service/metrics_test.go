@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/shirr9/pr-reviewer-service/internal/infrastructure/persistence/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsService_GetBusinessMetrics(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	ctx := context.Background()
+	storage := memory.NewStorage()
+	require.NoError(t, storage.NewTeamRepository().CreateTeam(ctx, &models.Team{Members: []*models.User{
+		{Id: "u1", Name: "u1", TeamName: "backend", IsActive: true},
+		{Id: "u2", Name: "u2", TeamName: "backend", IsActive: true},
+		{Id: "u3", Name: "u3", TeamName: "backend", IsActive: false},
+	}}))
+	require.NoError(t, storage.NewTeamRepository().CreateTeam(ctx, &models.Team{Members: []*models.User{
+		{Id: "f1", Name: "f1", TeamName: "frontend", IsActive: true},
+	}}))
+	now := time.Now().UTC()
+	prs := []*models.PullRequest{
+		{Id: "pr-1", AuthorId: "u1", Status: models.PRStatusOpen, CreatedAt: now},
+		{Id: "pr-2", AuthorId: "u2", Status: models.PRStatusOpen, CreatedAt: now},
+		{Id: "pr-3", AuthorId: "f1", Status: models.PRStatusOpen, CreatedAt: now},
+		{Id: "pr-4", AuthorId: "u1", Status: models.PRStatusMerged, CreatedAt: now, MergedAt: &now},
+	}
+	for _, pr := range prs {
+		pr.Title, pr.Priority = pr.Id, models.PRPriorityNormal
+	}
+	dump := storage.NewDumpRepository()
+	require.NoError(t, dump.InsertPullRequests(ctx, prs))
+	assignment := func(prID string) *models.ReviewAssignment {
+		return &models.ReviewAssignment{PRId: prID, ReviewerId: "u2", AssignedAt: now,
+			Source: models.AssignmentSourceAuto, State: models.ReviewStatePending}
+	}
+	require.NoError(t, dump.InsertAssignments(ctx, []*models.ReviewAssignment{assignment("pr-1"), assignment("pr-4")}))
+	service := NewMetricsService(storage.NewMetricsRepository(), logger)
+
+	metrics, err := service.GetBusinessMetrics(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, []models.TeamPRCounts{
+		{TeamName: "backend", OpenPRs: 2, WithoutReviewers: 1},
+		{TeamName: "frontend", OpenPRs: 1, WithoutReviewers: 1},
+	}, metrics.Teams)
+	assert.Equal(t, 3, metrics.ActiveUsers)
+	assert.Equal(t, 1, metrics.ActiveAssignments)
+}
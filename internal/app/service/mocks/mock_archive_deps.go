@@ -0,0 +1,151 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: archive.go
+//
+// Generated by this command:
+//
+//	mockgen -source=archive.go -destination=mocks/mock_archive_deps.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArchiveRepository is a mock of ArchiveRepository interface.
+type MockArchiveRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockArchiveRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockArchiveRepositoryMockRecorder is the mock recorder for MockArchiveRepository.
+type MockArchiveRepositoryMockRecorder struct {
+	mock *MockArchiveRepository
+}
+
+// NewMockArchiveRepository creates a new mock instance.
+func NewMockArchiveRepository(ctrl *gomock.Controller) *MockArchiveRepository {
+	mock := &MockArchiveRepository{ctrl: ctrl}
+	mock.recorder = &MockArchiveRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArchiveRepository) EXPECT() *MockArchiveRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ArchiveMerged mocks base method.
+func (m *MockArchiveRepository) ArchiveMerged(ctx context.Context, mergedBefore time.Time, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveMerged", ctx, mergedBefore, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveMerged indicates an expected call of ArchiveMerged.
+func (mr *MockArchiveRepositoryMockRecorder) ArchiveMerged(ctx, mergedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveMerged", reflect.TypeOf((*MockArchiveRepository)(nil).ArchiveMerged), ctx, mergedBefore, limit)
+}
+
+// Restore mocks base method.
+func (m *MockArchiveRepository) Restore(ctx context.Context, prID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, prID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockArchiveRepositoryMockRecorder) Restore(ctx, prID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockArchiveRepository)(nil).Restore), ctx, prID)
+}
+
+// MockArchivePRRepository is a mock of ArchivePRRepository interface.
+type MockArchivePRRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockArchivePRRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockArchivePRRepositoryMockRecorder is the mock recorder for MockArchivePRRepository.
+type MockArchivePRRepositoryMockRecorder struct {
+	mock *MockArchivePRRepository
+}
+
+// NewMockArchivePRRepository creates a new mock instance.
+func NewMockArchivePRRepository(ctrl *gomock.Controller) *MockArchivePRRepository {
+	mock := &MockArchivePRRepository{ctrl: ctrl}
+	mock.recorder = &MockArchivePRRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArchivePRRepository) EXPECT() *MockArchivePRRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByID mocks base method.
+func (m *MockArchivePRRepository) FindByID(ctx context.Context, prID string) (*models.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, prID)
+	ret0, _ := ret[0].(*models.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockArchivePRRepositoryMockRecorder) FindByID(ctx, prID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockArchivePRRepository)(nil).FindByID), ctx, prID)
+}
+
+// MockArchiveReviewerRepository is a mock of ArchiveReviewerRepository interface.
+type MockArchiveReviewerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockArchiveReviewerRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockArchiveReviewerRepositoryMockRecorder is the mock recorder for MockArchiveReviewerRepository.
+type MockArchiveReviewerRepositoryMockRecorder struct {
+	mock *MockArchiveReviewerRepository
+}
+
+// NewMockArchiveReviewerRepository creates a new mock instance.
+func NewMockArchiveReviewerRepository(ctrl *gomock.Controller) *MockArchiveReviewerRepository {
+	mock := &MockArchiveReviewerRepository{ctrl: ctrl}
+	mock.recorder = &MockArchiveReviewerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArchiveReviewerRepository) EXPECT() *MockArchiveReviewerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetReviewers mocks base method.
+func (m *MockArchiveReviewerRepository) GetReviewers(ctx context.Context, prID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewers", ctx, prID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewers indicates an expected call of GetReviewers.
+func (mr *MockArchiveReviewerRepositoryMockRecorder) GetReviewers(ctx, prID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewers", reflect.TypeOf((*MockArchiveReviewerRepository)(nil).GetReviewers), ctx, prID)
+}
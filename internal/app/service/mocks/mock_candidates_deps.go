@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: candidates.go
+//
+// Generated by this command:
+//
+//	mockgen -source=candidates.go -destination=mocks/mock_candidates_deps.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCandidateRepository is a mock of CandidateRepository interface.
+type MockCandidateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCandidateRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCandidateRepositoryMockRecorder is the mock recorder for MockCandidateRepository.
+type MockCandidateRepositoryMockRecorder struct {
+	mock *MockCandidateRepository
+}
+
+// NewMockCandidateRepository creates a new mock instance.
+func NewMockCandidateRepository(ctrl *gomock.Controller) *MockCandidateRepository {
+	mock := &MockCandidateRepository{ctrl: ctrl}
+	mock.recorder = &MockCandidateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCandidateRepository) EXPECT() *MockCandidateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindActiveCandidatesForReassignment mocks base method.
+func (m *MockCandidateRepository) FindActiveCandidatesForReassignment(ctx context.Context, teamName string, excludeUserIDs []string) ([]*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveCandidatesForReassignment", ctx, teamName, excludeUserIDs)
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveCandidatesForReassignment indicates an expected call of FindActiveCandidatesForReassignment.
+func (mr *MockCandidateRepositoryMockRecorder) FindActiveCandidatesForReassignment(ctx, teamName, excludeUserIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveCandidatesForReassignment", reflect.TypeOf((*MockCandidateRepository)(nil).FindActiveCandidatesForReassignment), ctx, teamName, excludeUserIDs)
+}
+
+// LockActiveCandidate mocks base method.
+func (m *MockCandidateRepository) LockActiveCandidate(ctx context.Context, userID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockActiveCandidate", ctx, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockActiveCandidate indicates an expected call of LockActiveCandidate.
+func (mr *MockCandidateRepositoryMockRecorder) LockActiveCandidate(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockActiveCandidate", reflect.TypeOf((*MockCandidateRepository)(nil).LockActiveCandidate), ctx, userID)
+}
+
+// MockExclusionLookup is a mock of ExclusionLookup interface.
+type MockExclusionLookup struct {
+	ctrl     *gomock.Controller
+	recorder *MockExclusionLookupMockRecorder
+	isgomock struct{}
+}
+
+// MockExclusionLookupMockRecorder is the mock recorder for MockExclusionLookup.
+type MockExclusionLookupMockRecorder struct {
+	mock *MockExclusionLookup
+}
+
+// NewMockExclusionLookup creates a new mock instance.
+func NewMockExclusionLookup(ctrl *gomock.Controller) *MockExclusionLookup {
+	mock := &MockExclusionLookup{ctrl: ctrl}
+	mock.recorder = &MockExclusionLookupMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExclusionLookup) EXPECT() *MockExclusionLookupMockRecorder {
+	return m.recorder
+}
+
+// GetExcludedReviewers mocks base method.
+func (m *MockExclusionLookup) GetExcludedReviewers(ctx context.Context, authorID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExcludedReviewers", ctx, authorID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExcludedReviewers indicates an expected call of GetExcludedReviewers.
+func (mr *MockExclusionLookupMockRecorder) GetExcludedReviewers(ctx, authorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExcludedReviewers", reflect.TypeOf((*MockExclusionLookup)(nil).GetExcludedReviewers), ctx, authorID)
+}
@@ -0,0 +1,183 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: dump.go
+//
+// Generated by this command:
+//
+//	mockgen -source=dump.go -destination=mocks/mock_dump_deps.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDumpRepository is a mock of DumpRepository interface.
+type MockDumpRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDumpRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDumpRepositoryMockRecorder is the mock recorder for MockDumpRepository.
+type MockDumpRepositoryMockRecorder struct {
+	mock *MockDumpRepository
+}
+
+// NewMockDumpRepository creates a new mock instance.
+func NewMockDumpRepository(ctrl *gomock.Controller) *MockDumpRepository {
+	mock := &MockDumpRepository{ctrl: ctrl}
+	mock.recorder = &MockDumpRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDumpRepository) EXPECT() *MockDumpRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Clear mocks base method.
+func (m *MockDumpRepository) Clear(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockDumpRepositoryMockRecorder) Clear(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockDumpRepository)(nil).Clear), ctx)
+}
+
+// EachAssignment mocks base method.
+func (m *MockDumpRepository) EachAssignment(ctx context.Context, fn func(*models.ReviewAssignment) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EachAssignment", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EachAssignment indicates an expected call of EachAssignment.
+func (mr *MockDumpRepositoryMockRecorder) EachAssignment(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EachAssignment", reflect.TypeOf((*MockDumpRepository)(nil).EachAssignment), ctx, fn)
+}
+
+// EachPullRequest mocks base method.
+func (m *MockDumpRepository) EachPullRequest(ctx context.Context, fn func(*models.PullRequest) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EachPullRequest", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EachPullRequest indicates an expected call of EachPullRequest.
+func (mr *MockDumpRepositoryMockRecorder) EachPullRequest(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EachPullRequest", reflect.TypeOf((*MockDumpRepository)(nil).EachPullRequest), ctx, fn)
+}
+
+// EachTeam mocks base method.
+func (m *MockDumpRepository) EachTeam(ctx context.Context, fn func(*models.TeamRecord) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EachTeam", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EachTeam indicates an expected call of EachTeam.
+func (mr *MockDumpRepositoryMockRecorder) EachTeam(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EachTeam", reflect.TypeOf((*MockDumpRepository)(nil).EachTeam), ctx, fn)
+}
+
+// EachUser mocks base method.
+func (m *MockDumpRepository) EachUser(ctx context.Context, fn func(*models.User) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EachUser", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EachUser indicates an expected call of EachUser.
+func (mr *MockDumpRepositoryMockRecorder) EachUser(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EachUser", reflect.TypeOf((*MockDumpRepository)(nil).EachUser), ctx, fn)
+}
+
+// InsertAssignments mocks base method.
+func (m *MockDumpRepository) InsertAssignments(ctx context.Context, assignments []*models.ReviewAssignment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAssignments", ctx, assignments)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertAssignments indicates an expected call of InsertAssignments.
+func (mr *MockDumpRepositoryMockRecorder) InsertAssignments(ctx, assignments any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAssignments", reflect.TypeOf((*MockDumpRepository)(nil).InsertAssignments), ctx, assignments)
+}
+
+// InsertPullRequests mocks base method.
+func (m *MockDumpRepository) InsertPullRequests(ctx context.Context, prs []*models.PullRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertPullRequests", ctx, prs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertPullRequests indicates an expected call of InsertPullRequests.
+func (mr *MockDumpRepositoryMockRecorder) InsertPullRequests(ctx, prs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPullRequests", reflect.TypeOf((*MockDumpRepository)(nil).InsertPullRequests), ctx, prs)
+}
+
+// InsertTeams mocks base method.
+func (m *MockDumpRepository) InsertTeams(ctx context.Context, teams []*models.TeamRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertTeams", ctx, teams)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertTeams indicates an expected call of InsertTeams.
+func (mr *MockDumpRepositoryMockRecorder) InsertTeams(ctx, teams any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertTeams", reflect.TypeOf((*MockDumpRepository)(nil).InsertTeams), ctx, teams)
+}
+
+// InsertUsers mocks base method.
+func (m *MockDumpRepository) InsertUsers(ctx context.Context, users []*models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertUsers", ctx, users)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertUsers indicates an expected call of InsertUsers.
+func (mr *MockDumpRepositoryMockRecorder) InsertUsers(ctx, users any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUsers", reflect.TypeOf((*MockDumpRepository)(nil).InsertUsers), ctx, users)
+}
+
+// IsEmpty mocks base method.
+func (m *MockDumpRepository) IsEmpty(ctx context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsEmpty", ctx)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsEmpty indicates an expected call of IsEmpty.
+func (mr *MockDumpRepositoryMockRecorder) IsEmpty(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEmpty", reflect.TypeOf((*MockDumpRepository)(nil).IsEmpty), ctx)
+}
@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: exclusion.go
+//
+// Generated by this command:
+//
+//	mockgen -source=exclusion.go -destination=mocks/mock_exclusion_deps.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockExclusionRepository is a mock of ExclusionRepository interface.
+type MockExclusionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockExclusionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockExclusionRepositoryMockRecorder is the mock recorder for MockExclusionRepository.
+type MockExclusionRepositoryMockRecorder struct {
+	mock *MockExclusionRepository
+}
+
+// NewMockExclusionRepository creates a new mock instance.
+func NewMockExclusionRepository(ctrl *gomock.Controller) *MockExclusionRepository {
+	mock := &MockExclusionRepository{ctrl: ctrl}
+	mock.recorder = &MockExclusionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExclusionRepository) EXPECT() *MockExclusionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockExclusionRepository) Delete(ctx context.Context, reviewerID, authorID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, reviewerID, authorID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockExclusionRepositoryMockRecorder) Delete(ctx, reviewerID, authorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockExclusionRepository)(nil).Delete), ctx, reviewerID, authorID)
+}
+
+// List mocks base method.
+func (m *MockExclusionRepository) List(ctx context.Context, userID string) ([]*models.ReviewerExclusion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, userID)
+	ret0, _ := ret[0].([]*models.ReviewerExclusion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockExclusionRepositoryMockRecorder) List(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockExclusionRepository)(nil).List), ctx, userID)
+}
+
+// Upsert mocks base method.
+func (m *MockExclusionRepository) Upsert(ctx context.Context, exclusion *models.ReviewerExclusion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, exclusion)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockExclusionRepositoryMockRecorder) Upsert(ctx, exclusion any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockExclusionRepository)(nil).Upsert), ctx, exclusion)
+}
+
+// MockExclusionUserRepository is a mock of ExclusionUserRepository interface.
+type MockExclusionUserRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockExclusionUserRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockExclusionUserRepositoryMockRecorder is the mock recorder for MockExclusionUserRepository.
+type MockExclusionUserRepositoryMockRecorder struct {
+	mock *MockExclusionUserRepository
+}
+
+// NewMockExclusionUserRepository creates a new mock instance.
+func NewMockExclusionUserRepository(ctrl *gomock.Controller) *MockExclusionUserRepository {
+	mock := &MockExclusionUserRepository{ctrl: ctrl}
+	mock.recorder = &MockExclusionUserRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExclusionUserRepository) EXPECT() *MockExclusionUserRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByIDs mocks base method.
+func (m *MockExclusionUserRepository) FindByIDs(ctx context.Context, userIDs []string) ([]*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ctx, userIDs)
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockExclusionUserRepositoryMockRecorder) FindByIDs(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockExclusionUserRepository)(nil).FindByIDs), ctx, userIDs)
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: internal/app/service/pull_request.go
+// Source: pull_request.go
 //
 // Generated by this command:
 //
-//	mockgen -source=internal/app/service/pull_request.go -destination=internal/app/service/mocks/mock_pull_request_deps.go -package=mocks
+//	mockgen -source=pull_request.go -destination=mocks/mock_pull_request_deps.go -package=mocks
 //
 
 // Package mocks is a generated GoMock package.
@@ -42,6 +42,50 @@ func (m *MockPullRequestRepository) EXPECT() *MockPullRequestRepositoryMockRecor
 	return m.recorder
 }
 
+// ClearAssignmentSkipped mocks base method.
+func (m *MockPullRequestRepository) ClearAssignmentSkipped(ctx context.Context, prID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearAssignmentSkipped", ctx, prID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearAssignmentSkipped indicates an expected call of ClearAssignmentSkipped.
+func (mr *MockPullRequestRepositoryMockRecorder) ClearAssignmentSkipped(ctx, prID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearAssignmentSkipped", reflect.TypeOf((*MockPullRequestRepository)(nil).ClearAssignmentSkipped), ctx, prID)
+}
+
+// CountByTitle mocks base method.
+func (m *MockPullRequestRepository) CountByTitle(ctx context.Context, query, status string, labels []string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByTitle", ctx, query, status, labels)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByTitle indicates an expected call of CountByTitle.
+func (mr *MockPullRequestRepositoryMockRecorder) CountByTitle(ctx, query, status, labels any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByTitle", reflect.TypeOf((*MockPullRequestRepository)(nil).CountByTitle), ctx, query, status, labels)
+}
+
+// CountOpenWithoutReviewers mocks base method.
+func (m *MockPullRequestRepository) CountOpenWithoutReviewers(ctx context.Context, teamName string, labels []string, skipped *bool) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpenWithoutReviewers", ctx, teamName, labels, skipped)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpenWithoutReviewers indicates an expected call of CountOpenWithoutReviewers.
+func (mr *MockPullRequestRepositoryMockRecorder) CountOpenWithoutReviewers(ctx, teamName, labels, skipped any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpenWithoutReviewers", reflect.TypeOf((*MockPullRequestRepository)(nil).CountOpenWithoutReviewers), ctx, teamName, labels, skipped)
+}
+
 // Create mocks base method.
 func (m *MockPullRequestRepository) Create(ctx context.Context, pr *models.PullRequest) error {
 	m.ctrl.T.Helper()
@@ -56,6 +100,21 @@ func (mr *MockPullRequestRepositoryMockRecorder) Create(ctx, pr any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPullRequestRepository)(nil).Create), ctx, pr)
 }
 
+// CreateBatch mocks base method.
+func (m *MockPullRequestRepository) CreateBatch(ctx context.Context, prs []*models.PullRequest) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", ctx, prs)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockPullRequestRepositoryMockRecorder) CreateBatch(ctx, prs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockPullRequestRepository)(nil).CreateBatch), ctx, prs)
+}
+
 // Exists mocks base method.
 func (m *MockPullRequestRepository) Exists(ctx context.Context, prID string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -86,18 +145,78 @@ func (mr *MockPullRequestRepositoryMockRecorder) FindByID(ctx, prID any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockPullRequestRepository)(nil).FindByID), ctx, prID)
 }
 
-// UpdateStatus mocks base method.
-func (m *MockPullRequestRepository) UpdateStatus(ctx context.Context, prID, status string, mergedAt *time.Time) error {
+// FindOpenPRsByReviewers mocks base method.
+func (m *MockPullRequestRepository) FindOpenPRsByReviewers(ctx context.Context, reviewerIDs []string) ([]*models.PullRequest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateStatus", ctx, prID, status, mergedAt)
+	ret := m.ctrl.Call(m, "FindOpenPRsByReviewers", ctx, reviewerIDs)
+	ret0, _ := ret[0].([]*models.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOpenPRsByReviewers indicates an expected call of FindOpenPRsByReviewers.
+func (mr *MockPullRequestRepositoryMockRecorder) FindOpenPRsByReviewers(ctx, reviewerIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOpenPRsByReviewers", reflect.TypeOf((*MockPullRequestRepository)(nil).FindOpenPRsByReviewers), ctx, reviewerIDs)
+}
+
+// FindOpenWithoutReviewers mocks base method.
+func (m *MockPullRequestRepository) FindOpenWithoutReviewers(ctx context.Context, teamName string, labels []string, skipped *bool, order models.PRSort, limit, offset int) ([]*models.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOpenWithoutReviewers", ctx, teamName, labels, skipped, order, limit, offset)
+	ret0, _ := ret[0].([]*models.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOpenWithoutReviewers indicates an expected call of FindOpenWithoutReviewers.
+func (mr *MockPullRequestRepositoryMockRecorder) FindOpenWithoutReviewers(ctx, teamName, labels, skipped, order, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOpenWithoutReviewers", reflect.TypeOf((*MockPullRequestRepository)(nil).FindOpenWithoutReviewers), ctx, teamName, labels, skipped, order, limit, offset)
+}
+
+// SearchByTitle mocks base method.
+func (m *MockPullRequestRepository) SearchByTitle(ctx context.Context, query, status string, labels []string, order models.PRSort, limit, offset int) ([]*models.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchByTitle", ctx, query, status, labels, order, limit, offset)
+	ret0, _ := ret[0].([]*models.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchByTitle indicates an expected call of SearchByTitle.
+func (mr *MockPullRequestRepositoryMockRecorder) SearchByTitle(ctx, query, status, labels, order, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByTitle", reflect.TypeOf((*MockPullRequestRepository)(nil).SearchByTitle), ctx, query, status, labels, order, limit, offset)
+}
+
+// SetLabels mocks base method.
+func (m *MockPullRequestRepository) SetLabels(ctx context.Context, prID string, labels []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLabels", ctx, prID, labels)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// SetLabels indicates an expected call of SetLabels.
+func (mr *MockPullRequestRepositoryMockRecorder) SetLabels(ctx, prID, labels any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLabels", reflect.TypeOf((*MockPullRequestRepository)(nil).SetLabels), ctx, prID, labels)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockPullRequestRepository) UpdateStatus(ctx context.Context, prID, fromStatus, status string, mergedAt *time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, prID, fromStatus, status, mergedAt)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // UpdateStatus indicates an expected call of UpdateStatus.
-func (mr *MockPullRequestRepositoryMockRecorder) UpdateStatus(ctx, prID, status, mergedAt any) *gomock.Call {
+func (mr *MockPullRequestRepositoryMockRecorder) UpdateStatus(ctx, prID, fromStatus, status, mergedAt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockPullRequestRepository)(nil).UpdateStatus), ctx, prID, status, mergedAt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockPullRequestRepository)(nil).UpdateStatus), ctx, prID, fromStatus, status, mergedAt)
 }
 
 // MockReviewerRepository is a mock of ReviewerRepository interface.
@@ -125,17 +244,77 @@ func (m *MockReviewerRepository) EXPECT() *MockReviewerRepositoryMockRecorder {
 }
 
 // AssignReviewer mocks base method.
-func (m *MockReviewerRepository) AssignReviewer(ctx context.Context, prID, reviewerID string) error {
+func (m *MockReviewerRepository) AssignReviewer(ctx context.Context, prID, reviewerID, source string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AssignReviewer", ctx, prID, reviewerID)
+	ret := m.ctrl.Call(m, "AssignReviewer", ctx, prID, reviewerID, source)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AssignReviewer indicates an expected call of AssignReviewer.
-func (mr *MockReviewerRepositoryMockRecorder) AssignReviewer(ctx, prID, reviewerID any) *gomock.Call {
+func (mr *MockReviewerRepositoryMockRecorder) AssignReviewer(ctx, prID, reviewerID, source any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignReviewer", reflect.TypeOf((*MockReviewerRepository)(nil).AssignReviewer), ctx, prID, reviewerID, source)
+}
+
+// FindStaleAssignments mocks base method.
+func (m *MockReviewerRepository) FindStaleAssignments(ctx context.Context, assignedBefore time.Time, limit int) ([]*models.ReviewAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindStaleAssignments", ctx, assignedBefore, limit)
+	ret0, _ := ret[0].([]*models.ReviewAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindStaleAssignments indicates an expected call of FindStaleAssignments.
+func (mr *MockReviewerRepositoryMockRecorder) FindStaleAssignments(ctx, assignedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStaleAssignments", reflect.TypeOf((*MockReviewerRepository)(nil).FindStaleAssignments), ctx, assignedBefore, limit)
+}
+
+// GetAssignmentTimes mocks base method.
+func (m *MockReviewerRepository) GetAssignmentTimes(ctx context.Context, userIDs []string, since time.Time) (map[string][]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignmentTimes", ctx, userIDs, since)
+	ret0, _ := ret[0].(map[string][]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssignmentTimes indicates an expected call of GetAssignmentTimes.
+func (mr *MockReviewerRepositoryMockRecorder) GetAssignmentTimes(ctx, userIDs, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignmentTimes", reflect.TypeOf((*MockReviewerRepository)(nil).GetAssignmentTimes), ctx, userIDs, since)
+}
+
+// GetAssignmentsByPRs mocks base method.
+func (m *MockReviewerRepository) GetAssignmentsByPRs(ctx context.Context, prIDs []string) ([]*models.ReviewAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignmentsByPRs", ctx, prIDs)
+	ret0, _ := ret[0].([]*models.ReviewAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssignmentsByPRs indicates an expected call of GetAssignmentsByPRs.
+func (mr *MockReviewerRepositoryMockRecorder) GetAssignmentsByPRs(ctx, prIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignmentsByPRs", reflect.TypeOf((*MockReviewerRepository)(nil).GetAssignmentsByPRs), ctx, prIDs)
+}
+
+// GetOpenReviewCounts mocks base method.
+func (m *MockReviewerRepository) GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenReviewCounts", ctx, userIDs)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenReviewCounts indicates an expected call of GetOpenReviewCounts.
+func (mr *MockReviewerRepositoryMockRecorder) GetOpenReviewCounts(ctx, userIDs any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignReviewer", reflect.TypeOf((*MockReviewerRepository)(nil).AssignReviewer), ctx, prID, reviewerID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenReviewCounts", reflect.TypeOf((*MockReviewerRepository)(nil).GetOpenReviewCounts), ctx, userIDs)
 }
 
 // GetPRsByReviewer mocks base method.
@@ -153,6 +332,21 @@ func (mr *MockReviewerRepositoryMockRecorder) GetPRsByReviewer(ctx, reviewerID a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPRsByReviewer", reflect.TypeOf((*MockReviewerRepository)(nil).GetPRsByReviewer), ctx, reviewerID)
 }
 
+// GetReviewerHistory mocks base method.
+func (m *MockReviewerRepository) GetReviewerHistory(ctx context.Context, prID string) ([]*models.ReviewerChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewerHistory", ctx, prID)
+	ret0, _ := ret[0].([]*models.ReviewerChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewerHistory indicates an expected call of GetReviewerHistory.
+func (mr *MockReviewerRepositoryMockRecorder) GetReviewerHistory(ctx, prID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewerHistory", reflect.TypeOf((*MockReviewerRepository)(nil).GetReviewerHistory), ctx, prID)
+}
+
 // GetReviewers mocks base method.
 func (m *MockReviewerRepository) GetReviewers(ctx context.Context, prID string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -168,6 +362,21 @@ func (mr *MockReviewerRepositoryMockRecorder) GetReviewers(ctx, prID any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewers", reflect.TypeOf((*MockReviewerRepository)(nil).GetReviewers), ctx, prID)
 }
 
+// GetReviewersByPRs mocks base method.
+func (m *MockReviewerRepository) GetReviewersByPRs(ctx context.Context, prIDs []string) (map[string][]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewersByPRs", ctx, prIDs)
+	ret0, _ := ret[0].(map[string][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewersByPRs indicates an expected call of GetReviewersByPRs.
+func (mr *MockReviewerRepositoryMockRecorder) GetReviewersByPRs(ctx, prIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewersByPRs", reflect.TypeOf((*MockReviewerRepository)(nil).GetReviewersByPRs), ctx, prIDs)
+}
+
 // IsAssigned mocks base method.
 func (m *MockReviewerRepository) IsAssigned(ctx context.Context, prID, reviewerID string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -183,18 +392,61 @@ func (mr *MockReviewerRepositoryMockRecorder) IsAssigned(ctx, prID, reviewerID a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAssigned", reflect.TypeOf((*MockReviewerRepository)(nil).IsAssigned), ctx, prID, reviewerID)
 }
 
+// LockAssignment mocks base method.
+func (m *MockReviewerRepository) LockAssignment(ctx context.Context, prID, reviewerID string) (*models.ReviewAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockAssignment", ctx, prID, reviewerID)
+	ret0, _ := ret[0].(*models.ReviewAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockAssignment indicates an expected call of LockAssignment.
+func (mr *MockReviewerRepositoryMockRecorder) LockAssignment(ctx, prID, reviewerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockAssignment", reflect.TypeOf((*MockReviewerRepository)(nil).LockAssignment), ctx, prID, reviewerID)
+}
+
+// RecordReviewerChange mocks base method.
+func (m *MockReviewerRepository) RecordReviewerChange(ctx context.Context, change *models.ReviewerChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordReviewerChange", ctx, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordReviewerChange indicates an expected call of RecordReviewerChange.
+func (mr *MockReviewerRepositoryMockRecorder) RecordReviewerChange(ctx, change any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordReviewerChange", reflect.TypeOf((*MockReviewerRepository)(nil).RecordReviewerChange), ctx, change)
+}
+
 // ReplaceReviewer mocks base method.
-func (m *MockReviewerRepository) ReplaceReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) error {
+func (m *MockReviewerRepository) ReplaceReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID, source string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReplaceReviewer", ctx, prID, oldReviewerID, newReviewerID)
+	ret := m.ctrl.Call(m, "ReplaceReviewer", ctx, prID, oldReviewerID, newReviewerID, source)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ReplaceReviewer indicates an expected call of ReplaceReviewer.
-func (mr *MockReviewerRepositoryMockRecorder) ReplaceReviewer(ctx, prID, oldReviewerID, newReviewerID any) *gomock.Call {
+func (mr *MockReviewerRepositoryMockRecorder) ReplaceReviewer(ctx, prID, oldReviewerID, newReviewerID, source any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceReviewer", reflect.TypeOf((*MockReviewerRepository)(nil).ReplaceReviewer), ctx, prID, oldReviewerID, newReviewerID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceReviewer", reflect.TypeOf((*MockReviewerRepository)(nil).ReplaceReviewer), ctx, prID, oldReviewerID, newReviewerID, source)
+}
+
+// SetReviewState mocks base method.
+func (m *MockReviewerRepository) SetReviewState(ctx context.Context, prID, reviewerID, state string, changedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReviewState", ctx, prID, reviewerID, state, changedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetReviewState indicates an expected call of SetReviewState.
+func (mr *MockReviewerRepositoryMockRecorder) SetReviewState(ctx, prID, reviewerID, state, changedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReviewState", reflect.TypeOf((*MockReviewerRepository)(nil).SetReviewState), ctx, prID, reviewerID, state, changedAt)
 }
 
 // MockUserRepository is a mock of UserRepository interface.
@@ -251,6 +503,66 @@ func (mr *MockUserRepositoryMockRecorder) FindByID(ctx, userID any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockUserRepository)(nil).FindByID), ctx, userID)
 }
 
+// FindByIDs mocks base method.
+func (m *MockUserRepository) FindByIDs(ctx context.Context, userIDs []string) ([]*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ctx, userIDs)
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockUserRepositoryMockRecorder) FindByIDs(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockUserRepository)(nil).FindByIDs), ctx, userIDs)
+}
+
+// GetExcludedReviewers mocks base method.
+func (m *MockUserRepository) GetExcludedReviewers(ctx context.Context, authorID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExcludedReviewers", ctx, authorID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExcludedReviewers indicates an expected call of GetExcludedReviewers.
+func (mr *MockUserRepositoryMockRecorder) GetExcludedReviewers(ctx, authorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExcludedReviewers", reflect.TypeOf((*MockUserRepository)(nil).GetExcludedReviewers), ctx, authorID)
+}
+
+// GetTeamLead mocks base method.
+func (m *MockUserRepository) GetTeamLead(ctx context.Context, teamName string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamLead", ctx, teamName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamLead indicates an expected call of GetTeamLead.
+func (mr *MockUserRepositoryMockRecorder) GetTeamLead(ctx, teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamLead", reflect.TypeOf((*MockUserRepository)(nil).GetTeamLead), ctx, teamName)
+}
+
+// LockActiveCandidate mocks base method.
+func (m *MockUserRepository) LockActiveCandidate(ctx context.Context, userID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockActiveCandidate", ctx, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockActiveCandidate indicates an expected call of LockActiveCandidate.
+func (mr *MockUserRepositoryMockRecorder) LockActiveCandidate(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockActiveCandidate", reflect.TypeOf((*MockUserRepository)(nil).LockActiveCandidate), ctx, userID)
+}
+
 // MockTransactor is a mock of Transactor interface.
 type MockTransactor struct {
 	ctrl     *gomock.Controller
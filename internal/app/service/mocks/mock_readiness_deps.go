@@ -0,0 +1,229 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: readiness.go
+//
+// Generated by this command:
+//
+//	mockgen -source=readiness.go -destination=mocks/mock_readiness_deps.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReadinessRepository is a mock of ReadinessRepository interface.
+type MockReadinessRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReadinessRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockReadinessRepositoryMockRecorder is the mock recorder for MockReadinessRepository.
+type MockReadinessRepositoryMockRecorder struct {
+	mock *MockReadinessRepository
+}
+
+// NewMockReadinessRepository creates a new mock instance.
+func NewMockReadinessRepository(ctrl *gomock.Controller) *MockReadinessRepository {
+	mock := &MockReadinessRepository{ctrl: ctrl}
+	mock.recorder = &MockReadinessRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReadinessRepository) EXPECT() *MockReadinessRepositoryMockRecorder {
+	return m.recorder
+}
+
+// MigrationVersion mocks base method.
+func (m *MockReadinessRepository) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MigrationVersion", ctx)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// MigrationVersion indicates an expected call of MigrationVersion.
+func (mr *MockReadinessRepositoryMockRecorder) MigrationVersion(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrationVersion", reflect.TypeOf((*MockReadinessRepository)(nil).MigrationVersion), ctx)
+}
+
+// Ping mocks base method.
+func (m *MockReadinessRepository) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockReadinessRepositoryMockRecorder) Ping(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockReadinessRepository)(nil).Ping), ctx)
+}
+
+// MockReadinessOutbox is a mock of ReadinessOutbox interface.
+type MockReadinessOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockReadinessOutboxMockRecorder
+	isgomock struct{}
+}
+
+// MockReadinessOutboxMockRecorder is the mock recorder for MockReadinessOutbox.
+type MockReadinessOutboxMockRecorder struct {
+	mock *MockReadinessOutbox
+}
+
+// NewMockReadinessOutbox creates a new mock instance.
+func NewMockReadinessOutbox(ctrl *gomock.Controller) *MockReadinessOutbox {
+	mock := &MockReadinessOutbox{ctrl: ctrl}
+	mock.recorder = &MockReadinessOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReadinessOutbox) EXPECT() *MockReadinessOutboxMockRecorder {
+	return m.recorder
+}
+
+// CountPending mocks base method.
+func (m *MockReadinessOutbox) CountPending(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPending", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPending indicates an expected call of CountPending.
+func (mr *MockReadinessOutboxMockRecorder) CountPending(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPending", reflect.TypeOf((*MockReadinessOutbox)(nil).CountPending), ctx)
+}
+
+// MockWorker is a mock of Worker interface.
+type MockWorker struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkerMockRecorder
+	isgomock struct{}
+}
+
+// MockWorkerMockRecorder is the mock recorder for MockWorker.
+type MockWorkerMockRecorder struct {
+	mock *MockWorker
+}
+
+// NewMockWorker creates a new mock instance.
+func NewMockWorker(ctrl *gomock.Controller) *MockWorker {
+	mock := &MockWorker{ctrl: ctrl}
+	mock.recorder = &MockWorkerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorker) EXPECT() *MockWorkerMockRecorder {
+	return m.recorder
+}
+
+// Interval mocks base method.
+func (m *MockWorker) Interval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Interval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// Interval indicates an expected call of Interval.
+func (mr *MockWorkerMockRecorder) Interval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Interval", reflect.TypeOf((*MockWorker)(nil).Interval))
+}
+
+// LastBeat mocks base method.
+func (m *MockWorker) LastBeat() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastBeat")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// LastBeat indicates an expected call of LastBeat.
+func (mr *MockWorkerMockRecorder) LastBeat() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastBeat", reflect.TypeOf((*MockWorker)(nil).LastBeat))
+}
+
+// MockReadinessLeader is a mock of ReadinessLeader interface.
+type MockReadinessLeader struct {
+	ctrl     *gomock.Controller
+	recorder *MockReadinessLeaderMockRecorder
+	isgomock struct{}
+}
+
+// MockReadinessLeaderMockRecorder is the mock recorder for MockReadinessLeader.
+type MockReadinessLeaderMockRecorder struct {
+	mock *MockReadinessLeader
+}
+
+// NewMockReadinessLeader creates a new mock instance.
+func NewMockReadinessLeader(ctrl *gomock.Controller) *MockReadinessLeader {
+	mock := &MockReadinessLeader{ctrl: ctrl}
+	mock.recorder = &MockReadinessLeaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReadinessLeader) EXPECT() *MockReadinessLeaderMockRecorder {
+	return m.recorder
+}
+
+// Holder mocks base method.
+func (m *MockReadinessLeader) Holder() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Holder")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Holder indicates an expected call of Holder.
+func (mr *MockReadinessLeaderMockRecorder) Holder() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Holder", reflect.TypeOf((*MockReadinessLeader)(nil).Holder))
+}
+
+// ID mocks base method.
+func (m *MockReadinessLeader) ID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ID indicates an expected call of ID.
+func (mr *MockReadinessLeaderMockRecorder) ID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ID", reflect.TypeOf((*MockReadinessLeader)(nil).ID))
+}
+
+// IsLeader mocks base method.
+func (m *MockReadinessLeader) IsLeader() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsLeader")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsLeader indicates an expected call of IsLeader.
+func (mr *MockReadinessLeaderMockRecorder) IsLeader() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsLeader", reflect.TypeOf((*MockReadinessLeader)(nil).IsLeader))
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reviewer_details.go
+//
+// Generated by this command:
+//
+//	mockgen -source=reviewer_details.go -destination=mocks/mock_reviewer_details_deps.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReviewerLookupRepository is a mock of ReviewerLookupRepository interface.
+type MockReviewerLookupRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewerLookupRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockReviewerLookupRepositoryMockRecorder is the mock recorder for MockReviewerLookupRepository.
+type MockReviewerLookupRepositoryMockRecorder struct {
+	mock *MockReviewerLookupRepository
+}
+
+// NewMockReviewerLookupRepository creates a new mock instance.
+func NewMockReviewerLookupRepository(ctrl *gomock.Controller) *MockReviewerLookupRepository {
+	mock := &MockReviewerLookupRepository{ctrl: ctrl}
+	mock.recorder = &MockReviewerLookupRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewerLookupRepository) EXPECT() *MockReviewerLookupRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByIDs mocks base method.
+func (m *MockReviewerLookupRepository) FindByIDs(ctx context.Context, userIDs []string) ([]*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ctx, userIDs)
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockReviewerLookupRepositoryMockRecorder) FindByIDs(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockReviewerLookupRepository)(nil).FindByIDs), ctx, userIDs)
+}
+
+// MockAssignmentLookupRepository is a mock of AssignmentLookupRepository interface.
+type MockAssignmentLookupRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAssignmentLookupRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAssignmentLookupRepositoryMockRecorder is the mock recorder for MockAssignmentLookupRepository.
+type MockAssignmentLookupRepositoryMockRecorder struct {
+	mock *MockAssignmentLookupRepository
+}
+
+// NewMockAssignmentLookupRepository creates a new mock instance.
+func NewMockAssignmentLookupRepository(ctrl *gomock.Controller) *MockAssignmentLookupRepository {
+	mock := &MockAssignmentLookupRepository{ctrl: ctrl}
+	mock.recorder = &MockAssignmentLookupRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAssignmentLookupRepository) EXPECT() *MockAssignmentLookupRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetAssignmentsByPRs mocks base method.
+func (m *MockAssignmentLookupRepository) GetAssignmentsByPRs(ctx context.Context, prIDs []string) ([]*models.ReviewAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignmentsByPRs", ctx, prIDs)
+	ret0, _ := ret[0].([]*models.ReviewAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssignmentsByPRs indicates an expected call of GetAssignmentsByPRs.
+func (mr *MockAssignmentLookupRepositoryMockRecorder) GetAssignmentsByPRs(ctx, prIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignmentsByPRs", reflect.TypeOf((*MockAssignmentLookupRepository)(nil).GetAssignmentsByPRs), ctx, prIDs)
+}
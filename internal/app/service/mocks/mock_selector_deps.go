@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: selector.go
+//
+// Generated by this command:
+//
+//	mockgen -source=selector.go -destination=mocks/mock_selector_deps.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSelectorUserRepository is a mock of SelectorUserRepository interface.
+type MockSelectorUserRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSelectorUserRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSelectorUserRepositoryMockRecorder is the mock recorder for MockSelectorUserRepository.
+type MockSelectorUserRepositoryMockRecorder struct {
+	mock *MockSelectorUserRepository
+}
+
+// NewMockSelectorUserRepository creates a new mock instance.
+func NewMockSelectorUserRepository(ctrl *gomock.Controller) *MockSelectorUserRepository {
+	mock := &MockSelectorUserRepository{ctrl: ctrl}
+	mock.recorder = &MockSelectorUserRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSelectorUserRepository) EXPECT() *MockSelectorUserRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindActiveCandidatesForReassignment mocks base method.
+func (m *MockSelectorUserRepository) FindActiveCandidatesForReassignment(ctx context.Context, teamName string, excludeUserIDs []string) ([]*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveCandidatesForReassignment", ctx, teamName, excludeUserIDs)
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveCandidatesForReassignment indicates an expected call of FindActiveCandidatesForReassignment.
+func (mr *MockSelectorUserRepositoryMockRecorder) FindActiveCandidatesForReassignment(ctx, teamName, excludeUserIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveCandidatesForReassignment", reflect.TypeOf((*MockSelectorUserRepository)(nil).FindActiveCandidatesForReassignment), ctx, teamName, excludeUserIDs)
+}
+
+// MockSelectorReviewerRepository is a mock of SelectorReviewerRepository interface.
+type MockSelectorReviewerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSelectorReviewerRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSelectorReviewerRepositoryMockRecorder is the mock recorder for MockSelectorReviewerRepository.
+type MockSelectorReviewerRepositoryMockRecorder struct {
+	mock *MockSelectorReviewerRepository
+}
+
+// NewMockSelectorReviewerRepository creates a new mock instance.
+func NewMockSelectorReviewerRepository(ctrl *gomock.Controller) *MockSelectorReviewerRepository {
+	mock := &MockSelectorReviewerRepository{ctrl: ctrl}
+	mock.recorder = &MockSelectorReviewerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSelectorReviewerRepository) EXPECT() *MockSelectorReviewerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetAssignmentTimes mocks base method.
+func (m *MockSelectorReviewerRepository) GetAssignmentTimes(ctx context.Context, userIDs []string, since time.Time) (map[string][]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignmentTimes", ctx, userIDs, since)
+	ret0, _ := ret[0].(map[string][]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssignmentTimes indicates an expected call of GetAssignmentTimes.
+func (mr *MockSelectorReviewerRepositoryMockRecorder) GetAssignmentTimes(ctx, userIDs, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignmentTimes", reflect.TypeOf((*MockSelectorReviewerRepository)(nil).GetAssignmentTimes), ctx, userIDs, since)
+}
+
+// GetOpenReviewCounts mocks base method.
+func (m *MockSelectorReviewerRepository) GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenReviewCounts", ctx, userIDs)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenReviewCounts indicates an expected call of GetOpenReviewCounts.
+func (mr *MockSelectorReviewerRepositoryMockRecorder) GetOpenReviewCounts(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenReviewCounts", reflect.TypeOf((*MockSelectorReviewerRepository)(nil).GetOpenReviewCounts), ctx, userIDs)
+}
@@ -0,0 +1,166 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: snapshot.go
+//
+// Generated by this command:
+//
+//	mockgen -source=snapshot.go -destination=mocks/mock_snapshot_deps.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	statistics "github.com/shirr9/pr-reviewer-service/internal/app/dto/statistics"
+	models "github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSnapshotRepository is a mock of SnapshotRepository interface.
+type MockSnapshotRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSnapshotRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSnapshotRepositoryMockRecorder is the mock recorder for MockSnapshotRepository.
+type MockSnapshotRepositoryMockRecorder struct {
+	mock *MockSnapshotRepository
+}
+
+// NewMockSnapshotRepository creates a new mock instance.
+func NewMockSnapshotRepository(ctrl *gomock.Controller) *MockSnapshotRepository {
+	mock := &MockSnapshotRepository{ctrl: ctrl}
+	mock.recorder = &MockSnapshotRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSnapshotRepository) EXPECT() *MockSnapshotRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindSnapshots mocks base method.
+func (m *MockSnapshotRepository) FindSnapshots(ctx context.Context, from, to time.Time) ([]*models.StatisticsSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSnapshots", ctx, from, to)
+	ret0, _ := ret[0].([]*models.StatisticsSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSnapshots indicates an expected call of FindSnapshots.
+func (mr *MockSnapshotRepositoryMockRecorder) FindSnapshots(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSnapshots", reflect.TypeOf((*MockSnapshotRepository)(nil).FindSnapshots), ctx, from, to)
+}
+
+// HasSnapshot mocks base method.
+func (m *MockSnapshotRepository) HasSnapshot(ctx context.Context, day time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasSnapshot", ctx, day)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasSnapshot indicates an expected call of HasSnapshot.
+func (mr *MockSnapshotRepositoryMockRecorder) HasSnapshot(ctx, day any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasSnapshot", reflect.TypeOf((*MockSnapshotRepository)(nil).HasSnapshot), ctx, day)
+}
+
+// SaveSnapshots mocks base method.
+func (m *MockSnapshotRepository) SaveSnapshots(ctx context.Context, snapshots []*models.StatisticsSnapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSnapshots", ctx, snapshots)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSnapshots indicates an expected call of SaveSnapshots.
+func (mr *MockSnapshotRepositoryMockRecorder) SaveSnapshots(ctx, snapshots any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSnapshots", reflect.TypeOf((*MockSnapshotRepository)(nil).SaveSnapshots), ctx, snapshots)
+}
+
+// MockSnapshotUserRepository is a mock of SnapshotUserRepository interface.
+type MockSnapshotUserRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSnapshotUserRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSnapshotUserRepositoryMockRecorder is the mock recorder for MockSnapshotUserRepository.
+type MockSnapshotUserRepositoryMockRecorder struct {
+	mock *MockSnapshotUserRepository
+}
+
+// NewMockSnapshotUserRepository creates a new mock instance.
+func NewMockSnapshotUserRepository(ctrl *gomock.Controller) *MockSnapshotUserRepository {
+	mock := &MockSnapshotUserRepository{ctrl: ctrl}
+	mock.recorder = &MockSnapshotUserRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSnapshotUserRepository) EXPECT() *MockSnapshotUserRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetAllUsers mocks base method.
+func (m *MockSnapshotUserRepository) GetAllUsers(ctx context.Context) ([]*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllUsers", ctx)
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllUsers indicates an expected call of GetAllUsers.
+func (mr *MockSnapshotUserRepositoryMockRecorder) GetAllUsers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUsers", reflect.TypeOf((*MockSnapshotUserRepository)(nil).GetAllUsers), ctx)
+}
+
+// MockSnapshotStatistics is a mock of SnapshotStatistics interface.
+type MockSnapshotStatistics struct {
+	ctrl     *gomock.Controller
+	recorder *MockSnapshotStatisticsMockRecorder
+	isgomock struct{}
+}
+
+// MockSnapshotStatisticsMockRecorder is the mock recorder for MockSnapshotStatistics.
+type MockSnapshotStatisticsMockRecorder struct {
+	mock *MockSnapshotStatistics
+}
+
+// NewMockSnapshotStatistics creates a new mock instance.
+func NewMockSnapshotStatistics(ctrl *gomock.Controller) *MockSnapshotStatistics {
+	mock := &MockSnapshotStatistics{ctrl: ctrl}
+	mock.recorder = &MockSnapshotStatisticsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSnapshotStatistics) EXPECT() *MockSnapshotStatisticsMockRecorder {
+	return m.recorder
+}
+
+// GetStatistics mocks base method.
+func (m *MockSnapshotStatistics) GetStatistics(ctx context.Context, req statistics.StatisticsRequest) (*statistics.StatisticsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatistics", ctx, req)
+	ret0, _ := ret[0].(*statistics.StatisticsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatistics indicates an expected call of GetStatistics.
+func (mr *MockSnapshotStatisticsMockRecorder) GetStatistics(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatistics", reflect.TypeOf((*MockSnapshotStatistics)(nil).GetStatistics), ctx, req)
+}
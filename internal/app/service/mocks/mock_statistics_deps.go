@@ -0,0 +1,451 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: statistics.go
+//
+// Generated by this command:
+//
+//	mockgen -source=statistics.go -destination=mocks/mock_statistics_deps.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStatisticsUserRepository is a mock of StatisticsUserRepository interface.
+type MockStatisticsUserRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatisticsUserRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockStatisticsUserRepositoryMockRecorder is the mock recorder for MockStatisticsUserRepository.
+type MockStatisticsUserRepositoryMockRecorder struct {
+	mock *MockStatisticsUserRepository
+}
+
+// NewMockStatisticsUserRepository creates a new mock instance.
+func NewMockStatisticsUserRepository(ctrl *gomock.Controller) *MockStatisticsUserRepository {
+	mock := &MockStatisticsUserRepository{ctrl: ctrl}
+	mock.recorder = &MockStatisticsUserRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatisticsUserRepository) EXPECT() *MockStatisticsUserRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByID mocks base method.
+func (m *MockStatisticsUserRepository) FindByID(ctx context.Context, userID string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, userID)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockStatisticsUserRepositoryMockRecorder) FindByID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockStatisticsUserRepository)(nil).FindByID), ctx, userID)
+}
+
+// FindByTeamName mocks base method.
+func (m *MockStatisticsUserRepository) FindByTeamName(ctx context.Context, teamName string) ([]*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTeamName", ctx, teamName)
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTeamName indicates an expected call of FindByTeamName.
+func (mr *MockStatisticsUserRepositoryMockRecorder) FindByTeamName(ctx, teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTeamName", reflect.TypeOf((*MockStatisticsUserRepository)(nil).FindByTeamName), ctx, teamName)
+}
+
+// GetAllUsers mocks base method.
+func (m *MockStatisticsUserRepository) GetAllUsers(ctx context.Context) ([]*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllUsers", ctx)
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllUsers indicates an expected call of GetAllUsers.
+func (mr *MockStatisticsUserRepositoryMockRecorder) GetAllUsers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUsers", reflect.TypeOf((*MockStatisticsUserRepository)(nil).GetAllUsers), ctx)
+}
+
+// MockStatisticsPRRepository is a mock of StatisticsPRRepository interface.
+type MockStatisticsPRRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatisticsPRRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockStatisticsPRRepositoryMockRecorder is the mock recorder for MockStatisticsPRRepository.
+type MockStatisticsPRRepositoryMockRecorder struct {
+	mock *MockStatisticsPRRepository
+}
+
+// NewMockStatisticsPRRepository creates a new mock instance.
+func NewMockStatisticsPRRepository(ctrl *gomock.Controller) *MockStatisticsPRRepository {
+	mock := &MockStatisticsPRRepository{ctrl: ctrl}
+	mock.recorder = &MockStatisticsPRRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatisticsPRRepository) EXPECT() *MockStatisticsPRRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountOpenWithoutReviewers mocks base method.
+func (m *MockStatisticsPRRepository) CountOpenWithoutReviewers(ctx context.Context, teamName string, labels []string, skipped *bool) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpenWithoutReviewers", ctx, teamName, labels, skipped)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpenWithoutReviewers indicates an expected call of CountOpenWithoutReviewers.
+func (mr *MockStatisticsPRRepositoryMockRecorder) CountOpenWithoutReviewers(ctx, teamName, labels, skipped any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpenWithoutReviewers", reflect.TypeOf((*MockStatisticsPRRepository)(nil).CountOpenWithoutReviewers), ctx, teamName, labels, skipped)
+}
+
+// FindOpenWithoutReviewers mocks base method.
+func (m *MockStatisticsPRRepository) FindOpenWithoutReviewers(ctx context.Context, teamName string, labels []string, skipped *bool, order models.PRSort, limit, offset int) ([]*models.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOpenWithoutReviewers", ctx, teamName, labels, skipped, order, limit, offset)
+	ret0, _ := ret[0].([]*models.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOpenWithoutReviewers indicates an expected call of FindOpenWithoutReviewers.
+func (mr *MockStatisticsPRRepositoryMockRecorder) FindOpenWithoutReviewers(ctx, teamName, labels, skipped, order, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOpenWithoutReviewers", reflect.TypeOf((*MockStatisticsPRRepository)(nil).FindOpenWithoutReviewers), ctx, teamName, labels, skipped, order, limit, offset)
+}
+
+// GetActivity mocks base method.
+func (m *MockStatisticsPRRepository) GetActivity(ctx context.Context, teamName, bucket string, from, to time.Time) ([]*models.ActivityBucket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActivity", ctx, teamName, bucket, from, to)
+	ret0, _ := ret[0].([]*models.ActivityBucket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActivity indicates an expected call of GetActivity.
+func (mr *MockStatisticsPRRepositoryMockRecorder) GetActivity(ctx, teamName, bucket, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivity", reflect.TypeOf((*MockStatisticsPRRepository)(nil).GetActivity), ctx, teamName, bucket, from, to)
+}
+
+// GetAllPRs mocks base method.
+func (m *MockStatisticsPRRepository) GetAllPRs(ctx context.Context, teamName string) ([]*models.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllPRs", ctx, teamName)
+	ret0, _ := ret[0].([]*models.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllPRs indicates an expected call of GetAllPRs.
+func (mr *MockStatisticsPRRepositoryMockRecorder) GetAllPRs(ctx, teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPRs", reflect.TypeOf((*MockStatisticsPRRepository)(nil).GetAllPRs), ctx, teamName)
+}
+
+// GetArchivedPRs mocks base method.
+func (m *MockStatisticsPRRepository) GetArchivedPRs(ctx context.Context, teamName string) ([]*models.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchivedPRs", ctx, teamName)
+	ret0, _ := ret[0].([]*models.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArchivedPRs indicates an expected call of GetArchivedPRs.
+func (mr *MockStatisticsPRRepositoryMockRecorder) GetArchivedPRs(ctx, teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedPRs", reflect.TypeOf((*MockStatisticsPRRepository)(nil).GetArchivedPRs), ctx, teamName)
+}
+
+// GetAuthorStats mocks base method.
+func (m *MockStatisticsPRRepository) GetAuthorStats(ctx context.Context, teamName string, includeArchived bool) ([]*models.AuthorStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorStats", ctx, teamName, includeArchived)
+	ret0, _ := ret[0].([]*models.AuthorStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthorStats indicates an expected call of GetAuthorStats.
+func (mr *MockStatisticsPRRepositoryMockRecorder) GetAuthorStats(ctx, teamName, includeArchived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorStats", reflect.TypeOf((*MockStatisticsPRRepository)(nil).GetAuthorStats), ctx, teamName, includeArchived)
+}
+
+// GetAuthorStatsByID mocks base method.
+func (m *MockStatisticsPRRepository) GetAuthorStatsByID(ctx context.Context, authorID string, includeArchived bool) (*models.AuthorStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorStatsByID", ctx, authorID, includeArchived)
+	ret0, _ := ret[0].(*models.AuthorStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthorStatsByID indicates an expected call of GetAuthorStatsByID.
+func (mr *MockStatisticsPRRepositoryMockRecorder) GetAuthorStatsByID(ctx, authorID, includeArchived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorStatsByID", reflect.TypeOf((*MockStatisticsPRRepository)(nil).GetAuthorStatsByID), ctx, authorID, includeArchived)
+}
+
+// MockStatisticsReviewerRepository is a mock of StatisticsReviewerRepository interface.
+type MockStatisticsReviewerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatisticsReviewerRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockStatisticsReviewerRepositoryMockRecorder is the mock recorder for MockStatisticsReviewerRepository.
+type MockStatisticsReviewerRepositoryMockRecorder struct {
+	mock *MockStatisticsReviewerRepository
+}
+
+// NewMockStatisticsReviewerRepository creates a new mock instance.
+func NewMockStatisticsReviewerRepository(ctrl *gomock.Controller) *MockStatisticsReviewerRepository {
+	mock := &MockStatisticsReviewerRepository{ctrl: ctrl}
+	mock.recorder = &MockStatisticsReviewerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatisticsReviewerRepository) EXPECT() *MockStatisticsReviewerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountReviewerChanges mocks base method.
+func (m *MockStatisticsReviewerRepository) CountReviewerChanges(ctx context.Context, teamName string, includeArchived bool) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountReviewerChanges", ctx, teamName, includeArchived)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountReviewerChanges indicates an expected call of CountReviewerChanges.
+func (mr *MockStatisticsReviewerRepositoryMockRecorder) CountReviewerChanges(ctx, teamName, includeArchived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountReviewerChanges", reflect.TypeOf((*MockStatisticsReviewerRepository)(nil).CountReviewerChanges), ctx, teamName, includeArchived)
+}
+
+// FindOpenAssignments mocks base method.
+func (m *MockStatisticsReviewerRepository) FindOpenAssignments(ctx context.Context, assignedBefore time.Time) ([]*models.ReviewAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOpenAssignments", ctx, assignedBefore)
+	ret0, _ := ret[0].([]*models.ReviewAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOpenAssignments indicates an expected call of FindOpenAssignments.
+func (mr *MockStatisticsReviewerRepositoryMockRecorder) FindOpenAssignments(ctx, assignedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOpenAssignments", reflect.TypeOf((*MockStatisticsReviewerRepository)(nil).FindOpenAssignments), ctx, assignedBefore)
+}
+
+// FindRecentAssignments mocks base method.
+func (m *MockStatisticsReviewerRepository) FindRecentAssignments(ctx context.Context, reviewerID string, includeArchived bool, limit int) ([]*models.ReviewAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRecentAssignments", ctx, reviewerID, includeArchived, limit)
+	ret0, _ := ret[0].([]*models.ReviewAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRecentAssignments indicates an expected call of FindRecentAssignments.
+func (mr *MockStatisticsReviewerRepositoryMockRecorder) FindRecentAssignments(ctx, reviewerID, includeArchived, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRecentAssignments", reflect.TypeOf((*MockStatisticsReviewerRepository)(nil).FindRecentAssignments), ctx, reviewerID, includeArchived, limit)
+}
+
+// GetAllReviewerCounts mocks base method.
+func (m *MockStatisticsReviewerRepository) GetAllReviewerCounts(ctx context.Context, teamName string) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllReviewerCounts", ctx, teamName)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllReviewerCounts indicates an expected call of GetAllReviewerCounts.
+func (mr *MockStatisticsReviewerRepositoryMockRecorder) GetAllReviewerCounts(ctx, teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllReviewerCounts", reflect.TypeOf((*MockStatisticsReviewerRepository)(nil).GetAllReviewerCounts), ctx, teamName)
+}
+
+// GetAllReviewers mocks base method.
+func (m *MockStatisticsReviewerRepository) GetAllReviewers(ctx context.Context, teamName string) (map[string][]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllReviewers", ctx, teamName)
+	ret0, _ := ret[0].(map[string][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllReviewers indicates an expected call of GetAllReviewers.
+func (mr *MockStatisticsReviewerRepositoryMockRecorder) GetAllReviewers(ctx, teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllReviewers", reflect.TypeOf((*MockStatisticsReviewerRepository)(nil).GetAllReviewers), ctx, teamName)
+}
+
+// GetArchivedReviewers mocks base method.
+func (m *MockStatisticsReviewerRepository) GetArchivedReviewers(ctx context.Context, teamName string) (map[string][]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchivedReviewers", ctx, teamName)
+	ret0, _ := ret[0].(map[string][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArchivedReviewers indicates an expected call of GetArchivedReviewers.
+func (mr *MockStatisticsReviewerRepositoryMockRecorder) GetArchivedReviewers(ctx, teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedReviewers", reflect.TypeOf((*MockStatisticsReviewerRepository)(nil).GetArchivedReviewers), ctx, teamName)
+}
+
+// GetAssignmentSourceCounts mocks base method.
+func (m *MockStatisticsReviewerRepository) GetAssignmentSourceCounts(ctx context.Context, teamName string, includeArchived bool) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignmentSourceCounts", ctx, teamName, includeArchived)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssignmentSourceCounts indicates an expected call of GetAssignmentSourceCounts.
+func (mr *MockStatisticsReviewerRepositoryMockRecorder) GetAssignmentSourceCounts(ctx, teamName, includeArchived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignmentSourceCounts", reflect.TypeOf((*MockStatisticsReviewerRepository)(nil).GetAssignmentSourceCounts), ctx, teamName, includeArchived)
+}
+
+// GetAssignmentTimes mocks base method.
+func (m *MockStatisticsReviewerRepository) GetAssignmentTimes(ctx context.Context, userIDs []string, since time.Time) (map[string][]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignmentTimes", ctx, userIDs, since)
+	ret0, _ := ret[0].(map[string][]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssignmentTimes indicates an expected call of GetAssignmentTimes.
+func (mr *MockStatisticsReviewerRepositoryMockRecorder) GetAssignmentTimes(ctx, userIDs, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignmentTimes", reflect.TypeOf((*MockStatisticsReviewerRepository)(nil).GetAssignmentTimes), ctx, userIDs, since)
+}
+
+// GetPRsByReviewer mocks base method.
+func (m *MockStatisticsReviewerRepository) GetPRsByReviewer(ctx context.Context, reviewerID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPRsByReviewer", ctx, reviewerID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPRsByReviewer indicates an expected call of GetPRsByReviewer.
+func (mr *MockStatisticsReviewerRepositoryMockRecorder) GetPRsByReviewer(ctx, reviewerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPRsByReviewer", reflect.TypeOf((*MockStatisticsReviewerRepository)(nil).GetPRsByReviewer), ctx, reviewerID)
+}
+
+// GetReassignmentCounts mocks base method.
+func (m *MockStatisticsReviewerRepository) GetReassignmentCounts(ctx context.Context, teamName string, includeArchived bool) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReassignmentCounts", ctx, teamName, includeArchived)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReassignmentCounts indicates an expected call of GetReassignmentCounts.
+func (mr *MockStatisticsReviewerRepositoryMockRecorder) GetReassignmentCounts(ctx, teamName, includeArchived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReassignmentCounts", reflect.TypeOf((*MockStatisticsReviewerRepository)(nil).GetReassignmentCounts), ctx, teamName, includeArchived)
+}
+
+// GetReviewLoadHistogram mocks base method.
+func (m *MockStatisticsReviewerRepository) GetReviewLoadHistogram(ctx context.Context, teamName string, lastBucket int) (*models.ReviewLoadHistogram, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewLoadHistogram", ctx, teamName, lastBucket)
+	ret0, _ := ret[0].(*models.ReviewLoadHistogram)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewLoadHistogram indicates an expected call of GetReviewLoadHistogram.
+func (mr *MockStatisticsReviewerRepositoryMockRecorder) GetReviewLoadHistogram(ctx, teamName, lastBucket any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewLoadHistogram", reflect.TypeOf((*MockStatisticsReviewerRepository)(nil).GetReviewLoadHistogram), ctx, teamName, lastBucket)
+}
+
+// GetReviewLoads mocks base method.
+func (m *MockStatisticsReviewerRepository) GetReviewLoads(ctx context.Context, teamName string) ([]*models.ReviewLoad, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewLoads", ctx, teamName)
+	ret0, _ := ret[0].([]*models.ReviewLoad)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewLoads indicates an expected call of GetReviewLoads.
+func (mr *MockStatisticsReviewerRepositoryMockRecorder) GetReviewLoads(ctx, teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewLoads", reflect.TypeOf((*MockStatisticsReviewerRepository)(nil).GetReviewLoads), ctx, teamName)
+}
+
+// GetReviewTurnarounds mocks base method.
+func (m *MockStatisticsReviewerRepository) GetReviewTurnarounds(ctx context.Context, teamName string, includeArchived bool) (map[string]models.ReviewTurnaround, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewTurnarounds", ctx, teamName, includeArchived)
+	ret0, _ := ret[0].(map[string]models.ReviewTurnaround)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewTurnarounds indicates an expected call of GetReviewTurnarounds.
+func (mr *MockStatisticsReviewerRepositoryMockRecorder) GetReviewTurnarounds(ctx, teamName, includeArchived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewTurnarounds", reflect.TypeOf((*MockStatisticsReviewerRepository)(nil).GetReviewTurnarounds), ctx, teamName, includeArchived)
+}
+
+// GetReviewerSummary mocks base method.
+func (m *MockStatisticsReviewerRepository) GetReviewerSummary(ctx context.Context, reviewerID string, includeArchived bool) (*models.ReviewerSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewerSummary", ctx, reviewerID, includeArchived)
+	ret0, _ := ret[0].(*models.ReviewerSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewerSummary indicates an expected call of GetReviewerSummary.
+func (mr *MockStatisticsReviewerRepositoryMockRecorder) GetReviewerSummary(ctx, reviewerID, includeArchived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewerSummary", reflect.TypeOf((*MockStatisticsReviewerRepository)(nil).GetReviewerSummary), ctx, reviewerID, includeArchived)
+}
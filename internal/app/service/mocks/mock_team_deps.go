@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: internal/app/service/team.go
+// Source: team.go
 //
 // Generated by this command:
 //
-//	mockgen -source=internal/app/service/team.go -destination=internal/app/service/mocks/mock_team_deps.go -package=mocks
+//	mockgen -source=team.go -destination=mocks/mock_team_deps.go -package=mocks
 //
 
 // Package mocks is a generated GoMock package.
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/shirr9/pr-reviewer-service/internal/domain/models"
 	gomock "go.uber.org/mock/gomock"
@@ -41,18 +42,18 @@ func (m *MockTeamRepository) EXPECT() *MockTeamRepositoryMockRecorder {
 	return m.recorder
 }
 
-// CreateOrUpdateTeam mocks base method.
-func (m *MockTeamRepository) CreateOrUpdateTeam(ctx context.Context, team *models.Team) error {
+// CreateTeam mocks base method.
+func (m *MockTeamRepository) CreateTeam(ctx context.Context, team *models.Team) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateOrUpdateTeam", ctx, team)
+	ret := m.ctrl.Call(m, "CreateTeam", ctx, team)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreateOrUpdateTeam indicates an expected call of CreateOrUpdateTeam.
-func (mr *MockTeamRepositoryMockRecorder) CreateOrUpdateTeam(ctx, team any) *gomock.Call {
+// CreateTeam indicates an expected call of CreateTeam.
+func (mr *MockTeamRepositoryMockRecorder) CreateTeam(ctx, team any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateTeam", reflect.TypeOf((*MockTeamRepository)(nil).CreateOrUpdateTeam), ctx, team)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTeam", reflect.TypeOf((*MockTeamRepository)(nil).CreateTeam), ctx, team)
 }
 
 // GetTeamByName mocks base method.
@@ -70,17 +71,379 @@ func (mr *MockTeamRepositoryMockRecorder) GetTeamByName(ctx, teamName any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamByName", reflect.TypeOf((*MockTeamRepository)(nil).GetTeamByName), ctx, teamName)
 }
 
-// IsExists mocks base method.
-func (m *MockTeamRepository) IsExists(ctx context.Context, teamName string) (bool, error) {
+// ListTeams mocks base method.
+func (m *MockTeamRepository) ListTeams(ctx context.Context) ([]*models.Team, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsExists", ctx, teamName)
+	ret := m.ctrl.Call(m, "ListTeams", ctx)
+	ret0, _ := ret[0].([]*models.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTeams indicates an expected call of ListTeams.
+func (mr *MockTeamRepositoryMockRecorder) ListTeams(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTeams", reflect.TypeOf((*MockTeamRepository)(nil).ListTeams), ctx)
+}
+
+// MockTeamUserRepository is a mock of TeamUserRepository interface.
+type MockTeamUserRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTeamUserRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTeamUserRepositoryMockRecorder is the mock recorder for MockTeamUserRepository.
+type MockTeamUserRepositoryMockRecorder struct {
+	mock *MockTeamUserRepository
+}
+
+// NewMockTeamUserRepository creates a new mock instance.
+func NewMockTeamUserRepository(ctrl *gomock.Controller) *MockTeamUserRepository {
+	mock := &MockTeamUserRepository{ctrl: ctrl}
+	mock.recorder = &MockTeamUserRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTeamUserRepository) EXPECT() *MockTeamUserRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeactivateTeamUsers mocks base method.
+func (m *MockTeamUserRepository) DeactivateTeamUsers(ctx context.Context, teamName string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateTeamUsers", ctx, teamName)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateTeamUsers indicates an expected call of DeactivateTeamUsers.
+func (mr *MockTeamUserRepositoryMockRecorder) DeactivateTeamUsers(ctx, teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateTeamUsers", reflect.TypeOf((*MockTeamUserRepository)(nil).DeactivateTeamUsers), ctx, teamName)
+}
+
+// FindActiveCandidatesForReassignment mocks base method.
+func (m *MockTeamUserRepository) FindActiveCandidatesForReassignment(ctx context.Context, teamName string, excludeUserIDs []string) ([]*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveCandidatesForReassignment", ctx, teamName, excludeUserIDs)
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveCandidatesForReassignment indicates an expected call of FindActiveCandidatesForReassignment.
+func (mr *MockTeamUserRepositoryMockRecorder) FindActiveCandidatesForReassignment(ctx, teamName, excludeUserIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveCandidatesForReassignment", reflect.TypeOf((*MockTeamUserRepository)(nil).FindActiveCandidatesForReassignment), ctx, teamName, excludeUserIDs)
+}
+
+// FindByID mocks base method.
+func (m *MockTeamUserRepository) FindByID(ctx context.Context, userID string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, userID)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockTeamUserRepositoryMockRecorder) FindByID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockTeamUserRepository)(nil).FindByID), ctx, userID)
+}
+
+// FindByIDs mocks base method.
+func (m *MockTeamUserRepository) FindByIDs(ctx context.Context, userIDs []string) ([]*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ctx, userIDs)
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockTeamUserRepositoryMockRecorder) FindByIDs(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockTeamUserRepository)(nil).FindByIDs), ctx, userIDs)
+}
+
+// FindByTeamName mocks base method.
+func (m *MockTeamUserRepository) FindByTeamName(ctx context.Context, teamName string) ([]*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTeamName", ctx, teamName)
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTeamName indicates an expected call of FindByTeamName.
+func (mr *MockTeamUserRepositoryMockRecorder) FindByTeamName(ctx, teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTeamName", reflect.TypeOf((*MockTeamUserRepository)(nil).FindByTeamName), ctx, teamName)
+}
+
+// GetExcludedReviewers mocks base method.
+func (m *MockTeamUserRepository) GetExcludedReviewers(ctx context.Context, authorID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExcludedReviewers", ctx, authorID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExcludedReviewers indicates an expected call of GetExcludedReviewers.
+func (mr *MockTeamUserRepositoryMockRecorder) GetExcludedReviewers(ctx, authorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExcludedReviewers", reflect.TypeOf((*MockTeamUserRepository)(nil).GetExcludedReviewers), ctx, authorID)
+}
+
+// LockActiveCandidate mocks base method.
+func (m *MockTeamUserRepository) LockActiveCandidate(ctx context.Context, userID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockActiveCandidate", ctx, userID)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// IsExists indicates an expected call of IsExists.
-func (mr *MockTeamRepositoryMockRecorder) IsExists(ctx, teamName any) *gomock.Call {
+// LockActiveCandidate indicates an expected call of LockActiveCandidate.
+func (mr *MockTeamUserRepositoryMockRecorder) LockActiveCandidate(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockActiveCandidate", reflect.TypeOf((*MockTeamUserRepository)(nil).LockActiveCandidate), ctx, userID)
+}
+
+// MockTeamPRRepository is a mock of TeamPRRepository interface.
+type MockTeamPRRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTeamPRRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTeamPRRepositoryMockRecorder is the mock recorder for MockTeamPRRepository.
+type MockTeamPRRepositoryMockRecorder struct {
+	mock *MockTeamPRRepository
+}
+
+// NewMockTeamPRRepository creates a new mock instance.
+func NewMockTeamPRRepository(ctrl *gomock.Controller) *MockTeamPRRepository {
+	mock := &MockTeamPRRepository{ctrl: ctrl}
+	mock.recorder = &MockTeamPRRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTeamPRRepository) EXPECT() *MockTeamPRRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindOpenPRsByAuthors mocks base method.
+func (m *MockTeamPRRepository) FindOpenPRsByAuthors(ctx context.Context, authorIDs []string) ([]*models.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOpenPRsByAuthors", ctx, authorIDs)
+	ret0, _ := ret[0].([]*models.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOpenPRsByAuthors indicates an expected call of FindOpenPRsByAuthors.
+func (mr *MockTeamPRRepositoryMockRecorder) FindOpenPRsByAuthors(ctx, authorIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOpenPRsByAuthors", reflect.TypeOf((*MockTeamPRRepository)(nil).FindOpenPRsByAuthors), ctx, authorIDs)
+}
+
+// FindOpenPRsByReviewers mocks base method.
+func (m *MockTeamPRRepository) FindOpenPRsByReviewers(ctx context.Context, reviewerIDs []string) ([]*models.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOpenPRsByReviewers", ctx, reviewerIDs)
+	ret0, _ := ret[0].([]*models.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOpenPRsByReviewers indicates an expected call of FindOpenPRsByReviewers.
+func (mr *MockTeamPRRepositoryMockRecorder) FindOpenPRsByReviewers(ctx, reviewerIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOpenPRsByReviewers", reflect.TypeOf((*MockTeamPRRepository)(nil).FindOpenPRsByReviewers), ctx, reviewerIDs)
+}
+
+// FindOpenPRsReviewedByTeam mocks base method.
+func (m *MockTeamPRRepository) FindOpenPRsReviewedByTeam(ctx context.Context, teamName string, order models.PRSort) ([]*models.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOpenPRsReviewedByTeam", ctx, teamName, order)
+	ret0, _ := ret[0].([]*models.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOpenPRsReviewedByTeam indicates an expected call of FindOpenPRsReviewedByTeam.
+func (mr *MockTeamPRRepositoryMockRecorder) FindOpenPRsReviewedByTeam(ctx, teamName, order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOpenPRsReviewedByTeam", reflect.TypeOf((*MockTeamPRRepository)(nil).FindOpenPRsReviewedByTeam), ctx, teamName, order)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockTeamPRRepository) UpdateStatus(ctx context.Context, prID, fromStatus, status string, mergedAt *time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, prID, fromStatus, status, mergedAt)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockTeamPRRepositoryMockRecorder) UpdateStatus(ctx, prID, fromStatus, status, mergedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockTeamPRRepository)(nil).UpdateStatus), ctx, prID, fromStatus, status, mergedAt)
+}
+
+// MockTeamReviewerRepository is a mock of TeamReviewerRepository interface.
+type MockTeamReviewerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTeamReviewerRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTeamReviewerRepositoryMockRecorder is the mock recorder for MockTeamReviewerRepository.
+type MockTeamReviewerRepositoryMockRecorder struct {
+	mock *MockTeamReviewerRepository
+}
+
+// NewMockTeamReviewerRepository creates a new mock instance.
+func NewMockTeamReviewerRepository(ctrl *gomock.Controller) *MockTeamReviewerRepository {
+	mock := &MockTeamReviewerRepository{ctrl: ctrl}
+	mock.recorder = &MockTeamReviewerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTeamReviewerRepository) EXPECT() *MockTeamReviewerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetAssignmentsByPRs mocks base method.
+func (m *MockTeamReviewerRepository) GetAssignmentsByPRs(ctx context.Context, prIDs []string) ([]*models.ReviewAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignmentsByPRs", ctx, prIDs)
+	ret0, _ := ret[0].([]*models.ReviewAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssignmentsByPRs indicates an expected call of GetAssignmentsByPRs.
+func (mr *MockTeamReviewerRepositoryMockRecorder) GetAssignmentsByPRs(ctx, prIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignmentsByPRs", reflect.TypeOf((*MockTeamReviewerRepository)(nil).GetAssignmentsByPRs), ctx, prIDs)
+}
+
+// GetOpenReviewCounts mocks base method.
+func (m *MockTeamReviewerRepository) GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenReviewCounts", ctx, userIDs)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenReviewCounts indicates an expected call of GetOpenReviewCounts.
+func (mr *MockTeamReviewerRepositoryMockRecorder) GetOpenReviewCounts(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenReviewCounts", reflect.TypeOf((*MockTeamReviewerRepository)(nil).GetOpenReviewCounts), ctx, userIDs)
+}
+
+// GetReviewers mocks base method.
+func (m *MockTeamReviewerRepository) GetReviewers(ctx context.Context, prID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewers", ctx, prID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewers indicates an expected call of GetReviewers.
+func (mr *MockTeamReviewerRepositoryMockRecorder) GetReviewers(ctx, prID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewers", reflect.TypeOf((*MockTeamReviewerRepository)(nil).GetReviewers), ctx, prID)
+}
+
+// RecordReviewerChange mocks base method.
+func (m *MockTeamReviewerRepository) RecordReviewerChange(ctx context.Context, change *models.ReviewerChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordReviewerChange", ctx, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordReviewerChange indicates an expected call of RecordReviewerChange.
+func (mr *MockTeamReviewerRepositoryMockRecorder) RecordReviewerChange(ctx, change any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordReviewerChange", reflect.TypeOf((*MockTeamReviewerRepository)(nil).RecordReviewerChange), ctx, change)
+}
+
+// RemoveReviewer mocks base method.
+func (m *MockTeamReviewerRepository) RemoveReviewer(ctx context.Context, prID, reviewerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReviewer", ctx, prID, reviewerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveReviewer indicates an expected call of RemoveReviewer.
+func (mr *MockTeamReviewerRepositoryMockRecorder) RemoveReviewer(ctx, prID, reviewerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReviewer", reflect.TypeOf((*MockTeamReviewerRepository)(nil).RemoveReviewer), ctx, prID, reviewerID)
+}
+
+// ReplaceReviewer mocks base method.
+func (m *MockTeamReviewerRepository) ReplaceReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID, source string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceReviewer", ctx, prID, oldReviewerID, newReviewerID, source)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceReviewer indicates an expected call of ReplaceReviewer.
+func (mr *MockTeamReviewerRepositoryMockRecorder) ReplaceReviewer(ctx, prID, oldReviewerID, newReviewerID, source any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceReviewer", reflect.TypeOf((*MockTeamReviewerRepository)(nil).ReplaceReviewer), ctx, prID, oldReviewerID, newReviewerID, source)
+}
+
+// MockTeamTransactor is a mock of TeamTransactor interface.
+type MockTeamTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTeamTransactorMockRecorder
+	isgomock struct{}
+}
+
+// MockTeamTransactorMockRecorder is the mock recorder for MockTeamTransactor.
+type MockTeamTransactorMockRecorder struct {
+	mock *MockTeamTransactor
+}
+
+// NewMockTeamTransactor creates a new mock instance.
+func NewMockTeamTransactor(ctrl *gomock.Controller) *MockTeamTransactor {
+	mock := &MockTeamTransactor{ctrl: ctrl}
+	mock.recorder = &MockTeamTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTeamTransactor) EXPECT() *MockTeamTransactorMockRecorder {
+	return m.recorder
+}
+
+// WithinTransaction mocks base method.
+func (m *MockTeamTransactor) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTransaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTransaction indicates an expected call of WithinTransaction.
+func (mr *MockTeamTransactorMockRecorder) WithinTransaction(ctx, fn any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsExists", reflect.TypeOf((*MockTeamRepository)(nil).IsExists), ctx, teamName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTransaction", reflect.TypeOf((*MockTeamTransactor)(nil).WithinTransaction), ctx, fn)
 }
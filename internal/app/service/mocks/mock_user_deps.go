@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: internal/app/service/user.go
+// Source: user.go
 //
 // Generated by this command:
 //
-//	mockgen -source=internal/app/service/user.go -destination=internal/app/service/mocks/mock_user_deps.go -package=mocks
+//	mockgen -source=user.go -destination=mocks/mock_user_deps.go -package=mocks
 //
 
 // Package mocks is a generated GoMock package.
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/shirr9/pr-reviewer-service/internal/domain/models"
 	gomock "go.uber.org/mock/gomock"
@@ -56,6 +57,21 @@ func (mr *MockUserRepositoryForServiceMockRecorder) FindByID(ctx, userID any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockUserRepositoryForService)(nil).FindByID), ctx, userID)
 }
 
+// FindByIDs mocks base method.
+func (m *MockUserRepositoryForService) FindByIDs(ctx context.Context, userIDs []string) ([]*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ctx, userIDs)
+	ret0, _ := ret[0].([]*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockUserRepositoryForServiceMockRecorder) FindByIDs(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockUserRepositoryForService)(nil).FindByIDs), ctx, userIDs)
+}
+
 // SetIsActive mocks base method.
 func (m *MockUserRepositoryForService) SetIsActive(ctx context.Context, userID string, isActive bool) error {
 	m.ctrl.T.Helper()
@@ -70,6 +86,48 @@ func (mr *MockUserRepositoryForServiceMockRecorder) SetIsActive(ctx, userID, isA
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIsActive", reflect.TypeOf((*MockUserRepositoryForService)(nil).SetIsActive), ctx, userID, isActive)
 }
 
+// SetIsReviewer mocks base method.
+func (m *MockUserRepositoryForService) SetIsReviewer(ctx context.Context, userID string, isReviewer bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetIsReviewer", ctx, userID, isReviewer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetIsReviewer indicates an expected call of SetIsReviewer.
+func (mr *MockUserRepositoryForServiceMockRecorder) SetIsReviewer(ctx, userID, isReviewer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIsReviewer", reflect.TypeOf((*MockUserRepositoryForService)(nil).SetIsReviewer), ctx, userID, isReviewer)
+}
+
+// SetTags mocks base method.
+func (m *MockUserRepositoryForService) SetTags(ctx context.Context, userID string, tags []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTags", ctx, userID, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTags indicates an expected call of SetTags.
+func (mr *MockUserRepositoryForServiceMockRecorder) SetTags(ctx, userID, tags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTags", reflect.TypeOf((*MockUserRepositoryForService)(nil).SetTags), ctx, userID, tags)
+}
+
+// UpdateUsername mocks base method.
+func (m *MockUserRepositoryForService) UpdateUsername(ctx context.Context, userID, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUsername", ctx, userID, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUsername indicates an expected call of UpdateUsername.
+func (mr *MockUserRepositoryForServiceMockRecorder) UpdateUsername(ctx, userID, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUsername", reflect.TypeOf((*MockUserRepositoryForService)(nil).UpdateUsername), ctx, userID, username)
+}
+
 // MockPullRequestRepositoryForUser is a mock of PullRequestRepositoryForUser interface.
 type MockPullRequestRepositoryForUser struct {
 	ctrl     *gomock.Controller
@@ -108,3 +166,87 @@ func (mr *MockPullRequestRepositoryForUserMockRecorder) FindByReviewer(ctx, revi
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByReviewer", reflect.TypeOf((*MockPullRequestRepositoryForUser)(nil).FindByReviewer), ctx, reviewerID)
 }
+
+// FindOpenPRsByReviewers mocks base method.
+func (m *MockPullRequestRepositoryForUser) FindOpenPRsByReviewers(ctx context.Context, reviewerIDs []string) ([]*models.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOpenPRsByReviewers", ctx, reviewerIDs)
+	ret0, _ := ret[0].([]*models.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOpenPRsByReviewers indicates an expected call of FindOpenPRsByReviewers.
+func (mr *MockPullRequestRepositoryForUserMockRecorder) FindOpenPRsByReviewers(ctx, reviewerIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOpenPRsByReviewers", reflect.TypeOf((*MockPullRequestRepositoryForUser)(nil).FindOpenPRsByReviewers), ctx, reviewerIDs)
+}
+
+// MockReviewerRepositoryForUser is a mock of ReviewerRepositoryForUser interface.
+type MockReviewerRepositoryForUser struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewerRepositoryForUserMockRecorder
+	isgomock struct{}
+}
+
+// MockReviewerRepositoryForUserMockRecorder is the mock recorder for MockReviewerRepositoryForUser.
+type MockReviewerRepositoryForUserMockRecorder struct {
+	mock *MockReviewerRepositoryForUser
+}
+
+// NewMockReviewerRepositoryForUser creates a new mock instance.
+func NewMockReviewerRepositoryForUser(ctrl *gomock.Controller) *MockReviewerRepositoryForUser {
+	mock := &MockReviewerRepositoryForUser{ctrl: ctrl}
+	mock.recorder = &MockReviewerRepositoryForUserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewerRepositoryForUser) EXPECT() *MockReviewerRepositoryForUserMockRecorder {
+	return m.recorder
+}
+
+// FindAssignmentsAfter mocks base method.
+func (m *MockReviewerRepositoryForUser) FindAssignmentsAfter(ctx context.Context, reviewerID string, after models.AssignmentCursor, limit int) ([]*models.ReviewAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAssignmentsAfter", ctx, reviewerID, after, limit)
+	ret0, _ := ret[0].([]*models.ReviewAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAssignmentsAfter indicates an expected call of FindAssignmentsAfter.
+func (mr *MockReviewerRepositoryForUserMockRecorder) FindAssignmentsAfter(ctx, reviewerID, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAssignmentsAfter", reflect.TypeOf((*MockReviewerRepositoryForUser)(nil).FindAssignmentsAfter), ctx, reviewerID, after, limit)
+}
+
+// GetAssignmentsByPRs mocks base method.
+func (m *MockReviewerRepositoryForUser) GetAssignmentsByPRs(ctx context.Context, prIDs []string) ([]*models.ReviewAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignmentsByPRs", ctx, prIDs)
+	ret0, _ := ret[0].([]*models.ReviewAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssignmentsByPRs indicates an expected call of GetAssignmentsByPRs.
+func (mr *MockReviewerRepositoryForUserMockRecorder) GetAssignmentsByPRs(ctx, prIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignmentsByPRs", reflect.TypeOf((*MockReviewerRepositoryForUser)(nil).GetAssignmentsByPRs), ctx, prIDs)
+}
+
+// GetReviewsChangedAt mocks base method.
+func (m *MockReviewerRepositoryForUser) GetReviewsChangedAt(ctx context.Context, reviewerID string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewsChangedAt", ctx, reviewerID)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewsChangedAt indicates an expected call of GetReviewsChangedAt.
+func (mr *MockReviewerRepositoryForUserMockRecorder) GetReviewsChangedAt(ctx, reviewerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewsChangedAt", reflect.TypeOf((*MockReviewerRepositoryForUser)(nil).GetReviewsChangedAt), ctx, reviewerID)
+}
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/pullrequest"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/statistics"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/team"
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/shirr9/pr-reviewer-service/internal/infrastructure/persistence/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// orgEnv wires the services to in-memory storage holding two organizations, each with a backend
+// team whose members share their ids with the other one:
+//   - the default organization has u1, u2 and u3, all active;
+//   - payments has u1, u2 inactive and u4.
+//
+// Both have a PR pr-1 authored by u1.
+type orgEnv struct {
+	*memoryEnv
+	defaultCtx  context.Context
+	paymentsCtx context.Context
+	prs         *PullRequestService
+	teams       *TeamService
+	statistics  *StatisticsService
+	created     map[string]*pullrequest.CreatePrResponse
+}
+
+func newOrgEnv(t *testing.T) *orgEnv {
+	t.Helper()
+	env := &orgEnv{
+		memoryEnv:   newMemoryEnv(),
+		defaultCtx:  context.Background(),
+		paymentsCtx: orgctx.WithID(context.Background(), "payments"),
+		created:     map[string]*pullrequest.CreatePrResponse{},
+	}
+	env.prs = env.prService()
+	env.teams = env.teamService()
+	env.statistics = env.statisticsService(config.Statistics{})
+
+	teams := map[context.Context][]team.TeamMember{
+		env.defaultCtx: {
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+			{UserID: "u3", Username: "Carol", IsActive: true},
+		},
+		env.paymentsCtx: {
+			{UserID: "u1", Username: "Dave", IsActive: true},
+			{UserID: "u2", Username: "Erin", IsActive: false},
+			{UserID: "u4", Username: "Frank", IsActive: true},
+		},
+	}
+	for ctx, members := range teams {
+		_, err := env.teams.AddTeam(ctx, team.AddTeamRequest{TeamName: "backend", Members: members})
+		require.NoError(t, err)
+		resp, err := env.prs.CreatePR(ctx, pullrequest.CreatePrRequest{
+			PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u1",
+		})
+		require.NoError(t, err)
+		env.created[orgctx.ID(ctx)] = resp
+	}
+	return env
+}
+
+func TestOrgIsolation_CandidateSelection(t *testing.T) {
+	t.Run("Success - Reviewers are picked from the organization of the PR", func(t *testing.T) {
+		env := newOrgEnv(t)
+
+		assert.ElementsMatch(t, []string{"u2", "u3"}, env.created[orgctx.DefaultID].Pr.AssignedReviewers)
+		assert.Equal(t, []string{"u4"}, env.created["payments"].Pr.AssignedReviewers,
+			"u2 is inactive in payments and u3 is not a member there")
+	})
+
+	t.Run("Error - Reassignment finds no candidate in the other organization", func(t *testing.T) {
+		env := newOrgEnv(t)
+
+		_, err := env.prs.ReassignReviewer(env.defaultCtx, pullrequest.ReassignReviewerRequest{
+			PullRequestID: "pr-1", OldReviewerID: "u2",
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeNoCandidate, err.(*errors.AppError).Code, "u4 of payments is no candidate")
+	})
+
+	t.Run("Success - Merging a PR leaves the PR of the same id in the other organization open", func(t *testing.T) {
+		env := newOrgEnv(t)
+
+		_, err := env.prs.MergePR(env.paymentsCtx, pullrequest.MergePrRequest{PullRequestID: "pr-1"})
+		require.NoError(t, err)
+
+		stats, err := env.statistics.GetStatistics(env.defaultCtx, statistics.StatisticsRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, stats.OpenPRs)
+		assert.Zero(t, stats.MergedPRs)
+	})
+}
+
+// blockingOrgPRRepo blocks GetAllPRs of the default organization until release is closed, so a
+// statistics computation of it stays in flight.
+type blockingOrgPRRepo struct {
+	*memory.PullRequestRepository
+	started chan struct{}
+	release chan struct{}
+}
+
+func (r *blockingOrgPRRepo) GetAllPRs(ctx context.Context, teamName string) ([]*models.PullRequest, error) {
+	if orgctx.ID(ctx) == orgctx.DefaultID {
+		close(r.started)
+		<-r.release
+	}
+	return r.PullRequestRepository.GetAllPRs(ctx, teamName)
+}
+
+// assertOrgStatistics checks the statistics of each organization of orgEnv.
+func assertOrgStatistics(t *testing.T, defaultStats, paymentsStats *statistics.StatisticsResponse) {
+	t.Helper()
+	assert.Equal(t, 1, defaultStats.TotalPRs)
+	assert.Equal(t, 2, defaultStats.TotalAssignments)
+	assert.Equal(t, 1, paymentsStats.TotalPRs)
+	assert.Equal(t, 1, paymentsStats.TotalAssignments)
+	if assert.Len(t, defaultStats.TeamStats, 1) && assert.Len(t, paymentsStats.TeamStats, 1) {
+		assert.Equal(t, 3, defaultStats.TeamStats[0].ActiveMembers)
+		assert.Equal(t, 2, defaultStats.TeamStats[0].ActiveReviews)
+		assert.Equal(t, 2, paymentsStats.TeamStats[0].ActiveMembers)
+		assert.Equal(t, 1, paymentsStats.TeamStats[0].ActiveReviews)
+	}
+}
+
+func TestOrgIsolation_Statistics(t *testing.T) {
+	req := statistics.StatisticsRequest{Include: statistics.Include{TeamStats: true}}
+
+	t.Run("Success - Statistics count the organization of the request", func(t *testing.T) {
+		env := newOrgEnv(t)
+
+		defaultStats, err := env.statistics.GetStatistics(env.defaultCtx, req)
+		require.NoError(t, err)
+		paymentsStats, err := env.statistics.GetStatistics(env.paymentsCtx, req)
+		require.NoError(t, err)
+
+		assertOrgStatistics(t, defaultStats, paymentsStats)
+	})
+
+	t.Run("Success - Concurrent requests of another organization don't share the computation", func(t *testing.T) {
+		env := newOrgEnv(t)
+		prRepo := &blockingOrgPRRepo{PullRequestRepository: env.prRepo,
+			started: make(chan struct{}), release: make(chan struct{})}
+		service := NewStatisticsService(env.userRepo, prRepo, env.reviewerRepo, config.Statistics{}, testReview,
+			env.logger)
+
+		var defaultStats *statistics.StatisticsResponse
+		var defaultErr error
+		defaultDone := make(chan struct{})
+		go func() {
+			defer close(defaultDone)
+			defaultStats, defaultErr = service.GetStatistics(env.defaultCtx, req)
+		}()
+		<-prRepo.started
+
+		var paymentsStats *statistics.StatisticsResponse
+		var paymentsErr error
+		paymentsDone := make(chan struct{})
+		go func() {
+			defer close(paymentsDone)
+			paymentsStats, paymentsErr = service.GetStatistics(env.paymentsCtx, req)
+		}()
+		// joining the computation of the default organization would wait for it
+		select {
+		case <-paymentsDone:
+		case <-time.After(time.Second):
+			t.Error("statistics of payments waited for the computation of the default organization")
+		}
+		close(prRepo.release)
+		<-defaultDone
+		<-paymentsDone
+
+		require.NoError(t, defaultErr)
+		require.NoError(t, paymentsErr)
+		assertOrgStatistics(t, defaultStats, paymentsStats)
+	})
+}
+
+func TestOrgIsolation_Teams(t *testing.T) {
+	t.Run("Success - Team of the same name holds the members of its organization", func(t *testing.T) {
+		env := newOrgEnv(t)
+
+		got, err := env.teams.GetTeam(env.paymentsCtx, "backend")
+
+		require.NoError(t, err)
+		var names []string
+		for _, member := range got.Members {
+			names = append(names, member.Username)
+		}
+		assert.ElementsMatch(t, []string{"Dave", "Erin", "Frank"}, names)
+	})
+
+	t.Run("Success - Teams are listed per organization", func(t *testing.T) {
+		env := newOrgEnv(t)
+
+		page, err := env.teams.ListTeams(orgctx.WithID(context.Background(), "marketing"),
+			team.ListTeamsRequest{Page: dto.PageRequest{Limit: 10}})
+
+		require.NoError(t, err)
+		assert.Zero(t, page.Total)
+	})
+
+	t.Run("Success - Deactivating a team leaves the team of the same name in the other organization", func(t *testing.T) {
+		env := newOrgEnv(t)
+
+		resp, err := env.teams.DeactivateTeam(env.paymentsCtx, team.DeactivateTeamRequest{TeamName: "backend"})
+		require.NoError(t, err)
+		assert.Equal(t, 2, resp.DeactivatedUsers)
+
+		got, err := env.teams.GetTeam(env.defaultCtx, "backend")
+		require.NoError(t, err)
+		for _, member := range got.Members {
+			assert.True(t, member.IsActive, member.UserID)
+		}
+		paymentsHistory, err := env.prs.GetHistory(env.paymentsCtx, "pr-1")
+		require.NoError(t, err)
+		assert.NotEmpty(t, paymentsHistory.History, "u4 is removed from the PR of payments")
+		defaultHistory, err := env.prs.GetHistory(env.defaultCtx, "pr-1")
+		require.NoError(t, err)
+		assert.Empty(t, defaultHistory.History)
+	})
+}
+
+func TestOrgIsolation_Webhooks(t *testing.T) {
+	t.Run("Success - Deliveries are counted in the organization that queued them", func(t *testing.T) {
+		env := newOrgEnv(t)
+		webhookRepo := env.storage.NewWebhookRepository()
+		webhooks := NewWebhookService(webhookRepo, env.uow, nil, config.Webhook{}, env.logger)
+
+		require.NoError(t, webhooks.Send(env.paymentsCtx, map[string]string{"type": "review.escalated"}))
+
+		defaultStats, err := webhooks.Stats(env.defaultCtx)
+		require.NoError(t, err)
+		assert.Equal(t, &admin.WebhookStatsResponse{}, defaultStats)
+		paymentsStats, err := webhooks.Stats(env.paymentsCtx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, paymentsStats.Pending)
+		pending, err := webhookRepo.CountPending(env.defaultCtx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, pending, "readiness counts the deliveries of every organization")
+	})
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"sync/atomic"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+)
+
+// PoolMonitor defines the interface for the number of requests waiting for a database connection.
+type PoolMonitor interface {
+	WaitingAcquires() int
+}
+
+// LoadShedder refuses new requests while too many are already waiting for a database connection:
+// they would only queue up behind them and most would run out of the acquire timeout anyway.
+type LoadShedder struct {
+	pool       PoolMonitor
+	maxWaiting int
+	shed       atomic.Int64
+}
+
+// NewLoadShedder creates a shedder refusing requests while more than cfg.MaxWaiting requests are
+// waiting for a connection; it refuses none when MaxWaiting is zero.
+func NewLoadShedder(pool PoolMonitor, cfg config.Overload) *LoadShedder {
+	return &LoadShedder{pool: pool, maxWaiting: cfg.MaxWaiting}
+}
+
+// Shed reports whether a new request should be refused, counting it when it is.
+func (s *LoadShedder) Shed() bool {
+	if s.maxWaiting <= 0 || s.pool.WaitingAcquires() <= s.maxWaiting {
+		return false
+	}
+	s.shed.Add(1)
+	return true
+}
+
+// ShedCount returns the number of requests refused so far.
+func (s *LoadShedder) ShedCount() int64 {
+	return s.shed.Load()
+}
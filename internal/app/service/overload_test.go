@@ -0,0 +1,39 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+)
+
+type fakePoolMonitor int
+
+func (f fakePoolMonitor) WaitingAcquires() int { return int(f) }
+
+func TestLoadShedder_Shed(t *testing.T) {
+	tests := []struct {
+		name       string
+		waiting    int
+		maxWaiting int
+		want       bool
+	}{
+		{name: "Success - Disabled check lets every request through", waiting: 100, maxWaiting: 0, want: false},
+		{name: "Success - Request below the threshold is let through", waiting: 3, maxWaiting: 5, want: false},
+		{name: "Success - Request at the threshold is let through", waiting: 5, maxWaiting: 5, want: false},
+		{name: "Error - Request above the threshold is shed", waiting: 6, maxWaiting: 5, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shedder := NewLoadShedder(fakePoolMonitor(tt.waiting), config.Overload{MaxWaiting: tt.maxWaiting})
+
+			assert.Equal(t, tt.want, shedder.Shed())
+			if tt.want {
+				assert.Equal(t, int64(1), shedder.ShedCount())
+			} else {
+				assert.Zero(t, shedder.ShedCount())
+			}
+		})
+	}
+}
@@ -1,11 +1,22 @@
 package service
 
+//go:generate go tool mockgen -source=$GOFILE -destination=mocks/mock_pull_request_deps.go -package=mocks
+
 import (
 	"context"
+	stderrors "errors"
+	"fmt"
 	"log/slog"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
 	"github.com/shirr9/pr-reviewer-service/internal/app/dto/pullrequest"
+	"github.com/shirr9/pr-reviewer-service/internal/app/events"
 	"github.com/shirr9/pr-reviewer-service/internal/domain/errors"
 	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
 )
@@ -13,24 +24,47 @@ import (
 // PullRequestRepository defines the interface for pull request data persistence operations.
 type PullRequestRepository interface {
 	Create(ctx context.Context, pr *models.PullRequest) error
+	CreateBatch(ctx context.Context, prs []*models.PullRequest) ([]string, error)
 	FindByID(ctx context.Context, prID string) (*models.PullRequest, error)
 	Exists(ctx context.Context, prID string) (bool, error)
-	UpdateStatus(ctx context.Context, prID, status string, mergedAt *time.Time) error
+	UpdateStatus(ctx context.Context, prID, fromStatus, status string, mergedAt *time.Time) (int, error)
+	SetLabels(ctx context.Context, prID string, labels []string) error
+	SearchByTitle(ctx context.Context, query, status string, labels []string, order models.PRSort,
+		limit, offset int) ([]*models.PullRequest, error)
+	CountByTitle(ctx context.Context, query, status string, labels []string) (int, error)
+	FindOpenWithoutReviewers(ctx context.Context, teamName string, labels []string, skipped *bool, order models.PRSort,
+		limit, offset int) ([]*models.PullRequest, error)
+	CountOpenWithoutReviewers(ctx context.Context, teamName string, labels []string, skipped *bool) (int, error)
+	ClearAssignmentSkipped(ctx context.Context, prID string) error
+	FindOpenPRsByReviewers(ctx context.Context, reviewerIDs []string) ([]*models.PullRequest, error)
 }
 
 // ReviewerRepository defines the interface for reviewer assignment operations.
 type ReviewerRepository interface {
-	AssignReviewer(ctx context.Context, prID, reviewerID string) error
+	AssignReviewer(ctx context.Context, prID, reviewerID, source string) error
 	GetReviewers(ctx context.Context, prID string) ([]string, error)
+	GetReviewersByPRs(ctx context.Context, prIDs []string) (map[string][]string, error)
+	GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error)
+	GetAssignmentTimes(ctx context.Context, userIDs []string, since time.Time) (map[string][]time.Time, error)
+	GetAssignmentsByPRs(ctx context.Context, prIDs []string) ([]*models.ReviewAssignment, error)
+	FindStaleAssignments(ctx context.Context, assignedBefore time.Time, limit int) ([]*models.ReviewAssignment, error)
 	GetPRsByReviewer(ctx context.Context, reviewerID string) ([]string, error)
 	IsAssigned(ctx context.Context, prID, reviewerID string) (bool, error)
-	ReplaceReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) error
+	LockAssignment(ctx context.Context, prID, reviewerID string) (*models.ReviewAssignment, error)
+	SetReviewState(ctx context.Context, prID, reviewerID, state string, changedAt time.Time) error
+	ReplaceReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID, source string) error
+	RecordReviewerChange(ctx context.Context, change *models.ReviewerChange) error
+	GetReviewerHistory(ctx context.Context, prID string) ([]*models.ReviewerChange, error)
 }
 
 // UserRepository defines the interface for user data operations.
 type UserRepository interface {
 	FindByID(ctx context.Context, userID string) (*models.User, error)
 	FindActiveCandidatesForReassignment(ctx context.Context, teamName string, excludeUserIDs []string) ([]*models.User, error)
+	LockActiveCandidate(ctx context.Context, userID string) (bool, error)
+	FindByIDs(ctx context.Context, userIDs []string) ([]*models.User, error)
+	GetExcludedReviewers(ctx context.Context, authorID string) ([]string, error)
+	GetTeamLead(ctx context.Context, teamName string) (string, error)
 }
 
 // Transactor provides transaction management.
@@ -43,8 +77,12 @@ type PullRequestService struct {
 	prRepo       PullRequestRepository
 	reviewerRepo ReviewerRepository
 	userRepo     UserRepository
+	selector     *ReviewerSelector
 	uow          Transactor
+	review       config.Review
 	log          *slog.Logger
+	// events announces queue changes after their transactions commit, when set
+	events EventPublisher
 }
 
 // NewPullRequestService creates a new pull request service.
@@ -53,6 +91,7 @@ func NewPullRequestService(
 	reviewerRepo ReviewerRepository,
 	userRepo UserRepository,
 	uow Transactor,
+	review config.Review,
 	log *slog.Logger,
 ) *PullRequestService {
 	if log == nil {
@@ -62,7 +101,9 @@ func NewPullRequestService(
 		prRepo:       prRepo,
 		reviewerRepo: reviewerRepo,
 		userRepo:     userRepo,
+		selector:     NewReviewerSelector(userRepo, reviewerRepo, review),
 		uow:          uow,
+		review:       review,
 		log:          log,
 	}
 }
@@ -73,127 +114,657 @@ func (s *PullRequestService) CreatePR(ctx context.Context,
 	req pullrequest.CreatePrRequest) (*pullrequest.CreatePrResponse, error) {
 
 	var response pullrequest.CreatePrResponse
-	var reviewerIDs []string
+	var selection *Selection
+	var createdAt time.Time
+	var createdConcurrently bool
 
 	err := s.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
 		exists, err := s.prRepo.Exists(txCtx, req.PullRequestID)
 		if err != nil {
-			s.log.LogAttrs(ctx, slog.LevelError, "failed to check PR existence",
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to check PR existence",
 				slog.String("pr_id", req.PullRequestID), slog.String("error", err.Error()))
 			return err
 		}
 		if exists {
 			s.log.LogAttrs(ctx, slog.LevelWarn, "PR already exists",
 				slog.String("pr_id", req.PullRequestID))
-			return errors.NewPRExists("PR id already exists")
+			return s.existingPRError(txCtx, req.PullRequestID)
 		}
 
-		author, err := s.userRepo.FindByID(txCtx, req.AuthorID)
+		author, err := s.findActiveAuthor(txCtx, req.AuthorID)
 		if err != nil {
-			s.log.LogAttrs(ctx, slog.LevelError, "failed to find author",
-				slog.String("author_id", req.AuthorID), slog.String("error", err.Error()))
 			return err
 		}
-		if author == nil {
-			s.log.LogAttrs(ctx, slog.LevelWarn, "author not found",
-				slog.String("author_id", req.AuthorID))
-			return errors.NewNotFound("resource not found")
-		}
 
-		if !author.IsActive {
-			s.log.LogAttrs(ctx, slog.LevelWarn, "author is not active",
-				slog.String("author_id", req.AuthorID))
-			return errors.NewNotFound("author is not active")
+		selection = &Selection{}
+		if req.SkipAssignment {
+			s.log.LogAttrs(ctx, slog.LevelInfo, "reviewer assignment skipped",
+				slog.String("pr_id", req.PullRequestID))
+		} else if selection, err = s.selectNewPRReviewers(txCtx, req, author); err != nil {
+			return err
 		}
+		tagMatch := newTagMatch(models.NormalizeTags(req.RequiredTags), selection)
 
-		if author.TeamName == "" {
-			s.log.LogAttrs(ctx, slog.LevelWarn, "author has no team",
-				slog.String("author_id", req.AuthorID))
-			return errors.NewNotFound("resource not found")
+		pr := newPR(req, time.Now().UTC())
+		createdAt = pr.CreatedAt
+		if err := s.prRepo.Create(txCtx, pr); err != nil {
+			if errors.HasCode(err, errors.CodePRExists) {
+				s.log.LogAttrs(ctx, slog.LevelWarn, "PR was created concurrently",
+					slog.String("pr_id", req.PullRequestID))
+				createdConcurrently = true
+				return err
+			}
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to create PR",
+				slog.String("pr_id", req.PullRequestID), slog.String("error", err.Error()))
+			return err
 		}
 
-		candidates, err := s.userRepo.FindActiveCandidatesForReassignment(
-			txCtx,
-			author.TeamName,
-			[]string{req.AuthorID})
-
-		if err != nil {
-			s.log.LogAttrs(ctx, slog.LevelError, "failed to find reviewer candidates",
-				slog.String("team", author.TeamName), slog.String("error", err.Error()))
+		if err := s.assignReviewers(txCtx, req.PullRequestID, selection); err != nil {
 			return err
 		}
-		const maxReviewers = 2
-		reviewers := maxReviewers
-		if len(candidates) < reviewers {
-			reviewers = len(candidates)
+		response = pullrequest.CreatePrResponse{
+			Pr:                  newPRDto(pr, selection.ReviewerIDs),
+			OverloadedReviewers: selection.Overloaded,
+			TagMatch:            tagMatch,
 		}
+		return s.withReviewerDetails(txCtx, &response.Pr)
+	})
 
-		if reviewers == 0 {
-			s.log.LogAttrs(ctx, slog.LevelWarn, "no active reviewer candidates found",
-				slog.String("pr_id", req.PullRequestID),
-				slog.String("team", author.TeamName))
+	if createdConcurrently {
+		// the transaction lost an insert race and is aborted, so the winner is read in a fresh one
+		return nil, s.concurrentPRError(ctx, req.PullRequestID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "PR created successfully",
+		slog.String("pr_id", req.PullRequestID),
+		slog.Int("reviewers_count", len(selection.ReviewerIDs)))
+
+	queued := make([]events.Event, 0, len(selection.ReviewerIDs))
+	for _, reviewerID := range selection.ReviewerIDs {
+		queued = append(queued, s.assignedEvent(response.Pr, reviewerID, selection.source(reviewerID), createdAt))
+	}
+	s.publish(ctx, queued...)
+	return &response, nil
+}
+
+// createBulkChunkSize is the number of PRs a bulk create inserts in one transaction.
+const createBulkChunkSize = 25
+
+// CreateBulk creates the PRs of the batch in transactions of createBulkChunkSize PRs, inserting each
+// chunk with batched statements. PRs that exist or whose author can't be found are reported in their
+// results without aborting the chunk; a chunk failing as a whole fails each of its PRs.
+func (s *PullRequestService) CreateBulk(ctx context.Context, req pullrequest.CreateBulkRequest) (*pullrequest.CreateBulkResponse, error) {
+	response := pullrequest.CreateBulkResponse{
+		Results: make([]pullrequest.CreateBulkResult, 0, len(req.PullRequests)),
+	}
+
+	for start := 0; start < len(req.PullRequests); start += createBulkChunkSize {
+		chunk := req.PullRequests[start:min(start+createBulkChunkSize, len(req.PullRequests))]
+
+		results, err := s.createChunk(ctx, chunk, req.AssignReviewers)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			results = make([]pullrequest.CreateBulkResult, 0, len(chunk))
+			for _, item := range chunk {
+				results = append(results, pullrequest.CreateBulkResult{
+					PullRequestID: item.PullRequestID,
+					Result:        pullrequest.CreateResultFailed,
+					Error:         err.Error(),
+				})
+			}
 		}
 
-		reviewerIDs = make([]string, 0, reviewers)
-		for i := 0; i < reviewers; i++ {
-			reviewerIDs = append(reviewerIDs, candidates[i].Id)
+		for i, result := range results {
+			result.Index = start + i
+			switch result.Result {
+			case pullrequest.CreateResultCreated:
+				response.Summary.Created++
+			case pullrequest.CreateResultAlreadyExists:
+				response.Summary.AlreadyExists++
+			case pullrequest.CreateResultNotFound:
+				response.Summary.NotFound++
+			case pullrequest.CreateResultInvalid:
+				response.Summary.Invalid++
+			default:
+				response.Summary.Failed++
+			}
+			response.Results = append(response.Results, result)
 		}
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "bulk create finished",
+		slog.Int("requested", len(req.PullRequests)),
+		slog.Int("created", response.Summary.Created),
+		slog.Int("already_exists", response.Summary.AlreadyExists),
+		slog.Int("not_found", response.Summary.NotFound),
+		slog.Int("invalid", response.Summary.Invalid),
+		slog.Int("failed", response.Summary.Failed))
+
+	return &response, nil
+}
+
+// createChunk creates the PRs of one chunk in a single transaction and returns their results in order.
+// The assignments are announced once the transaction commits.
+func (s *PullRequestService) createChunk(ctx context.Context, items []pullrequest.CreatePrRequest,
+	assignReviewers bool) ([]pullrequest.CreateBulkResult, error) {
+	var results []pullrequest.CreateBulkResult
+	var queued []events.Event
 
+	err := s.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
+		results = make([]pullrequest.CreateBulkResult, len(items))
+		queued = nil
+		pending := make(map[string]int, len(items))
+		authors := make(map[string]*models.User, len(items))
 		now := time.Now().UTC()
-		pr := &models.PullRequest{
-			Id:        req.PullRequestID,
-			Title:     req.PullRequestName,
-			AuthorId:  req.AuthorID,
-			Status:    models.PRStatusOpen,
-			CreatedAt: now,
-			UpdatedAt: now,
+		prs := make([]*models.PullRequest, 0, len(items))
+
+		for i, item := range items {
+			results[i] = pullrequest.CreateBulkResult{PullRequestID: item.PullRequestID}
+			if _, ok := pending[item.PullRequestID]; ok {
+				results[i].Result = pullrequest.CreateResultAlreadyExists
+				results[i].Error = "PR id is repeated in the batch"
+				continue
+			}
+
+			exists, err := s.prRepo.Exists(txCtx, item.PullRequestID)
+			if err != nil {
+				s.log.LogAttrs(ctx, errorLevel(err), "failed to check PR existence",
+					slog.String("pr_id", item.PullRequestID), slog.String("error", err.Error()))
+				return err
+			}
+			if exists {
+				results[i].Result = pullrequest.CreateResultAlreadyExists
+				results[i].Error = "PR id already exists"
+				continue
+			}
+
+			author, err := s.findActiveAuthor(txCtx, item.AuthorID)
+			if errors.HasCode(err, errors.CodeNotFound) {
+				results[i].Result = pullrequest.CreateResultNotFound
+				results[i].Error = err.Error()
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if assignReviewers && !item.SkipAssignment && len(item.ReviewerIDs) > 0 {
+				// pinned reviewers are checked before the PR is inserted, so a PR is never left without them
+				err = s.checkPinnedReviewersOf(txCtx, item, author)
+				if errors.HasCode(err, errors.CodeValidation) {
+					results[i].Result = pullrequest.CreateResultInvalid
+					results[i].Error = err.Error()
+					continue
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+			pending[item.PullRequestID] = i
+			authors[item.PullRequestID] = author
+			prs = append(prs, newPR(item, now))
+		}
+		if len(prs) == 0 {
+			return nil
 		}
 
-		if err := s.prRepo.Create(txCtx, pr); err != nil {
-			s.log.LogAttrs(ctx, slog.LevelError, "failed to create PR",
-				slog.String("pr_id", req.PullRequestID), slog.String("error", err.Error()))
+		created, err := s.prRepo.CreateBatch(txCtx, prs)
+		if err != nil {
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to create PRs",
+				slog.Int("count", len(prs)), slog.String("error", err.Error()))
 			return err
 		}
+		inserted := make(map[string]bool, len(created))
+		for _, id := range created {
+			inserted[id] = true
+		}
 
-		for _, reviewerID := range reviewerIDs {
-			if err := s.reviewerRepo.AssignReviewer(txCtx, req.PullRequestID, reviewerID); err != nil {
-				s.log.LogAttrs(ctx, slog.LevelError, "failed to assign reviewer",
-					slog.String("pr_id", req.PullRequestID),
-					slog.String("reviewer_id", reviewerID),
-					slog.String("error", err.Error()))
-				return err
+		for _, pr := range prs {
+			i := pending[pr.Id]
+			if !inserted[pr.Id] {
+				s.log.LogAttrs(ctx, slog.LevelWarn, "PR was created concurrently",
+					slog.String("pr_id", pr.Id))
+				results[i].Result = pullrequest.CreateResultAlreadyExists
+				results[i].Error = "PR id already exists"
+				continue
+			}
+
+			selection := &Selection{}
+			if assignReviewers && !items[i].SkipAssignment {
+				selection, err = s.selectNewPRReviewers(txCtx, items[i], authors[pr.Id])
+				if err != nil {
+					return err
+				}
+				if err = s.assignReviewers(txCtx, pr.Id, selection); err != nil {
+					return err
+				}
+			}
+			prDto := newPRDto(pr, selection.ReviewerIDs)
+			results[i].Result = pullrequest.CreateResultCreated
+			results[i].Pr = &prDto
+			for _, reviewerID := range selection.ReviewerIDs {
+				queued = append(queued, s.assignedEvent(prDto, reviewerID, selection.source(reviewerID), pr.CreatedAt))
 			}
-		}
-		response = pullrequest.CreatePrResponse{
-			Pr: pullrequest.PR{
-				PullRequestID:     pr.Id,
-				PullRequestName:   pr.Title,
-				AuthorID:          pr.AuthorId,
-				Status:            pr.Status,
-				AssignedReviewers: reviewerIDs,
-			},
 		}
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	s.publish(ctx, queued...)
+	return results, nil
+}
+
+// newPR builds an open PR from the create request.
+func newPR(req pullrequest.CreatePrRequest, now time.Time) *models.PullRequest {
+	return &models.PullRequest{
+		Id:                req.PullRequestID,
+		Title:             req.PullRequestName,
+		AuthorId:          req.AuthorID,
+		Status:            models.PRStatusOpen,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+		Priority:          priorityOrDefault(req.Priority),
+		Labels:            models.NormalizeLabels(req.Labels),
+		AssignmentSkipped: req.SkipAssignment,
+	}
+}
 
+// selectNewPRReviewers selects reviewers of a new PR by the author, skipping the author and
+// reviewers excluded for them. Pinned reviewers are taken as they are once checked, and topped
+// up by the selector only when the request asks to fill the remaining places.
+func (s *PullRequestService) selectNewPRReviewers(ctx context.Context, req pullrequest.CreatePrRequest,
+	author *models.User) (*Selection, error) {
+	exclude, err := withExclusions(ctx, s.userRepo, req.AuthorID, []string{req.AuthorID})
 	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get excluded reviewers",
+			slog.String("author_id", req.AuthorID), slog.String("error", err.Error()))
 		return nil, err
 	}
 
-	s.log.LogAttrs(ctx, slog.LevelInfo, "PR created successfully",
-		slog.String("pr_id", req.PullRequestID),
-		slog.Int("reviewers_count", len(reviewerIDs)))
-	return &response, nil
+	requiredTags := models.NormalizeTags(req.RequiredTags)
+	pinned := &Selection{}
+	if len(req.ReviewerIDs) > 0 {
+		if pinned, err = s.checkPinnedReviewers(ctx, req, author, exclude); err != nil {
+			return nil, err
+		}
+		if !req.FillRemaining || len(pinned.ReviewerIDs) >= models.MaxReviewers {
+			s.logOverload(ctx, req.PullRequestID, pinned)
+			return pinned, nil
+		}
+		exclude = append(exclude, pinned.ReviewerIDs...)
+	}
+
+	selection, err := s.selector.Select(ctx, author.TeamName, exclude, priorityOrDefault(req.Priority),
+		requiredTags, models.MaxReviewers-len(pinned.ReviewerIDs))
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to select reviewers",
+			slog.String("team", author.TeamName), slog.String("error", err.Error()))
+		return nil, err
+	}
+	selection = &Selection{
+		ReviewerIDs: append(pinned.ReviewerIDs, selection.ReviewerIDs...),
+		Overloaded:  append(pinned.Overloaded, selection.Overloaded...),
+		TagMatched:  append(pinned.TagMatched, selection.TagMatched...),
+		Pinned:      pinned.Pinned,
+	}
+	if len(selection.ReviewerIDs) == 0 {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "no active reviewer candidates found",
+			slog.String("pr_id", req.PullRequestID),
+			slog.String("team", author.TeamName))
+	}
+	s.logOverload(ctx, req.PullRequestID, selection)
+	if tagMatch := newTagMatch(requiredTags, selection); tagMatch != nil && tagMatch.Fallback {
+		s.log.LogAttrs(ctx, slog.LevelInfo, "no tag match for some reviewers, using the team pool",
+			slog.String("pr_id", req.PullRequestID), slog.Any("required_tags", requiredTags))
+	}
+	return selection, nil
+}
+
+// checkPinnedReviewers checks that each pinned reviewer exists, is active, is in the author's team
+// and its reviewer pool, is neither the author nor excluded for them and, unless the PR is urgent,
+// is below capacity like the selector requires. Every failing reviewer is reported in the details of
+// a validation error.
+func (s *PullRequestService) checkPinnedReviewers(ctx context.Context, req pullrequest.CreatePrRequest,
+	author *models.User, exclude []string) (*Selection, error) {
+	users, err := s.userRepo.FindByIDs(ctx, req.ReviewerIDs)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find pinned reviewers",
+			slog.Any("reviewer_ids", req.ReviewerIDs), slog.String("error", err.Error()))
+		return nil, err
+	}
+	counts, err := s.reviewerRepo.GetOpenReviewCounts(ctx, req.ReviewerIDs)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to count open reviews of pinned reviewers",
+			slog.Any("reviewer_ids", req.ReviewerIDs), slog.String("error", err.Error()))
+		return nil, err
+	}
+	byID := make(map[string]*models.User, len(users))
+	for _, user := range users {
+		byID[user.Id] = user
+	}
+
+	requiredTags := models.NormalizeTags(req.RequiredTags)
+	urgent := priorityOrDefault(req.Priority) == models.PRPriorityUrgent
+	selection := &Selection{}
+	var fields []dto.FieldError
+	for i, reviewerID := range req.ReviewerIDs {
+		field := fmt.Sprintf("reviewer_ids[%d]", i)
+		user := byID[reviewerID]
+		var capacity int
+		if user != nil {
+			capacity = user.ReviewCapacity(s.review.MaxActiveReviews)
+		}
+		atCap := atCapacity(counts[reviewerID], capacity)
+		switch {
+		case reviewerID == author.Id:
+			fields = append(fields, dto.FieldError{Field: field, Rule: "not_author"})
+		case user == nil:
+			fields = append(fields, dto.FieldError{Field: field, Rule: "exists"})
+		case !user.IsActive:
+			fields = append(fields, dto.FieldError{Field: field, Rule: "active"})
+		case user.TeamName != author.TeamName:
+			fields = append(fields, dto.FieldError{Field: field, Rule: "team", Param: author.TeamName})
+		case user.NonReviewer:
+			fields = append(fields, dto.FieldError{Field: field, Rule: "reviewer"})
+		case slices.Contains(exclude, reviewerID):
+			fields = append(fields, dto.FieldError{Field: field, Rule: "not_excluded"})
+		case atCap && !urgent:
+			fields = append(fields, dto.FieldError{Field: field, Rule: "capacity", Param: strconv.Itoa(capacity)})
+		default:
+			selection.ReviewerIDs = append(selection.ReviewerIDs, reviewerID)
+			if atCap {
+				selection.Overloaded = append(selection.Overloaded, reviewerID)
+			}
+			if user.HasAnyTag(requiredTags) {
+				selection.TagMatched = append(selection.TagMatched, reviewerID)
+			}
+		}
+	}
+	if len(fields) > 0 {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "pinned reviewers can't review the PR",
+			slog.String("pr_id", req.PullRequestID), slog.Any("fields", fields))
+		return nil, errors.NewValidation("pinned reviewers can't review the PR").
+			WithDetails(dto.ValidationDetails{Fields: fields})
+	}
+	selection.Pinned = selection.ReviewerIDs
+	return selection, nil
+}
+
+// checkPinnedReviewersOf checks the pinned reviewers of a new PR by the author with the author's exclusions.
+func (s *PullRequestService) checkPinnedReviewersOf(ctx context.Context, req pullrequest.CreatePrRequest,
+	author *models.User) error {
+	exclude, err := withExclusions(ctx, s.userRepo, req.AuthorID, []string{req.AuthorID})
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get excluded reviewers",
+			slog.String("author_id", req.AuthorID), slog.String("error", err.Error()))
+		return err
+	}
+	_, err = s.checkPinnedReviewers(ctx, req, author, exclude)
+	return err
+}
+
+// assignReviewers assigns the chosen reviewers to the PR, pinned ones as manual assignments.
+func (s *PullRequestService) assignReviewers(ctx context.Context, prID string, selection *Selection) error {
+	for _, reviewerID := range selection.ReviewerIDs {
+		if err := s.reviewerRepo.AssignReviewer(ctx, prID, reviewerID, selection.source(reviewerID)); err != nil {
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to assign reviewer",
+				slog.String("pr_id", prID),
+				slog.String("reviewer_id", reviewerID),
+				slog.String("error", err.Error()))
+			return err
+		}
+	}
+	return nil
+}
+
+// findActiveAuthor returns the author of a new PR, who must exist, be active and belong to a team.
+func (s *PullRequestService) findActiveAuthor(ctx context.Context, authorID string) (*models.User, error) {
+	author, err := s.userRepo.FindByID(ctx, authorID)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find author",
+			slog.String("author_id", authorID), slog.String("error", err.Error()))
+		return nil, err
+	}
+	if author == nil {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "author not found",
+			slog.String("author_id", authorID))
+		return nil, errors.NewNotFound("resource not found")
+	}
+
+	if !author.IsActive {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "author is not active",
+			slog.String("author_id", authorID))
+		return nil, errors.NewNotFound("author is not active")
+	}
+
+	if author.TeamName == "" {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "author has no team",
+			slog.String("author_id", authorID))
+		return nil, errors.NewNotFound("resource not found")
+	}
+
+	return author, nil
+}
+
+// SuggestReviewers returns ranked reviewer candidates for a new PR by the author,
+// using the same selection as CreatePR, without writing anything.
+func (s *PullRequestService) SuggestReviewers(ctx context.Context,
+	req pullrequest.SuggestReviewersRequest) (*pullrequest.SuggestReviewersResponse, error) {
+	author, err := s.findActiveAuthor(ctx, req.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	exclude, err := withExclusions(ctx, s.userRepo, req.AuthorID, []string{req.AuthorID})
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get excluded reviewers",
+			slog.String("author_id", req.AuthorID), slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	ranked, err := s.selector.Rank(ctx, author.TeamName, exclude, priorityOrDefault(req.Priority),
+		models.NormalizeTags(req.RequiredTags))
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to rank reviewer candidates",
+			slog.String("team", author.TeamName), slog.String("error", err.Error()))
+		return nil, err
+	}
+	if len(ranked) > req.Count {
+		ranked = ranked[:req.Count]
+	}
+
+	response := &pullrequest.SuggestReviewersResponse{
+		AuthorID:   author.Id,
+		TeamName:   author.TeamName,
+		Candidates: make([]pullrequest.SuggestedReviewer, 0, len(ranked)),
+	}
+	for _, candidate := range ranked {
+		response.Candidates = append(response.Candidates, pullrequest.SuggestedReviewer{
+			UserID:      candidate.User.Id,
+			Username:    candidate.User.Name,
+			OpenReviews: candidate.OpenReviews,
+			Weight:      candidate.Weight,
+			Capacity:    candidate.Capacity,
+			AtCapacity:  candidate.AtCapacity,
+			MatchesTags: candidate.MatchesTags,
+			InHours:     candidate.InWorkingHours,
+		})
+	}
+	return response, nil
+}
+
+// logOverload warns about reviewers assigned beyond their capacity.
+func (s *PullRequestService) logOverload(ctx context.Context, prID string, selection *Selection) {
+	if len(selection.Overloaded) == 0 {
+		return
+	}
+	s.log.LogAttrs(ctx, slog.LevelWarn, "reviewers assigned over capacity",
+		slog.String("pr_id", prID), slog.Any("reviewer_ids", selection.Overloaded))
+}
+
+// newTagMatch reports how the selection matched the required tags, or nil when none are required.
+func newTagMatch(requiredTags []string, selection *Selection) *pullrequest.TagMatch {
+	if len(requiredTags) == 0 {
+		return nil
+	}
+	matched := selection.TagMatched
+	if matched == nil {
+		matched = []string{}
+	}
+	return &pullrequest.TagMatch{
+		RequiredTags:     requiredTags,
+		MatchedReviewers: matched,
+		Fallback:         len(matched) < len(selection.ReviewerIDs),
+	}
+}
+
+// priorityOrDefault returns the requested priority or NORMAL when none was given.
+func priorityOrDefault(priority string) string {
+	if priority == "" {
+		return models.PRPriorityNormal
+	}
+	return priority
+}
+
+// sortUrgentFirst moves URGENT PRs to the front, keeping the order otherwise.
+func sortUrgentFirst(prs []*models.PullRequest) {
+	sort.SliceStable(prs, func(i, j int) bool {
+		return prs[i].Priority == models.PRPriorityUrgent && prs[j].Priority != models.PRPriorityUrgent
+	})
+}
+
+// prSort converts the sort of a request, whose field the handler has checked, to the repository sort.
+func prSort(sort dto.Sort) models.PRSort {
+	return models.PRSort{Field: sort.Field, Desc: sort.Desc}
+}
+
+// newPRDto converts a pull request and its reviewers to the response DTO.
+// A PR without reviewers renders an empty list rather than null.
+func newPRDto(pr *models.PullRequest, reviewers []string) pullrequest.PR {
+	if reviewers == nil {
+		reviewers = []string{}
+	}
+	return pullrequest.PR{
+		PullRequestID:     pr.Id,
+		PullRequestName:   pr.Title,
+		AuthorID:          pr.AuthorId,
+		Status:            pr.Status,
+		Priority:          pr.Priority,
+		Labels:            labelsOrEmpty(pr.Labels),
+		AssignedReviewers: reviewers,
+		CreatedAt:         dto.FormatTime(pr.CreatedAt),
+		UpdatedAt:         dto.FormatTime(pr.UpdatedAt),
+		MergedAt:          dto.FormatTimePtr(pr.MergedAt),
+		AssignmentSkipped: pr.AssignmentSkipped,
+	}
+}
+
+// labelsOrEmpty keeps PR DTOs from rendering missing labels as null.
+func labelsOrEmpty(labels []string) []string {
+	if labels == nil {
+		return []string{}
+	}
+	return labels
+}
+
+// withReviewerDetails expands reviewers of the PR DTO.
+func (s *PullRequestService) withReviewerDetails(ctx context.Context, pr *pullrequest.PR) error {
+	if err := expandReviewers(ctx, s.userRepo, s.reviewerRepo, s.review.Deadline, pr); err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to load reviewer details",
+			slog.String("pr_id", pr.PullRequestID), slog.String("error", err.Error()))
+		return err
+	}
+	return nil
+}
+
+// existingPRError builds PR_EXISTS error carrying the existing PR with its assigned reviewers,
+// so sequential and concurrent duplicates get the same response.
+func (s *PullRequestService) existingPRError(ctx context.Context, prID string) error {
+	pr, err := s.prRepo.FindByID(ctx, prID)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find existing PR",
+			slog.String("pr_id", prID), slog.String("error", err.Error()))
+		return err
+	}
+	if pr == nil {
+		return errors.NewPRExists("PR id already exists")
+	}
+
+	reviewers, err := s.reviewerRepo.GetReviewers(ctx, prID)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get reviewers of existing PR",
+			slog.String("pr_id", prID), slog.String("error", err.Error()))
+		return err
+	}
+
+	existing := pullrequest.CreatePrResponse{Pr: newPRDto(pr, reviewers)}
+	if err = s.withReviewerDetails(ctx, &existing.Pr); err != nil {
+		return err
+	}
+
+	return errors.NewPRExists("PR id already exists").WithDetails(existing)
+}
+
+// concurrentPRError re-reads a PR created by a concurrent request inside a fresh transaction.
+func (s *PullRequestService) concurrentPRError(ctx context.Context, prID string) error {
+	var conflict error
+	err := s.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
+		conflict = s.existingPRError(txCtx, prID)
+		if errors.HasCode(conflict, errors.CodePRExists) {
+			return nil
+		}
+		return conflict
+	})
+	if err != nil {
+		return err
+	}
+	return conflict
 }
 
 // MergePR marks PR as MERGED (idempotent operation).
 func (s *PullRequestService) MergePR(ctx context.Context, req pullrequest.MergePrRequest) (*pullrequest.MergePrResponse, error) {
+	response, _, err := s.mergePR(ctx, req)
+	return response, err
+}
+
+// errStatusChanged reports that the PR status changed concurrently after it was read.
+var errStatusChanged = stderrors.New("PR status changed concurrently")
+
+// mergePR merges the PR and reports whether it was merged before. When a concurrent request
+// changes the status first, the PR is re-read in a fresh transaction, so a concurrent merge
+// yields the idempotent response instead of being overwritten.
+func (s *PullRequestService) mergePR(ctx context.Context, req pullrequest.MergePrRequest) (*pullrequest.MergePrResponse, bool, error) {
+	response, alreadyMerged, err := s.mergePRTx(ctx, req)
+	if stderrors.Is(err, errStatusChanged) {
+		s.log.LogAttrs(ctx, slog.LevelInfo, "PR status changed concurrently, re-reading",
+			slog.String("pr_id", req.PullRequestID))
+		response, alreadyMerged, err = s.mergePRTx(ctx, req)
+	}
+	if stderrors.Is(err, errStatusChanged) {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "PR status keeps changing concurrently",
+			slog.String("pr_id", req.PullRequestID))
+		return nil, false, errors.NewInvalidTransition("PR status changed concurrently")
+	}
+	return response, alreadyMerged, err
+}
+
+// mergePRTx merges the PR in its own transaction and reports whether it was merged before.
+func (s *PullRequestService) mergePRTx(ctx context.Context, req pullrequest.MergePrRequest) (*pullrequest.MergePrResponse, bool, error) {
 	var response pullrequest.MergePrResponse
+	var alreadyMerged bool
+	// overridden are the reviewers whose requested changes the merge overrides
+	var overridden []string
 
 	err := s.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
 		pr, err := s.prRepo.FindByID(txCtx, req.PullRequestID)
 		if err != nil {
-			s.log.LogAttrs(ctx, slog.LevelError, "failed to find PR",
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to find PR",
 				slog.String("pr_id", req.PullRequestID), slog.String("error", err.Error()))
 			return err
 		}
@@ -205,7 +776,7 @@ func (s *PullRequestService) MergePR(ctx context.Context, req pullrequest.MergeP
 
 		reviewers, err := s.reviewerRepo.GetReviewers(txCtx, pr.Id)
 		if err != nil {
-			s.log.LogAttrs(ctx, slog.LevelError, "failed to get reviewers",
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to get reviewers",
 				slog.String("pr_id", pr.Id), slog.String("error", err.Error()))
 			return err
 		}
@@ -213,164 +784,941 @@ func (s *PullRequestService) MergePR(ctx context.Context, req pullrequest.MergeP
 		if pr.Status == models.PRStatusMerged {
 			s.log.LogAttrs(ctx, slog.LevelInfo, "PR already merged (idempotent)",
 				slog.String("pr_id", pr.Id))
+			alreadyMerged = true
 
 			response = pullrequest.MergePrResponse{
-				Pr: pullrequest.PR{
-					PullRequestID:     pr.Id,
-					PullRequestName:   pr.Title,
-					AuthorID:          pr.AuthorId,
-					Status:            pr.Status,
-					AssignedReviewers: reviewers,
-					MergedAt:          pr.MergedAt.Format(time.RFC3339),
-				},
+				Pr: newPRDto(pr, reviewers),
+			}
+			return s.withReviewerDetails(txCtx, &response.Pr)
+		}
+		if pr.Status == models.PRStatusClosed {
+			s.log.LogAttrs(ctx, slog.LevelWarn, "cannot merge closed PR",
+				slog.String("pr_id", pr.Id))
+			return errors.NewPRClosed("cannot merge closed PR")
+		}
+
+		if s.review.RequireReviewerForMerge && len(reviewers) == 0 {
+			s.log.LogAttrs(ctx, slog.LevelWarn, "merge blocked by missing reviewers",
+				slog.String("pr_id", pr.Id))
+			return errors.NewNoReviewers(fmt.Sprintf("PR has %d assigned reviewers, at least 1 is required to merge", len(reviewers)))
+		}
+
+		if s.review.BlockMergeOnChangesRequested {
+			if overridden, err = s.checkNoChangesRequested(txCtx, pr.Id, req.Override); err != nil {
+				return err
 			}
-			return nil
 		}
 
 		mergedAt := time.Now().UTC()
-		if err := s.prRepo.UpdateStatus(txCtx, pr.Id, models.PRStatusMerged, &mergedAt); err != nil {
-			s.log.LogAttrs(ctx, slog.LevelError, "failed to update PR status",
+		updated, err := s.prRepo.UpdateStatus(txCtx, pr.Id, models.PRStatusOpen, models.PRStatusMerged, &mergedAt)
+		if err != nil {
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to update PR status",
 				slog.String("pr_id", pr.Id), slog.String("error", err.Error()))
 			return err
 		}
+		if updated == 0 {
+			return errStatusChanged
+		}
 
+		pr.Status = models.PRStatusMerged
+		pr.MergedAt = &mergedAt
+		pr.UpdatedAt = mergedAt
 		response = pullrequest.MergePrResponse{
-			Pr: pullrequest.PR{
-				PullRequestID:     pr.Id,
-				PullRequestName:   pr.Title,
-				AuthorID:          pr.AuthorId,
-				Status:            models.PRStatusMerged,
-				AssignedReviewers: reviewers,
-				MergedAt:          mergedAt.Format(time.RFC3339),
-			},
+			Pr: newPRDto(pr, reviewers),
 		}
-		return nil
+		return s.withReviewerDetails(txCtx, &response.Pr)
 	})
 
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	s.log.LogAttrs(ctx, slog.LevelInfo, "PR merged successfully",
 		slog.String("pr_id", req.PullRequestID))
+	if len(overridden) > 0 {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "merge overrode requested changes",
+			slog.Bool("audit", true),
+			slog.String("pr_id", req.PullRequestID), slog.Any("reviewer_ids", overridden))
+	}
 
-	return &response, nil
+	if !alreadyMerged {
+		// a merged PR leaves the open queues of all its reviewers
+		queued := make([]events.Event, 0, len(response.Pr.AssignedReviewers))
+		for _, reviewerID := range response.Pr.AssignedReviewers {
+			queued = append(queued, removedEvent(response.Pr.PullRequestID, reviewerID))
+		}
+		s.publish(ctx, queued...)
+	}
+	return &response, alreadyMerged, nil
 }
 
-// ReassignReviewer replaces old reviewer with a new one from the same team.
-func (s *PullRequestService) ReassignReviewer(ctx context.Context, req pullrequest.ReassignReviewerRequest) (*pullrequest.ReassignReviewerResponse, error) {
-	var response pullrequest.ReassignReviewerResponse
+// checkNoChangesRequested fails with CHANGES_REQUESTED if any reviewer of the PR has requested changes.
+// With override it returns those reviewers instead.
+func (s *PullRequestService) checkNoChangesRequested(ctx context.Context, prID string, override bool) ([]string, error) {
+	assignments, err := s.reviewerRepo.GetAssignmentsByPRs(ctx, []string{prID})
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get review states",
+			slog.String("pr_id", prID), slog.String("error", err.Error()))
+		return nil, err
+	}
 
-	err := s.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
-		pr, err := s.prRepo.FindByID(txCtx, req.PullRequestID)
-		if err != nil {
-			s.log.LogAttrs(ctx, slog.LevelError, "failed to find PR",
-				slog.String("pr_id", req.PullRequestID), slog.String("error", err.Error()))
-			return err
-		}
-		if pr == nil {
-			s.log.LogAttrs(ctx, slog.LevelWarn, "PR not found",
-				slog.String("pr_id", req.PullRequestID))
-			return errors.NewNotFound("PR not found")
+	var blocking []string
+	for _, a := range assignments {
+		if a.State == models.ReviewStateChangesRequested {
+			blocking = append(blocking, a.ReviewerId)
 		}
+	}
+	if len(blocking) > 0 && !override {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "merge blocked by requested changes",
+			slog.String("pr_id", prID), slog.Any("reviewer_ids", blocking))
+		return nil, errors.NewChangesRequested("changes requested by " + strings.Join(blocking, ", "))
+	}
+	return blocking, nil
+}
 
-		if pr.Status == models.PRStatusMerged {
-			s.log.LogAttrs(ctx, slog.LevelWarn, "cannot reassign on merged PR",
-				slog.String("pr_id", req.PullRequestID))
-			return errors.NewPRMerged("cannot reassign on merged PR")
-		}
+// MergeBulk merges each PR with MergePR semantics in its own transaction.
+// A failing PR is reported in its result and does not abort the rest of the batch.
+func (s *PullRequestService) MergeBulk(ctx context.Context, req pullrequest.MergeBulkRequest) (*pullrequest.MergeBulkResponse, error) {
+	response := pullrequest.MergeBulkResponse{
+		Results: make([]pullrequest.MergeBulkResult, 0, len(req.PullRequestIDs)),
+	}
 
-		isAssigned, err := s.reviewerRepo.IsAssigned(txCtx, req.PullRequestID, req.OldReviewerID)
-		if err != nil {
-			s.log.LogAttrs(ctx, slog.LevelError, "failed to check reviewer assignment",
-				slog.String("pr_id", req.PullRequestID),
-				slog.String("reviewer_id", req.OldReviewerID),
-				slog.String("error", err.Error()))
-			return err
-		}
-		if !isAssigned {
-			s.log.LogAttrs(ctx, slog.LevelWarn, "reviewer is not assigned to this PR",
-				slog.String("pr_id", req.PullRequestID),
-				slog.String("reviewer_id", req.OldReviewerID))
-			return errors.NewNotAssigned("reviewer is not assigned to this PR")
-		}
+	for _, prID := range req.PullRequestIDs {
+		result := pullrequest.MergeBulkResult{PullRequestID: prID}
 
-		oldReviewer, err := s.userRepo.FindByID(txCtx, req.OldReviewerID)
-		if err != nil {
-			s.log.LogAttrs(ctx, slog.LevelError, "failed to find old reviewer",
-				slog.String("reviewer_id", req.OldReviewerID), slog.String("error", err.Error()))
-			return err
-		}
-		if oldReviewer == nil {
-			s.log.LogAttrs(ctx, slog.LevelWarn, "old reviewer not found",
-				slog.String("reviewer_id", req.OldReviewerID))
-			return errors.NewNotFound("old reviewer not found")
+		_, alreadyMerged, err := s.mergePR(ctx, pullrequest.MergePrRequest{PullRequestID: prID})
+		switch {
+		case err == nil && alreadyMerged:
+			result.Result = pullrequest.MergeResultAlreadyMerged
+			response.Summary.AlreadyMerged++
+		case err == nil:
+			result.Result = pullrequest.MergeResultMerged
+			response.Summary.Merged++
+		case errors.HasCode(err, errors.CodeNotFound):
+			result.Result = pullrequest.MergeResultNotFound
+			response.Summary.NotFound++
+		default:
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			result.Result = pullrequest.MergeResultFailed
+			result.Error = err.Error()
+			response.Summary.Failed++
 		}
 
-		currentReviewers, err := s.reviewerRepo.GetReviewers(txCtx, req.PullRequestID)
-		if err != nil {
-			s.log.LogAttrs(ctx, slog.LevelError, "failed to get current reviewers",
-				slog.String("pr_id", req.PullRequestID), slog.String("error", err.Error()))
-			return err
-		}
+		response.Results = append(response.Results, result)
+	}
 
-		excludeUserIDs := append([]string{pr.AuthorId}, currentReviewers...)
+	s.log.LogAttrs(ctx, slog.LevelInfo, "bulk merge finished",
+		slog.Int("requested", len(req.PullRequestIDs)),
+		slog.Int("merged", response.Summary.Merged),
+		slog.Int("already_merged", response.Summary.AlreadyMerged),
+		slog.Int("not_found", response.Summary.NotFound),
+		slog.Int("failed", response.Summary.Failed))
 
-		candidates, err := s.userRepo.FindActiveCandidatesForReassignment(
-			txCtx,
-			oldReviewer.TeamName,
-			excludeUserIDs,
-		)
-		if err != nil {
-			s.log.LogAttrs(ctx, slog.LevelError, "failed to find replacement candidates",
+	return &response, nil
+}
+
+// ReassignReviewer replaces old reviewer with a new one from the same team.
+func (s *PullRequestService) ReassignReviewer(ctx context.Context, req pullrequest.ReassignReviewerRequest) (*pullrequest.ReassignReviewerResponse, error) {
+	return s.reassign(ctx, req, models.ReviewerChangeManual)
+}
+
+// reassign replaces the reviewer in a transaction of its own, recording the change with the
+// trigger, and announces it once committed.
+func (s *PullRequestService) reassign(ctx context.Context, req pullrequest.ReassignReviewerRequest,
+	trigger string) (*pullrequest.ReassignReviewerResponse, error) {
+	var response *pullrequest.ReassignReviewerResponse
+	var change *models.ReviewerChange
+
+	err := s.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		response, change, err = s.reassignReviewer(txCtx, req, trigger)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "reviewer reassigned successfully",
+		slog.String("pr_id", req.PullRequestID),
+		slog.String("old_reviewer", req.OldReviewerID),
+		slog.String("trigger", trigger))
+
+	s.publish(ctx,
+		removedEvent(change.PRId, change.OldReviewerId),
+		s.assignedEvent(response.Pr, change.NewReviewerId, reassignSource(req, change.Trigger), change.ChangedAt),
+	)
+	return response, nil
+}
+
+// reassignReviewer replaces the reviewer within the transaction in ctx and records the change with the trigger.
+func (s *PullRequestService) reassignReviewer(ctx context.Context, req pullrequest.ReassignReviewerRequest,
+	trigger string) (*pullrequest.ReassignReviewerResponse, *models.ReviewerChange, error) {
+	pr, err := s.prRepo.FindByID(ctx, req.PullRequestID)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find PR",
+			slog.String("pr_id", req.PullRequestID), slog.String("error", err.Error()))
+		return nil, nil, err
+	}
+	if pr == nil {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "PR not found",
+			slog.String("pr_id", req.PullRequestID))
+		return nil, nil, errors.NewNotFound("PR not found")
+	}
+
+	if pr.Status == models.PRStatusMerged {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "cannot reassign on merged PR",
+			slog.String("pr_id", req.PullRequestID))
+		return nil, nil, errors.NewPRMerged("cannot reassign on merged PR")
+	}
+	if pr.Status == models.PRStatusClosed {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "cannot reassign on closed PR",
+			slog.String("pr_id", req.PullRequestID))
+		return nil, nil, errors.NewPRClosed("cannot reassign on closed PR")
+	}
+
+	isAssigned, err := s.reviewerRepo.IsAssigned(ctx, req.PullRequestID, req.OldReviewerID)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to check reviewer assignment",
+			slog.String("pr_id", req.PullRequestID),
+			slog.String("reviewer_id", req.OldReviewerID),
+			slog.String("error", err.Error()))
+		return nil, nil, err
+	}
+	if !isAssigned {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "reviewer is not assigned to this PR",
+			slog.String("pr_id", req.PullRequestID),
+			slog.String("reviewer_id", req.OldReviewerID))
+		return nil, nil, errors.NewNotAssigned("reviewer is not assigned to this PR")
+	}
+
+	oldReviewer, err := s.userRepo.FindByID(ctx, req.OldReviewerID)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find old reviewer",
+			slog.String("reviewer_id", req.OldReviewerID), slog.String("error", err.Error()))
+		return nil, nil, err
+	}
+	if oldReviewer == nil {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "old reviewer not found",
+			slog.String("reviewer_id", req.OldReviewerID))
+		return nil, nil, errors.NewNotFound("old reviewer not found")
+	}
+
+	currentReviewers, err := s.reviewerRepo.GetReviewers(ctx, req.PullRequestID)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get current reviewers",
+			slog.String("pr_id", req.PullRequestID), slog.String("error", err.Error()))
+		return nil, nil, err
+	}
+
+	var newReviewerID string
+	var openReviews int
+	source := reassignSource(req, trigger)
+	switch {
+	case req.NewReviewerID != "":
+		newReviewerID, openReviews, err = s.checkExplicitReviewer(ctx, pr, oldReviewer, currentReviewers, req.NewReviewerID)
+	case trigger == models.ReviewerChangeEscalation:
+		newReviewerID, openReviews, err = s.chooseEscalationTarget(ctx, pr, oldReviewer, currentReviewers)
+	default:
+		newReviewerID, openReviews, err = s.chooseReplacement(ctx, pr, oldReviewer, currentReviewers)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.reviewerRepo.ReplaceReviewer(ctx, req.PullRequestID, req.OldReviewerID, newReviewerID, source); err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to replace reviewer",
+			slog.String("pr_id", req.PullRequestID),
+			slog.String("old_reviewer", req.OldReviewerID),
+			slog.String("new_reviewer", newReviewerID),
+			slog.String("error", err.Error()))
+		return nil, nil, err
+	}
+
+	change := &models.ReviewerChange{
+		PRId:          req.PullRequestID,
+		OldReviewerId: req.OldReviewerID,
+		NewReviewerId: newReviewerID,
+		Trigger:       trigger,
+		ChangedAt:     time.Now().UTC(),
+	}
+	if err := s.reviewerRepo.RecordReviewerChange(ctx, change); err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to record reviewer change",
+			slog.String("pr_id", req.PullRequestID), slog.String("error", err.Error()))
+		return nil, nil, err
+	}
+
+	updatedReviewers, err := s.reviewerRepo.GetReviewers(ctx, req.PullRequestID)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get updated reviewers",
+			slog.String("pr_id", req.PullRequestID), slog.String("error", err.Error()))
+		return nil, nil, err
+	}
+
+	response := &pullrequest.ReassignReviewerResponse{
+		Pr:                    newPRDto(pr, updatedReviewers),
+		ReplacedBy:            newReviewerID,
+		ReplacedByOpenReviews: openReviews,
+	}
+	if err := s.withReviewerDetails(ctx, &response.Pr); err != nil {
+		return nil, nil, err
+	}
+	return response, change, nil
+}
+
+// reassignSource returns the assignment source of the reviewer replacing the old one.
+func reassignSource(req pullrequest.ReassignReviewerRequest, trigger string) string {
+	switch {
+	case req.NewReviewerID != "":
+		return models.AssignmentSourceManual
+	case trigger == models.ReviewerChangeEscalation:
+		return models.AssignmentSourceEscalation
+	default:
+		return models.AssignmentSourceReassign
+	}
+}
+
+// chooseEscalationTarget hands a stale review to the lead of the old reviewer's team, falling back
+// to chooseReplacement when the team has no lead or the lead can't take the review. Like
+// chooseReplacement, it also returns the open review count of the target.
+func (s *PullRequestService) chooseEscalationTarget(ctx context.Context, pr *models.PullRequest,
+	oldReviewer *models.User, currentReviewers []string) (string, int, error) {
+	leadID, err := s.userRepo.GetTeamLead(ctx, oldReviewer.TeamName)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get team lead",
+			slog.String("team", oldReviewer.TeamName), slog.String("error", err.Error()))
+		return "", 0, err
+	}
+
+	if leadID != "" && leadID != oldReviewer.Id {
+		newReviewerID, openReviews, err := s.checkExplicitReviewer(ctx, pr, oldReviewer, currentReviewers, leadID)
+		var appErr *errors.AppError
+		if err == nil || !stderrors.As(err, &appErr) {
+			return newReviewerID, openReviews, err
+		}
+	}
+
+	return s.chooseReplacement(ctx, pr, oldReviewer, currentReviewers)
+}
+
+// chooseReplacement picks the active member of the old reviewer's team with the fewest open
+// reviews who is neither the author nor already assigned, at random among the equally loaded.
+// It returns the replacement with its open review count.
+func (s *PullRequestService) chooseReplacement(ctx context.Context, pr *models.PullRequest,
+	oldReviewer *models.User, currentReviewers []string) (string, int, error) {
+	excludeUserIDs, err := withExclusions(ctx, s.userRepo, pr.AuthorId, append([]string{pr.AuthorId}, currentReviewers...))
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get excluded reviewers",
+			slog.String("author_id", pr.AuthorId), slog.String("error", err.Error()))
+		return "", 0, err
+	}
+
+	candidates, err := s.userRepo.FindActiveCandidatesForReassignment(ctx, oldReviewer.TeamName, excludeUserIDs)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find replacement candidates",
+			slog.String("team", oldReviewer.TeamName), slog.String("error", err.Error()))
+		return "", 0, err
+	}
+
+	var counts map[string]int
+	if len(candidates) > 0 {
+		userIDs := make([]string, 0, len(candidates))
+		for _, candidate := range candidates {
+			userIDs = append(userIDs, candidate.Id)
+		}
+		counts, err = s.reviewerRepo.GetOpenReviewCounts(ctx, userIDs)
+		if err != nil {
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to get open review counts",
 				slog.String("team", oldReviewer.TeamName), slog.String("error", err.Error()))
-			return err
+			return "", 0, err
 		}
+		rankByOpenReviews(candidates, counts)
+	}
+
+	newReviewerID, err := lockFirstActiveCandidate(ctx, s.userRepo, candidates, s.log)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to lock replacement candidate",
+			slog.String("pr_id", pr.Id), slog.String("error", err.Error()))
+		return "", 0, err
+	}
+
+	if newReviewerID == "" {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "no active replacement candidate in team",
+			slog.String("team", oldReviewer.TeamName))
+		return "", 0, errors.NewNoCandidate("no active replacement candidate in team")
+	}
+
+	return newReviewerID, counts[newReviewerID], nil
+}
+
+// checkExplicitReviewer validates the replacement named by the caller: the user must exist,
+// be active, belong to the old reviewer's team and its reviewer pool, not be the author and not be
+// assigned yet. It returns the replacement with its open review count.
+func (s *PullRequestService) checkExplicitReviewer(ctx context.Context, pr *models.PullRequest,
+	oldReviewer *models.User, currentReviewers []string, newReviewerID string) (string, int, error) {
+	newReviewer, err := s.userRepo.FindByID(ctx, newReviewerID)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find new reviewer",
+			slog.String("reviewer_id", newReviewerID), slog.String("error", err.Error()))
+		return "", 0, err
+	}
+	if newReviewer == nil {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "new reviewer not found",
+			slog.String("reviewer_id", newReviewerID))
+		return "", 0, errors.NewNotFound("new reviewer not found")
+	}
 
-		if len(candidates) == 0 {
-			s.log.LogAttrs(ctx, slog.LevelWarn, "no active replacement candidate in team",
-				slog.String("team", oldReviewer.TeamName))
-			return errors.NewNoCandidate("no active replacement candidate in team")
+	if newReviewer.TeamName != oldReviewer.TeamName {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "new reviewer is from another team",
+			slog.String("reviewer_id", newReviewerID),
+			slog.String("team", newReviewer.TeamName),
+			slog.String("expected_team", oldReviewer.TeamName))
+		return "", 0, errors.NewWrongTeam("new reviewer is not in the old reviewer's team")
+	}
+
+	if newReviewer.NonReviewer {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "new reviewer is not in the reviewer pool",
+			slog.String("reviewer_id", newReviewerID), slog.String("team", newReviewer.TeamName))
+		return "", 0, errors.NewNotReviewer("new reviewer is not in the team's reviewer pool")
+	}
+
+	if newReviewerID == pr.AuthorId {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "new reviewer is the PR author",
+			slog.String("pr_id", pr.Id), slog.String("reviewer_id", newReviewerID))
+		return "", 0, errors.NewReviewerIsAuthor("PR author cannot review own PR")
+	}
+
+	excluded, err := s.userRepo.GetExcludedReviewers(ctx, pr.AuthorId)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get excluded reviewers",
+			slog.String("author_id", pr.AuthorId), slog.String("error", err.Error()))
+		return "", 0, err
+	}
+	for _, userID := range excluded {
+		if userID == newReviewerID {
+			s.log.LogAttrs(ctx, slog.LevelWarn, "new reviewer is excluded for the PR author",
+				slog.String("pr_id", pr.Id), slog.String("reviewer_id", newReviewerID))
+			return "", 0, errors.NewReviewerExcluded("new reviewer is excluded from reviewing the author's PRs")
+		}
+	}
+
+	for _, reviewerID := range currentReviewers {
+		if reviewerID == newReviewerID {
+			s.log.LogAttrs(ctx, slog.LevelWarn, "new reviewer is already assigned",
+				slog.String("pr_id", pr.Id), slog.String("reviewer_id", newReviewerID))
+			return "", 0, errors.NewAlreadyAssigned("new reviewer is already assigned to this PR")
 		}
+	}
 
-		newReviewerID := candidates[0].Id
+	// the row lock keeps a concurrent deactivation from slipping in before the swap
+	active, err := s.userRepo.LockActiveCandidate(ctx, newReviewerID)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to lock new reviewer",
+			slog.String("reviewer_id", newReviewerID), slog.String("error", err.Error()))
+		return "", 0, err
+	}
+	if !active {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "new reviewer is not active",
+			slog.String("reviewer_id", newReviewerID))
+		return "", 0, errors.NewNotFound("new reviewer is not active")
+	}
 
-		if err := s.reviewerRepo.ReplaceReviewer(txCtx, req.PullRequestID, req.OldReviewerID, newReviewerID); err != nil {
-			s.log.LogAttrs(ctx, slog.LevelError, "failed to replace reviewer",
+	counts, err := s.reviewerRepo.GetOpenReviewCounts(ctx, []string{newReviewerID})
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get open review count",
+			slog.String("reviewer_id", newReviewerID), slog.String("error", err.Error()))
+		return "", 0, err
+	}
+
+	return newReviewerID, counts[newReviewerID], nil
+}
+
+// SubmitReview records the review state of an assigned reviewer on an open PR.
+// Setting the current state again is a no-op.
+func (s *PullRequestService) SubmitReview(ctx context.Context, req pullrequest.ReviewRequest) (*pullrequest.ReviewResponse, error) {
+	var response pullrequest.ReviewResponse
+
+	err := s.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
+		pr, err := s.prRepo.FindByID(txCtx, req.PullRequestID)
+		if err != nil {
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to find PR",
+				slog.String("pr_id", req.PullRequestID), slog.String("error", err.Error()))
+			return err
+		}
+		if pr == nil {
+			s.log.LogAttrs(ctx, slog.LevelWarn, "PR not found",
+				slog.String("pr_id", req.PullRequestID))
+			return errors.NewNotFound("PR not found")
+		}
+
+		if pr.Status == models.PRStatusMerged {
+			s.log.LogAttrs(ctx, slog.LevelWarn, "cannot review merged PR",
+				slog.String("pr_id", req.PullRequestID))
+			return errors.NewPRMerged("cannot change review of merged PR")
+		}
+		if pr.Status == models.PRStatusClosed {
+			s.log.LogAttrs(ctx, slog.LevelWarn, "cannot review closed PR",
+				slog.String("pr_id", req.PullRequestID))
+			return errors.NewPRClosed("cannot change review of closed PR")
+		}
+
+		assignment, err := s.reviewerRepo.LockAssignment(txCtx, req.PullRequestID, req.ReviewerID)
+		if err != nil {
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to lock assignment",
 				slog.String("pr_id", req.PullRequestID),
-				slog.String("old_reviewer", req.OldReviewerID),
-				slog.String("new_reviewer", newReviewerID),
+				slog.String("reviewer_id", req.ReviewerID),
 				slog.String("error", err.Error()))
 			return err
 		}
+		if assignment == nil {
+			s.log.LogAttrs(ctx, slog.LevelWarn, "reviewer is not assigned to this PR",
+				slog.String("pr_id", req.PullRequestID),
+				slog.String("reviewer_id", req.ReviewerID))
+			return errors.NewNotAssigned("reviewer is not assigned to this PR")
+		}
+
+		if assignment.State != req.State {
+			if !models.CanChangeReviewState(assignment.State, req.State) {
+				s.log.LogAttrs(ctx, slog.LevelWarn, "invalid review state transition",
+					slog.String("pr_id", req.PullRequestID),
+					slog.String("reviewer_id", req.ReviewerID),
+					slog.String("from", assignment.State),
+					slog.String("to", req.State))
+				return errors.NewInvalidTransition("cannot change review from " + assignment.State + " to " + req.State)
+			}
 
-		updatedReviewers, err := s.reviewerRepo.GetReviewers(txCtx, req.PullRequestID)
+			if err := s.reviewerRepo.SetReviewState(txCtx, req.PullRequestID, req.ReviewerID,
+				req.State, time.Now().UTC()); err != nil {
+				s.log.LogAttrs(ctx, errorLevel(err), "failed to set review state",
+					slog.String("pr_id", req.PullRequestID),
+					slog.String("reviewer_id", req.ReviewerID),
+					slog.String("error", err.Error()))
+				return err
+			}
+		}
+
+		reviewers, err := s.reviewerRepo.GetReviewers(txCtx, req.PullRequestID)
 		if err != nil {
-			s.log.LogAttrs(ctx, slog.LevelError, "failed to get updated reviewers",
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to get reviewers",
 				slog.String("pr_id", req.PullRequestID), slog.String("error", err.Error()))
 			return err
 		}
 
-		response = pullrequest.ReassignReviewerResponse{
-			Pr: pullrequest.PR{
-				PullRequestID:     pr.Id,
-				PullRequestName:   pr.Title,
-				AuthorID:          pr.AuthorId,
-				Status:            pr.Status,
-				AssignedReviewers: updatedReviewers,
-			},
-			ReplacedBy: newReviewerID,
+		response = pullrequest.ReviewResponse{
+			Pr: newPRDto(pr, reviewers),
 		}
+		return s.withReviewerDetails(txCtx, &response.Pr)
+	})
 
-		return nil
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "review state recorded",
+		slog.String("pr_id", req.PullRequestID),
+		slog.String("reviewer_id", req.ReviewerID),
+		slog.String("state", req.State))
+	return &response, nil
+}
+
+// SetLabels replaces the labels of an open PR.
+func (s *PullRequestService) SetLabels(ctx context.Context, req pullrequest.SetLabelsRequest) (*pullrequest.SetLabelsResponse, error) {
+	var response pullrequest.SetLabelsResponse
+	labels := models.NormalizeLabels(req.Labels)
+
+	err := s.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
+		pr, err := s.prRepo.FindByID(txCtx, req.PullRequestID)
+		if err != nil {
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to find PR",
+				slog.String("pr_id", req.PullRequestID), slog.String("error", err.Error()))
+			return err
+		}
+		if pr == nil {
+			s.log.LogAttrs(ctx, slog.LevelWarn, "PR not found",
+				slog.String("pr_id", req.PullRequestID))
+			return errors.NewNotFound("PR not found")
+		}
+
+		if pr.Status == models.PRStatusMerged {
+			s.log.LogAttrs(ctx, slog.LevelWarn, "cannot change labels of merged PR",
+				slog.String("pr_id", req.PullRequestID))
+			return errors.NewPRMerged("cannot change labels of merged PR")
+		}
+		if pr.Status == models.PRStatusClosed {
+			s.log.LogAttrs(ctx, slog.LevelWarn, "cannot change labels of closed PR",
+				slog.String("pr_id", req.PullRequestID))
+			return errors.NewPRClosed("cannot change labels of closed PR")
+		}
+
+		if err := s.prRepo.SetLabels(txCtx, pr.Id, labels); err != nil {
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to set PR labels",
+				slog.String("pr_id", pr.Id), slog.String("error", err.Error()))
+			return err
+		}
+
+		reviewers, err := s.reviewerRepo.GetReviewers(txCtx, pr.Id)
+		if err != nil {
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to get reviewers",
+				slog.String("pr_id", pr.Id), slog.String("error", err.Error()))
+			return err
+		}
+
+		pr.Labels = labels
+		pr.UpdatedAt = time.Now().UTC()
+		response = pullrequest.SetLabelsResponse{
+			Pr: newPRDto(pr, reviewers),
+		}
+		return s.withReviewerDetails(txCtx, &response.Pr)
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	s.log.LogAttrs(ctx, slog.LevelInfo, "reviewer reassigned successfully",
+	s.log.LogAttrs(ctx, slog.LevelInfo, "PR labels updated",
 		slog.String("pr_id", req.PullRequestID),
-		slog.String("old_reviewer", req.OldReviewerID))
+		slog.Any("labels", labels))
+	return &response, nil
+}
+
+// SearchPRs returns a page of pull requests whose title contains the query, newest first unless sorted.
+func (s *PullRequestService) SearchPRs(ctx context.Context, req pullrequest.SearchPrRequest) (*dto.Page[pullrequest.PR], error) {
+	labels := models.NormalizeLabels(req.Labels)
+	prs, err := s.prRepo.SearchByTitle(ctx, req.Query, req.Status, labels, prSort(req.Sort), req.Page.Limit,
+		req.Page.Offset)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to search PRs",
+			slog.String("query", req.Query), slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	total, err := s.prRepo.CountByTitle(ctx, req.Query, req.Status, labels)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to count found PRs",
+			slog.String("query", req.Query), slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	prIDs := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		prIDs = append(prIDs, pr.Id)
+	}
+
+	reviewers, err := s.reviewerRepo.GetReviewersByPRs(ctx, prIDs)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get reviewers of found PRs",
+			slog.String("query", req.Query), slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	items := make([]pullrequest.PR, 0, len(prs))
+	for _, pr := range prs {
+		items = append(items, newPRDto(pr, reviewers[pr.Id]))
+	}
+
+	if req.ExpandReviewers {
+		expanded := make([]*pullrequest.PR, 0, len(items))
+		for i := range items {
+			expanded = append(expanded, &items[i])
+		}
+		if err := expandReviewers(ctx, s.userRepo, s.reviewerRepo, s.review.Deadline, expanded...); err != nil {
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to load reviewer details",
+				slog.String("query", req.Query), slog.String("error", err.Error()))
+			return nil, err
+		}
+	}
+
+	page := dto.NewPage(items, total, req.Page)
+	return &page, nil
+}
+
+// GetHistory returns reviewer changes of the pull request in chronological order.
+func (s *PullRequestService) GetHistory(ctx context.Context, prID string) (*pullrequest.HistoryResponse, error) {
+	pr, err := s.prRepo.FindByID(ctx, prID)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find PR",
+			slog.String("pr_id", prID), slog.String("error", err.Error()))
+		return nil, err
+	}
+	if pr == nil {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "PR not found",
+			slog.String("pr_id", prID))
+		return nil, errors.NewNotFound("PR not found")
+	}
+
+	changes, err := s.reviewerRepo.GetReviewerHistory(ctx, prID)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get reviewer history",
+			slog.String("pr_id", prID), slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	response := pullrequest.HistoryResponse{
+		PullRequestID: prID,
+		History:       make([]pullrequest.ReviewerChange, 0, len(changes)),
+	}
+	for _, change := range changes {
+		response.History = append(response.History, pullrequest.ReviewerChange{
+			OldReviewerID: change.OldReviewerId,
+			NewReviewerID: change.NewReviewerId,
+			Trigger:       change.Trigger,
+			ChangedAt:     dto.FormatTime(change.ChangedAt),
+		})
+	}
+
 	return &response, nil
 }
+
+// GetUnassignedPRs returns a page of open PRs without reviewers, oldest first unless sorted.
+// PRs created with skipped assignment are listed with it set.
+func (s *PullRequestService) GetUnassignedPRs(ctx context.Context, req pullrequest.UnassignedRequest) (*dto.Page[pullrequest.PR], error) {
+	labels := models.NormalizeLabels(req.Labels)
+	prs, err := s.prRepo.FindOpenWithoutReviewers(ctx, "", labels, req.AssignmentSkipped, prSort(req.Sort),
+		req.Page.Limit, req.Page.Offset)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find unassigned PRs",
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	total, err := s.prRepo.CountOpenWithoutReviewers(ctx, "", labels, req.AssignmentSkipped)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to count unassigned PRs",
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	items := make([]pullrequest.PR, 0, len(prs))
+	for _, pr := range prs {
+		items = append(items, newPRDto(pr, []string{}))
+	}
+
+	page := dto.NewPage(items, total, req.Page)
+	return &page, nil
+}
+
+// AssignPR selects and assigns reviewers of an open PR without them, as at creation, and marks its
+// assignment as no longer skipped. When there are no candidates the PR stays without reviewers, left
+// to AssignPending. A PR that already has reviewers is returned as it is, so a retried request
+// doesn't fail; a concurrent assignment filling the PR first is retried once in the same way.
+func (s *PullRequestService) AssignPR(ctx context.Context, req pullrequest.AssignPrRequest) (*pullrequest.AssignPrResponse, error) {
+	response, selection, err := s.tryAssignPR(ctx, req)
+	if errors.HasCode(err, errors.CodeTooManyReviewers) {
+		s.log.LogAttrs(ctx, slog.LevelInfo, "PR assigned concurrently, retrying",
+			slog.String("pr_id", req.PullRequestID))
+		response, selection, err = s.tryAssignPR(ctx, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "PR reviewers assigned",
+		slog.String("pr_id", req.PullRequestID),
+		slog.Int("reviewers_count", len(selection.ReviewerIDs)))
+
+	assignedAt := time.Now().UTC()
+	queued := make([]events.Event, 0, len(selection.ReviewerIDs))
+	for _, reviewerID := range selection.ReviewerIDs {
+		queued = append(queued, s.assignedEvent(response.Pr, reviewerID, selection.source(reviewerID), assignedAt))
+	}
+	s.publish(ctx, queued...)
+	return response, nil
+}
+
+// tryAssignPR makes one attempt of AssignPR in its own transaction. The selection holds only the
+// reviewers assigned by the attempt.
+func (s *PullRequestService) tryAssignPR(ctx context.Context,
+	req pullrequest.AssignPrRequest) (*pullrequest.AssignPrResponse, *Selection, error) {
+	var response pullrequest.AssignPrResponse
+	selection := &Selection{}
+
+	err := s.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
+		pr, err := s.prRepo.FindByID(txCtx, req.PullRequestID)
+		if err != nil {
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to find PR",
+				slog.String("pr_id", req.PullRequestID), slog.String("error", err.Error()))
+			return err
+		}
+		if pr == nil {
+			s.log.LogAttrs(ctx, slog.LevelWarn, "PR not found",
+				slog.String("pr_id", req.PullRequestID))
+			return errors.NewNotFound("PR not found")
+		}
+		if pr.Status == models.PRStatusMerged {
+			s.log.LogAttrs(ctx, slog.LevelWarn, "cannot assign reviewers to merged PR",
+				slog.String("pr_id", req.PullRequestID))
+			return errors.NewPRMerged("cannot assign reviewers to merged PR")
+		}
+		if pr.Status == models.PRStatusClosed {
+			s.log.LogAttrs(ctx, slog.LevelWarn, "cannot assign reviewers to closed PR",
+				slog.String("pr_id", req.PullRequestID))
+			return errors.NewPRClosed("cannot assign reviewers to closed PR")
+		}
+
+		current, err := s.reviewerRepo.GetReviewers(txCtx, pr.Id)
+		if err != nil {
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to get reviewers",
+				slog.String("pr_id", pr.Id), slog.String("error", err.Error()))
+			return err
+		}
+		if len(current) > 0 {
+			response.Pr = newPRDto(pr, current)
+			return s.withReviewerDetails(txCtx, &response.Pr)
+		}
+
+		author, err := s.userRepo.FindByID(txCtx, pr.AuthorId)
+		if err != nil {
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to find author",
+				slog.String("author_id", pr.AuthorId), slog.String("error", err.Error()))
+			return err
+		}
+		if author != nil && author.TeamName != "" {
+			createReq := pullrequest.CreatePrRequest{
+				PullRequestID: pr.Id,
+				AuthorID:      pr.AuthorId,
+				Priority:      pr.Priority,
+			}
+			if selection, err = s.selectNewPRReviewers(txCtx, createReq, author); err != nil {
+				return err
+			}
+		} else {
+			s.log.LogAttrs(ctx, slog.LevelWarn, "no active reviewer candidates found",
+				slog.String("pr_id", pr.Id))
+		}
+
+		if err = s.assignReviewers(txCtx, pr.Id, selection); err != nil {
+			return err
+		}
+		if err = s.prRepo.ClearAssignmentSkipped(txCtx, pr.Id); err != nil {
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to clear assignment skip",
+				slog.String("pr_id", pr.Id), slog.String("error", err.Error()))
+			return err
+		}
+		pr.AssignmentSkipped = false
+
+		response = pullrequest.AssignPrResponse{
+			Pr:                  newPRDto(pr, selection.ReviewerIDs),
+			OverloadedReviewers: selection.Overloaded,
+		}
+		return s.withReviewerDetails(txCtx, &response.Pr)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &response, selection, nil
+}
+
+// AssignPending retries reviewer assignment for a page of open PRs without reviewers,
+// each in its own transaction. A failing PR stays unassigned and does not abort the rest.
+// PRs created with skipped assignment did not fail and wait for AssignPR instead.
+func (s *PullRequestService) AssignPending(ctx context.Context, req pullrequest.AssignPendingRequest) (*pullrequest.AssignPendingResponse, error) {
+	skipped := false
+	prs, err := s.prRepo.FindOpenWithoutReviewers(ctx, "", nil, &skipped, models.PRSort{}, req.Limit+1, req.Offset)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find unassigned PRs",
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	response := pullrequest.AssignPendingResponse{
+		HasMore: len(prs) > req.Limit,
+	}
+	if response.HasMore {
+		prs = prs[:req.Limit]
+	}
+
+	response.PullRequests = make([]pullrequest.PR, 0, len(prs))
+	for _, pr := range prs {
+		reviewerIDs, err := s.assignPendingPR(ctx, pr)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to assign reviewers to pending PR",
+				slog.String("pr_id", pr.Id), slog.String("error", err.Error()))
+			reviewerIDs = nil
+		}
+
+		response.Processed++
+		if len(reviewerIDs) == 0 {
+			response.StillUnassigned++
+			reviewerIDs = []string{}
+		} else {
+			response.Assigned++
+		}
+		response.PullRequests = append(response.PullRequests, newPRDto(pr, reviewerIDs))
+	}
+	response.NextOffset = req.Offset + response.StillUnassigned
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "pending PRs processed",
+		slog.Int("processed", response.Processed),
+		slog.Int("assigned", response.Assigned),
+		slog.Int("still_unassigned", response.StillUnassigned))
+
+	return &response, nil
+}
+
+// assignPendingPR assigns reviewers from the author's team to a PR without reviewers.
+// Returns the reviewers of the PR, empty if there are still no candidates. A concurrent
+// assignment filling the PR first fails the attempt with TOO_MANY_REVIEWERS; it is retried
+// once, which finds the reviewers assigned meanwhile.
+func (s *PullRequestService) assignPendingPR(ctx context.Context, pr *models.PullRequest) ([]string, error) {
+	reviewerIDs, err := s.tryAssignPendingPR(ctx, pr)
+	if errors.HasCode(err, errors.CodeTooManyReviewers) {
+		s.log.LogAttrs(ctx, slog.LevelInfo, "pending PR assigned concurrently, retrying",
+			slog.String("pr_id", pr.Id))
+		reviewerIDs, err = s.tryAssignPendingPR(ctx, pr)
+	}
+	return reviewerIDs, err
+}
+
+// tryAssignPendingPR makes one attempt of assignPendingPR in its own transaction.
+func (s *PullRequestService) tryAssignPendingPR(ctx context.Context, pr *models.PullRequest) ([]string, error) {
+	var reviewerIDs []string
+
+	err := s.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
+		current, err := s.reviewerRepo.GetReviewers(txCtx, pr.Id)
+		if err != nil {
+			return err
+		}
+		if len(current) > 0 {
+			// assigned concurrently since the page was read
+			reviewerIDs = current
+			return nil
+		}
+
+		author, err := s.userRepo.FindByID(txCtx, pr.AuthorId)
+		if err != nil {
+			return err
+		}
+		if author == nil || author.TeamName == "" {
+			return nil
+		}
+
+		exclude, err := withExclusions(txCtx, s.userRepo, pr.AuthorId, []string{pr.AuthorId})
+		if err != nil {
+			return err
+		}
+
+		selection, err := s.selector.Select(txCtx, author.TeamName, exclude, pr.Priority, nil, models.MaxReviewers)
+		if err != nil {
+			return err
+		}
+		reviewerIDs = selection.ReviewerIDs
+		s.logOverload(ctx, pr.Id, selection)
+
+		for _, reviewerID := range reviewerIDs {
+			if err := s.reviewerRepo.AssignReviewer(txCtx, pr.Id, reviewerID, models.AssignmentSourceAuto); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reviewerIDs, nil
+}
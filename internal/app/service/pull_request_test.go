@@ -1,17 +1,24 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"log/slog"
 	"os"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
 	"github.com/shirr9/pr-reviewer-service/internal/app/dto/pullrequest"
 	"github.com/shirr9/pr-reviewer-service/internal/app/service/mocks"
 	"github.com/shirr9/pr-reviewer-service/internal/domain/errors"
 	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -25,7 +32,7 @@ func TestPullRequestService_CreatePR(t *testing.T) {
 	mockUoW := mocks.NewMockTransactor(ctrl)
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
-	service := NewPullRequestService(mockPRRepo, mockReviewerRepo, mockUserRepo, mockUoW, logger)
+	service := NewPullRequestService(mockPRRepo, mockReviewerRepo, mockUserRepo, mockUoW, testReview, logger)
 
 	t.Run("Success - Create PR with 2 reviewers", func(t *testing.T) {
 		ctx := context.Background()
@@ -51,10 +58,14 @@ func TestPullRequestService_CreatePR(t *testing.T) {
 			func(ctx context.Context, fn func(context.Context) error) error {
 				mockPRRepo.EXPECT().Exists(ctx, "pr-1").Return(false, nil)
 				mockUserRepo.EXPECT().FindByID(ctx, "u1").Return(author, nil)
+				mockUserRepo.EXPECT().GetExcludedReviewers(ctx, "u1").Return(nil, nil)
 				mockUserRepo.EXPECT().FindActiveCandidatesForReassignment(ctx, "backend", []string{"u1"}).Return(candidates, nil)
+				mockReviewerRepo.EXPECT().GetOpenReviewCounts(ctx, []string{"u2", "u3"}).Return(map[string]int{"u2": 1, "u3": 1}, nil)
 				mockPRRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
-				mockReviewerRepo.EXPECT().AssignReviewer(ctx, "pr-1", "u2").Return(nil)
-				mockReviewerRepo.EXPECT().AssignReviewer(ctx, "pr-1", "u3").Return(nil)
+				mockReviewerRepo.EXPECT().AssignReviewer(ctx, "pr-1", "u2", models.AssignmentSourceAuto).Return(nil)
+				mockReviewerRepo.EXPECT().AssignReviewer(ctx, "pr-1", "u3", models.AssignmentSourceAuto).Return(nil)
+				mockUserRepo.EXPECT().FindByIDs(ctx, []string{"u2", "u3"}).Return(candidates, nil)
+				mockReviewerRepo.EXPECT().GetAssignmentsByPRs(ctx, []string{"pr-1"}).Return(nil, nil)
 				return fn(ctx)
 			},
 		)
@@ -68,6 +79,10 @@ func TestPullRequestService_CreatePR(t *testing.T) {
 		assert.Equal(t, "u1", resp.Pr.AuthorID)
 		assert.Equal(t, models.PRStatusOpen, resp.Pr.Status)
 		assert.Len(t, resp.Pr.AssignedReviewers, 2)
+		assert.Equal(t, []pullrequest.Reviewer{
+			{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+			{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+		}, resp.Pr.Reviewers)
 	})
 
 	t.Run("Success - Create PR with 1 reviewer", func(t *testing.T) {
@@ -93,9 +108,13 @@ func TestPullRequestService_CreatePR(t *testing.T) {
 			func(ctx context.Context, fn func(context.Context) error) error {
 				mockPRRepo.EXPECT().Exists(ctx, "pr-2").Return(false, nil)
 				mockUserRepo.EXPECT().FindByID(ctx, "u1").Return(author, nil)
+				mockUserRepo.EXPECT().GetExcludedReviewers(ctx, "u1").Return(nil, nil)
 				mockUserRepo.EXPECT().FindActiveCandidatesForReassignment(ctx, "backend", []string{"u1"}).Return(candidates, nil)
+				mockReviewerRepo.EXPECT().GetOpenReviewCounts(ctx, []string{"u2"}).Return(map[string]int{}, nil)
 				mockPRRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
-				mockReviewerRepo.EXPECT().AssignReviewer(ctx, "pr-2", "u2").Return(nil)
+				mockReviewerRepo.EXPECT().AssignReviewer(ctx, "pr-2", "u2", models.AssignmentSourceAuto).Return(nil)
+				mockUserRepo.EXPECT().FindByIDs(ctx, []string{"u2"}).Return(candidates, nil)
+				mockReviewerRepo.EXPECT().GetAssignmentsByPRs(ctx, []string{"pr-2"}).Return(nil, nil)
 				return fn(ctx)
 			},
 		)
@@ -118,6 +137,15 @@ func TestPullRequestService_CreatePR(t *testing.T) {
 		mockUoW.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(
 			func(ctx context.Context, fn func(context.Context) error) error {
 				mockPRRepo.EXPECT().Exists(ctx, "pr-1").Return(true, nil)
+				mockPRRepo.EXPECT().FindByID(ctx, "pr-1").Return(&models.PullRequest{
+					Id: "pr-1", Title: "Test PR", AuthorId: "u1", Status: models.PRStatusOpen,
+				}, nil)
+				mockReviewerRepo.EXPECT().GetReviewers(ctx, "pr-1").Return([]string{"u2", "u3"}, nil)
+				mockUserRepo.EXPECT().FindByIDs(ctx, []string{"u2", "u3"}).Return([]*models.User{
+					{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+					{Id: "u3", Name: "Charlie", TeamName: "backend", IsActive: true},
+				}, nil)
+				mockReviewerRepo.EXPECT().GetAssignmentsByPRs(ctx, []string{"pr-1"}).Return(nil, nil)
 				return fn(ctx)
 			},
 		)
@@ -126,7 +154,13 @@ func TestPullRequestService_CreatePR(t *testing.T) {
 
 		assert.Error(t, err)
 		assert.Nil(t, resp)
-		assert.Equal(t, "PR_EXISTS", err.(*errors.AppError).Code)
+		appErr := err.(*errors.AppError)
+		assert.Equal(t, "PR_EXISTS", appErr.Code)
+		details, ok := appErr.Details.(pullrequest.CreatePrResponse)
+		assert.True(t, ok)
+		assert.Equal(t, "u1", details.Pr.AuthorID)
+		assert.Equal(t, []string{"u2", "u3"}, details.Pr.AssignedReviewers)
+		assert.Len(t, details.Pr.Reviewers, 2)
 	})
 
 	t.Run("Error - Author not found", func(t *testing.T) {
@@ -203,6 +237,7 @@ func TestPullRequestService_CreatePR(t *testing.T) {
 			func(ctx context.Context, fn func(context.Context) error) error {
 				mockPRRepo.EXPECT().Exists(ctx, "pr-5").Return(false, nil)
 				mockUserRepo.EXPECT().FindByID(ctx, "u1").Return(author, nil)
+				mockUserRepo.EXPECT().GetExcludedReviewers(ctx, "u1").Return(nil, nil)
 				mockUserRepo.EXPECT().FindActiveCandidatesForReassignment(ctx, "backend", []string{"u1"}).Return(candidates, nil)
 				mockPRRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
 				return fn(ctx)
@@ -227,7 +262,7 @@ func TestPullRequestService_MergePR(t *testing.T) {
 	mockUoW := mocks.NewMockTransactor(ctrl)
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
-	service := NewPullRequestService(mockPRRepo, mockReviewerRepo, mockUserRepo, mockUoW, logger)
+	service := NewPullRequestService(mockPRRepo, mockReviewerRepo, mockUserRepo, mockUoW, testReview, logger)
 
 	t.Run("Success - Merge PR", func(t *testing.T) {
 		ctx := context.Background()
@@ -248,7 +283,14 @@ func TestPullRequestService_MergePR(t *testing.T) {
 			func(ctx context.Context, fn func(context.Context) error) error {
 				mockPRRepo.EXPECT().FindByID(ctx, "pr-1").Return(pr, nil)
 				mockReviewerRepo.EXPECT().GetReviewers(ctx, "pr-1").Return(reviewers, nil)
-				mockPRRepo.EXPECT().UpdateStatus(ctx, "pr-1", models.PRStatusMerged, gomock.Any()).Return(nil)
+				mockPRRepo.EXPECT().UpdateStatus(ctx, "pr-1", models.PRStatusOpen, models.PRStatusMerged, gomock.Any()).Return(1, nil)
+				mockUserRepo.EXPECT().FindByIDs(ctx, reviewers).Return([]*models.User{
+					{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+					{Id: "u3", Name: "Charlie", TeamName: "backend", IsActive: false},
+				}, nil)
+				mockReviewerRepo.EXPECT().GetAssignmentsByPRs(ctx, []string{"pr-1"}).Return([]*models.ReviewAssignment{
+					{PRId: "pr-1", ReviewerId: "u2", AssignedAt: time.Now().UTC().AddDate(0, 0, -14)},
+				}, nil)
 				return fn(ctx)
 			},
 		)
@@ -260,6 +302,10 @@ func TestPullRequestService_MergePR(t *testing.T) {
 		assert.Equal(t, "pr-1", resp.Pr.PullRequestID)
 		assert.Equal(t, models.PRStatusMerged, resp.Pr.Status)
 		assert.NotEmpty(t, resp.Pr.MergedAt)
+		assert.Len(t, resp.Pr.Reviewers, 2)
+		assert.False(t, resp.Pr.Reviewers[1].IsActive)
+		assert.NotEmpty(t, resp.Pr.Reviewers[0].Deadline)
+		assert.False(t, resp.Pr.Reviewers[0].Overdue, "merged PRs are never overdue")
 	})
 
 	t.Run("Success - Idempotent merge (already merged)", func(t *testing.T) {
@@ -284,6 +330,8 @@ func TestPullRequestService_MergePR(t *testing.T) {
 				mockPRRepo.EXPECT().FindByID(ctx, "pr-1").Return(pr, nil)
 				mockReviewerRepo.EXPECT().GetReviewers(ctx, "pr-1").Return(reviewers, nil)
 				// UpdateStatus should NOT be called for idempotent case
+				mockUserRepo.EXPECT().FindByIDs(ctx, reviewers).Return(nil, nil)
+				mockReviewerRepo.EXPECT().GetAssignmentsByPRs(ctx, []string{"pr-1"}).Return(nil, nil)
 				return fn(ctx)
 			},
 		)
@@ -327,7 +375,7 @@ func TestPullRequestService_ReassignReviewer(t *testing.T) {
 	mockUoW := mocks.NewMockTransactor(ctrl)
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
-	service := NewPullRequestService(mockPRRepo, mockReviewerRepo, mockUserRepo, mockUoW, logger)
+	service := NewPullRequestService(mockPRRepo, mockReviewerRepo, mockUserRepo, mockUoW, testReview, logger)
 
 	t.Run("Success - Reassign reviewer", func(t *testing.T) {
 		ctx := context.Background()
@@ -353,6 +401,7 @@ func TestPullRequestService_ReassignReviewer(t *testing.T) {
 		currentReviewers := []string{"u2", "u3"}
 		candidates := []*models.User{
 			{Id: "u4", Name: "David", TeamName: "backend", IsActive: true},
+			{Id: "u5", Name: "Eve", TeamName: "backend", IsActive: true},
 		}
 		updatedReviewers := []string{"u4", "u3"}
 
@@ -362,9 +411,22 @@ func TestPullRequestService_ReassignReviewer(t *testing.T) {
 				mockReviewerRepo.EXPECT().IsAssigned(ctx, "pr-1", "u2").Return(true, nil)
 				mockUserRepo.EXPECT().FindByID(ctx, "u2").Return(oldReviewer, nil)
 				mockReviewerRepo.EXPECT().GetReviewers(ctx, "pr-1").Return(currentReviewers, nil)
+				mockUserRepo.EXPECT().GetExcludedReviewers(ctx, "u1").Return(nil, nil)
 				mockUserRepo.EXPECT().FindActiveCandidatesForReassignment(ctx, "backend", []string{"u1", "u2", "u3"}).Return(candidates, nil)
-				mockReviewerRepo.EXPECT().ReplaceReviewer(ctx, "pr-1", "u2", "u4").Return(nil)
+				mockReviewerRepo.EXPECT().GetOpenReviewCounts(ctx, []string{"u4", "u5"}).Return(map[string]int{"u4": 1, "u5": 3}, nil)
+				mockUserRepo.EXPECT().LockActiveCandidate(ctx, "u4").Return(true, nil)
+				mockReviewerRepo.EXPECT().ReplaceReviewer(ctx, "pr-1", "u2", "u4", models.AssignmentSourceReassign).Return(nil)
+				mockReviewerRepo.EXPECT().RecordReviewerChange(ctx,
+					reviewerChangeMatcher("pr-1", "u2", "u4", models.ReviewerChangeManual)).Return(nil)
 				mockReviewerRepo.EXPECT().GetReviewers(ctx, "pr-1").Return(updatedReviewers, nil)
+				mockUserRepo.EXPECT().FindByIDs(ctx, updatedReviewers).Return([]*models.User{
+					{Id: "u3", Name: "Charlie", TeamName: "backend", IsActive: true},
+					{Id: "u4", Name: "David", TeamName: "backend", IsActive: true},
+				}, nil)
+				mockReviewerRepo.EXPECT().GetAssignmentsByPRs(ctx, []string{"pr-1"}).Return([]*models.ReviewAssignment{
+					{PRId: "pr-1", ReviewerId: "u3", AssignedAt: time.Now().UTC().AddDate(0, 0, -14)},
+					{PRId: "pr-1", ReviewerId: "u4", AssignedAt: time.Now().UTC()},
+				}, nil)
 				return fn(ctx)
 			},
 		)
@@ -375,7 +437,12 @@ func TestPullRequestService_ReassignReviewer(t *testing.T) {
 		assert.NotNil(t, resp)
 		assert.Equal(t, "pr-1", resp.Pr.PullRequestID)
 		assert.Equal(t, "u4", resp.ReplacedBy)
+		assert.Equal(t, 1, resp.ReplacedByOpenReviews)
 		assert.Equal(t, models.PRStatusOpen, resp.Pr.Status)
+		assert.Equal(t, "David", resp.Pr.Reviewers[0].Username, "reviewers keep assigned_reviewers order")
+		assert.Equal(t, "Charlie", resp.Pr.Reviewers[1].Username)
+		assert.False(t, resp.Pr.Reviewers[0].Overdue)
+		assert.True(t, resp.Pr.Reviewers[1].Overdue)
 	})
 
 	t.Run("Error - PR not found", func(t *testing.T) {
@@ -486,6 +553,7 @@ func TestPullRequestService_ReassignReviewer(t *testing.T) {
 				mockReviewerRepo.EXPECT().IsAssigned(ctx, "pr-1", "u2").Return(true, nil)
 				mockUserRepo.EXPECT().FindByID(ctx, "u2").Return(oldReviewer, nil)
 				mockReviewerRepo.EXPECT().GetReviewers(ctx, "pr-1").Return(currentReviewers, nil)
+				mockUserRepo.EXPECT().GetExcludedReviewers(ctx, "u1").Return(nil, nil)
 				mockUserRepo.EXPECT().FindActiveCandidatesForReassignment(ctx, "backend", []string{"u1", "u2", "u3"}).Return(candidates, nil)
 				return fn(ctx)
 			},
@@ -498,3 +566,1479 @@ func TestPullRequestService_ReassignReviewer(t *testing.T) {
 		assert.Equal(t, "NO_CANDIDATE", err.(*errors.AppError).Code)
 	})
 }
+
+func TestPullRequestService_CreatePR_ConcurrentDuplicate(t *testing.T) {
+	store := newFakeStore(
+		&models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+		&models.User{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+		&models.User{Id: "u3", Name: "Charlie", TeamName: "backend", IsActive: true},
+	)
+	// both requests pass the existence check before either inserts, as under Repeatable Read
+	var barrier sync.WaitGroup
+	barrier.Add(2)
+	store.beforeExists = func() {
+		barrier.Done()
+		barrier.Wait()
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+	req := pullrequest.CreatePrRequest{
+		PullRequestID:   "pr-race",
+		PullRequestName: "Race PR",
+		AuthorID:        "u1",
+	}
+
+	var wg sync.WaitGroup
+	responses := make([]*pullrequest.CreatePrResponse, 2)
+	errs := make([]error, 2)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			responses[i], errs[i] = service.CreatePR(context.Background(), req)
+		}(i)
+	}
+	wg.Wait()
+
+	var created *pullrequest.CreatePrResponse
+	var conflict *errors.AppError
+	for i := range errs {
+		if errs[i] == nil {
+			assert.Nil(t, created, "exactly one request must succeed")
+			created = responses[i]
+			continue
+		}
+		assert.Nil(t, conflict, "exactly one request must conflict")
+		conflict = errs[i].(*errors.AppError)
+	}
+
+	if assert.NotNil(t, created) && assert.NotNil(t, conflict) {
+		assert.Equal(t, errors.CodePRExists, conflict.Code)
+		details, ok := conflict.Details.(pullrequest.CreatePrResponse)
+		assert.True(t, ok)
+		assert.Equal(t, created.Pr, details.Pr)
+	}
+
+	store.beforeExists = nil
+	_, err := service.CreatePR(context.Background(), req)
+	sequential, ok := err.(*errors.AppError)
+	if assert.True(t, ok) && assert.NotNil(t, conflict) {
+		assert.Equal(t, conflict, sequential, "sequential duplicate must get the same error")
+	}
+}
+
+func TestPullRequestService_ReassignReviewer_ConcurrentDeactivation(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	newStore := func() *fakeStore {
+		store := newFakeStore(
+			&models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u3", Name: "Charlie", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u4", Name: "David", TeamName: "backend", IsActive: true},
+		)
+		store.prs["pr-1"] = &models.PullRequest{Id: "pr-1", Title: "Test PR", AuthorId: "u1", Status: models.PRStatusOpen}
+		store.reviewers["pr-1"] = []string{"u2"}
+		return store
+	}
+	req := pullrequest.ReassignReviewerRequest{PullRequestID: "pr-1", OldReviewerID: "u2"}
+
+	t.Run("Success - Skips candidate deactivated after selection", func(t *testing.T) {
+		store := newStore()
+		store.afterCandidates = func() {
+			_ = store.SetIsActive(context.Background(), "u3", false)
+		}
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+		resp, err := service.ReassignReviewer(context.Background(), req)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Equal(t, "u4", resp.ReplacedBy)
+		assert.Equal(t, []string{"u4"}, resp.Pr.AssignedReviewers)
+	})
+
+	t.Run("Error - Every candidate deactivated after selection", func(t *testing.T) {
+		store := newStore()
+		store.afterCandidates = func() {
+			_ = store.SetIsActive(context.Background(), "u3", false)
+			_ = store.SetIsActive(context.Background(), "u4", false)
+		}
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+		resp, err := service.ReassignReviewer(context.Background(), req)
+
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Equal(t, errors.CodeNoCandidate, err.(*errors.AppError).Code)
+		reviewers, _ := store.GetReviewers(context.Background(), "pr-1")
+		assert.Equal(t, []string{"u2"}, reviewers)
+	})
+}
+
+func TestPullRequestService_ReassignReviewer_LeastLoaded(t *testing.T) {
+	logger := slog.New(slog.DiscardHandler)
+
+	newStore := func() *fakeStore {
+		store := newFakeStore(
+			&models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u3", Name: "Charlie", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u4", Name: "David", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u5", Name: "Eve", TeamName: "backend", IsActive: true},
+		)
+		store.prs["pr-1"] = &models.PullRequest{Id: "pr-1", AuthorId: "u1", Status: models.PRStatusOpen}
+		store.reviewers["pr-1"] = []string{"u2"}
+		store.prs["pr-2"] = &models.PullRequest{Id: "pr-2", AuthorId: "u1", Status: models.PRStatusOpen}
+		store.reviewers["pr-2"] = []string{"u3"}
+		store.prs["pr-3"] = &models.PullRequest{Id: "pr-3", AuthorId: "u1", Status: models.PRStatusOpen}
+		store.reviewers["pr-3"] = []string{"u3"}
+		return store
+	}
+	req := pullrequest.ReassignReviewerRequest{PullRequestID: "pr-1", OldReviewerID: "u2"}
+
+	t.Run("Success - Candidate with the fewest open reviews is chosen", func(t *testing.T) {
+		store := newStore()
+		store.reviewers["pr-2"] = []string{"u3", "u4"}
+		store.reviewers["pr-3"] = []string{"u3", "u5"}
+		store.prs["pr-4"] = &models.PullRequest{Id: "pr-4", AuthorId: "u1", Status: models.PRStatusOpen}
+		store.reviewers["pr-4"] = []string{"u5"}
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+		resp, err := service.ReassignReviewer(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Equal(t, "u4", resp.ReplacedBy)
+		assert.Equal(t, 1, resp.ReplacedByOpenReviews)
+	})
+
+	t.Run("Success - Ties are broken at random", func(t *testing.T) {
+		chosen := make(map[string]bool)
+		for range 50 {
+			store := newStore()
+			service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+			resp, err := service.ReassignReviewer(context.Background(), req)
+
+			require.NoError(t, err)
+			assert.Zero(t, resp.ReplacedByOpenReviews)
+			chosen[resp.ReplacedBy] = true
+		}
+		assert.Equal(t, map[string]bool{"u4": true, "u5": true}, chosen, "u3 reviews two PRs, u4 and u5 none")
+	})
+}
+
+func TestPullRequestService_Timestamps_RoundTrip(t *testing.T) {
+	store := newFakeStore(
+		&models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+		&models.User{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+	)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+	ctx := context.Background()
+
+	parseUTC := func(t *testing.T, value string) time.Time {
+		t.Helper()
+		parsed, err := time.Parse(time.RFC3339, value)
+		assert.NoError(t, err)
+		_, offset := parsed.Zone()
+		assert.Equal(t, 0, offset, "timestamp %q must be UTC", value)
+		assert.True(t, len(value) > 0 && value[len(value)-1] == 'Z', "timestamp %q must use Z suffix", value)
+		return parsed
+	}
+
+	created, err := service.CreatePR(ctx, pullrequest.CreatePrRequest{
+		PullRequestID: "pr-ts", PullRequestName: "Timestamps", AuthorID: "u1",
+	})
+	assert.NoError(t, err)
+	createdAt := parseUTC(t, created.Pr.CreatedAt)
+	assert.Empty(t, created.Pr.MergedAt)
+
+	merged, err := service.MergePR(ctx, pullrequest.MergePrRequest{PullRequestID: "pr-ts"})
+	assert.NoError(t, err)
+	assert.Equal(t, created.Pr.CreatedAt, merged.Pr.CreatedAt)
+	mergedAt := parseUTC(t, merged.Pr.MergedAt)
+	updatedAt := parseUTC(t, merged.Pr.UpdatedAt)
+	assert.False(t, mergedAt.Before(createdAt), "merged_at must not precede created_at")
+	assert.False(t, updatedAt.Before(mergedAt), "updated_at must not precede merged_at")
+
+	again, err := service.MergePR(ctx, pullrequest.MergePrRequest{PullRequestID: "pr-ts"})
+	assert.NoError(t, err)
+	assert.Equal(t, merged.Pr.MergedAt, again.Pr.MergedAt)
+	assert.False(t, parseUTC(t, again.Pr.UpdatedAt).Before(parseUTC(t, again.Pr.MergedAt)))
+}
+
+func TestPullRequestService_SearchPRs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPRRepo := mocks.NewMockPullRequestRepository(ctrl)
+	mockReviewerRepo := mocks.NewMockReviewerRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	service := NewPullRequestService(mockPRRepo, mockReviewerRepo, mockUserRepo, nil, testReview, logger)
+
+	t.Run("Success - PRs with reviewers", func(t *testing.T) {
+		ctx := context.Background()
+		req := pullrequest.SearchPrRequest{Query: "payments", Status: models.PRStatusOpen, Page: dto.PageRequest{Limit: 20}}
+
+		mockPRRepo.EXPECT().SearchByTitle(ctx, "payments", models.PRStatusOpen, []string{}, models.PRSort{}, 20, 0).Return([]*models.PullRequest{
+			{Id: "pr-2", Title: "Payments refund", AuthorId: "u1", Status: models.PRStatusOpen},
+			{Id: "pr-1", Title: "Payments API", AuthorId: "u2", Status: models.PRStatusOpen},
+		}, nil)
+		mockPRRepo.EXPECT().CountByTitle(ctx, "payments", models.PRStatusOpen, []string{}).Return(2, nil)
+		mockReviewerRepo.EXPECT().GetReviewersByPRs(ctx, []string{"pr-2", "pr-1"}).Return(map[string][]string{
+			"pr-2": {"u3", "u4"},
+		}, nil)
+
+		resp, err := service.SearchPRs(ctx, req)
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.Items, 2)
+		assert.Equal(t, 2, resp.Total)
+		assert.Equal(t, 20, resp.Limit)
+		assert.Equal(t, "pr-2", resp.Items[0].PullRequestID)
+		assert.Equal(t, []string{"u3", "u4"}, resp.Items[0].AssignedReviewers)
+		assert.Equal(t, "pr-1", resp.Items[1].PullRequestID)
+		assert.Empty(t, resp.Items[1].AssignedReviewers)
+	})
+
+	t.Run("Success - Expanded reviewers", func(t *testing.T) {
+		ctx := context.Background()
+		req := pullrequest.SearchPrRequest{Query: "api", Page: dto.PageRequest{Limit: 20}, ExpandReviewers: true}
+
+		mockPRRepo.EXPECT().SearchByTitle(ctx, "api", "", []string{}, models.PRSort{}, 20, 0).Return([]*models.PullRequest{
+			{Id: "pr-1", Title: "Payments API", AuthorId: "u2", Status: models.PRStatusOpen},
+			{Id: "pr-3", Title: "Users API", AuthorId: "u2", Status: models.PRStatusOpen},
+		}, nil)
+		mockPRRepo.EXPECT().CountByTitle(ctx, "api", "", []string{}).Return(2, nil)
+		mockReviewerRepo.EXPECT().GetReviewersByPRs(ctx, []string{"pr-1", "pr-3"}).Return(map[string][]string{
+			"pr-1": {"u3"},
+			"pr-3": {"u3", "u4"},
+		}, nil)
+		mockUserRepo.EXPECT().FindByIDs(ctx, []string{"u3", "u4"}).Return([]*models.User{
+			{Id: "u3", Name: "Charlie", TeamName: "backend", IsActive: true},
+			{Id: "u4", Name: "David", TeamName: "backend", IsActive: true},
+		}, nil)
+		mockReviewerRepo.EXPECT().GetAssignmentsByPRs(ctx, []string{"pr-1", "pr-3"}).Return(nil, nil)
+
+		resp, err := service.SearchPRs(ctx, req)
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.Items[0].Reviewers, 1)
+		assert.Len(t, resp.Items[1].Reviewers, 2)
+		assert.Equal(t, "David", resp.Items[1].Reviewers[1].Username)
+	})
+
+	t.Run("Success - No matches", func(t *testing.T) {
+		ctx := context.Background()
+		req := pullrequest.SearchPrRequest{Query: "nothing", Page: dto.PageRequest{Limit: 20}}
+
+		mockPRRepo.EXPECT().SearchByTitle(ctx, "nothing", "", []string{}, models.PRSort{}, 20, 0).Return(nil, nil)
+		mockPRRepo.EXPECT().CountByTitle(ctx, "nothing", "", []string{}).Return(0, nil)
+		mockReviewerRepo.EXPECT().GetReviewersByPRs(ctx, []string{}).Return(map[string][]string{}, nil)
+
+		resp, err := service.SearchPRs(ctx, req)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp.Items)
+		assert.Empty(t, resp.Items)
+	})
+
+	t.Run("Error - Repository failure", func(t *testing.T) {
+		ctx := context.Background()
+		req := pullrequest.SearchPrRequest{Query: "payments", Page: dto.PageRequest{Limit: 20}}
+
+		mockPRRepo.EXPECT().SearchByTitle(ctx, "payments", "", []string{}, models.PRSort{}, 20, 0).Return(nil, assert.AnError)
+
+		resp, err := service.SearchPRs(ctx, req)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("Error - Count failure", func(t *testing.T) {
+		ctx := context.Background()
+		req := pullrequest.SearchPrRequest{Query: "payments", Page: dto.PageRequest{Limit: 20, Offset: 40}}
+
+		mockPRRepo.EXPECT().SearchByTitle(ctx, "payments", "", []string{}, models.PRSort{}, 20, 40).Return(nil, nil)
+		mockPRRepo.EXPECT().CountByTitle(ctx, "payments", "", []string{}).Return(0, assert.AnError)
+
+		resp, err := service.SearchPRs(ctx, req)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, resp)
+	})
+}
+
+func TestPullRequestService_GetHistory(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	store := newFakeStore(
+		&models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+		&models.User{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+		&models.User{Id: "u3", Name: "Charlie", TeamName: "backend", IsActive: true},
+		&models.User{Id: "u4", Name: "David", TeamName: "backend", IsActive: true},
+	)
+	store.prs["pr-1"] = &models.PullRequest{Id: "pr-1", Title: "Test PR", AuthorId: "u1", Status: models.PRStatusOpen}
+	store.reviewers["pr-1"] = []string{"u2"}
+	// u4 is busier than the others, so every replacement is chosen among the less loaded
+	store.prs["pr-busy"] = &models.PullRequest{Id: "pr-busy", Title: "Busy PR", AuthorId: "u1", Status: models.PRStatusOpen}
+	store.reviewers["pr-busy"] = []string{"u4"}
+	service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+	ctx := context.Background()
+
+	t.Run("Success - Reassignments in chronological order", func(t *testing.T) {
+		_, err := service.ReassignReviewer(ctx, pullrequest.ReassignReviewerRequest{PullRequestID: "pr-1", OldReviewerID: "u2"})
+		assert.NoError(t, err)
+		_, err = service.ReassignReviewer(ctx, pullrequest.ReassignReviewerRequest{PullRequestID: "pr-1", OldReviewerID: "u3"})
+		assert.NoError(t, err)
+
+		resp, err := service.GetHistory(ctx, "pr-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "pr-1", resp.PullRequestID)
+		if assert.Len(t, resp.History, 2) {
+			assert.Equal(t, "u2", resp.History[0].OldReviewerID)
+			assert.Equal(t, "u3", resp.History[0].NewReviewerID)
+			assert.Equal(t, "u3", resp.History[1].OldReviewerID)
+			assert.Equal(t, "u2", resp.History[1].NewReviewerID, "a previous reviewer is a candidate again")
+			assert.Equal(t, models.ReviewerChangeManual, resp.History[1].Trigger)
+			assert.NotEmpty(t, resp.History[1].ChangedAt)
+		}
+	})
+
+	t.Run("Success - PR without changes", func(t *testing.T) {
+		store.prs["pr-2"] = &models.PullRequest{Id: "pr-2", Title: "Quiet PR", AuthorId: "u1", Status: models.PRStatusOpen}
+
+		resp, err := service.GetHistory(ctx, "pr-2")
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp.History)
+		assert.Empty(t, resp.History)
+	})
+
+	t.Run("Error - PR not found", func(t *testing.T) {
+		resp, err := service.GetHistory(ctx, "nonexistent")
+
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Equal(t, "NOT_FOUND", err.(*errors.AppError).Code)
+	})
+}
+
+// failingBatches fails CreateBatch for batches containing any of the listed PRs.
+type failingBatches struct {
+	*fakeStore
+	failing map[string]bool
+}
+
+func (f failingBatches) CreateBatch(ctx context.Context, prs []*models.PullRequest) ([]string, error) {
+	for _, pr := range prs {
+		if f.failing[pr.Id] {
+			return nil, assert.AnError
+		}
+	}
+	return f.fakeStore.CreateBatch(ctx, prs)
+}
+
+func TestPullRequestService_CreateBulk(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	newStore := func() *fakeStore {
+		store := newFakeStore(
+			&models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u3", Name: "Carol", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u4", Name: "Dave", TeamName: "backend", IsActive: false},
+		)
+		store.prs["pr-old"] = &models.PullRequest{Id: "pr-old", Title: "Old", AuthorId: "u1", Status: models.PRStatusOpen}
+		return store
+	}
+	results := func(resp *pullrequest.CreateBulkResponse) []string {
+		out := make([]string, 0, len(resp.Results))
+		for _, r := range resp.Results {
+			out = append(out, fmt.Sprintf("%d:%s:%s", r.Index, r.PullRequestID, r.Result))
+		}
+		return out
+	}
+
+	t.Run("Success - Reports every item", func(t *testing.T) {
+		store := newStore()
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+		resp, err := service.CreateBulk(context.Background(), pullrequest.CreateBulkRequest{
+			PullRequests: []pullrequest.CreatePrRequest{
+				{PullRequestID: "pr-1", PullRequestName: "First", AuthorID: "u1", Labels: []string{"API"}},
+				{PullRequestID: "pr-old", PullRequestName: "Old again", AuthorID: "u1"},
+				{PullRequestID: "pr-2", PullRequestName: "Missing author", AuthorID: "ghost"},
+				{PullRequestID: "pr-3", PullRequestName: "Inactive author", AuthorID: "u4"},
+				{PullRequestID: "pr-1", PullRequestName: "Repeated", AuthorID: "u2"},
+				{PullRequestID: "pr-4", PullRequestName: "Second", AuthorID: "u2"},
+			},
+			AssignReviewers: true,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"0:pr-1:created",
+			"1:pr-old:already_exists",
+			"2:pr-2:not_found",
+			"3:pr-3:not_found",
+			"4:pr-1:already_exists",
+			"5:pr-4:created",
+		}, results(resp))
+		assert.Equal(t, pullrequest.CreateBulkSummary{Created: 2, AlreadyExists: 2, NotFound: 2}, resp.Summary)
+		assert.Equal(t, "author is not active", resp.Results[3].Error)
+		if assert.NotNil(t, resp.Results[0].Pr) {
+			assert.Equal(t, []string{"api"}, resp.Results[0].Pr.Labels)
+			assert.Len(t, resp.Results[0].Pr.AssignedReviewers, 2)
+			assert.NotContains(t, resp.Results[0].Pr.AssignedReviewers, "u1")
+		}
+		assert.Equal(t, "First", store.prs["pr-1"].Title)
+		assert.Len(t, store.reviewers["pr-4"], 2)
+		assert.Equal(t, "Old", store.prs["pr-old"].Title)
+	})
+
+	t.Run("Success - Reviewers are not assigned unless requested", func(t *testing.T) {
+		store := newStore()
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+		resp, err := service.CreateBulk(context.Background(), pullrequest.CreateBulkRequest{
+			PullRequests: []pullrequest.CreatePrRequest{{PullRequestID: "pr-1", PullRequestName: "First", AuthorID: "u1"}},
+		})
+
+		assert.NoError(t, err)
+		if assert.NotNil(t, resp.Results[0].Pr) {
+			assert.Equal(t, []string{}, resp.Results[0].Pr.AssignedReviewers)
+		}
+		assert.Empty(t, store.reviewers["pr-1"])
+	})
+
+	t.Run("Success - Failing chunk does not abort the rest", func(t *testing.T) {
+		store := newStore()
+		prRepo := failingBatches{fakeStore: store, failing: map[string]bool{"pr-3": true}}
+		service := NewPullRequestService(prRepo, store, fakeUsers{store}, store, testReview, logger)
+		items := make([]pullrequest.CreatePrRequest, 0, createBulkChunkSize+2)
+		for i := range createBulkChunkSize + 2 {
+			id := fmt.Sprintf("pr-%d", i)
+			items = append(items, pullrequest.CreatePrRequest{PullRequestID: id, PullRequestName: id, AuthorID: "u1"})
+		}
+
+		resp, err := service.CreateBulk(context.Background(), pullrequest.CreateBulkRequest{PullRequests: items})
+
+		assert.NoError(t, err)
+		assert.Equal(t, pullrequest.CreateBulkSummary{Created: 2, Failed: createBulkChunkSize}, resp.Summary)
+		assert.Equal(t, pullrequest.CreateResultFailed, resp.Results[0].Result)
+		assert.NotEmpty(t, resp.Results[0].Error)
+		last := resp.Results[len(resp.Results)-1]
+		assert.Equal(t, createBulkChunkSize+1, last.Index)
+		assert.Equal(t, pullrequest.CreateResultCreated, last.Result)
+		assert.NotContains(t, store.prs, "pr-0")
+	})
+
+	t.Run("Error - Context canceled", func(t *testing.T) {
+		store := newStore()
+		prRepo := failingBatches{fakeStore: store, failing: map[string]bool{"pr-1": true}}
+		service := NewPullRequestService(prRepo, store, fakeUsers{store}, store, testReview, logger)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		resp, err := service.CreateBulk(ctx, pullrequest.CreateBulkRequest{
+			PullRequests: []pullrequest.CreatePrRequest{{PullRequestID: "pr-1", PullRequestName: "First", AuthorID: "u1"}},
+		})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, resp)
+	})
+}
+
+// failingUpdates fails UpdateStatus for the listed PRs.
+type failingUpdates struct {
+	*fakeStore
+	failing map[string]bool
+}
+
+func (f failingUpdates) UpdateStatus(ctx context.Context, prID, fromStatus, status string, mergedAt *time.Time) (int, error) {
+	if f.failing[prID] {
+		return 0, assert.AnError
+	}
+	return f.fakeStore.UpdateStatus(ctx, prID, fromStatus, status, mergedAt)
+}
+
+func TestPullRequestService_MergeBulk(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	store := newFakeStore(&models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true})
+	mergedAt := time.Now().UTC().Add(-time.Hour)
+	store.prs["pr-open"] = &models.PullRequest{Id: "pr-open", Title: "Open", AuthorId: "u1", Status: models.PRStatusOpen}
+	store.prs["pr-merged"] = &models.PullRequest{Id: "pr-merged", Title: "Merged", AuthorId: "u1",
+		Status: models.PRStatusMerged, MergedAt: &mergedAt}
+	store.prs["pr-broken"] = &models.PullRequest{Id: "pr-broken", Title: "Broken", AuthorId: "u1", Status: models.PRStatusOpen}
+	store.prs["pr-last"] = &models.PullRequest{Id: "pr-last", Title: "Last", AuthorId: "u1", Status: models.PRStatusOpen}
+
+	prRepo := failingUpdates{fakeStore: store, failing: map[string]bool{"pr-broken": true}}
+	service := NewPullRequestService(prRepo, store, fakeUsers{store}, store, testReview, logger)
+
+	resp, err := service.MergeBulk(context.Background(), pullrequest.MergeBulkRequest{
+		PullRequestIDs: []string{"pr-open", "pr-merged", "missing", "pr-broken", "pr-last", "pr-open"},
+	})
+
+	assert.NoError(t, err)
+	results := make([]string, 0, len(resp.Results))
+	for _, r := range resp.Results {
+		results = append(results, r.PullRequestID+":"+r.Result)
+	}
+	assert.Equal(t, []string{
+		"pr-open:merged",
+		"pr-merged:already_merged",
+		"missing:not_found",
+		"pr-broken:failed",
+		"pr-last:merged",
+		"pr-open:already_merged",
+	}, results)
+	assert.NotEmpty(t, resp.Results[3].Error)
+	assert.Equal(t, pullrequest.MergeBulkSummary{Merged: 2, AlreadyMerged: 2, NotFound: 1, Failed: 1}, resp.Summary)
+	assert.Equal(t, models.PRStatusMerged, store.prs["pr-last"].Status, "failure must not abort the batch")
+	assert.Equal(t, models.PRStatusOpen, store.prs["pr-broken"].Status)
+}
+
+func TestPullRequestService_UnassignedPRs(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	newStore := func() *fakeStore {
+		store := newFakeStore(
+			&models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: false},
+			&models.User{Id: "s1", Name: "Sam", TeamName: "solo", IsActive: true},
+		)
+		base := time.Now().UTC().Add(-time.Hour)
+		for i, id := range []string{"pr-1", "pr-2", "pr-3"} {
+			store.prs[id] = &models.PullRequest{Id: id, Title: id, AuthorId: "u1", Status: models.PRStatusOpen,
+				CreatedAt: base.Add(time.Duration(i) * time.Minute)}
+		}
+		store.prs["pr-solo"] = &models.PullRequest{Id: "pr-solo", Title: "solo", AuthorId: "s1", Status: models.PRStatusOpen,
+			CreatedAt: base.Add(-time.Minute)}
+		store.prs["pr-assigned"] = &models.PullRequest{Id: "pr-assigned", Title: "assigned", AuthorId: "u1", Status: models.PRStatusOpen}
+		store.reviewers["pr-assigned"] = []string{"u2"}
+		store.prs["pr-merged"] = &models.PullRequest{Id: "pr-merged", Title: "merged", AuthorId: "u1", Status: models.PRStatusMerged}
+		return store
+	}
+
+	t.Run("Success - Pages of open PRs without reviewers", func(t *testing.T) {
+		store := newStore()
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+		first, err := service.GetUnassignedPRs(context.Background(), pullrequest.UnassignedRequest{Page: dto.PageRequest{Limit: 3}})
+		assert.NoError(t, err)
+		assert.Equal(t, 4, first.Total)
+		ids := make([]string, 0, len(first.Items))
+		for _, pr := range first.Items {
+			ids = append(ids, pr.PullRequestID)
+			assert.NotNil(t, pr.AssignedReviewers)
+		}
+		assert.Equal(t, []string{"pr-solo", "pr-1", "pr-2"}, ids)
+
+		second, err := service.GetUnassignedPRs(context.Background(), pullrequest.UnassignedRequest{
+			Page: dto.PageRequest{Limit: 3, Offset: 3},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 4, second.Total)
+		assert.Equal(t, 3, second.Offset)
+		assert.Len(t, second.Items, 1)
+		assert.Equal(t, "pr-3", second.Items[0].PullRequestID)
+	})
+
+	t.Run("Success - Assign pending after roster change", func(t *testing.T) {
+		store := newStore()
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+		_ = store.SetIsActive(context.Background(), "u2", true)
+
+		resp, err := service.AssignPending(context.Background(), pullrequest.AssignPendingRequest{Limit: 2})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, resp.Processed)
+		assert.Equal(t, 1, resp.Assigned)
+		assert.Equal(t, 1, resp.StillUnassigned, "the solo author still has no candidates")
+		assert.Equal(t, 1, resp.NextOffset)
+		assert.True(t, resp.HasMore)
+		assert.Equal(t, []string{"u2"}, resp.PullRequests[1].AssignedReviewers)
+
+		resp, err = service.AssignPending(context.Background(), pullrequest.AssignPendingRequest{Limit: 2, Offset: resp.NextOffset})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, resp.Assigned)
+		assert.False(t, resp.HasMore)
+
+		remaining, err := service.GetUnassignedPRs(context.Background(), pullrequest.UnassignedRequest{Page: dto.PageRequest{Limit: 10}})
+		assert.NoError(t, err)
+		assert.Len(t, remaining.Items, 1)
+		assert.Equal(t, "pr-solo", remaining.Items[0].PullRequestID)
+	})
+
+	t.Run("Success - Assign pending retries after a concurrent assignment", func(t *testing.T) {
+		store := newStore()
+		_ = store.SetIsActive(context.Background(), "u2", true)
+		reviewers := &racingReviewers{fakeStore: store, concurrent: []string{"u2"}}
+		service := NewPullRequestService(store, reviewers, fakeUsers{store}, store, testReview, logger)
+
+		resp, err := service.AssignPending(context.Background(), pullrequest.AssignPendingRequest{Limit: 2})
+
+		assert.NoError(t, err)
+		assert.True(t, reviewers.raced)
+		assert.Equal(t, 1, resp.Assigned)
+		assert.Equal(t, []string{"u2"}, resp.PullRequests[1].AssignedReviewers)
+		assert.Equal(t, []string{"u2"}, store.reviewers["pr-1"], "the retry must not assign again")
+	})
+}
+
+// racingReviewers assigns the concurrent reviewers to the PR on the first assignment, which
+// then fails at the reviewer cap as if another request had filled the PR first.
+type racingReviewers struct {
+	*fakeStore
+	concurrent []string
+	raced      bool
+}
+
+func (r *racingReviewers) AssignReviewer(ctx context.Context, prID, reviewerID, source string) error {
+	if !r.raced {
+		r.raced = true
+		for _, id := range r.concurrent {
+			_ = r.fakeStore.AssignReviewer(ctx, prID, id, source)
+		}
+		return errors.NewTooManyReviewers("PR already has 2 reviewers")
+	}
+	return r.fakeStore.AssignReviewer(ctx, prID, reviewerID, source)
+}
+
+func TestPullRequestService_ReassignReviewer_ExplicitNewReviewer(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	newStore := func() *fakeStore {
+		store := newFakeStore(
+			&models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u3", Name: "Carol", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u4", Name: "Dave", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u5", Name: "Eve", TeamName: "backend", IsActive: false},
+			&models.User{Id: "u6", Name: "Grace", TeamName: "backend", IsActive: true, NonReviewer: true},
+			&models.User{Id: "f1", Name: "Frank", TeamName: "frontend", IsActive: true},
+		)
+		store.prs["pr-1"] = &models.PullRequest{Id: "pr-1", Title: "feature", AuthorId: "u1", Status: models.PRStatusOpen}
+		store.reviewers["pr-1"] = []string{"u2", "u3"}
+		return store
+	}
+
+	t.Run("Success - Replace with the named reviewer", func(t *testing.T) {
+		store := newStore()
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+		resp, err := service.ReassignReviewer(context.Background(), pullrequest.ReassignReviewerRequest{
+			PullRequestID: "pr-1", OldReviewerID: "u2", NewReviewerID: "u4",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "u4", resp.ReplacedBy)
+		assert.ElementsMatch(t, []string{"u3", "u4"}, resp.Pr.AssignedReviewers)
+		assert.Len(t, store.history, 1)
+		assert.Equal(t, "u4", store.history[0].NewReviewerId)
+		assert.Equal(t, models.ReviewerChangeManual, store.history[0].Trigger)
+		assert.Equal(t, models.AssignmentSourceManual, store.sources[[2]string{"pr-1", "u4"}])
+	})
+
+	errorCases := []struct {
+		name          string
+		newReviewerID string
+		code          string
+	}{
+		{name: "Error - New reviewer not found", newReviewerID: "ghost", code: errors.CodeNotFound},
+		{name: "Error - New reviewer inactive", newReviewerID: "u5", code: errors.CodeNotFound},
+		{name: "Error - New reviewer from another team", newReviewerID: "f1", code: errors.CodeWrongTeam},
+		{name: "Error - New reviewer outside the reviewer pool", newReviewerID: "u6", code: errors.CodeNotReviewer},
+		{name: "Error - New reviewer is the author", newReviewerID: "u1", code: errors.CodeReviewerIsAuthor},
+		{name: "Error - New reviewer already assigned", newReviewerID: "u3", code: errors.CodeAlreadyAssigned},
+		{name: "Error - New reviewer is the old reviewer", newReviewerID: "u2", code: errors.CodeAlreadyAssigned},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := newStore()
+			service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+			resp, err := service.ReassignReviewer(context.Background(), pullrequest.ReassignReviewerRequest{
+				PullRequestID: "pr-1", OldReviewerID: "u2", NewReviewerID: tc.newReviewerID,
+			})
+
+			assert.Nil(t, resp)
+			assert.True(t, errors.HasCode(err, tc.code), "got %v", err)
+			assert.Equal(t, []string{"u2", "u3"}, store.reviewers["pr-1"])
+			assert.Empty(t, store.history)
+		})
+	}
+}
+
+func TestPullRequestService_SuggestReviewers(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	store := newFakeStore(
+		&models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+		&models.User{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+		&models.User{Id: "u3", Name: "Carol", TeamName: "backend", IsActive: true},
+		&models.User{Id: "u4", Name: "Dave", TeamName: "backend", IsActive: false},
+	)
+	store.prs["pr-1"] = &models.PullRequest{Id: "pr-1", AuthorId: "u3", Status: models.PRStatusOpen}
+	store.reviewers["pr-1"] = []string{"u2"}
+	service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+	t.Run("Success - Matches the reviewers CreatePR assigns", func(t *testing.T) {
+		suggested, err := service.SuggestReviewers(context.Background(), pullrequest.SuggestReviewersRequest{AuthorID: "u1", Count: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "backend", suggested.TeamName)
+		assert.Equal(t, []pullrequest.SuggestedReviewer{{UserID: "u3", Username: "Carol", OpenReviews: 0, InHours: true}}, suggested.Candidates)
+		assert.Len(t, store.prs, 1, "suggestion must not write")
+
+		all, err := service.SuggestReviewers(context.Background(), pullrequest.SuggestReviewersRequest{AuthorID: "u1", Count: 10})
+		assert.NoError(t, err)
+		assert.Len(t, all.Candidates, 2)
+		assert.Equal(t, 1, all.Candidates[1].OpenReviews)
+
+		created, err := service.CreatePR(context.Background(), pullrequest.CreatePrRequest{
+			PullRequestID: "pr-2", PullRequestName: "feature", AuthorID: "u1",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u3", "u2"}, created.Pr.AssignedReviewers)
+	})
+
+	t.Run("Error - Inactive author", func(t *testing.T) {
+		resp, err := service.SuggestReviewers(context.Background(), pullrequest.SuggestReviewersRequest{AuthorID: "u4", Count: 2})
+
+		assert.Nil(t, resp)
+		assert.True(t, errors.HasCode(err, errors.CodeNotFound))
+	})
+}
+
+func TestPullRequestService_CreatePR_RequiredTags(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	store := newFakeStore(
+		&models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+		&models.User{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+		&models.User{Id: "u3", Name: "Carol", TeamName: "backend", IsActive: true, Tags: []string{"postgres"}},
+		&models.User{Id: "u4", Name: "Dave", TeamName: "backend", IsActive: true, Tags: []string{"go", "security"}},
+	)
+	store.prs["pr-busy"] = &models.PullRequest{Id: "pr-busy", AuthorId: "u1", Status: models.PRStatusOpen}
+	store.reviewers["pr-busy"] = []string{"u3", "u4"}
+	service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+	t.Run("Success - Matching reviewers are preferred over less loaded ones", func(t *testing.T) {
+		resp, err := service.CreatePR(context.Background(), pullrequest.CreatePrRequest{
+			PullRequestID: "pr-1", PullRequestName: "migration", AuthorID: "u1", RequiredTags: []string{"Postgres", "security"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u3", "u4"}, resp.Pr.AssignedReviewers)
+		assert.Equal(t, &pullrequest.TagMatch{
+			RequiredTags:     []string{"postgres", "security"},
+			MatchedReviewers: []string{"u3", "u4"},
+		}, resp.TagMatch)
+	})
+
+	t.Run("Success - Falls back to the team when too few reviewers match", func(t *testing.T) {
+		resp, err := service.CreatePR(context.Background(), pullrequest.CreatePrRequest{
+			PullRequestID: "pr-2", PullRequestName: "auth", AuthorID: "u1", RequiredTags: []string{"security"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u4", "u2"}, resp.Pr.AssignedReviewers)
+		assert.Equal(t, []string{"u4"}, resp.TagMatch.MatchedReviewers)
+		assert.True(t, resp.TagMatch.Fallback)
+	})
+
+	t.Run("Success - Nobody matches", func(t *testing.T) {
+		resp, err := service.CreatePR(context.Background(), pullrequest.CreatePrRequest{
+			PullRequestID: "pr-3", PullRequestName: "styles", AuthorID: "u1", RequiredTags: []string{"css"},
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.Pr.AssignedReviewers, 2)
+		assert.Equal(t, []string{}, resp.TagMatch.MatchedReviewers)
+		assert.True(t, resp.TagMatch.Fallback)
+	})
+
+	t.Run("Success - No tag match info without required tags", func(t *testing.T) {
+		resp, err := service.CreatePR(context.Background(), pullrequest.CreatePrRequest{
+			PullRequestID: "pr-4", PullRequestName: "docs", AuthorID: "u1",
+		})
+
+		assert.NoError(t, err)
+		assert.Nil(t, resp.TagMatch)
+	})
+
+	t.Run("Success - Suggestions mark matching candidates", func(t *testing.T) {
+		suggested, err := service.SuggestReviewers(context.Background(), pullrequest.SuggestReviewersRequest{
+			AuthorID: "u1", Count: 10, RequiredTags: []string{"GO"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "u4", suggested.Candidates[0].UserID)
+		assert.True(t, suggested.Candidates[0].MatchesTags)
+		assert.False(t, suggested.Candidates[1].MatchesTags)
+	})
+}
+
+func TestPullRequestService_CreatePR_Priority(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	store := newFakeStore(
+		&models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+		&models.User{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+	)
+	store.prs["pr-busy"] = &models.PullRequest{Id: "pr-busy", AuthorId: "u1", Status: models.PRStatusOpen}
+	store.reviewers["pr-busy"] = []string{"u2"}
+	service := NewPullRequestService(store, store, fakeUsers{store}, store, config.Review{Deadline: testReview.Deadline, MaxActiveReviews: 1}, logger)
+
+	t.Run("Success - Defaults to NORMAL and overloads the only candidate at capacity", func(t *testing.T) {
+		resp, err := service.CreatePR(context.Background(), pullrequest.CreatePrRequest{
+			PullRequestID: "pr-1", PullRequestName: "feature", AuthorID: "u1",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.PRPriorityNormal, resp.Pr.Priority)
+		assert.Equal(t, models.PRPriorityNormal, store.prs["pr-1"].Priority)
+		assert.Equal(t, []string{"u2"}, resp.Pr.AssignedReviewers)
+		assert.Equal(t, []string{"u2"}, resp.OverloadedReviewers)
+	})
+
+	t.Run("Success - URGENT ignores capacity", func(t *testing.T) {
+		resp, err := service.CreatePR(context.Background(), pullrequest.CreatePrRequest{
+			PullRequestID: "pr-2", PullRequestName: "hotfix", AuthorID: "u1", Priority: models.PRPriorityUrgent,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.PRPriorityUrgent, resp.Pr.Priority)
+		assert.Equal(t, []string{"u2"}, resp.Pr.AssignedReviewers)
+	})
+}
+
+func TestPullRequestService_CreatePR_PinnedReviewers(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	oneReview := 1
+	newService := func() (*fakeStore, *PullRequestService) {
+		store := newFakeStore(
+			&models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u3", Name: "Carol", TeamName: "backend", IsActive: true, Tags: []string{"go"}},
+			&models.User{Id: "u4", Name: "Dave", TeamName: "backend", IsActive: false},
+			&models.User{Id: "u5", Name: "Eve", TeamName: "frontend", IsActive: true},
+			&models.User{Id: "u6", Name: "Frank", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u7", Name: "Grace", TeamName: "backend", IsActive: true, MaxActiveReviews: &oneReview},
+		)
+		store.exclusions = append(store.exclusions, &models.ReviewerExclusion{ReviewerId: "u6", AuthorId: "u1"})
+		store.prs["pr-busy"] = &models.PullRequest{Id: "pr-busy", AuthorId: "u2", Status: models.PRStatusOpen}
+		store.reviewers["pr-busy"] = []string{"u7"}
+		return store, NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+	}
+
+	t.Run("Success - Exactly the pinned reviewers are assigned", func(t *testing.T) {
+		store, service := newService()
+
+		resp, err := service.CreatePR(context.Background(), pullrequest.CreatePrRequest{
+			PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u1", ReviewerIDs: []string{"u3"},
+			RequiredTags: []string{"go"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u3"}, resp.Pr.AssignedReviewers)
+		assert.Equal(t, []string{"u3"}, resp.TagMatch.MatchedReviewers)
+		assert.Equal(t, models.AssignmentSourceManual, store.sources[[2]string{"pr-1", "u3"}])
+	})
+
+	t.Run("Success - Remaining place is filled automatically", func(t *testing.T) {
+		store, service := newService()
+
+		resp, err := service.CreatePR(context.Background(), pullrequest.CreatePrRequest{
+			PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u1", ReviewerIDs: []string{"u3"},
+			FillRemaining: true,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u3", "u2"}, resp.Pr.AssignedReviewers)
+		assert.Equal(t, models.AssignmentSourceManual, store.sources[[2]string{"pr-1", "u3"}])
+		assert.Equal(t, models.AssignmentSourceAuto, store.sources[[2]string{"pr-1", "u2"}])
+	})
+
+	t.Run("Success - Reviewer at capacity is pinned to an urgent PR", func(t *testing.T) {
+		_, service := newService()
+
+		resp, err := service.CreatePR(context.Background(), pullrequest.CreatePrRequest{
+			PullRequestID: "pr-1", PullRequestName: "Hotfix", AuthorID: "u1", ReviewerIDs: []string{"u7"},
+			Priority: models.PRPriorityUrgent,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u7"}, resp.Pr.AssignedReviewers)
+		assert.Equal(t, []string{"u7"}, resp.OverloadedReviewers)
+	})
+
+	t.Run("Error - Every pinned reviewer that can't review is reported", func(t *testing.T) {
+		store, service := newService()
+
+		_, err := service.CreatePR(context.Background(), pullrequest.CreatePrRequest{
+			PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u1",
+			ReviewerIDs: []string{"u1", "ghost", "u4", "u5", "u6", "u7", "u2"},
+		})
+
+		assert.True(t, errors.HasCode(err, errors.CodeValidation))
+		var domainErr *errors.AppError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, dto.ValidationDetails{Fields: []dto.FieldError{
+			{Field: "reviewer_ids[0]", Rule: "not_author"},
+			{Field: "reviewer_ids[1]", Rule: "exists"},
+			{Field: "reviewer_ids[2]", Rule: "active"},
+			{Field: "reviewer_ids[3]", Rule: "team", Param: "backend"},
+			{Field: "reviewer_ids[4]", Rule: "not_excluded"},
+			{Field: "reviewer_ids[5]", Rule: "capacity", Param: "1"},
+		}}, domainErr.Details)
+		assert.NotContains(t, store.prs, "pr-1")
+	})
+
+	t.Run("Success - Bulk create reports pinned reviewers that can't review", func(t *testing.T) {
+		store, service := newService()
+
+		resp, err := service.CreateBulk(context.Background(), pullrequest.CreateBulkRequest{
+			PullRequests: []pullrequest.CreatePrRequest{
+				{PullRequestID: "pr-1", PullRequestName: "First", AuthorID: "u1", ReviewerIDs: []string{"u5"}},
+				{PullRequestID: "pr-2", PullRequestName: "Second", AuthorID: "u1", ReviewerIDs: []string{"u2", "u3"}},
+			},
+			AssignReviewers: true,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, pullrequest.CreateResultInvalid, resp.Results[0].Result)
+		assert.Equal(t, pullrequest.CreateBulkSummary{Created: 1, Invalid: 1}, resp.Summary)
+		assert.NotContains(t, store.prs, "pr-1")
+		assert.Equal(t, []string{"u2", "u3"}, store.reviewers["pr-2"])
+	})
+}
+
+func TestPullRequestService_SkipAssignment(t *testing.T) {
+	newService := func(log *slog.Logger) (*fakeStore, *PullRequestService) {
+		store := newFakeStore(
+			&models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+			&models.User{Id: "s1", Name: "Sam", TeamName: "solo", IsActive: true},
+		)
+		store.prs["pr-failed"] = &models.PullRequest{Id: "pr-failed", Title: "failed", AuthorId: "s1",
+			Status: models.PRStatusOpen, CreatedAt: time.Now().UTC().Add(-time.Hour)}
+		return store, NewPullRequestService(store, store, fakeUsers{store}, store, testReview, log)
+	}
+	skipped := func(t *testing.T, service *PullRequestService, prID, authorID string) {
+		_, err := service.CreatePR(context.Background(), pullrequest.CreatePrRequest{
+			PullRequestID: prID, PullRequestName: prID, AuthorID: authorID, SkipAssignment: true,
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("Success - PR is created without reviewers or warnings", func(t *testing.T) {
+		var logs bytes.Buffer
+		store, service := newService(slog.New(slog.NewTextHandler(&logs, nil)))
+
+		resp, err := service.CreatePR(context.Background(), pullrequest.CreatePrRequest{
+			PullRequestID: "pr-1", PullRequestName: "Migrate", AuthorID: "s1", SkipAssignment: true,
+		})
+
+		assert.NoError(t, err)
+		assert.Empty(t, resp.Pr.AssignedReviewers)
+		assert.True(t, resp.Pr.AssignmentSkipped)
+		assert.True(t, store.prs["pr-1"].AssignmentSkipped)
+		assert.NotContains(t, logs.String(), "no active reviewer candidates found")
+	})
+
+	t.Run("Success - Bulk create skips the assignment of marked PRs", func(t *testing.T) {
+		store, service := newService(slog.New(slog.DiscardHandler))
+
+		resp, err := service.CreateBulk(context.Background(), pullrequest.CreateBulkRequest{
+			AssignReviewers: true,
+			PullRequests: []pullrequest.CreatePrRequest{
+				{PullRequestID: "pr-1", PullRequestName: "Migrate", AuthorID: "u1", SkipAssignment: true},
+				{PullRequestID: "pr-2", PullRequestName: "Fix", AuthorID: "u1"},
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, resp.Summary.Created)
+		assert.Empty(t, store.reviewers["pr-1"])
+		assert.True(t, store.prs["pr-1"].AssignmentSkipped)
+		assert.Equal(t, []string{"u2"}, store.reviewers["pr-2"])
+	})
+
+	t.Run("Success - Pinned reviewers of a skipped PR are not checked, as in CreatePR", func(t *testing.T) {
+		store, service := newService(slog.New(slog.DiscardHandler))
+		item := pullrequest.CreatePrRequest{PullRequestID: "pr-1", PullRequestName: "Migrate", AuthorID: "u1",
+			ReviewerIDs: []string{"missing"}, SkipAssignment: true}
+
+		_, err := service.CreatePR(context.Background(), item)
+		require.NoError(t, err)
+		item.PullRequestID = "pr-2"
+		resp, err := service.CreateBulk(context.Background(), pullrequest.CreateBulkRequest{
+			AssignReviewers: true, PullRequests: []pullrequest.CreatePrRequest{item},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, resp.Summary.Created)
+		assert.Empty(t, store.reviewers["pr-2"])
+		assert.True(t, store.prs["pr-2"].AssignmentSkipped)
+	})
+
+	t.Run("Success - Listed apart from failed assignments", func(t *testing.T) {
+		_, service := newService(slog.New(slog.DiscardHandler))
+		skipped(t, service, "pr-1", "u1")
+		only, others := true, false
+
+		all, err := service.GetUnassignedPRs(context.Background(), pullrequest.UnassignedRequest{Page: dto.PageRequest{Limit: 10}})
+		require.NoError(t, err)
+		assert.Equal(t, 2, all.Total)
+		onlySkipped, err := service.GetUnassignedPRs(context.Background(), pullrequest.UnassignedRequest{
+			Page: dto.PageRequest{Limit: 10}, AssignmentSkipped: &only,
+		})
+		require.NoError(t, err)
+		require.Len(t, onlySkipped.Items, 1)
+		assert.Equal(t, "pr-1", onlySkipped.Items[0].PullRequestID)
+		assert.True(t, onlySkipped.Items[0].AssignmentSkipped)
+		failed, err := service.GetUnassignedPRs(context.Background(), pullrequest.UnassignedRequest{
+			Page: dto.PageRequest{Limit: 10}, AssignmentSkipped: &others,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, failed.Total)
+		assert.Equal(t, "pr-failed", failed.Items[0].PullRequestID)
+	})
+
+	t.Run("Success - Assign pending leaves skipped PRs alone", func(t *testing.T) {
+		store, service := newService(slog.New(slog.DiscardHandler))
+		skipped(t, service, "pr-1", "u1")
+
+		resp, err := service.AssignPending(context.Background(), pullrequest.AssignPendingRequest{Limit: 10})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, resp.Processed)
+		assert.Equal(t, "pr-failed", resp.PullRequests[0].PullRequestID)
+		assert.Empty(t, store.reviewers["pr-1"])
+	})
+
+	t.Run("Success - Assign runs the selection later", func(t *testing.T) {
+		store, service := newService(slog.New(slog.DiscardHandler))
+		skipped(t, service, "pr-1", "u1")
+
+		resp, err := service.AssignPR(context.Background(), pullrequest.AssignPrRequest{PullRequestID: "pr-1"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u2"}, resp.Pr.AssignedReviewers)
+		assert.False(t, resp.Pr.AssignmentSkipped)
+		assert.False(t, store.prs["pr-1"].AssignmentSkipped)
+		assert.Equal(t, models.AssignmentSourceAuto, store.sources[[2]string{"pr-1", "u2"}])
+
+		again, err := service.AssignPR(context.Background(), pullrequest.AssignPrRequest{PullRequestID: "pr-1"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u2"}, again.Pr.AssignedReviewers, "a retry keeps the reviewers")
+	})
+
+	t.Run("Success - Assign without candidates leaves the PR to assign pending", func(t *testing.T) {
+		store, service := newService(slog.New(slog.DiscardHandler))
+		skipped(t, service, "pr-1", "s1")
+
+		resp, err := service.AssignPR(context.Background(), pullrequest.AssignPrRequest{PullRequestID: "pr-1"})
+
+		assert.NoError(t, err)
+		assert.Empty(t, resp.Pr.AssignedReviewers)
+		assert.False(t, store.prs["pr-1"].AssignmentSkipped)
+	})
+
+	t.Run("Error - Assign to missing or merged PR", func(t *testing.T) {
+		store, service := newService(slog.New(slog.DiscardHandler))
+		store.prs["pr-merged"] = &models.PullRequest{Id: "pr-merged", AuthorId: "u1", Status: models.PRStatusMerged}
+
+		_, err := service.AssignPR(context.Background(), pullrequest.AssignPrRequest{PullRequestID: "pr-missing"})
+		assert.True(t, errors.HasCode(err, errors.CodeNotFound))
+		_, err = service.AssignPR(context.Background(), pullrequest.AssignPrRequest{PullRequestID: "pr-merged"})
+		assert.True(t, errors.HasCode(err, errors.CodePRMerged))
+	})
+}
+
+func TestPullRequestService_SubmitReview(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	store := newFakeStore(
+		&models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+		&models.User{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+		&models.User{Id: "u3", Name: "Carol", TeamName: "backend", IsActive: true},
+	)
+	store.prs["pr-1"] = &models.PullRequest{Id: "pr-1", AuthorId: "u1", Status: models.PRStatusOpen}
+	store.reviewers["pr-1"] = []string{"u2", "u3"}
+	store.prs["pr-merged"] = &models.PullRequest{Id: "pr-merged", AuthorId: "u1", Status: models.PRStatusMerged}
+	store.reviewers["pr-merged"] = []string{"u2"}
+	store.states[[2]string{"pr-merged", "u2"}] = models.ReviewStateChangesRequested
+	service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+	review := func(prID, reviewerID, state string) (*pullrequest.ReviewResponse, error) {
+		return service.SubmitReview(context.Background(), pullrequest.ReviewRequest{
+			PullRequestID: prID, ReviewerID: reviewerID, State: state,
+		})
+	}
+	stateOf := func(resp *pullrequest.ReviewResponse, reviewerID string) pullrequest.Reviewer {
+		for _, r := range resp.Pr.Reviewers {
+			if r.UserID == reviewerID {
+				return r
+			}
+		}
+		return pullrequest.Reviewer{}
+	}
+
+	t.Run("Success - Request changes and approve", func(t *testing.T) {
+		resp, err := review("pr-1", "u2", models.ReviewStateChangesRequested)
+		assert.NoError(t, err)
+		assert.Equal(t, models.ReviewStateChangesRequested, stateOf(resp, "u2").State)
+		assert.NotEmpty(t, stateOf(resp, "u2").StateChangedAt)
+		assert.Equal(t, models.ReviewStatePending, stateOf(resp, "u3").State)
+		assert.Empty(t, stateOf(resp, "u3").StateChangedAt)
+
+		resp, err = review("pr-1", "u2", models.ReviewStateApproved)
+		assert.NoError(t, err)
+		assert.Equal(t, models.ReviewStateApproved, stateOf(resp, "u2").State)
+	})
+
+	t.Run("Success - Same state is a no-op", func(t *testing.T) {
+		before := store.stateChangedAt[[2]string{"pr-1", "u2"}]
+
+		resp, err := review("pr-1", "u2", models.ReviewStateApproved)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.ReviewStateApproved, stateOf(resp, "u2").State)
+		assert.Equal(t, before, store.stateChangedAt[[2]string{"pr-1", "u2"}])
+	})
+
+	t.Run("Error - Approval can't be reset to pending", func(t *testing.T) {
+		resp, err := review("pr-1", "u2", models.ReviewStatePending)
+
+		assert.Nil(t, resp)
+		assert.True(t, errors.HasCode(err, errors.CodeInvalidTransition))
+		assert.Equal(t, models.ReviewStateApproved, store.states[[2]string{"pr-1", "u2"}])
+	})
+
+	t.Run("Error - Merged PR", func(t *testing.T) {
+		resp, err := review("pr-merged", "u2", models.ReviewStateApproved)
+
+		assert.Nil(t, resp)
+		assert.True(t, errors.HasCode(err, errors.CodePRMerged))
+		assert.Equal(t, models.ReviewStateChangesRequested, store.states[[2]string{"pr-merged", "u2"}])
+	})
+
+	t.Run("Error - Reviewer not assigned", func(t *testing.T) {
+		resp, err := review("pr-1", "u1", models.ReviewStateApproved)
+
+		assert.Nil(t, resp)
+		assert.True(t, errors.HasCode(err, errors.CodeNotAssigned))
+	})
+
+	t.Run("Error - PR not found", func(t *testing.T) {
+		resp, err := review("unknown", "u2", models.ReviewStateApproved)
+
+		assert.Nil(t, resp)
+		assert.True(t, errors.HasCode(err, errors.CodeNotFound))
+	})
+}
+
+func TestPullRequestService_MergePR_ChangesRequested(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	newStore := func() *fakeStore {
+		store := newFakeStore(
+			&models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u3", Name: "Carol", TeamName: "backend", IsActive: true},
+		)
+		store.prs["pr-1"] = &models.PullRequest{Id: "pr-1", AuthorId: "u1", Status: models.PRStatusOpen}
+		store.reviewers["pr-1"] = []string{"u2", "u3"}
+		store.states[[2]string{"pr-1", "u2"}] = models.ReviewStateApproved
+		store.states[[2]string{"pr-1", "u3"}] = models.ReviewStateChangesRequested
+		return store
+	}
+	blocking := config.Review{Deadline: testReview.Deadline, BlockMergeOnChangesRequested: true}
+
+	t.Run("Error - Blocked when enabled", func(t *testing.T) {
+		store := newStore()
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, blocking, logger)
+
+		resp, err := service.MergePR(context.Background(), pullrequest.MergePrRequest{PullRequestID: "pr-1"})
+
+		assert.Nil(t, resp)
+		assert.True(t, errors.HasCode(err, errors.CodeChangesRequested))
+		assert.Contains(t, err.Error(), "u3")
+		assert.Equal(t, models.PRStatusOpen, store.prs["pr-1"].Status)
+	})
+
+	t.Run("Success - Merged once changes are approved", func(t *testing.T) {
+		store := newStore()
+		store.states[[2]string{"pr-1", "u3"}] = models.ReviewStateApproved
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, blocking, logger)
+
+		resp, err := service.MergePR(context.Background(), pullrequest.MergePrRequest{PullRequestID: "pr-1"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.PRStatusMerged, resp.Pr.Status)
+	})
+
+	t.Run("Error - Approvals do not outweigh requested changes", func(t *testing.T) {
+		store := newStore()
+		store.reviewers["pr-1"] = []string{"u2", "u3", "u4"}
+		store.states[[2]string{"pr-1", "u4"}] = models.ReviewStateApproved
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, blocking, logger)
+
+		_, err := service.MergePR(context.Background(), pullrequest.MergePrRequest{PullRequestID: "pr-1"})
+
+		assert.True(t, errors.HasCode(err, errors.CodeChangesRequested))
+		assert.NotContains(t, err.Error(), "u2")
+	})
+
+	t.Run("Success - Override merges over requested changes and is logged", func(t *testing.T) {
+		store := newStore()
+		var logs bytes.Buffer
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, blocking,
+			slog.New(slog.NewTextHandler(&logs, nil)))
+
+		resp, err := service.MergePR(context.Background(), pullrequest.MergePrRequest{PullRequestID: "pr-1", Override: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, models.PRStatusMerged, resp.Pr.Status)
+		assert.Contains(t, logs.String(), `msg="merge overrode requested changes" audit=true pr_id=pr-1 reviewer_ids=[u3]`)
+	})
+
+	t.Run("Error - Override does not supply missing reviewers", func(t *testing.T) {
+		store := newStore()
+		store.reviewers["pr-1"] = nil
+		review := blocking
+		review.RequireReviewerForMerge = true
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, review, logger)
+
+		_, err := service.MergePR(context.Background(), pullrequest.MergePrRequest{PullRequestID: "pr-1", Override: true})
+
+		assert.True(t, errors.HasCode(err, errors.CodeNoReviewers))
+	})
+
+	t.Run("Success - Override without requested changes is not logged", func(t *testing.T) {
+		store := newStore()
+		store.states[[2]string{"pr-1", "u3"}] = models.ReviewStateApproved
+		var logs bytes.Buffer
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, blocking,
+			slog.New(slog.NewTextHandler(&logs, nil)))
+
+		_, err := service.MergePR(context.Background(), pullrequest.MergePrRequest{PullRequestID: "pr-1", Override: true})
+
+		require.NoError(t, err)
+		assert.NotContains(t, logs.String(), "overrode")
+	})
+
+	t.Run("Success - Not blocked by default", func(t *testing.T) {
+		store := newStore()
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+		resp, err := service.MergePR(context.Background(), pullrequest.MergePrRequest{PullRequestID: "pr-1"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.PRStatusMerged, resp.Pr.Status)
+	})
+}
+
+func TestPullRequestService_MergePR_RequireReviewer(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	newStore := func() *fakeStore {
+		store := newFakeStore(
+			&models.User{Id: "s1", Name: "Sam", TeamName: "solo", IsActive: true},
+		)
+		mergedAt := time.Now().UTC()
+		store.prs["pr-1"] = &models.PullRequest{Id: "pr-1", AuthorId: "s1", Status: models.PRStatusOpen}
+		store.prs["pr-merged"] = &models.PullRequest{Id: "pr-merged", AuthorId: "s1", Status: models.PRStatusMerged,
+			MergedAt: &mergedAt}
+		return store
+	}
+	requiring := config.Review{Deadline: testReview.Deadline, RequireReviewerForMerge: true}
+
+	t.Run("Error - Blocked without reviewers when enabled", func(t *testing.T) {
+		store := newStore()
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, requiring, logger)
+
+		resp, err := service.MergePR(context.Background(), pullrequest.MergePrRequest{PullRequestID: "pr-1"})
+
+		assert.Nil(t, resp)
+		assert.True(t, errors.HasCode(err, errors.CodeNoReviewers))
+		assert.Contains(t, err.Error(), "0 assigned reviewers")
+		assert.Equal(t, models.PRStatusOpen, store.prs["pr-1"].Status)
+	})
+
+	t.Run("Success - Merged once a reviewer is assigned", func(t *testing.T) {
+		store := newStore()
+		store.reviewers["pr-1"] = []string{"u2"}
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, requiring, logger)
+
+		resp, err := service.MergePR(context.Background(), pullrequest.MergePrRequest{PullRequestID: "pr-1"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.PRStatusMerged, resp.Pr.Status)
+	})
+
+	t.Run("Success - Already merged PR stays idempotent", func(t *testing.T) {
+		store := newStore()
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, requiring, logger)
+
+		resp, err := service.MergePR(context.Background(), pullrequest.MergePrRequest{PullRequestID: "pr-merged"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.PRStatusMerged, resp.Pr.Status)
+	})
+
+	t.Run("Success - Not blocked by default", func(t *testing.T) {
+		store := newStore()
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+		resp, err := service.MergePR(context.Background(), pullrequest.MergePrRequest{PullRequestID: "pr-1"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.PRStatusMerged, resp.Pr.Status)
+	})
+}
+
+// lostUpdates reports every status update as lost to a concurrent request.
+type lostUpdates struct {
+	*fakeStore
+}
+
+func (l lostUpdates) UpdateStatus(ctx context.Context, prID, fromStatus, status string, mergedAt *time.Time) (int, error) {
+	return 0, nil
+}
+
+func TestPullRequestService_MergePR_Concurrent(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	newStore := func() *fakeStore {
+		store := newFakeStore(
+			&models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+		)
+		store.prs["pr-1"] = &models.PullRequest{Id: "pr-1", Title: "Test PR", AuthorId: "u1", Status: models.PRStatusOpen}
+		store.reviewers["pr-1"] = []string{"u2"}
+		return store
+	}
+	req := pullrequest.MergePrRequest{PullRequestID: "pr-1"}
+
+	t.Run("Success - Double merge merges once", func(t *testing.T) {
+		store := newStore()
+		// both requests read the open PR before either updates it, as under Repeatable Read
+		var barrier sync.WaitGroup
+		barrier.Add(2)
+		var reads atomic.Int32
+		store.afterFindPR = func() {
+			if reads.Add(1) <= 2 {
+				barrier.Done()
+				barrier.Wait()
+			}
+		}
+		service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+		var wg sync.WaitGroup
+		responses := make([]*pullrequest.MergePrResponse, 2)
+		alreadyMerged := make([]bool, 2)
+		errs := make([]error, 2)
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				responses[i], alreadyMerged[i], errs[i] = service.mergePR(context.Background(), req)
+			}(i)
+		}
+		wg.Wait()
+
+		assert.NoError(t, errs[0])
+		assert.NoError(t, errs[1])
+		assert.NotEqual(t, alreadyMerged[0], alreadyMerged[1], "exactly one request must merge")
+		if assert.NotNil(t, responses[0]) && assert.NotNil(t, responses[1]) {
+			assert.Equal(t, responses[0].Pr, responses[1].Pr, "the losing request must see the winner's merge")
+			assert.Equal(t, models.PRStatusMerged, responses[0].Pr.Status)
+		}
+	})
+
+	t.Run("Error - Status changing on every attempt conflicts", func(t *testing.T) {
+		store := newStore()
+		service := NewPullRequestService(lostUpdates{store}, store, fakeUsers{store}, store, testReview, logger)
+
+		resp, err := service.MergePR(context.Background(), req)
+
+		assert.Nil(t, resp)
+		assert.True(t, errors.HasCode(err, errors.CodeInvalidTransition))
+		assert.Nil(t, store.prs["pr-1"].MergedAt)
+	})
+}
+
+func TestPullRequestService_Labels(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	store := newFakeStore(
+		&models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+	)
+	store.prs["pr-merged"] = &models.PullRequest{Id: "pr-merged", AuthorId: "u1", Status: models.PRStatusMerged}
+	service := NewPullRequestService(store, store, fakeUsers{store}, store, testReview, logger)
+
+	t.Run("Success - Labels are normalized on create", func(t *testing.T) {
+		resp, err := service.CreatePR(context.Background(), pullrequest.CreatePrRequest{
+			PullRequestID: "pr-1", PullRequestName: "feature", AuthorID: "u1", Labels: []string{"Infra", " api", "infra"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"api", "infra"}, resp.Pr.Labels)
+		assert.Equal(t, []string{"api", "infra"}, store.prs["pr-1"].Labels)
+	})
+
+	t.Run("Success - No labels render as an empty list", func(t *testing.T) {
+		resp, err := service.CreatePR(context.Background(), pullrequest.CreatePrRequest{
+			PullRequestID: "pr-2", PullRequestName: "docs", AuthorID: "u1",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{}, resp.Pr.Labels)
+	})
+
+	t.Run("Success - Filter by labels", func(t *testing.T) {
+		search, err := service.SearchPRs(context.Background(), pullrequest.SearchPrRequest{
+			Query: "e", Page: dto.PageRequest{Limit: 20}, Labels: []string{"API", "infra"},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, search.Items, 1)
+		assert.Equal(t, "pr-1", search.Items[0].PullRequestID)
+
+		unassigned, err := service.GetUnassignedPRs(context.Background(), pullrequest.UnassignedRequest{
+			Page: dto.PageRequest{Limit: 20}, Labels: []string{"api"},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, unassigned.Items, 1)
+		assert.Equal(t, "pr-1", unassigned.Items[0].PullRequestID)
+	})
+
+	t.Run("Success - Set labels", func(t *testing.T) {
+		resp, err := service.SetLabels(context.Background(), pullrequest.SetLabelsRequest{
+			PullRequestID: "pr-2", Labels: []string{"Docs"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"docs"}, resp.Pr.Labels)
+		assert.Equal(t, []string{"docs"}, store.prs["pr-2"].Labels)
+
+		resp, err = service.SetLabels(context.Background(), pullrequest.SetLabelsRequest{PullRequestID: "pr-2"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{}, resp.Pr.Labels)
+	})
+
+	t.Run("Error - Merged PR", func(t *testing.T) {
+		resp, err := service.SetLabels(context.Background(), pullrequest.SetLabelsRequest{
+			PullRequestID: "pr-merged", Labels: []string{"docs"},
+		})
+
+		assert.Nil(t, resp)
+		assert.True(t, errors.HasCode(err, errors.CodePRMerged))
+	})
+
+	t.Run("Error - PR not found", func(t *testing.T) {
+		resp, err := service.SetLabels(context.Background(), pullrequest.SetLabelsRequest{
+			PullRequestID: "unknown", Labels: []string{"docs"},
+		})
+
+		assert.Nil(t, resp)
+		assert.True(t, errors.HasCode(err, errors.CodeNotFound))
+	})
+}
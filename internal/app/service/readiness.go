@@ -0,0 +1,245 @@
+package service
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mocks/mock_readiness_deps.go -package=mocks
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+)
+
+// Names of the readiness checks; background jobs are checked under the names they are added with.
+const (
+	CheckDatabase   = "database"
+	CheckMigrations = "migrations"
+	CheckOutbox     = "outbox"
+	CheckLeader     = "leader"
+)
+
+// workerStaleIntervals is the number of intervals after which a job that did not go round its loop
+// is considered stopped or hung.
+const workerStaleIntervals = 2
+
+// ReadinessRepository defines the interface for the state of the database.
+type ReadinessRepository interface {
+	Ping(ctx context.Context) error
+	MigrationVersion(ctx context.Context) (version uint, dirty bool, err error)
+}
+
+// ReadinessOutbox defines the interface for counting the queued webhook deliveries of all organizations.
+type ReadinessOutbox interface {
+	CountPending(ctx context.Context) (int, error)
+}
+
+// Worker defines the interface of a background job whose liveness is checked.
+type Worker interface {
+	LastBeat() time.Time
+	Interval() time.Duration
+}
+
+// ReadinessLeader defines the interface of the leader election, whose state is reported.
+type ReadinessLeader interface {
+	ID() string
+	IsLeader() bool
+	// Holder returns the id of the leader, empty when unknown
+	Holder() string
+}
+
+// ReadinessService checks the dependencies the service needs to serve requests.
+type ReadinessService struct {
+	repo   ReadinessRepository
+	outbox ReadinessOutbox
+	leader ReadinessLeader
+	// expectedMigration is the schema version the service was built for
+	expectedMigration uint
+	workers           []namedWorker
+	cfg               config.Readiness
+	log               *slog.Logger
+}
+
+type namedWorker struct {
+	name   string
+	worker Worker
+}
+
+// NewReadinessService creates a new readiness service expecting the database at expectedMigration.
+// The outbox is not checked when outbox is nil.
+func NewReadinessService(
+	repo ReadinessRepository,
+	outbox ReadinessOutbox,
+	expectedMigration uint,
+	cfg config.Readiness,
+	log *slog.Logger,
+) *ReadinessService {
+	if log == nil {
+		log = slog.Default()
+	}
+	return &ReadinessService{
+		repo:              repo,
+		outbox:            outbox,
+		expectedMigration: expectedMigration,
+		cfg:               cfg,
+		log:               log,
+	}
+}
+
+// AddWorker checks the liveness of the background job under name. It must be called before Check.
+func (s *ReadinessService) AddWorker(name string, worker Worker) {
+	s.workers = append(s.workers, namedWorker{name: name, worker: worker})
+}
+
+// SetLeader reports the leader election under the leader check. It must be called before Check.
+func (s *ReadinessService) SetLeader(leader ReadinessLeader) {
+	s.leader = leader
+}
+
+// readinessCheck is a check run by Check; it returns the details of the result without its name,
+// status and latency.
+type readinessCheck struct {
+	name string
+	run  func(ctx context.Context) (admin.ReadinessCheck, error)
+}
+
+// Check runs all checks concurrently, each bounded by the configured timeout. The service is
+// unavailable when a critical check fails, and degraded with a warning for every other failure.
+func (s *ReadinessService) Check(ctx context.Context) *admin.ReadinessResponse {
+	checks := []readinessCheck{
+		{name: CheckDatabase, run: s.checkDatabase},
+		{name: CheckMigrations, run: s.checkMigrations},
+	}
+	if s.outbox != nil {
+		checks = append(checks, readinessCheck{name: CheckOutbox, run: s.checkOutbox})
+	}
+	if s.leader != nil {
+		checks = append(checks, readinessCheck{name: CheckLeader, run: s.checkLeader})
+	}
+	for _, w := range s.workers {
+		checks = append(checks, readinessCheck{name: w.name, run: func(context.Context) (admin.ReadinessCheck, error) {
+			return checkWorker(w.worker, time.Now())
+		}})
+	}
+
+	results := make([]admin.ReadinessCheck, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Go(func() { results[i] = s.runCheck(ctx, check) })
+	}
+	wg.Wait()
+
+	response := &admin.ReadinessResponse{Status: admin.ReadinessReady, Checks: results}
+	for _, result := range results {
+		if result.Status == admin.CheckOK {
+			continue
+		}
+		s.log.LogAttrs(ctx, slog.LevelWarn, "readiness check failed",
+			slog.String("check", result.Name),
+			slog.Bool("critical", result.Critical),
+			slog.String("error", result.Error))
+		if result.Critical {
+			response.Status = admin.ReadinessUnavailable
+			continue
+		}
+		response.Warnings = append(response.Warnings, result.Name+": "+result.Error)
+		if response.Status == admin.ReadinessReady {
+			response.Status = admin.ReadinessDegraded
+		}
+	}
+	return response
+}
+
+// runCheck runs the check within the timeout and fills in its name, status and latency.
+func (s *ReadinessService) runCheck(ctx context.Context, check readinessCheck) admin.ReadinessCheck {
+	if s.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.cfg.Timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	result, err := check.run(ctx)
+	result.LatencyMS = float64(time.Since(start).Microseconds()) / 1000
+	result.Name = check.name
+	result.Critical = slices.Contains(s.cfg.Critical, check.name)
+	result.Status = admin.CheckOK
+	if err != nil {
+		result.Status = admin.CheckFail
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func (s *ReadinessService) checkDatabase(ctx context.Context) (admin.ReadinessCheck, error) {
+	return admin.ReadinessCheck{}, s.repo.Ping(ctx)
+}
+
+// checkMigrations fails while migrations are pending or after one failed halfway. A database
+// ahead of the service passes, as during a rollout the old replicas run on the new schema.
+func (s *ReadinessService) checkMigrations(ctx context.Context) (admin.ReadinessCheck, error) {
+	version, dirty, err := s.repo.MigrationVersion(ctx)
+	if err != nil {
+		return admin.ReadinessCheck{}, err
+	}
+	result := admin.ReadinessCheck{Migration: &admin.MigrationStatus{
+		Current:  version,
+		Expected: s.expectedMigration,
+		Dirty:    dirty,
+	}}
+	switch {
+	case dirty:
+		return result, fmt.Errorf("migration %d failed halfway", version)
+	case version < s.expectedMigration:
+		return result, fmt.Errorf("database is at migration %d, %d expected", version, s.expectedMigration)
+	}
+	return result, nil
+}
+
+// checkOutbox fails when more webhook deliveries are pending than the configured maximum, which
+// means they are not posted, or not fast enough.
+func (s *ReadinessService) checkOutbox(ctx context.Context) (admin.ReadinessCheck, error) {
+	pending, err := s.outbox.CountPending(ctx)
+	if err != nil {
+		return admin.ReadinessCheck{}, err
+	}
+	result := admin.ReadinessCheck{Outbox: &admin.OutboxStatus{Pending: pending, Max: s.cfg.MaxOutboxBacklog}}
+	if s.cfg.MaxOutboxBacklog > 0 && pending > s.cfg.MaxOutboxBacklog {
+		return result, fmt.Errorf("%d deliveries pending, more than %d", pending, s.cfg.MaxOutboxBacklog)
+	}
+	return result, nil
+}
+
+// checkLeader reports whether this replica leads; it fails only when no replica is known to lead,
+// as when the lease can't be read, since the exclusive jobs then run nowhere.
+func (s *ReadinessService) checkLeader(context.Context) (admin.ReadinessCheck, error) {
+	result := admin.ReadinessCheck{Leader: &admin.LeaderStatus{
+		ID:     s.leader.ID(),
+		Leader: s.leader.IsLeader(),
+		Holder: s.leader.Holder(),
+	}}
+	if result.Leader.Holder == "" {
+		return result, fmt.Errorf("no leader elected")
+	}
+	return result, nil
+}
+
+// checkWorker fails when the job never started or did not go round its loop for
+// workerStaleIntervals of its interval.
+func checkWorker(worker Worker, now time.Time) (admin.ReadinessCheck, error) {
+	last, interval := worker.LastBeat(), worker.Interval()
+	result := admin.ReadinessCheck{Worker: &admin.WorkerStatus{
+		LastBeatAt: dto.FormatTime(last),
+		Interval:   interval.String(),
+	}}
+	switch {
+	case last.IsZero():
+		return result, fmt.Errorf("not running")
+	case now.Sub(last) > workerStaleIntervals*interval:
+		return result, fmt.Errorf("no run for %s", now.Sub(last).Truncate(time.Second))
+	}
+	return result, nil
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+	"github.com/shirr9/pr-reviewer-service/internal/app/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeWorker is a background job that last went round its loop at last.
+type fakeWorker struct {
+	last time.Time
+}
+
+func (w fakeWorker) LastBeat() time.Time     { return w.last }
+func (w fakeWorker) Interval() time.Duration { return time.Minute }
+
+func TestReadinessService_Check(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	cfg := config.Readiness{
+		Critical:         []string{CheckDatabase, CheckMigrations},
+		Timeout:          time.Second,
+		MaxOutboxBacklog: 10,
+	}
+
+	newService := func(t *testing.T, setup func(repo *mocks.MockReadinessRepository, outbox *mocks.MockReadinessOutbox)) *ReadinessService {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockReadinessRepository(ctrl)
+		outbox := mocks.NewMockReadinessOutbox(ctrl)
+		setup(repo, outbox)
+		service := NewReadinessService(repo, outbox, 22, cfg, logger)
+		service.AddWorker("webhook_job", fakeWorker{last: time.Now()})
+		return service
+	}
+	healthy := func(repo *mocks.MockReadinessRepository, outbox *mocks.MockReadinessOutbox) {
+		repo.EXPECT().Ping(gomock.Any()).Return(nil)
+		repo.EXPECT().MigrationVersion(gomock.Any()).Return(uint(22), false, nil)
+		outbox.EXPECT().CountPending(gomock.Any()).Return(3, nil)
+	}
+	byName := func(resp *admin.ReadinessResponse) map[string]admin.ReadinessCheck {
+		checks := make(map[string]admin.ReadinessCheck, len(resp.Checks))
+		for _, check := range resp.Checks {
+			checks[check.Name] = check
+		}
+		return checks
+	}
+
+	t.Run("Success - Every check passes", func(t *testing.T) {
+		resp := newService(t, healthy).Check(context.Background())
+
+		assert.Equal(t, admin.ReadinessReady, resp.Status)
+		assert.Empty(t, resp.Warnings)
+		names := make([]string, 0, len(resp.Checks))
+		for _, check := range resp.Checks {
+			names = append(names, check.Name)
+			assert.Equal(t, admin.CheckOK, check.Status, check.Name)
+		}
+		assert.Equal(t, []string{CheckDatabase, CheckMigrations, CheckOutbox, "webhook_job"}, names)
+		checks := byName(resp)
+		assert.True(t, checks[CheckDatabase].Critical)
+		assert.False(t, checks[CheckOutbox].Critical)
+		assert.Equal(t, &admin.MigrationStatus{Current: 22, Expected: 22}, checks[CheckMigrations].Migration)
+		assert.Equal(t, &admin.OutboxStatus{Pending: 3, Max: 10}, checks[CheckOutbox].Outbox)
+		assert.Equal(t, "1m0s", checks["webhook_job"].Worker.Interval)
+	})
+
+	t.Run("Success - Database ahead of the service", func(t *testing.T) {
+		resp := newService(t, func(repo *mocks.MockReadinessRepository, outbox *mocks.MockReadinessOutbox) {
+			repo.EXPECT().Ping(gomock.Any()).Return(nil)
+			repo.EXPECT().MigrationVersion(gomock.Any()).Return(uint(23), false, nil)
+			outbox.EXPECT().CountPending(gomock.Any()).Return(0, nil)
+		}).Check(context.Background())
+
+		assert.Equal(t, admin.ReadinessReady, resp.Status)
+	})
+
+	t.Run("Success - Failure of a check that is not critical degrades", func(t *testing.T) {
+		resp := newService(t, func(repo *mocks.MockReadinessRepository, outbox *mocks.MockReadinessOutbox) {
+			repo.EXPECT().Ping(gomock.Any()).Return(nil)
+			repo.EXPECT().MigrationVersion(gomock.Any()).Return(uint(22), false, nil)
+			outbox.EXPECT().CountPending(gomock.Any()).Return(11, nil)
+		}).Check(context.Background())
+
+		assert.Equal(t, admin.ReadinessDegraded, resp.Status)
+		assert.Equal(t, []string{"outbox: 11 deliveries pending, more than 10"}, resp.Warnings)
+		assert.Equal(t, admin.CheckFail, byName(resp)[CheckOutbox].Status)
+	})
+
+	t.Run("Error - Database unreachable", func(t *testing.T) {
+		resp := newService(t, func(repo *mocks.MockReadinessRepository, outbox *mocks.MockReadinessOutbox) {
+			repo.EXPECT().Ping(gomock.Any()).Return(assert.AnError)
+			repo.EXPECT().MigrationVersion(gomock.Any()).Return(uint(0), false, assert.AnError)
+			outbox.EXPECT().CountPending(gomock.Any()).Return(0, assert.AnError)
+		}).Check(context.Background())
+
+		assert.Equal(t, admin.ReadinessUnavailable, resp.Status)
+		checks := byName(resp)
+		assert.Equal(t, assert.AnError.Error(), checks[CheckDatabase].Error)
+		assert.Nil(t, checks[CheckMigrations].Migration)
+		// only checks that are not critical are warnings
+		assert.Equal(t, []string{"outbox: " + assert.AnError.Error()}, resp.Warnings)
+	})
+
+	t.Run("Error - Pending and failed migrations", func(t *testing.T) {
+		for version, dirty := range map[uint]bool{21: false, 22: true} {
+			resp := newService(t, func(repo *mocks.MockReadinessRepository, outbox *mocks.MockReadinessOutbox) {
+				repo.EXPECT().Ping(gomock.Any()).Return(nil)
+				repo.EXPECT().MigrationVersion(gomock.Any()).Return(version, dirty, nil)
+				outbox.EXPECT().CountPending(gomock.Any()).Return(0, nil)
+			}).Check(context.Background())
+
+			assert.Equal(t, admin.ReadinessUnavailable, resp.Status)
+			check := byName(resp)[CheckMigrations]
+			assert.Equal(t, admin.CheckFail, check.Status)
+			assert.Equal(t, &admin.MigrationStatus{Current: version, Expected: 22, Dirty: dirty}, check.Migration)
+		}
+	})
+
+	t.Run("Error - Checks are bounded by the timeout", func(t *testing.T) {
+		service := newService(t, func(repo *mocks.MockReadinessRepository, outbox *mocks.MockReadinessOutbox) {
+			repo.EXPECT().Ping(gomock.Any()).DoAndReturn(func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			})
+			repo.EXPECT().MigrationVersion(gomock.Any()).Return(uint(22), false, nil)
+			outbox.EXPECT().CountPending(gomock.Any()).Return(0, nil)
+		})
+		service.cfg.Timeout = 10 * time.Millisecond
+
+		resp := service.Check(context.Background())
+
+		assert.Equal(t, admin.ReadinessUnavailable, resp.Status)
+		assert.Equal(t, context.DeadlineExceeded.Error(), byName(resp)[CheckDatabase].Error)
+	})
+}
+
+func TestCheckWorker(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		last time.Time
+		err  string
+	}{
+		{name: "Success - Waiting for the next run", last: now.Add(-time.Minute)},
+		{name: "Success - Late within two intervals", last: now.Add(-2 * time.Minute)},
+		{name: "Error - Never started", err: "not running"},
+		{name: "Error - Stopped or hung", last: now.Add(-150 * time.Second), err: "no run for 2m30s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := checkWorker(fakeWorker{last: tt.last}, now)
+
+			require.NotNil(t, result.Worker)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.last.Format(time.RFC3339), result.Worker.LastBeatAt)
+				return
+			}
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+// fakeLeader is the leader election as seen by the replica id.
+type fakeLeader struct {
+	id, holder string
+}
+
+func (l fakeLeader) ID() string     { return l.id }
+func (l fakeLeader) IsLeader() bool { return l.holder == l.id }
+func (l fakeLeader) Holder() string { return l.holder }
+
+func TestReadinessService_CheckLeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		leader fakeLeader
+		want   admin.LeaderStatus
+		err    string
+	}{
+		{name: "Success - Replica leads", leader: fakeLeader{id: "replica-1", holder: "replica-1"},
+			want: admin.LeaderStatus{ID: "replica-1", Leader: true, Holder: "replica-1"}},
+		{name: "Success - Replica follows", leader: fakeLeader{id: "replica-2", holder: "replica-1"},
+			want: admin.LeaderStatus{ID: "replica-2", Holder: "replica-1"}},
+		{name: "Error - No leader known", leader: fakeLeader{id: "replica-2"},
+			want: admin.LeaderStatus{ID: "replica-2"}, err: "no leader elected"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewReadinessService(nil, nil, 22, config.Readiness{}, nil)
+			service.SetLeader(tt.leader)
+
+			result, err := service.checkLeader(context.Background())
+
+			assert.Equal(t, &tt.want, result.Leader)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
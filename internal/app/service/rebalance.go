@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dbctx"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/pullrequest"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// SuggestRebalance suggests up to req.Limit pending reviews of the user's active teammates to hand
+// over to the user, without applying them. A teammate is overloaded while they review at least two
+// open PRs more than the user, so a move narrows the gap without reversing it; the most loaded
+// teammate gives up a review first, in id order among the equally loaded, newest PR first. Reviews
+// already approved or with requested changes stay with their reviewer, and so do PRs the user
+// authored, reviews or is excluded from. It only reads and may be served by a replica.
+// Returns NOT_FOUND AppError when the user doesn't exist or is not active, and NOT_REVIEWER when
+// the user is outside the team's reviewer pool.
+func (s *PullRequestService) SuggestRebalance(ctx context.Context,
+	req pullrequest.RebalanceSuggestionsRequest) (*pullrequest.RebalanceSuggestionsResponse, error) {
+	ctx = dbctx.ReadOnly(ctx)
+	user, err := s.userRepo.FindByID(ctx, req.UserID)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find user",
+			slog.String("user_id", req.UserID), slog.String("error", err.Error()))
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.NewNotFound("user not found")
+	}
+	if !user.IsActive {
+		return nil, errors.NewNotFound("user is not active")
+	}
+	if user.NonReviewer {
+		return nil, errors.NewNotReviewer("user is not in the team's reviewer pool")
+	}
+
+	teammates, err := s.userRepo.FindActiveCandidatesForReassignment(ctx, user.TeamName, []string{user.Id})
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find teammates",
+			slog.String("team", user.TeamName), slog.String("error", err.Error()))
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(teammates)+1)
+	for _, teammate := range teammates {
+		userIDs = append(userIDs, teammate.Id)
+	}
+	counts, err := s.reviewerRepo.GetOpenReviewCounts(ctx, append(userIDs, user.Id))
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get open review counts",
+			slog.String("team", user.TeamName), slog.String("error", err.Error()))
+		return nil, err
+	}
+	response := &pullrequest.RebalanceSuggestionsResponse{
+		UserID:      user.Id,
+		TeamName:    user.TeamName,
+		OpenReviews: counts[user.Id],
+		Suggestions: []pullrequest.RebalanceSuggestion{},
+	}
+	if len(teammates) == 0 {
+		return response, nil
+	}
+
+	movable, err := s.findMovableReviews(ctx, user.Id, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	load := make(map[string]int, len(counts))
+	for id, count := range counts {
+		load[id] = count
+	}
+	for len(response.Suggestions) < req.Limit {
+		var from string
+		for _, teammate := range teammates {
+			if len(movable[teammate.Id]) > 0 && load[teammate.Id]-load[user.Id] >= 2 &&
+				(from == "" || load[teammate.Id] > load[from]) {
+				from = teammate.Id
+			}
+		}
+		if from == "" {
+			break
+		}
+		pr := movable[from][0]
+		response.Suggestions = append(response.Suggestions, pullrequest.RebalanceSuggestion{
+			PullRequestID:              pr.Id,
+			PullRequestName:            pr.Title,
+			CurrentReviewerID:          from,
+			CurrentReviewerOpenReviews: counts[from],
+			ProposedReviewerID:         user.Id,
+		})
+		load[from]--
+		load[user.Id]++
+		// the user can take a PR only once, whoever else reviews it
+		for id, prs := range movable {
+			movable[id] = removePR(prs, pr.Id)
+		}
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "rebalance suggested",
+		slog.String("user_id", user.Id),
+		slog.Int("suggestions", len(response.Suggestions)))
+	return response, nil
+}
+
+// findMovableReviews returns the open PRs, newest first, on which each of the reviewers has a
+// pending review the user could take over.
+func (s *PullRequestService) findMovableReviews(ctx context.Context, userID string,
+	reviewerIDs []string) (map[string][]*models.PullRequest, error) {
+	prs, err := s.prRepo.FindOpenPRsByReviewers(ctx, reviewerIDs)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find open PRs by reviewers",
+			slog.Int("count", len(reviewerIDs)), slog.String("error", err.Error()))
+		return nil, err
+	}
+	if len(prs) == 0 {
+		return nil, nil
+	}
+	prIDs := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		prIDs = append(prIDs, pr.Id)
+	}
+	assignments, err := s.reviewerRepo.GetAssignmentsByPRs(ctx, prIDs)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get assignments",
+			slog.Int("count", len(prIDs)), slog.String("error", err.Error()))
+		return nil, err
+	}
+	pending := make(map[string][]string, len(prs))
+	reviewedByUser := make(map[string]bool)
+	for _, a := range assignments {
+		switch {
+		case a.ReviewerId == userID:
+			reviewedByUser[a.PRId] = true
+		case a.State == models.ReviewStatePending:
+			pending[a.PRId] = append(pending[a.PRId], a.ReviewerId)
+		}
+	}
+
+	excludedBy := make(map[string]bool)
+	movable := make(map[string][]*models.PullRequest, len(reviewerIDs))
+	for _, pr := range prs {
+		if pr.AuthorId == userID || reviewedByUser[pr.Id] || len(pending[pr.Id]) == 0 {
+			continue
+		}
+		excluded, ok := excludedBy[pr.AuthorId]
+		if !ok {
+			excludedIDs, err := s.userRepo.GetExcludedReviewers(ctx, pr.AuthorId)
+			if err != nil {
+				s.log.LogAttrs(ctx, errorLevel(err), "failed to get excluded reviewers",
+					slog.String("author_id", pr.AuthorId), slog.String("error", err.Error()))
+				return nil, err
+			}
+			for _, id := range excludedIDs {
+				excluded = excluded || id == userID
+			}
+			excludedBy[pr.AuthorId] = excluded
+		}
+		if excluded {
+			continue
+		}
+		for _, reviewerID := range pending[pr.Id] {
+			movable[reviewerID] = append(movable[reviewerID], pr)
+		}
+	}
+	return movable, nil
+}
+
+// removePR returns prs without the PR with the id.
+func removePR(prs []*models.PullRequest, prID string) []*models.PullRequest {
+	for i, pr := range prs {
+		if pr.Id == prID {
+			return append(prs[:i:i], prs[i+1:]...)
+		}
+	}
+	return prs
+}
+
+// ApplyRebalance hands the review of a rebalance suggestion over to the proposed reviewer in one
+// transaction, with the same checks as naming the replacement on ReassignReviewer, and records the
+// change with the rebalance trigger.
+func (s *PullRequestService) ApplyRebalance(ctx context.Context,
+	req pullrequest.ApplyRebalanceRequest) (*pullrequest.ReassignReviewerResponse, error) {
+	return s.reassign(ctx, pullrequest.ReassignReviewerRequest{
+		PullRequestID: req.PullRequestID,
+		OldReviewerID: req.CurrentReviewerID,
+		NewReviewerID: req.ProposedReviewerID,
+	}, models.ReviewerChangeRebalance)
+}
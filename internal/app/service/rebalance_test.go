@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/pullrequest"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/shirr9/pr-reviewer-service/internal/infrastructure/persistence/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRebalanceService wires the PR service to in-memory storage with a team of u1-u5, where u5 is
+// back without reviews. u2 reviews pr-1 to pr-4 and u3 pr-1 and pr-5, created in that order; u2
+// approved pr-4 and pr-3 is by u5.
+func newRebalanceService(t *testing.T) *PullRequestService {
+	t.Helper()
+	env := newMemoryEnv()
+	env.team(t, "backend", "u1", "u2", "u3", "u4", "u5")
+
+	created := time.Now().UTC().Add(-time.Hour)
+	for i, pr := range []struct {
+		id        string
+		author    string
+		reviewers []string
+	}{
+		{"pr-1", "u1", []string{"u2", "u3"}},
+		{"pr-2", "u1", []string{"u2"}},
+		{"pr-3", "u5", []string{"u2"}},
+		{"pr-4", "u1", []string{"u2"}},
+		{"pr-5", "u1", []string{"u3"}},
+	} {
+		env.pr(t, pr.id, pr.author, created.Add(time.Duration(i)*time.Minute), pr.reviewers...)
+	}
+	require.NoError(t, env.reviewerRepo.SetReviewState(env.ctx, "pr-4", "u2", models.ReviewStateApproved,
+		time.Now().UTC()))
+
+	return env.prService()
+}
+
+func TestPullRequestService_SuggestRebalance(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - Pending reviews move from the most loaded teammate", func(t *testing.T) {
+		service := newRebalanceService(t)
+
+		resp, err := service.SuggestRebalance(ctx, pullrequest.RebalanceSuggestionsRequest{UserID: "u5", Limit: 5})
+
+		require.NoError(t, err)
+		assert.Equal(t, "backend", resp.TeamName)
+		assert.Zero(t, resp.OpenReviews)
+		// u2 gives up reviews until two ahead of u5 at most; u3 is not ahead enough
+		assert.Equal(t, []pullrequest.RebalanceSuggestion{
+			{PullRequestID: "pr-2", PullRequestName: "PR pr-2", CurrentReviewerID: "u2",
+				CurrentReviewerOpenReviews: 4, ProposedReviewerID: "u5"},
+			{PullRequestID: "pr-1", PullRequestName: "PR pr-1", CurrentReviewerID: "u2",
+				CurrentReviewerOpenReviews: 4, ProposedReviewerID: "u5"},
+		}, resp.Suggestions)
+	})
+
+	t.Run("Success - Limited suggestions", func(t *testing.T) {
+		service := newRebalanceService(t)
+
+		resp, err := service.SuggestRebalance(ctx, pullrequest.RebalanceSuggestionsRequest{UserID: "u5", Limit: 1})
+
+		require.NoError(t, err)
+		if assert.Len(t, resp.Suggestions, 1) {
+			assert.Equal(t, "pr-2", resp.Suggestions[0].PullRequestID)
+		}
+	})
+
+	t.Run("Success - Nothing to move onto a loaded user", func(t *testing.T) {
+		service := newRebalanceService(t)
+
+		resp, err := service.SuggestRebalance(ctx, pullrequest.RebalanceSuggestionsRequest{UserID: "u2", Limit: 5})
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, resp.OpenReviews)
+		assert.Empty(t, resp.Suggestions)
+	})
+
+	t.Run("Error - User not active", func(t *testing.T) {
+		service := newRebalanceService(t)
+		require.NoError(t, service.userRepo.(*memory.UserRepository).SetIsActive(ctx, "u5", false))
+
+		_, err := service.SuggestRebalance(ctx, pullrequest.RebalanceSuggestionsRequest{UserID: "u5", Limit: 5})
+
+		assert.True(t, errors.HasCode(err, errors.CodeNotFound))
+	})
+}
+
+func TestPullRequestService_ApplyRebalance(t *testing.T) {
+	ctx := context.Background()
+	service := newRebalanceService(t)
+	req := pullrequest.ApplyRebalanceRequest{PullRequestID: "pr-2", CurrentReviewerID: "u2", ProposedReviewerID: "u5"}
+
+	resp, err := service.ApplyRebalance(ctx, req)
+
+	require.NoError(t, err)
+	assert.Equal(t, "u5", resp.ReplacedBy)
+	assert.Equal(t, []string{"u5"}, resp.Pr.AssignedReviewers)
+	history, err := service.GetHistory(ctx, "pr-2")
+	require.NoError(t, err)
+	if assert.Len(t, history.History, 1) {
+		assert.Equal(t, models.ReviewerChangeRebalance, history.History[0].Trigger)
+	}
+
+	_, err = service.ApplyRebalance(ctx, req)
+	assert.True(t, errors.HasCode(err, errors.CodeNotAssigned), "an applied suggestion is not applied again")
+}
@@ -0,0 +1,93 @@
+package service
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mocks/mock_reviewer_details_deps.go -package=mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/pullrequest"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// ReviewerLookupRepository defines the interface for loading reviewer details.
+type ReviewerLookupRepository interface {
+	FindByIDs(ctx context.Context, userIDs []string) ([]*models.User, error)
+}
+
+// AssignmentLookupRepository defines the interface for loading when reviewers were assigned and their review states.
+type AssignmentLookupRepository interface {
+	GetAssignmentsByPRs(ctx context.Context, prIDs []string) ([]*models.ReviewAssignment, error)
+}
+
+// expandReviewers fills Reviewers of the PR DTOs from their AssignedReviewers using a single user lookup
+// and a single assignment lookup; deadline is the review allowance used for the deadline and overdue fields.
+// Reviewers are kept in the AssignedReviewers order; unknown ids are skipped.
+func expandReviewers(ctx context.Context, users ReviewerLookupRepository, assignments AssignmentLookupRepository,
+	deadline time.Duration, prs ...*pullrequest.PR) error {
+	var ids, prIDs []string
+	seen := make(map[string]bool)
+	for _, pr := range prs {
+		if len(pr.AssignedReviewers) > 0 {
+			prIDs = append(prIDs, pr.PullRequestID)
+		}
+		for _, id := range pr.AssignedReviewers {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	found, err := users.FindByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	byID := make(map[string]*models.User, len(found))
+	for _, u := range found {
+		byID[u.Id] = u
+	}
+
+	assigned, err := assignments.GetAssignmentsByPRs(ctx, prIDs)
+	if err != nil {
+		return err
+	}
+	byPR := make(map[string]map[string]*models.ReviewAssignment, len(prIDs))
+	for _, a := range assigned {
+		if byPR[a.PRId] == nil {
+			byPR[a.PRId] = make(map[string]*models.ReviewAssignment)
+		}
+		byPR[a.PRId][a.ReviewerId] = a
+	}
+
+	now := time.Now().UTC()
+	for _, pr := range prs {
+		pr.Reviewers = make([]pullrequest.Reviewer, 0, len(pr.AssignedReviewers))
+		for _, id := range pr.AssignedReviewers {
+			u, ok := byID[id]
+			if !ok {
+				continue
+			}
+			reviewer := pullrequest.Reviewer{
+				UserID:   u.Id,
+				Username: u.Name,
+				TeamName: u.TeamName,
+				IsActive: u.IsActive,
+			}
+			if a, ok := byPR[pr.PullRequestID][id]; ok {
+				reviewer.AssignedAt = dto.FormatTime(a.AssignedAt)
+				reviewer.Source = a.Source
+				reviewer.Deadline = dto.FormatTime(models.ReviewDeadline(a.AssignedAt, deadline))
+				reviewer.Overdue = pr.Status == models.PRStatusOpen && models.IsOverdue(a.AssignedAt, deadline, now)
+				reviewer.State = a.State
+				reviewer.StateChangedAt = dto.FormatTimePtr(a.StateChangedAt)
+			}
+			pr.Reviewers = append(pr.Reviewers, reviewer)
+		}
+	}
+	return nil
+}
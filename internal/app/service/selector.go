@@ -0,0 +1,192 @@
+package service
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mocks/mock_selector_deps.go -package=mocks
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// SelectorUserRepository defines the interface for finding reviewer candidates.
+type SelectorUserRepository interface {
+	FindActiveCandidatesForReassignment(ctx context.Context, teamName string, excludeUserIDs []string) ([]*models.User, error)
+}
+
+// SelectorReviewerRepository defines the interface for reading the current review load.
+type SelectorReviewerRepository interface {
+	GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error)
+	GetAssignmentTimes(ctx context.Context, userIDs []string, since time.Time) (map[string][]time.Time, error)
+}
+
+// RankedCandidate is a possible reviewer together with the number of open PRs they review.
+// Capacity is zero when the user has no limit; Weight is set only by the weighted strategy.
+// MatchesTags is set when the user has one of the tags required by the PR.
+type RankedCandidate struct {
+	User           *models.User
+	OpenReviews    int
+	Weight         float64
+	Capacity       int
+	AtCapacity     bool
+	MatchesTags    bool
+	InWorkingHours bool
+}
+
+// Selection is the outcome of choosing reviewers for a PR.
+// Overloaded lists the chosen reviewers who were already at capacity,
+// TagMatched the chosen reviewers who have one of the required tags,
+// Pinned the chosen reviewers the request named rather than the selector.
+type Selection struct {
+	ReviewerIDs []string
+	Overloaded  []string
+	TagMatched  []string
+	Pinned      []string
+}
+
+// source returns how the chosen reviewer was assigned.
+func (s *Selection) source(reviewerID string) string {
+	if slices.Contains(s.Pinned, reviewerID) {
+		return models.AssignmentSourceManual
+	}
+	return models.AssignmentSourceAuto
+}
+
+// ReviewerSelector chooses reviewers for new PRs: active members of the author's team,
+// excluding the given users, least loaded first with ties broken by user id.
+// When the PR requires tags, users having any of them go first and the rest of the team
+// is used as a fallback.
+// The load is the number of open reviews, or with the weighted strategy the decayed
+// number of recent assignments, falling back to open reviews on equal weights.
+// Among equally loaded users those currently in their working hours go first.
+// Users at capacity are skipped for non-urgent PRs; if the whole team is at capacity,
+// the least loaded user is chosen anyway and reported as overloaded.
+type ReviewerSelector struct {
+	userRepo     SelectorUserRepository
+	reviewerRepo SelectorReviewerRepository
+	review       config.Review
+}
+
+// NewReviewerSelector creates a new reviewer selector for the review policy.
+func NewReviewerSelector(userRepo SelectorUserRepository, reviewerRepo SelectorReviewerRepository,
+	review config.Review) *ReviewerSelector {
+	return &ReviewerSelector{
+		userRepo:     userRepo,
+		reviewerRepo: reviewerRepo,
+		review:       review,
+	}
+}
+
+// Rank returns the candidates from the team for a PR of the given priority and normalized
+// required tags in selection order. For non-urgent PRs users at capacity come after everyone else.
+func (s *ReviewerSelector) Rank(ctx context.Context, teamName string, excludeUserIDs []string,
+	priority string, requiredTags []string) ([]RankedCandidate, error) {
+	users, err := s.userRepo.FindActiveCandidatesForReassignment(ctx, teamName, excludeUserIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return []RankedCandidate{}, nil
+	}
+
+	userIDs := make([]string, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.Id)
+	}
+	counts, err := s.reviewerRepo.GetOpenReviewCounts(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	weighted := s.review.Strategy == config.StrategyWeighted
+	var assignedAt map[string][]time.Time
+	now := time.Now().UTC()
+	if weighted {
+		assignedAt, err = s.reviewerRepo.GetAssignmentTimes(ctx, userIDs, now.Add(-models.WeightWindow))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ranked := make([]RankedCandidate, 0, len(users))
+	for _, user := range users {
+		capacity := user.ReviewCapacity(s.review.MaxActiveReviews)
+		candidate := RankedCandidate{
+			User:           user,
+			OpenReviews:    counts[user.Id],
+			Capacity:       capacity,
+			AtCapacity:     atCapacity(counts[user.Id], capacity),
+			MatchesTags:    user.HasAnyTag(requiredTags),
+			InWorkingHours: user.InWorkingHours(now),
+		}
+		if weighted {
+			candidate.Weight = models.ReviewWeight(assignedAt[user.Id], now, s.review.WeightHalfLife)
+		}
+		ranked = append(ranked, candidate)
+	}
+	urgent := priority == models.PRPriorityUrgent
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if !urgent && ranked[i].AtCapacity != ranked[j].AtCapacity {
+			return !ranked[i].AtCapacity
+		}
+		if ranked[i].MatchesTags != ranked[j].MatchesTags {
+			return ranked[i].MatchesTags
+		}
+		if ranked[i].Weight != ranked[j].Weight {
+			return ranked[i].Weight < ranked[j].Weight
+		}
+		if ranked[i].OpenReviews != ranked[j].OpenReviews {
+			return ranked[i].OpenReviews < ranked[j].OpenReviews
+		}
+		if ranked[i].InWorkingHours != ranked[j].InWorkingHours {
+			return ranked[i].InWorkingHours
+		}
+		return ranked[i].User.Id < ranked[j].User.Id
+	})
+	return ranked, nil
+}
+
+// Select chooses at most count reviewers for a PR of the given priority and required tags.
+func (s *ReviewerSelector) Select(ctx context.Context, teamName string, excludeUserIDs []string,
+	priority string, requiredTags []string, count int) (*Selection, error) {
+	ranked, err := s.Rank(ctx, teamName, excludeUserIDs, priority, requiredTags)
+	if err != nil {
+		return nil, err
+	}
+
+	chosen := ranked
+	if priority != models.PRPriorityUrgent {
+		available := 0
+		for available < len(ranked) && !ranked[available].AtCapacity {
+			available++
+		}
+		chosen = ranked[:available]
+		if available == 0 && len(ranked) > 0 {
+			// everyone is at capacity: overload the least loaded instead of leaving the PR unassigned
+			chosen = ranked[:1]
+		}
+	}
+	if len(chosen) > count {
+		chosen = chosen[:count]
+	}
+
+	selection := &Selection{ReviewerIDs: make([]string, 0, len(chosen))}
+	for _, candidate := range chosen {
+		selection.ReviewerIDs = append(selection.ReviewerIDs, candidate.User.Id)
+		if candidate.AtCapacity {
+			selection.Overloaded = append(selection.Overloaded, candidate.User.Id)
+		}
+		if candidate.MatchesTags {
+			selection.TagMatched = append(selection.TagMatched, candidate.User.Id)
+		}
+	}
+	return selection, nil
+}
+
+// atCapacity reports whether a user with the given capacity can't take more reviews.
+func atCapacity(openReviews, capacity int) bool {
+	return capacity > 0 && openReviews >= capacity
+}
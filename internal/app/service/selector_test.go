@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewerSelector(t *testing.T) {
+	store := newFakeStore(
+		&models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+		&models.User{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+		&models.User{Id: "u3", Name: "Carol", TeamName: "backend", IsActive: true},
+		&models.User{Id: "u4", Name: "Dave", TeamName: "backend", IsActive: true},
+		&models.User{Id: "u5", Name: "Eve", TeamName: "backend", IsActive: false},
+		&models.User{Id: "f1", Name: "Frank", TeamName: "frontend", IsActive: true},
+	)
+	store.prs["pr-1"] = &models.PullRequest{Id: "pr-1", AuthorId: "u1", Status: models.PRStatusOpen}
+	store.prs["pr-2"] = &models.PullRequest{Id: "pr-2", AuthorId: "u1", Status: models.PRStatusOpen}
+	store.prs["pr-3"] = &models.PullRequest{Id: "pr-3", AuthorId: "u1", Status: models.PRStatusMerged}
+	store.reviewers["pr-1"] = []string{"u2", "u3"}
+	store.reviewers["pr-2"] = []string{"u2"}
+	store.reviewers["pr-3"] = []string{"u4"}
+	selector := NewReviewerSelector(store, store, config.Review{})
+
+	t.Run("Success - Least loaded first, merged PRs don't count", func(t *testing.T) {
+		ranked, err := selector.Rank(context.Background(), "backend", []string{"u1"}, models.PRPriorityNormal, nil)
+
+		assert.NoError(t, err)
+		ids := make([]string, 0, len(ranked))
+		loads := make([]int, 0, len(ranked))
+		for _, c := range ranked {
+			ids = append(ids, c.User.Id)
+			loads = append(loads, c.OpenReviews)
+		}
+		assert.Equal(t, []string{"u4", "u3", "u2"}, ids)
+		assert.Equal(t, []int{0, 1, 2}, loads)
+	})
+
+	t.Run("Success - Select limits the count", func(t *testing.T) {
+		selection, err := selector.Select(context.Background(), "backend", []string{"u1", "u4"}, models.PRPriorityNormal, nil, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u3", "u2"}, selection.ReviewerIDs)
+		assert.Empty(t, selection.Overloaded)
+	})
+
+	t.Run("Success - No candidates", func(t *testing.T) {
+		ranked, err := selector.Rank(context.Background(), "frontend", []string{"f1"}, models.PRPriorityNormal, nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, ranked)
+	})
+
+	t.Run("Success - Capacity applies to non-urgent PRs only", func(t *testing.T) {
+		capped := NewReviewerSelector(store, store, config.Review{MaxActiveReviews: 2})
+
+		normal, err := capped.Select(context.Background(), "backend", []string{"u1", "u4"}, models.PRPriorityHigh, nil, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u3"}, normal.ReviewerIDs)
+		assert.Empty(t, normal.Overloaded)
+
+		urgent, err := capped.Select(context.Background(), "backend", []string{"u1", "u4"}, models.PRPriorityUrgent, nil, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u3", "u2"}, urgent.ReviewerIDs)
+		assert.Equal(t, []string{"u2"}, urgent.Overloaded)
+	})
+
+	t.Run("Success - Falls back to the least loaded when everyone is at capacity", func(t *testing.T) {
+		capped := NewReviewerSelector(store, store, config.Review{MaxActiveReviews: 1})
+
+		selection, err := capped.Select(context.Background(), "backend", []string{"u1", "u4"}, models.PRPriorityNormal, nil, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u3"}, selection.ReviewerIDs)
+		assert.Equal(t, []string{"u3"}, selection.Overloaded)
+	})
+
+	t.Run("Success - Per-user capacity overrides the default", func(t *testing.T) {
+		limit := 1
+		overridden := newFakeStore(
+			&models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+			&models.User{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true, MaxActiveReviews: &limit},
+			&models.User{Id: "u3", Name: "Carol", TeamName: "backend", IsActive: true},
+		)
+		overridden.prs["pr-1"] = &models.PullRequest{Id: "pr-1", AuthorId: "u1", Status: models.PRStatusOpen}
+		overridden.reviewers["pr-1"] = []string{"u2"}
+		overridden.prs["pr-2"] = &models.PullRequest{Id: "pr-2", AuthorId: "u1", Status: models.PRStatusOpen}
+		overridden.reviewers["pr-2"] = []string{"u3"}
+
+		ranked, err := NewReviewerSelector(overridden, overridden, config.Review{MaxActiveReviews: 5}).Rank(context.Background(), "backend",
+			[]string{"u1"}, models.PRPriorityNormal, nil)
+		assert.NoError(t, err)
+		assert.Len(t, ranked, 2)
+		assert.Equal(t, "u3", ranked[0].User.Id)
+		assert.Equal(t, 5, ranked[0].Capacity)
+		assert.False(t, ranked[0].AtCapacity)
+		assert.Equal(t, "u2", ranked[1].User.Id)
+		assert.Equal(t, 1, ranked[1].Capacity)
+		assert.True(t, ranked[1].AtCapacity)
+	})
+}
+
+func TestReviewerSelector_Weighted(t *testing.T) {
+	store := newFakeStore(
+		&models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+		&models.User{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+		&models.User{Id: "u3", Name: "Carol", TeamName: "backend", IsActive: true},
+		&models.User{Id: "u4", Name: "Dave", TeamName: "backend", IsActive: true},
+	)
+	now := time.Now().UTC()
+	// u2 reviewed a lot last week, all merged by now; u3 holds one fresh open review
+	for i, prID := range []string{"pr-1", "pr-2", "pr-3"} {
+		store.prs[prID] = &models.PullRequest{Id: prID, AuthorId: "u1", Status: models.PRStatusMerged}
+		store.reviewers[prID] = []string{"u2"}
+		store.assignedAt[[2]string{prID, "u2"}] = now.Add(-time.Duration(5+i) * 24 * time.Hour)
+	}
+	store.prs["pr-4"] = &models.PullRequest{Id: "pr-4", AuthorId: "u1", Status: models.PRStatusOpen}
+	store.reviewers["pr-4"] = []string{"u3"}
+	store.assignedAt[[2]string{"pr-4", "u3"}] = now.Add(-time.Hour)
+	// u4 was assigned before the window only
+	store.prs["pr-5"] = &models.PullRequest{Id: "pr-5", AuthorId: "u1", Status: models.PRStatusMerged}
+	store.reviewers["pr-5"] = []string{"u4"}
+	store.assignedAt[[2]string{"pr-5", "u4"}] = now.Add(-models.WeightWindow - time.Hour)
+
+	t.Run("Success - Least loaded ignores history", func(t *testing.T) {
+		selector := NewReviewerSelector(store, store, config.Review{Strategy: config.StrategyLeastLoaded})
+
+		ranked, err := selector.Rank(context.Background(), "backend", []string{"u1"}, models.PRPriorityNormal, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u2", "u4", "u3"}, rankedIDs(ranked))
+	})
+
+	t.Run("Success - Weighted prefers the lightest recent history", func(t *testing.T) {
+		selector := NewReviewerSelector(store, store, config.Review{
+			Strategy: config.StrategyWeighted, WeightHalfLife: 7 * 24 * time.Hour,
+		})
+
+		ranked, err := selector.Rank(context.Background(), "backend", []string{"u1"}, models.PRPriorityNormal, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u4", "u3", "u2"}, rankedIDs(ranked))
+		assert.Zero(t, ranked[0].Weight)
+		assert.InDelta(t, 1, ranked[1].Weight, 0.01)
+		assert.Greater(t, ranked[2].Weight, ranked[1].Weight)
+	})
+}
+
+func TestReviewerSelector_WorkingHours(t *testing.T) {
+	now := time.Now().UTC()
+	clock := func(offset time.Duration) string { return now.Add(offset).Format("15:04") }
+	store := newFakeStore(
+		&models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+		&models.User{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true,
+			Timezone: "UTC", WorkStart: clock(2 * time.Hour), WorkEnd: clock(3 * time.Hour)},
+		&models.User{Id: "u3", Name: "Carol", TeamName: "backend", IsActive: true,
+			Timezone: "UTC", WorkStart: clock(-time.Hour), WorkEnd: clock(time.Hour)},
+		&models.User{Id: "u4", Name: "Dave", TeamName: "backend", IsActive: true},
+		&models.User{Id: "u5", Name: "Eve", TeamName: "backend", IsActive: true,
+			Timezone: "UTC", WorkStart: clock(2 * time.Hour), WorkEnd: clock(3 * time.Hour)},
+	)
+	store.prs["pr-1"] = &models.PullRequest{Id: "pr-1", AuthorId: "u1", Status: models.PRStatusOpen}
+	store.reviewers["pr-1"] = []string{"u3", "u4"}
+	selector := NewReviewerSelector(store, store, config.Review{})
+
+	t.Run("Success - Working hours break ties after load", func(t *testing.T) {
+		ranked, err := selector.Rank(context.Background(), "backend", []string{"u1"}, models.PRPriorityNormal, nil)
+
+		assert.NoError(t, err)
+		// u2 and u5 are idle but off hours; u3 and u4 are in hours but busier
+		assert.Equal(t, []string{"u2", "u5", "u3", "u4"}, rankedIDs(ranked))
+
+		store.reviewers["pr-1"] = nil
+		ranked, err = selector.Rank(context.Background(), "backend", []string{"u1"}, models.PRPriorityNormal, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u3", "u4", "u2", "u5"}, rankedIDs(ranked))
+		assert.True(t, ranked[1].InWorkingHours, "no timezone means always available")
+		assert.False(t, ranked[2].InWorkingHours)
+	})
+}
+
+func rankedIDs(ranked []RankedCandidate) []string {
+	ids := make([]string, 0, len(ranked))
+	for _, c := range ranked {
+		ids = append(ids, c.User.Id)
+	}
+	return ids
+}
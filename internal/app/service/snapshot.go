@@ -0,0 +1,206 @@
+package service
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mocks/mock_snapshot_deps.go -package=mocks
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/dbctx"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/statistics"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// SnapshotRepository defines the interface for persistence of the daily statistics snapshots.
+type SnapshotRepository interface {
+	SaveSnapshots(ctx context.Context, snapshots []*models.StatisticsSnapshot) error
+	HasSnapshot(ctx context.Context, day time.Time) (bool, error)
+	FindSnapshots(ctx context.Context, from, to time.Time) ([]*models.StatisticsSnapshot, error)
+}
+
+// SnapshotUserRepository defines the interface for finding the teams to take snapshots of.
+type SnapshotUserRepository interface {
+	GetAllUsers(ctx context.Context) ([]*models.User, error)
+}
+
+// SnapshotStatistics defines the interface for computing the statistics a snapshot keeps.
+type SnapshotStatistics interface {
+	GetStatistics(ctx context.Context, req statistics.StatisticsRequest) (*statistics.StatisticsResponse, error)
+}
+
+// SnapshotService keeps the key aggregates of the statistics, of all PRs and of every team, once a day,
+// so their history can be read back after the PRs changed.
+type SnapshotService struct {
+	snapshotRepo SnapshotRepository
+	userRepo     SnapshotUserRepository
+	stats        SnapshotStatistics
+	log          *slog.Logger
+}
+
+// NewSnapshotService creates a new snapshot service.
+func NewSnapshotService(
+	snapshotRepo SnapshotRepository,
+	userRepo SnapshotUserRepository,
+	stats SnapshotStatistics,
+	log *slog.Logger,
+) *SnapshotService {
+	if log == nil {
+		log = slog.Default()
+	}
+	return &SnapshotService{
+		snapshotRepo: snapshotRepo,
+		userRepo:     userRepo,
+		stats:        stats,
+		log:          log,
+	}
+}
+
+// TakeSnapshot computes the snapshot of today, replacing the one already taken today.
+func (s *SnapshotService) TakeSnapshot(ctx context.Context) (*statistics.DailySnapshot, error) {
+	now := time.Now().UTC()
+	day := models.BucketStart(now, models.ActivityBucketDay)
+
+	teams, err := s.snapshotTeams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	snapshots := make([]*models.StatisticsSnapshot, 0, len(teams)+1)
+	// the snapshot of all teams comes first
+	for _, teamName := range append([]string{""}, teams...) {
+		var response *statistics.StatisticsResponse
+		if response, err = s.stats.GetStatistics(ctx, statistics.StatisticsRequest{TeamName: teamName}); err != nil {
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to compute statistics snapshot",
+				slog.String("team_name", teamName), slog.String("error", err.Error()))
+			return nil, err
+		}
+		snapshots = append(snapshots, &models.StatisticsSnapshot{
+			Day:                     day,
+			TeamName:                teamName,
+			TotalPRs:                response.TotalPRs,
+			OpenPRs:                 response.OpenPRs,
+			MergedPRs:               response.MergedPRs,
+			TotalAssignments:        response.TotalAssignments,
+			ReassignmentEvents:      response.ReassignmentEvents,
+			OpenPRsWithoutReviewers: response.OpenPRsWithoutReviewers,
+			TakenAt:                 now,
+		})
+	}
+
+	if err = s.snapshotRepo.SaveSnapshots(ctx, snapshots); err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to save statistics snapshot",
+			slog.Time("day", day), slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "statistics snapshot taken",
+		slog.Time("day", day),
+		slog.Int("teams", len(teams)))
+
+	return &dailySnapshots(snapshots)[0], nil
+}
+
+// TakeDailySnapshot takes the snapshot of today unless it was already taken, reporting whether it did.
+func (s *SnapshotService) TakeDailySnapshot(ctx context.Context) (bool, error) {
+	day := models.BucketStart(time.Now(), models.ActivityBucketDay)
+	taken, err := s.snapshotRepo.HasSnapshot(ctx, day)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to check statistics snapshot",
+			slog.Time("day", day), slog.String("error", err.Error()))
+		return false, err
+	}
+	if taken {
+		return false, nil
+	}
+	if _, err = s.TakeSnapshot(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// snapshotTeams returns the names of the teams with members, in alphabetical order.
+func (s *SnapshotService) snapshotTeams(ctx context.Context) ([]string, error) {
+	users, err := s.userRepo.GetAllUsers(dbctx.ReadOnly(ctx))
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get users", slog.String("error", err.Error()))
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var teams []string
+	for _, user := range users {
+		if !seen[user.TeamName] {
+			seen[user.TeamName] = true
+			teams = append(teams, user.TeamName)
+		}
+	}
+	sort.Strings(teams)
+	return teams, nil
+}
+
+// GetHistory returns the snapshots of the days starting within the range, in order.
+// Statistics history is read-only and may be served by a replica.
+func (s *SnapshotService) GetHistory(ctx context.Context,
+	req statistics.HistoryRequest) (*statistics.HistoryResponse, error) {
+	ctx = dbctx.ReadOnly(ctx)
+	if !req.To.After(req.From) {
+		return nil, errors.NewValidation("to must be after from")
+	}
+
+	snapshots, err := s.snapshotRepo.FindSnapshots(ctx, nextDayStart(req.From), nextDayStart(req.To))
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find statistics snapshots",
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	response := &statistics.HistoryResponse{
+		From:      dto.FormatTime(req.From),
+		To:        dto.FormatTime(req.To),
+		Snapshots: dailySnapshots(snapshots),
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "statistics history retrieved",
+		slog.Int("days", len(response.Snapshots)))
+
+	return response, nil
+}
+
+// nextDayStart returns t when it starts a day in UTC, otherwise the start of the following day.
+func nextDayStart(t time.Time) time.Time {
+	day := models.BucketStart(t, models.ActivityBucketDay)
+	if day.Before(t) {
+		return models.NextBucketStart(day, models.ActivityBucketDay)
+	}
+	return day
+}
+
+// dailySnapshots groups the snapshots, ordered by day and team name, into one entry per day.
+func dailySnapshots(snapshots []*models.StatisticsSnapshot) []statistics.DailySnapshot {
+	days := make([]statistics.DailySnapshot, 0)
+	for _, snapshot := range snapshots {
+		aggregates := statistics.SnapshotAggregates{
+			TotalPRs:                snapshot.TotalPRs,
+			OpenPRs:                 snapshot.OpenPRs,
+			MergedPRs:               snapshot.MergedPRs,
+			TotalAssignments:        snapshot.TotalAssignments,
+			ReassignmentEvents:      snapshot.ReassignmentEvents,
+			OpenPRsWithoutReviewers: snapshot.OpenPRsWithoutReviewers,
+		}
+		day := dto.FormatTime(snapshot.Day)
+		if len(days) == 0 || days[len(days)-1].Day != day {
+			days = append(days, statistics.DailySnapshot{Day: day, Teams: []statistics.TeamSnapshot{}})
+		}
+		current := &days[len(days)-1]
+		if snapshot.TeamName == "" {
+			current.TakenAt = dto.FormatTime(snapshot.TakenAt)
+			current.SnapshotAggregates = aggregates
+			continue
+		}
+		current.Teams = append(current.Teams,
+			statistics.TeamSnapshot{TeamName: snapshot.TeamName, SnapshotAggregates: aggregates})
+	}
+	return days
+}
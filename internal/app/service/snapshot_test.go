@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/statistics"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/shirr9/pr-reviewer-service/internal/infrastructure/persistence/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotService(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	ctx := context.Background()
+	storage := memory.NewStorage()
+	teamRepo := storage.NewTeamRepository()
+	for team, userIDs := range map[string][]string{"backend": {"u1", "u2"}, "frontend": {"f1"}} {
+		members := make([]*models.User, 0, len(userIDs))
+		for _, userID := range userIDs {
+			members = append(members, &models.User{Id: userID, Name: userID, TeamName: team, IsActive: true})
+		}
+		require.NoError(t, teamRepo.CreateTeam(ctx, &models.Team{Members: members}))
+	}
+	now := time.Now().UTC()
+	prs := []*models.PullRequest{
+		{Id: "pr-1", AuthorId: "u1", Status: models.PRStatusOpen, CreatedAt: now.Add(-time.Hour)},
+		{Id: "pr-2", AuthorId: "f1", Status: models.PRStatusOpen, CreatedAt: now.Add(-time.Hour)},
+	}
+	for _, pr := range prs {
+		pr.Title, pr.Priority = pr.Id, models.PRPriorityNormal
+	}
+	dump := storage.NewDumpRepository()
+	require.NoError(t, dump.InsertPullRequests(ctx, prs))
+	require.NoError(t, dump.InsertAssignments(ctx, []*models.ReviewAssignment{{PRId: "pr-1", ReviewerId: "u2",
+		AssignedAt: now.Add(-time.Hour), Source: models.AssignmentSourceAuto, State: models.ReviewStatePending}}))
+
+	stats := NewStatisticsService(storage.NewUserRepository(), storage.NewPullRequestRepository(),
+		storage.NewReviewerRepository(), config.Statistics{}, testReview, logger)
+	snapshotRepo := storage.NewSnapshotRepository()
+	service := NewSnapshotService(snapshotRepo, storage.NewUserRepository(), stats, logger)
+	today := models.BucketStart(now, models.ActivityBucketDay)
+
+	t.Run("Success - Daily snapshot is taken once", func(t *testing.T) {
+		taken, err := service.TakeDailySnapshot(ctx)
+		require.NoError(t, err)
+		assert.True(t, taken)
+
+		taken, err = service.TakeDailySnapshot(ctx)
+		require.NoError(t, err)
+		assert.False(t, taken)
+	})
+
+	t.Run("Success - Snapshot on demand replaces the one of the day", func(t *testing.T) {
+		require.NoError(t, dump.InsertPullRequests(ctx, []*models.PullRequest{{Id: "pr-3", Title: "pr-3",
+			AuthorId: "u2", Status: models.PRStatusOpen, Priority: models.PRPriorityNormal, CreatedAt: now}}))
+
+		snapshot, err := service.TakeSnapshot(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, today.Format(time.RFC3339), snapshot.Day)
+		assert.Equal(t, statistics.SnapshotAggregates{TotalPRs: 3, OpenPRs: 3, TotalAssignments: 1,
+			OpenPRsWithoutReviewers: 2}, snapshot.SnapshotAggregates)
+		assert.Equal(t, []statistics.TeamSnapshot{
+			{TeamName: "backend", SnapshotAggregates: statistics.SnapshotAggregates{TotalPRs: 2, OpenPRs: 2,
+				TotalAssignments: 1, OpenPRsWithoutReviewers: 1}},
+			{TeamName: "frontend", SnapshotAggregates: statistics.SnapshotAggregates{TotalPRs: 1, OpenPRs: 1,
+				OpenPRsWithoutReviewers: 1}},
+		}, snapshot.Teams)
+	})
+
+	t.Run("Success - History lists the days of the range", func(t *testing.T) {
+		earlier := []*models.StatisticsSnapshot{
+			{Day: today.AddDate(0, 0, -2), TotalPRs: 1, TakenAt: today.AddDate(0, 0, -2)},
+			{Day: today.AddDate(0, 0, -9), TotalPRs: 9, TakenAt: today.AddDate(0, 0, -9)},
+		}
+		require.NoError(t, snapshotRepo.SaveSnapshots(ctx, earlier))
+
+		resp, err := service.GetHistory(ctx, statistics.HistoryRequest{
+			From: today.AddDate(0, 0, -3).Add(time.Hour), To: now,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Snapshots, 2)
+		assert.Equal(t, today.AddDate(0, 0, -2).Format(time.RFC3339), resp.Snapshots[0].Day)
+		assert.Equal(t, 1, resp.Snapshots[0].TotalPRs)
+		assert.Empty(t, resp.Snapshots[0].Teams)
+		assert.Equal(t, today.Format(time.RFC3339), resp.Snapshots[1].Day)
+		assert.Len(t, resp.Snapshots[1].Teams, 2)
+	})
+
+	t.Run("Error - Range ends before it starts", func(t *testing.T) {
+		_, err := service.GetHistory(ctx, statistics.HistoryRequest{From: now, To: now.Add(-time.Hour)})
+
+		assert.True(t, errors.HasCode(err, errors.CodeValidation))
+	})
+}
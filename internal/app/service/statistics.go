@@ -1,31 +1,105 @@
 package service
 
+//go:generate go tool mockgen -source=$GOFILE -destination=mocks/mock_statistics_deps.go -package=mocks
+
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"math"
+	"sort"
+	"strconv"
+	"time"
 
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dbctx"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
 	"github.com/shirr9/pr-reviewer-service/internal/app/dto/statistics"
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/errors"
 	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"golang.org/x/sync/singleflight"
 )
 
+// distributionLastBucket is the last bucket of the review load histograms, holding it and every larger count.
+const distributionLastBucket = 5
+
+// prsWithoutReviewersLimit is the number of open PRs without reviewers listed by the statistics.
+const prsWithoutReviewersLimit = 20
+
+// recentAssignmentsLimit is the number of latest assignments listed by the statistics of a user.
+const recentAssignmentsLimit = 10
+
+// defaultMaxTimeseriesBuckets caps the buckets of a time series when the configuration doesn't.
+const defaultMaxTimeseriesBuckets = 366
+
+// statisticsFlightKey identifies the statistics of the organization of ctx by their sections and filters.
+func statisticsFlightKey(ctx context.Context, req statistics.StatisticsRequest) string {
+	key := "statistics:" + strconv.Quote(orgctx.ID(ctx))
+	if req.Include.UserStats {
+		key += ":" + statistics.SectionUserStats
+	}
+	if req.Include.PRStats {
+		key += ":" + statistics.SectionPRStats
+	}
+	if req.Include.TeamStats {
+		key += ":" + statistics.SectionTeamStats
+	}
+	if req.Include.AuthorStats {
+		key += ":" + statistics.SectionAuthorStats
+	}
+	if req.IncludeArchived {
+		key += ":archived"
+	}
+	if req.TeamName != "" {
+		key += ":team:" + req.TeamName
+	}
+	return key
+}
+
 type StatisticsUserRepository interface {
 	GetAllUsers(ctx context.Context) ([]*models.User, error)
+	FindByID(ctx context.Context, userID string) (*models.User, error)
+	FindByTeamName(ctx context.Context, teamName string) ([]*models.User, error)
 }
 
 type StatisticsPRRepository interface {
-	GetAllPRs(ctx context.Context) ([]*models.PullRequest, error)
+	GetAllPRs(ctx context.Context, teamName string) ([]*models.PullRequest, error)
+	GetArchivedPRs(ctx context.Context, teamName string) ([]*models.PullRequest, error)
+	GetActivity(ctx context.Context, teamName, bucket string, from, to time.Time) ([]*models.ActivityBucket, error)
+	FindOpenWithoutReviewers(ctx context.Context, teamName string, labels []string, skipped *bool, order models.PRSort,
+		limit, offset int) ([]*models.PullRequest, error)
+	CountOpenWithoutReviewers(ctx context.Context, teamName string, labels []string, skipped *bool) (int, error)
+	GetAuthorStats(ctx context.Context, teamName string, includeArchived bool) ([]*models.AuthorStats, error)
+	GetAuthorStatsByID(ctx context.Context, authorID string, includeArchived bool) (*models.AuthorStats, error)
 }
 
 type StatisticsReviewerRepository interface {
-	GetReviewers(ctx context.Context, prID string) ([]string, error)
-	GetAllReviewerCounts(ctx context.Context) (map[string]int, error)
+	GetAllReviewers(ctx context.Context, teamName string) (map[string][]string, error)
+	GetArchivedReviewers(ctx context.Context, teamName string) (map[string][]string, error)
+	GetAllReviewerCounts(ctx context.Context, teamName string) (map[string]int, error)
 	GetPRsByReviewer(ctx context.Context, reviewerID string) ([]string, error)
+	GetReassignmentCounts(ctx context.Context, teamName string, includeArchived bool) (map[string]int, error)
+	CountReviewerChanges(ctx context.Context, teamName string, includeArchived bool) (int, error)
+	GetAssignmentSourceCounts(ctx context.Context, teamName string, includeArchived bool) (map[string]int, error)
+	FindOpenAssignments(ctx context.Context, assignedBefore time.Time) ([]*models.ReviewAssignment, error)
+	GetAssignmentTimes(ctx context.Context, userIDs []string, since time.Time) (map[string][]time.Time, error)
+	GetReviewTurnarounds(ctx context.Context, teamName string,
+		includeArchived bool) (map[string]models.ReviewTurnaround, error)
+	GetReviewerSummary(ctx context.Context, reviewerID string, includeArchived bool) (*models.ReviewerSummary, error)
+	FindRecentAssignments(ctx context.Context, reviewerID string, includeArchived bool,
+		limit int) ([]*models.ReviewAssignment, error)
+	GetReviewLoadHistogram(ctx context.Context, teamName string, lastBucket int) (*models.ReviewLoadHistogram, error)
+	GetReviewLoads(ctx context.Context, teamName string) ([]*models.ReviewLoad, error)
 }
 
 type StatisticsService struct {
 	userRepo     StatisticsUserRepository
 	prRepo       StatisticsPRRepository
 	reviewerRepo StatisticsReviewerRepository
+	cfg          config.Statistics
+	review       config.Review
+	group        singleflight.Group
 	log          *slog.Logger
 }
 
@@ -33,6 +107,8 @@ func NewStatisticsService(
 	userRepo StatisticsUserRepository,
 	prRepo StatisticsPRRepository,
 	reviewerRepo StatisticsReviewerRepository,
+	cfg config.Statistics,
+	review config.Review,
 	log *slog.Logger,
 ) *StatisticsService {
 	if log == nil {
@@ -42,101 +118,757 @@ func NewStatisticsService(
 		userRepo:     userRepo,
 		prRepo:       prRepo,
 		reviewerRepo: reviewerRepo,
+		cfg:          cfg,
+		review:       review,
 		log:          log,
 	}
 }
 
-func (s *StatisticsService) GetStatistics(ctx context.Context) (*statistics.StatisticsResponse, error) {
-	prs, err := s.prRepo.GetAllPRs(ctx)
+// GetStatistics returns aggregated statistics with the requested sections and pages of the user and
+// PR lists, restricted to a team when the request names one; an unknown team is not found.
+// Concurrent requests for the same sections share one computation unless singleflight is disabled.
+// Statistics are read-only and may be served by a replica. The computation stops between its steps
+// once ctx is done, such as when the client disconnects, returning the error of ctx.
+func (s *StatisticsService) GetStatistics(ctx context.Context,
+	req statistics.StatisticsRequest) (*statistics.StatisticsResponse, error) {
+	ctx = dbctx.ReadOnly(ctx)
+	var full *statistics.StatisticsResponse
+	var err error
+	if s.cfg.DisableSingleflight {
+		full, err = s.computeStatistics(ctx, req.Include, req.IncludeArchived, req.TeamName)
+	} else {
+		full, err = s.sharedStatistics(ctx, req)
+	}
 	if err != nil {
-		s.log.LogAttrs(ctx, slog.LevelError, "failed to get all PRs", slog.String("error", err.Error()))
 		return nil, err
 	}
 
-	users, err := s.userRepo.GetAllUsers(ctx)
+	// the shared result is copied, not modified, as other requests page it differently
+	response := *full
+	if full.UserStats != nil {
+		page := dto.PageOf(full.UserStats.Items, req.Users)
+		response.UserStats = &page
+	}
+	if full.PRStats != nil {
+		page := dto.PageOf(full.PRStats.Items, req.PRs)
+		response.PRStats = &page
+	}
+	return &response, nil
+}
+
+// sharedStatistics joins the computation of concurrent requests for the same statistics until ctx is done.
+func (s *StatisticsService) sharedStatistics(ctx context.Context,
+	req statistics.StatisticsRequest) (*statistics.StatisticsResponse, error) {
+	for {
+		flight := s.group.DoChan(statisticsFlightKey(ctx, req), func() (interface{}, error) {
+			return s.computeStatistics(ctx, req.Include, req.IncludeArchived, req.TeamName)
+		})
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case result := <-flight:
+			if result.Err != nil {
+				// the request that started the computation was canceled, not this one
+				if result.Shared && ctx.Err() == nil && isContextError(result.Err) {
+					s.log.LogAttrs(ctx, slog.LevelDebug, "shared statistics computation stopped, computing again")
+					continue
+				}
+				return nil, result.Err
+			}
+			if result.Shared {
+				s.log.LogAttrs(ctx, slog.LevelDebug, "statistics computation shared between concurrent requests")
+			}
+			return result.Val.(*statistics.StatisticsResponse), nil
+		}
+	}
+}
+
+// interrupted returns the error of ctx once it is done, so a computation checking it between its
+// steps stops when nobody waits for the result anymore; step names the step it stops before.
+func (s *StatisticsService) interrupted(ctx context.Context, step string) error {
+	err := ctx.Err()
 	if err != nil {
-		s.log.LogAttrs(ctx, slog.LevelError, "failed to get all users", slog.String("error", err.Error()))
+		s.log.LogAttrs(ctx, errorLevel(err), "statistics computation stopped",
+			slog.String("before", step), slog.String("error", err.Error()))
+	}
+	return err
+}
+
+// computeStatistics aggregates statistics from the repositories, with the complete user and PR lists
+// of the included sections, adding archived PRs with includeArchived. Unless teamName is empty, the
+// repositories return only the members of the team and the PRs they authored. Repositories needed
+// only by sections or data that are not included are not called.
+// The number of repository calls doesn't depend on the number of PRs or users.
+func (s *StatisticsService) computeStatistics(ctx context.Context, include statistics.Include,
+	includeArchived bool, teamName string) (*statistics.StatisticsResponse, error) {
+	// users are read up front for a team, reporting an unknown one, and otherwise only by the sections needing them
+	var users []*models.User
+	if teamName != "" {
+		var err error
+		if users, err = s.teamMembers(ctx, teamName); err != nil {
+			return nil, err
+		}
+	}
+
+	prs, err := s.prRepo.GetAllPRs(ctx, teamName)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get all PRs", slog.String("error", err.Error()))
 		return nil, err
 	}
 
-	reviewerCounts, err := s.reviewerRepo.GetAllReviewerCounts(ctx)
+	if err := s.interrupted(ctx, "reviewers"); err != nil {
+		return nil, err
+	}
+	reviewersByPR, err := s.reviewerRepo.GetAllReviewers(ctx, teamName)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get reviewers", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	// archivedReviewers stays nil unless archived data is included
+	var archivedReviewers map[string][]string
+	if includeArchived {
+		if err := s.interrupted(ctx, "archived PRs"); err != nil {
+			return nil, err
+		}
+		archived, err := s.prRepo.GetArchivedPRs(ctx, teamName)
+		if err != nil {
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to get archived PRs", slog.String("error", err.Error()))
+			return nil, err
+		}
+		if archivedReviewers, err = s.reviewerRepo.GetArchivedReviewers(ctx, teamName); err != nil {
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to get archived reviewers", slog.String("error", err.Error()))
+			return nil, err
+		}
+		prs = append(prs, archived...)
+		for prID, reviewerIDs := range archivedReviewers {
+			reviewersByPR[prID] = reviewerIDs
+		}
+	}
+
+	if err := s.interrupted(ctx, "counts"); err != nil {
+		return nil, err
+	}
+	reassignmentEvents, err := s.reviewerRepo.CountReviewerChanges(ctx, teamName, includeArchived)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to count reviewer changes", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	sourceCounts, err := s.reviewerRepo.GetAssignmentSourceCounts(ctx, teamName, includeArchived)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to count assignment sources", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	// the same queries as the unassigned PR list, so both report the same PRs
+	withoutReviewers, err := s.prRepo.CountOpenWithoutReviewers(ctx, teamName, nil, nil)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to count PRs without reviewers", slog.String("error", err.Error()))
+		return nil, err
+	}
+	unassigned, err := s.prRepo.FindOpenWithoutReviewers(ctx, teamName, nil, nil, models.PRSort{},
+		prsWithoutReviewersLimit, 0)
 	if err != nil {
-		s.log.LogAttrs(ctx, slog.LevelError, "failed to get reviewer counts", slog.String("error", err.Error()))
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find PRs without reviewers", slog.String("error", err.Error()))
+		return nil, err
+	}
+	prsWithoutReviewers := make([]string, 0, len(unassigned))
+	for _, pr := range unassigned {
+		prsWithoutReviewers = append(prsWithoutReviewers, pr.Id)
+	}
+
+	if err := s.interrupted(ctx, "aggregates"); err != nil {
 		return nil, err
 	}
+	sourceStats := make([]statistics.SourceStats, len(models.AssignmentSources))
+	for i, source := range models.AssignmentSources {
+		sourceStats[i] = statistics.SourceStats{Source: source, Assignments: sourceCounts[source]}
+	}
 
 	totalPRs := len(prs)
 	openPRs := 0
 	mergedPRs := 0
 	totalAssignments := 0
+	assignmentSkippedPRs := 0
+
+	byPriority := make(map[string]*statistics.PriorityStats, len(models.PRPriorities))
+	priorityStats := make([]statistics.PriorityStats, len(models.PRPriorities))
+	for i, priority := range models.PRPriorities {
+		priorityStats[i].Priority = priority
+		byPriority[priority] = &priorityStats[i]
+	}
+
+	byLabel := make(map[string]*statistics.LabelStats)
 
-	prStats := make([]statistics.PRStats, 0, len(prs))
 	for _, pr := range prs {
-		if pr.Status == "OPEN" {
+		if pr.Status == models.PRStatusOpen {
 			openPRs++
-		} else if pr.Status == "MERGED" {
+			if pr.AssignmentSkipped {
+				assignmentSkippedPRs++
+			}
+		} else if pr.Status == models.PRStatusMerged {
 			mergedPRs++
 		}
 
-		reviewers, err := s.reviewerRepo.GetReviewers(ctx, pr.Id)
+		if stat, ok := byPriority[pr.Priority]; ok {
+			stat.TotalPRs++
+			if pr.Status == models.PRStatusOpen {
+				stat.OpenPRs++
+			}
+		}
+
+		for _, label := range pr.Labels {
+			stat, ok := byLabel[label]
+			if !ok {
+				stat = &statistics.LabelStats{Label: label}
+				byLabel[label] = stat
+			}
+			stat.TotalPRs++
+			if pr.Status == models.PRStatusOpen {
+				stat.OpenPRs++
+			}
+		}
+
+		totalAssignments += len(reviewersByPR[pr.Id])
+	}
+
+	labelStats := make([]statistics.LabelStats, 0, len(byLabel))
+	for _, stat := range byLabel {
+		labelStats = append(labelStats, *stat)
+	}
+	sort.Slice(labelStats, func(i, j int) bool { return labelStats[i].Label < labelStats[j].Label })
+
+	response := &statistics.StatisticsResponse{
+		TotalPRs:                totalPRs,
+		OpenPRs:                 openPRs,
+		MergedPRs:               mergedPRs,
+		TotalAssignments:        totalAssignments,
+		ReassignmentEvents:      reassignmentEvents,
+		AssignmentSkippedPRs:    assignmentSkippedPRs,
+		OpenPRsWithoutReviewers: withoutReviewers,
+		PRsWithoutReviewers:     prsWithoutReviewers,
+		ByPriority:              priorityStats,
+		ByLabel:                 labelStats,
+		BySource:                sourceStats,
+	}
+
+	if include.PRStats {
+		if err := s.interrupted(ctx, statistics.SectionPRStats); err != nil {
+			return nil, err
+		}
+		if response.PRStats, err = s.computePRStats(ctx, prs, reviewersByPR, teamName, includeArchived); err != nil {
+			return nil, err
+		}
+	}
+
+	if include.UserStats || include.TeamStats {
+		if err := s.interrupted(ctx, "users"); err != nil {
+			return nil, err
+		}
+		if teamName == "" {
+			if users, err = s.userRepo.GetAllUsers(ctx); err != nil {
+				s.log.LogAttrs(ctx, errorLevel(err), "failed to get all users", slog.String("error", err.Error()))
+				return nil, err
+			}
+		}
+		activeReviews := activeReviewsByUser(prs, reviewersByPR)
+
+		if include.UserStats {
+			response.UserStats, err = s.computeUserStats(ctx, users, activeReviews, archivedReviewers,
+				teamName, includeArchived)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if include.TeamStats {
+			if err := s.interrupted(ctx, statistics.SectionTeamStats); err != nil {
+				return nil, err
+			}
+			loads, err := s.reviewerRepo.GetReviewLoads(ctx, teamName)
+			if err != nil {
+				s.log.LogAttrs(ctx, errorLevel(err), "failed to get review loads", slog.String("error", err.Error()))
+				return nil, err
+			}
+			response.TeamStats = computeTeamStats(users, prs, activeReviews, loads)
+		}
+	}
+
+	if include.AuthorStats {
+		if err := s.interrupted(ctx, statistics.SectionAuthorStats); err != nil {
+			return nil, err
+		}
+		authors, err := s.prRepo.GetAuthorStats(ctx, teamName, includeArchived)
 		if err != nil {
-			s.log.LogAttrs(ctx, slog.LevelError, "failed to get reviewers for PR",
-				slog.String("pr_id", pr.Id), slog.String("error", err.Error()))
-			continue
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to get author stats", slog.String("error", err.Error()))
+			return nil, err
 		}
+		response.AuthorStats = authorStats(authors)
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "statistics retrieved",
+		slog.Int("total_prs", totalPRs),
+		slog.Int("total_assignments", totalAssignments))
+
+	return response, nil
+}
 
-		totalAssignments += len(reviewers)
+// computePRStats builds the PR list of the statistics.
+func (s *StatisticsService) computePRStats(ctx context.Context, prs []*models.PullRequest,
+	reviewersByPR map[string][]string, teamName string, includeArchived bool) (*dto.Page[statistics.PRStats], error) {
+	reassignmentCounts, err := s.reviewerRepo.GetReassignmentCounts(ctx, teamName, includeArchived)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get reassignment counts", slog.String("error", err.Error()))
+		return nil, err
+	}
 
+	prStats := make([]statistics.PRStats, 0, len(prs))
+	for _, pr := range prs {
 		prStats = append(prStats, statistics.PRStats{
-			PullRequestID:   pr.Id,
-			PullRequestName: pr.Title,
-			ReviewersCount:  len(reviewers),
-			Status:          pr.Status,
+			PullRequestID:      pr.Id,
+			PullRequestName:    pr.Title,
+			ReviewersCount:     len(reviewersByPR[pr.Id]),
+			Status:             pr.Status,
+			ReassignmentsCount: reassignmentCounts[pr.Id],
+			Priority:           pr.Priority,
+			Labels:             labelsOrEmpty(pr.Labels),
+			AssignmentSkipped:  pr.AssignmentSkipped,
 		})
 	}
+	return &dto.Page[statistics.PRStats]{Items: prStats, Total: len(prStats)}, nil
+}
+
+// computeUserStats builds the user list of the statistics, sorted by user id. Assignments to
+// the archived PRs are added to the assignment counts and, with includeArchived, to the turnarounds.
+func (s *StatisticsService) computeUserStats(ctx context.Context, users []*models.User, activeReviews map[string]int,
+	archivedReviewers map[string][]string, teamName string,
+	includeArchived bool) (*dto.Page[statistics.UserStats], error) {
+	reviewerCounts, err := s.reviewerRepo.GetAllReviewerCounts(ctx, teamName)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get reviewer counts", slog.String("error", err.Error()))
+		return nil, err
+	}
+	for _, reviewerIDs := range archivedReviewers {
+		for _, reviewerID := range reviewerIDs {
+			reviewerCounts[reviewerID]++
+		}
+	}
 
-	userStatsMap := make(map[string]*statistics.UserStats)
+	if err := s.interrupted(ctx, "assignment times"); err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(users))
 	for _, user := range users {
-		userStatsMap[user.Id] = &statistics.UserStats{
+		userIDs = append(userIDs, user.Id)
+	}
+	now := time.Now().UTC()
+	assignedAt, err := s.reviewerRepo.GetAssignmentTimes(ctx, userIDs, now.Add(-models.WeightWindow))
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get assignment times", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if err := s.interrupted(ctx, "turnarounds"); err != nil {
+		return nil, err
+	}
+	turnarounds, err := s.reviewerRepo.GetReviewTurnarounds(ctx, teamName, includeArchived)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get review turnarounds", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	userStats := make([]statistics.UserStats, 0, len(users))
+	for _, user := range users {
+		stat := statistics.UserStats{
 			UserID:           user.Id,
 			Username:         user.Name,
 			AssignmentsCount: reviewerCounts[user.Id],
-			ActiveReviews:    0,
+			ActiveReviews:    activeReviews[user.Id],
+			Weight:           models.ReviewWeight(assignedAt[user.Id], now, s.review.WeightHalfLife),
+		}
+		if turnaround, ok := turnarounds[user.Id]; ok {
+			setTurnaround(&stat, turnaround)
 		}
+		userStats = append(userStats, stat)
 	}
+	sort.Slice(userStats, func(i, j int) bool { return userStats[i].UserID < userStats[j].UserID })
+	return &dto.Page[statistics.UserStats]{Items: userStats, Total: len(userStats)}, nil
+}
+
+// setTurnaround sets the turnaround of the user statistics in whole seconds.
+func setTurnaround(stat *statistics.UserStats, turnaround models.ReviewTurnaround) {
+	avg, median := int64(math.Round(turnaround.AvgSeconds)), int64(math.Round(turnaround.MedianSeconds))
+	stat.AvgTurnaroundSeconds, stat.MedianTurnaroundSeconds = &avg, &median
+}
 
+// activeReviewsByUser counts the reviewer assignments of each user on open PRs.
+func activeReviewsByUser(prs []*models.PullRequest, reviewersByPR map[string][]string) map[string]int {
+	active := make(map[string]int)
 	for _, pr := range prs {
-		if pr.Status != "OPEN" {
+		if pr.Status != models.PRStatusOpen {
 			continue
 		}
+		for _, reviewerID := range reviewersByPR[pr.Id] {
+			active[reviewerID]++
+		}
+	}
+	return active
+}
 
-		reviewers, err := s.reviewerRepo.GetReviewers(ctx, pr.Id)
-		if err != nil {
+// computeTeamStats aggregates the users by team, sorted by team name, scoring the balance of each team
+// from the review loads of its active members.
+func computeTeamStats(users []*models.User, prs []*models.PullRequest,
+	activeReviews map[string]int, loads []*models.ReviewLoad) []statistics.TeamStats {
+	openAuthored := make(map[string]int)
+	for _, pr := range prs {
+		if pr.Status == models.PRStatusOpen {
+			openAuthored[pr.AuthorId]++
+		}
+	}
+
+	byTeam := make(map[string]*statistics.TeamStats)
+	for _, user := range users {
+		stat, ok := byTeam[user.TeamName]
+		if !ok {
+			stat = &statistics.TeamStats{TeamName: user.TeamName}
+			byTeam[user.TeamName] = stat
+		}
+		stat.Members++
+		if user.IsActive {
+			stat.ActiveMembers++
+		}
+		stat.OpenPRs += openAuthored[user.Id]
+		stat.ActiveReviews += activeReviews[user.Id]
+	}
+
+	openReviews := make(map[string][]int)
+	for _, load := range loads {
+		openReviews[load.TeamName] = append(openReviews[load.TeamName], load.ActiveReviews)
+	}
+
+	teamStats := make([]statistics.TeamStats, 0, len(byTeam))
+	for _, stat := range byTeam {
+		stat.BalanceScore = balanceScore(openReviews[stat.TeamName])
+		teamStats = append(teamStats, *stat)
+	}
+	sort.Slice(teamStats, func(i, j int) bool { return teamStats[i].TeamName < teamStats[j].TeamName })
+	return teamStats
+}
+
+// authorStats converts the counts of the authors, keeping their order, with the average number of
+// reviewers of their PRs.
+func authorStats(authors []*models.AuthorStats) []statistics.AuthorStats {
+	stats := make([]statistics.AuthorStats, 0, len(authors))
+	for _, author := range authors {
+		stats = append(stats, authorStat(author))
+	}
+	return stats
+}
+
+// authorStat converts the counts of an author.
+func authorStat(author *models.AuthorStats) statistics.AuthorStats {
+	stat := statistics.AuthorStats{
+		AuthorID:  author.AuthorId,
+		Username:  author.Username,
+		TotalPRs:  author.TotalPRs,
+		OpenPRs:   author.OpenPRs,
+		MergedPRs: author.MergedPRs,
+	}
+	if author.TotalPRs > 0 {
+		stat.AvgReviewers = float64(author.Reviewers) / float64(author.TotalPRs)
+	}
+	return stat
+}
+
+// balanceScore scores how evenly the open reviews are spread over the members of a team: 1 minus the
+// Gini coefficient of the counts normalized to [0, 1], so 1 is perfectly even and 0 is all reviews
+// on one member. It is nil for fewer than two members, where balance means nothing.
+func balanceScore(counts []int) *float64 {
+	n := len(counts)
+	if n < 2 {
+		return nil
+	}
+	total, diffs := 0, 0
+	for i, a := range counts {
+		total += a
+		for _, b := range counts[i+1:] {
+			diffs += max(a-b, b-a)
+		}
+	}
+	score := 1.0
+	if total > 0 {
+		// the Gini coefficient of n counts is at most (n-1)/n, which normalizes it
+		gini := float64(diffs) / float64(n*total)
+		score = 1 - gini*float64(n)/float64(n-1)
+	}
+	return &score
+}
+
+// GetUserStatistics returns the statistics of one user, computed as for the user and author sections of
+// the statistics but with queries reading only that user's assignments and PRs, and their latest
+// assignments; an unknown user is not found. Like GetStatistics it stops between its queries once
+// ctx is done.
+func (s *StatisticsService) GetUserStatistics(ctx context.Context,
+	req statistics.UserStatisticsRequest) (*statistics.UserStatisticsResponse, error) {
+	ctx = dbctx.ReadOnly(ctx)
+	user, err := s.userRepo.FindByID(ctx, req.UserID)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find user",
+			slog.String("user_id", req.UserID), slog.String("error", err.Error()))
+		return nil, err
+	}
+	if user == nil {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "user not found", slog.String("user_id", req.UserID))
+		return nil, errors.NewNotFound("user not found")
+	}
+
+	summary, err := s.reviewerRepo.GetReviewerSummary(ctx, user.Id, req.IncludeArchived)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get reviewer summary",
+			slog.String("user_id", user.Id), slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if err := s.interrupted(ctx, "assignment times"); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	assignedAt, err := s.reviewerRepo.GetAssignmentTimes(ctx, []string{user.Id}, now.Add(-models.WeightWindow))
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get assignment times",
+			slog.String("user_id", user.Id), slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if err := s.interrupted(ctx, "author stats"); err != nil {
+		return nil, err
+	}
+	authored, err := s.prRepo.GetAuthorStatsByID(ctx, user.Id, req.IncludeArchived)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get author stats",
+			slog.String("user_id", user.Id), slog.String("error", err.Error()))
+		return nil, err
+	}
+	username := user.Name
+	authored.Username = &username
+
+	if err := s.interrupted(ctx, "recent assignments"); err != nil {
+		return nil, err
+	}
+	recent, err := s.reviewerRepo.FindRecentAssignments(ctx, user.Id, req.IncludeArchived, recentAssignmentsLimit)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find recent assignments",
+			slog.String("user_id", user.Id), slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	response := &statistics.UserStatisticsResponse{
+		UserStats: statistics.UserStats{
+			UserID:           user.Id,
+			Username:         user.Name,
+			AssignmentsCount: summary.Assignments,
+			ActiveReviews:    summary.ActiveReviews,
+			Weight:           models.ReviewWeight(assignedAt[user.Id], now, s.review.WeightHalfLife),
+		},
+		Authored:          authorStat(authored),
+		RecentAssignments: make([]statistics.RecentAssignment, 0, len(recent)),
+	}
+	if summary.Turnaround != nil {
+		setTurnaround(&response.UserStats, *summary.Turnaround)
+	}
+	for _, a := range recent {
+		response.RecentAssignments = append(response.RecentAssignments, statistics.RecentAssignment{
+			PullRequestID: a.PRId,
+			AssignedAt:    dto.FormatTime(a.AssignedAt),
+			Source:        a.Source,
+			State:         a.State,
+		})
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "user statistics retrieved",
+		slog.String("user_id", user.Id),
+		slog.Int("assignments", summary.Assignments))
+
+	return response, nil
+}
+
+// GetOverdue returns reviews on open PRs that are past their deadline, grouped by reviewer.
+func (s *StatisticsService) GetOverdue(ctx context.Context) (*statistics.OverdueResponse, error) {
+	ctx = dbctx.ReadOnly(ctx)
+	now := time.Now().UTC()
+	// business deadlines are never earlier than the calendar one, so younger assignments can't be overdue
+	assignments, err := s.reviewerRepo.FindOpenAssignments(ctx, now.Add(-s.review.Deadline))
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find open assignments", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	response := &statistics.OverdueResponse{Reviewers: make([]statistics.OverdueReviewer, 0)}
+	for _, a := range assignments {
+		deadline := models.ReviewDeadline(a.AssignedAt, s.review.Deadline)
+		if !now.After(deadline) {
 			continue
 		}
 
-		for _, reviewerID := range reviewers {
-			if stat, ok := userStatsMap[reviewerID]; ok {
-				stat.ActiveReviews++
-			}
+		// assignments come ordered by reviewer, so each reviewer's group is contiguous
+		if n := len(response.Reviewers); n == 0 || response.Reviewers[n-1].UserID != a.ReviewerId {
+			response.Reviewers = append(response.Reviewers, statistics.OverdueReviewer{UserID: a.ReviewerId})
+		}
+		last := &response.Reviewers[len(response.Reviewers)-1]
+		last.Assignments = append(last.Assignments, statistics.OverdueAssignment{
+			PullRequestID: a.PRId,
+			AssignedAt:    dto.FormatTime(a.AssignedAt),
+			Deadline:      dto.FormatTime(deadline),
+		})
+		response.Total++
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "overdue reviews retrieved",
+		slog.Int("total", response.Total),
+		slog.Int("reviewers", len(response.Reviewers)))
+
+	return response, nil
+}
+
+// GetDistribution returns histograms of the review load of the active users of a team, or of all
+// teams without one, and the load of every user on request.
+func (s *StatisticsService) GetDistribution(ctx context.Context,
+	req statistics.DistributionRequest) (*statistics.DistributionResponse, error) {
+	ctx = dbctx.ReadOnly(ctx)
+	if req.TeamName != "" {
+		if _, err := s.teamMembers(ctx, req.TeamName); err != nil {
+			return nil, err
 		}
 	}
 
-	userStats := make([]statistics.UserStats, 0, len(userStatsMap))
-	for _, stat := range userStatsMap {
-		userStats = append(userStats, *stat)
+	histogram, err := s.reviewerRepo.GetReviewLoadHistogram(ctx, req.TeamName, distributionLastBucket)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get review load histogram",
+			slog.String("team_name", req.TeamName), slog.String("error", err.Error()))
+		return nil, err
 	}
 
-	s.log.LogAttrs(ctx, slog.LevelInfo, "statistics retrieved",
-		slog.Int("total_prs", totalPRs),
-		slog.Int("total_assignments", totalAssignments))
+	response := &statistics.DistributionResponse{
+		TeamName:         req.TeamName,
+		ActiveReviews:    distributionBuckets(histogram.ActiveReviews),
+		TotalAssignments: distributionBuckets(histogram.TotalAssignments),
+	}
+	for _, bucket := range response.ActiveReviews {
+		response.ActiveUsers += bucket.Users
+	}
+
+	if req.Detail {
+		loads, err := s.reviewerRepo.GetReviewLoads(ctx, req.TeamName)
+		if err != nil {
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to get review loads",
+				slog.String("team_name", req.TeamName), slog.String("error", err.Error()))
+			return nil, err
+		}
+		response.Users = make([]statistics.DistributionUser, 0, len(loads))
+		for _, load := range loads {
+			response.Users = append(response.Users, statistics.DistributionUser{
+				UserID:           load.UserId,
+				Username:         load.Username,
+				TeamName:         load.TeamName,
+				ActiveReviews:    load.ActiveReviews,
+				TotalAssignments: load.TotalAssignments,
+			})
+		}
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "review load distribution retrieved",
+		slog.String("team_name", req.TeamName),
+		slog.Int("active_users", response.ActiveUsers))
+
+	return response, nil
+}
+
+// teamMembers gets the members of a team, which is not found without any.
+func (s *StatisticsService) teamMembers(ctx context.Context, teamName string) ([]*models.User, error) {
+	members, err := s.userRepo.FindByTeamName(ctx, teamName)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get team members",
+			slog.String("team_name", teamName), slog.String("error", err.Error()))
+		return nil, err
+	}
+	if len(members) == 0 {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "team not found", slog.String("team_name", teamName))
+		return nil, errors.NewNotFound("team not found")
+	}
+	return members, nil
+}
+
+// distributionBuckets lists every bucket of a histogram up to the last one, empty buckets included.
+func distributionBuckets(counts map[int]int) []statistics.DistributionBucket {
+	buckets := make([]statistics.DistributionBucket, 0, distributionLastBucket+1)
+	for bucket := 0; bucket <= distributionLastBucket; bucket++ {
+		label := strconv.Itoa(bucket)
+		if bucket == distributionLastBucket {
+			label += "+"
+		}
+		buckets = append(buckets, statistics.DistributionBucket{Bucket: label, Users: counts[bucket]})
+	}
+	return buckets
+}
+
+// GetTimeseries returns the PRs created and merged and the reviewer changes made within the range in
+// buckets of the requested size, every bucket of the range included, restricted to the PRs of a team
+// when the request names one; an unknown team is not found. Ranges spanning more buckets than
+// configured are refused.
+func (s *StatisticsService) GetTimeseries(ctx context.Context,
+	req statistics.TimeseriesRequest) (*statistics.TimeseriesResponse, error) {
+	ctx = dbctx.ReadOnly(ctx)
+	if !req.To.After(req.From) {
+		return nil, errors.NewValidation("to must be after from")
+	}
+
+	maxBuckets := s.cfg.MaxTimeseriesBuckets
+	if maxBuckets <= 0 {
+		maxBuckets = defaultMaxTimeseriesBuckets
+	}
+	var starts []time.Time
+	for start := models.BucketStart(req.From, req.Bucket); start.Before(req.To); {
+		if len(starts) == maxBuckets {
+			return nil, errors.NewValidation(fmt.Sprintf("the range must span at most %d buckets", maxBuckets))
+		}
+		starts = append(starts, start)
+		start = models.NextBucketStart(start, req.Bucket)
+	}
+
+	if req.TeamName != "" {
+		if _, err := s.teamMembers(ctx, req.TeamName); err != nil {
+			return nil, err
+		}
+	}
+
+	activity, err := s.prRepo.GetActivity(ctx, req.TeamName, req.Bucket, req.From, req.To)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get activity", slog.String("bucket", req.Bucket),
+			slog.String("team_name", req.TeamName), slog.String("error", err.Error()))
+		return nil, err
+	}
+	byStart := make(map[time.Time]*models.ActivityBucket, len(activity))
+	for _, b := range activity {
+		byStart[b.Start] = b
+	}
+
+	response := &statistics.TimeseriesResponse{
+		Bucket:  req.Bucket,
+		From:    dto.FormatTime(req.From),
+		To:      dto.FormatTime(req.To),
+		Buckets: make([]statistics.TimeseriesBucket, 0, len(starts)),
+	}
+	for _, start := range starts {
+		bucket := statistics.TimeseriesBucket{Start: dto.FormatTime(start)}
+		if b, ok := byStart[start]; ok {
+			bucket.Created, bucket.Merged, bucket.Reassignments = b.Created, b.Merged, b.Reassignments
+		}
+		response.Buckets = append(response.Buckets, bucket)
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "activity time series retrieved",
+		slog.String("bucket", req.Bucket),
+		slog.Int("buckets", len(response.Buckets)))
 
-	return &statistics.StatisticsResponse{
-		TotalPRs:         totalPRs,
-		OpenPRs:          openPRs,
-		MergedPRs:        mergedPRs,
-		TotalAssignments: totalAssignments,
-		UserStats:        userStats,
-		PRStats:          prStats,
-	}, nil
+	return response, nil
 }
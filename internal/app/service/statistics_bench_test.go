@@ -0,0 +1,308 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/statistics"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/shirr9/pr-reviewer-service/internal/infrastructure/persistence/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+// statisticsDataset is the size of synthetic data for statistics benchmarks.
+type statisticsDataset struct {
+	prs   int
+	users int
+}
+
+func (d statisticsDataset) String() string {
+	return fmt.Sprintf("prs=%d/users=%d", d.prs, d.users)
+}
+
+// seedStatistics fills in-memory storage with teams of ten users and PRs with two reviewers each
+// from the author's team. Every third PR is open, every fifth was reassigned once.
+func seedStatistics(tb testing.TB, dataset statisticsDataset) *memory.Storage {
+	tb.Helper()
+	ctx := context.Background()
+	storage := memory.NewStorage()
+	teamRepo := storage.NewTeamRepository()
+	prRepo := storage.NewPullRequestRepository()
+	reviewerRepo := storage.NewReviewerRepository()
+
+	const teamSize = 10
+	userID := func(i int) string { return fmt.Sprintf("u%d", i) }
+	for start := 0; start < dataset.users; start += teamSize {
+		team := &models.Team{}
+		for i := start; i < min(start+teamSize, dataset.users); i++ {
+			team.Members = append(team.Members, &models.User{
+				Id: userID(i), Name: userID(i), TeamName: fmt.Sprintf("team-%d", start/teamSize), IsActive: true,
+			})
+		}
+		if err := teamRepo.CreateTeam(ctx, team); err != nil {
+			tb.Fatalf("failed to seed team: %v", err)
+		}
+	}
+
+	labels := [][]string{{}, {"backend"}, {"backend", "urgent"}, {"frontend"}}
+	createdAt := time.Now().UTC().Add(-time.Duration(dataset.prs) * time.Minute)
+	for i := 0; i < dataset.prs; i++ {
+		author := i % dataset.users
+		pr := &models.PullRequest{
+			Id:        fmt.Sprintf("pr-%d", i),
+			Title:     fmt.Sprintf("PR %d", i),
+			AuthorId:  userID(author),
+			Status:    models.PRStatusMerged,
+			CreatedAt: createdAt.Add(time.Duration(i) * time.Minute),
+			Priority:  models.PRPriorities[i%len(models.PRPriorities)],
+			Labels:    labels[i%len(labels)],
+		}
+		pr.UpdatedAt = pr.CreatedAt
+		if i%3 == 0 {
+			pr.Status = models.PRStatusOpen
+		}
+		if err := prRepo.Create(ctx, pr); err != nil {
+			tb.Fatalf("failed to seed PR: %v", err)
+		}
+
+		teamStart := author - author%teamSize
+		teamLen := min(teamSize, dataset.users-teamStart)
+		for offset := 1; offset <= 2 && offset < teamLen; offset++ {
+			reviewer := teamStart + (author-teamStart+offset)%teamLen
+			if err := reviewerRepo.AssignReviewer(ctx, pr.Id, userID(reviewer), models.AssignmentSourceAuto); err != nil {
+				tb.Fatalf("failed to seed reviewer: %v", err)
+			}
+		}
+		if i%5 == 0 {
+			if err := reviewerRepo.RecordReviewerChange(ctx, &models.ReviewerChange{
+				PRId: pr.Id, OldReviewerId: userID(author), Trigger: models.ReviewerChangeManual, ChangedAt: pr.CreatedAt,
+			}); err != nil {
+				tb.Fatalf("failed to seed reviewer change: %v", err)
+			}
+		}
+	}
+	return storage
+}
+
+// statsCallCounter wraps the in-memory repositories and counts repository calls made by the statistics service.
+type statsCallCounter struct {
+	users     *memory.UserRepository
+	prs       *memory.PullRequestRepository
+	reviewers *memory.ReviewerRepository
+	calls     int
+}
+
+func newStatsCallCounter(storage *memory.Storage) *statsCallCounter {
+	return &statsCallCounter{
+		users:     storage.NewUserRepository(),
+		prs:       storage.NewPullRequestRepository(),
+		reviewers: storage.NewReviewerRepository(),
+	}
+}
+
+func (c *statsCallCounter) GetAllUsers(ctx context.Context) ([]*models.User, error) {
+	c.calls++
+	return c.users.GetAllUsers(ctx)
+}
+
+func (c *statsCallCounter) GetAllPRs(ctx context.Context, teamName string) ([]*models.PullRequest, error) {
+	c.calls++
+	return c.prs.GetAllPRs(ctx, teamName)
+}
+
+func (c *statsCallCounter) GetArchivedPRs(ctx context.Context, teamName string) ([]*models.PullRequest, error) {
+	c.calls++
+	return c.prs.GetArchivedPRs(ctx, teamName)
+}
+
+func (c *statsCallCounter) GetAllReviewers(ctx context.Context, teamName string) (map[string][]string, error) {
+	c.calls++
+	return c.reviewers.GetAllReviewers(ctx, teamName)
+}
+
+func (c *statsCallCounter) GetArchivedReviewers(ctx context.Context, teamName string) (map[string][]string, error) {
+	c.calls++
+	return c.reviewers.GetArchivedReviewers(ctx, teamName)
+}
+
+func (c *statsCallCounter) GetAllReviewerCounts(ctx context.Context, teamName string) (map[string]int, error) {
+	c.calls++
+	return c.reviewers.GetAllReviewerCounts(ctx, teamName)
+}
+
+func (c *statsCallCounter) GetPRsByReviewer(ctx context.Context, reviewerID string) ([]string, error) {
+	c.calls++
+	return c.reviewers.GetPRsByReviewer(ctx, reviewerID)
+}
+
+func (c *statsCallCounter) GetReassignmentCounts(ctx context.Context, teamName string,
+	includeArchived bool) (map[string]int, error) {
+	c.calls++
+	return c.reviewers.GetReassignmentCounts(ctx, teamName, includeArchived)
+}
+
+func (c *statsCallCounter) CountReviewerChanges(ctx context.Context, teamName string, includeArchived bool) (int, error) {
+	c.calls++
+	return c.reviewers.CountReviewerChanges(ctx, teamName, includeArchived)
+}
+
+func (c *statsCallCounter) GetAssignmentSourceCounts(ctx context.Context, teamName string,
+	includeArchived bool) (map[string]int, error) {
+	c.calls++
+	return c.reviewers.GetAssignmentSourceCounts(ctx, teamName, includeArchived)
+}
+
+func (c *statsCallCounter) FindOpenAssignments(ctx context.Context, assignedBefore time.Time) ([]*models.ReviewAssignment, error) {
+	c.calls++
+	return c.reviewers.FindOpenAssignments(ctx, assignedBefore)
+}
+
+func (c *statsCallCounter) GetAssignmentTimes(ctx context.Context, userIDs []string,
+	since time.Time) (map[string][]time.Time, error) {
+	c.calls++
+	return c.reviewers.GetAssignmentTimes(ctx, userIDs, since)
+}
+
+func (c *statsCallCounter) GetReviewTurnarounds(ctx context.Context, teamName string,
+	includeArchived bool) (map[string]models.ReviewTurnaround, error) {
+	c.calls++
+	return c.reviewers.GetReviewTurnarounds(ctx, teamName, includeArchived)
+}
+
+func (c *statsCallCounter) FindByTeamName(ctx context.Context, teamName string) ([]*models.User, error) {
+	c.calls++
+	return c.users.FindByTeamName(ctx, teamName)
+}
+
+func (c *statsCallCounter) GetReviewLoadHistogram(ctx context.Context, teamName string,
+	lastBucket int) (*models.ReviewLoadHistogram, error) {
+	c.calls++
+	return c.reviewers.GetReviewLoadHistogram(ctx, teamName, lastBucket)
+}
+
+func (c *statsCallCounter) GetReviewLoads(ctx context.Context, teamName string) ([]*models.ReviewLoad, error) {
+	c.calls++
+	return c.reviewers.GetReviewLoads(ctx, teamName)
+}
+
+func (c *statsCallCounter) GetActivity(ctx context.Context, teamName, bucket string,
+	from, to time.Time) ([]*models.ActivityBucket, error) {
+	c.calls++
+	return c.prs.GetActivity(ctx, teamName, bucket, from, to)
+}
+
+func (c *statsCallCounter) FindOpenWithoutReviewers(ctx context.Context, teamName string, labels []string,
+	skipped *bool, order models.PRSort, limit, offset int) ([]*models.PullRequest, error) {
+	c.calls++
+	return c.prs.FindOpenWithoutReviewers(ctx, teamName, labels, skipped, order, limit, offset)
+}
+
+func (c *statsCallCounter) CountOpenWithoutReviewers(ctx context.Context, teamName string, labels []string,
+	skipped *bool) (int, error) {
+	c.calls++
+	return c.prs.CountOpenWithoutReviewers(ctx, teamName, labels, skipped)
+}
+
+func (c *statsCallCounter) GetAuthorStats(ctx context.Context, teamName string,
+	includeArchived bool) ([]*models.AuthorStats, error) {
+	c.calls++
+	return c.prs.GetAuthorStats(ctx, teamName, includeArchived)
+}
+
+func (c *statsCallCounter) GetAuthorStatsByID(ctx context.Context, authorID string,
+	includeArchived bool) (*models.AuthorStats, error) {
+	c.calls++
+	return c.prs.GetAuthorStatsByID(ctx, authorID, includeArchived)
+}
+
+func (c *statsCallCounter) FindByID(ctx context.Context, userID string) (*models.User, error) {
+	c.calls++
+	return c.users.FindByID(ctx, userID)
+}
+
+func (c *statsCallCounter) GetReviewerSummary(ctx context.Context, reviewerID string,
+	includeArchived bool) (*models.ReviewerSummary, error) {
+	c.calls++
+	return c.reviewers.GetReviewerSummary(ctx, reviewerID, includeArchived)
+}
+
+func (c *statsCallCounter) FindRecentAssignments(ctx context.Context, reviewerID string, includeArchived bool,
+	limit int) ([]*models.ReviewAssignment, error) {
+	c.calls++
+	return c.reviewers.FindRecentAssignments(ctx, reviewerID, includeArchived, limit)
+}
+
+func TestStatisticsService_GetStatistics_QueryCount(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	callsFor := func(dataset statisticsDataset) int {
+		counter := newStatsCallCounter(seedStatistics(t, dataset))
+		service := NewStatisticsService(counter, counter, counter, config.Statistics{DisableSingleflight: true},
+			testReview, logger)
+
+		req := allStatistics
+		req.Include.AuthorStats = true
+		resp, err := service.GetStatistics(context.Background(), req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, dataset.prs, resp.TotalPRs)
+		assert.Len(t, resp.UserStats.Items, dataset.users)
+		return counter.calls
+	}
+
+	small := callsFor(statisticsDataset{prs: 10, users: 10})
+	large := callsFor(statisticsDataset{prs: 1000, users: 100})
+
+	assert.Equal(t, small, large, "repository calls must not grow with the number of PRs or users")
+}
+
+func TestStatisticsService_GetUserStatistics_QueryCount(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	callsFor := func(dataset statisticsDataset) int {
+		counter := newStatsCallCounter(seedStatistics(t, dataset))
+		service := NewStatisticsService(counter, counter, counter, config.Statistics{}, testReview, logger)
+
+		resp, err := service.GetUserStatistics(context.Background(),
+			statistics.UserStatisticsRequest{UserID: "u1", IncludeArchived: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, dataset.prs/dataset.users, resp.Authored.TotalPRs)
+		return counter.calls
+	}
+
+	small := callsFor(statisticsDataset{prs: 10, users: 10})
+	large := callsFor(statisticsDataset{prs: 1000, users: 100})
+
+	assert.Equal(t, small, large, "repository calls must not grow with the number of PRs or users")
+}
+
+func BenchmarkGetStatistics(b *testing.B) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	for _, dataset := range []statisticsDataset{
+		{prs: 1_000, users: 100},
+		{prs: 1_000, users: 1_000},
+		{prs: 10_000, users: 100},
+		{prs: 10_000, users: 1_000},
+		{prs: 100_000, users: 100},
+		{prs: 100_000, users: 1_000},
+	} {
+		b.Run(dataset.String(), func(b *testing.B) {
+			storage := seedStatistics(b, dataset)
+			service := NewStatisticsService(storage.NewUserRepository(), storage.NewPullRequestRepository(),
+				storage.NewReviewerRepository(), config.Statistics{DisableSingleflight: true}, testReview, logger)
+			ctx := context.Background()
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for b.Loop() {
+				if _, err := service.GetStatistics(ctx, allStatistics); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,1211 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dbctx"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/statistics"
+	"github.com/shirr9/pr-reviewer-service/internal/app/service/mocks"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/shirr9/pr-reviewer-service/internal/infrastructure/persistence/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// allStatistics requests the complete user and PR lists.
+var allStatistics = statistics.StatisticsRequest{
+	Include: statistics.DefaultInclude,
+	Users:   dto.PageRequest{Limit: math.MaxInt},
+	PRs:     dto.PageRequest{Limit: math.MaxInt},
+}
+
+// countingStatsRepo is a fake statistics repository that counts calls
+// and blocks GetAllPRs until release is closed.
+type countingStatsRepo struct {
+	prCalls       atomic.Int32
+	reviewerCalls atomic.Int32
+	release       chan struct{}
+	prs           []*models.PullRequest
+	users         []*models.User
+	// reassignments is returned as reassignment counts keyed by PR id.
+	reassignments map[string]int
+	// assignments are open PR assignments filtered by FindOpenAssignments and GetAssignmentTimes.
+	assignments []*models.ReviewAssignment
+}
+
+func (r *countingStatsRepo) GetAllPRs(ctx context.Context, teamName string) ([]*models.PullRequest, error) {
+	r.prCalls.Add(1)
+	if r.release != nil {
+		<-r.release
+	}
+	return r.prs, nil
+}
+
+func (r *countingStatsRepo) GetArchivedPRs(ctx context.Context, teamName string) ([]*models.PullRequest, error) {
+	return nil, nil
+}
+
+func (r *countingStatsRepo) GetAllUsers(ctx context.Context) ([]*models.User, error) {
+	return r.users, nil
+}
+
+func (r *countingStatsRepo) GetAllReviewers(ctx context.Context, teamName string) (map[string][]string, error) {
+	r.reviewerCalls.Add(1)
+	reviewers := make(map[string][]string, len(r.prs))
+	for _, pr := range r.prs {
+		reviewers[pr.Id] = []string{"u2"}
+	}
+	return reviewers, nil
+}
+
+func (r *countingStatsRepo) GetArchivedReviewers(ctx context.Context, teamName string) (map[string][]string, error) {
+	return nil, nil
+}
+
+func (r *countingStatsRepo) GetAllReviewerCounts(ctx context.Context, teamName string) (map[string]int, error) {
+	return map[string]int{"u2": len(r.prs)}, nil
+}
+
+func (r *countingStatsRepo) GetPRsByReviewer(ctx context.Context, reviewerID string) ([]string, error) {
+	return nil, nil
+}
+
+func (r *countingStatsRepo) GetReassignmentCounts(ctx context.Context, teamName string,
+	includeArchived bool) (map[string]int, error) {
+	return r.reassignments, nil
+}
+
+func (r *countingStatsRepo) CountReviewerChanges(ctx context.Context, teamName string, includeArchived bool) (int, error) {
+	total := 0
+	for _, count := range r.reassignments {
+		total += count
+	}
+	return total, nil
+}
+
+func (r *countingStatsRepo) GetAssignmentSourceCounts(ctx context.Context, teamName string,
+	includeArchived bool) (map[string]int, error) {
+	return map[string]int{models.AssignmentSourceAuto: len(r.prs)}, nil
+}
+
+func (r *countingStatsRepo) FindOpenAssignments(ctx context.Context, assignedBefore time.Time) ([]*models.ReviewAssignment, error) {
+	var assignments []*models.ReviewAssignment
+	for _, a := range r.assignments {
+		if a.AssignedAt.Before(assignedBefore) {
+			assignments = append(assignments, a)
+		}
+	}
+	return assignments, nil
+}
+
+func (r *countingStatsRepo) GetAssignmentTimes(ctx context.Context, userIDs []string, since time.Time) (map[string][]time.Time, error) {
+	times := make(map[string][]time.Time)
+	for _, a := range r.assignments {
+		if !a.AssignedAt.Before(since) {
+			times[a.ReviewerId] = append(times[a.ReviewerId], a.AssignedAt)
+		}
+	}
+	return times, nil
+}
+
+func (r *countingStatsRepo) GetReviewTurnarounds(ctx context.Context, teamName string,
+	includeArchived bool) (map[string]models.ReviewTurnaround, error) {
+	return nil, nil
+}
+
+func (r *countingStatsRepo) FindByTeamName(ctx context.Context, teamName string) ([]*models.User, error) {
+	var users []*models.User
+	for _, user := range r.users {
+		if user.TeamName == teamName {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+func (r *countingStatsRepo) GetReviewLoadHistogram(ctx context.Context, teamName string,
+	lastBucket int) (*models.ReviewLoadHistogram, error) {
+	return &models.ReviewLoadHistogram{}, nil
+}
+
+func (r *countingStatsRepo) GetReviewLoads(ctx context.Context, teamName string) ([]*models.ReviewLoad, error) {
+	return nil, nil
+}
+
+func (r *countingStatsRepo) GetActivity(ctx context.Context, teamName, bucket string,
+	from, to time.Time) ([]*models.ActivityBucket, error) {
+	return nil, nil
+}
+
+// FindOpenWithoutReviewers finds nothing, as every PR of the fake is reviewed by u2.
+func (r *countingStatsRepo) FindOpenWithoutReviewers(ctx context.Context, teamName string, labels []string,
+	skipped *bool, order models.PRSort, limit, offset int) ([]*models.PullRequest, error) {
+	return nil, nil
+}
+
+func (r *countingStatsRepo) CountOpenWithoutReviewers(ctx context.Context, teamName string, labels []string,
+	skipped *bool) (int, error) {
+	return 0, nil
+}
+
+func (r *countingStatsRepo) GetAuthorStats(ctx context.Context, teamName string,
+	includeArchived bool) ([]*models.AuthorStats, error) {
+	return nil, nil
+}
+
+func (r *countingStatsRepo) GetAuthorStatsByID(ctx context.Context, authorID string,
+	includeArchived bool) (*models.AuthorStats, error) {
+	return &models.AuthorStats{AuthorId: authorID}, nil
+}
+
+func (r *countingStatsRepo) FindByID(ctx context.Context, userID string) (*models.User, error) {
+	for _, user := range r.users {
+		if user.Id == userID {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *countingStatsRepo) GetReviewerSummary(ctx context.Context, reviewerID string,
+	includeArchived bool) (*models.ReviewerSummary, error) {
+	return &models.ReviewerSummary{}, nil
+}
+
+func (r *countingStatsRepo) FindRecentAssignments(ctx context.Context, reviewerID string, includeArchived bool,
+	limit int) ([]*models.ReviewAssignment, error) {
+	return nil, nil
+}
+
+func newCountingStatsRepo() *countingStatsRepo {
+	return &countingStatsRepo{
+		release: make(chan struct{}),
+		prs: []*models.PullRequest{
+			{Id: "pr-1", Title: "First", AuthorId: "u1", Status: models.PRStatusOpen},
+			{Id: "pr-2", Title: "Second", AuthorId: "u1", Status: models.PRStatusMerged},
+		},
+		users: []*models.User{
+			{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+			{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+		},
+	}
+}
+
+// runConcurrentStatistics fires n concurrent GetStatistics calls, releases the repository
+// once all of them are in flight and waits for completion.
+func runConcurrentStatistics(t *testing.T, service *StatisticsService, repo *countingStatsRepo, n int) {
+	t.Helper()
+
+	var wg sync.WaitGroup
+	var started sync.WaitGroup
+	started.Add(n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			started.Done()
+			resp, err := service.GetStatistics(context.Background(), allStatistics)
+			assert.NoError(t, err)
+			if assert.NotNil(t, resp) {
+				assert.Equal(t, 2, resp.TotalPRs)
+				assert.Equal(t, 1, resp.OpenPRs)
+				assert.Equal(t, 1, resp.MergedPRs)
+			}
+		}()
+	}
+
+	started.Wait()
+	// give every goroutine time to reach the repository call or join the flight
+	time.Sleep(50 * time.Millisecond)
+	close(repo.release)
+	wg.Wait()
+}
+
+func TestStatisticsService_GetStatistics_Singleflight(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	t.Run("Success - Concurrent calls share one computation", func(t *testing.T) {
+		repo := newCountingStatsRepo()
+		service := NewStatisticsService(repo, repo, repo, config.Statistics{}, testReview, logger)
+
+		runConcurrentStatistics(t, service, repo, 10)
+
+		assert.Equal(t, int32(1), repo.prCalls.Load())
+	})
+
+	t.Run("Success - Disabled singleflight computes per call", func(t *testing.T) {
+		repo := newCountingStatsRepo()
+		service := NewStatisticsService(repo, repo, repo, config.Statistics{DisableSingleflight: true}, testReview, logger)
+
+		runConcurrentStatistics(t, service, repo, 5)
+
+		assert.Equal(t, int32(5), repo.prCalls.Load())
+	})
+
+	t.Run("Success - Sequential calls are not cached", func(t *testing.T) {
+		repo := newCountingStatsRepo()
+		close(repo.release)
+		service := NewStatisticsService(repo, repo, repo, config.Statistics{}, testReview, logger)
+
+		_, err := service.GetStatistics(context.Background(), allStatistics)
+		assert.NoError(t, err)
+		_, err = service.GetStatistics(context.Background(), allStatistics)
+		assert.NoError(t, err)
+
+		assert.Equal(t, int32(2), repo.prCalls.Load())
+	})
+}
+
+func TestStatisticsService_GetStatistics_Canceled(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	// start runs GetStatistics with ctx, returning its error through the channel.
+	start := func(service *StatisticsService, ctx context.Context) <-chan error {
+		done := make(chan error, 1)
+		go func() {
+			_, err := service.GetStatistics(ctx, allStatistics)
+			done <- err
+		}()
+		return done
+	}
+
+	t.Run("Error - Computation stops after the step the client left in", func(t *testing.T) {
+		repo := newCountingStatsRepo()
+		service := NewStatisticsService(repo, repo, repo, config.Statistics{DisableSingleflight: true}, testReview, logger)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		done := start(service, ctx)
+		require.Eventually(t, func() bool { return repo.prCalls.Load() == 1 }, time.Second, time.Millisecond)
+		cancel()
+		close(repo.release)
+
+		assert.ErrorIs(t, <-done, context.Canceled)
+		assert.Zero(t, repo.reviewerCalls.Load())
+	})
+
+	t.Run("Error - Waiter leaves a shared computation at once", func(t *testing.T) {
+		repo := newCountingStatsRepo()
+		defer close(repo.release)
+		service := NewStatisticsService(repo, repo, repo, config.Statistics{}, testReview, logger)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		start(service, context.Background())
+		require.Eventually(t, func() bool { return repo.prCalls.Load() == 1 }, time.Second, time.Millisecond)
+		done := start(service, ctx)
+		cancel()
+
+		select {
+		case err := <-done:
+			assert.ErrorIs(t, err, context.Canceled)
+		case <-time.After(time.Second):
+			t.Fatal("the canceled request waited for the shared computation")
+		}
+	})
+
+	t.Run("Success - Waiter computes again when the first request is canceled", func(t *testing.T) {
+		repo := newCountingStatsRepo()
+		service := NewStatisticsService(repo, repo, repo, config.Statistics{}, testReview, logger)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		first := start(service, ctx)
+		require.Eventually(t, func() bool { return repo.prCalls.Load() == 1 }, time.Second, time.Millisecond)
+		second := start(service, context.Background())
+		// give the second request time to join the flight
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+		close(repo.release)
+
+		assert.ErrorIs(t, <-first, context.Canceled)
+		assert.NoError(t, <-second)
+		assert.Equal(t, int32(2), repo.prCalls.Load())
+	})
+}
+
+func TestStatisticsService_GetStatistics_ReassignmentsCount(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	repo := newCountingStatsRepo()
+	close(repo.release)
+	repo.reassignments = map[string]int{"pr-1": 3}
+	service := NewStatisticsService(repo, repo, repo, config.Statistics{}, testReview, logger)
+
+	resp, err := service.GetStatistics(context.Background(), allStatistics)
+
+	assert.NoError(t, err)
+	counts := make(map[string]int)
+	for _, pr := range resp.PRStats.Items {
+		counts[pr.PullRequestID] = pr.ReassignmentsCount
+	}
+	assert.Equal(t, map[string]int{"pr-1": 3, "pr-2": 0}, counts)
+	assert.Equal(t, 3, resp.ReassignmentEvents)
+}
+
+func TestStatisticsService_GetOverdue(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	now := time.Now().UTC()
+	repo := newCountingStatsRepo()
+	repo.assignments = []*models.ReviewAssignment{
+		{PRId: "pr-1", ReviewerId: "u2", AssignedAt: now.AddDate(0, 0, -10)},
+		{PRId: "pr-3", ReviewerId: "u2", AssignedAt: now.AddDate(0, 0, -8)},
+		{PRId: "pr-4", ReviewerId: "u2", AssignedAt: now.Add(-time.Hour)},
+		{PRId: "pr-1", ReviewerId: "u3", AssignedAt: now.AddDate(0, 0, -9)},
+	}
+	service := NewStatisticsService(repo, repo, repo, config.Statistics{}, testReview, logger)
+
+	resp, err := service.GetOverdue(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, resp.Total)
+	assert.Len(t, resp.Reviewers, 2)
+	assert.Equal(t, "u2", resp.Reviewers[0].UserID)
+	assert.Len(t, resp.Reviewers[0].Assignments, 2)
+	assert.Equal(t, "pr-1", resp.Reviewers[0].Assignments[0].PullRequestID)
+	assert.Equal(t, "u3", resp.Reviewers[1].UserID)
+	assert.NotEmpty(t, resp.Reviewers[1].Assignments[0].Deadline)
+}
+
+func TestStatisticsService_GetStatistics_ByPriority(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	repo := newCountingStatsRepo()
+	close(repo.release)
+	repo.prs = []*models.PullRequest{
+		{Id: "pr-1", AuthorId: "u1", Status: models.PRStatusOpen, Priority: models.PRPriorityUrgent},
+		{Id: "pr-2", AuthorId: "u1", Status: models.PRStatusMerged, Priority: models.PRPriorityUrgent},
+		{Id: "pr-3", AuthorId: "u1", Status: models.PRStatusOpen, Priority: models.PRPriorityNormal},
+	}
+	service := NewStatisticsService(repo, repo, repo, config.Statistics{}, testReview, logger)
+
+	resp, err := service.GetStatistics(context.Background(), allStatistics)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []statistics.PriorityStats{
+		{Priority: models.PRPriorityLow},
+		{Priority: models.PRPriorityNormal, TotalPRs: 1, OpenPRs: 1},
+		{Priority: models.PRPriorityHigh},
+		{Priority: models.PRPriorityUrgent, TotalPRs: 2, OpenPRs: 1},
+	}, resp.ByPriority)
+}
+
+func TestStatisticsService_GetStatistics_AssignmentSkipped(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	repo := newCountingStatsRepo()
+	close(repo.release)
+	repo.prs = []*models.PullRequest{
+		{Id: "pr-1", AuthorId: "u1", Status: models.PRStatusOpen, AssignmentSkipped: true},
+		{Id: "pr-2", AuthorId: "u1", Status: models.PRStatusOpen},
+		{Id: "pr-3", AuthorId: "u1", Status: models.PRStatusMerged, AssignmentSkipped: true},
+	}
+	service := NewStatisticsService(repo, repo, repo, config.Statistics{}, testReview, logger)
+
+	resp, err := service.GetStatistics(context.Background(), allStatistics)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resp.AssignmentSkippedPRs, "merged PRs wait for nothing")
+	assert.True(t, resp.PRStats.Items[0].AssignmentSkipped)
+	assert.False(t, resp.PRStats.Items[1].AssignmentSkipped)
+}
+
+func TestStatisticsService_GetStatistics_ByLabel(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	repo := newCountingStatsRepo()
+	close(repo.release)
+	repo.prs = []*models.PullRequest{
+		{Id: "pr-1", AuthorId: "u1", Status: models.PRStatusOpen, Labels: []string{"api", "infra"}},
+		{Id: "pr-2", AuthorId: "u1", Status: models.PRStatusMerged, Labels: []string{"infra"}},
+		{Id: "pr-3", AuthorId: "u1", Status: models.PRStatusOpen},
+	}
+	service := NewStatisticsService(repo, repo, repo, config.Statistics{}, testReview, logger)
+
+	resp, err := service.GetStatistics(context.Background(), allStatistics)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []statistics.LabelStats{
+		{Label: "api", TotalPRs: 1, OpenPRs: 1},
+		{Label: "infra", TotalPRs: 2, OpenPRs: 1},
+	}, resp.ByLabel)
+	labels := make(map[string][]string, len(resp.PRStats.Items))
+	for _, pr := range resp.PRStats.Items {
+		labels[pr.PullRequestID] = pr.Labels
+	}
+	assert.Equal(t, []string{}, labels["pr-3"])
+}
+
+func TestStatisticsService_GetStatistics_Weight(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	now := time.Now().UTC()
+	repo := newCountingStatsRepo()
+	close(repo.release)
+	repo.users = []*models.User{{Id: "u2", Name: "Bob"}, {Id: "u3", Name: "Carol"}}
+	repo.assignments = []*models.ReviewAssignment{
+		{PRId: "pr-1", ReviewerId: "u2", AssignedAt: now},
+		{PRId: "pr-2", ReviewerId: "u2", AssignedAt: now.AddDate(0, 0, -7)},
+		{PRId: "pr-3", ReviewerId: "u3", AssignedAt: now.AddDate(0, 0, -40)},
+	}
+	review := config.Review{Deadline: testReview.Deadline, WeightHalfLife: 7 * 24 * time.Hour}
+	service := NewStatisticsService(repo, repo, repo, config.Statistics{}, review, logger)
+
+	resp, err := service.GetStatistics(context.Background(), allStatistics)
+
+	assert.NoError(t, err)
+	weights := make(map[string]float64, len(resp.UserStats.Items))
+	for _, stat := range resp.UserStats.Items {
+		weights[stat.UserID] = stat.Weight
+	}
+	assert.InDelta(t, 1.5, weights["u2"], 0.01)
+	assert.Zero(t, weights["u3"])
+}
+
+func TestStatisticsService_GetStatistics_Turnaround(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	ctx := context.Background()
+	storage := memory.NewStorage()
+	team := &models.Team{}
+	for _, userID := range []string{"u1", "u2", "u3", "u4"} {
+		team.Members = append(team.Members, &models.User{Id: userID, Name: userID, TeamName: "backend", IsActive: true})
+	}
+	require.NoError(t, storage.NewTeamRepository().CreateTeam(ctx, team))
+
+	mergedAt := time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC)
+	dump := storage.NewDumpRepository()
+	var prs []*models.PullRequest
+	for _, prID := range []string{"pr-1", "pr-2", "pr-3", "pr-open"} {
+		pr := &models.PullRequest{Id: prID, Title: prID, AuthorId: "u1", Status: models.PRStatusMerged,
+			CreatedAt: mergedAt.Add(-24 * time.Hour), MergedAt: &mergedAt, Priority: models.PRPriorityNormal}
+		if prID == "pr-open" {
+			pr.Status, pr.MergedAt = models.PRStatusOpen, nil
+		}
+		prs = append(prs, pr)
+	}
+	require.NoError(t, dump.InsertPullRequests(ctx, prs))
+	var assignments []*models.ReviewAssignment
+	for _, a := range []struct {
+		prID, reviewerID string
+		before           time.Duration
+	}{
+		{"pr-1", "u2", time.Hour},
+		{"pr-2", "u2", 4 * time.Hour},
+		{"pr-3", "u2", 10 * time.Hour},
+		{"pr-1", "u3", 2 * time.Hour},
+		{"pr-3", "u3", 3*time.Hour + 30*time.Minute + 400*time.Millisecond},
+		{"pr-open", "u4", 48 * time.Hour},
+	} {
+		assignments = append(assignments, &models.ReviewAssignment{PRId: a.prID, ReviewerId: a.reviewerID,
+			AssignedAt: mergedAt.Add(-a.before), Source: models.AssignmentSourceAuto, State: models.ReviewStatePending})
+	}
+	require.NoError(t, dump.InsertAssignments(ctx, assignments))
+
+	repo := storage.NewReviewerRepository()
+	service := NewStatisticsService(storage.NewUserRepository(), storage.NewPullRequestRepository(), repo,
+		config.Statistics{}, testReview, logger)
+
+	resp, err := service.GetStatistics(ctx, allStatistics)
+
+	require.NoError(t, err)
+	seconds := func(n int64) *int64 { return &n }
+	stats := make(map[string]statistics.UserStats, len(resp.UserStats.Items))
+	for _, stat := range resp.UserStats.Items {
+		stats[stat.UserID] = stat
+	}
+	// 1h, 4h and 10h average to 5h with the median in the middle
+	assert.Equal(t, seconds(18000), stats["u2"].AvgTurnaroundSeconds)
+	assert.Equal(t, seconds(14400), stats["u2"].MedianTurnaroundSeconds)
+	// the median of an even count lies between the middle values; both are rounded to whole seconds
+	assert.Equal(t, seconds(9900), stats["u3"].AvgTurnaroundSeconds)
+	assert.Equal(t, seconds(9900), stats["u3"].MedianTurnaroundSeconds)
+	// reviews of open PRs and authors don't count
+	assert.Nil(t, stats["u4"].AvgTurnaroundSeconds)
+	assert.Nil(t, stats["u4"].MedianTurnaroundSeconds)
+	assert.Nil(t, stats["u1"].AvgTurnaroundSeconds)
+
+	t.Run("Success - A replaced reviewer is not counted", func(t *testing.T) {
+		require.NoError(t, repo.ReplaceReviewer(ctx, "pr-3", "u2", "u4", models.AssignmentSourceReassign))
+
+		resp, err := service.GetStatistics(ctx, allStatistics)
+
+		require.NoError(t, err)
+		for _, stat := range resp.UserStats.Items {
+			stats[stat.UserID] = stat
+		}
+		assert.Equal(t, seconds(9000), stats["u2"].AvgTurnaroundSeconds)
+		assert.Equal(t, seconds(9000), stats["u2"].MedianTurnaroundSeconds)
+	})
+}
+
+func TestStatisticsService_GetStatistics_WithoutReviewers(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	ctx := context.Background()
+	storage := memory.NewStorage()
+	require.NoError(t, storage.NewTeamRepository().CreateTeam(ctx, &models.Team{Members: []*models.User{
+		{Id: "u1", Name: "u1", TeamName: "backend", IsActive: true},
+		{Id: "u2", Name: "u2", TeamName: "backend", IsActive: true},
+	}}))
+	createdAt := time.Now().UTC().Add(-time.Hour)
+	var prs []*models.PullRequest
+	for i := 0; i < prsWithoutReviewersLimit+2; i++ {
+		prs = append(prs, &models.PullRequest{Id: fmt.Sprintf("pr-%02d", i), Title: "PR", AuthorId: "u1",
+			Status: models.PRStatusOpen, CreatedAt: createdAt.Add(time.Duration(i) * time.Second),
+			Priority: models.PRPriorityNormal})
+	}
+	mergedAt := createdAt
+	prs = append(prs, &models.PullRequest{Id: "pr-merged", Title: "PR", AuthorId: "u1", Status: models.PRStatusMerged,
+		CreatedAt: createdAt, MergedAt: &mergedAt, Priority: models.PRPriorityNormal})
+	require.NoError(t, storage.NewDumpRepository().InsertPullRequests(ctx, prs))
+	reviewerRepo := storage.NewReviewerRepository()
+	require.NoError(t, reviewerRepo.AssignReviewer(ctx, "pr-00", "u2", models.AssignmentSourceAuto))
+
+	service := NewStatisticsService(storage.NewUserRepository(), storage.NewPullRequestRepository(), reviewerRepo,
+		config.Statistics{}, testReview, logger)
+
+	resp, err := service.GetStatistics(ctx, allStatistics)
+
+	require.NoError(t, err)
+	// merged PRs and PRs with a reviewer don't count; only the oldest are listed
+	assert.Equal(t, prsWithoutReviewersLimit+1, resp.OpenPRsWithoutReviewers)
+	assert.Len(t, resp.PRsWithoutReviewers, prsWithoutReviewersLimit)
+	assert.Equal(t, "pr-01", resp.PRsWithoutReviewers[0])
+	assert.NotContains(t, resp.PRsWithoutReviewers, "pr-21")
+}
+
+func TestStatisticsService_GetStatistics_UserOrder(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	repo := newCountingStatsRepo()
+	close(repo.release)
+	repo.users = []*models.User{{Id: "u3", Name: "Carol"}, {Id: "u1", Name: "Alice"}, {Id: "u2", Name: "Bob"}}
+	service := NewStatisticsService(repo, repo, repo, config.Statistics{}, testReview, logger)
+
+	resp, err := service.GetStatistics(context.Background(), allStatistics)
+
+	assert.NoError(t, err)
+	ids := make([]string, 0, len(resp.UserStats.Items))
+	for _, stat := range resp.UserStats.Items {
+		ids = append(ids, stat.UserID)
+	}
+	assert.Equal(t, []string{"u1", "u2", "u3"}, ids)
+}
+
+func TestStatisticsService_GetStatistics_Pages(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	repo := newCountingStatsRepo()
+	close(repo.release)
+	repo.users = []*models.User{{Id: "u3", Name: "Carol"}, {Id: "u1", Name: "Alice"}, {Id: "u2", Name: "Bob"}}
+	service := NewStatisticsService(repo, repo, repo, config.Statistics{}, testReview, logger)
+
+	resp, err := service.GetStatistics(context.Background(), statistics.StatisticsRequest{
+		Include: statistics.DefaultInclude,
+		Users:   dto.PageRequest{Limit: 1, Offset: 1},
+		PRs:     dto.PageRequest{Limit: 10, Offset: 5},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, resp.UserStats.Total)
+	assert.Equal(t, 1, resp.UserStats.Offset)
+	assert.Len(t, resp.UserStats.Items, 1)
+	assert.Equal(t, "u2", resp.UserStats.Items[0].UserID)
+	assert.Equal(t, 2, resp.PRStats.Total)
+	assert.Equal(t, []statistics.PRStats{}, resp.PRStats.Items)
+	assert.Equal(t, 2, resp.TotalPRs, "aggregates cover all PRs regardless of the page")
+}
+
+func TestStatisticsService_GetStatistics_Include(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	ctx := context.Background()
+	readCtx := dbctx.ReadOnly(ctx)
+	prs := []*models.PullRequest{
+		{Id: "pr-1", AuthorId: "u1", Status: models.PRStatusOpen},
+		{Id: "pr-2", AuthorId: "u3", Status: models.PRStatusOpen},
+		{Id: "pr-3", AuthorId: "u1", Status: models.PRStatusMerged},
+	}
+	reviewers := map[string][]string{"pr-1": {"u2"}, "pr-2": {"u1", "u2"}, "pr-3": {"u2"}}
+	users := []*models.User{
+		{Id: "u3", TeamName: "frontend", IsActive: true},
+		{Id: "u1", TeamName: "backend", IsActive: true},
+		{Id: "u2", TeamName: "backend"},
+	}
+
+	// newService expects only the calls the aggregates need; any other repository call fails the test
+	newService := func(t *testing.T) (*StatisticsService, *mocks.MockStatisticsUserRepository,
+		*mocks.MockStatisticsPRRepository, *mocks.MockStatisticsReviewerRepository) {
+		ctrl := gomock.NewController(t)
+		userRepo := mocks.NewMockStatisticsUserRepository(ctrl)
+		prRepo := mocks.NewMockStatisticsPRRepository(ctrl)
+		reviewerRepo := mocks.NewMockStatisticsReviewerRepository(ctrl)
+		prRepo.EXPECT().GetAllPRs(readCtx, "").Return(prs, nil)
+		prRepo.EXPECT().CountOpenWithoutReviewers(readCtx, "", nil, nil).Return(0, nil)
+		prRepo.EXPECT().FindOpenWithoutReviewers(readCtx, "", nil, nil, models.PRSort{}, prsWithoutReviewersLimit, 0).
+			Return(nil, nil)
+		reviewerRepo.EXPECT().GetAllReviewers(readCtx, "").Return(reviewers, nil)
+		reviewerRepo.EXPECT().CountReviewerChanges(readCtx, "", false).Return(2, nil)
+		reviewerRepo.EXPECT().GetAssignmentSourceCounts(readCtx, "", false).Return(map[string]int{
+			models.AssignmentSourceAuto: 3, models.AssignmentSourceDeactivation: 1,
+		}, nil)
+		service := NewStatisticsService(userRepo, prRepo, reviewerRepo, config.Statistics{DisableSingleflight: true},
+			testReview, logger)
+		return service, userRepo, prRepo, reviewerRepo
+	}
+
+	t.Run("Success - Aggregates only", func(t *testing.T) {
+		service, _, _, _ := newService(t)
+
+		resp, err := service.GetStatistics(ctx, statistics.StatisticsRequest{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, resp.TotalPRs)
+		assert.Equal(t, 2, resp.OpenPRs)
+		assert.Equal(t, 4, resp.TotalAssignments)
+		assert.Equal(t, 2, resp.ReassignmentEvents)
+		assert.Equal(t, []statistics.SourceStats{
+			{Source: models.AssignmentSourceAuto, Assignments: 3},
+			{Source: models.AssignmentSourceManual},
+			{Source: models.AssignmentSourceReassign},
+			{Source: models.AssignmentSourceDeactivation, Assignments: 1},
+			{Source: models.AssignmentSourceEscalation},
+		}, resp.BySource)
+		assert.Nil(t, resp.UserStats)
+		assert.Nil(t, resp.PRStats)
+		assert.Nil(t, resp.TeamStats)
+		assert.Nil(t, resp.AuthorStats)
+	})
+
+	t.Run("Success - Author stats keep unknown authors", func(t *testing.T) {
+		service, _, prRepo, _ := newService(t)
+		alice := "Alice"
+		prRepo.EXPECT().GetAuthorStats(readCtx, "", false).Return([]*models.AuthorStats{
+			{AuthorId: "u1", Username: &alice, TotalPRs: 2, OpenPRs: 1, MergedPRs: 1, Reviewers: 2},
+			{AuthorId: "gone", TotalPRs: 1, OpenPRs: 1, Reviewers: 2},
+		}, nil)
+
+		resp, err := service.GetStatistics(ctx, statistics.StatisticsRequest{
+			Include: statistics.Include{AuthorStats: true},
+		})
+
+		assert.NoError(t, err)
+		assert.Nil(t, resp.UserStats)
+		assert.Equal(t, []statistics.AuthorStats{
+			{AuthorID: "u1", Username: &alice, TotalPRs: 2, OpenPRs: 1, MergedPRs: 1, AvgReviewers: 1},
+			{AuthorID: "gone", TotalPRs: 1, OpenPRs: 1, AvgReviewers: 2},
+		}, resp.AuthorStats)
+	})
+
+	t.Run("Success - PR stats skip the user repository", func(t *testing.T) {
+		service, _, _, reviewerRepo := newService(t)
+		reviewerRepo.EXPECT().GetReassignmentCounts(readCtx, "", false).Return(map[string]int{"pr-2": 1}, nil)
+
+		resp, err := service.GetStatistics(ctx, statistics.StatisticsRequest{
+			Include: statistics.Include{PRStats: true},
+			PRs:     dto.PageRequest{Limit: math.MaxInt},
+		})
+
+		assert.NoError(t, err)
+		assert.Nil(t, resp.UserStats)
+		if assert.NotNil(t, resp.PRStats) {
+			assert.Equal(t, 3, resp.PRStats.Total)
+			assert.Equal(t, 1, resp.PRStats.Items[1].ReassignmentsCount)
+		}
+	})
+
+	t.Run("Success - Team stats skip the per-user aggregation", func(t *testing.T) {
+		service, userRepo, _, reviewerRepo := newService(t)
+		withReviewer := append([]*models.User{{Id: "u4", TeamName: "frontend", IsActive: true}}, users...)
+		userRepo.EXPECT().GetAllUsers(readCtx).Return(withReviewer, nil)
+		reviewerRepo.EXPECT().GetReviewLoads(readCtx, "").Return([]*models.ReviewLoad{
+			{UserId: "u1", TeamName: "backend", ActiveReviews: 1},
+			{UserId: "u3", TeamName: "frontend", ActiveReviews: 3},
+			{UserId: "u4", TeamName: "frontend", ActiveReviews: 1},
+		}, nil)
+
+		resp, err := service.GetStatistics(ctx, statistics.StatisticsRequest{
+			Include: statistics.Include{TeamStats: true},
+		})
+
+		assert.NoError(t, err)
+		assert.Nil(t, resp.UserStats)
+		assert.Nil(t, resp.PRStats)
+		half := 0.5
+		assert.Equal(t, []statistics.TeamStats{
+			{TeamName: "backend", Members: 2, ActiveMembers: 1, OpenPRs: 1, ActiveReviews: 3},
+			{TeamName: "frontend", Members: 2, ActiveMembers: 2, OpenPRs: 1, BalanceScore: &half},
+		}, resp.TeamStats)
+	})
+
+	t.Run("Success - User stats skip the reassignment counts", func(t *testing.T) {
+		service, userRepo, _, reviewerRepo := newService(t)
+		userRepo.EXPECT().GetAllUsers(readCtx).Return(users, nil)
+		reviewerRepo.EXPECT().GetAllReviewerCounts(readCtx, "").Return(map[string]int{"u2": 3}, nil)
+		reviewerRepo.EXPECT().GetAssignmentTimes(readCtx, gomock.Any(), gomock.Any()).Return(nil, nil)
+		reviewerRepo.EXPECT().GetReviewTurnarounds(readCtx, "", false).Return(map[string]models.ReviewTurnaround{
+			"u2": {AvgSeconds: 90.4, MedianSeconds: 60.5},
+		}, nil)
+
+		resp, err := service.GetStatistics(ctx, statistics.StatisticsRequest{
+			Include: statistics.Include{UserStats: true},
+			Users:   dto.PageRequest{Limit: math.MaxInt},
+		})
+
+		assert.NoError(t, err)
+		assert.Nil(t, resp.PRStats)
+		avg, median := int64(90), int64(61)
+		if assert.NotNil(t, resp.UserStats) {
+			assert.Equal(t, []statistics.UserStats{
+				{UserID: "u1", ActiveReviews: 1},
+				{UserID: "u2", AssignmentsCount: 3, ActiveReviews: 2, AvgTurnaroundSeconds: &avg,
+					MedianTurnaroundSeconds: &median},
+				{UserID: "u3"},
+			}, resp.UserStats.Items)
+		}
+	})
+}
+
+func TestStatisticsService_GetStatistics_Team(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	ctx := context.Background()
+	storage := memory.NewStorage()
+	teamRepo := storage.NewTeamRepository()
+	for team, userIDs := range map[string][]string{"backend": {"u1", "u2"}, "frontend": {"f1", "f2"}} {
+		members := make([]*models.User, 0, len(userIDs))
+		for _, userID := range userIDs {
+			members = append(members, &models.User{Id: userID, Name: userID, TeamName: team, IsActive: true})
+		}
+		require.NoError(t, teamRepo.CreateTeam(ctx, &models.Team{Members: members}))
+	}
+
+	now := time.Now().UTC()
+	mergedAt, oldMergedAt := now.Add(-time.Hour), now.Add(-30*24*time.Hour)
+	prs := []*models.PullRequest{
+		{Id: "pr-backend", AuthorId: "u1", Status: models.PRStatusOpen, CreatedAt: now.Add(-3 * time.Hour)},
+		{Id: "pr-merged", AuthorId: "f1", Status: models.PRStatusMerged, CreatedAt: now.Add(-2 * time.Hour),
+			MergedAt: &mergedAt},
+		{Id: "pr-unassigned", AuthorId: "f1", Status: models.PRStatusOpen, CreatedAt: now.Add(-time.Hour)},
+		{Id: "pr-old", AuthorId: "f1", Status: models.PRStatusMerged, CreatedAt: oldMergedAt.Add(-time.Hour),
+			MergedAt: &oldMergedAt},
+	}
+	var assignments []*models.ReviewAssignment
+	for prID, reviewerID := range map[string]string{"pr-backend": "u2", "pr-merged": "f2", "pr-old": "f2"} {
+		assignments = append(assignments, &models.ReviewAssignment{PRId: prID, ReviewerId: reviewerID,
+			AssignedAt: now.Add(-4 * time.Hour), Source: models.AssignmentSourceAuto, State: models.ReviewStatePending})
+	}
+	for _, pr := range prs {
+		pr.Title, pr.Priority = pr.Id, models.PRPriorityNormal
+	}
+	dump := storage.NewDumpRepository()
+	require.NoError(t, dump.InsertPullRequests(ctx, prs))
+	require.NoError(t, dump.InsertAssignments(ctx, assignments))
+	_, err := storage.NewArchiveRepository().ArchiveMerged(ctx, now.Add(-24*time.Hour), 10)
+	require.NoError(t, err)
+
+	service := NewStatisticsService(storage.NewUserRepository(), storage.NewPullRequestRepository(),
+		storage.NewReviewerRepository(), config.Statistics{}, testReview, logger)
+	frontend := allStatistics
+	frontend.Include.TeamStats = true
+	frontend.TeamName = "frontend"
+
+	t.Run("Success - Only the team's members and the PRs they authored", func(t *testing.T) {
+		resp, err := service.GetStatistics(ctx, frontend)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, resp.TotalPRs)
+		assert.Equal(t, 1, resp.OpenPRs)
+		assert.Equal(t, 1, resp.MergedPRs)
+		assert.Equal(t, 1, resp.TotalAssignments)
+		assert.Equal(t, 1, resp.OpenPRsWithoutReviewers)
+		assert.Equal(t, []string{"pr-unassigned"}, resp.PRsWithoutReviewers)
+		require.Len(t, resp.UserStats.Items, 2)
+		assert.Equal(t, "f1", resp.UserStats.Items[0].UserID)
+		assert.Equal(t, "f2", resp.UserStats.Items[1].UserID)
+		assert.Equal(t, 1, resp.UserStats.Items[1].AssignmentsCount)
+		require.Len(t, resp.PRStats.Items, 2)
+		assert.Equal(t, "pr-unassigned", resp.PRStats.Items[0].PullRequestID)
+		assert.Equal(t, "pr-merged", resp.PRStats.Items[1].PullRequestID)
+		require.Len(t, resp.TeamStats, 1)
+		assert.Equal(t, "frontend", resp.TeamStats[0].TeamName)
+	})
+
+	t.Run("Success - Archived PRs of the team", func(t *testing.T) {
+		req := frontend
+		req.IncludeArchived = true
+
+		resp, err := service.GetStatistics(ctx, req)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, resp.TotalPRs)
+		assert.Equal(t, 2, resp.MergedPRs)
+		assert.Equal(t, 2, resp.TotalAssignments)
+		assert.Equal(t, 2, resp.UserStats.Items[1].AssignmentsCount)
+	})
+
+	t.Run("Success - Author stats of the team", func(t *testing.T) {
+		req := frontend
+		req.Include = statistics.Include{AuthorStats: true}
+		req.IncludeArchived = true
+
+		resp, err := service.GetStatistics(ctx, req)
+
+		require.NoError(t, err)
+		f1 := "f1"
+		assert.Equal(t, []statistics.AuthorStats{
+			{AuthorID: "f1", Username: &f1, TotalPRs: 3, OpenPRs: 1, MergedPRs: 2, AvgReviewers: 2.0 / 3},
+		}, resp.AuthorStats)
+	})
+
+	t.Run("Success - Without a team everything is counted", func(t *testing.T) {
+		resp, err := service.GetStatistics(ctx, allStatistics)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, resp.TotalPRs)
+		assert.Len(t, resp.UserStats.Items, 4)
+	})
+
+	t.Run("Error - Team not found", func(t *testing.T) {
+		req := frontend
+		req.TeamName = "missing"
+
+		_, err := service.GetStatistics(ctx, req)
+
+		assert.True(t, errors.HasCode(err, errors.CodeNotFound))
+	})
+}
+
+func TestStatisticsService_GetUserStatistics(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	ctx := context.Background()
+	storage := memory.NewStorage()
+	members := make([]*models.User, 0, 3)
+	for _, userID := range []string{"u1", "u2", "u3"} {
+		members = append(members, &models.User{Id: userID, Name: userID, TeamName: "backend", IsActive: true})
+	}
+	require.NoError(t, storage.NewTeamRepository().CreateTeam(ctx, &models.Team{Members: members}))
+
+	now := time.Now().UTC()
+	mergedAt, oldMergedAt := now.Add(-time.Hour), now.Add(-30*24*time.Hour)
+	prs := []*models.PullRequest{
+		{Id: "pr-open", AuthorId: "u1", Status: models.PRStatusOpen, CreatedAt: now.Add(-3 * time.Hour)},
+		{Id: "pr-merged", AuthorId: "u1", Status: models.PRStatusMerged, CreatedAt: now.Add(-5 * time.Hour),
+			MergedAt: &mergedAt},
+		{Id: "pr-old", AuthorId: "u1", Status: models.PRStatusMerged, CreatedAt: oldMergedAt.Add(-time.Hour),
+			MergedAt: &oldMergedAt},
+		{Id: "pr-other", AuthorId: "u3", Status: models.PRStatusOpen, CreatedAt: now.Add(-time.Hour)},
+	}
+	for _, pr := range prs {
+		pr.Title, pr.Priority = pr.Id, models.PRPriorityNormal
+	}
+	assignments := []*models.ReviewAssignment{
+		{PRId: "pr-open", ReviewerId: "u2", AssignedAt: now.Add(-2 * time.Hour), Source: models.AssignmentSourceAuto},
+		{PRId: "pr-merged", ReviewerId: "u2", AssignedAt: now.Add(-3 * time.Hour), Source: models.AssignmentSourceManual},
+		{PRId: "pr-old", ReviewerId: "u2", AssignedAt: oldMergedAt.Add(-time.Hour), Source: models.AssignmentSourceAuto},
+		{PRId: "pr-other", ReviewerId: "u1", AssignedAt: now.Add(-time.Hour), Source: models.AssignmentSourceAuto},
+	}
+	for _, a := range assignments {
+		a.State = models.ReviewStatePending
+	}
+	dump := storage.NewDumpRepository()
+	require.NoError(t, dump.InsertPullRequests(ctx, prs))
+	require.NoError(t, dump.InsertAssignments(ctx, assignments))
+	_, err := storage.NewArchiveRepository().ArchiveMerged(ctx, now.Add(-24*time.Hour), 10)
+	require.NoError(t, err)
+
+	service := NewStatisticsService(storage.NewUserRepository(), storage.NewPullRequestRepository(),
+		storage.NewReviewerRepository(), config.Statistics{}, testReview, logger)
+	statsOf := func(t *testing.T, includeArchived bool, userID string) (statistics.UserStats, statistics.AuthorStats) {
+		req := allStatistics
+		req.Include.AuthorStats = true
+		req.IncludeArchived = includeArchived
+		resp, err := service.GetStatistics(ctx, req)
+		require.NoError(t, err)
+		var user statistics.UserStats
+		for _, stat := range resp.UserStats.Items {
+			if stat.UserID == userID {
+				user = stat
+			}
+		}
+		var author statistics.AuthorStats
+		for _, stat := range resp.AuthorStats {
+			if stat.AuthorID == userID {
+				author = stat
+			}
+		}
+		return user, author
+	}
+
+	for _, includeArchived := range []bool{false, true} {
+		t.Run(fmt.Sprintf("Success - Same as the statistics with include_archived=%t", includeArchived),
+			func(t *testing.T) {
+				for _, userID := range []string{"u1", "u2"} {
+					user, author := statsOf(t, includeArchived, userID)
+
+					resp, err := service.GetUserStatistics(ctx,
+						statistics.UserStatisticsRequest{UserID: userID, IncludeArchived: includeArchived})
+
+					require.NoError(t, err)
+					assert.InDelta(t, user.Weight, resp.Weight, 1e-6)
+					resp.Weight, user.Weight = 0, 0
+					assert.Equal(t, user, resp.UserStats)
+					if userID == "u1" {
+						assert.Equal(t, author, resp.Authored)
+					}
+				}
+			})
+	}
+
+	t.Run("Success - Recent assignments come latest first", func(t *testing.T) {
+		resp, err := service.GetUserStatistics(ctx, statistics.UserStatisticsRequest{UserID: "u2", IncludeArchived: true})
+
+		require.NoError(t, err)
+		require.Len(t, resp.RecentAssignments, 3)
+		assert.Equal(t, statistics.RecentAssignment{
+			PullRequestID: "pr-open",
+			AssignedAt:    dto.FormatTime(now.Add(-2 * time.Hour)),
+			Source:        models.AssignmentSourceAuto,
+			State:         models.ReviewStatePending,
+		}, resp.RecentAssignments[0])
+		assert.Equal(t, "pr-merged", resp.RecentAssignments[1].PullRequestID)
+		assert.Equal(t, "pr-old", resp.RecentAssignments[2].PullRequestID)
+	})
+
+	t.Run("Success - A user without assignments", func(t *testing.T) {
+		resp, err := service.GetUserStatistics(ctx, statistics.UserStatisticsRequest{UserID: "u3"})
+
+		require.NoError(t, err)
+		username := "u3"
+		assert.Equal(t, statistics.AuthorStats{AuthorID: "u3", Username: &username, TotalPRs: 1, OpenPRs: 1,
+			AvgReviewers: 1}, resp.Authored)
+		assert.Zero(t, resp.AssignmentsCount)
+		assert.Nil(t, resp.AvgTurnaroundSeconds)
+		assert.Empty(t, resp.RecentAssignments)
+		assert.NotNil(t, resp.RecentAssignments)
+	})
+
+	t.Run("Success - A user who authored nothing", func(t *testing.T) {
+		resp, err := service.GetUserStatistics(ctx, statistics.UserStatisticsRequest{UserID: "u2"})
+
+		require.NoError(t, err)
+		username := "u2"
+		assert.Equal(t, statistics.AuthorStats{AuthorID: "u2", Username: &username}, resp.Authored)
+	})
+
+	t.Run("Error - User not found", func(t *testing.T) {
+		_, err := service.GetUserStatistics(ctx, statistics.UserStatisticsRequest{UserID: "missing"})
+
+		assert.True(t, errors.HasCode(err, errors.CodeNotFound))
+	})
+}
+
+func TestStatisticsService_GetDistribution(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	ctx := context.Background()
+	storage := memory.NewStorage()
+	teamRepo := storage.NewTeamRepository()
+	backend := &models.Team{}
+	for _, userID := range []string{"u1", "u2", "u3", "u4"} {
+		backend.Members = append(backend.Members, &models.User{Id: userID, Name: userID, TeamName: "backend",
+			IsActive: userID != "u4"})
+	}
+	require.NoError(t, teamRepo.CreateTeam(ctx, backend))
+	require.NoError(t, teamRepo.CreateTeam(ctx, &models.Team{Members: []*models.User{
+		{Id: "f1", Name: "f1", TeamName: "frontend", IsActive: true},
+	}}))
+
+	createdAt := time.Now().UTC().Add(-time.Hour)
+	var prs []*models.PullRequest
+	var assignments []*models.ReviewAssignment
+	assign := func(prID, status string, reviewerIDs ...string) {
+		prs = append(prs, &models.PullRequest{Id: prID, Title: prID, AuthorId: "u1", Status: status,
+			CreatedAt: createdAt, Priority: models.PRPriorityNormal})
+		for _, reviewerID := range reviewerIDs {
+			assignments = append(assignments, &models.ReviewAssignment{PRId: prID, ReviewerId: reviewerID,
+				AssignedAt: createdAt, Source: models.AssignmentSourceAuto, State: models.ReviewStatePending})
+		}
+	}
+	for i := 0; i < 6; i++ {
+		assign(fmt.Sprintf("pr-%d", i), models.PRStatusOpen, "u2")
+	}
+	assign("pr-open", models.PRStatusOpen, "u3", "f1")
+	assign("pr-merged", models.PRStatusMerged, "u3", "u4")
+	dump := storage.NewDumpRepository()
+	require.NoError(t, dump.InsertPullRequests(ctx, prs))
+	require.NoError(t, dump.InsertAssignments(ctx, assignments))
+
+	service := NewStatisticsService(storage.NewUserRepository(), storage.NewPullRequestRepository(),
+		storage.NewReviewerRepository(), config.Statistics{}, testReview, logger)
+	buckets := func(counts ...int) []statistics.DistributionBucket {
+		labels := []string{"0", "1", "2", "3", "4", "5+"}
+		result := make([]statistics.DistributionBucket, len(labels))
+		for i, label := range labels {
+			result[i] = statistics.DistributionBucket{Bucket: label, Users: counts[i]}
+		}
+		return result
+	}
+
+	t.Run("Success - Histograms of a team's active users", func(t *testing.T) {
+		resp, err := service.GetDistribution(ctx, statistics.DistributionRequest{TeamName: "backend"})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, resp.ActiveUsers)
+		assert.Equal(t, buckets(1, 1, 0, 0, 0, 1), resp.ActiveReviews)
+		assert.Equal(t, buckets(1, 0, 1, 0, 0, 1), resp.TotalAssignments)
+		assert.Nil(t, resp.Users)
+	})
+
+	t.Run("Success - All teams", func(t *testing.T) {
+		resp, err := service.GetDistribution(ctx, statistics.DistributionRequest{})
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, resp.ActiveUsers)
+		assert.Equal(t, buckets(1, 2, 0, 0, 0, 1), resp.ActiveReviews)
+	})
+
+	t.Run("Success - Detail lists the most loaded first", func(t *testing.T) {
+		resp, err := service.GetDistribution(ctx, statistics.DistributionRequest{TeamName: "backend", Detail: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, []statistics.DistributionUser{
+			{UserID: "u2", Username: "u2", TeamName: "backend", ActiveReviews: 6, TotalAssignments: 6},
+			{UserID: "u3", Username: "u3", TeamName: "backend", ActiveReviews: 1, TotalAssignments: 2},
+			{UserID: "u1", Username: "u1", TeamName: "backend"},
+		}, resp.Users)
+	})
+
+	t.Run("Error - Team not found", func(t *testing.T) {
+		_, err := service.GetDistribution(ctx, statistics.DistributionRequest{TeamName: "missing"})
+
+		assert.True(t, errors.HasCode(err, errors.CodeNotFound))
+	})
+}
+
+func TestStatisticsService_GetTimeseries(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	ctx := context.Background()
+	storage := memory.NewStorage()
+	require.NoError(t, storage.NewTeamRepository().CreateTeam(ctx, &models.Team{Members: []*models.User{
+		{Id: "u1", Name: "u1", TeamName: "backend", IsActive: true},
+	}}))
+	require.NoError(t, storage.NewTeamRepository().CreateTeam(ctx, &models.Team{Members: []*models.User{
+		{Id: "f1", Name: "f1", TeamName: "frontend", IsActive: true},
+	}}))
+
+	// 2024-06-03 is a Monday
+	monday := time.Date(2024, time.June, 3, 0, 0, 0, 0, time.UTC)
+	mergedAt := monday.AddDate(0, 0, 8).Add(10 * time.Hour)
+	prs := []*models.PullRequest{
+		{Id: "pr-1", Title: "pr-1", AuthorId: "u1", Status: models.PRStatusMerged, CreatedAt: monday.Add(9 * time.Hour),
+			MergedAt: &mergedAt, Priority: models.PRPriorityNormal},
+		{Id: "pr-2", Title: "pr-2", AuthorId: "u1", Status: models.PRStatusOpen, CreatedAt: monday.Add(11 * time.Hour),
+			Priority: models.PRPriorityNormal},
+		{Id: "pr-3", Title: "pr-3", AuthorId: "u1", Status: models.PRStatusOpen,
+			CreatedAt: monday.AddDate(0, 0, 15), Priority: models.PRPriorityNormal},
+		{Id: "pr-4", Title: "pr-4", AuthorId: "f1", Status: models.PRStatusOpen,
+			CreatedAt: monday.Add(12 * time.Hour), Priority: models.PRPriorityNormal},
+	}
+	require.NoError(t, storage.NewDumpRepository().InsertPullRequests(ctx, prs))
+	require.NoError(t, storage.NewReviewerRepository().RecordReviewerChange(ctx, &models.ReviewerChange{
+		PRId: "pr-2", OldReviewerId: "u2", Trigger: models.ReviewerChangeManual, ChangedAt: monday.AddDate(0, 0, 2),
+	}))
+	require.NoError(t, storage.NewReviewerRepository().RecordReviewerChange(ctx, &models.ReviewerChange{
+		PRId: "pr-4", OldReviewerId: "f2", Trigger: models.ReviewerChangeManual, ChangedAt: monday.AddDate(0, 0, 2),
+	}))
+
+	service := NewStatisticsService(storage.NewUserRepository(), storage.NewPullRequestRepository(),
+		storage.NewReviewerRepository(), config.Statistics{MaxTimeseriesBuckets: 30}, testReview, logger)
+
+	t.Run("Success - Weeks with empty ones in between", func(t *testing.T) {
+		resp, err := service.GetTimeseries(ctx, statistics.TimeseriesRequest{
+			Bucket: models.ActivityBucketWeek, From: monday.Add(-24 * time.Hour), To: monday.AddDate(0, 0, 28),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []statistics.TimeseriesBucket{
+			{Start: "2024-05-27T00:00:00Z"},
+			{Start: "2024-06-03T00:00:00Z", Created: 3, Reassignments: 2},
+			{Start: "2024-06-10T00:00:00Z", Merged: 1},
+			{Start: "2024-06-17T00:00:00Z", Created: 1},
+			{Start: "2024-06-24T00:00:00Z"},
+		}, resp.Buckets)
+	})
+
+	t.Run("Success - Days count only events within the range", func(t *testing.T) {
+		resp, err := service.GetTimeseries(ctx, statistics.TimeseriesRequest{
+			Bucket: models.ActivityBucketDay, From: monday.Add(10 * time.Hour), To: monday.AddDate(0, 0, 3),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []statistics.TimeseriesBucket{
+			{Start: "2024-06-03T00:00:00Z", Created: 2},
+			{Start: "2024-06-04T00:00:00Z"},
+			{Start: "2024-06-05T00:00:00Z", Reassignments: 2},
+		}, resp.Buckets)
+	})
+
+	t.Run("Success - Team counts the PRs of its members within the range", func(t *testing.T) {
+		resp, err := service.GetTimeseries(ctx, statistics.TimeseriesRequest{
+			Bucket: models.ActivityBucketDay, TeamName: "frontend", From: monday, To: monday.AddDate(0, 0, 3),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []statistics.TimeseriesBucket{
+			{Start: "2024-06-03T00:00:00Z", Created: 1},
+			{Start: "2024-06-04T00:00:00Z"},
+			{Start: "2024-06-05T00:00:00Z", Reassignments: 1},
+		}, resp.Buckets)
+	})
+
+	t.Run("Error - Unknown team", func(t *testing.T) {
+		_, err := service.GetTimeseries(ctx, statistics.TimeseriesRequest{
+			Bucket: models.ActivityBucketDay, TeamName: "ghost", From: monday, To: monday.AddDate(0, 0, 3),
+		})
+
+		assert.True(t, errors.HasCode(err, errors.CodeNotFound))
+	})
+
+	t.Run("Error - Range longer than the cap", func(t *testing.T) {
+		_, err := service.GetTimeseries(ctx, statistics.TimeseriesRequest{
+			Bucket: models.ActivityBucketDay, From: monday, To: monday.AddDate(0, 0, 31),
+		})
+
+		assert.True(t, errors.HasCode(err, errors.CodeValidation))
+	})
+
+	t.Run("Error - Empty range", func(t *testing.T) {
+		_, err := service.GetTimeseries(ctx, statistics.TimeseriesRequest{
+			Bucket: models.ActivityBucketDay, From: monday, To: monday,
+		})
+
+		assert.True(t, errors.HasCode(err, errors.CodeValidation))
+	})
+}
+
+func TestBalanceScore(t *testing.T) {
+	cases := []struct {
+		name   string
+		counts []int
+		want   float64
+	}{
+		{name: "Nobody reviewing is even", counts: []int{0, 0, 0}, want: 1},
+		{name: "Equal loads are even", counts: []int{2, 2, 2, 2}, want: 1},
+		{name: "One member with all reviews", counts: []int{0, 6, 0}, want: 0},
+		{name: "Two members", counts: []int{1, 3}, want: 0.5},
+		// the pairwise differences sum to 16 and the maximum for 8 reviews over 4 members is 24
+		{name: "Skewed team", counts: []int{0, 1, 2, 5}, want: 1 - 16.0/24},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := balanceScore(tc.counts)
+
+			require.NotNil(t, got)
+			assert.InDelta(t, tc.want, *got, 1e-9)
+		})
+	}
+
+	t.Run("Fewer than two members have no score", func(t *testing.T) {
+		assert.Nil(t, balanceScore(nil))
+		assert.Nil(t, balanceScore([]int{4}))
+	})
+}
@@ -1,33 +1,54 @@
 package service
 
+//go:generate go tool mockgen -source=$GOFILE -destination=mocks/mock_team_deps.go -package=mocks
+
 import (
 	"context"
 	"log/slog"
+	"time"
 
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dbctx"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/pullrequest"
 	"github.com/shirr9/pr-reviewer-service/internal/app/dto/team"
 	"github.com/shirr9/pr-reviewer-service/internal/domain/errors"
 	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
 )
 
 // TeamRepository defines the interface for team and user management operations.
+// CreateTeam must return TEAM_EXISTS AppError if the team exists, atomically with the creation.
+// GetTeamByName and ListTeams report a lead only while they are a member of the team.
 type TeamRepository interface {
-	CreateOrUpdateTeam(ctx context.Context, team *models.Team) error
+	CreateTeam(ctx context.Context, team *models.Team) error
 	GetTeamByName(ctx context.Context, teamName string) (*models.Team, error)
-	IsExists(ctx context.Context, teamName string) (bool, error)
+	ListTeams(ctx context.Context) ([]*models.Team, error)
 }
 
 type TeamUserRepository interface {
+	FindByID(ctx context.Context, userID string) (*models.User, error)
 	FindByTeamName(ctx context.Context, teamName string) ([]*models.User, error)
 	DeactivateTeamUsers(ctx context.Context, teamName string) (int, error)
+	FindActiveCandidatesForReassignment(ctx context.Context, teamName string, excludeUserIDs []string) ([]*models.User, error)
+	LockActiveCandidate(ctx context.Context, userID string) (bool, error)
+	FindByIDs(ctx context.Context, userIDs []string) ([]*models.User, error)
+	GetExcludedReviewers(ctx context.Context, authorID string) ([]string, error)
 }
 
 type TeamPRRepository interface {
 	FindOpenPRsByReviewers(ctx context.Context, reviewerIDs []string) ([]*models.PullRequest, error)
+	FindOpenPRsByAuthors(ctx context.Context, authorIDs []string) ([]*models.PullRequest, error)
+	UpdateStatus(ctx context.Context, prID, fromStatus, status string, mergedAt *time.Time) (int, error)
+	FindOpenPRsReviewedByTeam(ctx context.Context, teamName string, order models.PRSort) ([]*models.PullRequest, error)
 }
 
 type TeamReviewerRepository interface {
 	GetReviewers(ctx context.Context, prID string) ([]string, error)
 	RemoveReviewer(ctx context.Context, prID, reviewerID string) error
+	ReplaceReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID, source string) error
+	RecordReviewerChange(ctx context.Context, change *models.ReviewerChange) error
+	GetAssignmentsByPRs(ctx context.Context, prIDs []string) ([]*models.ReviewAssignment, error)
+	GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error)
 }
 
 type TeamTransactor interface {
@@ -41,6 +62,7 @@ type TeamService struct {
 	prRepo       TeamPRRepository
 	reviewerRepo TeamReviewerRepository
 	uow          TeamTransactor
+	review       config.Review
 	log          *slog.Logger
 }
 
@@ -51,6 +73,7 @@ func NewTeamService(
 	prRepo TeamPRRepository,
 	reviewerRepo TeamReviewerRepository,
 	uow TeamTransactor,
+	review config.Review,
 	log *slog.Logger,
 ) *TeamService {
 	if log == nil {
@@ -62,6 +85,7 @@ func NewTeamService(
 		prRepo:       prRepo,
 		reviewerRepo: reviewerRepo,
 		uow:          uow,
+		review:       review,
 		log:          log,
 	}
 }
@@ -71,37 +95,40 @@ func (s *TeamService) AddTeam(ctx context.Context, req team.AddTeamRequest) (*te
 	if len(req.Members) == 0 {
 		s.log.LogAttrs(ctx, slog.LevelWarn, "team must have at least one member",
 			slog.String("team_name", req.TeamName))
-		return nil, errors.New("BAD_REQUEST", "team must have at least one member")
-	}
-
-	exists, err := s.teamRepo.IsExists(ctx, req.TeamName)
-	if err != nil {
-		s.log.LogAttrs(ctx, slog.LevelError, "failed to check team existence",
-			slog.String("team_name", req.TeamName), slog.String("error", err.Error()))
-		return nil, err
-	}
-
-	if exists {
-		s.log.LogAttrs(ctx, slog.LevelWarn, "team already exists",
-			slog.String("team_name", req.TeamName))
-		return nil, errors.NewTeamExists("team_name already exists")
+		return nil, errors.NewBadRequest("team must have at least one member")
 	}
 
 	domainTeam := &models.Team{
-		Members: make([]*models.User, 0, len(req.Members)),
+		Members:     make([]*models.User, 0, len(req.Members)),
+		Description: req.Description,
+		LeadId:      req.LeadID,
+		CreatedAt:   time.Now().UTC(),
 	}
 
+	members := make([]team.TeamMember, 0, len(req.Members))
+	var reviewers int
 	for _, memberDTO := range req.Members {
-		domainTeam.Members = append(domainTeam.Members, &models.User{
-			Id:       memberDTO.UserID,
-			Name:     memberDTO.Username,
-			TeamName: req.TeamName,
-			IsActive: memberDTO.IsActive,
-		})
+		memberDTO, user := newTeamMember(req.TeamName, memberDTO)
+		if !user.NonReviewer {
+			reviewers++
+		}
+		members = append(members, memberDTO)
+		domainTeam.Members = append(domainTeam.Members, user)
+	}
+
+	if req.LeadID != "" && !domainTeam.HasMember(req.LeadID) {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "team lead is not a member",
+			slog.String("team_name", req.TeamName), slog.String("lead_id", req.LeadID))
+		return nil, errors.NewBadRequest("lead_id must be a member of the team")
 	}
 
-	if err = s.teamRepo.CreateOrUpdateTeam(ctx, domainTeam); err != nil {
-		s.log.LogAttrs(ctx, slog.LevelError, "failed to create team",
+	if err := s.teamRepo.CreateTeam(ctx, domainTeam); err != nil {
+		if errors.HasCode(err, errors.CodeTeamExists) {
+			s.log.LogAttrs(ctx, slog.LevelWarn, "team already exists",
+				slog.String("team_name", req.TeamName))
+			return nil, s.existingTeamError(ctx, req.TeamName, err)
+		}
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to create team",
 			slog.String("team_name", req.TeamName), slog.String("error", err.Error()))
 		return nil, err
 	}
@@ -110,19 +137,123 @@ func (s *TeamService) AddTeam(ctx context.Context, req team.AddTeamRequest) (*te
 		slog.String("team_name", req.TeamName),
 		slog.Int("members_count", len(req.Members)))
 
-	return &team.AddTeamResponse{
+	response := &team.AddTeamResponse{
 		Team: team.Team{
-			TeamName: req.TeamName,
-			Members:  req.Members,
+			TeamName:    req.TeamName,
+			Description: req.Description,
+			LeadID:      req.LeadID,
+			CreatedAt:   dto.FormatTime(domainTeam.CreatedAt),
+			Members:     members,
 		},
-	}, nil
+	}
+	if reviewers == 0 {
+		// the team may still be given reviewers later, so it is created anyway
+		s.log.LogAttrs(ctx, slog.LevelWarn, "team has no reviewers",
+			slog.String("team_name", req.TeamName))
+		response.Warnings = append(response.Warnings, "no member is a reviewer, so PRs of the team get no reviewers")
+	}
+	return response, nil
+}
+
+// newTeamMember returns the member of the team with its tags normalized and IsReviewer set, and the
+// user it is stored as.
+func newTeamMember(teamName string, member team.TeamMember) (team.TeamMember, *models.User) {
+	if len(member.Tags) > 0 {
+		member.Tags = models.NormalizeTags(member.Tags)
+	}
+	isReviewer := member.InReviewerPool()
+	member.IsReviewer = &isReviewer
+	return member, &models.User{
+		Id:               member.UserID,
+		Name:             member.Username,
+		TeamName:         teamName,
+		IsActive:         member.IsActive,
+		MaxActiveReviews: member.MaxActiveReviews,
+		Tags:             member.Tags,
+		Timezone:         member.Timezone,
+		WorkStart:        member.WorkHoursStart,
+		WorkEnd:          member.WorkHoursEnd,
+		NonReviewer:      !isReviewer,
+	}
+}
+
+// ImportTeams creates the teams one by one as AddTeam does, so members that already exist are
+// moved to their new team. A team that can't be created is reported in its result and the import
+// goes on; only a canceled context stops it.
+func (s *TeamService) ImportTeams(ctx context.Context, req team.ImportTeamsRequest) (*team.ImportTeamsResponse, error) {
+	response := team.ImportTeamsResponse{
+		Results:   make([]team.ImportTeamResult, 0, len(req.Teams)),
+		RowErrors: []team.ImportRowError{},
+	}
+	for _, t := range req.Teams {
+		result := team.ImportTeamResult{TeamName: t.TeamName, Members: len(t.Members)}
+		_, err := s.AddTeam(ctx, t)
+		switch {
+		case err == nil:
+			result.Result = team.ImportResultCreated
+			response.Summary.Created++
+		case ctx.Err() != nil:
+			return nil, ctx.Err()
+		case errors.HasCode(err, errors.CodeTeamExists):
+			result.Result = team.ImportResultAlreadyExists
+			result.Error = err.Error()
+			response.Summary.AlreadyExists++
+		default:
+			result.Result = team.ImportResultFailed
+			result.Error = err.Error()
+			response.Summary.Failed++
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "team import finished",
+		slog.Int("requested", len(req.Teams)),
+		slog.Int("created", response.Summary.Created),
+		slog.Int("already_exists", response.Summary.AlreadyExists),
+		slog.Int("failed", response.Summary.Failed))
+
+	return &response, nil
+}
+
+// existingTeamError attaches the summary of the existing team to the TEAM_EXISTS conflict,
+// so the client learns its roster without another request. The conflict is returned as is when
+// the team can't be read, e.g. when it was deleted in the meantime.
+func (s *TeamService) existingTeamError(ctx context.Context, teamName string, conflict error) error {
+	existing, err := s.teamRepo.GetTeamByName(ctx, teamName)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get existing team",
+			slog.String("team_name", teamName), slog.String("error", err.Error()))
+		return err
+	}
+	if existing == nil {
+		return conflict
+	}
+	return errors.NewTeamExists(conflict.Error()).WithDetails(newTeamSummary(teamName, existing))
+}
+
+// newTeamSummary summarizes the team named teamName: its metadata and member counts.
+func newTeamSummary(teamName string, t *models.Team) team.TeamSummary {
+	summary := team.TeamSummary{
+		TeamName:    teamName,
+		Description: t.Description,
+		LeadID:      t.LeadId,
+		CreatedAt:   dto.FormatTime(t.CreatedAt),
+		Members:     len(t.Members),
+	}
+	for _, member := range t.Members {
+		if member.IsActive {
+			summary.ActiveMembers++
+		}
+	}
+	return summary
 }
 
-// GetTeam returns a team with all its members.
+// GetTeam returns a team with all its members. It only reads and may be served by a replica.
 func (s *TeamService) GetTeam(ctx context.Context, teamName string) (*team.GetTeamResponse, error) {
+	ctx = dbctx.ReadOnly(ctx)
 	t, err := s.teamRepo.GetTeamByName(ctx, teamName)
 	if err != nil {
-		s.log.LogAttrs(ctx, slog.LevelError, "failed to get team",
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get team",
 			slog.String("team_name", teamName), slog.String("error", err.Error()))
 		return nil, err
 	}
@@ -135,10 +266,17 @@ func (s *TeamService) GetTeam(ctx context.Context, teamName string) (*team.GetTe
 
 	members := make([]team.TeamMember, 0, len(t.Members))
 	for _, user := range t.Members {
+		isReviewer := !user.NonReviewer
 		members = append(members, team.TeamMember{
-			UserID:   user.Id,
-			Username: user.Name,
-			IsActive: user.IsActive,
+			UserID:           user.Id,
+			Username:         user.Name,
+			IsActive:         user.IsActive,
+			IsReviewer:       &isReviewer,
+			MaxActiveReviews: user.MaxActiveReviews,
+			Tags:             user.Tags,
+			Timezone:         user.Timezone,
+			WorkHoursStart:   user.WorkStart,
+			WorkHoursEnd:     user.WorkEnd,
 		})
 	}
 
@@ -146,17 +284,40 @@ func (s *TeamService) GetTeam(ctx context.Context, teamName string) (*team.GetTe
 		slog.String("team_name", teamName),
 		slog.Int("members_count", len(members)))
 
-	return &team.GetTeamResponse{
-		TeamName: teamName,
-		Members:  members,
-	}, nil
+	response := &team.GetTeamResponse{
+		TeamName:    teamName,
+		Description: t.Description,
+		LeadID:      t.LeadId,
+		CreatedAt:   dto.FormatTime(t.CreatedAt),
+		Members:     members,
+	}
+	response.ETag = teamETag(response)
+	return response, nil
+}
+
+// ListTeams returns a page of teams ordered by name, with their metadata and member counts.
+func (s *TeamService) ListTeams(ctx context.Context, req team.ListTeamsRequest) (*dto.Page[team.TeamSummary], error) {
+	teams, err := s.teamRepo.ListTeams(ctx)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to list teams", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	items := make([]team.TeamSummary, 0, len(teams))
+	for _, t := range teams {
+		items = append(items, newTeamSummary(t.GetTeamName(), t))
+	}
+	page := dto.PageOf(items, req.Page)
+	return &page, nil
 }
 
-// DeactivateTeam deactivates all users in a team and reassigns open PRs.
-func (s *TeamService) DeactivateTeam(ctx context.Context, teamName string) (*team.DeactivateTeamResponse, error) {
+// GetReviewQueue returns open PRs with reviewers from the team, URGENT first, then oldest first, unless sorted.
+// With UnreviewedOnly, PRs approved by any reviewer are left out.
+func (s *TeamService) GetReviewQueue(ctx context.Context, req team.ReviewQueueRequest) (*team.ReviewQueueResponse, error) {
+	teamName := req.TeamName
 	t, err := s.teamRepo.GetTeamByName(ctx, teamName)
 	if err != nil {
-		s.log.LogAttrs(ctx, slog.LevelError, "failed to get team",
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get team",
 			slog.String("team_name", teamName), slog.String("error", err.Error()))
 		return nil, err
 	}
@@ -167,69 +328,307 @@ func (s *TeamService) DeactivateTeam(ctx context.Context, teamName string) (*tea
 		return nil, errors.NewNotFound("team not found")
 	}
 
-	users, err := s.userRepo.FindByTeamName(ctx, teamName)
+	prs, err := s.prRepo.FindOpenPRsReviewedByTeam(ctx, teamName, prSort(req.Sort))
 	if err != nil {
-		s.log.LogAttrs(ctx, slog.LevelError, "failed to find users by team",
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find team review queue",
 			slog.String("team_name", teamName), slog.String("error", err.Error()))
 		return nil, err
 	}
 
-	reviewerIDs := make([]string, 0, len(users))
-	for _, user := range users {
-		reviewerIDs = append(reviewerIDs, user.Id)
+	if req.UnreviewedOnly && len(prs) > 0 {
+		prs, err = s.withoutApprovals(ctx, prs)
+		if err != nil {
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to get review states",
+				slog.String("team_name", teamName), slog.String("error", err.Error()))
+			return nil, err
+		}
+	}
+
+	if req.Sort.Field == "" {
+		sortUrgentFirst(prs)
+	}
+
+	loads, err := s.reviewerLoads(ctx, t.Members)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get team review load",
+			slog.String("team_name", teamName), slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	response := &team.ReviewQueueResponse{
+		TeamName:     teamName,
+		PullRequests: make([]team.ReviewQueueItem, 0, len(prs)),
+		Reviewers:    loads,
+	}
+	for _, pr := range prs {
+		response.PullRequests = append(response.PullRequests, team.ReviewQueueItem{
+			PR:         newPRDto(pr, pr.ReviewersId),
+			AgeSeconds: int64(now.Sub(pr.CreatedAt).Seconds()),
+		})
+	}
+
+	if req.ExpandReviewers {
+		expanded := make([]*pullrequest.PR, 0, len(response.PullRequests))
+		for i := range response.PullRequests {
+			expanded = append(expanded, &response.PullRequests[i].PR)
+		}
+		if err := expandReviewers(ctx, s.userRepo, s.reviewerRepo, s.review.Deadline, expanded...); err != nil {
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to load reviewer details",
+				slog.String("team_name", teamName), slog.String("error", err.Error()))
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
+
+// withoutApprovals drops the PRs approved by at least one reviewer.
+func (s *TeamService) withoutApprovals(ctx context.Context, prs []*models.PullRequest) ([]*models.PullRequest, error) {
+	prIDs := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		prIDs = append(prIDs, pr.Id)
+	}
+	assignments, err := s.reviewerRepo.GetAssignmentsByPRs(ctx, prIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	approved := make(map[string]bool)
+	for _, a := range assignments {
+		if a.State == models.ReviewStateApproved {
+			approved[a.PRId] = true
+		}
+	}
+	unreviewed := make([]*models.PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		if !approved[pr.Id] {
+			unreviewed = append(unreviewed, pr)
+		}
+	}
+	return unreviewed, nil
+}
+
+// reviewerLoads returns open review counts and capacities of the members.
+func (s *TeamService) reviewerLoads(ctx context.Context, members []*models.User) ([]team.ReviewerLoad, error) {
+	userIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		userIDs = append(userIDs, member.Id)
+	}
+	counts, err := s.reviewerRepo.GetOpenReviewCounts(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	loads := make([]team.ReviewerLoad, 0, len(members))
+	for _, member := range members {
+		capacity := member.ReviewCapacity(s.review.MaxActiveReviews)
+		loads = append(loads, team.ReviewerLoad{
+			UserID:      member.Id,
+			Username:    member.Name,
+			IsActive:    member.IsActive,
+			OpenReviews: counts[member.Id],
+			Capacity:    capacity,
+			AtCapacity:  atCapacity(counts[member.Id], capacity),
+		})
 	}
+	return loads, nil
+}
 
-	var deactivatedCount int
-	var removedAssignments int
+// DeactivateTeam deactivates all users in a team and reassigns their reviews on open PRs
+// to active members of each PR author's team, removing the reviewer only when nobody is left.
+// On request the open PRs authored by the team are closed first, so they are not reassigned.
+func (s *TeamService) DeactivateTeam(ctx context.Context, req team.DeactivateTeamRequest) (*team.DeactivateTeamResponse, error) {
+	teamName := req.TeamName
+	var response team.DeactivateTeamResponse
+
+	err := s.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
+		t, err := s.teamRepo.GetTeamByName(txCtx, teamName)
+		if err != nil {
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to get team",
+				slog.String("team_name", teamName), slog.String("error", err.Error()))
+			return err
+		}
+
+		if t == nil {
+			s.log.LogAttrs(ctx, slog.LevelWarn, "team not found",
+				slog.String("team_name", teamName))
+			return errors.NewNotFound("team not found")
+		}
+
+		users, err := s.userRepo.FindByTeamName(txCtx, teamName)
+		if err != nil {
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to find users by team",
+				slog.String("team_name", teamName), slog.String("error", err.Error()))
+			return err
+		}
+
+		deactivated := make(map[string]bool, len(users))
+		reviewerIDs := make([]string, 0, len(users))
+		for _, user := range users {
+			deactivated[user.Id] = true
+			reviewerIDs = append(reviewerIDs, user.Id)
+		}
+
+		// deactivate first so the team members are no longer returned as replacement candidates
+		count, err := s.userRepo.DeactivateTeamUsers(txCtx, teamName)
+		if err != nil {
+			return err
+		}
+
+		var closedPRs []string
+		if req.CloseAuthoredPRs {
+			if closedPRs, err = s.closeAuthoredPRs(txCtx, reviewerIDs); err != nil {
+				return err
+			}
+		}
 
-	err = s.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
 		openPRs, err := s.prRepo.FindOpenPRsByReviewers(txCtx, reviewerIDs)
 		if err != nil {
 			return err
 		}
 
-		for _, pr := range openPRs {
+		authorTeams := make(map[string]string)
+		authorExclusions := make(map[string][]string)
+		var reassigned, removed int
+		affected := make([]team.AffectedPR, 0, min(len(openPRs), team.MaxAffectedPRs))
+		for i, pr := range openPRs {
+			authorTeam, ok := authorTeams[pr.AuthorId]
+			if !ok {
+				author, err := s.userRepo.FindByID(txCtx, pr.AuthorId)
+				if err != nil {
+					return err
+				}
+				if author != nil {
+					authorTeam = author.TeamName
+				}
+				authorTeams[pr.AuthorId] = authorTeam
+
+				if authorTeam != "" {
+					excluded, err := s.userRepo.GetExcludedReviewers(txCtx, pr.AuthorId)
+					if err != nil {
+						return err
+					}
+					authorExclusions[pr.AuthorId] = excluded
+				}
+			}
+
 			reviewers, err := s.reviewerRepo.GetReviewers(txCtx, pr.Id)
 			if err != nil {
 				return err
 			}
 
+			// exclusions grow with each replacement so one PR never gets the same reviewer twice
+			exclude := append([]string{pr.AuthorId}, reviewers...)
+			exclude = append(exclude, authorExclusions[pr.AuthorId]...)
+			replaced := make([]team.ReplacedReviewer, 0, len(reviewers))
 			for _, reviewerID := range reviewers {
-				for _, uid := range reviewerIDs {
-					if reviewerID == uid {
-						if err := s.reviewerRepo.RemoveReviewer(txCtx, pr.Id, reviewerID); err != nil {
-							return err
-						}
-						removedAssignments++
-						break
+				if !deactivated[reviewerID] {
+					continue
+				}
+
+				var newReviewerID string
+				if authorTeam != "" {
+					newReviewerID, err = findReplacement(txCtx, s.userRepo, authorTeam, exclude, s.log)
+					if err != nil {
+						return err
 					}
 				}
+
+				change := &models.ReviewerChange{
+					PRId:          pr.Id,
+					OldReviewerId: reviewerID,
+					NewReviewerId: newReviewerID,
+					Trigger:       models.ReviewerChangeDeactivation,
+					ChangedAt:     time.Now().UTC(),
+				}
+
+				if newReviewerID == "" {
+					if err := s.reviewerRepo.RemoveReviewer(txCtx, pr.Id, reviewerID); err != nil {
+						return err
+					}
+					if err := s.reviewerRepo.RecordReviewerChange(txCtx, change); err != nil {
+						return err
+					}
+					replaced = append(replaced, team.ReplacedReviewer{OldReviewerID: reviewerID})
+					removed++
+					continue
+				}
+
+				if err := s.reviewerRepo.ReplaceReviewer(txCtx, pr.Id, reviewerID, newReviewerID, models.AssignmentSourceDeactivation); err != nil {
+					return err
+				}
+				if err := s.reviewerRepo.RecordReviewerChange(txCtx, change); err != nil {
+					return err
+				}
+				exclude = append(exclude, newReviewerID)
+				replaced = append(replaced, team.ReplacedReviewer{OldReviewerID: reviewerID, NewReviewerID: &newReviewerID})
+				reassigned++
+			}
+			if i < team.MaxAffectedPRs {
+				affected = append(affected, team.AffectedPR{PullRequestID: pr.Id, Reviewers: replaced})
 			}
 		}
 
-		count, err := s.userRepo.DeactivateTeamUsers(txCtx, teamName)
-		if err != nil {
-			return err
+		response = team.DeactivateTeamResponse{
+			DeactivatedUsers:     count,
+			ReassignedPRs:        reassigned + removed,
+			Reassigned:           reassigned,
+			Removed:              removed,
+			UserIDs:              reviewerIDs,
+			AffectedPRs:          affected,
+			AffectedPRsTruncated: len(openPRs) > team.MaxAffectedPRs,
+			ClosedPRs:            closedPRs,
 		}
-		deactivatedCount = count
-
 		return nil
 	})
 
 	if err != nil {
-		s.log.LogAttrs(ctx, slog.LevelError, "failed to deactivate team",
-			slog.String("team_name", teamName), slog.String("error", err.Error()))
+		if !errors.HasCode(err, errors.CodeNotFound) {
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to deactivate team",
+				slog.String("team_name", teamName), slog.String("error", err.Error()))
+		}
 		return nil, err
 	}
 
 	s.log.LogAttrs(ctx, slog.LevelInfo, "team deactivated successfully",
 		slog.String("team_name", teamName),
-		slog.Int("deactivated_users", deactivatedCount),
-		slog.Int("removed_assignments", removedAssignments))
-
-	return &team.DeactivateTeamResponse{
-		DeactivatedUsers: deactivatedCount,
-		ReassignedPRs:    removedAssignments,
-		UserIDs:          reviewerIDs,
-	}, nil
+		slog.Int("deactivated_users", response.DeactivatedUsers),
+		slog.Int("reassigned", response.Reassigned),
+		slog.Int("removed", response.Removed),
+		slog.Int("closed_prs", len(response.ClosedPRs)))
+
+	return &response, nil
+}
+
+// closeAuthoredPRs closes the open PRs of the authors within the transaction in ctx and removes
+// their reviewers. PRs merged concurrently are skipped. Returns the ids of the closed PRs.
+func (s *TeamService) closeAuthoredPRs(ctx context.Context, authorIDs []string) ([]string, error) {
+	prs, err := s.prRepo.FindOpenPRsByAuthors(ctx, authorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	closed := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		updated, err := s.prRepo.UpdateStatus(ctx, pr.Id, models.PRStatusOpen, models.PRStatusClosed, nil)
+		if err != nil {
+			return nil, err
+		}
+		if updated == 0 {
+			continue
+		}
+
+		reviewers, err := s.reviewerRepo.GetReviewers(ctx, pr.Id)
+		if err != nil {
+			return nil, err
+		}
+		for _, reviewerID := range reviewers {
+			if err := s.reviewerRepo.RemoveReviewer(ctx, pr.Id, reviewerID); err != nil {
+				return nil, err
+			}
+		}
+		closed = append(closed, pr.Id)
+	}
+	return closed, nil
 }
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"iter"
+	"log/slog"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/team"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// TeamImportRepository defines the interface for storing a team whose members come in batches.
+// ImportTeam must return TEAM_EXISTS AppError if the team exists, atomically with the creation,
+// and must store nothing when batches yields an error, which it returns.
+type TeamImportRepository interface {
+	ImportTeam(ctx context.Context, team *models.TeamRecord, batches iter.Seq2[[]*models.User, error]) error
+}
+
+// TeamImportService implements the streamed import of teams too large for /team/add.
+type TeamImportService struct {
+	repo TeamImportRepository
+	cfg  config.Import
+	log  *slog.Logger
+}
+
+// NewTeamImportService creates a new team import service. A zero cfg.MaxTeamMembers leaves teams
+// unbounded and a cfg.BatchSize below one stores members one at a time.
+func NewTeamImportService(repo TeamImportRepository, cfg config.Import, log *slog.Logger) *TeamImportService {
+	if log == nil {
+		log = slog.Default()
+	}
+	cfg.BatchSize = max(cfg.BatchSize, 1)
+	return &TeamImportService{repo: repo, cfg: cfg, log: log}
+}
+
+// ImportTeam creates a team like AddTeam without holding all its members at once: they are
+// converted as members yields them and stored batch by batch in one transaction. The import fails
+// as soon as the members exceed the configured limit or list a user twice, and nothing is stored
+// when it fails, an error yielded by members included.
+func (s *TeamImportService) ImportTeam(ctx context.Context, req team.ImportTeamRequest,
+	members iter.Seq2[team.TeamMember, error]) (*team.ImportTeamResponse, error) {
+	record := &models.TeamRecord{
+		Name:        req.TeamName,
+		Description: req.Description,
+		LeadId:      req.LeadID,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	// positions of the members read so far by user id
+	seen := make(map[string]int)
+	var reviewers int
+	batches := func(yield func([]*models.User, error) bool) {
+		batch := make([]*models.User, 0, s.cfg.BatchSize)
+		for member, err := range members {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if s.cfg.MaxTeamMembers > 0 && len(seen) == s.cfg.MaxTeamMembers {
+				yield(nil, errors.NewValidation(
+					fmt.Sprintf("members must not have more than %d entries", s.cfg.MaxTeamMembers)))
+				return
+			}
+			if first, ok := seen[member.UserID]; ok {
+				yield(nil, errors.NewValidation(
+					fmt.Sprintf("user_id %s is already listed as members[%d]", member.UserID, first)))
+				return
+			}
+			seen[member.UserID] = len(seen)
+
+			_, user := newTeamMember(req.TeamName, member)
+			if !user.NonReviewer {
+				reviewers++
+			}
+			batch = append(batch, user)
+			if len(batch) == s.cfg.BatchSize {
+				if !yield(batch, nil) {
+					return
+				}
+				batch = make([]*models.User, 0, s.cfg.BatchSize)
+			}
+		}
+
+		_, leadListed := seen[req.LeadID]
+		switch {
+		case len(seen) == 0:
+			yield(nil, errors.NewBadRequest("team must have at least one member"))
+		case req.LeadID != "" && !leadListed:
+			yield(nil, errors.NewBadRequest("lead_id must be a member of the team"))
+		case len(batch) > 0:
+			yield(batch, nil)
+		}
+	}
+
+	if err := s.repo.ImportTeam(ctx, record, batches); err != nil {
+		// rejected members and an existing team are the client's doing
+		level := errorLevel(err)
+		var appErr *errors.AppError
+		if stderrors.As(err, &appErr) {
+			level = slog.LevelWarn
+		}
+		s.log.LogAttrs(ctx, level, "failed to import team",
+			slog.String("team_name", req.TeamName), slog.Int("members_read", len(seen)),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "team imported successfully",
+		slog.String("team_name", req.TeamName),
+		slog.Int("members_count", len(seen)))
+
+	response := &team.ImportTeamResponse{
+		TeamName:  req.TeamName,
+		CreatedAt: dto.FormatTime(record.CreatedAt),
+		Members:   len(seen),
+		Reviewers: reviewers,
+	}
+	if reviewers == 0 {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "team has no reviewers",
+			slog.String("team_name", req.TeamName))
+		response.Warnings = append(response.Warnings, "no member is a reviewer, so PRs of the team get no reviewers")
+	}
+	return response, nil
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/team"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/shirr9/pr-reviewer-service/internal/infrastructure/persistence/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// streamMembers yields count members u1, u2... recording into read how many were taken, and then
+// err when it is set.
+func streamMembers(count int, err error, read *int) iter.Seq2[team.TeamMember, error] {
+	return func(yield func(team.TeamMember, error) bool) {
+		for i := 1; i <= count; i++ {
+			*read = i
+			id := fmt.Sprintf("u%d", i)
+			if !yield(team.TeamMember{UserID: id, Username: "user " + id, IsActive: true}, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(team.TeamMember{}, err)
+		}
+	}
+}
+
+func TestTeamImportService_ImportTeam(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	ctx := context.Background()
+	cfg := config.Import{MaxTeamMembers: 10, BatchSize: 3}
+
+	t.Run("Success - Members are stored in batches", func(t *testing.T) {
+		storage := memory.NewStorage()
+		service := NewTeamImportService(storage.NewTeamRepository(), cfg, logger)
+		var read int
+
+		resp, err := service.ImportTeam(ctx, team.ImportTeamRequest{TeamName: "backend", LeadID: "u7"},
+			streamMembers(7, nil, &read))
+
+		require.NoError(t, err)
+		assert.Equal(t, "backend", resp.TeamName)
+		assert.Equal(t, 7, resp.Members)
+		assert.Equal(t, 7, resp.Reviewers)
+		assert.Empty(t, resp.Warnings)
+		stored, err := storage.NewTeamRepository().GetTeamByName(ctx, "backend")
+		require.NoError(t, err)
+		assert.Len(t, stored.Members, 7)
+		assert.Equal(t, "u7", stored.LeadId)
+	})
+
+	t.Run("Success - Team without reviewers is warned about", func(t *testing.T) {
+		storage := memory.NewStorage()
+		service := NewTeamImportService(storage.NewTeamRepository(), cfg, logger)
+		notReviewer := false
+		members := func(yield func(team.TeamMember, error) bool) {
+			yield(team.TeamMember{UserID: "u1", Username: "Alice", IsReviewer: &notReviewer}, nil)
+		}
+
+		resp, err := service.ImportTeam(ctx, team.ImportTeamRequest{TeamName: "backend"}, members)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, resp.Reviewers)
+		assert.Len(t, resp.Warnings, 1)
+	})
+
+	t.Run("Error - Limit is enforced as soon as it is exceeded", func(t *testing.T) {
+		storage := memory.NewStorage()
+		service := NewTeamImportService(storage.NewTeamRepository(), cfg, logger)
+		var read int
+
+		resp, err := service.ImportTeam(ctx, team.ImportTeamRequest{TeamName: "backend"},
+			streamMembers(1000, nil, &read))
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.True(t, errors.HasCode(err, errors.CodeValidation))
+		assert.Equal(t, 11, read)
+		assertTeamAbsent(t, storage, "backend")
+	})
+
+	t.Run("Error - Member listed twice", func(t *testing.T) {
+		storage := memory.NewStorage()
+		service := NewTeamImportService(storage.NewTeamRepository(), cfg, logger)
+		members := func(yield func(team.TeamMember, error) bool) {
+			for _, id := range []string{"u1", "u2", "u1"} {
+				if !yield(team.TeamMember{UserID: id, Username: id}, nil) {
+					return
+				}
+			}
+		}
+
+		_, err := service.ImportTeam(ctx, team.ImportTeamRequest{TeamName: "backend"}, members)
+
+		require.Error(t, err)
+		assert.True(t, errors.HasCode(err, errors.CodeValidation))
+		assert.Contains(t, err.Error(), "members[0]")
+		assertTeamAbsent(t, storage, "backend")
+	})
+
+	t.Run("Error - Failed stream stores nothing", func(t *testing.T) {
+		storage := memory.NewStorage()
+		service := NewTeamImportService(storage.NewTeamRepository(), cfg, logger)
+		var read int
+		streamErr := fmt.Errorf("unexpected EOF")
+
+		_, err := service.ImportTeam(ctx, team.ImportTeamRequest{TeamName: "backend"},
+			streamMembers(5, streamErr, &read))
+
+		assert.ErrorIs(t, err, streamErr)
+		assertTeamAbsent(t, storage, "backend")
+	})
+
+	t.Run("Error - Lead is not a member", func(t *testing.T) {
+		storage := memory.NewStorage()
+		service := NewTeamImportService(storage.NewTeamRepository(), cfg, logger)
+		var read int
+
+		_, err := service.ImportTeam(ctx, team.ImportTeamRequest{TeamName: "backend", LeadID: "u9"},
+			streamMembers(4, nil, &read))
+
+		assert.True(t, errors.HasCode(err, errors.CodeBadRequest))
+		assertTeamAbsent(t, storage, "backend")
+	})
+
+	t.Run("Error - No members", func(t *testing.T) {
+		storage := memory.NewStorage()
+		service := NewTeamImportService(storage.NewTeamRepository(), cfg, logger)
+		var read int
+
+		_, err := service.ImportTeam(ctx, team.ImportTeamRequest{TeamName: "backend"}, streamMembers(0, nil, &read))
+
+		assert.True(t, errors.HasCode(err, errors.CodeBadRequest))
+	})
+
+	t.Run("Error - Team exists before members are read", func(t *testing.T) {
+		storage := memory.NewStorage()
+		require.NoError(t, storage.NewTeamRepository().CreateTeam(ctx, &models.Team{Members: []*models.User{
+			{Id: "x1", Name: "Xavier", TeamName: "backend", IsActive: true},
+		}}))
+		service := NewTeamImportService(storage.NewTeamRepository(), cfg, logger)
+		var read int
+
+		_, err := service.ImportTeam(ctx, team.ImportTeamRequest{TeamName: "backend"}, streamMembers(5, nil, &read))
+
+		assert.True(t, errors.HasCode(err, errors.CodeTeamExists))
+		assert.Zero(t, read)
+	})
+}
+
+func assertTeamAbsent(t *testing.T, storage *memory.Storage, teamName string) {
+	t.Helper()
+	stored, err := storage.NewTeamRepository().GetTeamByName(context.Background(), teamName)
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+}
@@ -2,15 +2,25 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"os"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dbctx"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/pullrequest"
 	"github.com/shirr9/pr-reviewer-service/internal/app/dto/team"
 	"github.com/shirr9/pr-reviewer-service/internal/app/service/mocks"
 	"github.com/shirr9/pr-reviewer-service/internal/domain/errors"
 	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/shirr9/pr-reviewer-service/internal/infrastructure/persistence/memory"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -21,7 +31,7 @@ func TestTeamService_AddTeam(t *testing.T) {
 	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
-	service := NewTeamService(mockTeamRepo, nil, nil, nil, nil, logger)
+	service := NewTeamService(mockTeamRepo, nil, nil, nil, nil, testReview, logger)
 
 	t.Run("Success - Add new team", func(t *testing.T) {
 		ctx := context.Background()
@@ -34,8 +44,7 @@ func TestTeamService_AddTeam(t *testing.T) {
 			},
 		}
 
-		mockTeamRepo.EXPECT().IsExists(ctx, "backend").Return(false, nil)
-		mockTeamRepo.EXPECT().CreateOrUpdateTeam(ctx, gomock.Any()).DoAndReturn(
+		mockTeamRepo.EXPECT().CreateTeam(ctx, gomock.Any()).DoAndReturn(
 			func(ctx context.Context, team *models.Team) error {
 				assert.Len(t, team.Members, 3)
 				assert.Equal(t, "u1", team.Members[0].Id)
@@ -54,6 +63,52 @@ func TestTeamService_AddTeam(t *testing.T) {
 		assert.Len(t, resp.Team.Members, 3)
 	})
 
+	t.Run("Success - Metadata is stored and returned", func(t *testing.T) {
+		ctx := context.Background()
+		req := team.AddTeamRequest{
+			TeamName:    "backend",
+			Description: "Search and payments",
+			LeadID:      "u2",
+			Members: []team.TeamMember{
+				{UserID: "u1", Username: "Alice", IsActive: true},
+				{UserID: "u2", Username: "Bob", IsActive: true},
+			},
+		}
+
+		mockTeamRepo.EXPECT().CreateTeam(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, team *models.Team) error {
+				assert.Equal(t, "Search and payments", team.Description)
+				assert.Equal(t, "u2", team.LeadId)
+				assert.False(t, team.CreatedAt.IsZero())
+				return nil
+			},
+		)
+
+		resp, err := service.AddTeam(ctx, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Search and payments", resp.Team.Description)
+		assert.Equal(t, "u2", resp.Team.LeadID)
+		assert.NotEmpty(t, resp.Team.CreatedAt)
+	})
+
+	t.Run("Error - Lead is not a member", func(t *testing.T) {
+		ctx := context.Background()
+		req := team.AddTeamRequest{
+			TeamName: "backend",
+			LeadID:   "u9",
+			Members: []team.TeamMember{
+				{UserID: "u1", Username: "Alice", IsActive: true},
+			},
+		}
+
+		resp, err := service.AddTeam(ctx, req)
+
+		assert.Nil(t, resp)
+		assert.True(t, errors.HasCode(err, errors.CodeBadRequest))
+		assert.ErrorContains(t, err, "lead_id")
+	})
+
 	t.Run("Error - Team already exists", func(t *testing.T) {
 		ctx := context.Background()
 		req := team.AddTeamRequest{
@@ -63,13 +118,49 @@ func TestTeamService_AddTeam(t *testing.T) {
 			},
 		}
 
-		mockTeamRepo.EXPECT().IsExists(ctx, "backend").Return(true, nil)
+		createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockTeamRepo.EXPECT().CreateTeam(ctx, gomock.Any()).Return(errors.NewTeamExists("team_name already exists"))
+		mockTeamRepo.EXPECT().GetTeamByName(ctx, "backend").Return(&models.Team{
+			Description: "Platform services",
+			LeadId:      "u2",
+			CreatedAt:   createdAt,
+			Members: []*models.User{
+				{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+				{Id: "u3", Name: "Carol", TeamName: "backend", IsActive: false},
+			},
+		}, nil)
 
 		resp, err := service.AddTeam(ctx, req)
 
 		assert.Error(t, err)
 		assert.Nil(t, resp)
 		assert.Equal(t, "TEAM_EXISTS", err.(*errors.AppError).Code)
+		assert.Equal(t, team.TeamSummary{
+			TeamName:      "backend",
+			Description:   "Platform services",
+			LeadID:        "u2",
+			CreatedAt:     "2024-01-01T00:00:00Z",
+			Members:       2,
+			ActiveMembers: 1,
+		}, err.(*errors.AppError).Details)
+	})
+
+	t.Run("Error - Team already exists but is gone when read", func(t *testing.T) {
+		ctx := context.Background()
+		req := team.AddTeamRequest{
+			TeamName: "backend",
+			Members: []team.TeamMember{
+				{UserID: "u1", Username: "Alice", IsActive: true},
+			},
+		}
+
+		mockTeamRepo.EXPECT().CreateTeam(ctx, gomock.Any()).Return(errors.NewTeamExists("team_name already exists"))
+		mockTeamRepo.EXPECT().GetTeamByName(ctx, "backend").Return(nil, nil)
+
+		_, err := service.AddTeam(ctx, req)
+
+		assert.True(t, errors.HasCode(err, errors.CodeTeamExists))
+		assert.Nil(t, err.(*errors.AppError).Details)
 	})
 
 	t.Run("Error - Empty members list", func(t *testing.T) {
@@ -83,7 +174,7 @@ func TestTeamService_AddTeam(t *testing.T) {
 
 		assert.Error(t, err)
 		assert.Nil(t, resp)
-		assert.Equal(t, "BAD_REQUEST", err.(*errors.AppError).Code)
+		assert.Equal(t, errors.CodeBadRequest, err.(*errors.AppError).Code)
 		assert.Contains(t, err.Error(), "at least one member")
 	})
 
@@ -96,8 +187,7 @@ func TestTeamService_AddTeam(t *testing.T) {
 			},
 		}
 
-		mockTeamRepo.EXPECT().IsExists(ctx, "payments").Return(false, nil)
-		mockTeamRepo.EXPECT().CreateOrUpdateTeam(ctx, gomock.Any()).Return(nil)
+		mockTeamRepo.EXPECT().CreateTeam(ctx, gomock.Any()).Return(nil)
 
 		resp, err := service.AddTeam(ctx, req)
 
@@ -117,8 +207,7 @@ func TestTeamService_AddTeam(t *testing.T) {
 			},
 		}
 
-		mockTeamRepo.EXPECT().IsExists(ctx, "testteam").Return(false, nil)
-		mockTeamRepo.EXPECT().CreateOrUpdateTeam(ctx, gomock.Any()).DoAndReturn(
+		mockTeamRepo.EXPECT().CreateTeam(ctx, gomock.Any()).DoAndReturn(
 			func(ctx context.Context, team *models.Team) error {
 				assert.False(t, team.Members[0].IsActive)
 				assert.False(t, team.Members[1].IsActive)
@@ -131,6 +220,148 @@ func TestTeamService_AddTeam(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, resp)
 	})
+
+	t.Run("Success - Members are reviewers unless set otherwise", func(t *testing.T) {
+		ctx := context.Background()
+		notReviewer := false
+		req := team.AddTeamRequest{
+			TeamName: "backend",
+			Members: []team.TeamMember{
+				{UserID: "u1", Username: "Alice", IsActive: true},
+				{UserID: "u2", Username: "Bob", IsActive: true, IsReviewer: &notReviewer},
+			},
+		}
+
+		mockTeamRepo.EXPECT().CreateTeam(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, team *models.Team) error {
+				assert.False(t, team.Members[0].NonReviewer)
+				assert.True(t, team.Members[1].NonReviewer)
+				return nil
+			},
+		)
+
+		resp, err := service.AddTeam(ctx, req)
+
+		assert.NoError(t, err)
+		assert.True(t, *resp.Team.Members[0].IsReviewer)
+		assert.False(t, *resp.Team.Members[1].IsReviewer)
+		assert.Empty(t, resp.Warnings)
+	})
+
+	t.Run("Success - Team without reviewers is created with a warning", func(t *testing.T) {
+		ctx := context.Background()
+		notReviewer := false
+		req := team.AddTeamRequest{
+			TeamName: "design",
+			Members: []team.TeamMember{
+				{UserID: "u7", Username: "Grace", IsActive: true, IsReviewer: &notReviewer},
+			},
+		}
+
+		mockTeamRepo.EXPECT().CreateTeam(ctx, gomock.Any()).Return(nil)
+
+		resp, err := service.AddTeam(ctx, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"no member is a reviewer, so PRs of the team get no reviewers"}, resp.Warnings)
+	})
+}
+
+func TestTeamService_ImportTeams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	service := NewTeamService(mockTeamRepo, nil, nil, nil, nil, testReview, logger)
+
+	t.Run("Success - Each team gets its own result", func(t *testing.T) {
+		ctx := context.Background()
+		req := team.ImportTeamsRequest{Teams: []team.AddTeamRequest{
+			{TeamName: "backend", Members: []team.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}}},
+			{TeamName: "frontend", Members: []team.TeamMember{{UserID: "u2", Username: "Bob", IsActive: true}}},
+			{TeamName: "mobile", Members: []team.TeamMember{{UserID: "u3", Username: "Carol", IsActive: true}}},
+		}}
+
+		gomock.InOrder(
+			mockTeamRepo.EXPECT().CreateTeam(ctx, gomock.Any()).Return(nil),
+			mockTeamRepo.EXPECT().CreateTeam(ctx, gomock.Any()).Return(errors.NewTeamExists("team_name already exists")),
+			mockTeamRepo.EXPECT().GetTeamByName(ctx, "frontend").Return(nil, nil),
+			mockTeamRepo.EXPECT().CreateTeam(ctx, gomock.Any()).Return(assert.AnError),
+		)
+
+		resp, err := service.ImportTeams(ctx, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []team.ImportTeamResult{
+			{TeamName: "backend", Result: team.ImportResultCreated, Members: 1},
+			{TeamName: "frontend", Result: team.ImportResultAlreadyExists, Members: 1, Error: "team_name already exists"},
+			{TeamName: "mobile", Result: team.ImportResultFailed, Members: 1, Error: assert.AnError.Error()},
+		}, resp.Results)
+		assert.Equal(t, team.ImportTeamsSummary{Created: 1, AlreadyExists: 1, Failed: 1}, resp.Summary)
+	})
+
+	t.Run("Error - Canceled context stops the import", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		req := team.ImportTeamsRequest{Teams: []team.AddTeamRequest{
+			{TeamName: "backend", Members: []team.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}}},
+			{TeamName: "frontend", Members: []team.TeamMember{{UserID: "u2", Username: "Bob", IsActive: true}}},
+		}}
+
+		mockTeamRepo.EXPECT().CreateTeam(ctx, gomock.Any()).Return(context.Canceled)
+
+		resp, err := service.ImportTeams(ctx, req)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, resp)
+	})
+}
+
+func TestTeamService_AddTeam_Concurrent(t *testing.T) {
+	const requests = 8
+	store := newFakeStore()
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	service := NewTeamService(store, nil, nil, nil, nil, testReview, logger)
+
+	var (
+		wg      sync.WaitGroup
+		start   = make(chan struct{})
+		success atomic.Int32
+		exists  atomic.Int32
+	)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req := team.AddTeamRequest{
+				TeamName: "backend",
+				Members: []team.TeamMember{
+					{UserID: fmt.Sprintf("u%d", i), Username: fmt.Sprintf("User %d", i), IsActive: true},
+				},
+			}
+			<-start
+			_, err := service.AddTeam(context.Background(), req)
+			switch {
+			case err == nil:
+				success.Add(1)
+			case errors.HasCode(err, errors.CodeTeamExists):
+				exists.Add(1)
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), success.Load())
+	assert.Equal(t, int32(requests-1), exists.Load())
+
+	created, err := store.GetTeamByName(context.Background(), "backend")
+	assert.NoError(t, err)
+	assert.Len(t, created.Members, 1)
 }
 
 func TestTeamService_GetTeam(t *testing.T) {
@@ -140,10 +371,11 @@ func TestTeamService_GetTeam(t *testing.T) {
 	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
-	service := NewTeamService(mockTeamRepo, nil, nil, nil, nil, logger)
+	service := NewTeamService(mockTeamRepo, nil, nil, nil, nil, testReview, logger)
 
 	t.Run("Success - Get existing team", func(t *testing.T) {
 		ctx := context.Background()
+		readCtx := dbctx.ReadOnly(ctx)
 		teamName := "backend"
 
 		domainTeam := &models.Team{
@@ -154,7 +386,7 @@ func TestTeamService_GetTeam(t *testing.T) {
 			},
 		}
 
-		mockTeamRepo.EXPECT().GetTeamByName(ctx, "backend").Return(domainTeam, nil)
+		mockTeamRepo.EXPECT().GetTeamByName(readCtx, "backend").Return(domainTeam, nil)
 
 		resp, err := service.GetTeam(ctx, teamName)
 
@@ -169,11 +401,32 @@ func TestTeamService_GetTeam(t *testing.T) {
 		assert.False(t, resp.Members[2].IsActive)
 	})
 
+	t.Run("Success - Metadata returned", func(t *testing.T) {
+		ctx := context.Background()
+		readCtx := dbctx.ReadOnly(ctx)
+		createdAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+		mockTeamRepo.EXPECT().GetTeamByName(readCtx, "backend").Return(&models.Team{
+			Members:     []*models.User{{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true}},
+			Description: "Search and payments",
+			LeadId:      "u1",
+			CreatedAt:   createdAt,
+		}, nil)
+
+		resp, err := service.GetTeam(ctx, "backend")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Search and payments", resp.Description)
+		assert.Equal(t, "u1", resp.LeadID)
+		assert.Equal(t, "2025-03-01T12:00:00Z", resp.CreatedAt)
+	})
+
 	t.Run("Error - Team not found", func(t *testing.T) {
 		ctx := context.Background()
+		readCtx := dbctx.ReadOnly(ctx)
 		teamName := "nonexistent"
 
-		mockTeamRepo.EXPECT().GetTeamByName(ctx, "nonexistent").Return(nil, nil)
+		mockTeamRepo.EXPECT().GetTeamByName(readCtx, "nonexistent").Return(nil, nil)
 
 		resp, err := service.GetTeam(ctx, teamName)
 
@@ -184,6 +437,7 @@ func TestTeamService_GetTeam(t *testing.T) {
 
 	t.Run("Success - Get team with single member", func(t *testing.T) {
 		ctx := context.Background()
+		readCtx := dbctx.ReadOnly(ctx)
 		teamName := "solo"
 
 		domainTeam := &models.Team{
@@ -192,7 +446,7 @@ func TestTeamService_GetTeam(t *testing.T) {
 			},
 		}
 
-		mockTeamRepo.EXPECT().GetTeamByName(ctx, "solo").Return(domainTeam, nil)
+		mockTeamRepo.EXPECT().GetTeamByName(readCtx, "solo").Return(domainTeam, nil)
 
 		resp, err := service.GetTeam(ctx, teamName)
 
@@ -204,6 +458,7 @@ func TestTeamService_GetTeam(t *testing.T) {
 
 	t.Run("Success - Get team with all inactive members", func(t *testing.T) {
 		ctx := context.Background()
+		readCtx := dbctx.ReadOnly(ctx)
 		teamName := "inactive-team"
 
 		domainTeam := &models.Team{
@@ -213,7 +468,7 @@ func TestTeamService_GetTeam(t *testing.T) {
 			},
 		}
 
-		mockTeamRepo.EXPECT().GetTeamByName(ctx, "inactive-team").Return(domainTeam, nil)
+		mockTeamRepo.EXPECT().GetTeamByName(readCtx, "inactive-team").Return(domainTeam, nil)
 
 		resp, err := service.GetTeam(ctx, teamName)
 
@@ -224,3 +479,470 @@ func TestTeamService_GetTeam(t *testing.T) {
 		assert.False(t, resp.Members[1].IsActive)
 	})
 }
+
+func TestTeamService_GetTeam_ETag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	service := NewTeamService(mockTeamRepo, nil, nil, nil, nil, testReview, logger)
+	ctx := context.Background()
+	readCtx := dbctx.ReadOnly(ctx)
+
+	etagOf := func(t *testing.T, members ...*models.User) string {
+		t.Helper()
+		mockTeamRepo.EXPECT().GetTeamByName(readCtx, "backend").Return(&models.Team{Members: members}, nil)
+		resp, err := service.GetTeam(ctx, "backend")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return resp.ETag
+	}
+	alice := func() *models.User {
+		return &models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true, Tags: []string{"go"}}
+	}
+	bob := func() *models.User { return &models.User{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true} }
+
+	base := etagOf(t, alice(), bob())
+
+	t.Run("Success - Same roster has the same ETag", func(t *testing.T) {
+		assert.NotEmpty(t, base)
+		assert.Equal(t, base, etagOf(t, alice(), bob()))
+		assert.Equal(t, base, etagOf(t, bob(), alice()), "member order must not matter")
+	})
+
+	t.Run("Success - Added member changes the ETag", func(t *testing.T) {
+		carol := &models.User{Id: "u3", Name: "Carol", TeamName: "backend", IsActive: true}
+
+		assert.NotEqual(t, base, etagOf(t, alice(), bob(), carol))
+	})
+
+	t.Run("Success - Deactivated member changes the ETag", func(t *testing.T) {
+		inactive := bob()
+		inactive.IsActive = false
+
+		assert.NotEqual(t, base, etagOf(t, alice(), inactive))
+	})
+
+	t.Run("Success - Changed member field changes the ETag", func(t *testing.T) {
+		retagged := alice()
+		retagged.Tags = []string{"sql"}
+
+		assert.NotEqual(t, base, etagOf(t, retagged, bob()))
+	})
+
+	t.Run("Success - Changed lead changes the ETag", func(t *testing.T) {
+		mockTeamRepo.EXPECT().GetTeamByName(readCtx, "backend").Return(&models.Team{
+			Members: []*models.User{alice(), bob()}, LeadId: "u1",
+		}, nil)
+
+		resp, err := service.GetTeam(ctx, "backend")
+
+		assert.NoError(t, err)
+		assert.NotEqual(t, base, resp.ETag)
+	})
+}
+
+func TestTeamService_ListTeams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	service := NewTeamService(mockTeamRepo, nil, nil, nil, nil, testReview, logger)
+	ctx := context.Background()
+
+	t.Run("Success - Page of summaries", func(t *testing.T) {
+		mockTeamRepo.EXPECT().ListTeams(ctx).Return([]*models.Team{
+			{Members: []*models.User{{Id: "u1", TeamName: "backend", IsActive: true}, {Id: "u2", TeamName: "backend"}},
+				Description: "Search", LeadId: "u1"},
+			{Members: []*models.User{{Id: "p1", TeamName: "platform", IsActive: true}}},
+		}, nil)
+
+		page, err := service.ListTeams(ctx, team.ListTeamsRequest{Page: dto.PageRequest{Limit: 1}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, page.Total)
+		assert.Equal(t, []team.TeamSummary{
+			{TeamName: "backend", Description: "Search", LeadID: "u1", Members: 2, ActiveMembers: 1},
+		}, page.Items)
+	})
+
+	t.Run("Error - Repository failure", func(t *testing.T) {
+		mockTeamRepo.EXPECT().ListTeams(ctx).Return(nil, fmt.Errorf("connection refused"))
+
+		page, err := service.ListTeams(ctx, team.ListTeamsRequest{Page: dto.PageRequest{Limit: 1}})
+
+		assert.Error(t, err)
+		assert.Nil(t, page)
+	})
+}
+
+func TestTeamService_DeactivateTeam(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
+	mockUserRepo := mocks.NewMockTeamUserRepository(ctrl)
+	mockPRRepo := mocks.NewMockTeamPRRepository(ctrl)
+	mockReviewerRepo := mocks.NewMockTeamReviewerRepository(ctrl)
+	mockUoW := mocks.NewMockTeamTransactor(ctrl)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	service := NewTeamService(mockTeamRepo, mockUserRepo, mockPRRepo, mockReviewerRepo, mockUoW, testReview, logger)
+
+	payments := &models.Team{
+		Members: []*models.User{
+			{Id: "p1", Name: "Paul", TeamName: "payments", IsActive: true},
+			{Id: "p2", Name: "Peter", TeamName: "payments", IsActive: true},
+		},
+	}
+
+	t.Run("Success - Reassigns reviewers from the author's team", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockUoW.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error {
+				mockTeamRepo.EXPECT().GetTeamByName(ctx, "payments").Return(payments, nil)
+				mockUserRepo.EXPECT().FindByTeamName(ctx, "payments").Return(payments.Members, nil)
+				mockUserRepo.EXPECT().DeactivateTeamUsers(ctx, "payments").Return(2, nil)
+				mockPRRepo.EXPECT().FindOpenPRsByReviewers(ctx, []string{"p1", "p2"}).Return([]*models.PullRequest{
+					{Id: "pr-1", AuthorId: "u1", Status: models.PRStatusOpen},
+				}, nil)
+				mockUserRepo.EXPECT().FindByID(ctx, "u1").Return(&models.User{Id: "u1", TeamName: "backend", IsActive: true}, nil)
+				mockReviewerRepo.EXPECT().GetReviewers(ctx, "pr-1").Return([]string{"p1", "p2"}, nil)
+				mockUserRepo.EXPECT().GetExcludedReviewers(ctx, "u1").Return(nil, nil)
+				mockUserRepo.EXPECT().FindActiveCandidatesForReassignment(ctx, "backend", []string{"u1", "p1", "p2"}).
+					Return([]*models.User{{Id: "u2", TeamName: "backend", IsActive: true}}, nil)
+				mockUserRepo.EXPECT().LockActiveCandidate(ctx, "u2").Return(true, nil)
+				mockReviewerRepo.EXPECT().ReplaceReviewer(ctx, "pr-1", "p1", "u2", models.AssignmentSourceDeactivation).Return(nil)
+				mockReviewerRepo.EXPECT().RecordReviewerChange(ctx,
+					reviewerChangeMatcher("pr-1", "p1", "u2", models.ReviewerChangeDeactivation)).Return(nil)
+				mockUserRepo.EXPECT().FindActiveCandidatesForReassignment(ctx, "backend", []string{"u1", "p1", "p2", "u2"}).
+					Return([]*models.User{}, nil)
+				mockReviewerRepo.EXPECT().RemoveReviewer(ctx, "pr-1", "p2").Return(nil)
+				mockReviewerRepo.EXPECT().RecordReviewerChange(ctx,
+					reviewerChangeMatcher("pr-1", "p2", "", models.ReviewerChangeDeactivation)).Return(nil)
+				return fn(ctx)
+			},
+		)
+
+		resp, err := service.DeactivateTeam(ctx, team.DeactivateTeamRequest{TeamName: "payments"})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Equal(t, 2, resp.DeactivatedUsers)
+		assert.Equal(t, 2, resp.ReassignedPRs)
+		assert.Equal(t, 1, resp.Reassigned)
+		assert.Equal(t, 1, resp.Removed)
+		assert.Equal(t, []string{"p1", "p2"}, resp.UserIDs)
+		replacement := "u2"
+		assert.Equal(t, []team.AffectedPR{{PullRequestID: "pr-1", Reviewers: []team.ReplacedReviewer{
+			{OldReviewerID: "p1", NewReviewerID: &replacement},
+			{OldReviewerID: "p2"},
+		}}}, resp.AffectedPRs)
+		assert.False(t, resp.AffectedPRsTruncated)
+	})
+
+	t.Run("Success - Removes reviewers when author has no team", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockUoW.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error {
+				mockTeamRepo.EXPECT().GetTeamByName(ctx, "payments").Return(payments, nil)
+				mockUserRepo.EXPECT().FindByTeamName(ctx, "payments").Return(payments.Members, nil)
+				mockUserRepo.EXPECT().DeactivateTeamUsers(ctx, "payments").Return(2, nil)
+				mockPRRepo.EXPECT().FindOpenPRsByReviewers(ctx, []string{"p1", "p2"}).Return([]*models.PullRequest{
+					{Id: "pr-2", AuthorId: "gone", Status: models.PRStatusOpen},
+				}, nil)
+				mockUserRepo.EXPECT().FindByID(ctx, "gone").Return(nil, nil)
+				mockReviewerRepo.EXPECT().GetReviewers(ctx, "pr-2").Return([]string{"p1"}, nil)
+				mockReviewerRepo.EXPECT().RemoveReviewer(ctx, "pr-2", "p1").Return(nil)
+				mockReviewerRepo.EXPECT().RecordReviewerChange(ctx,
+					reviewerChangeMatcher("pr-2", "p1", "", models.ReviewerChangeDeactivation)).Return(nil)
+				return fn(ctx)
+			},
+		)
+
+		resp, err := service.DeactivateTeam(ctx, team.DeactivateTeamRequest{TeamName: "payments"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, resp.Reassigned)
+		assert.Equal(t, 1, resp.Removed)
+	})
+
+	t.Run("Error - Team not found", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockUoW.EXPECT().WithinTransaction(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error {
+				mockTeamRepo.EXPECT().GetTeamByName(ctx, "ghost").Return(nil, nil)
+				return fn(ctx)
+			},
+		)
+
+		resp, err := service.DeactivateTeam(ctx, team.DeactivateTeamRequest{TeamName: "ghost"})
+
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Equal(t, "NOT_FOUND", err.(*errors.AppError).Code)
+	})
+}
+
+func TestTeamService_DeactivateTeam_AffectedPRsTruncated(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.DiscardHandler)
+	storage := memory.NewStorage()
+	userRepo := storage.NewUserRepository()
+	uow := storage.NewUnitOfWork()
+	service := NewTeamService(storage.NewTeamRepository(), userRepo, storage.NewPullRequestRepository(),
+		storage.NewReviewerRepository(), uow, testReview, logger)
+	prService := NewPullRequestService(storage.NewPullRequestRepository(), storage.NewReviewerRepository(), userRepo,
+		uow, testReview, logger)
+
+	_, err := service.AddTeam(ctx, team.AddTeamRequest{
+		TeamName: "payments",
+		Members: []team.TeamMember{
+			{UserID: "p1", Username: "Paul", IsActive: true},
+			{UserID: "p2", Username: "Peter", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+	for i := range team.MaxAffectedPRs + 1 {
+		_, err := prService.CreatePR(ctx, pullrequest.CreatePrRequest{
+			PullRequestID: fmt.Sprintf("pr-%03d", i), PullRequestName: "Refund", AuthorID: "p2",
+		})
+		require.NoError(t, err)
+	}
+
+	resp, err := service.DeactivateTeam(ctx, team.DeactivateTeamRequest{TeamName: "payments"})
+
+	require.NoError(t, err)
+	assert.Equal(t, team.MaxAffectedPRs+1, resp.ReassignedPRs)
+	assert.Equal(t, team.MaxAffectedPRs+1, resp.Removed)
+	assert.Len(t, resp.AffectedPRs, team.MaxAffectedPRs)
+	assert.True(t, resp.AffectedPRsTruncated)
+	assert.Equal(t, []team.ReplacedReviewer{{OldReviewerID: "p1"}}, resp.AffectedPRs[0].Reviewers,
+		"nobody is left to replace p1")
+}
+
+func TestTeamService_DeactivateTeam_CloseAuthoredPRs(t *testing.T) {
+	logger := slog.New(slog.DiscardHandler)
+	newServices := func(t *testing.T) (*TeamService, *PullRequestService) {
+		ctx := context.Background()
+		storage := memory.NewStorage()
+		userRepo := storage.NewUserRepository()
+		uow := storage.NewUnitOfWork()
+		service := NewTeamService(storage.NewTeamRepository(), userRepo, storage.NewPullRequestRepository(),
+			storage.NewReviewerRepository(), uow, testReview, logger)
+		prService := NewPullRequestService(storage.NewPullRequestRepository(), storage.NewReviewerRepository(), userRepo,
+			uow, testReview, logger)
+
+		_, err := service.AddTeam(ctx, team.AddTeamRequest{
+			TeamName: "payments",
+			Members: []team.TeamMember{
+				{UserID: "p1", Username: "Paul", IsActive: true},
+				{UserID: "p2", Username: "Peter", IsActive: true},
+				{UserID: "p3", Username: "Pam", IsActive: true},
+			},
+		})
+		require.NoError(t, err)
+		_, err = prService.CreatePR(ctx, pullrequest.CreatePrRequest{
+			PullRequestID: "pr-refund", PullRequestName: "Refund", AuthorID: "p1",
+		})
+		require.NoError(t, err)
+		return service, prService
+	}
+
+	t.Run("Success - Authored PRs are closed without reviewers", func(t *testing.T) {
+		ctx := context.Background()
+		service, prService := newServices(t)
+
+		resp, err := service.DeactivateTeam(ctx, team.DeactivateTeamRequest{TeamName: "payments", CloseAuthoredPRs: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-refund"}, resp.ClosedPRs)
+		assert.Zero(t, resp.ReassignedPRs, "closed PRs are not reassigned")
+		assert.Empty(t, resp.AffectedPRs)
+
+		_, err = prService.MergePR(ctx, pullrequest.MergePrRequest{PullRequestID: "pr-refund"})
+		assert.True(t, errors.HasCode(err, errors.CodePRClosed))
+		found, err := prService.SearchPRs(ctx, pullrequest.SearchPrRequest{
+			Query: "Refund", Status: models.PRStatusClosed, Page: dto.PageRequest{Limit: 20},
+		})
+		require.NoError(t, err)
+		if assert.Len(t, found.Items, 1) {
+			assert.Empty(t, found.Items[0].AssignedReviewers)
+		}
+	})
+
+	t.Run("Success - Authored PRs stay open by default", func(t *testing.T) {
+		ctx := context.Background()
+		service, _ := newServices(t)
+
+		resp, err := service.DeactivateTeam(ctx, team.DeactivateTeamRequest{TeamName: "payments"})
+
+		require.NoError(t, err)
+		assert.Nil(t, resp.ClosedPRs)
+		assert.Equal(t, 2, resp.ReassignedPRs)
+		assert.Equal(t, 2, resp.Removed)
+	})
+}
+
+func TestTeamService_GetReviewQueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTeamRepo := mocks.NewMockTeamRepository(ctrl)
+	mockUserRepo := mocks.NewMockTeamUserRepository(ctrl)
+	mockPRRepo := mocks.NewMockTeamPRRepository(ctrl)
+	mockReviewerRepo := mocks.NewMockTeamReviewerRepository(ctrl)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	service := NewTeamService(mockTeamRepo, mockUserRepo, mockPRRepo, mockReviewerRepo, nil, testReview, logger)
+
+	backend := &models.Team{
+		Members: []*models.User{{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true}},
+	}
+
+	t.Run("Success - Oldest PR first with age", func(t *testing.T) {
+		ctx := context.Background()
+		now := time.Now().UTC()
+
+		mockTeamRepo.EXPECT().GetTeamByName(ctx, "backend").Return(backend, nil)
+		mockReviewerRepo.EXPECT().GetOpenReviewCounts(ctx, []string{"u1"}).Return(map[string]int{"u1": 2}, nil)
+		mockPRRepo.EXPECT().FindOpenPRsReviewedByTeam(ctx, "backend", models.PRSort{}).Return([]*models.PullRequest{
+			{Id: "pr-1", Title: "Old", AuthorId: "x1", Status: models.PRStatusOpen,
+				CreatedAt: now.Add(-2 * time.Hour), UpdatedAt: now.Add(-2 * time.Hour), ReviewersId: []string{"u1"}},
+			{Id: "pr-2", Title: "New", AuthorId: "x2", Status: models.PRStatusOpen,
+				CreatedAt: now.Add(-time.Minute), UpdatedAt: now.Add(-time.Minute), ReviewersId: []string{"u1", "u2"}},
+		}, nil)
+
+		resp, err := service.GetReviewQueue(ctx, team.ReviewQueueRequest{TeamName: "backend"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "backend", resp.TeamName)
+		assert.Len(t, resp.PullRequests, 2)
+		assert.Equal(t, "pr-1", resp.PullRequests[0].PullRequestID)
+		assert.Equal(t, []string{"u1"}, resp.PullRequests[0].AssignedReviewers)
+		assert.GreaterOrEqual(t, resp.PullRequests[0].AgeSeconds, int64(7200))
+		assert.Equal(t, []string{"u1", "u2"}, resp.PullRequests[1].AssignedReviewers)
+		assert.Less(t, resp.PullRequests[1].AgeSeconds, resp.PullRequests[0].AgeSeconds)
+		assert.Equal(t, []team.ReviewerLoad{
+			{UserID: "u1", Username: "Alice", IsActive: true, OpenReviews: 2},
+		}, resp.Reviewers)
+	})
+
+	t.Run("Success - Reviewer capacity", func(t *testing.T) {
+		ctx := context.Background()
+		limit := 3
+		capped := NewTeamService(mockTeamRepo, mockUserRepo, mockPRRepo, mockReviewerRepo, nil,
+			config.Review{Deadline: testReview.Deadline, MaxActiveReviews: 2}, logger)
+
+		mockTeamRepo.EXPECT().GetTeamByName(ctx, "backend").Return(&models.Team{Members: []*models.User{
+			{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+			{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true, MaxActiveReviews: &limit},
+		}}, nil)
+		mockReviewerRepo.EXPECT().GetOpenReviewCounts(ctx, []string{"u1", "u2"}).
+			Return(map[string]int{"u1": 2, "u2": 2}, nil)
+		mockPRRepo.EXPECT().FindOpenPRsReviewedByTeam(ctx, "backend", models.PRSort{}).Return(nil, nil)
+
+		resp, err := capped.GetReviewQueue(ctx, team.ReviewQueueRequest{TeamName: "backend"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []team.ReviewerLoad{
+			{UserID: "u1", Username: "Alice", IsActive: true, OpenReviews: 2, Capacity: 2, AtCapacity: true},
+			{UserID: "u2", Username: "Bob", IsActive: true, OpenReviews: 2, Capacity: 3},
+		}, resp.Reviewers)
+	})
+
+	t.Run("Success - Expanded reviewers", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockTeamRepo.EXPECT().GetTeamByName(ctx, "backend").Return(backend, nil)
+		mockReviewerRepo.EXPECT().GetOpenReviewCounts(ctx, []string{"u1"}).Return(map[string]int{"u1": 2}, nil)
+		mockPRRepo.EXPECT().FindOpenPRsReviewedByTeam(ctx, "backend", models.PRSort{}).Return([]*models.PullRequest{
+			{Id: "pr-1", Title: "Old", AuthorId: "x1", Status: models.PRStatusOpen, ReviewersId: []string{"u1"}},
+		}, nil)
+		mockUserRepo.EXPECT().FindByIDs(ctx, []string{"u1"}).Return(backend.Members, nil)
+		mockReviewerRepo.EXPECT().GetAssignmentsByPRs(ctx, []string{"pr-1"}).Return(nil, nil)
+
+		resp, err := service.GetReviewQueue(ctx, team.ReviewQueueRequest{TeamName: "backend", ExpandReviewers: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []pullrequest.Reviewer{
+			{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		}, resp.PullRequests[0].Reviewers)
+	})
+
+	t.Run("Success - Unreviewed only skips approved PRs", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockTeamRepo.EXPECT().GetTeamByName(ctx, "backend").Return(backend, nil)
+		mockReviewerRepo.EXPECT().GetOpenReviewCounts(ctx, []string{"u1"}).Return(map[string]int{"u1": 2}, nil)
+		mockPRRepo.EXPECT().FindOpenPRsReviewedByTeam(ctx, "backend", models.PRSort{}).Return([]*models.PullRequest{
+			{Id: "pr-1", Title: "Approved", AuthorId: "x1", Status: models.PRStatusOpen, ReviewersId: []string{"u1", "u2"}},
+			{Id: "pr-2", Title: "Changes requested", AuthorId: "x2", Status: models.PRStatusOpen, ReviewersId: []string{"u1"}},
+		}, nil)
+		mockReviewerRepo.EXPECT().GetAssignmentsByPRs(ctx, []string{"pr-1", "pr-2"}).Return([]*models.ReviewAssignment{
+			{PRId: "pr-1", ReviewerId: "u1", State: models.ReviewStateChangesRequested},
+			{PRId: "pr-1", ReviewerId: "u2", State: models.ReviewStateApproved},
+			{PRId: "pr-2", ReviewerId: "u1", State: models.ReviewStateChangesRequested},
+		}, nil)
+
+		resp, err := service.GetReviewQueue(ctx, team.ReviewQueueRequest{TeamName: "backend", UnreviewedOnly: true})
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.PullRequests, 1)
+		assert.Equal(t, "pr-2", resp.PullRequests[0].PullRequestID)
+	})
+
+	t.Run("Success - Sort replaces the urgent-first order", func(t *testing.T) {
+		ctx := context.Background()
+		newestFirst := models.PRSort{Field: models.PRSortCreatedAt, Desc: true}
+
+		mockTeamRepo.EXPECT().GetTeamByName(ctx, "backend").Return(backend, nil)
+		mockReviewerRepo.EXPECT().GetOpenReviewCounts(ctx, []string{"u1"}).Return(map[string]int{"u1": 2}, nil)
+		mockPRRepo.EXPECT().FindOpenPRsReviewedByTeam(ctx, "backend", newestFirst).Return([]*models.PullRequest{
+			{Id: "pr-2", AuthorId: "x2", Status: models.PRStatusOpen, Priority: models.PRPriorityNormal,
+				ReviewersId: []string{"u1"}},
+			{Id: "pr-1", AuthorId: "x1", Status: models.PRStatusOpen, Priority: models.PRPriorityUrgent,
+				ReviewersId: []string{"u1"}},
+		}, nil)
+
+		resp, err := service.GetReviewQueue(ctx, team.ReviewQueueRequest{
+			TeamName: "backend", Sort: dto.Sort{Field: "created_at", Desc: true},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "pr-2", resp.PullRequests[0].PullRequestID)
+		assert.Equal(t, "pr-1", resp.PullRequests[1].PullRequestID)
+	})
+
+	t.Run("Success - Empty queue", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockTeamRepo.EXPECT().GetTeamByName(ctx, "backend").Return(backend, nil)
+		mockReviewerRepo.EXPECT().GetOpenReviewCounts(ctx, []string{"u1"}).Return(map[string]int{"u1": 2}, nil)
+		mockPRRepo.EXPECT().FindOpenPRsReviewedByTeam(ctx, "backend", models.PRSort{}).Return(nil, nil)
+
+		resp, err := service.GetReviewQueue(ctx, team.ReviewQueueRequest{TeamName: "backend", UnreviewedOnly: true})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp.PullRequests)
+		assert.Empty(t, resp.PullRequests)
+	})
+
+	t.Run("Error - Team not found", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockTeamRepo.EXPECT().GetTeamByName(ctx, "unknown").Return(nil, nil)
+
+		resp, err := service.GetReviewQueue(ctx, team.ReviewQueueRequest{TeamName: "unknown"})
+
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Equal(t, "NOT_FOUND", err.(*errors.AppError).Code)
+	})
+}
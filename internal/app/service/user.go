@@ -1,9 +1,15 @@
 package service
 
+//go:generate go tool mockgen -source=$GOFILE -destination=mocks/mock_user_deps.go -package=mocks
+
 import (
 	"context"
 	"log/slog"
+	"time"
 
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dbctx"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
 	userDto "github.com/shirr9/pr-reviewer-service/internal/app/dto/user"
 	"github.com/shirr9/pr-reviewer-service/internal/domain/errors"
 	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
@@ -13,33 +19,55 @@ import (
 type UserRepositoryForService interface {
 	FindByID(ctx context.Context, userID string) (*models.User, error)
 	SetIsActive(ctx context.Context, userID string, isActive bool) error
+	SetIsReviewer(ctx context.Context, userID string, isReviewer bool) error
+	SetTags(ctx context.Context, userID string, tags []string) error
+	UpdateUsername(ctx context.Context, userID, username string) error
+	FindByIDs(ctx context.Context, userIDs []string) ([]*models.User, error)
 }
 
 // PullRequestRepositoryForUser defines the interface for PR operations needed by UserService.
 type PullRequestRepositoryForUser interface {
 	FindByReviewer(ctx context.Context, reviewerID string) ([]*models.PullRequest, error)
+	FindOpenPRsByReviewers(ctx context.Context, reviewerIDs []string) ([]*models.PullRequest, error)
+}
+
+// ReviewerRepositoryForUser defines the interface for assignment operations needed by UserService.
+type ReviewerRepositoryForUser interface {
+	GetAssignmentsByPRs(ctx context.Context, prIDs []string) ([]*models.ReviewAssignment, error)
+	FindAssignmentsAfter(ctx context.Context, reviewerID string, after models.AssignmentCursor,
+		limit int) ([]*models.ReviewAssignment, error)
+	GetReviewsChangedAt(ctx context.Context, reviewerID string) (time.Time, error)
 }
 
+// pollAssignmentsLimit is the most assignments a poll returns at once.
+const pollAssignmentsLimit = 100
+
 // UserService implements business logic for user operations.
 type UserService struct {
-	userRepo UserRepositoryForService
-	prRepo   PullRequestRepositoryForUser
-	log      *slog.Logger
+	userRepo     UserRepositoryForService
+	prRepo       PullRequestRepositoryForUser
+	reviewerRepo ReviewerRepositoryForUser
+	review       config.Review
+	log          *slog.Logger
 }
 
 // NewUserService creates a new user service.
 func NewUserService(
 	userRepo UserRepositoryForService,
 	prRepo PullRequestRepositoryForUser,
+	reviewerRepo ReviewerRepositoryForUser,
+	review config.Review,
 	log *slog.Logger,
 ) *UserService {
 	if log == nil {
 		log = slog.Default()
 	}
 	return &UserService{
-		userRepo: userRepo,
-		prRepo:   prRepo,
-		log:      log,
+		userRepo:     userRepo,
+		prRepo:       prRepo,
+		reviewerRepo: reviewerRepo,
+		review:       review,
+		log:          log,
 	}
 }
 
@@ -47,7 +75,7 @@ func NewUserService(
 func (s *UserService) SetIsActive(ctx context.Context, req userDto.SetIsActiveRequest) (*userDto.SetIsActiveResponse, error) {
 	user, err := s.userRepo.FindByID(ctx, req.UserID)
 	if err != nil {
-		s.log.LogAttrs(ctx, slog.LevelError, "failed to find user",
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find user",
 			slog.String("user_id", req.UserID), slog.String("error", err.Error()))
 		return nil, err
 	}
@@ -58,7 +86,7 @@ func (s *UserService) SetIsActive(ctx context.Context, req userDto.SetIsActiveRe
 	}
 
 	if err := s.userRepo.SetIsActive(ctx, req.UserID, req.IsActive); err != nil {
-		s.log.LogAttrs(ctx, slog.LevelError, "failed to set is_active",
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to set is_active",
 			slog.String("user_id", req.UserID),
 			slog.Bool("is_active", req.IsActive),
 			slog.String("error", err.Error()))
@@ -71,31 +99,172 @@ func (s *UserService) SetIsActive(ctx context.Context, req userDto.SetIsActiveRe
 
 	return &userDto.SetIsActiveResponse{
 		User: userDto.User{
-			UserID:   user.Id,
-			Username: user.Name,
-			TeamName: user.TeamName,
-			IsActive: req.IsActive,
+			UserID:     user.Id,
+			Username:   user.Name,
+			TeamName:   user.TeamName,
+			IsActive:   req.IsActive,
+			IsReviewer: !user.NonReviewer,
+			Tags:       user.Tags,
 		},
 	}, nil
 }
 
-// GetReview returns list of PRs where user is assigned as reviewer.
+// SetIsReviewer adds the user to the reviewer pool of their team or takes them out of it and returns
+// updated user. Reviews the user already has are kept.
+func (s *UserService) SetIsReviewer(ctx context.Context, req userDto.SetIsReviewerRequest) (*userDto.SetIsReviewerResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, req.UserID)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find user",
+			slog.String("user_id", req.UserID), slog.String("error", err.Error()))
+		return nil, err
+	}
+	if user == nil {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "user not found",
+			slog.String("user_id", req.UserID))
+		return nil, errors.NewNotFound("user not found")
+	}
+
+	if err := s.userRepo.SetIsReviewer(ctx, req.UserID, req.IsReviewer); err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to set is_reviewer",
+			slog.String("user_id", req.UserID),
+			slog.Bool("is_reviewer", req.IsReviewer),
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "user is_reviewer updated",
+		slog.String("user_id", req.UserID),
+		slog.Bool("is_reviewer", req.IsReviewer))
+
+	return &userDto.SetIsReviewerResponse{
+		User: userDto.User{
+			UserID:     user.Id,
+			Username:   user.Name,
+			TeamName:   user.TeamName,
+			IsActive:   user.IsActive,
+			IsReviewer: req.IsReviewer,
+			Tags:       user.Tags,
+		},
+	}, nil
+}
+
+// SetTags replaces user's tags and returns updated user.
+func (s *UserService) SetTags(ctx context.Context, req userDto.SetTagsRequest) (*userDto.SetTagsResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, req.UserID)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find user",
+			slog.String("user_id", req.UserID), slog.String("error", err.Error()))
+		return nil, err
+	}
+	if user == nil {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "user not found",
+			slog.String("user_id", req.UserID))
+		return nil, errors.NewNotFound("user not found")
+	}
+
+	tags := models.NormalizeTags(req.Tags)
+	if err := s.userRepo.SetTags(ctx, req.UserID, tags); err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to set tags",
+			slog.String("user_id", req.UserID), slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "user tags updated",
+		slog.String("user_id", req.UserID),
+		slog.Any("tags", tags))
+
+	return &userDto.SetTagsResponse{
+		User: userDto.User{
+			UserID:     user.Id,
+			Username:   user.Name,
+			TeamName:   user.TeamName,
+			IsActive:   user.IsActive,
+			IsReviewer: !user.NonReviewer,
+			Tags:       tags,
+		},
+	}, nil
+}
+
+// UpdateUser changes the username of the user and returns updated user.
+func (s *UserService) UpdateUser(ctx context.Context, req userDto.UpdateUserRequest) (*userDto.UpdateUserResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, req.UserID)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find user",
+			slog.String("user_id", req.UserID), slog.String("error", err.Error()))
+		return nil, err
+	}
+	if user == nil {
+		s.log.LogAttrs(ctx, slog.LevelWarn, "user not found",
+			slog.String("user_id", req.UserID))
+		return nil, errors.NewNotFound("user not found")
+	}
+
+	if err := s.userRepo.UpdateUsername(ctx, req.UserID, req.Username); err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to update username",
+			slog.String("user_id", req.UserID), slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "username updated",
+		slog.String("user_id", req.UserID),
+		slog.String("username", req.Username))
+
+	return &userDto.UpdateUserResponse{
+		User: userDto.User{
+			UserID:     user.Id,
+			Username:   req.Username,
+			TeamName:   user.TeamName,
+			IsActive:   user.IsActive,
+			IsReviewer: !user.NonReviewer,
+			Tags:       user.Tags,
+		},
+	}, nil
+}
+
+// GetReview returns list of PRs where user is assigned as reviewer. It only reads and may be
+// served by a replica.
 func (s *UserService) GetReview(ctx context.Context, userID string) (*userDto.GetReviewResponse, error) {
+	ctx = dbctx.ReadOnly(ctx)
+	// read before the PRs, so a change made in between is reported by the next request rather than hidden
+	lastModified, err := s.reviewerRepo.GetReviewsChangedAt(ctx, userID)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get reviews change time",
+			slog.String("user_id", userID), slog.String("error", err.Error()))
+		return nil, err
+	}
 	prs, err := s.prRepo.FindByReviewer(ctx, userID)
 	if err != nil {
-		s.log.LogAttrs(ctx, slog.LevelError, "failed to find PRs by reviewer",
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find PRs by reviewer",
 			slog.String("user_id", userID), slog.String("error", err.Error()))
 		return nil, err
 	}
 
+	sortUrgentFirst(prs)
+
+	mine := make(map[string]*models.ReviewAssignment, len(prs))
+	if len(prs) > 0 {
+		prIDs := make([]string, 0, len(prs))
+		for _, pr := range prs {
+			prIDs = append(prIDs, pr.Id)
+		}
+		assignments, err := s.reviewerRepo.GetAssignmentsByPRs(ctx, prIDs)
+		if err != nil {
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to get assignments",
+				slog.String("user_id", userID), slog.String("error", err.Error()))
+			return nil, err
+		}
+		for _, a := range assignments {
+			if a.ReviewerId == userID {
+				mine[a.PRId] = a
+			}
+		}
+	}
+
+	now := time.Now().UTC()
 	prDTOs := make([]userDto.PR, 0, len(prs))
 	for _, pr := range prs {
-		prDTOs = append(prDTOs, userDto.PR{
-			PullRequestID:   pr.Id,
-			PullRequestName: pr.Title,
-			AuthorID:        pr.AuthorId,
-			Status:          pr.Status,
-		})
+		prDTOs = append(prDTOs, s.newReviewPRDto(pr, mine[pr.Id], now))
+		lastModified = latest(lastModified, s.reviewChangedAt(pr, mine[pr.Id], now))
 	}
 
 	s.log.LogAttrs(ctx, slog.LevelInfo, "user PRs retrieved",
@@ -105,5 +274,187 @@ func (s *UserService) GetReview(ctx context.Context, userID string) (*userDto.Ge
 	return &userDto.GetReviewResponse{
 		UserID:       userID,
 		PullRequests: prDTOs,
+		LastModified: lastModified,
 	}, nil
 }
+
+// reviewChangedAt returns when the PR as listed for its reviewer last changed by itself: its
+// update, the assignment and its review state, and the deadline once the review became overdue at it.
+func (s *UserService) reviewChangedAt(pr *models.PullRequest, a *models.ReviewAssignment, now time.Time) time.Time {
+	changedAt := pr.UpdatedAt
+	if a == nil {
+		return changedAt
+	}
+	changedAt = latest(changedAt, a.AssignedAt)
+	if a.StateChangedAt != nil {
+		changedAt = latest(changedAt, *a.StateChangedAt)
+	}
+	if pr.Status == models.PRStatusOpen && models.IsOverdue(a.AssignedAt, s.review.Deadline, now) {
+		changedAt = latest(changedAt, models.ReviewDeadline(a.AssignedAt, s.review.Deadline))
+	}
+	return changedAt
+}
+
+// latest returns the later of two times.
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// PollAssignments returns the user's assignments on open PRs made after req.Since, oldest first,
+// without waiting for new ones. Assignment times come from the clock of the instance making them,
+// so an assignment committed slightly later than one made after it may come before the cursor and
+// be missed by a poll.
+// Returns NOT_FOUND AppError when the user doesn't exist.
+func (s *UserService) PollAssignments(ctx context.Context, req userDto.PollAssignmentsRequest) (*userDto.PollAssignmentsResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, req.UserID)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find user",
+			slog.String("user_id", req.UserID), slog.String("error", err.Error()))
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.NewNotFound("user not found")
+	}
+
+	assignments, err := s.reviewerRepo.FindAssignmentsAfter(ctx, req.UserID, req.Since, pollAssignmentsLimit+1)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find new assignments",
+			slog.String("user_id", req.UserID), slog.String("error", err.Error()))
+		return nil, err
+	}
+	response := &userDto.PollAssignmentsResponse{
+		UserID:       req.UserID,
+		Cursor:       userDto.FormatAssignmentCursor(req.Since),
+		PullRequests: []userDto.PR{},
+		HasMore:      len(assignments) > pollAssignmentsLimit,
+	}
+	if len(assignments) == 0 {
+		return response, nil
+	}
+	assignments = assignments[:min(len(assignments), pollAssignmentsLimit)]
+
+	prs, err := s.prRepo.FindOpenPRsByReviewers(ctx, []string{req.UserID})
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find open PRs by reviewer",
+			slog.String("user_id", req.UserID), slog.String("error", err.Error()))
+		return nil, err
+	}
+	byID := make(map[string]*models.PullRequest, len(prs))
+	for _, pr := range prs {
+		byID[pr.Id] = pr
+	}
+
+	now := time.Now().UTC()
+	for _, a := range assignments {
+		// the cursor moves past a PR merged since the assignments were read, which is left out
+		if pr := byID[a.PRId]; pr != nil {
+			response.PullRequests = append(response.PullRequests, s.newReviewPRDto(pr, a, now))
+		}
+	}
+	last := assignments[len(assignments)-1]
+	response.Cursor = userDto.FormatAssignmentCursor(models.AssignmentCursor{AssignedAt: last.AssignedAt, PRId: last.PRId})
+
+	return response, nil
+}
+
+// newReviewPRDto converts a PR with the user's assignment on it, which may be missing, to the DTO.
+func (s *UserService) newReviewPRDto(pr *models.PullRequest, a *models.ReviewAssignment, now time.Time) userDto.PR {
+	prDTO := userDto.PR{
+		PullRequestID:   pr.Id,
+		PullRequestName: pr.Title,
+		AuthorID:        pr.AuthorId,
+		Status:          pr.Status,
+		Priority:        pr.Priority,
+		Labels:          labelsOrEmpty(pr.Labels),
+	}
+	if a != nil {
+		prDTO.AssignedAt = dto.FormatTime(a.AssignedAt)
+		prDTO.Source = a.Source
+		prDTO.Deadline = dto.FormatTime(models.ReviewDeadline(a.AssignedAt, s.review.Deadline))
+		prDTO.Overdue = pr.Status == models.PRStatusOpen && models.IsOverdue(a.AssignedAt, s.review.Deadline, now)
+		prDTO.ReviewState = a.State
+		prDTO.StateChangedAt = dto.FormatTimePtr(a.StateChangedAt)
+	}
+	return prDTO
+}
+
+// GetUsers returns the users with the ids in one lookup, skipping unknown ids. It only reads and
+// may be served by a replica.
+func (s *UserService) GetUsers(ctx context.Context, userIDs []string) ([]userDto.User, error) {
+	if len(userIDs) == 0 {
+		return []userDto.User{}, nil
+	}
+	ctx = dbctx.ReadOnly(ctx)
+	users, err := s.userRepo.FindByIDs(ctx, userIDs)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find users",
+			slog.Int("count", len(userIDs)), slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	result := make([]userDto.User, 0, len(users))
+	for _, u := range users {
+		result = append(result, userDto.User{
+			UserID:     u.Id,
+			Username:   u.Name,
+			TeamName:   u.TeamName,
+			IsActive:   u.IsActive,
+			IsReviewer: !u.NonReviewer,
+			Tags:       u.Tags,
+		})
+	}
+	return result, nil
+}
+
+// GetOpenReviews returns the open PRs each of the users is assigned to review, URGENT first, with
+// one PR lookup and one assignment lookup for all of them. Users without open reviews are left out.
+// It only reads and may be served by a replica.
+func (s *UserService) GetOpenReviews(ctx context.Context, userIDs []string) (map[string][]userDto.PR, error) {
+	result := make(map[string][]userDto.PR, len(userIDs))
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+	ctx = dbctx.ReadOnly(ctx)
+	prs, err := s.prRepo.FindOpenPRsByReviewers(ctx, userIDs)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find open PRs by reviewers",
+			slog.Int("count", len(userIDs)), slog.String("error", err.Error()))
+		return nil, err
+	}
+	if len(prs) == 0 {
+		return result, nil
+	}
+	sortUrgentFirst(prs)
+
+	prIDs := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		prIDs = append(prIDs, pr.Id)
+	}
+	assignments, err := s.reviewerRepo.GetAssignmentsByPRs(ctx, prIDs)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to get assignments",
+			slog.Int("count", len(prIDs)), slog.String("error", err.Error()))
+		return nil, err
+	}
+	wanted := make(map[string]bool, len(userIDs))
+	for _, id := range userIDs {
+		wanted[id] = true
+	}
+	byPR := make(map[string][]*models.ReviewAssignment, len(prs))
+	for _, a := range assignments {
+		if wanted[a.ReviewerId] {
+			byPR[a.PRId] = append(byPR[a.PRId], a)
+		}
+	}
+
+	now := time.Now().UTC()
+	for _, pr := range prs {
+		for _, a := range byPR[pr.Id] {
+			result[a.ReviewerId] = append(result[a.ReviewerId], s.newReviewPRDto(pr, a, now))
+		}
+	}
+	return result, nil
+}
@@ -2,10 +2,14 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/shirr9/pr-reviewer-service/internal/app/dbctx"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
 	"github.com/shirr9/pr-reviewer-service/internal/app/dto/user"
 	"github.com/shirr9/pr-reviewer-service/internal/app/service/mocks"
 	"github.com/shirr9/pr-reviewer-service/internal/domain/errors"
@@ -22,7 +26,7 @@ func TestUserService_SetIsActive(t *testing.T) {
 	mockPRRepo := mocks.NewMockPullRequestRepositoryForUser(ctrl)
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
-	service := NewUserService(mockUserRepo, mockPRRepo, logger)
+	service := NewUserService(mockUserRepo, mockPRRepo, nil, testReview, logger)
 
 	t.Run("Success - Set user active", func(t *testing.T) {
 		ctx := context.Background()
@@ -116,18 +120,145 @@ func TestUserService_SetIsActive(t *testing.T) {
 	})
 }
 
+func TestUserService_SetIsReviewer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUserRepo := mocks.NewMockUserRepositoryForService(ctrl)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	service := NewUserService(mockUserRepo, nil, nil, testReview, logger)
+
+	t.Run("Success - User leaves the reviewer pool", func(t *testing.T) {
+		ctx := context.Background()
+		existingUser := &models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true}
+
+		mockUserRepo.EXPECT().FindByID(ctx, "u1").Return(existingUser, nil)
+		mockUserRepo.EXPECT().SetIsReviewer(ctx, "u1", false).Return(nil)
+
+		resp, err := service.SetIsReviewer(ctx, user.SetIsReviewerRequest{UserID: "u1", IsReviewer: false})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "backend", resp.User.TeamName)
+		assert.True(t, resp.User.IsActive)
+		assert.False(t, resp.User.IsReviewer)
+	})
+
+	t.Run("Error - User not found", func(t *testing.T) {
+		ctx := context.Background()
+		mockUserRepo.EXPECT().FindByID(ctx, "nonexistent").Return(nil, nil)
+
+		resp, err := service.SetIsReviewer(ctx, user.SetIsReviewerRequest{UserID: "nonexistent", IsReviewer: true})
+
+		assert.Nil(t, resp)
+		assert.True(t, errors.HasCode(err, errors.CodeNotFound))
+	})
+}
+
+func TestUserService_SetTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepositoryForService(ctrl)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	service := NewUserService(mockUserRepo, nil, nil, testReview, logger)
+
+	t.Run("Success - Tags are normalized", func(t *testing.T) {
+		ctx := context.Background()
+		existingUser := &models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true, Tags: []string{"go"}}
+
+		mockUserRepo.EXPECT().FindByID(ctx, "u1").Return(existingUser, nil)
+		mockUserRepo.EXPECT().SetTags(ctx, "u1", []string{"postgres", "security"}).Return(nil)
+
+		resp, err := service.SetTags(ctx, user.SetTagsRequest{UserID: "u1", Tags: []string{"Security", " postgres", "security"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "backend", resp.User.TeamName)
+		assert.Equal(t, []string{"postgres", "security"}, resp.User.Tags)
+	})
+
+	t.Run("Success - Empty list clears tags", func(t *testing.T) {
+		ctx := context.Background()
+		existingUser := &models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true, Tags: []string{"go"}}
+
+		mockUserRepo.EXPECT().FindByID(ctx, "u1").Return(existingUser, nil)
+		mockUserRepo.EXPECT().SetTags(ctx, "u1", []string{}).Return(nil)
+
+		resp, err := service.SetTags(ctx, user.SetTagsRequest{UserID: "u1"})
+
+		assert.NoError(t, err)
+		assert.Empty(t, resp.User.Tags)
+	})
+
+	t.Run("Error - User not found", func(t *testing.T) {
+		ctx := context.Background()
+		mockUserRepo.EXPECT().FindByID(ctx, "nonexistent").Return(nil, nil)
+
+		resp, err := service.SetTags(ctx, user.SetTagsRequest{UserID: "nonexistent", Tags: []string{"go"}})
+
+		assert.Nil(t, resp)
+		assert.True(t, errors.HasCode(err, errors.CodeNotFound))
+	})
+}
+
+func TestUserService_UpdateUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepositoryForService(ctrl)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	service := NewUserService(mockUserRepo, nil, nil, testReview, logger)
+
+	t.Run("Success - Username updated", func(t *testing.T) {
+		ctx := context.Background()
+		existingUser := &models.User{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true, Tags: []string{"go"}}
+
+		mockUserRepo.EXPECT().FindByID(ctx, "u1").Return(existingUser, nil)
+		mockUserRepo.EXPECT().UpdateUsername(ctx, "u1", "Alice Smith").Return(nil)
+
+		resp, err := service.UpdateUser(ctx, user.UpdateUserRequest{UserID: "u1", Username: "Alice Smith"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Alice Smith", resp.User.Username)
+		assert.Equal(t, "backend", resp.User.TeamName)
+		assert.Equal(t, []string{"go"}, resp.User.Tags)
+	})
+
+	t.Run("Error - User not found", func(t *testing.T) {
+		ctx := context.Background()
+		mockUserRepo.EXPECT().FindByID(ctx, "nonexistent").Return(nil, nil)
+
+		resp, err := service.UpdateUser(ctx, user.UpdateUserRequest{UserID: "nonexistent", Username: "Nobody"})
+
+		assert.Nil(t, resp)
+		assert.True(t, errors.HasCode(err, errors.CodeNotFound))
+	})
+
+	t.Run("Error - Repository failure", func(t *testing.T) {
+		ctx := context.Background()
+		mockUserRepo.EXPECT().FindByID(ctx, "u1").Return(&models.User{Id: "u1", Name: "Alice"}, nil)
+		mockUserRepo.EXPECT().UpdateUsername(ctx, "u1", "Alice Smith").Return(assert.AnError)
+
+		resp, err := service.UpdateUser(ctx, user.UpdateUserRequest{UserID: "u1", Username: "Alice Smith"})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
 func TestUserService_GetReview(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockUserRepo := mocks.NewMockUserRepositoryForService(ctrl)
 	mockPRRepo := mocks.NewMockPullRequestRepositoryForUser(ctrl)
+	mockReviewerRepo := mocks.NewMockReviewerRepositoryForUser(ctrl)
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
-	service := NewUserService(mockUserRepo, mockPRRepo, logger)
+	service := NewUserService(mockUserRepo, mockPRRepo, mockReviewerRepo, testReview, logger)
 
 	t.Run("Success - Get reviews for user with multiple PRs", func(t *testing.T) {
 		ctx := context.Background()
+		readCtx := dbctx.ReadOnly(ctx)
 		userID := "u2"
 
 		prs := []*models.PullRequest{
@@ -145,7 +276,17 @@ func TestUserService_GetReview(t *testing.T) {
 			},
 		}
 
-		mockPRRepo.EXPECT().FindByReviewer(ctx, "u2").Return(prs, nil)
+		longAgo := time.Now().UTC().AddDate(0, 0, -14)
+		recently := time.Now().UTC().Add(-time.Minute)
+
+		mockReviewerRepo.EXPECT().GetReviewsChangedAt(readCtx, "u2").Return(time.Time{}, nil)
+		mockPRRepo.EXPECT().FindByReviewer(readCtx, "u2").Return(prs, nil)
+		mockReviewerRepo.EXPECT().GetAssignmentsByPRs(readCtx, []string{"pr-1", "pr-2"}).Return([]*models.ReviewAssignment{
+			{PRId: "pr-1", ReviewerId: "u2", AssignedAt: longAgo, State: models.ReviewStatePending},
+			{PRId: "pr-1", ReviewerId: "u3", AssignedAt: recently, State: models.ReviewStateApproved},
+			{PRId: "pr-2", ReviewerId: "u2", AssignedAt: recently,
+				State: models.ReviewStateChangesRequested, StateChangedAt: &recently},
+		}, nil)
 
 		resp, err := service.GetReview(ctx, userID)
 
@@ -156,25 +297,39 @@ func TestUserService_GetReview(t *testing.T) {
 		assert.Equal(t, "Add feature", resp.PullRequests[0].PullRequestName)
 		assert.Equal(t, "u1", resp.PullRequests[0].AuthorID)
 		assert.Equal(t, models.PRStatusOpen, resp.PullRequests[0].Status)
+		assert.Equal(t, dto.FormatTime(longAgo), resp.PullRequests[0].AssignedAt)
+		assert.Equal(t, dto.FormatTime(models.ReviewDeadline(longAgo, testReview.Deadline)), resp.PullRequests[0].Deadline)
+		assert.True(t, resp.PullRequests[0].Overdue)
+		assert.False(t, resp.PullRequests[1].Overdue)
+		assert.Equal(t, models.ReviewStatePending, resp.PullRequests[0].ReviewState)
+		assert.Empty(t, resp.PullRequests[0].StateChangedAt)
+		assert.Equal(t, models.ReviewStateChangesRequested, resp.PullRequests[1].ReviewState)
+		assert.Equal(t, dto.FormatTime(recently), resp.PullRequests[1].StateChangedAt)
+		assert.Equal(t, recently, resp.LastModified, "the latest assignment or review state change")
 	})
 
 	t.Run("Success - Get reviews for user with no PRs", func(t *testing.T) {
 		ctx := context.Background()
+		readCtx := dbctx.ReadOnly(ctx)
 		userID := "u3"
 
 		prs := []*models.PullRequest{}
 
-		mockPRRepo.EXPECT().FindByReviewer(ctx, "u3").Return(prs, nil)
+		removedAt := time.Now().UTC().Add(-time.Hour)
+		mockReviewerRepo.EXPECT().GetReviewsChangedAt(readCtx, "u3").Return(removedAt, nil)
+		mockPRRepo.EXPECT().FindByReviewer(readCtx, "u3").Return(prs, nil)
 
 		resp, err := service.GetReview(ctx, userID)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, resp)
 		assert.Len(t, resp.PullRequests, 0)
+		assert.Equal(t, removedAt, resp.LastModified, "the last removal empties the list")
 	})
 
 	t.Run("Success - Get reviews including merged PRs", func(t *testing.T) {
 		ctx := context.Background()
+		readCtx := dbctx.ReadOnly(ctx)
 		userID := "u1"
 
 		prs := []*models.PullRequest{
@@ -192,7 +347,14 @@ func TestUserService_GetReview(t *testing.T) {
 			},
 		}
 
-		mockPRRepo.EXPECT().FindByReviewer(ctx, "u1").Return(prs, nil)
+		longAgo := time.Now().UTC().AddDate(0, 0, -14)
+
+		mockReviewerRepo.EXPECT().GetReviewsChangedAt(readCtx, "u1").Return(time.Time{}, nil)
+		mockPRRepo.EXPECT().FindByReviewer(readCtx, "u1").Return(prs, nil)
+		mockReviewerRepo.EXPECT().GetAssignmentsByPRs(readCtx, []string{"pr-1", "pr-2"}).Return([]*models.ReviewAssignment{
+			{PRId: "pr-1", ReviewerId: "u1", AssignedAt: longAgo},
+			{PRId: "pr-2", ReviewerId: "u1", AssignedAt: longAgo},
+		}, nil)
 
 		resp, err := service.GetReview(ctx, userID)
 
@@ -201,10 +363,13 @@ func TestUserService_GetReview(t *testing.T) {
 		assert.Len(t, resp.PullRequests, 2)
 		assert.Equal(t, models.PRStatusOpen, resp.PullRequests[0].Status)
 		assert.Equal(t, models.PRStatusMerged, resp.PullRequests[1].Status)
+		assert.True(t, resp.PullRequests[0].Overdue)
+		assert.False(t, resp.PullRequests[1].Overdue, "merged PRs are never overdue")
 	})
 
 	t.Run("Success - Get reviews for inactive user", func(t *testing.T) {
 		ctx := context.Background()
+		readCtx := dbctx.ReadOnly(ctx)
 		userID := "u4"
 
 		prs := []*models.PullRequest{
@@ -216,7 +381,9 @@ func TestUserService_GetReview(t *testing.T) {
 			},
 		}
 
-		mockPRRepo.EXPECT().FindByReviewer(ctx, "u4").Return(prs, nil)
+		mockReviewerRepo.EXPECT().GetReviewsChangedAt(readCtx, "u4").Return(time.Time{}, nil)
+		mockPRRepo.EXPECT().FindByReviewer(readCtx, "u4").Return(prs, nil)
+		mockReviewerRepo.EXPECT().GetAssignmentsByPRs(readCtx, []string{"pr-10"}).Return(nil, nil)
 
 		resp, err := service.GetReview(ctx, userID)
 
@@ -224,4 +391,231 @@ func TestUserService_GetReview(t *testing.T) {
 		assert.NotNil(t, resp)
 		assert.Len(t, resp.PullRequests, 1)
 	})
+
+	t.Run("Success - URGENT PRs first", func(t *testing.T) {
+		ctx := context.Background()
+		readCtx := dbctx.ReadOnly(ctx)
+
+		prs := []*models.PullRequest{
+			{Id: "pr-1", Title: "Newest", AuthorId: "u1", Status: models.PRStatusOpen, Priority: models.PRPriorityHigh},
+			{Id: "pr-2", Title: "Hotfix", AuthorId: "u1", Status: models.PRStatusOpen, Priority: models.PRPriorityUrgent},
+			{Id: "pr-3", Title: "Oldest", AuthorId: "u1", Status: models.PRStatusOpen, Priority: models.PRPriorityLow},
+		}
+
+		mockReviewerRepo.EXPECT().GetReviewsChangedAt(readCtx, "u5").Return(time.Time{}, nil)
+		mockPRRepo.EXPECT().FindByReviewer(readCtx, "u5").Return(prs, nil)
+		mockReviewerRepo.EXPECT().GetAssignmentsByPRs(readCtx, []string{"pr-2", "pr-1", "pr-3"}).Return(nil, nil)
+
+		resp, err := service.GetReview(ctx, "u5")
+
+		assert.NoError(t, err)
+		ids := make([]string, 0, len(resp.PullRequests))
+		for _, pr := range resp.PullRequests {
+			ids = append(ids, pr.PullRequestID)
+		}
+		assert.Equal(t, []string{"pr-2", "pr-1", "pr-3"}, ids)
+		assert.Equal(t, models.PRPriorityUrgent, resp.PullRequests[0].Priority)
+	})
+
+	t.Run("Success - Overdue review changes the list at its deadline", func(t *testing.T) {
+		ctx := context.Background()
+		readCtx := dbctx.ReadOnly(ctx)
+		longAgo := time.Now().UTC().AddDate(0, 0, -14)
+
+		mockReviewerRepo.EXPECT().GetReviewsChangedAt(readCtx, "u6").Return(longAgo, nil)
+		mockPRRepo.EXPECT().FindByReviewer(readCtx, "u6").Return([]*models.PullRequest{
+			{Id: "pr-1", Title: "Old", AuthorId: "u1", Status: models.PRStatusOpen, UpdatedAt: longAgo},
+		}, nil)
+		mockReviewerRepo.EXPECT().GetAssignmentsByPRs(readCtx, []string{"pr-1"}).Return([]*models.ReviewAssignment{
+			{PRId: "pr-1", ReviewerId: "u6", AssignedAt: longAgo, State: models.ReviewStatePending},
+		}, nil)
+
+		resp, err := service.GetReview(ctx, "u6")
+
+		assert.NoError(t, err)
+		assert.True(t, resp.PullRequests[0].Overdue)
+		assert.Equal(t, models.ReviewDeadline(longAgo, testReview.Deadline), resp.LastModified)
+	})
+
+	t.Run("Error - Change time unavailable", func(t *testing.T) {
+		ctx := context.Background()
+		readCtx := dbctx.ReadOnly(ctx)
+
+		mockReviewerRepo.EXPECT().GetReviewsChangedAt(readCtx, "u7").Return(time.Time{}, assert.AnError)
+
+		resp, err := service.GetReview(ctx, "u7")
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, resp)
+	})
+}
+
+func TestUserService_GetUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUserRepo := mocks.NewMockUserRepositoryForService(ctrl)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	service := NewUserService(mockUserRepo, mocks.NewMockPullRequestRepositoryForUser(ctrl),
+		mocks.NewMockReviewerRepositoryForUser(ctrl), testReview, logger)
+	ctx := context.Background()
+
+	t.Run("Success - Users are found in one lookup", func(t *testing.T) {
+		mockUserRepo.EXPECT().FindByIDs(dbctx.ReadOnly(ctx), []string{"u1", "u2", "u9"}).Return([]*models.User{
+			{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+			{Id: "u2", Name: "Bob", TeamName: "backend"},
+		}, nil)
+
+		users, err := service.GetUsers(ctx, []string{"u1", "u2", "u9"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []user.User{
+			{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true, IsReviewer: true},
+			{UserID: "u2", Username: "Bob", TeamName: "backend", IsReviewer: true},
+		}, users)
+	})
+
+	t.Run("Success - No ids need no lookup", func(t *testing.T) {
+		users, err := service.GetUsers(ctx, nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, users)
+	})
+
+	t.Run("Error - Repository failure", func(t *testing.T) {
+		mockUserRepo.EXPECT().FindByIDs(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+
+		_, err := service.GetUsers(ctx, []string{"u1"})
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestUserService_GetOpenReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPRRepo := mocks.NewMockPullRequestRepositoryForUser(ctrl)
+	mockReviewerRepo := mocks.NewMockReviewerRepositoryForUser(ctrl)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	service := NewUserService(mocks.NewMockUserRepositoryForService(ctrl), mockPRRepo, mockReviewerRepo,
+		testReview, logger)
+	ctx := context.Background()
+	readCtx := dbctx.ReadOnly(ctx)
+
+	t.Run("Success - Reviews of all users from one PR and one assignment lookup", func(t *testing.T) {
+		assignedAt := time.Now().UTC().Add(-time.Hour)
+		mockPRRepo.EXPECT().FindOpenPRsByReviewers(readCtx, []string{"u2", "u3"}).Return([]*models.PullRequest{
+			{Id: "pr-1", Title: "Add feature", AuthorId: "u1", Status: models.PRStatusOpen, Priority: models.PRPriorityNormal},
+			{Id: "pr-2", Title: "Fix outage", AuthorId: "u1", Status: models.PRStatusOpen, Priority: models.PRPriorityUrgent},
+		}, nil)
+		mockReviewerRepo.EXPECT().GetAssignmentsByPRs(readCtx, []string{"pr-2", "pr-1"}).Return([]*models.ReviewAssignment{
+			{PRId: "pr-1", ReviewerId: "u2", AssignedAt: assignedAt, State: models.ReviewStatePending},
+			{PRId: "pr-1", ReviewerId: "u4", AssignedAt: assignedAt, State: models.ReviewStatePending},
+			{PRId: "pr-2", ReviewerId: "u2", AssignedAt: assignedAt, State: models.ReviewStateApproved},
+		}, nil)
+
+		reviews, err := service.GetOpenReviews(ctx, []string{"u2", "u3"})
+
+		assert.NoError(t, err)
+		assert.Len(t, reviews, 1)
+		assert.Len(t, reviews["u2"], 2)
+		assert.Equal(t, "pr-2", reviews["u2"][0].PullRequestID)
+		assert.Equal(t, models.ReviewStateApproved, reviews["u2"][0].ReviewState)
+		assert.Equal(t, dto.FormatTime(assignedAt), reviews["u2"][1].AssignedAt)
+	})
+
+	t.Run("Success - Users without open reviews", func(t *testing.T) {
+		mockPRRepo.EXPECT().FindOpenPRsByReviewers(readCtx, []string{"u5"}).Return(nil, nil)
+
+		reviews, err := service.GetOpenReviews(ctx, []string{"u5"})
+
+		assert.NoError(t, err)
+		assert.Empty(t, reviews)
+	})
+
+	t.Run("Error - Repository failure", func(t *testing.T) {
+		mockPRRepo.EXPECT().FindOpenPRsByReviewers(readCtx, []string{"u2"}).Return(nil, assert.AnError)
+
+		_, err := service.GetOpenReviews(ctx, []string{"u2"})
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestUserService_PollAssignments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockUserRepo := mocks.NewMockUserRepositoryForService(ctrl)
+	mockPRRepo := mocks.NewMockPullRequestRepositoryForUser(ctrl)
+	mockReviewerRepo := mocks.NewMockReviewerRepositoryForUser(ctrl)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	service := NewUserService(mockUserRepo, mockPRRepo, mockReviewerRepo, testReview, logger)
+	ctx := context.Background()
+	reviewer := &models.User{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true}
+	assignedAt := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	since := models.AssignmentCursor{AssignedAt: assignedAt, PRId: "pr-1"}
+
+	t.Run("Success - Assignments after the cursor with the next cursor", func(t *testing.T) {
+		mockUserRepo.EXPECT().FindByID(ctx, "u2").Return(reviewer, nil)
+		mockReviewerRepo.EXPECT().FindAssignmentsAfter(ctx, "u2", since, pollAssignmentsLimit+1).
+			Return([]*models.ReviewAssignment{
+				{PRId: "pr-3", ReviewerId: "u2", AssignedAt: assignedAt, Source: models.AssignmentSourceAuto},
+				{PRId: "pr-2", ReviewerId: "u2", AssignedAt: assignedAt.Add(time.Second), Source: models.AssignmentSourceManual},
+				{PRId: "pr-4", ReviewerId: "u2", AssignedAt: assignedAt.Add(time.Minute)},
+			}, nil)
+		mockPRRepo.EXPECT().FindOpenPRsByReviewers(ctx, []string{"u2"}).Return([]*models.PullRequest{
+			{Id: "pr-2", Title: "Fix login", AuthorId: "u1", Status: models.PRStatusOpen},
+			{Id: "pr-3", Title: "Add search", AuthorId: "u1", Status: models.PRStatusOpen},
+		}, nil)
+
+		resp, err := service.PollAssignments(ctx, user.PollAssignmentsRequest{UserID: "u2", Since: since})
+
+		assert.NoError(t, err)
+		if assert.Len(t, resp.PullRequests, 2) {
+			assert.Equal(t, "pr-3", resp.PullRequests[0].PullRequestID)
+			assert.Equal(t, "pr-2", resp.PullRequests[1].PullRequestID)
+			assert.Equal(t, models.AssignmentSourceManual, resp.PullRequests[1].Source)
+		}
+		// pr-4 was merged after the assignments were read, and the cursor still moves past it
+		next, err := user.ParseAssignmentCursor(resp.Cursor)
+		assert.NoError(t, err)
+		assert.Equal(t, models.AssignmentCursor{AssignedAt: assignedAt.Add(time.Minute), PRId: "pr-4"}, next)
+		assert.False(t, resp.HasMore)
+	})
+
+	t.Run("Success - Nothing new keeps the cursor", func(t *testing.T) {
+		mockUserRepo.EXPECT().FindByID(ctx, "u2").Return(reviewer, nil)
+		mockReviewerRepo.EXPECT().FindAssignmentsAfter(ctx, "u2", since, pollAssignmentsLimit+1).Return(nil, nil)
+
+		resp, err := service.PollAssignments(ctx, user.PollAssignmentsRequest{UserID: "u2", Since: since})
+
+		assert.NoError(t, err)
+		assert.Empty(t, resp.PullRequests)
+		assert.NotNil(t, resp.PullRequests)
+		assert.Equal(t, user.FormatAssignmentCursor(since), resp.Cursor)
+	})
+
+	t.Run("Success - More assignments than fit are reported", func(t *testing.T) {
+		assignments := make([]*models.ReviewAssignment, 0, pollAssignmentsLimit+1)
+		prs := make([]*models.PullRequest, 0, pollAssignmentsLimit+1)
+		for i := range pollAssignmentsLimit + 1 {
+			id := fmt.Sprintf("pr-%03d", i)
+			assignments = append(assignments, &models.ReviewAssignment{PRId: id, ReviewerId: "u2", AssignedAt: assignedAt})
+			prs = append(prs, &models.PullRequest{Id: id, Status: models.PRStatusOpen})
+		}
+		mockUserRepo.EXPECT().FindByID(ctx, "u2").Return(reviewer, nil)
+		mockReviewerRepo.EXPECT().FindAssignmentsAfter(ctx, "u2", models.AssignmentCursor{}, pollAssignmentsLimit+1).
+			Return(assignments, nil)
+		mockPRRepo.EXPECT().FindOpenPRsByReviewers(ctx, []string{"u2"}).Return(prs, nil)
+
+		resp, err := service.PollAssignments(ctx, user.PollAssignmentsRequest{UserID: "u2"})
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.PullRequests, pollAssignmentsLimit)
+		assert.True(t, resp.HasMore)
+	})
+
+	t.Run("Error - Unknown user", func(t *testing.T) {
+		mockUserRepo.EXPECT().FindByID(ctx, "ghost").Return(nil, nil)
+
+		_, err := service.PollAssignments(ctx, user.PollAssignmentsRequest{UserID: "ghost"})
+
+		assert.True(t, errors.HasCode(err, errors.CodeNotFound))
+	})
 }
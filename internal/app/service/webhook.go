@@ -0,0 +1,333 @@
+package service
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mocks/mock_webhook_deps.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// Defaults used when the configured delivery policy is not positive.
+const (
+	defaultWebhookMaxAttempts    = 8
+	defaultWebhookInitialBackoff = 30 * time.Second
+	defaultWebhookMaxBackoff     = time.Hour
+)
+
+// WebhookRepository defines the interface for webhook delivery persistence.
+type WebhookRepository interface {
+	Enqueue(ctx context.Context, delivery *models.WebhookDelivery) error
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error)
+	FindByID(ctx context.Context, id int64) (*models.WebhookDelivery, error)
+	ListDead(ctx context.Context, limit, offset int) ([]*models.WebhookDelivery, int, error)
+	FindAttempts(ctx context.Context, deliveryIDs []int64) (map[int64][]*models.WebhookAttempt, error)
+	SaveAttempt(ctx context.Context, delivery *models.WebhookDelivery, attempt *models.WebhookAttempt) error
+	Requeue(ctx context.Context, id int64, at time.Time) (bool, error)
+	Stats(ctx context.Context) (*models.WebhookDeliveryStats, error)
+}
+
+// WebhookSender defines the interface for posting an event to the webhook.
+type WebhookSender interface {
+	Send(ctx context.Context, event any) error
+}
+
+// WebhookService queues events for the webhook and delivers them, retrying failed posts with
+// exponential backoff until the delivery succeeds or runs out of attempts and is dead-lettered.
+type WebhookService struct {
+	webhookRepo WebhookRepository
+	uow         Transactor
+	sender      WebhookSender
+	cfg         config.Webhook
+	log         *slog.Logger
+	// jitter spreads the retry delay, so deliveries failed together are not retried together.
+	jitter func(time.Duration) time.Duration
+}
+
+// NewWebhookService creates a new webhook service. Queued events are not delivered when sender is nil.
+func NewWebhookService(
+	webhookRepo WebhookRepository,
+	uow Transactor,
+	sender WebhookSender,
+	cfg config.Webhook,
+	log *slog.Logger,
+) *WebhookService {
+	if log == nil {
+		log = slog.Default()
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultWebhookMaxAttempts
+	}
+	if cfg.InitialBackoff <= 0 {
+		cfg.InitialBackoff = defaultWebhookInitialBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = defaultWebhookMaxBackoff
+	}
+	return &WebhookService{
+		webhookRepo: webhookRepo,
+		uow:         uow,
+		sender:      sender,
+		cfg:         cfg,
+		log:         log,
+		jitter:      equalJitter,
+	}
+}
+
+// equalJitter picks a delay between half the given one and the full one.
+func equalJitter(delay time.Duration) time.Duration {
+	half := delay / 2
+	if half <= 0 {
+		return delay
+	}
+	return half + rand.N(delay-half+1)
+}
+
+// Send queues the event for delivery; it is posted by DeliverDue. The event type is taken from
+// the "type" field of the event's JSON.
+func (s *WebhookService) Send(ctx context.Context, event any) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	var envelope struct {
+		Type string `json:"type"`
+	}
+	_ = json.Unmarshal(payload, &envelope)
+
+	delivery := &models.WebhookDelivery{
+		EventType:     envelope.Type,
+		Payload:       payload,
+		NextAttemptAt: time.Now().UTC(),
+	}
+	if err = s.webhookRepo.Enqueue(ctx, delivery); err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to queue webhook delivery",
+			slog.String("event_type", delivery.EventType), slog.String("error", err.Error()))
+		return err
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelDebug, "webhook delivery queued",
+		slog.Int64("delivery_id", delivery.Id), slog.String("event_type", delivery.EventType))
+	return nil
+}
+
+// DeliverDue posts up to limit deliveries that are due, one after another. A failed post schedules
+// the next attempt, or dead-letters the delivery after the last one. Returns the numbers of
+// deliveries delivered and failed.
+func (s *WebhookService) DeliverDue(ctx context.Context, limit int) (delivered, failed int, err error) {
+	if s.sender == nil {
+		return 0, 0, nil
+	}
+	due, err := s.webhookRepo.FindDue(ctx, time.Now().UTC(), limit)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find due webhook deliveries",
+			slog.String("error", err.Error()))
+		return 0, 0, err
+	}
+
+	for _, delivery := range due {
+		ok, err := s.deliver(ctx, delivery)
+		if err != nil {
+			if ctx.Err() != nil {
+				return delivered, failed, ctx.Err()
+			}
+			// the attempt is made again on the next run
+			s.log.LogAttrs(ctx, errorLevel(err), "failed to record webhook delivery attempt",
+				slog.Int64("delivery_id", delivery.Id), slog.String("error", err.Error()))
+			continue
+		}
+		if ok {
+			delivered++
+		} else {
+			failed++
+		}
+	}
+
+	if len(due) > 0 {
+		s.log.LogAttrs(ctx, slog.LevelInfo, "webhook deliveries attempted",
+			slog.Int("due", len(due)),
+			slog.Int("delivered", delivered),
+			slog.Int("failed", failed))
+	}
+	return delivered, failed, nil
+}
+
+// deliver makes an attempt to post the delivery and records it. Reports whether the post succeeded.
+func (s *WebhookService) deliver(ctx context.Context, delivery *models.WebhookDelivery) (bool, error) {
+	sendErr := s.sender.Send(ctx, json.RawMessage(delivery.Payload))
+	if sendErr != nil && ctx.Err() != nil {
+		// stopped by shutdown, not by the receiver: the attempt doesn't count
+		return false, ctx.Err()
+	}
+
+	now := time.Now().UTC()
+	delivery.Attempts++
+	attempt := &models.WebhookAttempt{DeliveryId: delivery.Id, Attempt: delivery.Attempts, AttemptedAt: now}
+	switch {
+	case sendErr == nil:
+		delivery.Status = models.DeliveryStatusDelivered
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= s.cfg.MaxAttempts:
+		attempt.Error = sendErr.Error()
+		delivery.LastError = attempt.Error
+		delivery.Status = models.DeliveryStatusDead
+	default:
+		attempt.Error = sendErr.Error()
+		delivery.LastError = attempt.Error
+		delay := models.RetryDelay(delivery.Attempts, s.cfg.InitialBackoff, s.cfg.MaxBackoff)
+		delivery.NextAttemptAt = now.Add(s.jitter(delay))
+	}
+
+	err := s.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
+		return s.webhookRepo.SaveAttempt(txCtx, delivery, attempt)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	switch delivery.Status {
+	case models.DeliveryStatusDelivered:
+		s.log.LogAttrs(ctx, slog.LevelInfo, "webhook delivered",
+			slog.Int64("delivery_id", delivery.Id),
+			slog.String("event_type", delivery.EventType),
+			slog.Int("attempt", attempt.Attempt))
+	case models.DeliveryStatusDead:
+		s.log.LogAttrs(ctx, slog.LevelError, "webhook delivery dead-lettered",
+			slog.Int64("delivery_id", delivery.Id),
+			slog.String("event_type", delivery.EventType),
+			slog.Int("attempts", delivery.Attempts),
+			slog.String("error", attempt.Error))
+	default:
+		s.log.LogAttrs(ctx, slog.LevelWarn, "webhook delivery failed, will retry",
+			slog.Int64("delivery_id", delivery.Id),
+			slog.String("event_type", delivery.EventType),
+			slog.Int("attempt", attempt.Attempt),
+			slog.Time("next_attempt_at", delivery.NextAttemptAt),
+			slog.String("error", attempt.Error))
+	}
+	return sendErr == nil, nil
+}
+
+// ListDeadLetters returns a page of dead-lettered deliveries with their attempts, oldest first.
+func (s *WebhookService) ListDeadLetters(ctx context.Context,
+	req admin.ListDeadLettersRequest) (*dto.Page[admin.WebhookDelivery], error) {
+	deliveries, total, err := s.webhookRepo.ListDead(ctx, req.Page.Limit, req.Page.Offset)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to list dead webhook deliveries",
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		ids = append(ids, delivery.Id)
+	}
+	attempts, err := s.webhookRepo.FindAttempts(ctx, ids)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to find webhook delivery attempts",
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	items := make([]admin.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		items = append(items, newDeliveryDto(delivery, attempts[delivery.Id]))
+	}
+	page := dto.NewPage(items, total, req.Page)
+	return &page, nil
+}
+
+// Redeliver queues a dead-lettered delivery again with a fresh set of attempts; the next run of
+// the delivery job posts it. Deliveries that are not dead can't be redelivered.
+func (s *WebhookService) Redeliver(ctx context.Context, req admin.RedeliverRequest) (*admin.RedeliverResponse, error) {
+	var response admin.RedeliverResponse
+	err := s.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
+		requeued, err := s.webhookRepo.Requeue(txCtx, req.DeliveryID, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+		delivery, err := s.webhookRepo.FindByID(txCtx, req.DeliveryID)
+		if err != nil {
+			return err
+		}
+		if delivery == nil {
+			return errors.NewNotFound("delivery not found")
+		}
+		if !requeued {
+			return errors.NewInvalidTransition(fmt.Sprintf("delivery is %s, only dead deliveries can be redelivered",
+				delivery.Status))
+		}
+
+		attempts, err := s.webhookRepo.FindAttempts(txCtx, []int64{delivery.Id})
+		if err != nil {
+			return err
+		}
+		response.Delivery = newDeliveryDto(delivery, attempts[delivery.Id])
+		return nil
+	})
+	if err != nil {
+		level := errorLevel(err)
+		if errors.HasCode(err, errors.CodeNotFound) || errors.HasCode(err, errors.CodeInvalidTransition) {
+			level = slog.LevelWarn
+		}
+		s.log.LogAttrs(ctx, level, "failed to redeliver webhook delivery",
+			slog.Int64("delivery_id", req.DeliveryID), slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	s.log.LogAttrs(ctx, slog.LevelInfo, "webhook delivery requeued", slog.Int64("delivery_id", req.DeliveryID))
+
+	return &response, nil
+}
+
+// Stats counts the deliveries by status and the attempts made, failed ones included.
+func (s *WebhookService) Stats(ctx context.Context) (*admin.WebhookStatsResponse, error) {
+	stats, err := s.webhookRepo.Stats(ctx)
+	if err != nil {
+		s.log.LogAttrs(ctx, errorLevel(err), "failed to count webhook deliveries",
+			slog.String("error", err.Error()))
+		return nil, err
+	}
+	return &admin.WebhookStatsResponse{
+		Pending:        stats.Pending,
+		Delivered:      stats.Delivered,
+		Dead:           stats.Dead,
+		Attempts:       stats.Attempts,
+		FailedAttempts: stats.FailedAttempts,
+	}, nil
+}
+
+// newDeliveryDto converts a delivery with its attempts to the response DTO.
+func newDeliveryDto(delivery *models.WebhookDelivery, attempts []*models.WebhookAttempt) admin.WebhookDelivery {
+	result := admin.WebhookDelivery{
+		DeliveryID:  delivery.Id,
+		EventType:   delivery.EventType,
+		Payload:     json.RawMessage(delivery.Payload),
+		Status:      delivery.Status,
+		Attempts:    delivery.Attempts,
+		LastError:   delivery.LastError,
+		CreatedAt:   dto.FormatTime(delivery.CreatedAt),
+		DeliveredAt: dto.FormatTimePtr(delivery.DeliveredAt),
+		History:     make([]admin.WebhookAttempt, 0, len(attempts)),
+	}
+	if delivery.Status == models.DeliveryStatusPending {
+		result.NextAttemptAt = dto.FormatTime(delivery.NextAttemptAt)
+	}
+	for _, attempt := range attempts {
+		result.History = append(result.History, admin.WebhookAttempt{
+			Attempt:     attempt.Attempt,
+			AttemptedAt: dto.FormatTime(attempt.AttemptedAt),
+			Error:       attempt.Error,
+		})
+	}
+	return result
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/admin"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/shirr9/pr-reviewer-service/internal/infrastructure/persistence/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSender fails the first failures posts and records the successful ones.
+type fakeSender struct {
+	failures int
+	sent     []string
+}
+
+func (s *fakeSender) Send(ctx context.Context, event any) error {
+	if s.failures > 0 {
+		s.failures--
+		return fmt.Errorf("webhook responded with status 503")
+	}
+	s.sent = append(s.sent, string(event.(json.RawMessage)))
+	return nil
+}
+
+// webhookEnv wires the webhook service to in-memory storage and a fake receiver.
+type webhookEnv struct {
+	*memoryEnv
+	repo    *memory.WebhookRepository
+	sender  *fakeSender
+	service *WebhookService
+}
+
+func newWebhookEnv(maxAttempts int) *webhookEnv {
+	env := &webhookEnv{memoryEnv: newMemoryEnv(), sender: &fakeSender{}}
+	env.repo = env.storage.NewWebhookRepository()
+	env.service = NewWebhookService(env.repo, env.uow, env.sender, config.Webhook{
+		MaxAttempts: maxAttempts, InitialBackoff: time.Minute, MaxBackoff: time.Hour,
+	}, env.logger)
+	return env
+}
+
+// retryAtOnce makes failed deliveries due again right away instead of after the backoff.
+func (e *webhookEnv) retryAtOnce() {
+	e.service.jitter = func(time.Duration) time.Duration { return 0 }
+}
+
+func TestWebhookService_DeliverDue(t *testing.T) {
+	event := map[string]string{"type": "review.escalated", "pull_request_id": "pr-1"}
+
+	t.Run("Success - Queued event is posted once", func(t *testing.T) {
+		env := newWebhookEnv(3)
+		assert.NoError(t, env.service.Send(env.ctx, event))
+
+		delivered, failed, err := env.service.DeliverDue(env.ctx, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+		assert.Zero(t, failed)
+		assert.Equal(t, []string{`{"pull_request_id":"pr-1","type":"review.escalated"}`}, env.sender.sent)
+
+		delivered, _, err = env.service.DeliverDue(env.ctx, 10)
+		assert.NoError(t, err)
+		assert.Zero(t, delivered)
+		delivery, err := env.repo.FindByID(env.ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, models.DeliveryStatusDelivered, delivery.Status)
+		assert.Equal(t, "review.escalated", delivery.EventType)
+		assert.NotNil(t, delivery.DeliveredAt)
+	})
+
+	t.Run("Success - Failed post is retried after a jittered backoff", func(t *testing.T) {
+		env := newWebhookEnv(3)
+		env.sender.failures = 1
+		assert.NoError(t, env.service.Send(env.ctx, event))
+
+		before := time.Now().UTC()
+		_, failed, err := env.service.DeliverDue(env.ctx, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, failed)
+
+		delivery, err := env.repo.FindByID(env.ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, models.DeliveryStatusPending, delivery.Status)
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.Contains(t, delivery.LastError, "503")
+		// the first retry waits between half the initial backoff and all of it
+		assert.False(t, delivery.NextAttemptAt.Before(before.Add(30*time.Second)))
+		assert.False(t, delivery.NextAttemptAt.After(time.Now().Add(time.Minute)))
+
+		// not due yet
+		delivered, failed, err := env.service.DeliverDue(env.ctx, 10)
+		assert.NoError(t, err)
+		assert.Zero(t, delivered+failed)
+		assert.Empty(t, env.sender.sent)
+	})
+
+	t.Run("Success - Delivery is dead-lettered after the last attempt", func(t *testing.T) {
+		env := newWebhookEnv(2)
+		env.retryAtOnce()
+		env.sender.failures = 2
+		assert.NoError(t, env.service.Send(env.ctx, event))
+
+		_, _, err := env.service.DeliverDue(env.ctx, 10)
+		assert.NoError(t, err)
+		_, failed, err := env.service.DeliverDue(env.ctx, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, failed)
+
+		page, err := env.service.ListDeadLetters(env.ctx, admin.ListDeadLettersRequest{Page: dto.PageRequest{Limit: 10}})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, page.Total)
+		dead := page.Items[0]
+		assert.Equal(t, models.DeliveryStatusDead, dead.Status)
+		assert.Equal(t, 2, dead.Attempts)
+		assert.Empty(t, dead.NextAttemptAt)
+		assert.JSONEq(t, `{"pull_request_id":"pr-1","type":"review.escalated"}`, string(dead.Payload))
+		assert.Len(t, dead.History, 2)
+		assert.Equal(t, 2, dead.History[1].Attempt)
+		assert.Contains(t, dead.History[1].Error, "503")
+
+		// dead deliveries are not retried on their own
+		delivered, failed, err := env.service.DeliverDue(env.ctx, 10)
+		assert.NoError(t, err)
+		assert.Zero(t, delivered+failed)
+	})
+
+	t.Run("Success - Succeeds on a retry", func(t *testing.T) {
+		env := newWebhookEnv(3)
+		env.retryAtOnce()
+		env.sender.failures = 2
+		assert.NoError(t, env.service.Send(env.ctx, event))
+
+		for i := 0; i < 2; i++ {
+			_, failed, err := env.service.DeliverDue(env.ctx, 10)
+			assert.NoError(t, err)
+			assert.Equal(t, 1, failed)
+		}
+		delivered, _, err := env.service.DeliverDue(env.ctx, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+
+		stats, err := env.service.Stats(env.ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, admin.WebhookStatsResponse{Delivered: 1, Attempts: 3, FailedAttempts: 2}, *stats)
+	})
+
+	t.Run("Success - Respects the limit", func(t *testing.T) {
+		env := newWebhookEnv(3)
+		for i := 0; i < 3; i++ {
+			assert.NoError(t, env.service.Send(env.ctx, event))
+		}
+
+		delivered, _, err := env.service.DeliverDue(env.ctx, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, delivered)
+	})
+
+	t.Run("Error - Cancelled post is not counted as an attempt", func(t *testing.T) {
+		env := newWebhookEnv(3)
+		env.sender.failures = 1
+		assert.NoError(t, env.service.Send(env.ctx, event))
+		ctx, cancel := context.WithCancel(env.ctx)
+		cancel()
+
+		_, _, err := env.service.DeliverDue(ctx, 10)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		delivery, err := env.repo.FindByID(env.ctx, 1)
+		assert.NoError(t, err)
+		assert.Zero(t, delivery.Attempts)
+	})
+}
+
+func TestWebhookService_Redeliver(t *testing.T) {
+	event := map[string]string{"type": "review.escalated"}
+
+	t.Run("Success - Dead delivery gets a fresh set of attempts", func(t *testing.T) {
+		env := newWebhookEnv(1)
+		env.sender.failures = 1
+		assert.NoError(t, env.service.Send(env.ctx, event))
+		_, _, err := env.service.DeliverDue(env.ctx, 10)
+		assert.NoError(t, err)
+
+		resp, err := env.service.Redeliver(env.ctx, admin.RedeliverRequest{DeliveryID: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, models.DeliveryStatusPending, resp.Delivery.Status)
+		assert.Zero(t, resp.Delivery.Attempts)
+		assert.NotEmpty(t, resp.Delivery.NextAttemptAt)
+		assert.Len(t, resp.Delivery.History, 1)
+
+		delivered, _, err := env.service.DeliverDue(env.ctx, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+
+		stats, err := env.service.Stats(env.ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, admin.WebhookStatsResponse{Delivered: 1, Attempts: 2, FailedAttempts: 1}, *stats)
+	})
+
+	t.Run("Error - Delivery not found", func(t *testing.T) {
+		env := newWebhookEnv(1)
+
+		_, err := env.service.Redeliver(env.ctx, admin.RedeliverRequest{DeliveryID: 1})
+
+		assert.True(t, errors.HasCode(err, errors.CodeNotFound))
+	})
+
+	t.Run("Error - Delivery is not dead", func(t *testing.T) {
+		env := newWebhookEnv(1)
+		assert.NoError(t, env.service.Send(env.ctx, event))
+
+		_, err := env.service.Redeliver(env.ctx, admin.RedeliverRequest{DeliveryID: 1})
+
+		assert.True(t, errors.HasCode(err, errors.CodeInvalidTransition))
+	})
+}
+
+func TestEqualJitter(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		delay := equalJitter(time.Minute)
+		assert.GreaterOrEqual(t, delay, 30*time.Second)
+		assert.LessOrEqual(t, delay, time.Minute)
+	}
+	assert.Equal(t, time.Duration(1), equalJitter(1))
+}
@@ -0,0 +1,89 @@
+// Package shutdown stops the components of the service in order once it is signalled to stop, so
+// that the work they hold is finished or reported rather than lost silently.
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// StopFunc stops a component. It finishes the work the component holds, such as queued events or
+// open client streams, until ctx is done, and returns an error describing what it left unfinished.
+type StopFunc func(ctx context.Context) error
+
+type step struct {
+	name string
+	stop StopFunc
+}
+
+// Coordinator stops the added components one after another under a single deadline.
+type Coordinator struct {
+	timeout time.Duration
+	log     *slog.Logger
+	steps   []step
+}
+
+// NewCoordinator creates a coordinator whose shutdown runs at most timeout; zero leaves it unbounded.
+func NewCoordinator(timeout time.Duration, log *slog.Logger) *Coordinator {
+	if log == nil {
+		log = slog.Default()
+	}
+	return &Coordinator{timeout: timeout, log: log}
+}
+
+// Add adds a component stopped after the ones added before it, so a component is added after those
+// that feed it work: the servers before the jobs, the jobs before the flush of what they queued.
+func (c *Coordinator) Add(name string, stop StopFunc) {
+	c.steps = append(c.steps, step{name: name, stop: stop})
+}
+
+// Shutdown stops the components in order. Each one sees the context cancelled once the deadline
+// passes; a component failing or running out of time is logged as abandoned and the next one is
+// stopped all the same, with what is left of the deadline. Returns the errors of the components joined.
+func (c *Coordinator) Shutdown(ctx context.Context) error {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	var errs []error
+	for _, s := range c.steps {
+		stepStart := time.Now()
+		err := c.stop(ctx, s)
+		if err != nil {
+			c.log.LogAttrs(ctx, slog.LevelWarn, "shutdown abandoned work",
+				slog.String("component", s.name),
+				slog.Duration("duration", time.Since(stepStart)),
+				slog.String("error", err.Error()))
+			errs = append(errs, fmt.Errorf("%s: %w", s.name, err))
+			continue
+		}
+		c.log.LogAttrs(ctx, slog.LevelInfo, "component stopped",
+			slog.String("component", s.name), slog.Duration("duration", time.Since(stepStart)))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		c.log.LogAttrs(ctx, slog.LevelWarn, "shutdown finished with abandoned work",
+			slog.Duration("duration", time.Since(start)), slog.Int("abandoned", len(errs)))
+		return err
+	}
+	c.log.LogAttrs(ctx, slog.LevelInfo, "shutdown finished", slog.Duration("duration", time.Since(start)))
+	return nil
+}
+
+// stop stops the component, turning a panic into an error so that the others are still stopped.
+func (c *Coordinator) stop(ctx context.Context, s step) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panicked: %v", r)
+		}
+	}()
+	// a component is stopped even when no time is left, so it can still cancel its work and report
+	// what it drops
+	return s.stop(ctx)
+}
@@ -0,0 +1,194 @@
+package shutdown
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/job"
+	"github.com/shirr9/pr-reviewer-service/internal/app/service"
+	"github.com/shirr9/pr-reviewer-service/internal/infrastructure/jobs"
+	"github.com/shirr9/pr-reviewer-service/internal/infrastructure/persistence/memory"
+	"github.com/shirr9/pr-reviewer-service/internal/infrastructure/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syncBuffer collects the log lines of the components and the coordinator, written concurrently.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestCoordinator_Shutdown(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - Components are stopped in order", func(t *testing.T) {
+		var logs syncBuffer
+		c := NewCoordinator(time.Second, slog.New(slog.NewTextHandler(&logs, nil)))
+		var stopped []string
+		for _, name := range []string{"http", "jobs", "webhook"} {
+			c.Add(name, func(ctx context.Context) error { stopped = append(stopped, name); return nil })
+		}
+
+		err := c.Shutdown(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"http", "jobs", "webhook"}, stopped)
+		assert.Contains(t, logs.String(), "shutdown finished")
+		assert.NotContains(t, logs.String(), "abandoned")
+	})
+
+	t.Run("Error - Components after one running out of time are still stopped", func(t *testing.T) {
+		var logs syncBuffer
+		c := NewCoordinator(50*time.Millisecond, slog.New(slog.NewTextHandler(&logs, nil)))
+		c.Add("http", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		var jobsCtxErr error
+		c.Add("jobs", func(ctx context.Context) error { jobsCtxErr = ctx.Err(); return nil })
+
+		err := c.Shutdown(ctx)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorContains(t, err, "http: ")
+		assert.ErrorIs(t, jobsCtxErr, context.DeadlineExceeded, "the next component sees no time left")
+		assert.Contains(t, logs.String(), "shutdown abandoned work")
+		assert.Contains(t, logs.String(), "component=http")
+		assert.Contains(t, logs.String(), "msg=\"component stopped\" component=jobs")
+	})
+
+	t.Run("Error - Panic is reported as abandoned work", func(t *testing.T) {
+		c := NewCoordinator(time.Second, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		c.Add("grpc", func(ctx context.Context) error { panic("nil server") })
+		stopped := false
+		c.Add("jobs", func(ctx context.Context) error { stopped = true; return nil })
+
+		err := c.Shutdown(ctx)
+
+		assert.ErrorContains(t, err, "grpc: panicked: nil server")
+		assert.True(t, stopped)
+	})
+}
+
+// webhookEnv wires the webhook delivery as main does: events are queued in the outbox, and the
+// scheduler flushes what the webhook job left queued to the receiver on shutdown.
+type webhookEnv struct {
+	webhooks    *service.WebhookService
+	coordinator *Coordinator
+	logs        *syncBuffer
+}
+
+func newWebhookEnv(t *testing.T, receiver http.HandlerFunc, timeout time.Duration) *webhookEnv {
+	t.Helper()
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	logs := &syncBuffer{}
+	log := slog.New(slog.NewTextHandler(logs, nil))
+	cfg := config.Webhook{Timeout: time.Second, Interval: time.Hour, BatchSize: 2, MaxAttempts: 3,
+		InitialBackoff: time.Minute, MaxBackoff: time.Hour}
+	storage := memory.NewStorage()
+	webhooks := service.NewWebhookService(storage.NewWebhookRepository(), storage.NewUnitOfWork(),
+		webhook.NewClient(server.URL, cfg.Timeout), cfg, log)
+
+	webhookJob := job.NewWebhookJob(webhooks, cfg)
+	scheduler := jobs.NewScheduler(nil, nil, nil, log)
+	require.NoError(t, scheduler.Register(jobs.Job{Name: "webhook", Interval: cfg.Interval,
+		LockKey: job.WebhookLockKey, Run: webhookJob.RunOnce, Flush: webhookJob.Flush}))
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	jobsDone := make(chan struct{})
+	go func() {
+		defer close(jobsDone)
+		scheduler.Run(jobsCtx)
+	}()
+
+	coordinator := NewCoordinator(timeout, log)
+	coordinator.Add("jobs", func(ctx context.Context) error {
+		stopJobs()
+		select {
+		case <-jobsDone:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	coordinator.Add("webhook", scheduler.Flush)
+	return &webhookEnv{webhooks: webhooks, coordinator: coordinator, logs: logs}
+}
+
+func (e *webhookEnv) enqueue(t *testing.T, n int) {
+	t.Helper()
+	for range n {
+		require.NoError(t, e.webhooks.Send(context.Background(), map[string]string{"type": "review.escalated"}))
+	}
+}
+
+func TestCoordinator_Shutdown_WebhookFlush(t *testing.T) {
+	t.Run("Success - Queued events are delivered before the service stops", func(t *testing.T) {
+		var mu sync.Mutex
+		received := 0
+		env := newWebhookEnv(t, func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			received++
+		}, 5*time.Second)
+		env.enqueue(t, 5)
+
+		err := env.coordinator.Shutdown(context.Background())
+
+		require.NoError(t, err)
+		mu.Lock()
+		assert.Equal(t, 5, received)
+		mu.Unlock()
+		stats, err := env.webhooks.Stats(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, stats.Pending)
+		assert.Equal(t, 5, stats.Delivered)
+		assert.Contains(t, env.logs.String(), "job flushed")
+	})
+
+	t.Run("Error - Events the receiver doesn't take in time are logged as abandoned", func(t *testing.T) {
+		unblock := make(chan struct{})
+		env := newWebhookEnv(t, func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-unblock:
+			case <-r.Context().Done():
+			}
+		}, 100*time.Millisecond)
+		t.Cleanup(func() { close(unblock) })
+		env.enqueue(t, 3)
+
+		err := env.coordinator.Shutdown(context.Background())
+
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.ErrorContains(t, err, "webhook: job webhook: 3 deliveries left pending")
+		stats, err := env.webhooks.Stats(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 3, stats.Pending, "the abandoned deliveries stay queued for the next start")
+		assert.Contains(t, env.logs.String(), "shutdown abandoned work")
+		assert.Contains(t, env.logs.String(), "3 deliveries left pending")
+	})
+}
@@ -1,24 +1,60 @@
 package errors
 
+import "errors"
+
 const (
 	CodeTeamExists  = "TEAM_EXISTS"
 	CodePRExists    = "PR_EXISTS"
 	CodePRMerged    = "PR_MERGED"
+	CodePRClosed    = "PR_CLOSED"
 	CodeNotAssigned = "NOT_ASSIGNED"
 	CodeNoCandidate = "NO_CANDIDATE"
 	CodeNotFound    = "NOT_FOUND"
+
+	CodeAlreadyAssigned  = "ALREADY_ASSIGNED"
+	CodeWrongTeam        = "WRONG_TEAM"
+	CodeReviewerIsAuthor = "REVIEWER_IS_AUTHOR"
+	CodeReviewerExcluded = "REVIEWER_EXCLUDED"
+	CodeNotReviewer      = "NOT_REVIEWER"
+	CodeTooManyReviewers = "TOO_MANY_REVIEWERS"
+
+	CodeInvalidTransition = "INVALID_TRANSITION"
+	CodeChangesRequested  = "CHANGES_REQUESTED"
+	CodeNoReviewers       = "NO_REVIEWERS"
+
+	// CodeNotEmpty marks an import into a storage that already holds data.
+	CodeNotEmpty = "NOT_EMPTY"
+
+	// CodeJobRunning marks a request to run a background job that is running already.
+	CodeJobRunning = "JOB_RUNNING"
+
+	// CodeValidation marks a request that doesn't decode or doesn't pass validation,
+	// CodeBadRequest a valid request the service rejects.
+	CodeValidation = "VALIDATION_ERROR"
+	CodeBadRequest = "BAD_REQUEST"
+
+	// CodeServiceOverloaded marks a request refused because the database can't serve it in time.
+	CodeServiceOverloaded = "SERVICE_OVERLOADED"
 )
 
 // AppError represents a domain error with code and message.
+// Details optionally carries a payload describing the conflicting resource.
 type AppError struct {
 	Code    string
 	Message string
+	Details any
 }
 
 func (e *AppError) Error() string {
 	return e.Message
 }
 
+// WithDetails attaches a details payload to the error.
+func (e *AppError) WithDetails(details any) *AppError {
+	e.Details = details
+	return e
+}
+
 // New creates a new AppError.
 func New(code, message string) *AppError {
 	return &AppError{
@@ -27,6 +63,12 @@ func New(code, message string) *AppError {
 	}
 }
 
+// HasCode reports whether err is an AppError with the given code.
+func HasCode(err error, code string) bool {
+	var appErr *AppError
+	return errors.As(err, &appErr) && appErr.Code == code
+}
+
 func NewTeamExists(message string) *AppError {
 	return New(CodeTeamExists, message)
 }
@@ -39,6 +81,10 @@ func NewPRMerged(message string) *AppError {
 	return New(CodePRMerged, message)
 }
 
+func NewPRClosed(message string) *AppError {
+	return New(CodePRClosed, message)
+}
+
 func NewNotAssigned(message string) *AppError {
 	return New(CodeNotAssigned, message)
 }
@@ -50,3 +96,59 @@ func NewNoCandidate(message string) *AppError {
 func NewNotFound(message string) *AppError {
 	return New(CodeNotFound, message)
 }
+
+func NewAlreadyAssigned(message string) *AppError {
+	return New(CodeAlreadyAssigned, message)
+}
+
+func NewWrongTeam(message string) *AppError {
+	return New(CodeWrongTeam, message)
+}
+
+func NewReviewerIsAuthor(message string) *AppError {
+	return New(CodeReviewerIsAuthor, message)
+}
+
+func NewReviewerExcluded(message string) *AppError {
+	return New(CodeReviewerExcluded, message)
+}
+
+func NewNotReviewer(message string) *AppError {
+	return New(CodeNotReviewer, message)
+}
+
+func NewTooManyReviewers(message string) *AppError {
+	return New(CodeTooManyReviewers, message)
+}
+
+func NewInvalidTransition(message string) *AppError {
+	return New(CodeInvalidTransition, message)
+}
+
+func NewChangesRequested(message string) *AppError {
+	return New(CodeChangesRequested, message)
+}
+
+func NewNoReviewers(message string) *AppError {
+	return New(CodeNoReviewers, message)
+}
+
+func NewNotEmpty(message string) *AppError {
+	return New(CodeNotEmpty, message)
+}
+
+func NewJobRunning(message string) *AppError {
+	return New(CodeJobRunning, message)
+}
+
+func NewValidation(message string) *AppError {
+	return New(CodeValidation, message)
+}
+
+func NewBadRequest(message string) *AppError {
+	return New(CodeBadRequest, message)
+}
+
+func NewServiceOverloaded(message string) *AppError {
+	return New(CodeServiceOverloaded, message)
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// Sizes of the buckets of activity time series. Buckets are in UTC; weeks start on Monday, like
+// date_trunc of PostgreSQL.
+const (
+	ActivityBucketDay  = "day"
+	ActivityBucketWeek = "week"
+)
+
+// ActivityBuckets lists the supported bucket sizes.
+var ActivityBuckets = []string{ActivityBucketDay, ActivityBucketWeek}
+
+// ActivityBucket counts the PRs created and merged and the reviewer changes made within the bucket
+// starting at Start.
+type ActivityBucket struct {
+	Start         time.Time
+	Created       int
+	Merged        int
+	Reassignments int
+}
+
+// BucketStart returns the start of the bucket of the given size holding t, in UTC.
+func BucketStart(t time.Time, bucket string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if bucket == ActivityBucketWeek {
+		// Sunday is the last day of the week starting on Monday
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	}
+	return day
+}
+
+// NextBucketStart returns the start of the bucket following the one starting at start.
+func NextBucketStart(start time.Time, bucket string) time.Time {
+	if bucket == ActivityBucketWeek {
+		return start.AddDate(0, 0, 7)
+	}
+	return start.AddDate(0, 0, 1)
+}
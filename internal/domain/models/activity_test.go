@@ -0,0 +1,41 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketStart(t *testing.T) {
+	// 2024-06-05 is a Wednesday
+	wednesday := time.Date(2024, time.June, 5, 15, 30, 0, 0, time.UTC)
+	monday := time.Date(2024, time.June, 3, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name   string
+		t      time.Time
+		bucket string
+		want   time.Time
+	}{
+		{name: "Day", t: wednesday, bucket: ActivityBucketDay, want: time.Date(2024, time.June, 5, 0, 0, 0, 0, time.UTC)},
+		{name: "Week starts on Monday", t: wednesday, bucket: ActivityBucketWeek, want: monday},
+		{name: "Monday starts its own week", t: monday.Add(time.Hour), bucket: ActivityBucketWeek, want: monday},
+		{name: "Sunday ends the week", t: monday.AddDate(0, 0, 6).Add(23 * time.Hour), bucket: ActivityBucketWeek,
+			want: monday},
+		{name: "Converted to UTC", t: time.Date(2024, time.June, 5, 1, 0, 0, 0, time.FixedZone("UTC+3", 3*3600)),
+			bucket: ActivityBucketDay, want: time.Date(2024, time.June, 4, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, BucketStart(tc.t, tc.bucket))
+		})
+	}
+}
+
+func TestNextBucketStart(t *testing.T) {
+	monday := time.Date(2024, time.June, 3, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, monday.AddDate(0, 0, 1), NextBucketStart(monday, ActivityBucketDay))
+	assert.Equal(t, monday.AddDate(0, 0, 7), NextBucketStart(monday, ActivityBucketWeek))
+}
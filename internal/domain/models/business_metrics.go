@@ -0,0 +1,17 @@
+package models
+
+// TeamPRCounts counts the open PRs authored by the members of a team and those of them nobody is
+// assigned to. TeamName is empty for PRs whose author is not a known user.
+type TeamPRCounts struct {
+	TeamName         string
+	OpenPRs          int
+	WithoutReviewers int
+}
+
+// BusinessMetrics holds the counts behind the business gauges: the open PRs of every team with any,
+// the active users and the reviewer assignments on open PRs.
+type BusinessMetrics struct {
+	Teams             []TeamPRCounts
+	ActiveUsers       int
+	ActiveAssignments int
+}
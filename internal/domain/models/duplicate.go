@@ -0,0 +1,24 @@
+package models
+
+import "strings"
+
+// Kinds of identifiers checked for near-duplicates.
+const (
+	IdentifierKindUser        = "user"
+	IdentifierKindTeam        = "team"
+	IdentifierKindPullRequest = "pull_request"
+)
+
+// NearDuplicate is a group of distinct identifiers of one kind that share their DuplicateKey, such
+// as "user-1" and "User-1 ", which requests could tell apart before identifiers were normalized.
+// Ids are sorted.
+type NearDuplicate struct {
+	Kind string
+	Key  string
+	Ids  []string
+}
+
+// DuplicateKey is the identifier trimmed of surrounding whitespace and folded to lower case.
+func DuplicateKey(id string) string {
+	return strings.ToLower(strings.TrimSpace(id))
+}
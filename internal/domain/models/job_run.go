@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// JobRun is the last run of a scheduled job. Error is empty for a run that succeeded; LastSuccessAt
+// is when the last successful run finished, nil before the first one.
+type JobRun struct {
+	Name          string
+	StartedAt     time.Time
+	FinishedAt    time.Time
+	Error         string
+	LastSuccessAt *time.Time
+}
+
+// ScheduledJob describes a job of the scheduler with its last run, nil before the first one.
+// Exclusive jobs run on one replica at a time.
+type ScheduledJob struct {
+	Name      string
+	Interval  time.Duration
+	Exclusive bool
+	LastRun   *JobRun
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// Lease is a named lease held by one replica until it expires; the holder keeps it by renewing it
+// before ExpiresAt, and any replica may take it over after. AcquiredAt is when the holder took it.
+type Lease struct {
+	Name       string
+	Holder     string
+	AcquiredAt time.Time
+	ExpiresAt  time.Time
+}
@@ -1,19 +1,92 @@
 package models
 
-import "time"
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
 
 const (
 	PRStatusOpen   = "OPEN"
 	PRStatusMerged = "MERGED"
+	// PRStatusClosed marks a PR closed without merging, which keeps no reviewers.
+	PRStatusClosed = "CLOSED"
 )
 
+const (
+	PRPriorityLow    = "LOW"
+	PRPriorityNormal = "NORMAL"
+	PRPriorityHigh   = "HIGH"
+	PRPriorityUrgent = "URGENT"
+)
+
+// MaxReviewers is the most reviewers a PR can have, also enforced by the storage.
+const MaxReviewers = 2
+
+// PRPriorities lists the priorities from lowest to highest.
+var PRPriorities = []string{PRPriorityLow, PRPriorityNormal, PRPriorityHigh, PRPriorityUrgent}
+
+// PriorityRank returns the position of the priority in PRPriorities, -1 for an unknown one.
+func PriorityRank(priority string) int {
+	return slices.Index(PRPriorities, priority)
+}
+
+// Fields lists of pull requests can be sorted by.
+const (
+	PRSortCreatedAt = "created_at"
+	PRSortUpdatedAt = "updated_at"
+	PRSortTitle     = "title"
+	PRSortPriority  = "priority"
+)
+
+// PRSort orders a list of pull requests by a field, ties broken by id. Priority sorts by rank.
+// The zero value keeps the default order of the list.
+type PRSort struct {
+	Field string
+	Desc  bool
+}
+
+// PullRequest is a pull request. AssignmentSkipped is set for a PR created without reviewer
+// assignment until it is assigned on request; such a PR is not an assignment failure.
 type PullRequest struct {
-	Id          string
-	Title       string
-	AuthorId    string
-	Status      string
-	CreatedAt   time.Time
-	MergedAt    *time.Time
-	UpdatedAt   time.Time
-	ReviewersId []string
+	Id                string
+	Title             string
+	AuthorId          string
+	Status            string
+	CreatedAt         time.Time
+	MergedAt          *time.Time
+	UpdatedAt         time.Time
+	Priority          string
+	Labels            []string
+	AssignmentSkipped bool
+	ReviewersId       []string
+}
+
+// AuthorStats counts the PRs authored by a user and the reviewers assigned to them. Username is nil
+// when the author is not a known user.
+type AuthorStats struct {
+	AuthorId  string
+	Username  *string
+	TotalPRs  int
+	OpenPRs   int
+	MergedPRs int
+	Reviewers int
+}
+
+// NormalizeLabels trims and lowercases labels, dropping empty ones and duplicates.
+// The result is sorted and never nil.
+func NormalizeLabels(labels []string) []string {
+	normalized := make([]string, 0, len(labels))
+	seen := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		label = strings.ToLower(strings.TrimSpace(label))
+		if label == "" || seen[label] {
+			continue
+		}
+		seen[label] = true
+		normalized = append(normalized, label)
+	}
+	sort.Strings(normalized)
+	return normalized
 }
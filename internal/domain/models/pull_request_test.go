@@ -0,0 +1,12 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeLabels(t *testing.T) {
+	assert.Equal(t, []string{"api", "docs", "infra"}, NormalizeLabels([]string{" Infra", "api", "DOCS", "infra ", "  "}))
+	assert.Equal(t, []string{}, NormalizeLabels(nil))
+}
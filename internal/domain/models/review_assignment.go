@@ -0,0 +1,129 @@
+package models
+
+import "time"
+
+const (
+	ReviewStatePending          = "PENDING"
+	ReviewStateApproved         = "APPROVED"
+	ReviewStateChangesRequested = "CHANGES_REQUESTED"
+)
+
+// Sources of a reviewer assignment: chosen by the service for a new PR, picked by a human,
+// chosen as a replacement on reassignment, or replacing a deactivated or escalated reviewer.
+const (
+	AssignmentSourceAuto         = "auto"
+	AssignmentSourceManual       = "manual"
+	AssignmentSourceReassign     = "reassign"
+	AssignmentSourceDeactivation = "deactivation"
+	AssignmentSourceEscalation   = "escalation"
+)
+
+// AssignmentSources lists the assignment sources in the order statistics report them.
+var AssignmentSources = []string{
+	AssignmentSourceAuto, AssignmentSourceManual, AssignmentSourceReassign,
+	AssignmentSourceDeactivation, AssignmentSourceEscalation,
+}
+
+// ReviewAssignment represents a reviewer assigned to a PR, how they were assigned and the state of their review.
+// StateChangedAt is nil while the review has never left PENDING.
+type ReviewAssignment struct {
+	PRId           string
+	ReviewerId     string
+	AssignedAt     time.Time
+	Source         string
+	State          string
+	StateChangedAt *time.Time
+}
+
+// ReviewTurnaround describes how long the reviews of a reviewer took from assignment to the merge
+// of their PRs, in seconds.
+type ReviewTurnaround struct {
+	AvgSeconds    float64
+	MedianSeconds float64
+}
+
+// ReviewerSummary counts the reviewer assignments of one user: Assignments on all PRs and
+// ActiveReviews on open ones. Turnaround is nil until a PR the user reviewed is merged.
+type ReviewerSummary struct {
+	Assignments   int
+	ActiveReviews int
+	Turnaround    *ReviewTurnaround
+}
+
+// ReviewLoad counts the reviewer assignments of a user: ActiveReviews on open PRs and
+// TotalAssignments on all PRs, archived ones included.
+type ReviewLoad struct {
+	UserId           string
+	Username         string
+	TeamName         string
+	ActiveReviews    int
+	TotalAssignments int
+}
+
+// ReviewLoadHistogram counts users by their active reviews and by their total assignments. Both maps
+// are keyed by the count capped at the last bucket; empty buckets are absent.
+type ReviewLoadHistogram struct {
+	ActiveReviews    map[int]int
+	TotalAssignments map[int]int
+}
+
+// AssignmentCursor is a position in a reviewer's assignments ordered by assignment time, with the
+// PR id breaking ties. The zero cursor is before every assignment.
+type AssignmentCursor struct {
+	AssignedAt time.Time
+	PRId       string
+}
+
+// After reports whether the assignment comes after the cursor.
+func (c AssignmentCursor) After(a *ReviewAssignment) bool {
+	if !a.AssignedAt.Equal(c.AssignedAt) {
+		return a.AssignedAt.After(c.AssignedAt)
+	}
+	return a.PRId > c.PRId
+}
+
+// reviewTransitions lists the states a review may move to from each state.
+// An approval is withdrawn by requesting changes; PENDING is reachable again only
+// after changes were requested, when the author asks for another look.
+var reviewTransitions = map[string][]string{
+	ReviewStatePending:          {ReviewStateApproved, ReviewStateChangesRequested},
+	ReviewStateApproved:         {ReviewStateChangesRequested},
+	ReviewStateChangesRequested: {ReviewStateApproved, ReviewStatePending},
+}
+
+// CanChangeReviewState reports whether a review may move from one state to another.
+func CanChangeReviewState(from, to string) bool {
+	for _, state := range reviewTransitions[from] {
+		if state == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ReviewDeadline returns the moment a review assigned at assignedAt is due,
+// counting only business time: weekends (in UTC) don't consume the allowance.
+func ReviewDeadline(assignedAt time.Time, within time.Duration) time.Time {
+	t := assignedAt.UTC()
+	remaining := within
+	for remaining > 0 {
+		dayStart := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		nextDay := dayStart.AddDate(0, 0, 1)
+		if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+			t = nextDay
+			continue
+		}
+		left := nextDay.Sub(t)
+		if remaining <= left {
+			return t.Add(remaining)
+		}
+		remaining -= left
+		t = nextDay
+	}
+	return t
+}
+
+// IsOverdue reports whether a review assigned at assignedAt is past its deadline at now.
+func IsOverdue(assignedAt time.Time, within time.Duration, now time.Time) bool {
+	return now.After(ReviewDeadline(assignedAt, within))
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewDeadline(t *testing.T) {
+	// 2024-06-05 is a Wednesday
+	at := func(day, hour int) time.Time {
+		return time.Date(2024, time.June, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	cases := []struct {
+		name       string
+		assignedAt time.Time
+		within     time.Duration
+		want       time.Time
+	}{
+		{name: "Same day", assignedAt: at(5, 10), within: 4 * time.Hour, want: at(5, 14)},
+		{name: "Across midnight", assignedAt: at(5, 20), within: 8 * time.Hour, want: at(6, 4)},
+		{name: "Friday skips the weekend", assignedAt: at(7, 20), within: 8 * time.Hour, want: at(10, 4)},
+		{name: "Assigned on Saturday starts on Monday", assignedAt: at(8, 15), within: 2 * time.Hour, want: at(10, 2)},
+		{name: "Several business days", assignedAt: at(6, 12), within: 72 * time.Hour, want: at(11, 12)},
+		{name: "Ends exactly at midnight", assignedAt: at(5, 20), within: 4 * time.Hour, want: at(6, 0)},
+		{name: "Zero allowance", assignedAt: at(8, 15), within: 0, want: at(8, 15)},
+		{name: "Other time zones are normalized",
+			assignedAt: time.Date(2024, time.June, 8, 1, 0, 0, 0, time.FixedZone("UTC+3", 3*3600)),
+			within:     time.Hour, want: at(7, 23)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, ReviewDeadline(tc.assignedAt, tc.within))
+		})
+	}
+
+	t.Run("Overdue only after the deadline", func(t *testing.T) {
+		assert.False(t, IsOverdue(at(5, 10), 4*time.Hour, at(5, 14)))
+		assert.True(t, IsOverdue(at(5, 10), 4*time.Hour, at(5, 15)))
+		assert.False(t, IsOverdue(at(7, 20), 8*time.Hour, at(9, 12)), "weekend time doesn't count")
+	})
+}
+
+func TestCanChangeReviewState(t *testing.T) {
+	cases := []struct {
+		from, to string
+		want     bool
+	}{
+		{from: ReviewStatePending, to: ReviewStateApproved, want: true},
+		{from: ReviewStatePending, to: ReviewStateChangesRequested, want: true},
+		{from: ReviewStateApproved, to: ReviewStateChangesRequested, want: true},
+		{from: ReviewStateApproved, to: ReviewStatePending, want: false},
+		{from: ReviewStateChangesRequested, to: ReviewStateApproved, want: true},
+		{from: ReviewStateChangesRequested, to: ReviewStatePending, want: true},
+		{from: ReviewStateApproved, to: ReviewStateApproved, want: false},
+		{from: ReviewStatePending, to: "UNKNOWN", want: false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.from+" to "+tc.to, func(t *testing.T) {
+			assert.Equal(t, tc.want, CanChangeReviewState(tc.from, tc.to))
+		})
+	}
+}
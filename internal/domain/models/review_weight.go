@@ -0,0 +1,28 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// WeightWindow is how far back assignments count towards the review weight of a user.
+const WeightWindow = 30 * 24 * time.Hour
+
+// ReviewWeight scores the recent review load of a user from their assignment times:
+// an assignment made at now counts as 1 and halves with every halfLife of age.
+// Assignments older than WeightWindow are ignored; a non-positive halfLife disables the decay.
+func ReviewWeight(assignedAt []time.Time, now time.Time, halfLife time.Duration) float64 {
+	weight := 0.0
+	for _, at := range assignedAt {
+		age := now.Sub(at)
+		if age > WeightWindow {
+			continue
+		}
+		if age < 0 || halfLife <= 0 {
+			weight++
+			continue
+		}
+		weight += math.Pow(0.5, float64(age)/float64(halfLife))
+	}
+	return weight
+}
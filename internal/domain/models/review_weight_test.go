@@ -0,0 +1,41 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewWeight(t *testing.T) {
+	now := time.Date(2024, time.June, 5, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	cases := []struct {
+		name       string
+		assignedAt []time.Time
+		halfLife   time.Duration
+		want       float64
+	}{
+		{name: "No assignments", want: 0},
+		{name: "Fresh assignment counts fully", assignedAt: []time.Time{now}, halfLife: 7 * day, want: 1},
+		{name: "Halves every half-life",
+			assignedAt: []time.Time{now.Add(-7 * day), now.Add(-14 * day)}, halfLife: 7 * day, want: 0.75},
+		{name: "Outside the window is ignored",
+			assignedAt: []time.Time{now.Add(-WeightWindow - time.Minute)}, halfLife: 7 * day, want: 0},
+		{name: "Future assignment counts fully", assignedAt: []time.Time{now.Add(time.Hour)}, halfLife: 7 * day, want: 1},
+		{name: "No decay without half-life",
+			assignedAt: []time.Time{now.Add(-20 * day), now.Add(-day)}, halfLife: 0, want: 2},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.InDelta(t, tc.want, ReviewWeight(tc.assignedAt, now, tc.halfLife), 1e-9)
+		})
+	}
+
+	t.Run("Older assignments weigh less", func(t *testing.T) {
+		recent := ReviewWeight([]time.Time{now.Add(-day)}, now, 7*day)
+		old := ReviewWeight([]time.Time{now.Add(-10 * day)}, now, 7*day)
+		assert.Greater(t, recent, old)
+	})
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// ReviewerExclusion forbids ReviewerId to review PRs authored by AuthorId.
+// A mutual exclusion works the other way round as well.
+type ReviewerExclusion struct {
+	ReviewerId string
+	AuthorId   string
+	Mutual     bool
+	CreatedAt  time.Time
+}
+
+// Excludes reports whether the exclusion forbids reviewerID to review PRs of authorID.
+func (e *ReviewerExclusion) Excludes(reviewerID, authorID string) bool {
+	if e.ReviewerId == reviewerID && e.AuthorId == authorID {
+		return true
+	}
+	return e.Mutual && e.ReviewerId == authorID && e.AuthorId == reviewerID
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewerExclusion_Excludes(t *testing.T) {
+	ordered := &ReviewerExclusion{ReviewerId: "u1", AuthorId: "u2"}
+	mutual := &ReviewerExclusion{ReviewerId: "u1", AuthorId: "u2", Mutual: true}
+
+	assert.True(t, ordered.Excludes("u1", "u2"))
+	assert.False(t, ordered.Excludes("u2", "u1"))
+	assert.True(t, mutual.Excludes("u1", "u2"))
+	assert.True(t, mutual.Excludes("u2", "u1"))
+	assert.False(t, mutual.Excludes("u1", "u3"))
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Triggers of a reviewer change.
+const (
+	ReviewerChangeManual       = "manual"
+	ReviewerChangeDeactivation = "deactivation"
+	ReviewerChangeEscalation   = "escalation"
+	ReviewerChangeRebalance    = "rebalance"
+)
+
+// ReviewerChange represents a reviewer replaced on a PR.
+// NewReviewerId is empty when the reviewer was removed without replacement.
+type ReviewerChange struct {
+	PRId          string
+	OldReviewerId string
+	NewReviewerId string
+	Trigger       string
+	ChangedAt     time.Time
+}
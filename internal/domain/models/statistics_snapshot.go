@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// StatisticsSnapshot holds the key aggregates of the statistics as they were on Day, the start of a
+// day in UTC. They cover all PRs when TeamName is empty, otherwise the PRs authored by members of the team.
+type StatisticsSnapshot struct {
+	Day                     time.Time
+	TeamName                string
+	TotalPRs                int
+	OpenPRs                 int
+	MergedPRs               int
+	TotalAssignments        int
+	ReassignmentEvents      int
+	OpenPRsWithoutReviewers int
+	TakenAt                 time.Time
+}
@@ -1,15 +1,88 @@
 package models
 
+import "time"
+
+// User represents a team member.
+// MaxActiveReviews overrides the configured review capacity when set.
+// Tags describe the user's areas of expertise.
+// Timezone, WorkStart and WorkEnd are empty unless the user set their working hours.
+// NonReviewer is set for users outside the reviewer pool of their team, who author PRs but are never
+// assigned to review them.
 type User struct {
-	Id       string
-	Name     string
-	TeamName string
-	IsActive bool
+	Id               string
+	Name             string
+	TeamName         string
+	IsActive         bool
+	MaxActiveReviews *int
+	Tags             []string
+	Timezone         string
+	WorkStart        string
+	WorkEnd          string
+	NonReviewer      bool
+}
+
+// InWorkingHours reports whether now is inside the user's working hours.
+func (u *User) InWorkingHours(now time.Time) bool {
+	return InWorkingHours(u.Timezone, u.WorkStart, u.WorkEnd, now)
+}
+
+// NormalizeTags normalizes user tags the same way as PR labels.
+func NormalizeTags(tags []string) []string {
+	return NormalizeLabels(tags)
+}
+
+// HasAnyTag reports whether the user has at least one of the normalized tags.
+func (u *User) HasAnyTag(tags []string) bool {
+	for _, tag := range tags {
+		for _, own := range u.Tags {
+			if own == tag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ReviewCapacity returns how many open reviews the user can take, falling back to defaultMax.
+// Zero means unlimited; an override is always positive.
+func (u *User) ReviewCapacity(defaultMax int) int {
+	if u.MaxActiveReviews != nil {
+		return *u.MaxActiveReviews
+	}
+	return defaultMax
 }
 
-// Team represent team members
+// Team represent team members and the team metadata.
+// LeadId is empty when the team has no lead; CreatedAt is zero for teams created before it was recorded.
 type Team struct {
-	Members []*User
+	Members     []*User
+	Description string
+	LeadId      string
+	CreatedAt   time.Time
+}
+
+// TeamRecord is the stored metadata of a team, which is kept apart from its members. Unlike in Team,
+// LeadId is the stored lead even when they have moved to another team.
+type TeamRecord struct {
+	Name        string
+	Description string
+	LeadId      string
+	CreatedAt   time.Time
+}
+
+// Record returns the metadata of the team to store.
+func (t *Team) Record() *TeamRecord {
+	return &TeamRecord{Name: t.GetTeamName(), Description: t.Description, LeadId: t.LeadId, CreatedAt: t.CreatedAt}
+}
+
+// HasMember reports whether the user is a member of the team.
+func (t *Team) HasMember(userID string) bool {
+	for _, member := range t.Members {
+		if member.Id == userID {
+			return true
+		}
+	}
+	return false
 }
 
 // GetTeamName returns team name(from the first member)
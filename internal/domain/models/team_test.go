@@ -0,0 +1,17 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUser_HasAnyTag(t *testing.T) {
+	user := &User{Id: "u1", Tags: NormalizeTags([]string{"Go", " postgres "})}
+
+	assert.Equal(t, []string{"go", "postgres"}, user.Tags)
+	assert.True(t, user.HasAnyTag([]string{"frontend", "postgres"}))
+	assert.False(t, user.HasAnyTag([]string{"frontend"}))
+	assert.False(t, user.HasAnyTag(nil))
+	assert.False(t, (&User{Id: "u2"}).HasAnyTag([]string{"go"}))
+}
@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// Statuses of a webhook delivery.
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusDelivered = "delivered"
+	// DeliveryStatusDead marks a delivery that failed every attempt; it is only retried on request.
+	DeliveryStatusDead = "dead"
+)
+
+// WebhookDelivery is an event waiting for, or done with, delivery to the webhook.
+// Attempts counts the attempts since the delivery was queued or last requeued; LastError is the
+// error of the latest failed attempt.
+type WebhookDelivery struct {
+	Id            int64
+	EventType     string
+	Payload       []byte
+	Status        string
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	DeliveredAt   *time.Time
+}
+
+// WebhookAttempt is an attempt to deliver an event; Error is empty for a successful one.
+type WebhookAttempt struct {
+	DeliveryId  int64
+	Attempt     int
+	AttemptedAt time.Time
+	Error       string
+}
+
+// WebhookDeliveryStats counts the deliveries by status and all attempts made, failed ones included.
+type WebhookDeliveryStats struct {
+	Pending        int
+	Delivered      int
+	Dead           int
+	Attempts       int
+	FailedAttempts int
+}
+
+// RetryDelay is the delay before retrying a delivery that failed its attempt-th attempt: initial,
+// doubled after every further failure and capped at maxDelay.
+func RetryDelay(attempt int, initial, maxDelay time.Duration) time.Duration {
+	delay := initial
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
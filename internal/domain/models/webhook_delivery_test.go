@@ -0,0 +1,17 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, 30*time.Second, RetryDelay(1, 30*time.Second, time.Hour))
+	assert.Equal(t, time.Minute, RetryDelay(2, 30*time.Second, time.Hour))
+	assert.Equal(t, 4*time.Minute, RetryDelay(4, 30*time.Second, time.Hour))
+	assert.Equal(t, time.Hour, RetryDelay(8, 30*time.Second, time.Hour))
+	// doubling stops at the cap instead of overflowing
+	assert.Equal(t, time.Hour, RetryDelay(1000, 30*time.Second, time.Hour))
+}
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// Default working hours used when a user has a timezone but no hours of their own.
+const (
+	DefaultWorkStart = "09:00"
+	DefaultWorkEnd   = "18:00"
+)
+
+// clockLayout is the format of working hours boundaries.
+const clockLayout = "15:04"
+
+// InWorkingHours reports whether now falls into the working hours of a user in the given
+// IANA timezone. Hours are local wall-clock times, so DST shifts are followed; an end before
+// the start means the hours span midnight. A user without a timezone, or with a timezone or
+// hours that can't be resolved, is treated as always available.
+func InWorkingHours(timezone, start, end string, now time.Time) bool {
+	if timezone == "" {
+		return true
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return true
+	}
+	if start == "" || end == "" {
+		start, end = DefaultWorkStart, DefaultWorkEnd
+	}
+	from, err := time.Parse(clockLayout, start)
+	if err != nil {
+		return true
+	}
+	to, err := time.Parse(clockLayout, end)
+	if err != nil {
+		return true
+	}
+
+	local := now.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	fromMinute := from.Hour()*60 + from.Minute()
+	toMinute := to.Hour()*60 + to.Minute()
+	if fromMinute == toMinute {
+		return true
+	}
+	if fromMinute < toMinute {
+		return minute >= fromMinute && minute < toMinute
+	}
+	return minute >= fromMinute || minute < toMinute
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInWorkingHours(t *testing.T) {
+	cases := []struct {
+		name      string
+		timezone  string
+		start     string
+		end       string
+		now       time.Time
+		available bool
+	}{
+		{name: "No timezone is always available",
+			now: time.Date(2024, time.June, 5, 3, 0, 0, 0, time.UTC), available: true},
+		{name: "Unknown timezone is always available", timezone: "Mars/Olympus",
+			now: time.Date(2024, time.June, 5, 3, 0, 0, 0, time.UTC), available: true},
+		{name: "Default hours inside", timezone: "Europe/Berlin",
+			now: time.Date(2024, time.June, 5, 8, 0, 0, 0, time.UTC), available: true},
+		{name: "Default hours after the end", timezone: "Europe/Berlin",
+			now: time.Date(2024, time.June, 5, 16, 0, 0, 0, time.UTC), available: false},
+		{name: "End is exclusive", timezone: "UTC", start: "10:00", end: "12:00",
+			now: time.Date(2024, time.June, 5, 12, 0, 0, 0, time.UTC), available: false},
+		{name: "Hours spanning midnight", timezone: "Asia/Tokyo", start: "22:00", end: "06:00",
+			now: time.Date(2024, time.June, 5, 18, 30, 0, 0, time.UTC), available: true},
+		{name: "Outside hours spanning midnight", timezone: "Asia/Tokyo", start: "22:00", end: "06:00",
+			now: time.Date(2024, time.June, 5, 3, 0, 0, 0, time.UTC), available: false},
+		// 13:30 UTC is 08:30 in New York before the DST switch on 2024-03-10 and 09:30 after it
+		{name: "Before DST starts", timezone: "America/New_York",
+			now: time.Date(2024, time.March, 8, 13, 30, 0, 0, time.UTC), available: false},
+		{name: "After DST starts", timezone: "America/New_York",
+			now: time.Date(2024, time.March, 11, 13, 30, 0, 0, time.UTC), available: true},
+		{name: "After DST ends", timezone: "America/New_York",
+			now: time.Date(2024, time.November, 4, 13, 30, 0, 0, time.UTC), available: false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.available, InWorkingHours(tc.timezone, tc.start, tc.end, tc.now))
+		})
+	}
+}
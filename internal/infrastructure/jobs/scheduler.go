@@ -0,0 +1,392 @@
+// Package jobs runs the periodic background jobs of the service: it ticks every job with jitter,
+// runs an exclusive job on the leader replica only and under an advisory lock, recovers its panics,
+// records its last run, stops with the service and flushes the work the jobs left queued.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// jitter is the fraction of its interval by which the wait before a run is shortened or lengthened
+// at random, so that the replicas of the service and the jobs of one replica don't tick in step.
+const jitter = 0.1
+
+// Handler runs a job once. A returned error fails the run; the job runs again on its next tick.
+type Handler func(ctx context.Context) error
+
+// Job is a periodic job.
+type Job struct {
+	// Name identifies the job in logs, metrics, the recorded runs and the admin endpoint
+	Name string
+	// Interval is the average time between the runs of the job
+	Interval time.Duration
+	// RunAtStart runs the job as soon as the scheduler starts instead of after the first interval
+	RunAtStart bool
+	// LockKey is the advisory lock held while the job runs, so that one replica runs it at a time;
+	// the others skip the run. Zero runs the job on every replica.
+	LockKey int64
+	Run     Handler
+	// Flush, if set, finishes the work the job left queued when the service stops, such as the events
+	// still to be posted. It runs once on Flush, under the lock of the job.
+	Flush Handler
+}
+
+// Locker defines the interface for a lock shared between replicas.
+type Locker interface {
+	TryLock(ctx context.Context, key int64) (release func(), locked bool, err error)
+}
+
+// RunRepository defines the interface for recording the last run of every job.
+type RunRepository interface {
+	SaveRun(ctx context.Context, run *models.JobRun) error
+	GetRuns(ctx context.Context) (map[string]*models.JobRun, error)
+}
+
+// Metrics defines the interface of the job metrics.
+type Metrics interface {
+	ObserveRun(job string, duration time.Duration, failed bool)
+}
+
+// Leader defines the interface of the leader election between replicas.
+type Leader interface {
+	IsLeader() bool
+	// Term returns a context cancelled when the leadership of this replica ends
+	Term() context.Context
+}
+
+// Entry is a job registered with the scheduler.
+type Entry struct {
+	job     Job
+	running atomic.Bool
+	beat    atomic.Int64
+}
+
+// Name returns the name of the job.
+func (e *Entry) Name() string {
+	return e.job.Name
+}
+
+// Interval returns how often the job runs.
+func (e *Entry) Interval() time.Duration {
+	return e.job.Interval
+}
+
+// LastBeat returns when the job last started waiting or running, the zero time before the scheduler
+// runs it, so readiness checks can tell a job that stopped or hangs in a run from a waiting one.
+func (e *Entry) LastBeat() time.Time {
+	at := e.beat.Load()
+	if at == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, at)
+}
+
+func (e *Entry) markBeat() {
+	e.beat.Store(time.Now().UnixNano())
+}
+
+// Scheduler runs the registered jobs every interval until it is stopped.
+type Scheduler struct {
+	locker  Locker
+	runs    RunRepository
+	metrics Metrics
+	leader  Leader
+	log     *slog.Logger
+
+	mu      sync.Mutex
+	entries []*Entry
+	started bool
+}
+
+// NewScheduler creates a scheduler without jobs. Jobs run on every replica without a locker, runs
+// are not recorded without a repository and metrics may be nil.
+func NewScheduler(locker Locker, runs RunRepository, metrics Metrics, log *slog.Logger) *Scheduler {
+	if log == nil {
+		log = slog.Default()
+	}
+	return &Scheduler{
+		locker:  locker,
+		runs:    runs,
+		metrics: metrics,
+		log:     log,
+	}
+}
+
+// SetLeader runs the exclusive jobs on schedule only while this replica leads, and cuts their runs
+// short when the leadership ends. It must be called before Run. Without a leader every replica
+// tries to run them, and the locks keep them to one at a time.
+func (s *Scheduler) SetLeader(leader Leader) {
+	s.leader = leader
+}
+
+// Register adds a job. Jobs must be registered before Run and have unique names.
+func (s *Scheduler) Register(job Job) error {
+	switch {
+	case job.Name == "":
+		return fmt.Errorf("job has no name")
+	case job.Interval <= 0:
+		return fmt.Errorf("interval of job %s must be positive", job.Name)
+	case job.Run == nil:
+		return fmt.Errorf("job %s has no handler", job.Name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return fmt.Errorf("job %s registered after the scheduler started", job.Name)
+	}
+	for _, e := range s.entries {
+		if e.job.Name == job.Name {
+			return fmt.Errorf("job %s is already registered", job.Name)
+		}
+	}
+	s.entries = append(s.entries, &Entry{job: job})
+	return nil
+}
+
+// Entries returns the registered jobs in the order of registration.
+func (s *Scheduler) Entries() []*Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Entry(nil), s.entries...)
+}
+
+// Run runs every job on its interval until ctx is cancelled, then waits for the runs in progress,
+// which see ctx cancelled, to return.
+func (s *Scheduler) Run(ctx context.Context) {
+	s.mu.Lock()
+	s.started = true
+	entries := s.entries
+	s.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, e := range entries {
+		wg.Go(func() { s.loop(ctx, e) })
+	}
+	wg.Wait()
+}
+
+// loop runs the job every jittered interval until ctx is cancelled.
+func (s *Scheduler) loop(ctx context.Context, e *Entry) {
+	s.log.LogAttrs(ctx, slog.LevelInfo, "job started",
+		slog.String("job", e.job.Name),
+		slog.Duration("interval", e.job.Interval),
+		slog.Bool("exclusive", e.job.LockKey != 0))
+
+	e.markBeat()
+	if e.job.RunAtStart {
+		s.runScheduled(ctx, e)
+	}
+	timer := time.NewTimer(jittered(e.job.Interval))
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			s.log.LogAttrs(context.Background(), slog.LevelInfo, "job stopped", slog.String("job", e.job.Name))
+			return
+		case <-timer.C:
+			e.markBeat()
+			s.runScheduled(ctx, e)
+			timer.Reset(jittered(e.job.Interval))
+		}
+	}
+}
+
+// runScheduled runs the job on schedule. An exclusive job is skipped unless this replica leads,
+// and runs under its term, so that it stops when the leadership ends.
+func (s *Scheduler) runScheduled(ctx context.Context, e *Entry) {
+	if s.leader != nil && e.job.LockKey != 0 {
+		if !s.leader.IsLeader() {
+			s.log.LogAttrs(ctx, slog.LevelDebug, "job runs on the leader", slog.String("job", e.job.Name))
+			return
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		stop := context.AfterFunc(s.leader.Term(), cancel)
+		defer stop()
+	}
+	_, _ = s.run(ctx, e)
+}
+
+// Flush runs the flush handlers of the jobs, one after another, until they are done or ctx is done,
+// and returns their errors joined. It is meant for shutdown, after Run has returned. An exclusive job
+// is flushed only when its lock is free: a replica holding it runs the job and does the work anyway.
+func (s *Scheduler) Flush(ctx context.Context) error {
+	var errs []error
+	for _, e := range s.Entries() {
+		if e.job.Flush == nil {
+			continue
+		}
+		if err := s.flush(ctx, e); err != nil {
+			errs = append(errs, fmt.Errorf("job %s: %w", e.job.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// flush runs the flush handler of the job under its lock.
+func (s *Scheduler) flush(ctx context.Context, e *Entry) error {
+	if !e.running.CompareAndSwap(false, true) {
+		return fmt.Errorf("job is running")
+	}
+	defer e.running.Store(false)
+
+	if e.job.LockKey != 0 && s.locker != nil {
+		release, locked, err := s.locker.TryLock(ctx, e.job.LockKey)
+		if err != nil {
+			return fmt.Errorf("failed to take job lock: %w", err)
+		}
+		if !locked {
+			s.log.LogAttrs(ctx, slog.LevelInfo, "job is flushed by another replica", slog.String("job", e.job.Name))
+			return nil
+		}
+		defer release()
+	}
+
+	start := time.Now()
+	if err := s.call(ctx, e, e.job.Flush); err != nil {
+		return err
+	}
+	s.log.LogAttrs(ctx, slog.LevelInfo, "job flushed",
+		slog.String("job", e.job.Name), slog.Duration("duration", time.Since(start)))
+	return nil
+}
+
+// jittered returns the interval shortened or lengthened at random by up to the jitter fraction.
+func jittered(interval time.Duration) time.Duration {
+	return time.Duration(float64(interval) * (1 + jitter*(2*rand.Float64()-1)))
+}
+
+// Trigger runs the job now, outside its schedule, and returns the run; a failed run is reported in
+// it rather than as an error. It runs on any replica, leader or not, under the lock of the job.
+// Returns NOT_FOUND AppError for an unknown job and JOB_RUNNING AppError while the job runs here or,
+// for an exclusive job, on another replica.
+func (s *Scheduler) Trigger(ctx context.Context, name string) (*models.JobRun, error) {
+	s.mu.Lock()
+	var entry *Entry
+	for _, e := range s.entries {
+		if e.job.Name == name {
+			entry = e
+		}
+	}
+	s.mu.Unlock()
+	if entry == nil {
+		return nil, domainErrors.NewNotFound(fmt.Sprintf("job %s not found", name))
+	}
+
+	run, err := s.run(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+	if run == nil {
+		return nil, domainErrors.NewJobRunning(fmt.Sprintf("job %s is running", name))
+	}
+	return run, nil
+}
+
+// Jobs returns the registered jobs with their last runs, on whichever replica they ran.
+func (s *Scheduler) Jobs(ctx context.Context) ([]*models.ScheduledJob, error) {
+	runs := map[string]*models.JobRun{}
+	if s.runs != nil {
+		var err error
+		if runs, err = s.runs.GetRuns(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	entries := s.Entries()
+	jobs := make([]*models.ScheduledJob, 0, len(entries))
+	for _, e := range entries {
+		jobs = append(jobs, &models.ScheduledJob{
+			Name:      e.job.Name,
+			Interval:  e.job.Interval,
+			Exclusive: e.job.LockKey != 0,
+			LastRun:   runs[e.job.Name],
+		})
+	}
+	return jobs, nil
+}
+
+// run runs the job once under its lock and records the run. It returns no run when the job is
+// running already, here or on another replica, and an error only when the lock can't be taken.
+func (s *Scheduler) run(ctx context.Context, e *Entry) (*models.JobRun, error) {
+	if !e.running.CompareAndSwap(false, true) {
+		s.log.LogAttrs(ctx, slog.LevelDebug, "job is running already", slog.String("job", e.job.Name))
+		return nil, nil
+	}
+	defer e.running.Store(false)
+
+	if e.job.LockKey != 0 && s.locker != nil {
+		release, locked, err := s.locker.TryLock(ctx, e.job.LockKey)
+		if err != nil {
+			s.log.LogAttrs(ctx, slog.LevelError, "failed to take job lock",
+				slog.String("job", e.job.Name), slog.String("error", err.Error()))
+			return nil, err
+		}
+		if !locked {
+			s.log.LogAttrs(ctx, slog.LevelDebug, "job is running on another replica", slog.String("job", e.job.Name))
+			return nil, nil
+		}
+		defer release()
+	}
+
+	run := &models.JobRun{Name: e.job.Name, StartedAt: time.Now().UTC()}
+	err := s.call(ctx, e, e.job.Run)
+	run.FinishedAt = time.Now().UTC()
+	duration := run.FinishedAt.Sub(run.StartedAt)
+
+	if err != nil {
+		run.Error = err.Error()
+		s.log.LogAttrs(ctx, runErrorLevel(ctx), "job failed",
+			slog.String("job", e.job.Name), slog.Duration("duration", duration), slog.String("error", run.Error))
+	} else {
+		run.LastSuccessAt = &run.FinishedAt
+		s.log.LogAttrs(ctx, slog.LevelDebug, "job finished",
+			slog.String("job", e.job.Name), slog.Duration("duration", duration))
+	}
+	if s.metrics != nil {
+		s.metrics.ObserveRun(e.job.Name, duration, err != nil)
+	}
+	if s.runs != nil {
+		// a run cut short by shutdown is still recorded
+		if saveErr := s.runs.SaveRun(context.WithoutCancel(ctx), run); saveErr != nil {
+			s.log.LogAttrs(ctx, slog.LevelWarn, "failed to record job run",
+				slog.String("job", e.job.Name), slog.String("error", saveErr.Error()))
+		}
+	}
+	return run, nil
+}
+
+// call runs a handler of the job, turning a panic into an error so that it fails the run rather
+// than the service.
+func (s *Scheduler) call(ctx context.Context, e *Entry, handler Handler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.log.LogAttrs(ctx, slog.LevelError, "job panicked",
+				slog.String("job", e.job.Name), slog.Any("panic", r), slog.String("stack", string(debug.Stack())))
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return handler(ctx)
+}
+
+// runErrorLevel is the level a failed run is logged at: Info when the scheduler is stopping, as the
+// run was cut short rather than failed, and Error otherwise.
+func runErrorLevel(ctx context.Context) slog.Level {
+	if ctx.Err() != nil {
+		return slog.LevelInfo
+	}
+	return slog.LevelError
+}
@@ -0,0 +1,480 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeLocker struct {
+	mu       sync.Mutex
+	held     bool
+	keys     []int64
+	released int
+}
+
+func (l *fakeLocker) TryLock(ctx context.Context, key int64) (func(), bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.held {
+		return nil, false, nil
+	}
+	l.keys = append(l.keys, key)
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.released++
+	}, true, nil
+}
+
+type fakeRuns struct {
+	mu   sync.Mutex
+	runs map[string]*models.JobRun
+}
+
+func (r *fakeRuns) SaveRun(ctx context.Context, run *models.JobRun) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.runs == nil {
+		r.runs = make(map[string]*models.JobRun)
+	}
+	saved := *run
+	r.runs[run.Name] = &saved
+	return nil
+}
+
+func (r *fakeRuns) GetRuns(ctx context.Context) (map[string]*models.JobRun, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	runs := make(map[string]*models.JobRun, len(r.runs))
+	for name, run := range r.runs {
+		runs[name] = run
+	}
+	return runs, nil
+}
+
+type observedRun struct {
+	job    string
+	failed bool
+}
+
+type fakeMetrics struct {
+	mu   sync.Mutex
+	runs []observedRun
+}
+
+func (m *fakeMetrics) ObserveRun(job string, duration time.Duration, failed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.runs = append(m.runs, observedRun{job: job, failed: failed})
+}
+
+// fakeLeader leads until its term is ended.
+type fakeLeader struct {
+	mu      sync.Mutex
+	term    context.Context
+	endTerm context.CancelFunc
+}
+
+func newFakeLeader(leading bool) *fakeLeader {
+	l := &fakeLeader{}
+	l.term, l.endTerm = context.WithCancel(context.Background())
+	if !leading {
+		l.endTerm()
+	}
+	return l
+}
+
+func (l *fakeLeader) IsLeader() bool {
+	return l.Term().Err() == nil
+}
+
+func (l *fakeLeader) Term() context.Context {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.term
+}
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, nil))
+}
+
+func TestScheduler_Register(t *testing.T) {
+	noop := func(ctx context.Context) error { return nil }
+	tests := []struct {
+		name string
+		job  Job
+	}{
+		{"Error - No name", Job{Interval: time.Minute, Run: noop}},
+		{"Error - Interval not positive", Job{Name: "escalation", Run: noop}},
+		{"Error - No handler", Job{Name: "escalation", Interval: time.Minute}},
+		{"Error - Name taken", Job{Name: "webhook", Interval: time.Minute, Run: noop}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScheduler(nil, nil, nil, testLogger())
+			require.NoError(t, s.Register(Job{Name: "webhook", Interval: time.Minute, Run: noop}))
+
+			assert.Error(t, s.Register(tt.job))
+			assert.Len(t, s.Entries(), 1)
+		})
+	}
+
+	t.Run("Error - Scheduler started", func(t *testing.T) {
+		s := NewScheduler(nil, nil, nil, testLogger())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		s.Run(ctx)
+
+		assert.Error(t, s.Register(Job{Name: "webhook", Interval: time.Minute, Run: noop}))
+	})
+}
+
+func TestScheduler_Trigger(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - Runs under the lock and records the run", func(t *testing.T) {
+		locker, runs, metrics := &fakeLocker{}, &fakeRuns{}, &fakeMetrics{}
+		s := NewScheduler(locker, runs, metrics, testLogger())
+		calls := 0
+		require.NoError(t, s.Register(Job{Name: "escalation", Interval: time.Hour, LockKey: 7,
+			Run: func(ctx context.Context) error { calls++; return nil }}))
+
+		run, err := s.Trigger(ctx, "escalation")
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, "escalation", run.Name)
+		assert.Empty(t, run.Error)
+		assert.NotNil(t, run.LastSuccessAt)
+		assert.Equal(t, []int64{7}, locker.keys)
+		assert.Equal(t, 1, locker.released)
+		assert.Equal(t, []observedRun{{job: "escalation"}}, metrics.runs)
+		assert.Equal(t, run.StartedAt, runs.runs["escalation"].StartedAt)
+	})
+
+	t.Run("Success - Failure is reported in the run", func(t *testing.T) {
+		locker, runs, metrics := &fakeLocker{}, &fakeRuns{}, &fakeMetrics{}
+		s := NewScheduler(locker, runs, metrics, testLogger())
+		require.NoError(t, s.Register(Job{Name: "webhook", Interval: time.Hour, LockKey: 7,
+			Run: func(ctx context.Context) error { return fmt.Errorf("receiver is down") }}))
+
+		run, err := s.Trigger(ctx, "webhook")
+
+		require.NoError(t, err)
+		assert.Equal(t, "receiver is down", run.Error)
+		assert.Nil(t, run.LastSuccessAt)
+		assert.Equal(t, 1, locker.released)
+		assert.Equal(t, []observedRun{{job: "webhook", failed: true}}, metrics.runs)
+		assert.Equal(t, "receiver is down", runs.runs["webhook"].Error)
+	})
+
+	t.Run("Success - Panic fails the run", func(t *testing.T) {
+		locker, metrics := &fakeLocker{}, &fakeMetrics{}
+		s := NewScheduler(locker, nil, metrics, testLogger())
+		require.NoError(t, s.Register(Job{Name: "snapshot", Interval: time.Hour, LockKey: 7,
+			Run: func(ctx context.Context) error { panic("nil map") }}))
+
+		run, err := s.Trigger(ctx, "snapshot")
+
+		require.NoError(t, err)
+		assert.Contains(t, run.Error, "nil map")
+		assert.Equal(t, 1, locker.released)
+		assert.Equal(t, []observedRun{{job: "snapshot", failed: true}}, metrics.runs)
+	})
+
+	t.Run("Success - Job without a lock key runs without the lock", func(t *testing.T) {
+		locker := &fakeLocker{held: true}
+		s := NewScheduler(locker, nil, nil, testLogger())
+		require.NoError(t, s.Register(Job{Name: "metrics", Interval: time.Hour,
+			Run: func(ctx context.Context) error { return nil }}))
+
+		_, err := s.Trigger(ctx, "metrics")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Error - Another replica holds the lock", func(t *testing.T) {
+		metrics := &fakeMetrics{}
+		s := NewScheduler(&fakeLocker{held: true}, nil, metrics, testLogger())
+		calls := 0
+		require.NoError(t, s.Register(Job{Name: "escalation", Interval: time.Hour, LockKey: 7,
+			Run: func(ctx context.Context) error { calls++; return nil }}))
+
+		run, err := s.Trigger(ctx, "escalation")
+
+		assert.Nil(t, run)
+		assert.True(t, domainErrors.HasCode(err, domainErrors.CodeJobRunning))
+		assert.Zero(t, calls)
+		assert.Empty(t, metrics.runs)
+	})
+
+	t.Run("Error - Job is running here", func(t *testing.T) {
+		s := NewScheduler(nil, nil, nil, testLogger())
+		started, finish := make(chan struct{}), make(chan struct{})
+		require.NoError(t, s.Register(Job{Name: "escalation", Interval: time.Hour,
+			Run: func(ctx context.Context) error { close(started); <-finish; return nil }}))
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, _ = s.Trigger(ctx, "escalation")
+		}()
+		<-started
+
+		_, err := s.Trigger(ctx, "escalation")
+		close(finish)
+		<-done
+
+		assert.True(t, domainErrors.HasCode(err, domainErrors.CodeJobRunning))
+	})
+
+	t.Run("Error - Unknown job", func(t *testing.T) {
+		s := NewScheduler(nil, nil, nil, testLogger())
+
+		_, err := s.Trigger(ctx, "digest")
+
+		assert.True(t, domainErrors.HasCode(err, domainErrors.CodeNotFound))
+	})
+}
+
+func TestScheduler_Run(t *testing.T) {
+	t.Run("Success - Runs jobs on their interval until stopped", func(t *testing.T) {
+		s := NewScheduler(nil, nil, nil, testLogger())
+		ticks := make(chan struct{}, 10)
+		require.NoError(t, s.Register(Job{Name: "webhook", Interval: 10 * time.Millisecond,
+			Run: func(ctx context.Context) error { ticks <- struct{}{}; return nil }}))
+		require.NoError(t, s.Register(Job{Name: "snapshot", Interval: time.Hour,
+			Run: func(ctx context.Context) error { return nil }}))
+		entries := s.Entries()
+		assert.True(t, entries[0].LastBeat().IsZero())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			s.Run(ctx)
+		}()
+		for range 2 {
+			select {
+			case <-ticks:
+			case <-time.After(time.Second):
+				t.Fatal("job did not run on its interval")
+			}
+		}
+		cancel()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("scheduler did not stop after cancellation")
+		}
+		assert.False(t, entries[0].LastBeat().IsZero())
+		assert.False(t, entries[1].LastBeat().IsZero())
+	})
+
+	t.Run("Success - Runs at start when asked to", func(t *testing.T) {
+		s := NewScheduler(nil, nil, nil, testLogger())
+		ran := make(chan struct{}, 1)
+		require.NoError(t, s.Register(Job{Name: "metrics", Interval: time.Hour, RunAtStart: true,
+			Run: func(ctx context.Context) error { ran <- struct{}{}; return nil }}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			s.Run(ctx)
+		}()
+
+		select {
+		case <-ran:
+		case <-time.After(time.Second):
+			t.Fatal("job did not run at start")
+		}
+		cancel()
+		<-done
+	})
+
+	t.Run("Success - Stop waits for the run in progress", func(t *testing.T) {
+		runs := &fakeRuns{}
+		s := NewScheduler(nil, runs, nil, testLogger())
+		started := make(chan struct{})
+		require.NoError(t, s.Register(Job{Name: "escalation", Interval: time.Hour, RunAtStart: true,
+			Run: func(ctx context.Context) error {
+				close(started)
+				<-ctx.Done()
+				return ctx.Err()
+			}}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			s.Run(ctx)
+		}()
+		<-started
+		cancel()
+		<-done
+
+		recorded, err := runs.GetRuns(context.Background())
+		require.NoError(t, err)
+		require.Contains(t, recorded, "escalation")
+		assert.Equal(t, context.Canceled.Error(), recorded["escalation"].Error)
+	})
+}
+
+func TestScheduler_Leader(t *testing.T) {
+	t.Run("Success - Follower runs only the jobs that are not exclusive", func(t *testing.T) {
+		s := NewScheduler(nil, nil, nil, testLogger())
+		s.SetLeader(newFakeLeader(false))
+		ran := make(chan string, 10)
+		require.NoError(t, s.Register(Job{Name: "escalation", Interval: time.Hour, RunAtStart: true, LockKey: 7,
+			Run: func(ctx context.Context) error { ran <- "escalation"; return nil }}))
+		require.NoError(t, s.Register(Job{Name: "metrics", Interval: time.Hour, RunAtStart: true,
+			Run: func(ctx context.Context) error { ran <- "metrics"; return nil }}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			s.Run(ctx)
+		}()
+		select {
+		case name := <-ran:
+			assert.Equal(t, "metrics", name)
+		case <-time.After(time.Second):
+			t.Fatal("job did not run at start")
+		}
+		cancel()
+		<-done
+
+		assert.Empty(t, ran)
+		assert.False(t, s.Entries()[0].LastBeat().IsZero(), "a skipped job is alive")
+	})
+
+	t.Run("Success - Run stops when the leadership ends", func(t *testing.T) {
+		leader, runs := newFakeLeader(true), &fakeRuns{}
+		s := NewScheduler(nil, runs, nil, testLogger())
+		s.SetLeader(leader)
+		started := make(chan struct{})
+		require.NoError(t, s.Register(Job{Name: "webhook", Interval: time.Hour, RunAtStart: true, LockKey: 7,
+			Run: func(ctx context.Context) error {
+				close(started)
+				<-ctx.Done()
+				return ctx.Err()
+			}}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go s.Run(ctx)
+		<-started
+		leader.endTerm()
+
+		require.Eventually(t, func() bool {
+			recorded, _ := runs.GetRuns(context.Background())
+			return recorded["webhook"] != nil
+		}, time.Second, 5*time.Millisecond)
+		recorded, err := runs.GetRuns(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, context.Canceled.Error(), recorded["webhook"].Error)
+	})
+
+	t.Run("Success - Trigger runs on a follower", func(t *testing.T) {
+		s := NewScheduler(&fakeLocker{}, nil, nil, testLogger())
+		s.SetLeader(newFakeLeader(false))
+		require.NoError(t, s.Register(Job{Name: "escalation", Interval: time.Hour, LockKey: 7,
+			Run: func(ctx context.Context) error { return ctx.Err() }}))
+
+		run, err := s.Trigger(context.Background(), "escalation")
+
+		require.NoError(t, err)
+		assert.Empty(t, run.Error)
+	})
+}
+
+func TestScheduler_Flush(t *testing.T) {
+	ctx := context.Background()
+	noop := func(ctx context.Context) error { return nil }
+
+	t.Run("Success - Flushes the jobs under their locks", func(t *testing.T) {
+		locker, metrics := &fakeLocker{}, &fakeMetrics{}
+		s := NewScheduler(locker, nil, metrics, testLogger())
+		var flushed []string
+		require.NoError(t, s.Register(Job{Name: "webhook", Interval: time.Hour, LockKey: 7, Run: noop,
+			Flush: func(ctx context.Context) error { flushed = append(flushed, "webhook"); return nil }}))
+		require.NoError(t, s.Register(Job{Name: "metrics", Interval: time.Hour, Run: noop}))
+
+		err := s.Flush(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"webhook"}, flushed)
+		assert.Equal(t, []int64{7}, locker.keys)
+		assert.Equal(t, 1, locker.released)
+		assert.Empty(t, metrics.runs, "a flush is not a run")
+	})
+
+	t.Run("Success - Lock held by another replica skips the flush", func(t *testing.T) {
+		s := NewScheduler(&fakeLocker{held: true}, nil, nil, testLogger())
+		calls := 0
+		require.NoError(t, s.Register(Job{Name: "webhook", Interval: time.Hour, LockKey: 7, Run: noop,
+			Flush: func(ctx context.Context) error { calls++; return nil }}))
+
+		err := s.Flush(ctx)
+
+		assert.NoError(t, err)
+		assert.Zero(t, calls)
+	})
+
+	t.Run("Error - Failed and panicking flushes are joined", func(t *testing.T) {
+		s := NewScheduler(nil, nil, nil, testLogger())
+		require.NoError(t, s.Register(Job{Name: "webhook", Interval: time.Hour, Run: noop,
+			Flush: func(ctx context.Context) error { return fmt.Errorf("3 deliveries pending") }}))
+		require.NoError(t, s.Register(Job{Name: "digest", Interval: time.Hour, Run: noop,
+			Flush: func(ctx context.Context) error { panic("nil map") }}))
+
+		err := s.Flush(ctx)
+
+		assert.ErrorContains(t, err, "job webhook: 3 deliveries pending")
+		assert.ErrorContains(t, err, "job digest: job panicked: nil map")
+	})
+}
+
+func TestScheduler_Jobs(t *testing.T) {
+	runs := &fakeRuns{}
+	s := NewScheduler(&fakeLocker{}, runs, nil, testLogger())
+	noop := func(ctx context.Context) error { return nil }
+	require.NoError(t, s.Register(Job{Name: "escalation", Interval: time.Hour, LockKey: 7, Run: noop}))
+	require.NoError(t, s.Register(Job{Name: "metrics", Interval: time.Minute, Run: noop}))
+	_, err := s.Trigger(context.Background(), "escalation")
+	require.NoError(t, err)
+
+	jobs, err := s.Jobs(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, "escalation", jobs[0].Name)
+	assert.True(t, jobs[0].Exclusive)
+	require.NotNil(t, jobs[0].LastRun)
+	assert.Empty(t, jobs[0].LastRun.Error)
+	assert.Equal(t, "metrics", jobs[1].Name)
+	assert.Equal(t, time.Minute, jobs[1].Interval)
+	assert.False(t, jobs[1].Exclusive)
+	assert.Nil(t, jobs[1].LastRun)
+}
+
+func TestJittered(t *testing.T) {
+	for range 100 {
+		wait := jittered(time.Minute)
+
+		assert.GreaterOrEqual(t, wait, 54*time.Second)
+		assert.LessOrEqual(t, wait, 66*time.Second)
+	}
+}
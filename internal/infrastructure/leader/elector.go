@@ -0,0 +1,224 @@
+// Package leader elects the replica running the singleton work of the service, such as the
+// exclusive background jobs, with a lease in the database that the leader keeps renewing. When the
+// leader dies its lease runs out and another replica takes it over.
+package leader
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// LeaseName is the name of the lease held by the leader.
+const LeaseName = "leader"
+
+// LeaseRepository defines the interface for the leases of the election.
+// AcquireLease must return the lease as it is afterwards, held by holder or by another replica.
+type LeaseRepository interface {
+	AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (*models.Lease, error)
+	ReleaseLease(ctx context.Context, name, holder string) error
+}
+
+// Metrics defines the interface of the leader metric.
+type Metrics interface {
+	SetLeader(leader bool)
+}
+
+// ended is the term of a replica that doesn't lead.
+var ended = func() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}()
+
+// Elector takes part in the election for this replica.
+type Elector struct {
+	repo    LeaseRepository
+	id      string
+	cfg     config.Leader
+	metrics Metrics
+	log     *slog.Logger
+
+	mu sync.Mutex
+	// term is cancelled when the leadership of this replica ends; nil while it doesn't lead
+	term    context.Context
+	endTerm context.CancelFunc
+	// holder is the replica last seen holding the lease, empty when unknown
+	holder string
+}
+
+// NewElector creates an elector for the replica identified by id, see NewID. Metrics may be nil.
+func NewElector(repo LeaseRepository, id string, cfg config.Leader, metrics Metrics, log *slog.Logger) (*Elector, error) {
+	switch {
+	case id == "":
+		return nil, fmt.Errorf("replica has no id")
+	case cfg.LeaseTTL <= 0 || cfg.RenewInterval <= 0:
+		return nil, fmt.Errorf("lease ttl and renew interval must be positive")
+	case cfg.RenewInterval >= cfg.LeaseTTL:
+		return nil, fmt.Errorf("renew interval %s must be shorter than lease ttl %s", cfg.RenewInterval, cfg.LeaseTTL)
+	}
+	if log == nil {
+		log = slog.Default()
+	}
+	return &Elector{repo: repo, id: id, cfg: cfg, metrics: metrics, log: log}, nil
+}
+
+// NewID returns an id for this replica: its host name, which names the pod or container, with a
+// random suffix so that a restarted replica doesn't pass for the one before it.
+func NewID() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "replica"
+	}
+	return host + "-" + rand.Text()[:8]
+}
+
+// ID returns the id of this replica.
+func (e *Elector) ID() string {
+	return e.id
+}
+
+// IsLeader reports whether this replica leads.
+func (e *Elector) IsLeader() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.term != nil
+}
+
+// Term returns a context cancelled when the current leadership of this replica ends, or a cancelled
+// one while it doesn't lead. Work only the leader may do runs under it, so it stops with the
+// leadership.
+func (e *Elector) Term() context.Context {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.term == nil {
+		return ended
+	}
+	return e.term
+}
+
+// Holder returns the id of the replica last seen holding the lease, empty when unknown, as before
+// the first attempt or after a failed one.
+func (e *Elector) Holder() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.holder
+}
+
+// Run tries to take the lease, or renews it while leading, every renew interval until ctx is
+// cancelled, then steps down and releases the lease so that another replica takes over at once.
+// The leader steps down when it can't renew its lease before it runs out, before another replica
+// can take it.
+func (e *Elector) Run(ctx context.Context) {
+	e.log.LogAttrs(ctx, slog.LevelInfo, "leader election started",
+		slog.String("id", e.id),
+		slog.Duration("lease_ttl", e.cfg.LeaseTTL),
+		slog.Duration("renew_interval", e.cfg.RenewInterval))
+
+	ticker := time.NewTicker(e.cfg.RenewInterval)
+	defer ticker.Stop()
+	expiry := time.NewTimer(e.cfg.LeaseTTL)
+	expiry.Stop()
+	defer expiry.Stop()
+
+	e.campaign(ctx, expiry)
+	for {
+		select {
+		case <-ctx.Done():
+			e.stepDown(slog.LevelInfo, "shutting down")
+			e.release(context.WithoutCancel(ctx))
+			e.log.LogAttrs(context.Background(), slog.LevelInfo, "leader election stopped")
+			return
+		case <-ticker.C:
+			e.campaign(ctx, expiry)
+		case <-expiry.C:
+			e.stepDown(slog.LevelWarn, "lease ran out before it was renewed")
+		}
+	}
+}
+
+// campaign takes or renews the lease. While leading, expiry is set to when the lease runs out.
+func (e *Elector) campaign(ctx context.Context, expiry *time.Timer) {
+	start := time.Now()
+	callCtx, cancel := context.WithTimeout(ctx, e.cfg.RenewInterval)
+	defer cancel()
+	lease, err := e.repo.AcquireLease(callCtx, LeaseName, e.id, e.cfg.LeaseTTL)
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		e.log.LogAttrs(ctx, slog.LevelWarn, "failed to acquire leader lease",
+			slog.Bool("leader", e.IsLeader()), slog.String("error", err.Error()))
+		e.mu.Lock()
+		if e.term == nil {
+			e.holder = ""
+		}
+		e.mu.Unlock()
+		return
+	}
+
+	if lease.Holder != e.id {
+		expiry.Stop()
+		e.stepDown(slog.LevelWarn, "lease taken by "+lease.Holder)
+		e.mu.Lock()
+		e.holder = lease.Holder
+		e.mu.Unlock()
+		return
+	}
+	// the lease was extended after start, so by the clock of this replica it runs out no sooner
+	expiry.Reset(time.Until(start.Add(e.cfg.LeaseTTL)))
+	e.startTerm()
+}
+
+// startTerm makes this replica the leader unless it leads already.
+func (e *Elector) startTerm() {
+	e.mu.Lock()
+	e.holder = e.id
+	if e.term != nil {
+		e.mu.Unlock()
+		return
+	}
+	e.term, e.endTerm = context.WithCancel(context.Background())
+	e.mu.Unlock()
+
+	e.log.LogAttrs(context.Background(), slog.LevelInfo, "became leader", slog.String("id", e.id))
+	if e.metrics != nil {
+		e.metrics.SetLeader(true)
+	}
+}
+
+// stepDown ends the leadership of this replica, cancelling the work running under its term, and
+// logs the reason at level.
+func (e *Elector) stepDown(level slog.Level, reason string) {
+	e.mu.Lock()
+	if e.term == nil {
+		e.mu.Unlock()
+		return
+	}
+	e.endTerm()
+	e.term, e.endTerm = nil, nil
+	e.holder = ""
+	e.mu.Unlock()
+
+	e.log.LogAttrs(context.Background(), level, "stepped down as leader",
+		slog.String("id", e.id), slog.String("reason", reason))
+	if e.metrics != nil {
+		e.metrics.SetLeader(false)
+	}
+}
+
+// release gives up the lease, if this replica holds it, within a renew interval.
+func (e *Elector) release(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, e.cfg.RenewInterval)
+	defer cancel()
+	if err := e.repo.ReleaseLease(ctx, LeaseName, e.id); err != nil {
+		e.log.LogAttrs(ctx, slog.LevelWarn, "failed to release leader lease", slog.String("error", err.Error()))
+	}
+}
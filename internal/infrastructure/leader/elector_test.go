@@ -0,0 +1,192 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLeases keeps the leases in memory, expiring them by the local clock.
+type fakeLeases struct {
+	mu     sync.Mutex
+	leases map[string]*models.Lease
+	down   bool
+}
+
+func (r *fakeLeases) AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (*models.Lease, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.down {
+		return nil, errors.New("connection refused")
+	}
+	if r.leases == nil {
+		r.leases = make(map[string]*models.Lease)
+	}
+	now := time.Now()
+	lease := r.leases[name]
+	switch {
+	case lease == nil || lease.ExpiresAt.Before(now):
+		lease = &models.Lease{Name: name, Holder: holder, AcquiredAt: now, ExpiresAt: now.Add(ttl)}
+		r.leases[name] = lease
+	case lease.Holder == holder:
+		lease.ExpiresAt = now.Add(ttl)
+	}
+	leaseCopy := *lease
+	return &leaseCopy, nil
+}
+
+func (r *fakeLeases) ReleaseLease(ctx context.Context, name, holder string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if lease := r.leases[name]; lease != nil && lease.Holder == holder {
+		delete(r.leases, name)
+	}
+	return nil
+}
+
+func (r *fakeLeases) setDown(down bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.down = down
+}
+
+type fakeMetrics struct {
+	mu     sync.Mutex
+	leader bool
+}
+
+func (m *fakeMetrics) SetLeader(leader bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.leader = leader
+}
+
+func (m *fakeMetrics) isLeader() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.leader
+}
+
+var testConfig = config.Leader{LeaseTTL: 200 * time.Millisecond, RenewInterval: 20 * time.Millisecond}
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, nil))
+}
+
+// start runs an elector for the replica id until the test ends or the returned function stops it,
+// which waits for it to release the lease.
+func start(t *testing.T, repo LeaseRepository, id string, metrics Metrics) (*Elector, func()) {
+	t.Helper()
+	e, err := NewElector(repo, id, testConfig, metrics, testLogger())
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		e.Run(ctx)
+	}()
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			cancel()
+			<-done
+		})
+	}
+	t.Cleanup(stop)
+	return e, stop
+}
+
+func TestElector(t *testing.T) {
+	t.Run("Success - One replica leads, the other follows", func(t *testing.T) {
+		repo, metrics := &fakeLeases{}, &fakeMetrics{}
+		first, _ := start(t, repo, "replica-1", metrics)
+		require.Eventually(t, first.IsLeader, time.Second, 5*time.Millisecond)
+		second, _ := start(t, repo, "replica-2", nil)
+
+		require.Eventually(t, func() bool { return second.Holder() == "replica-1" }, time.Second, 5*time.Millisecond)
+		assert.False(t, second.IsLeader())
+		assert.Error(t, second.Term().Err())
+		assert.NoError(t, first.Term().Err())
+		assert.Equal(t, "replica-1", first.Holder())
+		assert.True(t, metrics.isLeader())
+	})
+
+	t.Run("Success - Follower takes over when the leader stops", func(t *testing.T) {
+		repo, metrics := &fakeLeases{}, &fakeMetrics{}
+		first, stopFirst := start(t, repo, "replica-1", metrics)
+		require.Eventually(t, first.IsLeader, time.Second, 5*time.Millisecond)
+		term := first.Term()
+		second, _ := start(t, repo, "replica-2", nil)
+
+		stopFirst()
+
+		assert.Error(t, term.Err(), "work of the old leader is cancelled")
+		assert.False(t, first.IsLeader())
+		assert.False(t, metrics.isLeader())
+		// the released lease is taken on the next attempt, without waiting for it to run out
+		require.Eventually(t, second.IsLeader, testConfig.LeaseTTL/2, 5*time.Millisecond)
+	})
+
+	t.Run("Success - Follower takes over within the lease ttl after the leader died", func(t *testing.T) {
+		repo := &fakeLeases{}
+		_, err := repo.AcquireLease(context.Background(), LeaseName, "crashed", testConfig.LeaseTTL)
+		require.NoError(t, err)
+		died := time.Now()
+
+		follower, _ := start(t, repo, "replica-2", nil)
+
+		require.Eventually(t, follower.IsLeader, time.Second, 5*time.Millisecond)
+		assert.Less(t, time.Since(died), testConfig.LeaseTTL+2*testConfig.RenewInterval)
+	})
+
+	t.Run("Success - Leader steps down when it can't renew the lease", func(t *testing.T) {
+		repo, metrics := &fakeLeases{}, &fakeMetrics{}
+		e, _ := start(t, repo, "replica-1", metrics)
+		require.Eventually(t, e.IsLeader, time.Second, 5*time.Millisecond)
+		term := e.Term()
+
+		repo.setDown(true)
+
+		require.Eventually(t, func() bool { return term.Err() != nil }, 2*testConfig.LeaseTTL, 5*time.Millisecond)
+		assert.False(t, e.IsLeader())
+		assert.Empty(t, e.Holder())
+		assert.False(t, metrics.isLeader())
+
+		repo.setDown(false)
+		require.Eventually(t, e.IsLeader, time.Second, 5*time.Millisecond, "leads again once the lease is renewed")
+	})
+}
+
+func TestNewElector(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		cfg  config.Leader
+	}{
+		{"Error - No id", "", testConfig},
+		{"Error - Lease ttl not positive", "replica-1", config.Leader{RenewInterval: time.Second}},
+		{"Error - Renew interval not positive", "replica-1", config.Leader{LeaseTTL: time.Second}},
+		{"Error - Renew interval not shorter than the ttl", "replica-1",
+			config.Leader{LeaseTTL: time.Second, RenewInterval: time.Second}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewElector(&fakeLeases{}, tt.id, tt.cfg, nil, testLogger())
+
+			assert.Error(t, err)
+		})
+	}
+
+	t.Run("Success - Ids of replicas differ", func(t *testing.T) {
+		assert.NotEqual(t, NewID(), NewID())
+	})
+}
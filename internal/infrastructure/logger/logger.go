@@ -3,6 +3,8 @@ package logger
 import (
 	"io"
 	"log/slog"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
 )
 
 const (
@@ -11,18 +13,30 @@ const (
 	EnvProd  = "prod"
 )
 
-// NewLogger creates a new structured logger based on the environment.
-func NewLogger(env string, w io.Writer) *slog.Logger {
-	var logger *slog.Logger
+// NewLogger creates a new structured logger configured by cfg. Settings left empty take the defaults
+// of the environment: JSON everywhere, Debug level in dev and local and Info otherwise. cfg must be
+// valid; an unknown level falls back to the default.
+func NewLogger(env string, cfg config.Log, w io.Writer) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: defaultLevel(env), AddSource: cfg.AddSource}
+	if cfg.Level != "" {
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(cfg.Level)); err == nil {
+			opts.Level = level
+		}
+	}
+
+	if cfg.Format == config.LogFormatText {
+		return slog.New(slog.NewTextHandler(w, opts))
+	}
+	return slog.New(slog.NewJSONHandler(w, opts))
+}
+
+// defaultLevel returns the level logged in the environment when the config sets none.
+func defaultLevel(env string) slog.Level {
 	switch env {
-	case EnvDev:
-		logger = slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug}))
-	case EnvLocal:
-		logger = slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug}))
-	case EnvProd:
-		logger = slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	case EnvDev, EnvLocal:
+		return slog.LevelDebug
 	default:
-		logger = slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slog.LevelInfo}))
+		return slog.LevelInfo
 	}
-	return logger
 }
@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLogger(t *testing.T) {
+	tests := []struct {
+		name  string
+		env   string
+		cfg   config.Log
+		text  bool
+		level slog.Level
+	}{
+		{name: "Success - Dev defaults to JSON at Debug", env: EnvDev, level: slog.LevelDebug},
+		{name: "Success - Local defaults to JSON at Debug", env: EnvLocal, level: slog.LevelDebug},
+		{name: "Success - Prod defaults to JSON at Info", env: EnvProd, level: slog.LevelInfo},
+		{name: "Success - Unknown env defaults to JSON at Info", env: "staging", level: slog.LevelInfo},
+		{name: "Success - Text keeps the level of the env", env: EnvLocal,
+			cfg: config.Log{Format: config.LogFormatText}, text: true, level: slog.LevelDebug},
+		{name: "Success - JSON keeps the level of the env", env: EnvProd,
+			cfg: config.Log{Format: config.LogFormatJSON}, level: slog.LevelInfo},
+		{name: "Success - Level overrides the env", env: EnvDev,
+			cfg: config.Log{Level: "warn"}, level: slog.LevelWarn},
+		{name: "Success - Text at Debug in prod", env: EnvProd,
+			cfg: config.Log{Format: config.LogFormatText, Level: "debug"}, text: true, level: slog.LevelDebug},
+		{name: "Success - JSON at Error", env: EnvLocal,
+			cfg: config.Log{Format: config.LogFormatJSON, Level: "ERROR"}, level: slog.LevelError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := NewLogger(tt.env, tt.cfg, &bytes.Buffer{})
+
+			if tt.text {
+				assert.IsType(t, &slog.TextHandler{}, log.Handler())
+			} else {
+				assert.IsType(t, &slog.JSONHandler{}, log.Handler())
+			}
+			ctx := context.Background()
+			assert.True(t, log.Enabled(ctx, tt.level))
+			assert.False(t, log.Enabled(ctx, tt.level-1), "levels below %s are dropped", tt.level)
+		})
+	}
+}
+
+func TestNewLogger_AddSource(t *testing.T) {
+	for _, format := range []string{config.LogFormatJSON, config.LogFormatText} {
+		t.Run("Success - Source is added in "+format, func(t *testing.T) {
+			var out bytes.Buffer
+
+			NewLogger(EnvProd, config.Log{Format: format, AddSource: true}, &out).Info("started")
+
+			assert.Contains(t, out.String(), "logger_test.go")
+		})
+	}
+
+	t.Run("Success - Source is left out by default", func(t *testing.T) {
+		var out bytes.Buffer
+
+		NewLogger(EnvProd, config.Log{}, &out).Info("started")
+
+		assert.NotContains(t, out.String(), "logger_test.go")
+	})
+}
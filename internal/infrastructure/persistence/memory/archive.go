@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// ArchiveRepository moves merged PRs between the main data and the archive in memory.
+type ArchiveRepository struct {
+	s *Storage
+}
+
+// ArchiveMerged moves up to limit PRs merged before the given moment, oldest first, with their
+// reviewers into the archive and returns their ids. Reviewer history is kept as is.
+func (r *ArchiveRepository) ArchiveMerged(ctx context.Context, mergedBefore time.Time, limit int) ([]string, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+
+	var prs []*models.PullRequest
+	for _, pr := range st.prs {
+		if pr.Status == models.PRStatusMerged && pr.MergedAt != nil && pr.MergedAt.Before(mergedBefore) {
+			prs = append(prs, pr)
+		}
+	}
+	sort.Slice(prs, func(i, j int) bool {
+		if !prs[i].MergedAt.Equal(*prs[j].MergedAt) {
+			return prs[i].MergedAt.Before(*prs[j].MergedAt)
+		}
+		return prs[i].Id < prs[j].Id
+	})
+	if len(prs) > limit {
+		prs = prs[:limit]
+	}
+
+	var prIDs []string
+	for _, pr := range prs {
+		st.archivedPRs[pr.Id] = pr
+		if assignments := st.assignments[pr.Id]; assignments != nil {
+			st.archivedAssignments[pr.Id] = assignments
+			for reviewerID := range assignments {
+				st.reviewsChanged(reviewerID)
+			}
+		}
+		delete(st.prs, pr.Id)
+		delete(st.assignments, pr.Id)
+		prIDs = append(prIDs, pr.Id)
+	}
+	return prIDs, nil
+}
+
+// Restore moves an archived PR with its reviewers back to the main data and reports whether it
+// was archived. Returns PR_EXISTS AppError when a PR with the same id was created since.
+func (r *ArchiveRepository) Restore(ctx context.Context, prID string) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+
+	pr, ok := st.archivedPRs[prID]
+	if !ok {
+		return false, nil
+	}
+	if _, ok = st.prs[prID]; ok {
+		return false, domainErrors.NewPRExists("PR id already exists")
+	}
+	st.prs[prID] = pr
+	if assignments := st.archivedAssignments[prID]; assignments != nil {
+		st.assignments[prID] = assignments
+		for reviewerID := range assignments {
+			st.reviewsChanged(reviewerID)
+		}
+	}
+	delete(st.archivedPRs, prID)
+	delete(st.archivedAssignments, prID)
+	return true, nil
+}
@@ -0,0 +1,179 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// DumpRepository reads the data of the organization of the context in memory for an export and
+// writes it back for an import.
+type DumpRepository struct {
+	s *Storage
+}
+
+// EachTeam calls fn for the metadata of every team, ordered by name.
+func (r *DumpRepository) EachTeam(ctx context.Context, fn func(*models.TeamRecord) error) error {
+	r.s.mu.Lock()
+	st := r.s.org(ctx)
+	teams := make([]*models.TeamRecord, 0, len(st.teams))
+	for name, team := range st.teams {
+		teams = append(teams, &models.TeamRecord{
+			Name: name, Description: team.Description, LeadId: team.LeadId, CreatedAt: team.CreatedAt,
+		})
+	}
+	r.s.mu.Unlock()
+	sort.Slice(teams, func(i, j int) bool { return teams[i].Name < teams[j].Name })
+	return each(teams, fn)
+}
+
+// EachUser calls fn for every user, ordered by id.
+func (r *DumpRepository) EachUser(ctx context.Context, fn func(*models.User) error) error {
+	r.s.mu.Lock()
+	st := r.s.org(ctx)
+	users := make([]*models.User, 0, len(st.users))
+	for _, user := range st.users {
+		users = append(users, copyUser(user))
+	}
+	r.s.mu.Unlock()
+	sort.Slice(users, func(i, j int) bool { return users[i].Id < users[j].Id })
+	return each(users, fn)
+}
+
+// EachPullRequest calls fn for every PR that is not archived, ordered by id, without reviewers.
+func (r *DumpRepository) EachPullRequest(ctx context.Context, fn func(*models.PullRequest) error) error {
+	r.s.mu.Lock()
+	st := r.s.org(ctx)
+	prs := make([]*models.PullRequest, 0, len(st.prs))
+	for _, pr := range st.prs {
+		prs = append(prs, copyPR(pr))
+	}
+	r.s.mu.Unlock()
+	sort.Slice(prs, func(i, j int) bool { return prs[i].Id < prs[j].Id })
+	return each(prs, fn)
+}
+
+// EachAssignment calls fn for every reviewer assignment of PRs that are not archived, ordered by
+// PR id and reviewer id.
+func (r *DumpRepository) EachAssignment(ctx context.Context, fn func(*models.ReviewAssignment) error) error {
+	r.s.mu.Lock()
+	var assignments []*models.ReviewAssignment
+	for _, byReviewer := range r.s.org(ctx).assignments {
+		for _, assignment := range byReviewer {
+			assignments = append(assignments, copyAssignment(assignment))
+		}
+	}
+	r.s.mu.Unlock()
+	sort.Slice(assignments, func(i, j int) bool {
+		if assignments[i].PRId != assignments[j].PRId {
+			return assignments[i].PRId < assignments[j].PRId
+		}
+		return assignments[i].ReviewerId < assignments[j].ReviewerId
+	})
+	return each(assignments, fn)
+}
+
+// IsEmpty reports whether no users, teams or PRs, archived or not, are stored.
+func (r *DumpRepository) IsEmpty(ctx context.Context) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	return len(st.users) == 0 && len(st.teams) == 0 && len(st.prs) == 0 && len(st.archivedPRs) == 0, nil
+}
+
+// Clear deletes all users, teams, PRs and everything about them of the organization; webhook
+// deliveries and statistics snapshots are kept, as are the changes of review lists, to which the
+// emptied lists are added.
+func (r *DumpRepository) Clear(ctx context.Context) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	cleared := newOrgState()
+	cleared.snapshots = st.snapshots
+	cleared.reviewsChangedAt = st.reviewsChangedAt
+	cleared.deliveries, cleared.attempts = st.deliveries, st.attempts
+	for _, assignment := range st.allAssignments() {
+		cleared.reviewsChanged(assignment.ReviewerId)
+	}
+	r.s.state.orgs[orgctx.ID(ctx)] = cleared
+	return nil
+}
+
+// InsertUsers adds the users, which must not exist yet.
+func (r *DumpRepository) InsertUsers(ctx context.Context, users []*models.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	for _, user := range users {
+		if _, ok := st.users[user.Id]; ok {
+			return fmt.Errorf("failed to insert users: user %s already exists", user.Id)
+		}
+		st.users[user.Id] = copyUser(user)
+	}
+	return nil
+}
+
+// InsertTeams adds the metadata of the teams, which must not exist yet.
+func (r *DumpRepository) InsertTeams(ctx context.Context, teams []*models.TeamRecord) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	for _, team := range teams {
+		if _, ok := st.teams[team.Name]; ok {
+			return fmt.Errorf("failed to insert teams: team %s already exists", team.Name)
+		}
+		if team.LeadId != "" {
+			if _, ok := st.users[team.LeadId]; !ok {
+				return fmt.Errorf("failed to insert teams: unknown lead %s", team.LeadId)
+			}
+		}
+		st.teams[team.Name] = &models.Team{
+			Description: team.Description, LeadId: team.LeadId, CreatedAt: team.CreatedAt,
+		}
+	}
+	return nil
+}
+
+// InsertPullRequests adds the PRs without reviewers, which must not exist yet.
+func (r *DumpRepository) InsertPullRequests(ctx context.Context, prs []*models.PullRequest) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	for _, pr := range prs {
+		if _, ok := st.prs[pr.Id]; ok {
+			return fmt.Errorf("failed to insert pull requests: PR %s already exists", pr.Id)
+		}
+		if _, ok := st.users[pr.AuthorId]; !ok {
+			return fmt.Errorf("failed to insert pull requests: unknown author %s", pr.AuthorId)
+		}
+		st.prs[pr.Id] = copyPR(pr)
+	}
+	return nil
+}
+
+// InsertAssignments adds the assignments as they are, keeping their source and state.
+func (r *DumpRepository) InsertAssignments(ctx context.Context, assignments []*models.ReviewAssignment) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	for _, assignment := range assignments {
+		if err := st.assign(assignment.PRId, assignment.ReviewerId, assignment.Source, true); err != nil {
+			return err
+		}
+		st.assignments[assignment.PRId][assignment.ReviewerId] = copyAssignment(assignment)
+	}
+	return nil
+}
+
+// each calls fn for the records, outside the lock, stopping at its first error.
+func each[T any](records []T, fn func(T) error) error {
+	for _, record := range records {
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// DuplicateRepository looks for near-duplicate identifiers in memory.
+type DuplicateRepository struct {
+	s *Storage
+}
+
+// FindNearDuplicates returns the groups of user ids, team names and PR ids, archived PRs included,
+// that are equal once trimmed and folded to lower case, ordered by kind and key.
+func (r *DuplicateRepository) FindNearDuplicates(ctx context.Context) ([]models.NearDuplicate, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+
+	ids := map[string]map[string]bool{
+		models.IdentifierKindUser:        {},
+		models.IdentifierKindTeam:        {},
+		models.IdentifierKindPullRequest: {},
+	}
+	for id, user := range st.users {
+		ids[models.IdentifierKindUser][id] = true
+		ids[models.IdentifierKindTeam][user.TeamName] = true
+	}
+	for name := range st.teams {
+		ids[models.IdentifierKindTeam][name] = true
+	}
+	for id := range st.prs {
+		ids[models.IdentifierKindPullRequest][id] = true
+	}
+	for id := range st.archivedPRs {
+		ids[models.IdentifierKindPullRequest][id] = true
+	}
+
+	var duplicates []models.NearDuplicate
+	for kind, kindIds := range ids {
+		byKey := make(map[string][]string)
+		for id := range kindIds {
+			key := models.DuplicateKey(id)
+			byKey[key] = append(byKey[key], id)
+		}
+		for key, group := range byKey {
+			if len(group) > 1 {
+				slices.Sort(group)
+				duplicates = append(duplicates, models.NearDuplicate{Kind: kind, Key: key, Ids: group})
+			}
+		}
+	}
+	slices.SortFunc(duplicates, func(a, b models.NearDuplicate) int {
+		if c := strings.Compare(a.Kind, b.Kind); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Key, b.Key)
+	})
+	return duplicates, nil
+}
@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// ExclusionRepository manages reviewer exclusions in memory.
+type ExclusionRepository struct {
+	s *Storage
+}
+
+// Upsert creates the exclusion or updates whether it is mutual. CreatedAt is filled by the repository.
+func (r *ExclusionRepository) Upsert(ctx context.Context, exclusion *models.ReviewerExclusion) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	if exclusion.ReviewerId == exclusion.AuthorId {
+		return fmt.Errorf("failed to upsert exclusion: reviewer and author must differ")
+	}
+	for _, userID := range []string{exclusion.ReviewerId, exclusion.AuthorId} {
+		if _, ok := st.users[userID]; !ok {
+			return fmt.Errorf("failed to upsert exclusion: unknown user %s", userID)
+		}
+	}
+	key := [2]string{exclusion.ReviewerId, exclusion.AuthorId}
+	if existing, ok := st.exclusions[key]; ok {
+		existing.Mutual = exclusion.Mutual
+		exclusion.CreatedAt = existing.CreatedAt
+		return nil
+	}
+	exclusion.CreatedAt = time.Now().UTC()
+	cp := *exclusion
+	st.exclusions[key] = &cp
+	return nil
+}
+
+// Delete removes the exclusion of the reviewer for the author, including a mutual one
+// stored the other way round. Returns the number of removed exclusions.
+func (r *ExclusionRepository) Delete(ctx context.Context, reviewerID, authorID string) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	removed := 0
+	if _, ok := st.exclusions[[2]string{reviewerID, authorID}]; ok {
+		delete(st.exclusions, [2]string{reviewerID, authorID})
+		removed++
+	}
+	reversed := [2]string{authorID, reviewerID}
+	if exclusion, ok := st.exclusions[reversed]; ok && exclusion.Mutual {
+		delete(st.exclusions, reversed)
+		removed++
+	}
+	return removed, nil
+}
+
+// List returns exclusions involving the user, or all of them when userID is empty,
+// ordered by reviewer and author.
+func (r *ExclusionRepository) List(ctx context.Context, userID string) ([]*models.ReviewerExclusion, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var exclusions []*models.ReviewerExclusion
+	for _, exclusion := range r.s.org(ctx).exclusions {
+		if userID == "" || exclusion.ReviewerId == userID || exclusion.AuthorId == userID {
+			cp := *exclusion
+			exclusions = append(exclusions, &cp)
+		}
+	}
+	sort.Slice(exclusions, func(i, j int) bool {
+		if exclusions[i].ReviewerId != exclusions[j].ReviewerId {
+			return exclusions[i].ReviewerId < exclusions[j].ReviewerId
+		}
+		return exclusions[i].AuthorId < exclusions[j].AuthorId
+	})
+	return exclusions, nil
+}
@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// MetricsRepository reads the counts behind the business metrics from memory.
+type MetricsRepository struct {
+	s *Storage
+}
+
+// GetBusinessMetrics counts the open PRs of every team, with and without reviewers, the active users
+// and the assignments on open PRs. The counts cover all organizations, those of the teams sharing a
+// name summed.
+func (r *MetricsRepository) GetBusinessMetrics(ctx context.Context) (*models.BusinessMetrics, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	metrics := &models.BusinessMetrics{}
+	byTeam := make(map[string]*models.TeamPRCounts)
+	for _, st := range r.s.state.orgs {
+		for id, pr := range st.prs {
+			if pr.Status != models.PRStatusOpen {
+				continue
+			}
+			teamName := ""
+			if author, ok := st.users[pr.AuthorId]; ok {
+				teamName = author.TeamName
+			}
+			counts, ok := byTeam[teamName]
+			if !ok {
+				counts = &models.TeamPRCounts{TeamName: teamName}
+				byTeam[teamName] = counts
+			}
+			counts.OpenPRs++
+			if len(st.assignments[id]) == 0 {
+				counts.WithoutReviewers++
+			}
+			metrics.ActiveAssignments += len(st.assignments[id])
+		}
+
+		for _, user := range st.users {
+			if user.IsActive {
+				metrics.ActiveUsers++
+			}
+		}
+	}
+	for _, counts := range byTeam {
+		metrics.Teams = append(metrics.Teams, *counts)
+	}
+	sort.Slice(metrics.Teams, func(i, j int) bool { return metrics.Teams[i].TeamName < metrics.Teams[j].TeamName })
+	return metrics, nil
+}
@@ -0,0 +1,28 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+)
+
+// OrgRepository lists the organizations sharing the storage.
+type OrgRepository struct {
+	s *Storage
+}
+
+// ListOrgs returns the organizations that have users, teams, PRs or pending webhook deliveries, and the
+// default one, ordered by id.
+func (r *OrgRepository) ListOrgs(ctx context.Context) ([]string, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	orgs := []string{orgctx.DefaultID}
+	for orgID, st := range r.s.state.orgs {
+		if orgID != orgctx.DefaultID && (len(st.teams) > 0 || len(st.users) > 0 || len(st.prs) > 0 || st.hasPending()) {
+			orgs = append(orgs, orgID)
+		}
+	}
+	slices.Sort(orgs)
+	return orgs, nil
+}
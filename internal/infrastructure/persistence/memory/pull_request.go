@@ -0,0 +1,514 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// PullRequestRepository manages pull requests in memory.
+type PullRequestRepository struct {
+	s *Storage
+}
+
+// Create creates a new Pull Request.
+// Returns PR_EXISTS AppError when a PR with the same id already exists.
+func (r *PullRequestRepository) Create(ctx context.Context, pr *models.PullRequest) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	if _, ok := st.prs[pr.Id]; ok {
+		return domainErrors.NewPRExists("PR id already exists")
+	}
+	st.prs[pr.Id] = copyPR(pr)
+	return nil
+}
+
+// CreateBatch creates the PRs and returns ids of the created ones, skipping PRs whose id already exists.
+func (r *PullRequestRepository) CreateBatch(ctx context.Context, prs []*models.PullRequest) ([]string, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	created := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		if _, ok := st.prs[pr.Id]; ok {
+			continue
+		}
+		st.prs[pr.Id] = copyPR(pr)
+		created = append(created, pr.Id)
+	}
+	return created, nil
+}
+
+// FindByID finds PR by ID.
+func (r *PullRequestRepository) FindByID(ctx context.Context, prID string) (*models.PullRequest, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	pr, ok := r.s.org(ctx).prs[prID]
+	if !ok {
+		return nil, nil
+	}
+	return copyPR(pr), nil
+}
+
+// Exists checks if a PR exists by ID.
+func (r *PullRequestRepository) Exists(ctx context.Context, prID string) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	_, ok := r.s.org(ctx).prs[prID]
+	return ok, nil
+}
+
+// UpdateStatus moves the PR from fromStatus to status and returns the number of updated rows,
+// which is zero when the PR is not in fromStatus.
+func (r *PullRequestRepository) UpdateStatus(ctx context.Context, prID, fromStatus, status string,
+	mergedAt *time.Time) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	pr, ok := r.s.org(ctx).prs[prID]
+	if !ok || pr.Status != fromStatus {
+		return 0, nil
+	}
+	pr.Status = status
+	pr.MergedAt = mergedAt
+	pr.UpdatedAt = time.Now().UTC()
+	return 1, nil
+}
+
+// SetLabels replaces the labels of a PR.
+func (r *PullRequestRepository) SetLabels(ctx context.Context, prID string, labels []string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if pr, ok := r.s.org(ctx).prs[prID]; ok {
+		pr.Labels = append([]string{}, labels...)
+		pr.UpdatedAt = time.Now().UTC()
+	}
+	return nil
+}
+
+// ClearAssignmentSkipped marks the reviewer assignment of a PR as no longer skipped.
+func (r *PullRequestRepository) ClearAssignmentSkipped(ctx context.Context, prID string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if pr, ok := r.s.org(ctx).prs[prID]; ok && pr.AssignmentSkipped {
+		pr.AssignmentSkipped = false
+		pr.UpdatedAt = time.Now().UTC()
+	}
+	return nil
+}
+
+// FindByReviewer finds all PR, where the user is assigned as a reviewer, newest first.
+func (r *PullRequestRepository) FindByReviewer(ctx context.Context, reviewerID string) ([]*models.PullRequest, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	return st.filterPRs(func(pr *models.PullRequest) bool {
+		_, ok := st.assignments[pr.Id][reviewerID]
+		return ok
+	}, newestFirst), nil
+}
+
+// GetAllPRs returns all pull requests, or those authored by members of a team unless teamName is
+// empty, newest first.
+func (r *PullRequestRepository) GetAllPRs(ctx context.Context, teamName string) ([]*models.PullRequest, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	return st.filterPRs(st.authoredByTeam(teamName), newestFirst), nil
+}
+
+// GetArchivedPRs gets all archived PRs, or those authored by members of a team unless teamName is
+// empty, newest first.
+func (r *PullRequestRepository) GetArchivedPRs(ctx context.Context, teamName string) ([]*models.PullRequest, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	authoredByTeam := st.authoredByTeam(teamName)
+	var prs []*models.PullRequest
+	for _, pr := range st.archivedPRs {
+		if authoredByTeam(pr) {
+			prs = append(prs, copyPR(pr))
+		}
+	}
+	sort.Slice(prs, func(i, j int) bool { return newestFirst(prs[i], prs[j]) })
+	return prs, nil
+}
+
+// GetAuthorStats counts the PRs of every author and their reviewers, adding archived PRs with
+// includeArchived, the most prolific authors first. Unless teamName is empty only the members of the
+// team are counted; otherwise authors who are not known users are kept with a nil username.
+func (r *PullRequestRepository) GetAuthorStats(ctx context.Context, teamName string,
+	includeArchived bool) ([]*models.AuthorStats, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	byAuthor := make(map[string]*models.AuthorStats)
+	count := func(pr *models.PullRequest, reviewers int) {
+		if pr.AuthorId == "" || !st.inTeam(pr.AuthorId, teamName) {
+			return
+		}
+		stat, ok := byAuthor[pr.AuthorId]
+		if !ok {
+			stat = &models.AuthorStats{AuthorId: pr.AuthorId}
+			if user, known := st.users[pr.AuthorId]; known {
+				username := user.Name
+				stat.Username = &username
+			}
+			byAuthor[pr.AuthorId] = stat
+		}
+		stat.TotalPRs++
+		switch pr.Status {
+		case models.PRStatusOpen:
+			stat.OpenPRs++
+		case models.PRStatusMerged:
+			stat.MergedPRs++
+		}
+		stat.Reviewers += reviewers
+	}
+	for id, pr := range st.prs {
+		count(pr, len(st.assignments[id]))
+	}
+	if includeArchived {
+		for id, pr := range st.archivedPRs {
+			count(pr, len(st.archivedAssignments[id]))
+		}
+	}
+
+	stats := make([]*models.AuthorStats, 0, len(byAuthor))
+	for _, stat := range byAuthor {
+		stats = append(stats, stat)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].TotalPRs != stats[j].TotalPRs {
+			return stats[i].TotalPRs > stats[j].TotalPRs
+		}
+		return stats[i].AuthorId < stats[j].AuthorId
+	})
+	return stats, nil
+}
+
+// GetAuthorStatsByID counts the PRs of an author and their reviewers, adding archived PRs with
+// includeArchived. The counts are zero for a user who authored no PRs; the username is not read.
+func (r *PullRequestRepository) GetAuthorStatsByID(ctx context.Context, authorID string,
+	includeArchived bool) (*models.AuthorStats, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	stat := &models.AuthorStats{AuthorId: authorID}
+	count := func(prs map[string]*models.PullRequest, assignments map[string]map[string]*models.ReviewAssignment) {
+		for id, pr := range prs {
+			if pr.AuthorId != authorID {
+				continue
+			}
+			stat.TotalPRs++
+			switch pr.Status {
+			case models.PRStatusOpen:
+				stat.OpenPRs++
+			case models.PRStatusMerged:
+				stat.MergedPRs++
+			}
+			stat.Reviewers += len(assignments[id])
+		}
+	}
+	count(st.prs, st.assignments)
+	if includeArchived {
+		count(st.archivedPRs, st.archivedAssignments)
+	}
+	return stat, nil
+}
+
+// GetActivity counts the PRs created and merged and the reviewer changes made within [from, to) in
+// buckets of the given size, archived PRs included, counting only the PRs authored by members of a
+// team unless teamName is empty. Buckets without any of them are absent; the others are ordered by
+// start.
+func (r *PullRequestRepository) GetActivity(ctx context.Context, teamName, bucket string,
+	from, to time.Time) ([]*models.ActivityBucket, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	byStart := make(map[time.Time]*models.ActivityBucket)
+	count := func(at time.Time, field func(b *models.ActivityBucket) *int) {
+		if at.Before(from) || !at.Before(to) {
+			return
+		}
+		start := models.BucketStart(at, bucket)
+		b, ok := byStart[start]
+		if !ok {
+			b = &models.ActivityBucket{Start: start}
+			byStart[start] = b
+		}
+		*field(b)++
+	}
+	created := func(b *models.ActivityBucket) *int { return &b.Created }
+	merged := func(b *models.ActivityBucket) *int { return &b.Merged }
+	authoredByTeam := st.authoredByTeam(teamName)
+	for _, prs := range []map[string]*models.PullRequest{st.prs, st.archivedPRs} {
+		for _, pr := range prs {
+			if !authoredByTeam(pr) {
+				continue
+			}
+			count(pr.CreatedAt, created)
+			if pr.MergedAt != nil {
+				count(*pr.MergedAt, merged)
+			}
+		}
+	}
+	for _, change := range st.history {
+		if !st.prAuthoredByTeam(change.PRId, teamName) {
+			continue
+		}
+		count(change.ChangedAt, func(b *models.ActivityBucket) *int { return &b.Reassignments })
+	}
+
+	buckets := make([]*models.ActivityBucket, 0, len(byStart))
+	for _, b := range byStart {
+		buckets = append(buckets, b)
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Start.Before(buckets[j].Start) })
+	return buckets, nil
+}
+
+// FindOpenPRsByReviewers finds all open PRs where any of the specified reviewers is assigned, newest first.
+func (r *PullRequestRepository) FindOpenPRsByReviewers(ctx context.Context, reviewerIDs []string) ([]*models.PullRequest, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	return st.filterPRs(func(pr *models.PullRequest) bool {
+		if pr.Status != models.PRStatusOpen {
+			return false
+		}
+		for _, reviewerID := range reviewerIDs {
+			if _, ok := st.assignments[pr.Id][reviewerID]; ok {
+				return true
+			}
+		}
+		return false
+	}, newestFirst), nil
+}
+
+// FindOpenPRsByAuthors finds all open PRs authored by any of the specified users, newest first.
+func (r *PullRequestRepository) FindOpenPRsByAuthors(ctx context.Context, authorIDs []string) ([]*models.PullRequest, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return r.s.org(ctx).filterPRs(func(pr *models.PullRequest) bool {
+		return pr.Status == models.PRStatusOpen && slices.Contains(authorIDs, pr.AuthorId)
+	}, newestFirst), nil
+}
+
+// SearchByTitle finds PRs whose title contains the query (case-insensitive), optionally filtered by status
+// and labels. An empty status matches all PRs; a PR matches the labels when it has all of them.
+// Results are ordered by order, by creation time, newest first, when it is unset.
+func (r *PullRequestRepository) SearchByTitle(ctx context.Context, query, status string, labels []string,
+	order models.PRSort, limit, offset int) ([]*models.PullRequest, error) {
+	less, err := prOrder(order, newestFirst)
+	if err != nil {
+		return nil, err
+	}
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	prs := r.s.org(ctx).filterPRs(matchesTitle(query, status, labels), less)
+	return truncate(prs, limit, offset), nil
+}
+
+// CountByTitle counts PRs matching SearchByTitle with the same query, status and labels.
+func (r *PullRequestRepository) CountByTitle(ctx context.Context, query, status string, labels []string) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return len(r.s.org(ctx).filterPRs(matchesTitle(query, status, labels), newestFirst)), nil
+}
+
+// matchesTitle returns the filter of SearchByTitle.
+func matchesTitle(query, status string, labels []string) func(pr *models.PullRequest) bool {
+	query = strings.ToLower(query)
+	return func(pr *models.PullRequest) bool {
+		return strings.Contains(strings.ToLower(pr.Title), query) &&
+			(status == "" || pr.Status == status) && hasAllLabels(pr, labels)
+	}
+}
+
+// FindOpenPRsReviewedByTeam finds open PRs with reviewers from the team, ordered by order,
+// oldest first when it is unset. ReviewersId of each PR holds only the reviewers that belong to the team.
+func (r *PullRequestRepository) FindOpenPRsReviewedByTeam(ctx context.Context, teamName string,
+	order models.PRSort) ([]*models.PullRequest, error) {
+	less, err := prOrder(order, oldestFirst)
+	if err != nil {
+		return nil, err
+	}
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	teamReviewers := func(pr *models.PullRequest) []string {
+		var reviewerIDs []string
+		for reviewerID := range st.assignments[pr.Id] {
+			if user, ok := st.users[reviewerID]; ok && user.TeamName == teamName {
+				reviewerIDs = append(reviewerIDs, reviewerID)
+			}
+		}
+		sort.Strings(reviewerIDs)
+		return reviewerIDs
+	}
+	prs := st.filterPRs(func(pr *models.PullRequest) bool {
+		return pr.Status == models.PRStatusOpen && len(teamReviewers(pr)) > 0
+	}, less)
+	for _, pr := range prs {
+		pr.ReviewersId = teamReviewers(pr)
+	}
+	return prs, nil
+}
+
+// FindOpenWithoutReviewers finds open PRs that have no assigned reviewers and carry all the labels,
+// ordered by order, oldest first when it is unset. Unless skipped is nil, it keeps only the PRs
+// whose reviewer assignment was skipped, or only the others; unless teamName is empty, only the PRs
+// authored by members of the team.
+func (r *PullRequestRepository) FindOpenWithoutReviewers(ctx context.Context, teamName string, labels []string,
+	skipped *bool, order models.PRSort, limit, offset int) ([]*models.PullRequest, error) {
+	less, err := prOrder(order, oldestFirst)
+	if err != nil {
+		return nil, err
+	}
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	prs := st.filterPRs(st.withoutReviewers(teamName, labels, skipped), less)
+	return truncate(prs, limit, offset), nil
+}
+
+// CountOpenWithoutReviewers counts the PRs found by FindOpenWithoutReviewers.
+func (r *PullRequestRepository) CountOpenWithoutReviewers(ctx context.Context, teamName string, labels []string,
+	skipped *bool) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	return len(st.filterPRs(st.withoutReviewers(teamName, labels, skipped), oldestFirst)), nil
+}
+
+// withoutReviewers returns the filter of FindOpenWithoutReviewers. The caller holds the lock.
+func (st *orgState) withoutReviewers(teamName string, labels []string, skipped *bool) func(pr *models.PullRequest) bool {
+	authoredByTeam := st.authoredByTeam(teamName)
+	return func(pr *models.PullRequest) bool {
+		return pr.Status == models.PRStatusOpen && len(st.assignments[pr.Id]) == 0 && hasAllLabels(pr, labels) &&
+			(skipped == nil || pr.AssignmentSkipped == *skipped) && authoredByTeam(pr)
+	}
+}
+
+// authoredByTeam returns a filter keeping the PRs authored by members of a team, or all PRs when
+// teamName is empty. The caller holds the lock.
+func (st *orgState) authoredByTeam(teamName string) func(pr *models.PullRequest) bool {
+	return func(pr *models.PullRequest) bool { return st.inTeam(pr.AuthorId, teamName) }
+}
+
+// inTeam reports whether the user is a member of a team, or true for any user when teamName is empty.
+// The caller holds the lock.
+func (st *orgState) inTeam(userID, teamName string) bool {
+	if teamName == "" {
+		return true
+	}
+	user, ok := st.users[userID]
+	return ok && user.TeamName == teamName
+}
+
+// prAuthoredByTeam reports whether the current or archived PR is authored by a member of a team, or
+// true for any PR when teamName is empty. The caller holds the lock.
+func (st *orgState) prAuthoredByTeam(prID, teamName string) bool {
+	pr, ok := st.prs[prID]
+	if !ok {
+		pr, ok = st.archivedPRs[prID]
+	}
+	return teamName == "" || ok && st.inTeam(pr.AuthorId, teamName)
+}
+
+// filterPRs returns copies of the PRs matching keep in the given order. The caller holds the lock.
+func (st *orgState) filterPRs(keep func(pr *models.PullRequest) bool,
+	less func(a, b *models.PullRequest) bool) []*models.PullRequest {
+	var prs []*models.PullRequest
+	for _, pr := range st.prs {
+		if keep(pr) {
+			prs = append(prs, copyPR(pr))
+		}
+	}
+	sort.Slice(prs, func(i, j int) bool { return less(prs[i], prs[j]) })
+	return prs
+}
+
+// newestFirst orders PRs by creation time descending, then by id.
+func newestFirst(a, b *models.PullRequest) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+	return a.Id < b.Id
+}
+
+// oldestFirst orders PRs by creation time, then by id.
+func oldestFirst(a, b *models.PullRequest) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+	return a.Id < b.Id
+}
+
+// prOrder returns the order by s with ties broken by id, or def when s is unset.
+// An unknown field is an error, as in the postgres repository.
+func prOrder(s models.PRSort, def func(a, b *models.PullRequest) bool) (func(a, b *models.PullRequest) bool, error) {
+	var compare func(a, b *models.PullRequest) int
+	switch s.Field {
+	case "":
+		return def, nil
+	case models.PRSortCreatedAt:
+		compare = func(a, b *models.PullRequest) int { return a.CreatedAt.Compare(b.CreatedAt) }
+	case models.PRSortUpdatedAt:
+		compare = func(a, b *models.PullRequest) int { return a.UpdatedAt.Compare(b.UpdatedAt) }
+	case models.PRSortTitle:
+		compare = func(a, b *models.PullRequest) int { return strings.Compare(a.Title, b.Title) }
+	case models.PRSortPriority:
+		compare = func(a, b *models.PullRequest) int {
+			return models.PriorityRank(a.Priority) - models.PriorityRank(b.Priority)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported sort field %q", s.Field)
+	}
+	return func(a, b *models.PullRequest) bool {
+		c := compare(a, b)
+		if s.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c < 0
+		}
+		return a.Id < b.Id
+	}, nil
+}
+
+// hasAllLabels reports whether the PR carries every label.
+func hasAllLabels(pr *models.PullRequest, labels []string) bool {
+	for _, label := range labels {
+		found := false
+		for _, own := range pr.Labels {
+			if own == label {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// truncate applies OFFSET and LIMIT.
+func truncate[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return nil
+	}
+	items = items[offset:]
+	if limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
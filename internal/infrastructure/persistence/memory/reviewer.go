@@ -0,0 +1,644 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// ReviewerRepository manages reviewers in memory.
+type ReviewerRepository struct {
+	s *Storage
+}
+
+// AssignReviewer assigns a reviewer to a PR from the given source. Assigning an assigned reviewer again does nothing.
+func (r *ReviewerRepository) AssignReviewer(ctx context.Context, prID, reviewerID, source string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return r.s.org(ctx).assign(prID, reviewerID, source, false)
+}
+
+// assign adds a pending assignment from the source. An existing one is kept, or is an error with failOnExisting.
+// A PR at the reviewer cap gets TOO_MANY_REVIEWERS AppError. The caller holds the lock.
+func (st *orgState) assign(prID, reviewerID, source string, failOnExisting bool) error {
+	if _, ok := st.prs[prID]; !ok {
+		return fmt.Errorf("failed to assign reviewer: unknown PR %s", prID)
+	}
+	if _, ok := st.users[reviewerID]; !ok {
+		return fmt.Errorf("failed to assign reviewer: unknown user %s", reviewerID)
+	}
+	if st.assignments[prID] == nil {
+		st.assignments[prID] = make(map[string]*models.ReviewAssignment)
+	}
+	if _, ok := st.assignments[prID][reviewerID]; ok {
+		if failOnExisting {
+			return fmt.Errorf("failed to assign new reviewer: %s is already assigned to %s", reviewerID, prID)
+		}
+		return nil
+	}
+	if len(st.assignments[prID]) >= models.MaxReviewers {
+		return domainErrors.NewTooManyReviewers(fmt.Sprintf("PR already has %d reviewers", models.MaxReviewers))
+	}
+	st.assignments[prID][reviewerID] = &models.ReviewAssignment{
+		PRId:       prID,
+		ReviewerId: reviewerID,
+		AssignedAt: time.Now().UTC(),
+		Source:     source,
+		State:      models.ReviewStatePending,
+	}
+	st.reviewsChanged(reviewerID)
+	return nil
+}
+
+// unassign removes an assignment if there is one. The caller holds the lock.
+func (st *orgState) unassign(prID, reviewerID string) {
+	if _, ok := st.assignments[prID][reviewerID]; ok {
+		delete(st.assignments[prID], reviewerID)
+		st.reviewsChanged(reviewerID)
+	}
+}
+
+// reviewsChanged records that the assignments of the reviewer changed now. The caller holds the lock.
+func (st *orgState) reviewsChanged(reviewerID string) {
+	st.reviewsChangedAt[reviewerID] = time.Now().UTC()
+}
+
+// GetReviewers gets all reviewers assigned to a PR ordered by id.
+func (r *ReviewerRepository) GetReviewers(ctx context.Context, prID string) ([]string, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return r.s.org(ctx).reviewers(prID), nil
+}
+
+// reviewers returns the sorted reviewer ids of the PR, nil when there are none. The caller holds the lock.
+func (st *orgState) reviewers(prID string) []string {
+	var reviewerIDs []string
+	for reviewerID := range st.assignments[prID] {
+		reviewerIDs = append(reviewerIDs, reviewerID)
+	}
+	sort.Strings(reviewerIDs)
+	return reviewerIDs
+}
+
+// GetPRsByReviewer gets all PRs assigned to a reviewer ordered by id.
+func (r *ReviewerRepository) GetPRsByReviewer(ctx context.Context, reviewerID string) ([]string, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var prIDs []string
+	for prID, byReviewer := range r.s.org(ctx).assignments {
+		if _, ok := byReviewer[reviewerID]; ok {
+			prIDs = append(prIDs, prID)
+		}
+	}
+	sort.Strings(prIDs)
+	return prIDs, nil
+}
+
+// GetReviewersByPRs gets reviewers of the given PRs keyed by PR ID.
+// PRs without reviewers are absent from the map.
+func (r *ReviewerRepository) GetReviewersByPRs(ctx context.Context, prIDs []string) (map[string][]string, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	reviewers := make(map[string][]string)
+	for _, prID := range prIDs {
+		if reviewerIDs := r.s.org(ctx).reviewers(prID); len(reviewerIDs) > 0 {
+			reviewers[prID] = reviewerIDs
+		}
+	}
+	return reviewers, nil
+}
+
+// GetOpenReviewCounts counts open PRs assigned to each of the given users.
+// Users without open reviews are absent from the map.
+func (r *ReviewerRepository) GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	counts := make(map[string]int)
+	for _, assignment := range r.s.org(ctx).openAssignments() {
+		if contains(userIDs, assignment.ReviewerId) {
+			counts[assignment.ReviewerId]++
+		}
+	}
+	return counts, nil
+}
+
+// GetAssignmentTimes gets the times of current assignments of the given users made since the given moment.
+// Users without such assignments are absent from the map.
+func (r *ReviewerRepository) GetAssignmentTimes(ctx context.Context, userIDs []string,
+	since time.Time) (map[string][]time.Time, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	wanted := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		wanted[userID] = true
+	}
+	times := make(map[string][]time.Time)
+	for _, byReviewer := range r.s.org(ctx).assignments {
+		for reviewerID, assignment := range byReviewer {
+			if wanted[reviewerID] && !assignment.AssignedAt.Before(since) {
+				times[reviewerID] = append(times[reviewerID], assignment.AssignedAt)
+			}
+		}
+	}
+	for _, userTimes := range times {
+		sort.Slice(userTimes, func(i, j int) bool { return userTimes[i].Before(userTimes[j]) })
+	}
+	return times, nil
+}
+
+// GetAssignmentsByPRs gets reviewer assignments of the given PRs ordered by PR ID and reviewer ID.
+func (r *ReviewerRepository) GetAssignmentsByPRs(ctx context.Context, prIDs []string) ([]*models.ReviewAssignment, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var assignments []*models.ReviewAssignment
+	for _, assignment := range r.s.org(ctx).allAssignments() {
+		if contains(prIDs, assignment.PRId) {
+			assignments = append(assignments, assignment)
+		}
+	}
+	return assignments, nil
+}
+
+// FindOpenAssignments gets assignments on open PRs made before the given moment,
+// ordered by reviewer ID and assignment time.
+func (r *ReviewerRepository) FindOpenAssignments(ctx context.Context, assignedBefore time.Time) ([]*models.ReviewAssignment, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var assignments []*models.ReviewAssignment
+	for _, assignment := range r.s.org(ctx).openAssignments() {
+		if assignment.AssignedAt.Before(assignedBefore) {
+			assignments = append(assignments, assignment)
+		}
+	}
+	sort.SliceStable(assignments, func(i, j int) bool {
+		if assignments[i].ReviewerId != assignments[j].ReviewerId {
+			return assignments[i].ReviewerId < assignments[j].ReviewerId
+		}
+		return assignments[i].AssignedAt.Before(assignments[j].AssignedAt)
+	})
+	return assignments, nil
+}
+
+// FindAssignmentsAfter gets up to limit of the reviewer's assignments on open PRs that come after
+// the cursor, in cursor order.
+func (r *ReviewerRepository) FindAssignmentsAfter(ctx context.Context, reviewerID string,
+	after models.AssignmentCursor, limit int) ([]*models.ReviewAssignment, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var assignments []*models.ReviewAssignment
+	for _, assignment := range r.s.org(ctx).openAssignments() {
+		if assignment.ReviewerId == reviewerID && after.After(assignment) {
+			assignments = append(assignments, assignment)
+		}
+	}
+	sort.Slice(assignments, func(i, j int) bool {
+		return models.AssignmentCursor{AssignedAt: assignments[i].AssignedAt, PRId: assignments[i].PRId}.
+			After(assignments[j])
+	})
+	if len(assignments) > limit {
+		assignments = assignments[:limit]
+	}
+	return assignments, nil
+}
+
+// FindStaleAssignments gets up to limit pending assignments made before the given moment
+// on open PRs nobody has approved, oldest first.
+func (r *ReviewerRepository) FindStaleAssignments(ctx context.Context, assignedBefore time.Time,
+	limit int) ([]*models.ReviewAssignment, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	approved := make(map[string]bool)
+	for _, assignment := range st.allAssignments() {
+		if assignment.State == models.ReviewStateApproved {
+			approved[assignment.PRId] = true
+		}
+	}
+	var assignments []*models.ReviewAssignment
+	for _, assignment := range st.openAssignments() {
+		if assignment.State == models.ReviewStatePending && assignment.AssignedAt.Before(assignedBefore) &&
+			!approved[assignment.PRId] {
+			assignments = append(assignments, assignment)
+		}
+	}
+	sort.SliceStable(assignments, func(i, j int) bool {
+		return assignments[i].AssignedAt.Before(assignments[j].AssignedAt)
+	})
+	return truncate(assignments, limit, 0), nil
+}
+
+// LockAssignment gets the assignment of a reviewer to a PR. Returns nil if the reviewer is not assigned.
+// Transactions are serialized, so nothing has to be locked.
+func (r *ReviewerRepository) LockAssignment(ctx context.Context, prID, reviewerID string) (*models.ReviewAssignment, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	assignment, ok := r.s.org(ctx).assignments[prID][reviewerID]
+	if !ok {
+		return nil, nil
+	}
+	return copyAssignment(assignment), nil
+}
+
+// SetReviewState records the review state of a reviewer on a PR.
+func (r *ReviewerRepository) SetReviewState(ctx context.Context, prID, reviewerID, state string, changedAt time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if assignment, ok := r.s.org(ctx).assignments[prID][reviewerID]; ok {
+		assignment.State = state
+		assignment.StateChangedAt = &changedAt
+	}
+	return nil
+}
+
+// IsAssigned checks if a reviewer is assigned to a PR.
+func (r *ReviewerRepository) IsAssigned(ctx context.Context, prID, reviewerID string) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	_, ok := r.s.org(ctx).assignments[prID][reviewerID]
+	return ok, nil
+}
+
+// ReplaceReviewer replaces an old reviewer with a new one from the given source for a PR.
+func (r *ReviewerRepository) ReplaceReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID, source string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	st.unassign(prID, oldReviewerID)
+	return st.assign(prID, newReviewerID, source, true)
+}
+
+// GetAllReviewers gets reviewers of all PRs, or of those authored by members of a team unless teamName
+// is empty, keyed by PR ID, each ordered by id. PRs without reviewers are absent from the map.
+func (r *ReviewerRepository) GetAllReviewers(ctx context.Context, teamName string) (map[string][]string, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	reviewers := make(map[string][]string, len(st.assignments))
+	for prID := range st.assignments {
+		if !st.prAuthoredByTeam(prID, teamName) {
+			continue
+		}
+		if reviewerIDs := st.reviewers(prID); len(reviewerIDs) > 0 {
+			reviewers[prID] = reviewerIDs
+		}
+	}
+	return reviewers, nil
+}
+
+// GetArchivedReviewers gets reviewers of all archived PRs, or of those authored by members of a team
+// unless teamName is empty, keyed by PR ID, each ordered by id. PRs without reviewers are absent from the map.
+func (r *ReviewerRepository) GetArchivedReviewers(ctx context.Context, teamName string) (map[string][]string, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	reviewers := make(map[string][]string, len(st.archivedAssignments))
+	for prID, byReviewer := range st.archivedAssignments {
+		if !st.prAuthoredByTeam(prID, teamName) {
+			continue
+		}
+		for reviewerID := range byReviewer {
+			reviewers[prID] = append(reviewers[prID], reviewerID)
+		}
+		sort.Strings(reviewers[prID])
+	}
+	return reviewers, nil
+}
+
+// GetAllReviewerCounts returns a map of reviewer IDs to their assignment counts, keeping only the
+// reviewers that are members of a team unless teamName is empty.
+func (r *ReviewerRepository) GetAllReviewerCounts(ctx context.Context, teamName string) (map[string]int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	counts := make(map[string]int)
+	for _, byReviewer := range st.assignments {
+		for reviewerID := range byReviewer {
+			if st.inTeam(reviewerID, teamName) {
+				counts[reviewerID]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+// GetAssignmentSourceCounts returns a map of assignment sources to the number of current assignments
+// made from them, counting only the PRs authored by members of a team unless teamName is empty.
+// Assignments of archived PRs are counted only with includeArchived.
+func (r *ReviewerRepository) GetAssignmentSourceCounts(ctx context.Context, teamName string,
+	includeArchived bool) (map[string]int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	counts := make(map[string]int)
+	add := func(assignments map[string]map[string]*models.ReviewAssignment) {
+		for prID, byReviewer := range assignments {
+			if !st.prAuthoredByTeam(prID, teamName) {
+				continue
+			}
+			for _, assignment := range byReviewer {
+				counts[assignment.Source]++
+			}
+		}
+	}
+	add(st.assignments)
+	if includeArchived {
+		add(st.archivedAssignments)
+	}
+	return counts, nil
+}
+
+// GetReviewTurnarounds returns a map of reviewer IDs to the turnaround of their current assignments
+// on merged PRs; a replaced or removed reviewer is no longer assigned, so their review is not counted.
+// Assignments of archived PRs are counted only with includeArchived. Unless teamName is empty, only
+// the reviewers that are members of the team are kept. Reviewers without such assignments are absent from the map.
+func (r *ReviewerRepository) GetReviewTurnarounds(ctx context.Context, teamName string,
+	includeArchived bool) (map[string]models.ReviewTurnaround, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	seconds := make(map[string][]float64)
+	add := func(prs map[string]*models.PullRequest, assignments map[string]map[string]*models.ReviewAssignment) {
+		for prID, byReviewer := range assignments {
+			pr, ok := prs[prID]
+			if !ok || pr.Status != models.PRStatusMerged || pr.MergedAt == nil {
+				continue
+			}
+			for reviewerID, assignment := range byReviewer {
+				if !st.inTeam(reviewerID, teamName) {
+					continue
+				}
+				seconds[reviewerID] = append(seconds[reviewerID], pr.MergedAt.Sub(assignment.AssignedAt).Seconds())
+			}
+		}
+	}
+	add(st.prs, st.assignments)
+	if includeArchived {
+		add(st.archivedPRs, st.archivedAssignments)
+	}
+
+	turnarounds := make(map[string]models.ReviewTurnaround, len(seconds))
+	for reviewerID, values := range seconds {
+		turnarounds[reviewerID] = turnaround(values)
+	}
+	return turnarounds, nil
+}
+
+// turnaround averages the review durations, in seconds, of a reviewer; values is not empty and gets sorted.
+func turnaround(values []float64) models.ReviewTurnaround {
+	sort.Float64s(values)
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	// the median is interpolated like percentile_cont(0.5)
+	median := (values[(len(values)-1)/2] + values[len(values)/2]) / 2
+	return models.ReviewTurnaround{AvgSeconds: sum / float64(len(values)), MedianSeconds: median}
+}
+
+// GetReviewerSummary counts the current assignments of a reviewer, those on open PRs, and the turnaround
+// of those on merged PRs. Assignments of archived PRs are counted only with includeArchived.
+func (r *ReviewerRepository) GetReviewerSummary(ctx context.Context, reviewerID string,
+	includeArchived bool) (*models.ReviewerSummary, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	summary := &models.ReviewerSummary{}
+	var seconds []float64
+	add := func(prs map[string]*models.PullRequest, assignments map[string]map[string]*models.ReviewAssignment) {
+		for prID, byReviewer := range assignments {
+			assignment, ok := byReviewer[reviewerID]
+			pr, known := prs[prID]
+			if !ok || !known {
+				continue
+			}
+			summary.Assignments++
+			if pr.Status == models.PRStatusOpen {
+				summary.ActiveReviews++
+			} else if pr.Status == models.PRStatusMerged && pr.MergedAt != nil {
+				seconds = append(seconds, pr.MergedAt.Sub(assignment.AssignedAt).Seconds())
+			}
+		}
+	}
+	add(st.prs, st.assignments)
+	if includeArchived {
+		add(st.archivedPRs, st.archivedAssignments)
+	}
+	if len(seconds) > 0 {
+		t := turnaround(seconds)
+		summary.Turnaround = &t
+	}
+	return summary, nil
+}
+
+// FindRecentAssignments gets up to limit of the current assignments of a reviewer, most recent first,
+// adding those of archived PRs with includeArchived.
+func (r *ReviewerRepository) FindRecentAssignments(ctx context.Context, reviewerID string,
+	includeArchived bool, limit int) ([]*models.ReviewAssignment, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	var assignments []*models.ReviewAssignment
+	add := func(all map[string]map[string]*models.ReviewAssignment) {
+		for _, byReviewer := range all {
+			if assignment, ok := byReviewer[reviewerID]; ok {
+				assignments = append(assignments, copyAssignment(assignment))
+			}
+		}
+	}
+	add(st.assignments)
+	if includeArchived {
+		add(st.archivedAssignments)
+	}
+	sort.Slice(assignments, func(i, j int) bool {
+		if !assignments[i].AssignedAt.Equal(assignments[j].AssignedAt) {
+			return assignments[i].AssignedAt.After(assignments[j].AssignedAt)
+		}
+		return assignments[i].PRId < assignments[j].PRId
+	})
+	if len(assignments) > limit {
+		assignments = assignments[:limit]
+	}
+	return assignments, nil
+}
+
+// GetReviewLoadHistogram counts the active users of a team, or of all teams when teamName is empty,
+// by their active reviews and total assignments; counts above lastBucket fall into lastBucket.
+func (r *ReviewerRepository) GetReviewLoadHistogram(ctx context.Context, teamName string,
+	lastBucket int) (*models.ReviewLoadHistogram, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	histogram := &models.ReviewLoadHistogram{
+		ActiveReviews:    make(map[int]int),
+		TotalAssignments: make(map[int]int),
+	}
+	for _, load := range r.s.org(ctx).reviewLoads(teamName) {
+		histogram.ActiveReviews[min(load.ActiveReviews, lastBucket)]++
+		histogram.TotalAssignments[min(load.TotalAssignments, lastBucket)]++
+	}
+	return histogram, nil
+}
+
+// GetReviewLoads returns the review load of every active user of a team, or of all teams when
+// teamName is empty, the most loaded first, then ordered by user id.
+func (r *ReviewerRepository) GetReviewLoads(ctx context.Context, teamName string) ([]*models.ReviewLoad, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	loads := r.s.org(ctx).reviewLoads(teamName)
+	sort.SliceStable(loads, func(i, j int) bool {
+		if loads[i].ActiveReviews != loads[j].ActiveReviews {
+			return loads[i].ActiveReviews > loads[j].ActiveReviews
+		}
+		return loads[i].TotalAssignments > loads[j].TotalAssignments
+	})
+	return loads, nil
+}
+
+// reviewLoads counts the assignments of the active users of a team, or of all teams when teamName
+// is empty, ordered by user id.
+func (st *orgState) reviewLoads(teamName string) []*models.ReviewLoad {
+	users := st.filterUsers(func(user *models.User) bool {
+		return user.IsActive && (teamName == "" || user.TeamName == teamName)
+	})
+	loads := make([]*models.ReviewLoad, len(users))
+	byUser := make(map[string]*models.ReviewLoad, len(users))
+	for i, user := range users {
+		loads[i] = &models.ReviewLoad{UserId: user.Id, Username: user.Name, TeamName: user.TeamName}
+		byUser[user.Id] = loads[i]
+	}
+	for prID, byReviewer := range st.assignments {
+		for reviewerID := range byReviewer {
+			if load, ok := byUser[reviewerID]; ok {
+				load.TotalAssignments++
+				if st.prs[prID].Status == models.PRStatusOpen {
+					load.ActiveReviews++
+				}
+			}
+		}
+	}
+	for _, byReviewer := range st.archivedAssignments {
+		for reviewerID := range byReviewer {
+			if load, ok := byUser[reviewerID]; ok {
+				load.TotalAssignments++
+			}
+		}
+	}
+	return loads
+}
+
+// RemoveReviewer removes a reviewer from a PR.
+func (r *ReviewerRepository) RemoveReviewer(ctx context.Context, prID, reviewerID string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.org(ctx).unassign(prID, reviewerID)
+	return nil
+}
+
+// GetReviewsChangedAt returns when the assignments of the reviewer last changed, zero when they
+// never did.
+func (r *ReviewerRepository) GetReviewsChangedAt(ctx context.Context, reviewerID string) (time.Time, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return r.s.org(ctx).reviewsChangedAt[reviewerID], nil
+}
+
+// RecordReviewerChange appends a reviewer change to the PR history.
+func (r *ReviewerRepository) RecordReviewerChange(ctx context.Context, change *models.ReviewerChange) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	cp := *change
+	st.history = append(st.history, &cp)
+	return nil
+}
+
+// GetReviewerHistory gets reviewer changes of a PR in chronological order.
+func (r *ReviewerRepository) GetReviewerHistory(ctx context.Context, prID string) ([]*models.ReviewerChange, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var changes []*models.ReviewerChange
+	for _, change := range r.s.org(ctx).history {
+		if change.PRId == prID {
+			cp := *change
+			changes = append(changes, &cp)
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].ChangedAt.Before(changes[j].ChangedAt) })
+	return changes, nil
+}
+
+// CountReviewerChanges returns the number of recorded reviewer changes, removals included, counting
+// only the PRs authored by members of a team unless teamName is empty.
+// Changes of archived PRs are counted only with includeArchived.
+func (r *ReviewerRepository) CountReviewerChanges(ctx context.Context, teamName string, includeArchived bool) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	count := 0
+	for _, change := range st.history {
+		if _, ok := st.prs[change.PRId]; (ok || includeArchived) &&
+			st.prAuthoredByTeam(change.PRId, teamName) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// GetReassignmentCounts returns a map of PR IDs to the number of times a reviewer was replaced,
+// keeping only the PRs authored by members of a team unless teamName is empty.
+// Removals without replacement are not counted, nor, without includeArchived, the archived PRs.
+func (r *ReviewerRepository) GetReassignmentCounts(ctx context.Context, teamName string,
+	includeArchived bool) (map[string]int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	counts := make(map[string]int)
+	for _, change := range st.history {
+		if _, ok := st.prs[change.PRId]; change.NewReviewerId != "" && (ok || includeArchived) &&
+			st.prAuthoredByTeam(change.PRId, teamName) {
+			counts[change.PRId]++
+		}
+	}
+	return counts, nil
+}
+
+// allAssignments returns copies of all assignments ordered by PR ID and reviewer ID. The caller holds the lock.
+func (st *orgState) allAssignments() []*models.ReviewAssignment {
+	var assignments []*models.ReviewAssignment
+	for _, byReviewer := range st.assignments {
+		for _, assignment := range byReviewer {
+			assignments = append(assignments, copyAssignment(assignment))
+		}
+	}
+	sort.Slice(assignments, func(i, j int) bool {
+		if assignments[i].PRId != assignments[j].PRId {
+			return assignments[i].PRId < assignments[j].PRId
+		}
+		return assignments[i].ReviewerId < assignments[j].ReviewerId
+	})
+	return assignments
+}
+
+// openAssignments returns copies of the assignments on open PRs. The caller holds the lock.
+func (st *orgState) openAssignments() []*models.ReviewAssignment {
+	var assignments []*models.ReviewAssignment
+	for _, assignment := range st.allAssignments() {
+		if pr, ok := st.prs[assignment.PRId]; ok && pr.Status == models.PRStatusOpen {
+			assignments = append(assignments, assignment)
+		}
+	}
+	return assignments
+}
+
+// contains reports whether values has the value.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
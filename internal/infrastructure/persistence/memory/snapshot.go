@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// SnapshotRepository stores the daily statistics snapshots in memory.
+type SnapshotRepository struct {
+	s *Storage
+}
+
+// SaveSnapshots stores the snapshots, replacing those of the same day and team.
+func (r *SnapshotRepository) SaveSnapshots(ctx context.Context, snapshots []*models.StatisticsSnapshot) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, snapshot := range snapshots {
+		s := *snapshot
+		r.s.org(ctx).snapshots[snapshotKey(s.Day, s.TeamName)] = &s
+	}
+	return nil
+}
+
+// HasSnapshot reports whether the snapshot of all teams was taken on the day.
+func (r *SnapshotRepository) HasSnapshot(ctx context.Context, day time.Time) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	_, ok := r.s.org(ctx).snapshots[snapshotKey(day, "")]
+	return ok, nil
+}
+
+// FindSnapshots returns the snapshots of the days in [from, to), ordered by day, the snapshot of
+// all teams before those of the teams in alphabetical order.
+func (r *SnapshotRepository) FindSnapshots(ctx context.Context, from, to time.Time) ([]*models.StatisticsSnapshot, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var snapshots []*models.StatisticsSnapshot
+	for _, snapshot := range r.s.org(ctx).snapshots {
+		if !snapshot.Day.Before(from) && snapshot.Day.Before(to) {
+			s := *snapshot
+			snapshots = append(snapshots, &s)
+		}
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		if !snapshots[i].Day.Equal(snapshots[j].Day) {
+			return snapshots[i].Day.Before(snapshots[j].Day)
+		}
+		return snapshots[i].TeamName < snapshots[j].TeamName
+	})
+	return snapshots, nil
+}
+
+// snapshotKey keys a snapshot by its day, like the DATE column does, and team.
+func snapshotKey(day time.Time, teamName string) [2]string {
+	return [2]string{day.UTC().Format(time.DateOnly), teamName}
+}
@@ -0,0 +1,298 @@
+// Package memory implements the repositories in memory, mirroring the postgres package.
+// It serves tests and local runs that need the real services without a database.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// Storage holds the in-memory data shared by the repositories.
+type Storage struct {
+	mu    sync.Mutex
+	state *state
+	// txMu serializes transactions: unlike Postgres there is no isolation between them.
+	txMu sync.Mutex
+}
+
+// state is everything stored, so a transaction can restore it on rollback.
+type state struct {
+	// orgs hold the data of every organization keyed by its id, created on first use.
+	orgs map[string]*orgState
+	// lastDeliveryID is the id of the last webhook delivery of any organization.
+	lastDeliveryID int64
+}
+
+// orgState is the data of an organization; ids and team names are unique within it only.
+type orgState struct {
+	users map[string]*models.User
+	// teams hold the team metadata keyed by name, without members.
+	teams map[string]*models.Team
+	prs   map[string]*models.PullRequest
+	// assignments are keyed by PR id and reviewer id.
+	assignments map[string]map[string]*models.ReviewAssignment
+	history     []*models.ReviewerChange
+	// archivedPRs and archivedAssignments hold archived PRs, keyed like prs and assignments.
+	archivedPRs         map[string]*models.PullRequest
+	archivedAssignments map[string]map[string]*models.ReviewAssignment
+	// exclusions are keyed by reviewer id and author id.
+	exclusions map[[2]string]*models.ReviewerExclusion
+	// snapshots are keyed by day, formatted as time.DateOnly, and team name.
+	snapshots map[[2]string]*models.StatisticsSnapshot
+	// reviewsChangedAt holds when the assignments of each reviewer last changed, keyed by reviewer id.
+	reviewsChangedAt map[string]time.Time
+	// deliveries are keyed by id; attempts are in the order they were made.
+	deliveries map[int64]*models.WebhookDelivery
+	attempts   []*models.WebhookAttempt
+}
+
+// NewStorage creates an empty storage.
+func NewStorage() *Storage {
+	return &Storage{state: &state{orgs: make(map[string]*orgState)}}
+}
+
+// newOrgState creates the empty data of an organization.
+func newOrgState() *orgState {
+	return &orgState{
+		users:       make(map[string]*models.User),
+		teams:       make(map[string]*models.Team),
+		prs:         make(map[string]*models.PullRequest),
+		assignments: make(map[string]map[string]*models.ReviewAssignment),
+		exclusions:  make(map[[2]string]*models.ReviewerExclusion),
+		snapshots:   make(map[[2]string]*models.StatisticsSnapshot),
+
+		archivedPRs:         make(map[string]*models.PullRequest),
+		archivedAssignments: make(map[string]map[string]*models.ReviewAssignment),
+		reviewsChangedAt:    make(map[string]time.Time),
+		deliveries:          make(map[int64]*models.WebhookDelivery),
+	}
+}
+
+// org returns the data of the organization of the context, creating it on first use. The caller
+// must hold mu.
+func (s *Storage) org(ctx context.Context) *orgState {
+	orgID := orgctx.ID(ctx)
+	st, ok := s.state.orgs[orgID]
+	if !ok {
+		st = newOrgState()
+		s.state.orgs[orgID] = st
+	}
+	return st
+}
+
+func (s *Storage) NewOrgRepository() *OrgRepository {
+	return &OrgRepository{s: s}
+}
+
+func (s *Storage) NewUnitOfWork() *UnitOfWork {
+	return &UnitOfWork{s: s}
+}
+
+func (s *Storage) NewPullRequestRepository() *PullRequestRepository {
+	return &PullRequestRepository{s: s}
+}
+
+func (s *Storage) NewReviewerRepository() *ReviewerRepository {
+	return &ReviewerRepository{s: s}
+}
+
+func (s *Storage) NewTeamRepository() *TeamRepository {
+	return &TeamRepository{s: s}
+}
+
+func (s *Storage) NewUserRepository() *UserRepository {
+	return &UserRepository{s: s}
+}
+
+func (s *Storage) NewExclusionRepository() *ExclusionRepository {
+	return &ExclusionRepository{s: s}
+}
+
+func (s *Storage) NewArchiveRepository() *ArchiveRepository {
+	return &ArchiveRepository{s: s}
+}
+
+func (s *Storage) NewWebhookRepository() *WebhookRepository {
+	return &WebhookRepository{s: s}
+}
+
+func (s *Storage) NewSnapshotRepository() *SnapshotRepository {
+	return &SnapshotRepository{s: s}
+}
+
+func (s *Storage) NewMetricsRepository() *MetricsRepository {
+	return &MetricsRepository{s: s}
+}
+
+func (s *Storage) NewDuplicateRepository() *DuplicateRepository {
+	return &DuplicateRepository{s: s}
+}
+
+func (s *Storage) NewDumpRepository() *DumpRepository {
+	return &DumpRepository{s: s}
+}
+
+// UnitOfWork runs functions one at a time, discarding their changes on error.
+type UnitOfWork struct {
+	s *Storage
+}
+
+// txKey marks a context that is already inside WithinTransaction.
+type txKey struct{}
+
+// WithinTransaction executes fn exclusively. When fn returns an error or panics, the data is restored
+// to what it was before, including changes made meanwhile outside of transactions.
+// A nested call joins the outer transaction. A canceled context starts no transaction, as with Postgres.
+func (uow *UnitOfWork) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if ctx.Value(txKey{}) != nil {
+		return fn(ctx)
+	}
+	if err = ctx.Err(); err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	uow.s.txMu.Lock()
+	defer uow.s.txMu.Unlock()
+
+	uow.s.mu.Lock()
+	snapshot := uow.s.state.clone()
+	uow.s.mu.Unlock()
+
+	rollback := func() {
+		uow.s.mu.Lock()
+		uow.s.state = snapshot
+		uow.s.mu.Unlock()
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			rollback()
+			panic(p)
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, txKey{}, true)); err != nil {
+		rollback()
+		return err
+	}
+	return nil
+}
+
+// inTx reports whether the context belongs to a transaction.
+func inTx(ctx context.Context) bool {
+	return ctx.Value(txKey{}) != nil
+}
+
+// clone deep-copies the state.
+func (st *state) clone() *state {
+	cp := &state{
+		orgs:           make(map[string]*orgState, len(st.orgs)),
+		lastDeliveryID: st.lastDeliveryID,
+	}
+	for orgID, org := range st.orgs {
+		cp.orgs[orgID] = org.clone()
+	}
+	return cp
+}
+
+// clone deep-copies the data of the organization.
+func (st *orgState) clone() *orgState {
+	cp := &orgState{
+		users:       make(map[string]*models.User, len(st.users)),
+		teams:       make(map[string]*models.Team, len(st.teams)),
+		prs:         make(map[string]*models.PullRequest, len(st.prs)),
+		assignments: make(map[string]map[string]*models.ReviewAssignment, len(st.assignments)),
+		history:     make([]*models.ReviewerChange, 0, len(st.history)),
+		exclusions:  make(map[[2]string]*models.ReviewerExclusion, len(st.exclusions)),
+		snapshots:   make(map[[2]string]*models.StatisticsSnapshot, len(st.snapshots)),
+
+		archivedPRs:         make(map[string]*models.PullRequest, len(st.archivedPRs)),
+		archivedAssignments: make(map[string]map[string]*models.ReviewAssignment, len(st.archivedAssignments)),
+		reviewsChangedAt:    maps.Clone(st.reviewsChangedAt),
+		deliveries:          make(map[int64]*models.WebhookDelivery, len(st.deliveries)),
+		attempts:            make([]*models.WebhookAttempt, 0, len(st.attempts)),
+	}
+	for id, user := range st.users {
+		cp.users[id] = copyUser(user)
+	}
+	for name, team := range st.teams {
+		t := *team
+		cp.teams[name] = &t
+	}
+	for id, pr := range st.prs {
+		cp.prs[id] = copyPR(pr)
+	}
+	for prID, byReviewer := range st.assignments {
+		cp.assignments[prID] = copyAssignments(byReviewer)
+	}
+	for id, pr := range st.archivedPRs {
+		cp.archivedPRs[id] = copyPR(pr)
+	}
+	for prID, byReviewer := range st.archivedAssignments {
+		cp.archivedAssignments[prID] = copyAssignments(byReviewer)
+	}
+	for _, change := range st.history {
+		c := *change
+		cp.history = append(cp.history, &c)
+	}
+	for key, exclusion := range st.exclusions {
+		e := *exclusion
+		cp.exclusions[key] = &e
+	}
+	for key, snapshot := range st.snapshots {
+		sn := *snapshot
+		cp.snapshots[key] = &sn
+	}
+	for id, delivery := range st.deliveries {
+		cp.deliveries[id] = copyDelivery(delivery)
+	}
+	for _, attempt := range st.attempts {
+		a := *attempt
+		cp.attempts = append(cp.attempts, &a)
+	}
+	return cp
+}
+
+func copyUser(user *models.User) *models.User {
+	cp := *user
+	if user.MaxActiveReviews != nil {
+		maxReviews := *user.MaxActiveReviews
+		cp.MaxActiveReviews = &maxReviews
+	}
+	cp.Tags = append([]string{}, user.Tags...)
+	return &cp
+}
+
+func copyPR(pr *models.PullRequest) *models.PullRequest {
+	cp := *pr
+	if pr.MergedAt != nil {
+		mergedAt := *pr.MergedAt
+		cp.MergedAt = &mergedAt
+	}
+	cp.Labels = append([]string{}, pr.Labels...)
+	cp.ReviewersId = nil
+	return &cp
+}
+
+// copyAssignments copies the assignments of a PR keyed by reviewer id.
+func copyAssignments(byReviewer map[string]*models.ReviewAssignment) map[string]*models.ReviewAssignment {
+	cp := make(map[string]*models.ReviewAssignment, len(byReviewer))
+	for reviewerID, assignment := range byReviewer {
+		cp[reviewerID] = copyAssignment(assignment)
+	}
+	return cp
+}
+
+func copyAssignment(assignment *models.ReviewAssignment) *models.ReviewAssignment {
+	cp := *assignment
+	if assignment.StateChangedAt != nil {
+		changedAt := *assignment.StateChangedAt
+		cp.StateChangedAt = &changedAt
+	}
+	return &cp
+}
@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"context"
+	"iter"
+	"sort"
+
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// TeamRepository manages teams in memory. A team exists while it has members.
+type TeamRepository struct {
+	s *Storage
+}
+
+// CreateTeam creates a new team with its members.
+// Returns TEAM_EXISTS AppError if the team is stored, even without members, as with the key in Postgres.
+func (r *TeamRepository) CreateTeam(ctx context.Context, team *models.Team) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	if st.teamExists(team.GetTeamName()) {
+		return domainErrors.NewTeamExists("team_name already exists")
+	}
+	st.upsertMembers(team)
+	st.insertTeam(team.Record())
+	return nil
+}
+
+// ImportTeam creates a new team whose members come in batches. Unlike Postgres, which stores
+// each batch as it comes, the members are kept until the last batch so that the lock is not held
+// while they are read, and stored together; nothing is stored when batches yields an error.
+// Returns TEAM_EXISTS AppError if the team already exists, before any batch is read.
+func (r *TeamRepository) ImportTeam(ctx context.Context, team *models.TeamRecord,
+	batches iter.Seq2[[]*models.User, error]) error {
+	r.s.mu.Lock()
+	exists := r.s.org(ctx).teamExists(team.Name)
+	r.s.mu.Unlock()
+	if exists {
+		return domainErrors.NewTeamExists("team_name already exists")
+	}
+
+	imported := &models.Team{Description: team.Description, LeadId: team.LeadId, CreatedAt: team.CreatedAt}
+	for members, err := range batches {
+		if err != nil {
+			return err
+		}
+		imported.Members = append(imported.Members, members...)
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	// the team may have been created while the members were read
+	if st.teamExists(team.Name) {
+		return domainErrors.NewTeamExists("team_name already exists")
+	}
+	st.upsertMembers(imported)
+	st.insertTeam(team)
+	return nil
+}
+
+// GetTeamByName gets a team by its name with its metadata and members ordered by username.
+func (r *TeamRepository) GetTeamByName(ctx context.Context, teamName string) (*models.Team, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	members := st.filterUsers(func(user *models.User) bool { return user.TeamName == teamName })
+	if len(members) == 0 {
+		return nil, nil
+	}
+	sort.SliceStable(members, func(i, j int) bool { return members[i].Name < members[j].Name })
+	return st.withMetadata(teamName, members), nil
+}
+
+// ListTeams gets all teams with their metadata, ordered by name, with members ordered by username.
+func (r *TeamRepository) ListTeams(ctx context.Context) ([]*models.Team, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	byTeam := make(map[string][]*models.User)
+	for _, user := range st.filterUsers(func(*models.User) bool { return true }) {
+		byTeam[user.TeamName] = append(byTeam[user.TeamName], user)
+	}
+	teams := make([]*models.Team, 0, len(byTeam))
+	for teamName, members := range byTeam {
+		sort.SliceStable(members, func(i, j int) bool { return members[i].Name < members[j].Name })
+		teams = append(teams, st.withMetadata(teamName, members))
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].GetTeamName() < teams[j].GetTeamName() })
+	return teams, nil
+}
+
+// withMetadata returns the team of the members with its stored metadata; a lead who has moved
+// to another team is not reported. The caller holds the lock.
+func (st *orgState) withMetadata(teamName string, members []*models.User) *models.Team {
+	team := &models.Team{Members: members}
+	if stored, ok := st.teams[teamName]; ok {
+		team.Description = stored.Description
+		team.CreatedAt = stored.CreatedAt
+	}
+	team.LeadId = st.teamLead(teamName)
+	return team
+}
+
+// teamLead returns the lead of the team while they are a member of it. The caller holds the lock.
+func (st *orgState) teamLead(teamName string) string {
+	stored, ok := st.teams[teamName]
+	if !ok || stored.LeadId == "" {
+		return ""
+	}
+	if lead, ok := st.users[stored.LeadId]; !ok || lead.TeamName != teamName {
+		return ""
+	}
+	return stored.LeadId
+}
+
+// insertTeam stores the metadata of a new team. The caller holds the lock.
+func (st *orgState) insertTeam(team *models.TeamRecord) {
+	st.teams[team.Name] = &models.Team{Description: team.Description, LeadId: team.LeadId, CreatedAt: team.CreatedAt}
+}
+
+// teamExists reports whether the team is stored. The caller holds the lock.
+func (st *orgState) teamExists(teamName string) bool {
+	_, ok := st.teams[teamName]
+	return ok
+}
+
+// upsertMembers creates or updates team members. The caller holds the lock.
+func (st *orgState) upsertMembers(team *models.Team) {
+	teamName := team.GetTeamName()
+	for _, member := range team.Members {
+		user := copyUser(member)
+		user.TeamName = teamName
+		st.users[member.Id] = user
+	}
+}
@@ -0,0 +1,158 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// UserRepository manages users in memory.
+type UserRepository struct {
+	s *Storage
+}
+
+// FindByID finds user by ID.
+func (r *UserRepository) FindByID(ctx context.Context, userID string) (*models.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	user, ok := r.s.org(ctx).users[userID]
+	if !ok {
+		return nil, nil
+	}
+	return copyUser(user), nil
+}
+
+// FindByIDs finds users by IDs ordered by id. Unknown IDs are skipped.
+func (r *UserRepository) FindByIDs(ctx context.Context, userIDs []string) ([]*models.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return r.s.org(ctx).filterUsers(func(user *models.User) bool { return contains(userIDs, user.Id) }), nil
+}
+
+// SetIsActive updates the is_active status of a user.
+func (r *UserRepository) SetIsActive(ctx context.Context, userID string, isActive bool) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if user, ok := r.s.org(ctx).users[userID]; ok {
+		user.IsActive = isActive
+	}
+	return nil
+}
+
+// SetTags replaces the tags of a user.
+func (r *UserRepository) SetTags(ctx context.Context, userID string, tags []string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if user, ok := r.s.org(ctx).users[userID]; ok {
+		user.Tags = append([]string{}, tags...)
+	}
+	return nil
+}
+
+// SetIsReviewer adds the user to the reviewer pool of their team or takes them out of it.
+func (r *UserRepository) SetIsReviewer(ctx context.Context, userID string, isReviewer bool) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if user, ok := r.s.org(ctx).users[userID]; ok {
+		user.NonReviewer = !isReviewer
+	}
+	return nil
+}
+
+// UpdateUsername changes the display name of a user.
+func (r *UserRepository) UpdateUsername(ctx context.Context, userID, username string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if user, ok := r.s.org(ctx).users[userID]; ok {
+		user.Name = username
+	}
+	return nil
+}
+
+// GetExcludedReviewers returns IDs of users who must not review PRs of the author.
+func (r *UserRepository) GetExcludedReviewers(ctx context.Context, authorID string) ([]string, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var userIDs []string
+	for _, exclusion := range r.s.org(ctx).exclusions {
+		for _, userID := range []string{exclusion.ReviewerId, exclusion.AuthorId} {
+			if userID != authorID && exclusion.Excludes(userID, authorID) {
+				userIDs = append(userIDs, userID)
+			}
+		}
+	}
+	sort.Strings(userIDs)
+	return userIDs, nil
+}
+
+// FindActiveCandidatesForReassignment finds active users of the team's reviewer pool excluding specified user IDs.
+func (r *UserRepository) FindActiveCandidatesForReassignment(ctx context.Context, teamName string,
+	excludeUserIDs []string) ([]*models.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return r.s.org(ctx).filterUsers(func(user *models.User) bool {
+		return user.TeamName == teamName && user.IsActive && !user.NonReviewer && !contains(excludeUserIDs, user.Id)
+	}), nil
+}
+
+// LockActiveCandidate reports whether the user is still active. Like the postgres repository
+// it must run inside a transaction; transactions are serialized, so nothing has to be locked.
+func (r *UserRepository) LockActiveCandidate(ctx context.Context, userID string) (bool, error) {
+	if !inTx(ctx) {
+		return false, fmt.Errorf("failed to lock candidate: transaction required")
+	}
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	user, ok := r.s.org(ctx).users[userID]
+	return ok && user.IsActive, nil
+}
+
+// GetAllUsers returns all users ordered by id.
+func (r *UserRepository) GetAllUsers(ctx context.Context) ([]*models.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return r.s.org(ctx).filterUsers(func(*models.User) bool { return true }), nil
+}
+
+// FindByTeamName finds all users in a team ordered by id.
+func (r *UserRepository) FindByTeamName(ctx context.Context, teamName string) ([]*models.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return r.s.org(ctx).filterUsers(func(user *models.User) bool { return user.TeamName == teamName }), nil
+}
+
+// GetTeamLead gets the lead of the team, or an empty string when the team has no lead
+// or the lead is no longer a member of it.
+func (r *UserRepository) GetTeamLead(ctx context.Context, teamName string) (string, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return r.s.org(ctx).teamLead(teamName), nil
+}
+
+// DeactivateTeamUsers deactivates all users in a team.
+func (r *UserRepository) DeactivateTeamUsers(ctx context.Context, teamName string) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	deactivated := 0
+	for _, user := range r.s.org(ctx).users {
+		if user.TeamName == teamName && user.IsActive {
+			user.IsActive = false
+			deactivated++
+		}
+	}
+	return deactivated, nil
+}
+
+// filterUsers returns copies of the users matching keep ordered by id. The caller holds the lock.
+func (st *orgState) filterUsers(keep func(user *models.User) bool) []*models.User {
+	var users []*models.User
+	for _, user := range st.users {
+		if keep(user) {
+			users = append(users, copyUser(user))
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Id < users[j].Id })
+	return users
+}
@@ -0,0 +1,191 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// WebhookRepository manages webhook deliveries and their attempts in memory.
+type WebhookRepository struct {
+	s *Storage
+}
+
+// Enqueue stores a pending delivery due at its NextAttemptAt. Id and CreatedAt are filled by the repository.
+func (r *WebhookRepository) Enqueue(ctx context.Context, delivery *models.WebhookDelivery) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.state.lastDeliveryID++
+	delivery.Id = r.s.state.lastDeliveryID
+	delivery.Status = models.DeliveryStatusPending
+	delivery.CreatedAt = time.Now().UTC()
+	r.s.org(ctx).deliveries[delivery.Id] = copyDelivery(delivery)
+	return nil
+}
+
+// FindDue returns up to limit pending deliveries due at the given moment, the longest overdue first.
+func (r *WebhookRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var due []*models.WebhookDelivery
+	for _, delivery := range r.s.org(ctx).deliveries {
+		if delivery.Status == models.DeliveryStatusPending && !delivery.NextAttemptAt.After(now) {
+			due = append(due, copyDelivery(delivery))
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].NextAttemptAt.Equal(due[j].NextAttemptAt) {
+			return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+		}
+		return due[i].Id < due[j].Id
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+// FindByID returns the delivery or nil if it does not exist.
+func (r *WebhookRepository) FindByID(ctx context.Context, id int64) (*models.WebhookDelivery, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	delivery, ok := r.s.org(ctx).deliveries[id]
+	if !ok {
+		return nil, nil
+	}
+	return copyDelivery(delivery), nil
+}
+
+// ListDead returns a page of dead deliveries, oldest first, and the number of all of them.
+func (r *WebhookRepository) ListDead(ctx context.Context, limit, offset int) ([]*models.WebhookDelivery, int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var dead []*models.WebhookDelivery
+	for _, delivery := range r.s.org(ctx).deliveries {
+		if delivery.Status == models.DeliveryStatusDead {
+			dead = append(dead, delivery)
+		}
+	}
+	sort.Slice(dead, func(i, j int) bool { return dead[i].Id < dead[j].Id })
+
+	total := len(dead)
+	dead = dead[min(offset, total):]
+	dead = dead[:min(limit, len(dead))]
+	page := make([]*models.WebhookDelivery, 0, len(dead))
+	for _, delivery := range dead {
+		page = append(page, copyDelivery(delivery))
+	}
+	return page, total, nil
+}
+
+// FindAttempts returns the attempts of the deliveries keyed by delivery id, in the order they were made.
+func (r *WebhookRepository) FindAttempts(ctx context.Context, deliveryIDs []int64) (map[int64][]*models.WebhookAttempt, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	wanted := make(map[int64]bool, len(deliveryIDs))
+	for _, id := range deliveryIDs {
+		wanted[id] = true
+	}
+	attempts := make(map[int64][]*models.WebhookAttempt, len(deliveryIDs))
+	for _, attempt := range r.s.org(ctx).attempts {
+		if wanted[attempt.DeliveryId] {
+			a := *attempt
+			attempts[attempt.DeliveryId] = append(attempts[attempt.DeliveryId], &a)
+		}
+	}
+	return attempts, nil
+}
+
+// SaveAttempt records the attempt and stores the state of the delivery after it.
+func (r *WebhookRepository) SaveAttempt(ctx context.Context, delivery *models.WebhookDelivery,
+	attempt *models.WebhookAttempt) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	a := *attempt
+	st.attempts = append(st.attempts, &a)
+	if existing, ok := st.deliveries[delivery.Id]; ok {
+		// the event itself never changes
+		updated := copyDelivery(delivery)
+		updated.EventType, updated.Payload, updated.CreatedAt = existing.EventType, existing.Payload, existing.CreatedAt
+		st.deliveries[delivery.Id] = updated
+	}
+	return nil
+}
+
+// Requeue makes a dead delivery pending again, due at the given moment, with its attempt count
+// restarted. Reports false if the delivery does not exist or is not dead.
+func (r *WebhookRepository) Requeue(ctx context.Context, id int64, at time.Time) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	delivery, ok := r.s.org(ctx).deliveries[id]
+	if !ok || delivery.Status != models.DeliveryStatusDead {
+		return false, nil
+	}
+	delivery.Status = models.DeliveryStatusPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = at
+	return true, nil
+}
+
+// Stats counts the deliveries by status and all attempts made.
+func (r *WebhookRepository) Stats(ctx context.Context) (*models.WebhookDeliveryStats, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	st := r.s.org(ctx)
+	var stats models.WebhookDeliveryStats
+	for _, delivery := range st.deliveries {
+		switch delivery.Status {
+		case models.DeliveryStatusPending:
+			stats.Pending++
+		case models.DeliveryStatusDelivered:
+			stats.Delivered++
+		case models.DeliveryStatusDead:
+			stats.Dead++
+		}
+	}
+	stats.Attempts = len(st.attempts)
+	for _, attempt := range st.attempts {
+		if attempt.Error != "" {
+			stats.FailedAttempts++
+		}
+	}
+	return &stats, nil
+}
+
+// CountPending counts the pending deliveries of all organizations.
+func (r *WebhookRepository) CountPending(ctx context.Context) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	pending := 0
+	for _, st := range r.s.state.orgs {
+		for _, delivery := range st.deliveries {
+			if delivery.Status == models.DeliveryStatusPending {
+				pending++
+			}
+		}
+	}
+	return pending, nil
+}
+
+// hasPending reports whether the organization has pending deliveries. The caller holds the lock.
+func (st *orgState) hasPending() bool {
+	for _, delivery := range st.deliveries {
+		if delivery.Status == models.DeliveryStatusPending {
+			return true
+		}
+	}
+	return false
+}
+
+func copyDelivery(delivery *models.WebhookDelivery) *models.WebhookDelivery {
+	cp := *delivery
+	cp.Payload = append([]byte{}, delivery.Payload...)
+	if delivery.DeliveredAt != nil {
+		deliveredAt := *delivery.DeliveredAt
+		cp.DeliveredAt = &deliveredAt
+	}
+	return &cp
+}
@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AdvisoryLocker takes session-level advisory locks, so that a piece of work runs on one replica at a time.
+type AdvisoryLocker struct {
+	pool *pgxpool.Pool
+}
+
+// TryLock takes the advisory lock with the key without waiting. When the lock is taken, the returned
+// function releases it; it must be called once the work is done. Reports false if another session holds the lock.
+func (l *AdvisoryLocker) TryLock(ctx context.Context, key int64) (func(), bool, error) {
+	// the lock belongs to the session, so it is held on a dedicated connection
+	conn, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to acquire connection: %w", poolError(ctx, err))
+	}
+
+	var locked bool
+	if err = conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, key).Scan(&locked); err != nil {
+		conn.Release()
+		return nil, false, fmt.Errorf("failed to take advisory lock: %w", err)
+	}
+	if !locked {
+		conn.Release()
+		return nil, false, nil
+	}
+
+	release := func() {
+		// a fresh context: the caller's one may be cancelled by now
+		if _, err := conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, key); err != nil {
+			// closing the session drops the lock it holds
+			_ = conn.Conn().Close(context.Background())
+		}
+		conn.Release()
+	}
+	return release, true, nil
+}
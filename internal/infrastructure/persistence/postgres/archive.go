@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+)
+
+// ArchiveRepository moves merged PRs between the main tables and the archive tables.
+type ArchiveRepository struct {
+	pool *pgxpool.Pool
+}
+
+// ArchiveMerged moves up to limit PRs merged before the given moment, oldest first, with their
+// reviewers into the archive and returns their ids. Reviewer history is kept as is.
+// It must run inside a transaction, or a failure leaves PRs in both tables.
+func (r *ArchiveRepository) ArchiveMerged(ctx context.Context, mergedBefore time.Time, limit int) ([]string, error) {
+	selectQuery := `SELECT id FROM pull_request
+	                WHERE org_id = $3 AND status = 'MERGED' AND merged_at < $1
+	                ORDER BY merged_at, id
+	                LIMIT $2
+	                FOR UPDATE SKIP LOCKED`
+
+	orgID := orgctx.ID(ctx)
+	executor := getTx(ctx, r.pool)
+	rows, err := executor.Query(ctx, selectQuery, mergedBefore, limit, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select PRs to archive: %w", err)
+	}
+	defer rows.Close()
+
+	var prIDs []string
+	for rows.Next() {
+		var prID string
+		if err = rows.Scan(&prID); err != nil {
+			return nil, fmt.Errorf("failed to scan PR ID: %w", err)
+		}
+		prIDs = append(prIDs, prID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	if len(prIDs) == 0 {
+		return nil, nil
+	}
+
+	prQuery := `INSERT INTO pull_request_archive
+	                (id, title, author_id, status, created_at, merged_at, updated_at, priority, labels, reviewer_count, archived_at,
+	                 org_id)
+	            SELECT id, title, author_id, status, created_at, merged_at, updated_at, priority, labels, reviewer_count, $2,
+	                   org_id
+	            FROM pull_request
+	            WHERE org_id = $3 AND id = ANY($1)`
+	if _, err = executor.Exec(ctx, prQuery, prIDs, time.Now().UTC(), orgID); err != nil {
+		return nil, fmt.Errorf("failed to archive PRs: %w", err)
+	}
+
+	// the archived PRs leave the review lists of their reviewers
+	reviewerQuery := `WITH archived AS (
+	                      INSERT INTO pr_reviewer_archive (pr_id, reviewer_id, assigned_at, source, state, state_changed_at,
+	                                                       org_id)
+	                      SELECT pr_id, reviewer_id, assigned_at, source, state, state_changed_at, org_id
+	                      FROM pr_reviewer
+	                      WHERE org_id = $3 AND pr_id = ANY($1)
+	                      RETURNING reviewer_id
+	                  )
+	                  INSERT INTO review_change (reviewer_id, changed_at, org_id)
+	                  SELECT DISTINCT reviewer_id, $2::timestamptz, $3 FROM archived`
+	if _, err = executor.Exec(ctx, reviewerQuery, prIDs, time.Now().UTC(), orgID); err != nil {
+		return nil, fmt.Errorf("failed to archive reviewers: %w", err)
+	}
+
+	// reviewers go with their PR
+	deleteQuery := `DELETE FROM pull_request WHERE org_id = $2 AND id = ANY($1)`
+	if _, err = executor.Exec(ctx, deleteQuery, prIDs, orgID); err != nil {
+		return nil, fmt.Errorf("failed to remove archived PRs: %w", err)
+	}
+
+	return prIDs, nil
+}
+
+// Restore moves an archived PR with its reviewers back to the main tables and reports whether it
+// was archived. Returns PR_EXISTS AppError when a PR with the same id was created since.
+// It must run inside a transaction, or a failure leaves the PR in both tables.
+func (r *ArchiveRepository) Restore(ctx context.Context, prID string) (bool, error) {
+	prQuery := `INSERT INTO pull_request
+	                (id, title, author_id, status, created_at, merged_at, updated_at, priority, labels, reviewer_count, org_id)
+	            SELECT id, title, author_id, status, created_at, merged_at, updated_at, priority, labels, reviewer_count, org_id
+	            FROM pull_request_archive
+	            WHERE org_id = $2 AND id = $1`
+
+	orgID := orgctx.ID(ctx)
+	executor := getTx(ctx, r.pool)
+	tag, err := executor.Exec(ctx, prQuery, prID, orgID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return false, domainErrors.NewPRExists("PR id already exists")
+		}
+		return false, fmt.Errorf("failed to restore PR: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	reviewerQuery := `WITH restored AS (
+	                      INSERT INTO pr_reviewer (pr_id, reviewer_id, assigned_at, source, state, state_changed_at, org_id)
+	                      SELECT pr_id, reviewer_id, assigned_at, source, state, state_changed_at, org_id
+	                      FROM pr_reviewer_archive
+	                      WHERE org_id = $3 AND pr_id = $1
+	                      RETURNING reviewer_id
+	                  )
+	                  INSERT INTO review_change (reviewer_id, changed_at, org_id)
+	                  SELECT reviewer_id, $2::timestamptz, $3 FROM restored`
+	if _, err = executor.Exec(ctx, reviewerQuery, prID, time.Now().UTC(), orgID); err != nil {
+		return false, fmt.Errorf("failed to restore reviewers: %w", err)
+	}
+
+	deleteQuery := `DELETE FROM pull_request_archive WHERE org_id = $2 AND id = $1`
+	if _, err = executor.Exec(ctx, deleteQuery, prID, orgID); err != nil {
+		return false, fmt.Errorf("failed to remove restored PR from the archive: %w", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,122 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchiveRepository(t *testing.T) {
+	f := newFixture(t)
+	f.team("backend", "u1", "u2", "u3")
+	now := time.Now().UTC()
+	f.pr("pr-1", "u1", now, "u2", "u3")
+	f.pr("pr-2", "u1", now, "u2")
+	f.pr("pr-3", "u1", now, "u3")
+	f.merge("pr-1")
+	f.merge("pr-2")
+	assert.NoError(t, f.reviewers.RecordReviewerChange(f.ctx, &models.ReviewerChange{
+		PRId: "pr-1", OldReviewerId: "u2", NewReviewerId: "u3", Trigger: models.ReviewerChangeManual, ChangedAt: now}))
+	cutoff := time.Now().UTC().Add(time.Hour)
+
+	t.Run("Success - Merged PRs archived oldest first in batches", func(t *testing.T) {
+		var first, second []string
+		err := f.inTx(func(ctx context.Context) error {
+			var err error
+			if first, err = f.archive.ArchiveMerged(ctx, cutoff, 1); err != nil {
+				return err
+			}
+			second, err = f.archive.ArchiveMerged(ctx, cutoff, 1)
+			return err
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"pr-1"}, first)
+		assert.Equal(t, []string{"pr-2"}, second)
+		pr, err := f.prs.FindByID(f.ctx, "pr-1")
+		assert.NoError(t, err)
+		assert.Nil(t, pr)
+
+		archived, err := f.reviewers.GetArchivedReviewers(f.ctx, "")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string][]string{"pr-1": {"u2", "u3"}, "pr-2": {"u2"}}, archived)
+		prs, err := f.prs.GetArchivedPRs(f.ctx, "")
+		assert.NoError(t, err)
+		assert.Len(t, prs, 2)
+	})
+
+	t.Run("Success - Assignment sources of archived PRs counted on request", func(t *testing.T) {
+		current, err := f.reviewers.GetAssignmentSourceCounts(f.ctx, "", false)
+		assert.NoError(t, err)
+		all, err := f.reviewers.GetAssignmentSourceCounts(f.ctx, "", true)
+		assert.NoError(t, err)
+
+		assert.Equal(t, map[string]int{models.AssignmentSourceAuto: 1}, current)
+		assert.Equal(t, map[string]int{models.AssignmentSourceAuto: 4}, all)
+	})
+
+	t.Run("Success - Nothing left to archive", func(t *testing.T) {
+		prIDs, err := f.archive.ArchiveMerged(f.ctx, cutoff, 10)
+
+		assert.NoError(t, err)
+		assert.Empty(t, prIDs)
+	})
+
+	t.Run("Success - History of archived PRs counted on request", func(t *testing.T) {
+		current, err := f.reviewers.CountReviewerChanges(f.ctx, "", false)
+		assert.NoError(t, err)
+		all, err := f.reviewers.CountReviewerChanges(f.ctx, "", true)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 0, current)
+		assert.Equal(t, 1, all)
+	})
+
+	t.Run("Success - Reassignments of archived PRs counted on request", func(t *testing.T) {
+		current, err := f.reviewers.GetReassignmentCounts(f.ctx, "", false)
+		assert.NoError(t, err)
+		all, err := f.reviewers.GetReassignmentCounts(f.ctx, "", true)
+		assert.NoError(t, err)
+
+		assert.Empty(t, current)
+		assert.Equal(t, map[string]int{"pr-1": 1}, all)
+	})
+
+	t.Run("Success - Restore brings back the PR, its reviewers and counter", func(t *testing.T) {
+		var restored bool
+		err := f.inTx(func(ctx context.Context) error {
+			var err error
+			restored, err = f.archive.Restore(ctx, "pr-1")
+			return err
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, restored)
+		reviewers, err := f.reviewers.GetReviewers(f.ctx, "pr-1")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u2", "u3"}, reviewers)
+		assert.Equal(t, 2, f.reviewerCount("pr-1"))
+	})
+
+	t.Run("Success - Restore of a PR that is not archived", func(t *testing.T) {
+		restored, err := f.archive.Restore(f.ctx, "pr-3")
+
+		assert.NoError(t, err)
+		assert.False(t, restored)
+	})
+
+	t.Run("Error - Restore over a PR created since", func(t *testing.T) {
+		f.pr("pr-2", "u1", now)
+
+		restored, err := f.archive.Restore(f.ctx, "pr-2")
+
+		assert.False(t, restored)
+		assert.True(t, domainErrors.HasCode(err, domainErrors.CodePRExists))
+	})
+}
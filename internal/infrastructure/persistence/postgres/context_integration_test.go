@@ -0,0 +1,274 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// repositoryCalls calls every repository method once with the given context and returns its error.
+// LockActiveCandidate is left out, as it refuses to run outside a transaction before using ctx.
+func repositoryCalls(f *fixture) map[string]func(ctx context.Context) error {
+	now := time.Now().UTC()
+	pr := &models.PullRequest{Id: "pr-new", Title: "PR", AuthorId: "u1", Status: models.PRStatusOpen,
+		CreatedAt: now, UpdatedAt: now, Priority: models.PRPriorityNormal, Labels: []string{}}
+	team := &models.Team{Members: []*models.User{{Id: "f1", Name: "f1", TeamName: "frontend", IsActive: true}}}
+	ignore := func(_ any, err error) error { return err }
+
+	return map[string]func(ctx context.Context) error{
+		"PullRequest.Create":      func(ctx context.Context) error { return f.prs.Create(ctx, pr) },
+		"PullRequest.CreateBatch": func(ctx context.Context) error { return ignore(f.prs.CreateBatch(ctx, []*models.PullRequest{pr})) },
+		"PullRequest.FindByID":    func(ctx context.Context) error { return ignore(f.prs.FindByID(ctx, "pr-1")) },
+		"PullRequest.Exists":      func(ctx context.Context) error { return ignore(f.prs.Exists(ctx, "pr-1")) },
+		"PullRequest.UpdateStatus": func(ctx context.Context) error {
+			return ignore(f.prs.UpdateStatus(ctx, "pr-1", models.PRStatusOpen, models.PRStatusMerged, &now))
+		},
+		"PullRequest.SetLabels": func(ctx context.Context) error { return f.prs.SetLabels(ctx, "pr-1", []string{"a"}) },
+		"PullRequest.ClearAssignmentSkipped": func(ctx context.Context) error {
+			return f.prs.ClearAssignmentSkipped(ctx, "pr-1")
+		},
+		"PullRequest.FindByReviewer": func(ctx context.Context) error { return ignore(f.prs.FindByReviewer(ctx, "u2")) },
+		"PullRequest.GetAllPRs":      func(ctx context.Context) error { return ignore(f.prs.GetAllPRs(ctx, "")) },
+		"PullRequest.GetArchivedPRs": func(ctx context.Context) error { return ignore(f.prs.GetArchivedPRs(ctx, "")) },
+		"PullRequest.GetActivity": func(ctx context.Context) error {
+			return ignore(f.prs.GetActivity(ctx, "", models.ActivityBucketDay, time.Now().Add(-time.Hour), time.Now()))
+		},
+		"PullRequest.FindOpenPRsByReviewers": func(ctx context.Context) error {
+			return ignore(f.prs.FindOpenPRsByReviewers(ctx, []string{"u2"}))
+		},
+		"PullRequest.FindOpenPRsByAuthors": func(ctx context.Context) error {
+			return ignore(f.prs.FindOpenPRsByAuthors(ctx, []string{"u1"}))
+		},
+		"PullRequest.SearchByTitle": func(ctx context.Context) error {
+			return ignore(f.prs.SearchByTitle(ctx, "PR", "", nil, models.PRSort{}, 10, 0))
+		},
+		"PullRequest.CountByTitle": func(ctx context.Context) error { return ignore(f.prs.CountByTitle(ctx, "PR", "", nil)) },
+		"PullRequest.FindOpenPRsReviewedByTeam": func(ctx context.Context) error {
+			return ignore(f.prs.FindOpenPRsReviewedByTeam(ctx, "backend", models.PRSort{}))
+		},
+		"PullRequest.FindOpenWithoutReviewers": func(ctx context.Context) error {
+			return ignore(f.prs.FindOpenWithoutReviewers(ctx, "", nil, nil, models.PRSort{}, 10, 0))
+		},
+		"PullRequest.CountOpenWithoutReviewers": func(ctx context.Context) error {
+			return ignore(f.prs.CountOpenWithoutReviewers(ctx, "", nil, nil))
+		},
+		"PullRequest.GetAuthorStats": func(ctx context.Context) error {
+			return ignore(f.prs.GetAuthorStats(ctx, "", true))
+		},
+		"PullRequest.GetAuthorStatsByID": func(ctx context.Context) error {
+			return ignore(f.prs.GetAuthorStatsByID(ctx, "u1", true))
+		},
+
+		"Reviewer.AssignReviewer": func(ctx context.Context) error {
+			return f.reviewers.AssignReviewer(ctx, "pr-1", "u3", models.AssignmentSourceAuto)
+		},
+		"Reviewer.GetReviewers":      func(ctx context.Context) error { return ignore(f.reviewers.GetReviewers(ctx, "pr-1")) },
+		"Reviewer.GetPRsByReviewer":  func(ctx context.Context) error { return ignore(f.reviewers.GetPRsByReviewer(ctx, "u2")) },
+		"Reviewer.GetReviewersByPRs": func(ctx context.Context) error { return ignore(f.reviewers.GetReviewersByPRs(ctx, []string{"pr-1"})) },
+		"Reviewer.GetOpenReviewCounts": func(ctx context.Context) error {
+			return ignore(f.reviewers.GetOpenReviewCounts(ctx, []string{"u2"}))
+		},
+		"Reviewer.GetAssignmentTimes": func(ctx context.Context) error {
+			return ignore(f.reviewers.GetAssignmentTimes(ctx, []string{"u2"}, now.Add(-time.Hour)))
+		},
+		"Reviewer.GetAssignmentsByPRs": func(ctx context.Context) error {
+			return ignore(f.reviewers.GetAssignmentsByPRs(ctx, []string{"pr-1"}))
+		},
+		"Reviewer.FindOpenAssignments":  func(ctx context.Context) error { return ignore(f.reviewers.FindOpenAssignments(ctx, now)) },
+		"Reviewer.FindStaleAssignments": func(ctx context.Context) error { return ignore(f.reviewers.FindStaleAssignments(ctx, now, 10)) },
+		"Reviewer.LockAssignment":       func(ctx context.Context) error { return ignore(f.reviewers.LockAssignment(ctx, "pr-1", "u2")) },
+		"Reviewer.SetReviewState": func(ctx context.Context) error {
+			return f.reviewers.SetReviewState(ctx, "pr-1", "u2", models.ReviewStateApproved, now)
+		},
+		"Reviewer.IsAssigned": func(ctx context.Context) error { return ignore(f.reviewers.IsAssigned(ctx, "pr-1", "u2")) },
+		"Reviewer.ReplaceReviewer": func(ctx context.Context) error {
+			return f.reviewers.ReplaceReviewer(ctx, "pr-1", "u2", "u3", models.AssignmentSourceReassign)
+		},
+		"Reviewer.GetAllReviewers":      func(ctx context.Context) error { return ignore(f.reviewers.GetAllReviewers(ctx, "")) },
+		"Reviewer.GetArchivedReviewers": func(ctx context.Context) error { return ignore(f.reviewers.GetArchivedReviewers(ctx, "")) },
+		"Reviewer.GetAllReviewerCounts": func(ctx context.Context) error { return ignore(f.reviewers.GetAllReviewerCounts(ctx, "")) },
+		"Reviewer.RemoveReviewer":       func(ctx context.Context) error { return f.reviewers.RemoveReviewer(ctx, "pr-1", "u2") },
+		"Reviewer.RecordReviewerChange": func(ctx context.Context) error {
+			return f.reviewers.RecordReviewerChange(ctx, &models.ReviewerChange{PRId: "pr-1", OldReviewerId: "u2",
+				Trigger: models.ReviewerChangeManual, ChangedAt: now})
+		},
+		"Reviewer.GetReviewerHistory":  func(ctx context.Context) error { return ignore(f.reviewers.GetReviewerHistory(ctx, "pr-1")) },
+		"Reviewer.GetReviewsChangedAt": func(ctx context.Context) error { return ignore(f.reviewers.GetReviewsChangedAt(ctx, "u2")) },
+		"Reviewer.CountReviewerChanges": func(ctx context.Context) error {
+			return ignore(f.reviewers.CountReviewerChanges(ctx, "", true))
+		},
+		"Reviewer.GetReassignmentCounts": func(ctx context.Context) error { return ignore(f.reviewers.GetReassignmentCounts(ctx, "", true)) },
+		"Reviewer.GetAssignmentSourceCounts": func(ctx context.Context) error {
+			return ignore(f.reviewers.GetAssignmentSourceCounts(ctx, "", true))
+		},
+		"Reviewer.GetReviewTurnarounds": func(ctx context.Context) error {
+			return ignore(f.reviewers.GetReviewTurnarounds(ctx, "", true))
+		},
+		"Reviewer.GetReviewerSummary": func(ctx context.Context) error {
+			return ignore(f.reviewers.GetReviewerSummary(ctx, "u1", true))
+		},
+		"Reviewer.FindRecentAssignments": func(ctx context.Context) error {
+			return ignore(f.reviewers.FindRecentAssignments(ctx, "u1", true, 10))
+		},
+		"Reviewer.GetReviewLoadHistogram": func(ctx context.Context) error {
+			return ignore(f.reviewers.GetReviewLoadHistogram(ctx, "backend", 5))
+		},
+		"Reviewer.GetReviewLoads": func(ctx context.Context) error {
+			return ignore(f.reviewers.GetReviewLoads(ctx, "backend"))
+		},
+
+		"Team.CreateTeam": func(ctx context.Context) error { return f.teams.CreateTeam(ctx, team) },
+		"Team.ImportTeam": func(ctx context.Context) error {
+			return f.teams.ImportTeam(ctx, team.Record(), func(yield func([]*models.User, error) bool) {
+				yield(team.Members, nil)
+			})
+		},
+		"Team.GetTeamByName": func(ctx context.Context) error { return ignore(f.teams.GetTeamByName(ctx, "backend")) },
+		"Team.ListTeams":     func(ctx context.Context) error { return ignore(f.teams.ListTeams(ctx)) },
+
+		"User.FindByID":       func(ctx context.Context) error { return ignore(f.users.FindByID(ctx, "u1")) },
+		"User.FindByIDs":      func(ctx context.Context) error { return ignore(f.users.FindByIDs(ctx, []string{"u1"})) },
+		"User.SetIsActive":    func(ctx context.Context) error { return f.users.SetIsActive(ctx, "u1", false) },
+		"User.SetIsReviewer":  func(ctx context.Context) error { return f.users.SetIsReviewer(ctx, "u1", true) },
+		"User.SetTags":        func(ctx context.Context) error { return f.users.SetTags(ctx, "u1", []string{"go"}) },
+		"User.UpdateUsername": func(ctx context.Context) error { return f.users.UpdateUsername(ctx, "u1", "Alice") },
+		"User.GetExcludedReviewers": func(ctx context.Context) error {
+			return ignore(f.users.GetExcludedReviewers(ctx, "u1"))
+		},
+		"User.FindActiveCandidatesForReassignment": func(ctx context.Context) error {
+			return ignore(f.users.FindActiveCandidatesForReassignment(ctx, "backend", []string{"u1"}))
+		},
+		"User.GetAllUsers":         func(ctx context.Context) error { return ignore(f.users.GetAllUsers(ctx)) },
+		"User.FindByTeamName":      func(ctx context.Context) error { return ignore(f.users.FindByTeamName(ctx, "backend")) },
+		"User.GetTeamLead":         func(ctx context.Context) error { return ignore(f.users.GetTeamLead(ctx, "backend")) },
+		"User.DeactivateTeamUsers": func(ctx context.Context) error { return ignore(f.users.DeactivateTeamUsers(ctx, "backend")) },
+
+		"Exclusion.Upsert": func(ctx context.Context) error {
+			return f.exclusions.Upsert(ctx, &models.ReviewerExclusion{ReviewerId: "u2", AuthorId: "u1"})
+		},
+		"Exclusion.Delete": func(ctx context.Context) error { return ignore(f.exclusions.Delete(ctx, "u2", "u1")) },
+		"Exclusion.List":   func(ctx context.Context) error { return ignore(f.exclusions.List(ctx, "u1")) },
+
+		"Archive.ArchiveMerged": func(ctx context.Context) error { return ignore(f.archive.ArchiveMerged(ctx, now, 10)) },
+		"Archive.Restore":       func(ctx context.Context) error { return ignore(f.archive.Restore(ctx, "pr-1")) },
+
+		"Snapshot.SaveSnapshots": func(ctx context.Context) error {
+			return f.snapshots.SaveSnapshots(ctx, []*models.StatisticsSnapshot{{Day: now, TakenAt: now}})
+		},
+		"Snapshot.HasSnapshot":   func(ctx context.Context) error { return ignore(f.snapshots.HasSnapshot(ctx, now)) },
+		"Snapshot.FindSnapshots": func(ctx context.Context) error { return ignore(f.snapshots.FindSnapshots(ctx, now, now)) },
+
+		"Metrics.GetBusinessMetrics": func(ctx context.Context) error { return ignore(f.metrics.GetBusinessMetrics(ctx)) },
+
+		"JobRun.SaveRun": func(ctx context.Context) error {
+			return f.jobRuns.SaveRun(ctx, &models.JobRun{Name: "job", StartedAt: now, FinishedAt: now})
+		},
+		"JobRun.GetRuns": func(ctx context.Context) error { return ignore(f.jobRuns.GetRuns(ctx)) },
+
+		"Lease.AcquireLease": func(ctx context.Context) error {
+			return ignore(f.leases.AcquireLease(ctx, "leader", "replica-1", time.Minute))
+		},
+		"Lease.ReleaseLease": func(ctx context.Context) error { return f.leases.ReleaseLease(ctx, "leader", "replica-1") },
+
+		"Duplicate.FindNearDuplicates": func(ctx context.Context) error { return ignore(f.duplicates.FindNearDuplicates(ctx)) },
+
+		"AdvisoryLocker.TryLock": func(ctx context.Context) error {
+			release, _, err := testStorage.NewAdvisoryLocker().TryLock(ctx, 1)
+			if release != nil {
+				release()
+			}
+			return err
+		},
+	}
+}
+
+func TestRepositories_CanceledContext(t *testing.T) {
+	f := newFixture(t)
+	f.team("backend", "u1", "u2", "u3")
+	f.pr("pr-1", "u1", time.Now().UTC(), "u2")
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	for name, call := range repositoryCalls(f) {
+		t.Run("Error - "+name+" with a canceled context", func(t *testing.T) {
+			assert.ErrorIs(t, call(canceled), context.Canceled)
+		})
+		t.Run("Error - "+name+" with an expired context", func(t *testing.T) {
+			assert.ErrorIs(t, call(expired), context.DeadlineExceeded)
+		})
+	}
+
+	// nothing was written by the calls above
+	reviewers, err := f.reviewers.GetReviewers(f.ctx, "pr-1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, reviewers)
+}
+
+func TestRepositories_CancellationStopsRunningQuery(t *testing.T) {
+	f := newFixture(t)
+	f.team("backend", "u1")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	// a statement still running when the caller gives up, like a slow statistics query
+	started := time.Now()
+	_, err := testStorage.pool.Exec(ctx, `SELECT pg_sleep(10)`)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(started), 5*time.Second, "the query must stop at the deadline")
+}
+
+func TestUnitOfWork_Deadlines(t *testing.T) {
+	f := newFixture(t)
+
+	t.Run("Error - Canceled context starts no transaction", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		called := false
+
+		err := f.uow.WithinTransaction(ctx, func(ctx context.Context) error {
+			called = true
+			return nil
+		})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.False(t, called)
+	})
+
+	t.Run("Success - Caller deadline is kept", func(t *testing.T) {
+		deadline := time.Now().Add(2 * time.Second)
+		ctx, cancel := context.WithDeadline(context.Background(), deadline)
+		defer cancel()
+
+		err := f.uow.WithinTransaction(ctx, func(ctx context.Context) error {
+			got, ok := ctx.Deadline()
+			assert.True(t, ok)
+			assert.True(t, got.Equal(deadline))
+			return nil
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Error - Transaction stops at the caller deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		started := time.Now()
+
+		err := f.uow.WithinTransaction(ctx, func(ctx context.Context) error {
+			_, err := getTx(ctx, testStorage.pool).Exec(ctx, `SELECT pg_sleep(10)`)
+			return err
+		})
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(started), 5*time.Second)
+	})
+}
@@ -0,0 +1,237 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// DumpRepository reads the tables of the organization of the context for an export and writes them
+// back for an import. The reads go to the primary and stream the rows, so they should run within a
+// transaction for the tables to be consistent with each other; so should the writes, for an import
+// to be all or nothing.
+type DumpRepository struct {
+	pool *pgxpool.Pool
+}
+
+// EachTeam calls fn for the metadata of every team, ordered by name.
+func (r *DumpRepository) EachTeam(ctx context.Context, fn func(*models.TeamRecord) error) error {
+	query := `SELECT name, description, COALESCE(lead_id, ''), created_at FROM team WHERE org_id = $1 ORDER BY name`
+
+	return eachRow(ctx, getTx(ctx, r.pool), query, "team", func(rows pgx.Rows) error {
+		var team models.TeamRecord
+		var createdAt *time.Time
+		if err := rows.Scan(&team.Name, &team.Description, &team.LeadId, &createdAt); err != nil {
+			return fmt.Errorf("failed to scan team: %w", err)
+		}
+		if createdAt != nil {
+			team.CreatedAt = *createdAt
+		}
+		return fn(&team)
+	})
+}
+
+// EachUser calls fn for every user, ordered by id.
+func (r *DumpRepository) EachUser(ctx context.Context, fn func(*models.User) error) error {
+	query := `SELECT id, username, team_name, is_active, max_active_reviews, tags, timezone, work_start, work_end, NOT is_reviewer
+	          FROM "user"
+	          WHERE org_id = $1
+	          ORDER BY id`
+
+	return eachRow(ctx, getTx(ctx, r.pool), query, "user", func(rows pgx.Rows) error {
+		var user models.User
+		if err := rows.Scan(&user.Id, &user.Name, &user.TeamName, &user.IsActive, &user.MaxActiveReviews, &user.Tags,
+			&user.Timezone, &user.WorkStart, &user.WorkEnd, &user.NonReviewer); err != nil {
+			return fmt.Errorf("failed to scan user: %w", err)
+		}
+		return fn(&user)
+	})
+}
+
+// EachPullRequest calls fn for every PR that is not archived, ordered by id, without reviewers.
+func (r *DumpRepository) EachPullRequest(ctx context.Context, fn func(*models.PullRequest) error) error {
+	query := `SELECT id, title, author_id, status, created_at, merged_at, updated_at, priority, labels, assignment_skipped
+	          FROM pull_request
+	          WHERE org_id = $1
+	          ORDER BY id`
+
+	return eachRow(ctx, getTx(ctx, r.pool), query, "pull request", func(rows pgx.Rows) error {
+		var pr models.PullRequest
+		if err := rows.Scan(&pr.Id, &pr.Title, &pr.AuthorId, &pr.Status,
+			&pr.CreatedAt, &pr.MergedAt, &pr.UpdatedAt, &pr.Priority, &pr.Labels, &pr.AssignmentSkipped); err != nil {
+			return fmt.Errorf("failed to scan pull request: %w", err)
+		}
+		return fn(&pr)
+	})
+}
+
+// EachAssignment calls fn for every reviewer assignment of PRs that are not archived, ordered by
+// PR id and reviewer id.
+func (r *DumpRepository) EachAssignment(ctx context.Context, fn func(*models.ReviewAssignment) error) error {
+	query := `SELECT pr_id, reviewer_id, assigned_at, source, state, state_changed_at
+	          FROM pr_reviewer
+	          WHERE org_id = $1
+	          ORDER BY pr_id, reviewer_id`
+
+	return eachRow(ctx, getTx(ctx, r.pool), query, "assignment", func(rows pgx.Rows) error {
+		var assignment models.ReviewAssignment
+		if err := rows.Scan(&assignment.PRId, &assignment.ReviewerId, &assignment.AssignedAt, &assignment.Source,
+			&assignment.State, &assignment.StateChangedAt); err != nil {
+			return fmt.Errorf("failed to scan assignment: %w", err)
+		}
+		return fn(&assignment)
+	})
+}
+
+// IsEmpty reports whether no users, teams or PRs, archived or not, are stored.
+func (r *DumpRepository) IsEmpty(ctx context.Context) (bool, error) {
+	query := `SELECT NOT (EXISTS(SELECT 1 FROM "user" WHERE org_id = $1) OR EXISTS(SELECT 1 FROM team WHERE org_id = $1)
+	              OR EXISTS(SELECT 1 FROM pull_request WHERE org_id = $1)
+	              OR EXISTS(SELECT 1 FROM pull_request_archive WHERE org_id = $1))`
+
+	var empty bool
+	if err := getTx(ctx, r.pool).QueryRow(ctx, query, orgctx.ID(ctx)).Scan(&empty); err != nil {
+		return false, fmt.Errorf("failed to check for stored data: %w", err)
+	}
+	return empty, nil
+}
+
+// Clear deletes all users, teams, PRs and everything about them of the organization; webhook
+// deliveries and statistics snapshots are kept, as are the changes of review lists, to which the
+// emptied lists are added.
+func (r *DumpRepository) Clear(ctx context.Context) error {
+	changeQuery := `INSERT INTO review_change (reviewer_id, changed_at, org_id)
+	                SELECT DISTINCT reviewer_id, $1::timestamptz, org_id FROM pr_reviewer WHERE org_id = $2`
+	// the tables go in the order of their foreign keys; the other organizations are left as they are
+	queries := []string{
+		`DELETE FROM reviewer_assignment_event WHERE org_id = $1`,
+		`DELETE FROM reviewer_exclusion WHERE org_id = $1`,
+		`DELETE FROM pr_reviewer WHERE org_id = $1`,
+		`DELETE FROM pr_reviewer_archive WHERE org_id = $1`,
+		`DELETE FROM pull_request WHERE org_id = $1`,
+		`DELETE FROM pull_request_archive WHERE org_id = $1`,
+		`DELETE FROM team WHERE org_id = $1`,
+		`DELETE FROM "user" WHERE org_id = $1`,
+	}
+
+	orgID := orgctx.ID(ctx)
+	executor := getTx(ctx, r.pool)
+	if _, err := executor.Exec(ctx, changeQuery, time.Now().UTC(), orgID); err != nil {
+		return fmt.Errorf("failed to record review changes: %w", err)
+	}
+	for _, q := range queries {
+		if _, err := executor.Exec(ctx, q, orgID); err != nil {
+			return fmt.Errorf("failed to clear data: %w", err)
+		}
+	}
+	return nil
+}
+
+// InsertUsers inserts the users with one batch of statements.
+func (r *DumpRepository) InsertUsers(ctx context.Context, users []*models.User) error {
+	query := `INSERT INTO "user" (id, username, team_name, is_active, max_active_reviews, tags, timezone, work_start, work_end,
+	                              is_reviewer, org_id)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	batch := &pgx.Batch{}
+	for _, u := range users {
+		batch.Queue(query, u.Id, u.Name, u.TeamName, u.IsActive, u.MaxActiveReviews, textArray(u.Tags),
+			u.Timezone, u.WorkStart, u.WorkEnd, !u.NonReviewer, orgctx.ID(ctx))
+	}
+	return execBatch(ctx, getTx(ctx, r.pool), batch, "users")
+}
+
+// InsertTeams inserts the metadata of the teams with one batch of statements. A zero CreatedAt is
+// stored as unknown.
+func (r *DumpRepository) InsertTeams(ctx context.Context, teams []*models.TeamRecord) error {
+	query := `INSERT INTO team (name, description, lead_id, created_at, org_id) VALUES ($1, $2, NULLIF($3, ''), $4, $5)`
+
+	batch := &pgx.Batch{}
+	for _, t := range teams {
+		var createdAt *time.Time
+		if !t.CreatedAt.IsZero() {
+			createdAt = &t.CreatedAt
+		}
+		batch.Queue(query, t.Name, t.Description, t.LeadId, createdAt, orgctx.ID(ctx))
+	}
+	return execBatch(ctx, getTx(ctx, r.pool), batch, "teams")
+}
+
+// InsertPullRequests inserts the PRs without reviewers with one batch of statements.
+func (r *DumpRepository) InsertPullRequests(ctx context.Context, prs []*models.PullRequest) error {
+	query := `INSERT INTO pull_request
+	              (id, title, author_id, status, created_at, merged_at, updated_at, priority, labels, assignment_skipped,
+	               org_id)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	batch := &pgx.Batch{}
+	for _, pr := range prs {
+		batch.Queue(query, pr.Id, pr.Title, pr.AuthorId, pr.Status, pr.CreatedAt, pr.MergedAt, pr.UpdatedAt,
+			pr.Priority, textArray(pr.Labels), pr.AssignmentSkipped, orgctx.ID(ctx))
+	}
+	return execBatch(ctx, getTx(ctx, r.pool), batch, "pull requests")
+}
+
+// InsertAssignments inserts the assignments as they are, keeping their source and state, with one
+// batch of statements that also counts them in the reviewer_count of their PR and records the
+// change of the review lists now, as the restored assignment times may be older than a list a
+// client has seen.
+func (r *DumpRepository) InsertAssignments(ctx context.Context, assignments []*models.ReviewAssignment) error {
+	query := `WITH inserted AS (
+	              INSERT INTO pr_reviewer (pr_id, reviewer_id, assigned_at, source, state, state_changed_at, org_id)
+	              VALUES ($1, $2, $3, $4, $5, $6, $8)
+	              RETURNING pr_id, reviewer_id
+	          ), changed AS (
+	              INSERT INTO review_change (reviewer_id, changed_at, org_id)
+	              SELECT reviewer_id, $7, $8 FROM inserted
+	          )
+	          UPDATE pull_request SET reviewer_count = reviewer_count + 1
+	          WHERE org_id = $8 AND id IN (SELECT pr_id FROM inserted)`
+
+	now := time.Now().UTC()
+	batch := &pgx.Batch{}
+	for _, a := range assignments {
+		batch.Queue(query, a.PRId, a.ReviewerId, a.AssignedAt, a.Source, a.State, a.StateChangedAt, now,
+			orgctx.ID(ctx))
+	}
+	return execBatch(ctx, getTx(ctx, r.pool), batch, "assignments")
+}
+
+// execBatch sends the batch and checks the result of every statement.
+func execBatch(ctx context.Context, executor txOrPool, batch *pgx.Batch, entity string) error {
+	results := executor.SendBatch(ctx, batch)
+	defer results.Close()
+
+	for range batch.Len() {
+		if _, err := results.Exec(); err != nil {
+			return fmt.Errorf("failed to insert %s: %w", entity, err)
+		}
+	}
+	return nil
+}
+
+// eachRow runs the query on the rows of the organization of the context, passed as $1, and calls
+// scan for every row, stopping at the first error of scan.
+func eachRow(ctx context.Context, executor txOrPool, query, entity string, scan func(pgx.Rows) error) error {
+	rows, err := executor.Query(ctx, query, orgctx.ID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to read %ss: %w", entity, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err = scan(rows); err != nil {
+			return err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return nil
+}
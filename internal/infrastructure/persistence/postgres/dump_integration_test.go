@@ -0,0 +1,157 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDumpRepository(t *testing.T) {
+	f := newFixture(t)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	f.team("frontend", "u3")
+	assert.NoError(t, f.teams.CreateTeam(f.ctx, &models.Team{
+		Description: "Core services", LeadId: "u1", CreatedAt: now, Members: []*models.User{
+			{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+			{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+		}}))
+	f.pr("pr-2", "u1", now, "u2")
+	f.pr("pr-1", "u2", now, "u1", "u3")
+	f.merge("pr-2")
+
+	t.Run("Success - Tables are read in key order", func(t *testing.T) {
+		var teams []*models.TeamRecord
+		var users []string
+		var prs []*models.PullRequest
+		var assignments [][2]string
+		err := f.inTx(func(ctx context.Context) error {
+			if err := f.dump.EachTeam(ctx, func(team *models.TeamRecord) error {
+				teams = append(teams, team)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := f.dump.EachUser(ctx, func(user *models.User) error {
+				users = append(users, user.Id)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := f.dump.EachPullRequest(ctx, func(pr *models.PullRequest) error {
+				prs = append(prs, pr)
+				return nil
+			}); err != nil {
+				return err
+			}
+			return f.dump.EachAssignment(ctx, func(assignment *models.ReviewAssignment) error {
+				assignments = append(assignments, [2]string{assignment.PRId, assignment.ReviewerId})
+				return nil
+			})
+		})
+
+		assert.NoError(t, err)
+		if assert.Len(t, teams, 2) {
+			assert.Equal(t, "backend", teams[0].Name)
+			assert.Equal(t, "Core services", teams[0].Description)
+			assert.Equal(t, "u1", teams[0].LeadId)
+			assert.Equal(t, now, teams[0].CreatedAt)
+			assert.Equal(t, "frontend", teams[1].Name)
+			assert.True(t, teams[1].CreatedAt.IsZero())
+		}
+		assert.Equal(t, []string{"u1", "u2", "u3"}, users)
+		if assert.Len(t, prs, 2) {
+			assert.Equal(t, "pr-1", prs[0].Id)
+			assert.Equal(t, now, prs[0].CreatedAt)
+			assert.Equal(t, models.PRStatusMerged, prs[1].Status)
+			assert.NotNil(t, prs[1].MergedAt)
+		}
+		assert.Equal(t, [][2]string{{"pr-1", "u1"}, {"pr-1", "u3"}, {"pr-2", "u2"}}, assignments)
+	})
+
+	t.Run("Error - Reading stops at the first error of fn", func(t *testing.T) {
+		calls := 0
+		err := f.dump.EachUser(f.ctx, func(*models.User) error {
+			calls++
+			return assert.AnError
+		})
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Success - Cleared tables are written back", func(t *testing.T) {
+		empty, err := f.dump.IsEmpty(f.ctx)
+		assert.NoError(t, err)
+		assert.False(t, empty)
+
+		changedAt := now.Add(time.Minute)
+		err = f.inTx(func(ctx context.Context) error {
+			if err := f.dump.Clear(ctx); err != nil {
+				return err
+			}
+			if err := f.dump.InsertUsers(ctx, []*models.User{
+				{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true, Tags: []string{"go"}},
+				{Id: "u2", Name: "Bob", TeamName: "backend"},
+			}); err != nil {
+				return err
+			}
+			if err := f.dump.InsertTeams(ctx, []*models.TeamRecord{
+				{Name: "backend", Description: "Core services", LeadId: "u1", CreatedAt: now},
+				{Name: "frontend"},
+			}); err != nil {
+				return err
+			}
+			if err := f.dump.InsertPullRequests(ctx, []*models.PullRequest{{
+				Id: "pr-1", Title: "Add search", AuthorId: "u1", Status: models.PRStatusMerged, CreatedAt: now,
+				UpdatedAt: changedAt, MergedAt: &changedAt, Priority: models.PRPriorityHigh,
+			}}); err != nil {
+				return err
+			}
+			return f.dump.InsertAssignments(ctx, []*models.ReviewAssignment{{
+				PRId: "pr-1", ReviewerId: "u2", AssignedAt: now, Source: models.AssignmentSourceManual,
+				State: models.ReviewStateApproved, StateChangedAt: &changedAt,
+			}})
+		})
+		assert.NoError(t, err)
+
+		team, err := f.teams.GetTeamByName(f.ctx, "backend")
+		assert.NoError(t, err)
+		assert.Equal(t, "u1", team.LeadId)
+		assert.Len(t, team.Members, 2)
+		pr, err := f.prs.FindByID(f.ctx, "pr-1")
+		assert.NoError(t, err)
+		assert.Equal(t, models.PRStatusMerged, pr.Status)
+		assert.Equal(t, 1, f.reviewerCount("pr-1"))
+		assignments, err := f.reviewers.GetAssignmentsByPRs(f.ctx, []string{"pr-1"})
+		assert.NoError(t, err)
+		if assert.Len(t, assignments, 1) {
+			assert.Equal(t, models.ReviewStateApproved, assignments[0].State)
+			assert.Equal(t, models.AssignmentSourceManual, assignments[0].Source)
+		}
+	})
+
+	t.Run("Error - Insert fails on a missing reference", func(t *testing.T) {
+		err := f.inTx(func(ctx context.Context) error {
+			return f.dump.InsertAssignments(ctx, []*models.ReviewAssignment{{
+				PRId: "pr-1", ReviewerId: "ghost", AssignedAt: now, Source: models.AssignmentSourceAuto,
+				State: models.ReviewStatePending,
+			}})
+		})
+
+		assert.Error(t, err)
+		assert.Equal(t, 1, f.reviewerCount("pr-1"))
+	})
+
+	t.Run("Success - Cleared storage is empty", func(t *testing.T) {
+		assert.NoError(t, f.dump.Clear(f.ctx))
+
+		empty, err := f.dump.IsEmpty(f.ctx)
+		assert.NoError(t, err)
+		assert.True(t, empty)
+	})
+}
@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// DuplicateRepository looks for near-duplicate identifiers.
+type DuplicateRepository struct {
+	pool    *pgxpool.Pool
+	replica *pgxpool.Pool
+}
+
+// FindNearDuplicates returns the groups of user ids, team names and PR ids, archived PRs included,
+// that are equal once trimmed and folded to lower case, ordered by kind and key.
+func (r *DuplicateRepository) FindNearDuplicates(ctx context.Context) ([]models.NearDuplicate, error) {
+	// btrim without characters only trims spaces; the keys are trimmed like strings.TrimSpace
+	// trims the identifiers of requests
+	query := `WITH ids AS (
+	              SELECT 'user' AS kind, id FROM "user" WHERE org_id = $1
+	              UNION
+	              SELECT 'team', name FROM team WHERE org_id = $1
+	              UNION
+	              SELECT 'team', team_name FROM "user" WHERE org_id = $1
+	              UNION
+	              SELECT 'pull_request', id FROM pull_request WHERE org_id = $1
+	              UNION
+	              SELECT 'pull_request', id FROM pull_request_archive WHERE org_id = $1
+	          )
+	          SELECT kind, lower(btrim(id, E' \t\n\r\v\f')) AS key, array_agg(id ORDER BY id COLLATE "C")
+	          FROM ids
+	          GROUP BY kind, key
+	          HAVING COUNT(*) > 1
+	          ORDER BY kind COLLATE "C", key COLLATE "C"`
+
+	rows, err := getReader(ctx, r.pool, r.replica).Query(ctx, query, orgctx.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find near-duplicate identifiers: %w", err)
+	}
+	defer rows.Close()
+
+	var duplicates []models.NearDuplicate
+	for rows.Next() {
+		var d models.NearDuplicate
+		if err = rows.Scan(&d.Kind, &d.Key, &d.Ids); err != nil {
+			return nil, fmt.Errorf("failed to scan near-duplicate identifiers: %w", err)
+		}
+		duplicates = append(duplicates, d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return duplicates, nil
+}
@@ -0,0 +1,30 @@
+//go:build integration
+
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuplicateRepository_FindNearDuplicates(t *testing.T) {
+	f := newFixture(t)
+	now := time.Now().UTC()
+	f.team("backend", "user-1", "user-1 ", "user-2")
+	f.team("Backend", "user-3")
+	f.pr("pr-1", "user-2", now)
+	f.pr("PR-1", "user-2", now)
+
+	duplicates, err := f.duplicates.FindNearDuplicates(f.ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, []models.NearDuplicate{
+		{Kind: models.IdentifierKindPullRequest, Key: "pr-1", Ids: []string{"PR-1", "pr-1"}},
+		{Kind: models.IdentifierKindTeam, Key: "backend", Ids: []string{"Backend", "backend"}},
+		{Kind: models.IdentifierKindUser, Key: "user-1", Ids: []string{"user-1", "user-1 "}},
+	}, duplicates)
+}
@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	// pgUniqueViolation is the SQLSTATE code of unique_violation.
+	pgUniqueViolation = "23505"
+	// pgCheckViolation is the SQLSTATE code of check_violation.
+	pgCheckViolation = "23514"
+	// pgSerializationFailure is the SQLSTATE code of serialization_failure.
+	pgSerializationFailure = "40001"
+	// pgUndefinedTable is the SQLSTATE code of undefined_table.
+	pgUndefinedTable = "42P01"
+)
+
+// isUniqueViolation reports whether err is caused by a unique constraint violation.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation
+}
+
+// reviewerCountCheck is the constraint capping the reviewers of a PR.
+const reviewerCountCheck = "pull_request_reviewer_count_check"
+
+// isCheckViolation reports whether err is caused by a violation of the named check constraint.
+func isCheckViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgCheckViolation && pgErr.ConstraintName == constraint
+}
+
+// isSerializationFailure reports whether err is caused by a concurrent update
+// of a row locked inside a Repeatable Read transaction.
+func isSerializationFailure(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgSerializationFailure
+}
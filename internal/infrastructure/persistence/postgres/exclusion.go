@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// ExclusionRepository manages reviewer exclusions in the database.
+type ExclusionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// Upsert creates the exclusion or updates whether it is mutual. CreatedAt is filled from the database.
+func (r *ExclusionRepository) Upsert(ctx context.Context, exclusion *models.ReviewerExclusion) error {
+	query := `INSERT INTO reviewer_exclusion (reviewer_id, author_id, mutual, org_id)
+	          VALUES ($1, $2, $3, $4)
+	          ON CONFLICT (org_id, reviewer_id, author_id) DO UPDATE SET mutual = EXCLUDED.mutual
+	          RETURNING created_at`
+
+	executor := getTx(ctx, r.pool)
+	err := executor.QueryRow(ctx, query, exclusion.ReviewerId, exclusion.AuthorId, exclusion.Mutual, orgctx.ID(ctx)).
+		Scan(&exclusion.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert exclusion: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the exclusion of the reviewer for the author, including a mutual one
+// stored the other way round. Returns the number of removed exclusions.
+func (r *ExclusionRepository) Delete(ctx context.Context, reviewerID, authorID string) (int, error) {
+	query := `DELETE FROM reviewer_exclusion
+	          WHERE org_id = $3
+	            AND ((reviewer_id = $1 AND author_id = $2) OR (reviewer_id = $2 AND author_id = $1 AND mutual))`
+
+	executor := getTx(ctx, r.pool)
+	result, err := executor.Exec(ctx, query, reviewerID, authorID, orgctx.ID(ctx))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete exclusion: %w", err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+// List returns exclusions involving the user, or all of them when userID is empty,
+// ordered by reviewer and author.
+func (r *ExclusionRepository) List(ctx context.Context, userID string) ([]*models.ReviewerExclusion, error) {
+	query := `SELECT reviewer_id, author_id, mutual, created_at
+	          FROM reviewer_exclusion
+	          WHERE org_id = $2 AND ($1 = '' OR reviewer_id = $1 OR author_id = $1)
+	          ORDER BY reviewer_id, author_id`
+
+	executor := getTx(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, userID, orgctx.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exclusions: %w", err)
+	}
+	defer rows.Close()
+
+	var exclusions []*models.ReviewerExclusion
+	for rows.Next() {
+		var exclusion models.ReviewerExclusion
+		if err = rows.Scan(&exclusion.ReviewerId, &exclusion.AuthorId, &exclusion.Mutual, &exclusion.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan exclusion: %w", err)
+		}
+		exclusions = append(exclusions, &exclusion)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return exclusions, nil
+}
@@ -0,0 +1,60 @@
+//go:build integration
+
+package postgres
+
+import (
+	"testing"
+
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExclusionRepository(t *testing.T) {
+	f := newFixture(t)
+	f.team("backend", "u1", "u2", "u3")
+
+	t.Run("Success - Upsert fills created_at and updates mutual", func(t *testing.T) {
+		exclusion := &models.ReviewerExclusion{ReviewerId: "u2", AuthorId: "u1", Mutual: true}
+		assert.NoError(t, f.exclusions.Upsert(f.ctx, exclusion))
+		assert.False(t, exclusion.CreatedAt.IsZero())
+
+		again := &models.ReviewerExclusion{ReviewerId: "u2", AuthorId: "u1"}
+		assert.NoError(t, f.exclusions.Upsert(f.ctx, again))
+		assert.True(t, exclusion.CreatedAt.Equal(again.CreatedAt))
+
+		exclusions, err := f.exclusions.List(f.ctx, "")
+		assert.NoError(t, err)
+		assert.Len(t, exclusions, 1)
+		assert.False(t, exclusions[0].Mutual)
+	})
+
+	t.Run("Error - Self exclusion", func(t *testing.T) {
+		err := f.exclusions.Upsert(f.ctx, &models.ReviewerExclusion{ReviewerId: "u1", AuthorId: "u1"})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Success - List by user", func(t *testing.T) {
+		assert.NoError(t, f.exclusions.Upsert(f.ctx, &models.ReviewerExclusion{ReviewerId: "u3", AuthorId: "u2"}))
+
+		exclusions, err := f.exclusions.List(f.ctx, "u1")
+		assert.NoError(t, err)
+		assert.Len(t, exclusions, 1)
+
+		exclusions, err = f.exclusions.List(f.ctx, "u2")
+		assert.NoError(t, err)
+		assert.Len(t, exclusions, 2)
+	})
+
+	t.Run("Success - Delete removes a mutual pair given either way", func(t *testing.T) {
+		assert.NoError(t, f.exclusions.Upsert(f.ctx, &models.ReviewerExclusion{ReviewerId: "u1", AuthorId: "u3", Mutual: true}))
+
+		removed, err := f.exclusions.Delete(f.ctx, "u3", "u1")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, removed)
+
+		removed, err = f.exclusions.Delete(f.ctx, "u3", "u1")
+		assert.NoError(t, err)
+		assert.Equal(t, 0, removed)
+	})
+}
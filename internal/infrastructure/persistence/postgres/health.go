@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// HealthRepository reports the state of the database for the readiness checks.
+type HealthRepository struct {
+	pool *pgxpool.Pool
+}
+
+// Ping checks that the primary answers.
+func (r *HealthRepository) Ping(ctx context.Context) error {
+	if err := r.pool.Ping(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+	return nil
+}
+
+// MigrationVersion returns the version recorded by the migrator and whether its last migration
+// failed halfway. A database the migrator never ran on is at version zero.
+func (r *HealthRepository) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	query := `SELECT version, dirty FROM schema_migrations LIMIT 1`
+
+	var version int64
+	var dirty bool
+	err := r.pool.QueryRow(ctx, query).Scan(&version, &dirty)
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, pgx.ErrNoRows), errors.As(err, &pgErr) && pgErr.Code == pgUndefinedTable:
+		return 0, false, nil
+	case err != nil:
+		return 0, false, fmt.Errorf("failed to read migration version: %w", err)
+	}
+	return uint(version), dirty, nil
+}
@@ -0,0 +1,38 @@
+//go:build integration
+
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthRepository(t *testing.T) {
+	f := newFixture(t)
+	health := testStorage.NewHealthRepository()
+
+	t.Run("Success - Ping", func(t *testing.T) {
+		assert.NoError(t, health.Ping(f.ctx))
+	})
+
+	t.Run("Success - Database the migrator never ran on is at version zero", func(t *testing.T) {
+		version, dirty, err := health.MigrationVersion(f.ctx)
+
+		assert.NoError(t, err)
+		assert.Zero(t, version)
+		assert.False(t, dirty)
+	})
+
+	t.Run("Success - Version recorded by the migrator", func(t *testing.T) {
+		f.exec(`CREATE TABLE schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)`)
+		t.Cleanup(func() { f.exec(`DROP TABLE schema_migrations`) })
+		f.exec(`INSERT INTO schema_migrations VALUES (21, true)`)
+
+		version, dirty, err := health.MigrationVersion(f.ctx)
+
+		assert.NoError(t, err)
+		assert.EqualValues(t, 21, version)
+		assert.True(t, dirty)
+	})
+}
@@ -0,0 +1,233 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/testcontainers/testcontainers-go"
+	tcpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+)
+
+// testStorage is connected to a throwaway Postgres with all migrations applied.
+var testStorage *Storage
+
+// testConfig is the connection config of testStorage, for tests needing a database of their own.
+var testConfig *pgxpool.Config
+
+func TestMain(m *testing.M) {
+	os.Exit(runWithDatabase(m))
+}
+
+// runWithDatabase starts Postgres in a container, applies the migrations and runs the tests.
+func runWithDatabase(m *testing.M) int {
+	ctx := context.Background()
+
+	container, err := tcpostgres.Run(ctx, "postgres:16",
+		tcpostgres.WithDatabase("pr_reviewer"),
+		tcpostgres.WithUsername("postgres"),
+		tcpostgres.WithPassword("postgres"),
+		tcpostgres.BasicWaitStrategies(),
+	)
+	if err != nil {
+		log.Printf("failed to start postgres container: %v", err)
+		return 1
+	}
+	defer func() {
+		if err := testcontainers.TerminateContainer(container); err != nil {
+			log.Printf("failed to terminate postgres container: %v", err)
+		}
+	}()
+
+	dsn, err := container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		log.Printf("failed to get connection string: %v", err)
+		return 1
+	}
+	poolCfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		log.Printf("failed to parse connection config: %v", err)
+		return 1
+	}
+	// same as NewStorage: timestamps are read back in UTC
+	poolCfg.ConnConfig.RuntimeParams["timezone"] = "UTC"
+	testConfig = poolCfg.Copy()
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		log.Printf("failed to create connection pool: %v", err)
+		return 1
+	}
+	defer pool.Close()
+
+	if err = applyMigrations(ctx, pool, "migrations"); err != nil {
+		log.Printf("failed to apply migrations: %v", err)
+		return 1
+	}
+
+	testStorage = &Storage{pool: pool}
+	return m.Run()
+}
+
+// applyMigrations runs the up migrations from dir in order.
+func applyMigrations(ctx context.Context, pool *pgxpool.Pool, dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.up.sql"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		sql, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		// without arguments pgx uses the simple protocol, which allows several statements
+		if _, err = pool.Exec(ctx, string(sql)); err != nil {
+			return fmt.Errorf("%s: %w", filepath.Base(file), err)
+		}
+	}
+	return nil
+}
+
+// fixture gives a test an empty database and the repositories under test.
+// Tests using it must not run in parallel.
+type fixture struct {
+	t          *testing.T
+	ctx        context.Context
+	prs        *PullRequestRepository
+	reviewers  *ReviewerRepository
+	users      *UserRepository
+	teams      *TeamRepository
+	exclusions *ExclusionRepository
+	archive    *ArchiveRepository
+	webhooks   *WebhookRepository
+	snapshots  *SnapshotRepository
+	metrics    *MetricsRepository
+	duplicates *DuplicateRepository
+	dump       *DumpRepository
+	jobRuns    *JobRunRepository
+	leases     *LeaseRepository
+	orgs       *OrgRepository
+	uow        *UnitOfWork
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	f := &fixture{
+		t:          t,
+		ctx:        context.Background(),
+		prs:        testStorage.NewPullRequestRepository(),
+		reviewers:  testStorage.NewReviewerRepository(),
+		users:      testStorage.NewUserRepository(),
+		teams:      testStorage.NewTeamRepository(),
+		exclusions: testStorage.NewExclusionRepository(),
+		archive:    testStorage.NewArchiveRepository(),
+		webhooks:   testStorage.NewWebhookRepository(),
+		snapshots:  testStorage.NewSnapshotRepository(),
+		metrics:    testStorage.NewMetricsRepository(),
+		duplicates: testStorage.NewDuplicateRepository(),
+		dump:       testStorage.NewDumpRepository(),
+		jobRuns:    testStorage.NewJobRunRepository(),
+		leases:     testStorage.NewLeaseRepository(),
+		orgs:       testStorage.NewOrgRepository(),
+		uow:        testStorage.NewUnitOfWork(),
+	}
+	f.exec(`TRUNCATE reviewer_assignment_event, reviewer_exclusion, pr_reviewer_archive, pull_request_archive,
+		pr_reviewer, pull_request, team, "user", webhook_delivery_attempt, webhook_delivery,
+		statistics_snapshot, review_change, job_run, leader_lease CASCADE`)
+	return f
+}
+
+// inOrg returns a fixture working on the organization, sharing the database and the repositories.
+func (f *fixture) inOrg(orgID string) *fixture {
+	org := *f
+	org.ctx = orgctx.WithID(f.ctx, orgID)
+	return &org
+}
+
+// exec runs a statement directly, failing the test on error.
+func (f *fixture) exec(sql string, args ...any) {
+	f.t.Helper()
+	if _, err := testStorage.pool.Exec(f.ctx, sql, args...); err != nil {
+		f.t.Fatalf("failed to exec %q: %v", sql, err)
+	}
+}
+
+// team creates a team of active members with the given IDs; usernames equal the IDs.
+func (f *fixture) team(teamName string, userIDs ...string) *models.Team {
+	f.t.Helper()
+	team := &models.Team{}
+	for _, userID := range userIDs {
+		team.Members = append(team.Members, &models.User{Id: userID, Name: userID, TeamName: teamName, IsActive: true})
+	}
+	if err := f.teams.CreateTeam(f.ctx, team); err != nil {
+		f.t.Fatalf("failed to create team %s: %v", teamName, err)
+	}
+	return team
+}
+
+// pr creates an open NORMAL PR by the author created at the given time and assigns the reviewers.
+func (f *fixture) pr(prID, authorID string, createdAt time.Time, reviewerIDs ...string) *models.PullRequest {
+	f.t.Helper()
+	pr := &models.PullRequest{
+		Id:        prID,
+		Title:     "PR " + prID,
+		AuthorId:  authorID,
+		Status:    models.PRStatusOpen,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+		Priority:  models.PRPriorityNormal,
+		Labels:    []string{},
+	}
+	if err := f.prs.Create(f.ctx, pr); err != nil {
+		f.t.Fatalf("failed to create PR %s: %v", prID, err)
+	}
+	for _, reviewerID := range reviewerIDs {
+		if err := f.reviewers.AssignReviewer(f.ctx, prID, reviewerID, models.AssignmentSourceAuto); err != nil {
+			f.t.Fatalf("failed to assign %s to %s: %v", reviewerID, prID, err)
+		}
+	}
+	return pr
+}
+
+// merge marks the PR as merged.
+func (f *fixture) merge(prID string) {
+	f.t.Helper()
+	mergedAt := time.Now().UTC()
+	if _, err := f.prs.UpdateStatus(f.ctx, prID, models.PRStatusOpen, models.PRStatusMerged, &mergedAt); err != nil {
+		f.t.Fatalf("failed to merge PR %s: %v", prID, err)
+	}
+}
+
+// setAssignedAt moves the assignment time of the reviewer on the PR.
+func (f *fixture) setAssignedAt(prID, reviewerID string, at time.Time) {
+	f.t.Helper()
+	f.exec(`UPDATE pr_reviewer SET assigned_at = $3 WHERE pr_id = $1 AND reviewer_id = $2 AND org_id = $4`,
+		prID, reviewerID, at, orgctx.ID(f.ctx))
+}
+
+// reviewerCount reads the reviewer count kept on the PR.
+func (f *fixture) reviewerCount(prID string) int {
+	f.t.Helper()
+	var count int
+	err := testStorage.pool.QueryRow(f.ctx, `SELECT reviewer_count FROM pull_request WHERE id = $1 AND org_id = $2`,
+		prID, orgctx.ID(f.ctx)).Scan(&count)
+	if err != nil {
+		f.t.Fatalf("failed to read reviewer count of %s: %v", prID, err)
+	}
+	return count
+}
+
+// inTx runs fn inside a transaction of the unit of work and returns its error.
+func (f *fixture) inTx(fn func(ctx context.Context) error) error {
+	return f.uow.WithinTransaction(f.ctx, fn)
+}
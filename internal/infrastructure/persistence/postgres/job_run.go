@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// JobRunRepository keeps the last run of every scheduled job in the database.
+type JobRunRepository struct {
+	pool *pgxpool.Pool
+}
+
+// SaveRun records the run as the last one of its job; LastSuccessAt is moved to its end when it
+// succeeded and kept otherwise.
+func (r *JobRunRepository) SaveRun(ctx context.Context, run *models.JobRun) error {
+	query := `INSERT INTO job_run (name, started_at, finished_at, error, last_success_at)
+	          VALUES ($1, $2, $3, NULLIF($4, ''), CASE WHEN $4 = '' THEN $3::timestamptz END)
+	          ON CONFLICT (name) DO UPDATE
+	          SET started_at = EXCLUDED.started_at,
+	              finished_at = EXCLUDED.finished_at,
+	              error = EXCLUDED.error,
+	              last_success_at = COALESCE(EXCLUDED.last_success_at, job_run.last_success_at)`
+
+	if _, err := getTx(ctx, r.pool).Exec(ctx, query, run.Name, run.StartedAt, run.FinishedAt, run.Error); err != nil {
+		return fmt.Errorf("failed to save job run: %w", err)
+	}
+	return nil
+}
+
+// GetRuns returns the last run of every job that ran, keyed by job name.
+func (r *JobRunRepository) GetRuns(ctx context.Context) (map[string]*models.JobRun, error) {
+	query := `SELECT name, started_at, finished_at, COALESCE(error, ''), last_success_at FROM job_run`
+
+	rows, err := getTx(ctx, r.pool).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job runs: %w", err)
+	}
+	defer rows.Close()
+
+	runs := make(map[string]*models.JobRun)
+	for rows.Next() {
+		var run models.JobRun
+		if err = rows.Scan(&run.Name, &run.StartedAt, &run.FinishedAt, &run.Error, &run.LastSuccessAt); err != nil {
+			return nil, fmt.Errorf("failed to scan job run: %w", err)
+		}
+		runs[run.Name] = &run
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return runs, nil
+}
@@ -0,0 +1,55 @@
+//go:build integration
+
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobRunRepository(t *testing.T) {
+	f := newFixture(t)
+	at := time.Now().UTC().Truncate(time.Microsecond)
+
+	t.Run("Success - No runs", func(t *testing.T) {
+		runs, err := f.jobRuns.GetRuns(f.ctx)
+
+		require.NoError(t, err)
+		assert.Empty(t, runs)
+	})
+
+	t.Run("Success - Last run replaces the previous one", func(t *testing.T) {
+		require.NoError(t, f.jobRuns.SaveRun(f.ctx, &models.JobRun{Name: "escalation", StartedAt: at,
+			FinishedAt: at.Add(time.Second)}))
+		require.NoError(t, f.jobRuns.SaveRun(f.ctx, &models.JobRun{Name: "webhook", StartedAt: at, FinishedAt: at}))
+		require.NoError(t, f.jobRuns.SaveRun(f.ctx, &models.JobRun{Name: "escalation", StartedAt: at.Add(time.Minute),
+			FinishedAt: at.Add(2 * time.Minute), Error: "database is down"}))
+
+		runs, err := f.jobRuns.GetRuns(f.ctx)
+
+		require.NoError(t, err)
+		require.Len(t, runs, 2)
+		run := runs["escalation"]
+		require.NotNil(t, run)
+		assert.True(t, at.Add(time.Minute).Equal(run.StartedAt))
+		assert.True(t, at.Add(2*time.Minute).Equal(run.FinishedAt))
+		assert.Equal(t, "database is down", run.Error)
+		require.NotNil(t, run.LastSuccessAt, "a failed run keeps the last success")
+		assert.True(t, at.Add(time.Second).Equal(*run.LastSuccessAt))
+		assert.Empty(t, runs["webhook"].Error)
+	})
+
+	t.Run("Success - Failing from the start leaves no success", func(t *testing.T) {
+		require.NoError(t, f.jobRuns.SaveRun(f.ctx, &models.JobRun{Name: "snapshot", StartedAt: at, FinishedAt: at,
+			Error: "boom"}))
+
+		runs, err := f.jobRuns.GetRuns(f.ctx)
+
+		require.NoError(t, err)
+		assert.Nil(t, runs["snapshot"].LastSuccessAt)
+	})
+}
@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// LeaseRepository keeps the leases of the leader election. Expiry is judged by the clock of the
+// database, so the clocks of the replicas need not agree.
+type LeaseRepository struct {
+	pool *pgxpool.Pool
+}
+
+// AcquireLease takes the lease for holder for ttl from now, or renews it if holder has it already.
+// A lease held by another replica is taken over only once it expired. Returns the lease as it is
+// afterwards, whoever holds it.
+func (r *LeaseRepository) AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (*models.Lease, error) {
+	query := `INSERT INTO leader_lease (name, holder, acquired_at, expires_at)
+	          VALUES ($1, $2, now(), now() + make_interval(secs => $3))
+	          ON CONFLICT (name) DO UPDATE
+	          SET holder = EXCLUDED.holder,
+	              acquired_at = CASE WHEN leader_lease.holder = EXCLUDED.holder
+	                                 THEN leader_lease.acquired_at ELSE EXCLUDED.acquired_at END,
+	              expires_at = EXCLUDED.expires_at
+	          WHERE leader_lease.holder = EXCLUDED.holder OR leader_lease.expires_at < now()
+	          RETURNING name, holder, acquired_at, expires_at`
+
+	lease, err := scanLease(getTx(ctx, r.pool).QueryRow(ctx, query, name, holder, ttl.Seconds()))
+	if errors.Is(err, pgx.ErrNoRows) {
+		// another replica holds the lease
+		query = `SELECT name, holder, acquired_at, expires_at FROM leader_lease WHERE name = $1`
+		lease, err = scanLease(getTx(ctx, r.pool).QueryRow(ctx, query, name))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lease: %w", err)
+	}
+	return lease, nil
+}
+
+// ReleaseLease gives up the lease if holder has it, so that another replica may take it at once.
+func (r *LeaseRepository) ReleaseLease(ctx context.Context, name, holder string) error {
+	query := `DELETE FROM leader_lease WHERE name = $1 AND holder = $2`
+
+	if _, err := getTx(ctx, r.pool).Exec(ctx, query, name, holder); err != nil {
+		return fmt.Errorf("failed to release lease: %w", err)
+	}
+	return nil
+}
+
+func scanLease(row pgx.Row) (*models.Lease, error) {
+	var lease models.Lease
+	if err := row.Scan(&lease.Name, &lease.Holder, &lease.AcquiredAt, &lease.ExpiresAt); err != nil {
+		return nil, err
+	}
+	return &lease, nil
+}
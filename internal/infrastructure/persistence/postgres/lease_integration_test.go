@@ -0,0 +1,53 @@
+//go:build integration
+
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeaseRepository(t *testing.T) {
+	f := newFixture(t)
+
+	t.Run("Success - Lease is taken, renewed and kept from others", func(t *testing.T) {
+		lease, err := f.leases.AcquireLease(f.ctx, "leader", "replica-1", time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, "replica-1", lease.Holder)
+		assert.WithinDuration(t, lease.AcquiredAt.Add(time.Minute), lease.ExpiresAt, time.Second)
+
+		renewed, err := f.leases.AcquireLease(f.ctx, "leader", "replica-1", time.Minute)
+		require.NoError(t, err)
+		assert.True(t, lease.AcquiredAt.Equal(renewed.AcquiredAt))
+		assert.False(t, renewed.ExpiresAt.Before(lease.ExpiresAt))
+
+		other, err := f.leases.AcquireLease(f.ctx, "leader", "replica-2", time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, "replica-1", other.Holder)
+	})
+
+	t.Run("Success - Expired lease is taken over", func(t *testing.T) {
+		f.exec(`UPDATE leader_lease SET expires_at = now() - interval '1 second' WHERE name = 'leader'`)
+
+		lease, err := f.leases.AcquireLease(f.ctx, "leader", "replica-2", time.Minute)
+
+		require.NoError(t, err)
+		assert.Equal(t, "replica-2", lease.Holder)
+	})
+
+	t.Run("Success - Released lease is free", func(t *testing.T) {
+		require.NoError(t, f.leases.ReleaseLease(f.ctx, "leader", "replica-1"), "releasing a lease held by another is a no-op")
+		other, err := f.leases.AcquireLease(f.ctx, "leader", "replica-1", time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, "replica-2", other.Holder)
+
+		require.NoError(t, f.leases.ReleaseLease(f.ctx, "leader", "replica-2"))
+		lease, err := f.leases.AcquireLease(f.ctx, "leader", "replica-1", time.Minute)
+
+		require.NoError(t, err)
+		assert.Equal(t, "replica-1", lease.Holder)
+	})
+}
@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// MetricsRepository reads the counts behind the business metrics.
+type MetricsRepository struct {
+	pool    *pgxpool.Pool
+	replica *pgxpool.Pool
+}
+
+// GetBusinessMetrics counts the open PRs of every team, with and without reviewers, the active users
+// and the assignments on open PRs, with two aggregate queries. The counts cover all organizations,
+// those of the teams sharing a name summed, as the gauges are read by the operator of the instance.
+func (r *MetricsRepository) GetBusinessMetrics(ctx context.Context) (*models.BusinessMetrics, error) {
+	teamsQuery := `SELECT COALESCE(u.team_name, ''), COUNT(*),
+	                      COUNT(*) FILTER (WHERE NOT EXISTS (SELECT 1 FROM pr_reviewer r
+	                                                    WHERE r.org_id = pr.org_id AND r.pr_id = pr.id))
+	               FROM pull_request pr
+	               LEFT JOIN "user" u ON u.org_id = pr.org_id AND u.id = pr.author_id
+	               WHERE pr.status = 'OPEN'
+	               GROUP BY 1
+	               ORDER BY 1`
+	totalsQuery := `SELECT (SELECT COUNT(*) FROM "user" WHERE is_active),
+	                       (SELECT COUNT(*) FROM pr_reviewer r
+	                        JOIN pull_request pr ON pr.org_id = r.org_id AND pr.id = r.pr_id
+	                        WHERE pr.status = 'OPEN')`
+
+	executor := getReader(ctx, r.pool, r.replica)
+	rows, err := executor.Query(ctx, teamsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count open PRs by team: %w", err)
+	}
+	defer rows.Close()
+
+	var metrics models.BusinessMetrics
+	for rows.Next() {
+		var counts models.TeamPRCounts
+		if err = rows.Scan(&counts.TeamName, &counts.OpenPRs, &counts.WithoutReviewers); err != nil {
+			return nil, fmt.Errorf("failed to scan open PR counts: %w", err)
+		}
+		metrics.Teams = append(metrics.Teams, counts)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	if err = executor.QueryRow(ctx, totalsQuery).Scan(&metrics.ActiveUsers, &metrics.ActiveAssignments); err != nil {
+		return nil, fmt.Errorf("failed to count active users and assignments: %w", err)
+	}
+
+	return &metrics, nil
+}
@@ -0,0 +1,35 @@
+//go:build integration
+
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsRepository_GetBusinessMetrics(t *testing.T) {
+	f := newFixture(t)
+	now := time.Now().UTC()
+	f.team("backend", "u1", "u2", "u3")
+	f.team("frontend", "f1")
+	f.exec(`UPDATE "user" SET is_active = false WHERE id = 'u3'`)
+	f.pr("pr-1", "u1", now, "u2", "f1")
+	f.pr("pr-2", "u2", now)
+	f.pr("pr-3", "f1", now)
+	f.pr("pr-4", "u1", now, "u2")
+	f.merge("pr-4")
+
+	metrics, err := f.metrics.GetBusinessMetrics(f.ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, []models.TeamPRCounts{
+		{TeamName: "backend", OpenPRs: 2, WithoutReviewers: 1},
+		{TeamName: "frontend", OpenPRs: 1, WithoutReviewers: 1},
+	}, metrics.Teams)
+	assert.Equal(t, 3, metrics.ActiveUsers)
+	assert.Equal(t, 2, metrics.ActiveAssignments)
+}
@@ -0,0 +1,233 @@
+package postgres
+
+import (
+	"cmp"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// migrationFiles are the migrations applied by the migrator, embedded so the service knows the
+// schema version it was built for.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockKey is the advisory lock held while migrating, so two migrators don't interleave.
+const migrationLockKey int64 = 0x6d696772617465 // "migrate"
+
+// Migration is an embedded migration: its version and the name of its files without the direction.
+type Migration struct {
+	Version uint
+	Name    string
+}
+
+// MigrationStatus is the schema version of the database next to the embedded migrations.
+type MigrationStatus struct {
+	// Version is the version of the last applied migration, zero when none is
+	Version uint
+	// Dirty reports that the migration to Version failed halfway and must be fixed by hand
+	Dirty bool
+	// Latest is the version of the newest embedded migration
+	Latest uint
+	// Pending are the embedded migrations newer than Version, oldest first
+	Pending []Migration
+}
+
+// migrations lists the embedded migrations, oldest first.
+func migrations() ([]Migration, error) {
+	names, err := fs.Glob(migrationFiles, "migrations/*.up.sql")
+	if err != nil {
+		return nil, err
+	}
+	list := make([]Migration, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSuffix(strings.TrimPrefix(name, "migrations/"), ".up.sql")
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s has no version: %w", name, err)
+		}
+		list = append(list, Migration{Version: uint(version), Name: name})
+	}
+	slices.SortFunc(list, func(a, b Migration) int { return cmp.Compare(a.Version, b.Version) })
+	return list, nil
+}
+
+// LatestMigration returns the version of the newest up migration, the one the database should be at.
+func LatestMigration() (uint, error) {
+	list, err := migrations()
+	if err != nil || len(list) == 0 {
+		return 0, err
+	}
+	return list[len(list)-1].Version, nil
+}
+
+// Migrator applies and reverts the embedded migrations. The version is recorded in
+// schema_migrations as the golang-migrate tool records it, so either can take over from the other
+// and the readiness check reads it the same way.
+type Migrator struct {
+	pool *pgxpool.Pool
+}
+
+// Status returns the schema version of the database and the migrations it lacks.
+func (m *Migrator) Status(ctx context.Context) (*MigrationStatus, error) {
+	list, err := migrations()
+	if err != nil {
+		return nil, err
+	}
+	version, dirty, err := (&HealthRepository{pool: m.pool}).MigrationVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &MigrationStatus{Version: version, Dirty: dirty}
+	for _, migration := range list {
+		status.Latest = migration.Version
+		if migration.Version > version {
+			status.Pending = append(status.Pending, migration)
+		}
+	}
+	return status, nil
+}
+
+// Up applies the pending migrations in order and returns those it applied. A failed migration
+// leaves the database dirty at its version, which Up and Down then refuse to migrate.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.migrate(ctx, func(conn *pgxpool.Conn, version uint) error {
+		list, err := migrations()
+		if err != nil {
+			return err
+		}
+		for _, migration := range list {
+			if migration.Version <= version {
+				continue
+			}
+			if err = runMigration(ctx, conn, migration, "up", migration.Version); err != nil {
+				return err
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the last n applied migrations, newest first, and returns those it reverted; it
+// stops early at version zero. A failed migration leaves the database dirty like in Up.
+func (m *Migrator) Down(ctx context.Context, n int) ([]Migration, error) {
+	var reverted []Migration
+	err := m.migrate(ctx, func(conn *pgxpool.Conn, version uint) error {
+		list, err := migrations()
+		if err != nil {
+			return err
+		}
+		for i := len(list) - 1; i >= 0 && len(reverted) < n; i-- {
+			if list[i].Version > version {
+				continue
+			}
+			var previous uint
+			if i > 0 {
+				previous = list[i-1].Version
+			}
+			if err = runMigration(ctx, conn, list[i], "down", previous); err != nil {
+				return err
+			}
+			reverted = append(reverted, list[i])
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// migrate runs fn with the current version on a connection holding the migration lock, creating
+// schema_migrations first. A dirty database is refused.
+func (m *Migrator) migrate(ctx context.Context, fn func(conn *pgxpool.Conn, version uint) error) error {
+	// the lock belongs to the session, so everything runs on one connection
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", poolError(ctx, err))
+	}
+	defer conn.Release()
+
+	if _, err = conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, migrationLockKey); err != nil {
+		return fmt.Errorf("failed to take migration lock: %w", err)
+	}
+	defer func() {
+		// a fresh context: the caller's one may be cancelled by now
+		if _, err := conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockKey); err != nil {
+			// closing the session drops the lock it holds
+			_ = conn.Conn().Close(context.Background())
+		}
+	}()
+
+	query := `CREATE TABLE IF NOT EXISTS schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)`
+	if _, err = conn.Exec(ctx, query); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	var version int64
+	var dirty bool
+	err = conn.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to read migration version: %w", err)
+	}
+	if dirty {
+		return fmt.Errorf("database is dirty at version %d: fix the failed migration by hand and record "+
+			"the version it left in schema_migrations", version)
+	}
+	return fn(conn, uint(version))
+}
+
+// runMigration runs the migration in direction, recording version as dirty until it succeeds.
+// Version zero is recorded as no row, as golang-migrate does.
+func runMigration(ctx context.Context, conn *pgxpool.Conn, migration Migration, direction string, version uint) error {
+	sql, err := migrationFiles.ReadFile("migrations/" + migration.Name + "." + direction + ".sql")
+	if err != nil {
+		return err
+	}
+	if err = setDirtyVersion(ctx, conn, version); err != nil {
+		return err
+	}
+	// without arguments pgx uses the simple protocol, which runs the statements of the file in one
+	// implicit transaction
+	if _, err = conn.Exec(ctx, string(sql)); err != nil {
+		return fmt.Errorf("migration %s %s failed: %w", migration.Name, direction, err)
+	}
+	if version == 0 {
+		_, err = conn.Exec(ctx, `TRUNCATE schema_migrations`)
+	} else {
+		_, err = conn.Exec(ctx, `UPDATE schema_migrations SET dirty = false`)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record migration version: %w", err)
+	}
+	return nil
+}
+
+// setDirtyVersion records version as the one being migrated to.
+func setDirtyVersion(ctx context.Context, conn *pgxpool.Conn, version uint) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err = tx.Exec(ctx, `TRUNCATE schema_migrations`); err != nil {
+		return fmt.Errorf("failed to record migration version: %w", err)
+	}
+	if _, err = tx.Exec(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES ($1, true)`,
+		int64(version)); err != nil {
+		return fmt.Errorf("failed to record migration version: %w", err)
+	}
+	return tx.Commit(ctx)
+}
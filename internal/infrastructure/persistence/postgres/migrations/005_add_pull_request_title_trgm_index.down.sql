@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_pull_request_title_trgm;
@@ -0,0 +1,3 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_pull_request_title_trgm ON pull_request USING GIN (title gin_trgm_ops);
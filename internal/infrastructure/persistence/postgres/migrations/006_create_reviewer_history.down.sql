@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_reviewer_history_pr;
+DROP TABLE IF EXISTS reviewer_history;
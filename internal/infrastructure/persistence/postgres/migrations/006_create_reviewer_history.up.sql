@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS reviewer_history (
+    id BIGSERIAL PRIMARY KEY,
+    pr_id VARCHAR(255) NOT NULL,
+    old_reviewer_id VARCHAR(255) NOT NULL,
+    new_reviewer_id VARCHAR(255),
+    trigger VARCHAR(32) NOT NULL CHECK (trigger IN ('manual', 'deactivation')),
+    changed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (pr_id) REFERENCES pull_request(id) ON DELETE CASCADE,
+    FOREIGN KEY (old_reviewer_id) REFERENCES "user"(id) ON DELETE RESTRICT,
+    FOREIGN KEY (new_reviewer_id) REFERENCES "user"(id) ON DELETE RESTRICT
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviewer_history_pr ON reviewer_history(pr_id, changed_at);
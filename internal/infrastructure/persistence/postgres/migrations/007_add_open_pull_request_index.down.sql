@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_pull_request_open_created;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_pull_request_open_created ON pull_request(created_at, id) WHERE status = 'OPEN';
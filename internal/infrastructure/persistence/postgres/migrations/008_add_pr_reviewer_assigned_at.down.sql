@@ -0,0 +1 @@
+ALTER TABLE pr_reviewer DROP COLUMN IF EXISTS assigned_at;
@@ -0,0 +1 @@
+ALTER TABLE pr_reviewer ADD COLUMN IF NOT EXISTS assigned_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP;
@@ -0,0 +1,3 @@
+ALTER TABLE pull_request DROP COLUMN IF EXISTS priority;
+
+DROP TYPE IF EXISTS pr_priority;
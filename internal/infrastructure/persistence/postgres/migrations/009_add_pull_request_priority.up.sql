@@ -0,0 +1,8 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'pr_priority') THEN
+        CREATE TYPE pr_priority AS ENUM ('LOW', 'NORMAL', 'HIGH', 'URGENT');
+    END IF;
+END $$;
+
+ALTER TABLE pull_request ADD COLUMN IF NOT EXISTS priority pr_priority NOT NULL DEFAULT 'NORMAL';
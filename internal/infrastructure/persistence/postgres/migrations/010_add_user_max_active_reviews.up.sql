@@ -0,0 +1 @@
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS max_active_reviews INTEGER CHECK (max_active_reviews > 0);
@@ -0,0 +1,4 @@
+ALTER TABLE pr_reviewer DROP COLUMN IF EXISTS state_changed_at;
+ALTER TABLE pr_reviewer DROP COLUMN IF EXISTS state;
+
+DROP TYPE IF EXISTS review_state;
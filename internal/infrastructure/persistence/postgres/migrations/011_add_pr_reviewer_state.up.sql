@@ -0,0 +1,9 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'review_state') THEN
+        CREATE TYPE review_state AS ENUM ('PENDING', 'APPROVED', 'CHANGES_REQUESTED');
+    END IF;
+END $$;
+
+ALTER TABLE pr_reviewer ADD COLUMN IF NOT EXISTS state review_state NOT NULL DEFAULT 'PENDING';
+ALTER TABLE pr_reviewer ADD COLUMN IF NOT EXISTS state_changed_at TIMESTAMP WITH TIME ZONE NULL;
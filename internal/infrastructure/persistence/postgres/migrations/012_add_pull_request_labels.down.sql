@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_pull_request_labels;
+
+ALTER TABLE pull_request DROP COLUMN IF EXISTS labels;
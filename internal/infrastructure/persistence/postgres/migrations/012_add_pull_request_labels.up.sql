@@ -0,0 +1,3 @@
+ALTER TABLE pull_request ADD COLUMN IF NOT EXISTS labels TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_pull_request_labels ON pull_request USING GIN (labels);
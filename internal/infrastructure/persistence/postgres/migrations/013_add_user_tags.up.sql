@@ -0,0 +1 @@
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,3 @@
+ALTER TABLE "user" DROP COLUMN IF EXISTS work_end;
+ALTER TABLE "user" DROP COLUMN IF EXISTS work_start;
+ALTER TABLE "user" DROP COLUMN IF EXISTS timezone;
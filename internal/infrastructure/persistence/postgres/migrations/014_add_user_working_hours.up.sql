@@ -0,0 +1,3 @@
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT '';
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS work_start TEXT NOT NULL DEFAULT '';
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS work_end TEXT NOT NULL DEFAULT '';
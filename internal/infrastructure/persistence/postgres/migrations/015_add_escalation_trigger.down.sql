@@ -0,0 +1,5 @@
+DELETE FROM reviewer_history WHERE trigger = 'escalation';
+
+ALTER TABLE reviewer_history DROP CONSTRAINT IF EXISTS reviewer_history_trigger_check;
+ALTER TABLE reviewer_history ADD CONSTRAINT reviewer_history_trigger_check
+    CHECK (trigger IN ('manual', 'deactivation'));
@@ -0,0 +1,3 @@
+ALTER TABLE reviewer_history DROP CONSTRAINT IF EXISTS reviewer_history_trigger_check;
+ALTER TABLE reviewer_history ADD CONSTRAINT reviewer_history_trigger_check
+    CHECK (trigger IN ('manual', 'deactivation', 'escalation'));
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS reviewer_exclusion (
+    reviewer_id VARCHAR(255) NOT NULL,
+    author_id VARCHAR(255) NOT NULL,
+    mutual BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (reviewer_id, author_id),
+    CHECK (reviewer_id <> author_id),
+    FOREIGN KEY (reviewer_id) REFERENCES "user"(id) ON DELETE CASCADE,
+    FOREIGN KEY (author_id) REFERENCES "user"(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviewer_exclusion_author ON reviewer_exclusion(author_id);
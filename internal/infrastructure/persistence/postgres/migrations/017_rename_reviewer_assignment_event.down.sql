@@ -0,0 +1,4 @@
+ALTER INDEX idx_reviewer_assignment_event_pr RENAME TO idx_reviewer_history_pr;
+ALTER TABLE reviewer_assignment_event RENAME CONSTRAINT reviewer_assignment_event_trigger_check
+    TO reviewer_history_trigger_check;
+ALTER TABLE reviewer_assignment_event RENAME TO reviewer_history;
@@ -0,0 +1,4 @@
+ALTER TABLE reviewer_history RENAME TO reviewer_assignment_event;
+ALTER TABLE reviewer_assignment_event RENAME CONSTRAINT reviewer_history_trigger_check
+    TO reviewer_assignment_event_trigger_check;
+ALTER INDEX idx_reviewer_history_pr RENAME TO idx_reviewer_assignment_event_pr;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS team (
+    name VARCHAR(255) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    lead_id VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (lead_id) REFERENCES "user"(id) ON DELETE SET NULL
+);
+
+-- teams created before the table have no known creation time
+INSERT INTO team (name, created_at)
+SELECT DISTINCT team_name, NULL::TIMESTAMP WITH TIME ZONE FROM "user"
+ON CONFLICT (name) DO NOTHING;
@@ -0,0 +1,2 @@
+ALTER TABLE pull_request DROP CONSTRAINT IF EXISTS pull_request_reviewer_count_check;
+ALTER TABLE pull_request DROP COLUMN IF EXISTS reviewer_count;
@@ -0,0 +1,9 @@
+ALTER TABLE pull_request ADD COLUMN IF NOT EXISTS reviewer_count INTEGER NOT NULL DEFAULT 0;
+
+UPDATE pull_request pr
+SET reviewer_count = (SELECT COUNT(*) FROM pr_reviewer prr WHERE prr.pr_id = pr.id);
+
+-- the cap matches models.MaxReviewers
+ALTER TABLE pull_request DROP CONSTRAINT IF EXISTS pull_request_reviewer_count_check;
+ALTER TABLE pull_request ADD CONSTRAINT pull_request_reviewer_count_check
+    CHECK (reviewer_count BETWEEN 0 AND 2);
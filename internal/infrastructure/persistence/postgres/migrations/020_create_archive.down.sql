@@ -0,0 +1,20 @@
+INSERT INTO pull_request (id, title, author_id, status, created_at, merged_at, updated_at, priority, labels, reviewer_count)
+SELECT id, title, author_id, status, created_at, merged_at, updated_at, priority, labels, reviewer_count
+FROM pull_request_archive
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO pr_reviewer (pr_id, reviewer_id, assigned_at, state, state_changed_at)
+SELECT pr_id, reviewer_id, assigned_at, state, state_changed_at
+FROM pr_reviewer_archive
+ON CONFLICT (pr_id, reviewer_id) DO NOTHING;
+
+DELETE FROM reviewer_assignment_event e
+WHERE NOT EXISTS (SELECT 1 FROM pull_request pr WHERE pr.id = e.pr_id);
+
+ALTER TABLE reviewer_assignment_event DROP CONSTRAINT IF EXISTS reviewer_history_pr_id_fkey;
+ALTER TABLE reviewer_assignment_event ADD CONSTRAINT reviewer_history_pr_id_fkey
+    FOREIGN KEY (pr_id) REFERENCES pull_request(id) ON DELETE CASCADE;
+
+DROP INDEX IF EXISTS idx_pull_request_merged;
+DROP TABLE IF EXISTS pr_reviewer_archive;
+DROP TABLE IF EXISTS pull_request_archive;
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS pull_request_archive (
+    id VARCHAR(255) PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    author_id VARCHAR(255),
+    status pr_status NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE,
+    merged_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    priority pr_priority NOT NULL,
+    labels TEXT[] NOT NULL DEFAULT '{}',
+    reviewer_count INTEGER NOT NULL DEFAULT 0,
+    archived_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (author_id) REFERENCES "user"(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pull_request_archive_merged ON pull_request_archive(merged_at);
+
+CREATE TABLE IF NOT EXISTS pr_reviewer_archive (
+    pr_id VARCHAR(255),
+    reviewer_id VARCHAR(255),
+    assigned_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    state review_state NOT NULL,
+    state_changed_at TIMESTAMP WITH TIME ZONE NULL,
+    PRIMARY KEY (pr_id, reviewer_id),
+    FOREIGN KEY (pr_id) REFERENCES pull_request_archive(id) ON DELETE CASCADE,
+    FOREIGN KEY (reviewer_id) REFERENCES "user"(id) ON DELETE RESTRICT
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_reviewer_archive_reviewer ON pr_reviewer_archive(reviewer_id);
+
+CREATE INDEX IF NOT EXISTS idx_pull_request_merged ON pull_request(merged_at) WHERE status = 'MERGED';
+
+-- the history of an archived PR is kept where it is
+ALTER TABLE reviewer_assignment_event DROP CONSTRAINT IF EXISTS reviewer_history_pr_id_fkey;
@@ -0,0 +1,4 @@
+ALTER TABLE pr_reviewer_archive DROP COLUMN IF EXISTS source;
+ALTER TABLE pr_reviewer DROP COLUMN IF EXISTS source;
+
+DROP TYPE IF EXISTS assignment_source;
@@ -0,0 +1,10 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'assignment_source') THEN
+        CREATE TYPE assignment_source AS ENUM ('auto', 'manual', 'reassign', 'deactivation', 'escalation');
+    END IF;
+END $$;
+
+-- the source of assignments made before the column is unknown, most were automatic
+ALTER TABLE pr_reviewer ADD COLUMN IF NOT EXISTS source assignment_source NOT NULL DEFAULT 'auto';
+ALTER TABLE pr_reviewer_archive ADD COLUMN IF NOT EXISTS source assignment_source NOT NULL DEFAULT 'auto';
@@ -0,0 +1,33 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'webhook_delivery_status') THEN
+        CREATE TYPE webhook_delivery_status AS ENUM ('pending', 'delivered', 'dead');
+    END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+    id BIGSERIAL PRIMARY KEY,
+    event_type VARCHAR(255) NOT NULL,
+    payload JSONB NOT NULL,
+    status webhook_delivery_status NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP WITH TIME ZONE NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_due ON webhook_delivery(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_dead ON webhook_delivery(id) WHERE status = 'dead';
+
+-- attempt numbers restart when a dead delivery is requeued, so they don't identify an attempt
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempt (
+    id BIGSERIAL PRIMARY KEY,
+    delivery_id BIGINT NOT NULL,
+    attempt INTEGER NOT NULL,
+    attempted_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    FOREIGN KEY (delivery_id) REFERENCES webhook_delivery(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_attempt_delivery ON webhook_delivery_attempt(delivery_id);
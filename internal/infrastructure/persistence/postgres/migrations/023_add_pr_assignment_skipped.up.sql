@@ -0,0 +1,2 @@
+-- set for PRs created without reviewer assignment, until reviewers are assigned on request
+ALTER TABLE pull_request ADD COLUMN IF NOT EXISTS assignment_skipped BOOLEAN NOT NULL DEFAULT false;
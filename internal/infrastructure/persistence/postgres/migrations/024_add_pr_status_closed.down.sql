@@ -0,0 +1,15 @@
+-- an enum value can't be dropped, so the type is recreated; this fails while CLOSED PRs exist
+DROP INDEX IF EXISTS idx_pull_request_open_created;
+DROP INDEX IF EXISTS idx_pull_request_merged;
+
+ALTER TYPE pr_status RENAME TO pr_status_old;
+CREATE TYPE pr_status AS ENUM ('OPEN', 'MERGED');
+
+ALTER TABLE pull_request ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE pull_request ALTER COLUMN status TYPE pr_status USING status::text::pr_status;
+ALTER TABLE pull_request ALTER COLUMN status SET DEFAULT 'OPEN';
+ALTER TABLE pull_request_archive ALTER COLUMN status TYPE pr_status USING status::text::pr_status;
+DROP TYPE pr_status_old;
+
+CREATE INDEX IF NOT EXISTS idx_pull_request_open_created ON pull_request(created_at, id) WHERE status = 'OPEN';
+CREATE INDEX IF NOT EXISTS idx_pull_request_merged ON pull_request(merged_at) WHERE status = 'MERGED';
@@ -0,0 +1,2 @@
+-- PRs closed without merging, e.g. those authored by a deactivated team
+ALTER TYPE pr_status ADD VALUE IF NOT EXISTS 'CLOSED';
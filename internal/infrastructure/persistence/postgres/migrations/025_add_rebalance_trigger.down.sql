@@ -0,0 +1,5 @@
+DELETE FROM reviewer_assignment_event WHERE trigger = 'rebalance';
+
+ALTER TABLE reviewer_assignment_event DROP CONSTRAINT IF EXISTS reviewer_assignment_event_trigger_check;
+ALTER TABLE reviewer_assignment_event ADD CONSTRAINT reviewer_assignment_event_trigger_check
+    CHECK (trigger IN ('manual', 'deactivation', 'escalation'));
@@ -0,0 +1 @@
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS is_reviewer BOOLEAN NOT NULL DEFAULT true;
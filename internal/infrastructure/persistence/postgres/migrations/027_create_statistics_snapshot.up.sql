@@ -0,0 +1,14 @@
+-- team_name is empty for the snapshot of all teams; it has no foreign key, so the history of a
+-- team outlives the team
+CREATE TABLE IF NOT EXISTS statistics_snapshot (
+    day DATE NOT NULL,
+    team_name VARCHAR(255) NOT NULL,
+    total_prs INTEGER NOT NULL,
+    open_prs INTEGER NOT NULL,
+    merged_prs INTEGER NOT NULL,
+    total_assignments INTEGER NOT NULL,
+    reassignment_events INTEGER NOT NULL,
+    open_prs_without_reviewers INTEGER NOT NULL,
+    taken_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (day, team_name)
+);
@@ -0,0 +1,9 @@
+-- review_change records when the assignments of a reviewer changed, for the Last-Modified of their
+-- review list. Rows are only appended, so concurrent changes for one reviewer never update the same
+-- row; like statistics_snapshot it has no foreign key.
+CREATE TABLE IF NOT EXISTS review_change (
+    reviewer_id VARCHAR(255) NOT NULL,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_change_reviewer ON review_change(reviewer_id, changed_at DESC);
@@ -0,0 +1,9 @@
+-- job_run keeps the last run of every scheduled job, written by whichever replica ran it; error is
+-- NULL for a run that succeeded
+CREATE TABLE IF NOT EXISTS job_run (
+    name VARCHAR(100) PRIMARY KEY,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    error TEXT,
+    last_success_at TIMESTAMP WITH TIME ZONE
+);
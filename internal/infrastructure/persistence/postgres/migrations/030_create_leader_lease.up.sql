@@ -0,0 +1,8 @@
+-- leader_lease holds the leases of the leader election: the replica named by holder leads until
+-- expires_at unless it renews the lease before then
+CREATE TABLE IF NOT EXISTS leader_lease (
+    name VARCHAR(100) PRIMARY KEY,
+    holder VARCHAR(255) NOT NULL,
+    acquired_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
@@ -0,0 +1,63 @@
+-- fails while two organizations share an id or a team name, as the keys can no longer tell them apart
+DROP INDEX IF EXISTS idx_review_change_reviewer;
+CREATE INDEX IF NOT EXISTS idx_review_change_reviewer ON review_change(reviewer_id, changed_at DESC);
+DROP INDEX IF EXISTS idx_users_team_active;
+CREATE INDEX IF NOT EXISTS idx_users_team_active ON "user"(team_name, is_active);
+DROP INDEX IF EXISTS idx_webhook_delivery_due;
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_due ON webhook_delivery(next_attempt_at) WHERE status = 'pending';
+DROP INDEX IF EXISTS idx_webhook_delivery_dead;
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_dead ON webhook_delivery(id) WHERE status = 'dead';
+
+ALTER TABLE "user" DROP CONSTRAINT user_pkey CASCADE;
+ALTER TABLE pull_request DROP CONSTRAINT pull_request_pkey CASCADE;
+ALTER TABLE pull_request_archive DROP CONSTRAINT pull_request_archive_pkey CASCADE;
+ALTER TABLE team DROP CONSTRAINT team_pkey;
+ALTER TABLE pr_reviewer DROP CONSTRAINT pr_reviewer_pkey;
+ALTER TABLE pr_reviewer_archive DROP CONSTRAINT pr_reviewer_archive_pkey;
+ALTER TABLE reviewer_exclusion DROP CONSTRAINT reviewer_exclusion_pkey;
+ALTER TABLE statistics_snapshot DROP CONSTRAINT statistics_snapshot_pkey;
+
+ALTER TABLE "user" ADD CONSTRAINT user_pkey PRIMARY KEY (id);
+ALTER TABLE team ADD CONSTRAINT team_pkey PRIMARY KEY (name);
+ALTER TABLE pull_request ADD CONSTRAINT pull_request_pkey PRIMARY KEY (id);
+ALTER TABLE pr_reviewer ADD CONSTRAINT pr_reviewer_pkey PRIMARY KEY (pr_id, reviewer_id);
+ALTER TABLE reviewer_exclusion ADD CONSTRAINT reviewer_exclusion_pkey PRIMARY KEY (reviewer_id, author_id);
+ALTER TABLE pull_request_archive ADD CONSTRAINT pull_request_archive_pkey PRIMARY KEY (id);
+ALTER TABLE pr_reviewer_archive ADD CONSTRAINT pr_reviewer_archive_pkey PRIMARY KEY (pr_id, reviewer_id);
+ALTER TABLE statistics_snapshot ADD CONSTRAINT statistics_snapshot_pkey PRIMARY KEY (day, team_name);
+
+ALTER TABLE team ADD CONSTRAINT team_lead_id_fkey
+    FOREIGN KEY (lead_id) REFERENCES "user"(id) ON DELETE SET NULL;
+ALTER TABLE pull_request ADD CONSTRAINT pull_request_author_id_fkey
+    FOREIGN KEY (author_id) REFERENCES "user"(id);
+ALTER TABLE pr_reviewer ADD CONSTRAINT pr_reviewer_pr_id_fkey
+    FOREIGN KEY (pr_id) REFERENCES pull_request(id) ON DELETE CASCADE;
+ALTER TABLE pr_reviewer ADD CONSTRAINT pr_reviewer_reviewer_id_fkey
+    FOREIGN KEY (reviewer_id) REFERENCES "user"(id) ON DELETE RESTRICT;
+ALTER TABLE reviewer_assignment_event ADD CONSTRAINT reviewer_history_old_reviewer_id_fkey
+    FOREIGN KEY (old_reviewer_id) REFERENCES "user"(id) ON DELETE RESTRICT;
+ALTER TABLE reviewer_assignment_event ADD CONSTRAINT reviewer_history_new_reviewer_id_fkey
+    FOREIGN KEY (new_reviewer_id) REFERENCES "user"(id) ON DELETE RESTRICT;
+ALTER TABLE reviewer_exclusion ADD CONSTRAINT reviewer_exclusion_reviewer_id_fkey
+    FOREIGN KEY (reviewer_id) REFERENCES "user"(id) ON DELETE CASCADE;
+ALTER TABLE reviewer_exclusion ADD CONSTRAINT reviewer_exclusion_author_id_fkey
+    FOREIGN KEY (author_id) REFERENCES "user"(id) ON DELETE CASCADE;
+ALTER TABLE pull_request_archive ADD CONSTRAINT pull_request_archive_author_id_fkey
+    FOREIGN KEY (author_id) REFERENCES "user"(id);
+ALTER TABLE pr_reviewer_archive ADD CONSTRAINT pr_reviewer_archive_pr_id_fkey
+    FOREIGN KEY (pr_id) REFERENCES pull_request_archive(id) ON DELETE CASCADE;
+ALTER TABLE pr_reviewer_archive ADD CONSTRAINT pr_reviewer_archive_reviewer_id_fkey
+    FOREIGN KEY (reviewer_id) REFERENCES "user"(id) ON DELETE RESTRICT;
+
+ALTER TABLE webhook_delivery_attempt DROP COLUMN IF EXISTS org_id;
+ALTER TABLE webhook_delivery DROP COLUMN IF EXISTS org_id;
+ALTER TABLE review_change DROP COLUMN IF EXISTS org_id;
+ALTER TABLE statistics_snapshot DROP COLUMN IF EXISTS org_id;
+ALTER TABLE pr_reviewer_archive DROP COLUMN IF EXISTS org_id;
+ALTER TABLE pull_request_archive DROP COLUMN IF EXISTS org_id;
+ALTER TABLE reviewer_exclusion DROP COLUMN IF EXISTS org_id;
+ALTER TABLE reviewer_assignment_event DROP COLUMN IF EXISTS org_id;
+ALTER TABLE pr_reviewer DROP COLUMN IF EXISTS org_id;
+ALTER TABLE pull_request DROP COLUMN IF EXISTS org_id;
+ALTER TABLE team DROP COLUMN IF EXISTS org_id;
+ALTER TABLE "user" DROP COLUMN IF EXISTS org_id;
@@ -0,0 +1,66 @@
+-- org_id scopes the data of every organization sharing the instance: ids, team names and the
+-- statistics are unique within an organization only. Rows written before the column belong to the
+-- default organization, as do requests that name none.
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS org_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE team ADD COLUMN IF NOT EXISTS org_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE pull_request ADD COLUMN IF NOT EXISTS org_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE pr_reviewer ADD COLUMN IF NOT EXISTS org_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE reviewer_assignment_event ADD COLUMN IF NOT EXISTS org_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE reviewer_exclusion ADD COLUMN IF NOT EXISTS org_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE pull_request_archive ADD COLUMN IF NOT EXISTS org_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE pr_reviewer_archive ADD COLUMN IF NOT EXISTS org_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE statistics_snapshot ADD COLUMN IF NOT EXISTS org_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE review_change ADD COLUMN IF NOT EXISTS org_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE webhook_delivery ADD COLUMN IF NOT EXISTS org_id VARCHAR(255) NOT NULL DEFAULT 'default';
+ALTER TABLE webhook_delivery_attempt ADD COLUMN IF NOT EXISTS org_id VARCHAR(255) NOT NULL DEFAULT 'default';
+
+-- dropping the keys drops the foreign keys referencing them, recreated below with org_id
+ALTER TABLE "user" DROP CONSTRAINT user_pkey CASCADE;
+ALTER TABLE pull_request DROP CONSTRAINT pull_request_pkey CASCADE;
+ALTER TABLE pull_request_archive DROP CONSTRAINT pull_request_archive_pkey CASCADE;
+ALTER TABLE team DROP CONSTRAINT team_pkey;
+ALTER TABLE pr_reviewer DROP CONSTRAINT pr_reviewer_pkey;
+ALTER TABLE pr_reviewer_archive DROP CONSTRAINT pr_reviewer_archive_pkey;
+ALTER TABLE reviewer_exclusion DROP CONSTRAINT reviewer_exclusion_pkey;
+ALTER TABLE statistics_snapshot DROP CONSTRAINT statistics_snapshot_pkey;
+
+ALTER TABLE "user" ADD CONSTRAINT user_pkey PRIMARY KEY (org_id, id);
+ALTER TABLE team ADD CONSTRAINT team_pkey PRIMARY KEY (org_id, name);
+ALTER TABLE pull_request ADD CONSTRAINT pull_request_pkey PRIMARY KEY (org_id, id);
+ALTER TABLE pr_reviewer ADD CONSTRAINT pr_reviewer_pkey PRIMARY KEY (org_id, pr_id, reviewer_id);
+ALTER TABLE reviewer_exclusion ADD CONSTRAINT reviewer_exclusion_pkey PRIMARY KEY (org_id, reviewer_id, author_id);
+ALTER TABLE pull_request_archive ADD CONSTRAINT pull_request_archive_pkey PRIMARY KEY (org_id, id);
+ALTER TABLE pr_reviewer_archive ADD CONSTRAINT pr_reviewer_archive_pkey PRIMARY KEY (org_id, pr_id, reviewer_id);
+ALTER TABLE statistics_snapshot ADD CONSTRAINT statistics_snapshot_pkey PRIMARY KEY (org_id, day, team_name);
+
+ALTER TABLE team ADD CONSTRAINT team_lead_id_fkey
+    FOREIGN KEY (org_id, lead_id) REFERENCES "user"(org_id, id) ON DELETE SET NULL (lead_id);
+ALTER TABLE pull_request ADD CONSTRAINT pull_request_author_id_fkey
+    FOREIGN KEY (org_id, author_id) REFERENCES "user"(org_id, id);
+ALTER TABLE pr_reviewer ADD CONSTRAINT pr_reviewer_pr_id_fkey
+    FOREIGN KEY (org_id, pr_id) REFERENCES pull_request(org_id, id) ON DELETE CASCADE;
+ALTER TABLE pr_reviewer ADD CONSTRAINT pr_reviewer_reviewer_id_fkey
+    FOREIGN KEY (org_id, reviewer_id) REFERENCES "user"(org_id, id) ON DELETE RESTRICT;
+ALTER TABLE reviewer_assignment_event ADD CONSTRAINT reviewer_history_old_reviewer_id_fkey
+    FOREIGN KEY (org_id, old_reviewer_id) REFERENCES "user"(org_id, id) ON DELETE RESTRICT;
+ALTER TABLE reviewer_assignment_event ADD CONSTRAINT reviewer_history_new_reviewer_id_fkey
+    FOREIGN KEY (org_id, new_reviewer_id) REFERENCES "user"(org_id, id) ON DELETE RESTRICT;
+ALTER TABLE reviewer_exclusion ADD CONSTRAINT reviewer_exclusion_reviewer_id_fkey
+    FOREIGN KEY (org_id, reviewer_id) REFERENCES "user"(org_id, id) ON DELETE CASCADE;
+ALTER TABLE reviewer_exclusion ADD CONSTRAINT reviewer_exclusion_author_id_fkey
+    FOREIGN KEY (org_id, author_id) REFERENCES "user"(org_id, id) ON DELETE CASCADE;
+ALTER TABLE pull_request_archive ADD CONSTRAINT pull_request_archive_author_id_fkey
+    FOREIGN KEY (org_id, author_id) REFERENCES "user"(org_id, id);
+ALTER TABLE pr_reviewer_archive ADD CONSTRAINT pr_reviewer_archive_pr_id_fkey
+    FOREIGN KEY (org_id, pr_id) REFERENCES pull_request_archive(org_id, id) ON DELETE CASCADE;
+ALTER TABLE pr_reviewer_archive ADD CONSTRAINT pr_reviewer_archive_reviewer_id_fkey
+    FOREIGN KEY (org_id, reviewer_id) REFERENCES "user"(org_id, id) ON DELETE RESTRICT;
+
+DROP INDEX IF EXISTS idx_users_team_active;
+CREATE INDEX IF NOT EXISTS idx_users_team_active ON "user"(org_id, team_name, is_active);
+DROP INDEX IF EXISTS idx_review_change_reviewer;
+CREATE INDEX IF NOT EXISTS idx_review_change_reviewer ON review_change(org_id, reviewer_id, changed_at DESC);
+DROP INDEX IF EXISTS idx_webhook_delivery_due;
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_due ON webhook_delivery(org_id, next_attempt_at) WHERE status = 'pending';
+DROP INDEX IF EXISTS idx_webhook_delivery_dead;
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_dead ON webhook_delivery(org_id, id) WHERE status = 'dead';
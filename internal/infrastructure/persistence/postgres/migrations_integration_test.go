@@ -0,0 +1,110 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMigrationDatabase creates an empty database of its own, dropped after the test, and returns a
+// pool connected to it.
+func newMigrationDatabase(t *testing.T, name string) *pgxpool.Pool {
+	t.Helper()
+	ctx := context.Background()
+	_, err := testStorage.pool.Exec(ctx, `CREATE DATABASE `+name)
+	require.NoError(t, err)
+
+	cfg := testConfig.Copy()
+	cfg.ConnConfig.Database = name
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		pool.Close()
+		_, err := testStorage.pool.Exec(ctx, `DROP DATABASE `+name)
+		assert.NoError(t, err)
+	})
+	return pool
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+	pool := newMigrationDatabase(t, "migrator_test")
+	migrator := &Migrator{pool: pool}
+	health := &HealthRepository{pool: pool}
+	list, err := migrations()
+	require.NoError(t, err)
+	latest := list[len(list)-1]
+
+	t.Run("Success - Status of an empty database", func(t *testing.T) {
+		status, err := migrator.Status(ctx)
+
+		require.NoError(t, err)
+		assert.Zero(t, status.Version)
+		assert.Equal(t, latest.Version, status.Latest)
+		assert.Equal(t, list, status.Pending)
+	})
+
+	t.Run("Success - Up applies every migration", func(t *testing.T) {
+		applied, err := migrator.Up(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, list, applied)
+		version, dirty, err := health.MigrationVersion(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, latest.Version, version)
+		assert.False(t, dirty)
+	})
+
+	t.Run("Success - Up without pending migrations", func(t *testing.T) {
+		applied, err := migrator.Up(ctx)
+
+		assert.NoError(t, err)
+		assert.Empty(t, applied)
+	})
+
+	t.Run("Success - Down reverts the last migrations", func(t *testing.T) {
+		reverted, err := migrator.Down(ctx, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, []Migration{latest, list[len(list)-2]}, reverted)
+		status, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, list[len(list)-3].Version, status.Version)
+		assert.Equal(t, list[len(list)-2:], status.Pending)
+	})
+
+	t.Run("Success - Down to an empty schema and up again", func(t *testing.T) {
+		reverted, err := migrator.Down(ctx, len(list)+1)
+		require.NoError(t, err)
+		assert.Len(t, reverted, len(list)-2)
+		version, _, err := health.MigrationVersion(ctx)
+		assert.NoError(t, err)
+		assert.Zero(t, version)
+
+		applied, err := migrator.Up(ctx)
+		require.NoError(t, err)
+		assert.Len(t, applied, len(list))
+	})
+
+	t.Run("Error - Dirty database is refused", func(t *testing.T) {
+		_, err := pool.Exec(ctx, `UPDATE schema_migrations SET dirty = true`)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_, err := pool.Exec(ctx, `UPDATE schema_migrations SET dirty = false`)
+			assert.NoError(t, err)
+		})
+
+		_, err = migrator.Up(ctx)
+		assert.ErrorContains(t, err, "dirty")
+		_, err = migrator.Down(ctx, 1)
+		assert.ErrorContains(t, err, "dirty")
+		status, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		assert.True(t, status.Dirty)
+	})
+}
@@ -0,0 +1,26 @@
+package postgres
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestMigration(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("migrations", "*.up.sql"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	latest, err := LatestMigration()
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, len(files), latest, "migrations are numbered from 1 without gaps")
+	for _, file := range files {
+		down := file[:len(file)-len(".up.sql")] + ".down.sql"
+		_, err := os.Stat(down)
+		assert.NoError(t, err, "%s has no down migration", filepath.Base(file))
+	}
+}
@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+)
+
+// OrgRepository lists the organizations sharing the database.
+type OrgRepository struct {
+	pool *pgxpool.Pool
+}
+
+// ListOrgs returns the organizations that have users, teams, PRs or pending webhook deliveries, and the
+// default one, ordered by id. It reads the primary, so an organization created just before a background
+// job runs is seen.
+func (r *OrgRepository) ListOrgs(ctx context.Context) ([]string, error) {
+	query := `SELECT $1
+	          UNION
+	          SELECT org_id FROM "user"
+	          UNION
+	          SELECT org_id FROM team
+	          UNION
+	          SELECT org_id FROM pull_request
+	          UNION
+	          SELECT org_id FROM webhook_delivery WHERE status = 'pending'
+	          ORDER BY 1`
+
+	rows, err := getTx(ctx, r.pool).Query(ctx, query, orgctx.DefaultID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	defer rows.Close()
+
+	var orgs []string
+	for rows.Next() {
+		var orgID string
+		if err = rows.Scan(&orgID); err != nil {
+			return nil, fmt.Errorf("failed to scan organization: %w", err)
+		}
+		orgs = append(orgs, orgID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return orgs, nil
+}
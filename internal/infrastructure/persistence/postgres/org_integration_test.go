@@ -0,0 +1,113 @@
+//go:build integration
+
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrgRepository_ListOrgs(t *testing.T) {
+	t.Run("Success - Default organization listed on an empty database", func(t *testing.T) {
+		f := newFixture(t)
+
+		orgs, err := f.orgs.ListOrgs(f.ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{orgctx.DefaultID}, orgs)
+	})
+
+	t.Run("Success - Organizations holding data are listed", func(t *testing.T) {
+		f := newFixture(t)
+		f.inOrg("payments").team("backend", "user-1")
+
+		orgs, err := f.orgs.ListOrgs(f.ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{orgctx.DefaultID, "payments"}, orgs)
+	})
+
+	t.Run("Success - Organizations with pending webhook deliveries are listed", func(t *testing.T) {
+		f := newFixture(t)
+		marketing := f.inOrg("marketing")
+		require.NoError(t, marketing.webhooks.Enqueue(marketing.ctx, &models.WebhookDelivery{
+			EventType: "review.escalated", Payload: []byte(`{}`), NextAttemptAt: time.Now().UTC(),
+		}))
+
+		orgs, err := f.orgs.ListOrgs(f.ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{orgctx.DefaultID, "marketing"}, orgs)
+	})
+}
+
+func TestOrgIsolation(t *testing.T) {
+	f := newFixture(t)
+	payments := f.inOrg("payments")
+	now := time.Now().UTC()
+	f.team("backend", "user-1", "user-2", "user-3")
+	payments.team("backend", "user-1", "user-4")
+	f.pr("pr-1", "user-1", now, "user-2", "user-3")
+	payments.pr("pr-1", "user-1", now, "user-4")
+
+	t.Run("Success - Teams of the same name keep their own members", func(t *testing.T) {
+		team, err := payments.teams.GetTeamByName(payments.ctx, "backend")
+
+		require.NoError(t, err)
+		var userIDs []string
+		for _, member := range team.Members {
+			userIDs = append(userIDs, member.Id)
+		}
+		assert.ElementsMatch(t, []string{"user-1", "user-4"}, userIDs)
+	})
+
+	t.Run("Success - PRs of the same id keep their own reviewers", func(t *testing.T) {
+		assert.Equal(t, 1, payments.reviewerCount("pr-1"))
+		assert.Equal(t, 2, f.reviewerCount("pr-1"))
+		loads, err := f.reviewers.GetReviewLoads(f.ctx, "backend")
+		require.NoError(t, err)
+		active := map[string]int{}
+		for _, load := range loads {
+			active[load.UserId] = load.ActiveReviews
+		}
+		assert.Equal(t, map[string]int{"user-1": 0, "user-2": 1, "user-3": 1}, active)
+	})
+
+	t.Run("Success - Webhook deliveries stay in their organization", func(t *testing.T) {
+		delivery := &models.WebhookDelivery{EventType: "review.escalated", Payload: []byte(`{}`), NextAttemptAt: now}
+		require.NoError(t, payments.webhooks.Enqueue(payments.ctx, delivery))
+		delivery.Status, delivery.Attempts = models.DeliveryStatusDead, 1
+		require.NoError(t, payments.webhooks.SaveAttempt(payments.ctx, delivery, &models.WebhookAttempt{
+			DeliveryId: delivery.Id, Attempt: 1, AttemptedAt: now, Error: "timeout",
+		}))
+
+		dead, total, err := f.webhooks.ListDead(f.ctx, 10, 0)
+		require.NoError(t, err)
+		assert.Empty(t, dead)
+		assert.Zero(t, total)
+		stats, err := f.webhooks.Stats(f.ctx)
+		require.NoError(t, err)
+		assert.Equal(t, models.WebhookDeliveryStats{}, *stats)
+		requeued, err := f.webhooks.Requeue(f.ctx, delivery.Id, now)
+		require.NoError(t, err)
+		assert.False(t, requeued)
+
+		stats, err = payments.webhooks.Stats(payments.ctx)
+		require.NoError(t, err)
+		assert.Equal(t, models.WebhookDeliveryStats{Dead: 1, Attempts: 1, FailedAttempts: 1}, *stats)
+	})
+
+	t.Run("Success - Merging a PR leaves the PR of the same id in the other organization", func(t *testing.T) {
+		payments.merge("pr-1")
+
+		pr, err := f.prs.FindByID(f.ctx, "pr-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, models.PRStatusOpen, pr.Status)
+	})
+}
@@ -0,0 +1,167 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+)
+
+// poolTracer traces a connection pool. It bounds the wait for a connection by acquireTimeout,
+// counts the callers waiting and those that gave up, and passes statements to the query logger
+// when there is one.
+type poolTracer struct {
+	queries        *QueryLogger
+	acquireTimeout time.Duration
+	waiting        atomic.Int64
+	timeouts       atomic.Int64
+}
+
+var (
+	_ pgx.QueryTracer       = (*poolTracer)(nil)
+	_ pgx.BatchTracer       = (*poolTracer)(nil)
+	_ pgxpool.AcquireTracer = (*poolTracer)(nil)
+)
+
+// acquireKey is a key for storing the acquire in progress in context.
+type acquireKey struct{}
+
+// acquire is what TraceAcquireStart passes to TraceAcquireEnd.
+type acquire struct {
+	caller context.Context
+	cancel context.CancelFunc
+}
+
+// TraceAcquireStart counts the caller as waiting and bounds the wait by the acquire timeout; pgxpool
+// waits for a connection with the returned context.
+func (t *poolTracer) TraceAcquireStart(ctx context.Context, _ *pgxpool.Pool, _ pgxpool.TraceAcquireStartData) context.Context {
+	t.waiting.Add(1)
+	if t.acquireTimeout <= 0 {
+		return ctx
+	}
+	acquireCtx, cancel := context.WithTimeout(ctx, t.acquireTimeout)
+	return context.WithValue(acquireCtx, acquireKey{}, acquire{caller: ctx, cancel: cancel})
+}
+
+// TraceAcquireEnd ends the wait, counting it when the acquire timeout rather than the caller ended it.
+func (t *poolTracer) TraceAcquireEnd(ctx context.Context, _ *pgxpool.Pool, data pgxpool.TraceAcquireEndData) {
+	t.waiting.Add(-1)
+	a, ok := ctx.Value(acquireKey{}).(acquire)
+	if !ok {
+		return
+	}
+	if data.Err != nil && a.caller.Err() == nil && errors.Is(data.Err, context.DeadlineExceeded) {
+		t.timeouts.Add(1)
+	}
+	a.cancel()
+}
+
+// TraceQueryStart passes the statement to the query logger.
+func (t *poolTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if t.queries == nil {
+		return ctx
+	}
+	return t.queries.TraceQueryStart(ctx, conn, data)
+}
+
+// TraceQueryEnd passes the statement to the query logger.
+func (t *poolTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	if t.queries != nil {
+		t.queries.TraceQueryEnd(ctx, conn, data)
+	}
+}
+
+// TraceBatchStart passes the batch to the query logger.
+func (t *poolTracer) TraceBatchStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
+	if t.queries == nil {
+		return ctx
+	}
+	return t.queries.TraceBatchStart(ctx, conn, data)
+}
+
+// TraceBatchQuery passes the statement of a batch to the query logger.
+func (t *poolTracer) TraceBatchQuery(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchQueryData) {
+	if t.queries != nil {
+		t.queries.TraceBatchQuery(ctx, conn, data)
+	}
+}
+
+// TraceBatchEnd passes the end of the batch to the query logger.
+func (t *poolTracer) TraceBatchEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchEndData) {
+	if t.queries != nil {
+		t.queries.TraceBatchEnd(ctx, conn, data)
+	}
+}
+
+// poolError reports a failure to get a connection of the pool within the acquire timeout as
+// SERVICE_OVERLOADED; other errors are returned as is. Waiting for a connection is the only step
+// bounded by another deadline than that of ctx, so a timeout ctx didn't reach is such a failure.
+func poolError(ctx context.Context, err error) error {
+	if err != nil && ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+		return domainErrors.NewServiceOverloaded("no database connection is available, retry later")
+	}
+	return err
+}
+
+// poolExecutor runs statements outside transactions on a connection pool, reporting the failures
+// to get a connection in time by poolError.
+type poolExecutor struct {
+	pool *pgxpool.Pool
+}
+
+func (p poolExecutor) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	tag, err := p.pool.Exec(ctx, sql, arguments...)
+	return tag, poolError(ctx, err)
+}
+
+func (p poolExecutor) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	rows, err := p.pool.Query(ctx, sql, args...)
+	return rows, poolError(ctx, err)
+}
+
+func (p poolExecutor) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return poolRow{ctx: ctx, row: p.pool.QueryRow(ctx, sql, args...)}
+}
+
+func (p poolExecutor) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	return poolBatchResults{ctx: ctx, results: p.pool.SendBatch(ctx, b)}
+}
+
+// poolRow is a row of a pool, whose error surfaces on Scan.
+type poolRow struct {
+	ctx context.Context
+	row pgx.Row
+}
+
+func (r poolRow) Scan(dest ...any) error {
+	return poolError(r.ctx, r.row.Scan(dest...))
+}
+
+// poolBatchResults are the results of a batch sent to a pool, whose error surfaces on reading them.
+type poolBatchResults struct {
+	ctx     context.Context
+	results pgx.BatchResults
+}
+
+func (r poolBatchResults) Exec() (pgconn.CommandTag, error) {
+	tag, err := r.results.Exec()
+	return tag, poolError(r.ctx, err)
+}
+
+func (r poolBatchResults) Query() (pgx.Rows, error) {
+	rows, err := r.results.Query()
+	return rows, poolError(r.ctx, err)
+}
+
+func (r poolBatchResults) QueryRow() pgx.Row {
+	return poolRow{ctx: r.ctx, row: r.results.QueryRow()}
+}
+
+func (r poolBatchResults) Close() error {
+	return poolError(r.ctx, r.results.Close())
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolTracer_Acquire(t *testing.T) {
+	t.Run("Success - Wait is bounded by the acquire timeout", func(t *testing.T) {
+		tracer := &poolTracer{acquireTimeout: time.Second}
+
+		ctx := tracer.TraceAcquireStart(context.Background(), nil, pgxpool.TraceAcquireStartData{})
+		deadline, ok := ctx.Deadline()
+		assert.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(time.Second), deadline, 100*time.Millisecond)
+		assert.Equal(t, 1, int(tracer.waiting.Load()))
+
+		tracer.TraceAcquireEnd(ctx, nil, pgxpool.TraceAcquireEndData{})
+		assert.Zero(t, tracer.waiting.Load())
+		assert.Zero(t, tracer.timeouts.Load())
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	})
+
+	t.Run("Success - Acquire timeout is counted", func(t *testing.T) {
+		tracer := &poolTracer{acquireTimeout: time.Millisecond}
+
+		ctx := tracer.TraceAcquireStart(context.Background(), nil, pgxpool.TraceAcquireStartData{})
+		<-ctx.Done()
+		tracer.TraceAcquireEnd(ctx, nil, pgxpool.TraceAcquireEndData{Err: ctx.Err()})
+
+		assert.Zero(t, tracer.waiting.Load())
+		assert.Equal(t, int64(1), tracer.timeouts.Load())
+	})
+
+	t.Run("Success - Deadline of the caller is not counted", func(t *testing.T) {
+		tracer := &poolTracer{acquireTimeout: time.Second}
+		caller, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+
+		ctx := tracer.TraceAcquireStart(caller, nil, pgxpool.TraceAcquireStartData{})
+		<-ctx.Done()
+		tracer.TraceAcquireEnd(ctx, nil, pgxpool.TraceAcquireEndData{Err: ctx.Err()})
+
+		assert.Zero(t, tracer.timeouts.Load())
+	})
+
+	t.Run("Success - Zero timeout leaves the wait unbounded", func(t *testing.T) {
+		tracer := &poolTracer{}
+
+		ctx := tracer.TraceAcquireStart(context.Background(), nil, pgxpool.TraceAcquireStartData{})
+		_, ok := ctx.Deadline()
+		assert.False(t, ok)
+
+		tracer.TraceAcquireEnd(ctx, nil, pgxpool.TraceAcquireEndData{})
+		assert.Zero(t, tracer.waiting.Load())
+	})
+}
+
+func TestPoolError(t *testing.T) {
+	timedOut := fmt.Errorf("failed to connect: %w", context.DeadlineExceeded)
+
+	t.Run("Success - Acquire timeout is SERVICE_OVERLOADED", func(t *testing.T) {
+		err := poolError(context.Background(), timedOut)
+
+		assert.True(t, domainErrors.HasCode(err, domainErrors.CodeServiceOverloaded))
+	})
+
+	t.Run("Success - Deadline of the caller is kept", func(t *testing.T) {
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer cancel()
+
+		assert.Equal(t, timedOut, poolError(ctx, timedOut))
+	})
+
+	t.Run("Success - Other errors are kept", func(t *testing.T) {
+		err := errors.New("connection refused")
+
+		assert.Equal(t, err, poolError(context.Background(), err))
+		assert.NoError(t, poolError(context.Background(), nil))
+	})
+}
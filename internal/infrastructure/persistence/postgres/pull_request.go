@@ -4,44 +4,89 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
 	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
 )
 
 type PullRequestRepository struct {
 	pool *pgxpool.Pool
+	// replica serves reads of read-only contexts; nil without a configured replica.
+	replica *pgxpool.Pool
 }
 
 // Create creates a new Pull Request.
+// Returns PR_EXISTS AppError when a PR with the same id already exists.
 func (r *PullRequestRepository) Create(ctx context.Context, pr *models.PullRequest) error {
-	query := `INSERT INTO pull_request (id, title, author_id, status, created_at, updated_at) 
-	          VALUES ($1, $2, $3, $4, $5, $6)`
+	query := `INSERT INTO pull_request (id, title, author_id, status, created_at, updated_at, priority, labels, assignment_skipped,
+	                                    org_id) 
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
 
 	executor := getTx(ctx, r.pool)
 	_, err := executor.Exec(ctx, query,
-		pr.Id, pr.Title, pr.AuthorId, pr.Status, pr.CreatedAt, pr.UpdatedAt,
+		pr.Id, pr.Title, pr.AuthorId, pr.Status, pr.CreatedAt, pr.UpdatedAt, pr.Priority, textArray(pr.Labels),
+		pr.AssignmentSkipped, orgctx.ID(ctx),
 	)
 	if err != nil {
+		if isUniqueViolation(err) {
+			return domainErrors.NewPRExists("PR id already exists")
+		}
 		return fmt.Errorf("failed to create pull request: %w", err)
 	}
 
 	return nil
 }
 
+// CreateBatch inserts the PRs with one batch of statements and returns ids of the inserted ones,
+// skipping PRs whose id already exists.
+func (r *PullRequestRepository) CreateBatch(ctx context.Context, prs []*models.PullRequest) ([]string, error) {
+	query := `INSERT INTO pull_request (id, title, author_id, status, created_at, updated_at, priority, labels, assignment_skipped,
+	                                    org_id) 
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	          ON CONFLICT (org_id, id) DO NOTHING`
+
+	batch := &pgx.Batch{}
+	for _, pr := range prs {
+		batch.Queue(query,
+			pr.Id, pr.Title, pr.AuthorId, pr.Status, pr.CreatedAt, pr.UpdatedAt, pr.Priority, textArray(pr.Labels),
+			pr.AssignmentSkipped, orgctx.ID(ctx),
+		)
+	}
+
+	executor := getTx(ctx, r.pool)
+	results := executor.SendBatch(ctx, batch)
+	defer results.Close()
+
+	created := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		tag, err := results.Exec()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create pull requests: %w", err)
+		}
+		if tag.RowsAffected() > 0 {
+			created = append(created, pr.Id)
+		}
+	}
+
+	return created, nil
+}
+
 // FindByID finds PR by ID.
 func (r *PullRequestRepository) FindByID(ctx context.Context, prID string) (*models.PullRequest, error) {
-	query := `SELECT id, title, author_id, status, created_at, merged_at, updated_at 
+	query := `SELECT id, title, author_id, status, created_at, merged_at, updated_at, priority, labels, assignment_skipped 
 	          FROM pull_request 
-	          WHERE id = $1`
+	          WHERE org_id = $1 AND id = $2`
 
-	executor := getTx(ctx, r.pool)
+	executor := getReader(ctx, r.pool, r.replica)
 	var pr models.PullRequest
-	err := executor.QueryRow(ctx, query, prID).Scan(
+	err := executor.QueryRow(ctx, query, orgctx.ID(ctx), prID).Scan(
 		&pr.Id, &pr.Title, &pr.AuthorId, &pr.Status,
-		&pr.CreatedAt, &pr.MergedAt, &pr.UpdatedAt,
+		&pr.CreatedAt, &pr.MergedAt, &pr.UpdatedAt, &pr.Priority, &pr.Labels, &pr.AssignmentSkipped,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -55,11 +100,11 @@ func (r *PullRequestRepository) FindByID(ctx context.Context, prID string) (*mod
 
 // Exists checks if a PR exists by ID.
 func (r *PullRequestRepository) Exists(ctx context.Context, prID string) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM pull_request WHERE id = $1)`
+	query := `SELECT EXISTS(SELECT 1 FROM pull_request WHERE org_id = $1 AND id = $2)`
 
-	executor := getTx(ctx, r.pool)
+	executor := getReader(ctx, r.pool, r.replica)
 	var exists bool
-	err := executor.QueryRow(ctx, query, prID).Scan(&exists)
+	err := executor.QueryRow(ctx, query, orgctx.ID(ctx), prID).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("failed to check PR existence: %w", err)
 	}
@@ -67,30 +112,95 @@ func (r *PullRequestRepository) Exists(ctx context.Context, prID string) (bool,
 	return exists, nil
 }
 
-// UpdateStatus updates PR status.
-func (r *PullRequestRepository) UpdateStatus(ctx context.Context, prID, status string, mergedAt *time.Time) error {
+// UpdateStatus moves the PR from fromStatus to status and returns the number of updated rows.
+// No rows are updated when the PR is not in fromStatus anymore, including when a concurrent
+// transaction changed it after the snapshot was taken; the surrounding transaction stays usable.
+func (r *PullRequestRepository) UpdateStatus(ctx context.Context, prID, fromStatus, status string,
+	mergedAt *time.Time) (int, error) {
+	query := `UPDATE pull_request 
+	          SET status = $3, merged_at = $4, updated_at = $5 
+	          WHERE id = $1 AND status = $2 AND org_id = $6`
+	args := []any{prID, fromStatus, status, mergedAt, time.Now().UTC(), orgctx.ID(ctx)}
+
+	tx, ok := ctx.Value(txKey{}).(pgx.Tx)
+	if !ok {
+		result, err := r.pool.Exec(ctx, query, args...)
+		if err != nil {
+			return 0, fmt.Errorf("failed to update PR status: %w", poolError(ctx, err))
+		}
+		return int(result.RowsAffected()), nil
+	}
+
+	// savepoint keeps the transaction usable after a serialization failure
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create savepoint: %w", err)
+	}
+
+	result, err := savepoint.Exec(ctx, query, args...)
+	if err != nil {
+		_ = savepoint.Rollback(ctx)
+		if isSerializationFailure(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to update PR status: %w", err)
+	}
+
+	if err = savepoint.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to release savepoint: %w", err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+// SetLabels replaces the labels of a PR.
+func (r *PullRequestRepository) SetLabels(ctx context.Context, prID string, labels []string) error {
+	query := `UPDATE pull_request 
+	          SET labels = $2, updated_at = $3 
+	          WHERE org_id = $4 AND id = $1`
+
+	executor := getTx(ctx, r.pool)
+	_, err := executor.Exec(ctx, query, prID, textArray(labels), time.Now().UTC(), orgctx.ID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to set PR labels: %w", err)
+	}
+
+	return nil
+}
+
+// ClearAssignmentSkipped marks the reviewer assignment of a PR as no longer skipped.
+func (r *PullRequestRepository) ClearAssignmentSkipped(ctx context.Context, prID string) error {
 	query := `UPDATE pull_request 
-	          SET status = $2, merged_at = $3, updated_at = $4 
-	          WHERE id = $1`
+	          SET assignment_skipped = false, updated_at = $2 
+	          WHERE org_id = $3 AND id = $1 AND assignment_skipped`
 
-	_, err := r.pool.Exec(ctx, query, prID, status, mergedAt, time.Now())
+	executor := getTx(ctx, r.pool)
+	_, err := executor.Exec(ctx, query, prID, time.Now().UTC(), orgctx.ID(ctx))
 	if err != nil {
-		return fmt.Errorf("failed to update PR status: %w", err)
+		return fmt.Errorf("failed to clear PR assignment skip: %w", err)
 	}
 
 	return nil
 }
 
+// textArray returns an empty slice for nil, which pgx would otherwise send as NULL.
+func textArray(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
 // FindByReviewer finds all PR, where the user is assigned as a reviewer.
 func (r *PullRequestRepository) FindByReviewer(ctx context.Context, reviewerID string) ([]*models.PullRequest, error) {
 	query := `SELECT DISTINCT pr.id, pr.title, pr.author_id, pr.status, 
-	                 pr.created_at, pr.merged_at, pr.updated_at
+	                 pr.created_at, pr.merged_at, pr.updated_at, pr.priority, pr.labels, pr.assignment_skipped
 	          FROM pull_request pr
-	          JOIN pr_reviewer prr ON pr.id = prr.pr_id
-	          WHERE prr.reviewer_id = $1
+	          JOIN pr_reviewer prr ON prr.org_id = pr.org_id AND pr.id = prr.pr_id
+	          WHERE pr.org_id = $1 AND prr.reviewer_id = $2
 	          ORDER BY pr.created_at DESC`
 
-	rows, err := r.pool.Query(ctx, query, reviewerID)
+	rows, err := getReader(ctx, r.pool, r.replica).Query(ctx, query, orgctx.ID(ctx), reviewerID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find PRs by reviewer: %w", err)
 	}
@@ -101,7 +211,7 @@ func (r *PullRequestRepository) FindByReviewer(ctx context.Context, reviewerID s
 		var pr models.PullRequest
 		if err = rows.Scan(
 			&pr.Id, &pr.Title, &pr.AuthorId, &pr.Status,
-			&pr.CreatedAt, &pr.MergedAt, &pr.UpdatedAt,
+			&pr.CreatedAt, &pr.MergedAt, &pr.UpdatedAt, &pr.Priority, &pr.Labels, &pr.AssignmentSkipped,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan PR: %w", err)
 		}
@@ -111,13 +221,16 @@ func (r *PullRequestRepository) FindByReviewer(ctx context.Context, reviewerID s
 	return prs, nil
 }
 
-// GetAllPRs returns all pull requests.
-func (r *PullRequestRepository) GetAllPRs(ctx context.Context) ([]*models.PullRequest, error) {
-	query := `SELECT id, title, author_id, status, created_at, merged_at, updated_at
-	          FROM pull_request
+// GetAllPRs returns all pull requests, or those authored by members of a team unless teamName is empty.
+func (r *PullRequestRepository) GetAllPRs(ctx context.Context, teamName string) ([]*models.PullRequest, error) {
+	query := `SELECT id, title, author_id, status, created_at, merged_at, updated_at, priority, labels, assignment_skipped
+	          FROM pull_request pr
+	          WHERE pr.org_id = $2
+	            AND ($1 = '' OR EXISTS (SELECT 1 FROM "user" a
+	                                    WHERE a.org_id = pr.org_id AND a.id = pr.author_id AND a.team_name = $1))
 	          ORDER BY created_at DESC`
 
-	rows, err := r.pool.Query(ctx, query)
+	rows, err := getReader(ctx, r.pool, r.replica).Query(ctx, query, teamName, orgctx.ID(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all PRs: %w", err)
 	}
@@ -128,7 +241,7 @@ func (r *PullRequestRepository) GetAllPRs(ctx context.Context) ([]*models.PullRe
 		var pr models.PullRequest
 		if err = rows.Scan(
 			&pr.Id, &pr.Title, &pr.AuthorId, &pr.Status,
-			&pr.CreatedAt, &pr.MergedAt, &pr.UpdatedAt,
+			&pr.CreatedAt, &pr.MergedAt, &pr.UpdatedAt, &pr.Priority, &pr.Labels, &pr.AssignmentSkipped,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan PR: %w", err)
 		}
@@ -138,17 +251,184 @@ func (r *PullRequestRepository) GetAllPRs(ctx context.Context) ([]*models.PullRe
 	return prs, nil
 }
 
+// GetAuthorStats counts the PRs of every author and their reviewers, adding archived PRs with
+// includeArchived, the most prolific authors first. Unless teamName is empty only the members of the
+// team are counted; otherwise authors who are not known users are kept with a nil username.
+func (r *PullRequestRepository) GetAuthorStats(ctx context.Context, teamName string,
+	includeArchived bool) ([]*models.AuthorStats, error) {
+	query := `WITH authored AS (
+	              SELECT pr.author_id, pr.status,
+	                     (SELECT COUNT(*) FROM pr_reviewer r WHERE r.org_id = pr.org_id AND r.pr_id = pr.id) AS reviewers
+	              FROM pull_request pr
+	              WHERE pr.org_id = $3
+	              UNION ALL
+	              SELECT pr.author_id, pr.status,
+	                     (SELECT COUNT(*) FROM pr_reviewer_archive r WHERE r.org_id = pr.org_id AND r.pr_id = pr.id)
+	              FROM pull_request_archive pr
+	              WHERE $2 AND pr.org_id = $3
+	          )
+	          SELECT a.author_id, u.username, COUNT(*), COUNT(*) FILTER (WHERE a.status = 'OPEN'),
+	                 COUNT(*) FILTER (WHERE a.status = 'MERGED'), SUM(a.reviewers)::int
+	          FROM authored a
+	          LEFT JOIN "user" u ON u.org_id = $3 AND u.id = a.author_id
+	          WHERE a.author_id IS NOT NULL AND ($1 = '' OR u.team_name = $1)
+	          GROUP BY a.author_id, u.username
+	          ORDER BY COUNT(*) DESC, a.author_id`
+
+	rows, err := getReader(ctx, r.pool, r.replica).Query(ctx, query, teamName, includeArchived, orgctx.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get author stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []*models.AuthorStats
+	for rows.Next() {
+		var stat models.AuthorStats
+		if err = rows.Scan(&stat.AuthorId, &stat.Username, &stat.TotalPRs, &stat.OpenPRs, &stat.MergedPRs,
+			&stat.Reviewers); err != nil {
+			return nil, fmt.Errorf("failed to scan author stats: %w", err)
+		}
+		stats = append(stats, &stat)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetAuthorStatsByID counts the PRs of an author and their reviewers, adding archived PRs with
+// includeArchived. The counts are zero for a user who authored no PRs; the username is not read.
+func (r *PullRequestRepository) GetAuthorStatsByID(ctx context.Context, authorID string,
+	includeArchived bool) (*models.AuthorStats, error) {
+	query := `WITH authored AS (
+	              SELECT pr.status,
+	                     (SELECT COUNT(*) FROM pr_reviewer r WHERE r.org_id = pr.org_id AND r.pr_id = pr.id) AS reviewers
+	              FROM pull_request pr
+	              WHERE pr.org_id = $3 AND pr.author_id = $1
+	              UNION ALL
+	              SELECT pr.status,
+	                     (SELECT COUNT(*) FROM pr_reviewer_archive r WHERE r.org_id = pr.org_id AND r.pr_id = pr.id)
+	              FROM pull_request_archive pr
+	              WHERE $2 AND pr.org_id = $3 AND pr.author_id = $1
+	          )
+	          SELECT COUNT(*), COUNT(*) FILTER (WHERE status = 'OPEN'), COUNT(*) FILTER (WHERE status = 'MERGED'),
+	                 COALESCE(SUM(reviewers), 0)::int
+	          FROM authored`
+
+	stat := models.AuthorStats{AuthorId: authorID}
+	err := getReader(ctx, r.pool, r.replica).QueryRow(ctx, query, authorID, includeArchived, orgctx.ID(ctx)).Scan(
+		&stat.TotalPRs, &stat.OpenPRs, &stat.MergedPRs, &stat.Reviewers,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get author stats: %w", err)
+	}
+
+	return &stat, nil
+}
+
+// GetArchivedPRs gets all archived PRs, or those authored by members of a team unless teamName is
+// empty, most recently created first.
+func (r *PullRequestRepository) GetArchivedPRs(ctx context.Context, teamName string) ([]*models.PullRequest, error) {
+	query := `SELECT id, title, author_id, status, created_at, merged_at, updated_at, priority, labels
+	          FROM pull_request_archive pr
+	          WHERE pr.org_id = $2
+	            AND ($1 = '' OR EXISTS (SELECT 1 FROM "user" a
+	                                    WHERE a.org_id = pr.org_id AND a.id = pr.author_id AND a.team_name = $1))
+	          ORDER BY created_at DESC`
+
+	rows, err := getReader(ctx, r.pool, r.replica).Query(ctx, query, teamName, orgctx.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archived PRs: %w", err)
+	}
+	defer rows.Close()
+
+	var prs []*models.PullRequest
+	for rows.Next() {
+		var pr models.PullRequest
+		if err = rows.Scan(
+			&pr.Id, &pr.Title, &pr.AuthorId, &pr.Status,
+			&pr.CreatedAt, &pr.MergedAt, &pr.UpdatedAt, &pr.Priority, &pr.Labels,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan archived PR: %w", err)
+		}
+		prs = append(prs, &pr)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return prs, nil
+}
+
+// GetActivity counts the PRs created and merged and the reviewer changes made within [from, to) in
+// buckets of the given size, archived PRs included, counting only the PRs authored by members of a
+// team unless teamName is empty. Buckets without any of them are absent; the others are ordered by
+// start.
+func (r *PullRequestRepository) GetActivity(ctx context.Context, teamName, bucket string,
+	from, to time.Time) ([]*models.ActivityBucket, error) {
+	query := `SELECT date_trunc($1, at AT TIME ZONE 'UTC') AS start,
+	                 COUNT(*) FILTER (WHERE kind = 'created'),
+	                 COUNT(*) FILTER (WHERE kind = 'merged'),
+	                 COUNT(*) FILTER (WHERE kind = 'reassigned')
+	          FROM (
+	              SELECT 'created' AS kind, created_at AS at, author_id FROM pull_request WHERE org_id = $4
+	              UNION ALL
+	              SELECT 'created', created_at, author_id FROM pull_request_archive WHERE org_id = $4
+	              UNION ALL
+	              SELECT 'merged', merged_at, author_id FROM pull_request
+	              WHERE org_id = $4 AND merged_at IS NOT NULL
+	              UNION ALL
+	              SELECT 'merged', merged_at, author_id FROM pull_request_archive
+	              WHERE org_id = $4 AND merged_at IS NOT NULL
+	              UNION ALL
+	              SELECT 'reassigned', e.changed_at,
+	                     COALESCE((SELECT author_id FROM pull_request WHERE org_id = e.org_id AND id = e.pr_id),
+	                              (SELECT author_id FROM pull_request_archive WHERE org_id = e.org_id AND id = e.pr_id))
+	              FROM reviewer_assignment_event e WHERE e.org_id = $4
+	          ) events
+	          WHERE at >= $2 AND at < $3
+	            AND ($5 = '' OR EXISTS (SELECT 1 FROM "user" a
+	                                    WHERE a.org_id = $4 AND a.id = events.author_id AND a.team_name = $5))
+	          GROUP BY start
+	          ORDER BY start`
+
+	rows, err := getReader(ctx, r.pool, r.replica).Query(ctx, query, bucket, from, to, orgctx.ID(ctx), teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activity: %w", err)
+	}
+	defer rows.Close()
+
+	var buckets []*models.ActivityBucket
+	for rows.Next() {
+		b := &models.ActivityBucket{}
+		if err = rows.Scan(&b.Start, &b.Created, &b.Merged, &b.Reassignments); err != nil {
+			return nil, fmt.Errorf("failed to scan activity bucket: %w", err)
+		}
+		b.Start = b.Start.UTC()
+		buckets = append(buckets, b)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return buckets, nil
+}
+
 // FindOpenPRsByReviewers finds all open PRs where any of the specified reviewers is assigned.
 func (r *PullRequestRepository) FindOpenPRsByReviewers(ctx context.Context, reviewerIDs []string) ([]*models.PullRequest, error) {
 	query := `SELECT DISTINCT pr.id, pr.title, pr.author_id, pr.status, 
-	                 pr.created_at, pr.merged_at, pr.updated_at
+	                 pr.created_at, pr.merged_at, pr.updated_at, pr.priority, pr.labels, pr.assignment_skipped
 	          FROM pull_request pr
-	          JOIN pr_reviewer prr ON pr.id = prr.pr_id
-	          WHERE prr.reviewer_id = ANY($1) AND pr.status = 'OPEN'
+	          JOIN pr_reviewer prr ON prr.org_id = pr.org_id AND pr.id = prr.pr_id
+	          WHERE pr.org_id = $2 AND prr.reviewer_id = ANY($1) AND pr.status = 'OPEN'
 	          ORDER BY pr.created_at DESC`
 
-	executor := getTx(ctx, r.pool)
-	rows, err := executor.Query(ctx, query, reviewerIDs)
+	executor := getReader(ctx, r.pool, r.replica)
+	rows, err := executor.Query(ctx, query, reviewerIDs, orgctx.ID(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to find open PRs by reviewers: %w", err)
 	}
@@ -159,7 +439,7 @@ func (r *PullRequestRepository) FindOpenPRsByReviewers(ctx context.Context, revi
 		var pr models.PullRequest
 		if err = rows.Scan(
 			&pr.Id, &pr.Title, &pr.AuthorId, &pr.Status,
-			&pr.CreatedAt, &pr.MergedAt, &pr.UpdatedAt,
+			&pr.CreatedAt, &pr.MergedAt, &pr.UpdatedAt, &pr.Priority, &pr.Labels, &pr.AssignmentSkipped,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan PR: %w", err)
 		}
@@ -168,3 +448,252 @@ func (r *PullRequestRepository) FindOpenPRsByReviewers(ctx context.Context, revi
 
 	return prs, nil
 }
+
+// FindOpenPRsByAuthors finds all open PRs authored by any of the specified users.
+func (r *PullRequestRepository) FindOpenPRsByAuthors(ctx context.Context, authorIDs []string) ([]*models.PullRequest, error) {
+	query := `SELECT pr.id, pr.title, pr.author_id, pr.status,
+	                 pr.created_at, pr.merged_at, pr.updated_at, pr.priority, pr.labels, pr.assignment_skipped
+	          FROM pull_request pr
+	          WHERE pr.org_id = $2 AND pr.author_id = ANY($1) AND pr.status = 'OPEN'
+	          ORDER BY pr.created_at DESC, pr.id`
+
+	executor := getReader(ctx, r.pool, r.replica)
+	rows, err := executor.Query(ctx, query, authorIDs, orgctx.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find open PRs by authors: %w", err)
+	}
+	defer rows.Close()
+
+	var prs []*models.PullRequest
+	for rows.Next() {
+		var pr models.PullRequest
+		if err = rows.Scan(
+			&pr.Id, &pr.Title, &pr.AuthorId, &pr.Status,
+			&pr.CreatedAt, &pr.MergedAt, &pr.UpdatedAt, &pr.Priority, &pr.Labels, &pr.AssignmentSkipped,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan PR: %w", err)
+		}
+		prs = append(prs, &pr)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return prs, nil
+}
+
+// SearchByTitle finds PRs whose title contains the query (case-insensitive), optionally filtered by status
+// and labels. An empty status matches all PRs; a PR matches the labels when it has all of them.
+// Results are ordered by order, by creation time, newest first, when it is unset.
+func (r *PullRequestRepository) SearchByTitle(ctx context.Context, query, status string, labels []string,
+	order models.PRSort, limit, offset int) ([]*models.PullRequest, error) {
+	orderBy, err := prOrderBy(order, "", "created_at DESC, id")
+	if err != nil {
+		return nil, err
+	}
+	sqlQuery := `SELECT id, title, author_id, status, created_at, merged_at, updated_at, priority, labels, assignment_skipped
+	             FROM pull_request
+	             WHERE org_id = $6
+	               AND title ILIKE '%' || $1 || '%' ESCAPE '\'
+	               AND ($2 = '' OR status::text = $2)
+	               AND labels @> $3
+	             ORDER BY ` + orderBy + `
+	             LIMIT $4 OFFSET $5`
+
+	executor := getReader(ctx, r.pool, r.replica)
+	rows, err := executor.Query(ctx, sqlQuery, escapeLike(query), status, textArray(labels), limit, offset,
+		orgctx.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search PRs by title: %w", err)
+	}
+	defer rows.Close()
+
+	var prs []*models.PullRequest
+	for rows.Next() {
+		var pr models.PullRequest
+		if err = rows.Scan(
+			&pr.Id, &pr.Title, &pr.AuthorId, &pr.Status,
+			&pr.CreatedAt, &pr.MergedAt, &pr.UpdatedAt, &pr.Priority, &pr.Labels, &pr.AssignmentSkipped,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan PR: %w", err)
+		}
+		prs = append(prs, &pr)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return prs, nil
+}
+
+// CountByTitle counts PRs matching SearchByTitle with the same query, status and labels.
+func (r *PullRequestRepository) CountByTitle(ctx context.Context, query, status string, labels []string) (int, error) {
+	sqlQuery := `SELECT COUNT(*)
+	             FROM pull_request
+	             WHERE org_id = $4
+	               AND title ILIKE '%' || $1 || '%' ESCAPE '\'
+	               AND ($2 = '' OR status::text = $2)
+	               AND labels @> $3`
+
+	var count int
+	executor := getReader(ctx, r.pool, r.replica)
+	err := executor.QueryRow(ctx, sqlQuery, escapeLike(query), status, textArray(labels), orgctx.ID(ctx)).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count PRs by title: %w", err)
+	}
+	return count, nil
+}
+
+// prSortColumns maps the sort fields of PR lists to their columns. ORDER BY clauses are built only
+// from these constants, so a sort field never reaches the query text.
+var prSortColumns = map[string]string{
+	models.PRSortCreatedAt: "created_at",
+	models.PRSortUpdatedAt: "updated_at",
+	models.PRSortTitle:     "title",
+	// the pr_priority enum orders by rank
+	models.PRSortPriority: "priority",
+}
+
+// prOrderBy returns the ORDER BY list for order with columns of the alias, ties broken by id,
+// or def when order is unset. An unknown field is an error.
+func prOrderBy(order models.PRSort, alias, def string) (string, error) {
+	if order.Field == "" {
+		return def, nil
+	}
+	column, ok := prSortColumns[order.Field]
+	if !ok {
+		return "", fmt.Errorf("unsupported sort field %q", order.Field)
+	}
+	if alias != "" {
+		alias += "."
+	}
+	orderBy := alias + column
+	if order.Desc {
+		orderBy += " DESC"
+	}
+	return orderBy + ", " + alias + "id", nil
+}
+
+// escapeLike escapes LIKE wildcards so the value is matched literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// FindOpenPRsReviewedByTeam finds open PRs with reviewers from the team, ordered by order,
+// oldest first when it is unset. ReviewersId of each PR holds only the reviewers that belong to the team.
+func (r *PullRequestRepository) FindOpenPRsReviewedByTeam(ctx context.Context, teamName string,
+	order models.PRSort) ([]*models.PullRequest, error) {
+	orderBy, err := prOrderBy(order, "pr", "pr.created_at, pr.id")
+	if err != nil {
+		return nil, err
+	}
+	// rows of a PR stay adjacent as every order ends with the PR id
+	query := `SELECT pr.id, pr.title, pr.author_id, pr.status,
+	                 pr.created_at, pr.merged_at, pr.updated_at, pr.priority, pr.labels, pr.assignment_skipped, prr.reviewer_id
+	          FROM pull_request pr
+	          JOIN pr_reviewer prr ON prr.org_id = pr.org_id AND pr.id = prr.pr_id
+	          JOIN "user" u ON u.org_id = prr.org_id AND u.id = prr.reviewer_id
+	          WHERE pr.org_id = $2 AND u.team_name = $1 AND pr.status = 'OPEN'
+	          ORDER BY ` + orderBy + `, prr.reviewer_id`
+
+	executor := getReader(ctx, r.pool, r.replica)
+	rows, err := executor.Query(ctx, query, teamName, orgctx.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find open PRs reviewed by team: %w", err)
+	}
+	defer rows.Close()
+
+	var prs []*models.PullRequest
+	for rows.Next() {
+		var pr models.PullRequest
+		var reviewerID string
+		if err = rows.Scan(
+			&pr.Id, &pr.Title, &pr.AuthorId, &pr.Status,
+			&pr.CreatedAt, &pr.MergedAt, &pr.UpdatedAt, &pr.Priority, &pr.Labels, &pr.AssignmentSkipped, &reviewerID,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan PR: %w", err)
+		}
+		if n := len(prs); n > 0 && prs[n-1].Id == pr.Id {
+			prs[n-1].ReviewersId = append(prs[n-1].ReviewersId, reviewerID)
+			continue
+		}
+		pr.ReviewersId = []string{reviewerID}
+		prs = append(prs, &pr)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return prs, nil
+}
+
+// FindOpenWithoutReviewers finds open PRs that have no assigned reviewers and carry all the labels,
+// ordered by order, oldest first when it is unset. Unless skipped is nil, it keeps only the PRs
+// whose reviewer assignment was skipped, or only the others; unless teamName is empty, only the PRs
+// authored by members of the team.
+func (r *PullRequestRepository) FindOpenWithoutReviewers(ctx context.Context, teamName string, labels []string,
+	skipped *bool, order models.PRSort, limit, offset int) ([]*models.PullRequest, error) {
+	orderBy, err := prOrderBy(order, "pr", "pr.created_at, pr.id")
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT pr.id, pr.title, pr.author_id, pr.status,
+	                 pr.created_at, pr.merged_at, pr.updated_at, pr.priority, pr.labels, pr.assignment_skipped
+	          FROM pull_request pr
+	          WHERE pr.org_id = $6 AND pr.status = 'OPEN'
+	            AND pr.labels @> $1
+	            AND ($2::boolean IS NULL OR pr.assignment_skipped = $2)
+	            AND NOT EXISTS (SELECT 1 FROM pr_reviewer prr WHERE prr.org_id = pr.org_id AND prr.pr_id = pr.id)
+	            AND ($5 = '' OR EXISTS (SELECT 1 FROM "user" a
+	                                    WHERE a.org_id = pr.org_id AND a.id = pr.author_id AND a.team_name = $5))
+	          ORDER BY ` + orderBy + `
+	          LIMIT $3 OFFSET $4`
+
+	executor := getReader(ctx, r.pool, r.replica)
+	rows, err := executor.Query(ctx, query, textArray(labels), skipped, limit, offset, teamName, orgctx.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find PRs without reviewers: %w", err)
+	}
+	defer rows.Close()
+
+	var prs []*models.PullRequest
+	for rows.Next() {
+		var pr models.PullRequest
+		if err = rows.Scan(
+			&pr.Id, &pr.Title, &pr.AuthorId, &pr.Status,
+			&pr.CreatedAt, &pr.MergedAt, &pr.UpdatedAt, &pr.Priority, &pr.Labels, &pr.AssignmentSkipped,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan PR: %w", err)
+		}
+		prs = append(prs, &pr)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return prs, nil
+}
+
+// CountOpenWithoutReviewers counts the PRs found by FindOpenWithoutReviewers.
+func (r *PullRequestRepository) CountOpenWithoutReviewers(ctx context.Context, teamName string, labels []string,
+	skipped *bool) (int, error) {
+	query := `SELECT COUNT(*)
+	          FROM pull_request pr
+	          WHERE pr.org_id = $4 AND pr.status = 'OPEN'
+	            AND pr.labels @> $1
+	            AND ($2::boolean IS NULL OR pr.assignment_skipped = $2)
+	            AND NOT EXISTS (SELECT 1 FROM pr_reviewer prr WHERE prr.org_id = pr.org_id AND prr.pr_id = pr.id)
+	            AND ($3 = '' OR EXISTS (SELECT 1 FROM "user" a
+	                                    WHERE a.org_id = pr.org_id AND a.id = pr.author_id AND a.team_name = $3))`
+
+	var count int
+	executor := getReader(ctx, r.pool, r.replica)
+	if err := executor.QueryRow(ctx, query, textArray(labels), skipped, teamName, orgctx.ID(ctx)).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count PRs without reviewers: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,452 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// prIDs returns the IDs of the PRs in order.
+func prIDs(prs []*models.PullRequest) []string {
+	ids := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		ids = append(ids, pr.Id)
+	}
+	return ids
+}
+
+func TestPullRequestRepository_CreateAndFind(t *testing.T) {
+	f := newFixture(t)
+	f.team("backend", "u1")
+	createdAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Success - Stores all fields", func(t *testing.T) {
+		pr := &models.PullRequest{Id: "pr-1", Title: "Add search", AuthorId: "u1", Status: models.PRStatusOpen,
+			CreatedAt: createdAt, UpdatedAt: createdAt, Priority: models.PRPriorityUrgent, Labels: []string{"api", "infra"}}
+		assert.NoError(t, f.prs.Create(f.ctx, pr))
+
+		found, err := f.prs.FindByID(f.ctx, "pr-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Add search", found.Title)
+		assert.Equal(t, "u1", found.AuthorId)
+		assert.Equal(t, models.PRStatusOpen, found.Status)
+		assert.Equal(t, models.PRPriorityUrgent, found.Priority)
+		assert.Equal(t, []string{"api", "infra"}, found.Labels)
+		assert.True(t, createdAt.Equal(found.CreatedAt))
+		assert.Nil(t, found.MergedAt)
+	})
+
+	t.Run("Success - Nil labels are stored empty", func(t *testing.T) {
+		pr := &models.PullRequest{Id: "pr-2", Title: "No labels", AuthorId: "u1", Status: models.PRStatusOpen,
+			CreatedAt: createdAt, UpdatedAt: createdAt, Priority: models.PRPriorityNormal}
+		assert.NoError(t, f.prs.Create(f.ctx, pr))
+
+		found, err := f.prs.FindByID(f.ctx, "pr-2")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{}, found.Labels)
+	})
+
+	t.Run("Error - Duplicate id", func(t *testing.T) {
+		pr := &models.PullRequest{Id: "pr-1", Title: "Again", AuthorId: "u1", Status: models.PRStatusOpen,
+			CreatedAt: createdAt, UpdatedAt: createdAt, Priority: models.PRPriorityNormal}
+
+		err := f.prs.Create(f.ctx, pr)
+
+		assert.Error(t, err)
+		assert.Equal(t, domainErrors.CodePRExists, err.(*domainErrors.AppError).Code)
+	})
+
+	t.Run("Success - Batch skips existing ids", func(t *testing.T) {
+		newPR := func(id string) *models.PullRequest {
+			return &models.PullRequest{Id: id, Title: "Batch " + id, AuthorId: "u1", Status: models.PRStatusOpen,
+				CreatedAt: createdAt, UpdatedAt: createdAt, Priority: models.PRPriorityNormal, Labels: []string{"bulk"}}
+		}
+
+		created, err := f.prs.CreateBatch(f.ctx, []*models.PullRequest{newPR("pr-b1"), newPR("pr-1"), newPR("pr-b2")})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"pr-b1", "pr-b2"}, created)
+		found, err := f.prs.FindByID(f.ctx, "pr-b2")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"bulk"}, found.Labels)
+		existing, err := f.prs.FindByID(f.ctx, "pr-1")
+		assert.NoError(t, err)
+		assert.Equal(t, "Add search", existing.Title)
+	})
+
+	t.Run("Success - Missing PR", func(t *testing.T) {
+		found, err := f.prs.FindByID(f.ctx, "missing")
+
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("Success - Exists", func(t *testing.T) {
+		exists, err := f.prs.Exists(f.ctx, "pr-1")
+		assert.NoError(t, err)
+		assert.True(t, exists)
+
+		exists, err = f.prs.Exists(f.ctx, "missing")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestPullRequestRepository_Update(t *testing.T) {
+	f := newFixture(t)
+	f.team("backend", "u1")
+	f.pr("pr-1", "u1", time.Now().UTC().Add(-time.Hour))
+
+	t.Run("Success - UpdateStatus sets merged_at and updated_at", func(t *testing.T) {
+		before, _ := f.prs.FindByID(f.ctx, "pr-1")
+		mergedAt := time.Now().UTC().Truncate(time.Microsecond)
+
+		updated, err := f.prs.UpdateStatus(f.ctx, "pr-1", models.PRStatusOpen, models.PRStatusMerged, &mergedAt)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, updated)
+
+		found, err := f.prs.FindByID(f.ctx, "pr-1")
+		assert.NoError(t, err)
+		assert.Equal(t, models.PRStatusMerged, found.Status)
+		assert.True(t, mergedAt.Equal(*found.MergedAt))
+		assert.True(t, found.UpdatedAt.After(before.UpdatedAt))
+	})
+
+	t.Run("Success - UpdateStatus skips a PR in another status", func(t *testing.T) {
+		before, _ := f.prs.FindByID(f.ctx, "pr-1")
+		mergedAt := time.Now().UTC()
+
+		updated, err := f.prs.UpdateStatus(f.ctx, "pr-1", models.PRStatusOpen, models.PRStatusMerged, &mergedAt)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, updated)
+		found, _ := f.prs.FindByID(f.ctx, "pr-1")
+		assert.True(t, before.MergedAt.Equal(*found.MergedAt))
+	})
+
+	t.Run("Success - Concurrent merge keeps the transaction usable", func(t *testing.T) {
+		f.pr("pr-2", "u1", time.Now().UTC())
+		mergedAt := time.Now().UTC()
+
+		err := f.inTx(func(ctx context.Context) error {
+			// take the snapshot, then merge the PR behind it
+			if _, err := f.prs.FindByID(ctx, "pr-2"); err != nil {
+				return err
+			}
+			f.merge("pr-2")
+
+			updated, err := f.prs.UpdateStatus(ctx, "pr-2", models.PRStatusOpen, models.PRStatusMerged, &mergedAt)
+			assert.NoError(t, err)
+			assert.Equal(t, 0, updated)
+
+			_, err = f.prs.FindByID(ctx, "pr-2")
+			return err
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Success - UpdateStatus closes a PR", func(t *testing.T) {
+		f.pr("pr-3", "u1", time.Now().UTC())
+
+		updated, err := f.prs.UpdateStatus(f.ctx, "pr-3", models.PRStatusOpen, models.PRStatusClosed, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, updated)
+		found, _ := f.prs.FindByID(f.ctx, "pr-3")
+		assert.Equal(t, models.PRStatusClosed, found.Status)
+		assert.Nil(t, found.MergedAt)
+	})
+
+	t.Run("Success - SetLabels replaces labels", func(t *testing.T) {
+		assert.NoError(t, f.prs.SetLabels(f.ctx, "pr-1", []string{"docs"}))
+		found, _ := f.prs.FindByID(f.ctx, "pr-1")
+		assert.Equal(t, []string{"docs"}, found.Labels)
+
+		assert.NoError(t, f.prs.SetLabels(f.ctx, "pr-1", nil))
+		found, _ = f.prs.FindByID(f.ctx, "pr-1")
+		assert.Equal(t, []string{}, found.Labels)
+	})
+}
+
+func TestPullRequestRepository_Queries(t *testing.T) {
+	f := newFixture(t)
+	f.team("backend", "u1", "u2", "u3")
+	f.team("frontend", "f1")
+	base := time.Now().UTC().Add(-time.Hour)
+	f.pr("pr-old", "u1", base, "u2", "f1")
+	f.pr("pr-new", "u1", base.Add(time.Minute), "u2", "u3")
+	f.pr("pr-merged", "u1", base.Add(2*time.Minute), "u2")
+	f.merge("pr-merged")
+	f.pr("pr-empty", "u1", base.Add(3*time.Minute))
+
+	t.Run("Success - FindByReviewer returns all statuses newest first", func(t *testing.T) {
+		prs, err := f.prs.FindByReviewer(f.ctx, "u2")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"pr-merged", "pr-new", "pr-old"}, prIDs(prs))
+	})
+
+	t.Run("Success - GetAllPRs", func(t *testing.T) {
+		prs, err := f.prs.GetAllPRs(f.ctx, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"pr-empty", "pr-merged", "pr-new", "pr-old"}, prIDs(prs))
+	})
+
+	t.Run("Success - FindOpenPRsByReviewers skips merged PRs and duplicates", func(t *testing.T) {
+		prs, err := f.prs.FindOpenPRsByReviewers(f.ctx, []string{"u2", "u3"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"pr-new", "pr-old"}, prIDs(prs))
+	})
+
+	t.Run("Success - FindOpenPRsByAuthors skips merged PRs", func(t *testing.T) {
+		prs, err := f.prs.FindOpenPRsByAuthors(f.ctx, []string{"u1", "f1"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"pr-empty", "pr-new", "pr-old"}, prIDs(prs))
+	})
+
+	t.Run("Success - FindOpenPRsReviewedByTeam keeps only team reviewers", func(t *testing.T) {
+		prs, err := f.prs.FindOpenPRsReviewedByTeam(f.ctx, "backend", models.PRSort{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"pr-old", "pr-new"}, prIDs(prs))
+		assert.Equal(t, []string{"u2"}, prs[0].ReviewersId)
+		assert.Equal(t, []string{"u2", "u3"}, prs[1].ReviewersId)
+	})
+
+	t.Run("Success - FindOpenPRsReviewedByTeam newest first keeps reviewers together", func(t *testing.T) {
+		prs, err := f.prs.FindOpenPRsReviewedByTeam(f.ctx, "backend",
+			models.PRSort{Field: models.PRSortCreatedAt, Desc: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"pr-new", "pr-old"}, prIDs(prs))
+		assert.Equal(t, []string{"u2", "u3"}, prs[0].ReviewersId)
+	})
+
+	t.Run("Success - FindOpenWithoutReviewers", func(t *testing.T) {
+		prs, err := f.prs.FindOpenWithoutReviewers(f.ctx, "", nil, nil, models.PRSort{}, 10, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"pr-empty"}, prIDs(prs))
+
+		prs, err = f.prs.FindOpenWithoutReviewers(f.ctx, "", nil, nil, models.PRSort{}, 10, 1)
+		assert.NoError(t, err)
+		assert.Empty(t, prs)
+
+		count, err := f.prs.CountOpenWithoutReviewers(f.ctx, "", nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("Success - FindOpenWithoutReviewers by skipped assignment", func(t *testing.T) {
+		skipped, failed := true, false
+		f.exec(`UPDATE pull_request SET assignment_skipped = true WHERE id = 'pr-empty'`)
+
+		prs, err := f.prs.FindOpenWithoutReviewers(f.ctx, "", nil, &skipped, models.PRSort{}, 10, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"pr-empty"}, prIDs(prs))
+		assert.True(t, prs[0].AssignmentSkipped)
+		count, err := f.prs.CountOpenWithoutReviewers(f.ctx, "", nil, &failed)
+		assert.NoError(t, err)
+		assert.Zero(t, count)
+
+		assert.NoError(t, f.prs.ClearAssignmentSkipped(f.ctx, "pr-empty"))
+		pr, err := f.prs.FindByID(f.ctx, "pr-empty")
+		assert.NoError(t, err)
+		assert.False(t, pr.AssignmentSkipped)
+	})
+}
+
+func TestPullRequestRepository_GetActivity(t *testing.T) {
+	f := newFixture(t)
+	f.team("backend", "u1", "u2", "u3")
+	// 2024-06-03 is a Monday
+	monday := time.Date(2024, time.June, 3, 0, 0, 0, 0, time.UTC)
+	f.pr("pr-1", "u1", monday.Add(9*time.Hour), "u2")
+	f.pr("pr-2", "u1", monday.AddDate(0, 0, 1), "u2")
+	f.pr("pr-3", "u1", monday.AddDate(0, 0, 9))
+	f.exec(`UPDATE pull_request SET status = 'MERGED', merged_at = $2 WHERE id = $1`, "pr-1", monday.AddDate(0, 0, 8))
+	assert.NoError(t, f.reviewers.ReplaceReviewer(f.ctx, "pr-2", "u2", "u3", models.AssignmentSourceReassign))
+	assert.NoError(t, f.reviewers.RecordReviewerChange(f.ctx, &models.ReviewerChange{
+		PRId: "pr-2", OldReviewerId: "u2", NewReviewerId: "u3", Trigger: models.ReviewerChangeManual,
+		ChangedAt: monday.AddDate(0, 0, 2),
+	}))
+
+	t.Run("Success - Weeks start on Monday in UTC", func(t *testing.T) {
+		buckets, err := f.prs.GetActivity(f.ctx, "", models.ActivityBucketWeek, monday.AddDate(0, 0, -7),
+			monday.AddDate(0, 0, 14))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*models.ActivityBucket{
+			{Start: monday, Created: 2, Reassignments: 1},
+			{Start: monday.AddDate(0, 0, 7), Created: 1, Merged: 1},
+		}, buckets)
+	})
+
+	t.Run("Success - Only events within the range count", func(t *testing.T) {
+		buckets, err := f.prs.GetActivity(f.ctx, "", models.ActivityBucketDay, monday.Add(10*time.Hour),
+			monday.AddDate(0, 0, 3))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*models.ActivityBucket{
+			{Start: monday.AddDate(0, 0, 1), Created: 1},
+			{Start: monday.AddDate(0, 0, 2), Reassignments: 1},
+		}, buckets)
+	})
+
+	t.Run("Success - Team counts the PRs of its members", func(t *testing.T) {
+		f.team("frontend", "f1", "f2")
+		f.pr("pr-4", "f1", monday.AddDate(0, 0, 1), "f2")
+
+		buckets, err := f.prs.GetActivity(f.ctx, "backend", models.ActivityBucketWeek, monday, monday.AddDate(0, 0, 14))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*models.ActivityBucket{
+			{Start: monday, Created: 2, Reassignments: 1},
+			{Start: monday.AddDate(0, 0, 7), Created: 1, Merged: 1},
+		}, buckets)
+	})
+}
+
+func TestPullRequestRepository_SearchByTitle(t *testing.T) {
+	f := newFixture(t)
+	f.team("backend", "u1")
+	base := time.Now().UTC().Add(-time.Hour)
+	add := func(prID, title string, createdAt time.Time, labels ...string) {
+		pr := f.pr(prID, "u1", createdAt)
+		assert.NoError(t, f.prs.SetLabels(f.ctx, pr.Id, labels))
+		f.exec(`UPDATE pull_request SET title = $2 WHERE id = $1`, prID, title)
+	}
+	add("pr-1", "Fix Login page", base, "bug", "web")
+	add("pr-2", "login: 100% coverage", base.Add(time.Minute), "bug")
+	add("pr-3", "Refactor storage", base.Add(2*time.Minute))
+	f.merge("pr-1")
+
+	t.Run("Success - Case-insensitive match newest first", func(t *testing.T) {
+		prs, err := f.prs.SearchByTitle(f.ctx, "LOGIN", "", nil, models.PRSort{}, 10, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"pr-2", "pr-1"}, prIDs(prs))
+	})
+
+	t.Run("Success - Wildcards are matched literally", func(t *testing.T) {
+		prs, err := f.prs.SearchByTitle(f.ctx, "100%", "", nil, models.PRSort{}, 10, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"pr-2"}, prIDs(prs))
+
+		prs, err = f.prs.SearchByTitle(f.ctx, "_", "", nil, models.PRSort{}, 10, 0)
+		assert.NoError(t, err)
+		assert.Empty(t, prs)
+	})
+
+	t.Run("Success - Filters by status and labels", func(t *testing.T) {
+		prs, err := f.prs.SearchByTitle(f.ctx, "", models.PRStatusOpen, []string{"bug"}, models.PRSort{}, 10, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"pr-2"}, prIDs(prs))
+
+		prs, err = f.prs.SearchByTitle(f.ctx, "", "", []string{"bug", "web"}, models.PRSort{}, 10, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"pr-1"}, prIDs(prs))
+	})
+
+	t.Run("Success - Limit and offset", func(t *testing.T) {
+		prs, err := f.prs.SearchByTitle(f.ctx, "", "", nil, models.PRSort{}, 2, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"pr-3", "pr-2"}, prIDs(prs))
+
+		prs, err = f.prs.SearchByTitle(f.ctx, "", "", nil, models.PRSort{}, 2, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"pr-1"}, prIDs(prs))
+	})
+
+	t.Run("Success - Sorted by the requested field", func(t *testing.T) {
+		prs, err := f.prs.SearchByTitle(f.ctx, "login", "", nil, models.PRSort{Field: models.PRSortTitle, Desc: true}, 10, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"pr-2", "pr-1"}, prIDs(prs))
+
+		prs, err = f.prs.SearchByTitle(f.ctx, "", "", nil, models.PRSort{Field: models.PRSortCreatedAt}, 2, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"pr-1", "pr-2"}, prIDs(prs))
+	})
+
+	t.Run("Error - Unsupported sort field never reaches the query", func(t *testing.T) {
+		sort := models.PRSort{Field: "title; DROP TABLE pull_request; --"}
+
+		_, err := f.prs.SearchByTitle(f.ctx, "", "", nil, sort, 10, 0)
+
+		assert.ErrorContains(t, err, "unsupported sort field")
+		count, err := f.prs.CountByTitle(f.ctx, "", "", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("Success - Count matches the search", func(t *testing.T) {
+		count, err := f.prs.CountByTitle(f.ctx, "login", "", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		count, err = f.prs.CountByTitle(f.ctx, "", models.PRStatusOpen, []string{"bug"})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+}
+
+func TestPullRequestRepository_GetAuthorStats(t *testing.T) {
+	f := newFixture(t)
+	f.team("backend", "u1", "u2", "u3")
+	f.team("frontend", "f1")
+	now := time.Now().UTC()
+	f.pr("pr-1", "u1", now.Add(-3*time.Hour), "u2", "u3")
+	f.pr("pr-2", "u1", now.Add(-2*time.Hour))
+	f.pr("pr-3", "f1", now.Add(-time.Hour), "u2")
+	f.pr("pr-old", "f1", now.Add(-48*time.Hour), "u3")
+	f.merge("pr-old")
+	_, err := f.archive.ArchiveMerged(f.ctx, time.Now().UTC().Add(time.Minute), 10)
+	assert.NoError(t, err)
+
+	t.Run("Success - Most prolific authors first", func(t *testing.T) {
+		stats, err := f.prs.GetAuthorStats(f.ctx, "", false)
+
+		assert.NoError(t, err)
+		u1, f1 := "u1", "f1"
+		assert.Equal(t, []*models.AuthorStats{
+			{AuthorId: "u1", Username: &u1, TotalPRs: 2, OpenPRs: 2, Reviewers: 2},
+			{AuthorId: "f1", Username: &f1, TotalPRs: 1, OpenPRs: 1, Reviewers: 1},
+		}, stats)
+	})
+
+	t.Run("Success - Archived PRs of a team", func(t *testing.T) {
+		stats, err := f.prs.GetAuthorStats(f.ctx, "frontend", true)
+
+		assert.NoError(t, err)
+		f1 := "f1"
+		assert.Equal(t, []*models.AuthorStats{
+			{AuthorId: "f1", Username: &f1, TotalPRs: 2, OpenPRs: 1, MergedPRs: 1, Reviewers: 2},
+		}, stats)
+	})
+
+	t.Run("Success - One author", func(t *testing.T) {
+		stat, err := f.prs.GetAuthorStatsByID(f.ctx, "f1", true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &models.AuthorStats{AuthorId: "f1", TotalPRs: 2, OpenPRs: 1, MergedPRs: 1, Reviewers: 2}, stat)
+	})
+
+	t.Run("Success - A user who authored nothing", func(t *testing.T) {
+		stat, err := f.prs.GetAuthorStatsByID(f.ctx, "u2", true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &models.AuthorStats{AuthorId: "u2"}, stat)
+	})
+}
@@ -2,37 +2,88 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
 )
 
 // ReviewerRepository manages reviewers in the database
 type ReviewerRepository struct {
 	pool *pgxpool.Pool
+	// replica serves reads of read-only contexts; nil without a configured replica.
+	replica *pgxpool.Pool
 }
 
-// AssignReviewer assigns a reviewer to a PR
-func (r *ReviewerRepository) AssignReviewer(ctx context.Context, prID, reviewerID string) error {
-	query := `INSERT INTO pr_reviewer (pr_id, reviewer_id) 
-	          VALUES ($1, $2)
-	          ON CONFLICT (pr_id, reviewer_id) DO NOTHING`
-
+// AssignReviewer assigns a reviewer to a PR from the given source. Assigning an assigned reviewer
+// again keeps the original assignment time and source. Returns TOO_MANY_REVIEWERS AppError when
+// the PR is at the reviewer cap.
+func (r *ReviewerRepository) AssignReviewer(ctx context.Context, prID, reviewerID, source string) error {
 	executor := getTx(ctx, r.pool)
-	_, err := executor.Exec(ctx, query, prID, reviewerID)
-	if err != nil {
+	if err := insertReviewer(ctx, executor, prID, reviewerID, source, true); err != nil {
 		return fmt.Errorf("failed to assign reviewer: %w", err)
 	}
 
 	return nil
 }
 
+// insertReviewer inserts an assignment from the source starting now, counts it on the PR and
+// records the change of the reviewer's reviews, failing with TOO_MANY_REVIEWERS AppError when that
+// exceeds the reviewer cap. With ignoreExisting an assigned reviewer is left as is.
+func insertReviewer(ctx context.Context, executor txOrPool, prID, reviewerID, source string, ignoreExisting bool) error {
+	query := `WITH inserted AS (
+	              INSERT INTO pr_reviewer (pr_id, reviewer_id, assigned_at, source, org_id)
+	              VALUES ($1, $2, $3, $4, $5)
+	              %s
+	              RETURNING pr_id, reviewer_id
+	          ), changed AS (
+	              INSERT INTO review_change (reviewer_id, changed_at, org_id)
+	              SELECT reviewer_id, $3, $5 FROM inserted
+	          )
+	          UPDATE pull_request SET reviewer_count = reviewer_count + 1
+	          WHERE org_id = $5 AND id IN (SELECT pr_id FROM inserted)`
+	onConflict := ""
+	if ignoreExisting {
+		onConflict = "ON CONFLICT (org_id, pr_id, reviewer_id) DO NOTHING"
+	}
+
+	_, err := executor.Exec(ctx, fmt.Sprintf(query, onConflict), prID, reviewerID, time.Now().UTC(), source,
+		orgctx.ID(ctx))
+	if isCheckViolation(err, reviewerCountCheck) {
+		return domainErrors.NewTooManyReviewers(
+			fmt.Sprintf("PR already has %d reviewers", models.MaxReviewers))
+	}
+	return err
+}
+
+// deleteReviewer deletes an assignment, uncounts it on the PR and records the change of the
+// reviewer's reviews.
+func deleteReviewer(ctx context.Context, executor txOrPool, prID, reviewerID string) error {
+	query := `WITH deleted AS (
+	              DELETE FROM pr_reviewer WHERE org_id = $4 AND pr_id = $1 AND reviewer_id = $2
+	              RETURNING pr_id, reviewer_id
+	          ), changed AS (
+	              INSERT INTO review_change (reviewer_id, changed_at, org_id)
+	              SELECT reviewer_id, $3, $4 FROM deleted
+	          )
+	          UPDATE pull_request SET reviewer_count = reviewer_count - 1
+	          WHERE org_id = $4 AND id IN (SELECT pr_id FROM deleted)`
+
+	_, err := executor.Exec(ctx, query, prID, reviewerID, time.Now().UTC(), orgctx.ID(ctx))
+	return err
+}
+
 // GetReviewers gets all reviewers assigned to a PR
 func (r *ReviewerRepository) GetReviewers(ctx context.Context, prID string) ([]string, error) {
-	query := `SELECT reviewer_id FROM pr_reviewer WHERE pr_id = $1 ORDER BY reviewer_id`
+	query := `SELECT reviewer_id FROM pr_reviewer WHERE org_id = $2 AND pr_id = $1 ORDER BY reviewer_id`
 
-	executor := getTx(ctx, r.pool)
-	rows, err := executor.Query(ctx, query, prID)
+	executor := getReader(ctx, r.pool, r.replica)
+	rows, err := executor.Query(ctx, query, prID, orgctx.ID(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get reviewers: %w", err)
 	}
@@ -56,10 +107,10 @@ func (r *ReviewerRepository) GetReviewers(ctx context.Context, prID string) ([]s
 
 // GetPRsByReviewer gets all PRs assigned to a reviewer
 func (r *ReviewerRepository) GetPRsByReviewer(ctx context.Context, reviewerID string) ([]string, error) {
-	query := `SELECT pr_id FROM pr_reviewer WHERE reviewer_id = $1 ORDER BY pr_id`
+	query := `SELECT pr_id FROM pr_reviewer WHERE org_id = $2 AND reviewer_id = $1 ORDER BY pr_id`
 
-	executor := getTx(ctx, r.pool)
-	rows, err := executor.Query(ctx, query, reviewerID)
+	executor := getReader(ctx, r.pool, r.replica)
+	rows, err := executor.Query(ctx, query, reviewerID, orgctx.ID(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get PRs by reviewer: %w", err)
 	}
@@ -81,13 +132,249 @@ func (r *ReviewerRepository) GetPRsByReviewer(ctx context.Context, reviewerID st
 	return prIDs, nil
 }
 
+// GetReviewersByPRs gets reviewers of the given PRs keyed by PR ID.
+// PRs without reviewers are absent from the map.
+func (r *ReviewerRepository) GetReviewersByPRs(ctx context.Context, prIDs []string) (map[string][]string, error) {
+	query := `SELECT pr_id, reviewer_id FROM pr_reviewer WHERE org_id = $2 AND pr_id = ANY($1) ORDER BY pr_id, reviewer_id`
+
+	executor := getReader(ctx, r.pool, r.replica)
+	rows, err := executor.Query(ctx, query, prIDs, orgctx.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewers by PRs: %w", err)
+	}
+	defer rows.Close()
+
+	reviewers := make(map[string][]string)
+	for rows.Next() {
+		var prID, reviewerID string
+		if err = rows.Scan(&prID, &reviewerID); err != nil {
+			return nil, fmt.Errorf("failed to scan reviewer: %w", err)
+		}
+		reviewers[prID] = append(reviewers[prID], reviewerID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return reviewers, nil
+}
+
+// GetOpenReviewCounts counts open PRs assigned to each of the given users.
+// Users without open reviews are absent from the map.
+func (r *ReviewerRepository) GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	query := `SELECT prr.reviewer_id, COUNT(*)
+	          FROM pr_reviewer prr
+	          JOIN pull_request pr ON pr.org_id = prr.org_id AND pr.id = prr.pr_id
+	          WHERE prr.org_id = $2 AND prr.reviewer_id = ANY($1) AND pr.status = 'OPEN'
+	          GROUP BY prr.reviewer_id`
+
+	executor := getReader(ctx, r.pool, r.replica)
+	rows, err := executor.Query(ctx, query, userIDs, orgctx.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open review counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var reviewerID string
+		var count int
+		if err = rows.Scan(&reviewerID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan open review count: %w", err)
+		}
+		counts[reviewerID] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return counts, nil
+}
+
+// GetAssignmentTimes gets the times of current assignments of the given users made since the given moment.
+// Users without such assignments are absent from the map.
+func (r *ReviewerRepository) GetAssignmentTimes(ctx context.Context, userIDs []string,
+	since time.Time) (map[string][]time.Time, error) {
+	query := `SELECT reviewer_id, assigned_at
+	          FROM pr_reviewer
+	          WHERE org_id = $3 AND reviewer_id = ANY($1) AND assigned_at >= $2
+	          ORDER BY reviewer_id, assigned_at`
+
+	executor := getReader(ctx, r.pool, r.replica)
+	rows, err := executor.Query(ctx, query, userIDs, since, orgctx.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignment times: %w", err)
+	}
+	defer rows.Close()
+
+	times := make(map[string][]time.Time)
+	for rows.Next() {
+		var reviewerID string
+		var assignedAt time.Time
+		if err = rows.Scan(&reviewerID, &assignedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan assignment time: %w", err)
+		}
+		times[reviewerID] = append(times[reviewerID], assignedAt)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return times, nil
+}
+
+// GetAssignmentsByPRs gets reviewer assignments of the given PRs ordered by PR ID and reviewer ID.
+func (r *ReviewerRepository) GetAssignmentsByPRs(ctx context.Context, prIDs []string) ([]*models.ReviewAssignment, error) {
+	query := `SELECT pr_id, reviewer_id, assigned_at, source, state, state_changed_at
+	          FROM pr_reviewer
+	          WHERE org_id = $2 AND pr_id = ANY($1)
+	          ORDER BY pr_id, reviewer_id`
+
+	executor := getReader(ctx, r.pool, r.replica)
+	rows, err := executor.Query(ctx, query, prIDs, orgctx.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignments by PRs: %w", err)
+	}
+	defer rows.Close()
+
+	return scanAssignments(rows)
+}
+
+// FindAssignmentsAfter gets up to limit of the reviewer's assignments on open PRs that come after
+// the cursor, ordered by assignment time and PR ID.
+func (r *ReviewerRepository) FindAssignmentsAfter(ctx context.Context, reviewerID string,
+	after models.AssignmentCursor, limit int) ([]*models.ReviewAssignment, error) {
+	query := `SELECT prr.pr_id, prr.reviewer_id, prr.assigned_at, prr.source, prr.state, prr.state_changed_at
+	          FROM pr_reviewer prr
+	          JOIN pull_request pr ON pr.org_id = prr.org_id AND pr.id = prr.pr_id
+	          WHERE prr.org_id = $5 AND prr.reviewer_id = $1 AND pr.status = 'OPEN'
+	            AND (prr.assigned_at, prr.pr_id) > ($2, $3)
+	          ORDER BY prr.assigned_at, prr.pr_id
+	          LIMIT $4`
+
+	executor := getReader(ctx, r.pool, r.replica)
+	rows, err := executor.Query(ctx, query, reviewerID, after.AssignedAt, after.PRId, limit, orgctx.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find assignments after cursor: %w", err)
+	}
+	defer rows.Close()
+
+	return scanAssignments(rows)
+}
+
+// FindOpenAssignments gets assignments on open PRs made before the given moment,
+// ordered by reviewer ID and assignment time.
+func (r *ReviewerRepository) FindOpenAssignments(ctx context.Context, assignedBefore time.Time) ([]*models.ReviewAssignment, error) {
+	query := `SELECT prr.pr_id, prr.reviewer_id, prr.assigned_at, prr.source, prr.state, prr.state_changed_at
+	          FROM pr_reviewer prr
+	          JOIN pull_request pr ON pr.org_id = prr.org_id AND pr.id = prr.pr_id
+	          WHERE prr.org_id = $2 AND pr.status = 'OPEN' AND prr.assigned_at < $1
+	          ORDER BY prr.reviewer_id, prr.assigned_at, prr.pr_id`
+
+	executor := getReader(ctx, r.pool, r.replica)
+	rows, err := executor.Query(ctx, query, assignedBefore, orgctx.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find open assignments: %w", err)
+	}
+	defer rows.Close()
+
+	return scanAssignments(rows)
+}
+
+// FindStaleAssignments gets up to limit pending assignments made before the given moment
+// on open PRs nobody has approved, oldest first.
+func (r *ReviewerRepository) FindStaleAssignments(ctx context.Context, assignedBefore time.Time,
+	limit int) ([]*models.ReviewAssignment, error) {
+	query := `SELECT prr.pr_id, prr.reviewer_id, prr.assigned_at, prr.source, prr.state, prr.state_changed_at
+	          FROM pr_reviewer prr
+	          JOIN pull_request pr ON pr.org_id = prr.org_id AND pr.id = prr.pr_id
+	          WHERE prr.org_id = $3 AND pr.status = 'OPEN' AND prr.state = 'PENDING' AND prr.assigned_at < $1
+	            AND NOT EXISTS (
+	                SELECT 1 FROM pr_reviewer approved
+	                WHERE approved.org_id = prr.org_id AND approved.pr_id = prr.pr_id AND approved.state = 'APPROVED'
+	            )
+	          ORDER BY prr.assigned_at, prr.pr_id, prr.reviewer_id
+	          LIMIT $2`
+
+	executor := getReader(ctx, r.pool, r.replica)
+	rows, err := executor.Query(ctx, query, assignedBefore, limit, orgctx.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stale assignments: %w", err)
+	}
+	defer rows.Close()
+
+	return scanAssignments(rows)
+}
+
+// scanAssignments reads review assignments from the rows.
+func scanAssignments(rows pgx.Rows) ([]*models.ReviewAssignment, error) {
+	var assignments []*models.ReviewAssignment
+	for rows.Next() {
+		var assignment models.ReviewAssignment
+		if err := rows.Scan(
+			&assignment.PRId, &assignment.ReviewerId, &assignment.AssignedAt, &assignment.Source,
+			&assignment.State, &assignment.StateChangedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan assignment: %w", err)
+		}
+		assignments = append(assignments, &assignment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return assignments, nil
+}
+
+// LockAssignment gets the assignment of a reviewer to a PR and locks it until the transaction ends.
+// Returns nil if the reviewer is not assigned.
+func (r *ReviewerRepository) LockAssignment(ctx context.Context, prID, reviewerID string) (*models.ReviewAssignment, error) {
+	query := `SELECT pr_id, reviewer_id, assigned_at, source, state, state_changed_at
+	          FROM pr_reviewer
+	          WHERE org_id = $3 AND pr_id = $1 AND reviewer_id = $2
+	          FOR UPDATE`
+
+	executor := getTx(ctx, r.pool)
+	var assignment models.ReviewAssignment
+	err := executor.QueryRow(ctx, query, prID, reviewerID, orgctx.ID(ctx)).Scan(
+		&assignment.PRId, &assignment.ReviewerId, &assignment.AssignedAt, &assignment.Source,
+		&assignment.State, &assignment.StateChangedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to lock assignment: %w", err)
+	}
+
+	return &assignment, nil
+}
+
+// SetReviewState records the review state of a reviewer on a PR.
+func (r *ReviewerRepository) SetReviewState(ctx context.Context, prID, reviewerID, state string, changedAt time.Time) error {
+	query := `UPDATE pr_reviewer SET state = $3, state_changed_at = $4
+	          WHERE org_id = $5 AND pr_id = $1 AND reviewer_id = $2`
+
+	executor := getTx(ctx, r.pool)
+	_, err := executor.Exec(ctx, query, prID, reviewerID, state, changedAt, orgctx.ID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to set review state: %w", err)
+	}
+
+	return nil
+}
+
 // IsAssigned checks if a reviewer is assigned to a PR
 func (r *ReviewerRepository) IsAssigned(ctx context.Context, prID, reviewerID string) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM pr_reviewer WHERE pr_id = $1 AND reviewer_id = $2)`
+	query := `SELECT EXISTS(SELECT 1 FROM pr_reviewer WHERE org_id = $3 AND pr_id = $1 AND reviewer_id = $2)`
 
-	executor := getTx(ctx, r.pool)
+	executor := getReader(ctx, r.pool, r.replica)
 	var exists bool
-	err := executor.QueryRow(ctx, query, prID, reviewerID).Scan(&exists)
+	err := executor.QueryRow(ctx, query, prID, reviewerID, orgctx.ID(ctx)).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("failed to check reviewer assignment: %w", err)
 	}
@@ -95,32 +382,99 @@ func (r *ReviewerRepository) IsAssigned(ctx context.Context, prID, reviewerID st
 	return exists, nil
 }
 
-// ReplaceReviewer replaces an old reviewer with a new one for a PR
-func (r *ReviewerRepository) ReplaceReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) error {
+// ReplaceReviewer replaces an old reviewer with a new one from the given source for a PR;
+// the new assignment starts now.
+func (r *ReviewerRepository) ReplaceReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID, source string) error {
 	executor := getTx(ctx, r.pool)
 
-	deleteQuery := `DELETE FROM pr_reviewer WHERE pr_id = $1 AND reviewer_id = $2`
-	_, err := executor.Exec(ctx, deleteQuery, prID, oldReviewerID)
-	if err != nil {
+	if err := deleteReviewer(ctx, executor, prID, oldReviewerID); err != nil {
 		return fmt.Errorf("failed to remove old reviewer: %w", err)
 	}
 
-	insertQuery := `INSERT INTO pr_reviewer (pr_id, reviewer_id) VALUES ($1, $2)`
-	_, err = executor.Exec(ctx, insertQuery, prID, newReviewerID)
-	if err != nil {
+	if err := insertReviewer(ctx, executor, prID, newReviewerID, source, false); err != nil {
 		return fmt.Errorf("failed to assign new reviewer: %w", err)
 	}
 
 	return nil
 }
 
-// GetAllReviewerCounts returns a map of reviewer IDs to their assignment counts.
-func (r *ReviewerRepository) GetAllReviewerCounts(ctx context.Context) (map[string]int, error) {
-	query := `SELECT reviewer_id, COUNT(*) as count
-	          FROM pr_reviewer
-	          GROUP BY reviewer_id`
+// GetAllReviewers gets reviewers of all PRs, or of those authored by members of a team unless teamName
+// is empty, keyed by PR ID, each ordered by id. PRs without reviewers are absent from the map.
+func (r *ReviewerRepository) GetAllReviewers(ctx context.Context, teamName string) (map[string][]string, error) {
+	query := `SELECT r.pr_id, r.reviewer_id
+	          FROM pr_reviewer r
+	          JOIN pull_request pr ON pr.org_id = r.org_id AND pr.id = r.pr_id
+	          WHERE r.org_id = $2
+	            AND ($1 = '' OR EXISTS (SELECT 1 FROM "user" a
+	                                    WHERE a.org_id = pr.org_id AND a.id = pr.author_id AND a.team_name = $1))
+	          ORDER BY r.pr_id, r.reviewer_id`
+
+	rows, err := getReader(ctx, r.pool, r.replica).Query(ctx, query, teamName, orgctx.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all reviewers: %w", err)
+	}
+	defer rows.Close()
+
+	reviewers := make(map[string][]string)
+	for rows.Next() {
+		var prID, reviewerID string
+		if err = rows.Scan(&prID, &reviewerID); err != nil {
+			return nil, fmt.Errorf("failed to scan reviewer: %w", err)
+		}
+		reviewers[prID] = append(reviewers[prID], reviewerID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return reviewers, nil
+}
 
-	rows, err := r.pool.Query(ctx, query)
+// GetArchivedReviewers gets reviewers of all archived PRs, or of those authored by members of a team
+// unless teamName is empty, keyed by PR ID, each ordered by id. PRs without reviewers are absent from the map.
+func (r *ReviewerRepository) GetArchivedReviewers(ctx context.Context, teamName string) (map[string][]string, error) {
+	query := `SELECT r.pr_id, r.reviewer_id
+	          FROM pr_reviewer_archive r
+	          JOIN pull_request_archive pr ON pr.org_id = r.org_id AND pr.id = r.pr_id
+	          WHERE r.org_id = $2
+	            AND ($1 = '' OR EXISTS (SELECT 1 FROM "user" a
+	                                    WHERE a.org_id = pr.org_id AND a.id = pr.author_id AND a.team_name = $1))
+	          ORDER BY r.pr_id, r.reviewer_id`
+
+	rows, err := getReader(ctx, r.pool, r.replica).Query(ctx, query, teamName, orgctx.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archived reviewers: %w", err)
+	}
+	defer rows.Close()
+
+	reviewers := make(map[string][]string)
+	for rows.Next() {
+		var prID, reviewerID string
+		if err = rows.Scan(&prID, &reviewerID); err != nil {
+			return nil, fmt.Errorf("failed to scan archived reviewer: %w", err)
+		}
+		reviewers[prID] = append(reviewers[prID], reviewerID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return reviewers, nil
+}
+
+// GetAllReviewerCounts returns a map of reviewer IDs to their assignment counts, keeping only the
+// reviewers that are members of a team unless teamName is empty.
+func (r *ReviewerRepository) GetAllReviewerCounts(ctx context.Context, teamName string) (map[string]int, error) {
+	query := `SELECT r.reviewer_id, COUNT(*) as count
+	          FROM pr_reviewer r
+	          WHERE r.org_id = $2
+	            AND ($1 = '' OR EXISTS (SELECT 1 FROM "user" u
+	                                    WHERE u.org_id = r.org_id AND u.id = r.reviewer_id AND u.team_name = $1))
+	          GROUP BY r.reviewer_id`
+
+	rows, err := getReader(ctx, r.pool, r.replica).Query(ctx, query, teamName, orgctx.ID(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get reviewer counts: %w", err)
 	}
@@ -139,15 +493,381 @@ func (r *ReviewerRepository) GetAllReviewerCounts(ctx context.Context) (map[stri
 	return counts, nil
 }
 
+// GetAssignmentSourceCounts returns a map of assignment sources to the number of current assignments
+// made from them, counting only the PRs authored by members of a team unless teamName is empty.
+// Assignments of archived PRs are counted only with includeArchived.
+func (r *ReviewerRepository) GetAssignmentSourceCounts(ctx context.Context, teamName string,
+	includeArchived bool) (map[string]int, error) {
+	query := `SELECT source, COUNT(*)
+	          FROM (
+	              SELECT r.source, pr.author_id
+	              FROM pr_reviewer r
+	              JOIN pull_request pr ON pr.org_id = r.org_id AND pr.id = r.pr_id
+	              WHERE r.org_id = $3
+	              UNION ALL
+	              SELECT r.source, pr.author_id
+	              FROM pr_reviewer_archive r
+	              JOIN pull_request_archive pr ON pr.org_id = r.org_id AND pr.id = r.pr_id
+	              WHERE $1 AND r.org_id = $3
+	          ) assignments
+	          WHERE $2 = '' OR EXISTS (SELECT 1 FROM "user" a WHERE a.org_id = $3 AND a.id = author_id AND a.team_name = $2)
+	          GROUP BY source`
+
+	rows, err := getReader(ctx, r.pool, r.replica).Query(ctx, query, includeArchived, teamName, orgctx.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignment source counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var source string
+		var count int
+		if err = rows.Scan(&source, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan assignment source count: %w", err)
+		}
+		counts[source] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return counts, nil
+}
+
+// GetReviewTurnarounds returns a map of reviewer IDs to the turnaround of their current assignments
+// on merged PRs; a replaced or removed reviewer is no longer assigned, so their review is not counted.
+// Assignments of archived PRs are counted only with includeArchived. Unless teamName is empty, only
+// the reviewers that are members of the team are kept. Reviewers without such assignments are absent from the map.
+func (r *ReviewerRepository) GetReviewTurnarounds(ctx context.Context, teamName string,
+	includeArchived bool) (map[string]models.ReviewTurnaround, error) {
+	query := `SELECT reviewer_id, AVG(seconds), percentile_cont(0.5) WITHIN GROUP (ORDER BY seconds)
+	          FROM (
+	              SELECT r.reviewer_id, EXTRACT(EPOCH FROM pr.merged_at - r.assigned_at)::float8 AS seconds
+	              FROM pr_reviewer r
+	              JOIN pull_request pr ON pr.org_id = r.org_id AND pr.id = r.pr_id
+	              WHERE r.org_id = $3 AND pr.status = 'MERGED' AND pr.merged_at IS NOT NULL
+	              UNION ALL
+	              SELECT r.reviewer_id, EXTRACT(EPOCH FROM pr.merged_at - r.assigned_at)::float8
+	              FROM pr_reviewer_archive r
+	              JOIN pull_request_archive pr ON pr.org_id = r.org_id AND pr.id = r.pr_id
+	              WHERE $1 AND r.org_id = $3 AND pr.status = 'MERGED' AND pr.merged_at IS NOT NULL
+	          ) reviews
+	          WHERE $2 = '' OR EXISTS (SELECT 1 FROM "user" u WHERE u.org_id = $3 AND u.id = reviewer_id AND u.team_name = $2)
+	          GROUP BY reviewer_id`
+
+	rows, err := getReader(ctx, r.pool, r.replica).Query(ctx, query, includeArchived, teamName, orgctx.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review turnarounds: %w", err)
+	}
+	defer rows.Close()
+
+	turnarounds := make(map[string]models.ReviewTurnaround)
+	for rows.Next() {
+		var reviewerID string
+		var turnaround models.ReviewTurnaround
+		if err = rows.Scan(&reviewerID, &turnaround.AvgSeconds, &turnaround.MedianSeconds); err != nil {
+			return nil, fmt.Errorf("failed to scan review turnaround: %w", err)
+		}
+		turnarounds[reviewerID] = turnaround
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return turnarounds, nil
+}
+
+// GetReviewerSummary counts the current assignments of a reviewer, those on open PRs, and the turnaround
+// of those on merged PRs. Assignments of archived PRs are counted only with includeArchived.
+func (r *ReviewerRepository) GetReviewerSummary(ctx context.Context, reviewerID string,
+	includeArchived bool) (*models.ReviewerSummary, error) {
+	query := `SELECT COUNT(*), COUNT(*) FILTER (WHERE status = 'OPEN'),
+	                 AVG(seconds), percentile_cont(0.5) WITHIN GROUP (ORDER BY seconds)
+	          FROM (
+	              SELECT pr.status, CASE WHEN pr.status = 'MERGED'
+	                     THEN EXTRACT(EPOCH FROM pr.merged_at - r.assigned_at)::float8 END AS seconds
+	              FROM pr_reviewer r
+	              JOIN pull_request pr ON pr.org_id = r.org_id AND pr.id = r.pr_id
+	              WHERE r.org_id = $3 AND r.reviewer_id = $1
+	              UNION ALL
+	              SELECT pr.status, CASE WHEN pr.status = 'MERGED'
+	                     THEN EXTRACT(EPOCH FROM pr.merged_at - r.assigned_at)::float8 END
+	              FROM pr_reviewer_archive r
+	              JOIN pull_request_archive pr ON pr.org_id = r.org_id AND pr.id = r.pr_id
+	              WHERE $2 AND r.org_id = $3 AND r.reviewer_id = $1
+	          ) reviews`
+
+	var summary models.ReviewerSummary
+	var avg, median *float64
+	err := getReader(ctx, r.pool, r.replica).QueryRow(ctx, query, reviewerID, includeArchived, orgctx.ID(ctx)).Scan(
+		&summary.Assignments, &summary.ActiveReviews, &avg, &median,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewer summary: %w", err)
+	}
+	if avg != nil && median != nil {
+		summary.Turnaround = &models.ReviewTurnaround{AvgSeconds: *avg, MedianSeconds: *median}
+	}
+
+	return &summary, nil
+}
+
+// FindRecentAssignments gets up to limit of the current assignments of a reviewer, most recent first,
+// adding those of archived PRs with includeArchived.
+func (r *ReviewerRepository) FindRecentAssignments(ctx context.Context, reviewerID string,
+	includeArchived bool, limit int) ([]*models.ReviewAssignment, error) {
+	query := `SELECT pr_id, reviewer_id, assigned_at, source, state, state_changed_at
+	          FROM (
+	              SELECT pr_id, reviewer_id, assigned_at, source, state, state_changed_at
+	              FROM pr_reviewer
+	              WHERE org_id = $4 AND reviewer_id = $1
+	              UNION ALL
+	              SELECT pr_id, reviewer_id, assigned_at, source, state, state_changed_at
+	              FROM pr_reviewer_archive
+	              WHERE $2 AND org_id = $4 AND reviewer_id = $1
+	          ) assignments
+	          ORDER BY assigned_at DESC, pr_id
+	          LIMIT $3`
+
+	rows, err := getReader(ctx, r.pool, r.replica).Query(ctx, query, reviewerID, includeArchived, limit,
+		orgctx.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find recent assignments: %w", err)
+	}
+	defer rows.Close()
+
+	return scanAssignments(rows)
+}
+
+// reviewLoadsQuery counts the active reviews and total assignments of the active users of a team
+// of the organization $2, or of all its teams when $1 is empty, in the loads table.
+const reviewLoadsQuery = `WITH assigned AS (
+	              SELECT r.reviewer_id, COUNT(*) FILTER (WHERE pr.status = 'OPEN') AS active, COUNT(*) AS total
+	              FROM pr_reviewer r
+	              JOIN pull_request pr ON pr.org_id = r.org_id AND pr.id = r.pr_id
+	              WHERE r.org_id = $2
+	              GROUP BY r.reviewer_id
+	          ), archived AS (
+	              SELECT reviewer_id, COUNT(*) AS total
+	              FROM pr_reviewer_archive
+	              WHERE org_id = $2
+	              GROUP BY reviewer_id
+	          ), loads AS (
+	              SELECT u.id, u.username, u.team_name, COALESCE(c.active, 0) AS active,
+	                     COALESCE(c.total, 0) + COALESCE(a.total, 0) AS total
+	              FROM "user" u
+	              LEFT JOIN assigned c ON c.reviewer_id = u.id
+	              LEFT JOIN archived a ON a.reviewer_id = u.id
+	              WHERE u.org_id = $2 AND u.is_active AND ($1 = '' OR u.team_name = $1)
+	          )`
+
+// GetReviewLoadHistogram counts the active users of a team, or of all teams when teamName is empty,
+// by their active reviews and total assignments; counts above lastBucket fall into lastBucket.
+func (r *ReviewerRepository) GetReviewLoadHistogram(ctx context.Context, teamName string,
+	lastBucket int) (*models.ReviewLoadHistogram, error) {
+	query := reviewLoadsQuery + `
+	          SELECT 'active', LEAST(active, $3), COUNT(*) FROM loads GROUP BY 2
+	          UNION ALL
+	          SELECT 'total', LEAST(total, $3), COUNT(*) FROM loads GROUP BY 2`
+
+	rows, err := getReader(ctx, r.pool, r.replica).Query(ctx, query, teamName, orgctx.ID(ctx), lastBucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review load histogram: %w", err)
+	}
+	defer rows.Close()
+
+	histogram := &models.ReviewLoadHistogram{
+		ActiveReviews:    make(map[int]int),
+		TotalAssignments: make(map[int]int),
+	}
+	for rows.Next() {
+		var kind string
+		var bucket, users int
+		if err = rows.Scan(&kind, &bucket, &users); err != nil {
+			return nil, fmt.Errorf("failed to scan review load bucket: %w", err)
+		}
+		if kind == "active" {
+			histogram.ActiveReviews[bucket] = users
+		} else {
+			histogram.TotalAssignments[bucket] = users
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return histogram, nil
+}
+
+// GetReviewLoads returns the review load of every active user of a team, or of all teams when
+// teamName is empty, the most loaded first, then ordered by user id.
+func (r *ReviewerRepository) GetReviewLoads(ctx context.Context, teamName string) ([]*models.ReviewLoad, error) {
+	query := reviewLoadsQuery + `
+	          SELECT id, username, team_name, active, total FROM loads
+	          ORDER BY active DESC, total DESC, id`
+
+	rows, err := getReader(ctx, r.pool, r.replica).Query(ctx, query, teamName, orgctx.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review loads: %w", err)
+	}
+	defer rows.Close()
+
+	var loads []*models.ReviewLoad
+	for rows.Next() {
+		load := &models.ReviewLoad{}
+		if err = rows.Scan(&load.UserId, &load.Username, &load.TeamName, &load.ActiveReviews,
+			&load.TotalAssignments); err != nil {
+			return nil, fmt.Errorf("failed to scan review load: %w", err)
+		}
+		loads = append(loads, load)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return loads, nil
+}
+
+// GetReviewsChangedAt returns when the assignments of the reviewer last changed, zero when they
+// never did.
+func (r *ReviewerRepository) GetReviewsChangedAt(ctx context.Context, reviewerID string) (time.Time, error) {
+	query := `SELECT MAX(changed_at) FROM review_change WHERE org_id = $2 AND reviewer_id = $1`
+
+	var changedAt *time.Time
+	err := getReader(ctx, r.pool, r.replica).QueryRow(ctx, query, reviewerID, orgctx.ID(ctx)).Scan(&changedAt)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get reviews change time: %w", err)
+	}
+	if changedAt == nil {
+		return time.Time{}, nil
+	}
+	return changedAt.UTC(), nil
+}
+
 // RemoveReviewer removes a reviewer from a PR.
 func (r *ReviewerRepository) RemoveReviewer(ctx context.Context, prID, reviewerID string) error {
-	query := `DELETE FROM pr_reviewer WHERE pr_id = $1 AND reviewer_id = $2`
+	executor := getTx(ctx, r.pool)
+	if err := deleteReviewer(ctx, executor, prID, reviewerID); err != nil {
+		return fmt.Errorf("failed to remove reviewer: %w", err)
+	}
+
+	return nil
+}
+
+// RecordReviewerChange appends a reviewer change to the PR history.
+func (r *ReviewerRepository) RecordReviewerChange(ctx context.Context, change *models.ReviewerChange) error {
+	query := `INSERT INTO reviewer_assignment_event (pr_id, old_reviewer_id, new_reviewer_id, trigger, changed_at,
+	                                                 org_id)
+	          VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6)`
 
 	executor := getTx(ctx, r.pool)
-	_, err := executor.Exec(ctx, query, prID, reviewerID)
+	_, err := executor.Exec(ctx, query,
+		change.PRId, change.OldReviewerId, change.NewReviewerId, change.Trigger, change.ChangedAt, orgctx.ID(ctx),
+	)
 	if err != nil {
-		return fmt.Errorf("failed to remove reviewer: %w", err)
+		return fmt.Errorf("failed to record reviewer change: %w", err)
 	}
 
 	return nil
 }
+
+// GetReviewerHistory gets reviewer changes of a PR in chronological order.
+func (r *ReviewerRepository) GetReviewerHistory(ctx context.Context, prID string) ([]*models.ReviewerChange, error) {
+	query := `SELECT pr_id, old_reviewer_id, COALESCE(new_reviewer_id, ''), trigger, changed_at
+	          FROM reviewer_assignment_event
+	          WHERE org_id = $2 AND pr_id = $1
+	          ORDER BY changed_at, id`
+
+	executor := getReader(ctx, r.pool, r.replica)
+	rows, err := executor.Query(ctx, query, prID, orgctx.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewer history: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []*models.ReviewerChange
+	for rows.Next() {
+		var change models.ReviewerChange
+		if err = rows.Scan(
+			&change.PRId, &change.OldReviewerId, &change.NewReviewerId, &change.Trigger, &change.ChangedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan reviewer change: %w", err)
+		}
+		changes = append(changes, &change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return changes, nil
+}
+
+// CountReviewerChanges returns the number of recorded reviewer changes, removals included, counting
+// only the PRs authored by members of a team unless teamName is empty.
+// Changes of archived PRs are counted only with includeArchived.
+func (r *ReviewerRepository) CountReviewerChanges(ctx context.Context, teamName string, includeArchived bool) (int, error) {
+	query := `SELECT COUNT(*) FROM reviewer_assignment_event e
+	          WHERE e.org_id = $3
+	            AND ($1 OR EXISTS (SELECT 1 FROM pull_request pr WHERE pr.org_id = e.org_id AND pr.id = e.pr_id))
+	            AND ($2 = '' OR EXISTS (
+	                SELECT 1 FROM "user" a
+	                WHERE a.org_id = e.org_id AND a.team_name = $2 AND a.id IN (
+	                    SELECT author_id FROM pull_request WHERE org_id = e.org_id AND id = e.pr_id
+	                    UNION ALL
+	                    SELECT author_id FROM pull_request_archive WHERE org_id = e.org_id AND id = e.pr_id)))`
+
+	executor := getReader(ctx, r.pool, r.replica)
+	var count int
+	if err := executor.QueryRow(ctx, query, includeArchived, teamName, orgctx.ID(ctx)).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count reviewer changes: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetReassignmentCounts returns a map of PR IDs to the number of times a reviewer was replaced,
+// keeping only the PRs authored by members of a team unless teamName is empty.
+// Removals without replacement are not counted, nor, without includeArchived, the archived PRs.
+func (r *ReviewerRepository) GetReassignmentCounts(ctx context.Context, teamName string,
+	includeArchived bool) (map[string]int, error) {
+	query := `SELECT e.pr_id, COUNT(*)
+	          FROM reviewer_assignment_event e
+	          WHERE e.org_id = $3 AND e.new_reviewer_id IS NOT NULL
+	            AND ($1 OR EXISTS (SELECT 1 FROM pull_request pr WHERE pr.org_id = e.org_id AND pr.id = e.pr_id))
+	            AND ($2 = '' OR EXISTS (
+	                SELECT 1 FROM "user" a
+	                WHERE a.org_id = e.org_id AND a.team_name = $2 AND a.id IN (
+	                    SELECT author_id FROM pull_request WHERE org_id = e.org_id AND id = e.pr_id
+	                    UNION ALL
+	                    SELECT author_id FROM pull_request_archive WHERE org_id = e.org_id AND id = e.pr_id)))
+	          GROUP BY e.pr_id`
+
+	executor := getReader(ctx, r.pool, r.replica)
+	rows, err := executor.Query(ctx, query, includeArchived, teamName, orgctx.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reassignment counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var prID string
+		var count int
+		if err = rows.Scan(&prID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan reassignment count: %w", err)
+		}
+		counts[prID] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return counts, nil
+}
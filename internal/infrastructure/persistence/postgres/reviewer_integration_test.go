@@ -0,0 +1,543 @@
+//go:build integration
+
+package postgres
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewerRepository_Assignments(t *testing.T) {
+	f := newFixture(t)
+	f.team("backend", "u1", "u2", "u3", "u4")
+	base := time.Now().UTC().Add(-time.Hour)
+	f.pr("pr-1", "u1", base, "u3", "u2")
+	f.pr("pr-2", "u1", base.Add(time.Minute), "u2")
+	f.pr("pr-3", "u1", base.Add(2*time.Minute))
+
+	t.Run("Success - GetReviewers ordered by id", func(t *testing.T) {
+		reviewers, err := f.reviewers.GetReviewers(f.ctx, "pr-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u2", "u3"}, reviewers)
+	})
+
+	t.Run("Success - AssignReviewer twice is a no-op", func(t *testing.T) {
+		assignedAt := base.Truncate(time.Microsecond)
+		f.setAssignedAt("pr-2", "u2", assignedAt)
+
+		assert.NoError(t, f.reviewers.AssignReviewer(f.ctx, "pr-2", "u2", models.AssignmentSourceAuto))
+
+		reviewers, err := f.reviewers.GetReviewers(f.ctx, "pr-2")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u2"}, reviewers)
+		assignments, err := f.reviewers.GetAssignmentsByPRs(f.ctx, []string{"pr-2"})
+		assert.NoError(t, err)
+		if assert.Len(t, assignments, 1) {
+			assert.True(t, assignedAt.Equal(assignments[0].AssignedAt), "assignment time must be kept")
+		}
+	})
+
+	t.Run("Success - GetPRsByReviewer", func(t *testing.T) {
+		prIDs, err := f.reviewers.GetPRsByReviewer(f.ctx, "u2")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"pr-1", "pr-2"}, prIDs)
+	})
+
+	t.Run("Success - GetReviewersByPRs omits PRs without reviewers", func(t *testing.T) {
+		reviewers, err := f.reviewers.GetReviewersByPRs(f.ctx, []string{"pr-1", "pr-2", "pr-3"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string][]string{"pr-1": {"u2", "u3"}, "pr-2": {"u2"}}, reviewers)
+	})
+
+	t.Run("Success - GetAllReviewers omits PRs without reviewers", func(t *testing.T) {
+		reviewers, err := f.reviewers.GetAllReviewers(f.ctx, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string][]string{"pr-1": {"u2", "u3"}, "pr-2": {"u2"}}, reviewers)
+	})
+
+	t.Run("Success - IsAssigned", func(t *testing.T) {
+		assigned, err := f.reviewers.IsAssigned(f.ctx, "pr-1", "u3")
+		assert.NoError(t, err)
+		assert.True(t, assigned)
+
+		assigned, err = f.reviewers.IsAssigned(f.ctx, "pr-2", "u3")
+		assert.NoError(t, err)
+		assert.False(t, assigned)
+	})
+
+	t.Run("Success - ReplaceReviewer and RemoveReviewer", func(t *testing.T) {
+		replacedAt := time.Now().UTC().Add(-time.Second)
+		assert.NoError(t, f.reviewers.ReplaceReviewer(f.ctx, "pr-1", "u3", "u4", models.AssignmentSourceReassign))
+		reviewers, _ := f.reviewers.GetReviewers(f.ctx, "pr-1")
+		assert.Equal(t, []string{"u2", "u4"}, reviewers)
+		assignments, _ := f.reviewers.GetAssignmentsByPRs(f.ctx, []string{"pr-1"})
+		for _, a := range assignments {
+			if a.ReviewerId == "u4" {
+				assert.True(t, a.AssignedAt.After(replacedAt), "replacement starts a new assignment")
+				assert.Equal(t, models.AssignmentSourceReassign, a.Source)
+			} else {
+				assert.Equal(t, models.AssignmentSourceAuto, a.Source)
+			}
+		}
+
+		assert.NoError(t, f.reviewers.RemoveReviewer(f.ctx, "pr-1", "u4"))
+		reviewers, _ = f.reviewers.GetReviewers(f.ctx, "pr-1")
+		assert.Equal(t, []string{"u2"}, reviewers)
+	})
+
+	t.Run("Error - ReplaceReviewer with an assigned reviewer", func(t *testing.T) {
+		assert.NoError(t, f.reviewers.AssignReviewer(f.ctx, "pr-2", "u3", models.AssignmentSourceAuto))
+
+		err := f.reviewers.ReplaceReviewer(f.ctx, "pr-2", "u2", "u3", models.AssignmentSourceReassign)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Success - Reviewer count follows assignments", func(t *testing.T) {
+		// replacing with an assigned reviewer above removed the old one outside a transaction
+		assert.Equal(t, 1, f.reviewerCount("pr-1"))
+		assert.Equal(t, 1, f.reviewerCount("pr-2"))
+		assert.Equal(t, 0, f.reviewerCount("pr-3"))
+	})
+
+	t.Run("Error - AssignReviewer beyond the cap", func(t *testing.T) {
+		assert.NoError(t, f.reviewers.AssignReviewer(f.ctx, "pr-1", "u3", models.AssignmentSourceAuto))
+
+		err := f.reviewers.AssignReviewer(f.ctx, "pr-1", "u4", models.AssignmentSourceAuto)
+
+		assert.True(t, domainErrors.HasCode(err, domainErrors.CodeTooManyReviewers), "got %v", err)
+		reviewers, _ := f.reviewers.GetReviewers(f.ctx, "pr-1")
+		assert.Equal(t, []string{"u2", "u3"}, reviewers)
+		assert.Equal(t, 2, f.reviewerCount("pr-1"))
+	})
+
+	t.Run("Error - Direct update beyond the cap", func(t *testing.T) {
+		_, err := testStorage.pool.Exec(f.ctx, `UPDATE pull_request SET reviewer_count = 3 WHERE id = 'pr-3'`)
+
+		assert.True(t, isCheckViolation(err, reviewerCountCheck), "got %v", err)
+	})
+}
+
+func TestReviewerRepository_Counts(t *testing.T) {
+	f := newFixture(t)
+	f.team("backend", "u1", "u2", "u3")
+	base := time.Now().UTC().Add(-time.Hour)
+	f.pr("pr-1", "u1", base, "u2", "u3")
+	f.pr("pr-2", "u1", base.Add(time.Minute), "u2")
+	f.pr("pr-merged", "u1", base.Add(2*time.Minute), "u2")
+	f.merge("pr-merged")
+
+	t.Run("Success - GetOpenReviewCounts counts only open PRs", func(t *testing.T) {
+		counts, err := f.reviewers.GetOpenReviewCounts(f.ctx, []string{"u1", "u2", "u3"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int{"u2": 2, "u3": 1}, counts)
+	})
+
+	t.Run("Success - GetAllReviewerCounts counts all PRs", func(t *testing.T) {
+		counts, err := f.reviewers.GetAllReviewerCounts(f.ctx, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int{"u2": 3, "u3": 1}, counts)
+	})
+
+	t.Run("Success - GetAssignmentTimes since the given moment", func(t *testing.T) {
+		old := time.Now().UTC().Add(-40 * 24 * time.Hour).Truncate(time.Microsecond)
+		f.setAssignedAt("pr-1", "u2", old)
+
+		times, err := f.reviewers.GetAssignmentTimes(f.ctx, []string{"u2", "u3"}, time.Now().UTC().Add(-30*24*time.Hour))
+		assert.NoError(t, err)
+		assert.Len(t, times["u2"], 2)
+		assert.Len(t, times["u3"], 1)
+
+		times, err = f.reviewers.GetAssignmentTimes(f.ctx, []string{"u2"}, old)
+		assert.NoError(t, err)
+		assert.Len(t, times["u2"], 3)
+		assert.True(t, old.Equal(times["u2"][0]))
+	})
+
+	t.Run("Success - GetAssignmentSourceCounts counts current assignments", func(t *testing.T) {
+		assert.NoError(t, f.reviewers.ReplaceReviewer(f.ctx, "pr-2", "u2", "u3", models.AssignmentSourceEscalation))
+
+		counts, err := f.reviewers.GetAssignmentSourceCounts(f.ctx, "", false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int{models.AssignmentSourceAuto: 3, models.AssignmentSourceEscalation: 1}, counts)
+	})
+}
+
+func TestReviewerRepository_GetReviewTurnarounds(t *testing.T) {
+	f := newFixture(t)
+	f.team("backend", "u1", "u2", "u3", "u4")
+	mergedAt := time.Now().UTC().Add(-time.Hour).Truncate(time.Microsecond)
+	f.pr("pr-1", "u1", mergedAt.Add(-24*time.Hour), "u2", "u3")
+	f.pr("pr-2", "u1", mergedAt.Add(-24*time.Hour), "u2")
+	f.pr("pr-3", "u1", mergedAt.Add(-24*time.Hour), "u2")
+	f.pr("pr-4", "u1", mergedAt.Add(-24*time.Hour), "u3")
+	f.pr("pr-open", "u1", mergedAt.Add(-24*time.Hour), "u4")
+	f.pr("pr-old", "u1", mergedAt.Add(-72*time.Hour), "u3")
+	assert.NoError(t, f.reviewers.ReplaceReviewer(f.ctx, "pr-4", "u3", "u4", models.AssignmentSourceReassign))
+	for _, a := range []struct {
+		prID, reviewerID string
+		before           time.Duration
+	}{
+		{"pr-1", "u2", time.Hour},
+		{"pr-2", "u2", 4 * time.Hour},
+		{"pr-3", "u2", 10 * time.Hour},
+		{"pr-1", "u3", 2 * time.Hour},
+		{"pr-4", "u4", 30 * time.Minute},
+		{"pr-open", "u4", 5 * time.Hour},
+		{"pr-old", "u3", 54 * time.Hour},
+	} {
+		f.setAssignedAt(a.prID, a.reviewerID, mergedAt.Add(-a.before))
+	}
+	for _, prID := range []string{"pr-1", "pr-2", "pr-3", "pr-4"} {
+		f.exec(`UPDATE pull_request SET status = 'MERGED', merged_at = $2 WHERE id = $1`, prID, mergedAt)
+	}
+	f.exec(`UPDATE pull_request SET status = 'MERGED', merged_at = $2 WHERE id = 'pr-old'`, mergedAt.Add(-48*time.Hour))
+	archived, err := f.archive.ArchiveMerged(f.ctx, mergedAt.Add(-24*time.Hour), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"pr-old"}, archived)
+
+	t.Run("Success - Only current reviewers of merged PRs count", func(t *testing.T) {
+		turnarounds, err := f.reviewers.GetReviewTurnarounds(f.ctx, "", false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]models.ReviewTurnaround{
+			"u2": {AvgSeconds: 18000, MedianSeconds: 14400},
+			"u3": {AvgSeconds: 7200, MedianSeconds: 7200},
+			"u4": {AvgSeconds: 1800, MedianSeconds: 1800},
+		}, turnarounds)
+	})
+
+	t.Run("Success - Archived PRs are added on request", func(t *testing.T) {
+		turnarounds, err := f.reviewers.GetReviewTurnarounds(f.ctx, "", true)
+
+		assert.NoError(t, err)
+		// the median of 2h and 6h is interpolated
+		assert.Equal(t, models.ReviewTurnaround{AvgSeconds: 14400, MedianSeconds: 14400}, turnarounds["u3"])
+		assert.Equal(t, models.ReviewTurnaround{AvgSeconds: 18000, MedianSeconds: 14400}, turnarounds["u2"])
+	})
+
+	t.Run("Success - Summary of one reviewer", func(t *testing.T) {
+		summary, err := f.reviewers.GetReviewerSummary(f.ctx, "u4", false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &models.ReviewerSummary{Assignments: 2, ActiveReviews: 1,
+			Turnaround: &models.ReviewTurnaround{AvgSeconds: 1800, MedianSeconds: 1800}}, summary)
+	})
+
+	t.Run("Success - Summary with archived PRs", func(t *testing.T) {
+		summary, err := f.reviewers.GetReviewerSummary(f.ctx, "u3", true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &models.ReviewerSummary{Assignments: 2,
+			Turnaround: &models.ReviewTurnaround{AvgSeconds: 14400, MedianSeconds: 14400}}, summary)
+	})
+
+	t.Run("Success - Summary without assignments", func(t *testing.T) {
+		summary, err := f.reviewers.GetReviewerSummary(f.ctx, "u1", true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &models.ReviewerSummary{}, summary)
+	})
+
+	t.Run("Success - Recent assignments, latest first", func(t *testing.T) {
+		assignments, err := f.reviewers.FindRecentAssignments(f.ctx, "u2", false, 2)
+
+		assert.NoError(t, err)
+		if assert.Len(t, assignments, 2) {
+			assert.Equal(t, "pr-1", assignments[0].PRId)
+			assert.Equal(t, mergedAt.Add(-time.Hour), assignments[0].AssignedAt.UTC())
+			assert.Equal(t, "pr-2", assignments[1].PRId)
+		}
+	})
+
+	t.Run("Success - Recent assignments of archived PRs on request", func(t *testing.T) {
+		current, err := f.reviewers.FindRecentAssignments(f.ctx, "u3", false, 10)
+		assert.NoError(t, err)
+		all, err := f.reviewers.FindRecentAssignments(f.ctx, "u3", true, 10)
+		assert.NoError(t, err)
+
+		assert.Len(t, current, 1)
+		if assert.Len(t, all, 2) {
+			assert.Equal(t, "pr-old", all[1].PRId)
+		}
+	})
+}
+
+func TestReviewerRepository_ReviewLoads(t *testing.T) {
+	f := newFixture(t)
+	f.team("backend", "u1", "u2", "u3", "u4")
+	f.team("frontend", "f1")
+	base := time.Now().UTC().Add(-time.Hour)
+	for i := 0; i < 6; i++ {
+		f.pr(fmt.Sprintf("pr-%d", i), "u1", base, "u2")
+	}
+	f.pr("pr-open", "u1", base, "u3", "f1")
+	f.pr("pr-merged", "u1", base, "u3", "u4")
+	f.merge("pr-merged")
+	f.exec(`UPDATE "user" SET is_active = false WHERE id = 'u4'`)
+
+	t.Run("Success - Histogram of a team", func(t *testing.T) {
+		histogram, err := f.reviewers.GetReviewLoadHistogram(f.ctx, "backend", 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &models.ReviewLoadHistogram{
+			ActiveReviews:    map[int]int{0: 1, 1: 1, 5: 1},
+			TotalAssignments: map[int]int{0: 1, 2: 1, 5: 1},
+		}, histogram)
+	})
+
+	t.Run("Success - Histogram of all teams", func(t *testing.T) {
+		histogram, err := f.reviewers.GetReviewLoadHistogram(f.ctx, "", 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[int]int{0: 1, 1: 2, 5: 1}, histogram.ActiveReviews)
+	})
+
+	t.Run("Success - Loads of active users, the most loaded first", func(t *testing.T) {
+		loads, err := f.reviewers.GetReviewLoads(f.ctx, "backend")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*models.ReviewLoad{
+			{UserId: "u2", Username: "u2", TeamName: "backend", ActiveReviews: 6, TotalAssignments: 6},
+			{UserId: "u3", Username: "u3", TeamName: "backend", ActiveReviews: 1, TotalAssignments: 2},
+			{UserId: "u1", Username: "u1", TeamName: "backend"},
+		}, loads)
+	})
+}
+
+func TestStatisticsRepositories_TeamFilter(t *testing.T) {
+	f := newFixture(t)
+	f.team("backend", "u1", "u2")
+	f.team("frontend", "f1", "f2", "f3")
+	now := time.Now().UTC()
+	f.pr("pr-backend", "u1", now.Add(-2*time.Hour), "u2")
+	f.pr("pr-frontend", "f1", now.Add(-time.Hour), "f2")
+	f.pr("pr-unassigned", "f1", now)
+	assert.NoError(t, f.reviewers.ReplaceReviewer(f.ctx, "pr-frontend", "f2", "f3", models.AssignmentSourceReassign))
+	assert.NoError(t, f.reviewers.RecordReviewerChange(f.ctx, &models.ReviewerChange{PRId: "pr-frontend",
+		OldReviewerId: "f2", NewReviewerId: "f3", Trigger: models.ReviewerChangeManual, ChangedAt: now}))
+
+	t.Run("Success - PRs and reviewers of the authors of a team", func(t *testing.T) {
+		prs, err := f.prs.GetAllPRs(f.ctx, "frontend")
+		assert.NoError(t, err)
+		assert.Len(t, prs, 2)
+		assert.Equal(t, "pr-unassigned", prs[0].Id)
+		assert.Equal(t, "pr-frontend", prs[1].Id)
+
+		reviewers, err := f.reviewers.GetAllReviewers(f.ctx, "backend")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string][]string{"pr-backend": {"u2"}}, reviewers)
+	})
+
+	t.Run("Success - Counts of a team", func(t *testing.T) {
+		sources, err := f.reviewers.GetAssignmentSourceCounts(f.ctx, "frontend", false)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int{models.AssignmentSourceReassign: 1}, sources)
+
+		changes, err := f.reviewers.CountReviewerChanges(f.ctx, "backend", false)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, changes)
+
+		reassignments, err := f.reviewers.GetReassignmentCounts(f.ctx, "frontend", false)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int{"pr-frontend": 1}, reassignments)
+
+		counts, err := f.reviewers.GetAllReviewerCounts(f.ctx, "backend")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int{"u2": 1}, counts)
+
+		unassigned, err := f.prs.CountOpenWithoutReviewers(f.ctx, "backend", nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, unassigned)
+	})
+
+	t.Run("Success - Empty team name keeps everything", func(t *testing.T) {
+		prs, err := f.prs.GetAllPRs(f.ctx, "")
+		assert.NoError(t, err)
+		assert.Len(t, prs, 3)
+
+		unassigned, err := f.prs.CountOpenWithoutReviewers(f.ctx, "", nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, unassigned)
+	})
+}
+
+func TestReviewerRepository_ReviewState(t *testing.T) {
+	f := newFixture(t)
+	f.team("backend", "u1", "u2", "u3")
+	now := time.Now().UTC()
+	stale := now.Add(-4 * 24 * time.Hour).Truncate(time.Microsecond)
+	f.pr("pr-1", "u1", stale, "u2", "u3")
+	f.pr("pr-2", "u1", stale, "u2")
+	f.pr("pr-merged", "u1", stale, "u2")
+	f.merge("pr-merged")
+	for _, prID := range []string{"pr-1", "pr-2", "pr-merged"} {
+		f.setAssignedAt(prID, "u2", stale)
+	}
+
+	t.Run("Success - New assignments are pending", func(t *testing.T) {
+		assignments, err := f.reviewers.GetAssignmentsByPRs(f.ctx, []string{"pr-1"})
+
+		assert.NoError(t, err)
+		assert.Len(t, assignments, 2)
+		assert.Equal(t, "u2", assignments[0].ReviewerId)
+		assert.Equal(t, models.ReviewStatePending, assignments[0].State)
+		assert.Nil(t, assignments[0].StateChangedAt)
+		assert.True(t, stale.Equal(assignments[0].AssignedAt))
+	})
+
+	t.Run("Success - FindOpenAssignments skips merged PRs and recent assignments", func(t *testing.T) {
+		assignments, err := f.reviewers.FindOpenAssignments(f.ctx, now.Add(-time.Hour))
+
+		assert.NoError(t, err)
+		assert.Len(t, assignments, 2)
+		for _, assignment := range assignments {
+			assert.Equal(t, "u2", assignment.ReviewerId)
+		}
+	})
+
+	t.Run("Success - FindAssignmentsAfter pages by time, then PR id", func(t *testing.T) {
+		assignments, err := f.reviewers.FindAssignmentsAfter(f.ctx, "u2", models.AssignmentCursor{}, 10)
+
+		assert.NoError(t, err)
+		if assert.Len(t, assignments, 2) {
+			assert.Equal(t, "pr-1", assignments[0].PRId)
+			assert.Equal(t, "pr-2", assignments[1].PRId)
+		}
+
+		after := models.AssignmentCursor{AssignedAt: stale, PRId: "pr-1"}
+		assignments, err = f.reviewers.FindAssignmentsAfter(f.ctx, "u2", after, 10)
+		assert.NoError(t, err)
+		if assert.Len(t, assignments, 1) {
+			assert.Equal(t, "pr-2", assignments[0].PRId)
+		}
+
+		assignments, err = f.reviewers.FindAssignmentsAfter(f.ctx, "u2", models.AssignmentCursor{}, 1)
+		assert.NoError(t, err)
+		assert.Len(t, assignments, 1)
+	})
+
+	t.Run("Success - SetReviewState and LockAssignment", func(t *testing.T) {
+		changedAt := now.Truncate(time.Microsecond)
+		assert.NoError(t, f.reviewers.SetReviewState(f.ctx, "pr-1", "u3", models.ReviewStateApproved, changedAt))
+
+		assignment, err := f.reviewers.LockAssignment(f.ctx, "pr-1", "u3")
+		assert.NoError(t, err)
+		assert.Equal(t, models.ReviewStateApproved, assignment.State)
+		assert.True(t, changedAt.Equal(*assignment.StateChangedAt))
+
+		assignment, err = f.reviewers.LockAssignment(f.ctx, "pr-2", "u3")
+		assert.NoError(t, err)
+		assert.Nil(t, assignment)
+	})
+
+	t.Run("Success - FindStaleAssignments skips approved PRs", func(t *testing.T) {
+		assignments, err := f.reviewers.FindStaleAssignments(f.ctx, now.Add(-72*time.Hour), 10)
+
+		assert.NoError(t, err)
+		assert.Len(t, assignments, 1)
+		assert.Equal(t, "pr-2", assignments[0].PRId)
+
+		assignments, err = f.reviewers.FindStaleAssignments(f.ctx, now.Add(-72*time.Hour), 0)
+		assert.NoError(t, err)
+		assert.Empty(t, assignments)
+	})
+}
+
+func TestReviewerRepository_History(t *testing.T) {
+	f := newFixture(t)
+	f.team("backend", "u1", "u2", "u3")
+	f.pr("pr-1", "u1", time.Now().UTC(), "u2")
+	f.pr("pr-2", "u1", time.Now().UTC(), "u2")
+	first := time.Now().UTC().Add(-time.Minute).Truncate(time.Microsecond)
+
+	assert.NoError(t, f.reviewers.RecordReviewerChange(f.ctx, &models.ReviewerChange{
+		PRId: "pr-1", OldReviewerId: "u2", NewReviewerId: "u3", Trigger: models.ReviewerChangeManual, ChangedAt: first}))
+	assert.NoError(t, f.reviewers.RecordReviewerChange(f.ctx, &models.ReviewerChange{
+		PRId: "pr-1", OldReviewerId: "u3", Trigger: models.ReviewerChangeDeactivation, ChangedAt: first.Add(time.Second)}))
+	assert.NoError(t, f.reviewers.RecordReviewerChange(f.ctx, &models.ReviewerChange{
+		PRId: "pr-2", OldReviewerId: "u2", NewReviewerId: "u3", Trigger: models.ReviewerChangeEscalation, ChangedAt: first}))
+
+	t.Run("Success - History in chronological order", func(t *testing.T) {
+		history, err := f.reviewers.GetReviewerHistory(f.ctx, "pr-1")
+
+		assert.NoError(t, err)
+		assert.Len(t, history, 2)
+		assert.Equal(t, "u3", history[0].NewReviewerId)
+		assert.True(t, first.Equal(history[0].ChangedAt))
+		assert.Equal(t, "", history[1].NewReviewerId)
+		assert.Equal(t, models.ReviewerChangeDeactivation, history[1].Trigger)
+	})
+
+	t.Run("Success - Removals are not counted as reassignments", func(t *testing.T) {
+		counts, err := f.reviewers.GetReassignmentCounts(f.ctx, "", false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int{"pr-1": 1, "pr-2": 1}, counts)
+	})
+
+	t.Run("Success - Removals are counted as events", func(t *testing.T) {
+		count, err := f.reviewers.CountReviewerChanges(f.ctx, "", false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("Error - Unknown trigger", func(t *testing.T) {
+		err := f.reviewers.RecordReviewerChange(f.ctx, &models.ReviewerChange{
+			PRId: "pr-1", OldReviewerId: "u2", Trigger: "unknown", ChangedAt: first})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestReviewerRepository_GetReviewsChangedAt(t *testing.T) {
+	f := newFixture(t)
+	f.team("backend", "u1", "u2", "u3")
+	f.pr("pr-1", "u1", time.Now().UTC(), "u2")
+
+	changedAt := func(t *testing.T, reviewerID string) time.Time {
+		t.Helper()
+		at, err := f.reviewers.GetReviewsChangedAt(f.ctx, reviewerID)
+		assert.NoError(t, err)
+		return at
+	}
+
+	t.Run("Success - Assignment is a change", func(t *testing.T) {
+		assert.False(t, changedAt(t, "u2").IsZero())
+		assert.True(t, changedAt(t, "u3").IsZero())
+	})
+
+	t.Run("Success - Replacement changes both reviewers", func(t *testing.T) {
+		before := changedAt(t, "u2")
+
+		assert.NoError(t, f.reviewers.ReplaceReviewer(f.ctx, "pr-1", "u2", "u3", models.AssignmentSourceReassign))
+
+		assert.True(t, changedAt(t, "u2").After(before))
+		assert.False(t, changedAt(t, "u3").IsZero())
+	})
+
+	t.Run("Success - Removal is a change", func(t *testing.T) {
+		before := changedAt(t, "u3")
+
+		assert.NoError(t, f.reviewers.RemoveReviewer(f.ctx, "pr-1", "u3"))
+
+		assert.True(t, changedAt(t, "u3").After(before))
+	})
+
+	t.Run("Success - Unknown reviewer", func(t *testing.T) {
+		assert.True(t, changedAt(t, "u9").IsZero())
+	})
+}
@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dbctx"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePool stands for a connection pool in routing decisions; it is never queried.
+type fakePool struct {
+	name string
+}
+
+func (p *fakePool) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	panic("fakePool is not queried")
+}
+
+func (p *fakePool) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	panic("fakePool is not queried")
+}
+
+func (p *fakePool) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	panic("fakePool is not queried")
+}
+
+func (p *fakePool) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	panic("fakePool is not queried")
+}
+
+// fakeTx stands for a transaction stored in the context by UnitOfWork.
+type fakeTx struct {
+	pgx.Tx
+}
+
+func TestRoute(t *testing.T) {
+	primary, replica := &fakePool{name: "primary"}, &fakePool{name: "replica"}
+	tx := &fakeTx{}
+	inTx := func(ctx context.Context) context.Context {
+		// as UnitOfWork.WithinTransaction does
+		return context.WithValue(dbctx.Primary(ctx), txKey{}, pgx.Tx(tx))
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want txOrPool
+	}{
+		{"Success - Unmarked reads go to the primary", context.Background(), primary},
+		{"Success - Read-only reads go to the replica", dbctx.ReadOnly(context.Background()), replica},
+		{"Success - Transaction reads use the transaction", inTx(context.Background()), tx},
+		{"Success - Read-only call inside a transaction uses the transaction", dbctx.ReadOnly(inTx(context.Background())), tx},
+		{"Success - Primary overrides an earlier read-only mark", dbctx.Primary(dbctx.ReadOnly(context.Background())), primary},
+		{"Success - Read-only mark doesn't override primary", dbctx.ReadOnly(dbctx.Primary(context.Background())), primary},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Same(t, tt.want, route(tt.ctx, primary, replica))
+		})
+	}
+
+	t.Run("Success - Without a replica read-only reads go to the primary", func(t *testing.T) {
+		assert.Same(t, txOrPool(tx), getReader(inTx(context.Background()), nil, nil))
+		assert.Equal(t, txOrPool(poolExecutor{}), getReader(dbctx.ReadOnly(context.Background()), nil, nil))
+	})
+}
@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// SnapshotRepository stores the daily statistics snapshots in the database.
+type SnapshotRepository struct {
+	pool    *pgxpool.Pool
+	replica *pgxpool.Pool
+}
+
+// SaveSnapshots stores the snapshots with one batch of statements, replacing those of the same day and team.
+func (r *SnapshotRepository) SaveSnapshots(ctx context.Context, snapshots []*models.StatisticsSnapshot) error {
+	query := `INSERT INTO statistics_snapshot (day, team_name, total_prs, open_prs, merged_prs, total_assignments,
+	                                           reassignment_events, open_prs_without_reviewers, taken_at, org_id)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	          ON CONFLICT (org_id, day, team_name) DO UPDATE
+	          SET total_prs = EXCLUDED.total_prs,
+	              open_prs = EXCLUDED.open_prs,
+	              merged_prs = EXCLUDED.merged_prs,
+	              total_assignments = EXCLUDED.total_assignments,
+	              reassignment_events = EXCLUDED.reassignment_events,
+	              open_prs_without_reviewers = EXCLUDED.open_prs_without_reviewers,
+	              taken_at = EXCLUDED.taken_at`
+
+	batch := &pgx.Batch{}
+	for _, s := range snapshots {
+		batch.Queue(query, s.Day, s.TeamName, s.TotalPRs, s.OpenPRs, s.MergedPRs, s.TotalAssignments,
+			s.ReassignmentEvents, s.OpenPRsWithoutReviewers, s.TakenAt, orgctx.ID(ctx))
+	}
+	return execBatch(ctx, getTx(ctx, r.pool), batch, "statistics snapshots")
+}
+
+// HasSnapshot reports whether the snapshot of all teams was taken on the day. It reads the primary,
+// so a snapshot just taken by another replica is seen.
+func (r *SnapshotRepository) HasSnapshot(ctx context.Context, day time.Time) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM statistics_snapshot WHERE org_id = $2 AND day = $1 AND team_name = '')`
+
+	var exists bool
+	if err := getTx(ctx, r.pool).QueryRow(ctx, query, day, orgctx.ID(ctx)).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check statistics snapshot: %w", err)
+	}
+	return exists, nil
+}
+
+// FindSnapshots returns the snapshots of the days in [from, to), ordered by day, the snapshot of
+// all teams before those of the teams in alphabetical order.
+func (r *SnapshotRepository) FindSnapshots(ctx context.Context, from, to time.Time) ([]*models.StatisticsSnapshot, error) {
+	query := `SELECT day, team_name, total_prs, open_prs, merged_prs, total_assignments,
+	                 reassignment_events, open_prs_without_reviewers, taken_at
+	          FROM statistics_snapshot
+	          WHERE org_id = $3 AND day >= $1 AND day < $2
+	          ORDER BY day, team_name`
+
+	rows, err := getReader(ctx, r.pool, r.replica).Query(ctx, query, from, to, orgctx.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find statistics snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	var snapshots []*models.StatisticsSnapshot
+	for rows.Next() {
+		var s models.StatisticsSnapshot
+		if err = rows.Scan(&s.Day, &s.TeamName, &s.TotalPRs, &s.OpenPRs, &s.MergedPRs, &s.TotalAssignments,
+			&s.ReassignmentEvents, &s.OpenPRsWithoutReviewers, &s.TakenAt); err != nil {
+			return nil, fmt.Errorf("failed to scan statistics snapshot: %w", err)
+		}
+		snapshots = append(snapshots, &s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return snapshots, nil
+}
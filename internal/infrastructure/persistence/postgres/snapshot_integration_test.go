@@ -0,0 +1,53 @@
+//go:build integration
+
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotRepository(t *testing.T) {
+	f := newFixture(t)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	takenAt := time.Now().UTC().Truncate(time.Microsecond)
+	snapshot := func(day time.Time, teamName string, totalPRs int) *models.StatisticsSnapshot {
+		return &models.StatisticsSnapshot{Day: day, TeamName: teamName, TotalPRs: totalPRs, OpenPRs: 1, TakenAt: takenAt}
+	}
+
+	t.Run("Success - HasSnapshot looks for the snapshot of all teams", func(t *testing.T) {
+		require.NoError(t, f.snapshots.SaveSnapshots(f.ctx, []*models.StatisticsSnapshot{snapshot(today, "backend", 1)}))
+
+		taken, err := f.snapshots.HasSnapshot(f.ctx, today)
+		require.NoError(t, err)
+		assert.False(t, taken)
+
+		require.NoError(t, f.snapshots.SaveSnapshots(f.ctx, []*models.StatisticsSnapshot{snapshot(today, "", 2)}))
+
+		taken, err = f.snapshots.HasSnapshot(f.ctx, today)
+		require.NoError(t, err)
+		assert.True(t, taken)
+	})
+
+	t.Run("Success - SaveSnapshots replaces the snapshot of the same day and team", func(t *testing.T) {
+		require.NoError(t, f.snapshots.SaveSnapshots(f.ctx, []*models.StatisticsSnapshot{
+			snapshot(today, "", 5), snapshot(today.AddDate(0, 0, -1), "", 3), snapshot(today.AddDate(0, 0, -7), "", 1),
+		}))
+
+		found, err := f.snapshots.FindSnapshots(f.ctx, today.AddDate(0, 0, -1), today.AddDate(0, 0, 1))
+
+		require.NoError(t, err)
+		require.Len(t, found, 3)
+		assert.Equal(t, today.AddDate(0, 0, -1), found[0].Day.UTC())
+		assert.Equal(t, 3, found[0].TotalPRs)
+		assert.Equal(t, "", found[1].TeamName)
+		assert.Equal(t, 5, found[1].TotalPRs)
+		assert.Equal(t, 1, found[1].OpenPRs)
+		assert.True(t, takenAt.Equal(found[1].TakenAt))
+		assert.Equal(t, "backend", found[2].TeamName)
+	})
+}
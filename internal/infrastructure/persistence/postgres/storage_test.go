@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePinger fails the first pings, as many as failures.
+type fakePinger struct {
+	failures int
+	pings    int
+}
+
+func (p *fakePinger) Ping(ctx context.Context) error {
+	p.pings++
+	if p.pings <= p.failures {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func TestPing(t *testing.T) {
+	cfg := config.PostgresConnect{MaxAttempts: 5, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+
+	t.Run("Success - Failed pings are retried and logged at Warn", func(t *testing.T) {
+		var logs bytes.Buffer
+		db := &fakePinger{failures: 2}
+
+		err := ping(context.Background(), db, cfg, slog.New(slog.NewTextHandler(&logs, nil)))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, db.pings)
+		assert.Equal(t, 2, strings.Count(logs.String(), "level=WARN msg=\"database is not ready, retrying\""))
+		assert.Contains(t, logs.String(), "attempts=3")
+	})
+
+	t.Run("Success - Ready database is pinged once", func(t *testing.T) {
+		var logs bytes.Buffer
+		db := &fakePinger{}
+
+		err := ping(context.Background(), db, cfg, slog.New(slog.NewTextHandler(&logs, nil)))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, db.pings)
+		assert.Empty(t, logs.String())
+	})
+
+	t.Run("Error - Attempts run out", func(t *testing.T) {
+		db := &fakePinger{failures: 10}
+
+		err := ping(context.Background(), db, cfg, slog.New(slog.DiscardHandler))
+
+		assert.ErrorContains(t, err, "after 5 attempts: connection refused")
+		assert.Equal(t, 5, db.pings)
+	})
+
+	t.Run("Error - Deadline passes before the attempts run out", func(t *testing.T) {
+		slow := config.PostgresConnect{MaxAttempts: 100, InitialBackoff: 20 * time.Millisecond, MaxBackoff: time.Second}
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		db := &fakePinger{failures: 100}
+
+		err := ping(ctx, db, slow, slog.New(slog.DiscardHandler))
+
+		assert.ErrorContains(t, err, "connection refused")
+		assert.Less(t, db.pings, 5)
+	})
+}
@@ -2,43 +2,78 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"iter"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
 	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
 )
 
 // TeamRepository manages teams in the database.
 type TeamRepository struct {
 	pool *pgxpool.Pool
+	// replica serves reads of read-only contexts; nil without a configured replica.
+	replica *pgxpool.Pool
 }
 
-// CreateOrUpdateTeam creates/updates a team and its members.
-func (r *TeamRepository) CreateOrUpdateTeam(ctx context.Context, team *models.Team) error {
-	teamName := team.GetTeamName()
-
+// CreateTeam creates a new team with its members.
+// Returns TEAM_EXISTS AppError if the team already exists, which the key of the team table reports;
+// a concurrent request for the same team waits on the key until this one ends.
+func (r *TeamRepository) CreateTeam(ctx context.Context, team *models.Team) error {
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return fmt.Errorf("failed to begin transaction: %w", poolError(ctx, err))
 	}
 	defer tx.Rollback(ctx)
 
-	upsertQuery := `
-		INSERT INTO "user" (id, username, team_name, is_active) 
-		VALUES ($1, $2, $3, $4)
-		ON CONFLICT (id) 
-		DO UPDATE SET 
-			username = EXCLUDED.username,
-			team_name = EXCLUDED.team_name,
-			is_active = EXCLUDED.is_active`
+	record := team.Record()
+	if err = insertTeam(ctx, tx, record); err != nil {
+		return err
+	}
+	if err = upsertMembers(ctx, tx, team); err != nil {
+		return err
+	}
+	if err = setTeamLead(ctx, tx, record); err != nil {
+		return err
+	}
 
-	for _, member := range team.Members {
-		_, err = tx.Exec(ctx, upsertQuery,
-			member.Id, member.Name, teamName, member.IsActive)
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ImportTeam creates a new team whose members come in batches, storing each batch as it is
+// yielded, all in one transaction that is rolled back when batches yields an error.
+// Returns TEAM_EXISTS AppError if the team already exists, before any batch is read.
+func (r *TeamRepository) ImportTeam(ctx context.Context, team *models.TeamRecord,
+	batches iter.Seq2[[]*models.User, error]) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", poolError(ctx, err))
+	}
+	defer tx.Rollback(ctx)
+
+	if err = insertTeam(ctx, tx, team); err != nil {
+		return err
+	}
+	for members, err := range batches {
 		if err != nil {
-			return fmt.Errorf("failed to upsert user %s: %w", member.Id, err)
+			return err
+		}
+		if err = upsertMemberBatch(ctx, tx, team.Name, members); err != nil {
+			return err
 		}
 	}
+	if err = setTeamLead(ctx, tx, team); err != nil {
+		return err
+	}
 
 	if err = tx.Commit(ctx); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
@@ -47,28 +82,101 @@ func (r *TeamRepository) CreateOrUpdateTeam(ctx context.Context, team *models.Te
 	return nil
 }
 
-// IsExists checks if a team exists by team name.
-func (r *TeamRepository) IsExists(ctx context.Context, teamName string) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM "user" WHERE team_name = $1)`
+// upsertUserQuery creates or updates a team member.
+const upsertUserQuery = `
+	INSERT INTO "user" (id, username, team_name, is_active, max_active_reviews, tags, timezone, work_start, work_end,
+	                    is_reviewer, org_id)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	ON CONFLICT (org_id, id) 
+	DO UPDATE SET 
+		username = EXCLUDED.username,
+		team_name = EXCLUDED.team_name,
+		is_active = EXCLUDED.is_active,
+		max_active_reviews = EXCLUDED.max_active_reviews,
+		tags = EXCLUDED.tags,
+		timezone = EXCLUDED.timezone,
+		work_start = EXCLUDED.work_start,
+		work_end = EXCLUDED.work_end,
+		is_reviewer = EXCLUDED.is_reviewer`
+
+// upsertMembers creates or updates team members within the transaction.
+func upsertMembers(ctx context.Context, tx pgx.Tx, team *models.Team) error {
+	teamName := team.GetTeamName()
+	for _, member := range team.Members {
+		_, err := tx.Exec(ctx, upsertUserQuery,
+			member.Id, member.Name, teamName, member.IsActive, member.MaxActiveReviews, textArray(member.Tags),
+			member.Timezone, member.WorkStart, member.WorkEnd, !member.NonReviewer, orgctx.ID(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to upsert user %s: %w", member.Id, err)
+		}
+	}
+
+	return nil
+}
 
-	var exists bool
-	err := r.pool.QueryRow(ctx, query, teamName).Scan(&exists)
+// upsertMemberBatch creates or updates members of the team within the transaction in one round trip.
+func upsertMemberBatch(ctx context.Context, tx pgx.Tx, teamName string, members []*models.User) error {
+	batch := &pgx.Batch{}
+	for _, member := range members {
+		batch.Queue(upsertUserQuery,
+			member.Id, member.Name, teamName, member.IsActive, member.MaxActiveReviews, textArray(member.Tags),
+			member.Timezone, member.WorkStart, member.WorkEnd, !member.NonReviewer, orgctx.ID(ctx))
+	}
+	results := tx.SendBatch(ctx, batch)
+	defer results.Close()
+	for _, member := range members {
+		if _, err := results.Exec(); err != nil {
+			return fmt.Errorf("failed to upsert user %s: %w", member.Id, err)
+		}
+	}
+
+	return results.Close()
+}
+
+// insertTeam stores the metadata of a new team within the transaction, without its lead, who may
+// not be stored yet. A zero CreatedAt is stored as unknown.
+// Returns TEAM_EXISTS AppError if the organization has a team of that name.
+func insertTeam(ctx context.Context, tx pgx.Tx, team *models.TeamRecord) error {
+	query := `INSERT INTO team (name, description, created_at, org_id) VALUES ($1, $2, $3, $4)`
+
+	var createdAt *time.Time
+	if !team.CreatedAt.IsZero() {
+		createdAt = &team.CreatedAt
+	}
+	_, err := tx.Exec(ctx, query, team.Name, team.Description, createdAt, orgctx.ID(ctx))
 	if err != nil {
-		return false, fmt.Errorf("failed to check team existence: %w", err)
+		if isUniqueViolation(err) {
+			return domainErrors.NewTeamExists("team_name already exists")
+		}
+		return fmt.Errorf("failed to create team %s: %w", team.Name, err)
+	}
+
+	return nil
+}
+
+// setTeamLead stores the lead of a team inserted by insertTeam once the members are stored.
+func setTeamLead(ctx context.Context, tx pgx.Tx, team *models.TeamRecord) error {
+	if team.LeadId == "" {
+		return nil
+	}
+	query := `UPDATE team SET lead_id = $1 WHERE org_id = $2 AND name = $3`
+	if _, err := tx.Exec(ctx, query, team.LeadId, orgctx.ID(ctx), team.Name); err != nil {
+		return fmt.Errorf("failed to set lead of team %s: %w", team.Name, err)
 	}
 
-	return exists, nil
+	return nil
 }
 
-// GetTeamByName gets a team by its name.
+// GetTeamByName gets a team by its name with its metadata.
 func (r *TeamRepository) GetTeamByName(ctx context.Context, teamName string) (*models.Team, error) {
 	query := `
-		SELECT id, username, team_name, is_active 
+		SELECT id, username, team_name, is_active, max_active_reviews, tags, timezone, work_start, work_end, NOT is_reviewer 
 		FROM "user" 
-		WHERE team_name = $1 
+		WHERE org_id = $1 AND team_name = $2 
 		ORDER BY username`
 
-	rows, err := r.pool.Query(ctx, query, teamName)
+	executor := getReader(ctx, r.pool, r.replica)
+	rows, err := executor.Query(ctx, query, orgctx.ID(ctx), teamName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get team: %w", err)
 	}
@@ -77,7 +185,7 @@ func (r *TeamRepository) GetTeamByName(ctx context.Context, teamName string) (*m
 	var members []*models.User
 	for rows.Next() {
 		var user models.User
-		if err = rows.Scan(&user.Id, &user.Name, &user.TeamName, &user.IsActive); err != nil {
+		if err = rows.Scan(&user.Id, &user.Name, &user.TeamName, &user.IsActive, &user.MaxActiveReviews, &user.Tags, &user.Timezone, &user.WorkStart, &user.WorkEnd, &user.NonReviewer); err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
 		members = append(members, &user)
@@ -91,7 +199,68 @@ func (r *TeamRepository) GetTeamByName(ctx context.Context, teamName string) (*m
 		return nil, nil
 	}
 
-	return &models.Team{
+	team := &models.Team{
 		Members: members,
-	}, nil
+	}
+	var createdAt *time.Time
+	// a lead who has moved to another team is not reported
+	metadataQuery := `
+		SELECT t.description, COALESCE(l.id, ''), t.created_at
+		FROM team t
+		LEFT JOIN "user" l ON l.org_id = t.org_id AND l.id = t.lead_id AND l.team_name = t.name
+		WHERE t.org_id = $1 AND t.name = $2`
+	err = executor.QueryRow(ctx, metadataQuery, orgctx.ID(ctx), teamName).Scan(&team.Description, &team.LeadId, &createdAt)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get team metadata: %w", err)
+	}
+	if createdAt != nil {
+		team.CreatedAt = *createdAt
+	}
+
+	return team, nil
+}
+
+// ListTeams gets all teams with their metadata, ordered by name, with members ordered by username.
+// Like GetTeamByName, it reports a lead only while they are a member of the team.
+func (r *TeamRepository) ListTeams(ctx context.Context) ([]*models.Team, error) {
+	query := `
+		SELECT u.id, u.username, u.team_name, u.is_active, u.max_active_reviews, u.tags, u.timezone, u.work_start,
+		       u.work_end, NOT u.is_reviewer, COALESCE(t.description, ''), COALESCE(l.id, ''), t.created_at
+		FROM "user" u
+		LEFT JOIN team t ON t.org_id = u.org_id AND t.name = u.team_name
+		LEFT JOIN "user" l ON l.org_id = t.org_id AND l.id = t.lead_id AND l.team_name = t.name
+		WHERE u.org_id = $1
+		ORDER BY u.team_name, u.username`
+
+	executor := getReader(ctx, r.pool, r.replica)
+	rows, err := executor.Query(ctx, query, orgctx.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+	defer rows.Close()
+
+	var teams []*models.Team
+	for rows.Next() {
+		var user models.User
+		var team models.Team
+		var createdAt *time.Time
+		if err = rows.Scan(&user.Id, &user.Name, &user.TeamName, &user.IsActive, &user.MaxActiveReviews, &user.Tags,
+			&user.Timezone, &user.WorkStart, &user.WorkEnd, &user.NonReviewer, &team.Description, &team.LeadId, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan team member: %w", err)
+		}
+		if len(teams) == 0 || teams[len(teams)-1].GetTeamName() != user.TeamName {
+			if createdAt != nil {
+				team.CreatedAt = *createdAt
+			}
+			teams = append(teams, &team)
+		}
+		last := teams[len(teams)-1]
+		last.Members = append(last.Members, &user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return teams, nil
 }
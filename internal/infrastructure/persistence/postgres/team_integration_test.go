@@ -0,0 +1,175 @@
+//go:build integration
+
+package postgres
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeamRepository(t *testing.T) {
+	f := newFixture(t)
+	newTeam := func(teamName string, userIDs ...string) *models.Team {
+		team := &models.Team{}
+		for _, userID := range userIDs {
+			team.Members = append(team.Members, &models.User{Id: userID, Name: "name-" + userID, TeamName: teamName, IsActive: true})
+		}
+		return team
+	}
+
+	t.Run("Success - CreateTeam and GetTeamByName ordered by username", func(t *testing.T) {
+		assert.NoError(t, f.teams.CreateTeam(f.ctx, newTeam("backend", "u2", "u1")))
+
+		team, err := f.teams.GetTeamByName(f.ctx, "backend")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u1", "u2"}, userIDs(team.Members))
+		assert.Equal(t, "backend", team.GetTeamName())
+	})
+
+	t.Run("Error - CreateTeam of existing team", func(t *testing.T) {
+		err := f.teams.CreateTeam(f.ctx, newTeam("backend", "u3"))
+
+		assert.Error(t, err)
+		assert.Equal(t, domainErrors.CodeTeamExists, err.(*domainErrors.AppError).Code)
+		user, _ := f.users.FindByID(f.ctx, "u3")
+		assert.Nil(t, user)
+	})
+
+	t.Run("Success - CreateTeam moves users between teams", func(t *testing.T) {
+		assert.NoError(t, f.teams.CreateTeam(f.ctx, newTeam("frontend", "u2", "f1")))
+
+		backend, _ := f.teams.GetTeamByName(f.ctx, "backend")
+		assert.Equal(t, []string{"u1"}, userIDs(backend.Members))
+		frontend, _ := f.teams.GetTeamByName(f.ctx, "frontend")
+		assert.Equal(t, []string{"f1", "u2"}, userIDs(frontend.Members))
+	})
+
+	t.Run("Success - Metadata and lead", func(t *testing.T) {
+		createdAt := time.Now().UTC().Add(-time.Hour).Truncate(time.Microsecond)
+		team := newTeam("search", "s1", "s2")
+		team.Description, team.LeadId, team.CreatedAt = "Search", "s2", createdAt
+		assert.NoError(t, f.teams.CreateTeam(f.ctx, team))
+
+		got, err := f.teams.GetTeamByName(f.ctx, "search")
+		assert.NoError(t, err)
+		assert.Equal(t, "Search", got.Description)
+		assert.Equal(t, "s2", got.LeadId)
+		assert.True(t, createdAt.Equal(got.CreatedAt))
+		leadID, err := f.users.GetTeamLead(f.ctx, "search")
+		assert.NoError(t, err)
+		assert.Equal(t, "s2", leadID)
+
+		// a lead who left the team is not reported
+		assert.NoError(t, f.teams.CreateTeam(f.ctx, newTeam("ranking", "s2")))
+		got, _ = f.teams.GetTeamByName(f.ctx, "search")
+		assert.Empty(t, got.LeadId)
+		leadID, err = f.users.GetTeamLead(f.ctx, "search")
+		assert.NoError(t, err)
+		assert.Empty(t, leadID)
+	})
+
+	t.Run("Success - ListTeams ordered by name with members", func(t *testing.T) {
+		teams, err := f.teams.ListTeams(f.ctx)
+
+		assert.NoError(t, err)
+		names := make([]string, 0, len(teams))
+		for _, team := range teams {
+			names = append(names, team.GetTeamName())
+		}
+		assert.Equal(t, []string{"backend", "frontend", "ranking", "search"}, names)
+		assert.Equal(t, []string{"f1", "u2"}, userIDs(teams[1].Members))
+		assert.Equal(t, "Search", teams[3].Description)
+	})
+
+	t.Run("Error - CreateTeam of a team whose members all left", func(t *testing.T) {
+		team := newTeam("mobile", "m1")
+		team.Description = "Mobile"
+		assert.NoError(t, f.teams.CreateTeam(f.ctx, team))
+		assert.NoError(t, f.teams.CreateTeam(f.ctx, newTeam("platform", "m1")))
+
+		err := f.teams.CreateTeam(f.ctx, newTeam("mobile", "m2"))
+
+		assert.Error(t, err)
+		assert.Equal(t, domainErrors.CodeTeamExists, err.(*domainErrors.AppError).Code)
+		user, _ := f.users.FindByID(f.ctx, "m2")
+		assert.Nil(t, user)
+	})
+
+	t.Run("Success - GetTeamByName of missing team", func(t *testing.T) {
+		team, err := f.teams.GetTeamByName(f.ctx, "missing")
+		assert.NoError(t, err)
+		assert.Nil(t, team)
+	})
+
+	t.Run("Success - Concurrent CreateTeam has a single winner", func(t *testing.T) {
+		var wg sync.WaitGroup
+		errs := make([]error, 5)
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = f.teams.CreateTeam(f.ctx, newTeam("race", "r1", "r2"))
+			}(i)
+		}
+		wg.Wait()
+
+		created := 0
+		for _, err := range errs {
+			if err == nil {
+				created++
+				continue
+			}
+			assert.Equal(t, domainErrors.CodeTeamExists, err.(*domainErrors.AppError).Code)
+		}
+		assert.Equal(t, 1, created)
+	})
+
+	t.Run("Success - ImportTeam stores every batch with the lead", func(t *testing.T) {
+		record := &models.TeamRecord{Name: "imported", Description: "Imported", LeadId: "i3", CreatedAt: time.Now().UTC()}
+		batches := func(yield func([]*models.User, error) bool) {
+			if yield(newTeam("imported", "i1", "i2").Members, nil) {
+				yield(newTeam("imported", "i3").Members, nil)
+			}
+		}
+
+		assert.NoError(t, f.teams.ImportTeam(f.ctx, record, batches))
+
+		team, err := f.teams.GetTeamByName(f.ctx, "imported")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"i1", "i2", "i3"}, userIDs(team.Members))
+		assert.Equal(t, "i3", team.LeadId)
+	})
+
+	t.Run("Error - ImportTeam rolls back stored batches on error", func(t *testing.T) {
+		streamErr := errors.New("unexpected EOF")
+		batches := func(yield func([]*models.User, error) bool) {
+			if yield(newTeam("broken", "b1", "b2").Members, nil) {
+				yield(nil, streamErr)
+			}
+		}
+
+		err := f.teams.ImportTeam(f.ctx, &models.TeamRecord{Name: "broken"}, batches)
+
+		assert.ErrorIs(t, err, streamErr)
+		user, _ := f.users.FindByID(f.ctx, "b1")
+		assert.Nil(t, user)
+	})
+
+	t.Run("Error - ImportTeam of existing team reads no batch", func(t *testing.T) {
+		read := false
+		batches := func(yield func([]*models.User, error) bool) {
+			read = true
+		}
+
+		err := f.teams.ImportTeam(f.ctx, &models.TeamRecord{Name: "imported"}, batches)
+
+		assert.Equal(t, domainErrors.CodeTeamExists, err.(*domainErrors.AppError).Code)
+		assert.False(t, read)
+	})
+}
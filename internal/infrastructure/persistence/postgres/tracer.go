@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// maxLoggedSQLLength is the length the logged SQL is truncated to.
+const maxLoggedSQLLength = 200
+
+// QueryLogger is a pgx tracer logging every statement at Debug level. Argument values are only
+// logged with logArgs, as they may hold personal data; otherwise their count is logged.
+type QueryLogger struct {
+	log     *slog.Logger
+	logArgs bool
+}
+
+var (
+	_ pgx.QueryTracer = (*QueryLogger)(nil)
+	_ pgx.BatchTracer = (*QueryLogger)(nil)
+)
+
+// NewQueryLogger creates a tracer logging statements through log.
+func NewQueryLogger(log *slog.Logger, logArgs bool) *QueryLogger {
+	if log == nil {
+		log = slog.Default()
+	}
+	return &QueryLogger{log: log, logArgs: logArgs}
+}
+
+// queryStartKey is a key for storing the statement start time in context.
+type queryStartKey struct{}
+
+// queryStart is what TraceQueryStart passes to TraceQueryEnd.
+type queryStart struct {
+	at   time.Time
+	sql  string
+	args []any
+}
+
+// TraceQueryStart remembers the statement for TraceQueryEnd.
+func (l *QueryLogger) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, queryStartKey{}, queryStart{at: time.Now(), sql: data.SQL, args: data.Args})
+}
+
+// TraceQueryEnd logs the statement with its duration and the rows it affected.
+func (l *QueryLogger) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	start, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+	attrs := l.statementAttrs(start.sql, start.args, data.CommandTag)
+	attrs = append(attrs, slog.Duration("duration", time.Since(start.at)))
+	if data.Err != nil {
+		attrs = append(attrs, slog.String("error", data.Err.Error()))
+	}
+	l.log.LogAttrs(ctx, slog.LevelDebug, "sql statement", attrs...)
+}
+
+// TraceBatchStart is a no-op; the statements of a batch are logged one by one.
+func (l *QueryLogger) TraceBatchStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceBatchStartData) context.Context {
+	return ctx
+}
+
+// TraceBatchQuery logs a statement of a batch. Statements of a batch are sent together, so they
+// have no duration of their own.
+func (l *QueryLogger) TraceBatchQuery(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchQueryData) {
+	attrs := l.statementAttrs(data.SQL, data.Args, data.CommandTag)
+	attrs = append(attrs, slog.Bool("batch", true))
+	if data.Err != nil {
+		attrs = append(attrs, slog.String("error", data.Err.Error()))
+	}
+	l.log.LogAttrs(ctx, slog.LevelDebug, "sql statement", attrs...)
+}
+
+// TraceBatchEnd is a no-op.
+func (l *QueryLogger) TraceBatchEnd(context.Context, *pgx.Conn, pgx.TraceBatchEndData) {}
+
+// statementAttrs describes a statement: its command, SQL, arguments and affected rows.
+func (l *QueryLogger) statementAttrs(sql string, args []any, tag pgconn.CommandTag) []slog.Attr {
+	compact := strings.Join(strings.Fields(sql), " ")
+	attrs := []slog.Attr{
+		slog.String("statement", statementName(compact, tag)),
+		slog.String("sql", truncate(compact, maxLoggedSQLLength)),
+		slog.Int("args", len(args)),
+		slog.Int64("rows_affected", tag.RowsAffected()),
+	}
+	if l.logArgs {
+		attrs = append(attrs, slog.Any("arg_values", args))
+	}
+	return attrs
+}
+
+// statementName is the command of the statement as reported by the server, e.g. INSERT, or the
+// first keyword of the SQL when the statement failed.
+func statementName(sql string, tag pgconn.CommandTag) string {
+	if name, _, _ := strings.Cut(tag.String(), " "); name != "" {
+		return name
+	}
+	name, _, _ := strings.Cut(sql, " ")
+	return strings.ToUpper(name)
+}
+
+// truncate cuts s to at most n bytes without splitting a character, marking the cut with an ellipsis.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + "…"
+}
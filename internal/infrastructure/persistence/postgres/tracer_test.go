@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+// traceQuery runs a statement through the tracer and returns the logged record.
+func traceQuery(t *testing.T, logArgs bool, level slog.Level, start pgx.TraceQueryStartData,
+	end pgx.TraceQueryEndData) map[string]any {
+	t.Helper()
+	var buf bytes.Buffer
+	tracer := NewQueryLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: level})), logArgs)
+
+	ctx := tracer.TraceQueryStart(context.Background(), nil, start)
+	tracer.TraceQueryEnd(ctx, nil, end)
+
+	if buf.Len() == 0 {
+		return nil
+	}
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("failed to decode log record %q: %v", buf.String(), err)
+	}
+	return record
+}
+
+func TestQueryLogger(t *testing.T) {
+	update := pgx.TraceQueryStartData{
+		SQL: `UPDATE "user"
+		      SET is_active = $2
+		      WHERE id = $1`,
+		Args: []any{"u1", true},
+	}
+
+	t.Run("Success - Statement logged with redacted arguments", func(t *testing.T) {
+		record := traceQuery(t, false, slog.LevelDebug, update,
+			pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("UPDATE 1")})
+
+		assert.Equal(t, "DEBUG", record["level"])
+		assert.Equal(t, "UPDATE", record["statement"])
+		assert.Equal(t, `UPDATE "user" SET is_active = $2 WHERE id = $1`, record["sql"])
+		assert.EqualValues(t, 2, record["args"])
+		assert.EqualValues(t, 1, record["rows_affected"])
+		assert.Contains(t, record, "duration")
+		assert.NotContains(t, record, "arg_values")
+		assert.NotContains(t, record, "error")
+	})
+
+	t.Run("Success - Argument values logged on opt-in", func(t *testing.T) {
+		record := traceQuery(t, true, slog.LevelDebug, update,
+			pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("UPDATE 1")})
+
+		assert.Equal(t, []any{"u1", true}, record["arg_values"])
+	})
+
+	t.Run("Success - Failed statement named after its SQL", func(t *testing.T) {
+		record := traceQuery(t, false, slog.LevelDebug, pgx.TraceQueryStartData{SQL: "select 1"},
+			pgx.TraceQueryEndData{Err: errors.New("connection reset")})
+
+		assert.Equal(t, "SELECT", record["statement"])
+		assert.EqualValues(t, 0, record["rows_affected"])
+		assert.Equal(t, "connection reset", record["error"])
+	})
+
+	t.Run("Success - Long SQL truncated", func(t *testing.T) {
+		sql := "SELECT " + strings.Repeat("column, ", 100) + "id FROM pull_request"
+
+		record := traceQuery(t, false, slog.LevelDebug, pgx.TraceQueryStartData{SQL: sql},
+			pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 0")})
+
+		logged, _ := record["sql"].(string)
+		assert.Equal(t, sql[:maxLoggedSQLLength]+"…", logged)
+	})
+
+	t.Run("Success - Nothing logged above Debug", func(t *testing.T) {
+		record := traceQuery(t, false, slog.LevelInfo, update,
+			pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("UPDATE 1")})
+
+		assert.Nil(t, record)
+	})
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "short", truncate("short", 10))
+	assert.Equal(t, "abc…", truncate("abcdef", 3))
+	// "я" takes two bytes and is not split
+	assert.Equal(t, "ab…", truncate("abяcd", 3))
+}
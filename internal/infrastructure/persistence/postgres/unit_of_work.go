@@ -8,6 +8,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dbctx"
 )
 
 // UnitOfWork manages database transactions.
@@ -15,16 +16,20 @@ type UnitOfWork struct {
 	pool *pgxpool.Pool
 }
 
+// transactionTimeout bounds a transaction whose caller set no deadline.
+const transactionTimeout = 30 * time.Second
+
 // WithinTransaction executes a function within a database transaction with Repeatable Read isolation level.
+// The transaction ends at the caller's deadline, or after transactionTimeout when there is none.
 func (uow *UnitOfWork) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
-	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	ctx, cancel := transactionContext(ctx)
 	defer cancel()
 
 	tx, err := uow.pool.BeginTx(ctx, pgx.TxOptions{
 		IsoLevel: pgx.RepeatableRead,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return fmt.Errorf("failed to begin transaction: %w", poolError(ctx, err))
 	}
 
 	defer func() {
@@ -36,7 +41,7 @@ func (uow *UnitOfWork) WithinTransaction(ctx context.Context, fn func(ctx contex
 		}
 	}()
 
-	txCtx := context.WithValue(ctx, txKey{}, tx)
+	txCtx := context.WithValue(dbctx.Primary(ctx), txKey{}, tx)
 
 	err = fn(txCtx)
 	if err != nil {
@@ -50,11 +55,20 @@ func (uow *UnitOfWork) WithinTransaction(ctx context.Context, fn func(ctx contex
 	return nil
 }
 
+// transactionContext keeps the deadline of ctx, or sets transactionTimeout when it has none.
+func transactionContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, transactionTimeout)
+}
+
 // txOrPool is an interface pgx.Tx and Connection.
 type txOrPool interface {
 	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
 }
 
 // txKey is a key for storing transaction in context.
@@ -65,5 +79,26 @@ func getTx(ctx context.Context, pool *pgxpool.Pool) txOrPool {
 	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
 		return tx
 	}
-	return pool
+	return poolExecutor{pool: pool}
+}
+
+// getReader is getTx for queries that only read: outside a transaction, a context marked by
+// dbctx.ReadOnly is served by the replica when one is configured.
+func getReader(ctx context.Context, pool, replica *pgxpool.Pool) txOrPool {
+	if replica == nil {
+		return getTx(ctx, pool)
+	}
+	return route(ctx, poolExecutor{pool: pool}, poolExecutor{pool: replica})
+}
+
+// route picks the executor of a read: the transaction of ctx, else the replica for read-only
+// contexts, else the primary.
+func route(ctx context.Context, primary, replica txOrPool) txOrPool {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	if dbctx.IsReadOnly(ctx) {
+		return replica
+	}
+	return primary
 }
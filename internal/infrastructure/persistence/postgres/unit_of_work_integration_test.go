@@ -0,0 +1,163 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitOfWork_WithinTransaction(t *testing.T) {
+	f := newFixture(t)
+	f.team("backend", "u1", "u2", "u3")
+	f.pr("pr-1", "u1", time.Now().UTC())
+
+	t.Run("Success - Commit makes writes visible", func(t *testing.T) {
+		err := f.inTx(func(ctx context.Context) error {
+			if err := f.reviewers.AssignReviewer(ctx, "pr-1", "u2", models.AssignmentSourceAuto); err != nil {
+				return err
+			}
+			// reads inside the transaction see its own writes
+			reviewers, err := f.reviewers.GetReviewers(ctx, "pr-1")
+			assert.Equal(t, []string{"u2"}, reviewers)
+			return err
+		})
+		assert.NoError(t, err)
+
+		reviewers, _ := f.reviewers.GetReviewers(f.ctx, "pr-1")
+		assert.Equal(t, []string{"u2"}, reviewers)
+	})
+
+	t.Run("Success - Uncommitted writes are invisible outside", func(t *testing.T) {
+		_ = f.inTx(func(ctx context.Context) error {
+			assert.NoError(t, f.reviewers.AssignReviewer(ctx, "pr-1", "u3", models.AssignmentSourceAuto))
+
+			reviewers, err := f.reviewers.GetReviewers(f.ctx, "pr-1")
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"u2"}, reviewers)
+			return errors.New("abort")
+		})
+	})
+
+	t.Run("Error - Rollback on error", func(t *testing.T) {
+		failure := errors.New("boom")
+
+		err := f.inTx(func(ctx context.Context) error {
+			assert.NoError(t, f.reviewers.ReplaceReviewer(ctx, "pr-1", "u2", "u3", models.AssignmentSourceReassign))
+			assert.NoError(t, f.prs.SetLabels(ctx, "pr-1", []string{"rolled-back"}))
+			return failure
+		})
+
+		assert.ErrorIs(t, err, failure)
+		reviewers, _ := f.reviewers.GetReviewers(f.ctx, "pr-1")
+		assert.Equal(t, []string{"u2"}, reviewers)
+		pr, _ := f.prs.FindByID(f.ctx, "pr-1")
+		assert.Equal(t, []string{}, pr.Labels)
+	})
+
+	t.Run("Error - Rollback on panic", func(t *testing.T) {
+		assert.Panics(t, func() {
+			_ = f.inTx(func(ctx context.Context) error {
+				assert.NoError(t, f.reviewers.RemoveReviewer(ctx, "pr-1", "u2"))
+				panic("boom")
+			})
+		})
+
+		reviewers, _ := f.reviewers.GetReviewers(f.ctx, "pr-1")
+		assert.Equal(t, []string{"u2"}, reviewers)
+	})
+
+	t.Run("Success - Snapshot ignores changes committed later", func(t *testing.T) {
+		err := f.inTx(func(ctx context.Context) error {
+			before, err := f.users.FindByID(ctx, "u3")
+			if err != nil {
+				return err
+			}
+			f.exec(`UPDATE "user" SET username = 'renamed' WHERE id = 'u3'`)
+
+			after, err := f.users.FindByID(ctx, "u3")
+			assert.Equal(t, before.Name, after.Name)
+			return err
+		})
+		assert.NoError(t, err)
+
+		user, _ := f.users.FindByID(f.ctx, "u3")
+		assert.Equal(t, "renamed", user.Name)
+	})
+}
+
+func TestUserRepository_LockActiveCandidate(t *testing.T) {
+	f := newFixture(t)
+	f.team("backend", "u1", "u2", "u3")
+
+	t.Run("Error - Requires a transaction", func(t *testing.T) {
+		_, err := f.users.LockActiveCandidate(f.ctx, "u2")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Success - Active, inactive and missing users", func(t *testing.T) {
+		f.exec(`UPDATE "user" SET is_active = false WHERE id = 'u3'`)
+
+		err := f.inTx(func(ctx context.Context) error {
+			active, err := f.users.LockActiveCandidate(ctx, "u2")
+			assert.NoError(t, err)
+			assert.True(t, active)
+
+			active, err = f.users.LockActiveCandidate(ctx, "u3")
+			assert.NoError(t, err)
+			assert.False(t, active)
+
+			active, err = f.users.LockActiveCandidate(ctx, "missing")
+			assert.NoError(t, err)
+			assert.False(t, active)
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Success - Concurrent deactivation keeps the transaction usable", func(t *testing.T) {
+		err := f.inTx(func(ctx context.Context) error {
+			// take the snapshot, then change the row behind it
+			if _, err := f.users.FindByID(ctx, "u1"); err != nil {
+				return err
+			}
+			f.exec(`UPDATE "user" SET is_active = false WHERE id = 'u2'`)
+
+			active, err := f.users.LockActiveCandidate(ctx, "u2")
+			assert.NoError(t, err)
+			assert.False(t, active)
+
+			return f.reviewers.RecordReviewerChange(ctx, &models.ReviewerChange{
+				PRId: "missing", OldReviewerId: "u1", Trigger: models.ReviewerChangeManual, ChangedAt: time.Now().UTC()})
+		})
+
+		// the transaction is still alive: the next statement fails on its own foreign key, not as aborted
+		assert.Error(t, err)
+		assert.NotContains(t, err.Error(), "current transaction is aborted")
+	})
+}
+
+func TestAdvisoryLocker_TryLock(t *testing.T) {
+	locker := testStorage.NewAdvisoryLocker()
+	ctx := context.Background()
+
+	release, locked, err := locker.TryLock(ctx, 42)
+	assert.NoError(t, err)
+	assert.True(t, locked)
+
+	_, lockedAgain, err := locker.TryLock(ctx, 42)
+	assert.NoError(t, err)
+	assert.False(t, lockedAgain)
+
+	release()
+	releaseAgain, lockedAfterRelease, err := locker.TryLock(ctx, 42)
+	assert.NoError(t, err)
+	assert.True(t, lockedAfterRelease)
+	releaseAgain()
+}
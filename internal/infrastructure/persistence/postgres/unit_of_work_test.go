@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionContext(t *testing.T) {
+	t.Run("Success - Caller deadline kept", func(t *testing.T) {
+		parent, cancel := context.WithTimeout(context.Background(), time.Hour)
+		defer cancel()
+		want, _ := parent.Deadline()
+
+		ctx, cancelTx := transactionContext(parent)
+		defer cancelTx()
+
+		got, ok := ctx.Deadline()
+		assert.True(t, ok)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("Success - Timeout set without caller deadline", func(t *testing.T) {
+		ctx, cancel := transactionContext(context.Background())
+		defer cancel()
+
+		got, ok := ctx.Deadline()
+		assert.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(transactionTimeout), got, time.Second)
+	})
+}
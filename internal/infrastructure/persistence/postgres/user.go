@@ -7,22 +7,25 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
 	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
 )
 
 // UserRepository manages users in the database.
 type UserRepository struct {
 	pool *pgxpool.Pool
+	// replica serves reads of read-only contexts; nil without a configured replica.
+	replica *pgxpool.Pool
 }
 
 // FindByID finds user by ID.
 func (r *UserRepository) FindByID(ctx context.Context, userID string) (*models.User, error) {
-	query := `SELECT id, username, team_name, is_active FROM "user" WHERE id = $1`
+	query := `SELECT id, username, team_name, is_active, max_active_reviews, tags, timezone, work_start, work_end, NOT is_reviewer FROM "user" WHERE org_id = $1 AND id = $2`
 
-	executor := getTx(ctx, r.pool)
+	executor := getReader(ctx, r.pool, r.replica)
 	var user models.User
-	err := executor.QueryRow(ctx, query, userID).Scan(
-		&user.Id, &user.Name, &user.TeamName, &user.IsActive,
+	err := executor.QueryRow(ctx, query, orgctx.ID(ctx), userID).Scan(
+		&user.Id, &user.Name, &user.TeamName, &user.IsActive, &user.MaxActiveReviews, &user.Tags, &user.Timezone, &user.WorkStart, &user.WorkEnd, &user.NonReviewer,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -34,13 +37,40 @@ func (r *UserRepository) FindByID(ctx context.Context, userID string) (*models.U
 	return &user, nil
 }
 
+// FindByIDs finds users by IDs in a single query. Unknown IDs are skipped.
+func (r *UserRepository) FindByIDs(ctx context.Context, userIDs []string) ([]*models.User, error) {
+	query := `SELECT id, username, team_name, is_active, max_active_reviews, tags, timezone, work_start, work_end, NOT is_reviewer FROM "user" WHERE org_id = $1 AND id = ANY($2) ORDER BY id`
+
+	executor := getReader(ctx, r.pool, r.replica)
+	rows, err := executor.Query(ctx, query, orgctx.ID(ctx), userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*models.User
+	for rows.Next() {
+		var user models.User
+		if err = rows.Scan(&user.Id, &user.Name, &user.TeamName, &user.IsActive, &user.MaxActiveReviews, &user.Tags, &user.Timezone, &user.WorkStart, &user.WorkEnd, &user.NonReviewer); err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, &user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return users, nil
+}
+
 // SetIsActive updates the is_active status of a user.
 func (r *UserRepository) SetIsActive(ctx context.Context, userID string, isActive bool) error {
-	query := `UPDATE "user" SET is_active = $2 WHERE id = $1`
+	query := `UPDATE "user" SET is_active = $3 WHERE org_id = $1 AND id = $2`
 
-	result, err := r.pool.Exec(ctx, query, userID, isActive)
+	result, err := r.pool.Exec(ctx, query, orgctx.ID(ctx), userID, isActive)
 	if err != nil {
-		return fmt.Errorf("failed to set is_active: %w", err)
+		return fmt.Errorf("failed to set is_active: %w", poolError(ctx, err))
 	}
 
 	if result.RowsAffected() == 0 {
@@ -50,15 +80,81 @@ func (r *UserRepository) SetIsActive(ctx context.Context, userID string, isActiv
 	return nil
 }
 
-// FindActiveCandidatesForReassignment finds active users in the same team excluding specified user IDs.
+// SetTags replaces the tags of a user.
+func (r *UserRepository) SetTags(ctx context.Context, userID string, tags []string) error {
+	query := `UPDATE "user" SET tags = $3 WHERE org_id = $1 AND id = $2`
+
+	executor := getTx(ctx, r.pool)
+	if _, err := executor.Exec(ctx, query, orgctx.ID(ctx), userID, textArray(tags)); err != nil {
+		return fmt.Errorf("failed to set tags: %w", err)
+	}
+
+	return nil
+}
+
+// SetIsReviewer adds the user to the reviewer pool of their team or takes them out of it.
+func (r *UserRepository) SetIsReviewer(ctx context.Context, userID string, isReviewer bool) error {
+	query := `UPDATE "user" SET is_reviewer = $3 WHERE org_id = $1 AND id = $2`
+
+	executor := getTx(ctx, r.pool)
+	if _, err := executor.Exec(ctx, query, orgctx.ID(ctx), userID, isReviewer); err != nil {
+		return fmt.Errorf("failed to set is_reviewer: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateUsername changes the display name of a user.
+func (r *UserRepository) UpdateUsername(ctx context.Context, userID, username string) error {
+	query := `UPDATE "user" SET username = $3 WHERE org_id = $1 AND id = $2`
+
+	executor := getTx(ctx, r.pool)
+	if _, err := executor.Exec(ctx, query, orgctx.ID(ctx), userID, username); err != nil {
+		return fmt.Errorf("failed to update username: %w", err)
+	}
+
+	return nil
+}
+
+// GetExcludedReviewers returns IDs of users who must not review PRs of the author.
+func (r *UserRepository) GetExcludedReviewers(ctx context.Context, authorID string) ([]string, error) {
+	query := `SELECT reviewer_id FROM reviewer_exclusion WHERE org_id = $1 AND author_id = $2
+	          UNION
+	          SELECT author_id FROM reviewer_exclusion WHERE org_id = $1 AND reviewer_id = $2 AND mutual
+	          ORDER BY 1`
+
+	executor := getReader(ctx, r.pool, r.replica)
+	rows, err := executor.Query(ctx, query, orgctx.ID(ctx), authorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get excluded reviewers: %w", err)
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var userID string
+		if err = rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan excluded reviewer: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return userIDs, nil
+}
+
+// FindActiveCandidatesForReassignment finds active users of the team's reviewer pool excluding specified user IDs.
 func (r *UserRepository) FindActiveCandidatesForReassignment(ctx context.Context, teamName string, excludeUserIDs []string) ([]*models.User, error) {
-	query := `SELECT id, username, team_name, is_active 
+	query := `SELECT id, username, team_name, is_active, max_active_reviews, tags, timezone, work_start, work_end, NOT is_reviewer 
 	          FROM "user" 
-	          WHERE team_name = $1 AND is_active = true AND id != ALL($2)
+	          WHERE org_id = $1 AND team_name = $2 AND is_active = true AND is_reviewer = true AND id != ALL($3)
 	          ORDER BY id`
 
-	executor := getTx(ctx, r.pool)
-	rows, err := executor.Query(ctx, query, teamName, excludeUserIDs)
+	executor := getReader(ctx, r.pool, r.replica)
+	rows, err := executor.Query(ctx, query, orgctx.ID(ctx), teamName, excludeUserIDs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find active candidates: %w", err)
 	}
@@ -67,7 +163,7 @@ func (r *UserRepository) FindActiveCandidatesForReassignment(ctx context.Context
 	var users []*models.User
 	for rows.Next() {
 		var user models.User
-		if err := rows.Scan(&user.Id, &user.Name, &user.TeamName, &user.IsActive); err != nil {
+		if err := rows.Scan(&user.Id, &user.Name, &user.TeamName, &user.IsActive, &user.MaxActiveReviews, &user.Tags, &user.Timezone, &user.WorkStart, &user.WorkEnd, &user.NonReviewer); err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
 		users = append(users, &user)
@@ -76,11 +172,45 @@ func (r *UserRepository) FindActiveCandidatesForReassignment(ctx context.Context
 	return users, nil
 }
 
+// LockActiveCandidate locks the user row until the end of the transaction and reports
+// whether the user is still active. A concurrent deactivation yields false instead of
+// aborting the surrounding transaction, so the caller can try the next candidate.
+func (r *UserRepository) LockActiveCandidate(ctx context.Context, userID string) (bool, error) {
+	tx, ok := ctx.Value(txKey{}).(pgx.Tx)
+	if !ok {
+		return false, fmt.Errorf("failed to lock candidate: transaction required")
+	}
+
+	// savepoint keeps the transaction usable after a serialization failure
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to create savepoint: %w", err)
+	}
+
+	query := `SELECT is_active FROM "user" WHERE org_id = $1 AND id = $2 FOR SHARE`
+
+	var isActive bool
+	err = savepoint.QueryRow(ctx, query, orgctx.ID(ctx), userID).Scan(&isActive)
+	if err != nil {
+		_ = savepoint.Rollback(ctx)
+		if errors.Is(err, pgx.ErrNoRows) || isSerializationFailure(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to lock candidate: %w", err)
+	}
+
+	if err = savepoint.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to release savepoint: %w", err)
+	}
+
+	return isActive, nil
+}
+
 // GetAllUsers returns all users.
 func (r *UserRepository) GetAllUsers(ctx context.Context) ([]*models.User, error) {
-	query := `SELECT id, username, team_name, is_active FROM "user" ORDER BY id`
+	query := `SELECT id, username, team_name, is_active, max_active_reviews, tags, timezone, work_start, work_end, NOT is_reviewer FROM "user" WHERE org_id = $1 ORDER BY id`
 
-	rows, err := r.pool.Query(ctx, query)
+	rows, err := getReader(ctx, r.pool, r.replica).Query(ctx, query, orgctx.ID(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all users: %w", err)
 	}
@@ -89,7 +219,7 @@ func (r *UserRepository) GetAllUsers(ctx context.Context) ([]*models.User, error
 	var users []*models.User
 	for rows.Next() {
 		var user models.User
-		if err := rows.Scan(&user.Id, &user.Name, &user.TeamName, &user.IsActive); err != nil {
+		if err := rows.Scan(&user.Id, &user.Name, &user.TeamName, &user.IsActive, &user.MaxActiveReviews, &user.Tags, &user.Timezone, &user.WorkStart, &user.WorkEnd, &user.NonReviewer); err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
 		users = append(users, &user)
@@ -100,13 +230,13 @@ func (r *UserRepository) GetAllUsers(ctx context.Context) ([]*models.User, error
 
 // FindByTeamName finds all users in a team.
 func (r *UserRepository) FindByTeamName(ctx context.Context, teamName string) ([]*models.User, error) {
-	query := `SELECT id, username, team_name, is_active 
+	query := `SELECT id, username, team_name, is_active, max_active_reviews, tags, timezone, work_start, work_end, NOT is_reviewer 
 	          FROM "user" 
-	          WHERE team_name = $1
+	          WHERE org_id = $1 AND team_name = $2
 	          ORDER BY id`
 
-	executor := getTx(ctx, r.pool)
-	rows, err := executor.Query(ctx, query, teamName)
+	executor := getReader(ctx, r.pool, r.replica)
+	rows, err := executor.Query(ctx, query, orgctx.ID(ctx), teamName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find users by team: %w", err)
 	}
@@ -115,7 +245,7 @@ func (r *UserRepository) FindByTeamName(ctx context.Context, teamName string) ([
 	var users []*models.User
 	for rows.Next() {
 		var user models.User
-		if err := rows.Scan(&user.Id, &user.Name, &user.TeamName, &user.IsActive); err != nil {
+		if err := rows.Scan(&user.Id, &user.Name, &user.TeamName, &user.IsActive, &user.MaxActiveReviews, &user.Tags, &user.Timezone, &user.WorkStart, &user.WorkEnd, &user.NonReviewer); err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
 		users = append(users, &user)
@@ -124,12 +254,32 @@ func (r *UserRepository) FindByTeamName(ctx context.Context, teamName string) ([
 	return users, nil
 }
 
+// GetTeamLead gets the lead of the team, or an empty string when the team has no lead
+// or the lead is no longer a member of it.
+func (r *UserRepository) GetTeamLead(ctx context.Context, teamName string) (string, error) {
+	query := `SELECT l.id
+	          FROM team t
+	          JOIN "user" l ON l.org_id = t.org_id AND l.id = t.lead_id AND l.team_name = t.name
+	          WHERE t.org_id = $1 AND t.name = $2`
+
+	executor := getReader(ctx, r.pool, r.replica)
+	var leadID string
+	if err := executor.QueryRow(ctx, query, orgctx.ID(ctx), teamName).Scan(&leadID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get team lead: %w", err)
+	}
+
+	return leadID, nil
+}
+
 // DeactivateTeamUsers deactivates all users in a team.
 func (r *UserRepository) DeactivateTeamUsers(ctx context.Context, teamName string) (int, error) {
-	query := `UPDATE "user" SET is_active = false WHERE team_name = $1 AND is_active = true`
+	query := `UPDATE "user" SET is_active = false WHERE org_id = $1 AND team_name = $2 AND is_active = true`
 
 	executor := getTx(ctx, r.pool)
-	result, err := executor.Exec(ctx, query, teamName)
+	result, err := executor.Exec(ctx, query, orgctx.ID(ctx), teamName)
 	if err != nil {
 		return 0, fmt.Errorf("failed to deactivate team users: %w", err)
 	}
@@ -0,0 +1,151 @@
+//go:build integration
+
+package postgres
+
+import (
+	"testing"
+
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// userIDs returns the IDs of the users in order.
+func userIDs(users []*models.User) []string {
+	ids := make([]string, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.Id)
+	}
+	return ids
+}
+
+func TestUserRepository_Find(t *testing.T) {
+	f := newFixture(t)
+	maxReviews := 3
+	team := &models.Team{Members: []*models.User{
+		{Id: "u1", Name: "Alice", TeamName: "backend", IsActive: true, MaxActiveReviews: &maxReviews,
+			Tags: []string{"go", "postgres"}, Timezone: "Europe/Moscow", WorkStart: "10:00", WorkEnd: "19:00"},
+		{Id: "u2", Name: "Bob", TeamName: "backend", IsActive: false, NonReviewer: true},
+		{Id: "u3", Name: "Carol", TeamName: "backend", IsActive: true},
+		{Id: "u4", Name: "Dave", TeamName: "backend", IsActive: true},
+	}}
+	assert.NoError(t, f.teams.CreateTeam(f.ctx, team))
+	f.team("frontend", "f1")
+
+	t.Run("Success - FindByID reads all fields", func(t *testing.T) {
+		user, err := f.users.FindByID(f.ctx, "u1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, team.Members[0], user)
+	})
+
+	t.Run("Success - FindByID of missing user", func(t *testing.T) {
+		user, err := f.users.FindByID(f.ctx, "missing")
+
+		assert.NoError(t, err)
+		assert.Nil(t, user)
+	})
+
+	t.Run("Success - FindByIDs skips unknown ids", func(t *testing.T) {
+		users, err := f.users.FindByIDs(f.ctx, []string{"u2", "missing", "u1"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u1", "u2"}, userIDs(users))
+		assert.Equal(t, []string{}, users[1].Tags)
+		assert.Nil(t, users[1].MaxActiveReviews)
+		assert.True(t, users[1].NonReviewer)
+	})
+
+	t.Run("Success - GetAllUsers and FindByTeamName", func(t *testing.T) {
+		users, err := f.users.GetAllUsers(f.ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"f1", "u1", "u2", "u3", "u4"}, userIDs(users))
+
+		users, err = f.users.FindByTeamName(f.ctx, "backend")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u1", "u2", "u3", "u4"}, userIDs(users))
+	})
+
+	t.Run("Success - FindActiveCandidatesForReassignment", func(t *testing.T) {
+		users, err := f.users.FindActiveCandidatesForReassignment(f.ctx, "backend", []string{"u3"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u1", "u4"}, userIDs(users))
+
+		users, err = f.users.FindActiveCandidatesForReassignment(f.ctx, "backend", []string{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u1", "u3", "u4"}, userIDs(users))
+
+		assert.NoError(t, f.users.SetIsReviewer(f.ctx, "u4", false))
+		users, err = f.users.FindActiveCandidatesForReassignment(f.ctx, "backend", []string{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u1", "u3"}, userIDs(users), "users outside the reviewer pool are no candidates")
+	})
+}
+
+func TestUserRepository_Update(t *testing.T) {
+	f := newFixture(t)
+	f.team("backend", "u1", "u2", "u3")
+	f.team("frontend", "f1")
+
+	t.Run("Success - SetIsActive", func(t *testing.T) {
+		assert.NoError(t, f.users.SetIsActive(f.ctx, "u1", false))
+
+		user, _ := f.users.FindByID(f.ctx, "u1")
+		assert.False(t, user.IsActive)
+		assert.NoError(t, f.users.SetIsActive(f.ctx, "missing", false))
+	})
+
+	t.Run("Success - SetIsReviewer", func(t *testing.T) {
+		assert.NoError(t, f.users.SetIsReviewer(f.ctx, "u1", false))
+		user, _ := f.users.FindByID(f.ctx, "u1")
+		assert.True(t, user.NonReviewer)
+
+		assert.NoError(t, f.users.SetIsReviewer(f.ctx, "u1", true))
+		user, _ = f.users.FindByID(f.ctx, "u1")
+		assert.False(t, user.NonReviewer)
+	})
+
+	t.Run("Success - SetTags replaces and clears tags", func(t *testing.T) {
+		assert.NoError(t, f.users.SetTags(f.ctx, "u2", []string{"security"}))
+		user, _ := f.users.FindByID(f.ctx, "u2")
+		assert.Equal(t, []string{"security"}, user.Tags)
+
+		assert.NoError(t, f.users.SetTags(f.ctx, "u2", nil))
+		user, _ = f.users.FindByID(f.ctx, "u2")
+		assert.Equal(t, []string{}, user.Tags)
+	})
+
+	t.Run("Success - UpdateUsername", func(t *testing.T) {
+		assert.NoError(t, f.users.UpdateUsername(f.ctx, "u3", "Charlie"))
+
+		user, _ := f.users.FindByID(f.ctx, "u3")
+		assert.Equal(t, "Charlie", user.Name)
+		assert.Equal(t, "backend", user.TeamName)
+		assert.NoError(t, f.users.UpdateUsername(f.ctx, "missing", "Nobody"))
+	})
+
+	t.Run("Success - DeactivateTeamUsers counts only active users", func(t *testing.T) {
+		deactivated, err := f.users.DeactivateTeamUsers(f.ctx, "backend")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, deactivated)
+		frontend, _ := f.users.FindByID(f.ctx, "f1")
+		assert.True(t, frontend.IsActive)
+	})
+}
+
+func TestUserRepository_GetExcludedReviewers(t *testing.T) {
+	f := newFixture(t)
+	f.team("backend", "u1", "u2", "u3", "u4")
+	for _, exclusion := range []*models.ReviewerExclusion{
+		{ReviewerId: "u2", AuthorId: "u1"},
+		{ReviewerId: "u1", AuthorId: "u3", Mutual: true},
+		{ReviewerId: "u1", AuthorId: "u4"},
+	} {
+		assert.NoError(t, f.exclusions.Upsert(f.ctx, exclusion))
+	}
+
+	excluded, err := f.users.GetExcludedReviewers(f.ctx, "u1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u2", "u3"}, excluded)
+}
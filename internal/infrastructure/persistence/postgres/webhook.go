@@ -0,0 +1,227 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shirr9/pr-reviewer-service/internal/app/orgctx"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// WebhookRepository manages webhook deliveries and their attempts in the database.
+type WebhookRepository struct {
+	pool *pgxpool.Pool
+}
+
+// deliveryColumns are the columns scanned by scanDelivery.
+const deliveryColumns = `id, event_type, payload, status, attempts, next_attempt_at, last_error, created_at, delivered_at`
+
+// Enqueue stores a pending delivery due at its NextAttemptAt. Id and CreatedAt are filled from the database.
+func (r *WebhookRepository) Enqueue(ctx context.Context, delivery *models.WebhookDelivery) error {
+	query := `INSERT INTO webhook_delivery (event_type, payload, status, next_attempt_at, org_id)
+	          VALUES ($1, $2, 'pending', $3, $4)
+	          RETURNING id, created_at`
+
+	executor := getTx(ctx, r.pool)
+	err := executor.QueryRow(ctx, query, delivery.EventType, delivery.Payload, delivery.NextAttemptAt, orgctx.ID(ctx)).
+		Scan(&delivery.Id, &delivery.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue delivery: %w", err)
+	}
+	delivery.Status = models.DeliveryStatusPending
+	return nil
+}
+
+// FindDue returns up to limit pending deliveries due at the given moment, the longest overdue first.
+func (r *WebhookRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	query := `SELECT ` + deliveryColumns + `
+	          FROM webhook_delivery
+	          WHERE org_id = $1 AND status = 'pending' AND next_attempt_at <= $2
+	          ORDER BY next_attempt_at, id
+	          LIMIT $3`
+
+	executor := getTx(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, orgctx.ID(ctx), now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find due deliveries: %w", err)
+	}
+	return collectDeliveries(rows)
+}
+
+// FindByID returns the delivery or nil if it does not exist.
+func (r *WebhookRepository) FindByID(ctx context.Context, id int64) (*models.WebhookDelivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_delivery WHERE org_id = $1 AND id = $2`
+
+	executor := getTx(ctx, r.pool)
+	delivery, err := scanDelivery(executor.QueryRow(ctx, query, orgctx.ID(ctx), id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find delivery: %w", err)
+	}
+	return delivery, nil
+}
+
+// ListDead returns a page of dead deliveries, oldest first, and the number of all of them.
+func (r *WebhookRepository) ListDead(ctx context.Context, limit, offset int) ([]*models.WebhookDelivery, int, error) {
+	executor := getTx(ctx, r.pool)
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM webhook_delivery WHERE org_id = $1 AND status = 'dead'`
+	if err := executor.QueryRow(ctx, countQuery, orgctx.ID(ctx)).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count dead deliveries: %w", err)
+	}
+
+	query := `SELECT ` + deliveryColumns + `
+	          FROM webhook_delivery
+	          WHERE org_id = $1 AND status = 'dead'
+	          ORDER BY id
+	          LIMIT $2 OFFSET $3`
+	rows, err := executor.Query(ctx, query, orgctx.ID(ctx), limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list dead deliveries: %w", err)
+	}
+	deliveries, err := collectDeliveries(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return deliveries, total, nil
+}
+
+// FindAttempts returns the attempts of the deliveries keyed by delivery id, in the order they were made.
+func (r *WebhookRepository) FindAttempts(ctx context.Context, deliveryIDs []int64) (map[int64][]*models.WebhookAttempt, error) {
+	attempts := make(map[int64][]*models.WebhookAttempt, len(deliveryIDs))
+	if len(deliveryIDs) == 0 {
+		return attempts, nil
+	}
+
+	query := `SELECT delivery_id, attempt, attempted_at, error
+	          FROM webhook_delivery_attempt
+	          WHERE org_id = $1 AND delivery_id = ANY($2)
+	          ORDER BY delivery_id, id`
+
+	executor := getTx(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, orgctx.ID(ctx), deliveryIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find delivery attempts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var attempt models.WebhookAttempt
+		if err = rows.Scan(&attempt.DeliveryId, &attempt.Attempt, &attempt.AttemptedAt, &attempt.Error); err != nil {
+			return nil, fmt.Errorf("failed to scan delivery attempt: %w", err)
+		}
+		attempts[attempt.DeliveryId] = append(attempts[attempt.DeliveryId], &attempt)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// SaveAttempt records the attempt and stores the state of the delivery after it.
+// It must run inside a transaction, or a failure may keep the attempt without the new state.
+func (r *WebhookRepository) SaveAttempt(ctx context.Context, delivery *models.WebhookDelivery,
+	attempt *models.WebhookAttempt) error {
+	executor := getTx(ctx, r.pool)
+
+	attemptQuery := `INSERT INTO webhook_delivery_attempt (delivery_id, attempt, attempted_at, error, org_id)
+	                 VALUES ($1, $2, $3, $4, $5)`
+	_, err := executor.Exec(ctx, attemptQuery, attempt.DeliveryId, attempt.Attempt, attempt.AttemptedAt, attempt.Error,
+		orgctx.ID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to record delivery attempt: %w", err)
+	}
+
+	deliveryQuery := `UPDATE webhook_delivery
+	                  SET status = $2, attempts = $3, next_attempt_at = $4, last_error = $5, delivered_at = $6
+	                  WHERE id = $1 AND org_id = $7`
+	_, err = executor.Exec(ctx, deliveryQuery, delivery.Id, delivery.Status, delivery.Attempts,
+		delivery.NextAttemptAt, delivery.LastError, delivery.DeliveredAt, orgctx.ID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to update delivery: %w", err)
+	}
+
+	return nil
+}
+
+// Requeue makes a dead delivery pending again, due at the given moment, with its attempt count
+// restarted. Reports false if the delivery does not exist or is not dead.
+func (r *WebhookRepository) Requeue(ctx context.Context, id int64, at time.Time) (bool, error) {
+	query := `UPDATE webhook_delivery
+	          SET status = 'pending', attempts = 0, next_attempt_at = $2
+	          WHERE org_id = $1 AND id = $2 AND status = 'dead'`
+
+	executor := getTx(ctx, r.pool)
+	tag, err := executor.Exec(ctx, query, orgctx.ID(ctx), id, at)
+	if err != nil {
+		return false, fmt.Errorf("failed to requeue delivery: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// Stats counts the deliveries by status and all attempts made.
+func (r *WebhookRepository) Stats(ctx context.Context) (*models.WebhookDeliveryStats, error) {
+	query := `SELECT
+	              (SELECT COUNT(*) FROM webhook_delivery WHERE org_id = $1 AND status = 'pending'),
+	              (SELECT COUNT(*) FROM webhook_delivery WHERE org_id = $1 AND status = 'delivered'),
+	              (SELECT COUNT(*) FROM webhook_delivery WHERE org_id = $1 AND status = 'dead'),
+	              (SELECT COUNT(*) FROM webhook_delivery_attempt WHERE org_id = $1),
+	              (SELECT COUNT(*) FROM webhook_delivery_attempt WHERE org_id = $1 AND error <> '')`
+
+	var stats models.WebhookDeliveryStats
+	executor := getTx(ctx, r.pool)
+	err := executor.QueryRow(ctx, query, orgctx.ID(ctx)).
+		Scan(&stats.Pending, &stats.Delivered, &stats.Dead, &stats.Attempts, &stats.FailedAttempts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count deliveries: %w", err)
+	}
+	return &stats, nil
+}
+
+// CountPending counts the pending deliveries of all organizations.
+func (r *WebhookRepository) CountPending(ctx context.Context) (int, error) {
+	query := `SELECT COUNT(*) FROM webhook_delivery WHERE status = 'pending'`
+
+	var pending int
+	if err := getTx(ctx, r.pool).QueryRow(ctx, query).Scan(&pending); err != nil {
+		return 0, fmt.Errorf("failed to count pending deliveries: %w", err)
+	}
+	return pending, nil
+}
+
+// scanDelivery scans a row of deliveryColumns.
+func scanDelivery(row pgx.Row) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := row.Scan(&delivery.Id, &delivery.EventType, &delivery.Payload, &delivery.Status, &delivery.Attempts,
+		&delivery.NextAttemptAt, &delivery.LastError, &delivery.CreatedAt, &delivery.DeliveredAt)
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// collectDeliveries scans and closes rows of deliveryColumns.
+func collectDeliveries(rows pgx.Rows) ([]*models.WebhookDelivery, error) {
+	defer rows.Close()
+
+	var deliveries []*models.WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return deliveries, nil
+}
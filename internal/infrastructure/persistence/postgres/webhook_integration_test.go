@@ -0,0 +1,130 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookRepository(t *testing.T) {
+	f := newFixture(t)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	enqueue := func(dueAt time.Time) *models.WebhookDelivery {
+		t.Helper()
+		delivery := &models.WebhookDelivery{
+			EventType: "review.escalated", Payload: []byte(`{"type":"review.escalated"}`), NextAttemptAt: dueAt,
+		}
+		if err := f.webhooks.Enqueue(f.ctx, delivery); err != nil {
+			t.Fatalf("failed to enqueue delivery: %v", err)
+		}
+		return delivery
+	}
+	// fail records a failed attempt, dead-lettering the delivery when dead is set.
+	fail := func(delivery *models.WebhookDelivery, dead bool) {
+		t.Helper()
+		delivery.Attempts++
+		delivery.LastError = "webhook responded with status 503"
+		if dead {
+			delivery.Status = models.DeliveryStatusDead
+		}
+		err := f.uow.WithinTransaction(f.ctx, func(txCtx context.Context) error {
+			return f.webhooks.SaveAttempt(txCtx, delivery, &models.WebhookAttempt{
+				DeliveryId: delivery.Id, Attempt: delivery.Attempts, AttemptedAt: now, Error: delivery.LastError,
+			})
+		})
+		if err != nil {
+			t.Fatalf("failed to save attempt: %v", err)
+		}
+	}
+
+	t.Run("Success - Enqueue fills id and created_at, FindDue skips future deliveries", func(t *testing.T) {
+		due := enqueue(now.Add(-time.Minute))
+		later := enqueue(now.Add(time.Hour))
+		assert.NotZero(t, due.Id)
+		assert.False(t, due.CreatedAt.IsZero())
+		assert.Equal(t, models.DeliveryStatusPending, due.Status)
+
+		found, err := f.webhooks.FindDue(f.ctx, now, 10)
+		assert.NoError(t, err)
+		assert.Len(t, found, 1)
+		assert.Equal(t, due.Id, found[0].Id)
+		assert.JSONEq(t, `{"type":"review.escalated"}`, string(found[0].Payload))
+
+		fail(later, true)
+		fail(due, true)
+	})
+
+	t.Run("Success - SaveAttempt stores the state and the history", func(t *testing.T) {
+		delivery := enqueue(now)
+		fail(delivery, false)
+
+		deliveredAt := now.Add(time.Second)
+		delivery.Attempts++
+		delivery.Status = models.DeliveryStatusDelivered
+		delivery.DeliveredAt = &deliveredAt
+		err := f.webhooks.SaveAttempt(f.ctx, delivery, &models.WebhookAttempt{
+			DeliveryId: delivery.Id, Attempt: delivery.Attempts, AttemptedAt: deliveredAt,
+		})
+		assert.NoError(t, err)
+
+		stored, err := f.webhooks.FindByID(f.ctx, delivery.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, models.DeliveryStatusDelivered, stored.Status)
+		assert.Equal(t, 2, stored.Attempts)
+		assert.True(t, deliveredAt.Equal(*stored.DeliveredAt))
+
+		attempts, err := f.webhooks.FindAttempts(f.ctx, []int64{delivery.Id})
+		assert.NoError(t, err)
+		assert.Len(t, attempts[delivery.Id], 2)
+		assert.NotEmpty(t, attempts[delivery.Id][0].Error)
+		assert.Empty(t, attempts[delivery.Id][1].Error)
+	})
+
+	t.Run("Success - ListDead pages dead deliveries oldest first", func(t *testing.T) {
+		dead, total, err := f.webhooks.ListDead(f.ctx, 1, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.Len(t, dead, 1)
+		assert.Equal(t, models.DeliveryStatusDead, dead[0].Status)
+	})
+
+	t.Run("Success - Requeue only moves dead deliveries", func(t *testing.T) {
+		dead, _, err := f.webhooks.ListDead(f.ctx, 1, 0)
+		assert.NoError(t, err)
+
+		requeued, err := f.webhooks.Requeue(f.ctx, dead[0].Id, now)
+		assert.NoError(t, err)
+		assert.True(t, requeued)
+		stored, err := f.webhooks.FindByID(f.ctx, dead[0].Id)
+		assert.NoError(t, err)
+		assert.Equal(t, models.DeliveryStatusPending, stored.Status)
+		assert.Zero(t, stored.Attempts)
+
+		requeued, err = f.webhooks.Requeue(f.ctx, dead[0].Id, now)
+		assert.NoError(t, err)
+		assert.False(t, requeued)
+	})
+
+	t.Run("Success - Stats counts statuses and attempts", func(t *testing.T) {
+		stats, err := f.webhooks.Stats(f.ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.WebhookDeliveryStats{
+			Pending: 1, Delivered: 1, Dead: 1, Attempts: 4, FailedAttempts: 3,
+		}, *stats)
+	})
+
+	t.Run("Success - Unknown delivery", func(t *testing.T) {
+		delivery, err := f.webhooks.FindByID(f.ctx, -1)
+
+		assert.NoError(t, err)
+		assert.Nil(t, delivery)
+	})
+}
@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Client posts events as JSON to a webhook URL.
+type Client struct {
+	url  string
+	http *http.Client
+}
+
+// NewClient creates a webhook client; every delivery is bounded by the timeout.
+func NewClient(url string, timeout time.Duration) *Client {
+	return &Client{
+		url:  url,
+		http: &http.Client{Timeout: timeout},
+	}
+}
+
+// Send posts the event. Any status other than 2xx is an error.
+func (c *Client) Send(ctx context.Context, event any) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
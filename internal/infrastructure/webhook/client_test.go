@@ -0,0 +1,41 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Send(t *testing.T) {
+	t.Run("Success - Posts the event as JSON", func(t *testing.T) {
+		var received map[string]string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		err := NewClient(server.URL, time.Second).Send(context.Background(), map[string]string{"type": "review.escalated"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"type": "review.escalated"}, received)
+	})
+
+	t.Run("Error - Non-2xx status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		err := NewClient(server.URL, time.Second).Send(context.Background(), map[string]string{})
+
+		assert.ErrorContains(t, err, "502")
+	})
+}
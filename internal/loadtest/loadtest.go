@@ -0,0 +1,359 @@
+// Package loadtest generates load against the HTTP API and reports latencies and errors per operation.
+package loadtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/seed"
+)
+
+// Operation names used in the mix and in the results.
+const (
+	OpCreatePR   = "create_pr"
+	OpGetReview  = "get_review"
+	OpStatistics = "statistics"
+	OpGetTeam    = "get_team"
+	OpMergePR    = "merge_pr"
+)
+
+// DefaultMix runs all operations equally often.
+const DefaultMix = OpCreatePR + "=1," + OpGetReview + "=1," + OpStatistics + "=1," +
+	OpGetTeam + "=1," + OpMergePR + "=1"
+
+// requestTimeout is the timeout of the default HTTP client.
+const requestTimeout = 5 * time.Second
+
+// Config contains load test parameters.
+// Workers spread over Targets round-robin; Mix maps operation names to weights.
+// RPS caps the overall request rate, zero means unlimited with a random 10-100ms pause
+// between requests of a worker. Client defaults to an http.Client with a 5s timeout.
+// SkipSetup leaves the teams to an earlier `app seed` run with the same teams and users per team.
+type Config struct {
+	Targets      []string       `json:"targets"`
+	Concurrency  int            `json:"concurrency"`
+	Duration     time.Duration  `json:"-"`
+	Teams        int            `json:"teams"`
+	UsersPerTeam int            `json:"users_per_team"`
+	Mix          map[string]int `json:"mix"`
+	Seed         int64          `json:"seed"`
+	RPS          int            `json:"rps"`
+	SkipSetup    bool           `json:"skip_setup"`
+	Client       *http.Client   `json:"-"`
+}
+
+// validate checks the config.
+func (c Config) validate() error {
+	if len(c.Targets) == 0 {
+		return errors.New("at least one target is required")
+	}
+	if c.Concurrency <= 0 || c.Teams <= 0 || c.UsersPerTeam <= 0 {
+		return errors.New("concurrency, teams and users per team must be positive")
+	}
+	if c.Duration <= 0 {
+		return errors.New("duration must be positive")
+	}
+	if c.RPS < 0 {
+		return errors.New("rps must not be negative")
+	}
+	total := 0
+	for name, weight := range c.Mix {
+		if !knownOps[name] {
+			return fmt.Errorf("unknown operation %q", name)
+		}
+		if weight < 0 {
+			return fmt.Errorf("weight of %q must not be negative", name)
+		}
+		total += weight
+	}
+	if total == 0 {
+		return errors.New("at least one operation must have a positive weight")
+	}
+	return nil
+}
+
+var knownOps = map[string]bool{
+	OpCreatePR: true, OpGetReview: true, OpStatistics: true, OpGetTeam: true, OpMergePR: true,
+}
+
+// ParseMix parses "name=weight,name=weight" into a weight map.
+func ParseMix(mix string) (map[string]int, error) {
+	weights := make(map[string]int)
+	total := 0
+	for _, part := range strings.Split(mix, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("%q is not a name=weight pair", part)
+		}
+		if !knownOps[name] {
+			return nil, fmt.Errorf("unknown operation %q", name)
+		}
+		w, err := strconv.Atoi(value)
+		if err != nil || w < 0 {
+			return nil, fmt.Errorf("weight of %q must be a non-negative integer", name)
+		}
+		weights[name] = w
+		total += w
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("at least one operation must have a positive weight")
+	}
+	return weights, nil
+}
+
+// Runner runs a load test described by its config.
+type Runner struct {
+	cfg    Config
+	client *http.Client
+}
+
+// NewRunner creates a runner for the config.
+func NewRunner(cfg Config) (*Runner, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	client := cfg.Client
+	if client == nil {
+		client = &http.Client{Timeout: requestTimeout}
+	}
+	return &Runner{cfg: cfg, client: client}, nil
+}
+
+// Run creates the teams on the first target unless SkipSetup is set and sends requests until the
+// duration elapses or ctx is done. It fails only if the test data can't be created.
+func (r *Runner) Run(ctx context.Context) (*Results, error) {
+	if !r.cfg.SkipSetup {
+		if err := r.setup(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.Duration)
+	defer cancel()
+
+	stats := newStats()
+	ops := weightedOps(r.cfg.Mix)
+
+	var throttle <-chan time.Time
+	if r.cfg.RPS > 0 {
+		ticker := time.NewTicker(time.Second / time.Duration(r.cfg.RPS))
+		defer ticker.Stop()
+		throttle = ticker.C
+	}
+
+	var wg sync.WaitGroup
+	startedAt := time.Now()
+	for i := 0; i < r.cfg.Concurrency; i++ {
+		wg.Add(1)
+		go func(workerID int) {
+			defer wg.Done()
+			w := &worker{
+				cfg:     &r.cfg,
+				baseURL: r.cfg.Targets[workerID%len(r.cfg.Targets)],
+				rng:     rand.New(rand.NewSource(r.cfg.Seed + int64(workerID))),
+				stats:   stats,
+				client:  r.client,
+			}
+			w.run(ctx, ops, throttle)
+		}(i)
+	}
+	wg.Wait()
+
+	return stats.results(r.cfg, startedAt, time.Since(startedAt)), nil
+}
+
+// setup creates the sample teams of package seed, whose members the operations use, over the API;
+// existing teams are kept.
+func (r *Runner) setup(ctx context.Context) error {
+	for _, team := range seed.Teams(seed.Config{Teams: r.cfg.Teams, UsersPerTeam: r.cfg.UsersPerTeam}) {
+		members := make([]map[string]any, 0, len(team.Members))
+		for _, member := range team.Members {
+			members = append(members, map[string]any{
+				"user_id":   member.Id,
+				"username":  member.Name,
+				"is_active": member.IsActive,
+			})
+		}
+		payload := map[string]any{
+			"team_name": team.GetTeamName(),
+			"members":   members,
+		}
+
+		resp, err := doRequest(ctx, r.client, r.cfg.Targets[0], http.MethodPost, "/team/add", payload)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", team.GetTeamName(), err)
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusConflict {
+			return fmt.Errorf("failed to create %s: status %d", team.GetTeamName(), resp.StatusCode)
+		}
+	}
+	return nil
+}
+
+// weightedOps expands weights into a slice drawn from uniformly.
+func weightedOps(weights map[string]int) []string {
+	names := make([]string, 0, len(weights))
+	for name := range weights {
+		names = append(names, name)
+	}
+	// stable order keeps runs with the same seed reproducible
+	sort.Strings(names)
+
+	var ops []string
+	for _, name := range names {
+		for i := 0; i < weights[name]; i++ {
+			ops = append(ops, name)
+		}
+	}
+	return ops
+}
+
+// worker holds per-goroutine state; math/rand sources are not safe for concurrent use.
+type worker struct {
+	cfg     *Config
+	baseURL string
+	rng     *rand.Rand
+	stats   *stats
+	client  *http.Client
+}
+
+func (w *worker) run(ctx context.Context, ops []string, throttle <-chan time.Time) {
+	for {
+		if throttle != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-throttle:
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		switch ops[w.rng.Intn(len(ops))] {
+		case OpCreatePR:
+			w.createPR(ctx)
+		case OpGetReview:
+			w.getUserReviews(ctx)
+		case OpStatistics:
+			w.getStatistics(ctx)
+		case OpGetTeam:
+			w.getTeam(ctx)
+		case OpMergePR:
+			w.mergePR(ctx)
+		}
+
+		if throttle == nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Millisecond * time.Duration(10+w.rng.Intn(90))):
+			}
+		}
+	}
+}
+
+func (w *worker) randomUserID() string {
+	return seed.UserID(w.rng.Intn(w.cfg.Teams)+1, w.rng.Intn(w.cfg.UsersPerTeam)+1)
+}
+
+func (w *worker) createPR(ctx context.Context) {
+	prID := fmt.Sprintf("pr-%d-%d", time.Now().Unix(), w.rng.Intn(10000))
+	payload := map[string]any{
+		"pull_request_id":   prID,
+		"pull_request_name": fmt.Sprintf("Feature %s", prID),
+		"author_id":         w.randomUserID(),
+	}
+	w.do(ctx, OpCreatePR, http.MethodPost, "/pullRequest/create", payload, http.StatusCreated, http.StatusConflict)
+}
+
+func (w *worker) getUserReviews(ctx context.Context) {
+	path := fmt.Sprintf("/users/getReview?user_id=%s", w.randomUserID())
+	w.do(ctx, OpGetReview, http.MethodGet, path, nil, http.StatusOK)
+}
+
+func (w *worker) getStatistics(ctx context.Context) {
+	w.do(ctx, OpStatistics, http.MethodGet, "/statistics", nil, http.StatusOK)
+}
+
+func (w *worker) getTeam(ctx context.Context) {
+	path := "/team/get?team_name=" + seed.TeamName(w.rng.Intn(w.cfg.Teams)+1)
+	w.do(ctx, OpGetTeam, http.MethodGet, path, nil, http.StatusOK)
+}
+
+func (w *worker) mergePR(ctx context.Context) {
+	prID := fmt.Sprintf("pr-merge-%d-%d", time.Now().Unix(), w.rng.Intn(1000))
+	payload := map[string]any{
+		"pull_request_id": prID,
+	}
+	w.do(ctx, OpMergePR, http.MethodPost, "/pullRequest/merge", payload, http.StatusOK, http.StatusNotFound)
+}
+
+// do performs the request and records its latency and outcome.
+// Requests cut off by the end of the run are not recorded.
+func (w *worker) do(ctx context.Context, op, method, path string, payload any, okStatuses ...int) {
+	start := time.Now()
+	resp, err := doRequest(ctx, w.client, w.baseURL, method, path, payload)
+	latency := time.Since(start)
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		w.stats.record(op, latency, false, &ErrorSample{Error: err.Error()})
+		return
+	}
+	defer resp.Body.Close()
+
+	for _, status := range okStatuses {
+		if resp.StatusCode == status {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			w.stats.record(op, latency, true, nil)
+			return
+		}
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	w.stats.record(op, latency, false, &ErrorSample{
+		StatusCode: resp.StatusCode,
+		Error:      string(bytes.TrimSpace(body)),
+	})
+}
+
+func doRequest(ctx context.Context, client *http.Client, baseURL, method, path string,
+	payload any) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		jsonData, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(jsonData)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return client.Do(req)
+}
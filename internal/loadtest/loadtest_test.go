@@ -0,0 +1,212 @@
+package loadtest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testConfig(target string, mix map[string]int) Config {
+	return Config{
+		Targets:      []string{target},
+		Concurrency:  2,
+		Duration:     200 * time.Millisecond,
+		Teams:        2,
+		UsersPerTeam: 2,
+		Mix:          mix,
+		Seed:         1,
+		RPS:          100,
+	}
+}
+
+func TestParseMix(t *testing.T) {
+	t.Run("Success - Parses weights", func(t *testing.T) {
+		mix, err := ParseMix("create_pr=2, statistics=0,get_team=1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int{OpCreatePR: 2, OpStatistics: 0, OpGetTeam: 1}, mix)
+	})
+
+	tests := []struct {
+		name string
+		mix  string
+		want string
+	}{
+		{"Error - Not a pair", "create_pr", "not a name=weight pair"},
+		{"Error - Unknown operation", "delete_team=1", "unknown operation"},
+		{"Error - Negative weight", "create_pr=-1", "non-negative integer"},
+		{"Error - All weights zero", "create_pr=0", "positive weight"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseMix(tt.mix)
+
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestNewRunner(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		want   string
+	}{
+		{"Error - No targets", func(c *Config) { c.Targets = nil }, "target"},
+		{"Error - No workers", func(c *Config) { c.Concurrency = 0 }, "must be positive"},
+		{"Error - No duration", func(c *Config) { c.Duration = 0 }, "duration"},
+		{"Error - Negative rps", func(c *Config) { c.RPS = -1 }, "rps"},
+		{"Error - Unknown operation", func(c *Config) { c.Mix = map[string]int{"drop": 1} }, "unknown operation"},
+		{"Error - Empty mix", func(c *Config) { c.Mix = nil }, "positive weight"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig("http://localhost", map[string]int{OpGetTeam: 1})
+			tt.modify(&cfg)
+
+			_, err := NewRunner(cfg)
+
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestRunner_Run(t *testing.T) {
+	t.Run("Success - Collects results per operation", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/team/add":
+				w.WriteHeader(http.StatusCreated)
+			case "/statistics":
+				w.WriteHeader(http.StatusServiceUnavailable)
+			default:
+				w.WriteHeader(http.StatusOK)
+			}
+		}))
+		defer server.Close()
+
+		runner, err := NewRunner(testConfig(server.URL, map[string]int{OpStatistics: 1, OpGetTeam: 1}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		results, err := runner.Run(context.Background())
+		if err != nil {
+			t.Fatalf("run failed: %v", err)
+		}
+		stats, team := results.Operations[OpStatistics], results.Operations[OpGetTeam]
+		assert.Positive(t, stats.Failed)
+		assert.Zero(t, stats.Success)
+		assert.Equal(t, map[int]int{http.StatusServiceUnavailable: stats.Failed}, stats.Errors)
+		assert.NotEmpty(t, stats.ErrorSamples)
+		assert.Positive(t, team.Success)
+		assert.Zero(t, team.Failed)
+		assert.Equal(t, stats.Requests+team.Requests, results.Requests)
+		assert.Positive(t, results.Latency.P95)
+		assert.LessOrEqual(t, results.Latency.P50, results.Latency.P95)
+	})
+
+	t.Run("Success - Teams are not created with SkipSetup", func(t *testing.T) {
+		var created atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/team/add" {
+				created.Add(1)
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		cfg := testConfig(server.URL, map[string]int{OpGetTeam: 1})
+		cfg.SkipSetup = true
+		runner, err := NewRunner(cfg)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		results, err := runner.Run(context.Background())
+		if err != nil {
+			t.Fatalf("run failed: %v", err)
+		}
+		assert.Zero(t, created.Load())
+		assert.Positive(t, results.Operations[OpGetTeam].Success)
+	})
+
+	t.Run("Success - Writes the JSON report", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/team/add" {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		runner, err := NewRunner(testConfig(server.URL, map[string]int{OpGetTeam: 1}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		results, err := runner.Run(context.Background())
+		if err != nil {
+			t.Fatalf("run failed: %v", err)
+		}
+
+		path := filepath.Join(t.TempDir(), "report.json")
+		if err = results.WriteReport(path); err != nil {
+			t.Fatalf("failed to write report: %v", err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read report: %v", err)
+		}
+		var report struct {
+			Config struct {
+				Duration string `json:"duration"`
+			} `json:"config"`
+			Operations map[string]struct {
+				Failed  int            `json:"failed"`
+				Errors  map[string]int `json:"errors"`
+				Latency struct {
+					Histogram []struct {
+						UpperBound string `json:"le"`
+					} `json:"histogram"`
+				} `json:"latency"`
+			} `json:"operations"`
+		}
+		if err = json.Unmarshal(data, &report); err != nil {
+			t.Fatalf("failed to decode report: %v", err)
+		}
+		assert.Equal(t, "200ms", report.Config.Duration)
+		op := report.Operations[OpGetTeam]
+		assert.Equal(t, map[string]int{"500": op.Failed}, op.Errors)
+		histogram := op.Latency.Histogram
+		if !assert.NotEmpty(t, histogram) {
+			return
+		}
+		assert.Equal(t, "+Inf", histogram[len(histogram)-1].UpperBound)
+	})
+
+	t.Run("Error - Test data can't be created", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		runner, err := NewRunner(testConfig(server.URL, map[string]int{OpGetTeam: 1}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_, err = runner.Run(context.Background())
+
+		assert.ErrorContains(t, err, "failed to create team-1")
+	})
+}
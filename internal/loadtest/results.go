@@ -0,0 +1,353 @@
+package loadtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxErrorSamples limits how many failed responses are kept per operation.
+const maxErrorSamples = 10
+
+// histogramBounds are the upper bounds of latency histogram buckets.
+var histogramBounds = []time.Duration{
+	time.Millisecond,
+	2 * time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+}
+
+// ErrorSample describes one failed request; StatusCode is zero when no response was received.
+type ErrorSample struct {
+	StatusCode int    `json:"status_code,omitempty"`
+	Error      string `json:"error"`
+}
+
+// HistogramBucket counts latencies up to UpperBound; the last bucket has a zero UpperBound and means +Inf.
+type HistogramBucket struct {
+	UpperBound time.Duration
+	Count      int
+}
+
+// Latency describes a set of latencies.
+type Latency struct {
+	Min       time.Duration
+	Max       time.Duration
+	Avg       time.Duration
+	P50       time.Duration
+	P95       time.Duration
+	P99       time.Duration
+	Histogram []HistogramBucket
+}
+
+// OperationResults contains the results of one operation.
+// Errors counts failed requests by status code, with 0 for requests that got no response.
+type OperationResults struct {
+	Requests     int
+	Success      int
+	Failed       int
+	Latency      Latency
+	Errors       map[int]int
+	ErrorSamples []ErrorSample
+}
+
+// Results is the outcome of a load test run.
+type Results struct {
+	Config     Config
+	StartedAt  time.Time
+	Elapsed    time.Duration
+	Requests   int
+	Success    int
+	Failed     int
+	RPS        float64
+	Latency    Latency
+	Operations map[string]OperationResults
+}
+
+// opStats accumulates results of one operation.
+type opStats struct {
+	success   int
+	failed    int
+	latencies []time.Duration
+	errors    map[int]int
+	samples   []ErrorSample
+}
+
+// stats collects results of all operations.
+type stats struct {
+	mu  sync.Mutex
+	ops map[string]*opStats
+}
+
+func newStats() *stats {
+	return &stats{ops: make(map[string]*opStats)}
+}
+
+func (s *stats) record(op string, latency time.Duration, success bool, sample *ErrorSample) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, ok := s.ops[op]
+	if !ok {
+		st = &opStats{errors: make(map[int]int)}
+		s.ops[op] = st
+	}
+
+	st.latencies = append(st.latencies, latency)
+	if success {
+		st.success++
+		return
+	}
+	st.failed++
+	if sample != nil {
+		st.errors[sample.StatusCode]++
+		if len(st.samples) < maxErrorSamples {
+			st.samples = append(st.samples, *sample)
+		}
+	}
+}
+
+// results aggregates the collected stats.
+func (s *stats) results(cfg Config, startedAt time.Time, elapsed time.Duration) *Results {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results := &Results{
+		Config:     cfg,
+		StartedAt:  startedAt,
+		Elapsed:    elapsed,
+		Operations: make(map[string]OperationResults, len(s.ops)),
+	}
+
+	var all []time.Duration
+	for name, st := range s.ops {
+		results.Operations[name] = OperationResults{
+			Requests:     st.success + st.failed,
+			Success:      st.success,
+			Failed:       st.failed,
+			Latency:      summarize(st.latencies),
+			Errors:       st.errors,
+			ErrorSamples: st.samples,
+		}
+		results.Success += st.success
+		results.Failed += st.failed
+		all = append(all, st.latencies...)
+	}
+
+	results.Requests = results.Success + results.Failed
+	results.Latency = summarize(all)
+	if elapsed > 0 {
+		results.RPS = float64(results.Requests) / elapsed.Seconds()
+	}
+	return results
+}
+
+// summarize computes latency summary of the given latencies.
+func summarize(latencies []time.Duration) Latency {
+	if len(latencies) == 0 {
+		return Latency{Histogram: histogram(nil)}
+	}
+
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, l := range sorted {
+		total += l
+	}
+
+	return Latency{
+		Min:       sorted[0],
+		Max:       sorted[len(sorted)-1],
+		Avg:       total / time.Duration(len(sorted)),
+		P50:       percentile(sorted, 0.50),
+		P95:       percentile(sorted, 0.95),
+		P99:       percentile(sorted, 0.99),
+		Histogram: histogram(sorted),
+	}
+}
+
+// percentile returns the p-th percentile of sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(float64(len(sorted)) * p)
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
+
+// histogram distributes sorted latencies into histogramBounds buckets.
+func histogram(sorted []time.Duration) []HistogramBucket {
+	buckets := make([]HistogramBucket, 0, len(histogramBounds)+1)
+	i := 0
+	for _, bound := range histogramBounds {
+		count := 0
+		for i < len(sorted) && sorted[i] <= bound {
+			count++
+			i++
+		}
+		buckets = append(buckets, HistogramBucket{UpperBound: bound, Count: count})
+	}
+	return append(buckets, HistogramBucket{Count: len(sorted) - i})
+}
+
+// PrintSummary writes a human-readable summary of the results.
+func (r *Results) PrintSummary(w io.Writer) {
+	if r.Requests == 0 {
+		fmt.Fprintln(w, "No requests recorded")
+		return
+	}
+
+	successRate := float64(r.Success) / float64(r.Requests) * 100
+
+	fmt.Fprintln(w, "\n=== Load Test Results ===")
+	fmt.Fprintf(w, "Total Requests:    %d\n", r.Requests)
+	fmt.Fprintf(w, "Success:           %d (%.2f%%)\n", r.Success, successRate)
+	fmt.Fprintf(w, "Failed:            %d\n", r.Failed)
+	fmt.Fprintf(w, "Throughput:        %.2f req/s\n", r.RPS)
+	fmt.Fprintf(w, "Min Latency:       %s\n", r.Latency.Min)
+	fmt.Fprintf(w, "Max Latency:       %s\n", r.Latency.Max)
+	fmt.Fprintf(w, "Avg Latency:       %s\n", r.Latency.Avg)
+	fmt.Fprintf(w, "P95 Latency:       %s\n", r.Latency.P95)
+	fmt.Fprintf(w, "P99 Latency:       %s\n", r.Latency.P99)
+
+	names := make([]string, 0, len(r.Operations))
+	for name := range r.Operations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "\n--- Per operation ---")
+	for _, name := range names {
+		op := r.Operations[name]
+		fmt.Fprintf(w, "%-12s requests=%-6d failed=%-6d p95=%s\n", name, op.Requests, op.Failed, op.Latency.P95)
+	}
+	fmt.Fprintln(w, "========================")
+}
+
+// WriteReport writes the results to path as an indented JSON report with durations as strings.
+func (r *Results) WriteReport(path string) error {
+	data, err := json.MarshalIndent(r.report(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	if err = os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// report is the JSON form of Results.
+type report struct {
+	Config     reportConfig               `json:"config"`
+	StartedAt  string                     `json:"started_at"`
+	Elapsed    string                     `json:"elapsed"`
+	Requests   int                        `json:"requests"`
+	Success    int                        `json:"success"`
+	Failed     int                        `json:"failed"`
+	RPS        float64                    `json:"rps"`
+	Latency    reportLatency              `json:"latency"`
+	Operations map[string]reportOperation `json:"operations"`
+}
+
+type reportConfig struct {
+	Config
+	Duration string `json:"duration"`
+}
+
+type reportOperation struct {
+	Requests     int            `json:"requests"`
+	Success      int            `json:"success"`
+	Failed       int            `json:"failed"`
+	Latency      reportLatency  `json:"latency"`
+	Errors       map[string]int `json:"errors,omitempty"`
+	ErrorSamples []ErrorSample  `json:"error_samples,omitempty"`
+}
+
+type reportLatency struct {
+	Min       string         `json:"min"`
+	Max       string         `json:"max"`
+	Avg       string         `json:"avg"`
+	P50       string         `json:"p50"`
+	P95       string         `json:"p95"`
+	P99       string         `json:"p99"`
+	Histogram []reportBucket `json:"histogram"`
+}
+
+type reportBucket struct {
+	UpperBound string `json:"le"`
+	Count      int    `json:"count"`
+}
+
+func (r *Results) report() report {
+	rep := report{
+		Config:     reportConfig{Config: r.Config, Duration: r.Config.Duration.String()},
+		StartedAt:  r.StartedAt.UTC().Format(time.RFC3339),
+		Elapsed:    r.Elapsed.String(),
+		Requests:   r.Requests,
+		Success:    r.Success,
+		Failed:     r.Failed,
+		RPS:        r.RPS,
+		Latency:    newReportLatency(r.Latency),
+		Operations: make(map[string]reportOperation, len(r.Operations)),
+	}
+	for name, op := range r.Operations {
+		var errs map[string]int
+		if len(op.Errors) > 0 {
+			errs = make(map[string]int, len(op.Errors))
+			for status, count := range op.Errors {
+				key := "no_response"
+				if status != 0 {
+					key = strconv.Itoa(status)
+				}
+				errs[key] = count
+			}
+		}
+		rep.Operations[name] = reportOperation{
+			Requests:     op.Requests,
+			Success:      op.Success,
+			Failed:       op.Failed,
+			Latency:      newReportLatency(op.Latency),
+			Errors:       errs,
+			ErrorSamples: op.ErrorSamples,
+		}
+	}
+	return rep
+}
+
+func newReportLatency(l Latency) reportLatency {
+	buckets := make([]reportBucket, 0, len(l.Histogram))
+	for _, b := range l.Histogram {
+		bound := "+Inf"
+		if b.UpperBound != 0 {
+			bound = b.UpperBound.String()
+		}
+		buckets = append(buckets, reportBucket{UpperBound: bound, Count: b.Count})
+	}
+	return reportLatency{
+		Min:       l.Min.String(),
+		Max:       l.Max.String(),
+		Avg:       l.Avg.String(),
+		P50:       l.P50.String(),
+		P95:       l.P95.String(),
+		P99:       l.P99.String(),
+		Histogram: buckets,
+	}
+}
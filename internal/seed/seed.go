@@ -0,0 +1,230 @@
+// Package seed writes deterministic sample teams, users and pull requests for local development.
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+)
+
+// Config describes the sample data. The same config always yields the same data.
+type Config struct {
+	Teams        int
+	UsersPerTeam int
+	PRs          int
+	Seed         int64
+}
+
+// Validate checks the config.
+func (c Config) Validate() error {
+	if c.Teams <= 0 || c.UsersPerTeam <= 0 {
+		return errors.New("teams and users per team must be positive")
+	}
+	if c.PRs < 0 {
+		return errors.New("prs must not be negative")
+	}
+	return nil
+}
+
+// TeamName returns the name of the team-th sample team, counting from 1.
+func TeamName(team int) string {
+	return fmt.Sprintf("team-%d", team)
+}
+
+// UserID returns the id of the member-th member of the team-th sample team, counting from 1.
+func UserID(team, member int) string {
+	return fmt.Sprintf("user-%d-%d", team, member)
+}
+
+// PRID returns the id of the n-th sample pull request, counting from 1.
+func PRID(n int) string {
+	return fmt.Sprintf("pr-seed-%d", n)
+}
+
+// Teams returns the sample teams with their active members.
+func Teams(cfg Config) []*models.Team {
+	teams := make([]*models.Team, 0, cfg.Teams)
+	for i := 1; i <= cfg.Teams; i++ {
+		members := make([]*models.User, 0, cfg.UsersPerTeam)
+		for j := 1; j <= cfg.UsersPerTeam; j++ {
+			members = append(members, &models.User{
+				Id:       UserID(i, j),
+				Name:     fmt.Sprintf("User %d-%d", i, j),
+				TeamName: TeamName(i),
+				IsActive: true,
+			})
+		}
+		teams = append(teams, &models.Team{Members: members})
+	}
+	return teams
+}
+
+// titleWords and titleSubjects make up the titles of the sample pull requests, sampleLabels their labels.
+var titleWords = []string{"Fix", "Add", "Refactor", "Speed up", "Document", "Test"}
+
+var titleSubjects = []string{"login flow", "billing export", "search index", "rate limiter", "audit log",
+	"team settings", "webhook retries", "cache warmup"}
+
+var sampleLabels = []string{"backend", "frontend", "bug", "feature", "docs"}
+
+// PullRequest is a sample pull request with the reviewers assigned to it.
+type PullRequest struct {
+	PR        *models.PullRequest
+	Reviewers []string
+}
+
+// PullRequests returns the sample pull requests. Authors and reviewers are drawn from the sample
+// teams with a generator seeded by cfg.Seed; up to two teammates of the author review each PR and
+// about a third of them are merged. Times are spread over the 30 days before now.
+func PullRequests(cfg Config, now time.Time) []PullRequest {
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	prs := make([]PullRequest, 0, cfg.PRs)
+	for n := 1; n <= cfg.PRs; n++ {
+		team := rng.Intn(cfg.Teams) + 1
+		author := rng.Intn(cfg.UsersPerTeam) + 1
+		createdAt := now.Add(-time.Duration(rng.Int63n(int64(30 * 24 * time.Hour)))).UTC().Truncate(time.Second)
+
+		pr := &models.PullRequest{
+			Id: PRID(n),
+			Title: fmt.Sprintf("%s %s", titleWords[rng.Intn(len(titleWords))],
+				titleSubjects[rng.Intn(len(titleSubjects))]),
+			AuthorId:  UserID(team, author),
+			Status:    models.PRStatusOpen,
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+			Priority:  models.PRPriorities[rng.Intn(len(models.PRPriorities))],
+			Labels:    models.NormalizeLabels([]string{sampleLabels[rng.Intn(len(sampleLabels))]}),
+		}
+
+		var reviewers []string
+		for _, member := range rng.Perm(cfg.UsersPerTeam) {
+			if len(reviewers) == models.MaxReviewers {
+				break
+			}
+			if member+1 != author {
+				reviewers = append(reviewers, UserID(team, member+1))
+			}
+		}
+
+		if rng.Intn(3) == 0 {
+			mergedAt := createdAt.Add(time.Duration(rng.Int63n(int64(now.Sub(createdAt)) + 1)))
+			pr.Status = models.PRStatusMerged
+			pr.MergedAt = &mergedAt
+		}
+		prs = append(prs, PullRequest{PR: pr, Reviewers: reviewers})
+	}
+	return prs
+}
+
+// TeamRepository creates the sample teams.
+// CreateTeam must return TEAM_EXISTS AppError if the team exists.
+type TeamRepository interface {
+	CreateTeam(ctx context.Context, team *models.Team) error
+}
+
+// PullRequestRepository creates the sample pull requests.
+// Create must return PR_EXISTS AppError if the PR exists.
+type PullRequestRepository interface {
+	Create(ctx context.Context, pr *models.PullRequest) error
+	UpdateStatus(ctx context.Context, prID, fromStatus, status string, mergedAt *time.Time) (int, error)
+}
+
+// ReviewerRepository assigns the reviewers of the sample pull requests.
+type ReviewerRepository interface {
+	AssignReviewer(ctx context.Context, prID, reviewerID, source string) error
+}
+
+// Transactor provides transaction management.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Summary counts what a run of the seeder wrote and what it found already there.
+type Summary struct {
+	TeamsCreated int
+	TeamsExisted int
+	UsersCreated int
+	PRsCreated   int
+	PRsExisted   int
+	Reviewers    int
+}
+
+// Seeder writes the sample data through the repositories, as the services do.
+type Seeder struct {
+	teamRepo     TeamRepository
+	prRepo       PullRequestRepository
+	reviewerRepo ReviewerRepository
+	transactor   Transactor
+}
+
+// NewSeeder creates a seeder writing to the repositories.
+func NewSeeder(teamRepo TeamRepository, prRepo PullRequestRepository, reviewerRepo ReviewerRepository,
+	transactor Transactor) *Seeder {
+	return &Seeder{teamRepo: teamRepo, prRepo: prRepo, reviewerRepo: reviewerRepo, transactor: transactor}
+}
+
+// Seed writes the sample data of cfg. Teams and pull requests that already exist are left as they
+// are, so running it again writes nothing; each pull request is written with its reviewers in one
+// transaction.
+func (s *Seeder) Seed(ctx context.Context, cfg Config) (*Summary, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	summary := &Summary{}
+	for _, team := range Teams(cfg) {
+		err := s.teamRepo.CreateTeam(ctx, team)
+		switch {
+		case domainErrors.HasCode(err, domainErrors.CodeTeamExists):
+			summary.TeamsExisted++
+		case err != nil:
+			return summary, fmt.Errorf("failed to create %s: %w", team.GetTeamName(), err)
+		default:
+			summary.TeamsCreated++
+			summary.UsersCreated += len(team.Members)
+		}
+	}
+
+	for _, sample := range PullRequests(cfg, time.Now()) {
+		err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			return s.createPR(ctx, sample)
+		})
+		switch {
+		case domainErrors.HasCode(err, domainErrors.CodePRExists):
+			summary.PRsExisted++
+		case err != nil:
+			return summary, fmt.Errorf("failed to create %s: %w", sample.PR.Id, err)
+		default:
+			summary.PRsCreated++
+			summary.Reviewers += len(sample.Reviewers)
+		}
+	}
+	return summary, nil
+}
+
+// createPR creates the sample PR open, assigns its reviewers and merges it if it is merged.
+func (s *Seeder) createPR(ctx context.Context, sample PullRequest) error {
+	pr := *sample.PR
+	pr.Status = models.PRStatusOpen
+	pr.MergedAt = nil
+	if err := s.prRepo.Create(ctx, &pr); err != nil {
+		return err
+	}
+	for _, reviewerID := range sample.Reviewers {
+		if err := s.reviewerRepo.AssignReviewer(ctx, pr.Id, reviewerID, models.AssignmentSourceAuto); err != nil {
+			return err
+		}
+	}
+	if sample.PR.Status == models.PRStatusMerged {
+		if _, err := s.prRepo.UpdateStatus(ctx, pr.Id, models.PRStatusOpen, models.PRStatusMerged,
+			sample.PR.MergedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package seed
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/shirr9/pr-reviewer-service/internal/infrastructure/persistence/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSeeder(storage *memory.Storage) *Seeder {
+	return NewSeeder(storage.NewTeamRepository(), storage.NewPullRequestRepository(),
+		storage.NewReviewerRepository(), storage.NewUnitOfWork())
+}
+
+func TestPullRequests(t *testing.T) {
+	cfg := Config{Teams: 3, UsersPerTeam: 4, PRs: 30, Seed: 7}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Success - Same seed yields the same data", func(t *testing.T) {
+		assert.Equal(t, PullRequests(cfg, now), PullRequests(cfg, now))
+	})
+
+	t.Run("Success - Reviewers are teammates of the author", func(t *testing.T) {
+		for _, sample := range PullRequests(cfg, now) {
+			assert.Len(t, sample.Reviewers, models.MaxReviewers)
+			assert.NotContains(t, sample.Reviewers, sample.PR.AuthorId)
+			// ids are user-<team>-<member>
+			team := sample.PR.AuthorId[:strings.LastIndex(sample.PR.AuthorId, "-")+1]
+			for _, reviewerID := range sample.Reviewers {
+				assert.True(t, strings.HasPrefix(reviewerID, team))
+			}
+			assert.False(t, sample.PR.CreatedAt.After(now))
+			if sample.PR.MergedAt != nil {
+				assert.False(t, sample.PR.MergedAt.Before(sample.PR.CreatedAt))
+				assert.False(t, sample.PR.MergedAt.After(now))
+			}
+		}
+	})
+
+	t.Run("Success - Lone author gets no reviewers", func(t *testing.T) {
+		for _, sample := range PullRequests(Config{Teams: 1, UsersPerTeam: 1, PRs: 5}, now) {
+			assert.Empty(t, sample.Reviewers)
+		}
+	})
+}
+
+func TestSeeder_Seed(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{Teams: 2, UsersPerTeam: 3, PRs: 10, Seed: 1}
+
+	t.Run("Success - Data is written through the repositories", func(t *testing.T) {
+		storage := memory.NewStorage()
+
+		summary, err := newSeeder(storage).Seed(ctx, cfg)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, summary.TeamsCreated)
+		assert.Equal(t, 6, summary.UsersCreated)
+		assert.Equal(t, 10, summary.PRsCreated)
+		assert.Equal(t, 20, summary.Reviewers)
+
+		team, err := storage.NewTeamRepository().GetTeamByName(ctx, TeamName(2))
+		require.NoError(t, err)
+		require.NotNil(t, team)
+		assert.Len(t, team.Members, 3)
+
+		for _, sample := range PullRequests(cfg, time.Now()) {
+			pr, err := storage.NewPullRequestRepository().FindByID(ctx, sample.PR.Id)
+			require.NoError(t, err)
+			require.NotNil(t, pr)
+			assert.Equal(t, sample.PR.Status, pr.Status)
+			reviewers, err := storage.NewReviewerRepository().GetReviewers(ctx, pr.Id)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, sample.Reviewers, reviewers)
+		}
+	})
+
+	t.Run("Success - Running again writes nothing", func(t *testing.T) {
+		storage := memory.NewStorage()
+		_, err := newSeeder(storage).Seed(ctx, cfg)
+		require.NoError(t, err)
+
+		summary, err := newSeeder(storage).Seed(ctx, cfg)
+
+		require.NoError(t, err)
+		assert.Equal(t, &Summary{TeamsExisted: 2, PRsExisted: 10}, summary)
+	})
+
+	t.Run("Error - Invalid config", func(t *testing.T) {
+		_, err := newSeeder(memory.NewStorage()).Seed(ctx, Config{Teams: 0, UsersPerTeam: 3})
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,173 @@
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/shirr9/pr-reviewer-service/api"
+)
+
+// contractOptions make the validation strict: every status must be declared, all errors are reported,
+// and defaults are not written into the request that is sent afterwards.
+var contractOptions = &openapi3filter.Options{
+	IncludeResponseStatus: true,
+	MultiError:            true,
+	SkipSettingDefaults:   true,
+}
+
+// newSpecRouter loads api/openapi.yaml and returns a router finding its operations.
+// The servers are dropped so that operations match the host of the in-process server.
+func newSpecRouter(t *testing.T) routers.Router {
+	t.Helper()
+	doc, err := openapi3.NewLoader().LoadFromData(api.Spec)
+	if err != nil {
+		t.Fatalf("failed to load OpenAPI spec: %v", err)
+	}
+	if err = doc.Validate(context.Background()); err != nil {
+		t.Fatalf("invalid OpenAPI spec: %v", err)
+	}
+	doc.Servers = nil
+	router, err := legacy.NewRouter(doc)
+	if err != nil {
+		t.Fatalf("failed to build spec router: %v", err)
+	}
+	return router
+}
+
+// TestContract replays the golden scenario through the in-process server and validates every
+// response, and every request expected to succeed, against the OpenAPI spec. Requests of the
+// error steps are invalid on purpose, so only their responses are checked.
+func TestContract(t *testing.T) {
+	router := newSpecRouter(t)
+	e := newInProcessEnv(t)
+
+	for _, step := range goldenScenario {
+		req := e.newRequest(step.method, step.path, step.payload)
+		route, pathParams, err := router.FindRoute(req)
+		if err != nil {
+			t.Fatalf("%s: %s %s is not in the spec: %v", step.name, step.method, step.path, err)
+		}
+		input := &openapi3filter.RequestValidationInput{
+			Request:    req,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    contractOptions,
+		}
+		if step.status < http.StatusBadRequest {
+			if err = openapi3filter.ValidateRequest(context.Background(), input); err != nil {
+				t.Errorf("%s: request does not match the spec: %v", step.name, err)
+			}
+		}
+
+		resp, err := e.client.Do(req)
+		if err != nil {
+			t.Fatalf("%s: request failed: %v", step.name, err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("%s: failed to read response: %v", step.name, err)
+		}
+		if resp.StatusCode != step.status {
+			t.Fatalf("%s: expected status %d, got %d. Body: %s", step.name, step.status, resp.StatusCode, body)
+		}
+
+		err = openapi3filter.ValidateResponse(context.Background(), &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 resp.StatusCode,
+			Header:                 resp.Header,
+			Body:                   io.NopCloser(bytes.NewReader(body)),
+			Options:                contractOptions,
+		})
+		if err != nil {
+			t.Errorf("%s: response does not match the spec: %v\nBody: %s", step.name, err, body)
+		}
+	}
+}
+
+// TestContract_DetectsDrift makes sure the validation fails on the kinds of drift it guards against.
+func TestContract_DetectsDrift(t *testing.T) {
+	router := newSpecRouter(t)
+	req, err := http.NewRequest(http.MethodGet, "/team/get?team_name=backend", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	route, pathParams, err := router.FindRoute(req)
+	if err != nil {
+		t.Fatalf("failed to find route: %v", err)
+	}
+	input := &openapi3filter.RequestValidationInput{Request: req, PathParams: pathParams, Route: route}
+
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   string
+	}{
+		{
+			name:   "Error - undeclared field",
+			status: http.StatusOK,
+			body:   `{"team_name":"backend","members":[],"extra":1}`,
+			want:   "extra",
+		},
+		{
+			name:   "Error - wrong type",
+			status: http.StatusOK,
+			body:   `{"team_name":"backend","members":[{"user_id":"u1","username":"Alice","is_active":"yes"}]}`,
+			want:   "is_active",
+		},
+		{
+			name:   "Error - undocumented status",
+			status: http.StatusConflict,
+			body:   `{"error":{"code":"TEAM_EXISTS","message":"team_name already exists"}}`,
+			want:   "status is not supported",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := openapi3filter.ValidateResponse(context.Background(), &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: input,
+				Status:                 tt.status,
+				Header:                 http.Header{"Content-Type": []string{"application/json"}},
+				Body:                   io.NopCloser(strings.NewReader(tt.body)),
+				Options:                contractOptions,
+			})
+			if err == nil {
+				t.Fatal("expected the response to be rejected")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected the error to mention %q, got: %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// TestOpenAPISpecServed checks the server publishes the spec the contract tests validate against.
+func TestOpenAPISpecServed(t *testing.T) {
+	e := newInProcessEnv(t)
+	resp := e.do(http.MethodGet, "/openapi.yaml", nil)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Content-Type"); got != "application/yaml" {
+		t.Errorf("expected Content-Type application/yaml, got %q", got)
+	}
+	if !bytes.Equal(body, api.Spec) {
+		t.Error("served spec differs from api/openapi.yaml")
+	}
+}
@@ -0,0 +1,113 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+// sendDump sends a request to the dump endpoints with the token of the in-process server and
+// returns the status and the body of the response.
+func (e *env) sendDump(method, path string, body []byte) (int, []byte) {
+	e.t.Helper()
+	req, err := http.NewRequest(method, e.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		e.t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+testDumpToken)
+	resp, err := e.client.Do(req)
+	if err != nil {
+		e.t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		e.t.Fatalf("Failed to read response: %v", err)
+	}
+	return resp.StatusCode, data
+}
+
+// export downloads the dump, dropping exported_at, the one field that differs between exports.
+func (e *env) export() ([]byte, map[string]json.RawMessage) {
+	e.t.Helper()
+	status, body := e.sendDump(http.MethodGet, "/admin/export", nil)
+	if status != http.StatusOK {
+		e.t.Fatalf("export: expected status 200, got %d. Body: %s", status, body)
+	}
+	var sections map[string]json.RawMessage
+	if err := json.Unmarshal(body, &sections); err != nil {
+		e.t.Fatalf("Failed to decode export %s: %v", body, err)
+	}
+	delete(sections, "exported_at")
+	return body, sections
+}
+
+func TestE2EDumpRoundTrip(t *testing.T) {
+	source := newInProcessEnv(t)
+	source.call(http.MethodPost, "/team/add", map[string]any{
+		"team_name":   "backend",
+		"description": "Search and payments",
+		"lead_id":     "u1",
+		"members": []map[string]any{
+			{"user_id": "u1", "username": "Alice", "is_active": true, "tags": []string{"go"}},
+			{"user_id": "u2", "username": "Bob", "is_active": true, "max_active_reviews": 3},
+			{"user_id": "u3", "username": "Carol", "is_active": true,
+				"timezone": "UTC", "work_hours_start": "00:00", "work_hours_end": "00:00"},
+			{"user_id": "u4", "username": "Dave", "is_active": false},
+		},
+	}, http.StatusCreated, nil)
+	source.call(http.MethodPost, "/pullRequest/create", map[string]any{
+		"pull_request_id": "pr-1", "pull_request_name": "Add search", "author_id": "u1",
+		"priority": "HIGH", "labels": []string{"backend"},
+	}, http.StatusCreated, nil)
+	source.call(http.MethodPost, "/pullRequest/create", map[string]any{
+		"pull_request_id": "pr-2", "pull_request_name": "Fix typo", "author_id": "u2",
+	}, http.StatusCreated, nil)
+	source.call(http.MethodPost, "/pullRequest/review", map[string]any{
+		"pull_request_id": "pr-2", "reviewer_id": "u1", "state": "APPROVED",
+	}, http.StatusOK, nil)
+	source.call(http.MethodPost, "/pullRequest/merge", map[string]any{"pull_request_id": "pr-2"}, http.StatusOK, nil)
+
+	dump, exported := source.export()
+
+	target := newInProcessEnv(t)
+	status, body := target.sendDump(http.MethodPost, "/admin/import", dump)
+	if status != http.StatusCreated {
+		t.Fatalf("import: expected status 201, got %d. Body: %s", status, body)
+	}
+	var imported struct {
+		Teams        int  `json:"teams"`
+		Users        int  `json:"users"`
+		PullRequests int  `json:"pull_requests"`
+		Reviewers    int  `json:"reviewers"`
+		Cleared      bool `json:"cleared"`
+	}
+	if err := json.Unmarshal(body, &imported); err != nil {
+		t.Fatalf("Failed to decode import %s: %v", body, err)
+	}
+	if imported.Teams != 1 || imported.Users != 4 || imported.PullRequests != 2 || imported.Cleared {
+		t.Fatalf("unexpected import counts: %s", body)
+	}
+
+	_, reexported := target.export()
+	for name, section := range exported {
+		if !bytes.Equal(section, reexported[name]) {
+			t.Errorf("%s differs after the round trip:\nexported: %s\nrestored: %s", name, section, reexported[name])
+		}
+	}
+
+	status, body = target.sendDump(http.MethodPost, "/admin/import", dump)
+	if status != http.StatusConflict {
+		t.Fatalf("import into stored data: expected status 409, got %d. Body: %s", status, body)
+	}
+	status, body = target.sendDump(http.MethodPost, "/admin/import?force=true", dump)
+	if status != http.StatusOK {
+		t.Fatalf("forced import: expected status 200, got %d. Body: %s", status, body)
+	}
+	_, forced := target.export()
+	if !bytes.Equal(forced["checksums"], exported["checksums"]) {
+		t.Errorf("checksums differ after the forced import: %s, want %s", forced["checksums"], exported["checksums"])
+	}
+}
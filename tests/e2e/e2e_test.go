@@ -1,238 +1,274 @@
 package e2e
 
 import (
-	"bytes"
-	"encoding/json"
-	"io"
+	"fmt"
 	"net/http"
-	"os"
 	"testing"
-	"time"
-)
 
-const baseURL = "http://localhost:8080"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/pullrequest"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/statistics"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/team"
+	"github.com/shirr9/pr-reviewer-service/internal/app/dto/user"
+	domainErrors "github.com/shirr9/pr-reviewer-service/internal/domain/errors"
+	"github.com/shirr9/pr-reviewer-service/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
 
 func TestE2EWorkflow(t *testing.T) {
-	if os.Getenv("E2E_TEST") != "true" {
-		t.Skip("Skipping E2E test. Set E2E_TEST=true to run")
-	}
+	e := newEnv(t)
+	teamName := e.id("e2e-team")
+	author, bob, carol, dave := e.id("e2e-u1"), e.id("e2e-u2"), e.id("e2e-u3"), e.id("e2e-u4")
+	prID := e.id("e2e-pr")
+
+	t.Run("CreateTeam", func(t *testing.T) {
+		payload := map[string]any{
+			"team_name": teamName,
+			"members": []map[string]any{
+				{"user_id": author, "username": "E2E-Alice", "is_active": true},
+				{"user_id": bob, "username": "E2E-Bob", "is_active": true},
+				{"user_id": carol, "username": "E2E-Carol", "is_active": true},
+				{"user_id": dave, "username": "E2E-Dave", "is_active": true},
+			},
+		}
+		var resp team.AddTeamResponse
+		e.call(http.MethodPost, "/team/add", payload, http.StatusCreated, &resp)
 
-	waitForServer(t)
+		assert.Equal(t, teamName, resp.Team.TeamName)
+		assert.Len(t, resp.Team.Members, 4)
 
-	t.Run("CreateTeamAndUsers", testCreateTeam)
-	t.Run("CreatePullRequest", testCreatePullRequest)
-	t.Run("GetUserReviews", testGetUserReviews)
-	t.Run("ReassignReviewer", testReassignReviewer)
-	t.Run("MergePullRequest", testMergePullRequest)
-	t.Run("GetStatistics", testGetStatistics)
-	t.Run("DeactivateTeam", testDeactivateTeam)
-}
+		e.callError(http.MethodPost, "/team/add", payload, http.StatusConflict, domainErrors.CodeTeamExists)
+	})
 
-func waitForServer(t *testing.T) {
-	maxRetries := 30
-	for i := 0; i < maxRetries; i++ {
-		resp, err := http.Get(baseURL + "/statistics")
-		if err == nil && resp.StatusCode < 500 {
-			resp.Body.Close()
-			return
+	t.Run("CreatePullRequest", func(t *testing.T) {
+		payload := map[string]any{
+			"pull_request_id":   prID,
+			"pull_request_name": "E2E Add feature",
+			"author_id":         author,
 		}
-		if resp != nil {
-			resp.Body.Close()
+		var resp pullrequest.CreatePrResponse
+		e.call(http.MethodPost, "/pullRequest/create", payload, http.StatusCreated, &resp)
+
+		assert.Equal(t, prID, resp.Pr.PullRequestID)
+		assert.Equal(t, models.PRStatusOpen, resp.Pr.Status)
+		assert.Equal(t, author, resp.Pr.AuthorID)
+		// nobody has reviews yet, so ties are broken by user id
+		assert.ElementsMatch(t, []string{bob, carol}, resp.Pr.AssignedReviewers)
+
+		e.callError(http.MethodPost, "/pullRequest/create", payload, http.StatusConflict, domainErrors.CodePRExists)
+	})
+
+	t.Run("GetUserReviews", func(t *testing.T) {
+		var resp user.GetReviewResponse
+		e.call(http.MethodGet, "/users/getReview?user_id="+bob, nil, http.StatusOK, &resp)
+
+		assert.Equal(t, bob, resp.UserID)
+		if assert.Len(t, resp.PullRequests, 1) {
+			assert.Equal(t, prID, resp.PullRequests[0].PullRequestID)
+			assert.Equal(t, models.PRStatusOpen, resp.PullRequests[0].Status)
+			assert.Equal(t, models.ReviewStatePending, resp.PullRequests[0].ReviewState)
 		}
-		time.Sleep(1 * time.Second)
-	}
-	t.Fatal("Server did not start in time")
-}
-
-func testCreateTeam(t *testing.T) {
-	timestamp := time.Now().Unix()
-	payload := map[string]interface{}{
-		"team_name": "e2e-team-" + string(rune(timestamp)),
-		"members": []map[string]interface{}{
-			{"user_id": "e2e-u1", "username": "E2E-Alice", "is_active": true},
-			{"user_id": "e2e-u2", "username": "E2E-Bob", "is_active": true},
-			{"user_id": "e2e-u3", "username": "E2E-Charlie", "is_active": true},
-		},
-	}
-
-	resp := makeRequest(t, "POST", "/team/add", payload)
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusConflict {
-		body, _ := io.ReadAll(resp.Body)
-		t.Logf("Team creation returned status %d (may already exist): %s", resp.StatusCode, body)
-		return
-	}
-
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		t.Fatalf("Failed to decode response: %v", err)
-	}
-
-	if team, ok := result["team"].(map[string]interface{}); ok {
-		t.Logf("Team created/exists: %s", team["team_name"])
-	}
-}
-
-func testCreatePullRequest(t *testing.T) {
-	timestamp := time.Now().UnixNano()
-	payload := map[string]interface{}{
-		"pull_request_id":   "e2e-pr-" + string(rune(timestamp/1000000)),
-		"pull_request_name": "E2E Add feature",
-		"author_id":         "e2e-u1",
-	}
-
-	resp := makeRequest(t, "POST", "/pullRequest/create", payload)
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusConflict {
-		body, _ := io.ReadAll(resp.Body)
-		t.Logf("PR creation returned status %d (may already exist): %s", resp.StatusCode, body)
-		return
-	}
-
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		t.Fatalf("Failed to decode response: %v", err)
-	}
-
-	if pr, ok := result["pr"].(map[string]interface{}); ok {
-		t.Logf("PR created: %s with status %s", pr["pull_request_id"], pr["status"])
-	}
-}
-
-func testGetUserReviews(t *testing.T) {
-	resp := makeRequest(t, "GET", "/users/getReview?user_id=e2e-u2", nil)
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		t.Logf("Get reviews returned status %d: %s", resp.StatusCode, body)
-		return
-	}
-
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		t.Fatalf("Failed to decode response: %v", err)
-	}
-
-	t.Logf("User reviews retrieved for %s", result["user_id"])
-}
-
-func testReassignReviewer(t *testing.T) {
-	payload := map[string]interface{}{
-		"pull_request_id":  "e2e-pr-test",
-		"old_reviewer_id": "e2e-u2",
-	}
-
-	resp := makeRequest(t, "POST", "/pullRequest/reassign", payload)
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusBadRequest && resp.StatusCode != http.StatusNotFound {
-		body, _ := io.ReadAll(resp.Body)
-		t.Fatalf("Unexpected status %d: %s", resp.StatusCode, body)
-	}
-
-	t.Logf("Reassign response status: %d", resp.StatusCode)
-}
-
-func testMergePullRequest(t *testing.T) {
-	payload := map[string]interface{}{
-		"pull_request_id": "e2e-pr-test",
-	}
-
-	resp := makeRequest(t, "POST", "/pullRequest/merge", payload)
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
-		body, _ := io.ReadAll(resp.Body)
-		t.Logf("Merge returned status %d: %s", resp.StatusCode, body)
-		return
-	}
-
-	t.Logf("Merge response status: %d", resp.StatusCode)
-}
-
-func testGetStatistics(t *testing.T) {
-	resp := makeRequest(t, "GET", "/statistics", nil)
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		t.Fatalf("Expected status 200, got %d. Body: %s", resp.StatusCode, body)
-	}
+		e.call(http.MethodGet, "/users/getReview?user_id="+dave, nil, http.StatusOK, &resp)
+		assert.Empty(t, resp.PullRequests)
+	})
+
+	t.Run("GraphQLTeamQueues", func(t *testing.T) {
+		query := `query($team: String!) {
+			team(name: $team) { members { id openReviews { id author { username } } } }
+		}`
+		var resp struct {
+			Data struct {
+				Team struct {
+					Members []struct {
+						ID          string
+						OpenReviews []struct {
+							ID     string
+							Author struct{ Username string }
+						}
+					}
+				}
+			}
+		}
+		e.call(http.MethodPost, "/graphql", map[string]any{"query": query, "variables": map[string]any{"team": teamName}},
+			http.StatusOK, &resp)
+
+		reviews := make(map[string][]string)
+		for _, m := range resp.Data.Team.Members {
+			for _, pr := range m.OpenReviews {
+				reviews[m.ID] = append(reviews[m.ID], pr.ID)
+				assert.Equal(t, "E2E-Alice", pr.Author.Username)
+			}
+		}
+		assert.Equal(t, map[string][]string{bob: {prID}, carol: {prID}}, reviews)
+	})
+
+	t.Run("ReassignReviewer", func(t *testing.T) {
+		var resp pullrequest.ReassignReviewerResponse
+		e.call(http.MethodPost, "/pullRequest/reassign",
+			map[string]any{"pull_request_id": prID, "old_reviewer_id": bob}, http.StatusOK, &resp)
+
+		assert.Equal(t, dave, resp.ReplacedBy)
+		assert.ElementsMatch(t, []string{carol, dave}, resp.Pr.AssignedReviewers)
+
+		e.callError(http.MethodPost, "/pullRequest/reassign",
+			map[string]any{"pull_request_id": prID, "old_reviewer_id": bob},
+			http.StatusBadRequest, domainErrors.CodeNotAssigned)
+		e.callError(http.MethodPost, "/pullRequest/reassign",
+			map[string]any{"pull_request_id": e.id("e2e-missing"), "old_reviewer_id": bob},
+			http.StatusNotFound, domainErrors.CodeNotFound)
+	})
+
+	t.Run("MergePullRequest", func(t *testing.T) {
+		var resp pullrequest.MergePrResponse
+		e.call(http.MethodPost, "/pullRequest/merge", map[string]any{"pull_request_id": prID}, http.StatusOK, &resp)
+
+		assert.Equal(t, models.PRStatusMerged, resp.Pr.Status)
+		assert.NotEmpty(t, resp.Pr.MergedAt)
+
+		var again pullrequest.MergePrResponse
+		e.call(http.MethodPost, "/pullRequest/merge", map[string]any{"pull_request_id": prID}, http.StatusOK, &again)
+		assert.Equal(t, resp.Pr.MergedAt, again.Pr.MergedAt)
+
+		e.callError(http.MethodPost, "/pullRequest/reassign",
+			map[string]any{"pull_request_id": prID, "old_reviewer_id": carol},
+			http.StatusConflict, domainErrors.CodePRMerged)
+	})
+
+	t.Run("GetStatistics", func(t *testing.T) {
+		users, prs := allStatistics(e)
+
+		stats := make(map[string]statistics.UserStats)
+		for _, userStats := range users {
+			stats[userStats.UserID] = userStats
+		}
+		assert.Equal(t, 0, stats[bob].AssignmentsCount)
+		assert.Equal(t, 1, stats[carol].AssignmentsCount)
+		assert.Equal(t, 1, stats[dave].AssignmentsCount)
+		assert.Equal(t, 0, stats[dave].ActiveReviews)
+
+		var prStats *statistics.PRStats
+		for i := range prs {
+			if prs[i].PullRequestID == prID {
+				prStats = &prs[i]
+			}
+		}
+		if assert.NotNil(t, prStats) {
+			assert.Equal(t, 2, prStats.ReviewersCount)
+			assert.Equal(t, 1, prStats.ReassignmentsCount)
+			assert.Equal(t, models.PRStatusMerged, prStats.Status)
+		}
+	})
+
+	t.Run("UpdateUsername", func(t *testing.T) {
+		var resp user.UpdateUserResponse
+		e.call(http.MethodPost, "/users/update", map[string]any{"user_id": dave, "username": "E2E-David"},
+			http.StatusOK, &resp)
+		assert.Equal(t, "E2E-David", resp.User.Username)
+		assert.Equal(t, teamName, resp.User.TeamName)
+
+		var got team.GetTeamResponse
+		e.call(http.MethodGet, "/team/get?team_name="+teamName, nil, http.StatusOK, &got)
+		for _, member := range got.Members {
+			if member.UserID == dave {
+				assert.Equal(t, "E2E-David", member.Username)
+			}
+		}
 
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		t.Fatalf("Failed to decode response: %v", err)
-	}
+		users, _ := allStatistics(e)
+		for _, userStats := range users {
+			if userStats.UserID == dave {
+				assert.Equal(t, "E2E-David", userStats.Username)
+			}
+		}
 
-	if _, ok := result["total_prs"]; !ok {
-		t.Fatal("Statistics missing total_prs")
-	}
+		e.callError(http.MethodPost, "/users/update", map[string]any{"user_id": e.id("e2e-missing"), "username": "X"},
+			http.StatusNotFound, domainErrors.CodeNotFound)
+	})
+
+	t.Run("TeamETag", func(t *testing.T) {
+		getTeam := func(etag string) *http.Response {
+			req := e.newRequest(http.MethodGet, "/team/get?team_name="+teamName, nil)
+			if etag != "" {
+				req.Header.Set("If-None-Match", etag)
+			}
+			resp, err := e.client.Do(req)
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			resp.Body.Close()
+			return resp
+		}
 
-	if _, ok := result["user_stats"]; !ok {
-		t.Fatal("Statistics missing user_stats")
-	}
+		etag := getTeam("").Header.Get("ETag")
+		assert.NotEmpty(t, etag)
+		assert.Equal(t, http.StatusNotModified, getTeam(etag).StatusCode)
+
+		e.call(http.MethodPost, "/users/setIsActive", map[string]any{"user_id": dave, "is_active": false},
+			http.StatusOK, nil)
+		changed := getTeam(etag)
+		assert.Equal(t, http.StatusOK, changed.StatusCode)
+		assert.NotEqual(t, etag, changed.Header.Get("ETag"))
+
+		e.call(http.MethodPost, "/users/setIsActive", map[string]any{"user_id": dave, "is_active": true},
+			http.StatusOK, nil)
+		assert.Equal(t, http.StatusNotModified, getTeam(etag).StatusCode, "restored roster has the old ETag")
+	})
+
+	t.Run("DeactivateTeam", func(t *testing.T) {
+		var resp team.DeactivateTeamResponse
+		e.call(http.MethodPost, "/team/deactivate", map[string]any{"team_name": teamName}, http.StatusOK, &resp)
+
+		assert.Equal(t, 4, resp.DeactivatedUsers)
+		assert.Equal(t, 0, resp.ReassignedPRs)
+		assert.ElementsMatch(t, []string{author, bob, carol, dave}, resp.UserIDs)
+
+		var got team.GetTeamResponse
+		e.call(http.MethodGet, "/team/get?team_name="+teamName, nil, http.StatusOK, &got)
+		for _, member := range got.Members {
+			assert.False(t, member.IsActive, member.UserID)
+		}
+	})
 }
 
-func testDeactivateTeam(t *testing.T) {
-	payload := map[string]interface{}{
-		"team_name": "test-deactivate",
-	}
-
-	teamPayload := map[string]interface{}{
-		"team_name": "test-deactivate",
-		"members": []map[string]interface{}{
-			{"user_id": "u10", "username": "TestUser", "is_active": true},
-		},
+func TestE2EFreshState(t *testing.T) {
+	e := newEnv(t)
+	if e.suffix != "" {
+		t.Skip("an external deployment keeps earlier data")
 	}
-	resp := makeRequest(t, "POST", "/team/add", teamPayload)
-	resp.Body.Close()
 
-	resp = makeRequest(t, "POST", "/team/deactivate", payload)
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		t.Fatalf("Expected status 200, got %d. Body: %s", resp.StatusCode, body)
-	}
+	var resp statistics.StatisticsResponse
+	e.call(http.MethodGet, "/statistics", nil, http.StatusOK, &resp)
 
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		t.Fatalf("Failed to decode response: %v", err)
-	}
-
-	if _, ok := result["deactivated_users"]; !ok {
-		t.Fatal("Deactivate response missing deactivated_users")
-	}
+	assert.Equal(t, 0, resp.TotalPRs)
+	assert.Empty(t, resp.UserStats.Items)
+	assert.Zero(t, resp.UserStats.Total)
 }
 
-func makeRequest(t *testing.T, method, path string, payload interface{}) *http.Response {
-	var body io.Reader
-	if payload != nil {
-		jsonData, err := json.Marshal(payload)
-		if err != nil {
-			t.Fatalf("Failed to marshal payload: %v", err)
+// allStatistics walks the pages of the statistics and returns the complete user and PR lists.
+func allStatistics(e *env) ([]statistics.UserStats, []statistics.PRStats) {
+	e.t.Helper()
+	var users []statistics.UserStats
+	var prs []statistics.PRStats
+	for {
+		var resp statistics.StatisticsResponse
+		e.call(http.MethodGet, fmt.Sprintf("/statistics?users_offset=%d&prs_offset=%d", len(users), len(prs)),
+			nil, http.StatusOK, &resp)
+		users = append(users, resp.UserStats.Items...)
+		prs = append(prs, resp.PRStats.Items...)
+		if len(resp.UserStats.Items) == 0 && len(resp.PRStats.Items) == 0 {
+			return users, prs
 		}
-		body = bytes.NewBuffer(jsonData)
-	}
-
-	url := baseURL + path
-	req, err := http.NewRequest(method, url, body)
-	if err != nil {
-		t.Fatalf("Failed to create request: %v", err)
-	}
-
-	if payload != nil {
-		req.Header.Set("Content-Type", "application/json")
 	}
+}
 
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		t.Fatalf("Request failed: %v", err)
-	}
+func TestE2EValidation(t *testing.T) {
+	e := newEnv(t)
 
-	return resp
+	e.callError(http.MethodPost, "/pullRequest/create",
+		map[string]any{"pull_request_id": e.id("e2e-invalid")}, http.StatusBadRequest, domainErrors.CodeValidation)
+	e.callError(http.MethodPost, "/team/add",
+		map[string]any{"team_name": e.id("e2e-empty"), "members": []any{}}, http.StatusBadRequest, domainErrors.CodeValidation)
+	e.callError(http.MethodPost, "/pullRequest/create",
+		map[string]any{"pull_request_id": e.id("e2e-orphan"), "pull_request_name": "Orphan", "author_id": e.id("e2e-nobody")},
+		http.StatusNotFound, domainErrors.CodeNotFound)
 }
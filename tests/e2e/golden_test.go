@@ -0,0 +1,211 @@
+package e2e
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files with the current responses")
+
+// Timestamps and ages in responses depend on the wall clock and are replaced before the comparison.
+var (
+	timestampPattern = regexp.MustCompile(`"\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})"`)
+	agePattern       = regexp.MustCompile(`"age_seconds":\d+`)
+)
+
+// goldenStep is a request of the golden scenario; its response body is compared with testdata/golden/<name>.json.
+type goldenStep struct {
+	name    string
+	method  string
+	path    string
+	payload any
+	status  int
+}
+
+// goldenScenario runs in order on a single server: later steps rely on the state built by earlier ones.
+// The "platform" team has one member, so its PRs get no reviewers. Equal working hours mean
+// Dave is always available, keeping the ranking independent of the time of day.
+var goldenScenario = []goldenStep{
+	{"team_add", http.MethodPost, "/team/add", map[string]any{
+		"team_name":   "backend",
+		"description": "Search and payments",
+		"lead_id":     "u2",
+		"members": []map[string]any{
+			{"user_id": "u1", "username": "Alice", "is_active": true, "tags": []string{"go"}},
+			{"user_id": "u2", "username": "Bob", "is_active": true, "tags": []string{"go", "sql"}},
+			{"user_id": "u3", "username": "Carol", "is_active": true, "max_active_reviews": 2},
+			{"user_id": "u4", "username": "Dave", "is_active": true,
+				"timezone": "UTC", "work_hours_start": "09:00", "work_hours_end": "09:00"},
+			{"user_id": "u5", "username": "Eve", "is_active": false},
+		},
+	}, http.StatusCreated},
+	{"team_add_single_member", http.MethodPost, "/team/add", map[string]any{
+		"team_name": "platform",
+		"members":   []map[string]any{{"user_id": "p1", "username": "Pat", "is_active": true}},
+	}, http.StatusCreated},
+	{"team_get", http.MethodGet, "/team/get?team_name=backend", nil, http.StatusOK},
+	{"team_list", http.MethodGet, "/team/list", nil, http.StatusOK},
+	{"pr_create", http.MethodPost, "/pullRequest/create", map[string]any{
+		"pull_request_id": "pr-1", "pull_request_name": "Add search", "author_id": "u1",
+		"priority": "HIGH", "labels": []string{"backend", "feature"}, "required_tags": []string{"sql"},
+	}, http.StatusCreated},
+	{"pr_create_minimal", http.MethodPost, "/pullRequest/create", map[string]any{
+		"pull_request_id": "pr-2", "pull_request_name": "Fix typo", "author_id": "u2",
+	}, http.StatusCreated},
+	{"pr_create_no_reviewers", http.MethodPost, "/pullRequest/create", map[string]any{
+		"pull_request_id": "pr-3", "pull_request_name": "Bump deps", "author_id": "p1",
+	}, http.StatusCreated},
+	{"pr_create_bulk", http.MethodPost, "/pullRequest/createBulk", map[string]any{
+		"pull_requests": []map[string]any{
+			{"pull_request_id": "pr-bulk", "pull_request_name": "Update CI", "author_id": "p1", "labels": []string{"ci"}},
+			{"pull_request_id": "pr-3", "pull_request_name": "Bump deps", "author_id": "p1"},
+			{"pull_request_id": "pr-ghost", "pull_request_name": "Orphan", "author_id": "missing"},
+		},
+		"assign_reviewers": true,
+	}, http.StatusMultiStatus},
+	{"users_get_review", http.MethodGet, "/users/getReview?user_id=u2", nil, http.StatusOK},
+	{"users_get_review_empty", http.MethodGet, "/users/getReview?user_id=u5", nil, http.StatusOK},
+	{"pr_review", http.MethodPost, "/pullRequest/review", map[string]any{
+		"pull_request_id": "pr-1", "reviewer_id": "u2", "state": "APPROVED",
+	}, http.StatusOK},
+	{"pr_set_labels", http.MethodPost, "/pullRequest/setLabels", map[string]any{
+		"pull_request_id": "pr-1", "labels": []string{"backend"},
+	}, http.StatusOK},
+	{"pr_reassign", http.MethodPost, "/pullRequest/reassign", map[string]any{
+		"pull_request_id": "pr-1", "old_reviewer_id": "u3",
+	}, http.StatusOK},
+	{"pr_history", http.MethodGet, "/pullRequest/history?pull_request_id=pr-1", nil, http.StatusOK},
+	{"pr_history_empty", http.MethodGet, "/pullRequest/history?pull_request_id=pr-2", nil, http.StatusOK},
+	{"pr_search", http.MethodGet, "/pullRequest/search?q=a&expand=reviewers", nil, http.StatusOK},
+	{"pr_search_empty", http.MethodGet, "/pullRequest/search?q=nothing", nil, http.StatusOK},
+	{"pr_search_sorted", http.MethodGet, "/pullRequest/search?q=a&sort=title", nil, http.StatusOK},
+	{"pr_suggest_reviewers", http.MethodGet, "/pullRequest/suggestReviewers?author_id=u1&count=3", nil, http.StatusOK},
+	{"pr_unassigned", http.MethodGet, "/pullRequest/unassigned", nil, http.StatusOK},
+	{"pr_assign_pending", http.MethodPost, "/pullRequest/assignPending", nil, http.StatusOK},
+	{"team_review_queue", http.MethodGet, "/team/reviewQueue?team_name=backend&expand=reviewers", nil, http.StatusOK},
+	{"users_set_tags", http.MethodPost, "/users/setTags", map[string]any{
+		"user_id": "u3", "tags": []string{"frontend"},
+	}, http.StatusOK},
+	{"users_set_tags_empty", http.MethodPost, "/users/setTags", map[string]any{
+		"user_id": "u3", "tags": []string{},
+	}, http.StatusOK},
+	{"users_set_is_active", http.MethodPost, "/users/setIsActive", map[string]any{
+		"user_id": "u5", "is_active": true,
+	}, http.StatusOK},
+	{"users_set_is_reviewer", http.MethodPost, "/users/setIsReviewer", map[string]any{
+		"user_id": "p1", "is_reviewer": false,
+	}, http.StatusOK},
+	{"users_update", http.MethodPost, "/users/update", map[string]any{
+		"user_id": "u5", "username": "Evelyn",
+	}, http.StatusOK},
+	{"users_rebalance_suggestions", http.MethodGet, "/users/rebalanceSuggestions?user_id=u5", nil, http.StatusOK},
+	{"admin_add_exclusion", http.MethodPost, "/admin/exclusions", map[string]any{
+		"reviewer_id": "u4", "author_id": "u1", "mutual": true,
+	}, http.StatusCreated},
+	{"admin_list_exclusions", http.MethodGet, "/admin/exclusions", nil, http.StatusOK},
+	{"admin_remove_exclusion", http.MethodDelete, "/admin/exclusions?reviewer_id=u4&author_id=u1", nil, http.StatusOK},
+	{"admin_list_exclusions_empty", http.MethodGet, "/admin/exclusions?user_id=u1", nil, http.StatusOK},
+	{"admin_webhook_deadletter_empty", http.MethodGet, "/admin/webhooks/deadletter", nil, http.StatusOK},
+	{"admin_webhook_stats", http.MethodGet, "/admin/webhooks/stats", nil, http.StatusOK},
+	{"pr_merge", http.MethodPost, "/pullRequest/merge", map[string]any{"pull_request_id": "pr-1"}, http.StatusOK},
+	{"pr_merge_bulk", http.MethodPost, "/pullRequest/mergeBulk", map[string]any{
+		"pull_request_ids": []string{"pr-1", "pr-2", "pr-missing"},
+	}, http.StatusMultiStatus},
+	{"statistics", http.MethodGet, "/statistics", nil, http.StatusOK},
+	{"statistics_page", http.MethodGet, "/statistics?users_limit=2&users_offset=1&prs_limit=1", nil, http.StatusOK},
+	{"statistics_include", http.MethodGet, "/statistics?include=team_stats", nil, http.StatusOK},
+	{"statistics_authors", http.MethodGet, "/statistics?include=author_stats&include_archived=true", nil,
+		http.StatusOK},
+	{"statistics_user", http.MethodGet, "/statistics/user?user_id=u1&include_archived=true", nil, http.StatusOK},
+	{"statistics_team", http.MethodGet, "/statistics?team_name=backend", nil, http.StatusOK},
+	{"statistics_overdue", http.MethodGet, "/statistics/overdue", nil, http.StatusOK},
+	{"statistics_distribution", http.MethodGet, "/statistics/distribution?team_name=backend&detail=true", nil,
+		http.StatusOK},
+	{"admin_statistics_snapshot", http.MethodPost, "/admin/statistics/snapshot", nil, http.StatusOK},
+	{"statistics_history", http.MethodGet, "/statistics/history?from=2020-01-01T00:00:00Z", nil, http.StatusOK},
+	{"team_deactivate", http.MethodPost, "/team/deactivate", map[string]any{"team_name": "platform"}, http.StatusOK},
+
+	{"error_validation", http.MethodPost, "/pullRequest/create", map[string]any{
+		"pull_request_id": "pr-4",
+	}, http.StatusBadRequest},
+	{"error_malformed_json", http.MethodPost, "/team/add", "not an object", http.StatusBadRequest},
+	{"error_missing_query", http.MethodGet, "/team/get", nil, http.StatusBadRequest},
+	{"error_page_limit", http.MethodGet, "/pullRequest/search?q=a&limit=0", nil, http.StatusBadRequest},
+	{"error_sort_field", http.MethodGet, "/pullRequest/unassigned?sort=author_id", nil, http.StatusBadRequest},
+	{"error_statistics_team", http.MethodGet, "/statistics?team_name=missing", nil, http.StatusNotFound},
+	{"error_statistics_user", http.MethodGet, "/statistics/user?user_id=missing", nil, http.StatusNotFound},
+	{"error_statistics_history", http.MethodGet, "/statistics/history", nil, http.StatusBadRequest},
+	{"error_page_offset", http.MethodGet, "/statistics?prs_offset=first", nil, http.StatusBadRequest},
+	{"error_not_found", http.MethodGet, "/team/get?team_name=missing", nil, http.StatusNotFound},
+	{"error_team_lead_not_member", http.MethodPost, "/team/add", map[string]any{
+		"team_name": "frontend", "lead_id": "u1",
+		"members": []map[string]any{{"user_id": "f1", "username": "Fay", "is_active": true}},
+	}, http.StatusBadRequest},
+	{"error_team_exists", http.MethodPost, "/team/add", map[string]any{
+		"team_name": "platform",
+		"members":   []map[string]any{{"user_id": "p1", "username": "Pat", "is_active": true}},
+	}, http.StatusConflict},
+	{"error_pr_exists_with_details", http.MethodPost, "/pullRequest/create", map[string]any{
+		"pull_request_id": "pr-2", "pull_request_name": "Fix typo", "author_id": "u2",
+	}, http.StatusConflict},
+	{"error_redeliver_not_found", http.MethodPost, "/admin/webhooks/redeliver", map[string]any{
+		"delivery_id": 1,
+	}, http.StatusNotFound},
+	{"error_pr_merged", http.MethodPost, "/pullRequest/reassign", map[string]any{
+		"pull_request_id": "pr-1", "old_reviewer_id": "u2",
+	}, http.StatusConflict},
+}
+
+// TestGoldenResponses pins the JSON of every response, so renamed fields or changed omitempty
+// behaviour show up as a diff. Run with -update to rewrite the files after an intended change.
+func TestGoldenResponses(t *testing.T) {
+	e := newInProcessEnv(t)
+
+	for _, step := range goldenScenario {
+		resp := e.do(step.method, step.path, step.payload)
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("%s: failed to read response: %v", step.name, err)
+		}
+		if resp.StatusCode != step.status {
+			t.Fatalf("%s: expected status %d, got %d. Body: %s", step.name, step.status, resp.StatusCode, body)
+		}
+
+		body = timestampPattern.ReplaceAll(body, []byte(`"<timestamp>"`))
+		body = agePattern.ReplaceAll(body, []byte(`"age_seconds":"<age>"`))
+		t.Run(step.name, func(t *testing.T) {
+			assertGolden(t, step.name, body)
+		})
+	}
+}
+
+// assertGolden compares the body with testdata/golden/<name>.json byte for byte, or rewrites it with -update.
+func assertGolden(t *testing.T, name string, body []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name+".json")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create golden directory: %v", err)
+		}
+		if err := os.WriteFile(path, body, 0o644); err != nil {
+			t.Fatalf("failed to write golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file, run with -update to create it: %v", err)
+	}
+	if !bytes.Equal(want, body) {
+		t.Errorf("response differs from %s, run with -update if the change is intended\nwant: %s\ngot:  %s",
+			path, want, body)
+	}
+}
@@ -0,0 +1,191 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/app/config"
+	"github.com/shirr9/pr-reviewer-service/internal/app/events"
+	"github.com/shirr9/pr-reviewer-service/internal/app/graphql"
+	"github.com/shirr9/pr-reviewer-service/internal/app/handler"
+	"github.com/shirr9/pr-reviewer-service/internal/app/service"
+	"github.com/shirr9/pr-reviewer-service/internal/infrastructure/persistence/memory"
+)
+
+// defaultBaseURL is the deployment tested with E2E_TEST=true unless E2E_BASE_URL is set.
+const defaultBaseURL = "http://localhost:8080"
+
+// testReview is the review policy of the in-process server, the defaults of configs/config.yml.
+var testReview = config.Review{Deadline: 24 * time.Hour, Strategy: config.StrategyLeastLoaded}
+
+// testDumpToken authorizes the export of the in-process server.
+const testDumpToken = "test-dump-token"
+
+// env is the API under test. By default it is served in-process with httptest on fresh in-memory
+// storage, so every test starts from an empty state. With E2E_TEST=true the tests run against
+// a real deployment instead; ids are then made unique per run, as its database keeps earlier data.
+type env struct {
+	t       *testing.T
+	baseURL string
+	suffix  string
+	client  *http.Client
+}
+
+func newEnv(t *testing.T) *env {
+	t.Helper()
+	if os.Getenv("E2E_TEST") != "true" {
+		return newInProcessEnv(t)
+	}
+
+	e := &env{t: t, baseURL: os.Getenv("E2E_BASE_URL"), client: &http.Client{Timeout: 10 * time.Second}}
+	if e.baseURL == "" {
+		e.baseURL = defaultBaseURL
+	}
+	e.suffix = "-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	e.waitForServer()
+	return e
+}
+
+// newInProcessEnv serves the API in-process regardless of E2E_TEST, for tests that need a known state.
+func newInProcessEnv(t *testing.T) *env {
+	t.Helper()
+	srv := httptest.NewServer(newInProcessRouter())
+	t.Cleanup(srv.Close)
+	return &env{t: t, baseURL: srv.URL, client: srv.Client()}
+}
+
+// newInProcessRouter wires the real services and handlers to in-memory repositories, as main does with postgres.
+func newInProcessRouter() http.Handler {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	storage := memory.NewStorage()
+	prRepo := storage.NewPullRequestRepository()
+	reviewerRepo := storage.NewReviewerRepository()
+	userRepo := storage.NewUserRepository()
+	teamRepo := storage.NewTeamRepository()
+	uow := storage.NewUnitOfWork()
+
+	queues := events.NewBus(events.DefaultBuffer)
+	prService := service.NewPullRequestService(prRepo, reviewerRepo, userRepo, uow, testReview, logger)
+	prService.SetPublisher(queues)
+	userService := service.NewUserService(userRepo, prRepo, reviewerRepo, testReview, logger)
+	teamService := service.NewTeamService(teamRepo, userRepo, prRepo, reviewerRepo, uow, testReview, logger)
+	statisticsService := service.NewStatisticsService(userRepo, prRepo, reviewerRepo, config.Statistics{},
+		testReview, logger)
+	graphQLHandler, err := graphql.NewHandler(graphql.Services{
+		Teams: teamService, Users: userService, PullRequests: prService, Statistics: statisticsService,
+	}, config.GraphQL{MaxDepth: 8, MaxComplexity: 5000}, logger)
+	if err != nil {
+		panic(err)
+	}
+	return handler.NewRouter(handler.Services{
+		PullRequests: prService,
+		Users:        userService,
+		Teams:        teamService,
+		Statistics:   statisticsService,
+		Exclusions:   service.NewExclusionService(storage.NewExclusionRepository(), userRepo, logger),
+		Archive: service.NewArchiveService(storage.NewArchiveRepository(), prRepo, reviewerRepo, uow,
+			config.Archive{}, logger),
+		Webhooks:  service.NewWebhookService(storage.NewWebhookRepository(), uow, nil, config.Webhook{}, logger),
+		Snapshots: service.NewSnapshotService(storage.NewSnapshotRepository(), userRepo, statisticsService, logger),
+		Queues:    queues,
+		GraphQL:   graphQLHandler,
+		Dump:      service.NewDumpService(storage.NewDumpRepository(), uow, config.Dump{}, logger),
+		DumpToken: testDumpToken,
+	}, logger, handler.NewValidator())
+}
+
+// waitForServer waits until the external deployment answers.
+func (e *env) waitForServer() {
+	e.t.Helper()
+	for i := 0; i < 30; i++ {
+		resp, err := e.client.Get(e.baseURL + "/statistics")
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode < 500 {
+				return
+			}
+		}
+		time.Sleep(time.Second)
+	}
+	e.t.Fatal("Server did not start in time")
+}
+
+// id makes the id unique for the run against an external deployment, and keeps it as is in-process.
+func (e *env) id(name string) string {
+	return name + e.suffix
+}
+
+// do sends the request with the payload encoded as JSON.
+func (e *env) do(method, path string, payload any) *http.Response {
+	e.t.Helper()
+	resp, err := e.client.Do(e.newRequest(method, path, payload))
+	if err != nil {
+		e.t.Fatalf("Request failed: %v", err)
+	}
+	return resp
+}
+
+// newRequest builds a request to the API with the payload encoded as JSON.
+func (e *env) newRequest(method, path string, payload any) *http.Request {
+	e.t.Helper()
+	var body io.Reader
+	if payload != nil {
+		jsonData, err := json.Marshal(payload)
+		if err != nil {
+			e.t.Fatalf("Failed to marshal payload: %v", err)
+		}
+		body = bytes.NewReader(jsonData)
+	}
+
+	req, err := http.NewRequest(method, e.baseURL+path, body)
+	if err != nil {
+		e.t.Fatalf("Failed to create request: %v", err)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req
+}
+
+// call sends the request, checks the status and decodes the response body into target unless it is nil.
+func (e *env) call(method, path string, payload any, status int, target any) {
+	e.t.Helper()
+	resp := e.do(method, path, payload)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		e.t.Fatalf("Failed to read response: %v", err)
+	}
+	if resp.StatusCode != status {
+		e.t.Fatalf("%s %s: expected status %d, got %d. Body: %s", method, path, status, resp.StatusCode, body)
+	}
+	if target == nil {
+		return
+	}
+	if err = json.Unmarshal(body, target); err != nil {
+		e.t.Fatalf("Failed to decode response %s: %v", body, err)
+	}
+}
+
+// callError sends the request and checks the status and the error code of the response.
+func (e *env) callError(method, path string, payload any, status int, code string) {
+	e.t.Helper()
+	var errResp struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	e.call(method, path, payload, status, &errResp)
+	if errResp.Error.Code != code {
+		e.t.Fatalf("%s %s: expected error code %s, got %q", method, path, code, errResp.Error.Code)
+	}
+}
@@ -0,0 +1,79 @@
+package e2e
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/shirr9/pr-reviewer-service/internal/loadtest"
+)
+
+// Defaults of the perf-regression budget, overridable with PERF_P95 and PERF_DURATION.
+const (
+	defaultPerfP95      = 50 * time.Millisecond
+	defaultPerfDuration = 5 * time.Second
+)
+
+// TestPerfRegression runs a short load test against the in-process server and fails when the P95
+// latency of any operation exceeds the budget or a request fails. It is opt-in with PERF_TEST=true,
+// as timings depend on the machine.
+func TestPerfRegression(t *testing.T) {
+	if os.Getenv("PERF_TEST") != "true" {
+		t.Skip("set PERF_TEST=true to run the perf-regression test")
+	}
+	budget := durationEnv(t, "PERF_P95", defaultPerfP95)
+
+	srv := httptest.NewServer(newInProcessRouter())
+	defer srv.Close()
+
+	runner, err := loadtest.NewRunner(loadtest.Config{
+		Targets:      []string{srv.URL},
+		Concurrency:  10,
+		Duration:     durationEnv(t, "PERF_DURATION", defaultPerfDuration),
+		Teams:        5,
+		UsersPerTeam: 10,
+		Mix: map[string]int{
+			loadtest.OpCreatePR: 2, loadtest.OpGetReview: 3, loadtest.OpStatistics: 1,
+			loadtest.OpGetTeam: 1, loadtest.OpMergePR: 1,
+		},
+		Seed:   1,
+		Client: srv.Client(),
+	})
+	if err != nil {
+		t.Fatalf("invalid load test config: %v", err)
+	}
+	results, err := runner.Run(context.Background())
+	if err != nil {
+		t.Fatalf("load test failed: %v", err)
+	}
+
+	if results.Requests == 0 {
+		t.Fatal("no requests were made")
+	}
+	for name, op := range results.Operations {
+		if op.Failed > 0 {
+			t.Errorf("%s: %d of %d requests failed, by status: %v, samples: %v",
+				name, op.Failed, op.Requests, op.Errors, op.ErrorSamples)
+		}
+		if op.Latency.P95 > budget {
+			t.Errorf("%s: P95 latency %s exceeds the budget of %s", name, op.Latency.P95, budget)
+		}
+	}
+	t.Logf("%d requests, %.1f req/s, P95 %s", results.Requests, results.RPS, results.Latency.P95)
+}
+
+// durationEnv parses the duration in the environment variable, returning def when it is unset.
+func durationEnv(t *testing.T, name string, def time.Duration) time.Duration {
+	t.Helper()
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		t.Fatalf("invalid %s: %v", name, err)
+	}
+	return d
+}
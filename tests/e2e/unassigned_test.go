@@ -0,0 +1,100 @@
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+type unassignedPage struct {
+	Items []struct {
+		PullRequestID     string   `json:"pull_request_id"`
+		AssignedReviewers []string `json:"assigned_reviewers"`
+	} `json:"items"`
+	Total  int `json:"total"`
+	Offset int `json:"offset"`
+}
+
+type assignPendingResult struct {
+	PullRequests []struct {
+		PullRequestID     string   `json:"pull_request_id"`
+		AssignedReviewers []string `json:"assigned_reviewers"`
+	} `json:"pull_requests"`
+	NextOffset int  `json:"next_offset"`
+	HasMore    bool `json:"has_more"`
+}
+
+func TestE2EUnassignedPRs(t *testing.T) {
+	e := newEnv(t)
+	authorID := e.id("e2e-ua-author")
+	reviewerID := e.id("e2e-ua-reviewer")
+	prID := e.id("e2e-ua-pr")
+
+	e.call(http.MethodPost, "/team/add", map[string]interface{}{
+		"team_name": e.id("e2e-ua-team"),
+		"members": []map[string]interface{}{
+			{"user_id": authorID, "username": "E2E-Author", "is_active": true},
+			{"user_id": reviewerID, "username": "E2E-Reviewer", "is_active": false},
+		},
+	}, http.StatusCreated, nil)
+
+	e.call(http.MethodPost, "/pullRequest/create", map[string]interface{}{
+		"pull_request_id":   prID,
+		"pull_request_name": "E2E unassigned",
+		"author_id":         authorID,
+	}, http.StatusCreated, nil)
+
+	if !unassignedContains(e, prID) {
+		t.Fatalf("PR %s with no candidates is not listed as unassigned", prID)
+	}
+
+	e.call(http.MethodPost, "/users/setIsActive", map[string]interface{}{
+		"user_id":   reviewerID,
+		"is_active": true,
+	}, http.StatusOK, nil)
+
+	assigned := false
+	for offset := 0; !assigned; {
+		var result assignPendingResult
+		e.call(http.MethodPost, "/pullRequest/assignPending", map[string]interface{}{
+			"limit":  100,
+			"offset": offset,
+		}, http.StatusOK, &result)
+		for _, pr := range result.PullRequests {
+			if pr.PullRequestID == prID {
+				if len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != reviewerID {
+					t.Fatalf("expected %s to be assigned, got %v", reviewerID, pr.AssignedReviewers)
+				}
+				assigned = true
+			}
+		}
+		if !result.HasMore {
+			break
+		}
+		offset = result.NextOffset
+	}
+	if !assigned {
+		t.Fatalf("PR %s was not processed by assignPending", prID)
+	}
+
+	if unassignedContains(e, prID) {
+		t.Fatalf("PR %s is still listed as unassigned", prID)
+	}
+}
+
+// unassignedContains walks the unassigned pages looking for the PR.
+func unassignedContains(e *env, prID string) bool {
+	e.t.Helper()
+	for offset := 0; ; offset += 100 {
+		var page unassignedPage
+		e.call(http.MethodGet, fmt.Sprintf("/pullRequest/unassigned?limit=100&offset=%d", offset), nil, http.StatusOK, &page)
+		for _, pr := range page.Items {
+			if pr.PullRequestID == prID {
+				return true
+			}
+		}
+		if page.Offset+len(page.Items) >= page.Total {
+			return false
+		}
+	}
+}
@@ -1,300 +1,78 @@
 package main
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
+	"flag"
 	"fmt"
-	"io"
-	"math/rand"
-	"net/http"
-	"sync"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
 	"time"
-)
 
-const (
-	baseURL        = "http://localhost:8080"
-	numTeams       = 5
-	usersPerTeam   = 10
-	numPRs         = 50
-	concurrentReqs = 10
-	testDuration   = 30 * time.Second
+	"github.com/shirr9/pr-reviewer-service/internal/loadtest"
 )
 
-type Stats struct {
-	mu              sync.Mutex
-	totalRequests   int
-	successRequests int
-	failedRequests  int
-	totalLatency    time.Duration
-	minLatency      time.Duration
-	maxLatency      time.Duration
-	latencies       []time.Duration
-}
-
-func (s *Stats) recordRequest(latency time.Duration, success bool) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	s.totalRequests++
-	s.totalLatency += latency
-	s.latencies = append(s.latencies, latency)
-
-	if success {
-		s.successRequests++
-	} else {
-		s.failedRequests++
-	}
-
-	if s.minLatency == 0 || latency < s.minLatency {
-		s.minLatency = latency
-	}
-	if latency > s.maxLatency {
-		s.maxLatency = latency
-	}
-}
-
-func (s *Stats) printReport() {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	if s.totalRequests == 0 {
-		fmt.Println("No requests recorded")
-		return
-	}
-
-	avgLatency := s.totalLatency / time.Duration(s.totalRequests)
-	successRate := float64(s.successRequests) / float64(s.totalRequests) * 100
-
-	fmt.Println("\n=== Load Test Results ===")
-	fmt.Printf("Total Requests:    %d\n", s.totalRequests)
-	fmt.Printf("Success:           %d (%.2f%%)\n", s.successRequests, successRate)
-	fmt.Printf("Failed:            %d\n", s.failedRequests)
-	fmt.Printf("Min Latency:       %v\n", s.minLatency)
-	fmt.Printf("Max Latency:       %v\n", s.maxLatency)
-	fmt.Printf("Avg Latency:       %v\n", avgLatency)
-	fmt.Printf("P95 Latency:       %v\n", s.percentile(0.95))
-	fmt.Printf("P99 Latency:       %v\n", s.percentile(0.99))
-	fmt.Println("========================")
-}
-
-func (s *Stats) percentile(p float64) time.Duration {
-	if len(s.latencies) == 0 {
-		return 0
-	}
-
-	sorted := make([]time.Duration, len(s.latencies))
-	copy(sorted, s.latencies)
-
-	for i := 0; i < len(sorted); i++ {
-		for j := i + 1; j < len(sorted); j++ {
-			if sorted[i] > sorted[j] {
-				sorted[i], sorted[j] = sorted[j], sorted[i]
-			}
-		}
-	}
+func main() {
+	cfg, reportPath := parseFlags()
 
-	idx := int(float64(len(sorted)) * p)
-	if idx >= len(sorted) {
-		idx = len(sorted) - 1
+	runner, err := loadtest.NewRunner(cfg)
+	if err != nil {
+		log.Fatalf("invalid configuration: %v", err)
 	}
 
-	return sorted[idx]
-}
-
-func main() {
 	fmt.Println("Starting load test...")
-	fmt.Printf("Base URL: %s\n", baseURL)
-	fmt.Printf("Concurrent requests: %d\n", concurrentReqs)
-	fmt.Printf("Test duration: %v\n", testDuration)
-
-	stats := &Stats{
-		minLatency: time.Hour,
+	fmt.Printf("Targets: %s\n", strings.Join(cfg.Targets, ", "))
+	fmt.Printf("Concurrent requests: %d\n", cfg.Concurrency)
+	fmt.Printf("Test duration: %v\n", cfg.Duration)
+	fmt.Printf("Seed: %d\n", cfg.Seed)
+	if cfg.RPS > 0 {
+		fmt.Printf("RPS cap: %d\n", cfg.RPS)
 	}
 
-	setupTestData()
-	time.Sleep(2 * time.Second)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
 
-	var wg sync.WaitGroup
-	stopCh := make(chan struct{})
-
-	for i := 0; i < concurrentReqs; i++ {
-		wg.Add(1)
-		go func(workerID int) {
-			defer wg.Done()
-			runWorker(workerID, stats, stopCh)
-		}(i)
+	results, err := runner.Run(ctx)
+	if err != nil {
+		log.Fatalf("load test failed: %v", err)
 	}
+	results.PrintSummary(os.Stdout)
 
-	time.Sleep(testDuration)
-	close(stopCh)
-	wg.Wait()
-
-	stats.printReport()
-}
-
-func setupTestData() {
-	fmt.Println("Setting up test data...")
-
-	for i := 1; i <= numTeams; i++ {
-		teamName := fmt.Sprintf("team-%d", i)
-		members := []map[string]interface{}{}
-
-		for j := 1; j <= usersPerTeam; j++ {
-			userID := fmt.Sprintf("user-%d-%d", i, j)
-			members = append(members, map[string]interface{}{
-				"user_id":   userID,
-				"username":  fmt.Sprintf("User %d-%d", i, j),
-				"is_active": true,
-			})
+	if reportPath != "" {
+		if err = results.WriteReport(reportPath); err != nil {
+			log.Fatalf("%v", err)
 		}
-
-		payload := map[string]interface{}{
-			"team_name": teamName,
-			"members":   members,
-		}
-
-		makeRequest("POST", "/team/add", payload)
+		fmt.Printf("Report written to %s\n", reportPath)
 	}
-
-	fmt.Printf("Created %d teams with %d users each\n", numTeams, usersPerTeam)
 }
 
-func runWorker(workerID int, stats *Stats, stopCh chan struct{}) {
-	for {
-		select {
-		case <-stopCh:
-			return
-		default:
-			operation := rand.Intn(5)
-
-			switch operation {
-			case 0:
-				createPR(stats)
-			case 1:
-				getUserReviews(stats)
-			case 2:
-				getStatistics(stats)
-			case 3:
-				getTeam(stats)
-			case 4:
-				mergePR(stats)
-			}
-
-			time.Sleep(time.Millisecond * time.Duration(10+rand.Intn(90)))
-		}
-	}
-}
-
-func createPR(stats *Stats) {
-	prID := fmt.Sprintf("pr-%d-%d", time.Now().Unix(), rand.Intn(10000))
-	teamID := rand.Intn(numTeams) + 1
-	userID := fmt.Sprintf("user-%d-%d", teamID, rand.Intn(usersPerTeam)+1)
-
-	payload := map[string]interface{}{
-		"pull_request_id":   prID,
-		"pull_request_name": fmt.Sprintf("Feature %s", prID),
-		"author_id":         userID,
-	}
-
-	start := time.Now()
-	resp := makeRequest("POST", "/pullRequest/create", payload)
-	latency := time.Since(start)
-
-	success := resp != nil && (resp.StatusCode == http.StatusCreated || resp.StatusCode == http.StatusConflict)
-	stats.recordRequest(latency, success)
-
-	if resp != nil {
-		resp.Body.Close()
-	}
-}
-
-func getUserReviews(stats *Stats) {
-	teamID := rand.Intn(numTeams) + 1
-	userID := fmt.Sprintf("user-%d-%d", teamID, rand.Intn(usersPerTeam)+1)
-
-	start := time.Now()
-	resp := makeRequest("GET", fmt.Sprintf("/users/getReview?user_id=%s", userID), nil)
-	latency := time.Since(start)
-
-	success := resp != nil && resp.StatusCode == http.StatusOK
-	stats.recordRequest(latency, success)
-
-	if resp != nil {
-		resp.Body.Close()
-	}
-}
-
-func getStatistics(stats *Stats) {
-	start := time.Now()
-	resp := makeRequest("GET", "/statistics", nil)
-	latency := time.Since(start)
-
-	success := resp != nil && resp.StatusCode == http.StatusOK
-	stats.recordRequest(latency, success)
-
-	if resp != nil {
-		resp.Body.Close()
-	}
-}
-
-func getTeam(stats *Stats) {
-	teamID := rand.Intn(numTeams) + 1
-	teamName := fmt.Sprintf("team-%d", teamID)
-
-	start := time.Now()
-	resp := makeRequest("GET", fmt.Sprintf("/team/get?team_name=%s", teamName), nil)
-	latency := time.Since(start)
-
-	success := resp != nil && resp.StatusCode == http.StatusOK
-	stats.recordRequest(latency, success)
-
-	if resp != nil {
-		resp.Body.Close()
-	}
-}
-
-func mergePR(stats *Stats) {
-	prID := fmt.Sprintf("pr-merge-%d-%d", time.Now().Unix(), rand.Intn(1000))
-
-	start := time.Now()
-	resp := makeRequest("POST", "/pullRequest/merge", map[string]interface{}{
-		"pull_request_id": prID,
-	})
-	latency := time.Since(start)
-
-	success := resp != nil && (resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNotFound)
-	stats.recordRequest(latency, success)
-
-	if resp != nil {
-		resp.Body.Close()
-	}
-}
-
-func makeRequest(method, path string, payload interface{}) *http.Response {
-	var body io.Reader
-	if payload != nil {
-		jsonData, err := json.Marshal(payload)
-		if err != nil {
-			return nil
-		}
-		body = bytes.NewBuffer(jsonData)
-	}
-
-	url := baseURL + path
-	req, err := http.NewRequest(method, url, body)
+// parseFlags parses command line flags into the load test config and returns the report path.
+func parseFlags() (loadtest.Config, string) {
+	var cfg loadtest.Config
+	var targets, mix, reportPath string
+
+	flag.StringVar(&targets, "url", "http://localhost:8080", "base URLs of the service separated by commas")
+	flag.IntVar(&cfg.Concurrency, "concurrency", 10, "number of concurrent workers")
+	flag.DurationVar(&cfg.Duration, "duration", 30*time.Second, "test duration")
+	flag.IntVar(&cfg.Teams, "teams", 5, "number of teams to create")
+	flag.IntVar(&cfg.UsersPerTeam, "users-per-team", 10, "number of users per team")
+	flag.StringVar(&mix, "mix", loadtest.DefaultMix, "operation weights as name=weight pairs separated by commas")
+	flag.Int64Var(&cfg.Seed, "seed", time.Now().UnixNano(), "random seed")
+	flag.IntVar(&cfg.RPS, "rps", 0, "overall requests per second cap (0 means unlimited)")
+	flag.BoolVar(&cfg.SkipSetup, "skip-setup", false, "use teams written by `app seed` instead of creating them")
+	flag.StringVar(&reportPath, "report", "loadtest-report.json", "path of the JSON report (empty disables it)")
+	flag.Parse()
+
+	weights, err := loadtest.ParseMix(mix)
 	if err != nil {
-		return nil
-	}
-
-	if payload != nil {
-		req.Header.Set("Content-Type", "application/json")
+		log.Fatalf("invalid -mix: %v", err)
 	}
-
-	client := &http.Client{Timeout: 5 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil
+	cfg.Mix = weights
+	for _, target := range strings.Split(targets, ",") {
+		if target = strings.TrimSpace(target); target != "" {
+			cfg.Targets = append(cfg.Targets, target)
+		}
 	}
-
-	return resp
+	return cfg, reportPath
 }